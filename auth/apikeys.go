@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	ApiKeyPrefix      = "stk_"
+	ApiKeyScopeRead   = "read"
+	ApiKeyScopeBounty = "bounty"
+)
+
+// ApiKeyScopesKey holds the scopes of the API key used for the request,
+// it is not set for requests authenticated with a lnauth JWT or signed token
+var ApiKeyScopesKey = contextKey("api_key_scopes")
+
+// bountyScopeRoutes are the writes a bounty key can make, creating, editing and assigning bounties.
+// Payments, withdrawals and escrows are left out so a key can't move the sats of a workspace
+var bountyScopeRoutes = []struct {
+	method string
+	path   *regexp.Regexp
+}{
+	{http.MethodPost, regexp.MustCompile(`^/gobounties/?$`)},
+	{http.MethodDelete, regexp.MustCompile(`^/gobounties/assignee$`)},
+	{http.MethodPost, regexp.MustCompile(`^/gobounties/\d+/extend$`)},
+	{http.MethodPost, regexp.MustCompile(`^/gobounties/ticket/[^/]+/[^/]+/to_bounty$`)},
+}
+
+func bountyScopeAllowed(r *http.Request) bool {
+	for _, route := range bountyScopeRoutes {
+		if r.Method == route.method && route.path.MatchString(r.URL.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// ApiKeyVerifier resolves an API key to its owner pubkey and scopes, it is set on startup
+var ApiKeyVerifier func(key string) (string, []string, error)
+
+func HashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func IsApiKey(token string) bool {
+	return strings.HasPrefix(token, ApiKeyPrefix)
+}
+
+// ApiKeyAllowed checks the request against the scopes of an API key,
+// read keys can only make safe requests and bounty keys can also create, edit and assign bounties
+func ApiKeyAllowed(scopes []string, r *http.Request) bool {
+	isSafe := r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions
+
+	for _, scope := range scopes {
+		switch scope {
+		case ApiKeyScopeRead:
+			if isSafe {
+				return true
+			}
+		case ApiKeyScopeBounty:
+			if isSafe || bountyScopeAllowed(r) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsApiKeyRequest reports if the request was authenticated with an API key
+func IsApiKeyRequest(ctx context.Context) bool {
+	_, ok := ctx.Value(ApiKeyScopesKey).([]string)
+	return ok
+}
+
+func apiKeyContext(w http.ResponseWriter, r *http.Request, key string) (context.Context, bool) {
+	if ApiKeyVerifier == nil {
+		http.Error(w, http.StatusText(401), 401)
+		return nil, false
+	}
+
+	pubkey, scopes, err := ApiKeyVerifier(key)
+	if err != nil || pubkey == "" {
+		http.Error(w, http.StatusText(401), 401)
+		return nil, false
+	}
+
+	if !ApiKeyAllowed(scopes, r) {
+		http.Error(w, http.StatusText(403), 403)
+		return nil, false
+	}
+
+	ctx := context.WithValue(r.Context(), ContextKey, pubkey)
+	ctx = context.WithValue(ctx, ApiKeyScopesKey, scopes)
+	return ctx, true
+}
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiKeyAllowed(t *testing.T) {
+	get, _ := http.NewRequest(http.MethodGet, "/people", nil)
+	postBounty, _ := http.NewRequest(http.MethodPost, "/gobounties/", nil)
+	postWorkspace, _ := http.NewRequest(http.MethodPost, "/workspaces", nil)
+
+	assert.True(t, ApiKeyAllowed([]string{ApiKeyScopeRead}, get))
+	assert.False(t, ApiKeyAllowed([]string{ApiKeyScopeRead}, postBounty))
+	assert.True(t, ApiKeyAllowed([]string{ApiKeyScopeBounty}, postBounty))
+	assert.False(t, ApiKeyAllowed([]string{ApiKeyScopeBounty}, postWorkspace))
+	assert.False(t, ApiKeyAllowed([]string{}, get))
+
+	for _, route := range [][2]string{
+		{http.MethodPost, "/gobounties"},
+		{http.MethodDelete, "/gobounties/assignee"},
+		{http.MethodPost, "/gobounties/12/extend"},
+		{http.MethodPost, "/gobounties/ticket/pubkey/1700000000/to_bounty"},
+	} {
+		req, _ := http.NewRequest(route[0], route[1], nil)
+		assert.True(t, ApiKeyAllowed([]string{ApiKeyScopeBounty}, req), route[1])
+	}
+	for _, route := range [][2]string{
+		{http.MethodPost, "/gobounties/pay/1"},
+		{http.MethodPost, "/gobounties/budget/withdraw"},
+		{http.MethodPost, "/gobounties/budget_workspace/withdraw"},
+		{http.MethodPost, "/gobounties/12/escrow"},
+		{http.MethodPost, "/gobounties/12/escrow/release"},
+		{http.MethodPost, "/gobounties/12/escrow/refund"},
+		{http.MethodPost, "/gobounties/12/proofs/3/review"},
+		{http.MethodPost, "/gobounties/paymentstatus/1700000000"},
+		{http.MethodDelete, "/gobounties/pubkey/1700000000"},
+	} {
+		req, _ := http.NewRequest(route[0], route[1], nil)
+		assert.False(t, ApiKeyAllowed([]string{ApiKeyScopeBounty}, req), route[1])
+		assert.False(t, ApiKeyAllowed([]string{ApiKeyScopeRead, ApiKeyScopeBounty}, req), route[1])
+	}
+}
+
+func TestPubKeyContextWithApiKey(t *testing.T) {
+	ApiKeyVerifier = func(key string) (string, []string, error) {
+		if key == ApiKeyPrefix+"valid" {
+			return "pubkey", []string{ApiKeyScopeRead}, nil
+		}
+		return "", nil, errors.New("no api key found")
+	}
+	defer func() { ApiKeyVerifier = nil }()
+
+	var pubkeyFromContext string
+	var fromApiKey bool
+	handler := PubKeyContext(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pubkeyFromContext, _ = r.Context().Value(ContextKey).(string)
+		fromApiKey = IsApiKeyRequest(r.Context())
+	}))
+
+	t.Run("Should test that a valid api key sets the owner pubkey", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/person/notifications", nil)
+		req.Header.Set("x-api-key", ApiKeyPrefix+"valid")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "pubkey", pubkeyFromContext)
+		assert.True(t, fromApiKey)
+	})
+
+	t.Run("Should test that an unknown api key is rejected", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/person/notifications", nil)
+		req.Header.Set("x-api-key", ApiKeyPrefix+"unknown")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a read only api key can't make writes", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/gobounties/", nil)
+		req.Header.Set("x-api-key", ApiKeyPrefix+"valid")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
@@ -9,6 +10,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	btcec "github.com/btcsuite/btcd/btcec/v2"
@@ -32,13 +34,38 @@ type contextKey string
 // ContextKey ...
 var ContextKey = contextKey("key")
 
-// PubKeyContext parses pukey from signed timestamp
+// RequestPathKey holds the path a request was sent to when the router rewrote it, such as a /v1 path
+var RequestPathKey = contextKey("request_path")
+
+// RequestPath returns the path the client sent a request to, before the router rewrote it
+func RequestPath(r *http.Request) string {
+	if path, ok := r.Context().Value(RequestPathKey).(string); ok {
+		return path
+	}
+	return r.URL.Path
+}
+
+// PubKeyContext parses pukey from signed timestamp, writes of banned pubkeys are rejected
 func PubKeyContext(next http.Handler) http.Handler {
+	next = RejectBanned(next)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		token := r.URL.Query().Get("token")
 		if token == "" {
 			token = r.Header.Get("x-jwt")
 		}
+		if token == "" {
+			token = r.Header.Get("x-api-key")
+		}
+
+		if token == "" && IsNostrAuth(r) {
+			ctx, ok := nostrContext(w, r)
+			if !ok {
+				fmt.Println("[auth] invalid nostr auth")
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
 
 		if token == "" {
 			fmt.Println("[auth] no token")
@@ -46,6 +73,16 @@ func PubKeyContext(next http.Handler) http.Handler {
 			return
 		}
 
+		if IsApiKey(token) {
+			ctx, ok := apiKeyContext(w, r, token)
+			if !ok {
+				fmt.Println("[auth] invalid api key")
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
 		isJwt := strings.Contains(token, ".") && !strings.HasPrefix(token, ".")
 
 		if isJwt {
@@ -63,7 +100,22 @@ func PubKeyContext(next http.Handler) http.Handler {
 				return
 			}
 
+			if !SessionValid(claims, ClientFingerprint(r)) {
+				fmt.Println("Session has been revoked")
+				http.Error(w, http.StatusText(401), 401)
+				return
+			}
+
 			ctx := context.WithValue(r.Context(), ContextKey, claims["pubkey"])
+			if ImpersonationId(claims) != "" {
+				if status := impersonationAllowed(claims, r); status != 0 {
+					fmt.Println("[auth] impersonation refused", ImpersonationId(claims), r.Method, r.URL.Path)
+					http.Error(w, http.StatusText(status), status)
+					return
+				}
+				ctx = impersonationContext(ctx, claims)
+			}
+
 			next.ServeHTTP(w, r.WithContext(ctx))
 		} else {
 			pubkey, err := VerifyTribeUUID(token, true)
@@ -83,6 +135,58 @@ func PubKeyContext(next http.Handler) http.Handler {
 	})
 }
 
+// PubkeyFromToken returns the pubkey of a JWT or signed timestamp token,
+// for callers that can't go through the PubKeyContext middleware
+func PubkeyFromToken(token string, fingerprint string) (string, error) {
+	if token == "" {
+		return "", errors.New("no token")
+	}
+
+	isJwt := strings.Contains(token, ".") && !strings.HasPrefix(token, ".")
+	if isJwt {
+		claims, err := DecodeJwt(token)
+		if err != nil {
+			return "", err
+		}
+		if !SessionValid(claims, fingerprint) {
+			return "", errors.New("session has been revoked")
+		}
+		if ImpersonationId(claims) != "" {
+			return "", errImpersonationToken
+		}
+		pubkey, _ := claims["pubkey"].(string)
+		if pubkey == "" {
+			return "", errors.New("no pubkey in token")
+		}
+		return pubkey, nil
+	}
+
+	pubkey, err := VerifyTribeUUID(token, true)
+	if err != nil {
+		return "", err
+	}
+	if pubkey == "" {
+		return "", errors.New("no pubkey in token")
+	}
+	return pubkey, nil
+}
+
+// OptionalPubKeyContext sets the pubkey of a valid token when one is sent, anonymous requests still go through
+// and writes of banned pubkeys are rejected
+func OptionalPubKeyContext(next http.Handler) http.Handler {
+	next = RejectBanned(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		if token == "" {
+			token = r.Header.Get("x-jwt")
+		}
+		if pubkey, err := PubkeyFromToken(token, ClientFingerprint(r)); err == nil {
+			r = r.WithContext(context.WithValue(r.Context(), ContextKey, pubkey))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // PubKeyContext parses pukey from signed timestamp
 func PubKeyContextSuperAdmin(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -113,6 +217,18 @@ func PubKeyContextSuperAdmin(next http.Handler) http.Handler {
 				return
 			}
 
+			if !SessionValid(claims, ClientFingerprint(r)) {
+				fmt.Println("Session has been revoked")
+				http.Error(w, http.StatusText(401), 401)
+				return
+			}
+
+			if ImpersonationId(claims) != "" {
+				fmt.Println("[auth] impersonation token on an admin route")
+				http.Error(w, http.StatusText(401), 401)
+				return
+			}
+
 			pubkey := fmt.Sprintf("%v", claims["pubkey"])
 			if !IsFreePass() && !AdminCheck(pubkey) {
 				fmt.Println("Not a super admin")
@@ -181,8 +297,52 @@ func CypressContext(next http.Handler) http.Handler {
 	})
 }
 
+// SuperAdminsLoader reads the super admin pubkeys from the database, it is set on startup.
+// Without it the admins from the ADMINS env var are used
+var SuperAdminsLoader func() ([]string, error)
+
+// SuperAdminsCacheTTL is how long the loaded super admins are kept before reading them again
+const SuperAdminsCacheTTL = time.Minute
+
+var superAdminsCache = struct {
+	sync.Mutex
+	pubkeys []string
+	loaded  time.Time
+}{}
+
+// AdminPubkeys returns the current super admin pubkeys
+func AdminPubkeys() []string {
+	if SuperAdminsLoader == nil {
+		return config.SuperAdmins
+	}
+
+	superAdminsCache.Lock()
+	defer superAdminsCache.Unlock()
+
+	if superAdminsCache.pubkeys == nil || time.Since(superAdminsCache.loaded) > SuperAdminsCacheTTL {
+		pubkeys, err := SuperAdminsLoader()
+		if err != nil {
+			fmt.Println("[auth] could not load super admins", err)
+			if superAdminsCache.pubkeys == nil {
+				return config.SuperAdmins
+			}
+			return superAdminsCache.pubkeys
+		}
+		superAdminsCache.pubkeys = pubkeys
+		superAdminsCache.loaded = time.Now()
+	}
+	return superAdminsCache.pubkeys
+}
+
+// RefreshAdminPubkeys drops the cached super admins so the next check reads them again
+func RefreshAdminPubkeys() {
+	superAdminsCache.Lock()
+	superAdminsCache.pubkeys = nil
+	superAdminsCache.Unlock()
+}
+
 func AdminCheck(pubkey string) bool {
-	for _, val := range config.SuperAdmins {
+	for _, val := range AdminPubkeys() {
 		if val == pubkey {
 			return true
 		}
@@ -191,7 +351,8 @@ func AdminCheck(pubkey string) bool {
 }
 
 func IsFreePass() bool {
-	if len(config.SuperAdmins) == 1 && config.SuperAdmins[0] == config.AdminDevFreePass || config.AdminStrings == "" {
+	admins := AdminPubkeys()
+	if len(admins) == 1 && admins[0] == config.AdminDevFreePass || config.AdminStrings == "" && (SuperAdminsLoader == nil || len(admins) == 0) {
 		return true
 	}
 	return false
@@ -289,12 +450,15 @@ func DecodeJwt(token string) (jwt.MapClaims, error) {
 	return claims, err
 }
 
-func EncodeJwt(pubkey string) (string, error) {
+// EncodeSessionJwt creates a token tied to a session, jti changes every time the session token is rotated
+func EncodeSessionJwt(pubkey string, sessionId string, jti string) (string, error) {
 	exp := ExpireInHours(24 * 7)
 
 	claims := jwt.MapClaims{
 		"pubkey": pubkey,
 		"exp":    exp,
+		"sid":    sessionId,
+		"jti":    jti,
 	}
 
 	_, tokenString, err := TokenAuth.Encode(claims)
@@ -306,6 +470,44 @@ func EncodeJwt(pubkey string) (string, error) {
 	return tokenString, nil
 }
 
+// SessionValidator checks that a session is not revoked, that jti is its current token and that the
+// client fingerprint matches when the session is bound to one, it is set on startup
+var SessionValidator func(sessionId string, jti string, fingerprint string) bool
+
+// SessionValid checks the session of a token. Tokens issued before sessions existed have no sid, they
+// can't be revoked or refreshed and are only honoured until their exp
+func SessionValid(claims jwt.MapClaims, fingerprint string) bool {
+	sessionId, _ := claims["sid"].(string)
+	if sessionId == "" {
+		_, expires := claims["exp"]
+		return expires
+	}
+	if SessionValidator == nil {
+		return true
+	}
+	jti, _ := claims["jti"].(string)
+	return SessionValidator(sessionId, jti, fingerprint)
+}
+
+// ClientFingerprint is the fingerprint a client sends in the x-client-fingerprint header, or in
+// the fingerprint query param for websockets and event streams that can't set headers
+func ClientFingerprint(r *http.Request) string {
+	fingerprint := r.Header.Get("x-client-fingerprint")
+	if fingerprint == "" {
+		fingerprint = r.URL.Query().Get("fingerprint")
+	}
+	return fingerprint
+}
+
+// HashFingerprint is how a fingerprint is kept on a session, "" stays "" for unbound sessions
+func HashFingerprint(fingerprint string) string {
+	if fingerprint == "" {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(fingerprint))
+	return hex.EncodeToString(hash[:])
+}
+
 // tribe UUID is a base64 encoded string 69 bytes long
 // first 4 bytes is the timestamp
 // last 65 bytes is the sign
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/form3tech-oss/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionValid(t *testing.T) {
+	SessionValidator = func(sessionId string, jti string, fingerprint string) bool {
+		return sessionId == "session" && jti == "current" && fingerprint == ""
+	}
+	defer func() { SessionValidator = nil }()
+
+	// tokens without a session are only honoured until they expire
+	assert.True(t, SessionValid(jwt.MapClaims{"pubkey": "pubkey", "exp": ExpireInHours(1)}, ""))
+	assert.False(t, SessionValid(jwt.MapClaims{"pubkey": "pubkey"}, ""))
+	assert.True(t, SessionValid(jwt.MapClaims{"pubkey": "pubkey", "sid": "session", "jti": "current"}, ""))
+	assert.False(t, SessionValid(jwt.MapClaims{"pubkey": "pubkey", "sid": "session", "jti": "old"}, ""))
+	assert.False(t, SessionValid(jwt.MapClaims{"pubkey": "pubkey", "sid": "revoked", "jti": "current"}, ""))
+	assert.False(t, SessionValid(jwt.MapClaims{"pubkey": "pubkey", "sid": "session", "jti": "current"}, "other device"))
+}
+
+func TestClientFingerprint(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/websocket?fingerprint=from-query", nil)
+	assert.Equal(t, "from-query", ClientFingerprint(r))
+
+	r.Header.Set("x-client-fingerprint", "from-header")
+	assert.Equal(t, "from-header", ClientFingerprint(r))
+
+	assert.Equal(t, "", HashFingerprint(""))
+	assert.Len(t, HashFingerprint("from-header"), 64)
+	assert.NotEqual(t, "from-header", HashFingerprint("from-header"))
+}
+
+func TestAdminPubkeys(t *testing.T) {
+	loads := 0
+	SuperAdminsLoader = func() ([]string, error) {
+		loads++
+		return []string{"admin_pubkey"}, nil
+	}
+	defer func() {
+		SuperAdminsLoader = nil
+		RefreshAdminPubkeys()
+	}()
+	RefreshAdminPubkeys()
+
+	assert.True(t, AdminCheck("admin_pubkey"))
+	assert.False(t, AdminCheck("other_pubkey"))
+	assert.Equal(t, 1, loads)
+
+	RefreshAdminPubkeys()
+	assert.Equal(t, []string{"admin_pubkey"}, AdminPubkeys())
+	assert.Equal(t, 2, loads)
+}
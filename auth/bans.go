@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// BannedPubkeysLoader reads the active platform bans as the time each ban expires, the zero time
+// for bans that don't expire, it is set on startup. Without it no pubkey is banned
+var BannedPubkeysLoader func() (map[string]time.Time, error)
+
+// BannedPubkeysCacheTTL is how long the loaded bans are kept before reading them again
+const BannedPubkeysCacheTTL = time.Minute
+
+var bannedPubkeysCache = struct {
+	sync.Mutex
+	bans   map[string]time.Time
+	loaded time.Time
+}{}
+
+func bannedPubkeys() map[string]time.Time {
+	if BannedPubkeysLoader == nil {
+		return nil
+	}
+
+	bannedPubkeysCache.Lock()
+	defer bannedPubkeysCache.Unlock()
+
+	if bannedPubkeysCache.bans == nil || time.Since(bannedPubkeysCache.loaded) > BannedPubkeysCacheTTL {
+		bans, err := BannedPubkeysLoader()
+		if err != nil {
+			fmt.Println("[auth] could not load banned pubkeys", err)
+			return bannedPubkeysCache.bans
+		}
+		bannedPubkeysCache.bans = bans
+		bannedPubkeysCache.loaded = time.Now()
+	}
+	return bannedPubkeysCache.bans
+}
+
+// RefreshBannedPubkeys drops the cached bans so the next check reads them again
+func RefreshBannedPubkeys() {
+	bannedPubkeysCache.Lock()
+	bannedPubkeysCache.bans = nil
+	bannedPubkeysCache.Unlock()
+}
+
+// IsBanned is true while a pubkey has a ban that has not expired
+func IsBanned(pubkey string) bool {
+	expires, ok := bannedPubkeys()[pubkey]
+	if !ok {
+		return false
+	}
+	return expires.IsZero() || time.Now().Before(expires)
+}
+
+// RejectBanned turns away the writes of banned pubkeys, it goes after a middleware that sets the
+// pubkey and lets reads through so banned users can still see why
+func RejectBanned(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		pubkey, _ := r.Context().Value(ContextKey).(string)
+		if pubkey != "" && IsBanned(pubkey) {
+			fmt.Println("[auth] write from banned pubkey", pubkey)
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode("This pubkey is banned")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
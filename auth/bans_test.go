@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBanned(t *testing.T) {
+	loads := 0
+	BannedPubkeysLoader = func() (map[string]time.Time, error) {
+		loads++
+		return map[string]time.Time{
+			"permanent": {},
+			"expiring":  time.Now().Add(time.Hour),
+			"expired":   time.Now().Add(-time.Hour),
+		}, nil
+	}
+	defer func() {
+		BannedPubkeysLoader = nil
+		RefreshBannedPubkeys()
+	}()
+	RefreshBannedPubkeys()
+
+	assert.True(t, IsBanned("permanent"))
+	assert.True(t, IsBanned("expiring"))
+	assert.False(t, IsBanned("expired"))
+	assert.False(t, IsBanned("other"))
+	assert.Equal(t, 1, loads)
+
+	RefreshBannedPubkeys()
+	assert.True(t, IsBanned("permanent"))
+	assert.Equal(t, 2, loads)
+}
+
+func TestRejectBanned(t *testing.T) {
+	BannedPubkeysLoader = func() (map[string]time.Time, error) {
+		return map[string]time.Time{"banned": {}}, nil
+	}
+	defer func() {
+		BannedPubkeysLoader = nil
+		RefreshBannedPubkeys()
+	}()
+	RefreshBannedPubkeys()
+
+	handler := RejectBanned(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(method string, pubkey string) int {
+		req := httptest.NewRequest(method, "/tribes", nil)
+		req = req.WithContext(context.WithValue(req.Context(), ContextKey, pubkey))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "banned"))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodDelete, "banned"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "banned"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, "other"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, ""))
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/form3tech-oss/jwt-go"
+)
+
+// ImpersonationRecorder writes a request made with an impersonation token to the audit log and
+// returns false once the impersonation has ended, it is set on startup. Without it impersonation
+// tokens are refused
+var ImpersonationRecorder func(impersonationId string, method string, path string) bool
+
+var errImpersonationToken = errors.New("impersonation tokens only work on authenticated routes")
+
+// ImpersonationKey holds the impersonation a request was made with, it is not set for the
+// requests a pubkey makes itself
+var ImpersonationKey = contextKey("impersonation")
+
+// Impersonation is the impersonation of a request and the super admin acting in it
+type Impersonation struct {
+	Id    string
+	Actor string
+}
+
+// EncodeImpersonationJwt creates a token that acts as target on behalf of admin until expires,
+// the scope limits it to reads unless it is "write"
+func EncodeImpersonationJwt(target string, admin string, impersonationId string, scope string, expires time.Time) (string, error) {
+	claims := jwt.MapClaims{
+		"pubkey": target,
+		"exp":    expires.Unix(),
+		"imp":    impersonationId,
+		"act":    admin,
+		"scope":  scope,
+	}
+
+	_, tokenString, err := TokenAuth.Encode(claims)
+	if err != nil {
+		return "", err
+	}
+	return tokenString, nil
+}
+
+// ImpersonationId is the impersonation a token was issued for, "" for the tokens of the pubkey itself
+func ImpersonationId(claims jwt.MapClaims) string {
+	impersonationId, _ := claims["imp"].(string)
+	return impersonationId
+}
+
+// ImpersonationOf returns the impersonation a request was made with and false for the requests a
+// pubkey makes itself
+func ImpersonationOf(ctx context.Context) (Impersonation, bool) {
+	impersonation, ok := ctx.Value(ImpersonationKey).(Impersonation)
+	return impersonation, ok
+}
+
+// IsImpersonatedRequest reports if the request was made with an impersonation token
+func IsImpersonatedRequest(ctx context.Context) bool {
+	_, ok := ImpersonationOf(ctx)
+	return ok
+}
+
+func impersonationContext(ctx context.Context, claims jwt.MapClaims) context.Context {
+	actor, _ := claims["act"].(string)
+	return context.WithValue(ctx, ImpersonationKey, Impersonation{Id: ImpersonationId(claims), Actor: actor})
+}
+
+// impersonationAllowed records a request made with an impersonation token and returns the status
+// to refuse it with, 0 when it goes through
+func impersonationAllowed(claims jwt.MapClaims, r *http.Request) int {
+	if ImpersonationRecorder == nil || !ImpersonationRecorder(ImpersonationId(claims), r.Method, r.URL.Path) {
+		return http.StatusUnauthorized
+	}
+	scope, _ := claims["scope"].(string)
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return 0
+	}
+	if scope != "write" {
+		return http.StatusForbidden
+	}
+	return 0
+}
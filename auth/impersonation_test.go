@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImpersonationToken(t *testing.T) {
+	jwtKey, tokenAuth := config.JwtKey, TokenAuth
+	config.JwtKey = "impersonation_test_key"
+	InitJwt()
+	defer func() { config.JwtKey, TokenAuth = jwtKey, tokenAuth }()
+
+	logged := []string{}
+	ended := false
+	ImpersonationRecorder = func(impersonationId string, method string, path string) bool {
+		if ended {
+			return false
+		}
+		logged = append(logged, impersonationId+" "+method+" "+path)
+		return true
+	}
+	defer func() { ImpersonationRecorder = nil }()
+
+	readToken, _ := EncodeImpersonationJwt("target", "admin", "imp_read", "read", time.Now().Add(time.Minute))
+	writeToken, _ := EncodeImpersonationJwt("target", "admin", "imp_write", "write", time.Now().Add(time.Minute))
+
+	var impersonation Impersonation
+	var impersonated bool
+	serve := func(handler func(http.Handler) http.Handler, method string, token string) (int, string) {
+		pubkey := ""
+		impersonation, impersonated = Impersonation{}, false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pubkey, _ = r.Context().Value(ContextKey).(string)
+			impersonation, impersonated = ImpersonationOf(r.Context())
+			w.WriteHeader(http.StatusOK)
+		})
+		req := httptest.NewRequest(method, "/person", nil)
+		req.Header.Set("x-jwt", token)
+		rr := httptest.NewRecorder()
+		handler(next).ServeHTTP(rr, req)
+		return rr.Code, pubkey
+	}
+
+	code, pubkey := serve(PubKeyContext, http.MethodGet, readToken)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "target", pubkey)
+	assert.True(t, impersonated)
+	assert.Equal(t, Impersonation{Id: "imp_read", Actor: "admin"}, impersonation)
+
+	code, _ = serve(PubKeyContext, http.MethodPost, readToken)
+	assert.Equal(t, http.StatusForbidden, code)
+	code, _ = serve(PubKeyContext, http.MethodPost, writeToken)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, Impersonation{Id: "imp_write", Actor: "admin"}, impersonation)
+	assert.Equal(t, []string{"imp_read GET /person", "imp_read POST /person", "imp_write POST /person"}, logged)
+
+	code, _ = serve(PubKeyContextSuperAdmin, http.MethodGet, writeToken)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	_, err := PubkeyFromToken(writeToken, "")
+	assert.Error(t, err)
+
+	ended = true
+	code, _ = serve(PubKeyContext, http.MethodGet, writeToken)
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
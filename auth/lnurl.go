@@ -15,11 +15,15 @@ type LnEncodeData struct {
 }
 
 func EncodeLNURL(host string) (LnEncodeData, error) {
+	return EncodeLNURLForK1(host, generate32Bytes())
+}
+
+// EncodeLNURLForK1 encodes the login url of an existing challenge
+func EncodeLNURLForK1(host string, k1 string) (LnEncodeData, error) {
 	hostUrl := config.Host
 	if !strings.Contains(host, "localhost") {
 		hostUrl = "https://" + host
 	}
-	k1 := generate32Bytes()
 	url := hostUrl + "/" + "lnauth_login?tag=login&k1=" + k1 + "&action=login"
 
 	encode, err := lnurl.Encode(url)
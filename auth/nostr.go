@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+const (
+	// NostrHttpAuthKind is the event kind of NIP-98 HTTP auth events
+	NostrHttpAuthKind = 27235
+	// NostrAuthScheme prefixes the base64 encoded event in the Authorization header
+	NostrAuthScheme = "Nostr "
+	// NostrEventMaxAge is how far the created_at of an event may be from the server time
+	NostrEventMaxAge = 60 * time.Second
+)
+
+// seenNostrEvents keeps the ids of the accepted events until they are too old to be accepted again,
+// so a captured Authorization header can't be replayed
+var seenNostrEvents = struct {
+	sync.Mutex
+	expires map[string]time.Time
+}{expires: map[string]time.Time{}}
+
+// markNostrEventSeen records the id of an accepted event, it is false when the id was already used
+func markNostrEventSeen(event NostrEvent) bool {
+	seenNostrEvents.Lock()
+	defer seenNostrEvents.Unlock()
+
+	now := time.Now()
+	for id, expires := range seenNostrEvents.expires {
+		if now.After(expires) {
+			delete(seenNostrEvents.expires, id)
+		}
+	}
+	if _, ok := seenNostrEvents.expires[event.ID]; ok {
+		return false
+	}
+	seenNostrEvents.expires[event.ID] = time.Unix(event.CreatedAt, 0).Add(NostrEventMaxAge)
+	return true
+}
+
+// NostrPubkeyResolver maps a verified hex nostr pubkey to the owner pubkey of the linked person, it is set on startup
+var NostrPubkeyResolver func(nostrPubkey string) (string, error)
+
+type NostrEvent struct {
+	ID        string     `json:"id"`
+	Pubkey    string     `json:"pubkey"`
+	CreatedAt int64      `json:"created_at"`
+	Kind      int        `json:"kind"`
+	Tags      [][]string `json:"tags"`
+	Content   string     `json:"content"`
+	Sig       string     `json:"sig"`
+}
+
+// Tag returns the first value of the tag with the given name
+func (e NostrEvent) Tag(name string) string {
+	for _, tag := range e.Tags {
+		if len(tag) > 1 && tag[0] == name {
+			return tag[1]
+		}
+	}
+	return ""
+}
+
+// VerifyNostrEvent checks the event id and its schnorr signature
+func VerifyNostrEvent(event NostrEvent) error {
+	tags := event.Tags
+	if tags == nil {
+		tags = [][]string{}
+	}
+	serialized, err := json.Marshal([]interface{}{0, event.Pubkey, event.CreatedAt, event.Kind, tags, event.Content})
+	if err != nil {
+		return err
+	}
+	id := sha256.Sum256(serialized)
+	if hex.EncodeToString(id[:]) != event.ID {
+		return errors.New("invalid event id")
+	}
+
+	pubkeyBytes, err := hex.DecodeString(event.Pubkey)
+	if err != nil {
+		return err
+	}
+	pubkey, err := schnorr.ParsePubKey(pubkeyBytes)
+	if err != nil {
+		return err
+	}
+	sigBytes, err := hex.DecodeString(event.Sig)
+	if err != nil {
+		return err
+	}
+	sig, err := schnorr.ParseSignature(sigBytes)
+	if err != nil {
+		return err
+	}
+	if !sig.Verify(id[:], pubkey) {
+		return errors.New("invalid event signature")
+	}
+	return nil
+}
+
+// NostrEventRecent checks that created_at is within NostrEventMaxAge of now
+func NostrEventRecent(event NostrEvent) bool {
+	age := time.Since(time.Unix(event.CreatedAt, 0))
+	return age < NostrEventMaxAge && age > -NostrEventMaxAge
+}
+
+// IsNostrAuth reports if the request carries a NIP-98 Authorization header
+func IsNostrAuth(r *http.Request) bool {
+	return strings.HasPrefix(r.Header.Get("Authorization"), NostrAuthScheme)
+}
+
+// VerifyNostrHttpAuth validates the NIP-98 event of a request and returns the hex nostr pubkey that signed it.
+// A request with a body needs the payload tag, and an event is accepted once
+func VerifyNostrHttpAuth(r *http.Request) (string, error) {
+	encoded := strings.TrimPrefix(r.Header.Get("Authorization"), NostrAuthScheme)
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", err
+	}
+
+	event := NostrEvent{}
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return "", err
+	}
+
+	if event.Kind != NostrHttpAuthKind {
+		return "", errors.New("invalid event kind")
+	}
+	if !NostrEventRecent(event) {
+		return "", errors.New("event is too old")
+	}
+	if !strings.EqualFold(event.Tag("method"), r.Method) {
+		return "", errors.New("event method does not match")
+	}
+
+	eventUrl, err := url.Parse(event.Tag("u"))
+	if err != nil {
+		return "", err
+	}
+	// the host a proxy forwarded the request for is only taken from it when the proxy headers are trusted
+	host := r.Host
+	if forwarded := r.Header.Get("X-Forwarded-Host"); forwarded != "" && config.TrustProxyHeaders {
+		host = strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	if eventUrl.Host != host || eventUrl.Path != RequestPath(r) || eventUrl.RawQuery != r.URL.RawQuery {
+		return "", errors.New("event url does not match")
+	}
+
+	var body []byte
+	if r.Body != nil {
+		body, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return "", err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	payload := event.Tag("payload")
+	if payload == "" && len(body) > 0 {
+		return "", errors.New("event has no payload for the request body")
+	}
+	if payload != "" {
+		hash := sha256.Sum256(body)
+		if hex.EncodeToString(hash[:]) != payload {
+			return "", errors.New("event payload does not match")
+		}
+	}
+
+	if err := VerifyNostrEvent(event); err != nil {
+		return "", err
+	}
+	if !markNostrEventSeen(event) {
+		return "", errors.New("event was already used")
+	}
+	return event.Pubkey, nil
+}
+
+// NostrPubkeyToNpub encodes a hex nostr pubkey as a bech32 npub
+func NostrPubkeyToNpub(pubkey string) (string, error) {
+	data, err := hex.DecodeString(pubkey)
+	if err != nil {
+		return "", err
+	}
+	converted, err := bech32.ConvertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return bech32.Encode("npub", converted)
+}
+
+func nostrContext(w http.ResponseWriter, r *http.Request) (context.Context, bool) {
+	nostrPubkey, err := VerifyNostrHttpAuth(r)
+	if err != nil || NostrPubkeyResolver == nil {
+		http.Error(w, http.StatusText(401), 401)
+		return nil, false
+	}
+
+	pubkey, err := NostrPubkeyResolver(nostrPubkey)
+	if err != nil || pubkey == "" {
+		http.Error(w, http.StatusText(401), 401)
+		return nil, false
+	}
+
+	return context.WithValue(r.Context(), ContextKey, pubkey), true
+}
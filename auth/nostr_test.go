@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	btcec "github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func signNostrEvent(t *testing.T, privKey *btcec.PrivateKey, event NostrEvent) NostrEvent {
+	event.Pubkey = hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey()))
+	serialized, _ := json.Marshal([]interface{}{0, event.Pubkey, event.CreatedAt, event.Kind, event.Tags, event.Content})
+	id := sha256.Sum256(serialized)
+	event.ID = hex.EncodeToString(id[:])
+	sig, err := schnorr.Sign(privKey, id[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.Sig = hex.EncodeToString(sig.Serialize())
+	return event
+}
+
+func nostrAuthHeader(event NostrEvent) string {
+	raw, _ := json.Marshal(event)
+	return NostrAuthScheme + base64.StdEncoding.EncodeToString(raw)
+}
+
+func TestVerifyNostrHttpAuth(t *testing.T) {
+	privKey, _ := btcec.NewPrivateKey()
+	newEvent := func(method string, u string) NostrEvent {
+		return signNostrEvent(t, privKey, NostrEvent{
+			CreatedAt: time.Now().Unix(),
+			Kind:      NostrHttpAuthKind,
+			Tags:      [][]string{{"u", u}, {"method", method}},
+		})
+	}
+
+	t.Run("Should test that a valid event returns the nostr pubkey", func(t *testing.T) {
+		event := newEvent("GET", "http://example.com/person/notifications?page=1")
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/person/notifications?page=1", nil)
+		req.Header.Set("Authorization", nostrAuthHeader(event))
+
+		pubkey, err := VerifyNostrHttpAuth(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, event.Pubkey, pubkey)
+	})
+
+	t.Run("Should test that the url and method must match the request", func(t *testing.T) {
+		event := newEvent("GET", "http://example.com/person/notifications")
+		req, _ := http.NewRequest(http.MethodPost, "http://example.com/person/notifications", nil)
+		req.Header.Set("Authorization", nostrAuthHeader(event))
+		_, err := VerifyNostrHttpAuth(req)
+		assert.Error(t, err)
+
+		req, _ = http.NewRequest(http.MethodGet, "http://example.com/gobounties/1", nil)
+		req.Header.Set("Authorization", nostrAuthHeader(event))
+		_, err = VerifyNostrHttpAuth(req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Should test that the forwarded host is only used behind a trusted proxy", func(t *testing.T) {
+		trust := config.TrustProxyHeaders
+		defer func() { config.TrustProxyHeaders = trust }()
+
+		event := newEvent("GET", "http://other.example.com/person/notifications")
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/person/notifications", nil)
+		req.Header.Set("Authorization", nostrAuthHeader(event))
+		req.Header.Set("X-Forwarded-Host", "other.example.com")
+
+		config.TrustProxyHeaders = false
+		_, err := VerifyNostrHttpAuth(req)
+		assert.Error(t, err)
+
+		config.TrustProxyHeaders = true
+		pubkey, err := VerifyNostrHttpAuth(req)
+		assert.NoError(t, err)
+		assert.Equal(t, event.Pubkey, pubkey)
+	})
+
+	t.Run("Should test that old and tampered events are rejected", func(t *testing.T) {
+		event := signNostrEvent(t, privKey, NostrEvent{
+			CreatedAt: time.Now().Add(-5 * time.Minute).Unix(),
+			Kind:      NostrHttpAuthKind,
+			Tags:      [][]string{{"u", "http://example.com/people"}, {"method", "GET"}},
+		})
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/people", nil)
+		req.Header.Set("Authorization", nostrAuthHeader(event))
+		_, err := VerifyNostrHttpAuth(req)
+		assert.Error(t, err)
+
+		event = newEvent("GET", "http://example.com/people")
+		event.Content = "tampered"
+		req.Header.Set("Authorization", nostrAuthHeader(event))
+		_, err = VerifyNostrHttpAuth(req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Should test that the payload hash must match the body", func(t *testing.T) {
+		body := `{"name":"test"}`
+		hash := sha256.Sum256([]byte(body))
+		event := signNostrEvent(t, privKey, NostrEvent{
+			CreatedAt: time.Now().Unix(),
+			Kind:      NostrHttpAuthKind,
+			Tags:      [][]string{{"u", "http://example.com/person"}, {"method", "POST"}, {"payload", hex.EncodeToString(hash[:])}},
+		})
+
+		req, _ := http.NewRequest(http.MethodPost, "http://example.com/person", strings.NewReader(body))
+		req.Header.Set("Authorization", nostrAuthHeader(event))
+		_, err := VerifyNostrHttpAuth(req)
+		assert.NoError(t, err)
+
+		req, _ = http.NewRequest(http.MethodPost, "http://example.com/person", strings.NewReader(`{"name":"other"}`))
+		req.Header.Set("Authorization", nostrAuthHeader(event))
+		_, err = VerifyNostrHttpAuth(req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Should test that a request with a body needs the payload tag", func(t *testing.T) {
+		event := newEvent("POST", "http://example.com/person")
+		req, _ := http.NewRequest(http.MethodPost, "http://example.com/person", strings.NewReader(`{"name":"test"}`))
+		req.Header.Set("Authorization", nostrAuthHeader(event))
+
+		_, err := VerifyNostrHttpAuth(req)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Should test that an event can't be replayed", func(t *testing.T) {
+		event := newEvent("GET", "http://example.com/person/notifications?page=2")
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/person/notifications?page=2", nil)
+		req.Header.Set("Authorization", nostrAuthHeader(event))
+		_, err := VerifyNostrHttpAuth(req)
+		assert.NoError(t, err)
+
+		req, _ = http.NewRequest(http.MethodGet, "http://example.com/person/notifications?page=2", nil)
+		req.Header.Set("Authorization", nostrAuthHeader(event))
+		_, err = VerifyNostrHttpAuth(req)
+		assert.Error(t, err)
+	})
+}
+
+func TestPubKeyContextWithNostr(t *testing.T) {
+	privKey, _ := btcec.NewPrivateKey()
+	nostrPubkey := hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey()))
+	NostrPubkeyResolver = func(pubkey string) (string, error) {
+		if pubkey == nostrPubkey {
+			return "owner_pubkey", nil
+		}
+		return "", errors.New("no nostr identity found")
+	}
+	defer func() { NostrPubkeyResolver = nil }()
+
+	var pubkeyFromContext string
+	handler := PubKeyContext(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pubkeyFromContext, _ = r.Context().Value(ContextKey).(string)
+	}))
+
+	t.Run("Should test that a linked nostr key sets the owner pubkey", func(t *testing.T) {
+		event := signNostrEvent(t, privKey, NostrEvent{
+			CreatedAt: time.Now().Unix(),
+			Kind:      NostrHttpAuthKind,
+			Tags:      [][]string{{"u", "http://example.com/person/notifications"}, {"method", "GET"}},
+		})
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/person/notifications", nil)
+		req.Header.Set("Authorization", nostrAuthHeader(event))
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "owner_pubkey", pubkeyFromContext)
+	})
+
+	t.Run("Should test that an unlinked nostr key is rejected", func(t *testing.T) {
+		otherKey, _ := btcec.NewPrivateKey()
+		event := signNostrEvent(t, otherKey, NostrEvent{
+			CreatedAt: time.Now().Unix(),
+			Kind:      NostrHttpAuthKind,
+			Tags:      [][]string{{"u", "http://example.com/person/notifications"}, {"method", "GET"}},
+		})
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/person/notifications", nil)
+		req.Header.Set("Authorization", nostrAuthHeader(event))
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestNostrPubkeyToNpub(t *testing.T) {
+	npub, err := NostrPubkeyToNpub("3bf0c63fcb93463407af97a5e5ee64fa883d107ef9e558472c4eb9aaaefa459d")
+	assert.NoError(t, err)
+	assert.Equal(t, "npub180cvv07tjdrrgpa0j7j7tmnyl2yr6yr7l8j4s3evf6u64th6gkwsyjh6w6", npub)
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+var (
+	ErrPowInvalid  = errors.New("the challenge is not valid")
+	ErrPowExpired  = errors.New("the challenge expired")
+	ErrPowUnsolved = errors.New("the nonce does not solve the challenge")
+)
+
+// PowChallenge is a hashcash style proof of work, it is solved by a nonce that makes the sha256
+// of the challenge followed by the nonce start with Difficulty zero bits
+type PowChallenge struct {
+	Challenge  string `json:"challenge"`
+	Difficulty int    `json:"difficulty"`
+	Expires    int64  `json:"expires"`
+}
+
+func powSignature(payload string) string {
+	mac := hmac.New(sha256.New, []byte(config.JwtKey))
+	mac.Write([]byte("pow:" + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewPowChallenge makes a challenge signed with the jwt key, so the server does not have to
+// keep the challenges it gave out
+func NewPowChallenge(difficulty int, ttl time.Duration) PowChallenge {
+	random := make([]byte, 16)
+	rand.Read(random)
+	expires := time.Now().Add(ttl).Unix()
+	payload := fmt.Sprintf("%d.%d.%s", expires, difficulty, hex.EncodeToString(random))
+	return PowChallenge{
+		Challenge:  payload + "." + powSignature(payload),
+		Difficulty: difficulty,
+		Expires:    expires,
+	}
+}
+
+// PowZeroBits counts the leading zero bits of a hash
+func PowZeroBits(hash []byte) int {
+	count := 0
+	for _, b := range hash {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+	return count
+}
+
+// VerifyPow checks that a nonce solves a challenge made by NewPowChallenge of at least a
+// difficulty that has not expired. It does not stop a solution being used twice, the callers
+// remember the challenges they accepted
+func VerifyPow(challenge string, nonce string, minDifficulty int, now time.Time) error {
+	parts := strings.Split(challenge, ".")
+	if len(parts) != 4 {
+		return ErrPowInvalid
+	}
+	payload := strings.Join(parts[:3], ".")
+	if !hmac.Equal([]byte(parts[3]), []byte(powSignature(payload))) {
+		return ErrPowInvalid
+	}
+	expires, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return ErrPowInvalid
+	}
+	difficulty, err := strconv.Atoi(parts[1])
+	if err != nil || difficulty < minDifficulty {
+		return ErrPowInvalid
+	}
+	if now.Unix() > expires {
+		return ErrPowExpired
+	}
+
+	hash := sha256.Sum256([]byte(challenge + nonce))
+	if PowZeroBits(hash[:]) < difficulty {
+		return ErrPowUnsolved
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// solvePow finds the nonce of a challenge by trying every number
+func solvePow(challenge PowChallenge) string {
+	for i := 0; ; i++ {
+		nonce := strconv.Itoa(i)
+		hash := sha256.Sum256([]byte(challenge.Challenge + nonce))
+		if PowZeroBits(hash[:]) >= challenge.Difficulty {
+			return nonce
+		}
+	}
+}
+
+func TestPowZeroBits(t *testing.T) {
+	assert.Equal(t, 0, PowZeroBits([]byte{0x80}))
+	assert.Equal(t, 7, PowZeroBits([]byte{0x01}))
+	assert.Equal(t, 12, PowZeroBits([]byte{0x00, 0x0f}))
+	assert.Equal(t, 16, PowZeroBits([]byte{0x00, 0x00}))
+}
+
+func TestVerifyPow(t *testing.T) {
+	challenge := NewPowChallenge(8, time.Minute)
+	nonce := solvePow(challenge)
+
+	assert.NoError(t, VerifyPow(challenge.Challenge, nonce, 8, time.Now()))
+	assert.Equal(t, ErrPowExpired, VerifyPow(challenge.Challenge, nonce, 8, time.Now().Add(2*time.Minute)))
+	assert.Equal(t, ErrPowInvalid, VerifyPow("1.2.3", nonce, 8, time.Now()))
+	// a challenge easier than required
+	assert.Equal(t, ErrPowInvalid, VerifyPow(challenge.Challenge, nonce, 16, time.Now()))
+
+	// lowering the difficulty breaks the signature
+	parts := strings.Split(challenge.Challenge, ".")
+	parts[1] = "0"
+	forged := strings.Join(parts, ".")
+	assert.Equal(t, ErrPowInvalid, VerifyPow(forged, nonce, 0, time.Now()))
+
+	// a nonce that does not solve the challenge
+	for i := 0; ; i++ {
+		hash := sha256.Sum256([]byte(challenge.Challenge + "x" + strconv.Itoa(i)))
+		if PowZeroBits(hash[:]) < 8 {
+			assert.Equal(t, ErrPowUnsolved, VerifyPow(challenge.Challenge, "x"+strconv.Itoa(i), 8, time.Now()))
+			break
+		}
+	}
+}
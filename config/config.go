@@ -28,6 +28,7 @@ var SuperAdmins []string = []string{""}
 // these are constants for the store
 var InvoiceList = "INVOICELIST"
 var BudgetInvoiceList = "BUDGETINVOICELIST"
+var FeedCachePrefix = "FEEDCACHE_"
 var S3BucketName string
 var S3FolderName string
 var S3Url string
@@ -36,6 +37,119 @@ var AdminDevFreePass = "FREE_PASS"
 var Connection_Auth string
 var AdminStrings string
 
+// smtp settings for email notifications
+var SmtpHost string
+var SmtpPort string
+var SmtpUser string
+var SmtpPass string
+var SmtpFrom string
+
+// cron schedules for the maintenance scheduler
+var InvoiceExpirySchedule string
+var TribeMemberCountSchedule string
+var FeedRefreshSchedule string
+var BountyDeadlineSchedule string
+var IdempotencyPurgeSchedule string
+var ReputationSchedule string
+var AccountPurgeSchedule string
+var BudgetAlertSchedule string
+var InvoiceReconcileSchedule string
+var PaymentRetrySchedule string
+var AssignmentExpirySchedule string
+var LeaderboardSchedule string
+var MetricsRollupSchedule string
+var SearchReindexSchedule string
+var SearchSyncSchedule string
+var MediaJobSchedule string
+var WorkflowJobSchedule string
+var WebhookJobSchedule string
+var ImpersonationSchedule string
+var WorkspaceTransferSchedule string
+var RepositorySyncSchedule string
+
+// how long before an assignment expires its assignee is warned
+var AssignmentExpiryWarning string
+
+// how long a Stakwork request may take, and how many times one failing with a 5xx is retried
+var StakworkTimeout string
+var StakworkRetries string
+
+// shared secret the relay signs invoice webhooks with
+var InvoiceWebhookSecret string
+
+// lightning backend used for invoices and payments, relay, lnd, cln or lndhub
+var LightningBackend string
+var LndUrl string
+var LndMacaroon string
+var ClnUrl string
+var ClnRune string
+var LndhubUrl string
+var LndhubLogin string
+var LndhubPassword string
+
+// fiat currencies amounts are shown in and the provider of their bitcoin exchange rates
+var FiatCurrencies string
+var RatesProvider string
+var RatesStatic string
+var RatesCacheTTL string
+
+// search backend of the unified search, postgres or meilisearch
+var SearchBackend string
+var MeilisearchUrl string
+var MeilisearchKey string
+
+// storage of uploaded files, meme keeps the meme server only, s3 (or minio with an endpoint) or local
+var StorageBackend string
+var StorageLocalDir string
+var StoragePublicUrl string
+var StorageS3Endpoint string
+var StorageS3Region string
+var StorageS3Bucket string
+var StorageS3AccessKey string
+var StorageS3SecretKey string
+
+// limits on handing out connection codes, the count of codes an ip or a device can get in a
+// window and the proof of work difficulty in bits, 0 leaves the proof of work off
+var ConnectionCodeIpLimit string
+var ConnectionCodeDeviceLimit string
+var ConnectionCodeLimitWindow string
+var ConnectionCodePowDifficulty string
+
+// TrustProxyHeaders reads the client ip from X-Forwarded-For, only set it behind a proxy that sets the header
+var TrustProxyHeaders bool
+
+// challenge gate in front of public write endpoints, the provider is "pow" or "hcaptcha" and
+// empty leaves it off, the routes are comma separated paths and a path ending in /* is a prefix.
+// Pubkeys with an account older than the bypass age skip the challenge
+var ChallengeProvider string
+var ChallengeRoutes string
+var ChallengePowDifficulty string
+var ChallengeBypassAge string
+var HCaptchaSiteKey string
+var HCaptchaSecret string
+
+// spam checks on new tribes and bots, the comma separated domains that put a listing with a link
+// to them up for review and how many tribes or bots a pubkey creates in the window before its
+// next ones are reviewed
+var SpamBlockedDomains string
+var SpamCreateLimit string
+var SpamCreateWindow string
+
+// the date the paths without a version prefix stop working, sent as their Sunset header
+var LegacyApiSunset string
+
+// gRPC server for internal services such as the relay, off unless a port is set. Callers send the
+// token as their authorization metadata
+var GrpcPort string
+var GrpcToken string
+
+// response compression, the comma separated encodings in order of preference or none to turn it
+// off, the smallest body in bytes worth compressing, the level and the content types compressed
+var CompressionEncodings string
+var CompressionMinSize string
+var CompressionLevel string
+var CompressionTypes string
+
 var S3Client *s3.Client
 var PresignClient *s3.PresignClient
 
@@ -54,10 +168,86 @@ func InitConfig() {
 	S3Url = os.Getenv("S3_URL")
 	AdminCheck = os.Getenv("ADMIN_CHECK")
 	Connection_Auth = os.Getenv("CONNECTION_AUTH")
+	SmtpHost = os.Getenv("SMTP_HOST")
+	SmtpPort = os.Getenv("SMTP_PORT")
+	SmtpUser = os.Getenv("SMTP_USER")
+	SmtpPass = os.Getenv("SMTP_PASS")
+	SmtpFrom = os.Getenv("SMTP_FROM")
+	InvoiceExpirySchedule = os.Getenv("INVOICE_EXPIRY_SCHEDULE")
+	TribeMemberCountSchedule = os.Getenv("TRIBE_MEMBER_COUNT_SCHEDULE")
+	FeedRefreshSchedule = os.Getenv("FEED_REFRESH_SCHEDULE")
+	BountyDeadlineSchedule = os.Getenv("BOUNTY_DEADLINE_SCHEDULE")
+	IdempotencyPurgeSchedule = os.Getenv("IDEMPOTENCY_PURGE_SCHEDULE")
+	ReputationSchedule = os.Getenv("REPUTATION_SCHEDULE")
+	AccountPurgeSchedule = os.Getenv("ACCOUNT_PURGE_SCHEDULE")
+	BudgetAlertSchedule = os.Getenv("BUDGET_ALERT_SCHEDULE")
+	InvoiceReconcileSchedule = os.Getenv("INVOICE_RECONCILE_SCHEDULE")
+	PaymentRetrySchedule = os.Getenv("PAYMENT_RETRY_SCHEDULE")
+	AssignmentExpirySchedule = os.Getenv("ASSIGNMENT_EXPIRY_SCHEDULE")
+	LeaderboardSchedule = os.Getenv("LEADERBOARD_SCHEDULE")
+	MetricsRollupSchedule = os.Getenv("METRICS_ROLLUP_SCHEDULE")
+	SearchReindexSchedule = os.Getenv("SEARCH_REINDEX_SCHEDULE")
+	SearchSyncSchedule = os.Getenv("SEARCH_SYNC_SCHEDULE")
+	MediaJobSchedule = os.Getenv("MEDIA_JOB_SCHEDULE")
+	WorkflowJobSchedule = os.Getenv("WORKFLOW_JOB_SCHEDULE")
+	WebhookJobSchedule = os.Getenv("WEBHOOK_JOB_SCHEDULE")
+	ImpersonationSchedule = os.Getenv("IMPERSONATION_SCHEDULE")
+	WorkspaceTransferSchedule = os.Getenv("WORKSPACE_TRANSFER_SCHEDULE")
+	RepositorySyncSchedule = os.Getenv("REPOSITORY_SYNC_SCHEDULE")
+	GrpcPort = os.Getenv("GRPC_PORT")
+	LegacyApiSunset = os.Getenv("LEGACY_API_SUNSET")
+	CompressionEncodings = os.Getenv("COMPRESSION_ENCODINGS")
+	CompressionMinSize = os.Getenv("COMPRESSION_MIN_SIZE")
+	CompressionLevel = os.Getenv("COMPRESSION_LEVEL")
+	CompressionTypes = os.Getenv("COMPRESSION_TYPES")
+	GrpcToken = os.Getenv("GRPC_TOKEN")
+	AssignmentExpiryWarning = os.Getenv("ASSIGNMENT_EXPIRY_WARNING")
+	StakworkTimeout = os.Getenv("STAKWORK_TIMEOUT")
+	StakworkRetries = os.Getenv("STAKWORK_RETRIES")
+	InvoiceWebhookSecret = os.Getenv("INVOICE_WEBHOOK_SECRET")
+	LightningBackend = os.Getenv("LIGHTNING_BACKEND")
+	LndUrl = os.Getenv("LND_URL")
+	LndMacaroon = os.Getenv("LND_MACAROON")
+	ClnUrl = os.Getenv("CLN_URL")
+	ClnRune = os.Getenv("CLN_RUNE")
+	LndhubUrl = os.Getenv("LNDHUB_URL")
+	LndhubLogin = os.Getenv("LNDHUB_LOGIN")
+	LndhubPassword = os.Getenv("LNDHUB_PASSWORD")
+	FiatCurrencies = os.Getenv("FIAT_CURRENCIES")
+	RatesProvider = os.Getenv("RATES_PROVIDER")
+	RatesStatic = os.Getenv("RATES_STATIC")
+	RatesCacheTTL = os.Getenv("RATES_CACHE_TTL")
+	SearchBackend = os.Getenv("SEARCH_BACKEND")
+	MeilisearchUrl = os.Getenv("MEILISEARCH_URL")
+	MeilisearchKey = os.Getenv("MEILISEARCH_KEY")
+	StorageBackend = os.Getenv("STORAGE_BACKEND")
+	StorageLocalDir = os.Getenv("STORAGE_LOCAL_DIR")
+	StoragePublicUrl = os.Getenv("STORAGE_PUBLIC_URL")
+	StorageS3Endpoint = os.Getenv("STORAGE_S3_ENDPOINT")
+	StorageS3Region = os.Getenv("STORAGE_S3_REGION")
+	StorageS3Bucket = os.Getenv("STORAGE_S3_BUCKET")
+	StorageS3AccessKey = os.Getenv("STORAGE_S3_ACCESS_KEY")
+	StorageS3SecretKey = os.Getenv("STORAGE_S3_SECRET_KEY")
+	ConnectionCodeIpLimit = os.Getenv("CONNECTION_CODE_IP_LIMIT")
+	ConnectionCodeDeviceLimit = os.Getenv("CONNECTION_CODE_DEVICE_LIMIT")
+	ConnectionCodeLimitWindow = os.Getenv("CONNECTION_CODE_LIMIT_WINDOW")
+	ConnectionCodePowDifficulty = os.Getenv("CONNECTION_CODE_POW_DIFFICULTY")
+	TrustProxyHeaders = os.Getenv("TRUST_PROXY_HEADERS") == "true"
+	ChallengeProvider = os.Getenv("CHALLENGE_PROVIDER")
+	ChallengeRoutes = os.Getenv("CHALLENGE_ROUTES")
+	ChallengePowDifficulty = os.Getenv("CHALLENGE_POW_DIFFICULTY")
+	ChallengeBypassAge = os.Getenv("CHALLENGE_BYPASS_AGE")
+	HCaptchaSiteKey = os.Getenv("HCAPTCHA_SITE_KEY")
+	HCaptchaSecret = os.Getenv("HCAPTCHA_SECRET")
+	SpamBlockedDomains = os.Getenv("SPAM_BLOCKED_DOMAINS")
+	SpamCreateLimit = os.Getenv("SPAM_CREATE_LIMIT")
+	SpamCreateWindow = os.Getenv("SPAM_CREATE_WINDOW")
 
 	// Add to super admins
 	SuperAdmins = StripSuperAdmins(AdminStrings)
 
+	InitCorsConfig()
+
 	awsConfig, err := config.LoadDefaultConfig(context.TODO(),
 		config.WithRegion(AwsRegion),
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(AwsAccess, AwsSecret, "")),
@@ -71,17 +261,41 @@ func InitConfig() {
 	S3Client = s3.NewFromConfig(awsConfig)
 	PresignClient = s3.NewPresignClient(S3Client)
 
-	// only make this call if there is a Relay auth key
+	if LightningBackend == "" {
+		LightningBackend = "relay"
+	}
+
+	// only make this call if there is a Relay auth key, other lightning backends don't need a relay
 	if RelayAuthKey != "" {
 		RelayNodeKey = GetNodePubKey()
-	} else {
+	} else if LightningBackend == "relay" {
 		panic("No relay auth key set")
 	}
 
+	if FiatCurrencies == "" {
+		FiatCurrencies = "USD,EUR"
+	}
+
+	if SearchBackend == "" {
+		SearchBackend = "postgres"
+	}
+
+	if RatesProvider == "" {
+		RatesProvider = "coingecko"
+	}
+
+	if RatesCacheTTL == "" {
+		RatesCacheTTL = "5m"
+	}
+
 	if Host == "" {
 		Host = "https://people.sphinx.chat"
 	}
 
+	if StoragePublicUrl == "" {
+		StoragePublicUrl = Host
+	}
+
 	if MemeUrl == "" {
 		MemeUrl = "https://memes.sphinx.chat"
 	}
@@ -94,6 +308,80 @@ func InitConfig() {
 		S3BucketName = "sphinx-tribes"
 	}
 
+	if StorageBackend == "" {
+		StorageBackend = "meme"
+	}
+
+	if StorageLocalDir == "" {
+		StorageLocalDir = "./files"
+	}
+
+	// the files of the s3 storage go to the metrics bucket with the aws credentials unless set apart
+	if StorageS3Region == "" {
+		StorageS3Region = AwsRegion
+	}
+
+	if StorageS3Bucket == "" {
+		StorageS3Bucket = S3BucketName
+	}
+
+	if StorageS3AccessKey == "" {
+		StorageS3AccessKey = AwsAccess
+		StorageS3SecretKey = AwsSecret
+	}
+
+	if ConnectionCodeIpLimit == "" {
+		ConnectionCodeIpLimit = "5"
+	}
+
+	if ConnectionCodeDeviceLimit == "" {
+		ConnectionCodeDeviceLimit = "1"
+	}
+
+	if ConnectionCodeLimitWindow == "" {
+		ConnectionCodeLimitWindow = "24h"
+	}
+
+	if ChallengeRoutes == "" {
+		ChallengeRoutes = "/feed/download,/invoices,/budgetinvoices"
+	}
+
+	if ChallengePowDifficulty == "" {
+		ChallengePowDifficulty = "20"
+	}
+
+	if ChallengeBypassAge == "" {
+		ChallengeBypassAge = "168h"
+	}
+
+	if SpamCreateLimit == "" {
+		SpamCreateLimit = "3"
+	}
+
+	if SpamCreateWindow == "" {
+		SpamCreateWindow = "1h"
+	}
+
+	if LegacyApiSunset == "" {
+		LegacyApiSunset = "2027-06-30"
+	}
+
+	if CompressionEncodings == "" {
+		CompressionEncodings = "br,gzip"
+	}
+
+	if CompressionMinSize == "" {
+		CompressionMinSize = "1024"
+	}
+
+	if CompressionLevel == "" {
+		CompressionLevel = "5"
+	}
+
+	if CompressionTypes == "" {
+		CompressionTypes = "application/json,text/plain,text/html,text/css,text/csv,text/xml,application/xml,application/rss+xml,application/javascript,image/svg+xml"
+	}
+
 	if S3FolderName == "" {
 		S3FolderName = "metrics"
 	}
@@ -101,6 +389,110 @@ func InitConfig() {
 	if S3Url == "" {
 		S3Url = "https://sphinx-tribes.s3.amazonaws.com"
 	}
+
+	if SmtpPort == "" {
+		SmtpPort = "587"
+	}
+
+	if SmtpFrom == "" {
+		SmtpFrom = SmtpUser
+	}
+
+	if InvoiceExpirySchedule == "" {
+		InvoiceExpirySchedule = "*/10 * * * *"
+	}
+
+	if TribeMemberCountSchedule == "" {
+		TribeMemberCountSchedule = "0 * * * *"
+	}
+
+	if FeedRefreshSchedule == "" {
+		FeedRefreshSchedule = "*/30 * * * *"
+	}
+
+	if BountyDeadlineSchedule == "" {
+		BountyDeadlineSchedule = "0 0 * * *"
+	}
+
+	if IdempotencyPurgeSchedule == "" {
+		IdempotencyPurgeSchedule = "30 0 * * *"
+	}
+
+	if ReputationSchedule == "" {
+		ReputationSchedule = "0 3 * * *"
+	}
+
+	if AccountPurgeSchedule == "" {
+		AccountPurgeSchedule = "0 4 * * *"
+	}
+
+	if BudgetAlertSchedule == "" {
+		BudgetAlertSchedule = "*/15 * * * *"
+	}
+
+	if InvoiceReconcileSchedule == "" {
+		InvoiceReconcileSchedule = "*/5 * * * *"
+	}
+
+	if PaymentRetrySchedule == "" {
+		PaymentRetrySchedule = "* * * * *"
+	}
+
+	if AssignmentExpirySchedule == "" {
+		AssignmentExpirySchedule = "*/30 * * * *"
+	}
+
+	if LeaderboardSchedule == "" {
+		LeaderboardSchedule = "*/15 * * * *"
+	}
+
+	if MetricsRollupSchedule == "" {
+		MetricsRollupSchedule = "0 2 * * *"
+	}
+
+	if SearchReindexSchedule == "" {
+		SearchReindexSchedule = "0 3 * * *"
+	}
+
+	if SearchSyncSchedule == "" {
+		SearchSyncSchedule = "* * * * *"
+	}
+
+	if MediaJobSchedule == "" {
+		MediaJobSchedule = "* * * * *"
+	}
+
+	if WorkflowJobSchedule == "" {
+		WorkflowJobSchedule = "* * * * *"
+	}
+
+	if WebhookJobSchedule == "" {
+		WebhookJobSchedule = "* * * * *"
+	}
+
+	if ImpersonationSchedule == "" {
+		ImpersonationSchedule = "* * * * *"
+	}
+
+	if WorkspaceTransferSchedule == "" {
+		WorkspaceTransferSchedule = "*/5 * * * *"
+	}
+
+	if RepositorySyncSchedule == "" {
+		RepositorySyncSchedule = "*/30 * * * *"
+	}
+
+	if AssignmentExpiryWarning == "" {
+		AssignmentExpiryWarning = "24h"
+	}
+
+	if StakworkTimeout == "" {
+		StakworkTimeout = "10s"
+	}
+
+	if StakworkRetries == "" {
+		StakworkRetries = "2"
+	}
 }
 
 func StripSuperAdmins(adminStrings string) []string {
@@ -80,3 +80,18 @@ func TestStripSuperAdmins(t *testing.T) {
 	admins2 := StripSuperAdmins(test2Admins)
 	assert.Equal(t, len(admins2), 2)
 }
+
+func TestInitCorsConfig(t *testing.T) {
+	os.Setenv("CORS_ALLOWED_ORIGINS", "https://people.sphinx.chat, https://community.sphinx.chat")
+	os.Setenv("CORS_ROUTE_ORIGINS", "/admin=https://admin.sphinx.chat;/feeds=*")
+	defer os.Unsetenv("CORS_ALLOWED_ORIGINS")
+	defer os.Unsetenv("CORS_ROUTE_ORIGINS")
+
+	InitCorsConfig()
+
+	assert.Equal(t, []string{"https://people.sphinx.chat", "https://community.sphinx.chat"}, CorsAllowedOrigins)
+	assert.Equal(t, []string{"https://admin.sphinx.chat"}, CorsRouteOrigins["/admin"])
+	assert.Equal(t, []string{"*"}, CorsRouteOrigins["/feeds"])
+	assert.Contains(t, CorsAllowedHeaders, "x-jwt")
+	assert.NotEmpty(t, ContentSecurityPolicy)
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CorsConfig is the shape of the optional CORS_CONFIG_FILE, env vars override its values
+type CorsConfig struct {
+	AllowedOrigins []string            `json:"allowed_origins"`
+	AllowedMethods []string            `json:"allowed_methods"`
+	AllowedHeaders []string            `json:"allowed_headers"`
+	RouteOrigins   map[string][]string `json:"route_origins"`
+}
+
+// cors and security header settings
+var CorsAllowedOrigins []string
+var CorsAllowedMethods []string
+var CorsAllowedHeaders []string
+
+// CorsRouteOrigins overrides the allowed origins for routes under a path prefix
+var CorsRouteOrigins map[string][]string
+var ContentSecurityPolicy string
+var HstsMaxAge string
+
+func InitCorsConfig() {
+	corsConfig := CorsConfig{}
+	if path := os.Getenv("CORS_CONFIG_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			err = json.Unmarshal(data, &corsConfig)
+		}
+		if err != nil {
+			fmt.Println("Could not load cors config file", err)
+		}
+	}
+
+	CorsAllowedOrigins = corsConfig.AllowedOrigins
+	CorsAllowedMethods = corsConfig.AllowedMethods
+	CorsAllowedHeaders = corsConfig.AllowedHeaders
+	CorsRouteOrigins = corsConfig.RouteOrigins
+
+	if origins := os.Getenv("CORS_ALLOWED_ORIGINS"); origins != "" {
+		CorsAllowedOrigins = SplitList(origins, ",")
+	}
+	if methods := os.Getenv("CORS_ALLOWED_METHODS"); methods != "" {
+		CorsAllowedMethods = SplitList(methods, ",")
+	}
+	if headers := os.Getenv("CORS_ALLOWED_HEADERS"); headers != "" {
+		CorsAllowedHeaders = SplitList(headers, ",")
+	}
+	// CORS_ROUTE_ORIGINS looks like "/admin=https://admin.sphinx.chat;/gobounties=*"
+	if routes := os.Getenv("CORS_ROUTE_ORIGINS"); routes != "" {
+		CorsRouteOrigins = map[string][]string{}
+		for _, route := range SplitList(routes, ";") {
+			prefix, origins, found := strings.Cut(route, "=")
+			if !found {
+				continue
+			}
+			CorsRouteOrigins[strings.TrimSpace(prefix)] = SplitList(origins, ",")
+		}
+	}
+	ContentSecurityPolicy = os.Getenv("CONTENT_SECURITY_POLICY")
+	HstsMaxAge = os.Getenv("HSTS_MAX_AGE")
+
+	if len(CorsAllowedOrigins) == 0 {
+		CorsAllowedOrigins = []string{"*"}
+	}
+	if len(CorsAllowedMethods) == 0 {
+		CorsAllowedMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	}
+	if len(CorsAllowedHeaders) == 0 {
+		CorsAllowedHeaders = []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-User", "authorization", "x-jwt", "Referer", "User-Agent", "Last-Event-ID", "Idempotency-Key", "x-api-key"}
+	}
+	if ContentSecurityPolicy == "" {
+		// allows the swagger UI assets from unpkg, everything else is same origin
+		ContentSecurityPolicy = "default-src 'self'; script-src 'self' 'unsafe-inline' https://unpkg.com; style-src 'self' 'unsafe-inline' https://unpkg.com; img-src 'self' data: https:; frame-ancestors 'none'"
+	}
+	if HstsMaxAge == "" {
+		HstsMaxAge = "31536000"
+	}
+}
+
+// SplitList splits a separated env value, dropping blank entries
+func SplitList(value string, sep string) []string {
+	list := []string{}
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,110 @@
+package db
+
+import (
+	"time"
+)
+
+// AccountPurgeDelay is the grace period between deleting an account and purging its data
+const AccountPurgeDelay = 30 * 24 * time.Hour
+
+func (db database) GetPersonExport(pubkey string) PersonExport {
+	now := time.Now()
+	export := PersonExport{
+		CreatedBounties:  []NewBounty{},
+		AssignedBounties: []NewBounty{},
+		Payments:         []NewPaymentHistory{},
+		Notifications:    []Notification{},
+		Activities:       []Activity{},
+		Endorsements:     []Endorsement{},
+		ApiKeys:          []ApiKey{},
+		Sessions:         []AuthSession{},
+		NostrIdentities:  []NostrIdentity{},
+		ExportedAt:       &now,
+	}
+
+	db.db.Where("owner_pub_key = ?", pubkey).Find(&export.Profile)
+	db.db.Where("owner_id = ?", pubkey).Order("created DESC").Find(&export.CreatedBounties)
+	db.db.Where("assignee = ?", pubkey).Order("created DESC").Find(&export.AssignedBounties)
+	db.db.Where("sender_pub_key = ? OR receiver_pub_key = ?", pubkey, pubkey).Order("created DESC").Find(&export.Payments)
+	db.db.Where("owner_pub_key = ?", pubkey).Order("created DESC").Find(&export.Notifications)
+	db.db.Where("owner_pub_key = ?", pubkey).Order("created DESC").Find(&export.Activities)
+	db.db.Where("person_pub_key = ? OR endorser_pub_key = ?", pubkey, pubkey).Order("created DESC").Find(&export.Endorsements)
+	db.db.Where("owner_pub_key = ?", pubkey).Order("created DESC").Find(&export.ApiKeys)
+	db.db.Where("owner_pub_key = ?", pubkey).Order("created DESC").Find(&export.Sessions)
+	db.db.Where("owner_pub_key = ?", pubkey).Order("created DESC").Find(&export.NostrIdentities)
+	return export
+}
+
+// AnonymizePerson strips everything identifying from a person row, the pubkey is kept so the purge can find the data
+func (db database) AnonymizePerson(pubkey string) error {
+	now := time.Now()
+	return db.db.Model(&Person{}).Where("owner_pub_key = ?", pubkey).Updates(map[string]interface{}{
+		"owner_alias":       "Deleted user",
+		"unique_name":       "",
+		"description":       "",
+		"img":               "",
+		"tags":              "{}",
+		"owner_route_hint":  "",
+		"owner_contact_key": "",
+		"price_to_meet":     0,
+		"extras":            "{}",
+		"github_issues":     "{}",
+		"unlisted":          true,
+		"deleted":           true,
+		"updated":           &now,
+	}).Error
+}
+
+func (db database) RevokeAllAuthSessions(pubkey string) error {
+	now := time.Now()
+	return db.db.Model(&AuthSession{}).Where("owner_pub_key = ?", pubkey).Updates(map[string]interface{}{
+		"revoked": true,
+		"updated": &now,
+	}).Error
+}
+
+func (db database) RevokeAllApiKeys(pubkey string) error {
+	now := time.Now()
+	return db.db.Model(&ApiKey{}).Where("owner_pub_key = ?", pubkey).Updates(map[string]interface{}{
+		"revoked": true,
+		"updated": &now,
+	}).Error
+}
+
+func (db database) ScheduleAccountPurge(pubkey string) (AccountPurge, error) {
+	now := time.Now()
+	purgeAfter := now.Add(AccountPurgeDelay)
+	m := AccountPurge{}
+	db.db.Where("owner_pub_key = ?", pubkey).Find(&m)
+	m.OwnerPubKey = pubkey
+	m.PurgeAfter = &purgeAfter
+	m.Created = &now
+
+	if err := db.db.Save(&m).Error; err != nil {
+		return AccountPurge{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetDueAccountPurges() []AccountPurge {
+	ms := []AccountPurge{}
+	db.db.Where("purge_after < ?", time.Now()).Find(&ms)
+	return ms
+}
+
+// PurgeAccountData removes the data kept about a deleted account, bounties and payments
+// stay since workspaces need them for their books, but they only reference the anonymized pubkey
+func (db database) PurgeAccountData(pubkey string) error {
+	tx := db.db.Begin()
+	for _, model := range []interface{}{&Notification{}, &NotificationSettings{}, &Activity{}, &AuthSession{}, &ApiKey{}, &NostrIdentity{}, &IdempotencyKey{}} {
+		if err := tx.Where("owner_pub_key = ?", pubkey).Delete(model).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := tx.Where("owner_pub_key = ?", pubkey).Delete(&AccountPurge{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+func (db database) AddActivity(m Activity) (Activity, error) {
+	now := time.Now()
+	m.Created = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return Activity{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetActivitiesByPubkey(pubkey string, r *http.Request) []Activity {
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+
+	ms := []Activity{}
+	query := db.db.Where("owner_pub_key = ?", pubkey)
+	if limit > 1 {
+		query = query.Offset(offset).Limit(limit)
+	}
+	query.Order("created DESC").Find(&ms)
+	return ms
+}
+
+func (db database) GetActivitiesCount(pubkey string) int64 {
+	var count int64
+	db.db.Model(&Activity{}).Where("owner_pub_key = ?", pubkey).Count(&count)
+	return count
+}
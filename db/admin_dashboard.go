@@ -0,0 +1,80 @@
+package db
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// GetAdminDashboard counts the activity on the platform since a time and the moderation queues
+func (db database) GetAdminDashboard(since time.Time) AdminDashboard {
+	m := AdminDashboard{Since: since}
+
+	db.db.Model(&Person{}).Where("created >= ?", since).Count(&m.NewPeople)
+	db.db.Model(&Tribe{}).Where("created >= ?", since).Count(&m.TribesCreated)
+	db.db.Model(&Bot{}).Where("created >= ?", since).Count(&m.BotsCreated)
+
+	// bounties keep their created time as unix seconds
+	db.db.Model(&NewBounty{}).Where("created >= ?", since.Unix()).Count(&m.BountiesPosted)
+	db.db.Model(&NewBounty{}).Where("created >= ?", since.Unix()).Select("COALESCE(SUM(price), 0)").Row().Scan(&m.BountySatsPosted)
+	db.db.Model(&NewBounty{}).Where("paid = ? AND paid_date >= ?", true, since).Count(&m.BountiesPaid)
+	db.db.Model(&NewBounty{}).Where("paid = ? AND paid_date >= ?", true, since).Select("COALESCE(SUM(price), 0)").Row().Scan(&m.BountySatsPaid)
+	db.db.Model(&PaymentAttempt{}).Where("status = ? AND created >= ?", PaymentAttemptFailed, since).Count(&m.PaymentFailures)
+
+	db.db.Model(&ContentReport{}).Where("status = ?", ReportOpen).Count(&m.OpenReports)
+	db.db.Model(&ContentAppeal{}).Where("status = ?", AppealOpen).Count(&m.OpenAppeals)
+	var tribes, bots int64
+	db.db.Model(&Tribe{}).Where("pending_review = ? AND deleted = ?", true, false).Count(&tribes)
+	db.db.Model(&Bot{}).Where("pending_review = ? AND deleted = ?", true, false).Count(&bots)
+	m.PendingReviews = tribes + bots
+	db.db.Model(&BountyDispute{}).Where("status = ?", DisputeOpen).Count(&m.OpenDisputes)
+	db.db.Model(&PubkeyBan{}).Where(activeBanCondition).Count(&m.ActiveBans)
+
+	return m
+}
+
+// GetRecentErrors merges the failed payments, webhook deliveries, stakwork jobs and media jobs
+// since a time, newest first
+func (db database) GetRecentErrors(since time.Time, limit int) []AdminError {
+	errs := []AdminError{}
+
+	payments := []PaymentAttempt{}
+	db.db.Where("status = ? AND created >= ?", PaymentAttemptFailed, since).Order("created DESC").Limit(limit).Find(&payments)
+	for _, p := range payments {
+		errs = append(errs, AdminError{Source: "payment", Ref: strconv.FormatUint(uint64(p.BountyId), 10), Error: p.Error, Created: p.Created})
+	}
+
+	deliveries := []WebhookDelivery{}
+	db.db.Where("status = ? AND updated >= ?", WebhookDeliveryFailed, since).Order("updated DESC").Limit(limit).Find(&deliveries)
+	for _, d := range deliveries {
+		errs = append(errs, AdminError{Source: "webhook", Ref: d.Uuid, Error: d.Error, Created: d.Updated})
+	}
+
+	jobs := []StakworkJob{}
+	db.db.Where("status = ? AND updated >= ?", StakworkJobFailed, since).Order("updated DESC").Limit(limit).Find(&jobs)
+	for _, j := range jobs {
+		errs = append(errs, AdminError{Source: "stakwork", Ref: j.Uuid, Error: j.Error, Created: j.Updated})
+	}
+
+	media := []MediaJob{}
+	db.db.Where("status = ? AND updated >= ?", MediaJobFailed, since).Order("updated DESC").Limit(limit).Find(&media)
+	for _, j := range media {
+		errs = append(errs, AdminError{Source: "media", Ref: j.Key, Error: j.Error, Created: j.Updated})
+	}
+
+	sortAdminErrors(errs)
+	if len(errs) > limit {
+		errs = errs[:limit]
+	}
+	return errs
+}
+
+// sortAdminErrors orders errors newest first, errors without a time go last
+func sortAdminErrors(errs []AdminError) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[j].Created == nil {
+			return errs[i].Created != nil
+		}
+		return errs[i].Created != nil && errs[i].Created.After(*errs[j].Created)
+	})
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"time"
+)
+
+func (db database) GetWorkspaceAiSettings(workspace_uuid string) WorkspaceAiSettings {
+	settings := WorkspaceAiSettings{}
+	db.db.Where("workspace_uuid = ?", workspace_uuid).Find(&settings)
+	settings.WorkspaceUuid = workspace_uuid
+	return settings
+}
+
+func (db database) UpdateWorkspaceAiSettings(settings WorkspaceAiSettings) (WorkspaceAiSettings, error) {
+	existing := db.GetWorkspaceAiSettings(settings.WorkspaceUuid)
+
+	now := time.Now()
+	settings.ID = existing.ID
+	settings.Created = existing.Created
+	settings.Updated = &now
+	if settings.Created == nil {
+		settings.Created = &now
+	}
+
+	if err := db.db.Save(&settings).Error; err != nil {
+		return WorkspaceAiSettings{}, err
+	}
+	return settings, nil
+}
@@ -92,32 +92,39 @@ func (db database) ProcessAlerts(p Person) {
 		return
 	}
 
-	client := http.Client{}
-
 	for _, per := range people {
 		action.Pubkey = per.OwnerPubKey
-		buf, err := json.Marshal(action)
-		if err != nil {
-			fmt.Println("Ticket alerts: Unable to parse message into byte buffer", err)
-			return
-		}
-		request, err := http.NewRequest("POST", relayUrl, bytes.NewReader(buf))
-		if err != nil {
-			fmt.Println("Ticket alerts: Unable to create a request to send to relay", err)
-			return
-		}
-
-		mac := hmac.New(sha256.New, []byte(alertSecret))
-		mac.Write(buf)
-		hmac256Byte := mac.Sum(nil)
-		hmac256Hex := "sha256=" + hex.EncodeToString(hmac256Byte)
-		request.Header.Set("x-hub-signature-256", hmac256Hex)
-		request.Header.Set("Content-Type", "application/json")
-		_, err = client.Do(request)
-		if err != nil {
-			fmt.Println("Ticket alerts: Unable to communicate request to relay", err)
+		if err := SendBotAction(relayUrl, alertSecret, action); err != nil {
+			fmt.Println("Ticket alerts:", err)
 		}
 	}
 
 	return
 }
+
+// SendBotAction posts an action to the relay bot endpoint signed with the alert secret
+func SendBotAction(relayUrl string, alertSecret string, action Action) error {
+	buf, err := json.Marshal(action)
+	if err != nil {
+		return fmt.Errorf("unable to parse message into byte buffer: %w", err)
+	}
+	request, err := http.NewRequest("POST", relayUrl, bytes.NewReader(buf))
+	if err != nil {
+		return fmt.Errorf("unable to create a request to send to relay: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(alertSecret))
+	mac.Write(buf)
+	hmac256Byte := mac.Sum(nil)
+	hmac256Hex := "sha256=" + hex.EncodeToString(hmac256Byte)
+	request.Header.Set("x-hub-signature-256", hmac256Hex)
+	request.Header.Set("Content-Type", "application/json")
+
+	client := http.Client{}
+	res, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("unable to communicate request to relay: %w", err)
+	}
+	res.Body.Close()
+	return nil
+}
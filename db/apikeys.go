@@ -0,0 +1,52 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+func (db database) CreateApiKey(m ApiKey) (ApiKey, error) {
+	now := time.Now()
+	m.Created = &now
+	m.Updated = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return ApiKey{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetApiKeysByPubkey(pubkey string) []ApiKey {
+	ms := []ApiKey{}
+	db.db.Where("owner_pub_key = ?", pubkey).Where("revoked = ?", false).Order("created DESC").Find(&ms)
+	return ms
+}
+
+func (db database) GetApiKeyByHash(keyHash string) (ApiKey, error) {
+	m := ApiKey{}
+	db.db.Where("key_hash = ?", keyHash).Where("revoked = ?", false).Find(&m)
+	if m.ID == 0 {
+		return m, errors.New("no api key found")
+	}
+	return m, nil
+}
+
+func (db database) UpdateApiKeyLastUsed(id uint) {
+	now := time.Now()
+	db.db.Model(&ApiKey{}).Where("id = ?", id).Update("last_used", &now)
+}
+
+func (db database) RevokeApiKey(pubkey string, uuid string) error {
+	now := time.Now()
+	result := db.db.Model(&ApiKey{}).Where("owner_pub_key = ?", pubkey).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"revoked": true,
+		"updated": &now,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("no api key found")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+// AssignmentExpiry is when the assignment of a bounty runs out, the earliest of its deadline and
+// stale_after past the assignment. It is nil when neither is set
+func (b NewBounty) AssignmentExpiry() *time.Time {
+	var expiry *time.Time
+	if b.Deadline != nil {
+		deadline := *b.Deadline
+		expiry = &deadline
+	}
+	if b.StaleAfter != "" && b.AssignedDate != nil {
+		if staleAfter, err := time.ParseDuration(b.StaleAfter); err == nil {
+			stale := b.AssignedDate.Add(staleAfter)
+			if expiry == nil || stale.Before(*expiry) {
+				expiry = &stale
+			}
+		}
+	}
+	return expiry
+}
+
+// ExpiryWarned is true when the current assignee was already warned of the expiry
+func (b NewBounty) ExpiryWarned() bool {
+	return b.ExpiryWarnedAt != nil && (b.AssignedDate == nil || b.ExpiryWarnedAt.After(*b.AssignedDate))
+}
+
+// GetAssignedBountiesWithExpiry lists the assigned, unpaid bounties with a deadline or a stale_after
+func (db database) GetAssignedBountiesWithExpiry() []NewBounty {
+	ms := []NewBounty{}
+	db.db.Where("paid = ?", false).Where("assignee <> ''").Where("(deadline IS NOT NULL OR stale_after <> '')").Find(&ms)
+	return ms
+}
+
+func (db database) MarkExpiryWarned(bountyId uint) error {
+	now := time.Now()
+	return db.db.Model(&NewBounty{}).Where("id = ?", bountyId).Update("expiry_warned_at", &now).Error
+}
+
+// UnassignExpiredBounty opens an expired bounty again, it fails when the bounty was reassigned meanwhile
+func (db database) UnassignExpiredBounty(b NewBounty) error {
+	now := time.Now()
+	result := db.db.Model(&NewBounty{}).Where("id = ? AND assignee = ? AND paid = ?", b.ID, b.Assignee, false).Updates(map[string]interface{}{
+		"assignee":         "",
+		"assigned_date":    nil,
+		"proof_status":     "",
+		"expiry_warned_at": nil,
+		"updated":          &now,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("bounty was reassigned")
+	}
+	return nil
+}
+
+// ExtendBountyAssignment moves the deadline and the stale_after of a bounty, the assignee is warned again
+func (db database) ExtendBountyAssignment(bountyId uint, deadline *time.Time, staleAfter string) error {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"expiry_warned_at": nil,
+		"updated":          &now,
+	}
+	if deadline != nil {
+		updates["deadline"] = deadline
+	}
+	if staleAfter != "" {
+		updates["stale_after"] = staleAfter
+	}
+	return db.db.Model(&NewBounty{}).Where("id = ?", bountyId).Updates(updates).Error
+}
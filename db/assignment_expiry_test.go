@@ -0,0 +1,29 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignmentExpiry(t *testing.T) {
+	assigned := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+	deadline := time.Date(2025, time.March, 5, 9, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, NewBounty{AssignedDate: &assigned}.AssignmentExpiry())
+	assert.Equal(t, deadline, *NewBounty{AssignedDate: &assigned, Deadline: &deadline}.AssignmentExpiry())
+	assert.Equal(t, assigned.Add(24*time.Hour), *NewBounty{AssignedDate: &assigned, Deadline: &deadline, StaleAfter: "24h"}.AssignmentExpiry())
+	assert.Equal(t, deadline, *NewBounty{AssignedDate: &assigned, Deadline: &deadline, StaleAfter: "96h"}.AssignmentExpiry())
+	assert.Nil(t, NewBounty{StaleAfter: "24h"}.AssignmentExpiry())
+}
+
+func TestExpiryWarned(t *testing.T) {
+	assigned := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+	before := assigned.Add(-time.Hour)
+	after := assigned.Add(time.Hour)
+
+	assert.False(t, NewBounty{AssignedDate: &assigned}.ExpiryWarned())
+	assert.False(t, NewBounty{AssignedDate: &assigned, ExpiryWarnedAt: &before}.ExpiryWarned())
+	assert.True(t, NewBounty{AssignedDate: &assigned, ExpiryWarnedAt: &after}.ExpiryWarned())
+}
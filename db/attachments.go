@@ -0,0 +1,36 @@
+package db
+
+import (
+	"time"
+)
+
+func (db database) CreateAttachment(attachment Attachment) (Attachment, error) {
+	now := time.Now()
+	attachment.Created = &now
+	if err := db.db.Create(&attachment).Error; err != nil {
+		return Attachment{}, err
+	}
+	return attachment, nil
+}
+
+func (db database) GetAttachments(parentType AttachmentParent, parentId string) []Attachment {
+	ms := []Attachment{}
+	db.db.Where("parent_type = ? AND parent_id = ?", parentType, parentId).Order("id ASC").Find(&ms)
+	return ms
+}
+
+func (db database) CountAttachments(parentType AttachmentParent, parentId string) int64 {
+	var count int64
+	db.db.Model(&Attachment{}).Where("parent_type = ? AND parent_id = ?", parentType, parentId).Count(&count)
+	return count
+}
+
+func (db database) GetAttachment(id uint) Attachment {
+	m := Attachment{}
+	db.db.Where("id = ?", id).First(&m)
+	return m
+}
+
+func (db database) DeleteAttachment(id uint) error {
+	return db.db.Where("id = ?", id).Delete(&Attachment{}).Error
+}
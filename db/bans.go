@@ -0,0 +1,78 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+// activeBanCondition matches the bans that are not lifted and have not expired
+const activeBanCondition = "lifted_at IS NULL AND (expires_at IS NULL OR expires_at > now())"
+
+// notBanned keeps the content of banned pubkeys out of listings, column is the owner pubkey column
+func notBanned(column string) string {
+	return column + " NOT IN (SELECT pub_key FROM pubkey_bans WHERE " + activeBanCondition + ")"
+}
+
+// CreatePubkeyBan bans a pubkey, it fails when the pubkey already has an active ban
+func (db database) CreatePubkeyBan(m PubkeyBan) (PubkeyBan, error) {
+	if db.GetActivePubkeyBan(m.PubKey).ID != 0 {
+		return PubkeyBan{}, errors.New("pubkey is already banned")
+	}
+
+	now := time.Now()
+	m.ID = 0
+	m.LiftedBy = ""
+	m.LiftReason = ""
+	m.LiftedAt = nil
+	m.Created = &now
+	if err := db.db.Create(&m).Error; err != nil {
+		return PubkeyBan{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetActivePubkeyBan(pubkey string) PubkeyBan {
+	m := PubkeyBan{}
+	db.db.Where("pub_key = ?", pubkey).Where(activeBanCondition).Order("created DESC").Limit(1).Find(&m)
+	return m
+}
+
+// GetActivePubkeyBans lists every active ban
+func (db database) GetActivePubkeyBans() []PubkeyBan {
+	ms := []PubkeyBan{}
+	db.db.Where(activeBanCondition).Order("created DESC").Find(&ms)
+	return ms
+}
+
+// GetPubkeyBans is the audit of the bans of one pubkey, or of every pubkey when it is empty, newest first
+func (db database) GetPubkeyBans(pubkey string) []PubkeyBan {
+	ms := []PubkeyBan{}
+	query := db.db.Order("created DESC")
+	if pubkey != "" {
+		query = query.Where("pub_key = ?", pubkey)
+	}
+	query.Find(&ms)
+	return ms
+}
+
+// LiftPubkeyBan lifts the active ban of a pubkey, keeping who lifted it and why
+func (db database) LiftPubkeyBan(pubkey string, liftedBy string, reason string) (PubkeyBan, error) {
+	ban := db.GetActivePubkeyBan(pubkey)
+	if ban.ID == 0 {
+		return PubkeyBan{}, errors.New("pubkey is not banned")
+	}
+
+	now := time.Now()
+	ban.LiftedBy = liftedBy
+	ban.LiftReason = reason
+	ban.LiftedAt = &now
+	err := db.db.Model(&PubkeyBan{}).Where("id = ?", ban.ID).Updates(map[string]interface{}{
+		"lifted_by":   ban.LiftedBy,
+		"lift_reason": ban.LiftReason,
+		"lifted_at":   ban.LiftedAt,
+	}).Error
+	if err != nil {
+		return PubkeyBan{}, err
+	}
+	return ban, nil
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"time"
+)
+
+func (db database) GetBotCategories() []BotCategory {
+	ms := []BotCategory{}
+	db.db.Order("position ASC, name ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetBotCategory(slug string) BotCategory {
+	m := BotCategory{}
+	db.db.Where("slug = ?", slug).Find(&m)
+	return m
+}
+
+func (db database) CreateOrEditBotCategory(m BotCategory) (BotCategory, error) {
+	existing := db.GetBotCategory(m.Slug)
+
+	now := time.Now()
+	m.ID = existing.ID
+	m.Created = existing.Created
+	m.Updated = &now
+	if m.Created == nil {
+		m.Created = &now
+	}
+
+	if err := db.db.Save(&m).Error; err != nil {
+		return BotCategory{}, err
+	}
+	return m, nil
+}
+
+// DeleteBotCategory removes a category and takes it off its bots
+func (db database) DeleteBotCategory(slug string) error {
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&Bot{}).Where("category = ?", slug).Update("category", "").Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("slug = ?", slug).Delete(&BotCategory{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// GetBotsByCategory returns the listed bots of a category, the featured and best rated first
+func (db database) GetBotsByCategory(slug string, limit int, offset int) []Bot {
+	ms := []Bot{}
+	db.db.Where("category = ? AND (unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)", slug).
+		Order("featured DESC, rating DESC, review_count DESC").
+		Limit(limit).Offset(offset).
+		Find(&ms)
+	return ms
+}
+
+func (db database) GetFeaturedBots() []Bot {
+	ms := []Bot{}
+	db.db.Where("featured = ? AND (unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)", true).
+		Order("rating DESC, review_count DESC").
+		Find(&ms)
+	return ms
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (db database) GetBotReview(botUuid string, pubkey string) BotReview {
+	review := BotReview{}
+	db.db.Where("bot_uuid = ? AND reviewer_pub_key = ?", botUuid, pubkey).Find(&review)
+	return review
+}
+
+func (db database) GetBotReviews(botUuid string, limit int, offset int) []BotReview {
+	ms := []BotReview{}
+	db.db.Where("bot_uuid = ?", botUuid).Order("updated DESC").Limit(limit).Offset(offset).Find(&ms)
+	return ms
+}
+
+// UpsertBotReview saves the review of a person for a bot, replacing their previous one, and
+// updates the rating of the bot
+func (db database) UpsertBotReview(review BotReview) (BotReview, error) {
+	existing := db.GetBotReview(review.BotUuid, review.ReviewerPubKey)
+
+	now := time.Now()
+	review.ID = existing.ID
+	review.Created = existing.Created
+	review.Updated = &now
+	if review.Created == nil {
+		review.Created = &now
+	}
+
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return BotReview{}, err
+	}
+	if err := tx.Save(&review).Error; err != nil {
+		tx.Rollback()
+		return BotReview{}, err
+	}
+	if err := updateBotRating(tx, review.BotUuid); err != nil {
+		tx.Rollback()
+		return BotReview{}, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return BotReview{}, err
+	}
+	return review, nil
+}
+
+func (db database) DeleteBotReview(botUuid string, pubkey string) error {
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	if err := tx.Where("bot_uuid = ? AND reviewer_pub_key = ?", botUuid, pubkey).Delete(&BotReview{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := updateBotRating(tx, botUuid); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// updateBotRating stores the average rating and the review count on the bot, so listing and
+// searching bots can return and sort by them
+func updateBotRating(tx *gorm.DB, botUuid string) error {
+	return tx.Exec(`UPDATE bots SET
+		rating = (SELECT COALESCE(AVG(rating), 0) FROM bot_reviews WHERE bot_uuid = ?),
+		review_count = (SELECT COUNT(*) FROM bot_reviews WHERE bot_uuid = ?)
+		WHERE uuid = ?`, botUuid, botUuid, botUuid).Error
+}
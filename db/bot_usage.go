@@ -0,0 +1,76 @@
+package db
+
+import (
+	"time"
+)
+
+// botEarningsMonths is how many months of usage the earnings of a bot go back
+const botEarningsMonths = 12
+
+func (db database) CreateBotUsage(usage BotUsage) (BotUsage, error) {
+	now := time.Now()
+	usage.PayoutId = 0
+	usage.Created = &now
+	if err := db.db.Create(&usage).Error; err != nil {
+		return BotUsage{}, err
+	}
+	return usage, nil
+}
+
+// GetBotMonthlyEarnings sums the usage of a bot per month, latest month first
+func (db database) GetBotMonthlyEarnings(botUuid string) []BotMonthlyEarnings {
+	ms := []BotMonthlyEarnings{}
+	db.db.Model(&BotUsage{}).
+		Select(`to_char(created, 'YYYY-MM') AS month, SUM(messages) AS messages, SUM(amount) AS amount,
+			SUM(CASE WHEN payout_id > 0 THEN amount ELSE 0 END) AS paid`).
+		Where("bot_uuid = ?", botUuid).
+		Group("month").
+		Order("month DESC").
+		Limit(botEarningsMonths).
+		Scan(&ms)
+	return ms
+}
+
+// GetBotUnpaidUsage returns the sats owed to the owner of a bot and the last usage they cover
+func (db database) GetBotUnpaidUsage(botUuid string) (uint, uint) {
+	var amount, lastId uint
+	db.db.Model(&BotUsage{}).
+		Select("COALESCE(SUM(amount), 0), COALESCE(MAX(id), 0)").
+		Where("bot_uuid = ? AND payout_id = 0", botUuid).
+		Row().Scan(&amount, &lastId)
+	return amount, lastId
+}
+
+// SettleBotUsage records a payout, a successful one settles the unpaid usage up to lastUsageId
+func (db database) SettleBotUsage(payout BotPayout, lastUsageId uint) (BotPayout, error) {
+	now := time.Now()
+	payout.Created = &now
+
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return BotPayout{}, err
+	}
+	if err := tx.Create(&payout).Error; err != nil {
+		tx.Rollback()
+		return BotPayout{}, err
+	}
+	if payout.Status == BotPayoutSucceeded {
+		err := tx.Model(&BotUsage{}).
+			Where("bot_uuid = ? AND payout_id = 0 AND id <= ?", payout.BotUuid, lastUsageId).
+			Update("payout_id", payout.ID).Error
+		if err != nil {
+			tx.Rollback()
+			return BotPayout{}, err
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return BotPayout{}, err
+	}
+	return payout, nil
+}
+
+func (db database) GetBotPayouts(botUuid string, limit int) []BotPayout {
+	ms := []BotPayout{}
+	db.db.Where("bot_uuid = ?", botUuid).Order("created DESC").Limit(limit).Find(&ms)
+	return ms
+}
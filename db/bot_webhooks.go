@@ -0,0 +1,57 @@
+package db
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// CreateOrEditBotWebhook saves a webhook of a bot, a new one gets its uuid and signing secret
+func (db database) CreateOrEditBotWebhook(m BotWebhook) (BotWebhook, error) {
+	now := time.Now()
+	if m.Uuid == "" {
+		m.Uuid = xid.New().String()
+		m.Secret = utils.GetRandomToken(32)
+		m.Created = &now
+	} else {
+		existing := db.GetBotWebhook(m.Uuid)
+		m.ID = existing.ID
+		m.Secret = existing.Secret
+		m.Created = existing.Created
+	}
+	m.Updated = &now
+	if err := db.db.Save(&m).Error; err != nil {
+		return BotWebhook{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetBotWebhook(uuid string) BotWebhook {
+	m := BotWebhook{}
+	db.db.Where("uuid = ?", uuid).Find(&m)
+	return m
+}
+
+func (db database) GetBotWebhooks(botUuid string) []BotWebhook {
+	ms := []BotWebhook{}
+	db.db.Where("bot_uuid = ?", botUuid).Order("id ASC").Find(&ms)
+	return ms
+}
+
+func (db database) DeleteBotWebhook(uuid string) error {
+	return db.db.Where("uuid = ?", uuid).Delete(&BotWebhook{}).Error
+}
+
+// GetBotWebhooksForEvent returns the enabled webhooks subscribed to an event, those limited to
+// a tribe only for the events of that tribe. An event of one bot only goes to its own webhooks
+func (db database) GetBotWebhooksForEvent(event string, tribeUuid string, botUuid string) []BotWebhook {
+	ms := []BotWebhook{}
+	query := db.db.Where("enabled = ? AND ? = ANY(events)", true, event).
+		Where("(tribe_uuid = '' OR tribe_uuid IS NULL OR tribe_uuid = ?)", tribeUuid)
+	if botUuid != "" {
+		query = query.Where("bot_uuid = ?", botUuid)
+	}
+	query.Find(&ms)
+	return ms
+}
@@ -0,0 +1,51 @@
+package db
+
+// StreamWorkspaceBounties calls fn with each bounty of a workspace matching the filter, oldest first,
+// reading them one at a time so a large export is never held in memory
+func (db database) StreamWorkspaceBounties(workspaceUuid string, filter BountyExportFilter, fn func(NewBounty) error) error {
+	query := db.db.Model(&NewBounty{}).Where("workspace_uuid = ?", workspaceUuid)
+
+	switch filter.Status {
+	case "open":
+		query = query.Where("(assignee = '' OR assignee IS NULL) AND paid = ?", false)
+	case "assigned":
+		query = query.Where("assignee <> '' AND paid = ? AND completed = ?", false, false)
+	case "completed":
+		query = query.Where("completed = ? AND paid = ?", true, false)
+	case "paid":
+		query = query.Where("paid = ?", true)
+	}
+
+	if filter.Status == "paid" {
+		if filter.From != nil {
+			query = query.Where("paid_date >= ?", filter.From)
+		}
+		if filter.To != nil {
+			query = query.Where("paid_date < ?", filter.To)
+		}
+	} else {
+		if filter.From != nil {
+			query = query.Where("created >= ?", filter.From.Unix())
+		}
+		if filter.To != nil {
+			query = query.Where("created < ?", filter.To.Unix())
+		}
+	}
+
+	rows, err := query.Order("created ASC").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		bounty := NewBounty{}
+		if err := db.db.ScanRows(rows, &bounty); err != nil {
+			return err
+		}
+		if err := fn(bounty); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"github.com/lib/pq"
+)
+
+// BountyFeedFilter narrows the bounties of the feed, the empty fields do not filter
+type BountyFeedFilter struct {
+	WorkspaceUuid string
+	Languages     []string
+	MinPrice      uint
+}
+
+// GetBountyFeed returns the newest public bounties that match a filter
+func (db database) GetBountyFeed(filter BountyFeedFilter, limit int) []NewBounty {
+	ms := []NewBounty{}
+	query := db.db.Where("show != false").Where(notBanned("owner_id"))
+	if filter.WorkspaceUuid != "" {
+		query = query.Where("workspace_uuid = ?", filter.WorkspaceUuid)
+	}
+	if len(filter.Languages) > 0 {
+		query = query.Where("coding_languages && ?", pq.StringArray(filter.Languages))
+	}
+	if filter.MinPrice > 0 {
+		query = query.Where("price >= ?", filter.MinPrice)
+	}
+	query.Order("created DESC").Limit(limit).Find(&ms)
+	return ms
+}
@@ -0,0 +1,16 @@
+package db
+
+// CreateBounties stores the bounties of an import together, none of them is stored when one fails
+func (db database) CreateBounties(bounties []NewBounty) ([]NewBounty, error) {
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+	for i := range bounties {
+		if err := tx.Create(&bounties[i]).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	return bounties, tx.Commit().Error
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+func (db database) GetWorkspaceBudgetSettings(workspace_uuid string) WorkspaceBudgetSettings {
+	settings := WorkspaceBudgetSettings{}
+	db.db.Where("workspace_uuid = ?", workspace_uuid).Find(&settings)
+	settings.WorkspaceUuid = workspace_uuid
+	return settings
+}
+
+func (db database) UpdateWorkspaceBudgetSettings(settings WorkspaceBudgetSettings) (WorkspaceBudgetSettings, error) {
+	existing := db.GetWorkspaceBudgetSettings(settings.WorkspaceUuid)
+
+	now := time.Now()
+	settings.ID = existing.ID
+	settings.Created = existing.Created
+	settings.LowBalanceAlerted = existing.LowBalanceAlerted
+	settings.Updated = &now
+	if settings.Created == nil {
+		settings.Created = &now
+	}
+
+	if err := db.db.Save(&settings).Error; err != nil {
+		return WorkspaceBudgetSettings{}, err
+	}
+	return settings, nil
+}
+
+// GetLowBalanceAlertSettings returns the settings of every workspace with a low balance threshold
+func (db database) GetLowBalanceAlertSettings() []WorkspaceBudgetSettings {
+	ms := []WorkspaceBudgetSettings{}
+	db.db.Where("low_balance_threshold > 0").Find(&ms)
+	return ms
+}
+
+func (db database) SetLowBalanceAlerted(workspace_uuid string, alerted bool) error {
+	return db.db.Model(&WorkspaceBudgetSettings{}).Where("workspace_uuid = ?", workspace_uuid).Update("low_balance_alerted", alerted).Error
+}
+
+// GetWorkspaceSpentSince sums the bounty payments of a workspace since a time, only to the receiver when one is given
+func (db database) GetWorkspaceSpentSince(workspace_uuid string, receiver string, since time.Time) uint {
+	var spent uint
+	query := db.db.Model(&NewPaymentHistory{}).
+		Where("workspace_uuid = ?", workspace_uuid).
+		Where("payment_type = ?", Payment).
+		Where("status = true").
+		Where("created >= ?", since)
+	if receiver != "" {
+		query = query.Where("receiver_pub_key = ?", receiver)
+	}
+	query.Select("COALESCE(SUM(amount), 0)").Row().Scan(&spent)
+	return spent
+}
+
+// SpendingCapError checks a payment against the daily caps of a workspace, a cap of 0 is no cap
+func SpendingCapError(settings WorkspaceBudgetSettings, spentToday uint, spentToReceiverToday uint, amount uint) error {
+	if settings.DailyCap > 0 && spentToday+amount > settings.DailyCap {
+		return fmt.Errorf("payment exceeds the workspace daily spending cap of %d sats", settings.DailyCap)
+	}
+	if settings.PerUserDailyCap > 0 && spentToReceiverToday+amount > settings.PerUserDailyCap {
+		return fmt.Errorf("payment exceeds the daily spending cap of %d sats per user", settings.PerUserDailyCap)
+	}
+	return nil
+}
+
+// CheckSpendingCaps returns an error when paying amount to the receiver would go over a workspace cap
+func (db database) CheckSpendingCaps(workspace_uuid string, receiver string, amount uint) error {
+	settings := db.GetWorkspaceBudgetSettings(workspace_uuid)
+	if settings.DailyCap == 0 && settings.PerUserDailyCap == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	return SpendingCapError(
+		settings,
+		db.GetWorkspaceSpentSince(workspace_uuid, "", startOfDay),
+		db.GetWorkspaceSpentSince(workspace_uuid, receiver, startOfDay),
+		amount,
+	)
+}
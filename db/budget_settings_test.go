@@ -0,0 +1,25 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpendingCapError(t *testing.T) {
+	t.Run("Should test that payments are allowed without caps", func(t *testing.T) {
+		assert.NoError(t, SpendingCapError(WorkspaceBudgetSettings{}, 100000, 100000, 5000))
+	})
+
+	t.Run("Should test that the daily cap is enforced", func(t *testing.T) {
+		settings := WorkspaceBudgetSettings{DailyCap: 10000}
+		assert.NoError(t, SpendingCapError(settings, 5000, 0, 5000))
+		assert.Error(t, SpendingCapError(settings, 5001, 0, 5000))
+	})
+
+	t.Run("Should test that the per user daily cap is enforced", func(t *testing.T) {
+		settings := WorkspaceBudgetSettings{DailyCap: 10000, PerUserDailyCap: 3000}
+		assert.NoError(t, SpendingCapError(settings, 2000, 1000, 2000))
+		assert.Error(t, SpendingCapError(settings, 2000, 2000, 2000))
+	})
+}
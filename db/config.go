@@ -71,9 +71,75 @@ func InitDB() {
 	db.AutoMigrate(&WorkspaceFeatures{})
 	db.AutoMigrate(&FeaturePhase{})
 	db.AutoMigrate(&FeatureStory{})
+	db.AutoMigrate(&Notification{})
+	db.AutoMigrate(&NotificationSettings{})
+	db.AutoMigrate(&IdempotencyKey{})
+	db.AutoMigrate(&ApiKey{})
+	db.AutoMigrate(&AuthSession{})
+	db.AutoMigrate(&NostrIdentity{})
+	db.AutoMigrate(&SuperAdmin{})
+	db.AutoMigrate(&Activity{})
+	db.AutoMigrate(&Deletion{})
+	db.AutoMigrate(&Endorsement{})
+	db.AutoMigrate(&AccountPurge{})
+	db.AutoMigrate(&LedgerEntry{})
+	db.AutoMigrate(&WorkspaceBudgetSettings{})
+	db.AutoMigrate(&PaymentAttempt{})
+	db.AutoMigrate(&BountyDispute{})
+	db.AutoMigrate(&DisputeEvidence{})
+	db.AutoMigrate(&BountyEscrow{})
+	db.AutoMigrate(&BountyProof{})
+	db.AutoMigrate(&BountyWorkSession{})
+	db.AutoMigrate(&SavedSearch{})
+	db.AutoMigrate(&LeaderboardEntry{})
+	db.AutoMigrate(&MetricsDailyRollup{})
+	db.AutoMigrate(&MetricsHunterRollup{})
+	db.AutoMigrate(&MetricsProviderRollup{})
+	db.AutoMigrate(&SearchIndexChange{})
+	db.AutoMigrate(&MediaJob{})
+	db.AutoMigrate(&Attachment{})
+	db.AutoMigrate(&TicketComment{})
+	db.AutoMigrate(&TicketLink{})
+	db.AutoMigrate(&TicketRevision{})
+	db.AutoMigrate(&TicketCard{})
+	db.AutoMigrate(&FeatureDependency{})
+	db.AutoMigrate(&PhaseBudget{})
+	db.AutoMigrate(&Workflow{})
+	db.AutoMigrate(&WorkflowExecution{})
+	db.AutoMigrate(&WorkspaceAiSettings{})
+	db.AutoMigrate(&StakworkJob{})
+	db.AutoMigrate(&BotUsage{})
+	db.AutoMigrate(&BotPayout{})
+	db.AutoMigrate(&BotReview{})
+	db.AutoMigrate(&WebhookDelivery{})
+	db.AutoMigrate(&BotWebhook{})
+	db.AutoMigrate(&BotCategory{})
+	db.AutoMigrate(&TribeMember{})
+	db.AutoMigrate(&TribeBan{})
+	db.AutoMigrate(&TribeInvite{})
+	db.AutoMigrate(&TribeInviteRedemption{})
+	db.AutoMigrate(&TribeActivity{})
+	db.AutoMigrate(&TribeRankingSettings{})
+	db.AutoMigrate(&TribeVerification{})
+	db.AutoMigrate(&WorkspaceCalendarToken{})
+	db.AutoMigrate(&ContentReport{})
+	db.AutoMigrate(&ContentAppeal{})
+	db.AutoMigrate(&PubkeyBan{})
+	db.AutoMigrate(&Impersonation{})
+	db.AutoMigrate(&ImpersonatedRequest{})
+	db.AutoMigrate(&WorkspaceTemplate{})
+	db.AutoMigrate(&WorkspaceTransfer{})
+	db.AutoMigrate(&WorkspaceAuditLog{})
+	db.AutoMigrate(&WorkspaceIntegration{})
+	db.AutoMigrate(&RepositoryCommit{})
+	db.AutoMigrate(&RepositoryPullRequest{})
 
 	DB.MigrateTablesWithOrgUuid()
 	DB.MigrateOrganizationToWorkspace()
+	DB.CreatePeopleSearchIndexes()
+	DB.CreateBountyRecommendationIndexes()
+	DB.CreateSearchIndexes()
+	DB.CreateLedgerViews()
 
 	people := DB.GetAllPeople()
 	for _, p := range people {
@@ -85,18 +151,19 @@ func InitDB() {
 }
 
 const (
-	EditOrg        = "EDIT ORGANIZATION"
-	AddBounty      = "ADD BOUNTY"
-	UpdateBounty   = "UPDATE BOUNTY"
-	DeleteBounty   = "DELETE BOUNTY"
-	PayBounty      = "PAY BOUNTY"
-	AddUser        = "ADD USER"
-	UpdateUser     = "UPDATE USER"
-	DeleteUser     = "DELETE USER"
-	AddRoles       = "ADD ROLES"
-	AddBudget      = "ADD BUDGET"
-	WithdrawBudget = "WITHDRAW BUDGET"
-	ViewReport     = "VIEW REPORT"
+	EditOrg         = "EDIT ORGANIZATION"
+	AddBounty       = "ADD BOUNTY"
+	UpdateBounty    = "UPDATE BOUNTY"
+	DeleteBounty    = "DELETE BOUNTY"
+	PayBounty       = "PAY BOUNTY"
+	AddUser         = "ADD USER"
+	UpdateUser      = "UPDATE USER"
+	DeleteUser      = "DELETE USER"
+	AddRoles        = "ADD ROLES"
+	AddBudget       = "ADD BUDGET"
+	WithdrawBudget  = "WITHDRAW BUDGET"
+	ViewReport      = "VIEW REPORT"
+	ResolveDisputes = "RESOLVE DISPUTES"
 )
 
 var ConfigBountyRoles []BountyRoles = []BountyRoles{
@@ -136,6 +203,9 @@ var ConfigBountyRoles []BountyRoles = []BountyRoles{
 	{
 		Name: ViewReport,
 	},
+	{
+		Name: ResolveDisputes,
+	},
 }
 
 var ManageBountiesGroup = []string{AddBounty, UpdateBounty, DeleteBounty, PayBounty}
@@ -198,6 +268,9 @@ func (db database) MigrateTablesWithOrgUuid() {
 		} else {
 			db.db.AutoMigrate(&NewPaymentHistory{})
 		}
+	} else if db.db.Migrator().HasColumn(NewPaymentHistory{}, "workspace_uuid") {
+		// picks up the columns added to the payment history since it was migrated
+		db.db.AutoMigrate(&NewPaymentHistory{})
 	}
 	if !db.db.Migrator().HasTable("invoice_list") {
 		if !db.db.Migrator().HasColumn(InvoiceList{}, "workspace_uuid") {
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"time"
+)
+
+// GetCampaignConnectionCode hands out the newest code of a campaign, of any campaign when it is
+// "", that is neither used, revoked nor expired, and saves the ip and device it went to. The code
+// is taken in one statement so two requests can't be given the same code
+func (db database) GetCampaignConnectionCode(campaign string, ip string, device string) ConnectionCodesShort {
+	c := ConnectionCodesShort{}
+	now := time.Now()
+
+	campaignQuery := ""
+	args := []interface{}{now, ip, device, false, now}
+	if campaign != "" {
+		campaignQuery = "AND campaign = ?"
+		args = append(args, campaign)
+	}
+
+	db.db.Raw(`UPDATE connectioncodes SET is_used = true, redeemed_at = ?, redeemed_ip = ?, redeemed_device = ?
+	WHERE id = (SELECT id FROM connectioncodes WHERE is_used = ? AND revoked IS NOT TRUE
+	AND (expires_at IS NULL OR expires_at > ?) `+campaignQuery+`
+	ORDER BY id DESC LIMIT 1 FOR UPDATE SKIP LOCKED)
+	RETURNING connection_string, date_created`, args...).Scan(&c)
+
+	return c
+}
+
+// GetConnectionCodeCampaigns counts the codes of every campaign, the newest campaign first
+func (db database) GetConnectionCodeCampaigns() []ConnectionCodeCampaign {
+	ms := []ConnectionCodeCampaign{}
+	db.db.Raw(`SELECT COALESCE(campaign, '') AS campaign, COUNT(*) AS total,
+	COUNT(*) FILTER (WHERE is_used) AS redeemed,
+	COUNT(*) FILTER (WHERE NOT is_used AND revoked IS NOT TRUE AND expires_at <= NOW()) AS expired,
+	COUNT(*) FILTER (WHERE NOT is_used AND revoked IS TRUE) AS revoked,
+	COUNT(*) FILTER (WHERE NOT is_used AND revoked IS NOT TRUE AND (expires_at IS NULL OR expires_at > NOW())) AS available,
+	MIN(date_created) AS created, MAX(redeemed_at) AS last_redeemed
+	FROM connectioncodes GROUP BY COALESCE(campaign, '') ORDER BY created DESC`).Scan(&ms)
+
+	for i := range ms {
+		if ms[i].Total > 0 {
+			ms[i].RedemptionRate = float64(ms[i].Redeemed) / float64(ms[i].Total)
+		}
+	}
+	return ms
+}
+
+// GetCampaignConnectionCodes returns the codes of a campaign with when each was redeemed
+func (db database) GetCampaignConnectionCodes(campaign string) []ConnectionCodes {
+	ms := []ConnectionCodes{}
+	db.db.Where("COALESCE(campaign, '') = ?", campaign).Order("id ASC").Find(&ms)
+	return ms
+}
+
+// ConnectionCodeExists reports whether a connection string is one of the codes
+func (db database) ConnectionCodeExists(code string) bool {
+	var count int64
+	db.db.Model(&ConnectionCodes{}).Where("connection_string = ?", code).Count(&count)
+	return count > 0
+}
+
+// CountConnectionCodeRedemptions counts the codes handed out to an ip and to a device since a time
+func (db database) CountConnectionCodeRedemptions(ip string, device string, since time.Time) (int64, int64) {
+	var byIp, byDevice int64
+	if ip != "" {
+		db.db.Model(&ConnectionCodes{}).Where("redeemed_ip = ? AND redeemed_at >= ?", ip, since).Count(&byIp)
+	}
+	if device != "" {
+		db.db.Model(&ConnectionCodes{}).Where("redeemed_device = ? AND redeemed_at >= ?", device, since).Count(&byDevice)
+	}
+	return byIp, byDevice
+}
+
+// RevokeCampaignConnectionCodes stops the codes of a campaign that were not handed out yet from
+// being handed out, it returns how many were revoked
+func (db database) RevokeCampaignConnectionCodes(campaign string) (int64, error) {
+	result := db.db.Model(&ConnectionCodes{}).
+		Where("COALESCE(campaign, '') = ? AND is_used = ? AND revoked IS NOT TRUE", campaign, false).
+		Update("revoked", true)
+	return result.RowsAffected, result.Error
+}
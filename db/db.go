@@ -4,6 +4,7 @@
 package db
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -17,6 +18,7 @@ import (
 	"github.com/lib/pq"
 	_ "github.com/lib/pq"
 	"github.com/rs/xid"
+	"gorm.io/gorm"
 
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/utils"
@@ -268,7 +270,13 @@ func (db database) GetListedTribes(r *http.Request) []Tribe {
 	tags := keys.Get("tags") // this is a string of tags separated by commas
 	offset, limit, sortBy, direction, search := utils.GetPaginationParams(r)
 
-	thequery := db.db.Offset(offset).Limit(limit).Order(sortBy+" "+direction).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Where("LOWER(name) LIKE ?", "%"+search+"%")
+	// the directory is ranked unless the client sorts it itself
+	order := sortBy + " " + direction
+	if keys.Get("sortBy") == "" || sortBy == "rank" {
+		order = TribeRankingOrder(db.GetTribeRankingSettings())
+	}
+
+	thequery := db.db.Offset(offset).Limit(limit).Order(order).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Where(notBanned("owner_pub_key")).Where("LOWER(name) LIKE ?", "%"+search+"%")
 
 	if tags != "" {
 		// pull out the tags and add them in here
@@ -284,7 +292,7 @@ func (db database) GetListedTribes(r *http.Request) []Tribe {
 
 func (db database) GetTribesByOwner(pubkey string) []Tribe {
 	ms := []Tribe{}
-	db.db.Where("owner_pub_key = ? AND (unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)", pubkey).Find(&ms)
+	db.db.Where("owner_pub_key = ? AND (unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)", pubkey).Where(notBanned("owner_pub_key")).Find(&ms)
 	return ms
 }
 
@@ -300,12 +308,39 @@ func (db database) GetTribesByAppUrl(aurl string) []Tribe {
 	return ms
 }
 
+// GetChannelsByTribe returns the channels of a tribe in their order, without the archived ones
 func (db database) GetChannelsByTribe(tribe_uuid string) []Channel {
 	ms := []Channel{}
-	db.db.Where("tribe_uuid = ? AND (deleted = 'f' OR deleted is null)", tribe_uuid).Find(&ms)
+	db.db.Where("tribe_uuid = ? AND (deleted = 'f' OR deleted is null) AND (archived = 'f' OR archived is null)", tribe_uuid).Order("position ASC, id ASC").Find(&ms)
 	return ms
 }
 
+func (db database) GetArchivedChannelsByTribe(tribe_uuid string) []Channel {
+	ms := []Channel{}
+	db.db.Where("tribe_uuid = ? AND (deleted = 'f' OR deleted is null) AND archived = ?", tribe_uuid, true).Order("position ASC, id ASC").Find(&ms)
+	return ms
+}
+
+// ReorderChannels sets the position of the channels of a tribe to their index in ids
+func (db database) ReorderChannels(tribe_uuid string, ids []uint) error {
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	now := time.Now()
+	for position, id := range ids {
+		err := tx.Model(&Channel{}).Where("id = ? AND tribe_uuid = ?", id, tribe_uuid).Updates(map[string]interface{}{
+			"position": position,
+			"updated":  &now,
+		}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
 func (db database) GetChannel(id uint) Channel {
 	ms := Channel{}
 	db.db.Where("id = ?  AND (deleted = 'f' OR deleted is null)", id).Find(&ms)
@@ -317,7 +352,24 @@ func (db database) GetListedBots(r *http.Request) []Bot {
 	offset, limit, sortBy, direction, search := utils.GetPaginationParams(r)
 
 	// db.db.Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Find(&ms)
-	db.db.Offset(offset).Limit(limit).Order(sortBy+" "+direction).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Where("LOWER(name) LIKE ?", "%"+search+"%").Find(&ms)
+	order := sortBy + " " + direction
+	if sortBy == "rating" {
+		order = "rating " + direction + ", review_count " + direction
+	}
+	query := db.db.Offset(offset).Limit(limit).Order(order).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Where(notBanned("owner_pub_key")).Where("LOWER(name) LIKE ?", "%"+search+"%")
+	if r != nil {
+		keys := r.URL.Query()
+		if tags := SplitBotTags(keys.Get("tags")); len(tags) > 0 {
+			query = query.Where("tags @> ?", pq.StringArray(tags))
+		}
+		if category := keys.Get("category"); category != "" {
+			query = query.Where("category = ?", category)
+		}
+		if keys.Get("featured") == "true" {
+			query = query.Where("featured = ?", true)
+		}
+	}
+	query.Find(&ms)
 
 	return ms
 }
@@ -339,6 +391,7 @@ func (db database) GetListedPeople(r *http.Request) []Person {
 	orderQuery := ""
 	limitQuery := ""
 	searchQuery := ""
+	searchArgs := []interface{}{}
 
 	languageQuery := ""
 
@@ -350,28 +403,34 @@ func (db database) GetListedPeople(r *http.Request) []Person {
 	if limit > -1 {
 		limitQuery = fmt.Sprintf("LIMIT %d  OFFSET %d", limit, offset)
 	}
+	// the ORs are grouped so they can't bypass the listed, deleted and banned conditions
 	if search != "" {
-		searchQuery = fmt.Sprintf("AND LOWER(owner_alias) LIKE %[1]s OR LOWER(unique_name) LIKE %[1]s", "'%"+strings.ToLower(search)+"%'")
+		searchQuery = "AND (LOWER(owner_alias) LIKE ? OR LOWER(unique_name) LIKE ?)"
+		term := "%" + strings.ToLower(search) + "%"
+		searchArgs = append(searchArgs, term, term)
 	}
 
 	if languageLength > 0 {
-		for i, val := range languageArray {
+		languageClauses := []string{}
+		for _, val := range languageArray {
 			if val != "" {
-				if i == 0 {
-					languageQuery = "AND extras->'coding_languages' @> '[{\"label\": \"" + val + "\"}]'"
-				} else {
-					languageQuery += " OR extras->'coding_languages' @> '[{\"label\": \"" + val + "\"}]'"
-				}
+				label, _ := json.Marshal([]map[string]string{{"label": val}})
+				languageClauses = append(languageClauses, "extras->'coding_languages' @> ?::jsonb")
+				searchArgs = append(searchArgs, string(label))
 			}
 		}
-
+		if len(languageClauses) > 0 {
+			languageQuery = "AND (" + strings.Join(languageClauses, " OR ") + ")"
+		}
 	}
 
-	query := "SELECT * FROM people WHERE (unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)"
+	filterQuery, filterArgs := peopleFilterQuery(keys)
 
-	allQuery := query + " " + searchQuery + " " + languageQuery + " " + orderQuery + " " + limitQuery
+	query := "SELECT * FROM people WHERE (unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null) AND " + notBanned("owner_pub_key")
 
-	db.db.Raw(allQuery).Find(&ms)
+	allQuery := query + " " + searchQuery + " " + languageQuery + " " + filterQuery + " " + orderQuery + " " + limitQuery
+
+	db.db.Raw(allQuery, append(searchArgs, filterArgs...)...).Find(&ms)
 	return ms
 }
 
@@ -1219,7 +1278,7 @@ func (db database) GetAllBounties(r *http.Request) []NewBounty {
 		}
 	}
 
-	query := "SELECT * FROM public.bounty WHERE show != false"
+	query := "SELECT * FROM public.bounty WHERE show != false AND " + notBanned("owner_id")
 
 	allQuery := query + " " + statusQuery + " " + searchQuery + " " + workspaceQuery + " " + languageQuery + " " + phaseUuidQuery + " " + phasePriorityQuery + " " + orderQuery + " " + limitQuery
 
@@ -1477,7 +1536,20 @@ func (db database) SearchTribes(s string) []Tribe {
 	return ms
 }
 
-func (db database) SearchBots(s string, limit, offset int) []BotRes {
+// SplitBotTags reads a comma separated list of tags, skipping the empty ones
+func SplitBotTags(s string) []string {
+	tags := []string{}
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// SearchBots returns the bots matching a search and having all the tags, the best matches first
+// or the best rated when sortBy is "rating"
+func (db database) SearchBots(s string, limit, offset int, sortBy string, tags []string) []BotRes {
 	ms := []BotRes{}
 	if s == "" {
 		return ms
@@ -1486,13 +1558,21 @@ func (db database) SearchBots(s string, limit, offset int) []BotRes {
 	limitStr := strconv.Itoa(limit)
 	offsetStr := strconv.Itoa(offset)
 	s = strings.ReplaceAll(s, " ", " & ")
+	order := "rank DESC"
+	if sortBy == "rating" {
+		order = "rating DESC, review_count DESC, rank DESC"
+	}
+	if tags == nil {
+		tags = []string{}
+	}
 	db.db.Raw(
-		`SELECT uuid, owner_pub_key, name, unique_name, img, description, tags, price_per_use, ts_rank(tsv, q) as rank
+		`SELECT uuid, owner_pub_key, name, unique_name, img, description, tags, price_per_use, rating, review_count, category, featured, ts_rank(tsv, q) as rank
 		FROM bots, to_tsquery(?) q
 		WHERE tsv @@ q
+		AND tags @> ?
 		AND (deleted = 'f' OR deleted is null)
-		ORDER BY rank DESC 
-		LIMIT ? OFFSET ?;`, s, limitStr, offsetStr).Find(&ms)
+		ORDER BY `+order+`
+		LIMIT ? OFFSET ?;`, s, pq.StringArray(tags), limitStr, offsetStr).Find(&ms)
 	return ms
 }
 
@@ -1574,25 +1654,19 @@ func (db database) CreateConnectionCode(c []ConnectionCodes) ([]ConnectionCodes,
 		return nil, fmt.Errorf("no connection codes provided")
 	}
 	now := time.Now()
-	for _, code := range c {
-		if code.DateCreated == nil || code.DateCreated.IsZero() {
-			code.DateCreated = &now
+	for i := range c {
+		if c[i].DateCreated == nil || c[i].DateCreated.IsZero() {
+			c[i].DateCreated = &now
 		}
 	}
-	db.db.Create(&c)
+	if err := db.db.Create(&c).Error; err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
 func (db database) GetConnectionCode() ConnectionCodesShort {
-	c := ConnectionCodesShort{}
-
-	db.db.Raw(`SELECT connection_string, date_created FROM connectioncodes WHERE is_used =? ORDER BY id DESC LIMIT 1`, false).Find(&c)
-
-	db.db.Model(&ConnectionCodes{}).Where("connection_string = ?", c.ConnectionString).Updates(map[string]interface{}{
-		"is_used": true,
-	})
-
-	return c
+	return db.GetCampaignConnectionCode("", "", "")
 }
 
 func (db database) GetLnUser(lnKey string) int64 {
@@ -1713,6 +1787,13 @@ func (db database) GetInvoice(payment_request string) NewInvoiceList {
 	return ms
 }
 
+// GetUnsettledInvoices returns the invoices still waiting for a payment
+func (db database) GetUnsettledInvoices() []NewInvoiceList {
+	ms := []NewInvoiceList{}
+	db.db.Where("status = false").Find(&ms)
+	return ms
+}
+
 func (db database) UpdateInvoice(payment_request string) NewInvoiceList {
 	ms := NewInvoiceList{}
 	db.db.Model(&NewInvoiceList{}).Where("payment_request = ?", payment_request).Update("status", true)
@@ -1720,6 +1801,32 @@ func (db database) UpdateInvoice(payment_request string) NewInvoiceList {
 	return ms
 }
 
+// ErrInvoiceSettled is returned when another caller already claimed the settlement of an invoice
+var ErrInvoiceSettled = errors.New("the invoice was already settled")
+
+// claimInvoice marks an unsettled invoice as settled, only one of the callers settling the same
+// invoice at once gets it, the others get ErrInvoiceSettled
+func claimInvoice(tx *gorm.DB, payment_request string) error {
+	result := tx.Model(&NewInvoiceList{}).Where("payment_request = ? AND status = ?", payment_request, false).Update("status", true)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return ErrInvoiceSettled
+	}
+	return nil
+}
+
+// ClaimInvoice claims the settlement of an invoice before its payment is made
+func (db database) ClaimInvoice(payment_request string) error {
+	return claimInvoice(db.db, payment_request)
+}
+
+// ReleaseInvoice gives back a claimed invoice whose payment failed, so it is settled again later
+func (db database) ReleaseInvoice(payment_request string) error {
+	return db.db.Model(&NewInvoiceList{}).Where("payment_request = ?", payment_request).Update("status", false).Error
+}
+
 func (db database) AddInvoice(invoice NewInvoiceList) NewInvoiceList {
 	db.db.Create(&invoice)
 	return invoice
@@ -1804,3 +1911,17 @@ func (db database) DeleteAllBounties() error {
 	}
 	return nil
 }
+
+func (db database) GetOpenBountiesWithExpiry() []NewBounty {
+	ms := []NewBounty{}
+	db.db.Where("show = ?", true).Where("paid = ?", false).Where("completed = ?", false).Where("(assignee = '' OR assignee IS NULL)").Where("bounty_expires <> ''").Find(&ms)
+	return ms
+}
+
+func (db database) CloseBounty(created int64) error {
+	now := time.Now()
+	return db.db.Model(&NewBounty{}).Where("created = ?", created).Updates(map[string]interface{}{
+		"show":    false,
+		"updated": &now,
+	}).Error
+}
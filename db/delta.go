@@ -0,0 +1,55 @@
+package db
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+func (db database) AddDeletion(kind string, recordId string) error {
+	now := time.Now()
+	return db.db.Create(&Deletion{Kind: kind, RecordID: recordId, Deleted: &now}).Error
+}
+
+func (db database) GetDeletionsSince(kind string, since time.Time) []Deletion {
+	ms := []Deletion{}
+	db.db.Where("kind = ?", kind).Where("deleted > ?", since).Order("deleted ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetTribesUpdatedSince(since time.Time, r *http.Request) []Tribe {
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+
+	ms := []Tribe{}
+	query := db.db.Where("updated > ?", since).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)")
+	if limit > 1 {
+		query = query.Offset(offset).Limit(limit)
+	}
+	query.Order("updated ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetPeopleUpdatedSince(since time.Time, r *http.Request) []Person {
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+
+	ms := []Person{}
+	query := db.db.Where("updated > ?", since).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)")
+	if limit > 1 {
+		query = query.Offset(offset).Limit(limit)
+	}
+	query.Order("updated ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetBountiesUpdatedSince(since time.Time, r *http.Request) []NewBounty {
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+
+	ms := []NewBounty{}
+	query := db.db.Where("updated > ?", since).Where("show != false")
+	if limit > 1 {
+		query = query.Offset(offset).Limit(limit)
+	}
+	query.Order("updated ASC").Find(&ms)
+	return ms
+}
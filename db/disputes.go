@@ -0,0 +1,95 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+func (db database) CreateDispute(m BountyDispute) (BountyDispute, error) {
+	now := time.Now()
+	m.Uuid = xid.New().String()
+	m.Status = DisputeOpen
+	m.Created = &now
+	m.Updated = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return BountyDispute{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetDisputeByUuid(uuid string) BountyDispute {
+	m := BountyDispute{}
+	db.db.Where("uuid = ?", uuid).Find(&m)
+	return m
+}
+
+// GetOpenDispute returns the open dispute of a bounty, a bounty has at most one at a time
+func (db database) GetOpenDispute(bountyId uint) BountyDispute {
+	m := BountyDispute{}
+	db.db.Where("bounty_id = ? AND status = ?", bountyId, DisputeOpen).Find(&m)
+	return m
+}
+
+// GetBountyDisputes lists the disputes of a bounty with their evidence, newest first
+func (db database) GetBountyDisputes(bountyId uint) []BountyDispute {
+	ms := []BountyDispute{}
+	db.db.Where("bounty_id = ?", bountyId).Order("created DESC").Find(&ms)
+	for i := range ms {
+		ms[i].Evidence = db.GetDisputeEvidence(ms[i].ID)
+	}
+	return ms
+}
+
+// GetDisputes is the queue of disputes with a status, of one workspace or of every workspace
+// when the uuid is empty, oldest first
+func (db database) GetDisputes(workspaceUuid string, status DisputeStatus) []BountyDispute {
+	ms := []BountyDispute{}
+	query := db.db.Where("status = ?", status)
+	if workspaceUuid != "" {
+		query = query.Where("workspace_uuid = ?", workspaceUuid)
+	}
+	query.Order("created ASC").Find(&ms)
+	return ms
+}
+
+// CloseDispute stores the outcome of an open dispute, it fails when the dispute was closed meanwhile
+func (db database) CloseDispute(m BountyDispute) (BountyDispute, error) {
+	now := time.Now()
+	m.Updated = &now
+	m.ResolvedAt = &now
+
+	result := db.db.Model(&BountyDispute{}).Where("id = ? AND status = ?", m.ID, DisputeOpen).Updates(map[string]interface{}{
+		"status":      m.Status,
+		"outcome":     m.Outcome,
+		"resolution":  m.Resolution,
+		"resolved_by": m.ResolvedBy,
+		"resolved_at": m.ResolvedAt,
+		"updated":     m.Updated,
+	})
+	if result.Error != nil {
+		return BountyDispute{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return BountyDispute{}, errors.New("dispute is not open")
+	}
+	return m, nil
+}
+
+func (db database) AddDisputeEvidence(m DisputeEvidence) (DisputeEvidence, error) {
+	now := time.Now()
+	m.Created = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return DisputeEvidence{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetDisputeEvidence(disputeId uint) []DisputeEvidence {
+	ms := []DisputeEvidence{}
+	db.db.Where("dispute_id = ?", disputeId).Order("created ASC").Find(&ms)
+	return ms
+}
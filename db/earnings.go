@@ -0,0 +1,59 @@
+package db
+
+import "math"
+
+// GetPersonEarnings lists the bounty payments a person received, oldest first
+func (db database) GetPersonEarnings(pubkey string) []EarningRecord {
+	ms := []EarningRecord{}
+	db.db.Raw(`SELECT ph.id AS payment_id, ph.bounty_id, b.title AS bounty_title, ph.workspace_uuid,
+		w.name AS workspace_name, ph.sender_pub_key, ph.amount, ph.fiat, ph.created
+		FROM payment_histories ph
+		LEFT JOIN bounty b ON b.id = ph.bounty_id
+		LEFT JOIN workspaces w ON w.uuid = ph.workspace_uuid
+		WHERE ph.receiver_pub_key = ? AND ph.payment_type = ? AND ph.status = true
+		ORDER BY ph.created ASC`, pubkey, Payment).Scan(&ms)
+	return ms
+}
+
+// SummarizeEarnings totals the earnings by month and workspace, the records must be oldest first.
+// Fiat totals add up the value of each payment at receipt
+func SummarizeEarnings(records []EarningRecord) PersonEarnings {
+	earnings := PersonEarnings{Months: []MonthlyEarning{}}
+	index := map[string]int{}
+
+	for _, record := range records {
+		month := ""
+		if record.Created != nil {
+			month = record.Created.UTC().Format("2006-01")
+		}
+
+		key := month + "/" + record.WorkspaceUuid
+		i, ok := index[key]
+		if !ok {
+			i = len(earnings.Months)
+			index[key] = i
+			earnings.Months = append(earnings.Months, MonthlyEarning{
+				Month:         month,
+				WorkspaceUuid: record.WorkspaceUuid,
+				WorkspaceName: record.WorkspaceName,
+			})
+		}
+
+		earnings.Months[i].Amount += record.Amount
+		earnings.Months[i].Count++
+		earnings.Months[i].Fiat = addFiat(earnings.Months[i].Fiat, record.Fiat)
+		earnings.TotalAmount += record.Amount
+		earnings.TotalFiat = addFiat(earnings.TotalFiat, record.Fiat)
+	}
+	return earnings
+}
+
+func addFiat(total FiatAmounts, amounts FiatAmounts) FiatAmounts {
+	for currency, value := range amounts {
+		if total == nil {
+			total = FiatAmounts{}
+		}
+		total[currency] = math.Round((total[currency]+value)*100) / 100
+	}
+	return total
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeEarnings(t *testing.T) {
+	first := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	second := time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC)
+	third := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	earnings := SummarizeEarnings([]EarningRecord{
+		{WorkspaceUuid: "ws-1", Amount: 1000, Fiat: FiatAmounts{"USD": 0.65}, Created: &first},
+		{WorkspaceUuid: "ws-2", Amount: 500, Created: &first},
+		{WorkspaceUuid: "ws-1", Amount: 2000, Fiat: FiatAmounts{"USD": 1.4}, Created: &second},
+		{WorkspaceUuid: "ws-1", Amount: 100, Created: &third},
+	})
+
+	assert.Equal(t, uint(3600), earnings.TotalAmount)
+	assert.Equal(t, FiatAmounts{"USD": 2.05}, earnings.TotalFiat)
+	assert.Equal(t, []MonthlyEarning{
+		{Month: "2025-03", WorkspaceUuid: "ws-1", Amount: 3000, Count: 2, Fiat: FiatAmounts{"USD": 2.05}},
+		{Month: "2025-03", WorkspaceUuid: "ws-2", Amount: 500, Count: 1},
+		{Month: "2025-04", WorkspaceUuid: "ws-1", Amount: 100, Count: 1},
+	}, earnings.Months)
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"time"
+)
+
+func (db database) CreateEndorsement(m Endorsement) (Endorsement, error) {
+	now := time.Now()
+	m.Created = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return Endorsement{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetEndorsementByBountyId(bountyId uint) Endorsement {
+	m := Endorsement{}
+	db.db.Where("bounty_id = ?", bountyId).Find(&m)
+	return m
+}
+
+func (db database) GetEndorsementsByPubkey(pubkey string) []Endorsement {
+	ms := []Endorsement{}
+	db.db.Where("person_pub_key = ?", pubkey).Order("created DESC").Find(&ms)
+	return ms
+}
+
+func (db database) GetEndorsementSummary(pubkey string) EndorsementSummary {
+	summary := EndorsementSummary{}
+	db.db.Model(&Endorsement{}).Select("COUNT(*) AS count, COALESCE(AVG(rating), 0) AS average_rating").Where("person_pub_key = ?", pubkey).Scan(&summary)
+	return summary
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+func (db database) CreateBountyEscrow(m BountyEscrow) (BountyEscrow, error) {
+	now := time.Now()
+	m.Status = EscrowAwaitingFunding
+	m.Created = &now
+	m.Updated = &now
+
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return BountyEscrow{}, err
+	}
+	if err := tx.Create(&m).Error; err != nil {
+		tx.Rollback()
+		return BountyEscrow{}, err
+	}
+	if err := tx.Model(&NewBounty{}).Where("id = ?", m.BountyId).Update("escrow_status", m.Status).Error; err != nil {
+		tx.Rollback()
+		return BountyEscrow{}, err
+	}
+	return m, tx.Commit().Error
+}
+
+// GetBountyEscrow returns the latest escrow of a bounty
+func (db database) GetBountyEscrow(bountyId uint) BountyEscrow {
+	m := BountyEscrow{}
+	db.db.Where("bounty_id = ?", bountyId).Order("created DESC").Limit(1).Find(&m)
+	return m
+}
+
+// UpdateEscrowStatus moves an escrow and its bounty to the next status, it fails when the
+// escrow already left the expected status so concurrent releases and refunds can't both apply
+func (db database) UpdateEscrowStatus(m BountyEscrow, from EscrowStatus, to EscrowStatus) (BountyEscrow, error) {
+	now := time.Now()
+
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return BountyEscrow{}, err
+	}
+
+	result := tx.Model(&BountyEscrow{}).Where("id = ? AND status = ?", m.ID, from).Updates(map[string]interface{}{
+		"status":  to,
+		"updated": &now,
+	})
+	if result.Error != nil {
+		tx.Rollback()
+		return BountyEscrow{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return BountyEscrow{}, errors.New("escrow is not " + string(from))
+	}
+	if err := tx.Model(&NewBounty{}).Where("id = ?", m.BountyId).Update("escrow_status", to).Error; err != nil {
+		tx.Rollback()
+		return BountyEscrow{}, err
+	}
+
+	m.Status = to
+	m.Updated = &now
+	return m, tx.Commit().Error
+}
+
+// RecordEscrowPayout keeps the payment of a payout that went out, so recording it again never pays twice
+func (db database) RecordEscrowPayout(m BountyEscrow, paymentHash string, preimage string) (BountyEscrow, error) {
+	result := db.db.Model(&BountyEscrow{}).Where("id = ? AND status = ?", m.ID, EscrowPaying).Updates(map[string]interface{}{
+		"payout_hash":     paymentHash,
+		"payout_preimage": preimage,
+	})
+	if result.Error != nil {
+		return m, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return m, errors.New("escrow is not " + string(EscrowPaying))
+	}
+
+	m.PayoutHash = paymentHash
+	m.PayoutPreimage = preimage
+	return m, nil
+}
+
+// ProcessEscrowPayment records the payout of an escrow that is being paid, the workspace budget is left
+// as it is because the escrow was funded by its own invoice
+func (db database) ProcessEscrowPayment(payment NewPaymentHistory, bounty NewBounty, escrow BountyEscrow) error {
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+
+	result := tx.Model(&BountyEscrow{}).Where("id = ? AND status = ?", escrow.ID, EscrowPaying).Updates(map[string]interface{}{
+		"status":  EscrowReleased,
+		"updated": payment.Updated,
+	})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errors.New("escrow is not " + string(EscrowPaying))
+	}
+
+	if err := tx.Create(&payment).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	bounty.EscrowStatus = EscrowReleased
+	if err := tx.Where("created", bounty.Created).Updates(&bounty).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,31 @@
+package db
+
+import (
+	"time"
+)
+
+func (db database) CreateFeatureDependency(dependency FeatureDependency) (FeatureDependency, error) {
+	now := time.Now()
+	dependency.Created = &now
+	if err := db.db.Create(&dependency).Error; err != nil {
+		return FeatureDependency{}, err
+	}
+	return dependency, nil
+}
+
+// GetFeatureDependencies returns what a feature or a phase depends on
+func (db database) GetFeatureDependencies(itemType RoadmapItemType, itemUuid string) []FeatureDependency {
+	ms := []FeatureDependency{}
+	db.db.Where("item_type = ? AND item_uuid = ?", itemType, itemUuid).Order("id ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetFeatureDependency(id uint) FeatureDependency {
+	m := FeatureDependency{}
+	db.db.Where("id = ?", id).First(&m)
+	return m
+}
+
+func (db database) DeleteFeatureDependency(id uint) error {
+	return db.db.Where("id = ?", id).Delete(&FeatureDependency{}).Error
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// FiatAmounts is the value of an amount of sats in each configured fiat currency, stored
+// when the amount is set or paid so old records keep the rate of their time
+type FiatAmounts map[string]float64
+
+// Value ...
+func (f FiatAmounts) Value() (driver.Value, error) {
+	if len(f) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan ...
+func (f *FiatAmounts) Scan(src interface{}) error {
+	if src == nil {
+		*f = nil
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("type assertion .([]byte) failed")
+	}
+	return json.Unmarshal(source, f)
+}
+
+// FiatQuote converts sats at the current exchange rates, main sets it to the rates service
+var FiatQuote func(sats uint) map[string]float64
+
+// FiatAt is the value of an amount at the current rates, nil when no rates are available
+func FiatAt(sats uint) FiatAmounts {
+	if FiatQuote == nil || sats == 0 {
+		return nil
+	}
+	return FiatAmounts(FiatQuote(sats))
+}
@@ -0,0 +1,32 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiatAt(t *testing.T) {
+	defer func() { FiatQuote = nil }()
+
+	assert.Nil(t, FiatAt(1000))
+
+	FiatQuote = func(sats uint) map[string]float64 {
+		return map[string]float64{"USD": float64(sats) / 1000}
+	}
+	assert.Equal(t, FiatAmounts{"USD": 1}, FiatAt(1000))
+	assert.Nil(t, FiatAt(0))
+}
+
+func TestFiatAmountsValue(t *testing.T) {
+	value, err := FiatAmounts{}.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	value, err = FiatAmounts{"USD": 0.65}.Value()
+	assert.NoError(t, err)
+
+	scanned := FiatAmounts{}
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, FiatAmounts{"USD": 0.65}, scanned)
+}
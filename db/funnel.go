@@ -0,0 +1,110 @@
+package db
+
+import (
+	"sort"
+	"time"
+)
+
+// GetBountyFunnelRows returns the stage timestamps of the bounties created in a date range,
+// the proof stage being the first proof submitted on the bounty
+func (db database) GetBountyFunnelRows(r PaymentDateRange, workspace string) []BountyFunnelRow {
+	rows := []BountyFunnelRow{}
+
+	query := `SELECT b.id, b.created, b.assignee, b.assigned_date, b.paid, b.paid_date,
+	(SELECT MIN(p.created) FROM bounty_proofs p WHERE p.bounty_id = b.id) AS proof_submitted
+	FROM bounty b WHERE b.created >= ? AND b.created <= ?`
+	args := []interface{}{r.StartDate, r.EndDate}
+
+	if workspace != "" {
+		query += " AND b.workspace_uuid = ?"
+		args = append(args, workspace)
+	}
+
+	db.db.Raw(query, args...).Scan(&rows)
+	return rows
+}
+
+// SummarizeBountyFunnel counts the bounties that reached each stage and the median
+// seconds they spent in it before moving on, the paid stage being the last one
+func SummarizeBountyFunnel(rows []BountyFunnelRow) BountyFunnel {
+	var posted, assigned, proofs, paid int64
+	var inPosted, inAssigned, inProof []int64
+
+	for _, row := range rows {
+		created := time.Unix(row.Created, 0)
+		posted++
+
+		if row.Assignee != "" || row.AssignedDate != nil || row.Paid {
+			assigned++
+		}
+		if row.AssignedDate != nil {
+			inPosted = appendDuration(inPosted, created, *row.AssignedDate)
+		}
+
+		if row.ProofSubmitted != nil {
+			proofs++
+			if row.AssignedDate != nil {
+				inAssigned = appendDuration(inAssigned, *row.AssignedDate, *row.ProofSubmitted)
+			}
+		}
+
+		if row.Paid {
+			paid++
+			if row.PaidDate == nil {
+				continue
+			}
+			if row.ProofSubmitted != nil {
+				inProof = appendDuration(inProof, *row.ProofSubmitted, *row.PaidDate)
+			} else if row.AssignedDate != nil {
+				inAssigned = appendDuration(inAssigned, *row.AssignedDate, *row.PaidDate)
+			}
+		}
+	}
+
+	return BountyFunnel{
+		Stages: []BountyFunnelStage{
+			funnelStage(FunnelPosted, posted, posted, inPosted),
+			funnelStage(FunnelAssigned, assigned, posted, inAssigned),
+			funnelStage(FunnelProofSubmitted, proofs, posted, inProof),
+			funnelStage(FunnelPaid, paid, posted, nil),
+		},
+	}
+}
+
+func funnelStage(stage FunnelStage, count int64, posted int64, durations []int64) BountyFunnelStage {
+	var percentage uint
+	if posted != 0 {
+		percentage = uint(count * 100 / posted)
+	}
+	return BountyFunnelStage{
+		Stage:         stage,
+		Count:         count,
+		Percentage:    percentage,
+		MedianSeconds: MedianSeconds(durations),
+	}
+}
+
+// appendDuration skips the durations of timestamps that are out of order, e.g. a bounty
+// reassigned after its proof was submitted
+func appendDuration(durations []int64, from time.Time, to time.Time) []int64 {
+	if to.Before(from) {
+		return durations
+	}
+	return append(durations, int64(to.Sub(from).Seconds()))
+}
+
+// MedianSeconds returns the median of a list of durations, 0 if it is empty
+func MedianSeconds(durations []int64) int64 {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := append([]int64{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedianSeconds(t *testing.T) {
+	assert.Equal(t, int64(0), MedianSeconds(nil))
+	assert.Equal(t, int64(20), MedianSeconds([]int64{30, 10, 20}))
+	assert.Equal(t, int64(25), MedianSeconds([]int64{40, 10, 30, 20}))
+}
+
+func TestSummarizeBountyFunnel(t *testing.T) {
+	created := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	assigned := created.Add(time.Hour)
+	paid := assigned.Add(3 * time.Hour)
+	stale := created.Add(-time.Hour)
+
+	funnel := SummarizeBountyFunnel([]BountyFunnelRow{
+		// paid without a proof, the assigned stage lasts until payment
+		{Created: created.Unix(), Assignee: "alice", AssignedDate: &assigned, Paid: true, PaidDate: &paid},
+		// assigned before it was created, the duration is skipped
+		{Created: created.Unix(), Assignee: "bob", AssignedDate: &stale},
+		{Created: created.Unix()},
+		{Created: created.Unix()},
+	})
+
+	assert.Equal(t, []BountyFunnelStage{
+		{Stage: FunnelPosted, Count: 4, Percentage: 100, MedianSeconds: 3600},
+		{Stage: FunnelAssigned, Count: 2, Percentage: 50, MedianSeconds: 10800},
+		{Stage: FunnelProofSubmitted, Count: 0, Percentage: 0},
+		{Stage: FunnelPaid, Count: 1, Percentage: 25},
+	}, funnel.Stages)
+}
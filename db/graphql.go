@@ -0,0 +1,71 @@
+package db
+
+// The queries below load many records of a kind at once for the batching of the GraphQL api,
+// records that are not found are left out
+
+func (db database) GetPeopleByPubkeys(pubkeys []string) []Person {
+	ms := []Person{}
+	db.db.Where("owner_pub_key IN (?) AND (deleted = ? OR deleted IS NULL)", pubkeys, false).Find(&ms)
+	return ms
+}
+
+func (db database) GetWorkspacesByUuids(uuids []string) []Workspace {
+	ms := []Workspace{}
+	db.db.Where("uuid IN (?) AND (deleted = ? OR deleted IS NULL)", uuids, false).Find(&ms)
+	return ms
+}
+
+func (db database) GetTribesByUuids(uuids []string) []Tribe {
+	ms := []Tribe{}
+	db.db.Where("uuid IN (?) AND (deleted = ? OR deleted IS NULL)", uuids, false).Find(&ms)
+	return ms
+}
+
+func (db database) GetBountiesByIds(ids []uint) []NewBounty {
+	ms := []NewBounty{}
+	db.db.Where("id IN (?)", ids).Find(&ms)
+	return ms
+}
+
+func (db database) GetBountyProofsByBountyIds(ids []uint) []BountyProof {
+	ms := []BountyProof{}
+	db.db.Where("bounty_id IN (?)", ids).Order("created DESC").Find(&ms)
+	return ms
+}
+
+func (db database) GetFeaturesByUuids(uuids []string) []WorkspaceFeatures {
+	ms := []WorkspaceFeatures{}
+	db.db.Where("uuid IN (?)", uuids).Find(&ms)
+	return ms
+}
+
+func (db database) GetFeaturesByWorkspaceUuids(uuids []string) []WorkspaceFeatures {
+	ms := []WorkspaceFeatures{}
+	db.db.Where("workspace_uuid IN (?)", uuids).Order("priority ASC, created ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetPhasesByUuids(uuids []string) []FeaturePhase {
+	ms := []FeaturePhase{}
+	db.db.Where("uuid IN (?)", uuids).Find(&ms)
+	return ms
+}
+
+func (db database) GetPhasesByFeatureUuids(uuids []string) []FeaturePhase {
+	ms := []FeaturePhase{}
+	db.db.Where("feature_uuid IN (?)", uuids).Order("priority ASC, created ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetTicketCardsByPhaseUuids(uuids []string) []TicketCard {
+	ms := []TicketCard{}
+	db.db.Where("phase_uuid IN (?)", uuids).Order("lane, rank").Find(&ms)
+	return ms
+}
+
+// GetListedWorkspaceBounties returns a page of the listed bounties of a workspace, newest first
+func (db database) GetListedWorkspaceBounties(workspaceUuid string, limit int, offset int) []NewBounty {
+	ms := []NewBounty{}
+	db.db.Where("workspace_uuid = ? AND show = ?", workspaceUuid, true).Order("created DESC").Limit(limit).Offset(offset).Find(&ms)
+	return ms
+}
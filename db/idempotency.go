@@ -0,0 +1,50 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+// IdempotencyKeyTTL is how long a stored response is replayed for the same key
+var IdempotencyKeyTTL = 24 * time.Hour
+
+func (db database) GetIdempotencyKey(key string, pubkey string) (IdempotencyKey, error) {
+	m := IdempotencyKey{}
+	db.db.Where("key = ?", key).Where("owner_pub_key = ?", pubkey).Where("created > ?", time.Now().Add(-IdempotencyKeyTTL)).Find(&m)
+	if m.ID == 0 {
+		return m, errors.New("no idempotency key found")
+	}
+	return m, nil
+}
+
+// CreateIdempotencyKey reserves the key before the request runs, it fails if the key is already taken
+func (db database) CreateIdempotencyKey(m IdempotencyKey) (IdempotencyKey, error) {
+	now := time.Now()
+	m.Created = &now
+	m.Updated = &now
+
+	// an expired key can be reused
+	db.db.Where("key = ?", m.Key).Where("owner_pub_key = ?", m.OwnerPubKey).Where("created <= ?", now.Add(-IdempotencyKeyTTL)).Delete(&IdempotencyKey{})
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return IdempotencyKey{}, err
+	}
+	return m, nil
+}
+
+func (db database) UpdateIdempotencyKeyResponse(id uint, statusCode int, response string) error {
+	now := time.Now()
+	return db.db.Model(&IdempotencyKey{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status_code": statusCode,
+		"response":    response,
+		"updated":     &now,
+	}).Error
+}
+
+func (db database) DeleteIdempotencyKey(id uint) error {
+	return db.db.Where("id = ?", id).Delete(&IdempotencyKey{}).Error
+}
+
+func (db database) DeleteExpiredIdempotencyKeys() error {
+	return db.db.Where("created <= ?", time.Now().Add(-IdempotencyKeyTTL)).Delete(&IdempotencyKey{}).Error
+}
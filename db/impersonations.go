@@ -0,0 +1,79 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+func (db database) CreateImpersonation(m Impersonation) (Impersonation, error) {
+	now := time.Now()
+	m.Uuid = xid.New().String()
+	m.EndedAt = nil
+	m.Notified = false
+	m.Created = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return Impersonation{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetImpersonationByUuid(uuid string) Impersonation {
+	m := Impersonation{}
+	db.db.Where("uuid = ?", uuid).Find(&m)
+	return m
+}
+
+// GetImpersonations lists the impersonations of a target pubkey, or every impersonation when it is
+// empty, newest first
+func (db database) GetImpersonations(targetPubkey string, limit int) []Impersonation {
+	ms := []Impersonation{}
+	query := db.db.Order("created DESC").Limit(limit)
+	if targetPubkey != "" {
+		query = query.Where("target_pubkey = ?", targetPubkey)
+	}
+	query.Find(&ms)
+	return ms
+}
+
+// EndImpersonation ends an impersonation before it expires, it fails when it already ended
+func (db database) EndImpersonation(uuid string) (Impersonation, error) {
+	now := time.Now()
+	result := db.db.Model(&Impersonation{}).Where("uuid = ? AND ended_at IS NULL AND expires_at > ?", uuid, now).Update("ended_at", &now)
+	if result.Error != nil {
+		return Impersonation{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return Impersonation{}, errors.New("impersonation has already ended")
+	}
+	return db.GetImpersonationByUuid(uuid), nil
+}
+
+func (db database) CreateImpersonatedRequest(m ImpersonatedRequest) error {
+	now := time.Now()
+	m.Created = &now
+	return db.db.Create(&m).Error
+}
+
+// GetImpersonatedRequests is the audit log of an impersonation, oldest first
+func (db database) GetImpersonatedRequests(impersonationUuid string) []ImpersonatedRequest {
+	ms := []ImpersonatedRequest{}
+	db.db.Where("impersonation_uuid = ?", impersonationUuid).Order("created ASC").Find(&ms)
+	return ms
+}
+
+// GetEndedImpersonations returns the impersonations that ended or expired and whose target was not
+// notified yet
+func (db database) GetEndedImpersonations(now time.Time) []Impersonation {
+	ms := []Impersonation{}
+	db.db.Where("notified = ? AND (ended_at IS NOT NULL OR expires_at <= ?)", false, now).Order("created ASC").Find(&ms)
+	return ms
+}
+
+// ClaimImpersonationNotification marks an impersonation notified, false when it was already
+func (db database) ClaimImpersonationNotification(id uint) bool {
+	result := db.db.Model(&Impersonation{}).Where("id = ? AND notified = ?", id, false).Update("notified", true)
+	return result.Error == nil && result.RowsAffected == 1
+}
@@ -52,6 +52,7 @@ type Database interface {
 	GetBounty(id uint) NewBounty
 	UpdateBounty(b NewBounty) (NewBounty, error)
 	UpdateBountyPayment(b NewBounty) (NewBounty, error)
+	UpdateBountyCompleted(b NewBounty) (NewBounty, error)
 	GetListedOffers(r *http.Request) ([]PeopleExtra, error)
 	UpdateBot(uuid string, u map[string]interface{}) bool
 	GetAllTribes() []Tribe
@@ -69,7 +70,7 @@ type Database interface {
 	GetBotByUniqueName(un string) Bot
 	GetPersonByUniqueName(un string) Person
 	SearchTribes(s string) []Tribe
-	SearchBots(s string, limit int, offset int) []BotRes
+	SearchBots(s string, limit int, offset int, sortBy string, tags []string) []BotRes
 	SearchPeople(s string, limit int, offset int) []Person
 	CreateLeaderBoard(uuid string, leaderboards []LeaderBoard) ([]LeaderBoard, error)
 	GetLeaderBoard(uuid string) []LeaderBoard
@@ -115,6 +116,8 @@ type Database interface {
 	GetWorkspaceInvoices(workspace_uuid string) []NewInvoiceList
 	GetWorkspaceInvoicesCount(workspace_uuid string) int64
 	UpdateInvoice(payment_request string) NewInvoiceList
+	ClaimInvoice(payment_request string) error
+	ReleaseInvoice(payment_request string) error
 	AddInvoice(invoice NewInvoiceList) NewInvoiceList
 	DeleteInvoice(payment_request string) NewInvoiceList
 	AddUserInvoiceData(userData UserInvoiceData) UserInvoiceData
@@ -168,4 +171,320 @@ type Database interface {
 	GetPhaseByUuid(phaseUuid string) (FeaturePhase, error)
 	GetBountiesByPhaseUuid(phaseUuid string) []Bounty
 	GetFeaturePhasesBountiesCount(bountyType string, phaseUuid string) int64
+	GetOpenBountiesWithExpiry() []NewBounty
+	CloseBounty(created int64) error
+	GetNotificationSettings(pubkey string) NotificationSettings
+	UpdateNotificationSettings(m NotificationSettings) (NotificationSettings, error)
+	AddNotification(m Notification) (Notification, error)
+	GetNotificationsByPubkey(pubkey string, unreadOnly bool, r *http.Request) []Notification
+	GetNotificationsCount(pubkey string, unreadOnly bool) int64
+	UpdateNotificationRead(pubkey string, uuid string, read bool) error
+	MarkAllNotificationsRead(pubkey string) error
+	GetIdempotencyKey(key string, pubkey string) (IdempotencyKey, error)
+	CreateIdempotencyKey(m IdempotencyKey) (IdempotencyKey, error)
+	UpdateIdempotencyKeyResponse(id uint, statusCode int, response string) error
+	DeleteIdempotencyKey(id uint) error
+	DeleteExpiredIdempotencyKeys() error
+	CreateApiKey(m ApiKey) (ApiKey, error)
+	GetApiKeysByPubkey(pubkey string) []ApiKey
+	GetApiKeyByHash(keyHash string) (ApiKey, error)
+	UpdateApiKeyLastUsed(id uint)
+	RevokeApiKey(pubkey string, uuid string) error
+	CreateAuthSession(m AuthSession) (AuthSession, error)
+	StartAuthSession(m AuthSession) (string, error)
+	GetAuthSession(uuid string) (AuthSession, error)
+	GetActiveAuthSessions(pubkey string) []AuthSession
+	RotateAuthSession(uuid string, currentJti string, jti string) error
+	RevokeAuthSession(pubkey string, uuid string) error
+	TouchAuthSession(uuid string) error
+	RenameAuthSession(pubkey string, uuid string, deviceName string) error
+	CreateNostrIdentity(m NostrIdentity) (NostrIdentity, error)
+	GetNostrIdentity(nostrPubkey string) (NostrIdentity, error)
+	GetNostrIdentitiesByPubkey(pubkey string) []NostrIdentity
+	DeleteNostrIdentity(pubkey string, nostrPubkey string) error
+	GetSuperAdmins() []SuperAdmin
+	AddSuperAdmin(m SuperAdmin) (SuperAdmin, error)
+	DeleteSuperAdmin(pubkey string) error
+	AddActivity(m Activity) (Activity, error)
+	GetActivitiesByPubkey(pubkey string, r *http.Request) []Activity
+	GetActivitiesCount(pubkey string) int64
+	AddDeletion(kind string, recordId string) error
+	GetDeletionsSince(kind string, since time.Time) []Deletion
+	GetTribesUpdatedSince(since time.Time, r *http.Request) []Tribe
+	GetPeopleUpdatedSince(since time.Time, r *http.Request) []Person
+	GetBountiesUpdatedSince(since time.Time, r *http.Request) []NewBounty
+	GetPeopleFacets(r *http.Request) PeopleFacets
+	CreateEndorsement(m Endorsement) (Endorsement, error)
+	GetEndorsementByBountyId(bountyId uint) Endorsement
+	GetEndorsementsByPubkey(pubkey string) []Endorsement
+	GetEndorsementSummary(pubkey string) EndorsementSummary
+	GetReputationStats(pubkey string) ReputationStats
+	GetBountyHunterPubkeys() []string
+	UpdateReputationScore(pubkey string, score float64)
+	GetPersonExport(pubkey string) PersonExport
+	AnonymizePerson(pubkey string) error
+	RevokeAllAuthSessions(pubkey string) error
+	RevokeAllApiKeys(pubkey string) error
+	ScheduleAccountPurge(pubkey string) (AccountPurge, error)
+	GetDueAccountPurges() []AccountPurge
+	PurgeAccountData(pubkey string) error
+	UpdatePersonPrivacy(pubkey string, privacy PersonPrivacy) (Person, error)
+	GetWorkspaceMatePubkeys(pubkey string) map[string]bool
+	GetLedgerBalance(workspace_uuid string) int64
+	GetWorkspaceLedger(workspace_uuid string) WorkspaceLedger
+	GetWorkspaceBudgetSettings(workspace_uuid string) WorkspaceBudgetSettings
+	UpdateWorkspaceBudgetSettings(settings WorkspaceBudgetSettings) (WorkspaceBudgetSettings, error)
+	GetLowBalanceAlertSettings() []WorkspaceBudgetSettings
+	SetLowBalanceAlerted(workspace_uuid string, alerted bool) error
+	GetWorkspaceSpentSince(workspace_uuid string, receiver string, since time.Time) uint
+	CheckSpendingCaps(workspace_uuid string, receiver string, amount uint) error
+	GetUserRoles(uuid string, pubkey string) []WorkspaceUserRoles
+	GetUnsettledInvoices() []NewInvoiceList
+	CreatePaymentAttempt(attempt PaymentAttempt) (PaymentAttempt, error)
+	GetPaymentAttempts(bountyId uint) []PaymentAttempt
+	GetDuePaymentRetries(now time.Time) []PaymentAttempt
+	ClaimPaymentRetry(id uint) bool
+	GetPersonEarnings(pubkey string) []EarningRecord
+	GetBountyPayment(bountyId uint) NewPaymentHistory
+	CreateDispute(m BountyDispute) (BountyDispute, error)
+	GetDisputeByUuid(uuid string) BountyDispute
+	GetOpenDispute(bountyId uint) BountyDispute
+	GetBountyDisputes(bountyId uint) []BountyDispute
+	GetDisputes(workspaceUuid string, status DisputeStatus) []BountyDispute
+	CloseDispute(m BountyDispute) (BountyDispute, error)
+	AddDisputeEvidence(m DisputeEvidence) (DisputeEvidence, error)
+	GetDisputeEvidence(disputeId uint) []DisputeEvidence
+	CreateBountyEscrow(m BountyEscrow) (BountyEscrow, error)
+	GetBountyEscrow(bountyId uint) BountyEscrow
+	UpdateEscrowStatus(m BountyEscrow, from EscrowStatus, to EscrowStatus) (BountyEscrow, error)
+	RecordEscrowPayout(m BountyEscrow, paymentHash string, preimage string) (BountyEscrow, error)
+	ProcessEscrowPayment(payment NewPaymentHistory, bounty NewBounty, escrow BountyEscrow) error
+	CreateBountyProof(m BountyProof) (BountyProof, error)
+	GetBountyProof(id uint) BountyProof
+	GetBountyProofs(bountyId uint) []BountyProof
+	ReviewBountyProof(m BountyProof) (BountyProof, error)
+	CreateWorkSession(m BountyWorkSession) (BountyWorkSession, error)
+	GetActiveWorkSession(bountyId uint, hunter string) BountyWorkSession
+	UpdateWorkSession(m BountyWorkSession, from WorkSessionStatus, added uint) (BountyWorkSession, error)
+	GetBountyWorkSessions(bountyId uint) []BountyWorkSession
+	GetWorkspaceWorkSessions(workspaceUuid string, since time.Time) []BountyWorkSession
+	GetAssignedBountiesWithExpiry() []NewBounty
+	MarkExpiryWarned(bountyId uint) error
+	UnassignExpiredBounty(b NewBounty) error
+	ExtendBountyAssignment(bountyId uint, deadline *time.Time, staleAfter string) error
+	CreateSavedSearch(m SavedSearch) (SavedSearch, error)
+	GetSavedSearches(pubkey string) []SavedSearch
+	DeleteSavedSearch(pubkey string, id uint) error
+	GetSavedSearchesForBounty(bounty NewBounty) []SavedSearch
+	GetCompletedBountyLanguages(pubkey string) map[string]int
+	GetRecommendationCandidates(terms []string, pubkey string, limit int) []NewBounty
+	CreateBounties(bounties []NewBounty) ([]NewBounty, error)
+	StreamWorkspaceBounties(workspaceUuid string, filter BountyExportFilter, fn func(NewBounty) error) error
+	AggregateLeaderboard(kind LeaderboardKind, since *time.Time, limit int) []LeaderboardEntry
+	ReplaceLeaderboard(kind LeaderboardKind, window LeaderboardWindow, entries []LeaderboardEntry) error
+	GetLeaderboard(kind LeaderboardKind, window LeaderboardWindow) []LeaderboardEntry
+	GetBountyFunnelRows(r PaymentDateRange, workspace string) []BountyFunnelRow
+	RollupMetrics(now time.Time) error
+	GetMetricsRollupTime() *time.Time
+	GetRollupBountyMetrics(r PaymentDateRange, workspace string) (BountyMetrics, error)
+	GetRollupBountiesProviders(r PaymentDateRange, re *http.Request) ([]Person, error)
+	StreamBountiesByDateRange(r PaymentDateRange, re *http.Request, fn func(NewBounty) error) error
+	GetMetricsSeriesValues(from time.Time, to time.Time, interval MetricsInterval, workspace string) []MetricsSeriesValue
+	SearchEntities(query string, entityType string, limit int) []SearchResult
+	GetSearchDocuments(entityType string, offset int, limit int) []SearchResult
+	GetSearchIndexChanges(limit int) []SearchIndexChange
+	DeleteSearchIndexChanges(upTo uint) error
+	GetSearchIndexBacklog() SearchIndexBacklog
+	GetSearchDocumentsByID(entityType string, ids []string) []SearchResult
+	CountSearchDocuments(entityType string) int64
+	CreateMediaJob(job MediaJob) (MediaJob, error)
+	GetPendingMediaJobs(limit int) []MediaJob
+	ClaimMediaJob(id uint) bool
+	FinishMediaJob(job MediaJob, jobErr error) error
+	GetMediaJobByVariant(key string) MediaJob
+	CreateAttachment(attachment Attachment) (Attachment, error)
+	GetAttachments(parentType AttachmentParent, parentId string) []Attachment
+	CountAttachments(parentType AttachmentParent, parentId string) int64
+	GetAttachment(id uint) Attachment
+	DeleteAttachment(id uint) error
+	CreateTicketComment(comment TicketComment) (TicketComment, error)
+	GetTicketComments(ticketId string) []TicketComment
+	GetTicketComment(id uint) TicketComment
+	SetTicketThreadResolved(id uint, resolved bool, pubkey string) (TicketComment, error)
+	CreateTicketLink(link TicketLink) (TicketLink, error)
+	GetTicketLinks(ticketIds []string) []TicketLink
+	GetTicketLinksByType(linkType TicketLinkType) []TicketLink
+	GetTicketLink(id uint) TicketLink
+	DeleteTicketLink(id uint) error
+	CreateTicketRevision(revision TicketRevision) (TicketRevision, error)
+	GetTicketRevisions(ticketId string) []TicketRevision
+	GetTicketRevision(ticketId string, version int) TicketRevision
+	CountTicketRevisions(ticketId string) int64
+	GetTicketCards(phaseUuid string) []TicketCard
+	GetTicketCard(ticketId string) TicketCard
+	MoveTicketCard(card TicketCard) (TicketCard, error)
+	ApplyTicketChanges(people []Person, cards []TicketCard, removedTickets []string) error
+	CreateFeatureDependency(dependency FeatureDependency) (FeatureDependency, error)
+	GetFeatureDependencies(itemType RoadmapItemType, itemUuid string) []FeatureDependency
+	GetFeatureDependency(id uint) FeatureDependency
+	DeleteFeatureDependency(id uint) error
+	GetPhaseBudget(phaseUuid string) PhaseBudget
+	UpdatePhaseBudget(budget PhaseBudget) (PhaseBudget, error)
+	GetPhaseSpent(phaseUuid string) uint
+	GetPhaseCommitted(phaseUuid string) uint
+	CreateOrEditWorkflow(m Workflow) (Workflow, error)
+	GetWorkflowByUuid(uuid string) Workflow
+	GetWorkspaceWorkflows(workspaceUuid string) []Workflow
+	GetTriggeredWorkflows(workspaceUuid string, trigger string) []Workflow
+	CreateWorkflowExecution(workflow Workflow, trigger string, input PropertyMap) (WorkflowExecution, error)
+	GetWorkflowExecution(uuid string) WorkflowExecution
+	GetWorkflowExecutions(workflowUuid string, limit int) []WorkflowExecution
+	GetPendingWorkflowExecutions(limit int) []WorkflowExecution
+	ClaimWorkflowExecution(id uint) bool
+	UpdateWorkflowExecution(m WorkflowExecution) error
+	GetWorkspaceAiSettings(workspace_uuid string) WorkspaceAiSettings
+	UpdateWorkspaceAiSettings(settings WorkspaceAiSettings) (WorkspaceAiSettings, error)
+	CreateStakworkJob(job StakworkJob) (StakworkJob, error)
+	GetStakworkJob(uuid string) StakworkJob
+	GetStakworkJobByToken(token string) StakworkJob
+	UpdateStakworkJob(job StakworkJob) error
+	CreateBotUsage(usage BotUsage) (BotUsage, error)
+	GetBotMonthlyEarnings(botUuid string) []BotMonthlyEarnings
+	GetBotUnpaidUsage(botUuid string) (uint, uint)
+	SettleBotUsage(payout BotPayout, lastUsageId uint) (BotPayout, error)
+	GetBotPayouts(botUuid string, limit int) []BotPayout
+	GetBotReview(botUuid string, pubkey string) BotReview
+	GetBotReviews(botUuid string, limit int, offset int) []BotReview
+	UpsertBotReview(review BotReview) (BotReview, error)
+	DeleteBotReview(botUuid string, pubkey string) error
+	CreateWebhookDelivery(m WebhookDelivery) (WebhookDelivery, error)
+	GetPendingWebhookDeliveries(limit int) []WebhookDelivery
+	ClaimWebhookDelivery(id uint) bool
+	UpdateWebhookDelivery(m WebhookDelivery) error
+	GetWebhookDeliveries(source string, sourceUuid string, limit int) []WebhookDelivery
+	CreateOrEditBotWebhook(m BotWebhook) (BotWebhook, error)
+	GetBotWebhook(uuid string) BotWebhook
+	GetBotWebhooks(botUuid string) []BotWebhook
+	DeleteBotWebhook(uuid string) error
+	GetBotWebhooksForEvent(event string, tribeUuid string, botUuid string) []BotWebhook
+	GetBotCategories() []BotCategory
+	GetBotCategory(slug string) BotCategory
+	CreateOrEditBotCategory(m BotCategory) (BotCategory, error)
+	DeleteBotCategory(slug string) error
+	GetBotsByCategory(slug string, limit int, offset int) []Bot
+	GetFeaturedBots() []Bot
+	GetArchivedChannelsByTribe(tribe_uuid string) []Channel
+	ReorderChannels(tribe_uuid string, ids []uint) error
+	GetTribeMembers(tribeUuid string) []TribeMember
+	GetTribeMember(tribeUuid string, pubkey string) TribeMember
+	SyncTribeMembers(tribeUuid string, members []TribeMember) ([]TribeMember, error)
+	DeleteTribeMember(tribeUuid string, pubkey string) error
+	GetTribeBans(tribeUuid string) []TribeBan
+	GetTribeBan(tribeUuid string, pubkey string) TribeBan
+	BanTribeMember(ban TribeBan) (TribeBan, error)
+	DeleteTribeBan(tribeUuid string, pubkey string) error
+	CreateTribeInvite(m TribeInvite) (TribeInvite, error)
+	GetTribeInvite(code string) TribeInvite
+	GetTribeInvites(tribeUuid string) []TribeInvite
+	RevokeTribeInvite(code string) error
+	GetTribeInviteRedemptions(inviteId uint) []TribeInviteRedemption
+	RedeemTribeInvite(invite TribeInvite, pubkey string, alias string) (TribeInviteRedemption, error)
+	AddTribeActivity(m TribeActivity) (TribeActivity, error)
+	GetTribeMembersBefore(tribeUuid string, before time.Time) int64
+	GetTribeSeriesValues(tribeUuid string, from time.Time, to time.Time, interval MetricsInterval) []MetricsSeriesValue
+	GetTribeRankingSettings() TribeRankingSettings
+	UpdateTribeRankingSettings(settings TribeRankingSettings) (TribeRankingSettings, error)
+	GetFeaturedTribes() []Tribe
+	SetTribeFeatured(uuid string, featured bool, position int) error
+	ReorderFeaturedTribes(uuids []string) error
+	CreateTribeVerification(m TribeVerification) (TribeVerification, error)
+	GetTribeVerification(uuid string) TribeVerification
+	GetTribeVerifications(tribeUuid string) []TribeVerification
+	GetTribeVerificationsByStatus(status TribeVerificationStatus) []TribeVerification
+	UpdateTribeVerification(m TribeVerification) (TribeVerification, error)
+	GetBountyFeed(filter BountyFeedFilter, limit int) []NewBounty
+	GetWorkspaceCalendarToken(workspaceUuid string) WorkspaceCalendarToken
+	RotateWorkspaceCalendarToken(workspaceUuid string, createdBy string) (WorkspaceCalendarToken, error)
+	GetWorkspaceCalendarBounties(workspaceUuid string) []NewBounty
+	GetWorkspaceCalendarPhases(workspaceUuid string) []WorkspaceCalendarPhase
+	GetCampaignConnectionCode(campaign string, ip string, device string) ConnectionCodesShort
+	GetConnectionCodeCampaigns() []ConnectionCodeCampaign
+	GetCampaignConnectionCodes(campaign string) []ConnectionCodes
+	ConnectionCodeExists(code string) bool
+	CountConnectionCodeRedemptions(ip string, device string, since time.Time) (int64, int64)
+	RevokeCampaignConnectionCodes(campaign string) (int64, error)
+	GetReportTargetOwner(targetType ReportTargetType, targetId string) (string, bool)
+	CreateContentReport(m ContentReport) (ContentReport, error)
+	GetContentReportByUuid(uuid string) ContentReport
+	GetOpenContentReport(targetType ReportTargetType, targetId string, reportedBy string) ContentReport
+	GetContentReports(status ReportStatus, targetType ReportTargetType) []ContentReport
+	ResolveContentReport(m ContentReport) (ContentReport, error)
+	CreateContentAppeal(m ContentAppeal) (ContentAppeal, error)
+	GetContentAppealByUuid(uuid string) ContentAppeal
+	GetContentAppealByReport(reportUuid string) ContentAppeal
+	GetContentAppeals(status AppealStatus) []ContentAppeal
+	ResolveContentAppeal(m ContentAppeal, report ContentReport) (ContentAppeal, error)
+	GetRecentListingTexts(targetType ReportTargetType, since time.Time, limit int) []ListingText
+	CountListingsCreated(targetType ReportTargetType, pubkey string, since time.Time) int64
+	GetPendingReviewTribes() []Tribe
+	GetPendingReviewBots() []Bot
+	ReviewListing(targetType ReportTargetType, uuid string, approve bool) error
+	CreatePubkeyBan(m PubkeyBan) (PubkeyBan, error)
+	GetActivePubkeyBan(pubkey string) PubkeyBan
+	GetActivePubkeyBans() []PubkeyBan
+	GetPubkeyBans(pubkey string) []PubkeyBan
+	LiftPubkeyBan(pubkey string, liftedBy string, reason string) (PubkeyBan, error)
+	GetAdminDashboard(since time.Time) AdminDashboard
+	GetRecentErrors(since time.Time, limit int) []AdminError
+	CreateImpersonation(m Impersonation) (Impersonation, error)
+	GetImpersonationByUuid(uuid string) Impersonation
+	GetImpersonations(targetPubkey string, limit int) []Impersonation
+	EndImpersonation(uuid string) (Impersonation, error)
+	CreateImpersonatedRequest(m ImpersonatedRequest) error
+	GetImpersonatedRequests(impersonationUuid string) []ImpersonatedRequest
+	GetEndedImpersonations(now time.Time) []Impersonation
+	ClaimImpersonationNotification(id uint) bool
+	GetWorkspaceBundle(uuid string) WorkspaceBundle
+	ImportWorkspaceBundle(m WorkspaceBundle, people []Person) (Workspace, error)
+	CreateOrEditWorkspaceTemplate(m WorkspaceTemplate) (WorkspaceTemplate, error)
+	GetWorkspaceTemplate(uuid string) WorkspaceTemplate
+	GetWorkspaceTemplates(pubkey string) []WorkspaceTemplate
+	DeleteWorkspaceTemplate(uuid string) error
+	ArchiveWorkspace(uuid string, archived bool, pubkey string) (Workspace, error)
+	GetArchivedWorkspaceUuids() []string
+	CreateWorkspaceTransfer(m WorkspaceTransfer) (WorkspaceTransfer, error)
+	GetWorkspaceTransferByUuid(uuid string) WorkspaceTransfer
+	GetWorkspaceTransfers(workspaceUuid string) []WorkspaceTransfer
+	GetIncomingWorkspaceTransfers(pubkey string) []WorkspaceTransfer
+	ResolveWorkspaceTransfer(uuid string, status WorkspaceTransferStatus, actor string) (WorkspaceTransfer, error)
+	AcceptWorkspaceTransfer(uuid string) (WorkspaceTransfer, error)
+	ExpireWorkspaceTransfers(now time.Time) []WorkspaceTransfer
+	GetWorkspaceAuditLog(workspaceUuid string) []WorkspaceAuditLog
+	CreateOrEditWorkspaceIntegration(m WorkspaceIntegration) (WorkspaceIntegration, error)
+	GetWorkspaceIntegration(uuid string) WorkspaceIntegration
+	GetWorkspaceIntegrations(workspaceUuid string) []WorkspaceIntegration
+	GetWorkspaceIntegrationsForEvent(workspaceUuid string, event string) []WorkspaceIntegration
+	DeleteWorkspaceIntegration(uuid string) error
+	GetRepositoriesToSync(limit int) []WorkspaceRepositories
+	UpdateRepositorySync(uuid string, openPrCount int, syncError string) error
+	SaveRepositoryCommits(ms []RepositoryCommit) error
+	SaveRepositoryPullRequest(m RepositoryPullRequest) error
+	GetRepositoryCommits(repositoryUuid string, limit int) []RepositoryCommit
+	GetRepositoryPullRequests(repositoryUuid string, limit int) []RepositoryPullRequest
+	GetBountyPullRequests(bountyId uint) []RepositoryPullRequest
+	GetWorkspaceBountyByTicketUrl(workspaceUuid string, ticketUrl string) NewBounty
+	CompleteBountyFromPullRequest(bountyId uint, pullRequest RepositoryPullRequest) (NewBounty, bool, error)
+	GetWorkspaceFeaturesAndPhases(workspaceUuid string) ([]WorkspaceFeatures, []FeaturePhase)
+	ImportWorkspaceTickets(m TicketImport) error
+	GetPeopleByPubkeys(pubkeys []string) []Person
+	GetWorkspacesByUuids(uuids []string) []Workspace
+	GetTribesByUuids(uuids []string) []Tribe
+	GetBountiesByIds(ids []uint) []NewBounty
+	GetBountyProofsByBountyIds(ids []uint) []BountyProof
+	GetFeaturesByUuids(uuids []string) []WorkspaceFeatures
+	GetFeaturesByWorkspaceUuids(uuids []string) []WorkspaceFeatures
+	GetPhasesByUuids(uuids []string) []FeaturePhase
+	GetPhasesByFeatureUuids(uuids []string) []FeaturePhase
+	GetTicketCardsByPhaseUuids(uuids []string) []TicketCard
+	GetListedWorkspaceBounties(workspaceUuid string, limit int, offset int) []NewBounty
 }
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"time"
+)
+
+// LeaderboardSince is the start of a leaderboard window, nil for all time
+func LeaderboardSince(window LeaderboardWindow, now time.Time) *time.Time {
+	var since time.Time
+	switch window {
+	case LeaderboardWeekly:
+		since = now.AddDate(0, 0, -7)
+	case LeaderboardMonthly:
+		since = now.AddDate(0, 0, -30)
+	default:
+		return nil
+	}
+	return &since
+}
+
+// AggregateLeaderboard ranks the hunters by the sats of the bounties they were paid for, or the
+// workspaces by the sats they paid for bounties, since a time or ever
+func (db database) AggregateLeaderboard(kind LeaderboardKind, since *time.Time, limit int) []LeaderboardEntry {
+	entries := []LeaderboardEntry{}
+
+	paidSince := "paid = true"
+	args := []interface{}{}
+	if since != nil {
+		paidSince += " AND paid_date >= ?"
+		args = append(args, since)
+	}
+	args = append(args, limit)
+
+	if kind == LeaderboardEarners {
+		db.db.Raw(`SELECT b.assignee AS entry_key, COALESCE(MAX(p.owner_alias), '') AS name, COALESCE(MAX(p.img), '') AS img,
+		SUM(b.price) AS amount, COUNT(*) AS bounties
+		FROM bounty b LEFT JOIN people p ON p.owner_pub_key = b.assignee AND (p.deleted = false OR p.deleted IS NULL)
+		WHERE `+paidSince+` AND b.assignee <> ''
+		GROUP BY b.assignee ORDER BY amount DESC, bounties DESC LIMIT ?`, args...).Scan(&entries)
+	} else {
+		db.db.Raw(`SELECT t.workspace AS entry_key, COALESCE(MAX(w.name), '') AS name, COALESCE(MAX(w.img), '') AS img,
+		SUM(t.price) AS amount, COUNT(*) AS bounties
+		FROM (SELECT COALESCE(NULLIF(workspace_uuid, ''), org_uuid) AS workspace, price FROM bounty WHERE `+paidSince+`) t
+		LEFT JOIN workspaces w ON w.uuid = t.workspace
+		WHERE t.workspace <> '' AND (w.deleted = false OR w.deleted IS NULL)
+		GROUP BY t.workspace ORDER BY amount DESC, bounties DESC LIMIT ?`, args...).Scan(&entries)
+	}
+
+	for i := range entries {
+		entries[i].Kind = kind
+		entries[i].Rank = i + 1
+	}
+	return entries
+}
+
+// ReplaceLeaderboard swaps the entries of a leaderboard for newly computed ones
+func (db database) ReplaceLeaderboard(kind LeaderboardKind, window LeaderboardWindow, entries []LeaderboardEntry) error {
+	now := time.Now()
+
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	if err := tx.Where("kind = ? AND time_window = ?", kind, window).Delete(&LeaderboardEntry{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, entry := range entries {
+		entry.ID = 0
+		entry.Kind = kind
+		entry.Window = window
+		entry.ComputedAt = &now
+		if err := tx.Create(&entry).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+func (db database) GetLeaderboard(kind LeaderboardKind, window LeaderboardWindow) []LeaderboardEntry {
+	entries := []LeaderboardEntry{}
+	db.db.Where("kind = ? AND time_window = ?", kind, window).Order("rank ASC").Find(&entries)
+	return entries
+}
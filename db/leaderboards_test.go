@@ -0,0 +1,16 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderboardSince(t *testing.T) {
+	now := time.Date(2025, time.March, 31, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, time.March, 24, 12, 0, 0, 0, time.UTC), *LeaderboardSince(LeaderboardWeekly, now))
+	assert.Equal(t, time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC), *LeaderboardSince(LeaderboardMonthly, now))
+	assert.Nil(t, LeaderboardSince(LeaderboardAllTime, now))
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+)
+
+// NewLedgerTransaction builds the balanced pair of entries moving amount from the credit account to the debit account
+func NewLedgerTransaction(workspaceUuid string, paymentType PaymentType, debit LedgerAccount, credit LedgerAccount, amount uint, counterparty string, bountyId uint) []LedgerEntry {
+	now := time.Now()
+	transactionUuid := xid.New().String()
+
+	entry := LedgerEntry{
+		TransactionUuid: transactionUuid,
+		WorkspaceUuid:   workspaceUuid,
+		Counterparty:    counterparty,
+		PaymentType:     paymentType,
+		BountyId:        bountyId,
+		Created:         &now,
+	}
+
+	debitEntry := entry
+	debitEntry.Account = debit
+	debitEntry.Debit = amount
+
+	creditEntry := entry
+	creditEntry.Account = credit
+	creditEntry.Credit = amount
+
+	return []LedgerEntry{debitEntry, creditEntry}
+}
+
+// LedgerBalanced reports if the debits of the entries equal their credits
+func LedgerBalanced(entries []LedgerEntry) bool {
+	var debits, credits uint
+	for _, entry := range entries {
+		debits += entry.Debit
+		credits += entry.Credit
+	}
+	return debits == credits
+}
+
+// LedgerRunningBalances adds the balance after each entry of an account, entries must be in chronological order
+func LedgerRunningBalances(entries []LedgerEntry) []LedgerLine {
+	lines := []LedgerLine{}
+	var balance int64
+	for _, entry := range entries {
+		balance += int64(entry.Debit) - int64(entry.Credit)
+		lines = append(lines, LedgerLine{
+			LedgerEntry: entry,
+			Balance:     balance,
+		})
+	}
+	return lines
+}
+
+// postLedgerTransaction writes the entries with the transaction of the budget change, it has to run
+// before the stored budget is updated so the first posting of a workspace can record its opening balance
+func postLedgerTransaction(tx *gorm.DB, entries []LedgerEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if !LedgerBalanced(entries) {
+		return errors.New("unbalanced ledger transaction")
+	}
+
+	workspaceUuid := entries[0].WorkspaceUuid
+
+	var count int64
+	if err := tx.Model(&LedgerEntry{}).Where("workspace_uuid = ?", workspaceUuid).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		budget := NewBountyBudget{}
+		tx.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", workspaceUuid).Find(&budget)
+		if budget.TotalBudget > 0 {
+			opening := NewLedgerTransaction(workspaceUuid, Deposit, LedgerBudget, LedgerOpening, budget.TotalBudget, "", 0)
+			entries = append(opening, entries...)
+		}
+	}
+
+	return tx.Create(&entries).Error
+}
+
+func (db database) GetLedgerBalance(workspace_uuid string) int64 {
+	var balance int64
+	db.db.Raw("SELECT COALESCE(SUM(balance), 0) FROM workspace_ledger_balances WHERE workspace_uuid = ?", workspace_uuid).Scan(&balance)
+	return balance
+}
+
+func (db database) GetWorkspaceLedger(workspace_uuid string) WorkspaceLedger {
+	entries := []LedgerEntry{}
+	db.db.Where("workspace_uuid = ?", workspace_uuid).Where("account = ?", LedgerBudget).Order("created ASC, id ASC").Find(&entries)
+
+	storedBudget := db.GetWorkspaceBudget(workspace_uuid).TotalBudget
+
+	// workspaces that have not moved funds since the ledger was added only have a stored budget
+	balance := int64(storedBudget)
+	if len(entries) > 0 {
+		balance = db.GetLedgerBalance(workspace_uuid)
+	}
+
+	return WorkspaceLedger{
+		WorkspaceUuid: workspace_uuid,
+		Balance:       balance,
+		StoredBudget:  storedBudget,
+		Entries:       LedgerRunningBalances(entries),
+	}
+}
+
+// CreateLedgerViews adds the view deriving every workspace budget from its ledger entries
+func (db database) CreateLedgerViews() {
+	db.db.Exec(`CREATE OR REPLACE VIEW workspace_ledger_balances AS
+		SELECT workspace_uuid, SUM(debit)::bigint - SUM(credit)::bigint AS balance
+		FROM ledger_entries WHERE account = 'workspace_budget' GROUP BY workspace_uuid`)
+}
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLedgerTransaction(t *testing.T) {
+	entries := NewLedgerTransaction("workspace_uuid", Payment, LedgerHunter, LedgerBudget, 500, "hunter_pubkey", 3)
+
+	assert.Len(t, entries, 2)
+	assert.True(t, LedgerBalanced(entries))
+	assert.Equal(t, entries[0].TransactionUuid, entries[1].TransactionUuid)
+	assert.Equal(t, LedgerHunter, entries[0].Account)
+	assert.Equal(t, uint(500), entries[0].Debit)
+	assert.Equal(t, LedgerBudget, entries[1].Account)
+	assert.Equal(t, uint(500), entries[1].Credit)
+
+	assert.False(t, LedgerBalanced(entries[:1]))
+}
+
+func TestLedgerRunningBalances(t *testing.T) {
+	entries := []LedgerEntry{
+		{Account: LedgerBudget, Debit: 1000, PaymentType: Deposit},
+		{Account: LedgerBudget, Credit: 300, PaymentType: Payment},
+		{Account: LedgerBudget, Credit: 200, PaymentType: Withdraw},
+	}
+
+	lines := LedgerRunningBalances(entries)
+	assert.Len(t, lines, 3)
+	assert.Equal(t, int64(1000), lines[0].Balance)
+	assert.Equal(t, int64(700), lines[1].Balance)
+	assert.Equal(t, int64(500), lines[2].Balance)
+}
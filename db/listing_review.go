@@ -0,0 +1,81 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+func listingModel(targetType ReportTargetType) (interface{}, error) {
+	switch targetType {
+	case ReportTribe:
+		return &Tribe{}, nil
+	case ReportBot:
+		return &Bot{}, nil
+	}
+	return nil, errors.New("only tribes and bots are reviewed")
+}
+
+// GetRecentListingTexts returns the names and descriptions of the tribes or bots created since a time,
+// newest first
+func (db database) GetRecentListingTexts(targetType ReportTargetType, since time.Time, limit int) []ListingText {
+	ms := []ListingText{}
+	model, err := listingModel(targetType)
+	if err != nil {
+		return ms
+	}
+	db.db.Model(model).Select("uuid, owner_pub_key, name, description").
+		Where("created > ?", since).
+		Where("deleted = 'f' OR deleted is null").
+		Order("created DESC").Limit(limit).Scan(&ms)
+	return ms
+}
+
+// CountListingsCreated counts the tribes or bots a pubkey created since a time
+func (db database) CountListingsCreated(targetType ReportTargetType, pubkey string, since time.Time) int64 {
+	var count int64
+	model, err := listingModel(targetType)
+	if err != nil {
+		return 0
+	}
+	db.db.Model(model).Where("owner_pub_key = ? AND created > ?", pubkey, since).Count(&count)
+	return count
+}
+
+func (db database) GetPendingReviewTribes() []Tribe {
+	ms := []Tribe{}
+	db.db.Where("pending_review = ?", true).Where("deleted = 'f' OR deleted is null").Order("created ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetPendingReviewBots() []Bot {
+	ms := []Bot{}
+	db.db.Where("pending_review = ?", true).Where("deleted = 'f' OR deleted is null").Order("created ASC").Find(&ms)
+	return ms
+}
+
+// ReviewListing lists a tribe or bot held for review, or deletes it when it is rejected
+func (db database) ReviewListing(targetType ReportTargetType, uuid string, approve bool) error {
+	model, err := listingModel(targetType)
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"pending_review": false,
+		"updated":        time.Now(),
+	}
+	if approve {
+		updates["unlisted"] = false
+	} else {
+		updates["deleted"] = true
+	}
+
+	result := db.db.Model(model).Where("uuid = ? AND pending_review = ?", uuid, true).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("nothing to review")
+	}
+	return nil
+}
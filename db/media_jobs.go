@@ -0,0 +1,65 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const MaxMediaJobAttempts = 3
+
+func (db database) CreateMediaJob(job MediaJob) (MediaJob, error) {
+	now := time.Now()
+	job.Status = MediaJobPending
+	job.Created = &now
+	job.Updated = &now
+	if err := db.db.Create(&job).Error; err != nil {
+		return MediaJob{}, err
+	}
+	return job, nil
+}
+
+// GetPendingMediaJobs returns the oldest jobs waiting for the media worker
+func (db database) GetPendingMediaJobs(limit int) []MediaJob {
+	ms := []MediaJob{}
+	db.db.Where("status = ?", MediaJobPending).Order("id ASC").Limit(limit).Find(&ms)
+	return ms
+}
+
+// ClaimMediaJob marks a pending job as picked up, it reports false if another worker claimed it first
+func (db database) ClaimMediaJob(id uint) bool {
+	result := db.db.Model(&MediaJob{}).
+		Where("id = ?", id).
+		Where("status = ?", MediaJobPending).
+		Updates(map[string]interface{}{
+			"status":   MediaJobProcessing,
+			"attempts": gorm.Expr("attempts + 1"),
+			"updated":  time.Now(),
+		})
+	return result.Error == nil && result.RowsAffected == 1
+}
+
+// FinishMediaJob records the outcome of a claimed job, counting the attempt of the claim, a failed
+// job goes back to the queue until its attempts are used up
+func (db database) FinishMediaJob(job MediaJob, jobErr error) error {
+	updates := map[string]interface{}{
+		"status":  MediaJobDone,
+		"error":   "",
+		"updated": time.Now(),
+	}
+	if jobErr != nil {
+		updates["error"] = jobErr.Error()
+		updates["status"] = MediaJobPending
+		if job.Attempts+1 >= MaxMediaJobAttempts {
+			updates["status"] = MediaJobFailed
+		}
+	}
+	return db.db.Model(&MediaJob{}).Where("id = ?", job.ID).Updates(updates).Error
+}
+
+// GetMediaJobByVariant returns the job of the image a webp or thumbnail key is a copy of
+func (db database) GetMediaJobByVariant(key string) MediaJob {
+	m := MediaJob{}
+	db.db.Where("webp_key = ? OR thumbnail_key = ?", key, key).First(&m)
+	return m
+}
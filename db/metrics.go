@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/stakwork/sphinx-tribes/utils"
@@ -227,17 +228,15 @@ func CalculateAverageDays(paidCount int64, paidSum uint) uint {
 	return 0
 }
 
-func (db database) GetBountiesByDateRange(r PaymentDateRange, re *http.Request) []NewBounty {
-	offset, limit, sortBy, direction, _ := utils.GetPaginationParams(re)
-	keys := re.URL.Query()
+// bountiesByDateRangeQuery selects the bounties created in a date range with the status,
+// provider and workspace filters of the metrics query
+func bountiesByDateRangeQuery(r PaymentDateRange, keys url.Values) string {
 	open := keys.Get("Open")
 	assigned := keys.Get("Assigned")
 	paid := keys.Get("Paid")
 	providers := keys.Get("provider")
 	workspace := keys.Get("workspace")
 
-	orderQuery := ""
-	limitQuery := ""
 	workspaceQuery := ""
 
 	var statusConditions []string
@@ -259,14 +258,6 @@ func (db database) GetBountiesByDateRange(r PaymentDateRange, re *http.Request)
 		statusQuery = ""
 	}
 
-	if sortBy != "" && direction != "" {
-		orderQuery = "ORDER BY " + sortBy + " " + direction
-	} else {
-		orderQuery = " ORDER BY " + sortBy + " DESC"
-	}
-	if limit > 1 {
-		limitQuery = fmt.Sprintf("LIMIT %d  OFFSET %d", limit, offset)
-	}
 	if workspace != "" {
 		workspaceQuery = fmt.Sprintf("AND workspace_uuid = '%s'", workspace)
 	}
@@ -278,7 +269,25 @@ func (db database) GetBountiesByDateRange(r PaymentDateRange, re *http.Request)
 	}
 
 	query := `SELECT * FROM public.bounty WHERE created >= '` + r.StartDate + `'  AND created <= '` + r.EndDate + `'` + providerCondition
-	allQuery := query + " " + workspaceQuery + " " + statusQuery + " " + orderQuery + " " + limitQuery
+	return query + " " + workspaceQuery + " " + statusQuery
+}
+
+func (db database) GetBountiesByDateRange(r PaymentDateRange, re *http.Request) []NewBounty {
+	offset, limit, sortBy, direction, _ := utils.GetPaginationParams(re)
+
+	orderQuery := ""
+	limitQuery := ""
+
+	if sortBy != "" && direction != "" {
+		orderQuery = "ORDER BY " + sortBy + " " + direction
+	} else {
+		orderQuery = " ORDER BY " + sortBy + " DESC"
+	}
+	if limit > 1 {
+		limitQuery = fmt.Sprintf("LIMIT %d  OFFSET %d", limit, offset)
+	}
+
+	allQuery := bountiesByDateRangeQuery(r, re.URL.Query()) + " " + orderQuery + " " + limitQuery
 
 	b := []NewBounty{}
 	db.db.Raw(allQuery).Find(&b)
@@ -286,6 +295,27 @@ func (db database) GetBountiesByDateRange(r PaymentDateRange, re *http.Request)
 	return b
 }
 
+// StreamBountiesByDateRange calls fn with each bounty matching the metrics filters, oldest first
+// and without pagination, reading them one at a time so a large export is never held in memory
+func (db database) StreamBountiesByDateRange(r PaymentDateRange, re *http.Request, fn func(NewBounty) error) error {
+	rows, err := db.db.Raw(bountiesByDateRangeQuery(r, re.URL.Query()) + " ORDER BY created ASC").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		bounty := NewBounty{}
+		if err := db.db.ScanRows(rows, &bounty); err != nil {
+			return err
+		}
+		if err := fn(bounty); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 func (db database) GetBountiesByDateRangeCount(r PaymentDateRange, re *http.Request) int64 {
 	keys := re.URL.Query()
 	open := keys.Get("Open")
@@ -0,0 +1,195 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+)
+
+const rollupDay = "DATE(TO_TIMESTAMP(created) AT TIME ZONE 'UTC')"
+
+// RollupDays converts the unix date range of a metrics request to the UTC days of the roll-ups,
+// the roll-ups only answer whole days so a range starting mid day covers that whole day
+func RollupDays(r PaymentDateRange) (time.Time, time.Time, error) {
+	start, err := strconv.ParseInt(r.StartDate, 10, 64)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("invalid start date")
+	}
+	end, err := strconv.ParseInt(r.EndDate, 10, 64)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("invalid end date")
+	}
+	if end < start {
+		return time.Time{}, time.Time{}, errors.New("end date is before start date")
+	}
+
+	from := time.Unix(start, 0).UTC()
+	to := time.Unix(end, 0).UTC()
+	return time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC),
+		time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// RollupMetrics recomputes the daily metrics roll-ups from the bounty table, the bounties are
+// bucketed by the day they were created like the live metrics filter them
+func (db database) RollupMetrics(now time.Time) error {
+	tx := db.db.Begin()
+
+	for _, table := range []string{"metrics_daily_rollups", "metrics_hunter_rollups", "metrics_provider_rollups"} {
+		if err := tx.Exec("DELETE FROM " + table).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Exec(`INSERT INTO metrics_daily_rollups (day, workspace_uuid, bounties_posted, bounties_assigned,
+	bounties_paid, sats_posted, sats_paid, paid_seconds, paid_count, completed_seconds, completed_count, computed_at)
+	SELECT `+rollupDay+`, COALESCE(workspace_uuid, ''), COUNT(*),
+	COUNT(*) FILTER (WHERE assignee != '' AND paid = false),
+	COUNT(*) FILTER (WHERE paid = true),
+	COALESCE(SUM(price), 0),
+	COALESCE(SUM(price) FILTER (WHERE paid = true), 0),
+	COALESCE(SUM(EXTRACT(EPOCH FROM (paid_date - TO_TIMESTAMP(created)))) FILTER (WHERE paid_date IS NOT NULL), 0),
+	COUNT(*) FILTER (WHERE paid_date IS NOT NULL),
+	COALESCE(SUM(EXTRACT(EPOCH FROM (completion_date - TO_TIMESTAMP(created)))) FILTER (WHERE completion_date IS NOT NULL), 0),
+	COUNT(*) FILTER (WHERE completion_date IS NOT NULL),
+	?
+	FROM bounty GROUP BY 1, 2`, now).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Exec(`INSERT INTO metrics_hunter_rollups (day, workspace_uuid, hunter, bounties, sats)
+	SELECT ` + rollupDay + `, COALESCE(workspace_uuid, ''), assignee, COUNT(*), COALESCE(SUM(price), 0)
+	FROM bounty WHERE paid = true AND assignee != '' GROUP BY 1, 2, 3`).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Exec(`INSERT INTO metrics_provider_rollups (day, workspace_uuid, provider, open, assigned, paid, sats)
+	SELECT ` + rollupDay + `, COALESCE(workspace_uuid, ''), owner_id,
+	COUNT(*) FILTER (WHERE assignee = '' AND paid != true),
+	COUNT(*) FILTER (WHERE assignee != '' AND paid = false),
+	COUNT(*) FILTER (WHERE paid = true),
+	COALESCE(SUM(price), 0)
+	FROM bounty GROUP BY 1, 2, 3`).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// GetMetricsRollupTime returns when the metrics roll-ups were last computed, nil if they never were
+func (db database) GetMetricsRollupTime() *time.Time {
+	var computedAt *time.Time
+	db.db.Raw("SELECT MAX(computed_at) FROM metrics_daily_rollups").Row().Scan(&computedAt)
+	return computedAt
+}
+
+// GetRollupBountyMetrics sums the daily roll-ups of a date range into the bounty metrics, the
+// caller sets when the roll-ups were computed
+func (db database) GetRollupBountyMetrics(r PaymentDateRange, workspace string) (BountyMetrics, error) {
+	from, to, err := RollupDays(r)
+	if err != nil {
+		return BountyMetrics{}, err
+	}
+
+	totals := MetricsDailyRollup{}
+	daily := db.db.Model(&MetricsDailyRollup{}).
+		Select(`COALESCE(SUM(bounties_posted), 0) AS bounties_posted, COALESCE(SUM(bounties_assigned), 0) AS bounties_assigned,
+		COALESCE(SUM(bounties_paid), 0) AS bounties_paid, COALESCE(SUM(sats_posted), 0) AS sats_posted,
+		COALESCE(SUM(sats_paid), 0) AS sats_paid, COALESCE(SUM(paid_seconds), 0) AS paid_seconds,
+		COALESCE(SUM(paid_count), 0) AS paid_count, COALESCE(SUM(completed_seconds), 0) AS completed_seconds,
+		COALESCE(SUM(completed_count), 0) AS completed_count`).
+		Where("day >= ? AND day <= ?", from, to)
+	if workspace != "" {
+		daily = daily.Where("workspace_uuid = ?", workspace)
+	}
+	if err := daily.Scan(&totals).Error; err != nil {
+		return BountyMetrics{}, err
+	}
+
+	hunters := func() *gorm.DB {
+		query := db.db.Model(&MetricsHunterRollup{}).Where("day >= ? AND day <= ?", from, to)
+		if workspace != "" {
+			query = query.Where("workspace_uuid = ?", workspace)
+		}
+		return query
+	}
+
+	var uniqueHunters, newHunters int64
+	hunters().Distinct("hunter").Count(&uniqueHunters)
+	hunters().Where("hunter NOT IN (?)", db.db.Model(&MetricsHunterRollup{}).Select("hunter").Where("day < ?", from)).
+		Distinct("hunter").Count(&newHunters)
+
+	metrics := BountyMetrics{
+		BountiesPosted:    totals.BountiesPosted,
+		BountiesPaid:      totals.BountiesPaid,
+		BountiesAssigned:  totals.BountiesAssigned,
+		SatsPosted:        totals.SatsPosted,
+		SatsPaid:          totals.SatsPaid,
+		AveragePaid:       CalculateAverageDays(totals.PaidCount, uint(totals.PaidSeconds)),
+		AverageCompleted:  CalculateAverageDays(totals.CompletedCount, uint(totals.CompletedSeconds)),
+		UniqueHuntersPaid: uniqueHunters,
+		NewHuntersPaid:    newHunters,
+	}
+	if totals.BountiesPosted != 0 {
+		metrics.BountiesPaidPercentage = uint(totals.BountiesPaid * 100 / totals.BountiesPosted)
+	}
+	if totals.SatsPosted != 0 {
+		metrics.SatsPaidPercentage = totals.SatsPaid * 100 / totals.SatsPosted
+	}
+	return metrics, nil
+}
+
+// GetRollupBountiesProviders returns the people who created bounties in a date range from the
+// provider roll-ups, with the same status and provider filters as GetBountiesProviders
+func (db database) GetRollupBountiesProviders(r PaymentDateRange, re *http.Request) ([]Person, error) {
+	from, to, err := RollupDays(r)
+	if err != nil {
+		return nil, err
+	}
+
+	offset, limit, _, _, _ := utils.GetPaginationParams(re)
+	keys := re.URL.Query()
+
+	var statusConditions []string
+	if keys.Get("Open") == "true" {
+		statusConditions = append(statusConditions, "SUM(open) > 0")
+	}
+	if keys.Get("Assigned") == "true" {
+		statusConditions = append(statusConditions, "SUM(assigned) > 0")
+	}
+	if keys.Get("Paid") == "true" {
+		statusConditions = append(statusConditions, "SUM(paid) > 0")
+	}
+
+	query := db.db.Model(&MetricsProviderRollup{}).Select("provider").
+		Where("day >= ? AND day <= ?", from, to).Group("provider").Order("provider")
+	if providers := keys.Get("provider"); providers != "" {
+		query = query.Where("provider IN (?)", strings.Split(providers, ","))
+	}
+	if len(statusConditions) > 0 {
+		query = query.Having(fmt.Sprintf("(%s)", strings.Join(statusConditions, " OR ")))
+	}
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	var owners []string
+	if err := query.Pluck("provider", &owners).Error; err != nil {
+		return nil, err
+	}
+
+	bountyProviders := []Person{}
+	for _, owner := range owners {
+		bountyProviders = append(bountyProviders, db.GetPersonByPubkey(owner))
+	}
+	return bountyProviders, nil
+}
@@ -0,0 +1,22 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollupDays(t *testing.T) {
+	// 2025-03-01 15:00 UTC to 2025-03-03 09:00 UTC
+	from, to, err := RollupDays(PaymentDateRange{StartDate: "1740841200", EndDate: "1740992400"})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), to)
+
+	_, _, err = RollupDays(PaymentDateRange{StartDate: "yesterday", EndDate: "1740992400"})
+	assert.Error(t, err)
+
+	_, _, err = RollupDays(PaymentDateRange{StartDate: "1740992400", EndDate: "1740841200"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"time"
+)
+
+// BucketStart returns the UTC day or the monday of the week a time falls in
+func BucketStart(t time.Time, interval MetricsInterval) time.Time {
+	if interval == MetricsWeekly {
+		return WeekStart(t)
+	}
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// GetMetricsSeriesValues buckets the bounties created and the sats paid, on the day they were paid,
+// and the people who joined between two times. People don't belong to a workspace so the new
+// people are counted platform wide even when a workspace is given
+func (db database) GetMetricsSeriesValues(from time.Time, to time.Time, interval MetricsInterval, workspace string) []MetricsSeriesValue {
+	values := []MetricsSeriesValue{}
+
+	workspaceQuery := ""
+	if workspace != "" {
+		workspaceQuery = " AND workspace_uuid = ?"
+	}
+	bountyArgs := func(args ...interface{}) []interface{} {
+		if workspace != "" {
+			return append(args, workspace)
+		}
+		return args
+	}
+
+	args := append(bountyArgs(interval, from.Unix(), to.Unix()), bountyArgs(interval, from, to)...)
+	args = append(args, interval, from, to)
+
+	db.db.Raw(`SELECT 'bounties_created' AS series, DATE_TRUNC(?, TO_TIMESTAMP(created) AT TIME ZONE 'UTC') AS bucket, COUNT(*) AS value
+	FROM bounty WHERE created >= ? AND created <= ?`+workspaceQuery+` GROUP BY 2
+	UNION ALL
+	SELECT 'sats_paid', DATE_TRUNC(?, paid_date AT TIME ZONE 'UTC'), COALESCE(SUM(price), 0)
+	FROM bounty WHERE paid = true AND paid_date >= ? AND paid_date <= ?`+workspaceQuery+` GROUP BY 2
+	UNION ALL
+	SELECT 'new_people', DATE_TRUNC(?, created AT TIME ZONE 'UTC'), COUNT(*)
+	FROM people WHERE created >= ? AND created <= ? GROUP BY 2`, args...).Scan(&values)
+
+	return values
+}
+
+// BuildMetricsTimeSeries lays the series values out in one point per bucket between two times,
+// the buckets without any value are kept with zeros so the charts have no gaps
+func BuildMetricsTimeSeries(from time.Time, to time.Time, interval MetricsInterval, values []MetricsSeriesValue) []MetricsTimeSeriesPoint {
+	points := []MetricsTimeSeriesPoint{}
+	index := map[string]int{}
+
+	for bucket := BucketStart(from, interval); !bucket.After(to); {
+		key := bucket.Format("2006-01-02")
+		index[key] = len(points)
+		points = append(points, MetricsTimeSeriesPoint{Bucket: key})
+
+		if interval == MetricsWeekly {
+			bucket = bucket.AddDate(0, 0, 7)
+		} else {
+			bucket = bucket.AddDate(0, 0, 1)
+		}
+	}
+
+	for _, value := range values {
+		i, ok := index[BucketStart(value.Bucket, interval).Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		switch value.Series {
+		case "bounties_created":
+			points[i].BountiesCreated += value.Value
+		case "sats_paid":
+			points[i].SatsPaid += value.Value
+		case "new_people":
+			points[i].NewPeople += value.Value
+		}
+	}
+	return points
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketStart(t *testing.T) {
+	thursday := time.Date(2025, time.March, 13, 18, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, time.March, 13, 0, 0, 0, 0, time.UTC), BucketStart(thursday, MetricsDaily))
+	assert.Equal(t, time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC), BucketStart(thursday, MetricsWeekly))
+}
+
+func TestBuildMetricsTimeSeries(t *testing.T) {
+	from := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC)
+
+	points := BuildMetricsTimeSeries(from, to, MetricsDaily, []MetricsSeriesValue{
+		{Series: "bounties_created", Bucket: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), Value: 3},
+		{Series: "sats_paid", Bucket: time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), Value: 5000},
+		{Series: "new_people", Bucket: time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), Value: 2},
+	})
+
+	assert.Equal(t, []MetricsTimeSeriesPoint{
+		{Bucket: "2025-03-01", BountiesCreated: 3},
+		{Bucket: "2025-03-02"},
+		{Bucket: "2025-03-03", SatsPaid: 5000, NewPeople: 2},
+	}, points)
+
+	weeks := BuildMetricsTimeSeries(from, to, MetricsWeekly, []MetricsSeriesValue{
+		{Series: "bounties_created", Bucket: time.Date(2025, time.February, 24, 0, 0, 0, 0, time.UTC), Value: 1},
+		{Series: "bounties_created", Bucket: time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), Value: 4},
+	})
+
+	assert.Equal(t, []MetricsTimeSeriesPoint{
+		{Bucket: "2025-02-24", BountiesCreated: 1},
+		{Bucket: "2025-03-03", BountiesCreated: 4},
+	}, weeks)
+}
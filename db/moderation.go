@@ -0,0 +1,227 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+)
+
+// GetReportTargetOwner returns the owner of the content a report is about, false when it doesn't exist
+func (db database) GetReportTargetOwner(targetType ReportTargetType, targetId string) (string, bool) {
+	owner := struct {
+		OwnerPubKey string
+	}{}
+	switch targetType {
+	case ReportTribe:
+		db.db.Model(&Tribe{}).Select("owner_pub_key").Where("uuid = ?", targetId).Scan(&owner)
+	case ReportBot:
+		db.db.Model(&Bot{}).Select("owner_pub_key").Where("uuid = ?", targetId).Scan(&owner)
+	case ReportPerson:
+		db.db.Model(&Person{}).Select("owner_pub_key").Where("uuid = ?", targetId).Scan(&owner)
+	case ReportBounty:
+		db.db.Model(&NewBounty{}).Select("owner_id AS owner_pub_key").Where("id = ?", targetId).Scan(&owner)
+	}
+	return owner.OwnerPubKey, owner.OwnerPubKey != ""
+}
+
+func (db database) CreateContentReport(m ContentReport) (ContentReport, error) {
+	now := time.Now()
+	m.Uuid = xid.New().String()
+	m.Status = ReportOpen
+	m.Created = &now
+	m.Updated = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return ContentReport{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetContentReportByUuid(uuid string) ContentReport {
+	m := ContentReport{}
+	db.db.Where("uuid = ?", uuid).Find(&m)
+	return m
+}
+
+// GetOpenContentReport returns the open report of a pubkey on some content, a pubkey reports it once
+func (db database) GetOpenContentReport(targetType ReportTargetType, targetId string, reportedBy string) ContentReport {
+	m := ContentReport{}
+	db.db.Where("target_type = ? AND target_id = ? AND reported_by = ? AND status = ?", targetType, targetId, reportedBy, ReportOpen).Find(&m)
+	return m
+}
+
+// GetContentReports is the moderation queue of reports with a status, of one type of content
+// or of every type when it is empty, oldest first
+func (db database) GetContentReports(status ReportStatus, targetType ReportTargetType) []ContentReport {
+	ms := []ContentReport{}
+	query := db.db.Where("status = ?", status)
+	if targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+	query.Order("created ASC").Find(&ms)
+	return ms
+}
+
+// moderateContent applies a moderation action to the content of a report, or undoes it on behalf of
+// undoneBy
+func moderateContent(tx *gorm.DB, report ContentReport, undo bool, undoneBy string) error {
+	action := report.Action
+	if action == ModerationDismiss {
+		return nil
+	}
+
+	if report.TargetType == ReportBounty {
+		return tx.Model(&NewBounty{}).Where("id = ?", report.TargetId).Update("show", undo).Error
+	}
+
+	var model interface{}
+	switch report.TargetType {
+	case ReportTribe:
+		model = &Tribe{}
+	case ReportBot:
+		model = &Bot{}
+	case ReportPerson:
+		model = &Person{}
+	default:
+		return errors.New("unknown report target type")
+	}
+
+	column := "deleted"
+	if action == ModerationUnlist {
+		column = "unlisted"
+	}
+	if err := tx.Model(model).Where("uuid = ?", report.TargetId).Update(column, !undo).Error; err != nil {
+		return err
+	}
+
+	if action != ModerationBanOwner {
+		return nil
+	}
+	now := time.Now()
+	if undo {
+		return tx.Model(&PubkeyBan{}).Where("pub_key = ? AND report_uuid = ? AND lifted_at IS NULL", report.OwnerPubKey, report.Uuid).Updates(map[string]interface{}{
+			"lifted_by":   undoneBy,
+			"lift_reason": "appeal overturned",
+			"lifted_at":   &now,
+		}).Error
+	}
+
+	var active int64
+	tx.Model(&PubkeyBan{}).Where("pub_key = ?", report.OwnerPubKey).Where(activeBanCondition).Count(&active)
+	if active == 0 {
+		ban := PubkeyBan{
+			PubKey:     report.OwnerPubKey,
+			Reason:     string(report.Reason),
+			BannedBy:   report.ResolvedBy,
+			ReportUuid: report.Uuid,
+			Created:    &now,
+		}
+		if err := tx.Create(&ban).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Model(&Tribe{}).Where("owner_pub_key = ?", report.OwnerPubKey).Update("unlisted", true).Error; err != nil {
+		return err
+	}
+	return tx.Model(&Bot{}).Where("owner_pub_key = ?", report.OwnerPubKey).Update("unlisted", true).Error
+}
+
+// ResolveContentReport applies the action of an open report and closes it, along with the other
+// open reports on the same content, it fails when the report was closed meanwhile
+func (db database) ResolveContentReport(m ContentReport) (ContentReport, error) {
+	now := time.Now()
+	m.Status = ReportActioned
+	if m.Action == ModerationDismiss {
+		m.Status = ReportDismissed
+	}
+	m.ResolvedAt = &now
+	m.Updated = &now
+
+	err := db.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&ContentReport{}).Where("target_type = ? AND target_id = ? AND status = ?", m.TargetType, m.TargetId, ReportOpen).Updates(map[string]interface{}{
+			"status":         m.Status,
+			"action":         m.Action,
+			"moderator_note": m.ModeratorNote,
+			"resolved_by":    m.ResolvedBy,
+			"resolved_at":    m.ResolvedAt,
+			"updated":        m.Updated,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("report is not open")
+		}
+		return moderateContent(tx, m, false, "")
+	})
+	if err != nil {
+		return ContentReport{}, err
+	}
+	return m, nil
+}
+
+func (db database) CreateContentAppeal(m ContentAppeal) (ContentAppeal, error) {
+	now := time.Now()
+	m.Uuid = xid.New().String()
+	m.Status = AppealOpen
+	m.Created = &now
+	m.Updated = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return ContentAppeal{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetContentAppealByUuid(uuid string) ContentAppeal {
+	m := ContentAppeal{}
+	db.db.Where("uuid = ?", uuid).Find(&m)
+	return m
+}
+
+func (db database) GetContentAppealByReport(reportUuid string) ContentAppeal {
+	m := ContentAppeal{}
+	db.db.Where("report_uuid = ?", reportUuid).Find(&m)
+	return m
+}
+
+// GetContentAppeals is the queue of appeals with a status, oldest first
+func (db database) GetContentAppeals(status AppealStatus) []ContentAppeal {
+	ms := []ContentAppeal{}
+	db.db.Where("status = ?", status).Order("created ASC").Find(&ms)
+	return ms
+}
+
+// ResolveContentAppeal closes an open appeal, an overturned appeal undoes the action of its report,
+// it fails when the appeal was closed meanwhile
+func (db database) ResolveContentAppeal(m ContentAppeal, report ContentReport) (ContentAppeal, error) {
+	now := time.Now()
+	m.ResolvedAt = &now
+	m.Updated = &now
+
+	err := db.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&ContentAppeal{}).Where("id = ? AND status = ?", m.ID, AppealOpen).Updates(map[string]interface{}{
+			"status":      m.Status,
+			"resolution":  m.Resolution,
+			"resolved_by": m.ResolvedBy,
+			"resolved_at": m.ResolvedAt,
+			"updated":     m.Updated,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("appeal is not open")
+		}
+		if m.Status != AppealOverturned {
+			return nil
+		}
+		return moderateContent(tx, report, true, m.ResolvedBy)
+	})
+	if err != nil {
+		return ContentAppeal{}, err
+	}
+	return m, nil
+}
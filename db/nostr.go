@@ -0,0 +1,42 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+func (db database) CreateNostrIdentity(m NostrIdentity) (NostrIdentity, error) {
+	now := time.Now()
+	m.Created = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return NostrIdentity{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetNostrIdentity(nostrPubkey string) (NostrIdentity, error) {
+	m := NostrIdentity{}
+	db.db.Where("nostr_pubkey = ?", nostrPubkey).Find(&m)
+	if m.ID == 0 {
+		return m, errors.New("no nostr identity found")
+	}
+	return m, nil
+}
+
+func (db database) GetNostrIdentitiesByPubkey(pubkey string) []NostrIdentity {
+	ms := []NostrIdentity{}
+	db.db.Where("owner_pub_key = ?", pubkey).Order("created DESC").Find(&ms)
+	return ms
+}
+
+func (db database) DeleteNostrIdentity(pubkey string, nostrPubkey string) error {
+	result := db.db.Where("owner_pub_key = ?", pubkey).Where("nostr_pubkey = ?", nostrPubkey).Delete(&NostrIdentity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("no nostr identity found")
+	}
+	return nil
+}
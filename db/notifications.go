@@ -0,0 +1,117 @@
+package db
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// DefaultNotificationSettings are used for people that never saved their preferences
+func DefaultNotificationSettings(pubkey string) NotificationSettings {
+	return NotificationSettings{
+		OwnerPubKey:    pubkey,
+		Websocket:      true,
+		DisabledEvents: []string{},
+	}
+}
+
+func (db database) GetNotificationSettings(pubkey string) NotificationSettings {
+	ms := NotificationSettings{}
+	db.db.Where("owner_pub_key = ?", pubkey).Find(&ms)
+	if ms.ID == 0 {
+		return DefaultNotificationSettings(pubkey)
+	}
+	return ms
+}
+
+func (db database) UpdateNotificationSettings(m NotificationSettings) (NotificationSettings, error) {
+	if m.OwnerPubKey == "" {
+		return NotificationSettings{}, errors.New("no pub key")
+	}
+
+	now := time.Now()
+	m.Updated = &now
+
+	existing := NotificationSettings{}
+	db.db.Where("owner_pub_key = ?", m.OwnerPubKey).Find(&existing)
+
+	if existing.ID == 0 {
+		m.Created = &now
+		if err := db.db.Create(&m).Error; err != nil {
+			return NotificationSettings{}, err
+		}
+		return m, nil
+	}
+
+	m.ID = existing.ID
+	m.Created = existing.Created
+	if err := db.db.Model(&existing).Select("*").Updates(&m).Error; err != nil {
+		return NotificationSettings{}, err
+	}
+	return m, nil
+}
+
+func (db database) AddNotification(m Notification) (Notification, error) {
+	now := time.Now()
+	if m.Uuid == "" {
+		m.Uuid = xid.New().String()
+	}
+	m.Created = &now
+	m.Updated = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return Notification{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetNotificationsByPubkey(pubkey string, unreadOnly bool, r *http.Request) []Notification {
+	offset, limit, _, _, _ := utils.GetPaginationParams(r)
+
+	ms := []Notification{}
+	query := db.db.Where("owner_pub_key = ?", pubkey)
+	if unreadOnly {
+		query = query.Where("read = ?", false)
+	}
+	if limit > 1 {
+		query = query.Offset(offset).Limit(limit)
+	}
+	query.Order("created DESC").Find(&ms)
+	return ms
+}
+
+func (db database) GetNotificationsCount(pubkey string, unreadOnly bool) int64 {
+	var count int64
+	query := db.db.Model(&Notification{}).Where("owner_pub_key = ?", pubkey)
+	if unreadOnly {
+		query = query.Where("read = ?", false)
+	}
+	query.Count(&count)
+	return count
+}
+
+func (db database) UpdateNotificationRead(pubkey string, uuid string, read bool) error {
+	now := time.Now()
+	result := db.db.Model(&Notification{}).Where("owner_pub_key = ?", pubkey).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"read":    read,
+		"updated": &now,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("no notification found")
+	}
+	return nil
+}
+
+func (db database) MarkAllNotificationsRead(pubkey string) error {
+	now := time.Now()
+	return db.db.Model(&Notification{}).Where("owner_pub_key = ?", pubkey).Where("read = ?", false).Updates(map[string]interface{}{
+		"read":    true,
+		"updated": &now,
+	}).Error
+}
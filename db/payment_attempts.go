@@ -0,0 +1,64 @@
+package db
+
+import (
+	"time"
+)
+
+const (
+	MaxPaymentAttempts = 6
+	paymentRetryBase   = time.Minute
+	paymentRetryMax    = time.Hour
+)
+
+// PaymentRetryDelay is the exponential backoff before retrying a failed attempt, capped at an hour
+func PaymentRetryDelay(attempt int) time.Duration {
+	delay := paymentRetryBase
+	for i := 1; i < attempt && delay < paymentRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > paymentRetryMax {
+		delay = paymentRetryMax
+	}
+	return delay
+}
+
+// NextPaymentRetry returns when a transiently failed attempt is retried, nil once the attempts are used up
+func NextPaymentRetry(attempt int, failedAt time.Time) *time.Time {
+	if attempt >= MaxPaymentAttempts {
+		return nil
+	}
+	next := failedAt.Add(PaymentRetryDelay(attempt))
+	return &next
+}
+
+func (db database) CreatePaymentAttempt(attempt PaymentAttempt) (PaymentAttempt, error) {
+	if attempt.Created == nil {
+		now := time.Now()
+		attempt.Created = &now
+	}
+	if err := db.db.Create(&attempt).Error; err != nil {
+		return PaymentAttempt{}, err
+	}
+	return attempt, nil
+}
+
+func (db database) GetPaymentAttempts(bountyId uint) []PaymentAttempt {
+	ms := []PaymentAttempt{}
+	db.db.Where("bounty_id = ?", bountyId).Order("attempt ASC, id ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetDuePaymentRetries(now time.Time) []PaymentAttempt {
+	ms := []PaymentAttempt{}
+	db.db.Where("status = ?", PaymentAttemptRetrying).Where("next_retry <= ?", now).Order("next_retry ASC").Find(&ms)
+	return ms
+}
+
+// ClaimPaymentRetry marks a due retry as picked up, it reports false if another worker claimed it first
+func (db database) ClaimPaymentRetry(id uint) bool {
+	result := db.db.Model(&PaymentAttempt{}).
+		Where("id = ?", id).
+		Where("status = ?", PaymentAttemptRetrying).
+		Update("status", PaymentAttemptRetried)
+	return result.Error == nil && result.RowsAffected == 1
+}
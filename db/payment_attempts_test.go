@@ -0,0 +1,25 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, PaymentRetryDelay(1))
+	assert.Equal(t, 2*time.Minute, PaymentRetryDelay(2))
+	assert.Equal(t, 16*time.Minute, PaymentRetryDelay(5))
+	assert.Equal(t, time.Hour, PaymentRetryDelay(20))
+}
+
+func TestNextPaymentRetry(t *testing.T) {
+	failedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	next := NextPaymentRetry(3, failedAt)
+	assert.NotNil(t, next)
+	assert.Equal(t, failedAt.Add(4*time.Minute), *next)
+
+	assert.Nil(t, NextPaymentRetry(MaxPaymentAttempts, failedAt))
+}
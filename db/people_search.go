@@ -0,0 +1,110 @@
+package db
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// peopleFilterQuery builds the structured filters of GET /people as parameterized conditions,
+// the jsonb containment checks on extras use the GIN index created by CreatePeopleSearchIndexes
+func peopleFilterQuery(keys url.Values) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if languages := splitQueryList(keys.Get("coding_languages")); len(languages) > 0 {
+		ors := []string{}
+		for _, language := range languages {
+			contains, _ := json.Marshal(map[string]interface{}{
+				"coding_languages": []map[string]string{{"label": language}},
+			})
+			ors = append(ors, "extras @> ?")
+			args = append(args, string(contains))
+		}
+		conditions = append(conditions, "("+strings.Join(ors, " OR ")+")")
+	}
+
+	if skills := splitQueryList(keys.Get("skills")); len(skills) > 0 {
+		conditions = append(conditions, "tags && ?")
+		args = append(args, pq.StringArray(skills))
+	}
+
+	if priceMin, err := strconv.ParseInt(keys.Get("price_min"), 10, 64); err == nil {
+		conditions = append(conditions, "price_to_meet >= ?")
+		args = append(args, priceMin)
+	}
+	if priceMax, err := strconv.ParseInt(keys.Get("price_max"), 10, 64); err == nil {
+		conditions = append(conditions, "price_to_meet <= ?")
+		args = append(args, priceMax)
+	}
+
+	if minReputation, err := strconv.ParseFloat(keys.Get("min_reputation"), 64); err == nil {
+		conditions = append(conditions, "reputation_score >= ?")
+		args = append(args, minReputation)
+	}
+
+	// available people are not assigned to any open bounty
+	if keys.Get("available") == "true" {
+		conditions = append(conditions, "owner_pub_key NOT IN (SELECT assignee FROM bounty WHERE assignee != '' AND paid = false AND completed = false)")
+	}
+
+	if timezones := splitQueryList(keys.Get("timezone")); len(timezones) > 0 {
+		ors := []string{}
+		for _, timezone := range timezones {
+			contains, _ := json.Marshal(map[string]string{"timezone": timezone})
+			ors = append(ors, "extras @> ?")
+			args = append(args, string(contains))
+		}
+		conditions = append(conditions, "("+strings.Join(ors, " OR ")+")")
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "AND " + strings.Join(conditions, " AND "), args
+}
+
+func splitQueryList(value string) []string {
+	list := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// GetPeopleFacets counts the listed people matching the filters for each coding language and skill
+func (db database) GetPeopleFacets(r *http.Request) PeopleFacets {
+	var keys url.Values
+	if r != nil {
+		keys = r.URL.Query()
+	}
+	filterQuery, args := peopleFilterQuery(keys)
+	listed := "(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null) AND " + notBanned("owner_pub_key")
+
+	facets := PeopleFacets{
+		CodingLanguages: []FacetCount{},
+		Skills:          []FacetCount{},
+	}
+
+	db.db.Raw(`SELECT language->>'label' AS value, COUNT(*) AS count FROM people,
+	jsonb_array_elements(CASE WHEN jsonb_typeof(extras->'coding_languages') = 'array' THEN extras->'coding_languages' ELSE '[]'::jsonb END) AS language
+	WHERE `+listed+` `+filterQuery+` GROUP BY value ORDER BY count DESC`, args...).Scan(&facets.CodingLanguages)
+
+	db.db.Raw(`SELECT skill AS value, COUNT(*) AS count FROM people, unnest(tags) AS skill
+	WHERE `+listed+` `+filterQuery+` GROUP BY value ORDER BY count DESC`, args...).Scan(&facets.Skills)
+
+	return facets
+}
+
+// CreatePeopleSearchIndexes adds the GIN indexes used by the people search filters
+func (db database) CreatePeopleSearchIndexes() {
+	db.db.Exec("CREATE INDEX IF NOT EXISTS idx_people_extras ON people USING GIN (extras jsonb_path_ops)")
+	db.db.Exec("CREATE INDEX IF NOT EXISTS idx_people_tags ON people USING GIN (tags)")
+	db.db.Exec("CREATE INDEX IF NOT EXISTS idx_people_price_to_meet ON people (price_to_meet)")
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeopleFilterQuery(t *testing.T) {
+	t.Run("Should test that no filters add no conditions", func(t *testing.T) {
+		query, args := peopleFilterQuery(url.Values{})
+		assert.Equal(t, "", query)
+		assert.Empty(t, args)
+	})
+
+	t.Run("Should test that structured filters become parameterized conditions", func(t *testing.T) {
+		keys := url.Values{}
+		keys.Set("coding_languages", "Go, Rust")
+		keys.Set("skills", "design")
+		keys.Set("price_min", "100")
+		keys.Set("price_max", "5000")
+		keys.Set("available", "true")
+		keys.Set("timezone", "UTC")
+
+		query, args := peopleFilterQuery(keys)
+
+		assert.Equal(t, "AND (extras @> ? OR extras @> ?) AND tags && ? AND price_to_meet >= ? AND price_to_meet <= ? AND owner_pub_key NOT IN (SELECT assignee FROM bounty WHERE assignee != '' AND paid = false AND completed = false) AND (extras @> ?)", query)
+		assert.Equal(t, []interface{}{
+			`{"coding_languages":[{"label":"Go"}]}`,
+			`{"coding_languages":[{"label":"Rust"}]}`,
+			pq.StringArray{"design"},
+			int64(100),
+			int64(5000),
+			`{"timezone":"UTC"}`,
+		}, args)
+	})
+
+	t.Run("Should test that proven hunters can be filtered by reputation", func(t *testing.T) {
+		keys := url.Values{}
+		keys.Set("min_reputation", "50")
+
+		query, args := peopleFilterQuery(keys)
+		assert.Equal(t, "AND reputation_score >= ?", query)
+		assert.Equal(t, []interface{}{float64(50)}, args)
+	})
+
+	t.Run("Should test that invalid prices are ignored", func(t *testing.T) {
+		keys := url.Values{}
+		keys.Set("price_min", "cheap")
+
+		query, _ := peopleFilterQuery(keys)
+		assert.Equal(t, "", query)
+	})
+}
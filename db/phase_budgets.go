@@ -0,0 +1,80 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// phaseBudgetWarnAt is the share of a phase budget, in percent, past which the phase warns
+const phaseBudgetWarnAt = 90
+
+func (db database) GetPhaseBudget(phaseUuid string) PhaseBudget {
+	budget := PhaseBudget{}
+	db.db.Where("phase_uuid = ?", phaseUuid).Find(&budget)
+	budget.PhaseUuid = phaseUuid
+	return budget
+}
+
+func (db database) UpdatePhaseBudget(budget PhaseBudget) (PhaseBudget, error) {
+	existing := db.GetPhaseBudget(budget.PhaseUuid)
+
+	now := time.Now()
+	budget.ID = existing.ID
+	budget.Created = existing.Created
+	budget.Updated = &now
+	if budget.Created == nil {
+		budget.Created = &now
+	}
+
+	if err := db.db.Save(&budget).Error; err != nil {
+		return PhaseBudget{}, err
+	}
+	return budget, nil
+}
+
+// GetPhaseSpent sums the payments of the bounties of a phase
+func (db database) GetPhaseSpent(phaseUuid string) uint {
+	var spent uint
+	db.db.Model(&NewPaymentHistory{}).
+		Where("bounty_id IN (?)", db.db.Model(&NewBounty{}).Select("id").Where("phase_uuid = ?", phaseUuid)).
+		Where("payment_type = ?", Payment).
+		Where("status = true").
+		Select("COALESCE(SUM(amount), 0)").Row().Scan(&spent)
+	return spent
+}
+
+// GetPhaseCommitted sums the prices of the bounties of a phase that are not paid yet
+func (db database) GetPhaseCommitted(phaseUuid string) uint {
+	var committed uint
+	db.db.Model(&NewBounty{}).
+		Where("phase_uuid = ?", phaseUuid).
+		Where("paid = false").
+		Select("COALESCE(SUM(price), 0)").Row().Scan(&committed)
+	return committed
+}
+
+// NewPhaseBudgetStatus works out what is left of a phase budget and warns when the phase went
+// over it, is close to it or has open bounties that would take it over
+func NewPhaseBudgetStatus(phaseUuid string, allocated uint, spent uint, committed uint) PhaseBudgetStatus {
+	status := PhaseBudgetStatus{
+		PhaseUuid: phaseUuid,
+		Allocated: allocated,
+		Spent:     spent,
+		Committed: committed,
+		Remaining: int64(allocated) - int64(spent),
+		Warnings:  []string{},
+	}
+	if allocated == 0 {
+		return status
+	}
+
+	if spent > allocated {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("The phase is %d sats over its budget", spent-allocated))
+	} else if uint64(spent)*100 >= uint64(allocated)*phaseBudgetWarnAt {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("%d%% of the phase budget is spent", uint64(spent)*100/uint64(allocated)))
+	}
+	if committed > 0 && spent+committed > allocated {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("Paying the open bounties would take the phase %d sats over its budget", spent+committed-allocated))
+	}
+	return status
+}
@@ -0,0 +1,30 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPhaseBudgetStatus(t *testing.T) {
+	status := NewPhaseBudgetStatus("phase", 10000, 4000, 2000)
+	assert.Equal(t, int64(6000), status.Remaining)
+	assert.Empty(t, status.Warnings)
+
+	status = NewPhaseBudgetStatus("phase", 10000, 9500, 0)
+	assert.Equal(t, []string{"95% of the phase budget is spent"}, status.Warnings)
+
+	status = NewPhaseBudgetStatus("phase", 10000, 8000, 3000)
+	assert.Equal(t, []string{"Paying the open bounties would take the phase 1000 sats over its budget"}, status.Warnings)
+
+	status = NewPhaseBudgetStatus("phase", 10000, 12000, 500)
+	assert.Equal(t, int64(-2000), status.Remaining)
+	assert.Equal(t, []string{
+		"The phase is 2000 sats over its budget",
+		"Paying the open bounties would take the phase 2500 sats over its budget",
+	}, status.Warnings)
+
+	status = NewPhaseBudgetStatus("phase", 0, 12000, 500)
+	assert.Equal(t, int64(-12000), status.Remaining)
+	assert.Empty(t, status.Warnings)
+}
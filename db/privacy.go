@@ -0,0 +1,177 @@
+package db
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Visibility levels of the privacy settings of a person
+const (
+	PrivacyPublic       = "public"
+	PrivacyTribeMembers = "tribe-members"
+	PrivacyPrivate      = "private"
+)
+
+// Profile fields whose visibility can be set
+const (
+	PrivacyFieldEmail       = "email"
+	PrivacyFieldGithub      = "github"
+	PrivacyFieldPricing     = "pricing"
+	PrivacyFieldDescription = "description"
+)
+
+var PrivacyFields = []string{PrivacyFieldEmail, PrivacyFieldGithub, PrivacyFieldPricing, PrivacyFieldDescription}
+
+// PersonPrivacy maps a profile field to its visibility, people without settings store null
+type PersonPrivacy map[string]string
+
+// Value ...
+func (p PersonPrivacy) Value() (driver.Value, error) {
+	if len(p) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(p)
+}
+
+// Scan ...
+func (p *PersonPrivacy) Scan(src interface{}) error {
+	if src == nil {
+		*p = nil
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("type assertion .([]byte) failed")
+	}
+	return json.Unmarshal(source, p)
+}
+
+// PrivacyAudience is how close the caller is to the person being viewed
+type PrivacyAudience int
+
+const (
+	AudiencePublic PrivacyAudience = iota
+	AudienceMember
+	AudienceOwner
+)
+
+// ValidatePrivacy checks that only known fields and visibility levels are set
+func ValidatePrivacy(privacy PersonPrivacy) error {
+	for field, level := range privacy {
+		known := false
+		for _, f := range PrivacyFields {
+			if f == field {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown privacy field %s", field)
+		}
+
+		if level != PrivacyPublic && level != PrivacyTribeMembers && level != PrivacyPrivate {
+			return fmt.Errorf("invalid visibility for %s", field)
+		}
+	}
+	return nil
+}
+
+// FieldVisibility returns the visibility of a profile field, fields without a setting are public
+func (p Person) FieldVisibility(field string) string {
+	if level := p.Privacy[field]; level != "" {
+		return level
+	}
+	return PrivacyPublic
+}
+
+// HasVisibility reports if any profile field is set to the visibility level
+func (p Person) HasVisibility(level string) bool {
+	for _, field := range PrivacyFields {
+		if p.FieldVisibility(field) == level {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Person) fieldVisibleTo(field string, audience PrivacyAudience) bool {
+	switch p.FieldVisibility(field) {
+	case PrivacyPrivate:
+		return audience == AudienceOwner
+	case PrivacyTribeMembers:
+		return audience >= AudienceMember
+	}
+	return true
+}
+
+// ApplyPersonPrivacy blanks the fields the audience is not allowed to see
+func ApplyPersonPrivacy(person Person, audience PrivacyAudience) Person {
+	if audience == AudienceOwner {
+		return person
+	}
+
+	hideEmail := !person.fieldVisibleTo(PrivacyFieldEmail, audience)
+	hideGithub := !person.fieldVisibleTo(PrivacyFieldGithub, audience)
+	if hideEmail || hideGithub {
+		// copy extras so a cached person is never modified
+		extras := PropertyMap{}
+		for k, v := range person.Extras {
+			extras[k] = v
+		}
+		if hideEmail {
+			delete(extras, "email")
+		}
+		if hideGithub {
+			delete(extras, "github")
+		}
+		person.Extras = extras
+	}
+	if !person.fieldVisibleTo(PrivacyFieldPricing, audience) {
+		person.PriceToMeet = 0
+	}
+	if !person.fieldVisibleTo(PrivacyFieldDescription, audience) {
+		person.Description = ""
+	}
+	return person
+}
+
+func (db database) UpdatePersonPrivacy(pubkey string, privacy PersonPrivacy) (Person, error) {
+	if err := ValidatePrivacy(privacy); err != nil {
+		return Person{}, err
+	}
+
+	person := db.GetPersonByPubkey(pubkey)
+	if person.ID == 0 {
+		return Person{}, errors.New("person not found")
+	}
+
+	if err := db.db.Model(&Person{}).Where("id = ?", person.ID).Update("privacy", privacy).Error; err != nil {
+		return Person{}, err
+	}
+	person.Privacy = privacy
+	return person, nil
+}
+
+// GetWorkspaceMatePubkeys returns the pubkeys of everyone sharing a workspace with the pubkey
+func (db database) GetWorkspaceMatePubkeys(pubkey string) map[string]bool {
+	mates := map[string]bool{}
+	if pubkey == "" {
+		return mates
+	}
+
+	workspaces := db.db.Raw(`SELECT workspace_uuid FROM workspace_users WHERE owner_pub_key = ?
+		UNION SELECT uuid FROM workspaces WHERE owner_pub_key = ? AND deleted = false`, pubkey, pubkey)
+
+	pubkeys := []string{}
+	db.db.Raw(`SELECT owner_pub_key FROM workspace_users WHERE workspace_uuid IN (?)
+		UNION SELECT owner_pub_key FROM workspaces WHERE uuid IN (?) AND deleted = false`, workspaces, workspaces).Scan(&pubkeys)
+
+	for _, p := range pubkeys {
+		if p != pubkey {
+			mates[p] = true
+		}
+	}
+	return mates
+}
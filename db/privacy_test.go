@@ -0,0 +1,53 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePrivacy(t *testing.T) {
+	assert.NoError(t, ValidatePrivacy(PersonPrivacy{}))
+	assert.NoError(t, ValidatePrivacy(PersonPrivacy{PrivacyFieldEmail: PrivacyPrivate, PrivacyFieldPricing: PrivacyTribeMembers}))
+	assert.Error(t, ValidatePrivacy(PersonPrivacy{"img": PrivacyPrivate}))
+	assert.Error(t, ValidatePrivacy(PersonPrivacy{PrivacyFieldGithub: "friends"}))
+}
+
+func TestApplyPersonPrivacy(t *testing.T) {
+	person := Person{
+		OwnerPubKey: "pubkey",
+		Description: "rust developer",
+		PriceToMeet: 100,
+		Extras:      PropertyMap{"email": "alice@example.com", "github": "alice", "twitter": "alice"},
+		Privacy: PersonPrivacy{
+			PrivacyFieldEmail:       PrivacyPrivate,
+			PrivacyFieldGithub:      PrivacyTribeMembers,
+			PrivacyFieldDescription: PrivacyPublic,
+		},
+	}
+
+	t.Run("Should test that the owner sees every field", func(t *testing.T) {
+		assert.Equal(t, person, ApplyPersonPrivacy(person, AudienceOwner))
+	})
+
+	t.Run("Should test that members see tribe-members fields but not private ones", func(t *testing.T) {
+		shown := ApplyPersonPrivacy(person, AudienceMember)
+		assert.NotContains(t, shown.Extras, "email")
+		assert.Equal(t, "alice", shown.Extras["github"])
+		assert.Equal(t, int64(100), shown.PriceToMeet)
+		assert.Equal(t, "rust developer", shown.Description)
+	})
+
+	t.Run("Should test that the public only sees public fields", func(t *testing.T) {
+		shown := ApplyPersonPrivacy(person, AudiencePublic)
+		assert.NotContains(t, shown.Extras, "email")
+		assert.NotContains(t, shown.Extras, "github")
+		assert.Equal(t, "alice", shown.Extras["twitter"])
+		assert.Equal(t, "rust developer", shown.Description)
+	})
+
+	t.Run("Should test that the original extras are not modified", func(t *testing.T) {
+		ApplyPersonPrivacy(person, AudiencePublic)
+		assert.Equal(t, "alice@example.com", person.Extras["email"])
+	})
+}
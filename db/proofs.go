@@ -0,0 +1,76 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+// CreateBountyProof stores a submission of the work and marks the bounty as waiting for review
+func (db database) CreateBountyProof(m BountyProof) (BountyProof, error) {
+	now := time.Now()
+	m.Status = ProofSubmitted
+	m.Created = &now
+	m.Updated = &now
+
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return BountyProof{}, err
+	}
+	if err := tx.Create(&m).Error; err != nil {
+		tx.Rollback()
+		return BountyProof{}, err
+	}
+	if err := tx.Model(&NewBounty{}).Where("id = ?", m.BountyId).Update("proof_status", m.Status).Error; err != nil {
+		tx.Rollback()
+		return BountyProof{}, err
+	}
+	return m, tx.Commit().Error
+}
+
+func (db database) GetBountyProof(id uint) BountyProof {
+	m := BountyProof{}
+	db.db.Where("id = ?", id).Find(&m)
+	return m
+}
+
+// GetBountyProofs is the submission history of a bounty, newest first
+func (db database) GetBountyProofs(bountyId uint) []BountyProof {
+	ms := []BountyProof{}
+	db.db.Where("bounty_id = ?", bountyId).Order("created DESC").Find(&ms)
+	return ms
+}
+
+// ReviewBountyProof stores the owner's review of a submitted proof on it and its bounty, it fails
+// when the proof was reviewed meanwhile
+func (db database) ReviewBountyProof(m BountyProof) (BountyProof, error) {
+	now := time.Now()
+	m.Updated = &now
+	m.ReviewedAt = &now
+
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return BountyProof{}, err
+	}
+
+	result := tx.Model(&BountyProof{}).Where("id = ? AND status = ?", m.ID, ProofSubmitted).Updates(map[string]interface{}{
+		"status":         m.Status,
+		"reviewed_by":    m.ReviewedBy,
+		"review_comment": m.ReviewComment,
+		"reviewed_at":    m.ReviewedAt,
+		"updated":        m.Updated,
+	})
+	if result.Error != nil {
+		tx.Rollback()
+		return BountyProof{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return BountyProof{}, errors.New("proof is not waiting for review")
+	}
+	if err := tx.Model(&NewBounty{}).Where("id = ?", m.BountyId).Update("proof_status", m.Status).Error; err != nil {
+		tx.Rollback()
+		return BountyProof{}, err
+	}
+
+	return m, tx.Commit().Error
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"github.com/lib/pq"
+)
+
+type languageCount struct {
+	Language string
+	Count    int
+}
+
+// GetCompletedBountyLanguages counts the coding languages of the bounties a person was paid for or completed
+func (db database) GetCompletedBountyLanguages(pubkey string) map[string]int {
+	counts := []languageCount{}
+	db.db.Raw(`SELECT language, COUNT(*) AS count FROM bounty, unnest(coding_languages) AS language
+	WHERE assignee = ? AND (paid = true OR completed = true) GROUP BY language`, pubkey).Scan(&counts)
+
+	history := map[string]int{}
+	for _, c := range counts {
+		history[c.Language] = c.Count
+	}
+	return history
+}
+
+// GetRecommendationCandidates lists the open bounties sharing a coding language with the terms,
+// newest first, leaving out the person's own. The overlap uses the GIN index on coding_languages
+func (db database) GetRecommendationCandidates(terms []string, pubkey string, limit int) []NewBounty {
+	ms := []NewBounty{}
+	if len(terms) == 0 {
+		return ms
+	}
+	db.db.Where("show = ? AND paid = ? AND completed = ?", true, false, false).
+		Where("(assignee = '' OR assignee IS NULL)").
+		Where("owner_id <> ?", pubkey).
+		Where("coding_languages && ?", pq.StringArray(terms)).
+		Order("created DESC").
+		Limit(limit).
+		Find(&ms)
+	return ms
+}
+
+// CreateBountyRecommendationIndexes adds the GIN index the recommendation candidates are looked up with
+func (db database) CreateBountyRecommendationIndexes() {
+	db.db.Exec("CREATE INDEX IF NOT EXISTS idx_bounty_coding_languages ON bounty USING GIN (coding_languages)")
+}
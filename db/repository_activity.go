@@ -0,0 +1,141 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// GetRepositoriesToSync returns the repositories synced the longest ago, never synced ones first
+func (db database) GetRepositoriesToSync(limit int) []WorkspaceRepositories {
+	ms := []WorkspaceRepositories{}
+	db.db.Order("last_synced_at ASC NULLS FIRST").Limit(limit).Find(&ms)
+	return ms
+}
+
+// UpdateRepositorySync records the outcome of a sync, the error is cleared by a sync that worked
+func (db database) UpdateRepositorySync(uuid string, openPrCount int, syncError string) error {
+	now := time.Now()
+	return db.db.Model(&WorkspaceRepositories{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"open_pr_count":  openPrCount,
+		"sync_error":     syncError,
+		"last_synced_at": &now,
+	}).Error
+}
+
+// SaveRepositoryCommits stores the commits that were not synced before
+func (db database) SaveRepositoryCommits(ms []RepositoryCommit) error {
+	for _, m := range ms {
+		var count int64
+		db.db.Model(&RepositoryCommit{}).Where("repository_uuid = ? AND sha = ?", m.RepositoryUuid, m.Sha).Count(&count)
+		if count > 0 {
+			continue
+		}
+		if err := db.db.Create(&m).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveRepositoryPullRequest stores a pull request or updates the one with its number
+func (db database) SaveRepositoryPullRequest(m RepositoryPullRequest) error {
+	result := db.db.Model(&RepositoryPullRequest{}).Where("repository_uuid = ? AND number = ?", m.RepositoryUuid, m.Number).Updates(map[string]interface{}{
+		"title":     m.Title,
+		"state":     m.State,
+		"author":    m.Author,
+		"branch":    m.Branch,
+		"url":       m.Url,
+		"bounty_id": m.BountyId,
+		"opened":    m.Opened,
+		"closed":    m.Closed,
+		"updated":   m.Updated,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return db.db.Create(&m).Error
+	}
+	return nil
+}
+
+// GetRepositoryCommits returns the latest commits of a repository
+func (db database) GetRepositoryCommits(repositoryUuid string, limit int) []RepositoryCommit {
+	ms := []RepositoryCommit{}
+	db.db.Where("repository_uuid = ?", repositoryUuid).Order("committed DESC").Limit(limit).Find(&ms)
+	return ms
+}
+
+// GetRepositoryPullRequests returns the pull requests of a repository, the open ones first then
+// the most recently updated
+func (db database) GetRepositoryPullRequests(repositoryUuid string, limit int) []RepositoryPullRequest {
+	ms := []RepositoryPullRequest{}
+	db.db.Where("repository_uuid = ?", repositoryUuid).Order("state = 'open' DESC, updated DESC").Limit(limit).Find(&ms)
+	return ms
+}
+
+// GetBountyPullRequests returns the pull requests linked to a bounty
+func (db database) GetBountyPullRequests(bountyId uint) []RepositoryPullRequest {
+	ms := []RepositoryPullRequest{}
+	db.db.Where("bounty_id = ?", bountyId).Order("updated DESC").Find(&ms)
+	return ms
+}
+
+// GetWorkspaceBountyByTicketUrl returns the bounty of a workspace made for an issue url
+func (db database) GetWorkspaceBountyByTicketUrl(workspaceUuid string, ticketUrl string) NewBounty {
+	m := NewBounty{}
+	db.db.Where("workspace_uuid = ? AND ticket_url = ?", workspaceUuid, ticketUrl).Order("id DESC").Limit(1).Find(&m)
+	return m
+}
+
+// CompleteBountyFromPullRequest marks a bounty completed with its merged pull request as the proof
+// of work. A pull request completes its bounty once, so false is returned when its proof was
+// recorded before, or when the bounty has no assignee or is already completed or paid
+func (db database) CompleteBountyFromPullRequest(bountyId uint, pullRequest RepositoryPullRequest) (NewBounty, bool, error) {
+	bounty := NewBounty{}
+	completed := false
+	err := db.db.Transaction(func(tx *gorm.DB) error {
+		tx.Where("id = ?", bountyId).Find(&bounty)
+		if bounty.ID == 0 || bounty.Assignee == "" || bounty.Completed || bounty.Paid {
+			return nil
+		}
+		var recorded int64
+		tx.Model(&BountyProof{}).Where("bounty_id = ? AND ? = ANY(links)", bountyId, pullRequest.Url).Count(&recorded)
+		if recorded > 0 {
+			return nil
+		}
+
+		now := time.Now()
+		if err := tx.Create(&BountyProof{
+			BountyId:      bounty.ID,
+			WorkspaceUuid: bounty.WorkspaceUuid,
+			SubmittedBy:   bounty.Assignee,
+			Description:   fmt.Sprintf("Merged pull request #%d: %s", pullRequest.Number, pullRequest.Title),
+			Links:         pq.StringArray{pullRequest.Url},
+			Status:        ProofSubmitted,
+			Created:       &now,
+			Updated:       &now,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&NewBounty{}).Where("id = ?", bounty.ID).Updates(map[string]interface{}{
+			"completed":       true,
+			"completion_date": &now,
+			"proof_status":    ProofSubmitted,
+		}).Error; err != nil {
+			return err
+		}
+		bounty.Completed = true
+		bounty.CompletionDate = &now
+		bounty.ProofStatus = ProofSubmitted
+		completed = true
+		return nil
+	})
+	if err != nil {
+		return NewBounty{}, false, err
+	}
+	return bounty, completed, nil
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"math"
+
+	"github.com/araddon/dateparse"
+)
+
+// ReputationScore weighs completed bounties, on-time delivery and endorsement ratings into one score,
+// ratings above 3 raise the score and ratings below it lower it
+func ReputationScore(stats ReputationStats) float64 {
+	score := float64(stats.CompletedBounties)*10 + float64(stats.OnTimeBounties)*5
+	if stats.Endorsements > 0 {
+		score += (stats.AverageRating - 3) * 5 * float64(stats.Endorsements)
+	}
+	if score < 0 {
+		return 0
+	}
+	return math.Round(score*100) / 100
+}
+
+// BountyOnTime reports if a completed bounty was finished before its expiry or estimated completion date,
+// bounties without a deadline count as on time
+func BountyOnTime(bounty NewBounty) bool {
+	if bounty.CompletionDate == nil {
+		return false
+	}
+	deadline := bounty.BountyExpires
+	if deadline == "" {
+		deadline = bounty.EstimatedCompletionDate
+	}
+	if deadline == "" {
+		return true
+	}
+	due, err := dateparse.ParseAny(deadline)
+	if err != nil {
+		return true
+	}
+	return !bounty.CompletionDate.After(due)
+}
+
+func (db database) GetReputationStats(pubkey string) ReputationStats {
+	bounties := []NewBounty{}
+	db.db.Where("assignee = ?", pubkey).Where("completed = ? OR paid = ?", true, true).Find(&bounties)
+
+	stats := ReputationStats{
+		CompletedBounties: int64(len(bounties)),
+	}
+	for _, bounty := range bounties {
+		if BountyOnTime(bounty) {
+			stats.OnTimeBounties++
+		}
+	}
+
+	summary := db.GetEndorsementSummary(pubkey)
+	stats.Endorsements = summary.Count
+	stats.AverageRating = summary.AverageRating
+	return stats
+}
+
+func (db database) GetBountyHunterPubkeys() []string {
+	pubkeys := []string{}
+	db.db.Model(&NewBounty{}).Distinct("assignee").Where("assignee != ''").Where("completed = ? OR paid = ?", true, true).Pluck("assignee", &pubkeys)
+	return pubkeys
+}
+
+func (db database) UpdateReputationScore(pubkey string, score float64) {
+	db.db.Model(&Person{}).Where("owner_pub_key = ?", pubkey).Update("reputation_score", score)
+}
@@ -0,0 +1,30 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReputationScore(t *testing.T) {
+	t.Run("Should test that completed and on time bounties raise the score", func(t *testing.T) {
+		assert.Equal(t, float64(0), ReputationScore(ReputationStats{}))
+		assert.Equal(t, float64(35), ReputationScore(ReputationStats{CompletedBounties: 2, OnTimeBounties: 3}))
+	})
+
+	t.Run("Should test that endorsement ratings move the score around the neutral rating", func(t *testing.T) {
+		assert.Equal(t, float64(30), ReputationScore(ReputationStats{CompletedBounties: 1, Endorsements: 2, AverageRating: 5}))
+		assert.Equal(t, float64(0), ReputationScore(ReputationStats{CompletedBounties: 1, Endorsements: 2, AverageRating: 1}))
+	})
+}
+
+func TestBountyOnTime(t *testing.T) {
+	completed := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	assert.False(t, BountyOnTime(NewBounty{}))
+	assert.True(t, BountyOnTime(NewBounty{CompletionDate: &completed}))
+	assert.True(t, BountyOnTime(NewBounty{CompletionDate: &completed, BountyExpires: "2024-03-15T00:00:00Z"}))
+	assert.False(t, BountyOnTime(NewBounty{CompletionDate: &completed, BountyExpires: "2024-03-01T00:00:00Z"}))
+	assert.False(t, BountyOnTime(NewBounty{CompletionDate: &completed, EstimatedCompletionDate: "2024-03-05"}))
+}
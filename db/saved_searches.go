@@ -0,0 +1,77 @@
+package db
+
+import (
+	"strings"
+	"time"
+)
+
+func (db database) CreateSavedSearch(m SavedSearch) (SavedSearch, error) {
+	now := time.Now()
+	m.Created = &now
+	m.Updated = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return SavedSearch{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetSavedSearches(pubkey string) []SavedSearch {
+	ms := []SavedSearch{}
+	db.db.Where("owner_pub_key = ?", pubkey).Order("created ASC").Find(&ms)
+	return ms
+}
+
+func (db database) DeleteSavedSearch(pubkey string, id uint) error {
+	return db.db.Where("id = ? AND owner_pub_key = ?", id, pubkey).Delete(&SavedSearch{}).Error
+}
+
+// GetSavedSearchesForBounty narrows the saved searches to the ones a bounty can match on price
+// and workspace, MatchesSavedSearch checks the rest
+func (db database) GetSavedSearchesForBounty(bounty NewBounty) []SavedSearch {
+	ms := []SavedSearch{}
+	db.db.Where("min_price <= ?", bounty.Price).
+		Where("(workspace_uuid = '' OR workspace_uuid IS NULL OR workspace_uuid = ?)", bounty.WorkspaceUuid).
+		Where("owner_pub_key <> ?", bounty.OwnerID).
+		Find(&ms)
+	return ms
+}
+
+// MatchesSavedSearch is true when a bounty meets every criteria of a saved search. Languages
+// match the coding languages of the bounty, skills its languages, title or description
+func MatchesSavedSearch(search SavedSearch, bounty NewBounty) bool {
+	if bounty.Price < search.MinPrice {
+		return false
+	}
+	if search.WorkspaceUuid != "" && search.WorkspaceUuid != bounty.WorkspaceUuid {
+		return false
+	}
+
+	languages := map[string]bool{}
+	for _, language := range bounty.CodingLanguages {
+		languages[strings.ToLower(language)] = true
+	}
+
+	if len(search.Languages) > 0 {
+		matched := false
+		for _, language := range search.Languages {
+			matched = matched || languages[strings.ToLower(language)]
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(search.Skills) > 0 {
+		text := strings.ToLower(bounty.Title + " " + bounty.Description)
+		matched := false
+		for _, skill := range search.Skills {
+			skill = strings.ToLower(skill)
+			matched = matched || languages[skill] || strings.Contains(text, skill)
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
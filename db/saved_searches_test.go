@@ -0,0 +1,26 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesSavedSearch(t *testing.T) {
+	bounty := NewBounty{
+		Title:           "Add a Rust client",
+		Description:     "Wrap the tribes API",
+		Price:           5000,
+		WorkspaceUuid:   "ws-1",
+		CodingLanguages: pq.StringArray{"Rust", "Go"},
+	}
+
+	assert.True(t, MatchesSavedSearch(SavedSearch{}, bounty))
+	assert.True(t, MatchesSavedSearch(SavedSearch{Languages: pq.StringArray{"go"}, MinPrice: 5000}, bounty))
+	assert.True(t, MatchesSavedSearch(SavedSearch{Skills: pq.StringArray{"api"}, WorkspaceUuid: "ws-1"}, bounty))
+	assert.False(t, MatchesSavedSearch(SavedSearch{Languages: pq.StringArray{"Python"}}, bounty))
+	assert.False(t, MatchesSavedSearch(SavedSearch{Skills: pq.StringArray{"design"}}, bounty))
+	assert.False(t, MatchesSavedSearch(SavedSearch{MinPrice: 10000}, bounty))
+	assert.False(t, MatchesSavedSearch(SavedSearch{WorkspaceUuid: "ws-2"}, bounty))
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"fmt"
+)
+
+// searchSource is how an entity is projected into search results, vector is the tsvector it is matched on,
+// key the column its id comes from and columns the ones its document is made of
+type searchSource struct {
+	projection string
+	table      string
+	vector     string
+	listed     string
+	key        string
+	columns    string
+}
+
+// the bounties and workspaces have no tsv column, their expressions are indexed by CreateSearchIndexes
+var searchSources = map[string]searchSource{
+	SearchTypeTribe: {
+		projection: "uuid AS id, name AS title, description, img",
+		table:      "tribes",
+		vector:     "tsv",
+		listed:     "(deleted = 'f' OR deleted is null) AND (unlisted = 'f' OR unlisted is null)",
+		key:        "uuid",
+		columns:    "name, description, img, deleted, unlisted",
+	},
+	SearchTypePerson: {
+		projection: "owner_pub_key AS id, owner_alias AS title, description, img",
+		table:      "people",
+		vector:     "tsv",
+		listed:     "(deleted = 'f' OR deleted is null) AND (unlisted = 'f' OR unlisted is null)",
+		key:        "owner_pub_key",
+		columns:    "owner_alias, description, img, deleted, unlisted",
+	},
+	SearchTypeBot: {
+		projection: "uuid AS id, name AS title, description, img",
+		table:      "bots",
+		vector:     "tsv",
+		listed:     "(deleted = 'f' OR deleted is null)",
+		key:        "uuid",
+		columns:    "name, description, img, deleted",
+	},
+	SearchTypeBounty: {
+		projection: "CAST(id AS text) AS id, title, description, '' AS img",
+		table:      "bounty",
+		vector:     "to_tsvector('english', COALESCE(title, '') || ' ' || COALESCE(description, ''))",
+		listed:     "show = true",
+		key:        "id",
+		columns:    "title, description, show",
+	},
+	SearchTypeWorkspace: {
+		projection: "uuid AS id, name AS title, description, img",
+		table:      "workspaces",
+		vector:     "to_tsvector('english', COALESCE(name, '') || ' ' || COALESCE(description, ''))",
+		listed:     "(deleted = false OR deleted is null)",
+		key:        "uuid",
+		columns:    "name, description, img, deleted",
+	},
+}
+
+// SearchEntities ranks the listed entities of a type matching a plain text query
+func (db database) SearchEntities(query string, entityType string, limit int) []SearchResult {
+	results := []SearchResult{}
+	source, ok := searchSources[entityType]
+	if !ok || query == "" {
+		return results
+	}
+
+	// the tsv columns are built with the default text search config, the expressions with english
+	tsquery := "plainto_tsquery(?)"
+	if source.vector != "tsv" {
+		tsquery = "plainto_tsquery('english', ?)"
+	}
+
+	db.db.Raw(fmt.Sprintf(`SELECT ? AS type, %s, ts_rank(%s, q) AS score
+	FROM %s, %s q WHERE %s @@ q AND %s
+	ORDER BY score DESC LIMIT ?`, source.projection, source.vector, source.table, tsquery, source.vector, source.listed),
+		entityType, query, limit).Scan(&results)
+
+	return results
+}
+
+// GetSearchDocuments pages through the listed entities of a type for an external search index
+func (db database) GetSearchDocuments(entityType string, offset int, limit int) []SearchResult {
+	results := []SearchResult{}
+	source, ok := searchSources[entityType]
+	if !ok {
+		return results
+	}
+
+	db.db.Raw(fmt.Sprintf(`SELECT ? AS type, %s FROM %s WHERE %s ORDER BY id LIMIT ? OFFSET ?`,
+		source.projection, source.table, source.listed), entityType, limit, offset).Scan(&results)
+
+	return results
+}
+
+// CreateSearchIndexes adds the GIN indexes the bounties and workspaces are searched with
+func (db database) CreateSearchIndexes() {
+	db.db.Exec("CREATE INDEX IF NOT EXISTS idx_bounty_search ON bounty USING GIN (" + searchSources[SearchTypeBounty].vector + ")")
+	db.db.Exec("CREATE INDEX IF NOT EXISTS idx_workspaces_search ON workspaces USING GIN (" + searchSources[SearchTypeWorkspace].vector + ")")
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// SetSearchIndexTriggers installs the triggers queueing the writes of the searched entities into
+// search_index_changes, or drops them when there is no external index to keep in sync. Updates only
+// queue a change when a column of the search document changed
+func (db database) SetSearchIndexTriggers(enabled bool) error {
+	if enabled {
+		err := db.db.Exec(`CREATE OR REPLACE FUNCTION search_index_change() RETURNS trigger AS $$
+		BEGIN
+			INSERT INTO search_index_changes (type, entity_id, created) VALUES (TG_ARGV[0],
+				(CASE WHEN TG_OP = 'DELETE' THEN to_jsonb(OLD) ELSE to_jsonb(NEW) END) ->> TG_ARGV[1], now());
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql`).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, entityType := range SearchTypes {
+		source := searchSources[entityType]
+		trigger := fmt.Sprintf("search_index_%s", entityType)
+
+		if err := db.db.Exec(fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s", trigger, source.table)).Error; err != nil {
+			return err
+		}
+		if !enabled {
+			continue
+		}
+
+		err := db.db.Exec(fmt.Sprintf(`CREATE TRIGGER %s AFTER INSERT OR DELETE OR UPDATE OF %s ON %s
+		FOR EACH ROW EXECUTE PROCEDURE search_index_change('%s', '%s')`,
+			trigger, source.columns, source.table, entityType, source.key)).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSearchIndexChanges returns the oldest queued changes
+func (db database) GetSearchIndexChanges(limit int) []SearchIndexChange {
+	changes := []SearchIndexChange{}
+	db.db.Order("id ASC").Limit(limit).Find(&changes)
+	return changes
+}
+
+// DeleteSearchIndexChanges drops the queued changes up to an id once they are synced
+func (db database) DeleteSearchIndexChanges(upTo uint) error {
+	return db.db.Where("id <= ?", upTo).Delete(&SearchIndexChange{}).Error
+}
+
+// GetSearchIndexBacklog counts the queued changes and returns when the oldest was queued
+func (db database) GetSearchIndexBacklog() SearchIndexBacklog {
+	backlog := SearchIndexBacklog{}
+	db.db.Model(&SearchIndexChange{}).Count(&backlog.Pending)
+
+	var oldest *time.Time
+	db.db.Raw("SELECT MIN(created) FROM search_index_changes").Row().Scan(&oldest)
+	backlog.Oldest = oldest
+	return backlog
+}
+
+// GetSearchDocumentsByID returns the search documents of entities by id, the ones no longer listed are left out
+func (db database) GetSearchDocumentsByID(entityType string, ids []string) []SearchResult {
+	results := []SearchResult{}
+	source, ok := searchSources[entityType]
+	if !ok || len(ids) == 0 {
+		return results
+	}
+
+	db.db.Raw(fmt.Sprintf(`SELECT ? AS type, %s FROM %s WHERE %s AND CAST(%s AS text) IN (?)`,
+		source.projection, source.table, source.listed, source.key), entityType, ids).Scan(&results)
+
+	return results
+}
+
+// CountSearchDocuments counts the listed entities of a type, to compare with an external index
+func (db database) CountSearchDocuments(entityType string) int64 {
+	var count int64
+	source, ok := searchSources[entityType]
+	if !ok {
+		return count
+	}
+
+	db.db.Raw(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", source.table, source.listed)).Scan(&count)
+	return count
+}
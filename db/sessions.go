@@ -0,0 +1,113 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+)
+
+// ErrSessionTokenReused is returned when the token a session is rotated from is no longer its current one
+var ErrSessionTokenReused = errors.New("the token was already rotated")
+
+// AuthSessionTTL matches the lifetime of a JWT, a session not refreshed within it is no longer active
+const AuthSessionTTL = 7 * 24 * time.Hour
+
+func (db database) CreateAuthSession(m AuthSession) (AuthSession, error) {
+	now := time.Now()
+	m.LastUsed = &now
+	m.Created = &now
+	m.Updated = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return AuthSession{}, err
+	}
+	return m, nil
+}
+
+// StartAuthSession creates a new session family for the owner of m and returns its first token
+func (db database) StartAuthSession(m AuthSession) (string, error) {
+	m.Uuid = xid.New().String()
+	m.CurrentJti = xid.New().String()
+	if m.DeviceName == "" {
+		m.DeviceName = m.UserAgent
+	}
+	session, err := db.CreateAuthSession(m)
+	if err != nil {
+		return "", err
+	}
+	return auth.EncodeSessionJwt(session.OwnerPubKey, session.Uuid, session.CurrentJti)
+}
+
+func (db database) GetAuthSession(uuid string) (AuthSession, error) {
+	m := AuthSession{}
+	db.db.Where("uuid = ?", uuid).Find(&m)
+	if m.ID == 0 {
+		return m, errors.New("no session found")
+	}
+	return m, nil
+}
+
+func (db database) GetActiveAuthSessions(pubkey string) []AuthSession {
+	ms := []AuthSession{}
+	db.db.Where("owner_pub_key = ?", pubkey).Where("revoked = ?", false).Where("last_used > ?", time.Now().Add(-AuthSessionTTL)).Order("last_used DESC").Find(&ms)
+	for i := range ms {
+		ms[i].Bound = ms[i].Fingerprint != ""
+	}
+	return ms
+}
+
+// TouchAuthSession records that a session was just used
+func (db database) TouchAuthSession(uuid string) error {
+	now := time.Now()
+	return db.db.Model(&AuthSession{}).Where("uuid = ?", uuid).Update("last_used", &now).Error
+}
+
+func (db database) RenameAuthSession(pubkey string, uuid string, deviceName string) error {
+	now := time.Now()
+	result := db.db.Model(&AuthSession{}).Where("owner_pub_key = ?", pubkey).Where("uuid = ?", uuid).Where("revoked = ?", false).Updates(map[string]interface{}{
+		"device_name": deviceName,
+		"updated":     &now,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("no session found")
+	}
+	return nil
+}
+
+// RotateAuthSession moves a session from the token that was refreshed to the next one, only one of two
+// refreshes with the same token can rotate it
+func (db database) RotateAuthSession(uuid string, currentJti string, jti string) error {
+	now := time.Now()
+	result := db.db.Model(&AuthSession{}).Where("uuid = ?", uuid).Where("revoked = ?", false).Where("current_jti = ?", currentJti).Updates(map[string]interface{}{
+		"current_jti": jti,
+		"last_used":   &now,
+		"updated":     &now,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSessionTokenReused
+	}
+	return nil
+}
+
+func (db database) RevokeAuthSession(pubkey string, uuid string) error {
+	now := time.Now()
+	result := db.db.Model(&AuthSession{}).Where("owner_pub_key = ?", pubkey).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"revoked": true,
+		"updated": &now,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("no session found")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+func (db database) CreateStakworkJob(job StakworkJob) (StakworkJob, error) {
+	now := time.Now()
+	job.Uuid = xid.New().String()
+	job.Token = utils.GetRandomToken(40)
+	job.Status = StakworkJobProcessing
+	job.Created = &now
+	job.Updated = &now
+	if err := db.db.Create(&job).Error; err != nil {
+		return StakworkJob{}, err
+	}
+	return job, nil
+}
+
+func (db database) GetStakworkJob(uuid string) StakworkJob {
+	m := StakworkJob{}
+	db.db.Where("uuid = ?", uuid).First(&m)
+	return m
+}
+
+func (db database) GetStakworkJobByToken(token string) StakworkJob {
+	m := StakworkJob{}
+	if token == "" {
+		return m
+	}
+	db.db.Where("token = ?", token).First(&m)
+	return m
+}
+
+// UpdateStakworkJob saves the project, the status and the outcome of a job
+func (db database) UpdateStakworkJob(job StakworkJob) error {
+	return db.db.Model(&StakworkJob{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+		"project_id": job.ProjectId,
+		"status":     job.Status,
+		"result":     job.Result,
+		"error":      job.Error,
+		"updated":    time.Now(),
+	}).Error
+}
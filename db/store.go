@@ -22,9 +22,11 @@ type StoreData struct {
 }
 
 type LnStore struct {
-	K1     string
-	Key    string
-	Status bool
+	K1          string
+	Key         string
+	Status      bool
+	DeviceName  string
+	Fingerprint string
 }
 
 var Store StoreData
@@ -102,6 +104,21 @@ func (s StoreData) GetBudgetInvoiceCache() ([]BudgetStoreData, error) {
 	return c, nil
 }
 
+func (s StoreData) SetFeedCache(url string, value string) error {
+	// Feeds are refreshed by the scheduler, keep them for an hour
+	s.Cache.Set(config.FeedCachePrefix+url, value, time.Hour)
+	return nil
+}
+
+func (s StoreData) GetFeedCache(url string) (string, error) {
+	value, found := s.Cache.Get(config.FeedCachePrefix + url)
+	c, _ := value.(string)
+	if !found || c == "" {
+		return "", errors.New("Feed Cache not found")
+	}
+	return c, nil
+}
+
 func (s StoreData) SetSocketConnections(value Client) error {
 	// The websocket in cache should not expire unless when deleted
 	s.Cache.Set(value.Host, value, cache.NoExpiration)
@@ -247,7 +264,11 @@ func Poll(w http.ResponseWriter, r *http.Request) {
 		"last_login": time.Now().Unix(),
 	})
 
-	tribeJWT, _ := auth.EncodeJwt(pld.Pubkey)
+	tribeJWT, _ := DB.StartAuthSession(AuthSession{
+		OwnerPubKey: pld.Pubkey,
+		UserAgent:   r.UserAgent(),
+		Fingerprint: auth.HashFingerprint(auth.ClientFingerprint(r)),
+	})
 	pld.TribeJWT = tribeJWT
 
 	// store.DeleteChallenge(challenge)
@@ -7,7 +7,6 @@ import (
 	"errors"
 	"time"
 
-	"github.com/gorilla/websocket"
 	"github.com/lib/pq"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
@@ -15,36 +14,41 @@ import (
 
 // Tribe struct
 type Tribe struct {
-	UUID            string         `json:"uuid"`
-	OwnerPubKey     string         `json:"owner_pubkey"`
-	OwnerAlias      string         `json:"owner_alias"`
-	GroupKey        string         `json:"group_key"`
-	Name            string         `json:"name"`
-	UniqueName      string         `json:"unique_name"`
-	Description     string         `json:"description"`
-	Tags            pq.StringArray `gorm:"type:text[]" json:"tags"`
-	Img             string         `json:"img"`
-	PriceToJoin     int64          `json:"price_to_join"`
-	PricePerMessage int64          `json:"price_per_message"`
-	EscrowAmount    int64          `json:"escrow_amount"`
-	EscrowMillis    int64          `json:"escrow_millis"`
-	Created         *time.Time     `json:"created"`
-	Updated         *time.Time     `json:"updated"`
-	MemberCount     uint64         `json:"member_count"`
-	Unlisted        bool           `json:"unlisted"`
-	Private         bool           `json:"private"`
-	Deleted         bool           `json:"deleted"`
-	AppURL          string         `json:"app_url"`
-	FeedURL         string         `json:"feed_url"`
-	SecondBrainUrl  string         `json:"second_brain_url"`
-	FeedType        uint64         `json:"feed_type"`
-	LastActive      int64          `json:"last_active"`
-	Bots            string         `json:"bots"`
-	OwnerRouteHint  string         `json:"owner_route_hint"`
-	Pin             string         `json:"pin"`
-	Preview         string         `json:"preview"`
-	ProfileFilters  string         `json:"profile_filters"` // "twitter,github"
-	Badges          pq.StringArray `gorm:"type:text[]" json:"badges"`
+	UUID             string         `json:"uuid"`
+	OwnerPubKey      string         `json:"owner_pubkey"`
+	OwnerAlias       string         `json:"owner_alias"`
+	GroupKey         string         `json:"group_key"`
+	Name             string         `json:"name"`
+	UniqueName       string         `json:"unique_name"`
+	Description      string         `json:"description"`
+	Tags             pq.StringArray `gorm:"type:text[]" json:"tags"`
+	Img              string         `json:"img"`
+	PriceToJoin      int64          `json:"price_to_join"`
+	PricePerMessage  int64          `json:"price_per_message"`
+	EscrowAmount     int64          `json:"escrow_amount"`
+	EscrowMillis     int64          `json:"escrow_millis"`
+	Created          *time.Time     `json:"created"`
+	Updated          *time.Time     `gorm:"index" json:"updated"`
+	MemberCount      uint64         `json:"member_count"`
+	Unlisted         bool           `json:"unlisted"`
+	Private          bool           `json:"private"`
+	Deleted          bool           `json:"deleted"`
+	AppURL           string         `json:"app_url"`
+	FeedURL          string         `json:"feed_url"`
+	SecondBrainUrl   string         `json:"second_brain_url"`
+	FeedType         uint64         `json:"feed_type"`
+	LastActive       int64          `json:"last_active"`
+	Bots             string         `json:"bots"`
+	OwnerRouteHint   string         `json:"owner_route_hint"`
+	Pin              string         `json:"pin"`
+	Preview          string         `json:"preview"`
+	ProfileFilters   string         `json:"profile_filters"` // "twitter,github"
+	Badges           pq.StringArray `gorm:"type:text[]" json:"badges"`
+	Featured         bool           `json:"featured"`
+	FeaturedPosition int            `json:"featured_position"`
+	Verified         bool           `json:"verified"`
+	PendingReview    bool           `gorm:"default:false;index" json:"pending_review"`
+	ReviewReasons    pq.StringArray `gorm:"type:text[]" json:"review_reasons,omitempty"`
 }
 
 // Bot struct
@@ -64,6 +68,12 @@ type Bot struct {
 	Deleted        bool           `json:"deleted"`
 	MemberCount    uint64         `json:"member_count"`
 	OwnerRouteHint string         `json:"owner_route_hint"`
+	Rating         float64        `json:"rating"`
+	ReviewCount    uint           `json:"review_count"`
+	Category       string         `gorm:"index" json:"category"`
+	Featured       bool           `gorm:"index" json:"featured"`
+	PendingReview  bool           `gorm:"default:false;index" json:"pending_review"`
+	ReviewReasons  pq.StringArray `gorm:"type:text[]" json:"review_reasons,omitempty"`
 	Tsv            string         `gorm:"type:tsvector"`
 }
 
@@ -77,6 +87,10 @@ type BotRes struct {
 	Tags        pq.StringArray `gorm:"type:text[]" json:"tags"`
 	Img         string         `json:"img"`
 	PricePerUse int64          `json:"price_per_use"`
+	Rating      float64        `json:"rating"`
+	ReviewCount uint           `json:"review_count"`
+	Category    string         `json:"category"`
+	Featured    bool           `json:"featured"`
 }
 
 // for bot pricing info
@@ -109,7 +123,7 @@ type Person struct {
 	Tags             pq.StringArray `gorm:"type:text[]" json:"tags" null`
 	Img              string         `json:"img"`
 	Created          *time.Time     `json:"created"`
-	Updated          *time.Time     `json:"updated"`
+	Updated          *time.Time     `gorm:"index" json:"updated"`
 	Unlisted         bool           `json:"unlisted"`
 	Deleted          bool           `json:"deleted"`
 	LastLogin        int64          `json:"last_login"`
@@ -119,8 +133,10 @@ type Person struct {
 	NewTicketTime    int64          `json:"new_ticket_time", gorm: "-:all"`
 	TwitterConfirmed bool           `json:"twitter_confirmed"`
 	ReferredBy       uint           `json:"referred_by"`
+	ReputationScore  float64        `gorm:"index;default:0" json:"reputation_score"`
 	Extras           PropertyMap    `json:"extras", type: jsonb not null default '{}'::jsonb`
 	GithubIssues     PropertyMap    `json:"github_issues", type: jsonb not null default '{}'::jsonb`
+	Privacy          PersonPrivacy  `gorm:"type:jsonb" json:"privacy,omitempty"`
 }
 
 type GormDataTypeInterface interface {
@@ -170,10 +186,26 @@ type Channel struct {
 	ID        uint       `json:"id"`
 	TribeUUID string     `json:"tribe_uuid"`
 	Name      string     `json:"name"`
+	Position  int        `json:"position"`
 	Created   *time.Time `json:"created"`
+	Updated   *time.Time `json:"updated"`
+	Archived  bool       `json:"archived"`
 	Deleted   bool       `json:"deleted"`
+	// who can post in the channel, a channel only its admins post in is an announcement channel
+	PostPermission ChannelPostPermission `gorm:"default:everyone" json:"post_permission"`
+	PostPubkeys    pq.StringArray        `gorm:"type:text[]" json:"post_pubkeys"`
 }
 
+type ChannelPostPermission string
+
+const (
+	ChannelPostEveryone ChannelPostPermission = "everyone"
+	// only the owner of the tribe posts
+	ChannelPostAdmins ChannelPostPermission = "admins"
+	// the owner of the tribe and the post pubkeys post
+	ChannelPostPubkeys ChannelPostPermission = "pubkeys"
+)
+
 type AssetTx struct {
 	Sender   string `json:"sender"`
 	Receiver string `json:"receiver"`
@@ -225,6 +257,12 @@ type ConnectionCodes struct {
 	ConnectionString string     `json:"connection_string"`
 	IsUsed           bool       `json:"is_used"`
 	DateCreated      *time.Time `json:"date_created"`
+	Campaign         string     `gorm:"index" json:"campaign"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	Revoked          bool       `gorm:"default:false" json:"revoked"`
+	RedeemedAt       *time.Time `json:"redeemed_at"`
+	RedeemedIp       string     `gorm:"index" json:"redeemed_ip,omitempty"`
+	RedeemedDevice   string     `gorm:"index" json:"redeemed_device,omitempty"`
 }
 
 type ConnectionCodesShort struct {
@@ -352,9 +390,15 @@ type YoutubeDownload struct {
 	YoutubeUrls []string `json:"youtube_urls"`
 }
 
+// SocketWriter sends a message over a websocket, the clients of the websocket pool lock the
+// connection since it takes a single writer at a time
+type SocketWriter interface {
+	WriteJSON(v interface{}) error
+}
+
 type Client struct {
 	Host string
-	Conn *websocket.Conn
+	Conn SocketWriter
 }
 
 type Bounty struct {
@@ -405,6 +449,7 @@ type NewBounty struct {
 	BountyExpires           string         `json:"bounty_expires"`
 	CommitmentFee           uint64         `json:"commitment_fee"`
 	Price                   uint           `json:"price"`
+	PriceFiat               FiatAmounts    `gorm:"type:jsonb" json:"price_fiat,omitempty"`
 	Title                   string         `json:"title"`
 	Tribe                   string         `json:"tribe"`
 	Assignee                string         `json:"assignee"`
@@ -419,14 +464,23 @@ type NewBounty struct {
 	EstimatedSessionLength  string         `json:"estimated_session_length"`
 	EstimatedCompletionDate string         `json:"estimated_completion_date"`
 	Created                 int64          `json:"created"`
-	Updated                 *time.Time     `json:"updated"`
+	Updated                 *time.Time     `gorm:"index" json:"updated"`
 	AssignedDate            *time.Time     `json:"assigned_date,omitempty"`
 	CompletionDate          *time.Time     `json:"completion_date,omitempty"`
 	MarkAsPaidDate          *time.Time     `json:"mark_as_paid_date,omitempty"`
 	PaidDate                *time.Time     `json:"paid_date,omitempty"`
+	PaidFiat                FiatAmounts    `gorm:"type:jsonb" json:"paid_fiat,omitempty"`
+	Escrow                  bool           `gorm:"default:false" json:"escrow"`
+	EscrowStatus            EscrowStatus   `json:"escrow_status,omitempty"`
+	ProofStatus             ProofStatus    `json:"proof_status,omitempty"`
+	TimeSpent               uint           `json:"time_spent"`
+	Deadline                *time.Time     `json:"deadline,omitempty"`
+	StaleAfter              string         `json:"stale_after,omitempty"`
+	ExpiryWarnedAt          *time.Time     `json:"-"`
 	CodingLanguages         pq.StringArray `gorm:"type:text[];not null default:'[]'" json:"coding_languages"`
 	PhaseUuid               string         `json:"phase_uuid"`
 	PhasePriority           int            `json:"phase_priority"`
+	TicketId                string         `gorm:"index" json:"ticket_id,omitempty"`
 }
 
 type BountyOwners struct {
@@ -478,6 +532,7 @@ type BountyResponse struct {
 	Owner        Person         `json:"owner"`
 	Organization WorkspaceShort `json:"organization"`
 	Workspace    WorkspaceShort `json:"workspace"`
+	Attachments  []Attachment   `json:"attachments,omitempty"`
 }
 
 type BountyCountResponse struct {
@@ -517,6 +572,9 @@ type Workspace struct {
 	Updated      *time.Time `json:"updated"`
 	Show         bool       `json:"show"`
 	Deleted      bool       `gorm:"default:false" json:"deleted"`
+	Archived     bool       `gorm:"default:false" json:"archived"`
+	ArchivedAt   *time.Time `json:"archived_at,omitempty"`
+	ArchivedBy   string     `json:"archived_by,omitempty"`
 	BountyCount  int64      `json:"bounty_count,omitempty"`
 	Budget       uint       `json:"budget,omitempty"`
 	Website      string     `json:"website" validate:"omitempty,uri"`
@@ -564,6 +622,9 @@ type WorkspaceRepositories struct {
 	WorkspaceUuid string     `gorm:"not null" json:"workspace_uuid"`
 	Name          string     `gorm:"not null" json:"name"`
 	Url           string     `json:"url"`
+	OpenPrCount   int        `json:"open_pr_count"`
+	LastSyncedAt  *time.Time `json:"last_synced_at,omitempty"`
+	SyncError     string     `json:"sync_error,omitempty"`
 	Created       *time.Time `json:"created"`
 	Updated       *time.Time `json:"updated"`
 	CreatedBy     string     `json:"created_by"`
@@ -590,14 +651,15 @@ type WorkspaceFeatures struct {
 }
 
 type FeaturePhase struct {
-	Uuid        string     `json:"uuid" gorm:"primary_key"`
-	FeatureUuid string     `json:"feature_uuid"`
-	Name        string     `json:"name"`
-	Priority    int        `json:"priority"`
-	Created     *time.Time `json:"created"`
-	Updated     *time.Time `json:"updated"`
-	CreatedBy   string     `json:"created_by"`
-	UpdatedBy   string     `json:"updated_by"`
+	Uuid          string     `json:"uuid" gorm:"primary_key"`
+	FeatureUuid   string     `json:"feature_uuid"`
+	Name          string     `json:"name"`
+	Priority      int        `json:"priority"`
+	EstimatedDays int        `json:"estimated_days"`
+	Created       *time.Time `json:"created"`
+	Updated       *time.Time `json:"updated"`
+	CreatedBy     string     `json:"created_by"`
+	UpdatedBy     string     `json:"updated_by"`
 }
 
 type BountyRoles struct {
@@ -640,18 +702,19 @@ type NewBountyBudget struct {
 }
 
 type StatusBudget struct {
-	OrgUuid             string `json:"org_uuid"`
-	WorkspaceUuid       string `json:"workspace_uuid"`
-	CurrentBudget       uint   `json:"current_budget"`
-	OpenBudget          uint   `json:"open_budget"`
-	OpenCount           int64  `json:"open_count"`
-	OpenDifference      int    `json:"open_difference"`
-	AssignedBudget      uint   `json:"assigned_budget"`
-	AssignedCount       int64  `json:"assigned_count"`
-	AssignedDifference  int    `json:"assigned_difference"`
-	CompletedBudget     uint   `json:"completed_budget"`
-	CompletedCount      int64  `json:"completed_count"`
-	CompletedDifference int    `json:"completed_difference"`
+	OrgUuid             string      `json:"org_uuid"`
+	WorkspaceUuid       string      `json:"workspace_uuid"`
+	CurrentBudget       uint        `json:"current_budget"`
+	OpenBudget          uint        `json:"open_budget"`
+	OpenCount           int64       `json:"open_count"`
+	OpenDifference      int         `json:"open_difference"`
+	AssignedBudget      uint        `json:"assigned_budget"`
+	AssignedCount       int64       `json:"assigned_count"`
+	AssignedDifference  int         `json:"assigned_difference"`
+	CompletedBudget     uint        `json:"completed_budget"`
+	CompletedCount      int64       `json:"completed_count"`
+	CompletedDifference int         `json:"completed_difference"`
+	CurrentBudgetFiat   FiatAmounts `json:"current_budget_fiat,omitempty"`
 }
 
 type BudgetInvoiceRequest struct {
@@ -733,6 +796,10 @@ type NewPaymentHistory struct {
 	Created        *time.Time  `json:"created"`
 	Updated        *time.Time  `json:"updated"`
 	Status         bool        `json:"status"`
+	Memo           string      `json:"memo,omitempty"`
+	Fiat           FiatAmounts `gorm:"type:jsonb" json:"fiat,omitempty"`
+	PaymentHash    string      `json:"payment_hash,omitempty"`
+	Preimage       string      `json:"preimage,omitempty"`
 }
 
 type PaymentHistoryData struct {
@@ -876,6 +943,8 @@ type BountyMetrics struct {
 	AverageCompleted       uint  `json:"average_completed"`
 	UniqueHuntersPaid      int64 `json:"unique_hunters_paid"`
 	NewHuntersPaid         int64 `json:"new_hunters_paid"`
+	// ComputedAt is when the roll-ups the metrics were read from were computed, unset for live metrics
+	ComputedAt *time.Time `json:"computed_at,omitempty" structs:",omitempty"`
 }
 
 type MetricsBountyCsv struct {
@@ -898,6 +967,1877 @@ type FilterStattuCount struct {
 	Paid      int64 `json:"paid"`
 }
 
+type NotificationEvent string
+
+const (
+	NotificationBountyAssigned        NotificationEvent = "bounty_assigned"
+	NotificationPaymentReceived       NotificationEvent = "payment_received"
+	NotificationTicketReviewRequested NotificationEvent = "ticket_review_requested"
+	NotificationBudgetLow             NotificationEvent = "budget_low"
+	NotificationDisputeOpened         NotificationEvent = "dispute_opened"
+	NotificationDisputeEvidence       NotificationEvent = "dispute_evidence"
+	NotificationDisputeResolved       NotificationEvent = "dispute_resolved"
+	NotificationProofSubmitted        NotificationEvent = "proof_submitted"
+	NotificationProofReviewed         NotificationEvent = "proof_reviewed"
+	NotificationAssignmentExpiring    NotificationEvent = "assignment_expiring"
+	NotificationAssignmentExpired     NotificationEvent = "assignment_expired"
+	NotificationSavedSearchMatch      NotificationEvent = "saved_search_match"
+	NotificationTicketMention         NotificationEvent = "ticket_mention"
+	NotificationWorkflow              NotificationEvent = "workflow"
+	NotificationContentModerated      NotificationEvent = "content_moderated"
+	NotificationAppealResolved        NotificationEvent = "appeal_resolved"
+	NotificationImpersonated          NotificationEvent = "impersonated"
+	NotificationWorkspaceTransfer     NotificationEvent = "workspace_transfer"
+	NotificationPullRequestMerged     NotificationEvent = "pull_request_merged"
+)
+
+type Notification struct {
+	ID          uint              `json:"id"`
+	Uuid        string            `gorm:"unique;not null" json:"uuid"`
+	OwnerPubKey string            `gorm:"index" json:"owner_pubkey"`
+	Event       NotificationEvent `json:"event"`
+	Title       string            `json:"title"`
+	Content     string            `json:"content"`
+	Link        string            `json:"link"`
+	Read        bool              `gorm:"default:false" json:"read"`
+	Created     *time.Time        `json:"created"`
+	Updated     *time.Time        `json:"updated"`
+}
+
+type NotificationSettings struct {
+	ID             uint           `json:"id"`
+	OwnerPubKey    string         `gorm:"uniqueIndex" json:"owner_pubkey"`
+	Websocket      bool           `json:"websocket"`
+	Email          bool           `json:"email"`
+	SphinxPush     bool           `json:"sphinx_push"`
+	DisabledEvents pq.StringArray `gorm:"type:text[]" json:"disabled_events"`
+	Created        *time.Time     `json:"created"`
+	Updated        *time.Time     `json:"updated"`
+}
+
+type NotificationsResponse struct {
+	Total         int64          `json:"total"`
+	Unread        int64          `json:"unread"`
+	Notifications []Notification `json:"notifications"`
+}
+
+type IdempotencyKey struct {
+	ID          uint       `json:"id"`
+	Key         string     `gorm:"uniqueIndex:idx_idempotency_key_owner;not null" json:"key"`
+	OwnerPubKey string     `gorm:"uniqueIndex:idx_idempotency_key_owner" json:"owner_pubkey"`
+	Method      string     `json:"method"`
+	Path        string     `json:"path"`
+	RequestHash string     `json:"request_hash"`
+	StatusCode  int        `json:"status_code"`
+	Response    string     `json:"response"`
+	Created     *time.Time `gorm:"index" json:"created"`
+	Updated     *time.Time `json:"updated"`
+}
+
+type ApiKey struct {
+	ID          uint           `json:"id"`
+	Uuid        string         `gorm:"unique;not null" json:"uuid"`
+	OwnerPubKey string         `gorm:"index" json:"owner_pubkey"`
+	Name        string         `json:"name"`
+	Prefix      string         `json:"prefix"`
+	KeyHash     string         `gorm:"uniqueIndex" json:"-"`
+	Scopes      pq.StringArray `gorm:"type:text[]" json:"scopes"`
+	Revoked     bool           `gorm:"default:false" json:"revoked"`
+	LastUsed    *time.Time     `json:"last_used"`
+	Created     *time.Time     `json:"created"`
+	Updated     *time.Time     `json:"updated"`
+}
+
+type ApiKeyResponse struct {
+	ApiKey
+	Key string `json:"key"`
+}
+
+type AuthSession struct {
+	ID          uint       `json:"id"`
+	Uuid        string     `gorm:"unique;not null" json:"uuid"`
+	OwnerPubKey string     `gorm:"index" json:"owner_pubkey"`
+	CurrentJti  string     `json:"-"`
+	UserAgent   string     `json:"user_agent"`
+	DeviceName  string     `json:"device_name"`
+	Fingerprint string     `json:"-"`
+	Bound       bool       `gorm:"-" json:"bound"`
+	Current     bool       `gorm:"-" json:"current"`
+	Revoked     bool       `gorm:"default:false" json:"revoked"`
+	LastUsed    *time.Time `json:"last_used"`
+	Created     *time.Time `json:"created"`
+	Updated     *time.Time `json:"updated"`
+}
+
+type NostrIdentity struct {
+	ID          uint       `json:"id"`
+	NostrPubkey string     `gorm:"uniqueIndex" json:"nostr_pubkey"`
+	Npub        string     `json:"npub"`
+	OwnerPubKey string     `gorm:"index" json:"owner_pubkey"`
+	Created     *time.Time `json:"created"`
+}
+
+type SuperAdmin struct {
+	ID          uint       `json:"id"`
+	OwnerPubKey string     `gorm:"uniqueIndex" json:"owner_pubkey"`
+	AddedBy     string     `json:"added_by"`
+	Created     *time.Time `json:"created"`
+}
+
+type ActivityType string
+
+const (
+	ActivityBountyCreated   ActivityType = "bounty_created"
+	ActivityBountyAssigned  ActivityType = "bounty_assigned"
+	ActivityBountyCompleted ActivityType = "bounty_completed"
+	ActivityPaymentReceived ActivityType = "payment_received"
+	ActivityTribeCreated    ActivityType = "tribe_created"
+)
+
+type Activity struct {
+	ID          uint         `json:"id"`
+	OwnerPubKey string       `gorm:"index:idx_activity_owner_created" json:"owner_pubkey"`
+	Type        ActivityType `json:"type"`
+	Title       string       `json:"title"`
+	Link        string       `json:"link"`
+	Amount      uint         `json:"amount"`
+	Created     *time.Time   `gorm:"index:idx_activity_owner_created" json:"created"`
+}
+
+type ActivitiesResponse struct {
+	Total      int64      `json:"total"`
+	Activities []Activity `json:"activities"`
+}
+
+const (
+	DeletionKindTribe  = "tribe"
+	DeletionKindPerson = "person"
+	DeletionKindBounty = "bounty"
+)
+
+// Deletion is a tombstone for a removed record, delta sync clients use it to drop their local copy
+type Deletion struct {
+	ID       uint       `json:"-"`
+	Kind     string     `gorm:"index:idx_deletion_kind_deleted" json:"kind"`
+	RecordID string     `json:"record_id"`
+	Deleted  *time.Time `gorm:"index:idx_deletion_kind_deleted" json:"deleted"`
+}
+
+type DeltaResponse struct {
+	Updated   interface{} `json:"updated"`
+	Deletions []Deletion  `json:"deletions"`
+	SyncedAt  int64       `json:"synced_at"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type PeopleFacets struct {
+	CodingLanguages []FacetCount `json:"coding_languages"`
+	Skills          []FacetCount `json:"skills"`
+}
+
+type Endorsement struct {
+	ID             uint       `json:"id"`
+	BountyID       uint       `gorm:"uniqueIndex" json:"bounty_id"`
+	WorkspaceUuid  string     `json:"workspace_uuid"`
+	EndorserPubKey string     `json:"endorser_pubkey"`
+	PersonPubKey   string     `gorm:"index" json:"person_pubkey"`
+	Rating         int        `json:"rating"`
+	Testimonial    string     `json:"testimonial"`
+	Created        *time.Time `json:"created"`
+}
+
+type EndorsementSummary struct {
+	Count         int64   `json:"count"`
+	AverageRating float64 `json:"average_rating"`
+}
+
+type PersonWithEndorsements struct {
+	Person
+	Endorsements EndorsementSummary `json:"endorsements"`
+}
+
+type ReputationStats struct {
+	CompletedBounties int64   `json:"completed_bounties"`
+	OnTimeBounties    int64   `json:"on_time_bounties"`
+	Endorsements      int64   `json:"endorsements"`
+	AverageRating     float64 `json:"average_rating"`
+}
+
+// AccountPurge schedules the removal of the data kept for a deleted account once the grace period ends
+type AccountPurge struct {
+	ID          uint       `json:"id"`
+	OwnerPubKey string     `gorm:"uniqueIndex" json:"owner_pubkey"`
+	PurgeAfter  *time.Time `gorm:"index" json:"purge_after"`
+	Created     *time.Time `json:"created"`
+}
+
+type PersonExport struct {
+	Profile          Person              `json:"profile"`
+	CreatedBounties  []NewBounty         `json:"created_bounties"`
+	AssignedBounties []NewBounty         `json:"assigned_bounties"`
+	Payments         []NewPaymentHistory `json:"payments"`
+	Notifications    []Notification      `json:"notifications"`
+	Activities       []Activity          `json:"activities"`
+	Endorsements     []Endorsement       `json:"endorsements"`
+	ApiKeys          []ApiKey            `json:"api_keys"`
+	Sessions         []AuthSession       `json:"sessions"`
+	NostrIdentities  []NostrIdentity     `json:"nostr_identities"`
+	ExportedAt       *time.Time          `json:"exported_at"`
+}
+
+type LedgerAccount string
+
+const (
+	// LedgerBudget is the workspace budget, its balance is the spendable budget
+	LedgerBudget LedgerAccount = "workspace_budget"
+	// LedgerLightning is where deposits come from and withdrawals go to
+	LedgerLightning LedgerAccount = "lightning"
+	// LedgerHunter receives bounty payments
+	LedgerHunter LedgerAccount = "bounty_hunter"
+	// LedgerOpening balances the budgets that existed before the ledger
+	LedgerOpening LedgerAccount = "opening_balance"
+)
+
+type LedgerEntry struct {
+	ID              uint          `json:"id"`
+	TransactionUuid string        `gorm:"index" json:"transaction_uuid"`
+	WorkspaceUuid   string        `gorm:"index" json:"workspace_uuid"`
+	Account         LedgerAccount `json:"account"`
+	Counterparty    string        `json:"counterparty"`
+	Debit           uint          `json:"debit"`
+	Credit          uint          `json:"credit"`
+	PaymentType     PaymentType   `json:"payment_type"`
+	BountyId        uint          `json:"bounty_id"`
+	Created         *time.Time    `gorm:"index" json:"created"`
+}
+
+type LedgerLine struct {
+	LedgerEntry
+	Balance int64 `json:"balance"`
+}
+
+type WorkspaceLedger struct {
+	WorkspaceUuid string       `json:"workspace_uuid"`
+	Balance       int64        `json:"balance"`
+	StoredBudget  uint         `json:"stored_budget"`
+	Entries       []LedgerLine `json:"entries"`
+}
+
+type WorkspaceBudgetSettings struct {
+	ID                  uint       `json:"id"`
+	WorkspaceUuid       string     `gorm:"uniqueIndex" json:"workspace_uuid"`
+	LowBalanceThreshold uint       `json:"low_balance_threshold"`
+	DailyCap            uint       `json:"daily_cap"`
+	PerUserDailyCap     uint       `json:"per_user_daily_cap"`
+	LowBalanceAlerted   bool       `json:"low_balance_alerted"`
+	Created             *time.Time `json:"created"`
+	Updated             *time.Time `json:"updated"`
+}
+
+type PaymentAttemptStatus string
+
+const (
+	PaymentAttemptSucceeded PaymentAttemptStatus = "succeeded"
+	PaymentAttemptFailed    PaymentAttemptStatus = "failed"
+	// the attempt failed transiently and is retried at NextRetry
+	PaymentAttemptRetrying PaymentAttemptStatus = "retrying"
+	// the retry of the attempt has been picked up by the retry worker
+	PaymentAttemptRetried   PaymentAttemptStatus = "retried"
+	PaymentAttemptCancelled PaymentAttemptStatus = "cancelled"
+)
+
+type PaymentAttempt struct {
+	ID             uint                 `json:"id"`
+	BountyId       uint                 `gorm:"index" json:"bounty_id"`
+	WorkspaceUuid  string               `json:"workspace_uuid"`
+	SenderPubKey   string               `json:"sender_pubkey"`
+	ReceiverPubKey string               `json:"receiver_pubkey"`
+	Amount         uint                 `json:"amount"`
+	Attempt        int                  `json:"attempt"`
+	Status         PaymentAttemptStatus `gorm:"index" json:"status"`
+	FailureKind    string               `json:"failure_kind,omitempty"`
+	Error          string               `json:"error,omitempty"`
+	NextRetry      *time.Time           `gorm:"index" json:"next_retry,omitempty"`
+	Created        *time.Time           `json:"created"`
+}
+
+// EarningRecord is a bounty payment received by a person, with the fiat value at receipt
+type EarningRecord struct {
+	PaymentId     uint        `json:"payment_id"`
+	BountyId      uint        `json:"bounty_id"`
+	BountyTitle   string      `json:"bounty_title"`
+	WorkspaceUuid string      `json:"workspace_uuid"`
+	WorkspaceName string      `json:"workspace_name"`
+	SenderPubKey  string      `json:"sender_pubkey"`
+	Amount        uint        `json:"amount"`
+	Fiat          FiatAmounts `json:"fiat,omitempty"`
+	Created       *time.Time  `json:"created"`
+}
+
+type MonthlyEarning struct {
+	Month         string      `json:"month"`
+	WorkspaceUuid string      `json:"workspace_uuid"`
+	WorkspaceName string      `json:"workspace_name"`
+	Amount        uint        `json:"amount"`
+	Count         int         `json:"count"`
+	Fiat          FiatAmounts `json:"fiat,omitempty"`
+}
+
+type PersonEarnings struct {
+	TotalAmount uint             `json:"total_amount"`
+	TotalFiat   FiatAmounts      `json:"total_fiat,omitempty"`
+	Months      []MonthlyEarning `json:"months"`
+}
+
+type DisputeStatus string
+
+const (
+	DisputeOpen      DisputeStatus = "open"
+	DisputeResolved  DisputeStatus = "resolved"
+	DisputeWithdrawn DisputeStatus = "withdrawn"
+)
+
+type DisputeOutcome string
+
+const (
+	// DisputePayAssignee pays the bounty to its assignee from the workspace budget
+	DisputePayAssignee DisputeOutcome = "pay_assignee"
+	// DisputeUnassign removes the assignee so the bounty can be taken again
+	DisputeUnassign DisputeOutcome = "unassign"
+	// DisputeDismissed closes the dispute without changing the bounty
+	DisputeDismissed DisputeOutcome = "dismissed"
+)
+
+type BountyDispute struct {
+	ID            uint              `json:"id"`
+	Uuid          string            `gorm:"unique;not null" json:"uuid"`
+	BountyId      uint              `gorm:"index" json:"bounty_id"`
+	WorkspaceUuid string            `gorm:"index" json:"workspace_uuid"`
+	OpenedBy      string            `json:"opened_by"`
+	Respondent    string            `json:"respondent"`
+	Reason        string            `json:"reason"`
+	Status        DisputeStatus     `gorm:"index" json:"status"`
+	Outcome       DisputeOutcome    `json:"outcome,omitempty"`
+	Resolution    string            `json:"resolution,omitempty"`
+	ResolvedBy    string            `json:"resolved_by,omitempty"`
+	ResolvedAt    *time.Time        `json:"resolved_at,omitempty"`
+	Created       *time.Time        `json:"created"`
+	Updated       *time.Time        `json:"updated"`
+	Evidence      []DisputeEvidence `gorm:"-" json:"evidence"`
+}
+
+type DisputeEvidence struct {
+	ID          uint       `json:"id"`
+	DisputeId   uint       `gorm:"index" json:"dispute_id"`
+	SubmittedBy string     `json:"submitted_by"`
+	Content     string     `json:"content"`
+	Link        string     `json:"link,omitempty"`
+	Created     *time.Time `json:"created"`
+}
+
+type DisputeRequest struct {
+	Reason string `json:"reason"`
+}
+
+type DisputeEvidenceRequest struct {
+	Content string `json:"content"`
+	Link    string `json:"link"`
+}
+
+type DisputeResolutionRequest struct {
+	Outcome    DisputeOutcome `json:"outcome"`
+	Resolution string         `json:"resolution"`
+}
+
+type EscrowStatus string
+
+const (
+	EscrowAwaitingFunding EscrowStatus = "awaiting_funding"
+	// EscrowFunded escrows hold the payment of the workspace until the work is approved or rejected
+	EscrowFunded EscrowStatus = "funded"
+	// EscrowSettled escrows were approved and taken from the payer, the payout to the assignee is pending
+	EscrowSettled EscrowStatus = "settled"
+	// EscrowPaying escrows have a payout to the assignee in flight or sent but not recorded yet
+	EscrowPaying   EscrowStatus = "paying"
+	EscrowReleased EscrowStatus = "released"
+	EscrowRefunded EscrowStatus = "refunded"
+)
+
+// BountyEscrow is a hold invoice the workspace pays when a bounty is assigned, its preimage never leaves the server
+type BountyEscrow struct {
+	ID             uint         `json:"id"`
+	BountyId       uint         `gorm:"index" json:"bounty_id"`
+	WorkspaceUuid  string       `json:"workspace_uuid"`
+	Assignee       string       `json:"assignee"`
+	Amount         uint         `json:"amount"`
+	PaymentRequest string       `json:"payment_request"`
+	PaymentHash    string       `gorm:"unique" json:"payment_hash"`
+	Preimage       string       `json:"-"`
+	Status         EscrowStatus `gorm:"index" json:"status"`
+	PayoutHash     string       `json:"payout_hash,omitempty"`
+	PayoutPreimage string       `json:"-"`
+	CreatedBy      string       `json:"created_by"`
+	Created        *time.Time   `json:"created"`
+	Updated        *time.Time   `json:"updated"`
+}
+
+type ProofStatus string
+
+const (
+	ProofSubmitted        ProofStatus = "submitted"
+	ProofAccepted         ProofStatus = "accepted"
+	ProofChangesRequested ProofStatus = "changes_requested"
+)
+
+// BountyProof is a delivery of the work on a bounty, the owner accepts it or asks for changes
+type BountyProof struct {
+	ID            uint           `json:"id"`
+	BountyId      uint           `gorm:"index" json:"bounty_id"`
+	WorkspaceUuid string         `json:"workspace_uuid"`
+	SubmittedBy   string         `json:"submitted_by"`
+	Description   string         `json:"description"`
+	Links         pq.StringArray `gorm:"type:text[]" json:"links"`
+	Files         pq.StringArray `gorm:"type:text[]" json:"files"`
+	Status        ProofStatus    `gorm:"index" json:"status"`
+	ReviewedBy    string         `json:"reviewed_by,omitempty"`
+	ReviewComment string         `json:"review_comment,omitempty"`
+	ReviewedAt    *time.Time     `json:"reviewed_at,omitempty"`
+	Created       *time.Time     `json:"created"`
+	Updated       *time.Time     `json:"updated"`
+}
+
+type ProofRequest struct {
+	Description string   `json:"description"`
+	Links       []string `json:"links"`
+	Files       []string `json:"files"`
+}
+
+type ProofReviewRequest struct {
+	Status  ProofStatus `json:"status"`
+	Comment string      `json:"comment"`
+}
+
+type WorkSessionStatus string
+
+const (
+	WorkSessionRunning WorkSessionStatus = "running"
+	WorkSessionPaused  WorkSessionStatus = "paused"
+	WorkSessionStopped WorkSessionStatus = "stopped"
+)
+
+// BountyWorkSession is the time a hunter tracked on a bounty from start to stop, Seconds
+// holds the time of the runs that were paused, a running session adds the time since LastStarted
+type BountyWorkSession struct {
+	ID            uint              `json:"id"`
+	BountyId      uint              `gorm:"index" json:"bounty_id"`
+	WorkspaceUuid string            `gorm:"index" json:"workspace_uuid"`
+	Hunter        string            `gorm:"index" json:"hunter"`
+	Status        WorkSessionStatus `json:"status"`
+	Seconds       uint              `json:"seconds"`
+	LastStarted   *time.Time        `json:"last_started"`
+	Started       *time.Time        `gorm:"index" json:"started"`
+	Stopped       *time.Time        `json:"stopped,omitempty"`
+	Updated       *time.Time        `json:"updated"`
+}
+
+type BountyTimeSpent struct {
+	TimeSpent uint                `json:"time_spent"`
+	Sessions  []BountyWorkSession `json:"sessions"`
+}
+
+type WeeklyWorkHours struct {
+	Week     string  `json:"week"`
+	Hunter   string  `json:"hunter"`
+	Seconds  uint    `json:"seconds"`
+	Hours    float64 `json:"hours"`
+	Sessions int     `json:"sessions"`
+}
+
+type AssignmentExtensionRequest struct {
+	Deadline   *time.Time `json:"deadline"`
+	StaleAfter string     `json:"stale_after"`
+}
+
+// SavedSearch is a bounty filter a person is alerted about, empty criteria match every bounty
+type SavedSearch struct {
+	ID            uint           `json:"id"`
+	OwnerPubKey   string         `gorm:"index" json:"owner_pubkey"`
+	Name          string         `json:"name"`
+	Skills        pq.StringArray `gorm:"type:text[]" json:"skills"`
+	Languages     pq.StringArray `gorm:"type:text[]" json:"languages"`
+	MinPrice      uint           `json:"min_price"`
+	WorkspaceUuid string         `json:"workspace_uuid"`
+	Created       *time.Time     `json:"created"`
+	Updated       *time.Time     `json:"updated"`
+}
+
+type SavedSearchRequest struct {
+	Name          string   `json:"name"`
+	Skills        []string `json:"skills"`
+	Languages     []string `json:"languages"`
+	MinPrice      uint     `json:"min_price"`
+	WorkspaceUuid string   `json:"workspace_uuid"`
+}
+
+type BountyImportRow struct {
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Type            string     `json:"type"`
+	Price           uint       `json:"price"`
+	CodingLanguages []string   `json:"coding_languages"`
+	TicketUrl       string     `json:"ticket_url"`
+	Deadline        *time.Time `json:"deadline"`
+	StaleAfter      string     `json:"stale_after"`
+}
+
+// BountyImportError is a problem with a row of an import, rows count from 1
+type BountyImportError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+type BountyImportReport struct {
+	DryRun   bool                `json:"dry_run"`
+	Rows     int                 `json:"rows"`
+	Imported int                 `json:"imported"`
+	Errors   []BountyImportError `json:"errors"`
+}
+
+// BountyExportFilter narrows an export, the dates apply to the payment of paid bounties
+// and to the creation of the others
+type BountyExportFilter struct {
+	Status string
+	From   *time.Time
+	To     *time.Time
+}
+
+type LeaderboardKind string
+
+const (
+	LeaderboardEarners    LeaderboardKind = "earners"
+	LeaderboardWorkspaces LeaderboardKind = "workspaces"
+)
+
+type LeaderboardWindow string
+
+const (
+	LeaderboardWeekly  LeaderboardWindow = "weekly"
+	LeaderboardMonthly LeaderboardWindow = "monthly"
+	LeaderboardAllTime LeaderboardWindow = "all_time"
+)
+
+// LeaderboardEntry is a ranked earner or paying workspace, the entries are recomputed
+// by a scheduled job instead of on every request
+type LeaderboardEntry struct {
+	ID         uint              `json:"-"`
+	Kind       LeaderboardKind   `gorm:"index:idx_leaderboard_rank" json:"kind"`
+	Window     LeaderboardWindow `gorm:"column:time_window;index:idx_leaderboard_rank" json:"window"`
+	Rank       int               `gorm:"index:idx_leaderboard_rank" json:"rank"`
+	Key        string            `gorm:"column:entry_key" json:"key"`
+	Name       string            `json:"name"`
+	Img        string            `json:"img"`
+	Amount     uint              `json:"amount"`
+	Bounties   int               `json:"bounties"`
+	ComputedAt *time.Time        `json:"computed_at"`
+}
+
+type FunnelStage string
+
+const (
+	FunnelPosted         FunnelStage = "posted"
+	FunnelAssigned       FunnelStage = "assigned"
+	FunnelProofSubmitted FunnelStage = "proof_submitted"
+	FunnelPaid           FunnelStage = "paid"
+)
+
+// BountyFunnelRow holds the stage timestamps of one bounty
+type BountyFunnelRow struct {
+	ID             uint       `json:"id"`
+	Created        int64      `json:"created"`
+	Assignee       string     `json:"assignee"`
+	AssignedDate   *time.Time `json:"assigned_date"`
+	ProofSubmitted *time.Time `json:"proof_submitted"`
+	Paid           bool       `json:"paid"`
+	PaidDate       *time.Time `json:"paid_date"`
+}
+
+type BountyFunnelStage struct {
+	Stage         FunnelStage `json:"stage"`
+	Count         int64       `json:"count"`
+	Percentage    uint        `json:"percentage"`
+	MedianSeconds int64       `json:"median_seconds"`
+}
+
+type BountyFunnel struct {
+	Stages []BountyFunnelStage `json:"stages"`
+}
+
+// MetricsDailyRollup holds the bounty totals of a workspace for the bounties created on a day
+type MetricsDailyRollup struct {
+	ID               uint      `json:"id"`
+	Day              time.Time `gorm:"type:date;uniqueIndex:idx_metrics_daily_rollup" json:"day"`
+	WorkspaceUuid    string    `gorm:"uniqueIndex:idx_metrics_daily_rollup" json:"workspace_uuid"`
+	BountiesPosted   int64     `json:"bounties_posted"`
+	BountiesAssigned int64     `json:"bounties_assigned"`
+	BountiesPaid     int64     `json:"bounties_paid"`
+	SatsPosted       uint      `json:"sats_posted"`
+	SatsPaid         uint      `json:"sats_paid"`
+	PaidSeconds      float64   `json:"paid_seconds"`
+	PaidCount        int64     `json:"paid_count"`
+	CompletedSeconds float64   `json:"completed_seconds"`
+	CompletedCount   int64     `json:"completed_count"`
+	ComputedAt       time.Time `json:"computed_at"`
+}
+
+// MetricsHunterRollup holds what a hunter was paid by a workspace for the bounties created on a day
+type MetricsHunterRollup struct {
+	ID            uint      `json:"id"`
+	Day           time.Time `gorm:"type:date;uniqueIndex:idx_metrics_hunter_rollup" json:"day"`
+	WorkspaceUuid string    `gorm:"uniqueIndex:idx_metrics_hunter_rollup" json:"workspace_uuid"`
+	Hunter        string    `gorm:"uniqueIndex:idx_metrics_hunter_rollup" json:"hunter"`
+	Bounties      int64     `json:"bounties"`
+	Sats          uint      `json:"sats"`
+}
+
+// MetricsProviderRollup holds the bounties a provider created on a day by status
+type MetricsProviderRollup struct {
+	ID            uint      `json:"id"`
+	Day           time.Time `gorm:"type:date;uniqueIndex:idx_metrics_provider_rollup" json:"day"`
+	WorkspaceUuid string    `gorm:"uniqueIndex:idx_metrics_provider_rollup" json:"workspace_uuid"`
+	Provider      string    `gorm:"uniqueIndex:idx_metrics_provider_rollup" json:"provider"`
+	Open          int64     `json:"open"`
+	Assigned      int64     `json:"assigned"`
+	Paid          int64     `json:"paid"`
+	Sats          uint      `json:"sats"`
+}
+
+type MetricsInterval string
+
+const (
+	MetricsDaily  MetricsInterval = "day"
+	MetricsWeekly MetricsInterval = "week"
+)
+
+// MetricsSeriesValue is the value of one time series in one bucket
+type MetricsSeriesValue struct {
+	Series string    `json:"series"`
+	Bucket time.Time `json:"bucket"`
+	Value  int64     `json:"value"`
+}
+
+type MetricsTimeSeriesPoint struct {
+	Bucket          string `json:"bucket"`
+	BountiesCreated int64  `json:"bounties_created"`
+	SatsPaid        int64  `json:"sats_paid"`
+	NewPeople       int64  `json:"new_people"`
+}
+
+type MetricsTimeSeries struct {
+	Interval MetricsInterval          `json:"interval"`
+	From     string                   `json:"from"`
+	To       string                   `json:"to"`
+	Points   []MetricsTimeSeriesPoint `json:"points"`
+}
+
+const (
+	SearchTypeTribe     = "tribe"
+	SearchTypePerson    = "person"
+	SearchTypeBot       = "bot"
+	SearchTypeBounty    = "bounty"
+	SearchTypeWorkspace = "workspace"
+)
+
+// SearchTypes are the entities the unified search covers, in the order they are grouped in
+var SearchTypes = []string{SearchTypeTribe, SearchTypePerson, SearchTypeBot, SearchTypeBounty, SearchTypeWorkspace}
+
+// SearchResult is an entity found by the unified search, the same shape is indexed in meilisearch
+type SearchResult struct {
+	Type        string  `json:"type"`
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Img         string  `json:"img"`
+	Score       float64 `json:"score"`
+}
+
+// SearchIndexChange is an entity written since the search index was last synced, queued by a trigger
+type SearchIndexChange struct {
+	ID       uint      `json:"id"`
+	Type     string    `json:"type"`
+	EntityID string    `json:"entity_id"`
+	Created  time.Time `gorm:"default:now()" json:"created"`
+}
+
+type SearchIndexBacklog struct {
+	Pending int64      `json:"pending"`
+	Oldest  *time.Time `json:"oldest,omitempty"`
+}
+
+type MediaJobStatus string
+
+const (
+	MediaJobPending MediaJobStatus = "pending"
+	// the job has been picked up by the media worker
+	MediaJobProcessing MediaJobStatus = "processing"
+	MediaJobDone       MediaJobStatus = "done"
+	MediaJobFailed     MediaJobStatus = "failed"
+)
+
+// MediaJob queues the processing of an uploaded image, the webp and thumbnail keys are where
+// its copies are stored once done
+type MediaJob struct {
+	ID           uint           `json:"id"`
+	Key          string         `gorm:"uniqueIndex;not null" json:"key"`
+	WebpKey      string         `gorm:"index" json:"webp_key"`
+	ThumbnailKey string         `gorm:"index" json:"thumbnail_key"`
+	Status       MediaJobStatus `gorm:"index" json:"status"`
+	Attempts     int            `json:"attempts"`
+	Error        string         `json:"error,omitempty"`
+	Created      *time.Time     `json:"created"`
+	Updated      *time.Time     `json:"updated"`
+}
+
+type AttachmentParent string
+
+const (
+	AttachmentBounty AttachmentParent = "bounty"
+	// a ticket is addressed by its owner pubkey and created time, as <pubkey>:<created>
+	AttachmentTicket AttachmentParent = "ticket"
+)
+
+// Attachment is a file of the storage attached to a bounty or a ticket
+type Attachment struct {
+	ID          uint             `json:"id"`
+	ParentType  AttachmentParent `gorm:"index:idx_attachment_parent;not null" json:"parent_type"`
+	ParentId    string           `gorm:"index:idx_attachment_parent;not null" json:"parent_id"`
+	Key         string           `gorm:"not null" json:"key"`
+	Url         string           `gorm:"-" json:"url"`
+	Filename    string           `json:"filename"`
+	ContentType string           `json:"content_type"`
+	Size        int64            `json:"size"`
+	OwnerPubKey string           `gorm:"index" json:"owner_pubkey"`
+	Created     *time.Time       `json:"created"`
+}
+
+// TicketComment is a comment on a ticket, a reply points to the comment starting its thread and
+// the thread is resolved on that first comment
+type TicketComment struct {
+	ID           uint           `json:"id"`
+	TicketId     string         `gorm:"index;not null" json:"ticket_id"`
+	ThreadId     *uint          `gorm:"index" json:"thread_id"`
+	AuthorPubKey string         `json:"author_pubkey"`
+	Body         string         `gorm:"not null" json:"body"`
+	Mentions     pq.StringArray `gorm:"type:text[]" json:"mentions"`
+	Resolved     bool           `gorm:"default:false" json:"resolved"`
+	ResolvedBy   string         `json:"resolved_by,omitempty"`
+	ResolvedAt   *time.Time     `json:"resolved_at,omitempty"`
+	Created      *time.Time     `json:"created"`
+	Updated      *time.Time     `json:"updated"`
+}
+
+type TicketCommentAuthor struct {
+	OwnerPubKey string `json:"owner_pubkey"`
+	OwnerAlias  string `json:"owner_alias"`
+	UniqueName  string `json:"unique_name"`
+	Img         string `json:"img"`
+}
+
+// TicketThread is a comment with its author, and the replies when it starts a thread
+type TicketThread struct {
+	TicketComment
+	Author  TicketCommentAuthor `json:"author"`
+	Replies []TicketThread      `json:"replies,omitempty"`
+}
+
+type TicketLinkType string
+
+const (
+	TicketBlocks     TicketLinkType = "blocks"
+	TicketBlockedBy  TicketLinkType = "blocked_by"
+	TicketRelatesTo  TicketLinkType = "relates_to"
+	TicketDuplicates TicketLinkType = "duplicates"
+)
+
+// TicketLink links two tickets, blocked_by links are kept as blocks links the other way round
+type TicketLink struct {
+	ID         uint           `json:"id"`
+	FromTicket string         `gorm:"index;not null" json:"from_ticket"`
+	ToTicket   string         `gorm:"index;not null" json:"to_ticket"`
+	Type       TicketLinkType `gorm:"not null" json:"type"`
+	CreatedBy  string         `json:"created_by"`
+	Created    *time.Time     `json:"created"`
+}
+
+type TicketGraphNode struct {
+	Key         string `json:"key"`
+	OwnerPubKey string `json:"owner_pubkey"`
+	Created     int64  `json:"created"`
+	Title       string `json:"title"`
+}
+
+// TicketGraph is the dependency graph of someone's tickets, order lists every node after
+// the tickets blocking it
+type TicketGraph struct {
+	Nodes []TicketGraphNode `json:"nodes"`
+	Edges []TicketLink      `json:"edges"`
+	Order []string          `json:"order"`
+}
+
+// TicketRevision is the content of a ticket after an edit, revisions are never changed
+type TicketRevision struct {
+	ID           uint        `json:"id"`
+	TicketId     string      `gorm:"index;not null" json:"ticket_id"`
+	Version      int         `gorm:"not null" json:"version"`
+	Content      PropertyMap `gorm:"type:jsonb" json:"content"`
+	EditorPubKey string      `json:"editor_pubkey"`
+	Created      *time.Time  `json:"created"`
+}
+
+type TicketDiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// TicketFieldChange is a field that differs between two revisions, text fields come with
+// their lines kept (=), removed (-) and added (+)
+type TicketFieldChange struct {
+	Field  string           `json:"field"`
+	Before interface{}      `json:"before"`
+	After  interface{}      `json:"after"`
+	Lines  []TicketDiffLine `json:"lines,omitempty"`
+}
+
+type TicketDiff struct {
+	TicketId string              `json:"ticket_id"`
+	From     int                 `json:"from"`
+	To       int                 `json:"to"`
+	Changes  []TicketFieldChange `json:"changes"`
+}
+
+type TicketLane string
+
+const (
+	TicketLaneTodo       TicketLane = "todo"
+	TicketLaneInProgress TicketLane = "in_progress"
+	TicketLaneReview     TicketLane = "review"
+	TicketLaneDone       TicketLane = "done"
+)
+
+// TicketLanes are the lanes of a phase board, in the order they are shown
+var TicketLanes = []TicketLane{TicketLaneTodo, TicketLaneInProgress, TicketLaneReview, TicketLaneDone}
+
+// TicketCard places a ticket on the board of a phase, cards of a lane are ordered by rank and
+// every move bumps the version so a move made on a stale board is refused
+type TicketCard struct {
+	ID        uint       `json:"id"`
+	TicketId  string     `gorm:"uniqueIndex;not null" json:"ticket_id"`
+	PhaseUuid string     `gorm:"index;not null" json:"phase_uuid"`
+	Lane      TicketLane `gorm:"not null" json:"lane"`
+	Rank      string     `gorm:"not null" json:"rank"`
+	Version   int        `gorm:"not null" json:"version"`
+	Title     string     `gorm:"-" json:"title"`
+	UpdatedBy string     `json:"updated_by"`
+	Updated   *time.Time `json:"updated"`
+}
+
+type TicketBoardLane struct {
+	Lane  TicketLane   `json:"lane"`
+	Cards []TicketCard `json:"cards"`
+}
+
+type TicketBoard struct {
+	PhaseUuid string            `json:"phase_uuid"`
+	Lanes     []TicketBoardLane `json:"lanes"`
+}
+
+type TicketBulkItem struct {
+	TicketId string `json:"ticket_id"`
+	Ok       bool   `json:"ok"`
+	Error    string `json:"error,omitempty"`
+}
+
+// TicketBulkResult tells if a bulk operation was applied, it is applied to every ticket or none
+type TicketBulkResult struct {
+	Applied bool             `json:"applied"`
+	Results []TicketBulkItem `json:"results"`
+}
+
+type RoadmapItemType string
+
+const (
+	RoadmapItemFeature RoadmapItemType = "feature"
+	RoadmapItemPhase   RoadmapItemType = "phase"
+)
+
+// FeatureDependency makes a feature or a phase wait for another feature or phase to finish, the
+// phases of a feature wait for everything the feature depends on
+type FeatureDependency struct {
+	ID            uint            `json:"id"`
+	ItemType      RoadmapItemType `gorm:"not null" json:"item_type"`
+	ItemUuid      string          `gorm:"index;not null" json:"item_uuid"`
+	DependsOnType RoadmapItemType `gorm:"not null" json:"depends_on_type"`
+	DependsOnUuid string          `gorm:"index;not null" json:"depends_on_uuid"`
+	CreatedBy     string          `json:"created_by"`
+	Created       *time.Time      `json:"created"`
+}
+
+// RoadmapPhase is a phase on a roadmap, days count from the start of the roadmap
+type RoadmapPhase struct {
+	Uuid          string    `json:"uuid"`
+	FeatureUuid   string    `json:"feature_uuid"`
+	Name          string    `json:"name"`
+	Priority      int       `json:"priority"`
+	EstimatedDays int       `json:"estimated_days"`
+	StartDay      int       `json:"start_day"`
+	EndDay        int       `json:"end_day"`
+	StartDate     time.Time `json:"start_date"`
+	EndDate       time.Time `json:"end_date"`
+	DependsOn     []string  `json:"depends_on"`
+}
+
+type FeatureRoadmap struct {
+	FeatureUuid string         `json:"feature_uuid"`
+	StartDate   time.Time      `json:"start_date"`
+	EndDate     time.Time      `json:"end_date"`
+	Phases      []RoadmapPhase `json:"phases"`
+}
+
+// PhaseBudget is the sats a workspace allocates to a feature phase
+type PhaseBudget struct {
+	ID        uint       `json:"id"`
+	PhaseUuid string     `gorm:"uniqueIndex;not null" json:"phase_uuid"`
+	Allocated uint       `json:"allocated"`
+	UpdatedBy string     `json:"updated_by"`
+	Created   *time.Time `json:"created"`
+	Updated   *time.Time `json:"updated"`
+}
+
+// PhaseBudgetStatus is the burn of a phase budget, committed are the prices of its unpaid bounties
+type PhaseBudgetStatus struct {
+	PhaseUuid string   `json:"phase_uuid"`
+	Allocated uint     `json:"allocated"`
+	Spent     uint     `json:"spent"`
+	Committed uint     `json:"committed"`
+	Remaining int64    `json:"remaining"`
+	Warnings  []string `json:"warnings"`
+}
+
+type WorkflowStepType string
+
+const (
+	// starts a Stakwork project of the workflow_id param
+	WorkflowCallStakwork WorkflowStepType = "call_stakwork"
+	// adds a ticket to the wanted list of the owner_pubkey param
+	WorkflowCreateTicket WorkflowStepType = "create_ticket"
+	WorkflowNotify       WorkflowStepType = "notify"
+	// posts the body param, or everything the execution knows, to the url param
+	WorkflowWebhook WorkflowStepType = "webhook"
+)
+
+// WorkflowTriggerManual is the trigger of workflows only started from their run endpoint, any
+// other trigger is the name of an event of the workspace such as bounty.paid
+const WorkflowTriggerManual = "manual"
+
+// WorkflowStep is one step of a workflow, string params can use {{name}} to read the input of
+// the execution or the output of an earlier step
+type WorkflowStep struct {
+	Type   WorkflowStepType       `json:"type"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// Workflow runs its steps in order each time its trigger fires in the workspace
+type Workflow struct {
+	ID            uint          `json:"id"`
+	Uuid          string        `gorm:"uniqueIndex;not null" json:"uuid"`
+	WorkspaceUuid string        `gorm:"index;not null" json:"workspace_uuid"`
+	Name          string        `json:"name"`
+	Trigger       string        `gorm:"index" json:"trigger"`
+	Steps         WorkflowSteps `gorm:"type:jsonb" json:"steps"`
+	Enabled       bool          `json:"enabled"`
+	CreatedBy     string        `json:"created_by"`
+	Created       *time.Time    `json:"created"`
+	Updated       *time.Time    `json:"updated"`
+}
+
+type WorkflowStatus string
+
+const (
+	WorkflowPending   WorkflowStatus = "pending"
+	WorkflowRunning   WorkflowStatus = "running"
+	WorkflowSucceeded WorkflowStatus = "succeeded"
+	WorkflowFailed    WorkflowStatus = "failed"
+)
+
+// WorkflowStepState is the progress of a step of an execution, with the params the step had when
+// the execution was queued so editing the workflow doesn't change runs already started
+type WorkflowStepState struct {
+	Type       WorkflowStepType       `json:"type"`
+	Params     map[string]interface{} `json:"params"`
+	Status     WorkflowStatus         `json:"status"`
+	Attempts   int                    `json:"attempts"`
+	Error      string                 `json:"error,omitempty"`
+	Output     map[string]interface{} `json:"output,omitempty"`
+	FinishedAt *time.Time             `json:"finished_at,omitempty"`
+}
+
+// WorkflowExecution is a run of a workflow, it is queued until the workflow job picks it up and
+// goes back to the queue at NextRunAt while a failed step has attempts left
+type WorkflowExecution struct {
+	ID            uint               `json:"id"`
+	Uuid          string             `gorm:"uniqueIndex;not null" json:"uuid"`
+	WorkflowUuid  string             `gorm:"index;not null" json:"workflow_uuid"`
+	WorkspaceUuid string             `gorm:"index" json:"workspace_uuid"`
+	Trigger       string             `json:"trigger"`
+	Input         PropertyMap        `gorm:"type:jsonb" json:"input"`
+	Status        WorkflowStatus     `gorm:"index" json:"status"`
+	CurrentStep   int                `json:"current_step"`
+	Steps         WorkflowStepStates `gorm:"type:jsonb" json:"steps"`
+	NextRunAt     *time.Time         `gorm:"index" json:"next_run_at"`
+	Created       *time.Time         `json:"created"`
+	Updated       *time.Time         `json:"updated"`
+}
+
+// WorkspaceAiSettings is the Stakwork workflow and the model settings a workspace sends with its
+// Stakwork requests, empty fields leave the defaults of the workflow
+type WorkspaceAiSettings struct {
+	ID            uint       `json:"id"`
+	WorkspaceUuid string     `gorm:"uniqueIndex" json:"workspace_uuid"`
+	WorkflowId    string     `json:"workflow_id"`
+	Model         string     `json:"model"`
+	Temperature   *float64   `json:"temperature"`
+	SystemPrompt  string     `json:"system_prompt"`
+	UpdatedBy     string     `json:"updated_by"`
+	Created       *time.Time `json:"created"`
+	Updated       *time.Time `json:"updated"`
+}
+
+type StakworkJobStatus string
+
+const (
+	StakworkJobProcessing StakworkJobStatus = "processing"
+	StakworkJobCompleted  StakworkJobStatus = "completed"
+	StakworkJobFailed     StakworkJobStatus = "failed"
+)
+
+type StakworkJobKind string
+
+const (
+	// a call_stakwork step of a workflow execution, RefUuid is the execution
+	StakworkJobWorkflow        StakworkJobKind = "workflow"
+	StakworkJobYoutubeDownload StakworkJobKind = "youtube_download"
+)
+
+// StakworkJob is a project started on Stakwork, the token is in the callback url Stakwork calls
+// when the project finishes so the callback can't be made for another job
+type StakworkJob struct {
+	ID            uint              `json:"id"`
+	Uuid          string            `gorm:"uniqueIndex;not null" json:"uuid"`
+	Token         string            `gorm:"uniqueIndex;not null" json:"-"`
+	ProjectId     string            `gorm:"index" json:"project_id"`
+	Kind          StakworkJobKind   `json:"kind"`
+	RefUuid       string            `gorm:"index" json:"ref_uuid"`
+	WorkspaceUuid string            `gorm:"index" json:"workspace_uuid"`
+	Status        StakworkJobStatus `gorm:"index" json:"status"`
+	Result        PropertyMap       `gorm:"type:jsonb" json:"result,omitempty"`
+	Error         string            `json:"error,omitempty"`
+	Created       *time.Time        `json:"created"`
+	Updated       *time.Time        `json:"updated"`
+}
+
+// BotUsage is a batch of messages a bot answered in a tribe, charged at the price per use of the
+// bot when it was recorded. It is owed to the bot owner until a payout settles it
+type BotUsage struct {
+	ID           uint       `json:"id"`
+	BotUuid      string     `gorm:"index" json:"bot_uuid"`
+	TribeUuid    string     `json:"tribe_uuid"`
+	SenderPubKey string     `json:"sender_pubkey"`
+	Messages     uint       `json:"messages"`
+	Amount       uint       `json:"amount"`
+	PayoutId     uint       `gorm:"index" json:"payout_id"`
+	Created      *time.Time `gorm:"index" json:"created"`
+}
+
+type BotPayoutStatus string
+
+const (
+	BotPayoutSucceeded BotPayoutStatus = "succeeded"
+	BotPayoutFailed    BotPayoutStatus = "failed"
+)
+
+// BotPayout is a keysend of the sats owed to the owner of a bot
+type BotPayout struct {
+	ID          uint            `json:"id"`
+	BotUuid     string          `gorm:"index" json:"bot_uuid"`
+	OwnerPubKey string          `json:"owner_pubkey"`
+	Amount      uint            `json:"amount"`
+	Status      BotPayoutStatus `json:"status"`
+	Error       string          `json:"error,omitempty"`
+	PaymentHash string          `json:"payment_hash,omitempty"`
+	Created     *time.Time      `json:"created"`
+}
+
+// BotMonthlyEarnings is the usage of a bot in a month, paid is what was already paid out of it
+type BotMonthlyEarnings struct {
+	Month    string `json:"month"`
+	Messages uint   `json:"messages"`
+	Amount   uint   `json:"amount"`
+	Paid     uint   `json:"paid"`
+}
+
+type BotEarnings struct {
+	BotUuid     string               `json:"bot_uuid"`
+	PricePerUse int64                `json:"price_per_use"`
+	Owed        uint                 `json:"owed"`
+	Months      []BotMonthlyEarnings `json:"months"`
+	Payouts     []BotPayout          `json:"payouts"`
+}
+
+// BotReview is the rating of a bot by a person, each person has one review per bot
+type BotReview struct {
+	ID             uint       `json:"id"`
+	BotUuid        string     `gorm:"uniqueIndex:idx_bot_reviewer" json:"bot_uuid"`
+	ReviewerPubKey string     `gorm:"uniqueIndex:idx_bot_reviewer" json:"reviewer_pubkey"`
+	Rating         int        `json:"rating"`
+	Text           string     `json:"text"`
+	Created        *time.Time `json:"created"`
+	Updated        *time.Time `json:"updated"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending    WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivering WebhookDeliveryStatus = "delivering"
+	WebhookDeliveryDelivered  WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed     WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is a signed post of an event to a registered url, queued and retried until the
+// url answers with a 2xx or the attempts are used up. The source is what registered the url. A raw
+// delivery posts its payload as it is, for the urls of chat apps that expect their own format
+type WebhookDelivery struct {
+	ID             uint                  `json:"id"`
+	Uuid           string                `gorm:"unique" json:"uuid"`
+	Source         string                `gorm:"index:idx_webhook_delivery_source" json:"source"`
+	SourceUuid     string                `gorm:"index:idx_webhook_delivery_source" json:"source_uuid"`
+	Url            string                `json:"url"`
+	Secret         string                `json:"-"`
+	Raw            bool                  `json:"raw"`
+	Event          string                `json:"event"`
+	Payload        PropertyMap           `gorm:"type:jsonb" json:"payload"`
+	Status         WebhookDeliveryStatus `gorm:"index" json:"status"`
+	Attempts       int                   `json:"attempts"`
+	ResponseStatus int                   `json:"response_status"`
+	Error          string                `json:"error,omitempty"`
+	NextRunAt      *time.Time            `gorm:"index" json:"next_run_at"`
+	Created        *time.Time            `json:"created"`
+	Updated        *time.Time            `json:"updated"`
+}
+
+// BotWebhook is a url a bot owner registered to receive the events of the platform, limited
+// to one tribe when the tribe is set
+type BotWebhook struct {
+	ID        uint           `json:"id"`
+	Uuid      string         `gorm:"unique" json:"uuid"`
+	BotUuid   string         `gorm:"index" json:"bot_uuid"`
+	Url       string         `json:"url"`
+	Events    pq.StringArray `gorm:"type:text[]" json:"events"`
+	TribeUuid string         `json:"tribe_uuid"`
+	Secret    string         `json:"secret,omitempty"`
+	Enabled   bool           `json:"enabled"`
+	Created   *time.Time     `json:"created"`
+	Updated   *time.Time     `json:"updated"`
+}
+
+// BotCategory is a category of the bot directory curated by the admins, bots name theirs by slug
+type BotCategory struct {
+	ID          uint       `json:"id"`
+	Slug        string     `gorm:"unique" json:"slug"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Position    int        `json:"position"`
+	Created     *time.Time `json:"created"`
+	Updated     *time.Time `json:"updated"`
+}
+
+// TribeMember is a member of a tribe as reported by the relay of the tribe
+type TribeMember struct {
+	ID           uint       `json:"id"`
+	TribeUuid    string     `gorm:"uniqueIndex:idx_tribe_member" json:"tribe_uuid"`
+	MemberPubKey string     `gorm:"uniqueIndex:idx_tribe_member" json:"member_pubkey"`
+	Alias        string     `json:"alias"`
+	JoinedAt     *time.Time `json:"joined_at"`
+	Created      *time.Time `json:"created"`
+	Updated      *time.Time `json:"updated"`
+}
+
+// TribeBan keeps a pubkey out of a tribe until it expires, a ban without expiry is permanent
+type TribeBan struct {
+	ID           uint       `json:"id"`
+	TribeUuid    string     `gorm:"uniqueIndex:idx_tribe_ban" json:"tribe_uuid"`
+	MemberPubKey string     `gorm:"uniqueIndex:idx_tribe_ban" json:"member_pubkey"`
+	Reason       string     `json:"reason"`
+	BannedBy     string     `json:"banned_by"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	Created      *time.Time `json:"created"`
+}
+
+// TribeMembership is what the relay of a tribe checks before letting a pubkey in
+type TribeMembership struct {
+	TribeUuid string     `json:"tribe_uuid"`
+	PubKey    string     `json:"pubkey"`
+	Member    bool       `json:"member"`
+	Allowed   bool       `json:"allowed"`
+	Banned    bool       `json:"banned"`
+	Reason    string     `json:"reason,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// TribeInvite is a shareable code to join a tribe, MaxUses of 0 and a nil ExpiresAt are no limit
+type TribeInvite struct {
+	ID        uint       `json:"id"`
+	Code      string     `gorm:"uniqueIndex" json:"code"`
+	TribeUuid string     `gorm:"index" json:"tribe_uuid"`
+	CreatedBy string     `json:"created_by"`
+	Label     string     `json:"label"`
+	MaxUses   uint       `json:"max_uses"`
+	Uses      uint       `json:"uses"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Revoked   bool       `json:"revoked"`
+	Created   *time.Time `json:"created"`
+	Updated   *time.Time `json:"updated"`
+}
+
+type TribeInviteRedemption struct {
+	ID        uint       `json:"id"`
+	InviteId  uint       `gorm:"uniqueIndex:idx_tribe_invite_redemption" json:"invite_id"`
+	TribeUuid string     `json:"tribe_uuid"`
+	PubKey    string     `gorm:"uniqueIndex:idx_tribe_invite_redemption" json:"pubkey"`
+	Alias     string     `json:"alias"`
+	Created   *time.Time `json:"created"`
+}
+
+// TribeInviteAnalytics are the uses of an invite and who redeemed it
+type TribeInviteAnalytics struct {
+	Invite      TribeInvite             `json:"invite"`
+	Redemptions []TribeInviteRedemption `json:"redemptions"`
+}
+
+type TribeActivityKind string
+
+const (
+	// TribeActivityPing is a report of the relay that the tribe is active, Count is the
+	// messages sent since the last one
+	TribeActivityPing         TribeActivityKind = "ping"
+	TribeActivityBadgeAdded   TribeActivityKind = "badge_added"
+	TribeActivityBadgeRemoved TribeActivityKind = "badge_removed"
+)
+
+// TribeActivity is an event in the life of a tribe kept for its analytics
+type TribeActivity struct {
+	ID        uint              `json:"id"`
+	TribeUuid string            `gorm:"index" json:"tribe_uuid"`
+	Kind      TribeActivityKind `json:"kind"`
+	Name      string            `json:"name"`
+	Count     int64             `json:"count"`
+	Created   *time.Time        `json:"created"`
+}
+
+type TribeAnalyticsPoint struct {
+	Bucket          string `json:"bucket"`
+	NewMembers      int64  `json:"new_members"`
+	Members         int64  `json:"members"`
+	Messages        int64  `json:"messages"`
+	ActivePings     int64  `json:"active_pings"`
+	BountiesCreated int64  `json:"bounties_created"`
+	BountySats      int64  `json:"bounty_sats"`
+	BadgesAdded     int64  `json:"badges_added"`
+	BadgesRemoved   int64  `json:"badges_removed"`
+}
+
+type TribeAnalytics struct {
+	TribeUuid   string                `json:"tribe_uuid"`
+	Interval    MetricsInterval       `json:"interval"`
+	From        string                `json:"from"`
+	To          string                `json:"to"`
+	MemberCount uint64                `json:"member_count"`
+	Badges      []string              `json:"badges"`
+	Points      []TribeAnalyticsPoint `json:"points"`
+}
+
+// TribeRankingSettings weigh what orders the tribe directory. Recency favours tribes created
+// lately, members favours big tribes and activity favours tribes the relay reported active lately
+type TribeRankingSettings struct {
+	ID             uint       `json:"id"`
+	RecencyWeight  float64    `json:"recency_weight"`
+	MemberWeight   float64    `json:"member_weight"`
+	ActivityWeight float64    `json:"activity_weight"`
+	Updated        *time.Time `json:"updated"`
+}
+
+type TribeVerificationMethod string
+
+const (
+	// TribeVerificationDomain is proven with the token in a TXT record of the domain or in
+	// https://{domain}/.well-known/sphinx-tribe-verification.txt
+	TribeVerificationDomain TribeVerificationMethod = "domain"
+	// TribeVerificationNostr is proven by a NIP-05 identifier resolving to a nostr key linked to the owner
+	TribeVerificationNostr TribeVerificationMethod = "nostr"
+	// TribeVerificationGithub is proven by a "Sphinx Verification" gist signed by the owner
+	TribeVerificationGithub TribeVerificationMethod = "github"
+)
+
+type TribeVerificationStatus string
+
+const (
+	TribeVerificationPending     TribeVerificationStatus = "pending"
+	TribeVerificationNeedsReview TribeVerificationStatus = "needs_review"
+	TribeVerificationVerified    TribeVerificationStatus = "verified"
+	TribeVerificationRejected    TribeVerificationStatus = "rejected"
+)
+
+// TribeVerification is a claim of a tribe to a domain or an identity, the tribe is verified while
+// one of its claims is
+type TribeVerification struct {
+	ID         uint                    `json:"id"`
+	Uuid       string                  `gorm:"uniqueIndex" json:"uuid"`
+	TribeUuid  string                  `gorm:"index" json:"tribe_uuid"`
+	Method     TribeVerificationMethod `json:"method"`
+	Subject    string                  `json:"subject"`
+	Token      string                  `json:"token"`
+	Status     TribeVerificationStatus `gorm:"index" json:"status"`
+	Error      string                  `json:"error"`
+	ReviewedBy string                  `json:"reviewed_by"`
+	Note       string                  `json:"note"`
+	Created    *time.Time              `json:"created"`
+	Updated    *time.Time              `json:"updated"`
+}
+
+// WorkspaceCalendarToken is the secret in the url of the calendar feed of a workspace, calendar
+// apps can't sign requests so the token is what scopes a subscription to the workspace
+type WorkspaceCalendarToken struct {
+	ID            uint       `json:"id"`
+	WorkspaceUuid string     `gorm:"uniqueIndex" json:"workspace_uuid"`
+	Token         string     `gorm:"uniqueIndex;not null" json:"token"`
+	CreatedBy     string     `json:"created_by"`
+	Created       *time.Time `json:"created"`
+}
+
+// WorkspaceCalendarPhase is a phase of a workspace with the name of its feature for the calendar
+type WorkspaceCalendarPhase struct {
+	Uuid          string     `json:"uuid"`
+	FeatureUuid   string     `json:"feature_uuid"`
+	FeatureName   string     `json:"feature_name"`
+	Name          string     `json:"name"`
+	EstimatedDays int        `json:"estimated_days"`
+	Created       *time.Time `json:"created"`
+	Updated       *time.Time `json:"updated"`
+}
+
+// ConnectionCodeCampaign is how many of the codes of a campaign were handed out, the codes made
+// without a campaign are counted under ""
+type ConnectionCodeCampaign struct {
+	Campaign       string     `json:"campaign"`
+	Total          int64      `json:"total"`
+	Redeemed       int64      `json:"redeemed"`
+	Expired        int64      `json:"expired"`
+	Revoked        int64      `json:"revoked"`
+	Available      int64      `json:"available"`
+	RedemptionRate float64    `gorm:"-" json:"redemption_rate"`
+	Created        *time.Time `json:"created"`
+	LastRedeemed   *time.Time `json:"last_redeemed"`
+}
+
+// ReportTargetType is what a content report is about, the target id is the uuid of a tribe,
+// bot or person and the id of a bounty
+type ReportTargetType string
+
+const (
+	ReportTribe  ReportTargetType = "tribe"
+	ReportBot    ReportTargetType = "bot"
+	ReportPerson ReportTargetType = "person"
+	ReportBounty ReportTargetType = "bounty"
+)
+
+type ReportReason string
+
+const (
+	ReportScam          ReportReason = "scam"
+	ReportSpam          ReportReason = "spam"
+	ReportAbuse         ReportReason = "abuse"
+	ReportImpersonation ReportReason = "impersonation"
+	ReportIllegal       ReportReason = "illegal"
+	ReportOther         ReportReason = "other"
+)
+
+type ReportStatus string
+
+const (
+	ReportOpen      ReportStatus = "open"
+	ReportActioned  ReportStatus = "actioned"
+	ReportDismissed ReportStatus = "dismissed"
+)
+
+type ModerationAction string
+
+const (
+	// ModerationHide takes the content down, tribes, bots and people are deleted and bounties hidden
+	ModerationHide ModerationAction = "hide"
+	// ModerationUnlist keeps the content reachable by link but out of listings and search
+	ModerationUnlist ModerationAction = "unlist"
+	// ModerationBanOwner hides the content, bans its owner and unlists the rest of their tribes and bots
+	ModerationBanOwner ModerationAction = "ban_owner"
+	// ModerationDismiss closes the report without changing the content
+	ModerationDismiss ModerationAction = "dismiss"
+)
+
+type ContentReport struct {
+	ID            uint             `json:"id"`
+	Uuid          string           `gorm:"unique;not null" json:"uuid"`
+	TargetType    ReportTargetType `gorm:"index:idx_report_target" json:"target_type"`
+	TargetId      string           `gorm:"index:idx_report_target" json:"target_id"`
+	OwnerPubKey   string           `gorm:"index" json:"owner_pubkey"`
+	ReportedBy    string           `json:"reported_by"`
+	Reason        ReportReason     `json:"reason"`
+	Details       string           `json:"details"`
+	Status        ReportStatus     `gorm:"index" json:"status"`
+	Action        ModerationAction `json:"action,omitempty"`
+	ModeratorNote string           `json:"moderator_note,omitempty"`
+	ResolvedBy    string           `json:"resolved_by,omitempty"`
+	ResolvedAt    *time.Time       `json:"resolved_at,omitempty"`
+	Created       *time.Time       `json:"created"`
+	Updated       *time.Time       `json:"updated"`
+}
+
+type ContentReportRequest struct {
+	TargetType ReportTargetType `json:"target_type"`
+	TargetId   string           `json:"target_id"`
+	Reason     ReportReason     `json:"reason"`
+	Details    string           `json:"details"`
+}
+
+type ModerationActionRequest struct {
+	Action ModerationAction `json:"action"`
+	Note   string           `json:"note"`
+}
+
+type AppealStatus string
+
+const (
+	AppealOpen AppealStatus = "open"
+	// AppealUpheld keeps the moderation action of the report
+	AppealUpheld AppealStatus = "upheld"
+	// AppealOverturned undoes the moderation action of the report
+	AppealOverturned AppealStatus = "overturned"
+)
+
+// ContentAppeal is the owner of moderated content asking for the action of a report to be undone,
+// a report has at most one appeal
+type ContentAppeal struct {
+	ID          uint         `json:"id"`
+	Uuid        string       `gorm:"unique;not null" json:"uuid"`
+	ReportUuid  string       `gorm:"uniqueIndex" json:"report_uuid"`
+	OwnerPubKey string       `gorm:"index" json:"owner_pubkey"`
+	Statement   string       `json:"statement"`
+	Status      AppealStatus `gorm:"index" json:"status"`
+	Resolution  string       `json:"resolution,omitempty"`
+	ResolvedBy  string       `json:"resolved_by,omitempty"`
+	ResolvedAt  *time.Time   `json:"resolved_at,omitempty"`
+	Created     *time.Time   `json:"created"`
+	Updated     *time.Time   `json:"updated"`
+}
+
+type ContentAppealRequest struct {
+	Statement string `json:"statement"`
+}
+
+type AppealResolutionRequest struct {
+	Decision   AppealStatus `json:"decision"`
+	Resolution string       `json:"resolution"`
+}
+
+// PubkeyBan is a platform ban of a pubkey, ReportUuid is the report it was decided on. Bans are
+// lifted rather than deleted so they are also the audit of who banned whom and why, a ban is
+// active until it is lifted or expires and a nil ExpiresAt never expires
+type PubkeyBan struct {
+	ID         uint       `json:"id"`
+	PubKey     string     `gorm:"index" json:"pubkey"`
+	Reason     string     `json:"reason"`
+	BannedBy   string     `json:"banned_by"`
+	ReportUuid string     `json:"report_uuid,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LiftedBy   string     `json:"lifted_by,omitempty"`
+	LiftReason string     `json:"lift_reason,omitempty"`
+	LiftedAt   *time.Time `json:"lifted_at,omitempty"`
+	Created    *time.Time `json:"created"`
+}
+
+type PubkeyBanRequest struct {
+	PubKey    string     `json:"pubkey"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type PubkeyBanLiftRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ListingText is what the spam checks compare a new tribe or bot with
+type ListingText struct {
+	Uuid        string `json:"uuid"`
+	OwnerPubKey string `json:"owner_pubkey"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type ListingReviewRequest struct {
+	Approve bool `json:"approve"`
+}
+
+// AdminDashboard is the health of the platform shown to super admins, activity is counted since Since
+// and the queues are counted as they are now
+type AdminDashboard struct {
+	Since            time.Time `json:"since"`
+	NewPeople        int64     `json:"new_people"`
+	TribesCreated    int64     `json:"tribes_created"`
+	BotsCreated      int64     `json:"bots_created"`
+	BountiesPosted   int64     `json:"bounties_posted"`
+	BountySatsPosted uint      `json:"bounty_sats_posted"`
+	BountiesPaid     int64     `json:"bounties_paid"`
+	BountySatsPaid   uint      `json:"bounty_sats_paid"`
+	PaymentFailures  int64     `json:"payment_failures"`
+	OpenReports      int64     `json:"open_reports"`
+	OpenAppeals      int64     `json:"open_appeals"`
+	PendingReviews   int64     `json:"pending_reviews"`
+	OpenDisputes     int64     `json:"open_disputes"`
+	ActiveBans       int64     `json:"active_bans"`
+}
+
+// AdminError is a recent failure of a payment, webhook delivery or background job, Ref points at
+// what failed in its source
+type AdminError struct {
+	Source  string     `json:"source"`
+	Ref     string     `json:"ref"`
+	Error   string     `json:"error"`
+	Created *time.Time `json:"created"`
+}
+
+type ImpersonationScope string
+
+const (
+	// a read impersonation can only make GET requests, a write one can act as the pubkey
+	ImpersonationRead  ImpersonationScope = "read"
+	ImpersonationWrite ImpersonationScope = "write"
+)
+
+// Impersonation is a super admin acting as another pubkey to debug an issue they reported, through
+// a short lived token. The pubkey is notified once it ends
+type Impersonation struct {
+	ID           uint               `json:"id"`
+	Uuid         string             `gorm:"unique;not null" json:"uuid"`
+	AdminPubKey  string             `gorm:"index" json:"admin_pubkey"`
+	TargetPubKey string             `gorm:"index" json:"target_pubkey"`
+	Reason       string             `json:"reason"`
+	Scope        ImpersonationScope `json:"scope"`
+	ExpiresAt    *time.Time         `json:"expires_at"`
+	EndedAt      *time.Time         `json:"ended_at,omitempty"`
+	Notified     bool               `gorm:"index" json:"notified"`
+	Created      *time.Time         `json:"created"`
+}
+
+type ImpersonationRequest struct {
+	PubKey  string             `json:"pubkey"`
+	Reason  string             `json:"reason"`
+	Scope   ImpersonationScope `json:"scope"`
+	Minutes int                `json:"minutes"`
+}
+
+// ImpersonatedRequest is the audit log of the requests made with an impersonation token
+type ImpersonatedRequest struct {
+	ID                uint       `json:"id"`
+	ImpersonationUuid string     `gorm:"index" json:"impersonation_uuid"`
+	AdminPubKey       string     `json:"admin_pubkey"`
+	TargetPubKey      string     `json:"target_pubkey"`
+	Method            string     `json:"method"`
+	Path              string     `json:"path"`
+	Created           *time.Time `json:"created"`
+}
+
+// WorkspaceBundleVersion is the version of the workspace export format, imports of other versions are refused
+const WorkspaceBundleVersion = 1
+
+// WorkspaceBundleTicket is a ticket card of a phase board with the ticket it places, tickets are
+// kept on the profile of their owner
+type WorkspaceBundleTicket struct {
+	TicketCard
+	Ticket map[string]interface{} `json:"ticket"`
+}
+
+// WorkspaceBundle is a full backup of a workspace that can be imported on another deployment
+type WorkspaceBundle struct {
+	Version       int                     `json:"version"`
+	ExportedAt    *time.Time              `json:"exported_at"`
+	Workspace     Workspace               `json:"workspace"`
+	Repositories  []WorkspaceRepositories `json:"repositories"`
+	Features      []WorkspaceFeatures     `json:"features"`
+	Stories       []FeatureStory          `json:"stories"`
+	Phases        []FeaturePhase          `json:"phases"`
+	Tickets       []WorkspaceBundleTicket `json:"tickets"`
+	Bounties      []NewBounty             `json:"bounties"`
+	Users         []WorkspaceUsers        `json:"users"`
+	Roles         []WorkspaceUserRoles    `json:"roles"`
+	Budget        NewBountyBudget         `json:"budget"`
+	BudgetHistory []NewPaymentHistory     `json:"budget_history"`
+}
+
+// WorkspaceImportResult is what an import created, or would create on a dry run, with the parts of
+// the bundle that could not be restored
+type WorkspaceImportResult struct {
+	Workspace Workspace      `json:"workspace"`
+	Counts    map[string]int `json:"counts"`
+	Errors    []string       `json:"errors"`
+	Skipped   []string       `json:"skipped"`
+	DryRun    bool           `json:"dry_run"`
+}
+
+type WorkspaceTemplatePhase struct {
+	Name          string `json:"name"`
+	Priority      int    `json:"priority"`
+	EstimatedDays int    `json:"estimated_days"`
+}
+
+type WorkspaceTemplateFeature struct {
+	Name         string                   `json:"name"`
+	Brief        string                   `json:"brief"`
+	Requirements string                   `json:"requirements"`
+	Architecture string                   `json:"architecture"`
+	Priority     int                      `json:"priority"`
+	Phases       []WorkspaceTemplatePhase `json:"phases"`
+}
+
+// WorkspaceTemplateRole is a member a new workspace starts with and the roles they get
+type WorkspaceTemplateRole struct {
+	OwnerPubKey string   `json:"owner_pubkey"`
+	Roles       []string `json:"roles"`
+}
+
+// WorkspaceTemplateBounty is a bounty opened in every new workspace, on the phase of a feature
+// of the template when they are named
+type WorkspaceTemplateBounty struct {
+	Title           string   `json:"title"`
+	Description     string   `json:"description"`
+	Type            string   `json:"type"`
+	Price           uint     `json:"price"`
+	CodingLanguages []string `json:"coding_languages"`
+	StaleAfter      string   `json:"stale_after,omitempty"`
+	Feature         string   `json:"feature,omitempty"`
+	Phase           string   `json:"phase,omitempty"`
+}
+
+type WorkspaceTemplateStructure struct {
+	Features     []WorkspaceTemplateFeature `json:"features"`
+	DefaultRoles []WorkspaceTemplateRole    `json:"default_roles"`
+	Bounties     []WorkspaceTemplateBounty  `json:"bounties"`
+}
+
+// WorkspaceTemplate is the structure of a workspace saved to spin up similar workspaces from
+type WorkspaceTemplate struct {
+	ID                  uint                       `json:"id"`
+	Uuid                string                     `gorm:"unique;not null" json:"uuid"`
+	OwnerPubKey         string                     `gorm:"index" json:"owner_pubkey"`
+	Name                string                     `json:"name"`
+	Description         string                     `json:"description"`
+	SourceWorkspaceUuid string                     `json:"source_workspace_uuid"`
+	Structure           WorkspaceTemplateStructure `gorm:"type:jsonb" json:"structure"`
+	Created             *time.Time                 `json:"created"`
+	Updated             *time.Time                 `json:"updated"`
+}
+
+type WorkspaceTemplateRequest struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	IncludeBounties bool   `json:"include_bounties"`
+	IncludeRoles    bool   `json:"include_roles"`
+}
+
+type WorkspaceFromTemplateRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type WorkspaceTransferStatus string
+
+const (
+	WorkspaceTransferPending   WorkspaceTransferStatus = "pending"
+	WorkspaceTransferAccepted  WorkspaceTransferStatus = "accepted"
+	WorkspaceTransferDeclined  WorkspaceTransferStatus = "declined"
+	WorkspaceTransferCancelled WorkspaceTransferStatus = "cancelled"
+	WorkspaceTransferExpired   WorkspaceTransferStatus = "expired"
+)
+
+// WorkspaceTransfer is an owner handing a workspace to one of its users, it only happens once the
+// new owner accepts it before it expires
+type WorkspaceTransfer struct {
+	ID            uint                    `json:"id"`
+	Uuid          string                  `gorm:"unique;not null" json:"uuid"`
+	WorkspaceUuid string                  `gorm:"index" json:"workspace_uuid"`
+	FromPubKey    string                  `json:"from_pubkey"`
+	ToPubKey      string                  `gorm:"index" json:"to_pubkey"`
+	Status        WorkspaceTransferStatus `gorm:"index" json:"status"`
+	ExpiresAt     *time.Time              `json:"expires_at"`
+	ResolvedAt    *time.Time              `json:"resolved_at,omitempty"`
+	Created       *time.Time              `json:"created"`
+}
+
+type WorkspaceTransferRequest struct {
+	ToPubKey string `json:"to_pubkey"`
+	Hours    int    `json:"hours"`
+}
+
+// WorkspaceAuditLog records the changes to who controls a workspace
+type WorkspaceAuditLog struct {
+	ID            uint       `json:"id"`
+	WorkspaceUuid string     `gorm:"index" json:"workspace_uuid"`
+	Action        string     `json:"action"`
+	ActorPubKey   string     `json:"actor_pubkey"`
+	TargetPubKey  string     `json:"target_pubkey"`
+	Details       string     `json:"details"`
+	Created       *time.Time `json:"created"`
+}
+
+type WorkspaceIntegrationKind string
+
+const (
+	WorkspaceIntegrationSlack   WorkspaceIntegrationKind = "slack"
+	WorkspaceIntegrationDiscord WorkspaceIntegrationKind = "discord"
+)
+
+// WorkspaceIntegration posts the events of a workspace to a Slack or Discord incoming webhook.
+// The url holds the token of the webhook so it is only returned when the integration is saved
+type WorkspaceIntegration struct {
+	ID            uint                     `json:"id"`
+	Uuid          string                   `gorm:"unique" json:"uuid"`
+	WorkspaceUuid string                   `gorm:"index" json:"workspace_uuid"`
+	Kind          WorkspaceIntegrationKind `json:"kind"`
+	Name          string                   `json:"name"`
+	Url           string                   `json:"url,omitempty"`
+	Events        pq.StringArray           `gorm:"type:text[]" json:"events"`
+	Enabled       bool                     `json:"enabled"`
+	CreatedBy     string                   `json:"created_by"`
+	Created       *time.Time               `json:"created"`
+	Updated       *time.Time               `json:"updated"`
+}
+
+// RepositoryCommit is a recent commit of a workspace repository, synced from GitHub
+type RepositoryCommit struct {
+	ID             uint       `json:"id"`
+	RepositoryUuid string     `gorm:"uniqueIndex:idx_repository_commit" json:"repository_uuid"`
+	Sha            string     `gorm:"uniqueIndex:idx_repository_commit" json:"sha"`
+	Message        string     `json:"message"`
+	Author         string     `json:"author"`
+	Url            string     `json:"url"`
+	Committed      *time.Time `gorm:"index" json:"committed"`
+}
+
+type PullRequestState string
+
+const (
+	PullRequestOpen   PullRequestState = "open"
+	PullRequestClosed PullRequestState = "closed"
+	PullRequestMerged PullRequestState = "merged"
+)
+
+// RepositoryPullRequest is a pull request of a workspace repository, synced from GitHub. It is
+// linked to the bounty its branch or its closing issue reference points at
+type RepositoryPullRequest struct {
+	ID             uint             `json:"id"`
+	RepositoryUuid string           `gorm:"uniqueIndex:idx_repository_pull_request" json:"repository_uuid"`
+	Number         int              `gorm:"uniqueIndex:idx_repository_pull_request" json:"number"`
+	Title          string           `json:"title"`
+	State          PullRequestState `json:"state"`
+	Author         string           `json:"author"`
+	Branch         string           `json:"branch"`
+	Url            string           `json:"url"`
+	BountyId       uint             `gorm:"index" json:"bounty_id,omitempty"`
+	Opened         *time.Time       `json:"opened"`
+	Closed         *time.Time       `json:"closed,omitempty"`
+	Updated        *time.Time       `gorm:"index" json:"updated"`
+}
+
+type RepositoryActivity struct {
+	Repository   WorkspaceRepositories   `json:"repository"`
+	Commits      []RepositoryCommit      `json:"commits"`
+	PullRequests []RepositoryPullRequest `json:"pull_requests"`
+}
+
+// TicketImportSource is the tracker an import of tickets comes from
+type TicketImportSource string
+
+const (
+	TicketImportJira   TicketImportSource = "jira"
+	TicketImportLinear TicketImportSource = "linear"
+)
+
+// TicketImportApi reads the issues of a tracker with a token that is only used for the import.
+// Jira needs its site, the email of the token and a project key, Linear a team key
+type TicketImportApi struct {
+	Site    string `json:"site"`
+	Email   string `json:"email"`
+	Token   string `json:"token"`
+	Project string `json:"project"`
+}
+
+// TicketImportMapping says where a ticket's fields are in the issues. Fields maps key, title,
+// description, status, type, feature, phase and url to the columns of the export, Lanes maps a
+// status to a board lane. Issues without a feature or a phase go to the default ones
+type TicketImportMapping struct {
+	Fields         map[string]string     `json:"fields"`
+	Lanes          map[string]TicketLane `json:"lanes"`
+	DefaultFeature string                `json:"default_feature"`
+	DefaultPhase   string                `json:"default_phase"`
+}
+
+// TicketImportRequest is an export file of the tracker, or the api to read the issues from
+type TicketImportRequest struct {
+	File    string              `json:"file"`
+	Api     *TicketImportApi    `json:"api"`
+	Mapping TicketImportMapping `json:"mapping"`
+}
+
+type TicketImportTicket struct {
+	Key     string     `json:"key"`
+	Title   string     `json:"title"`
+	Feature string     `json:"feature"`
+	Phase   string     `json:"phase"`
+	Lane    TicketLane `json:"lane"`
+}
+
+// TicketImportReport is what an import created, or would create on a dry run. Features and
+// phases only list the ones that don't exist in the workspace yet
+type TicketImportReport struct {
+	DryRun   bool                 `json:"dry_run"`
+	Source   TicketImportSource   `json:"source"`
+	Issues   int                  `json:"issues"`
+	Features []string             `json:"features"`
+	Phases   []string             `json:"phases"`
+	Tickets  []TicketImportTicket `json:"tickets"`
+	Skipped  []string             `json:"skipped"`
+	Imported int                  `json:"imported"`
+}
+
+// TicketImport is what an import saves at once, the tickets are added to the wanted list of the
+// person and placed on their phase boards by the cards
+type TicketImport struct {
+	Features []WorkspaceFeatures
+	Phases   []FeaturePhase
+	Person   Person
+	Cards    []TicketCard
+}
+
 func (Person) TableName() string {
 	return "people"
 }
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+func (db database) GetSuperAdmins() []SuperAdmin {
+	ms := []SuperAdmin{}
+	db.db.Order("created ASC").Find(&ms)
+	return ms
+}
+
+func (db database) AddSuperAdmin(m SuperAdmin) (SuperAdmin, error) {
+	now := time.Now()
+	m.Created = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return SuperAdmin{}, err
+	}
+	return m, nil
+}
+
+func (db database) DeleteSuperAdmin(pubkey string) error {
+	result := db.db.Where("owner_pub_key = ?", pubkey).Delete(&SuperAdmin{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("no super admin found")
+	}
+	return nil
+}
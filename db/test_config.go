@@ -58,6 +58,70 @@ func InitTestDB() {
 	db.AutoMigrate(&WorkspaceUsers{})
 	db.AutoMigrate(&WorkspaceUserRoles{})
 	db.AutoMigrate(&Bot{})
+	db.AutoMigrate(&Notification{})
+	db.AutoMigrate(&NotificationSettings{})
+	db.AutoMigrate(&IdempotencyKey{})
+	db.AutoMigrate(&ApiKey{})
+	db.AutoMigrate(&AuthSession{})
+	db.AutoMigrate(&NostrIdentity{})
+	db.AutoMigrate(&SuperAdmin{})
+	db.AutoMigrate(&Activity{})
+	db.AutoMigrate(&Deletion{})
+	db.AutoMigrate(&Endorsement{})
+	db.AutoMigrate(&AccountPurge{})
+	db.AutoMigrate(&LedgerEntry{})
+
+	TestDB.CreateLedgerViews()
+	db.AutoMigrate(&WorkspaceBudgetSettings{})
+	db.AutoMigrate(&PaymentAttempt{})
+	db.AutoMigrate(&BountyDispute{})
+	db.AutoMigrate(&DisputeEvidence{})
+	db.AutoMigrate(&BountyEscrow{})
+	db.AutoMigrate(&BountyProof{})
+	db.AutoMigrate(&BountyWorkSession{})
+	db.AutoMigrate(&SavedSearch{})
+	db.AutoMigrate(&LeaderboardEntry{})
+	db.AutoMigrate(&MetricsDailyRollup{})
+	db.AutoMigrate(&MetricsHunterRollup{})
+	db.AutoMigrate(&MetricsProviderRollup{})
+	db.AutoMigrate(&SearchIndexChange{})
+	db.AutoMigrate(&MediaJob{})
+	db.AutoMigrate(&Attachment{})
+	db.AutoMigrate(&TicketComment{})
+	db.AutoMigrate(&TicketLink{})
+	db.AutoMigrate(&TicketRevision{})
+	db.AutoMigrate(&TicketCard{})
+	db.AutoMigrate(&FeatureDependency{})
+	db.AutoMigrate(&PhaseBudget{})
+	db.AutoMigrate(&Workflow{})
+	db.AutoMigrate(&WorkflowExecution{})
+	db.AutoMigrate(&WorkspaceAiSettings{})
+	db.AutoMigrate(&StakworkJob{})
+	db.AutoMigrate(&BotUsage{})
+	db.AutoMigrate(&BotPayout{})
+	db.AutoMigrate(&BotReview{})
+	db.AutoMigrate(&WebhookDelivery{})
+	db.AutoMigrate(&BotWebhook{})
+	db.AutoMigrate(&BotCategory{})
+	db.AutoMigrate(&TribeMember{})
+	db.AutoMigrate(&TribeBan{})
+	db.AutoMigrate(&TribeInvite{})
+	db.AutoMigrate(&TribeInviteRedemption{})
+	db.AutoMigrate(&TribeActivity{})
+	db.AutoMigrate(&TribeRankingSettings{})
+	db.AutoMigrate(&TribeVerification{})
+	db.AutoMigrate(&WorkspaceCalendarToken{})
+	db.AutoMigrate(&ContentReport{})
+	db.AutoMigrate(&ContentAppeal{})
+	db.AutoMigrate(&PubkeyBan{})
+	db.AutoMigrate(&Impersonation{})
+	db.AutoMigrate(&ImpersonatedRequest{})
+	db.AutoMigrate(&WorkspaceTemplate{})
+	db.AutoMigrate(&WorkspaceTransfer{})
+	db.AutoMigrate(&WorkspaceAuditLog{})
+	db.AutoMigrate(&WorkspaceIntegration{})
+	db.AutoMigrate(&RepositoryCommit{})
+	db.AutoMigrate(&RepositoryPullRequest{})
 
 	people := TestDB.GetAllPeople()
 	for _, p := range people {
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+// ApplyTicketChanges saves the tickets of people, moves cards and removes the cards of deleted
+// tickets together, nothing is saved when any of it fails
+func (db database) ApplyTicketChanges(people []Person, cards []TicketCard, removedTickets []string) error {
+	now := time.Now()
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+
+	for _, person := range people {
+		if err := tx.Model(&Person{}).Where("owner_pub_key = ?", person.OwnerPubKey).Updates(map[string]interface{}{
+			"extras":  person.Extras,
+			"updated": &now,
+		}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	for _, card := range cards {
+		card.Updated = &now
+		if card.ID == 0 {
+			card.Version = 1
+			if err := tx.Create(&card).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+			continue
+		}
+		result := tx.Model(&TicketCard{}).Where("id = ? AND version = ?", card.ID, card.Version).Updates(map[string]interface{}{
+			"phase_uuid": card.PhaseUuid,
+			"lane":       card.Lane,
+			"rank":       card.Rank,
+			"version":    card.Version + 1,
+			"updated_by": card.UpdatedBy,
+			"updated":    &now,
+		})
+		if result.Error != nil {
+			tx.Rollback()
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			tx.Rollback()
+			return errors.New("card was moved")
+		}
+	}
+
+	if len(removedTickets) > 0 {
+		if err := tx.Where("ticket_id IN (?)", removedTickets).Delete(&TicketCard{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
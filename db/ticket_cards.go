@@ -0,0 +1,100 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// rankDigits are the digits of card ranks, ranks compare as strings and never end with a 0 so
+// there is always room for another rank before them
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// RankBetween returns a rank that sorts after before and ahead of after, an empty before is the
+// top of the lane and an empty after its bottom
+func RankBetween(before string, after string) string {
+	if after != "" {
+		n := 0
+		for n < len(after) {
+			digit := byte('0')
+			if n < len(before) {
+				digit = before[n]
+			}
+			if digit != after[n] {
+				break
+			}
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(before) {
+				rest = before[n:]
+			}
+			return after[:n] + RankBetween(rest, after[n:])
+		}
+	}
+
+	low := 0
+	if before != "" {
+		low = strings.IndexByte(rankDigits, before[0])
+	}
+	high := len(rankDigits)
+	if after != "" {
+		high = strings.IndexByte(rankDigits, after[0])
+	}
+	if high-low > 1 {
+		return string(rankDigits[(low+high)/2])
+	}
+	if after != "" && len(after) > 1 {
+		return after[:1]
+	}
+	rest := ""
+	if len(before) > 1 {
+		rest = before[1:]
+	}
+	return string(rankDigits[low]) + RankBetween(rest, "")
+}
+
+// GetTicketCards returns the cards of a phase board in rank order
+func (db database) GetTicketCards(phaseUuid string) []TicketCard {
+	ms := []TicketCard{}
+	db.db.Where("phase_uuid = ?", phaseUuid).Order(`rank COLLATE "C" ASC`).Find(&ms)
+	return ms
+}
+
+func (db database) GetTicketCard(ticketId string) TicketCard {
+	m := TicketCard{}
+	db.db.Where("ticket_id = ?", ticketId).First(&m)
+	return m
+}
+
+// MoveTicketCard places a card, failing when it was moved since the version it had
+func (db database) MoveTicketCard(card TicketCard) (TicketCard, error) {
+	now := time.Now()
+	card.Updated = &now
+
+	if card.ID == 0 {
+		card.Version = 1
+		if err := db.db.Create(&card).Error; err != nil {
+			return TicketCard{}, err
+		}
+		return card, nil
+	}
+
+	result := db.db.Model(&TicketCard{}).Where("id = ? AND version = ?", card.ID, card.Version).Updates(map[string]interface{}{
+		"phase_uuid": card.PhaseUuid,
+		"lane":       card.Lane,
+		"rank":       card.Rank,
+		"version":    card.Version + 1,
+		"updated_by": card.UpdatedBy,
+		"updated":    &now,
+	})
+	if result.Error != nil {
+		return TicketCard{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return TicketCard{}, errors.New("card was moved")
+	}
+	card.Version++
+	return card, nil
+}
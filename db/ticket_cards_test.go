@@ -0,0 +1,36 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankBetween(t *testing.T) {
+	assert.Equal(t, "i", RankBetween("", ""))
+	assert.Equal(t, "r", RankBetween("i", ""))
+	assert.Equal(t, "9", RankBetween("", "i"))
+	assert.Equal(t, "a", RankBetween("9", "b"))
+	assert.Equal(t, "ai", RankBetween("a", "b"))
+	assert.Equal(t, "a5", RankBetween("a", "aa"))
+	assert.Equal(t, "0i", RankBetween("", "1"))
+
+	// dropping cards over and over at the same spot keeps finding room between the neighbours
+	for _, bounds := range [][2]string{{"a", "b"}, {"", "1"}, {"zz", ""}} {
+		low, high := bounds[0], bounds[1]
+		for i := 0; i < 50; i++ {
+			rank := RankBetween(low, high)
+			assert.True(t, rank > low, "%s > %s", rank, low)
+			if high != "" {
+				assert.True(t, rank < high, "%s < %s", rank, high)
+			}
+			assert.False(t, strings.HasSuffix(rank, "0"), rank)
+			if i%2 == 0 {
+				low = rank
+			} else {
+				high = rank
+			}
+		}
+	}
+}
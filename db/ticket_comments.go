@@ -0,0 +1,47 @@
+package db
+
+import (
+	"time"
+)
+
+func (db database) CreateTicketComment(comment TicketComment) (TicketComment, error) {
+	now := time.Now()
+	comment.Created = &now
+	comment.Updated = &now
+	if err := db.db.Create(&comment).Error; err != nil {
+		return TicketComment{}, err
+	}
+	return comment, nil
+}
+
+// GetTicketComments returns the comments of a ticket in the order they were made
+func (db database) GetTicketComments(ticketId string) []TicketComment {
+	ms := []TicketComment{}
+	db.db.Where("ticket_id = ?", ticketId).Order("id ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetTicketComment(id uint) TicketComment {
+	m := TicketComment{}
+	db.db.Where("id = ?", id).First(&m)
+	return m
+}
+
+// SetTicketThreadResolved resolves the thread a comment starts, or opens it again
+func (db database) SetTicketThreadResolved(id uint, resolved bool, pubkey string) (TicketComment, error) {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"resolved":    resolved,
+		"resolved_by": "",
+		"resolved_at": nil,
+		"updated":     now,
+	}
+	if resolved {
+		updates["resolved_by"] = pubkey
+		updates["resolved_at"] = now
+	}
+	if err := db.db.Model(&TicketComment{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return TicketComment{}, err
+	}
+	return db.GetTicketComment(id), nil
+}
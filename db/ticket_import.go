@@ -0,0 +1,58 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GetWorkspaceFeaturesAndPhases returns every feature of a workspace and the phases of them
+func (db database) GetWorkspaceFeaturesAndPhases(workspaceUuid string) ([]WorkspaceFeatures, []FeaturePhase) {
+	features := []WorkspaceFeatures{}
+	phases := []FeaturePhase{}
+	db.db.Where("workspace_uuid = ?", workspaceUuid).Order("created ASC").Find(&features)
+	featureUuids := []string{}
+	for _, feature := range features {
+		featureUuids = append(featureUuids, feature.Uuid)
+	}
+	if len(featureUuids) > 0 {
+		db.db.Where("feature_uuid IN (?)", featureUuids).Order("created ASC").Find(&phases)
+	}
+	return features, phases
+}
+
+// ImportWorkspaceTickets saves the features, phases, tickets and cards of an import, nothing is
+// saved when any of it fails
+func (db database) ImportWorkspaceTickets(m TicketImport) error {
+	now := time.Now()
+	return db.db.Transaction(func(tx *gorm.DB) error {
+		for i := range m.Features {
+			m.Features[i].Created = &now
+			m.Features[i].Updated = &now
+			if err := tx.Create(&m.Features[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range m.Phases {
+			m.Phases[i].Created = &now
+			m.Phases[i].Updated = &now
+			if err := tx.Create(&m.Phases[i]).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&Person{}).Where("owner_pub_key = ?", m.Person.OwnerPubKey).Updates(map[string]interface{}{
+			"extras":  m.Person.Extras,
+			"updated": &now,
+		}).Error; err != nil {
+			return err
+		}
+		for i := range m.Cards {
+			m.Cards[i].Version = 1
+			m.Cards[i].Updated = &now
+			if err := tx.Create(&m.Cards[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,40 @@
+package db
+
+import (
+	"time"
+)
+
+func (db database) CreateTicketLink(link TicketLink) (TicketLink, error) {
+	now := time.Now()
+	link.Created = &now
+	if err := db.db.Create(&link).Error; err != nil {
+		return TicketLink{}, err
+	}
+	return link, nil
+}
+
+// GetTicketLinks returns the links from or to any of the tickets
+func (db database) GetTicketLinks(ticketIds []string) []TicketLink {
+	ms := []TicketLink{}
+	if len(ticketIds) == 0 {
+		return ms
+	}
+	db.db.Where("from_ticket IN (?) OR to_ticket IN (?)", ticketIds, ticketIds).Order("id ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetTicketLinksByType(linkType TicketLinkType) []TicketLink {
+	ms := []TicketLink{}
+	db.db.Where("type = ?", linkType).Find(&ms)
+	return ms
+}
+
+func (db database) GetTicketLink(id uint) TicketLink {
+	m := TicketLink{}
+	db.db.Where("id = ?", id).First(&m)
+	return m
+}
+
+func (db database) DeleteTicketLink(id uint) error {
+	return db.db.Where("id = ?", id).Delete(&TicketLink{}).Error
+}
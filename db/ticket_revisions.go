@@ -0,0 +1,38 @@
+package db
+
+import (
+	"time"
+)
+
+// CreateTicketRevision stores a revision as the next version of its ticket
+func (db database) CreateTicketRevision(revision TicketRevision) (TicketRevision, error) {
+	now := time.Now()
+	revision.Created = &now
+
+	var version int
+	db.db.Model(&TicketRevision{}).Where("ticket_id = ?", revision.TicketId).Select("COALESCE(MAX(version), 0)").Row().Scan(&version)
+	revision.Version = version + 1
+
+	if err := db.db.Create(&revision).Error; err != nil {
+		return TicketRevision{}, err
+	}
+	return revision, nil
+}
+
+func (db database) GetTicketRevisions(ticketId string) []TicketRevision {
+	ms := []TicketRevision{}
+	db.db.Where("ticket_id = ?", ticketId).Order("version ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetTicketRevision(ticketId string, version int) TicketRevision {
+	m := TicketRevision{}
+	db.db.Where("ticket_id = ? AND version = ?", ticketId, version).First(&m)
+	return m
+}
+
+func (db database) CountTicketRevisions(ticketId string) int64 {
+	var count int64
+	db.db.Model(&TicketRevision{}).Where("ticket_id = ?", ticketId).Count(&count)
+	return count
+}
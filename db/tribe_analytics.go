@@ -0,0 +1,108 @@
+package db
+
+import (
+	"time"
+)
+
+func (db database) AddTribeActivity(m TribeActivity) (TribeActivity, error) {
+	now := time.Now()
+	m.Created = &now
+	if err := db.db.Create(&m).Error; err != nil {
+		return TribeActivity{}, err
+	}
+	return m, nil
+}
+
+// GetTribeMembersBefore counts the members in the roster of a tribe who joined before a time
+func (db database) GetTribeMembersBefore(tribeUuid string, before time.Time) int64 {
+	var count int64
+	db.db.Model(&TribeMember{}).Where("tribe_uuid = ? AND joined_at < ?", tribeUuid, before).Count(&count)
+	return count
+}
+
+// GetTribeSeriesValues buckets the members who joined, the activity reported by the relay, the
+// bounties posted to a tribe and its badge changes between two times. The roster only holds the
+// current members, so members who left are not counted when they joined
+func (db database) GetTribeSeriesValues(tribeUuid string, from time.Time, to time.Time, interval MetricsInterval) []MetricsSeriesValue {
+	values := []MetricsSeriesValue{}
+
+	db.db.Raw(`SELECT 'new_members' AS series, DATE_TRUNC(?, joined_at AT TIME ZONE 'UTC') AS bucket, COUNT(*) AS value
+	FROM tribe_members WHERE tribe_uuid = ? AND joined_at >= ? AND joined_at <= ? GROUP BY 2
+	UNION ALL
+	SELECT 'messages', DATE_TRUNC(?, created AT TIME ZONE 'UTC'), COALESCE(SUM(count), 0)
+	FROM tribe_activities WHERE tribe_uuid = ? AND kind = ? AND created >= ? AND created <= ? GROUP BY 2
+	UNION ALL
+	SELECT 'active_pings', DATE_TRUNC(?, created AT TIME ZONE 'UTC'), COUNT(*)
+	FROM tribe_activities WHERE tribe_uuid = ? AND kind = ? AND created >= ? AND created <= ? GROUP BY 2
+	UNION ALL
+	SELECT 'bounties_created', DATE_TRUNC(?, TO_TIMESTAMP(created) AT TIME ZONE 'UTC'), COUNT(*)
+	FROM bounty WHERE tribe = ? AND created >= ? AND created <= ? GROUP BY 2
+	UNION ALL
+	SELECT 'bounty_sats', DATE_TRUNC(?, TO_TIMESTAMP(created) AT TIME ZONE 'UTC'), COALESCE(SUM(price), 0)
+	FROM bounty WHERE tribe = ? AND created >= ? AND created <= ? GROUP BY 2
+	UNION ALL
+	SELECT 'badges_added', DATE_TRUNC(?, created AT TIME ZONE 'UTC'), COUNT(*)
+	FROM tribe_activities WHERE tribe_uuid = ? AND kind = ? AND created >= ? AND created <= ? GROUP BY 2
+	UNION ALL
+	SELECT 'badges_removed', DATE_TRUNC(?, created AT TIME ZONE 'UTC'), COUNT(*)
+	FROM tribe_activities WHERE tribe_uuid = ? AND kind = ? AND created >= ? AND created <= ? GROUP BY 2`,
+		interval, tribeUuid, from, to,
+		interval, tribeUuid, TribeActivityPing, from, to,
+		interval, tribeUuid, TribeActivityPing, from, to,
+		interval, tribeUuid, from.Unix(), to.Unix(),
+		interval, tribeUuid, from.Unix(), to.Unix(),
+		interval, tribeUuid, TribeActivityBadgeAdded, from, to,
+		interval, tribeUuid, TribeActivityBadgeRemoved, from, to,
+	).Scan(&values)
+
+	return values
+}
+
+// BuildTribeAnalytics lays the series values of a tribe out in one point per bucket like
+// BuildMetricsTimeSeries, the members of each point count those who joined up to its end
+func BuildTribeAnalytics(from time.Time, to time.Time, interval MetricsInterval, membersBefore int64, values []MetricsSeriesValue) []TribeAnalyticsPoint {
+	points := []TribeAnalyticsPoint{}
+	index := map[string]int{}
+
+	for bucket := BucketStart(from, interval); !bucket.After(to); {
+		key := bucket.Format("2006-01-02")
+		index[key] = len(points)
+		points = append(points, TribeAnalyticsPoint{Bucket: key})
+
+		if interval == MetricsWeekly {
+			bucket = bucket.AddDate(0, 0, 7)
+		} else {
+			bucket = bucket.AddDate(0, 0, 1)
+		}
+	}
+
+	for _, value := range values {
+		i, ok := index[BucketStart(value.Bucket, interval).Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		switch value.Series {
+		case "new_members":
+			points[i].NewMembers += value.Value
+		case "messages":
+			points[i].Messages += value.Value
+		case "active_pings":
+			points[i].ActivePings += value.Value
+		case "bounties_created":
+			points[i].BountiesCreated += value.Value
+		case "bounty_sats":
+			points[i].BountySats += value.Value
+		case "badges_added":
+			points[i].BadgesAdded += value.Value
+		case "badges_removed":
+			points[i].BadgesRemoved += value.Value
+		}
+	}
+
+	members := membersBefore
+	for i := range points {
+		members += points[i].NewMembers
+		points[i].Members = members
+	}
+	return points
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTribeAnalytics(t *testing.T) {
+	from := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 6, 10, 0, 0, 0, time.UTC)
+	values := []MetricsSeriesValue{
+		{Series: "new_members", Bucket: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), Value: 3},
+		{Series: "new_members", Bucket: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), Value: 2},
+		{Series: "messages", Bucket: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), Value: 40},
+		{Series: "bounty_sats", Bucket: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), Value: 5000},
+		{Series: "badges_added", Bucket: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Value: 1},
+	}
+
+	t.Run("Should test that the members add up across the buckets", func(t *testing.T) {
+		points := BuildTribeAnalytics(from, to, MetricsDaily, 10, values)
+		assert.Len(t, points, 3)
+		assert.Equal(t, "2024-03-04", points[0].Bucket)
+		assert.Equal(t, int64(13), points[0].Members)
+		assert.Equal(t, int64(13), points[1].Members)
+		assert.Equal(t, int64(15), points[2].Members)
+	})
+
+	t.Run("Should test that the values land in their bucket and outside values are dropped", func(t *testing.T) {
+		points := BuildTribeAnalytics(from, to, MetricsDaily, 0, values)
+		assert.Equal(t, int64(40), points[1].Messages)
+		assert.Equal(t, int64(5000), points[1].BountySats)
+		for _, point := range points {
+			assert.Equal(t, int64(0), point.BadgesAdded)
+		}
+	})
+
+	t.Run("Should test that weekly buckets start on monday", func(t *testing.T) {
+		points := BuildTribeAnalytics(from, to, MetricsWeekly, 0, values)
+		assert.Len(t, points, 1)
+		assert.Equal(t, int64(5), points[0].NewMembers)
+		assert.Equal(t, int64(40), points[0].Messages)
+	})
+}
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTribeInviteRevoked  = errors.New("the invite was revoked")
+	ErrTribeInviteExpired  = errors.New("the invite has expired")
+	ErrTribeInviteUsedUp   = errors.New("the invite has been used up")
+	ErrTribeInviteRedeemed = errors.New("the invite was already redeemed by this pubkey")
+)
+
+// TribeInviteError returns why an invite can't be redeemed at a time, or nil when it can
+func TribeInviteError(invite TribeInvite, at time.Time) error {
+	if invite.Revoked {
+		return ErrTribeInviteRevoked
+	}
+	if invite.ExpiresAt != nil && !invite.ExpiresAt.After(at) {
+		return ErrTribeInviteExpired
+	}
+	if invite.MaxUses > 0 && invite.Uses >= invite.MaxUses {
+		return ErrTribeInviteUsedUp
+	}
+	return nil
+}
+
+func (db database) CreateTribeInvite(m TribeInvite) (TribeInvite, error) {
+	now := time.Now()
+	m.Code = utils.GetRandomToken(12)
+	m.Uses = 0
+	m.Revoked = false
+	m.Created = &now
+	m.Updated = &now
+	if err := db.db.Create(&m).Error; err != nil {
+		return TribeInvite{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetTribeInvite(code string) TribeInvite {
+	m := TribeInvite{}
+	db.db.Where("code = ?", code).Find(&m)
+	return m
+}
+
+func (db database) GetTribeInvites(tribeUuid string) []TribeInvite {
+	ms := []TribeInvite{}
+	db.db.Where("tribe_uuid = ?", tribeUuid).Order("created DESC").Find(&ms)
+	return ms
+}
+
+func (db database) RevokeTribeInvite(code string) error {
+	return db.db.Model(&TribeInvite{}).Where("code = ?", code).Updates(map[string]interface{}{
+		"revoked": true,
+		"updated": time.Now(),
+	}).Error
+}
+
+func (db database) GetTribeInviteRedemptions(inviteId uint) []TribeInviteRedemption {
+	ms := []TribeInviteRedemption{}
+	db.db.Where("invite_id = ?", inviteId).Order("created DESC").Find(&ms)
+	return ms
+}
+
+// RedeemTribeInvite uses an invite for a pubkey and adds it to the roster of the tribe. The use is
+// counted only while the invite is still valid, so concurrent redemptions can't go over its limit
+func (db database) RedeemTribeInvite(invite TribeInvite, pubkey string, alias string) (TribeInviteRedemption, error) {
+	now := time.Now()
+
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return TribeInviteRedemption{}, err
+	}
+
+	redeemed := TribeInviteRedemption{}
+	tx.Where("invite_id = ? AND pub_key = ?", invite.ID, pubkey).Find(&redeemed)
+	if redeemed.ID != 0 {
+		tx.Rollback()
+		return TribeInviteRedemption{}, ErrTribeInviteRedeemed
+	}
+
+	result := tx.Model(&TribeInvite{}).
+		Where("id = ? AND revoked = ?", invite.ID, false).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Where("max_uses = 0 OR uses < max_uses").
+		Updates(map[string]interface{}{
+			"uses":    gorm.Expr("uses + 1"),
+			"updated": &now,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return TribeInviteRedemption{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		current := db.GetTribeInvite(invite.Code)
+		if err := TribeInviteError(current, now); err != nil {
+			return TribeInviteRedemption{}, err
+		}
+		return TribeInviteRedemption{}, ErrTribeInviteUsedUp
+	}
+
+	redemption := TribeInviteRedemption{
+		InviteId:  invite.ID,
+		TribeUuid: invite.TribeUuid,
+		PubKey:    pubkey,
+		Alias:     alias,
+		Created:   &now,
+	}
+	if err := tx.Create(&redemption).Error; err != nil {
+		tx.Rollback()
+		return TribeInviteRedemption{}, err
+	}
+
+	member := TribeMember{}
+	tx.Where("tribe_uuid = ? AND member_pub_key = ?", invite.TribeUuid, pubkey).Find(&member)
+	if member.ID == 0 {
+		member = TribeMember{
+			TribeUuid:    invite.TribeUuid,
+			MemberPubKey: pubkey,
+			Alias:        alias,
+			JoinedAt:     &now,
+			Created:      &now,
+			Updated:      &now,
+		}
+		if err := tx.Create(&member).Error; err != nil {
+			tx.Rollback()
+			return TribeInviteRedemption{}, err
+		}
+		if err := tx.Exec("UPDATE tribes SET member_count = member_count + 1 WHERE uuid = ?", invite.TribeUuid).Error; err != nil {
+			tx.Rollback()
+			return TribeInviteRedemption{}, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return TribeInviteRedemption{}, err
+	}
+	return redemption, nil
+}
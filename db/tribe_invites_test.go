@@ -0,0 +1,32 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTribeInviteError(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	t.Run("Should test that an invite without limits can be redeemed", func(t *testing.T) {
+		assert.NoError(t, TribeInviteError(TribeInvite{Uses: 500}, now))
+	})
+
+	t.Run("Should test that a revoked invite can't be redeemed", func(t *testing.T) {
+		assert.Equal(t, ErrTribeInviteRevoked, TribeInviteError(TribeInvite{Revoked: true}, now))
+	})
+
+	t.Run("Should test that the expiry of an invite is enforced", func(t *testing.T) {
+		assert.NoError(t, TribeInviteError(TribeInvite{ExpiresAt: &future}, now))
+		assert.Equal(t, ErrTribeInviteExpired, TribeInviteError(TribeInvite{ExpiresAt: &past}, now))
+	})
+
+	t.Run("Should test that the max uses of an invite are enforced", func(t *testing.T) {
+		assert.NoError(t, TribeInviteError(TribeInvite{MaxUses: 3, Uses: 2}, now))
+		assert.Equal(t, ErrTribeInviteUsedUp, TribeInviteError(TribeInvite{MaxUses: 3, Uses: 3}, now))
+	})
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"time"
+)
+
+func (db database) GetTribeMembers(tribeUuid string) []TribeMember {
+	ms := []TribeMember{}
+	db.db.Where("tribe_uuid = ?", tribeUuid).Order("joined_at ASC, id ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetTribeMember(tribeUuid string, pubkey string) TribeMember {
+	m := TribeMember{}
+	db.db.Where("tribe_uuid = ? AND member_pub_key = ?", tribeUuid, pubkey).Find(&m)
+	return m
+}
+
+// SyncTribeMembers replaces the roster of a tribe with the members the relay reported, keeping
+// when the ones already known joined, and updates the member count of the tribe. It returns the
+// members that were not in the roster before
+func (db database) SyncTribeMembers(tribeUuid string, members []TribeMember) ([]TribeMember, error) {
+	existing := map[string]TribeMember{}
+	for _, m := range db.GetTribeMembers(tribeUuid) {
+		existing[m.MemberPubKey] = m
+	}
+
+	now := time.Now()
+	joined := []TribeMember{}
+	pubkeys := []string{}
+
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		m.TribeUuid = tribeUuid
+		m.Updated = &now
+		if known, ok := existing[m.MemberPubKey]; ok {
+			m.ID = known.ID
+			m.Created = known.Created
+			if known.JoinedAt != nil {
+				m.JoinedAt = known.JoinedAt
+			}
+		} else {
+			m.Created = &now
+		}
+		if m.JoinedAt == nil {
+			m.JoinedAt = &now
+		}
+		if err := tx.Save(&m).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if _, ok := existing[m.MemberPubKey]; !ok {
+			joined = append(joined, m)
+		}
+		pubkeys = append(pubkeys, m.MemberPubKey)
+	}
+
+	left := tx.Where("tribe_uuid = ?", tribeUuid)
+	if len(pubkeys) > 0 {
+		left = left.Where("member_pub_key NOT IN ?", pubkeys)
+	}
+	if err := left.Delete(&TribeMember{}).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Model(&Tribe{}).Where("uuid = ?", tribeUuid).Updates(map[string]interface{}{
+		"member_count": len(pubkeys),
+		"updated":      &now,
+	}).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return joined, nil
+}
+
+// DeleteTribeMember takes a member off the roster of a tribe
+func (db database) DeleteTribeMember(tribeUuid string, pubkey string) error {
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	result := tx.Where("tribe_uuid = ? AND member_pub_key = ?", tribeUuid, pubkey).Delete(&TribeMember{})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		if err := tx.Exec("UPDATE tribes SET member_count = GREATEST(member_count - 1, 0) WHERE uuid = ?", tribeUuid).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// GetTribeBans returns the bans of a tribe that have not expired
+func (db database) GetTribeBans(tribeUuid string) []TribeBan {
+	ms := []TribeBan{}
+	db.db.Where("tribe_uuid = ? AND (expires_at IS NULL OR expires_at > ?)", tribeUuid, time.Now()).Order("created DESC").Find(&ms)
+	return ms
+}
+
+// GetTribeBan returns the ban of a pubkey in a tribe if it has not expired
+func (db database) GetTribeBan(tribeUuid string, pubkey string) TribeBan {
+	m := TribeBan{}
+	db.db.Where("tribe_uuid = ? AND member_pub_key = ? AND (expires_at IS NULL OR expires_at > ?)", tribeUuid, pubkey, time.Now()).Find(&m)
+	return m
+}
+
+// BanTribeMember bans a pubkey from a tribe, replacing an earlier ban, and takes it off the roster
+func (db database) BanTribeMember(ban TribeBan) (TribeBan, error) {
+	now := time.Now()
+	ban.Created = &now
+
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return TribeBan{}, err
+	}
+	if err := tx.Where("tribe_uuid = ? AND member_pub_key = ?", ban.TribeUuid, ban.MemberPubKey).Delete(&TribeBan{}).Error; err != nil {
+		tx.Rollback()
+		return TribeBan{}, err
+	}
+	if err := tx.Create(&ban).Error; err != nil {
+		tx.Rollback()
+		return TribeBan{}, err
+	}
+	result := tx.Where("tribe_uuid = ? AND member_pub_key = ?", ban.TribeUuid, ban.MemberPubKey).Delete(&TribeMember{})
+	if result.Error != nil {
+		tx.Rollback()
+		return TribeBan{}, result.Error
+	}
+	if result.RowsAffected > 0 {
+		if err := tx.Exec("UPDATE tribes SET member_count = GREATEST(member_count - 1, 0) WHERE uuid = ?", ban.TribeUuid).Error; err != nil {
+			tx.Rollback()
+			return TribeBan{}, err
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return TribeBan{}, err
+	}
+	return ban, nil
+}
+
+func (db database) DeleteTribeBan(tribeUuid string, pubkey string) error {
+	return db.db.Where("tribe_uuid = ? AND member_pub_key = ?", tribeUuid, pubkey).Delete(&TribeBan{}).Error
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultTribeRankingSettings order the directory when an admin has not tuned the ranking
+var DefaultTribeRankingSettings = TribeRankingSettings{
+	RecencyWeight:  1,
+	MemberWeight:   0.5,
+	ActivityWeight: 2,
+}
+
+// TribeRankingOrder returns the ORDER BY of the ranked directory. Featured tribes are pinned first
+// in their position, the others by a score adding the weighted recency, the log of the member
+// count and how recently the tribe was active. Recency and activity decay over 30 and 7 days
+func TribeRankingOrder(settings TribeRankingSettings) string {
+	return fmt.Sprintf(`featured DESC NULLS LAST, featured_position ASC,
+	(%f * EXP(-GREATEST(EXTRACT(EPOCH FROM NOW() - COALESCE(created, NOW())), 0) / 2592000)
+	+ %f * LN(1 + COALESCE(member_count, 0))
+	+ %f * EXP(-GREATEST(EXTRACT(EPOCH FROM NOW()) - COALESCE(last_active, 0), 0) / 604800)) DESC, created DESC`,
+		settings.RecencyWeight, settings.MemberWeight, settings.ActivityWeight)
+}
+
+// GetTribeRankingSettings returns the ranking settings, the defaults when none were saved
+func (db database) GetTribeRankingSettings() TribeRankingSettings {
+	settings := TribeRankingSettings{}
+	db.db.Order("id ASC").Limit(1).Find(&settings)
+	if settings.ID == 0 {
+		return DefaultTribeRankingSettings
+	}
+	return settings
+}
+
+func (db database) UpdateTribeRankingSettings(settings TribeRankingSettings) (TribeRankingSettings, error) {
+	now := time.Now()
+	settings.ID = db.GetTribeRankingSettings().ID
+	settings.Updated = &now
+	if err := db.db.Save(&settings).Error; err != nil {
+		return TribeRankingSettings{}, err
+	}
+	return settings, nil
+}
+
+// GetFeaturedTribes returns the listed featured tribes in their position
+func (db database) GetFeaturedTribes() []Tribe {
+	ms := []Tribe{}
+	db.db.Where("featured = ? AND (unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)", true).
+		Order("featured_position ASC, created DESC").
+		Find(&ms)
+	return ms
+}
+
+// SetTribeFeatured pins a tribe to the directory at a position or takes it off
+func (db database) SetTribeFeatured(uuid string, featured bool, position int) error {
+	if !featured {
+		position = 0
+	}
+	return db.db.Model(&Tribe{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"featured":          featured,
+		"featured_position": position,
+	}).Error
+}
+
+// ReorderFeaturedTribes sets the position of the featured tribes to their index in uuids
+func (db database) ReorderFeaturedTribes(uuids []string) error {
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	for position, uuid := range uuids {
+		err := tx.Model(&Tribe{}).Where("uuid = ? AND featured = ?", uuid, true).Updates(map[string]interface{}{
+			"featured_position": position,
+		}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
@@ -0,0 +1,22 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTribeRankingOrder(t *testing.T) {
+	t.Run("Should test that featured tribes are pinned first in their position", func(t *testing.T) {
+		order := TribeRankingOrder(DefaultTribeRankingSettings)
+		assert.True(t, strings.HasPrefix(order, "featured DESC NULLS LAST, featured_position ASC"))
+	})
+
+	t.Run("Should test that the weights are part of the score", func(t *testing.T) {
+		order := TribeRankingOrder(TribeRankingSettings{RecencyWeight: 1.5, MemberWeight: 0.25, ActivityWeight: 3})
+		assert.Contains(t, order, "1.500000 * EXP(")
+		assert.Contains(t, order, "0.250000 * LN(1 + COALESCE(member_count, 0))")
+		assert.Contains(t, order, "3.000000 * EXP(")
+	})
+}
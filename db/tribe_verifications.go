@@ -0,0 +1,77 @@
+package db
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+func (db database) CreateTribeVerification(m TribeVerification) (TribeVerification, error) {
+	now := time.Now()
+	m.Uuid = xid.New().String()
+	m.Token = "sphinx-tribe-verification=" + utils.GetRandomToken(16)
+	m.Status = TribeVerificationPending
+	m.Created = &now
+	m.Updated = &now
+	if err := db.db.Create(&m).Error; err != nil {
+		return TribeVerification{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetTribeVerification(uuid string) TribeVerification {
+	m := TribeVerification{}
+	db.db.Where("uuid = ?", uuid).Find(&m)
+	return m
+}
+
+func (db database) GetTribeVerifications(tribeUuid string) []TribeVerification {
+	ms := []TribeVerification{}
+	db.db.Where("tribe_uuid = ?", tribeUuid).Order("created DESC").Find(&ms)
+	return ms
+}
+
+// GetTribeVerificationsByStatus returns the claims of all tribes in a status, oldest first
+func (db database) GetTribeVerificationsByStatus(status TribeVerificationStatus) []TribeVerification {
+	ms := []TribeVerification{}
+	db.db.Where("status = ?", status).Order("updated ASC").Find(&ms)
+	return ms
+}
+
+// UpdateTribeVerification saves the outcome of a check or a review of a claim and sets whether
+// its tribe is verified, which it is while any of its claims is
+func (db database) UpdateTribeVerification(m TribeVerification) (TribeVerification, error) {
+	now := time.Now()
+	m.Updated = &now
+
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return TribeVerification{}, err
+	}
+	if err := tx.Model(&TribeVerification{}).Where("uuid = ?", m.Uuid).Updates(map[string]interface{}{
+		"status":      m.Status,
+		"error":       m.Error,
+		"reviewed_by": m.ReviewedBy,
+		"note":        m.Note,
+		"updated":     &now,
+	}).Error; err != nil {
+		tx.Rollback()
+		return TribeVerification{}, err
+	}
+
+	var verified int64
+	if err := tx.Model(&TribeVerification{}).Where("tribe_uuid = ? AND status = ?", m.TribeUuid, TribeVerificationVerified).Count(&verified).Error; err != nil {
+		tx.Rollback()
+		return TribeVerification{}, err
+	}
+	if err := tx.Model(&Tribe{}).Where("uuid = ?", m.TribeUuid).Update("verified", verified > 0).Error; err != nil {
+		tx.Rollback()
+		return TribeVerification{}, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return TribeVerification{}, err
+	}
+	return m, nil
+}
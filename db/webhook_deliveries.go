@@ -0,0 +1,63 @@
+package db
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+)
+
+const MaxWebhookDeliveryAttempts = 6
+
+// CreateWebhookDelivery queues a delivery to be posted right away
+func (db database) CreateWebhookDelivery(m WebhookDelivery) (WebhookDelivery, error) {
+	now := time.Now()
+	m.Uuid = xid.New().String()
+	m.Status = WebhookDeliveryPending
+	m.Attempts = 0
+	m.NextRunAt = &now
+	m.Created = &now
+	m.Updated = &now
+	if err := db.db.Create(&m).Error; err != nil {
+		return WebhookDelivery{}, err
+	}
+	return m, nil
+}
+
+// GetPendingWebhookDeliveries returns the oldest deliveries due to be posted
+func (db database) GetPendingWebhookDeliveries(limit int) []WebhookDelivery {
+	ms := []WebhookDelivery{}
+	db.db.Where("status = ? AND next_run_at <= ?", WebhookDeliveryPending, time.Now()).Order("id ASC").Limit(limit).Find(&ms)
+	return ms
+}
+
+// ClaimWebhookDelivery marks a pending delivery as being posted, it reports false if another
+// worker claimed it first
+func (db database) ClaimWebhookDelivery(id uint) bool {
+	result := db.db.Model(&WebhookDelivery{}).
+		Where("id = ?", id).
+		Where("status = ?", WebhookDeliveryPending).
+		Updates(map[string]interface{}{
+			"status":  WebhookDeliveryDelivering,
+			"updated": time.Now(),
+		})
+	return result.Error == nil && result.RowsAffected == 1
+}
+
+// UpdateWebhookDelivery saves the outcome of an attempt
+func (db database) UpdateWebhookDelivery(m WebhookDelivery) error {
+	return db.db.Model(&WebhookDelivery{}).Where("id = ?", m.ID).Updates(map[string]interface{}{
+		"status":          m.Status,
+		"attempts":        m.Attempts,
+		"response_status": m.ResponseStatus,
+		"error":           m.Error,
+		"next_run_at":     m.NextRunAt,
+		"updated":         time.Now(),
+	}).Error
+}
+
+// GetWebhookDeliveries returns the latest deliveries to the url registered by a source
+func (db database) GetWebhookDeliveries(source string, sourceUuid string, limit int) []WebhookDelivery {
+	ms := []WebhookDelivery{}
+	db.db.Where("source = ? AND source_uuid = ?", source, sourceUuid).Order("id DESC").Limit(limit).Find(&ms)
+	return ms
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Elapsed is the tracked time of a session in seconds, up to now when it is running
+func (s BountyWorkSession) Elapsed(now time.Time) uint {
+	seconds := s.Seconds
+	if s.Status == WorkSessionRunning && s.LastStarted != nil && now.After(*s.LastStarted) {
+		seconds += uint(now.Sub(*s.LastStarted) / time.Second)
+	}
+	return seconds
+}
+
+func (db database) CreateWorkSession(m BountyWorkSession) (BountyWorkSession, error) {
+	now := time.Now()
+	m.Status = WorkSessionRunning
+	m.Started = &now
+	m.LastStarted = &now
+	m.Updated = &now
+
+	if err := db.db.Create(&m).Error; err != nil {
+		return BountyWorkSession{}, err
+	}
+	return m, nil
+}
+
+// GetActiveWorkSession returns the running or paused session of a hunter on a bounty
+func (db database) GetActiveWorkSession(bountyId uint, hunter string) BountyWorkSession {
+	m := BountyWorkSession{}
+	db.db.Where("bounty_id = ? AND hunter = ? AND status IN (?)", bountyId, hunter, []WorkSessionStatus{WorkSessionRunning, WorkSessionPaused}).Find(&m)
+	return m
+}
+
+// UpdateWorkSession stores the next status of a session and adds the seconds it tracked since
+// it last started to the time spent on its bounty. It fails when the session left the expected
+// status meanwhile, so a run is never counted twice
+func (db database) UpdateWorkSession(m BountyWorkSession, from WorkSessionStatus, added uint) (BountyWorkSession, error) {
+	now := time.Now()
+	m.Updated = &now
+
+	tx := db.db.Begin()
+	if err := tx.Error; err != nil {
+		return BountyWorkSession{}, err
+	}
+
+	result := tx.Model(&BountyWorkSession{}).Where("id = ? AND status = ?", m.ID, from).Updates(map[string]interface{}{
+		"status":       m.Status,
+		"seconds":      m.Seconds,
+		"last_started": m.LastStarted,
+		"stopped":      m.Stopped,
+		"updated":      m.Updated,
+	})
+	if result.Error != nil {
+		tx.Rollback()
+		return BountyWorkSession{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return BountyWorkSession{}, errors.New("work session is not " + string(from))
+	}
+	if added > 0 {
+		if err := tx.Model(&NewBounty{}).Where("id = ?", m.BountyId).Update("time_spent", gorm.Expr("time_spent + ?", added)).Error; err != nil {
+			tx.Rollback()
+			return BountyWorkSession{}, err
+		}
+	}
+
+	return m, tx.Commit().Error
+}
+
+// GetBountyWorkSessions lists the work sessions of a bounty, newest first
+func (db database) GetBountyWorkSessions(bountyId uint) []BountyWorkSession {
+	ms := []BountyWorkSession{}
+	db.db.Where("bounty_id = ?", bountyId).Order("started DESC").Find(&ms)
+	return ms
+}
+
+// GetWorkspaceWorkSessions lists the work sessions started on the bounties of a workspace since a time, oldest first
+func (db database) GetWorkspaceWorkSessions(workspaceUuid string, since time.Time) []BountyWorkSession {
+	ms := []BountyWorkSession{}
+	db.db.Where("workspace_uuid = ? AND started >= ?", workspaceUuid, since).Order("started ASC").Find(&ms)
+	return ms
+}
+
+// WeekStart is the monday that starts the UTC week of a time
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// SummarizeWorkHours totals the tracked time per week and hunter, the sessions must be oldest first.
+// A session counts in the week it started, running ones up to now
+func SummarizeWorkHours(sessions []BountyWorkSession, now time.Time) []WeeklyWorkHours {
+	weeks := []WeeklyWorkHours{}
+	index := map[string]int{}
+
+	for _, session := range sessions {
+		if session.Started == nil {
+			continue
+		}
+		week := WeekStart(*session.Started).Format("2006-01-02")
+
+		key := week + "/" + session.Hunter
+		i, ok := index[key]
+		if !ok {
+			i = len(weeks)
+			index[key] = i
+			weeks = append(weeks, WeeklyWorkHours{Week: week, Hunter: session.Hunter})
+		}
+
+		weeks[i].Seconds += session.Elapsed(now)
+		weeks[i].Sessions++
+	}
+
+	for i := range weeks {
+		weeks[i].Hours = math.Round(float64(weeks[i].Seconds)/36) / 100
+	}
+	return weeks
+}
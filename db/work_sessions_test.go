@@ -0,0 +1,36 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeekStart(t *testing.T) {
+	sunday := time.Date(2025, time.March, 9, 23, 0, 0, 0, time.UTC)
+	monday := time.Date(2025, time.March, 10, 0, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), WeekStart(sunday))
+	assert.Equal(t, time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC), WeekStart(monday))
+}
+
+func TestSummarizeWorkHours(t *testing.T) {
+	now := time.Date(2025, time.March, 12, 12, 0, 0, 0, time.UTC)
+	first := time.Date(2025, time.March, 4, 9, 0, 0, 0, time.UTC)
+	second := time.Date(2025, time.March, 6, 9, 0, 0, 0, time.UTC)
+	running := now.Add(-30 * time.Minute)
+
+	weeks := SummarizeWorkHours([]BountyWorkSession{
+		{Hunter: "alice", Status: WorkSessionStopped, Seconds: 3600, Started: &first},
+		{Hunter: "bob", Status: WorkSessionPaused, Seconds: 1800, Started: &first},
+		{Hunter: "alice", Status: WorkSessionStopped, Seconds: 5400, Started: &second},
+		{Hunter: "alice", Status: WorkSessionRunning, Seconds: 600, Started: &running, LastStarted: &running},
+	}, now)
+
+	assert.Equal(t, []WeeklyWorkHours{
+		{Week: "2025-03-03", Hunter: "alice", Seconds: 9000, Hours: 2.5, Sessions: 2},
+		{Week: "2025-03-03", Hunter: "bob", Seconds: 1800, Hours: 0.5, Sessions: 1},
+		{Week: "2025-03-10", Hunter: "alice", Seconds: 2400, Hours: 0.67, Sessions: 1},
+	}, weeks)
+}
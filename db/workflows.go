@@ -0,0 +1,173 @@
+package db
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+const MaxWorkflowStepAttempts = 3
+
+type WorkflowSteps []WorkflowStep
+
+// Value ...
+func (s WorkflowSteps) Value() (driver.Value, error) {
+	if s == nil {
+		return json.Marshal([]WorkflowStep{})
+	}
+	return json.Marshal(s)
+}
+
+// Scan ...
+func (s *WorkflowSteps) Scan(src interface{}) error {
+	if src == nil {
+		*s = nil
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("type assertion .([]byte) failed")
+	}
+	return json.Unmarshal(source, s)
+}
+
+type WorkflowStepStates []WorkflowStepState
+
+// Value ...
+func (s WorkflowStepStates) Value() (driver.Value, error) {
+	if s == nil {
+		return json.Marshal([]WorkflowStepState{})
+	}
+	return json.Marshal(s)
+}
+
+// Scan ...
+func (s *WorkflowStepStates) Scan(src interface{}) error {
+	if src == nil {
+		*s = nil
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("type assertion .([]byte) failed")
+	}
+	return json.Unmarshal(source, s)
+}
+
+func (db database) CreateOrEditWorkflow(m Workflow) (Workflow, error) {
+	now := time.Now()
+	m.Updated = &now
+	if m.Uuid == "" {
+		m.Uuid = xid.New().String()
+		m.Created = &now
+		if err := db.db.Create(&m).Error; err != nil {
+			return Workflow{}, err
+		}
+		return m, nil
+	}
+	err := db.db.Model(&Workflow{}).Where("uuid = ?", m.Uuid).Updates(map[string]interface{}{
+		"name":    m.Name,
+		"trigger": m.Trigger,
+		"steps":   m.Steps,
+		"enabled": m.Enabled,
+		"updated": now,
+	}).Error
+	if err != nil {
+		return Workflow{}, err
+	}
+	return db.GetWorkflowByUuid(m.Uuid), nil
+}
+
+func (db database) GetWorkflowByUuid(uuid string) Workflow {
+	m := Workflow{}
+	db.db.Where("uuid = ?", uuid).First(&m)
+	return m
+}
+
+func (db database) GetWorkspaceWorkflows(workspaceUuid string) []Workflow {
+	ms := []Workflow{}
+	db.db.Where("workspace_uuid = ?", workspaceUuid).Order("id ASC").Find(&ms)
+	return ms
+}
+
+// GetTriggeredWorkflows returns the enabled workflows of a workspace started by a trigger
+func (db database) GetTriggeredWorkflows(workspaceUuid string, trigger string) []Workflow {
+	ms := []Workflow{}
+	db.db.Where("workspace_uuid = ? AND trigger = ? AND enabled = ?", workspaceUuid, trigger, true).Order("id ASC").Find(&ms)
+	return ms
+}
+
+// CreateWorkflowExecution queues a run of a workflow, with a pending state for each of its steps
+func (db database) CreateWorkflowExecution(workflow Workflow, trigger string, input PropertyMap) (WorkflowExecution, error) {
+	now := time.Now()
+	steps := WorkflowStepStates{}
+	for _, step := range workflow.Steps {
+		steps = append(steps, WorkflowStepState{Type: step.Type, Params: step.Params, Status: WorkflowPending})
+	}
+	if input == nil {
+		input = PropertyMap{}
+	}
+	m := WorkflowExecution{
+		Uuid:          xid.New().String(),
+		WorkflowUuid:  workflow.Uuid,
+		WorkspaceUuid: workflow.WorkspaceUuid,
+		Trigger:       trigger,
+		Input:         input,
+		Status:        WorkflowPending,
+		Steps:         steps,
+		NextRunAt:     &now,
+		Created:       &now,
+		Updated:       &now,
+	}
+	if err := db.db.Create(&m).Error; err != nil {
+		return WorkflowExecution{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetWorkflowExecution(uuid string) WorkflowExecution {
+	m := WorkflowExecution{}
+	db.db.Where("uuid = ?", uuid).First(&m)
+	return m
+}
+
+// GetWorkflowExecutions returns the runs of a workflow, the latest first
+func (db database) GetWorkflowExecutions(workflowUuid string, limit int) []WorkflowExecution {
+	ms := []WorkflowExecution{}
+	db.db.Where("workflow_uuid = ?", workflowUuid).Order("id DESC").Limit(limit).Find(&ms)
+	return ms
+}
+
+// GetPendingWorkflowExecutions returns the oldest executions due to run
+func (db database) GetPendingWorkflowExecutions(limit int) []WorkflowExecution {
+	ms := []WorkflowExecution{}
+	db.db.Where("status = ? AND next_run_at <= ?", WorkflowPending, time.Now()).Order("id ASC").Limit(limit).Find(&ms)
+	return ms
+}
+
+// ClaimWorkflowExecution marks a pending execution as running, it reports false if another
+// worker claimed it first
+func (db database) ClaimWorkflowExecution(id uint) bool {
+	result := db.db.Model(&WorkflowExecution{}).
+		Where("id = ?", id).
+		Where("status = ?", WorkflowPending).
+		Updates(map[string]interface{}{
+			"status":  WorkflowRunning,
+			"updated": time.Now(),
+		})
+	return result.Error == nil && result.RowsAffected == 1
+}
+
+// UpdateWorkflowExecution saves the progress of an execution
+func (db database) UpdateWorkflowExecution(m WorkflowExecution) error {
+	return db.db.Model(&WorkflowExecution{}).Where("id = ?", m.ID).Updates(map[string]interface{}{
+		"status":       m.Status,
+		"current_step": m.CurrentStep,
+		"steps":        m.Steps,
+		"next_run_at":  m.NextRunAt,
+		"updated":      time.Now(),
+	}).Error
+}
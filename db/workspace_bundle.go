@@ -0,0 +1,139 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GetWorkspaceBundle reads everything a workspace backup holds, the ticket cards come without
+// their tickets which are kept on the profiles of their owners
+func (db database) GetWorkspaceBundle(uuid string) WorkspaceBundle {
+	now := time.Now()
+	m := WorkspaceBundle{
+		Version:       WorkspaceBundleVersion,
+		ExportedAt:    &now,
+		Workspace:     db.GetWorkspaceByUuid(uuid),
+		Repositories:  []WorkspaceRepositories{},
+		Features:      []WorkspaceFeatures{},
+		Stories:       []FeatureStory{},
+		Phases:        []FeaturePhase{},
+		Tickets:       []WorkspaceBundleTicket{},
+		Bounties:      []NewBounty{},
+		Users:         []WorkspaceUsers{},
+		Roles:         []WorkspaceUserRoles{},
+		Budget:        db.GetWorkspaceBudget(uuid),
+		BudgetHistory: []NewPaymentHistory{},
+	}
+
+	db.db.Where("workspace_uuid = ?", uuid).Order("created ASC").Find(&m.Repositories)
+	db.db.Where("workspace_uuid = ?", uuid).Order("created ASC").Find(&m.Features)
+	featureUuids := []string{}
+	for _, feature := range m.Features {
+		featureUuids = append(featureUuids, feature.Uuid)
+	}
+	if len(featureUuids) > 0 {
+		db.db.Where("feature_uuid IN (?)", featureUuids).Order("created ASC").Find(&m.Stories)
+		db.db.Where("feature_uuid IN (?)", featureUuids).Order("created ASC").Find(&m.Phases)
+	}
+	phaseUuids := []string{}
+	for _, phase := range m.Phases {
+		phaseUuids = append(phaseUuids, phase.Uuid)
+	}
+	if len(phaseUuids) > 0 {
+		cards := []TicketCard{}
+		db.db.Where("phase_uuid IN (?)", phaseUuids).Order("phase_uuid, lane, rank").Find(&cards)
+		for _, card := range cards {
+			m.Tickets = append(m.Tickets, WorkspaceBundleTicket{TicketCard: card})
+		}
+	}
+	db.db.Where("workspace_uuid = ?", uuid).Order("created ASC").Find(&m.Bounties)
+	db.db.Where("workspace_uuid = ?", uuid).Order("created ASC").Find(&m.Users)
+	db.db.Where("workspace_uuid = ?", uuid).Order("owner_pub_key, role").Find(&m.Roles)
+	db.db.Where("workspace_uuid = ?", uuid).Order("created ASC").Find(&m.BudgetHistory)
+
+	return m
+}
+
+// ImportWorkspaceBundle creates a workspace from a remapped bundle along with the tickets added to
+// the profiles in people, nothing is created when any of it fails. Bounty ids are assigned on
+// insert so the budget history is pointed at the new ids
+func (db database) ImportWorkspaceBundle(m WorkspaceBundle, people []Person) (Workspace, error) {
+	now := time.Now()
+	err := db.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&m.Workspace).Error; err != nil {
+			return err
+		}
+		for i := range m.Repositories {
+			if err := tx.Create(&m.Repositories[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range m.Features {
+			if err := tx.Create(&m.Features[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range m.Stories {
+			if err := tx.Create(&m.Stories[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range m.Phases {
+			if err := tx.Create(&m.Phases[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		bountyIds := map[uint]uint{}
+		for i := range m.Bounties {
+			oldId := m.Bounties[i].ID
+			m.Bounties[i].ID = 0
+			if err := tx.Create(&m.Bounties[i]).Error; err != nil {
+				return err
+			}
+			bountyIds[oldId] = m.Bounties[i].ID
+		}
+		for i := range m.BudgetHistory {
+			m.BudgetHistory[i].BountyId = bountyIds[m.BudgetHistory[i].BountyId]
+			if err := tx.Create(&m.BudgetHistory[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range m.Users {
+			if err := tx.Create(&m.Users[i]).Error; err != nil {
+				return err
+			}
+		}
+		if len(m.Roles) > 0 {
+			if err := tx.Create(&m.Roles).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(&m.Budget).Error; err != nil {
+			return err
+		}
+
+		for _, person := range people {
+			if err := tx.Model(&Person{}).Where("owner_pub_key = ?", person.OwnerPubKey).Updates(map[string]interface{}{
+				"extras":  person.Extras,
+				"updated": &now,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		for _, ticket := range m.Tickets {
+			card := ticket.TicketCard
+			card.Updated = &now
+			if err := tx.Create(&card).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Workspace{}, err
+	}
+	return m.Workspace, nil
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+func (db database) GetWorkspaceCalendarToken(workspaceUuid string) WorkspaceCalendarToken {
+	m := WorkspaceCalendarToken{}
+	db.db.Where("workspace_uuid = ?", workspaceUuid).Find(&m)
+	return m
+}
+
+// RotateWorkspaceCalendarToken gives a workspace a new calendar token, the subscriptions to the
+// url of the previous one stop updating
+func (db database) RotateWorkspaceCalendarToken(workspaceUuid string, createdBy string) (WorkspaceCalendarToken, error) {
+	now := time.Now()
+	m := db.GetWorkspaceCalendarToken(workspaceUuid)
+	m.WorkspaceUuid = workspaceUuid
+	m.Token = utils.GetRandomToken(32)
+	m.CreatedBy = createdBy
+	m.Created = &now
+	if err := db.db.Save(&m).Error; err != nil {
+		return WorkspaceCalendarToken{}, err
+	}
+	return m, nil
+}
+
+// GetWorkspaceCalendarBounties returns the unpaid bounties of a workspace that have a deadline
+func (db database) GetWorkspaceCalendarBounties(workspaceUuid string) []NewBounty {
+	ms := []NewBounty{}
+	db.db.Where("workspace_uuid = ? AND paid = false", workspaceUuid).
+		Where("COALESCE(bounty_expires, '') <> '' OR COALESCE(estimated_completion_date, '') <> ''").
+		Order("created ASC").
+		Find(&ms)
+	return ms
+}
+
+// GetWorkspaceCalendarPhases returns the phases of the features of a workspace that have an estimate
+func (db database) GetWorkspaceCalendarPhases(workspaceUuid string) []WorkspaceCalendarPhase {
+	ms := []WorkspaceCalendarPhase{}
+	db.db.Raw(`SELECT p.uuid, p.feature_uuid, f.name AS feature_name, p.name, p.estimated_days, p.created, p.updated
+	FROM feature_phases p INNER JOIN workspace_features f ON f.uuid = p.feature_uuid
+	WHERE f.workspace_uuid = ? AND p.estimated_days > 0 AND p.created IS NOT NULL
+	ORDER BY p.created ASC`, workspaceUuid).Scan(&ms)
+	return ms
+}
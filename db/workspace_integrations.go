@@ -0,0 +1,53 @@
+package db
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// CreateOrEditWorkspaceIntegration saves an integration of a workspace, an edit without a url
+// keeps the url it had
+func (db database) CreateOrEditWorkspaceIntegration(m WorkspaceIntegration) (WorkspaceIntegration, error) {
+	now := time.Now()
+	if m.Uuid == "" {
+		m.Uuid = xid.New().String()
+		m.Created = &now
+	} else {
+		existing := db.GetWorkspaceIntegration(m.Uuid)
+		m.ID = existing.ID
+		m.CreatedBy = existing.CreatedBy
+		m.Created = existing.Created
+		if m.Url == "" {
+			m.Url = existing.Url
+		}
+	}
+	m.Updated = &now
+	if err := db.db.Save(&m).Error; err != nil {
+		return WorkspaceIntegration{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetWorkspaceIntegration(uuid string) WorkspaceIntegration {
+	m := WorkspaceIntegration{}
+	db.db.Where("uuid = ?", uuid).Find(&m)
+	return m
+}
+
+func (db database) GetWorkspaceIntegrations(workspaceUuid string) []WorkspaceIntegration {
+	ms := []WorkspaceIntegration{}
+	db.db.Where("workspace_uuid = ?", workspaceUuid).Order("id ASC").Find(&ms)
+	return ms
+}
+
+// GetWorkspaceIntegrationsForEvent returns the enabled integrations of a workspace subscribed to an event
+func (db database) GetWorkspaceIntegrationsForEvent(workspaceUuid string, event string) []WorkspaceIntegration {
+	ms := []WorkspaceIntegration{}
+	db.db.Where("workspace_uuid = ? AND enabled = ? AND ? = ANY(events)", workspaceUuid, true, event).Find(&ms)
+	return ms
+}
+
+func (db database) DeleteWorkspaceIntegration(uuid string) error {
+	return db.db.Where("uuid = ?", uuid).Delete(&WorkspaceIntegration{}).Error
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// Value ...
+func (s WorkspaceTemplateStructure) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan ...
+func (s *WorkspaceTemplateStructure) Scan(src interface{}) error {
+	if src == nil {
+		*s = WorkspaceTemplateStructure{}
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("type assertion .([]byte) failed")
+	}
+	return json.Unmarshal(source, s)
+}
+
+func (db database) CreateOrEditWorkspaceTemplate(m WorkspaceTemplate) (WorkspaceTemplate, error) {
+	now := time.Now()
+	m.Updated = &now
+	if m.Uuid == "" {
+		m.Uuid = xid.New().String()
+		m.Created = &now
+		if err := db.db.Create(&m).Error; err != nil {
+			return WorkspaceTemplate{}, err
+		}
+		return m, nil
+	}
+
+	err := db.db.Model(&WorkspaceTemplate{}).Where("uuid = ?", m.Uuid).Updates(map[string]interface{}{
+		"name":        m.Name,
+		"description": m.Description,
+		"structure":   m.Structure,
+		"updated":     m.Updated,
+	}).Error
+	if err != nil {
+		return WorkspaceTemplate{}, err
+	}
+	return db.GetWorkspaceTemplate(m.Uuid), nil
+}
+
+func (db database) GetWorkspaceTemplate(uuid string) WorkspaceTemplate {
+	m := WorkspaceTemplate{}
+	db.db.Where("uuid = ?", uuid).Find(&m)
+	return m
+}
+
+// GetWorkspaceTemplates lists the templates of a pubkey, the latest first
+func (db database) GetWorkspaceTemplates(pubkey string) []WorkspaceTemplate {
+	ms := []WorkspaceTemplate{}
+	db.db.Where("owner_pub_key = ?", pubkey).Order("created DESC").Find(&ms)
+	return ms
+}
+
+func (db database) DeleteWorkspaceTemplate(uuid string) error {
+	return db.db.Where("uuid = ?", uuid).Delete(&WorkspaceTemplate{}).Error
+}
@@ -0,0 +1,185 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTransferPending  = errors.New("workspace already has a pending transfer")
+	ErrTransferResolved = errors.New("transfer is no longer pending")
+	ErrTransferStale    = errors.New("workspace owner changed since the transfer was started")
+)
+
+func auditWorkspace(tx *gorm.DB, workspaceUuid string, action string, actor string, target string, details string) error {
+	now := time.Now()
+	return tx.Create(&WorkspaceAuditLog{
+		WorkspaceUuid: workspaceUuid,
+		Action:        action,
+		ActorPubKey:   actor,
+		TargetPubKey:  target,
+		Details:       details,
+		Created:       &now,
+	}).Error
+}
+
+// CreateWorkspaceTransfer starts a transfer, a workspace has at most one pending transfer
+func (db database) CreateWorkspaceTransfer(m WorkspaceTransfer) (WorkspaceTransfer, error) {
+	now := time.Now()
+	m.Uuid = xid.New().String()
+	m.Status = WorkspaceTransferPending
+	m.ResolvedAt = nil
+	m.Created = &now
+
+	err := db.db.Transaction(func(tx *gorm.DB) error {
+		var pending int64
+		tx.Model(&WorkspaceTransfer{}).Where("workspace_uuid = ? AND status = ? AND expires_at > ?", m.WorkspaceUuid, WorkspaceTransferPending, now).Count(&pending)
+		if pending > 0 {
+			return ErrTransferPending
+		}
+		if err := tx.Create(&m).Error; err != nil {
+			return err
+		}
+		return auditWorkspace(tx, m.WorkspaceUuid, "transfer_started", m.FromPubKey, m.ToPubKey, fmt.Sprintf("expires at %s", m.ExpiresAt.UTC().Format(time.RFC3339)))
+	})
+	if err != nil {
+		return WorkspaceTransfer{}, err
+	}
+	return m, nil
+}
+
+func (db database) GetWorkspaceTransferByUuid(uuid string) WorkspaceTransfer {
+	m := WorkspaceTransfer{}
+	db.db.Where("uuid = ?", uuid).Find(&m)
+	return m
+}
+
+// GetWorkspaceTransfers lists the transfers of a workspace, newest first
+func (db database) GetWorkspaceTransfers(workspaceUuid string) []WorkspaceTransfer {
+	ms := []WorkspaceTransfer{}
+	db.db.Where("workspace_uuid = ?", workspaceUuid).Order("created DESC").Find(&ms)
+	return ms
+}
+
+// GetIncomingWorkspaceTransfers lists the transfers a pubkey can still accept
+func (db database) GetIncomingWorkspaceTransfers(pubkey string) []WorkspaceTransfer {
+	ms := []WorkspaceTransfer{}
+	db.db.Where("to_pub_key = ? AND status = ? AND expires_at > ?", pubkey, WorkspaceTransferPending, time.Now()).Order("created DESC").Find(&ms)
+	return ms
+}
+
+// ResolveWorkspaceTransfer declines or cancels a pending transfer
+func (db database) ResolveWorkspaceTransfer(uuid string, status WorkspaceTransferStatus, actor string) (WorkspaceTransfer, error) {
+	m := WorkspaceTransfer{}
+	err := db.db.Transaction(func(tx *gorm.DB) error {
+		if err := resolveTransfer(tx, uuid, status); err != nil {
+			return err
+		}
+		tx.Where("uuid = ?", uuid).Find(&m)
+		return auditWorkspace(tx, m.WorkspaceUuid, "transfer_"+string(status), actor, m.ToPubKey, "")
+	})
+	if err != nil {
+		return WorkspaceTransfer{}, err
+	}
+	return m, nil
+}
+
+func resolveTransfer(tx *gorm.DB, uuid string, status WorkspaceTransferStatus) error {
+	now := time.Now()
+	result := tx.Model(&WorkspaceTransfer{}).
+		Where("uuid = ? AND status = ? AND expires_at > ?", uuid, WorkspaceTransferPending, now).
+		Updates(map[string]interface{}{"status": status, "resolved_at": &now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTransferResolved
+	}
+	return nil
+}
+
+// AcceptWorkspaceTransfer makes the new owner own the workspace. The new owner's user row and roles
+// go away since the owner has every role, and the previous owner stays on as a user with every role
+// until the new owner removes them
+func (db database) AcceptWorkspaceTransfer(uuid string) (WorkspaceTransfer, error) {
+	m := WorkspaceTransfer{}
+	err := db.db.Transaction(func(tx *gorm.DB) error {
+		if err := resolveTransfer(tx, uuid, WorkspaceTransferAccepted); err != nil {
+			return err
+		}
+		tx.Where("uuid = ?", uuid).Find(&m)
+
+		now := time.Now()
+		result := tx.Model(&Workspace{}).
+			Where("uuid = ? AND owner_pub_key = ?", m.WorkspaceUuid, m.FromPubKey).
+			Updates(map[string]interface{}{"owner_pub_key": m.ToPubKey, "updated": &now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTransferStale
+		}
+
+		if err := tx.Where("workspace_uuid = ? AND owner_pub_key IN ?", m.WorkspaceUuid, []string{m.ToPubKey, m.FromPubKey}).Delete(&WorkspaceUserRoles{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_uuid = ? AND owner_pub_key IN ?", m.WorkspaceUuid, []string{m.ToPubKey, m.FromPubKey}).Delete(&WorkspaceUsers{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&WorkspaceUsers{OwnerPubKey: m.FromPubKey, WorkspaceUuid: m.WorkspaceUuid, Created: &now, Updated: &now}).Error; err != nil {
+			return err
+		}
+		roles := []WorkspaceUserRoles{}
+		for _, role := range ConfigBountyRoles {
+			roles = append(roles, WorkspaceUserRoles{Role: role.Name, OwnerPubKey: m.FromPubKey, WorkspaceUuid: m.WorkspaceUuid, Created: &now})
+		}
+		if err := tx.Create(&roles).Error; err != nil {
+			return err
+		}
+
+		return auditWorkspace(tx, m.WorkspaceUuid, "transfer_accepted", m.ToPubKey, m.FromPubKey, "ownership moved")
+	})
+	if err != nil {
+		return WorkspaceTransfer{}, err
+	}
+	return m, nil
+}
+
+// ExpireWorkspaceTransfers marks the pending transfers past their window expired and returns them
+func (db database) ExpireWorkspaceTransfers(now time.Time) []WorkspaceTransfer {
+	ms := []WorkspaceTransfer{}
+	db.db.Where("status = ? AND expires_at <= ?", WorkspaceTransferPending, now).Find(&ms)
+
+	expired := []WorkspaceTransfer{}
+	for _, m := range ms {
+		err := db.db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(&WorkspaceTransfer{}).Where("id = ? AND status = ?", m.ID, WorkspaceTransferPending).
+				Updates(map[string]interface{}{"status": WorkspaceTransferExpired, "resolved_at": &now})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrTransferResolved
+			}
+			return auditWorkspace(tx, m.WorkspaceUuid, "transfer_expired", "", m.ToPubKey, "")
+		})
+		if err == nil {
+			m.Status = WorkspaceTransferExpired
+			m.ResolvedAt = &now
+			expired = append(expired, m)
+		}
+	}
+	return expired
+}
+
+// GetWorkspaceAuditLog lists the audit entries of a workspace, newest first
+func (db database) GetWorkspaceAuditLog(workspaceUuid string) []WorkspaceAuditLog {
+	ms := []WorkspaceAuditLog{}
+	db.db.Where("workspace_uuid = ?", workspaceUuid).Order("created DESC").Find(&ms)
+	return ms
+}
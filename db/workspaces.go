@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
 )
 
 func (db database) GetWorkspaces(r *http.Request) []Workspace {
@@ -267,6 +268,13 @@ func (db database) ProcessUpdateBudget(invoice NewInvoiceList) error {
 		return err
 	}
 
+	// claim the invoice first, so the budget of an invoice settled by several callers at once
+	// is only credited by one of them
+	if err = claimInvoice(tx, invoice.PaymentRequest); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	created := invoice.Created
 	workspace_uuid := invoice.WorkspaceUuid
 
@@ -274,10 +282,20 @@ func (db database) ProcessUpdateBudget(invoice NewInvoiceList) error {
 	paymentHistory := db.GetPaymentHistoryByCreated(created, workspace_uuid)
 	if paymentHistory.WorkspaceUuid != "" && paymentHistory.Amount != 0 {
 		paymentHistory.Status = true
+		// the deposit is valued when it is settled
+		paymentHistory.Fiat = FiatAt(paymentHistory.Amount)
 
 		// Update payment history
 		if err = tx.Where("created = ?", created).Where("workspace_uuid = ? ", workspace_uuid).Updates(paymentHistory).Error; err != nil {
 			tx.Rollback()
+			return err
+		}
+
+		// record the deposit in the ledger
+		entries := NewLedgerTransaction(workspace_uuid, Deposit, LedgerBudget, LedgerLightning, paymentHistory.Amount, paymentHistory.SenderPubKey, 0)
+		if err = postLedgerTransaction(tx, entries); err != nil {
+			tx.Rollback()
+			return err
 		}
 
 		// get Workspace budget and add payment to total budget
@@ -294,22 +312,17 @@ func (db database) ProcessUpdateBudget(invoice NewInvoiceList) error {
 
 			if err = tx.Create(&workBudget).Error; err != nil {
 				tx.Rollback()
+				return err
 			}
 		} else {
-			totalBudget := WorkspaceBudget.TotalBudget
-			WorkspaceBudget.TotalBudget = totalBudget + paymentHistory.Amount
-
+			// the amount is added in the database, not to the budget read outside the transaction
 			if err = tx.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", WorkspaceBudget.WorkspaceUuid).Updates(map[string]interface{}{
-				"total_budget": WorkspaceBudget.TotalBudget,
+				"total_budget": gorm.Expr("total_budget + ?", paymentHistory.Amount),
 			}).Error; err != nil {
 				tx.Rollback()
+				return err
 			}
 		}
-
-		// update invoice
-		if err = tx.Model(&NewInvoiceList{}).Where("payment_request = ?", invoice.PaymentRequest).Update("status", true).Error; err != nil {
-			tx.Rollback()
-		}
 	}
 
 	return tx.Commit().Error
@@ -323,8 +336,15 @@ func (db database) AddAndUpdateBudget(invoice NewInvoiceList) NewPaymentHistory
 
 	if paymentHistory.WorkspaceUuid != "" && paymentHistory.Amount != 0 {
 		paymentHistory.Status = true
+		// the deposit is valued when it is settled
+		paymentHistory.Fiat = FiatAt(paymentHistory.Amount)
 		db.db.Where("created = ?", created).Where("workspace_uuid = ? ", workspace_uuid).Updates(paymentHistory)
 
+		entries := NewLedgerTransaction(workspace_uuid, Deposit, LedgerBudget, LedgerLightning, paymentHistory.Amount, paymentHistory.SenderPubKey, 0)
+		if err := postLedgerTransaction(db.db, entries); err != nil {
+			fmt.Println("[ledger] could not record deposit", err)
+		}
+
 		// get Workspace budget and add payment to total budget
 		WorkspaceBudget := db.GetWorkspaceBudget(workspace_uuid)
 
@@ -361,6 +381,12 @@ func (db database) WithdrawBudget(sender_pubkey string, workspace_uuid string, a
 		return
 	}
 
+	entries := NewLedgerTransaction(workspace_uuid, Withdraw, LedgerLightning, LedgerBudget, amount, sender_pubkey, 0)
+	if err = postLedgerTransaction(tx, entries); err != nil {
+		tx.Rollback()
+		return
+	}
+
 	// get Workspace budget and add payment to total budget
 	WorkspaceBudget := db.GetWorkspaceBudget(workspace_uuid)
 	totalBudget := WorkspaceBudget.TotalBudget
@@ -384,6 +410,7 @@ func (db database) WithdrawBudget(sender_pubkey string, workspace_uuid string, a
 		SenderPubKey:   sender_pubkey,
 		ReceiverPubKey: "",
 		BountyId:       0,
+		Fiat:           FiatAt(amount),
 	}
 
 	if err = tx.Create(&budgetHistory).Error; err != nil {
@@ -401,6 +428,10 @@ func (db database) AddPaymentHistory(payment NewPaymentHistory) NewPaymentHistor
 
 	// deduct amount if it's a bounty payment
 	if payment.PaymentType == "payment" {
+		entries := NewLedgerTransaction(payment.WorkspaceUuid, Payment, LedgerHunter, LedgerBudget, payment.Amount, payment.ReceiverPubKey, payment.BountyId)
+		if err := postLedgerTransaction(db.db, entries); err != nil {
+			fmt.Println("[ledger] could not record payment", err)
+		}
 		WorkspaceBudget.TotalBudget = totalBudget - payment.Amount
 	}
 
@@ -429,6 +460,12 @@ func (db database) ProcessBountyPayment(payment NewPaymentHistory, bounty NewBou
 		return err
 	}
 
+	entries := NewLedgerTransaction(payment.WorkspaceUuid, Payment, LedgerHunter, LedgerBudget, payment.Amount, payment.ReceiverPubKey, payment.BountyId)
+	if err = postLedgerTransaction(tx, entries); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// get Workspace budget and subtract payment from total budget
 	WorkspaceBudget := db.GetWorkspaceBudget(payment.WorkspaceUuid)
 	totalBudget := WorkspaceBudget.TotalBudget
@@ -487,6 +524,31 @@ func (db database) ChangeWorkspaceDeleteStatus(workspace_uuid string, status boo
 	return ms
 }
 
+// ArchiveWorkspace sets or clears the archived state of a workspace and records who changed it
+func (db database) ArchiveWorkspace(uuid string, archived bool, pubkey string) (Workspace, error) {
+	updates := map[string]interface{}{
+		"archived":    archived,
+		"archived_at": nil,
+		"archived_by": "",
+	}
+	if archived {
+		now := time.Now()
+		updates["archived_at"] = &now
+		updates["archived_by"] = pubkey
+	}
+
+	if err := db.db.Model(&Workspace{}).Where("uuid = ?", uuid).Updates(updates).Error; err != nil {
+		return Workspace{}, err
+	}
+	return db.GetWorkspaceByUuid(uuid), nil
+}
+
+func (db database) GetArchivedWorkspaceUuids() []string {
+	uuids := []string{}
+	db.db.Model(&Workspace{}).Where("archived = ?", true).Pluck("uuid", &uuids)
+	return uuids
+}
+
 func (db database) UpdateWorkspaceForDeletion(uuid string) error {
 	updates := map[string]interface{}{
 		"website":     "",
@@ -586,3 +648,10 @@ func (db database) GetFeaturePhasesBountiesCount(bountyType string, phaseUuid st
 	query.Count(&count)
 	return count
 }
+
+// GetBountyPayment returns the latest successful payment of a bounty
+func (db database) GetBountyPayment(bountyId uint) NewPaymentHistory {
+	m := NewPaymentHistory{}
+	db.db.Where("bounty_id = ? AND payment_type = ? AND status = true", bountyId, Payment).Order("created DESC").Limit(1).Find(&m)
+	return m
+}
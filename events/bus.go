@@ -0,0 +1,87 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	BountyCreated      = "bounty.created"
+	BountyAssigned     = "bounty.assigned"
+	BountyPaid         = "bounty.paid"
+	BudgetLow          = "workspace.budget_low"
+	TicketCompleted    = "ticket.completed"
+	TribeJoined        = "tribe.joined"
+	BotPaymentReceived = "bot.payment_received"
+	PaymentAttempted   = "payment.attempted"
+)
+
+// AllEvents subscribes a handler to every event published on the bus
+const AllEvents = "*"
+
+// Names are the events the handlers publish
+var Names = []string{BountyCreated, BountyAssigned, BountyPaid, BudgetLow, TicketCompleted, TribeJoined, BotPaymentReceived, PaymentAttempted}
+
+// Event is something that happened in a workspace or a tribe, published by the handlers for the
+// services reacting to it such as the workflows and the bot webhooks
+type Event struct {
+	Name          string                 `json:"name"`
+	WorkspaceUuid string                 `json:"workspace_uuid"`
+	Payload       map[string]interface{} `json:"payload"`
+	Created       time.Time              `json:"created"`
+}
+
+type Handler func(event Event)
+
+// Bus delivers the published events to the handlers subscribed to their name, in the goroutine
+// of the publisher so a handler that needs to take long should start its own
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+var bus = NewBus()
+
+func NewBus() *Bus {
+	return &Bus{handlers: map[string][]Handler{}}
+}
+
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+func (b *Bus) Publish(event Event) {
+	if event.Created.IsZero() {
+		event.Created = time.Now()
+	}
+	b.mu.RLock()
+	handlers := append(append([]Handler{}, b.handlers[event.Name]...), b.handlers[AllEvents]...)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		deliver(handler, event)
+	}
+}
+
+// deliver runs a handler, a panicking handler doesn't stop the others or the publisher
+func deliver(handler Handler, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("[events] handler of", event.Name, "panicked:", r)
+		}
+	}()
+	handler(event)
+}
+
+// Subscribe adds a handler for an event of the default bus, or for all of them with AllEvents
+func Subscribe(name string, handler Handler) {
+	bus.Subscribe(name, handler)
+}
+
+// Publish delivers an event of a workspace to the subscribers of the default bus
+func Publish(name string, workspaceUuid string, payload map[string]interface{}) {
+	bus.Publish(Event{Name: name, WorkspaceUuid: workspaceUuid, Payload: payload})
+}
@@ -0,0 +1,35 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus(t *testing.T) {
+	t.Run("Should test that events reach the handlers of their name and of all events", func(t *testing.T) {
+		b := NewBus()
+		paid := []Event{}
+		all := []string{}
+		b.Subscribe(BountyPaid, func(event Event) { paid = append(paid, event) })
+		b.Subscribe(AllEvents, func(event Event) { all = append(all, event.Name) })
+
+		b.Publish(Event{Name: BountyCreated, WorkspaceUuid: "workspace"})
+		b.Publish(Event{Name: BountyPaid, WorkspaceUuid: "workspace", Payload: map[string]interface{}{"bounty_id": 1}})
+
+		assert.Len(t, paid, 1)
+		assert.Equal(t, 1, paid[0].Payload["bounty_id"])
+		assert.False(t, paid[0].Created.IsZero())
+		assert.Equal(t, []string{BountyCreated, BountyPaid}, all)
+	})
+
+	t.Run("Should test that a panicking handler doesn't stop the others", func(t *testing.T) {
+		b := NewBus()
+		delivered := false
+		b.Subscribe(TicketCompleted, func(event Event) { panic("boom") })
+		b.Subscribe(TicketCompleted, func(event Event) { delivered = true })
+
+		b.Publish(Event{Name: TicketCompleted})
+		assert.True(t, delivered)
+	})
+}
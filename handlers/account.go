@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+type accountHandler struct {
+	db db.Database
+}
+
+func NewAccountHandler(database db.Database) *accountHandler {
+	return &accountHandler{
+		db: database,
+	}
+}
+
+// ExportPersonData returns everything stored about the authenticated pubkey, as JSON or as a zip with ?format=zip
+func (ah *accountHandler) ExportPersonData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[account] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if auth.IsImpersonatedRequest(ctx) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("An impersonation can't export the account")
+		return
+	}
+
+	export := ah.db.GetPersonExport(pubKeyFromAuth)
+
+	if r.URL.Query().Get("format") != "zip" {
+		w.Header().Set("Content-Disposition", `attachment; filename="sphinx-export.json"`)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(export)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="sphinx-export.zip"`)
+	w.WriteHeader(http.StatusOK)
+
+	archive := zip.NewWriter(w)
+	file, err := archive.Create("export.json")
+	if err == nil {
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(export)
+	}
+	if err != nil {
+		fmt.Println("[account] could not write export archive", err)
+	}
+	archive.Close()
+}
+
+// DeleteAccount anonymizes the person, revokes every credential and schedules the purge of the remaining data
+func (ah *accountHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[account] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if auth.IsImpersonatedRequest(ctx) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("An impersonation can't delete the account")
+		return
+	}
+
+	if auth.IsApiKeyRequest(ctx) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("API keys can't be used to delete an account")
+		return
+	}
+
+	person := ah.db.GetPersonByPubkey(pubKeyFromAuth)
+	if person.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Person not found")
+		return
+	}
+
+	if err := ah.db.AnonymizePerson(pubKeyFromAuth); err != nil {
+		fmt.Println("[account] could not anonymize person", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete account")
+		return
+	}
+
+	if err := ah.db.RevokeAllAuthSessions(pubKeyFromAuth); err != nil {
+		fmt.Println("[account] could not revoke sessions", err)
+	}
+	if err := ah.db.RevokeAllApiKeys(pubKeyFromAuth); err != nil {
+		fmt.Println("[account] could not revoke api keys", err)
+	}
+	ah.db.AddDeletion(db.DeletionKindPerson, strconv.Itoa(int(person.ID)))
+
+	purge, err := ah.db.ScheduleAccountPurge(pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[account] could not schedule purge", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not schedule data purge")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(purge)
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportPersonData(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	aHandler := NewAccountHandler(mockDb)
+
+	export := db.PersonExport{
+		Profile: db.Person{OwnerPubKey: "pubkey", OwnerAlias: "alice"},
+		Notifications: []db.Notification{
+			{Title: "Bounty assigned"},
+		},
+	}
+
+	t.Run("Should test that a 401 is returned without a pubkey", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/person/export", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.ExportPersonData).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that the export is returned as a json attachment", func(t *testing.T) {
+		mockDb.On("GetPersonExport", "pubkey").Return(export).Once()
+
+		req, _ := http.NewRequest("GET", "/person/export", nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "pubkey"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.ExportPersonData).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), "sphinx-export.json")
+
+		var returned db.PersonExport
+		err := json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", returned.Profile.OwnerAlias)
+		assert.Len(t, returned.Notifications, 1)
+	})
+
+	t.Run("Should test that the export is zipped when format is zip", func(t *testing.T) {
+		mockDb.On("GetPersonExport", "pubkey").Return(export).Once()
+
+		req, _ := http.NewRequest("GET", "/person/export?format=zip", nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "pubkey"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.ExportPersonData).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/zip", rr.Header().Get("Content-Type"))
+
+		archive, err := zip.NewReader(bytes.NewReader(rr.Body.Bytes()), int64(rr.Body.Len()))
+		assert.NoError(t, err)
+		assert.Len(t, archive.File, 1)
+		assert.Equal(t, "export.json", archive.File[0].Name)
+
+		file, _ := archive.File[0].Open()
+		content, _ := io.ReadAll(file)
+		file.Close()
+
+		var returned db.PersonExport
+		err = json.Unmarshal(content, &returned)
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", returned.Profile.OwnerAlias)
+	})
+}
+
+func TestDeleteAccount(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	aHandler := NewAccountHandler(mockDb)
+
+	deleteRequest := func(ctx context.Context) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/person/delete_account", nil)
+		req = req.WithContext(ctx)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.DeleteAccount).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a 401 is returned without a pubkey", func(t *testing.T) {
+		rr := deleteRequest(context.Background())
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that an API key can't delete the account", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "pubkey")
+		ctx = context.WithValue(ctx, auth.ApiKeyScopesKey, []string{"bounties:write"})
+		rr := deleteRequest(ctx)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("Should test that a 404 is returned when the person does not exist", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "pubkey").Return(db.Person{}).Once()
+
+		rr := deleteRequest(context.WithValue(context.Background(), auth.ContextKey, "pubkey"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Should test that the account is anonymized and its purge scheduled", func(t *testing.T) {
+		purgeAfter := time.Now().Add(db.AccountPurgeDelay)
+		mockDb.On("GetPersonByPubkey", "pubkey").Return(db.Person{ID: 7, OwnerPubKey: "pubkey"}).Once()
+		mockDb.On("AnonymizePerson", "pubkey").Return(nil).Once()
+		mockDb.On("RevokeAllAuthSessions", "pubkey").Return(nil).Once()
+		mockDb.On("RevokeAllApiKeys", "pubkey").Return(nil).Once()
+		mockDb.On("AddDeletion", db.DeletionKindPerson, "7").Return(nil).Once()
+		mockDb.On("ScheduleAccountPurge", "pubkey").Return(db.AccountPurge{OwnerPubKey: "pubkey", PurgeAfter: &purgeAfter}, nil).Once()
+
+		rr := deleteRequest(context.WithValue(context.Background(), auth.ContextKey, "pubkey"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var returned db.AccountPurge
+		err := json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.NoError(t, err)
+		assert.Equal(t, "pubkey", returned.OwnerPubKey)
+		mockDb.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+type activityHandler struct {
+	db db.Database
+}
+
+func NewActivityHandler(database db.Database) *activityHandler {
+	return &activityHandler{
+		db: database,
+	}
+}
+
+// activityStore is where recordActivity saves entries, it is nil until InitActivities is called
+var activityStore db.Database
+
+func InitActivities(database db.Database) {
+	activityStore = database
+}
+
+// recordActivity adds an entry to the activity timeline shown on a person's profile
+func recordActivity(pubkey string, activityType db.ActivityType, title string, link string, amount uint) {
+	if activityStore == nil || pubkey == "" {
+		return
+	}
+	_, err := activityStore.AddActivity(db.Activity{
+		OwnerPubKey: pubkey,
+		Type:        activityType,
+		Title:       title,
+		Link:        link,
+		Amount:      amount,
+	})
+	if err != nil {
+		fmt.Println("[activity] could not record", activityType, err)
+	}
+}
+
+func (ah *activityHandler) GetPersonActivity(w http.ResponseWriter, r *http.Request) {
+	pubkey := chi.URLParam(r, "pubkey")
+	if pubkey == "" {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	activities := ah.db.GetActivitiesByPubkey(pubkey, r)
+	total := ah.db.GetActivitiesCount(pubkey)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.ActivitiesResponse{
+		Total:      total,
+		Activities: activities,
+	})
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPersonActivity(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	aHandler := NewActivityHandler(mockDb)
+
+	t.Run("Should test that the activity timeline of a person is returned", func(t *testing.T) {
+		activities := []db.Activity{
+			{OwnerPubKey: "pubkey", Type: db.ActivityPaymentReceived, Title: "bounty", Amount: 1000},
+			{OwnerPubKey: "pubkey", Type: db.ActivityBountyAssigned, Title: "bounty"},
+		}
+		mockDb.On("GetActivitiesByPubkey", "pubkey", mock.Anything).Return(activities).Once()
+		mockDb.On("GetActivitiesCount", "pubkey").Return(int64(2)).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("pubkey", "pubkey")
+		req, _ := http.NewRequest("GET", "/person/pubkey/activity?page=1&limit=20", nil)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetPersonActivity).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		response := db.ActivitiesResponse{}
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, int64(2), response.Total)
+		assert.Equal(t, activities, response.Activities)
+	})
+}
+
+func TestRecordActivity(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	InitActivities(mockDb)
+	defer InitActivities(nil)
+
+	t.Run("Should test that an activity is stored for the person", func(t *testing.T) {
+		mockDb.On("AddActivity", db.Activity{
+			OwnerPubKey: "pubkey",
+			Type:        db.ActivityBountyCreated,
+			Title:       "bounty",
+			Link:        "link",
+			Amount:      100,
+		}).Return(db.Activity{}, nil).Once()
+
+		recordActivity("pubkey", db.ActivityBountyCreated, "bounty", "link", 100)
+	})
+
+	t.Run("Should test that nothing is stored without a pubkey", func(t *testing.T) {
+		recordActivity("", db.ActivityBountyCreated, "bounty", "link", 100)
+	})
+}
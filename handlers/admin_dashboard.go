@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	adminDashboardDays    = 7
+	adminDashboardMaxDays = 90
+)
+
+// adminDashboardSince is the start of the ?days= window of the dashboard, a week by default
+func adminDashboardSince(r *http.Request) time.Time {
+	days, _ := strconv.Atoi(r.URL.Query().Get("days"))
+	if days <= 0 || days > adminDashboardMaxDays {
+		days = adminDashboardDays
+	}
+	return time.Now().AddDate(0, 0, -days)
+}
+
+// GetAdminDashboard returns the signups, listings, bounty volume and payment failures of the last
+// ?days= days along with the open moderation queues
+func (sh *superAdminHandler) GetAdminDashboard(w http.ResponseWriter, r *http.Request) {
+	dashboard := sh.db.GetAdminDashboard(adminDashboardSince(r))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dashboard)
+}
+
+// GetRecentErrors returns the latest failed payments, webhook deliveries and background jobs
+func (sh *superAdminHandler) GetRecentErrors(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(sh.db.GetRecentErrors(adminDashboardSince(r), limit))
+}
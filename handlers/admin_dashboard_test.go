@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAdminDashboard(t *testing.T) {
+	within := func(days int) interface{} {
+		return mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since.AddDate(0, 0, days)) < time.Minute
+		})
+	}
+
+	t.Run("Should test that the dashboard is counted over the requested days", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		sh := NewSuperAdminHandler(mockDb)
+		mockDb.On("GetAdminDashboard", within(30)).Return(db.AdminDashboard{NewPeople: 4, PaymentFailures: 2, OpenReports: 1}).Once()
+
+		rr := httptest.NewRecorder()
+		sh.GetAdminDashboard(rr, httptest.NewRequest(http.MethodGet, "/admin/dashboard?days=30", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		dashboard := db.AdminDashboard{}
+		json.Unmarshal(rr.Body.Bytes(), &dashboard)
+		assert.Equal(t, int64(4), dashboard.NewPeople)
+		assert.Equal(t, int64(2), dashboard.PaymentFailures)
+		assert.Equal(t, int64(1), dashboard.OpenReports)
+	})
+
+	t.Run("Should test that out of range days fall back to a week", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		sh := NewSuperAdminHandler(mockDb)
+		mockDb.On("GetAdminDashboard", within(7)).Return(db.AdminDashboard{}).Twice()
+
+		for _, days := range []string{"", "365"} {
+			rr := httptest.NewRecorder()
+			sh.GetAdminDashboard(rr, httptest.NewRequest(http.MethodGet, "/admin/dashboard?days="+days, nil))
+			assert.Equal(t, http.StatusOK, rr.Code)
+		}
+	})
+
+	t.Run("Should test that recent errors are limited", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		sh := NewSuperAdminHandler(mockDb)
+		now := time.Now()
+		mockDb.On("GetRecentErrors", within(7), 10).Return([]db.AdminError{{Source: "payment", Ref: "1", Error: "no route", Created: &now}}).Once()
+		mockDb.On("GetRecentErrors", within(1), 50).Return([]db.AdminError{}).Once()
+
+		rr := httptest.NewRecorder()
+		sh.GetRecentErrors(rr, httptest.NewRequest(http.MethodGet, "/admin/dashboard/errors?limit=10", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"source":"payment"`)
+
+		rr = httptest.NewRecorder()
+		sh.GetRecentErrors(rr, httptest.NewRequest(http.MethodGet, "/admin/dashboard/errors?days=1&limit=1000", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const maxAiTemperature = 2.0
+
+func (oh *workspaceHandler) GetWorkspaceAiSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "workspace_uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	hasRole := oh.userHasAccess(pubKeyFromAuth, uuid, db.ViewReport)
+	if !hasRole {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to view AI settings")
+		return
+	}
+
+	settings := oh.db.GetWorkspaceAiSettings(uuid)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(settings)
+}
+
+// UpdateWorkspaceAiSettings sets the Stakwork workflow, model, temperature and system prompt
+// additions the workspace's Stakwork requests use
+func (oh *workspaceHandler) UpdateWorkspaceAiSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "workspace_uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	hasRole := oh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg)
+	if !hasRole {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to edit AI settings")
+		return
+	}
+	if rejectArchivedWorkspace(w, uuid) {
+		return
+	}
+
+	settings := db.WorkspaceAiSettings{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &settings)
+	if err != nil {
+		fmt.Println("[workspaces]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if settings.Temperature != nil && (*settings.Temperature < 0 || *settings.Temperature > maxAiTemperature) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The temperature is between 0 and 2")
+		return
+	}
+	settings.WorkspaceUuid = uuid
+	settings.UpdatedBy = pubKeyFromAuth
+
+	settings, err = oh.db.UpdateWorkspaceAiSettings(settings)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(settings)
+}
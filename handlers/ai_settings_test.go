@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateWorkspaceAiSettings(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+
+	settingsRequest := func(body string) *httptest.ResponseRecorder {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", "workspace_uuid")
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, "pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPut, "/workspaces/workspace_uuid/ai_settings", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.UpdateWorkspaceAiSettings).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a 401 is returned without the edit role", func(t *testing.T) {
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return false
+		}
+		rr := settingsRequest(`{"model": "gpt-4o"}`)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that the temperature is between 0 and 2", func(t *testing.T) {
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return role == db.EditOrg
+		}
+		rr := settingsRequest(`{"temperature": 3}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the settings are saved for the workspace", func(t *testing.T) {
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return role == db.EditOrg
+		}
+		mockDb.On("UpdateWorkspaceAiSettings", mock.MatchedBy(func(s db.WorkspaceAiSettings) bool {
+			return s.WorkspaceUuid == "workspace_uuid" && s.WorkflowId == "42" && s.Model == "gpt-4o" &&
+				s.Temperature != nil && *s.Temperature == 0.2 && s.UpdatedBy == "pubkey"
+		})).Return(func(s db.WorkspaceAiSettings) (db.WorkspaceAiSettings, error) {
+			s.ID = 1
+			return s, nil
+		}).Once()
+
+		rr := settingsRequest(`{"workflow_id": "42", "model": "gpt-4o", "temperature": 0.2, "system_prompt": "Answer in Spanish"}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		returned := db.WorkspaceAiSettings{}
+		json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.Equal(t, uint(1), returned.ID)
+		assert.Equal(t, "Answer in Spanish", returned.SystemPrompt)
+	})
+}
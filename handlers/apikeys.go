@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+type apiKeyHandler struct {
+	db db.Database
+}
+
+type CreateApiKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+func NewApiKeyHandler(database db.Database) *apiKeyHandler {
+	return &apiKeyHandler{
+		db: database,
+	}
+}
+
+// VerifyApiKey is used by auth.PubKeyContext to resolve API keys
+func (ah *apiKeyHandler) VerifyApiKey(key string) (string, []string, error) {
+	apiKey, err := ah.db.GetApiKeyByHash(auth.HashApiKey(key))
+	if err != nil {
+		return "", nil, err
+	}
+	ah.db.UpdateApiKeyLastUsed(apiKey.ID)
+	return apiKey.OwnerPubKey, apiKey.Scopes, nil
+}
+
+func (ah *apiKeyHandler) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[api keys] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if auth.IsImpersonatedRequest(ctx) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("An impersonation can't create API keys")
+		return
+	}
+
+	// keys can only be created from a lnauth session
+	if auth.IsApiKeyRequest(ctx) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("API keys can't be used to create API keys")
+		return
+	}
+
+	request := CreateApiKeyRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &request)
+	if err != nil {
+		fmt.Println("[api keys]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if request.Name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Name is a required field")
+		return
+	}
+
+	if len(request.Scopes) == 0 {
+		request.Scopes = []string{auth.ApiKeyScopeRead}
+	}
+	for _, scope := range request.Scopes {
+		if scope != auth.ApiKeyScopeRead && scope != auth.ApiKeyScopeBounty {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("Invalid scope: " + scope)
+			return
+		}
+	}
+
+	key := auth.ApiKeyPrefix + utils.GetRandomToken(40)
+
+	apiKey, err := ah.db.CreateApiKey(db.ApiKey{
+		Uuid:        xid.New().String(),
+		OwnerPubKey: pubKeyFromAuth,
+		Name:        request.Name,
+		Prefix:      key[:len(auth.ApiKeyPrefix)+6],
+		KeyHash:     auth.HashApiKey(key),
+		Scopes:      request.Scopes,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	// the key is only ever returned once
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.ApiKeyResponse{ApiKey: apiKey, Key: key})
+}
+
+func (ah *apiKeyHandler) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[api keys] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	apiKeys := ah.db.GetApiKeysByPubkey(pubKeyFromAuth)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(apiKeys)
+}
+
+func (ah *apiKeyHandler) RevokeApiKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[api keys] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "uuid")
+	err := ah.db.RevokeApiKey(pubKeyFromAuth, uuid)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateApiKey(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	aHandler := NewApiKeyHandler(mockDb)
+
+	t.Run("Should test that a 401 error is returned if the user is not authorized", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/person/api_keys", nil)
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(aHandler.CreateApiKey)
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that an api key can't be used to create another api key", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "pubkey")
+		ctx = context.WithValue(ctx, auth.ApiKeyScopesKey, []string{auth.ApiKeyScopeRead})
+		body, _ := json.Marshal(CreateApiKeyRequest{Name: "script"})
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/person/api_keys", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(aHandler.CreateApiKey)
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("Should test that an invalid scope is rejected", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "pubkey")
+		body, _ := json.Marshal(CreateApiKeyRequest{Name: "script", Scopes: []string{"admin"}})
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/person/api_keys", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(aHandler.CreateApiKey)
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the key is returned once and only its hash is stored", func(t *testing.T) {
+		mockDb.On("CreateApiKey", mock.MatchedBy(func(k db.ApiKey) bool {
+			return k.OwnerPubKey == "pubkey" && k.Name == "script" && len(k.KeyHash) == 64 && len(k.Scopes) == 1 && k.Scopes[0] == auth.ApiKeyScopeBounty
+		})).Return(func(k db.ApiKey) (db.ApiKey, error) {
+			return k, nil
+		}).Once()
+
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "pubkey")
+		body, _ := json.Marshal(CreateApiKeyRequest{Name: "script", Scopes: []string{auth.ApiKeyScopeBounty}})
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/person/api_keys", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(aHandler.CreateApiKey)
+
+		handler.ServeHTTP(rr, req)
+
+		var returned db.ApiKeyResponse
+		err := json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, strings.HasPrefix(returned.Key, auth.ApiKeyPrefix))
+		assert.True(t, strings.HasPrefix(returned.Key, returned.Prefix))
+		assert.NotContains(t, rr.Body.String(), auth.HashApiKey(returned.Key))
+	})
+}
+
+func TestVerifyApiKey(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	aHandler := NewApiKeyHandler(mockDb)
+
+	t.Run("Should test that a key is resolved to its owner and scopes", func(t *testing.T) {
+		key := auth.ApiKeyPrefix + "key"
+		mockDb.On("GetApiKeyByHash", auth.HashApiKey(key)).Return(db.ApiKey{ID: 1, OwnerPubKey: "pubkey", Scopes: []string{auth.ApiKeyScopeRead}}, nil).Once()
+		mockDb.On("UpdateApiKeyLastUsed", uint(1)).Once()
+
+		pubkey, scopes, err := aHandler.VerifyApiKey(key)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "pubkey", pubkey)
+		assert.Equal(t, []string{auth.ApiKeyScopeRead}, scopes)
+	})
+}
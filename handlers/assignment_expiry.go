@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/notifications"
+)
+
+const defaultAssignmentExpiryWarning = 24 * time.Hour
+
+// validStaleAfter is true for an empty stale_after or a positive duration like 72h
+func validStaleAfter(staleAfter string) bool {
+	if staleAfter == "" {
+		return true
+	}
+	d, err := time.ParseDuration(staleAfter)
+	return err == nil && d > 0
+}
+
+func assignmentExpiryWarning() time.Duration {
+	warning, err := time.ParseDuration(config.AssignmentExpiryWarning)
+	if err != nil || warning <= 0 {
+		return defaultAssignmentExpiryWarning
+	}
+	return warning
+}
+
+// ExpireStaleAssignments warns the assignees whose time on a bounty is about to run out and
+// unassigns the ones whose time ran out without submitting proof of work
+func ExpireStaleAssignments() {
+	NewBountyHandler(http.DefaultClient, db.DB).expireStaleAssignments(time.Now())
+}
+
+func (h *bountyHandler) expireStaleAssignments(now time.Time) {
+	warning := assignmentExpiryWarning()
+
+	for _, bounty := range h.db.GetAssignedBountiesWithExpiry() {
+		if bounty.WorkspaceUuid == "" && bounty.OrgUuid != "" {
+			bounty.WorkspaceUuid = bounty.OrgUuid
+		}
+
+		// submitted work is waiting for the owner, the assignee is not stale
+		if bounty.ProofStatus == db.ProofSubmitted || bounty.ProofStatus == db.ProofAccepted {
+			continue
+		}
+
+		expiry := bounty.AssignmentExpiry()
+		if expiry == nil {
+			continue
+		}
+
+		if !now.Before(*expiry) {
+			h.unassignExpiredBounty(bounty)
+			continue
+		}
+
+		if expiry.Sub(now) <= warning && !bounty.ExpiryWarned() {
+			if err := h.db.MarkExpiryWarned(bounty.ID); err != nil {
+				fmt.Println("[scheduler] could not mark expiry warning of bounty", bounty.ID, err)
+				continue
+			}
+			notifications.Notify(bounty.Assignee, db.NotificationAssignmentExpiring, fmt.Sprintf("Your assignment expires on %s", expiry.UTC().Format("Jan 2, 15:04 MST")), bounty.Title, bountyLink(bounty.ID))
+		}
+	}
+}
+
+// unassignExpiredBounty opens a bounty whose assignment ran out, refunding its escrow first
+func (h *bountyHandler) unassignExpiredBounty(bounty db.NewBounty) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if escrowActive(bounty.EscrowStatus) {
+		if _, err := h.refundEscrow(bounty); err != nil {
+			log.Printf("[scheduler] bounty %d stays assigned, its escrow could not be refunded: %v", bounty.ID, err)
+			return
+		}
+	}
+
+	if err := h.db.UnassignExpiredBounty(bounty); err != nil {
+		fmt.Println("[scheduler] could not unassign bounty", bounty.ID, err)
+		return
+	}
+
+	assignee := bounty.Assignee
+	bounty.Assignee = ""
+	bounty.AssignedDate = nil
+	bounty.ProofStatus = ""
+	publishBountyEvent(bounty, "bounty_unassigned")
+	notifications.Notify(bounty.OwnerID, db.NotificationAssignmentExpired, "A bounty is open again, its assignment expired", bounty.Title, bountyLink(bounty.ID))
+	notifications.Notify(assignee, db.NotificationAssignmentExpired, "You were unassigned from a bounty, its assignment expired", bounty.Title, bountyLink(bounty.ID))
+}
+
+// ExtendBountyAssignment lets the owner or a bounty manager give the assignee more time, with a
+// later deadline or a longer stale_after
+func (h *bountyHandler) ExtendBountyAssignment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromPath(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
+
+	if pubKeyFromAuth != bounty.OwnerID && (bounty.WorkspaceUuid == "" || !h.userHasManageBountyRoles(pubKeyFromAuth, bounty.WorkspaceUuid)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have appropriate permissions to extend this bounty")
+		return
+	}
+	if bounty.Paid {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Bounty has already been paid")
+		return
+	}
+
+	request := db.AssignmentExtensionRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	request.StaleAfter = strings.TrimSpace(request.StaleAfter)
+	if request.Deadline == nil && request.StaleAfter == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A deadline or a stale_after is required")
+		return
+	}
+	if request.Deadline != nil && !request.Deadline.After(time.Now()) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Deadline must be in the future")
+		return
+	}
+	if !validStaleAfter(request.StaleAfter) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("stale_after must be a duration like 72h")
+		return
+	}
+
+	if err := h.db.ExtendBountyAssignment(bounty.ID, request.Deadline, request.StaleAfter); err != nil {
+		log.Printf("[bounty] could not extend bounty %d: %v", bounty.ID, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not extend the bounty")
+		return
+	}
+
+	if request.Deadline != nil {
+		bounty.Deadline = request.Deadline
+	}
+	if request.StaleAfter != "" {
+		bounty.StaleAfter = request.StaleAfter
+	}
+	publishBountyEvent(bounty, "bounty_extended")
+	if bounty.Assignee != "" {
+		notifications.Notify(bounty.Assignee, db.NotificationBountyAssigned, "You were given more time on a bounty", bounty.Title, bountyLink(bounty.ID))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bounty)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExpireStaleAssignments(t *testing.T) {
+	now := time.Now()
+	assigned := now.Add(-48 * time.Hour)
+
+	t.Run("Should test that an expired assignment is unassigned", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bounty := db.NewBounty{ID: 1, OwnerID: "owner", Assignee: "hunter", AssignedDate: &assigned, StaleAfter: "24h"}
+		mockDb.On("GetAssignedBountiesWithExpiry").Return([]db.NewBounty{bounty})
+		mockDb.On("UnassignExpiredBounty", bounty).Return(nil)
+
+		bHandler.expireStaleAssignments(now)
+	})
+
+	t.Run("Should test that an assignee is warned once before the expiry", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		deadline := now.Add(2 * time.Hour)
+		mockDb.On("GetAssignedBountiesWithExpiry").Return([]db.NewBounty{
+			{ID: 1, Assignee: "hunter", AssignedDate: &assigned, Deadline: &deadline},
+			{ID: 2, Assignee: "hunter", AssignedDate: &assigned, Deadline: &deadline, ExpiryWarnedAt: &now},
+		})
+		mockDb.On("MarkExpiryWarned", uint(1)).Return(nil).Once()
+
+		bHandler.expireStaleAssignments(now)
+	})
+
+	t.Run("Should test that submitted work keeps the assignment", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetAssignedBountiesWithExpiry").Return([]db.NewBounty{
+			{ID: 1, Assignee: "hunter", AssignedDate: &assigned, StaleAfter: "24h", ProofStatus: db.ProofSubmitted},
+		})
+
+		bHandler.expireStaleAssignments(now)
+	})
+
+	t.Run("Should test that a bounty whose escrow can't be refunded stays assigned", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.lnBackend = &keysendTestBackend{}
+		mockDb.On("GetAssignedBountiesWithExpiry").Return([]db.NewBounty{
+			{ID: 1, Assignee: "hunter", AssignedDate: &assigned, StaleAfter: "24h", EscrowStatus: db.EscrowFunded},
+		})
+		mockDb.On("GetBountyEscrow", uint(1)).Return(db.BountyEscrow{ID: 2, BountyId: 1, Status: db.EscrowFunded})
+
+		bHandler.expireStaleAssignments(now)
+	})
+}
+
+func TestExtendBountyAssignment(t *testing.T) {
+	bounty := db.NewBounty{ID: 1, OwnerID: "owner", Assignee: "hunter", WorkspaceUuid: "workspace-uuid"}
+
+	extend := func(bHandler *bountyHandler, pubkey string, body string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Post("/gobounties/{id}/extend", bHandler.ExtendBountyAssignment)
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/gobounties/1/extend", bytes.NewBufferString(body))
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that the owner gives the assignee a longer stale_after", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("ExtendBountyAssignment", uint(1), mock.AnythingOfType("*time.Time"), "168h").Return(nil)
+
+		rr := extend(bHandler, "owner", `{"stale_after":"168h"}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that an invalid stale_after is refused", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+
+		rr := extend(bHandler, "owner", `{"stale_after":"7 days"}`)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the assignee can't extend their own assignment", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasManageBountyRoles = func(pubKeyFromAuth string, uuid string) bool { return false }
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+
+		rr := extend(bHandler, "hunter", `{"stale_after":"168h"}`)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/storage"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+const maxAttachmentSize = 20 << 20
+
+// maxAttachments is how many files a bounty or a ticket can have
+const maxAttachments = 10
+
+// attachmentLimits are the types of files that can be attached, with the largest size of each
+var attachmentLimits = map[string]int64{
+	"application/pdf":           maxAttachmentSize,
+	"image/png":                 maxUploadSize,
+	"image/jpeg":                maxUploadSize,
+	"image/gif":                 maxUploadSize,
+	"image/webp":                maxUploadSize,
+	"text/plain; charset=utf-8": 1 << 20,
+}
+
+// withUrls sets the stable download url of every attachment
+func withUrls(attachments []db.Attachment) []db.Attachment {
+	for i := range attachments {
+		attachments[i].Url = uploadUrl(attachments[i].Key)
+	}
+	return attachments
+}
+
+// attach stores the file of the request and records it as an attachment of a bounty or a ticket
+func (uh *uploadHandler) attach(w http.ResponseWriter, r *http.Request, pubkey string, parentType db.AttachmentParent, parentId string) {
+	if uh.storage == nil {
+		w.WriteHeader(http.StatusNotImplemented)
+		json.NewEncoder(w).Encode("no storage backend is set")
+		return
+	}
+
+	if uh.db.CountAttachments(parentType, parentId) >= maxAttachments {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("a %s can't have more than %d attachments", parentType, maxAttachments))
+		return
+	}
+
+	stored, status, err := uh.store(w, r, storage.PurposeAttachment)
+	if err != nil {
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	attachment, err := uh.db.CreateAttachment(db.Attachment{
+		ParentType:  parentType,
+		ParentId:    parentId,
+		Key:         stored.Key,
+		Filename:    stored.Filename,
+		ContentType: stored.ContentType,
+		Size:        stored.Size,
+		OwnerPubKey: pubkey,
+	})
+	if err != nil {
+		uh.storage.Delete(stored.Key)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save attachment")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(withUrls([]db.Attachment{attachment})[0])
+}
+
+// AttachToBounty attaches a file to a bounty, for its owner and its assignee
+func (uh *uploadHandler) AttachToBounty(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[attachments] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid bounty id")
+		return
+	}
+
+	bounty := uh.db.GetBounty(id)
+	if bounty.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Bounty not found")
+		return
+	}
+	if bounty.OwnerID != pubKeyFromAuth && bounty.Assignee != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the bounty owner and its assignee can attach files")
+		return
+	}
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
+
+	uh.attach(w, r, pubKeyFromAuth, db.AttachmentBounty, strconv.Itoa(int(bounty.ID)))
+}
+
+// AttachToTicket attaches a file to a ticket, for its owner
+func (uh *uploadHandler) AttachToTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[attachments] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	pubKey := chi.URLParam(r, "pubKey")
+	created, err := strconv.ParseInt(chi.URLParam(r, "created"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid ticket created time")
+		return
+	}
+	if pubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the ticket owner can attach files")
+		return
+	}
+
+	if findTicket(uh.db.GetPersonByPubkey(pubKey), created) == nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Ticket not found")
+		return
+	}
+	if rejectArchivedWorkspaceOf(w, func() string { return ticketWorkspace(uh.db, ticketKey(pubKey, created)) }) {
+		return
+	}
+
+	uh.attach(w, r, pubKeyFromAuth, db.AttachmentTicket, ticketKey(pubKey, created))
+}
+
+// GetTicket returns a ticket of a person along with its attachments
+func (uh *uploadHandler) GetTicket(w http.ResponseWriter, r *http.Request) {
+	pubKey := chi.URLParam(r, "pubKey")
+	created, err := strconv.ParseInt(chi.URLParam(r, "created"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid ticket created time")
+		return
+	}
+
+	ticket := findTicket(uh.db.GetPersonByPubkey(pubKey), created)
+	if ticket == nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Ticket not found")
+		return
+	}
+
+	response := map[string]interface{}{}
+	for k, v := range ticket {
+		response[k] = v
+	}
+	response["attachments"] = withUrls(uh.db.GetAttachments(db.AttachmentTicket, ticketKey(pubKey, created)))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// attachmentWorkspace is the workspace of the bounty or ticket an attachment belongs to
+func attachmentWorkspace(database db.Database, attachment db.Attachment) string {
+	if attachment.ParentType == db.AttachmentTicket {
+		return ticketWorkspace(database, attachment.ParentId)
+	}
+	id, err := utils.ConvertStringToUint(attachment.ParentId)
+	if err != nil {
+		return ""
+	}
+	return database.GetBounty(id).WorkspaceUuid
+}
+
+// DeleteAttachment removes an attachment and its file, only whoever attached it can
+func (uh *uploadHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[attachments] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid attachment id")
+		return
+	}
+
+	attachment := uh.db.GetAttachment(id)
+	if attachment.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Attachment not found")
+		return
+	}
+	if attachment.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the owner of an attachment can delete it")
+		return
+	}
+	if rejectArchivedWorkspaceOf(w, func() string { return attachmentWorkspace(uh.db, attachment) }) {
+		return
+	}
+
+	if uh.storage != nil {
+		if err := uh.storage.Delete(attachment.Key); err != nil {
+			fmt.Println("[attachments] could not delete file", attachment.Key, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode("Could not delete attachment")
+			return
+		}
+	}
+	if err := uh.db.DeleteAttachment(attachment.ID); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete attachment")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// shapedWithAttachments adds the attachments to bounty responses when the shape includes them
+func (h *bountyHandler) shapedWithAttachments(responses []db.BountyResponse, shape responseShape) []db.BountyResponse {
+	if !shape.includes("attachments") {
+		return responses
+	}
+	return h.withAttachments(responses)
+}
+
+// withAttachments embeds the attachments of the bounties of a response
+func (h *bountyHandler) withAttachments(responses []db.BountyResponse) []db.BountyResponse {
+	for i := range responses {
+		responses[i].Attachments = withUrls(h.db.GetAttachments(db.AttachmentBounty, strconv.Itoa(int(responses[i].Bounty.ID))))
+	}
+	return responses
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAttachments(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	store := storage.NewLocalStorage(t.TempDir(), "https://people.sphinx.chat", []byte("secret"))
+	uh := &uploadHandler{db: mockDb, storage: store, now: time.Now}
+
+	router := chi.NewRouter()
+	router.Post("/gobounties/{id}/attachments", uh.AttachToBounty)
+	router.Post("/ticket/{pubKey}/{created}/attachments", uh.AttachToTicket)
+	router.Get("/ticket/{pubKey}/{created}", uh.GetTicket)
+	router.Delete("/attachments/{id}", uh.DeleteAttachment)
+
+	withPubkey := func(req *http.Request, pubkey string) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), auth.ContextKey, pubkey))
+	}
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	bounty := db.NewBounty{ID: 4, OwnerID: "owner", Assignee: "hunter"}
+	pdf := []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n")
+
+	t.Run("Should test that the assignee attaches a pdf to a bounty", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(4)).Return(bounty).Once()
+		mockDb.On("CountAttachments", db.AttachmentBounty, "4").Return(int64(0)).Once()
+		mockDb.On("CreateAttachment", mock.AnythingOfType("db.Attachment")).Return(func(a db.Attachment) (db.Attachment, error) {
+			a.ID = 9
+			return a, nil
+		}).Once()
+
+		rr := serve(withPubkey(uploadRequest(t, "/gobounties/4/attachments", "spec.pdf", pdf), "hunter"))
+
+		attachment := db.Attachment{}
+		json.Unmarshal(rr.Body.Bytes(), &attachment)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, db.AttachmentBounty, attachment.ParentType)
+		assert.Equal(t, "4", attachment.ParentId)
+		assert.Equal(t, "spec.pdf", attachment.Filename)
+		assert.Equal(t, "application/pdf", attachment.ContentType)
+		assert.Equal(t, int64(len(pdf)), attachment.Size)
+		assert.Equal(t, "hunter", attachment.OwnerPubKey)
+		assert.True(t, strings.HasPrefix(attachment.Key, storage.PurposeAttachment+"/"))
+		assert.True(t, strings.HasSuffix(attachment.Url, "/uploads/"+attachment.Key))
+	})
+
+	t.Run("Should test that only the bounty owner and assignee can attach files", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(4)).Return(bounty).Once()
+
+		rr := serve(withPubkey(uploadRequest(t, "/gobounties/4/attachments", "spec.pdf", pdf), "someone"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that files of other types are refused", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(4)).Return(bounty).Once()
+		mockDb.On("CountAttachments", db.AttachmentBounty, "4").Return(int64(0)).Once()
+
+		rr := serve(withPubkey(uploadRequest(t, "/gobounties/4/attachments", "page.html", []byte("<html><script></script></html>")), "owner"))
+		assert.Equal(t, http.StatusUnsupportedMediaType, rr.Code)
+	})
+
+	t.Run("Should test that a text file can't be larger than its limit", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(4)).Return(bounty).Once()
+		mockDb.On("CountAttachments", db.AttachmentBounty, "4").Return(int64(0)).Once()
+
+		rr := serve(withPubkey(uploadRequest(t, "/gobounties/4/attachments", "log.txt", []byte(strings.Repeat("a", 1<<20+1))), "owner"))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	})
+
+	t.Run("Should test that a bounty can't have more attachments than the limit", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(4)).Return(bounty).Once()
+		mockDb.On("CountAttachments", db.AttachmentBounty, "4").Return(int64(maxAttachments)).Once()
+
+		rr := serve(withPubkey(uploadRequest(t, "/gobounties/4/attachments", "spec.pdf", pdf), "owner"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	person := db.Person{OwnerPubKey: "owner", Extras: db.PropertyMap{"wanted": []interface{}{
+		map[string]interface{}{"created": float64(1700000000), "title": "Fix the login"},
+	}}}
+
+	t.Run("Should test that the ticket owner attaches a screenshot shown with the ticket", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(person).Once()
+		mockDb.On("CountAttachments", db.AttachmentTicket, "owner:1700000000").Return(int64(0)).Once()
+		mockDb.On("CreateAttachment", mock.AnythingOfType("db.Attachment")).Return(func(a db.Attachment) (db.Attachment, error) {
+			return a, nil
+		}).Once()
+
+		rr := serve(withPubkey(uploadRequest(t, "/ticket/owner/1700000000/attachments", "screen.png", testPng(4, 4)), "owner"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		mockDb.On("GetPersonByPubkey", "owner").Return(person).Once()
+		mockDb.On("GetAttachments", db.AttachmentTicket, "owner:1700000000").Return([]db.Attachment{{ID: 2, Key: "attachments/0123456789abcdef0123456789abcdef.png"}}).Once()
+
+		rr = serve(httptest.NewRequest(http.MethodGet, "/ticket/owner/1700000000", nil))
+		ticket := map[string]interface{}{}
+		json.Unmarshal(rr.Body.Bytes(), &ticket)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "Fix the login", ticket["title"])
+		attachments := ticket["attachments"].([]interface{})
+		assert.Len(t, attachments, 1)
+		assert.True(t, strings.HasSuffix(attachments[0].(map[string]interface{})["url"].(string), "/uploads/attachments/0123456789abcdef0123456789abcdef.png"))
+	})
+
+	t.Run("Should test that a missing ticket is not found", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(person).Once()
+		rr := serve(httptest.NewRequest(http.MethodGet, "/ticket/owner/1600000000", nil))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = serve(withPubkey(uploadRequest(t, "/ticket/owner/1700000000/attachments", "screen.png", testPng(4, 4)), "someone"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that only the owner of an attachment deletes it along with its file", func(t *testing.T) {
+		key := storage.NewKey(storage.PurposeAttachment, "spec.pdf")
+		store.Put(key, "application/pdf", strings.NewReader("%PDF"))
+		attachment := db.Attachment{ID: 5, Key: key, OwnerPubKey: "hunter"}
+
+		mockDb.On("GetAttachment", uint(5)).Return(attachment).Once()
+		rr := serve(withPubkey(httptest.NewRequest(http.MethodDelete, "/attachments/5", nil), "owner"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		mockDb.On("GetAttachment", uint(5)).Return(attachment).Once()
+		mockDb.On("DeleteAttachment", uint(5)).Return(nil).Once()
+		rr = serve(withPubkey(httptest.NewRequest(http.MethodDelete, "/attachments/5", nil), "hunter"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		_, err := store.Get(key)
+		assert.Equal(t, storage.ErrNotFound, err)
+	})
+}
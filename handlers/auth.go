@@ -1,12 +1,18 @@
 package handlers
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/form3tech-oss/jwt-go"
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
@@ -15,14 +21,14 @@ import (
 type authHandler struct {
 	db        db.Database
 	decodeJwt func(token string) (jwt.MapClaims, error)
-	encodeJwt func(pubkey string) (string, error)
+	encodeJwt func(pubkey string, sessionId string, jti string) (string, error)
 }
 
 func NewAuthHandler(db db.Database) *authHandler {
 	return &authHandler{
 		db:        db,
 		decodeJwt: auth.DecodeJwt,
-		encodeJwt: auth.EncodeJwt,
+		encodeJwt: auth.EncodeSessionJwt,
 	}
 }
 
@@ -31,7 +37,7 @@ func GetAdminPubkeys(w http.ResponseWriter, r *http.Request) {
 		Pubkeys []string `json:"pubkeys"`
 	}
 	pubkeys := PubKeysReturn{
-		Pubkeys: config.SuperAdmins,
+		Pubkeys: auth.AdminPubkeys(),
 	}
 	json.NewEncoder(w).Encode(pubkeys)
 	w.WriteHeader(http.StatusOK)
@@ -86,8 +92,25 @@ func (ah *authHandler) CreateConnectionCode(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode("Codes created successfully")
 }
 
-func (ah *authHandler) GetConnectionCode(w http.ResponseWriter, _ *http.Request) {
-	connectionCode := ah.db.GetConnectionCode()
+// GetConnectionCode hands out an unused connection code, of the ?campaign= when one is given.
+// An ip and the device of the X-Device-Id header only get a few codes in a window, and a solved
+// proof of work challenge is needed when one is configured
+func (ah *authHandler) GetConnectionCode(w http.ResponseWriter, r *http.Request) {
+	if !ah.checkConnectionCodePow(w, r) {
+		return
+	}
+
+	ip := clientIp(r)
+	device := r.Header.Get("X-Device-Id")
+	ipLimit, deviceLimit, window := connectionCodeLimits()
+	byIp, byDevice := ah.db.CountConnectionCodeRedemptions(ip, device, time.Now().Add(-window))
+	if (ipLimit > 0 && byIp >= ipLimit) || (device != "" && deviceLimit > 0 && byDevice >= deviceLimit) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode("Too many connection codes were requested, try again later")
+		return
+	}
+
+	connectionCode := ah.db.GetCampaignConnectionCode(r.URL.Query().Get("campaign"), ip, device)
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(connectionCode)
@@ -109,7 +132,14 @@ func GetLnurlAuth(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode("Could not generate LNURL AUTH")
 	}
 
-	db.Store.SetLnCache(encodeData.K1, db.LnStore{K1: encodeData.K1, Key: "", Status: false})
+	// the session started when the wallet signs in keeps the device name and fingerprint of this browser
+	db.Store.SetLnCache(encodeData.K1, db.LnStore{
+		K1:          encodeData.K1,
+		Key:         "",
+		Status:      false,
+		DeviceName:  r.URL.Query().Get("device_name"),
+		Fingerprint: auth.HashFingerprint(auth.ClientFingerprint(r)),
+	})
 
 	// add socket to store with K1, so the LNURL return data can use it
 	db.Store.SetSocketConnections(db.Client{
@@ -144,10 +174,16 @@ func ReceiveLnAuthData(w http.ResponseWriter, r *http.Request) {
 		db.DB.CreateLnUser(userKey)
 
 		// Set store data to true
+		lnStore, _ := db.Store.GetLnCache(k1)
 		db.Store.SetLnCache(k1, db.LnStore{K1: k1, Key: userKey, Status: true})
 
 		// Send socket message
-		tokenString, err := auth.EncodeJwt(userKey)
+		tokenString, err := db.DB.StartAuthSession(db.AuthSession{
+			OwnerPubKey: userKey,
+			UserAgent:   r.UserAgent(),
+			DeviceName:  lnStore.DeviceName,
+			Fingerprint: lnStore.Fingerprint,
+		})
 
 		if err != nil {
 			fmt.Println("[auth] error creating LNAUTH JWT")
@@ -200,13 +236,71 @@ func (ah *authHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// an impersonation would otherwise outlive its expiry as a session of the pubkey
+	if auth.ImpersonationId(claims) != "" {
+		fmt.Println("[auth] refresh of an impersonation token")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("impersonation tokens can't be refreshed")
+		return
+	}
+
 	pubkey := fmt.Sprint(claims["pubkey"])
 
 	userCount := ah.db.GetLnUser(pubkey)
 
 	if userCount > 0 {
+		sessionId, _ := claims["sid"].(string)
+		jti, _ := claims["jti"].(string)
+
+		if sessionId == "" {
+			// tokens issued before sessions existed can't be retired, they are honoured until they
+			// expire but are not turned into sessions, the user signs in again for one
+			fmt.Println("[auth] refresh of a token without a session")
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode("the token has no session, sign in again")
+			return
+		}
+
+		session, err := ah.db.GetAuthSession(sessionId)
+		if err != nil || session.Revoked || session.OwnerPubKey != pubkey {
+			fmt.Println("[auth] refresh for revoked or unknown session")
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode("session revoked")
+			return
+		}
+		if !sessionFingerprintMatches(session, auth.ClientFingerprint(r)) {
+			fmt.Println("[auth] refresh from another client fingerprint", sessionId)
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode("session bound to another client")
+			return
+		}
+		if session.CurrentJti != jti {
+			// an already rotated token was replayed, it may have been stolen so the whole family is revoked
+			fmt.Println("[auth] refresh token reuse detected, revoking session", sessionId)
+			ah.db.RevokeAuthSession(pubkey, sessionId)
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode("session revoked")
+			return
+		}
+
 		// Generate a new token
-		tokenString, err := ah.encodeJwt(pubkey)
+		newJti := xid.New().String()
+		if err := ah.db.RotateAuthSession(sessionId, jti, newJti); err != nil {
+			if errors.Is(err, db.ErrSessionTokenReused) {
+				// another refresh rotated the session from the same token first, it is treated as reuse
+				fmt.Println("[auth] refresh token reuse detected, revoking session", sessionId)
+				ah.db.RevokeAuthSession(pubkey, sessionId)
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode("session revoked")
+				return
+			}
+			fmt.Println("[auth] error rotating session", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(err.Error())
+			return
+		}
+
+		tokenString, err := ah.encodeJwt(pubkey, sessionId, newJti)
 
 		if err != nil {
 			fmt.Println("[auth] error creating  refresh JWT")
@@ -228,6 +322,171 @@ func (ah *authHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// sessionTouchInterval throttles last_used writes, every authenticated request validates its session
+const sessionTouchInterval = 5 * time.Minute
+
+// ValidateSession reports whether jti is the current token of a session that has not been revoked,
+// sessions bound to a client fingerprint also need the same fingerprint
+func (ah *authHandler) ValidateSession(sessionId string, jti string, fingerprint string) bool {
+	session, err := ah.db.GetAuthSession(sessionId)
+	if err != nil {
+		return false
+	}
+	if session.Revoked || session.CurrentJti != jti || !sessionFingerprintMatches(session, fingerprint) {
+		return false
+	}
+	if session.LastUsed == nil || time.Since(*session.LastUsed) > sessionTouchInterval {
+		ah.db.TouchAuthSession(sessionId)
+	}
+	return true
+}
+
+// sessionFingerprintMatches is true for unbound sessions
+func sessionFingerprintMatches(session db.AuthSession, fingerprint string) bool {
+	if session.Fingerprint == "" {
+		return true
+	}
+	hashed := auth.HashFingerprint(fingerprint)
+	return subtle.ConstantTimeCompare([]byte(session.Fingerprint), []byte(hashed)) == 1
+}
+
+// Logout revokes the session family of the token used for the request
+func (ah *authHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[auth] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := ah.decodeJwt(r.Header.Get("x-jwt"))
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	// a token issued before sessions existed has nothing to revoke, it is honoured until it expires
+	sessionId, _ := claims["sid"].(string)
+	if sessionId == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("the token has no session to revoke, it stops working when it expires")
+		return
+	}
+
+	if err := ah.db.RevokeAuthSession(pubKeyFromAuth, sessionId); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Logged out")
+}
+
+func (ah *authHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[auth] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if auth.IsImpersonatedRequest(ctx) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("An impersonation can't manage sessions")
+		return
+	}
+
+	sessions := ah.db.GetActiveAuthSessions(pubKeyFromAuth)
+
+	// flag the session the request was made with so clients don't offer to revoke it by mistake
+	if claims, err := ah.decodeJwt(r.Header.Get("x-jwt")); err == nil {
+		currentId, _ := claims["sid"].(string)
+		for i := range sessions {
+			sessions[i].Current = currentId != "" && sessions[i].Uuid == currentId
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(sessions)
+}
+
+func (ah *authHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[auth] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if auth.IsImpersonatedRequest(ctx) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("An impersonation can't manage sessions")
+		return
+	}
+
+	uuid := chi.URLParam(r, "uuid")
+	if err := ah.db.RevokeAuthSession(pubKeyFromAuth, uuid); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Session revoked")
+}
+
+type SessionRenameRequest struct {
+	DeviceName string `json:"device_name"`
+}
+
+// sessionDeviceNameMaxLength keeps device names to something a session list can show
+const sessionDeviceNameMaxLength = 100
+
+func (ah *authHandler) RenameSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[auth] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if auth.IsImpersonatedRequest(ctx) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("An impersonation can't manage sessions")
+		return
+	}
+
+	request := SessionRenameRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	deviceName := strings.TrimSpace(request.DeviceName)
+	if deviceName == "" || len(deviceName) > sessionDeviceNameMaxLength {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("device_name must be between 1 and %d characters", sessionDeviceNameMaxLength))
+		return
+	}
+
+	uuid := chi.URLParam(r, "uuid")
+	if err := ah.db.RenameAuthSession(pubKeyFromAuth, uuid, deviceName); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Session renamed")
+}
+
 func returnUserMap(p db.Person) map[string]interface{} {
 	user := make(map[string]interface{})
 
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	mocks "github.com/stakwork/sphinx-tribes/mocks"
@@ -19,6 +21,7 @@ import (
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetAdminPubkeys(t *testing.T) {
@@ -264,10 +267,13 @@ func TestRefreshToken(t *testing.T) {
 			GithubIssues: db.PropertyMap{},
 		}
 		db.TestDB.CreateOrEditPerson(person)
+		db.TestDB.CreateAuthSession(db.AuthSession{Uuid: "refresh_session", OwnerPubKey: person.OwnerPubKey, CurrentJti: "current_jti"})
 
 		// Mock JWT decoding
 		mockClaims := jwt.MapClaims{
 			"pubkey": person.OwnerPubKey,
+			"sid":    "refresh_session",
+			"jti":    "current_jti",
 		}
 		mockDecodeJwt := func(token string) (jwt.MapClaims, error) {
 			return mockClaims, nil
@@ -276,7 +282,7 @@ func TestRefreshToken(t *testing.T) {
 
 		// Mock JWT encoding
 		mockEncodedToken := "encoded_mock_token"
-		mockEncodeJwt := func(pubkey string) (string, error) {
+		mockEncodeJwt := func(pubkey string, sessionId string, jti string) (string, error) {
 			return mockEncodedToken, nil
 		}
 		aHandler.encodeJwt = mockEncodeJwt
@@ -307,3 +313,283 @@ func TestRefreshToken(t *testing.T) {
 		assert.EqualValues(t, person, fetchedPerson)
 	})
 }
+
+func TestRefreshTokenRotation(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	aHandler := NewAuthHandler(mockDb)
+	aHandler.encodeJwt = func(pubkey string, sessionId string, jti string) (string, error) {
+		return sessionId + ":" + jti, nil
+	}
+
+	t.Run("Should test that a session token is rotated", func(t *testing.T) {
+		aHandler.decodeJwt = func(token string) (jwt.MapClaims, error) {
+			return jwt.MapClaims{"pubkey": "pubkey", "sid": "session", "jti": "current"}, nil
+		}
+		mockDb.On("GetLnUser", "pubkey").Return(int64(1)).Once()
+		mockDb.On("GetAuthSession", "session").Return(db.AuthSession{Uuid: "session", OwnerPubKey: "pubkey", CurrentJti: "current"}, nil).Once()
+		mockDb.On("RotateAuthSession", "session", "current", mock.AnythingOfType("string")).Return(nil).Once()
+		mockDb.On("GetPersonByPubkey", "pubkey").Return(db.Person{OwnerPubKey: "pubkey"}).Once()
+
+		req, _ := http.NewRequest("GET", "/refresh_jwt", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.RefreshToken).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var responseData map[string]interface{}
+		json.Unmarshal(rr.Body.Bytes(), &responseData)
+		assert.True(t, strings.HasPrefix(responseData["jwt"].(string), "session:"))
+		assert.NotEqual(t, "session:current", responseData["jwt"])
+	})
+
+	t.Run("Should test that reusing a rotated token revokes the session family", func(t *testing.T) {
+		aHandler.decodeJwt = func(token string) (jwt.MapClaims, error) {
+			return jwt.MapClaims{"pubkey": "pubkey", "sid": "session", "jti": "old"}, nil
+		}
+		mockDb.On("GetLnUser", "pubkey").Return(int64(1)).Once()
+		mockDb.On("GetAuthSession", "session").Return(db.AuthSession{Uuid: "session", OwnerPubKey: "pubkey", CurrentJti: "current"}, nil).Once()
+		mockDb.On("RevokeAuthSession", "pubkey", "session").Return(nil).Once()
+
+		req, _ := http.NewRequest("GET", "/refresh_jwt", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.RefreshToken).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a refresh that lost the rotation revokes the session family", func(t *testing.T) {
+		aHandler.decodeJwt = func(token string) (jwt.MapClaims, error) {
+			return jwt.MapClaims{"pubkey": "pubkey", "sid": "session", "jti": "current"}, nil
+		}
+		mockDb.On("GetLnUser", "pubkey").Return(int64(1)).Once()
+		mockDb.On("GetAuthSession", "session").Return(db.AuthSession{Uuid: "session", OwnerPubKey: "pubkey", CurrentJti: "current"}, nil).Once()
+		mockDb.On("RotateAuthSession", "session", "current", mock.AnythingOfType("string")).Return(db.ErrSessionTokenReused).Once()
+		mockDb.On("RevokeAuthSession", "pubkey", "session").Return(nil).Once()
+
+		req, _ := http.NewRequest("GET", "/refresh_jwt", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.RefreshToken).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a revoked session cannot be refreshed", func(t *testing.T) {
+		aHandler.decodeJwt = func(token string) (jwt.MapClaims, error) {
+			return jwt.MapClaims{"pubkey": "pubkey", "sid": "session", "jti": "current"}, nil
+		}
+		mockDb.On("GetLnUser", "pubkey").Return(int64(1)).Once()
+		mockDb.On("GetAuthSession", "session").Return(db.AuthSession{Uuid: "session", OwnerPubKey: "pubkey", CurrentJti: "current", Revoked: true}, nil).Once()
+
+		req, _ := http.NewRequest("GET", "/refresh_jwt", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.RefreshToken).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a bound session cannot be refreshed from another client", func(t *testing.T) {
+		aHandler.decodeJwt = func(token string) (jwt.MapClaims, error) {
+			return jwt.MapClaims{"pubkey": "pubkey", "sid": "session", "jti": "current"}, nil
+		}
+		bound := db.AuthSession{Uuid: "session", OwnerPubKey: "pubkey", CurrentJti: "current", Fingerprint: auth.HashFingerprint("laptop")}
+		mockDb.On("GetLnUser", "pubkey").Return(int64(1)).Once()
+		mockDb.On("GetAuthSession", "session").Return(bound, nil).Once()
+
+		req, _ := http.NewRequest("GET", "/refresh_jwt", nil)
+		req.Header.Set("x-client-fingerprint", "other")
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.RefreshToken).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a token without a session is not refreshed into a new session", func(t *testing.T) {
+		aHandler.decodeJwt = func(token string) (jwt.MapClaims, error) {
+			return jwt.MapClaims{"pubkey": "pubkey"}, nil
+		}
+		mockDb.On("GetLnUser", "pubkey").Return(int64(1)).Once()
+
+		req, _ := http.NewRequest("GET", "/refresh_jwt", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.RefreshToken).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	aHandler := NewAuthHandler(mockDb)
+	aHandler.decodeJwt = func(token string) (jwt.MapClaims, error) {
+		return jwt.MapClaims{"pubkey": "pubkey", "sid": "session", "jti": "current"}, nil
+	}
+
+	t.Run("Should test that a 401 is returned without a pubkey", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/logout", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.Logout).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that logout revokes the session family", func(t *testing.T) {
+		mockDb.On("RevokeAuthSession", "pubkey", "session").Return(nil).Once()
+
+		req, _ := http.NewRequest("POST", "/logout", nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "pubkey"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.Logout).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that logout with a token without a session does not claim to log out", func(t *testing.T) {
+		aHandler.decodeJwt = func(token string) (jwt.MapClaims, error) {
+			return jwt.MapClaims{"pubkey": "pubkey"}, nil
+		}
+
+		req, _ := http.NewRequest("POST", "/logout", nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "pubkey"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.Logout).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestGetSessions(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	aHandler := NewAuthHandler(mockDb)
+
+	t.Run("Should test that the active sessions of a user are returned", func(t *testing.T) {
+		sessions := []db.AuthSession{{Uuid: "session", OwnerPubKey: "pubkey", CurrentJti: "current_jti", Fingerprint: "fingerprint_hash"}}
+		mockDb.On("GetActiveAuthSessions", "pubkey").Return(sessions).Once()
+
+		req, _ := http.NewRequest("GET", "/person/sessions", nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "pubkey"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetSessions).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "current_jti")
+		assert.NotContains(t, rr.Body.String(), "fingerprint_hash")
+		var returned []db.AuthSession
+		json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.Equal(t, "session", returned[0].Uuid)
+	})
+
+	t.Run("Should test that the session of the request is flagged as current", func(t *testing.T) {
+		aHandler.decodeJwt = func(token string) (jwt.MapClaims, error) {
+			return jwt.MapClaims{"pubkey": "pubkey", "sid": "session", "jti": "current"}, nil
+		}
+		sessions := []db.AuthSession{{Uuid: "other"}, {Uuid: "session"}}
+		mockDb.On("GetActiveAuthSessions", "pubkey").Return(sessions).Once()
+
+		req, _ := http.NewRequest("GET", "/person/sessions", nil)
+		req.Header.Set("x-jwt", "token")
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "pubkey"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetSessions).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var returned []db.AuthSession
+		json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.False(t, returned[0].Current)
+		assert.True(t, returned[1].Current)
+	})
+}
+
+func TestRenameSession(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	aHandler := NewAuthHandler(mockDb)
+
+	renameRequest := func(uuid string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", uuid)
+		req, _ := http.NewRequest("PATCH", "/person/sessions/"+uuid, strings.NewReader(body))
+		return req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, "pubkey"))
+	}
+
+	t.Run("Should test that a session can be renamed", func(t *testing.T) {
+		mockDb.On("RenameAuthSession", "pubkey", "session", "Work laptop").Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.RenameSession).ServeHTTP(rr, renameRequest("session", `{"device_name": " Work laptop "}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that an empty device name is rejected", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.RenameSession).ServeHTTP(rr, renameRequest("session", `{"device_name": "  "}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a 404 is returned for an unknown session", func(t *testing.T) {
+		mockDb.On("RenameAuthSession", "pubkey", "unknown", "Phone").Return(errors.New("no session found")).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.RenameSession).ServeHTTP(rr, renameRequest("unknown", `{"device_name": "Phone"}`))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestValidateSession(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	aHandler := NewAuthHandler(mockDb)
+	recent := time.Now()
+	stale := time.Now().Add(-time.Hour)
+
+	t.Run("Should test that a recently used session is valid without a write", func(t *testing.T) {
+		mockDb.On("GetAuthSession", "session").Return(db.AuthSession{Uuid: "session", CurrentJti: "current", LastUsed: &recent}, nil).Once()
+
+		assert.True(t, aHandler.ValidateSession("session", "current", ""))
+	})
+
+	t.Run("Should test that last used is touched when it is stale", func(t *testing.T) {
+		mockDb.On("GetAuthSession", "session").Return(db.AuthSession{Uuid: "session", CurrentJti: "current", LastUsed: &stale}, nil).Once()
+		mockDb.On("TouchAuthSession", "session").Return(nil).Once()
+
+		assert.True(t, aHandler.ValidateSession("session", "current", "any"))
+	})
+
+	t.Run("Should test that a bound session needs the same fingerprint", func(t *testing.T) {
+		bound := db.AuthSession{Uuid: "session", CurrentJti: "current", LastUsed: &recent, Fingerprint: auth.HashFingerprint("laptop")}
+		mockDb.On("GetAuthSession", "session").Return(bound, nil).Twice()
+
+		assert.True(t, aHandler.ValidateSession("session", "current", "laptop"))
+		assert.False(t, aHandler.ValidateSession("session", "current", "other"))
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	aHandler := NewAuthHandler(mockDb)
+
+	t.Run("Should test that a session can be revoked", func(t *testing.T) {
+		mockDb.On("RevokeAuthSession", "pubkey", "session").Return(nil).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "session")
+		req, _ := http.NewRequest("DELETE", "/person/sessions/session", nil)
+		req = req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, "pubkey"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.RevokeSession).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a 404 is returned for an unknown session", func(t *testing.T) {
+		mockDb.On("RevokeAuthSession", "pubkey", "unknown").Return(errors.New("no session found")).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "unknown")
+		req, _ := http.NewRequest("DELETE", "/person/sessions/unknown", nil)
+		req = req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, "pubkey"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.RevokeSession).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
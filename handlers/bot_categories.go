@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+var botCategorySlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type BotFeaturedRequest struct {
+	Featured bool `json:"featured"`
+}
+
+func (bt *botHandler) GetBotCategories(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bt.db.GetBotCategories())
+}
+
+// GetBotsByCategory lists the bots of a category, the featured and best rated first
+func (bt *botHandler) GetBotsByCategory(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if bt.db.GetBotCategory(slug).ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Category not found")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bt.db.GetBotsByCategory(slug, limit, offset))
+}
+
+func (bt *botHandler) GetFeaturedBots(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bt.db.GetFeaturedBots())
+}
+
+// CreateOrEditBotCategory saves a category of the bot directory, the slug identifies it
+func (bt *botHandler) CreateOrEditBotCategory(w http.ResponseWriter, r *http.Request) {
+	category := db.BotCategory{}
+	if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if !botCategorySlugPattern.MatchString(category.Slug) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The slug must be lowercase letters and digits separated by dashes")
+		return
+	}
+	if category.Name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The category needs a name")
+		return
+	}
+
+	category, err := bt.db.CreateOrEditBotCategory(category)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save the category")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(category)
+}
+
+// DeleteBotCategory removes a category, its bots are left without one
+func (bt *botHandler) DeleteBotCategory(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if bt.db.GetBotCategory(slug).ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Category not found")
+		return
+	}
+	if err := bt.db.DeleteBotCategory(slug); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete the category")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// SetBotFeatured adds a bot to the featured bots or takes it off
+func (bt *botHandler) SetBotFeatured(w http.ResponseWriter, r *http.Request) {
+	bot, ok := bt.botFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	request := BotFeaturedRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	bt.db.UpdateBot(bot.UUID, map[string]interface{}{
+		"featured": request.Featured,
+	})
+	bot.Featured = request.Featured
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bot)
+}
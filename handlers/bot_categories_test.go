@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBotCategories(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	btHandler := NewBotHandler(mockDb)
+
+	serve := func(method string, pattern string, path string, handler http.HandlerFunc, body string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.MethodFunc(method, pattern, handler)
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), method, path, bytes.NewBufferString(body))
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a category needs a slug of lowercase words", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/admin/bots/categories", "/admin/bots/categories", btHandler.CreateOrEditBotCategory, `{"slug": "Fun Bots", "name": "Fun"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a category is saved", func(t *testing.T) {
+		mockDb.On("CreateOrEditBotCategory", db.BotCategory{Slug: "fun-bots", Name: "Fun"}).Return(db.BotCategory{ID: 1, Slug: "fun-bots", Name: "Fun"}, nil).Once()
+		rr := serve(http.MethodPost, "/admin/bots/categories", "/admin/bots/categories", btHandler.CreateOrEditBotCategory, `{"slug": "fun-bots", "name": "Fun"}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that the bots of an unknown category are not found", func(t *testing.T) {
+		mockDb.On("GetBotCategory", "unknown").Return(db.BotCategory{}).Once()
+		rr := serve(http.MethodGet, "/bots/category/{slug}", "/bots/category/unknown", btHandler.GetBotsByCategory, "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Should test that the bots of a category are listed", func(t *testing.T) {
+		mockDb.On("GetBotCategory", "fun-bots").Return(db.BotCategory{ID: 1, Slug: "fun-bots"}).Once()
+		mockDb.On("GetBotsByCategory", "fun-bots", 20, 0).Return([]db.Bot{{UUID: "bot", Category: "fun-bots"}}).Once()
+		rr := serve(http.MethodGet, "/bots/category/{slug}", "/bots/category/fun-bots", btHandler.GetBotsByCategory, "")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that an admin can feature a bot", func(t *testing.T) {
+		mockDb.On("GetBot", "bot").Return(db.Bot{UUID: "bot"}).Once()
+		mockDb.On("UpdateBot", "bot", map[string]interface{}{"featured": true}).Return(true).Once()
+		rr := serve(http.MethodPut, "/admin/bots/{uuid}/featured", "/admin/bots/bot/featured", btHandler.SetBotFeatured, `{"featured": true}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
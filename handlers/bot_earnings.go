@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/notifications"
+)
+
+// botPayoutLimit is how many of the latest payouts the earnings of a bot list
+const botPayoutLimit = 20
+
+type BotUsageRequest struct {
+	TribeUuid    string `json:"tribe_uuid"`
+	SenderPubKey string `json:"sender_pubkey"`
+	Messages     uint   `json:"messages"`
+}
+
+// botFromUrl returns the bot of the {uuid} param, writing the error response when it is missing
+func (bt *botHandler) botFromUrl(w http.ResponseWriter, r *http.Request) (db.Bot, bool) {
+	bot := bt.db.GetBot(chi.URLParam(r, "uuid"))
+	if bot.UUID == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Bot not found")
+		return db.Bot{}, false
+	}
+	return bot, true
+}
+
+// RecordBotUsage records messages a bot answered in a tribe, reported by the owner of the tribe.
+// They are charged at the current price per use of the bot
+func (bt *botHandler) RecordBotUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bots] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bot, ok := bt.botFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	request := BotUsageRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if request.Messages == 0 {
+		request.Messages = 1
+	}
+
+	tribe := bt.db.GetTribe(request.TribeUuid)
+	if tribe.UUID == "" || tribe.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the owner of the tribe can report the usage of its bots")
+		return
+	}
+
+	usage, err := bt.db.CreateBotUsage(db.BotUsage{
+		BotUuid:      bot.UUID,
+		TribeUuid:    tribe.UUID,
+		SenderPubKey: request.SenderPubKey,
+		Messages:     request.Messages,
+		Amount:       uint(bot.PricePerUse) * request.Messages,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not record the usage")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(usage)
+}
+
+// GetBotEarnings returns the monthly usage of a bot, what is owed to its owner and the latest payouts
+func (bt *botHandler) GetBotEarnings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	bot, ok := bt.botFromUrl(w, r)
+	if !ok {
+		return
+	}
+	if pubKeyFromAuth == "" || bot.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	owed, _ := bt.db.GetBotUnpaidUsage(bot.UUID)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.BotEarnings{
+		BotUuid:     bot.UUID,
+		PricePerUse: bot.PricePerUse,
+		Owed:        owed,
+		Months:      bt.db.GetBotMonthlyEarnings(bot.UUID),
+		Payouts:     bt.db.GetBotPayouts(bot.UUID, botPayoutLimit),
+	})
+}
+
+// PayoutBot keysends the sats owed to the owner of a bot, the usage is settled only when the
+// payment succeeds so a failed payout can be asked again
+func (bt *botHandler) PayoutBot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	bot, ok := bt.botFromUrl(w, r)
+	if !ok {
+		return
+	}
+	if pubKeyFromAuth == "" || bot.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bt.m.Lock()
+	defer bt.m.Unlock()
+
+	owed, lastUsageId := bt.db.GetBotUnpaidUsage(bot.UUID)
+	if owed == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Nothing is owed to the bot")
+		return
+	}
+
+	payout := db.BotPayout{
+		BotUuid:     bot.UUID,
+		OwnerPubKey: bot.OwnerPubKey,
+		Amount:      owed,
+		Status:      db.BotPayoutSucceeded,
+	}
+	payment, err := bt.lnBackend.PayKeysend(owed, bot.OwnerPubKey, bot.OwnerRouteHint)
+	if err != nil {
+		log.Printf("[bots] Payout of %d to %s Failed: %s", owed, bot.OwnerPubKey, err)
+		payout.Status = db.BotPayoutFailed
+		payout.Error = err.Error()
+	} else {
+		payout.PaymentHash = payment.PaymentHash
+	}
+
+	if recorded, dbErr := bt.db.SettleBotUsage(payout, lastUsageId); dbErr != nil {
+		log.Printf("[bots] Could not record the payout of %s: %s", bot.UUID, dbErr)
+	} else {
+		payout = recorded
+	}
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadGateway)
+		json.NewEncoder(w).Encode(payout)
+		return
+	}
+
+	events.Publish(events.BotPaymentReceived, "", map[string]interface{}{
+		"bot_uuid":     bot.UUID,
+		"amount":       owed,
+		"payout_id":    payout.ID,
+		"payment_hash": payout.PaymentHash,
+	})
+	notifications.Notify(bot.OwnerPubKey, db.NotificationPaymentReceived, fmt.Sprintf("You received %d sats", owed), bot.Name, "")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(payout)
+}
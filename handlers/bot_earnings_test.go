@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBotEarnings(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	btHandler := NewBotHandler(mockDb)
+	bot := db.Bot{UUID: "bot", OwnerPubKey: "owner", Name: "Weather", PricePerUse: 5, OwnerRouteHint: "hint"}
+
+	serve := func(method string, path string, body string, pubkey string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Post("/bot/{uuid}/usage", btHandler.RecordBotUsage)
+		ro.Post("/bot/{uuid}/payout", btHandler.PayoutBot)
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, path, bytes.NewBufferString(body))
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that only the owner of the tribe can report usage", func(t *testing.T) {
+		mockDb.On("GetBot", "bot").Return(bot).Once()
+		mockDb.On("GetTribe", "tribe").Return(db.Tribe{UUID: "tribe", OwnerPubKey: "tribe-owner"}).Once()
+		rr := serve(http.MethodPost, "/bot/bot/usage", `{"tribe_uuid": "tribe", "messages": 2}`, "someone")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that usage is charged at the price per use of the bot", func(t *testing.T) {
+		mockDb.On("GetBot", "bot").Return(bot).Once()
+		mockDb.On("GetTribe", "tribe").Return(db.Tribe{UUID: "tribe", OwnerPubKey: "tribe-owner"}).Once()
+		mockDb.On("CreateBotUsage", db.BotUsage{BotUuid: "bot", TribeUuid: "tribe", SenderPubKey: "sender", Messages: 3, Amount: 15}).
+			Return(db.BotUsage{ID: 1}, nil).Once()
+		rr := serve(http.MethodPost, "/bot/bot/usage", `{"tribe_uuid": "tribe", "sender_pubkey": "sender", "messages": 3}`, "tribe-owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a payout keysends the owed sats and settles the usage", func(t *testing.T) {
+		backend := &keysendTestBackend{}
+		btHandler.lnBackend = backend
+		mockDb.On("GetBot", "bot").Return(bot).Once()
+		mockDb.On("GetBotUnpaidUsage", "bot").Return(uint(15), uint(7)).Once()
+		mockDb.On("SettleBotUsage", mock.MatchedBy(func(p db.BotPayout) bool {
+			return p.Amount == 15 && p.OwnerPubKey == "owner" && p.Status == db.BotPayoutSucceeded
+		}), uint(7)).Return(db.BotPayout{ID: 1, Status: db.BotPayoutSucceeded}, nil).Once()
+		rr := serve(http.MethodPost, "/bot/bot/payout", "", "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 1, backend.calls)
+	})
+
+	t.Run("Should test that a failed payout leaves the usage owed", func(t *testing.T) {
+		btHandler.lnBackend = &keysendTestBackend{err: errors.New("no route")}
+		mockDb.On("GetBot", "bot").Return(bot).Once()
+		mockDb.On("GetBotUnpaidUsage", "bot").Return(uint(15), uint(7)).Once()
+		mockDb.On("SettleBotUsage", mock.MatchedBy(func(p db.BotPayout) bool {
+			return p.Status == db.BotPayoutFailed && p.Error == "no route"
+		}), uint(7)).Return(db.BotPayout{ID: 2, Status: db.BotPayoutFailed}, nil).Once()
+		rr := serve(http.MethodPost, "/bot/bot/payout", "", "owner")
+		assert.Equal(t, http.StatusBadGateway, rr.Code)
+	})
+
+	t.Run("Should test that only the bot owner can ask for a payout", func(t *testing.T) {
+		mockDb.On("GetBot", "bot").Return(bot).Once()
+		rr := serve(http.MethodPost, "/bot/bot/payout", "", "someone")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const maxBotReviewLength = 2000
+
+type BotReviewRequest struct {
+	Rating int    `json:"rating"`
+	Text   string `json:"text"`
+}
+
+// GetBotReviews returns the reviews of a bot, the latest first
+func (bt *botHandler) GetBotReviews(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bt.db.GetBotReviews(chi.URLParam(r, "uuid"), limit, offset))
+}
+
+// ReviewBot saves the 1 to 5 star review of a bot by the user, replacing their previous one.
+// Owners can't review their own bots
+func (bt *botHandler) ReviewBot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bots] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bot, ok := bt.botFromUrl(w, r)
+	if !ok {
+		return
+	}
+	if bot.OwnerPubKey == pubKeyFromAuth {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("You can't review your own bot")
+		return
+	}
+
+	request := BotReviewRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if request.Rating < 1 || request.Rating > 5 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The rating must be between 1 and 5")
+		return
+	}
+	if utf8.RuneCountInString(request.Text) > maxBotReviewLength {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("The review can't be longer than %d characters", maxBotReviewLength))
+		return
+	}
+
+	review, err := bt.db.UpsertBotReview(db.BotReview{
+		BotUuid:        bot.UUID,
+		ReviewerPubKey: pubKeyFromAuth,
+		Rating:         request.Rating,
+		Text:           request.Text,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save the review")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(review)
+}
+
+// DeleteBotReview removes the review of the user from a bot
+func (bt *botHandler) DeleteBotReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bots] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "uuid")
+	if bt.db.GetBotReview(uuid, pubKeyFromAuth).ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Review not found")
+		return
+	}
+	if err := bt.db.DeleteBotReview(uuid, pubKeyFromAuth); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete the review")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
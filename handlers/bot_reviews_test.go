@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewBot(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	btHandler := NewBotHandler(mockDb)
+	bot := db.Bot{UUID: "bot", OwnerPubKey: "owner"}
+
+	review := func(body string, pubkey string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Post("/bot/{uuid}/reviews", btHandler.ReviewBot)
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/bot/bot/reviews", bytes.NewBufferString(body))
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that the owner can't review their own bot", func(t *testing.T) {
+		mockDb.On("GetBot", "bot").Return(bot).Once()
+		rr := review(`{"rating": 5}`, "owner")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the rating is between 1 and 5", func(t *testing.T) {
+		mockDb.On("GetBot", "bot").Return(bot).Once()
+		rr := review(`{"rating": 6}`, "reviewer")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the review of the user is saved", func(t *testing.T) {
+		mockDb.On("GetBot", "bot").Return(bot).Once()
+		mockDb.On("UpsertBotReview", db.BotReview{BotUuid: "bot", ReviewerPubKey: "reviewer", Rating: 4, Text: "Useful"}).
+			Return(db.BotReview{ID: 1, BotUuid: "bot", ReviewerPubKey: "reviewer", Rating: 4, Text: "Useful"}, nil).Once()
+		rr := review(`{"rating": 4, "text": "Useful"}`, "reviewer")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
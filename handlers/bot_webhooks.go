@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+)
+
+// WebhookSourceBot is the source of the webhook deliveries of bot webhooks
+const WebhookSourceBot = "bot_webhook"
+
+const botWebhookDeliveryLimit = 50
+
+// BotWebhookEvents are the events bots can subscribe to
+var BotWebhookEvents = []string{events.TribeJoined, events.BountyCreated, events.BotPaymentReceived}
+
+// botWebhookStore is where dispatchBotWebhooks queues deliveries, it is nil until InitBotWebhooks is called
+var botWebhookStore db.Database
+
+// InitBotWebhooks subscribes the bot webhooks to the events of the bus
+func InitBotWebhooks(database db.Database) {
+	botWebhookStore = database
+	events.Subscribe(events.AllEvents, dispatchBotWebhooks)
+}
+
+// dispatchBotWebhooks queues a delivery of an event to each bot webhook subscribed to it
+func dispatchBotWebhooks(event events.Event) {
+	if botWebhookStore == nil || !validBotWebhookEvent(event.Name) {
+		return
+	}
+	tribeUuid, _ := event.Payload["tribe_uuid"].(string)
+	botUuid, _ := event.Payload["bot_uuid"].(string)
+	for _, webhook := range botWebhookStore.GetBotWebhooksForEvent(event.Name, tribeUuid, botUuid) {
+		queueWebhookDelivery(botWebhookStore, db.WebhookDelivery{
+			Source:     WebhookSourceBot,
+			SourceUuid: webhook.Uuid,
+			Url:        webhook.Url,
+			Secret:     webhook.Secret,
+			Event:      event.Name,
+			Payload:    event.Payload,
+		})
+	}
+}
+
+func validBotWebhookEvent(name string) bool {
+	for _, event := range BotWebhookEvents {
+		if event == name {
+			return true
+		}
+	}
+	return false
+}
+
+func validateBotWebhook(webhook db.BotWebhook) error {
+	u, err := url.Parse(webhook.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("the url must be an http or https url")
+	}
+	host := strings.ToLower(u.Hostname())
+	if ip := net.ParseIP(host); (ip != nil && !publicWebhookAddress(ip)) || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errWebhookAddress
+	}
+	if len(webhook.Events) == 0 {
+		return errors.New("subscribe to at least one event")
+	}
+	for _, event := range webhook.Events {
+		if !validBotWebhookEvent(event) {
+			return fmt.Errorf("unknown event %s", event)
+		}
+	}
+	return nil
+}
+
+// ownedBotFromUrl returns the bot of the {uuid} param when the user owns it
+func (bt *botHandler) ownedBotFromUrl(w http.ResponseWriter, r *http.Request) (db.Bot, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bots] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return db.Bot{}, false
+	}
+
+	bot, ok := bt.botFromUrl(w, r)
+	if !ok {
+		return db.Bot{}, false
+	}
+	if bot.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the owner of the bot can manage its webhooks")
+		return db.Bot{}, false
+	}
+	return bot, true
+}
+
+// botWebhookFromUrl returns the webhook of the {webhook_uuid} param when it belongs to the bot
+func (bt *botHandler) botWebhookFromUrl(w http.ResponseWriter, r *http.Request, bot db.Bot) (db.BotWebhook, bool) {
+	webhook := bt.db.GetBotWebhook(chi.URLParam(r, "webhook_uuid"))
+	if webhook.ID == 0 || webhook.BotUuid != bot.UUID {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Webhook not found")
+		return db.BotWebhook{}, false
+	}
+	return webhook, true
+}
+
+// CreateOrEditBotWebhook registers a callback url for the events of a bot, the signing secret of
+// the deliveries is only returned when the webhook is created
+func (bt *botHandler) CreateOrEditBotWebhook(w http.ResponseWriter, r *http.Request) {
+	bot, ok := bt.ownedBotFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	webhook := db.BotWebhook{}
+	if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if err := validateBotWebhook(webhook); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if webhook.Uuid != "" {
+		existing := bt.db.GetBotWebhook(webhook.Uuid)
+		if existing.ID == 0 || existing.BotUuid != bot.UUID {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode("Webhook not found")
+			return
+		}
+	}
+	created := webhook.Uuid == ""
+	webhook.BotUuid = bot.UUID
+
+	webhook, err := bt.db.CreateOrEditBotWebhook(webhook)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save the webhook")
+		return
+	}
+	if !created {
+		webhook.Secret = ""
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(webhook)
+}
+
+// GetBotWebhooks lists the webhooks of a bot without their secrets
+func (bt *botHandler) GetBotWebhooks(w http.ResponseWriter, r *http.Request) {
+	bot, ok := bt.ownedBotFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	webhooks := bt.db.GetBotWebhooks(bot.UUID)
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(webhooks)
+}
+
+func (bt *botHandler) DeleteBotWebhook(w http.ResponseWriter, r *http.Request) {
+	bot, ok := bt.ownedBotFromUrl(w, r)
+	if !ok {
+		return
+	}
+	webhook, ok := bt.botWebhookFromUrl(w, r, bot)
+	if !ok {
+		return
+	}
+
+	if err := bt.db.DeleteBotWebhook(webhook.Uuid); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete the webhook")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// GetBotWebhookDeliveries returns the latest deliveries of a webhook with their outcome
+func (bt *botHandler) GetBotWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	bot, ok := bt.ownedBotFromUrl(w, r)
+	if !ok {
+		return
+	}
+	webhook, ok := bt.botWebhookFromUrl(w, r, bot)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bt.db.GetWebhookDeliveries(WebhookSourceBot, webhook.Uuid, botWebhookDeliveryLimit))
+}
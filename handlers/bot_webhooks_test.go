@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateOrEditBotWebhook(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	btHandler := NewBotHandler(mockDb)
+	bot := db.Bot{UUID: "bot", OwnerPubKey: "owner"}
+
+	register := func(body string, pubkey string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Post("/bot/{uuid}/webhooks", btHandler.CreateOrEditBotWebhook)
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/bot/bot/webhooks", bytes.NewBufferString(body))
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that only the bot owner can register a webhook", func(t *testing.T) {
+		mockDb.On("GetBot", "bot").Return(bot).Once()
+		rr := register(`{"url": "https://bot.example.com/hook", "events": ["tribe.joined"]}`, "someone")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that the events must be ones bots can subscribe to", func(t *testing.T) {
+		mockDb.On("GetBot", "bot").Return(bot).Once()
+		rr := register(`{"url": "https://bot.example.com/hook", "events": ["ticket.completed"]}`, "owner")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a new webhook returns its signing secret", func(t *testing.T) {
+		mockDb.On("GetBot", "bot").Return(bot).Once()
+		mockDb.On("CreateOrEditBotWebhook", mock.MatchedBy(func(w db.BotWebhook) bool {
+			return w.BotUuid == "bot" && w.Url == "https://bot.example.com/hook" && len(w.Events) == 2
+		})).Return(func(w db.BotWebhook) (db.BotWebhook, error) {
+			w.ID = 1
+			w.Uuid = "webhook"
+			w.Secret = "secret"
+			return w, nil
+		}).Once()
+		rr := register(`{"url": "https://bot.example.com/hook", "events": ["tribe.joined", "bot.payment_received"], "enabled": true}`, "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		webhook := db.BotWebhook{}
+		json.Unmarshal(rr.Body.Bytes(), &webhook)
+		assert.Equal(t, "secret", webhook.Secret)
+	})
+}
+
+func TestDispatchBotWebhooks(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	botWebhookStore = mockDb
+	defer func() { botWebhookStore = nil }()
+
+	t.Run("Should test that an event is queued for each subscribed webhook", func(t *testing.T) {
+		webhook := db.BotWebhook{Uuid: "webhook", BotUuid: "bot", Url: "https://bot.example.com/hook", Secret: "secret"}
+		mockDb.On("GetBotWebhooksForEvent", events.TribeJoined, "tribe", "").Return([]db.BotWebhook{webhook}).Once()
+		mockDb.On("CreateWebhookDelivery", mock.MatchedBy(func(d db.WebhookDelivery) bool {
+			return d.Source == WebhookSourceBot && d.SourceUuid == "webhook" && d.Secret == "secret" && d.Event == events.TribeJoined
+		})).Return(db.WebhookDelivery{}, nil).Once()
+
+		dispatchBotWebhooks(events.Event{Name: events.TribeJoined, Payload: map[string]interface{}{"tribe_uuid": "tribe"}})
+	})
+
+	t.Run("Should test that events bots can't subscribe to are ignored", func(t *testing.T) {
+		dispatchBotWebhooks(events.Event{Name: events.TicketCompleted, Payload: map[string]interface{}{}})
+	})
+}
@@ -8,21 +8,26 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/lightning"
 )
 
 type botHandler struct {
+	m               sync.Mutex
 	db              db.Database
+	lnBackend       lightning.Backend
 	verifyTribeUUID func(uuid string, checkTimestamp bool) (string, error)
 }
 
 func NewBotHandler(db db.Database) *botHandler {
 	return &botHandler{
 		db:              db,
+		lnBackend:       lightning.NewBackend(http.DefaultClient),
 		verifyTribeUUID: auth.VerifyTribeUUID,
 	}
 }
@@ -46,6 +51,23 @@ func (bt *botHandler) CreateOrEditBot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if bot.PricePerUse < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The price per use can't be negative")
+		return
+	}
+
+	if bot.Category != "" && bt.db.GetBotCategory(bot.Category).ID == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Unknown bot category")
+		return
+	}
+
+	// the rating comes from the reviews and featuring is up to the admins
+	bot.Rating = 0
+	bot.ReviewCount = 0
+	bot.Featured = false
+
 	now := time.Now()
 
 	extractedPubkey, err := bt.verifyTribeUUID(bot.UUID, false)
@@ -67,6 +89,20 @@ func (bt *botHandler) CreateOrEditBot(w http.ResponseWriter, r *http.Request) {
 	bot.OwnerPubKey = extractedPubkey
 	bot.Updated = &now
 	bot.UniqueName, _ = bt.BotUniqueNameFromName(bot.Name)
+	bot.PendingReview = false
+	bot.ReviewReasons = nil
+
+	// new bots that look like spam stay unlisted until an admin reviews them
+	if bt.db.GetBot(bot.UUID).UUID == "" {
+		if bot.Created == nil {
+			bot.Created = &now
+		}
+		if reasons := listingSpamReasons(bt.db, db.ReportBot, bot.OwnerPubKey, bot.Name, bot.Description); len(reasons) > 0 {
+			bot.Unlisted = true
+			bot.PendingReview = true
+			bot.ReviewReasons = reasons
+		}
+	}
 
 	_, err = bt.db.CreateOrEditBot(bot)
 	if err != nil {
@@ -116,7 +152,7 @@ func (bt *botHandler) SearchBots(w http.ResponseWriter, r *http.Request) {
 	if limit == 0 {
 		limit = 10
 	}
-	bots := bt.db.SearchBots(query, limit, offset)
+	bots := bt.db.SearchBots(query, limit, offset, r.URL.Query().Get("sortBy"), db.SplitBotTags(r.URL.Query().Get("tags")))
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(bots)
 }
@@ -64,6 +64,9 @@ func DeleteBountyAssignee(w http.ResponseWriter, r *http.Request) {
 
 	createdUint, _ := strconv.ParseUint(date, 10, 32)
 	b, err := db.DB.GetBountyByCreated(uint(createdUint))
+	if err == nil && rejectArchivedWorkspace(w, b.WorkspaceUuid) {
+		return
+	}
 
 	if err == nil && b.OwnerID == owner_key {
 		b.Assignee = ""
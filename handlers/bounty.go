@@ -1,8 +1,8 @@
 package handlers
 
 import (
-	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -15,6 +15,9 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/lightning"
+	"github.com/stakwork/sphinx-tribes/notifications"
 	"github.com/stakwork/sphinx-tribes/utils"
 	"gorm.io/gorm"
 )
@@ -26,9 +29,14 @@ type bountyHandler struct {
 	generateBountyResponse   func(bounties []db.NewBounty) []db.BountyResponse
 	userHasAccess            func(pubKeyFromAuth string, uuid string, role string) bool
 	userHasManageBountyRoles func(pubKeyFromAuth string, uuid string) bool
-	m                        sync.Mutex
+	lnBackend                lightning.Backend
+	m                        *sync.Mutex
 }
 
+// bountyPaymentMu is shared by every bounty handler, so the routes, the scheduled jobs and the
+// payment retries never pay or change the payment of a bounty at the same time
+var bountyPaymentMu sync.Mutex
+
 func NewBountyHandler(httpClient HttpClient, database db.Database) *bountyHandler {
 	dbConf := db.NewDatabaseConfig(&gorm.DB{})
 	return &bountyHandler{
@@ -38,15 +46,42 @@ func NewBountyHandler(httpClient HttpClient, database db.Database) *bountyHandle
 		getSocketConnections:     db.Store.GetSocketConnections,
 		userHasAccess:            dbConf.UserHasAccess,
 		userHasManageBountyRoles: dbConf.UserHasManageBountyRoles,
+		lnBackend:                lightning.NewBackend(httpClient),
+		m:                        &bountyPaymentMu,
 	}
 }
 
 func (h *bountyHandler) GetAllBounties(w http.ResponseWriter, r *http.Request) {
-	bounties := h.db.GetAllBounties(r)
-	var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(bounties)
+	since, isDelta, err := parseUpdatedSince(r)
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid updated_since")
+		return
+	}
+	shape, ok := responseShapeFromUrl(w, r, bountyEmbeds...)
+	if !ok {
+		return
+	}
+	if isDelta {
+		syncedAt := time.Now().Unix()
+		bounties := h.db.GetBountiesUpdatedSince(since, r)
+		updated, err := shape.shape(h.shapedBountyResponse(bounties, shape), "bounty")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode("Could not write the response")
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(db.DeltaResponse{
+			Updated:   updated,
+			Deletions: h.db.GetDeletionsSince(db.DeletionKindBounty, since),
+			SyncedAt:  syncedAt,
+		})
+		return
+	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(bountyResponse)
+	bounties := h.db.GetAllBounties(r)
+	writeShaped(w, shape, h.shapedBountyResponse(bounties, shape), "bounty")
 }
 
 func (h *bountyHandler) GetBountyById(w http.ResponseWriter, r *http.Request) {
@@ -54,14 +89,16 @@ func (h *bountyHandler) GetBountyById(w http.ResponseWriter, r *http.Request) {
 	if bountyId == "" {
 		w.WriteHeader(http.StatusNotFound)
 	}
+	shape, ok := responseShapeFromUrl(w, r, bountyEmbeds...)
+	if !ok {
+		return
+	}
 	bounties, err := h.db.GetBountyById(bountyId)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		fmt.Println("[bounty] Error", err)
 	} else {
-		var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(bounties)
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(bountyResponse)
+		writeShaped(w, shape, h.shapedWithAttachments(h.shapedBountyResponse(bounties, shape), shape), "bounty")
 	}
 }
 
@@ -125,15 +162,16 @@ func (h *bountyHandler) GetBountyByCreated(w http.ResponseWriter, r *http.Reques
 	if created == "" {
 		w.WriteHeader(http.StatusNotFound)
 	}
+	shape, ok := responseShapeFromUrl(w, r, bountyEmbeds...)
+	if !ok {
+		return
+	}
 	bounties, err := h.db.GetBountyDataByCreated(created)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		fmt.Println("[bounty] Error", err)
 	} else {
-		var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(bounties)
-
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(bountyResponse)
+		writeShaped(w, shape, h.shapedWithAttachments(h.shapedBountyResponse(bounties, shape), shape), "bounty")
 	}
 }
 
@@ -157,26 +195,30 @@ func GetBountyCount(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *bountyHandler) GetPersonCreatedBounties(w http.ResponseWriter, r *http.Request) {
+	shape, ok := responseShapeFromUrl(w, r, bountyEmbeds...)
+	if !ok {
+		return
+	}
 	bounties, err := h.db.GetCreatedBounties(r)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		fmt.Println("[bounty] Error", err)
 	} else {
-		var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(bounties)
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(bountyResponse)
+		writeShaped(w, shape, h.shapedBountyResponse(bounties, shape), "bounty")
 	}
 }
 
 func (h *bountyHandler) GetPersonAssignedBounties(w http.ResponseWriter, r *http.Request) {
+	shape, ok := responseShapeFromUrl(w, r, bountyEmbeds...)
+	if !ok {
+		return
+	}
 	bounties, err := h.db.GetAssignedBounties(r)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		fmt.Println("[bounty] Error", err)
 	} else {
-		var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(bounties)
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(bountyResponse)
+		writeShaped(w, shape, h.shapedBountyResponse(bounties, shape), "bounty")
 	}
 }
 
@@ -207,6 +249,10 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 		bounty.WorkspaceUuid = bounty.OrgUuid
 	}
 
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
+
 	//Check if bounty exists
 	bounty.Updated = &now
 
@@ -233,6 +279,12 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 		bounty.AssignedDate = &now
 	}
 
+	if !validStaleAfter(bounty.StaleAfter) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("stale_after must be a duration like 72h")
+		return
+	}
+
 	if bounty.Tribe == "" {
 		bounty.Tribe = "None"
 	}
@@ -249,9 +301,22 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 		bounty.Created = time.Now().Unix()
 	}
 
+	previousAssignee := ""
+	priceChanged := bounty.ID == 0
+	escrowTurnedOn := bounty.Escrow
 	if bounty.Title != "" && bounty.ID != 0 {
 		// get bounty from DB
 		dbBounty := h.db.GetBounty(bounty.ID)
+		previousAssignee = dbBounty.Assignee
+		priceChanged = bounty.Price != dbBounty.Price
+		escrowTurnedOn = bounty.Escrow && !dbBounty.Escrow
+		if dbBounty.Escrow && !bounty.Escrow {
+			h.db.UpdateBountyBoolColumn(bounty, "escrow")
+		}
+		// the assignment keeps its date while the assignee stays, stale_after counts from it
+		if bounty.Assignee != "" && bounty.Assignee == dbBounty.Assignee && dbBounty.AssignedDate != nil {
+			bounty.AssignedDate = dbBounty.AssignedDate
+		}
 
 		// trying to update
 		// check if bounty belongs to user
@@ -289,6 +354,18 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
+	// fiat values, the escrow and proof status and the time spent are set by the server, never taken from the client
+	bounty.PriceFiat = nil
+	bounty.PaidFiat = nil
+	bounty.EscrowStatus = ""
+	bounty.ProofStatus = ""
+	bounty.TimeSpent = 0
+	if priceChanged {
+		bounty.PriceFiat = db.FiatAt(bounty.Price)
+	}
+
+	isNewBounty := bounty.ID == 0
+
 	b, err := h.db.CreateOrEditBounty(bounty)
 	if err != nil {
 		fmt.Println("[bounty]", err)
@@ -296,6 +373,24 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if isNewBounty {
+		publishBountyEvent(b, "bounty_created")
+		emitBountyEvent(events.BountyCreated, b)
+		recordActivity(b.OwnerID, db.ActivityBountyCreated, b.Title, bountyLink(b.ID), b.Price)
+		h.alertSavedSearches(b)
+	} else {
+		publishBountyEvent(b, "bounty_updated")
+	}
+
+	if b.Assignee != "" && b.Assignee != previousAssignee {
+		notifications.Notify(b.Assignee, db.NotificationBountyAssigned, "A bounty was assigned to you", b.Title, bountyLink(b.ID))
+		recordActivity(b.Assignee, db.ActivityBountyAssigned, b.Title, bountyLink(b.ID), b.Price)
+		emitBountyEvent(events.BountyAssigned, b)
+	}
+	if b.Assignee != previousAssignee || escrowTurnedOn {
+		b.EscrowStatus = h.escrowAssignment(b, pubKeyFromAuth)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(b)
 }
@@ -341,6 +436,10 @@ func (h *bountyHandler) DeleteBounty(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if rejectArchivedWorkspace(w, createdBounty.WorkspaceUuid) {
+		return
+	}
+
 	b, err := h.db.DeleteBounty(pubkey, created)
 	if err != nil {
 		fmt.Println("[bounty] failed to delete bounty", err.Error())
@@ -348,6 +447,7 @@ func (h *bountyHandler) DeleteBounty(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode("failed to delete bounty")
 		return
 	}
+	h.db.AddDeletion(db.DeletionKindBounty, strconv.Itoa(int(createdBounty.ID)))
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(b)
 }
@@ -357,12 +457,18 @@ func UpdatePaymentStatus(w http.ResponseWriter, r *http.Request) {
 	created, _ := strconv.ParseUint(createdParam, 10, 32)
 
 	bounty, _ := db.DB.GetBountyByCreated(uint(created))
+	if bounty.ID != 0 && rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
 	if bounty.ID != 0 && bounty.Created == int64(created) {
 		bounty.Paid = !bounty.Paid
 		now := time.Now()
 		// if setting paid as true by mark as paid
 		// set completion date and mark as paid
 		if bounty.Paid {
+			if !bounty.Completed {
+				recordActivity(bounty.Assignee, db.ActivityBountyCompleted, bounty.Title, bountyLink(bounty.ID), bounty.Price)
+			}
 			bounty.Completed = true
 			bounty.CompletionDate = &now
 			bounty.MarkAsPaidDate = &now
@@ -371,6 +477,10 @@ func UpdatePaymentStatus(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 		db.DB.UpdateBountyPayment(bounty)
+		if bounty.Paid {
+			completeBountyTicket(db.DB, bounty)
+			emitBountyEvent(events.BountyPaid, bounty)
+		}
 	}
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(bounty)
@@ -381,28 +491,48 @@ func UpdateCompletedStatus(w http.ResponseWriter, r *http.Request) {
 	created, _ := strconv.ParseUint(createdParam, 10, 32)
 
 	bounty, _ := db.DB.GetBountyByCreated(uint(created))
+	if bounty.ID != 0 && rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
 	if bounty.ID != 0 && bounty.Created == int64(created) {
 		now := time.Now()
 		// set bounty as completed
 		if !bounty.Paid && !bounty.Completed {
 			bounty.CompletionDate = &now
 			bounty.Completed = true
+			recordActivity(bounty.Assignee, db.ActivityBountyCompleted, bounty.Title, bountyLink(bounty.ID), bounty.Price)
 		}
 		db.DB.UpdateBountyCompleted(bounty)
+		completeBountyTicket(db.DB, bounty)
 	}
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(bounty)
 }
 
 func (h *bountyHandler) GenerateBountyResponse(bounties []db.NewBounty) []db.BountyResponse {
+	return h.shapedBountyResponse(bounties, responseShape{})
+}
+
+// shapedBountyResponse builds the responses of bounties, only loading the people and workspaces
+// the shape includes
+func (h *bountyHandler) shapedBountyResponse(bounties []db.NewBounty, shape responseShape) []db.BountyResponse {
 	var bountyResponse []db.BountyResponse
 
 	for i := 0; i < len(bounties); i++ {
 		bounty := bounties[i]
 
-		owner := h.db.GetPersonByPubkey(bounty.OwnerID)
-		assignee := h.db.GetPersonByPubkey(bounty.Assignee)
-		workspace := h.db.GetWorkspaceByUuid(bounty.WorkspaceUuid)
+		owner := db.Person{}
+		if shape.includes("owner") {
+			owner = h.db.GetPersonByPubkey(bounty.OwnerID)
+		}
+		assignee := db.Person{}
+		if shape.includes("assignee") {
+			assignee = h.db.GetPersonByPubkey(bounty.Assignee)
+		}
+		workspace := db.Workspace{}
+		if shape.includes("workspace") || shape.includes("organization") {
+			workspace = h.db.GetWorkspaceByUuid(bounty.WorkspaceUuid)
+		}
 
 		b := db.BountyResponse{
 			Bounty: db.NewBounty{
@@ -433,6 +563,8 @@ func (h *bountyHandler) GenerateBountyResponse(bounties []db.NewBounty) []db.Bou
 				Updated:                 bounty.Updated,
 				CodingLanguages:         bounty.CodingLanguages,
 				Completed:               bounty.Completed,
+				TimeSpent:               bounty.TimeSpent,
+				TicketId:                bounty.TicketId,
 			},
 			Assignee: db.Person{
 				ID:               assignee.ID,
@@ -520,6 +652,11 @@ func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		h.m.Unlock()
+		return
+	}
+
 	// check if the bounty has been paid already to avoid double payment
 	if bounty.Paid {
 		w.WriteHeader(http.StatusMethodNotAllowed)
@@ -528,6 +665,22 @@ func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// an escrowed bounty is paid from its escrow, not from the budget, unless nobody funded the escrow
+	if escrowActive(bounty.EscrowStatus) && !h.dropUnfundedEscrow(bounty) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode("Bounty is paid through its escrow")
+		h.m.Unlock()
+		return
+	}
+
+	// once work was submitted, the bounty is paid when the owner accepted it
+	if bounty.ProofStatus != "" && bounty.ProofStatus != db.ProofAccepted {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode("Bounty's proof of work has not been accepted")
+		h.m.Unlock()
+		return
+	}
+
 	// check if user is the admin of the workspace
 	// or has a pay bounty role
 	hasRole := h.userHasAccess(pubKeyFromAuth, bounty.WorkspaceUuid, db.PayBounty)
@@ -548,6 +701,14 @@ func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// check the daily spending caps set by the workspace admins
+	if err := h.db.CheckSpendingCaps(bounty.WorkspaceUuid, bounty.Assignee, amount); err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(err.Error())
+		h.m.Unlock()
+		return
+	}
+
 	request := db.BountyPayRequest{}
 	body, err := io.ReadAll(r.Body)
 	r.Body.Close()
@@ -566,89 +727,106 @@ func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	url := fmt.Sprintf("%s/payment", config.RelayUrl)
-
 	assignee := h.db.GetPersonByPubkey(bounty.Assignee)
-	bodyData := utils.BuildKeysendBodyData(amount, assignee.OwnerPubKey, assignee.OwnerRouteHint)
 
-	jsonBody := []byte(bodyData)
-
-	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
-	req.Header.Set("x-user-token", config.RelayAuthKey)
-	req.Header.Set("Content-Type", "application/json")
-	log.Printf("[bounty] Making Bounty Payment: amount: %d, pubkey: %s, route_hint: %s", amount, assignee.OwnerPubKey, assignee.OwnerRouteHint)
-	res, err := h.httpClient.Do(req)
-
-	if err != nil {
-		log.Printf("[bounty] Request Failed: %s", err)
-		h.m.Unlock()
-		return
-	}
-
-	defer res.Body.Close()
-	body, err = io.ReadAll(res.Body)
-	if err != nil {
-		fmt.Println("[read body]", err)
+	paymentAttempt, err := h.keysendBounty(bounty, assignee, pubKeyFromAuth, 1)
+	if err != nil && paymentAttempt.FailureKind == string(lightning.FailureUnknown) {
 		w.WriteHeader(http.StatusNotAcceptable)
 		h.m.Unlock()
 		return
 	}
 
 	msg := make(map[string]interface{})
+	msg["invoice"] = ""
+	if err == nil {
+		msg["msg"] = "keysend_success"
+	} else {
+		msg["msg"] = "keysend_error"
+		if paymentAttempt.NextRetry != nil {
+			msg["next_retry"] = paymentAttempt.NextRetry
+		}
+	}
 
-	// payment is successful add to payment history
-	// and reduce workspaces budget
-	if res.StatusCode == 200 {
-		// Unmarshal result
-		keysendRes := db.KeysendSuccess{}
-		err = json.Unmarshal(body, &keysendRes)
+	socket, err := h.getSocketConnections(request.Websocket_token)
+	if err == nil {
+		socket.Conn.WriteJSON(msg)
+	}
 
-		if err != nil {
-			fmt.Println("[Unmarshal]", err)
-			w.WriteHeader(http.StatusNotAcceptable)
-			h.m.Unlock()
-			return
-		}
+	h.m.Unlock()
+}
 
-		now := time.Now()
+// keysendBounty pays the bounty to its assignee and records the attempt, on success the payment
+// is added to the payment history and taken from the workspace budget. Transient failures get a retry scheduled
+func (h *bountyHandler) keysendBounty(bounty db.NewBounty, assignee db.Person, senderPubkey string, attempt int) (db.PaymentAttempt, error) {
+	amount := bounty.Price
 
+	log.Printf("[bounty] Making Bounty Payment: amount: %d, pubkey: %s, route_hint: %s, attempt: %d", amount, assignee.OwnerPubKey, assignee.OwnerRouteHint, attempt)
+	payment, err := h.lnBackend.PayKeysend(amount, assignee.OwnerPubKey, assignee.OwnerRouteHint)
+
+	now := time.Now()
+	paymentAttempt := db.PaymentAttempt{
+		BountyId:       bounty.ID,
+		WorkspaceUuid:  bounty.WorkspaceUuid,
+		SenderPubKey:   senderPubkey,
+		ReceiverPubKey: assignee.OwnerPubKey,
+		Amount:         amount,
+		Attempt:        attempt,
+		Status:         db.PaymentAttemptSucceeded,
+		Created:        &now,
+	}
+
+	if err != nil {
+		kind := lightning.ClassifyFailure(err)
+		paymentAttempt.Status = db.PaymentAttemptFailed
+		paymentAttempt.FailureKind = string(kind)
+		paymentAttempt.Error = err.Error()
+		if kind == lightning.FailureTransient {
+			if next := db.NextPaymentRetry(attempt, now); next != nil {
+				paymentAttempt.Status = db.PaymentAttemptRetrying
+				paymentAttempt.NextRetry = next
+			}
+		}
+		log.Printf("[bounty] Keysend Payment to %s Failed (%s): %s", assignee.OwnerPubKey, kind, err)
+	} else {
 		paymentHistory := db.NewPaymentHistory{
 			Amount:         amount,
-			SenderPubKey:   pubKeyFromAuth,
+			SenderPubKey:   senderPubkey,
 			ReceiverPubKey: assignee.OwnerPubKey,
 			WorkspaceUuid:  bounty.WorkspaceUuid,
-			BountyId:       id,
+			BountyId:       bounty.ID,
 			Created:        &now,
 			Updated:        &now,
 			Status:         true,
 			PaymentType:    "payment",
+			Fiat:           db.FiatAt(amount),
+			PaymentHash:    payment.PaymentHash,
+			Preimage:       payment.Preimage,
 		}
 
+		if !bounty.Completed {
+			recordActivity(assignee.OwnerPubKey, db.ActivityBountyCompleted, bounty.Title, bountyLink(bounty.ID), bounty.Price)
+		}
 		bounty.Paid = true
 		bounty.PaidDate = &now
+		bounty.PaidFiat = paymentHistory.Fiat
 		bounty.Completed = true
 		bounty.CompletionDate = &now
 
 		h.db.ProcessBountyPayment(paymentHistory, bounty)
+		completeBountyTicket(h.db, bounty)
+		publishBountyEvent(bounty, "keysend_success")
+		emitBountyEvent(events.BountyPaid, bounty)
+		notifications.Notify(assignee.OwnerPubKey, db.NotificationPaymentReceived, fmt.Sprintf("You received %d sats", amount), bounty.Title, bountyLink(bounty.ID))
+		recordActivity(assignee.OwnerPubKey, db.ActivityPaymentReceived, bounty.Title, bountyLink(bounty.ID), amount)
+	}
 
-		msg["msg"] = "keysend_success"
-		msg["invoice"] = ""
-
-		socket, err := h.getSocketConnections(request.Websocket_token)
-		if err == nil {
-			socket.Conn.WriteJSON(msg)
-		}
+	if recorded, dbErr := recordPaymentAttempt(h.db, paymentAttempt); dbErr != nil {
+		log.Printf("[bounty] Could not record payment attempt: %s", dbErr)
 	} else {
-		msg["msg"] = "keysend_error"
-		msg["invoice"] = ""
-
-		socket, err := h.getSocketConnections(request.Websocket_token)
-		if err == nil {
-			socket.Conn.WriteJSON(msg)
-		}
+		paymentAttempt = recorded
 	}
 
-	h.m.Unlock()
+	return paymentAttempt, err
 }
 
 func (h *bountyHandler) BountyBudgetWithdraw(w http.ResponseWriter, r *http.Request) {
@@ -683,6 +861,11 @@ func (h *bountyHandler) BountyBudgetWithdraw(w http.ResponseWriter, r *http.Requ
 
 	log.Printf("[bounty] [BountyBudgetWithdraw] Logging body: workspace_uuid: %s, pubkey: %s, invoice: %s", request.OrgUuid, pubKeyFromAuth, request.PaymentRequest)
 
+	if rejectArchivedWorkspace(w, request.OrgUuid) {
+		h.m.Unlock()
+		return
+	}
+
 	// check if user is the admin of the workspace
 	// or has a withdraw bounty budget role
 	hasRole := h.userHasAccess(pubKeyFromAuth, request.OrgUuid, db.WithdrawBudget)
@@ -756,6 +939,11 @@ func (h *bountyHandler) NewBountyBudgetWithdraw(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	if rejectArchivedWorkspace(w, request.WorkspaceUuid) {
+		h.m.Unlock()
+		return
+	}
+
 	// check if user is the admin of the workspace
 	// or has a withdraw bounty budget role
 	hasRole := h.userHasAccess(pubKeyFromAuth, request.WorkspaceUuid, db.WithdrawBudget)
@@ -807,88 +995,38 @@ func formatPayError(errorMsg string) db.InvoicePayError {
 }
 
 func (h *bountyHandler) GetLightningInvoice(payment_request string) (db.InvoiceResult, db.InvoiceError) {
-	url := fmt.Sprintf("%s/invoice?payment_request=%s", config.RelayUrl, payment_request)
-
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-
-	req.Header.Set("x-user-token", config.RelayAuthKey)
-	req.Header.Set("Content-Type", "application/json")
-	res, _ := h.httpClient.Do(req)
-
+	invoice, err := h.lnBackend.CheckInvoice(payment_request)
 	if err != nil {
-		log.Printf("[bounty] Request Failed: %s", err)
+		if message, rejected := lightning.Rejected(err); rejected {
+			return db.InvoiceResult{}, db.InvoiceError{Success: false, Error: message}
+		}
+		log.Printf("[bounty] Checking Invoice failed: %s", err)
 		return db.InvoiceResult{}, db.InvoiceError{}
 	}
 
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-
-	if res.StatusCode != 200 {
-		// Unmarshal result
-		invoiceErr := db.InvoiceError{}
-		err = json.Unmarshal(body, &invoiceErr)
-
-		if err != nil {
-			log.Printf("[bounty] Reading Invoice body failed: %s", err)
-			return db.InvoiceResult{}, invoiceErr
-		}
-
-		return db.InvoiceResult{}, invoiceErr
-	} else {
-		// Unmarshal result
-		invoiceRes := db.InvoiceResult{}
-		err = json.Unmarshal(body, &invoiceRes)
-
-		if err != nil {
-			log.Printf("[bounty] Reading Invoice body failed: %s", err)
-			return invoiceRes, db.InvoiceError{}
-		}
-
-		return invoiceRes, db.InvoiceError{}
-	}
+	return db.InvoiceResult{Success: true, Response: invoiceCheckResponse(invoice)}, db.InvoiceError{}
 }
 
 func (h *bountyHandler) PayLightningInvoice(payment_request string) (db.InvoicePaySuccess, db.InvoicePayError) {
-	url := fmt.Sprintf("%s/invoices", config.RelayUrl)
-	bodyData := fmt.Sprintf(`{"payment_request": "%s"}`, payment_request)
-	jsonBody := []byte(bodyData)
-
-	req, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(jsonBody))
-
-	req.Header.Set("x-user-token", config.RelayAuthKey)
-	req.Header.Set("Content-Type", "application/json")
-	res, err := h.httpClient.Do(req)
-
+	invoice, err := h.lnBackend.PayInvoice(payment_request)
 	if err != nil {
-		log.Printf("[bounty] Request Failed: %s", err)
+		if message, rejected := lightning.Rejected(err); rejected {
+			return db.InvoicePaySuccess{}, db.InvoicePayError{Success: false, Error: message}
+		}
+		log.Printf("[bounty] Invoice payment failed: %s", err)
 		return db.InvoicePaySuccess{}, db.InvoicePayError{}
 	}
 
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-
-	if res.StatusCode != 200 {
-		invoiceError := db.InvoicePayError{}
-		err = json.Unmarshal(body, &invoiceError)
-
-		if err != nil {
-			log.Printf("[bounty] Reading Invoice pay error body failed: %s", err)
-			return db.InvoicePaySuccess{}, db.InvoicePayError{}
-		}
-
-		return db.InvoicePaySuccess{}, invoiceError
-	} else {
-		invoiceSuccess := db.InvoicePaySuccess{}
-		err = json.Unmarshal(body, &invoiceSuccess)
-
-		if err != nil {
-			log.Printf("[bounty] Reading Invoice pay success body failed: %s", err)
-			return db.InvoicePaySuccess{}, db.InvoicePayError{}
-		}
+	return db.InvoicePaySuccess{Success: true, Response: invoiceCheckResponse(invoice)}, db.InvoicePayError{}
+}
 
-		return invoiceSuccess, db.InvoicePayError{}
+func invoiceCheckResponse(invoice lightning.Invoice) db.InvoiceCheckResponse {
+	return db.InvoiceCheckResponse{
+		Settled:         invoice.Settled,
+		Payment_request: invoice.PaymentRequest,
+		Payment_hash:    invoice.PaymentHash,
+		Preimage:        invoice.Preimage,
+		Amount:          strconv.FormatUint(uint64(invoice.Amount), 10),
 	}
 }
 
@@ -910,7 +1048,6 @@ func (h *bountyHandler) PollInvoice(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
 	paymentRequest := chi.URLParam(r, "paymentRequest")
-	var err error
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[bounty] no pubkey from auth")
@@ -927,76 +1064,98 @@ func (h *bountyHandler) PollInvoice(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if invoiceRes.Response.Settled {
-		// Todo if an invoice is settled
-		invoice := h.db.GetInvoice(paymentRequest)
-		invData := h.db.GetUserInvoiceData(paymentRequest)
-		dbInvoice := h.db.GetInvoice(paymentRequest)
-
-		// Make any change only if the invoice has not been settled
-		if !dbInvoice.Status {
-			if invoice.Type == "BUDGET" {
-				h.db.AddAndUpdateBudget(invoice)
-			} else if invoice.Type == "KEYSEND" {
-				url := fmt.Sprintf("%s/payment", config.RelayUrl)
-
-				amount := invData.Amount
-
-				bodyData := utils.BuildKeysendBodyData(amount, invData.UserPubkey, invData.RouteHint)
-
-				jsonBody := []byte(bodyData)
-
-				req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
+		if err := h.settleInvoice(paymentRequest); err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(err.Error())
+			return
+		}
+	} else {
+		// Cheeck if time has expired
+		isInvoiceExpired := utils.GetInvoiceExpired(paymentRequest)
+		// If the invoice has expired and it is not paid delete from the DB
+		if isInvoiceExpired {
+			h.db.DeleteInvoice(paymentRequest)
+		}
+	}
 
-				req.Header.Set("x-user-token", config.RelayAuthKey)
-				req.Header.Set("Content-Type", "application/json")
-				res, _ := h.httpClient.Do(req)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(invoiceRes)
+}
 
-				defer res.Body.Close()
+// settleInvoice applies a settled invoice once, budget invoices top up the workspace budget and
+// keysend invoices pay the bounty hunter. Polling, the relay webhook and the reconciliation job share
+// it, so the invoice is claimed before anything is paid and only the caller that claims it goes on
+func (h *bountyHandler) settleInvoice(paymentRequest string) error {
+	invoice := h.db.GetInvoice(paymentRequest)
 
-				body, _ := io.ReadAll(res.Body)
+	// Make any change only if the invoice has not been settled
+	if invoice.Status {
+		return nil
+	}
 
-				if res.StatusCode == 200 {
-					// Unmarshal result
-					keysendRes := db.KeysendSuccess{}
-					err = json.Unmarshal(body, &keysendRes)
+	switch invoice.Type {
+	case "BUDGET":
+		// the budget is credited in the transaction that claims the invoice
+		if err := h.db.ProcessUpdateBudget(invoice); err != nil {
+			if errors.Is(err, db.ErrInvoiceSettled) {
+				return nil
+			}
+			return err
+		}
+		publishWorkspaceEvent(invoice.WorkspaceUuid, "budget_success", invoice)
+	case "KEYSEND":
+		if err := h.db.ClaimInvoice(paymentRequest); err != nil {
+			if errors.Is(err, db.ErrInvoiceSettled) {
+				return nil
+			}
+			return err
+		}
 
-					if err != nil {
-						w.WriteHeader(http.StatusForbidden)
-						json.NewEncoder(w).Encode("Could not decode keysend response")
-						return
-					}
+		invData := h.db.GetUserInvoiceData(paymentRequest)
+		amount := invData.Amount
 
-					bounty, err := h.db.GetBountyByCreated(uint(invData.Created))
-					if err == nil {
-						now := time.Now()
-						bounty.Paid = true
-						bounty.PaidDate = &now
-						bounty.Completed = true
-						bounty.CompletionDate = &now
-					}
+		_, err := h.lnBackend.PayKeysend(amount, invData.UserPubkey, invData.RouteHint)
+		if _, rejected := lightning.Rejected(err); err != nil && !rejected {
+			// the payment is tried again when the invoice is settled next
+			h.db.ReleaseInvoice(paymentRequest)
+			return err
+		}
 
-					h.db.UpdateBounty(bounty)
-				} else {
-					// Unmarshal result
-					keysendError := db.KeysendError{}
-					err = json.Unmarshal(body, &keysendError)
-					log.Printf("[bounty] Keysend Payment to %s Failed, with Error: %s", invData.UserPubkey, err)
+		if err == nil {
+			bounty, err := h.db.GetBountyByCreated(uint(invData.Created))
+			if err == nil {
+				if !bounty.Completed {
+					recordActivity(invData.UserPubkey, db.ActivityBountyCompleted, bounty.Title, bountyLink(bounty.ID), bounty.Price)
 				}
+				now := time.Now()
+				bounty.Paid = true
+				bounty.PaidDate = &now
+				bounty.Completed = true
+				bounty.CompletionDate = &now
+			}
+
+			h.db.UpdateBounty(bounty)
+			completeBountyTicket(h.db, bounty)
+			publishBountyEvent(bounty, "keysend_success")
+			if bounty.Paid {
+				emitBountyEvent(events.BountyPaid, bounty)
 			}
-			// Update the invoice status
-			h.db.UpdateInvoice(paymentRequest)
+			notifications.Notify(invData.UserPubkey, db.NotificationPaymentReceived, fmt.Sprintf("You received %d sats", amount), bounty.Title, bountyLink(bounty.ID))
+			recordActivity(invData.UserPubkey, db.ActivityPaymentReceived, bounty.Title, bountyLink(bounty.ID), amount)
+		} else {
+			log.Printf("[bounty] Keysend Payment to %s Failed, with Error: %s", invData.UserPubkey, err)
 		}
-	} else {
-		// Cheeck if time has expired
-		isInvoiceExpired := utils.GetInvoiceExpired(paymentRequest)
-		// If the invoice has expired and it is not paid delete from the DB
-		if isInvoiceExpired {
-			h.db.DeleteInvoice(paymentRequest)
+	default:
+		if err := h.db.ClaimInvoice(paymentRequest); err != nil {
+			if errors.Is(err, db.ErrInvoiceSettled) {
+				return nil
+			}
+			return err
 		}
 	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(invoiceRes)
+	publishPersonEvent(invoice.OwnerPubkey, "invoice_settled", invoice)
+	return nil
 }
 
 func GetFilterCount(w http.ResponseWriter, r *http.Request) {
@@ -1004,3 +1163,7 @@ func GetFilterCount(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(filterCount)
 }
+
+func bountyLink(id uint) string {
+	return fmt.Sprintf("%s/bounty/%d", config.Host, id)
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/rates"
+)
+
+func exportBountyStatus(bounty db.NewBounty) string {
+	switch {
+	case bounty.Paid:
+		return "paid"
+	case bounty.Completed:
+		return "completed"
+	case bounty.Assignee != "":
+		return "assigned"
+	}
+	return "open"
+}
+
+func exportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// parseExportFilter reads ?status= and the ?from= and ?to= dates, to is inclusive
+func parseExportFilter(r *http.Request) (db.BountyExportFilter, error) {
+	filter := db.BountyExportFilter{Status: r.URL.Query().Get("status")}
+	switch filter.Status {
+	case "", "open", "assigned", "completed", "paid":
+	default:
+		return filter, fmt.Errorf("status must be open, assigned, completed or paid")
+	}
+
+	if from := r.URL.Query().Get("from"); from != "" {
+		t, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return filter, fmt.Errorf("from must be a YYYY-MM-DD date")
+		}
+		filter.From = &t
+	}
+	if to := r.URL.Query().Get("to"); to != "" {
+		t, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return filter, fmt.Errorf("to must be a YYYY-MM-DD date")
+		}
+		t = t.AddDate(0, 0, 1)
+		filter.To = &t
+	}
+	return filter, nil
+}
+
+// ExportWorkspaceBounties streams the bounties of a workspace as CSV, or as JSON with ?format=json,
+// filtered by ?status= and a ?from= / ?to= date range
+func (h *bountyHandler) ExportWorkspaceBounties(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty export] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "workspace_uuid")
+	if !auth.AdminCheck(pubKeyFromAuth) && !h.userHasAccess(pubKeyFromAuth, uuid, db.ViewReport) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have appropriate permissions to view the reports of this workspace")
+		return
+	}
+
+	filter, err := parseExportFilter(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	filename := "bounties"
+	if filter.Status != "" {
+		filename += "-" + filter.Status
+	}
+
+	if r.URL.Query().Get("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.json"`, filename))
+		w.WriteHeader(http.StatusOK)
+
+		encoder := json.NewEncoder(w)
+		w.Write([]byte("["))
+		first := true
+		err = h.db.StreamWorkspaceBounties(uuid, filter, func(bounty db.NewBounty) error {
+			if !first {
+				w.Write([]byte(","))
+			}
+			first = false
+			return encoder.Encode(bounty)
+		})
+		w.Write([]byte("]"))
+		if err != nil {
+			log.Printf("[bounty export] export of %s stopped: %v", uuid, err)
+		}
+		return
+	}
+
+	// the currencies come from the config so the header is known before the first row
+	currencies := rates.ParseCurrencies(config.FiatCurrencies)
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	header := []string{"id", "title", "status", "bounty_url", "owner_pubkey", "assignee_pubkey", "price_sats", "created", "assigned", "completed", "paid"}
+	for _, currency := range currencies {
+		header = append(header, "paid_value_"+strings.ToLower(currency))
+	}
+	header = append(header, "coding_languages", "ticket_url")
+	writer.Write(header)
+
+	err = h.db.StreamWorkspaceBounties(uuid, filter, func(bounty db.NewBounty) error {
+		created := time.Unix(bounty.Created, 0)
+		line := []string{
+			strconv.Itoa(int(bounty.ID)),
+			bounty.Title,
+			exportBountyStatus(bounty),
+			bountyLink(bounty.ID),
+			bounty.OwnerID,
+			bounty.Assignee,
+			strconv.Itoa(int(bounty.Price)),
+			exportTime(&created),
+			exportTime(bounty.AssignedDate),
+			exportTime(bounty.CompletionDate),
+			exportTime(bounty.PaidDate),
+		}
+		for _, currency := range currencies {
+			value := ""
+			if fiat, ok := bounty.PaidFiat[currency]; ok {
+				value = strconv.FormatFloat(fiat, 'f', 2, 64)
+			}
+			line = append(line, value)
+		}
+		line = append(line, strings.Join(bounty.CodingLanguages, ";"), bounty.TicketUrl)
+		// the writer hands its buffer to the response as it fills up
+		return writer.Write(line)
+	})
+	writer.Flush()
+	if err != nil {
+		log.Printf("[bounty export] export of %s stopped: %v", uuid, err)
+	}
+}
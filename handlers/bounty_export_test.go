@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportWorkspaceBounties(t *testing.T) {
+	paidDate := time.Date(2025, time.March, 4, 10, 0, 0, 0, time.UTC)
+	bounties := []db.NewBounty{
+		{ID: 1, Title: "Fix login", OwnerID: "owner", Assignee: "hunter", Price: 1000, Paid: true, PaidDate: &paidDate, PaidFiat: db.FiatAmounts{"USD": 0.65}, Created: 1740000000},
+		{ID: 2, Title: "Dark mode, again", OwnerID: "owner", Price: 2500, Created: 1740000100},
+	}
+
+	export := func(bHandler *bountyHandler, url string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Get("/workspaces/{workspace_uuid}/bounties/export", bHandler.ExportWorkspaceBounties)
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "admin")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	stream := func(args mock.Arguments) {
+		fn := args.Get(2).(func(db.NewBounty) error)
+		for _, bounty := range bounties {
+			fn(bounty)
+		}
+	}
+
+	t.Run("Should test that the paid bounties of a date range are streamed as CSV", func(t *testing.T) {
+		currencies := config.FiatCurrencies
+		config.FiatCurrencies = "USD"
+		defer func() { config.FiatCurrencies = currencies }()
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return role == db.ViewReport }
+		mockDb.On("StreamWorkspaceBounties", "workspace-uuid", mock.MatchedBy(func(filter db.BountyExportFilter) bool {
+			return filter.Status == "paid" && filter.From.Equal(time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)) &&
+				filter.To.Equal(time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC))
+		}), mock.Anything).Run(stream).Return(nil)
+
+		rr := export(bHandler, "/workspaces/workspace-uuid/bounties/export?status=paid&from=2025-03-01&to=2025-03-31")
+
+		lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+		assert.Len(t, lines, 3)
+		assert.Equal(t, "id,title,status,bounty_url,owner_pubkey,assignee_pubkey,price_sats,created,assigned,completed,paid,paid_value_usd,coding_languages,ticket_url", lines[0])
+		assert.Contains(t, lines[1], ",paid,")
+		assert.Contains(t, lines[1], "2025-03-04T10:00:00Z,0.65,")
+		assert.Contains(t, lines[2], `"Dark mode, again"`)
+	})
+
+	t.Run("Should test that the bounties are streamed as a JSON array", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+		mockDb.On("StreamWorkspaceBounties", "workspace-uuid", db.BountyExportFilter{}, mock.Anything).Run(stream).Return(nil)
+
+		rr := export(bHandler, "/workspaces/workspace-uuid/bounties/export?format=json")
+
+		exported := []db.NewBounty{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &exported))
+		assert.Len(t, exported, 2)
+	})
+
+	t.Run("Should test that an invalid status is refused", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+
+		rr := export(bHandler, "/workspaces/workspace-uuid/bounties/export?status=lost")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the export needs the view report role", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+
+		rr := export(bHandler, "/workspaces/workspace-uuid/bounties/export")
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
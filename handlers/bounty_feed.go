@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// bountyFeedLimit is how many of the newest bounties the feed holds
+const bountyFeedLimit = 50
+
+type RssGuid struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type RssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description"`
+	Guid        RssGuid  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+}
+
+type RssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []RssItem `xml:"item"`
+}
+
+type RssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel RssChannel `xml:"channel"`
+}
+
+// bountyFeedItem is a bounty in the feed, its link is its guid so a reader sees it once even when
+// it is edited
+func bountyFeedItem(bounty db.NewBounty) RssItem {
+	link := bountyLink(bounty.ID)
+	description := bounty.Description
+	if bounty.Price > 0 {
+		description = fmt.Sprintf("%s sats\n\n%s", formatSats(bounty.Price), description)
+	}
+	return RssItem{
+		Title:       bounty.Title,
+		Link:        link,
+		Description: description,
+		Guid:        RssGuid{IsPermaLink: true, Value: link},
+		PubDate:     time.Unix(bounty.Created, 0).UTC().Format(time.RFC1123Z),
+		Categories:  bounty.CodingLanguages,
+	}
+}
+
+// GetBountyFeed is an RSS feed of the newest bounties, narrowed by ?workspace=, ?languages= as a
+// comma separated list and ?min_price= in sats
+func (h *bountyHandler) GetBountyFeed(w http.ResponseWriter, r *http.Request) {
+	keys := r.URL.Query()
+	filter := db.BountyFeedFilter{WorkspaceUuid: keys.Get("workspace")}
+	for _, language := range strings.Split(keys.Get("languages"), ",") {
+		if language = strings.TrimSpace(language); language != "" {
+			filter.Languages = append(filter.Languages, language)
+		}
+	}
+	if minPrice := keys.Get("min_price"); minPrice != "" {
+		price, err := strconv.ParseUint(minPrice, 10, 32)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("min_price must be a number of sats"))
+			return
+		}
+		filter.MinPrice = uint(price)
+	}
+
+	channel := RssChannel{
+		Title:       "Sphinx Community bounties",
+		Link:        config.Host + "/bounties",
+		Description: "The newest bounties on Sphinx Community",
+		Items:       []RssItem{},
+	}
+	if filter.WorkspaceUuid != "" {
+		workspace := h.db.GetWorkspaceByUuid(filter.WorkspaceUuid)
+		if workspace.ID == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("Workspace not found"))
+			return
+		}
+		channel.Title = workspace.Name + " bounties"
+		channel.Link = config.Host + "/workspace/bounties/" + workspace.Uuid
+		channel.Description = "The newest bounties of " + workspace.Name
+	}
+
+	bounties := h.db.GetBountyFeed(filter, bountyFeedLimit)
+	for _, bounty := range bounties {
+		channel.Items = append(channel.Items, bountyFeedItem(bounty))
+	}
+	if len(bounties) > 0 {
+		channel.LastBuildDate = channel.Items[0].PubDate
+	}
+
+	body, err := xml.MarshalIndent(RssFeed{Version: "2.0", Channel: channel}, "", "  ")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	w.Write(body)
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBountyFeed(t *testing.T) {
+	getFeed := func(bHandler *bountyHandler, url string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Get("/gobounties/feed.rss", bHandler.GetBountyFeed)
+
+		rr := httptest.NewRecorder()
+		ro.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, url, nil))
+		return rr
+	}
+
+	t.Run("Should test that the feed has a guid and a pubDate for every bounty", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bounties := []db.NewBounty{{ID: 7, Title: "Fix <the> login", Price: 21000, Created: 1700000000, CodingLanguages: []string{"Golang"}}}
+		mockDb.On("GetBountyFeed", db.BountyFeedFilter{}, bountyFeedLimit).Return(bounties)
+
+		rr := getFeed(bHandler, "/gobounties/feed.rss")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/rss+xml; charset=utf-8", rr.Header().Get("Content-Type"))
+		body := rr.Body.String()
+		assert.Contains(t, body, `<rss version="2.0">`)
+		assert.Contains(t, body, `<guid isPermaLink="true">`+bountyLink(7)+`</guid>`)
+		assert.Contains(t, body, "<pubDate>Tue, 14 Nov 2023 22:13:20 +0000</pubDate>")
+		assert.Contains(t, body, "<title>Fix &lt;the&gt; login</title>")
+		assert.Contains(t, body, "<category>Golang</category>")
+		assert.Contains(t, body, "21,000 sats")
+	})
+
+	t.Run("Should test that the filters are passed to the query", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		filter := db.BountyFeedFilter{WorkspaceUuid: "ws", Languages: []string{"Golang", "Rust"}, MinPrice: 5000}
+		mockDb.On("GetWorkspaceByUuid", "ws").Return(db.Workspace{ID: 1, Uuid: "ws", Name: "Stakwork"})
+		mockDb.On("GetBountyFeed", filter, bountyFeedLimit).Return([]db.NewBounty{})
+
+		rr := getFeed(bHandler, "/gobounties/feed.rss?workspace=ws&languages=Golang,%20Rust&min_price=5000")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "<title>Stakwork bounties</title>")
+	})
+
+	t.Run("Should test that an unknown workspace is not found", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetWorkspaceByUuid", "nope").Return(db.Workspace{})
+
+		rr := getFeed(bHandler, "/gobounties/feed.rss?workspace=nope")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Should test that a min price that is not a number is rejected", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+
+		rr := getFeed(bHandler, "/gobounties/feed.rss?min_price=lots")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
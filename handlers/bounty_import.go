@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/lib/pq"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+)
+
+const (
+	maxImportRows  = 500
+	maxImportBytes = 2 << 20
+)
+
+// importDate reads the RFC 3339 times and the plain dates of an import
+func importDate(value string) (*time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid date %q, use YYYY-MM-DD or RFC 3339", value)
+}
+
+// parseCsvImport reads bounty rows from a CSV with a header row. Coding languages are separated
+// by semicolons, columns that are not bounty fields are ignored
+func parseCsvImport(body []byte) ([]db.BountyImportRow, []db.BountyImportError, error) {
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, errors.New("the CSV has no header row")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, nil, errors.New("the CSV has no title column")
+	}
+
+	rows := []db.BountyImportRow{}
+	rowErrors := []db.BountyImportError{}
+	for n, record := range records[1:] {
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := db.BountyImportRow{
+			Title:       field("title"),
+			Description: field("description"),
+			Type:        field("type"),
+			TicketUrl:   field("ticket_url"),
+			StaleAfter:  field("stale_after"),
+		}
+		if price := field("price"); price != "" {
+			value, err := strconv.ParseUint(price, 10, 32)
+			if err != nil {
+				rowErrors = append(rowErrors, db.BountyImportError{Row: n + 1, Message: fmt.Sprintf("invalid price %q", price)})
+			}
+			row.Price = uint(value)
+		}
+		if languages := field("coding_languages"); languages != "" {
+			row.CodingLanguages = strings.Split(languages, ";")
+		}
+		if deadline := field("deadline"); deadline != "" {
+			t, err := importDate(deadline)
+			if err != nil {
+				rowErrors = append(rowErrors, db.BountyImportError{Row: n + 1, Message: err.Error()})
+			}
+			row.Deadline = t
+		}
+		rows = append(rows, row)
+	}
+	return rows, rowErrors, nil
+}
+
+// importRowErrors checks a row the way CreateOrEditBounty checks a bounty
+func importRowErrors(n int, row db.BountyImportRow) []db.BountyImportError {
+	rowErrors := []db.BountyImportError{}
+	add := func(message string) {
+		rowErrors = append(rowErrors, db.BountyImportError{Row: n, Message: message})
+	}
+
+	if strings.TrimSpace(row.Title) == "" {
+		add("title is required")
+	}
+	if strings.TrimSpace(row.Description) == "" {
+		add("description is required")
+	}
+	if !validStaleAfter(strings.TrimSpace(row.StaleAfter)) {
+		add("stale_after must be a duration like 72h")
+	}
+	return rowErrors
+}
+
+// ImportWorkspaceBounties creates the bounties of a CSV or JSON file in a workspace. Every row is
+// checked first, the bounties are only created when all rows are valid and not with ?dry_run=true
+func (h *bountyHandler) ImportWorkspaceBounties(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty import] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "workspace_uuid")
+	workspace := h.db.GetWorkspaceByUuid(uuid)
+	if workspace.ID == 0 || workspace.Deleted {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+	if rejectArchivedWorkspace(w, uuid) {
+		return
+	}
+	if !h.userHasManageBountyRoles(pubKeyFromAuth, uuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have appropriate permissions to create bounties in this workspace")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportBytes))
+	r.Body.Close()
+	if err != nil {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Imports are limited to %d bytes", maxImportBytes))
+		return
+	}
+
+	rows := []db.BountyImportRow{}
+	report := db.BountyImportReport{
+		DryRun: r.URL.Query().Get("dry_run") == "true",
+		Errors: []db.BountyImportError{},
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" && strings.Contains(r.Header.Get("Content-Type"), "csv") {
+		format = "csv"
+	}
+	if format == "csv" {
+		rows, report.Errors, err = parseCsvImport(body)
+	} else {
+		err = json.Unmarshal(body, &rows)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Could not read the import: " + err.Error())
+		return
+	}
+
+	report.Rows = len(rows)
+	if report.Rows == 0 || report.Rows > maxImportRows {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("An import has between 1 and %d rows", maxImportRows))
+		return
+	}
+
+	// created identifies a bounty together with its owner, so each row gets its own second
+	created := time.Now().Unix()
+	now := time.Now()
+	bounties := []db.NewBounty{}
+	for i, row := range rows {
+		report.Errors = append(report.Errors, importRowErrors(i+1, row)...)
+
+		bountyType := strings.TrimSpace(row.Type)
+		if bountyType == "" {
+			bountyType = "coding"
+		}
+		languages := pq.StringArray{}
+		for _, language := range row.CodingLanguages {
+			if language = strings.TrimSpace(language); language != "" {
+				languages = append(languages, language)
+			}
+		}
+
+		bounties = append(bounties, db.NewBounty{
+			OwnerID:         pubKeyFromAuth,
+			WorkspaceUuid:   uuid,
+			Type:            bountyType,
+			Title:           strings.TrimSpace(row.Title),
+			Description:     strings.TrimSpace(row.Description),
+			Price:           row.Price,
+			PriceFiat:       db.FiatAt(row.Price),
+			CodingLanguages: languages,
+			TicketUrl:       strings.TrimSpace(row.TicketUrl),
+			Deadline:        row.Deadline,
+			StaleAfter:      strings.TrimSpace(row.StaleAfter),
+			Tribe:           "None",
+			Show:            true,
+			Created:         created + int64(i),
+			Updated:         &now,
+		})
+	}
+
+	if len(report.Errors) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(report)
+		return
+	}
+	if report.DryRun {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(report)
+		return
+	}
+
+	bounties, err = h.db.CreateBounties(bounties)
+	if err != nil {
+		log.Printf("[bounty import] could not import bounties into %s: %v", uuid, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not import the bounties, none were created")
+		return
+	}
+
+	report.Imported = len(bounties)
+	for _, bounty := range bounties {
+		publishBountyEvent(bounty, "bounty_created")
+		emitBountyEvent(events.BountyCreated, bounty)
+		h.alertSavedSearches(bounty)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
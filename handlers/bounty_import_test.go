@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImportWorkspaceBounties(t *testing.T) {
+	workspace := db.Workspace{ID: 1, Uuid: "workspace-uuid", OwnerPubKey: "owner"}
+	csvBody := "title,description,price,coding_languages,deadline,notes\n" +
+		"Fix login,Users can't log in,1000,Go;Typescript,2030-01-31,ignored\n" +
+		"Add dark mode,Follow the system theme,2500,,,\n"
+
+	importBounties := func(bHandler *bountyHandler, url string, contentType string, body string) (*httptest.ResponseRecorder, db.BountyImportReport) {
+		ro := chi.NewRouter()
+		ro.Post("/workspaces/{workspace_uuid}/bounties/import", bHandler.ImportWorkspaceBounties)
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", contentType)
+		ro.ServeHTTP(rr, req)
+
+		report := db.BountyImportReport{}
+		json.Unmarshal(rr.Body.Bytes(), &report)
+		return rr, report
+	}
+
+	t.Run("Should test that a CSV is imported in one go", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasManageBountyRoles = func(pubKeyFromAuth string, uuid string) bool { return true }
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(workspace)
+		mockDb.On("CreateBounties", mock.MatchedBy(func(bounties []db.NewBounty) bool {
+			return len(bounties) == 2 &&
+				bounties[0].Title == "Fix login" && bounties[0].Price == 1000 && len(bounties[0].CodingLanguages) == 2 && bounties[0].Deadline != nil &&
+				bounties[1].Type == "coding" && bounties[1].Created == bounties[0].Created+1 && bounties[1].WorkspaceUuid == "workspace-uuid"
+		})).Return(func(bounties []db.NewBounty) []db.NewBounty { return bounties }, nil)
+		mockDb.On("GetSavedSearchesForBounty", mock.Anything).Return([]db.SavedSearch{})
+
+		rr, report := importBounties(bHandler, "/workspaces/workspace-uuid/bounties/import", "text/csv", csvBody)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 2, report.Rows)
+		assert.Equal(t, 2, report.Imported)
+	})
+
+	t.Run("Should test that a dry run only validates the rows", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasManageBountyRoles = func(pubKeyFromAuth string, uuid string) bool { return true }
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(workspace)
+
+		rr, report := importBounties(bHandler, "/workspaces/workspace-uuid/bounties/import?dry_run=true", "application/json",
+			`[{"title":"Fix login","description":"Users can't log in","price":1000}]`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 0, report.Imported)
+	})
+
+	t.Run("Should test that every row error is reported and nothing is created", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasManageBountyRoles = func(pubKeyFromAuth string, uuid string) bool { return true }
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(workspace)
+
+		body := "title,description,price,stale_after\n" +
+			"Fix login,Users can't log in,lots,\n" +
+			",No title,100,\n" +
+			"Ok,Fine,100,a week\n"
+		rr, report := importBounties(bHandler, "/workspaces/workspace-uuid/bounties/import", "text/csv", body)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, []db.BountyImportError{
+			{Row: 1, Message: `invalid price "lots"`},
+			{Row: 2, Message: "title is required"},
+			{Row: 3, Message: "stale_after must be a duration like 72h"},
+		}, report.Errors)
+	})
+
+	t.Run("Should test that a person without the manage bounty roles can't import", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasManageBountyRoles = func(pubKeyFromAuth string, uuid string) bool { return false }
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(workspace)
+
+		rr, _ := importBounties(bHandler, "/workspaces/workspace-uuid/bounties/import", "text/csv", csvBody)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
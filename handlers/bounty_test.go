@@ -616,6 +616,7 @@ func TestGetBountyByCreated(t *testing.T) {
 		mockDb.On("GetPersonByPubkey", "owner-1").Return(db.Person{}).Once()
 		mockDb.On("GetPersonByPubkey", "user1").Return(db.Person{}).Once()
 		mockDb.On("GetWorkspaceByUuid", "work-1").Return(db.Workspace{}).Once()
+		mockDb.On("GetAttachments", db.AttachmentBounty, "1").Return([]db.Attachment{{ID: 3, Key: "attachments/0123456789abcdef0123456789abcdef.pdf", Filename: "spec.pdf"}}).Once()
 		handler.ServeHTTP(rr, req)
 
 		var returnedBounty []db.BountyResponse
@@ -623,6 +624,8 @@ func TestGetBountyByCreated(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rr.Code)
 		assert.NotEmpty(t, returnedBounty)
+		assert.Equal(t, "spec.pdf", returnedBounty[0].Attachments[0].Filename)
+		assert.True(t, strings.HasSuffix(returnedBounty[0].Attachments[0].Url, "/uploads/attachments/0123456789abcdef0123456789abcdef.pdf"))
 
 	})
 	t.Run("Should return 404 if bounty is not present in db", func(t *testing.T) {
@@ -1349,6 +1352,34 @@ func TestMakeBountyPayment(t *testing.T) {
 
 	})
 
+	t.Run("403 error when the payment exceeds a workspace spending cap", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
+
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = mockUserHasAccessTrue
+		mockDb.On("GetBounty", mock.AnythingOfType("uint")).Return(bounty, nil)
+		mockDb.On("GetWorkspaceBudget", "work-1").Return(db.NewBountyBudget{
+			TotalBudget: 5000,
+		}, nil)
+		mockDb.On("CheckSpendingCaps", "work-1", "assignee-1", uint(1000)).Return(errors.New("payment exceeds the workspace daily spending cap of 500 sats"))
+
+		r := chi.NewRouter()
+		r.Post("/gobounties/pay/{id}", bHandler.MakeBountyPayment)
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/gobounties/pay/1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code, "Expected 403 Forbidden when the payment exceeds a spending cap")
+		assert.Contains(t, rr.Body.String(), "daily spending cap")
+	})
+
 	t.Run("Should test that a successful WebSocket message is sent if the payment is successful", func(t *testing.T) {
 		mockDb.ExpectedCalls = nil
 		bHandler.getSocketConnections = mockGetSocketConnections
@@ -1356,8 +1387,12 @@ func TestMakeBountyPayment(t *testing.T) {
 
 		mockDb.On("GetBounty", bountyID).Return(bounty, nil)
 		mockDb.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb.On("CheckSpendingCaps", bounty.WorkspaceUuid, bounty.Assignee, bounty.Price).Return(nil)
 		mockDb.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1", OwnerRouteHint: "OwnerRouteHint"}, nil)
 		mockDb.On("ProcessBountyPayment", mock.AnythingOfType("db.NewPaymentHistory"), mock.AnythingOfType("db.NewBounty")).Return(nil)
+		mockDb.On("CreatePaymentAttempt", mock.MatchedBy(func(attempt db.PaymentAttempt) bool {
+			return attempt.Status == db.PaymentAttemptSucceeded && attempt.Attempt == 1
+		})).Return(db.PaymentAttempt{}, nil)
 
 		expectedUrl := fmt.Sprintf("%s/payment", config.RelayUrl)
 		expectedBody := `{"amount": 1000, "destination_key": "assignee-1", "route_hint": "OwnerRouteHint", "text": "memotext added for notification"}`
@@ -1399,7 +1434,11 @@ func TestMakeBountyPayment(t *testing.T) {
 
 		mockDb2.On("GetBounty", bountyID).Return(bounty, nil)
 		mockDb2.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb2.On("CheckSpendingCaps", bounty.WorkspaceUuid, bounty.Assignee, bounty.Price).Return(nil)
 		mockDb2.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1", OwnerRouteHint: "OwnerRouteHint"}, nil)
+		mockDb2.On("CreatePaymentAttempt", mock.MatchedBy(func(attempt db.PaymentAttempt) bool {
+			return attempt.Status == db.PaymentAttemptFailed && attempt.FailureKind == "permanent"
+		})).Return(db.PaymentAttempt{}, nil)
 
 		expectedUrl := fmt.Sprintf("%s/payment", config.RelayUrl)
 		expectedBody := `{"amount": 1000, "destination_key": "assignee-1", "route_hint": "OwnerRouteHint", "text": "memotext added for notification"}`
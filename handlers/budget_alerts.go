@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/notifications"
+)
+
+func (oh *workspaceHandler) GetWorkspaceBudgetSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "workspace_uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	hasRole := oh.userHasAccess(pubKeyFromAuth, uuid, db.ViewReport)
+	if !hasRole {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to view budget settings")
+		return
+	}
+
+	settings := oh.db.GetWorkspaceBudgetSettings(uuid)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(settings)
+}
+
+// UpdateWorkspaceBudgetSettings sets the low balance threshold and the daily spending caps, 0 turns one off
+func (oh *workspaceHandler) UpdateWorkspaceBudgetSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "workspace_uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	hasRole := oh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg)
+	if !hasRole {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to edit budget settings")
+		return
+	}
+	if rejectArchivedWorkspace(w, uuid) {
+		return
+	}
+
+	settings := db.WorkspaceBudgetSettings{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &settings)
+	if err != nil {
+		fmt.Println("[workspaces]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	settings.WorkspaceUuid = uuid
+
+	settings, err = oh.db.UpdateWorkspaceBudgetSettings(settings)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(settings)
+}
+
+// CheckLowBudgets notifies the admins of workspaces whose budget dropped below their threshold
+func CheckLowBudgets() {
+	checkLowBudgets(db.DB)
+}
+
+func checkLowBudgets(database db.Database) {
+	for _, settings := range database.GetLowBalanceAlertSettings() {
+		budget := database.GetWorkspaceBudget(settings.WorkspaceUuid)
+		isLow := budget.TotalBudget < settings.LowBalanceThreshold
+
+		// alert once when the budget drops and again only after it was topped up
+		if isLow == settings.LowBalanceAlerted {
+			continue
+		}
+		if err := database.SetLowBalanceAlerted(settings.WorkspaceUuid, isLow); err != nil {
+			fmt.Println("[budget] could not update low balance alert", settings.WorkspaceUuid, err)
+			continue
+		}
+		if !isLow {
+			continue
+		}
+
+		workspace := database.GetWorkspaceByUuid(settings.WorkspaceUuid)
+		title := fmt.Sprintf("%s budget is low", workspace.Name)
+		content := fmt.Sprintf("The budget is %d sats, below the %d sats threshold", budget.TotalBudget, settings.LowBalanceThreshold)
+		link := "/workspace/" + workspace.Uuid
+
+		for _, pubkey := range budgetAdminPubkeys(database, workspace) {
+			notifications.Notify(pubkey, db.NotificationBudgetLow, title, content, link)
+		}
+		events.Publish(events.BudgetLow, workspace.Uuid, map[string]interface{}{
+			"workspace_uuid": workspace.Uuid,
+			"workspace_name": workspace.Name,
+			"budget":         budget.TotalBudget,
+			"threshold":      settings.LowBalanceThreshold,
+		})
+	}
+}
+
+// budgetAdminPubkeys returns the workspace owner and the users allowed to add budget
+func budgetAdminPubkeys(database db.Database, workspace db.Workspace) []string {
+	pubkeys := []string{workspace.OwnerPubKey}
+
+	users, _ := database.GetWorkspaceUsers(workspace.Uuid)
+	for _, user := range users {
+		if user.OwnerPubKey == workspace.OwnerPubKey {
+			continue
+		}
+		for _, role := range database.GetUserRoles(workspace.Uuid, user.OwnerPubKey) {
+			if role.Role == db.AddBudget {
+				pubkeys = append(pubkeys, user.OwnerPubKey)
+				break
+			}
+		}
+	}
+	return pubkeys
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateWorkspaceBudgetSettings(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+
+	settingsRequest := func(body string) *httptest.ResponseRecorder {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", "workspace_uuid")
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, auth.ContextKey, "pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPut, "/workspaces/workspace_uuid/budget/settings", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.UpdateWorkspaceBudgetSettings).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a 401 is returned without the edit role", func(t *testing.T) {
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return false
+		}
+		rr := settingsRequest(`{"daily_cap": 10000}`)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that the caps and threshold are saved for the workspace", func(t *testing.T) {
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return role == db.EditOrg
+		}
+		mockDb.On("UpdateWorkspaceBudgetSettings", mock.MatchedBy(func(s db.WorkspaceBudgetSettings) bool {
+			return s.WorkspaceUuid == "workspace_uuid" && s.DailyCap == 10000 && s.PerUserDailyCap == 2000 && s.LowBalanceThreshold == 5000
+		})).Return(func(s db.WorkspaceBudgetSettings) (db.WorkspaceBudgetSettings, error) {
+			s.ID = 1
+			return s, nil
+		}).Once()
+
+		rr := settingsRequest(`{"daily_cap": 10000, "per_user_daily_cap": 2000, "low_balance_threshold": 5000}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		returned := db.WorkspaceBudgetSettings{}
+		json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.Equal(t, uint(1), returned.ID)
+		assert.Equal(t, uint(10000), returned.DailyCap)
+	})
+}
+
+func TestCheckLowBudgets(t *testing.T) {
+	t.Run("Should test that a low budget is flagged once", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockDb.On("GetLowBalanceAlertSettings").Return([]db.WorkspaceBudgetSettings{
+			{WorkspaceUuid: "low", LowBalanceThreshold: 5000},
+			{WorkspaceUuid: "already_alerted", LowBalanceThreshold: 5000, LowBalanceAlerted: true},
+		}).Once()
+		mockDb.On("GetWorkspaceBudget", "low").Return(db.NewBountyBudget{TotalBudget: 1000}).Once()
+		mockDb.On("GetWorkspaceBudget", "already_alerted").Return(db.NewBountyBudget{TotalBudget: 1000}).Once()
+		mockDb.On("SetLowBalanceAlerted", "low", true).Return(nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "low").Return(db.Workspace{Uuid: "low", Name: "Low", OwnerPubKey: "owner"}).Once()
+		mockDb.On("GetWorkspaceUsers", "low").Return([]db.WorkspaceUsersData{
+			{Person: db.Person{OwnerPubKey: "funder"}},
+			{Person: db.Person{OwnerPubKey: "member"}},
+		}, nil).Once()
+		mockDb.On("GetUserRoles", "low", "funder").Return([]db.WorkspaceUserRoles{{Role: db.AddBudget}}).Once()
+		mockDb.On("GetUserRoles", "low", "member").Return([]db.WorkspaceUserRoles{{Role: db.ViewReport}}).Once()
+
+		checkLowBudgets(mockDb)
+	})
+
+	t.Run("Should test that the alert is reset once the budget is topped up", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockDb.On("GetLowBalanceAlertSettings").Return([]db.WorkspaceBudgetSettings{
+			{WorkspaceUuid: "topped_up", LowBalanceThreshold: 5000, LowBalanceAlerted: true},
+		}).Once()
+		mockDb.On("GetWorkspaceBudget", "topped_up").Return(db.NewBountyBudget{TotalBudget: 8000}).Once()
+		mockDb.On("SetLowBalanceAlerted", "topped_up", false).Return(nil).Once()
+
+		checkLowBudgets(mockDb)
+	})
+}
+
+func TestBudgetAdminPubkeys(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner"}
+
+	mockDb.On("GetWorkspaceUsers", "workspace_uuid").Return([]db.WorkspaceUsersData{
+		{Person: db.Person{OwnerPubKey: "owner"}},
+		{Person: db.Person{OwnerPubKey: "funder"}},
+		{Person: db.Person{OwnerPubKey: "member"}},
+	}, nil).Once()
+	mockDb.On("GetUserRoles", "workspace_uuid", "funder").Return([]db.WorkspaceUserRoles{{Role: db.AddBudget}}).Once()
+	mockDb.On("GetUserRoles", "workspace_uuid", "member").Return([]db.WorkspaceUserRoles{{Role: db.ViewReport}}).Once()
+
+	assert.Equal(t, []string{"owner", "funder"}, budgetAdminPubkeys(mockDb, workspace))
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+)
+
+func bountyEventPayload(bounty db.NewBounty) map[string]interface{} {
+	return map[string]interface{}{
+		"bounty_id":      bounty.ID,
+		"bounty_link":    bountyLink(bounty.ID),
+		"title":          bounty.Title,
+		"owner_pubkey":   bounty.OwnerID,
+		"assignee":       bounty.Assignee,
+		"price":          bounty.Price,
+		"phase_uuid":     bounty.PhaseUuid,
+		"ticket_id":      bounty.TicketId,
+		"workspace_uuid": bounty.WorkspaceUuid,
+		"tribe_uuid":     bounty.Tribe,
+	}
+}
+
+func paymentAttemptPayload(attempt db.PaymentAttempt) map[string]interface{} {
+	return map[string]interface{}{
+		"payment_attempt_id": attempt.ID,
+		"bounty_id":          attempt.BountyId,
+		"bounty_link":        bountyLink(attempt.BountyId),
+		"workspace_uuid":     attempt.WorkspaceUuid,
+		"sender_pubkey":      attempt.SenderPubKey,
+		"receiver_pubkey":    attempt.ReceiverPubKey,
+		"amount":             attempt.Amount,
+		"attempt":            attempt.Attempt,
+		"status":             string(attempt.Status),
+		"failure_kind":       attempt.FailureKind,
+		"error":              attempt.Error,
+		"next_retry":         attempt.NextRetry,
+		"created":            attempt.Created,
+	}
+}
+
+// emitBountyEvent publishes an event of a bounty on the internal bus
+func emitBountyEvent(name string, bounty db.NewBounty) {
+	events.Publish(name, bounty.WorkspaceUuid, bountyEventPayload(bounty))
+}
+
+// emitTicketCompleted publishes the completion of a ticket, the workspace is the one of its
+// bounty when it has one, else the one the ticket names, else the subscribers find it from
+// the phase of the ticket
+func emitTicketCompleted(key string, ticket map[string]interface{}, workspaceUuid string) {
+	pubkey, created, _ := parseTicketKey(key)
+	payload := map[string]interface{}{
+		"ticket_id":    key,
+		"ticket_link":  ticketLink(pubkey, created),
+		"title":        ticketString(ticket, "title"),
+		"owner_pubkey": pubkey,
+		"phase_uuid":   ticketString(ticket, "phase_uuid"),
+	}
+	if bountyId, ok := ticket["bounty_id"]; ok {
+		payload["bounty_id"] = bountyId
+	}
+	if workspaceUuid == "" {
+		workspaceUuid = ticketString(ticket, "workspace_uuid")
+	}
+	events.Publish(events.TicketCompleted, workspaceUuid, payload)
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const (
+	ChallengeHeader      = "X-Challenge"
+	ChallengeNonceHeader = "X-Challenge-Nonce"
+	CaptchaTokenHeader   = "X-Captcha-Token"
+
+	hCaptchaVerifyUrl = "https://api.hcaptcha.com/siteverify"
+	challengePowTtl   = 5 * time.Minute
+)
+
+var errChallengeUsed = errors.New("the challenge was already used")
+
+// ChallengeVerifier checks the answer a request carries to the challenge it was given
+type ChallengeVerifier interface {
+	Provider() string
+	Verify(r *http.Request) error
+}
+
+// claimPowChallenge remembers a solved challenge so its solution can't be replayed, it is false
+// when the challenge was already claimed
+func claimPowChallenge(challenge string) bool {
+	if _, err := db.Store.GetChallengeCache("pow:" + challenge); err == nil {
+		return false
+	}
+	db.Store.SetChallengeCache("pow:"+challenge, "used")
+	return true
+}
+
+type powVerifier struct {
+	difficulty int
+}
+
+func (v powVerifier) Provider() string {
+	return "pow"
+}
+
+func (v powVerifier) Verify(r *http.Request) error {
+	challenge := r.Header.Get(ChallengeHeader)
+	if err := auth.VerifyPow(challenge, r.Header.Get(ChallengeNonceHeader), v.difficulty, time.Now()); err != nil {
+		return err
+	}
+	if !claimPowChallenge(challenge) {
+		return errChallengeUsed
+	}
+	return nil
+}
+
+type hCaptchaVerifier struct {
+	httpClient HttpClient
+	verifyUrl  string
+	secret     string
+}
+
+func (v hCaptchaVerifier) Provider() string {
+	return "hcaptcha"
+}
+
+func (v hCaptchaVerifier) Verify(r *http.Request) error {
+	token := r.Header.Get(CaptchaTokenHeader)
+	if token == "" {
+		return errors.New("no captcha token")
+	}
+
+	form := url.Values{}
+	form.Set("secret", v.secret)
+	form.Set("response", token)
+	form.Set("remoteip", clientIp(r))
+	req, err := http.NewRequest(http.MethodPost, v.verifyUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not verify the captcha: %w", err)
+	}
+	defer res.Body.Close()
+
+	result := struct {
+		Success bool `json:"success"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return fmt.Errorf("could not verify the captcha: %w", err)
+	}
+	if !result.Success {
+		return errors.New("the captcha was not solved")
+	}
+	return nil
+}
+
+type challengeHandler struct {
+	db              db.Database
+	verifier        ChallengeVerifier
+	routes          []string
+	bypassAge       time.Duration
+	pubkeyFromToken func(token string, fingerprint string) (string, error)
+}
+
+// NewChallengeHandler builds the challenge gate from the config, the gate lets everything through
+// when no provider is set
+func NewChallengeHandler(httpClient HttpClient, database db.Database) *challengeHandler {
+	var verifier ChallengeVerifier
+	switch config.ChallengeProvider {
+	case "pow":
+		difficulty, err := strconv.Atoi(config.ChallengePowDifficulty)
+		if err != nil || difficulty <= 0 {
+			difficulty = 20
+		}
+		verifier = powVerifier{difficulty: difficulty}
+	case "hcaptcha":
+		verifier = hCaptchaVerifier{httpClient: httpClient, verifyUrl: hCaptchaVerifyUrl, secret: config.HCaptchaSecret}
+	case "":
+	default:
+		fmt.Println("[challenge] unknown CHALLENGE_PROVIDER, the challenge gate is off:", config.ChallengeProvider)
+	}
+
+	bypassAge, err := time.ParseDuration(config.ChallengeBypassAge)
+	if err != nil {
+		bypassAge = 7 * 24 * time.Hour
+	}
+
+	routes := []string{}
+	for _, route := range strings.Split(config.ChallengeRoutes, ",") {
+		if route = strings.TrimSpace(route); route != "" {
+			routes = append(routes, route)
+		}
+	}
+
+	return &challengeHandler{
+		db:              database,
+		verifier:        verifier,
+		routes:          routes,
+		bypassAge:       bypassAge,
+		pubkeyFromToken: auth.PubkeyFromToken,
+	}
+}
+
+func (ch *challengeHandler) gated(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	for _, route := range ch.routes {
+		if prefix := strings.TrimSuffix(route, "*"); prefix != route {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				return true
+			}
+		} else if r.URL.Path == route {
+			return true
+		}
+	}
+	return false
+}
+
+// inGoodStanding is true for a request signed by a pubkey whose account is older than the bypass age
+func (ch *challengeHandler) inGoodStanding(r *http.Request) bool {
+	token := r.Header.Get("x-jwt")
+	if token == "" {
+		return false
+	}
+	pubkey, err := ch.pubkeyFromToken(token, auth.ClientFingerprint(r))
+	if err != nil {
+		return false
+	}
+	person := ch.db.GetPersonByPubkey(pubkey)
+	if person.ID == 0 || person.Created == nil {
+		return false
+	}
+	return time.Since(*person.Created) >= ch.bypassAge
+}
+
+// Challenge makes unauthenticated writes to the configured routes answer a challenge first
+func (ch *challengeHandler) Challenge(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ch.verifier == nil || !ch.gated(r) || ch.inGoodStanding(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := ch.verifier.Verify(r); err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error":    err.Error(),
+				"provider": ch.verifier.Provider(),
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// GetChallenge tells a client what it has to answer before a gated request
+func (ch *challengeHandler) GetChallenge(w http.ResponseWriter, r *http.Request) {
+	switch verifier := ch.verifier.(type) {
+	case powVerifier:
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"provider":  verifier.Provider(),
+			"challenge": auth.NewPowChallenge(verifier.difficulty, challengePowTtl),
+		})
+	case hCaptchaVerifier:
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{
+			"provider": verifier.Provider(),
+			"site_key": config.HCaptchaSiteKey,
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("No challenge is needed")
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChallengeGate(t *testing.T) {
+	passed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	serve := func(ch *challengeHandler, req *http.Request) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		ch.Challenge(passed).ServeHTTP(rr, req)
+		return rr
+	}
+	solve := func(challenge auth.PowChallenge) string {
+		for i := 0; ; i++ {
+			hash := sha256.Sum256([]byte(challenge.Challenge + strconv.Itoa(i)))
+			if auth.PowZeroBits(hash[:]) >= challenge.Difficulty {
+				return strconv.Itoa(i)
+			}
+		}
+	}
+
+	t.Run("Should test that reads and routes that are not listed are not gated", func(t *testing.T) {
+		ch := &challengeHandler{verifier: powVerifier{difficulty: 4}, routes: []string{"/feed/download", "/workspaces/*"}}
+
+		assert.Equal(t, http.StatusOK, serve(ch, httptest.NewRequest(http.MethodGet, "/feed/download", nil)).Code)
+		assert.Equal(t, http.StatusOK, serve(ch, httptest.NewRequest(http.MethodPost, "/tribes", nil)).Code)
+		assert.Equal(t, http.StatusForbidden, serve(ch, httptest.NewRequest(http.MethodPost, "/feed/download", nil)).Code)
+		assert.Equal(t, http.StatusForbidden, serve(ch, httptest.NewRequest(http.MethodPost, "/workspaces/create", nil)).Code)
+	})
+
+	t.Run("Should test that a solved proof of work passes once", func(t *testing.T) {
+		db.InitCache()
+		ch := &challengeHandler{verifier: powVerifier{difficulty: 4}, routes: []string{"/feed/download"}}
+
+		rr := httptest.NewRecorder()
+		ch.GetChallenge(rr, httptest.NewRequest(http.MethodGet, "/challenge", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		response := struct {
+			Provider  string            `json:"provider"`
+			Challenge auth.PowChallenge `json:"challenge"`
+		}{}
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "pow", response.Provider)
+
+		req := func() *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/feed/download", nil)
+			req.Header.Set(ChallengeHeader, response.Challenge.Challenge)
+			req.Header.Set(ChallengeNonceHeader, solve(response.Challenge))
+			return req
+		}
+		assert.Equal(t, http.StatusOK, serve(ch, req()).Code)
+		rr = serve(ch, req())
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"provider":"pow"`)
+	})
+
+	t.Run("Should test that pubkeys in good standing skip the challenge", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		ch := &challengeHandler{
+			db:        mockDb,
+			verifier:  powVerifier{difficulty: 4},
+			routes:    []string{"/invoices"},
+			bypassAge: 24 * time.Hour,
+			pubkeyFromToken: func(token string, fingerprint string) (string, error) {
+				if token == "valid" {
+					return "pubkey", nil
+				}
+				return "", errors.New("invalid token")
+			},
+		}
+		old := time.Now().Add(-48 * time.Hour)
+		recent := time.Now().Add(-time.Hour)
+
+		req := func(token string) *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/invoices", nil)
+			req.Header.Set("x-jwt", token)
+			return req
+		}
+
+		mockDb.On("GetPersonByPubkey", "pubkey").Return(db.Person{ID: 1, Created: &old}).Once()
+		assert.Equal(t, http.StatusOK, serve(ch, req("valid")).Code)
+
+		mockDb.On("GetPersonByPubkey", "pubkey").Return(db.Person{ID: 1, Created: &recent}).Once()
+		assert.Equal(t, http.StatusForbidden, serve(ch, req("valid")).Code)
+
+		assert.Equal(t, http.StatusForbidden, serve(ch, req("forged")).Code)
+	})
+
+	t.Run("Should test that an hCaptcha token is checked with the verify endpoint", func(t *testing.T) {
+		mockHttpClient := mocks.NewHttpClient(t)
+		ch := &challengeHandler{
+			verifier: hCaptchaVerifier{httpClient: mockHttpClient, verifyUrl: "https://hcaptcha.test/siteverify", secret: "secret"},
+			routes:   []string{"/feed/download"},
+		}
+		reply := func(success bool) *http.Response {
+			body := `{"success": ` + strconv.FormatBool(success) + `}`
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+		}
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			body, _ := io.ReadAll(req.Body)
+			return strings.Contains(string(body), "response=solved") && strings.Contains(string(body), "secret=secret")
+		})).Return(reply(true), nil).Once()
+		mockHttpClient.On("Do", mock.Anything).Return(reply(false), nil).Once()
+
+		req := func(token string) *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/feed/download", nil)
+			req.Header.Set(CaptchaTokenHeader, token)
+			return req
+		}
+		assert.Equal(t, http.StatusForbidden, serve(ch, req("")).Code)
+		assert.Equal(t, http.StatusOK, serve(ch, req("solved")).Code)
+		assert.Equal(t, http.StatusForbidden, serve(ch, req("wrong")).Code)
+	})
+
+	t.Run("Should test that the gate is off without a provider", func(t *testing.T) {
+		ch := &challengeHandler{routes: []string{"/feed/download"}}
+
+		assert.Equal(t, http.StatusOK, serve(ch, httptest.NewRequest(http.MethodPost, "/feed/download", nil)).Code)
+		rr := httptest.NewRecorder()
+		ch.GetChallenge(rr, httptest.NewRequest(http.MethodGet, "/challenge", nil))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
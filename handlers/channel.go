@@ -2,16 +2,48 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi"
+	"github.com/lib/pq"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
 )
 
+type UpdateChannelRequest struct {
+	Name           string                   `json:"name"`
+	Archived       *bool                    `json:"archived"`
+	PostPermission db.ChannelPostPermission `json:"post_permission"`
+	PostPubkeys    []string                 `json:"post_pubkeys"`
+}
+
+type ReorderChannelsRequest struct {
+	TribeUUID  string `json:"tribe_uuid"`
+	ChannelIds []uint `json:"channel_ids"`
+}
+
+// channelPostPermission checks who can post in a channel, everyone when it isn't set. The
+// pubkeys are only kept for a channel limited to them
+func channelPostPermission(permission db.ChannelPostPermission, pubkeys []string) (db.ChannelPostPermission, pq.StringArray, error) {
+	switch permission {
+	case "", db.ChannelPostEveryone:
+		return db.ChannelPostEveryone, pq.StringArray{}, nil
+	case db.ChannelPostAdmins:
+		return permission, pq.StringArray{}, nil
+	case db.ChannelPostPubkeys:
+		if len(pubkeys) == 0 {
+			return "", nil, errors.New("list the pubkeys that can post in the channel")
+		}
+		return permission, pq.StringArray(pubkeys), nil
+	}
+	return "", nil, fmt.Errorf("unknown post permission %s", permission)
+}
+
 type channelHandler struct {
 	db db.Database
 }
@@ -93,6 +125,16 @@ func (ch *channelHandler) CreateChannel(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	// new channels go after the existing ones
+	channel.Position = len(tribeChannels)
+	channel.Archived = false
+	channel.PostPermission, channel.PostPubkeys, err = channelPostPermission(channel.PostPermission, channel.PostPubkeys)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
 	channel, err = ch.db.CreateChannel(channel)
 	if err != nil {
 		fmt.Println(err)
@@ -103,3 +145,151 @@ func (ch *channelHandler) CreateChannel(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(channel)
 }
+
+// ownedChannelFromUrl returns the channel of the {id} param when the user owns its tribe
+func (ch *channelHandler) ownedChannelFromUrl(w http.ResponseWriter, r *http.Request) (db.Channel, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id <= 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return db.Channel{}, false
+	}
+
+	channel := ch.db.GetChannel(uint(id))
+	if channel.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return db.Channel{}, false
+	}
+	if pubKeyFromAuth == "" || ch.db.GetTribe(channel.TribeUUID).OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		return db.Channel{}, false
+	}
+	return channel, true
+}
+
+// UpdateChannel renames a channel, sets who can post in it, or archives it to hide it from the
+// tribe while keeping its history, and brings an archived one back
+func (ch *channelHandler) UpdateChannel(w http.ResponseWriter, r *http.Request) {
+	channel, ok := ch.ownedChannelFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	request := UpdateChannelRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if request.Name != "" && request.Name != channel.Name {
+		tribeChannels := append(ch.db.GetChannelsByTribe(channel.TribeUUID), ch.db.GetArchivedChannelsByTribe(channel.TribeUUID)...)
+		for _, tribeChannel := range tribeChannels {
+			if tribeChannel.Name == request.Name {
+				w.WriteHeader(http.StatusNotAcceptable)
+				json.NewEncoder(w).Encode("Channel name already in use")
+				return
+			}
+		}
+		updates["name"] = request.Name
+		channel.Name = request.Name
+	}
+	if request.PostPermission != "" {
+		permission, pubkeys, err := channelPostPermission(request.PostPermission, request.PostPubkeys)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(err.Error())
+			return
+		}
+		updates["post_permission"] = permission
+		updates["post_pubkeys"] = pubkeys
+		channel.PostPermission = permission
+		channel.PostPubkeys = pubkeys
+	}
+	if request.Archived != nil && *request.Archived != channel.Archived {
+		updates["archived"] = *request.Archived
+		channel.Archived = *request.Archived
+		if !channel.Archived {
+			// an unarchived channel goes back at the end
+			channel.Position = len(ch.db.GetChannelsByTribe(channel.TribeUUID))
+			updates["position"] = channel.Position
+		}
+	}
+
+	if len(updates) > 0 {
+		now := time.Now()
+		updates["updated"] = &now
+		channel.Updated = &now
+		ch.db.UpdateChannel(channel.ID, updates)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(channel)
+}
+
+// ReorderChannels puts the channels of a tribe in the given order, the channels left out keep
+// their order after the given ones
+func (ch *channelHandler) ReorderChannels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	request := ReorderChannelsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	tribe := ch.db.GetTribe(request.TribeUUID)
+	if pubKeyFromAuth == "" || tribe.UUID == "" || tribe.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	channels := ch.db.GetChannelsByTribe(tribe.UUID)
+	inTribe := map[uint]bool{}
+	for _, channel := range channels {
+		inTribe[channel.ID] = true
+	}
+	seen := map[uint]bool{}
+	order := []uint{}
+	for _, id := range request.ChannelIds {
+		if !inTribe[id] || seen[id] {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(fmt.Sprintf("Channel %d is not a channel of the tribe or is listed twice", id))
+			return
+		}
+		seen[id] = true
+		order = append(order, id)
+	}
+	for _, channel := range channels {
+		if !seen[channel.ID] {
+			order = append(order, channel.ID)
+		}
+	}
+
+	if err := ch.db.ReorderChannels(tribe.UUID, order); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not reorder the channels")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ch.db.GetChannelsByTribe(tribe.UUID))
+}
+
+// GetArchivedChannels lists the archived channels of a tribe for its owner
+func (ch *channelHandler) GetArchivedChannels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	tribe := ch.db.GetTribe(chi.URLParam(r, "tribe_uuid"))
+	if pubKeyFromAuth == "" || tribe.UUID == "" || tribe.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ch.db.GetArchivedChannelsByTribe(tribe.UUID))
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/go-chi/chi"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestCreateChannel(t *testing.T) {
@@ -212,3 +214,96 @@ func TestDeleteChannel(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, rr.Code)
 	})
 }
+
+func TestUpdateChannel(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	cHandler := NewChannelHandler(mockDb)
+	tribe := db.Tribe{UUID: "tribe", OwnerPubKey: "owner"}
+
+	update := func(body string, pubkey string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Put("/channel/{id}", cHandler.UpdateChannel)
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPut, "/channel/1", bytes.NewBufferString(body))
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that only the tribe owner can update a channel", func(t *testing.T) {
+		mockDb.On("GetChannel", uint(1)).Return(db.Channel{ID: 1, TribeUUID: "tribe", Name: "general"}).Once()
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		rr := update(`{"name": "random"}`, "someone")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a channel can't take the name of another one", func(t *testing.T) {
+		mockDb.On("GetChannel", uint(1)).Return(db.Channel{ID: 1, TribeUUID: "tribe", Name: "general"}).Once()
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("GetChannelsByTribe", "tribe").Return([]db.Channel{{ID: 1, Name: "general"}}).Once()
+		mockDb.On("GetArchivedChannelsByTribe", "tribe").Return([]db.Channel{{ID: 2, Name: "random"}}).Once()
+		rr := update(`{"name": "random"}`, "owner")
+		assert.Equal(t, http.StatusNotAcceptable, rr.Code)
+	})
+
+	t.Run("Should test that the owner can rename and archive a channel", func(t *testing.T) {
+		mockDb.On("GetChannel", uint(1)).Return(db.Channel{ID: 1, TribeUUID: "tribe", Name: "general"}).Once()
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("GetChannelsByTribe", "tribe").Return([]db.Channel{{ID: 1, Name: "general"}}).Once()
+		mockDb.On("GetArchivedChannelsByTribe", "tribe").Return([]db.Channel{}).Once()
+		mockDb.On("UpdateChannel", uint(1), mock.MatchedBy(func(u map[string]interface{}) bool {
+			return u["name"] == "old-general" && u["archived"] == true
+		})).Return(true).Once()
+		rr := update(`{"name": "old-general", "archived": true}`, "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a channel limited to pubkeys needs them", func(t *testing.T) {
+		mockDb.On("GetChannel", uint(1)).Return(db.Channel{ID: 1, TribeUUID: "tribe", Name: "news"}).Once()
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		rr := update(`{"post_permission": "pubkeys"}`, "owner")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a channel can be made an announcement channel", func(t *testing.T) {
+		mockDb.On("GetChannel", uint(1)).Return(db.Channel{ID: 1, TribeUUID: "tribe", Name: "news"}).Once()
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("UpdateChannel", uint(1), mock.MatchedBy(func(u map[string]interface{}) bool {
+			return u["post_permission"] == db.ChannelPostAdmins
+		})).Return(true).Once()
+		rr := update(`{"post_permission": "admins"}`, "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		channel := db.Channel{}
+		json.Unmarshal(rr.Body.Bytes(), &channel)
+		assert.Equal(t, db.ChannelPostAdmins, channel.PostPermission)
+	})
+}
+
+func TestReorderChannels(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	cHandler := NewChannelHandler(mockDb)
+
+	reorder := func(body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/channel/reorder", bytes.NewBufferString(body))
+		http.HandlerFunc(cHandler.ReorderChannels).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a channel of another tribe is refused", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(db.Tribe{UUID: "tribe", OwnerPubKey: "owner"}).Once()
+		mockDb.On("GetChannelsByTribe", "tribe").Return([]db.Channel{{ID: 1}, {ID: 2}}).Once()
+		rr := reorder(`{"tribe_uuid": "tribe", "channel_ids": [3]}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the channels left out keep their order after the given ones", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(db.Tribe{UUID: "tribe", OwnerPubKey: "owner"}).Once()
+		mockDb.On("GetChannelsByTribe", "tribe").Return([]db.Channel{{ID: 1}, {ID: 2}, {ID: 3}}).Twice()
+		mockDb.On("ReorderChannels", "tribe", []uint{3, 1, 2}).Return(nil).Once()
+		rr := reorder(`{"tribe_uuid": "tribe", "channel_ids": [3]}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
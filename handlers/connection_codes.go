@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// connectionCodeBatchLimit is the most codes a batch can hold
+const connectionCodeBatchLimit = 1000
+
+// ConnectionCodeBatchRequest adds the given codes to a campaign, or Count generated codes when
+// none are given
+type ConnectionCodeBatchRequest struct {
+	Campaign  string     `json:"campaign"`
+	Codes     []string   `json:"codes"`
+	Count     int        `json:"count"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreateConnectionCodeBatch creates the codes of a campaign with a shared expiry
+func (ah *authHandler) CreateConnectionCodeBatch(w http.ResponseWriter, r *http.Request) {
+	request := ConnectionCodeBatchRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		fmt.Println("[auth]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	request.Campaign = strings.TrimSpace(request.Campaign)
+	if request.Campaign == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A campaign label is required")
+		return
+	}
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(time.Now()) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The expiry must be in the future")
+		return
+	}
+
+	codes := []string{}
+	for _, code := range request.Codes {
+		if code = strings.TrimSpace(code); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		for i := 0; i < request.Count; i++ {
+			codes = append(codes, utils.GetRandomToken(24))
+		}
+	}
+	if len(codes) == 0 || len(codes) > connectionCodeBatchLimit {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("A batch must have between 1 and %d codes", connectionCodeBatchLimit))
+		return
+	}
+
+	codeArr := []db.ConnectionCodes{}
+	for _, code := range codes {
+		codeArr = append(codeArr, db.ConnectionCodes{
+			ConnectionString: code,
+			Campaign:         request.Campaign,
+			ExpiresAt:        request.ExpiresAt,
+		})
+	}
+
+	created, err := ah.db.CreateConnectionCode(codeArr)
+	if err != nil {
+		fmt.Println("[auth] => ERR create connection code batch", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(created)
+}
+
+// GetConnectionCodeCampaigns reports how many codes of each campaign were redeemed
+func (ah *authHandler) GetConnectionCodeCampaigns(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ah.db.GetConnectionCodeCampaigns())
+}
+
+// GetCampaignConnectionCodes lists the codes of a campaign and when each was redeemed
+func (ah *authHandler) GetCampaignConnectionCodes(w http.ResponseWriter, r *http.Request) {
+	campaign := chi.URLParam(r, "campaign")
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ah.db.GetCampaignConnectionCodes(campaign))
+}
+
+const (
+	connectionCodePowTtl = 5 * time.Minute
+	defaultIpLimit       = 5
+	defaultDeviceLimit   = 1
+	defaultLimitWindow   = 24 * time.Hour
+)
+
+// clientIp is the ip of the request, the first X-Forwarded-For hop when the proxy headers are trusted
+func clientIp(r *http.Request) string {
+	if config.TrustProxyHeaders {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// connectionCodeLimits are the codes an ip and a device can get in the window, 0 is no limit
+func connectionCodeLimits() (int64, int64, time.Duration) {
+	ipLimit, err := strconv.ParseInt(config.ConnectionCodeIpLimit, 10, 64)
+	if err != nil {
+		ipLimit = defaultIpLimit
+	}
+	deviceLimit, err := strconv.ParseInt(config.ConnectionCodeDeviceLimit, 10, 64)
+	if err != nil {
+		deviceLimit = defaultDeviceLimit
+	}
+	window, err := time.ParseDuration(config.ConnectionCodeLimitWindow)
+	if err != nil || window <= 0 {
+		window = defaultLimitWindow
+	}
+	return ipLimit, deviceLimit, window
+}
+
+func connectionCodePowDifficulty() int {
+	difficulty, _ := strconv.Atoi(config.ConnectionCodePowDifficulty)
+	return difficulty
+}
+
+// checkConnectionCodePow accepts a request with a solved challenge in ?challenge= and ?nonce=,
+// each challenge once, or any request when the proof of work is off
+func (ah *authHandler) checkConnectionCodePow(w http.ResponseWriter, r *http.Request) bool {
+	difficulty := connectionCodePowDifficulty()
+	if difficulty <= 0 {
+		return true
+	}
+
+	challenge := r.URL.Query().Get("challenge")
+	if err := auth.VerifyPow(challenge, r.URL.Query().Get("nonce"), difficulty, time.Now()); err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(err.Error())
+		return false
+	}
+	if !claimPowChallenge(challenge) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("The challenge was already used")
+		return false
+	}
+	return true
+}
+
+// GetConnectionCodeChallenge gives out a proof of work challenge to solve before asking for a code
+func (ah *authHandler) GetConnectionCodeChallenge(w http.ResponseWriter, r *http.Request) {
+	difficulty := connectionCodePowDifficulty()
+	if difficulty <= 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("No proof of work is needed")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(auth.NewPowChallenge(difficulty, connectionCodePowTtl))
+}
+
+// RevokeCampaignConnectionCodes revokes the codes of a campaign that were not handed out, for
+// when the codes of a campaign leak
+func (ah *authHandler) RevokeCampaignConnectionCodes(w http.ResponseWriter, r *http.Request) {
+	campaign := chi.URLParam(r, "campaign")
+
+	revoked, err := ah.db.RevokeCampaignConnectionCodes(campaign)
+	if err != nil {
+		fmt.Println("[auth] => ERR revoke connection codes", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not revoke the codes")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]int64{"revoked": revoked})
+}
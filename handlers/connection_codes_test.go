@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestConnectionCodeCampaigns(t *testing.T) {
+	batch := func(aHandler *authHandler, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/connectioncodes/batch", bytes.NewBufferString(body))
+		http.HandlerFunc(aHandler.CreateConnectionCodeBatch).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a batch generates the codes of a campaign with its expiry", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		expires := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+		mockDb.On("CreateConnectionCode", mock.MatchedBy(func(codes []db.ConnectionCodes) bool {
+			if len(codes) != 3 || codes[0].ConnectionString == codes[1].ConnectionString {
+				return false
+			}
+			for _, code := range codes {
+				if code.Campaign != "conference" || code.ExpiresAt == nil || !code.ExpiresAt.Equal(expires) || len(code.ConnectionString) != 24 {
+					return false
+				}
+			}
+			return true
+		})).Return(func(codes []db.ConnectionCodes) ([]db.ConnectionCodes, error) {
+			return codes, nil
+		})
+
+		rr := batch(aHandler, `{"campaign": " conference ", "count": 3, "expires_at": "`+expires.Format(time.RFC3339)+`"}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		created := []db.ConnectionCodes{}
+		json.Unmarshal(rr.Body.Bytes(), &created)
+		assert.Len(t, created, 3)
+	})
+
+	t.Run("Should test that given codes are added to the campaign instead of generated ones", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("CreateConnectionCode", mock.MatchedBy(func(codes []db.ConnectionCodes) bool {
+			return len(codes) == 2 && codes[0].ConnectionString == "code1" && codes[1].ConnectionString == "code2" && codes[1].ExpiresAt == nil
+		})).Return([]db.ConnectionCodes{}, nil)
+
+		rr := batch(aHandler, `{"campaign": "flyers", "codes": ["code1", "", "code2"], "count": 50}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a batch is rejected without a campaign, with a past expiry or too many codes", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+
+		assert.Equal(t, http.StatusBadRequest, batch(aHandler, `{"count": 3}`).Code)
+		assert.Equal(t, http.StatusBadRequest, batch(aHandler, `{"campaign": "x", "count": 3, "expires_at": "2020-01-01T00:00:00Z"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, batch(aHandler, `{"campaign": "x", "count": 1001}`).Code)
+		assert.Equal(t, http.StatusBadRequest, batch(aHandler, `{"campaign": "x"}`).Code)
+		assert.Equal(t, http.StatusNotAcceptable, batch(aHandler, `not json`).Code)
+	})
+
+	t.Run("Should test that a failed insert is a bad request", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("CreateConnectionCode", mock.Anything).Return(nil, errors.New("duplicate"))
+
+		assert.Equal(t, http.StatusBadRequest, batch(aHandler, `{"campaign": "x", "codes": ["a"]}`).Code)
+	})
+
+	t.Run("Should test that a code is handed out from the requested campaign", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("CountConnectionCodeRedemptions", "192.0.2.1", "", mock.Anything).Return(int64(0), int64(0))
+		mockDb.On("GetCampaignConnectionCode", "flyers", "192.0.2.1", "").Return(db.ConnectionCodesShort{ConnectionString: "code1"})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetConnectionCode).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/connectioncodes?campaign=flyers", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"connection_string":"code1"`)
+	})
+
+	t.Run("Should test that the redemption rate of every campaign is reported", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("GetConnectionCodeCampaigns").Return([]db.ConnectionCodeCampaign{{Campaign: "flyers", Total: 4, Redeemed: 1, RedemptionRate: 0.25}})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetConnectionCodeCampaigns).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/connectioncodes/campaigns", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"redemption_rate":0.25`)
+	})
+}
+
+func TestConnectionCodeAbuse(t *testing.T) {
+	getCode := func(aHandler *authHandler, url string, device string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		if device != "" {
+			req.Header.Set("X-Device-Id", device)
+		}
+		http.HandlerFunc(aHandler.GetConnectionCode).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that an ip over its limit gets no code", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("CountConnectionCodeRedemptions", "192.0.2.1", "", mock.Anything).Return(int64(defaultIpLimit), int64(0))
+
+		assert.Equal(t, http.StatusTooManyRequests, getCode(aHandler, "/connectioncodes", "").Code)
+	})
+
+	t.Run("Should test that a device over its limit gets no code within the window", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("CountConnectionCodeRedemptions", "192.0.2.1", "phone", mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since) > defaultLimitWindow-time.Minute && time.Since(since) < defaultLimitWindow+time.Minute
+		})).Return(int64(0), int64(1))
+
+		assert.Equal(t, http.StatusTooManyRequests, getCode(aHandler, "/connectioncodes", "phone").Code)
+	})
+
+	t.Run("Should test that the code is saved with the ip and device it went to", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("CountConnectionCodeRedemptions", "192.0.2.1", "phone", mock.Anything).Return(int64(1), int64(0))
+		mockDb.On("GetCampaignConnectionCode", "", "192.0.2.1", "phone").Return(db.ConnectionCodesShort{ConnectionString: "code1"})
+
+		assert.Equal(t, http.StatusOK, getCode(aHandler, "/connectioncodes", "phone").Code)
+	})
+
+	t.Run("Should test that a solved challenge is needed once the proof of work is on, and only once", func(t *testing.T) {
+		db.InitCache()
+		config.ConnectionCodePowDifficulty = "4"
+		defer func() { config.ConnectionCodePowDifficulty = "" }()
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+
+		assert.Equal(t, http.StatusForbidden, getCode(aHandler, "/connectioncodes", "").Code)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetConnectionCodeChallenge).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/connectioncodes/challenge", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		challenge := auth.PowChallenge{}
+		json.Unmarshal(rr.Body.Bytes(), &challenge)
+		assert.Equal(t, 4, challenge.Difficulty)
+
+		nonce := ""
+		for i := 0; ; i++ {
+			hash := sha256.Sum256([]byte(challenge.Challenge + strconv.Itoa(i)))
+			if auth.PowZeroBits(hash[:]) >= challenge.Difficulty {
+				nonce = strconv.Itoa(i)
+				break
+			}
+		}
+		mockDb.On("CountConnectionCodeRedemptions", "192.0.2.1", "", mock.Anything).Return(int64(0), int64(0))
+		mockDb.On("GetCampaignConnectionCode", "", "192.0.2.1", "").Return(db.ConnectionCodesShort{ConnectionString: "code1"}).Once()
+
+		target := "/connectioncodes?challenge=" + url.QueryEscape(challenge.Challenge) + "&nonce=" + nonce
+		assert.Equal(t, http.StatusOK, getCode(aHandler, target, "").Code)
+		assert.Equal(t, http.StatusForbidden, getCode(aHandler, target, "").Code)
+	})
+
+	t.Run("Should test that the unredeemed codes of a campaign are revoked", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("RevokeCampaignConnectionCodes", "leaked").Return(int64(12), nil)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("campaign", "leaked")
+		req := httptest.NewRequest(http.MethodPost, "/connectioncodes/campaigns/leaked/revoke", nil)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.RevokeCampaignConnectionCodes).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"revoked":12`)
+	})
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// parseUpdatedSince reads the updated_since query param used by delta sync clients,
+// it accepts unix seconds or an RFC3339 timestamp
+func parseUpdatedSince(r *http.Request) (time.Time, bool, error) {
+	value := r.URL.Query().Get("updated_since")
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), true, nil
+	}
+	since, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, true, err
+	}
+	return since, true, nil
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	handlerMocks "github.com/stakwork/sphinx-tribes/handlers/mocks"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseUpdatedSince(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/tribes", nil)
+	_, isDelta, err := parseUpdatedSince(req)
+	assert.False(t, isDelta)
+	assert.NoError(t, err)
+
+	req, _ = http.NewRequest("GET", "/tribes?updated_since=1700000000", nil)
+	since, isDelta, err := parseUpdatedSince(req)
+	assert.True(t, isDelta)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1700000000), since.Unix())
+
+	req, _ = http.NewRequest("GET", "/tribes?updated_since=2023-11-14T22:13:20Z", nil)
+	since, _, err = parseUpdatedSince(req)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1700000000), since.Unix())
+
+	req, _ = http.NewRequest("GET", "/tribes?updated_since=yesterday", nil)
+	_, isDelta, err = parseUpdatedSince(req)
+	assert.True(t, isDelta)
+	assert.Error(t, err)
+}
+
+func TestDeltaSync(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	since := time.Unix(1700000000, 0)
+	deleted := time.Unix(1700000100, 0)
+
+	t.Run("Should test that tribes updated since the timestamp are returned with tombstones", func(t *testing.T) {
+		tHandler := NewTribeHandler(mockDb)
+		tribes := []db.Tribe{{UUID: "tribe_uuid", Name: "tribe"}}
+		deletions := []db.Deletion{{Kind: db.DeletionKindTribe, RecordID: "deleted_uuid", Deleted: &deleted}}
+		mockDb.On("GetTribesUpdatedSince", since, mock.Anything).Return(tribes).Once()
+		mockDb.On("GetDeletionsSince", db.DeletionKindTribe, since).Return(deletions).Once()
+
+		req, _ := http.NewRequest("GET", "/tribes?updated_since=1700000000", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetListedTribes).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		response := struct {
+			Updated   []db.Tribe    `json:"updated"`
+			Deletions []db.Deletion `json:"deletions"`
+			SyncedAt  int64         `json:"synced_at"`
+		}{}
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "tribe_uuid", response.Updated[0].UUID)
+		assert.Equal(t, "deleted_uuid", response.Deletions[0].RecordID)
+		assert.NotZero(t, response.SyncedAt)
+	})
+
+	t.Run("Should test that people updated since the timestamp are returned with tombstones", func(t *testing.T) {
+		pHandler := NewPeopleHandler(mockDb)
+		mockDb.On("GetPeopleUpdatedSince", since, mock.Anything).Return([]db.Person{{ID: 1, OwnerPubKey: "pubkey"}}).Once()
+		mockDb.On("GetDeletionsSince", db.DeletionKindPerson, since).Return([]db.Deletion{}).Once()
+
+		req, _ := http.NewRequest("GET", "/people?updated_since=1700000000", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetListedPeople).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "pubkey")
+	})
+
+	t.Run("Should test that bounty tombstones are returned", func(t *testing.T) {
+		bHandler := NewBountyHandler(handlerMocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetBountiesUpdatedSince", since, mock.Anything).Return([]db.NewBounty{}).Once()
+		mockDb.On("GetDeletionsSince", db.DeletionKindBounty, since).Return([]db.Deletion{{Kind: db.DeletionKindBounty, RecordID: "12", Deleted: &deleted}}).Once()
+
+		req, _ := http.NewRequest("GET", "/gobounties/all?updated_since=1700000000", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.GetAllBounties).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"record_id":"12"`)
+	})
+
+	t.Run("Should test that an invalid timestamp returns a 406", func(t *testing.T) {
+		tHandler := NewTribeHandler(mockDb)
+		req, _ := http.NewRequest("GET", "/tribes?updated_since=yesterday", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetListedTribes).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotAcceptable, rr.Code)
+	})
+}
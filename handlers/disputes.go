@@ -0,0 +1,438 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/lightning"
+	"github.com/stakwork/sphinx-tribes/notifications"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+func disputeParty(dispute db.BountyDispute, pubkey string) bool {
+	return pubkey == dispute.OpenedBy || pubkey == dispute.Respondent
+}
+
+// canResolveDispute is true for super admins and the workspace admins with the resolve disputes role,
+// the parties of a dispute can't resolve it themselves unless they are super admins
+func (h *bountyHandler) canResolveDispute(pubkey string, dispute db.BountyDispute) bool {
+	if auth.AdminCheck(pubkey) {
+		return true
+	}
+	if disputeParty(dispute, pubkey) || dispute.WorkspaceUuid == "" {
+		return false
+	}
+	return h.userHasAccess(pubkey, dispute.WorkspaceUuid, db.ResolveDisputes)
+}
+
+func parseDisputeStatus(status string) (db.DisputeStatus, bool) {
+	switch db.DisputeStatus(status) {
+	case "", db.DisputeOpen:
+		return db.DisputeOpen, true
+	case db.DisputeResolved, db.DisputeWithdrawn:
+		return db.DisputeStatus(status), true
+	}
+	return "", false
+}
+
+func (h *bountyHandler) getBountyFromPath(w http.ResponseWriter, r *http.Request) (db.NewBounty, bool) {
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid bounty id")
+		return db.NewBounty{}, false
+	}
+
+	bounty := h.db.GetBounty(id)
+	if bounty.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Bounty not found")
+		return db.NewBounty{}, false
+	}
+	if bounty.WorkspaceUuid == "" && bounty.OrgUuid != "" {
+		bounty.WorkspaceUuid = bounty.OrgUuid
+	}
+	return bounty, true
+}
+
+func (h *bountyHandler) getDispute(w http.ResponseWriter, r *http.Request) (db.BountyDispute, bool) {
+	dispute := h.db.GetDisputeByUuid(chi.URLParam(r, "uuid"))
+	if dispute.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Dispute not found")
+		return db.BountyDispute{}, false
+	}
+	return dispute, true
+}
+
+// OpenDispute lets the owner or the assignee of a bounty dispute it, a bounty has one open dispute at a time
+func (h *bountyHandler) OpenDispute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[disputes] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromPath(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
+
+	if pubKeyFromAuth != bounty.OwnerID && pubKeyFromAuth != bounty.Assignee {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the bounty owner or assignee can open a dispute")
+		return
+	}
+	if bounty.Assignee == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Bounty has no assignee to dispute with")
+		return
+	}
+
+	request := db.DisputeRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+	request.Reason = strings.TrimSpace(request.Reason)
+	if request.Reason == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A reason is required")
+		return
+	}
+
+	if h.db.GetOpenDispute(bounty.ID).ID != 0 {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("Bounty already has an open dispute")
+		return
+	}
+
+	respondent := bounty.Assignee
+	if pubKeyFromAuth == bounty.Assignee {
+		respondent = bounty.OwnerID
+	}
+
+	dispute, err := h.db.CreateDispute(db.BountyDispute{
+		BountyId:      bounty.ID,
+		WorkspaceUuid: bounty.WorkspaceUuid,
+		OpenedBy:      pubKeyFromAuth,
+		Respondent:    respondent,
+		Reason:        request.Reason,
+	})
+	if err != nil {
+		log.Printf("[disputes] could not open dispute: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not open dispute")
+		return
+	}
+
+	notifications.Notify(respondent, db.NotificationDisputeOpened, "A dispute was opened on your bounty", bounty.Title, bountyLink(bounty.ID))
+	if bounty.WorkspaceUuid != "" {
+		owner := h.db.GetWorkspaceByUuid(bounty.WorkspaceUuid).OwnerPubKey
+		if !disputeParty(dispute, owner) {
+			notifications.Notify(owner, db.NotificationDisputeOpened, "A bounty of your workspace is disputed", bounty.Title, bountyLink(bounty.ID))
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dispute)
+}
+
+// GetBountyDisputes lists the disputes of a bounty with their evidence, for the parties and the resolvers
+func (h *bountyHandler) GetBountyDisputes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[disputes] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	canView := pubKeyFromAuth == bounty.OwnerID || pubKeyFromAuth == bounty.Assignee
+	disputes := h.db.GetBountyDisputes(bounty.ID)
+	for _, dispute := range disputes {
+		canView = canView || disputeParty(dispute, pubKeyFromAuth)
+	}
+	if !canView && !h.canResolveDispute(pubKeyFromAuth, db.BountyDispute{WorkspaceUuid: bounty.WorkspaceUuid}) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have permission to view this bounty's disputes")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(disputes)
+}
+
+// AddDisputeEvidence attaches evidence to an open dispute, for either party
+func (h *bountyHandler) AddDisputeEvidence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[disputes] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	dispute, ok := h.getDispute(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, dispute.WorkspaceUuid) {
+		return
+	}
+	if !disputeParty(dispute, pubKeyFromAuth) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the parties of a dispute can add evidence")
+		return
+	}
+	if dispute.Status != db.DisputeOpen {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Dispute is not open")
+		return
+	}
+
+	request := db.DisputeEvidenceRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+	request.Content = strings.TrimSpace(request.Content)
+	if request.Content == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Evidence content is required")
+		return
+	}
+
+	evidence, err := h.db.AddDisputeEvidence(db.DisputeEvidence{
+		DisputeId:   dispute.ID,
+		SubmittedBy: pubKeyFromAuth,
+		Content:     request.Content,
+		Link:        strings.TrimSpace(request.Link),
+	})
+	if err != nil {
+		log.Printf("[disputes] could not add evidence: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not add evidence")
+		return
+	}
+
+	other := dispute.Respondent
+	if pubKeyFromAuth == dispute.Respondent {
+		other = dispute.OpenedBy
+	}
+	notifications.Notify(other, db.NotificationDisputeEvidence, "New evidence was added to a dispute", request.Content, bountyLink(dispute.BountyId))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(evidence)
+}
+
+// WithdrawDispute closes an open dispute without an outcome, only whoever opened it can withdraw it
+func (h *bountyHandler) WithdrawDispute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[disputes] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	dispute, ok := h.getDispute(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, dispute.WorkspaceUuid) {
+		return
+	}
+	if pubKeyFromAuth != dispute.OpenedBy {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only whoever opened the dispute can withdraw it")
+		return
+	}
+
+	dispute.Status = db.DisputeWithdrawn
+	dispute, err := h.db.CloseDispute(dispute)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	notifications.Notify(dispute.Respondent, db.NotificationDisputeResolved, "A dispute was withdrawn", dispute.Reason, bountyLink(dispute.BountyId))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dispute)
+}
+
+// ResolveDispute closes a dispute with an outcome, paying the bounty to its assignee or unassigning it when decided
+func (h *bountyHandler) ResolveDispute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[disputes] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	dispute, ok := h.getDispute(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, dispute.WorkspaceUuid) {
+		return
+	}
+	if !h.canResolveDispute(pubKeyFromAuth, dispute) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have permission to resolve this dispute")
+		return
+	}
+
+	request := db.DisputeResolutionRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if dispute.Status != db.DisputeOpen {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Dispute is not open")
+		return
+	}
+
+	bounty := h.db.GetBounty(dispute.BountyId)
+	if bounty.WorkspaceUuid == "" && bounty.OrgUuid != "" {
+		bounty.WorkspaceUuid = bounty.OrgUuid
+	}
+
+	switch request.Outcome {
+	case db.DisputePayAssignee:
+		if bounty.Paid || bounty.Assignee == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("Bounty is paid or has no assignee")
+			return
+		}
+		if h.db.GetWorkspaceBudget(bounty.WorkspaceUuid).TotalBudget < bounty.Price {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode("workspace budget is not enough to pay the amount")
+			return
+		}
+		if err := h.db.CheckSpendingCaps(bounty.WorkspaceUuid, bounty.Assignee, bounty.Price); err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(err.Error())
+			return
+		}
+
+		assignee := h.db.GetPersonByPubkey(bounty.Assignee)
+		attempt, err := h.keysendBounty(bounty, assignee, pubKeyFromAuth, 1)
+		// a scheduled retry completes the payment, otherwise the dispute stays open
+		if err != nil && attempt.NextRetry == nil {
+			w.WriteHeader(http.StatusBadGateway)
+			json.NewEncoder(w).Encode(fmt.Sprintf("Payment failed (%s), the dispute is still open", lightning.ClassifyFailure(err)))
+			return
+		}
+	case db.DisputeUnassign:
+		if bounty.Paid {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("A paid bounty can't be unassigned")
+			return
+		}
+		if bounty.Assignee != "" {
+			bounty = h.db.UpdateBountyNullColumn(bounty, "assignee")
+			bounty.Assignee = ""
+			publishBountyEvent(bounty, "bounty_updated")
+		}
+	case db.DisputeDismissed:
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Outcome must be pay_assignee, unassign or dismissed")
+		return
+	}
+
+	dispute.Status = db.DisputeResolved
+	dispute.Outcome = request.Outcome
+	dispute.Resolution = strings.TrimSpace(request.Resolution)
+	dispute.ResolvedBy = pubKeyFromAuth
+	dispute, err := h.db.CloseDispute(dispute)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	title := fmt.Sprintf("A dispute was resolved: %s", strings.ReplaceAll(string(dispute.Outcome), "_", " "))
+	for _, party := range []string{dispute.OpenedBy, dispute.Respondent} {
+		notifications.Notify(party, db.NotificationDisputeResolved, title, dispute.Resolution, bountyLink(dispute.BountyId))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dispute)
+}
+
+// GetWorkspaceDisputes is the queue of a workspace's disputes for its resolvers, ?status= defaults to open
+func (h *bountyHandler) GetWorkspaceDisputes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[disputes] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "workspace_uuid")
+	if !auth.AdminCheck(pubKeyFromAuth) && !h.userHasAccess(pubKeyFromAuth, uuid, db.ResolveDisputes) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have permission to view the disputes of this workspace")
+		return
+	}
+
+	status, ok := parseDisputeStatus(r.URL.Query().Get("status"))
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid status")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.db.GetDisputes(uuid, status))
+}
+
+// GetDisputeQueue is the queue of disputes of every workspace for the super admins
+func (h *bountyHandler) GetDisputeQueue(w http.ResponseWriter, r *http.Request) {
+	status, ok := parseDisputeStatus(r.URL.Query().Get("status"))
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid status")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.db.GetDisputes("", status))
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDisputes(t *testing.T) {
+	bounty := db.NewBounty{ID: 1, Title: "Fix the login", OwnerID: "owner", Assignee: "hunter", WorkspaceUuid: "workspace-uuid", Price: 1000}
+	dispute := db.BountyDispute{ID: 3, Uuid: "dispute-uuid", BountyId: 1, WorkspaceUuid: "workspace-uuid", OpenedBy: "hunter", Respondent: "owner", Status: db.DisputeOpen}
+
+	serve := func(pattern string, handler http.HandlerFunc, pubkey string, url string, body interface{}) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Post(pattern, handler)
+		ro.Get(pattern, handler)
+
+		method := http.MethodGet
+		var requestBody []byte
+		if body != nil {
+			method = http.MethodPost
+			requestBody, _ = json.Marshal(body)
+		}
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(requestBody))
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that the assignee can open a dispute against the owner", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetOpenDispute", uint(1)).Return(db.BountyDispute{})
+		mockDb.On("CreateDispute", mock.MatchedBy(func(d db.BountyDispute) bool {
+			return d.OpenedBy == "hunter" && d.Respondent == "owner" && d.Reason == "Work was delivered"
+		})).Return(dispute, nil)
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{OwnerPubKey: "owner"})
+
+		rr := serve("/gobounties/{id}/disputes", bHandler.OpenDispute, "hunter", "/gobounties/1/disputes", db.DisputeRequest{Reason: " Work was delivered "})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a bounty can't have two open disputes", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetOpenDispute", uint(1)).Return(dispute)
+
+		rr := serve("/gobounties/{id}/disputes", bHandler.OpenDispute, "owner", "/gobounties/1/disputes", db.DisputeRequest{Reason: "Not delivered"})
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Should test that someone else can't open a dispute", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+
+		rr := serve("/gobounties/{id}/disputes", bHandler.OpenDispute, "someone-else", "/gobounties/1/disputes", db.DisputeRequest{Reason: "Not delivered"})
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that only the parties can add evidence", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetDisputeByUuid", "dispute-uuid").Return(dispute)
+
+		rr := serve("/gobounties/disputes/{uuid}/evidence", bHandler.AddDisputeEvidence, "someone-else", "/gobounties/disputes/dispute-uuid/evidence", db.DisputeEvidenceRequest{Content: "screenshot"})
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		mockDb.On("AddDisputeEvidence", mock.MatchedBy(func(e db.DisputeEvidence) bool {
+			return e.DisputeId == 3 && e.SubmittedBy == "owner" && e.Link == "https://github.com/pr/1"
+		})).Return(db.DisputeEvidence{ID: 1}, nil)
+
+		rr = serve("/gobounties/disputes/{uuid}/evidence", bHandler.AddDisputeEvidence, "owner", "/gobounties/disputes/dispute-uuid/evidence", db.DisputeEvidenceRequest{Content: "The PR was never merged", Link: "https://github.com/pr/1"})
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a party can't resolve its own dispute", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+		mockDb.On("GetDisputeByUuid", "dispute-uuid").Return(dispute)
+
+		rr := serve("/gobounties/disputes/{uuid}/resolve", bHandler.ResolveDispute, "owner", "/gobounties/disputes/dispute-uuid/resolve", db.DisputeResolutionRequest{Outcome: db.DisputeDismissed})
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a designated admin can unassign the bounty", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return pubKeyFromAuth == "arbiter" && role == db.ResolveDisputes
+		}
+		mockDb.On("GetDisputeByUuid", "dispute-uuid").Return(dispute)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("UpdateBountyNullColumn", bounty, "assignee").Return(bounty)
+		mockDb.On("CloseDispute", mock.MatchedBy(func(d db.BountyDispute) bool {
+			return d.Status == db.DisputeResolved && d.Outcome == db.DisputeUnassign && d.ResolvedBy == "arbiter"
+		})).Return(dispute, nil)
+
+		rr := serve("/gobounties/disputes/{uuid}/resolve", bHandler.ResolveDispute, "arbiter", "/gobounties/disputes/dispute-uuid/resolve", db.DisputeResolutionRequest{Outcome: db.DisputeUnassign, Resolution: "Abandoned"})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that paying the assignee keeps the dispute open when the payment fails", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return pubKeyFromAuth == "arbiter" }
+		bHandler.lnBackend = &keysendTestBackend{err: errors.New("connection reset")}
+		mockDb.On("GetDisputeByUuid", "dispute-uuid").Return(dispute)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetWorkspaceBudget", "workspace-uuid").Return(db.NewBountyBudget{TotalBudget: 5000})
+		mockDb.On("CheckSpendingCaps", "workspace-uuid", "hunter", uint(1000)).Return(nil)
+		mockDb.On("GetPersonByPubkey", "hunter").Return(db.Person{OwnerPubKey: "hunter"})
+		mockDb.On("CreatePaymentAttempt", mock.MatchedBy(func(attempt db.PaymentAttempt) bool {
+			return attempt.Status == db.PaymentAttemptFailed && attempt.SenderPubKey == "arbiter"
+		})).Return(db.PaymentAttempt{}, nil)
+
+		rr := serve("/gobounties/disputes/{uuid}/resolve", bHandler.ResolveDispute, "arbiter", "/gobounties/disputes/dispute-uuid/resolve", db.DisputeResolutionRequest{Outcome: db.DisputePayAssignee})
+
+		assert.Equal(t, http.StatusBadGateway, rr.Code)
+		mockDb.AssertNotCalled(t, "CloseDispute", mock.Anything)
+	})
+
+	t.Run("Should test that the workspace queue lists the open disputes for resolvers", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return pubKeyFromAuth == "arbiter" }
+		mockDb.On("GetDisputes", "workspace-uuid", db.DisputeOpen).Return([]db.BountyDispute{dispute})
+
+		rr := serve("/workspaces/{workspace_uuid}/disputes", bHandler.GetWorkspaceDisputes, "arbiter", "/workspaces/workspace-uuid/disputes", nil)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		rr = serve("/workspaces/{workspace_uuid}/disputes", bHandler.GetWorkspaceDisputes, "hunter", "/workspaces/workspace-uuid/disputes", nil)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
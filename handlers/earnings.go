@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// getEarnings returns the payments received by the authenticated person, ?year= keeps a single year
+func (ph *peopleHandler) getEarnings(w http.ResponseWriter, r *http.Request) ([]db.EarningRecord, string, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[earnings] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return nil, "", false
+	}
+
+	year := r.URL.Query().Get("year")
+	if year != "" {
+		if _, err := strconv.Atoi(year); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("Invalid year")
+			return nil, "", false
+		}
+	}
+
+	records := ph.db.GetPersonEarnings(pubKeyFromAuth)
+	if year == "" {
+		return records, year, true
+	}
+
+	filtered := []db.EarningRecord{}
+	for _, record := range records {
+		if record.Created != nil && record.Created.UTC().Format("2006") == year {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered, year, true
+}
+
+// GetPersonEarnings returns the sats the authenticated person received, totalled by month and workspace
+func (ph *peopleHandler) GetPersonEarnings(w http.ResponseWriter, r *http.Request) {
+	records, _, ok := ph.getEarnings(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.SummarizeEarnings(records))
+}
+
+// ExportPersonEarnings exports every payment received with its time, fiat value at receipt and bounty,
+// as JSON or as CSV with ?format=csv
+func (ph *peopleHandler) ExportPersonEarnings(w http.ResponseWriter, r *http.Request) {
+	records, year, ok := ph.getEarnings(w, r)
+	if !ok {
+		return
+	}
+
+	filename := "earnings"
+	if year != "" {
+		filename += "-" + year
+	}
+
+	if r.URL.Query().Get("format") != "csv" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.json"`, filename))
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(records)
+		return
+	}
+
+	// one column for every currency a payment was valued in
+	currencySet := map[string]bool{}
+	for _, record := range records {
+		for currency := range record.Fiat {
+			currencySet[currency] = true
+		}
+	}
+	currencies := []string{}
+	for currency := range currencySet {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	header := []string{"received", "payment_id", "bounty_id", "bounty_title", "bounty_url", "workspace_uuid", "workspace_name", "sender_pubkey", "amount_sats"}
+	for _, currency := range currencies {
+		header = append(header, "value_"+strings.ToLower(currency))
+	}
+	writer.Write(header)
+
+	for _, record := range records {
+		received := ""
+		if record.Created != nil {
+			received = record.Created.UTC().Format(time.RFC3339)
+		}
+		bountyUrl := ""
+		if record.BountyId != 0 {
+			bountyUrl = bountyLink(record.BountyId)
+		}
+		line := []string{
+			received,
+			strconv.Itoa(int(record.PaymentId)),
+			strconv.Itoa(int(record.BountyId)),
+			record.BountyTitle,
+			bountyUrl,
+			record.WorkspaceUuid,
+			record.WorkspaceName,
+			record.SenderPubKey,
+			strconv.Itoa(int(record.Amount)),
+		}
+		for _, currency := range currencies {
+			value := ""
+			if fiat, ok := record.Fiat[currency]; ok {
+				value = strconv.FormatFloat(fiat, 'f', 2, 64)
+			}
+			line = append(line, value)
+		}
+		writer.Write(line)
+	}
+	writer.Flush()
+}
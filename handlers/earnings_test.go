@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonEarnings(t *testing.T) {
+	march := time.Date(2025, time.March, 3, 10, 0, 0, 0, time.UTC)
+	january := time.Date(2026, time.January, 5, 10, 0, 0, 0, time.UTC)
+	records := []db.EarningRecord{
+		{PaymentId: 1, BountyId: 10, BountyTitle: "Fix, the login", WorkspaceUuid: "ws-1", WorkspaceName: "Workspace", Amount: 1000, Fiat: db.FiatAmounts{"USD": 0.65}, Created: &march},
+		{PaymentId: 2, BountyId: 11, BountyTitle: "Docs", WorkspaceUuid: "ws-1", WorkspaceName: "Workspace", Amount: 2000, Created: &january},
+	}
+
+	request := func(handler http.HandlerFunc, pubkey string, url string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that earnings need an authenticated person", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ph := NewPeopleHandler(mockDb)
+
+		rr := request(ph.GetPersonEarnings, "", "/people/wallet/earnings")
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that earnings are totalled by month for the requested year", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ph := NewPeopleHandler(mockDb)
+		mockDb.On("GetPersonEarnings", "hunter").Return(records)
+
+		rr := request(ph.GetPersonEarnings, "hunter", "/people/wallet/earnings?year=2025")
+
+		earnings := db.PersonEarnings{}
+		json.Unmarshal(rr.Body.Bytes(), &earnings)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, uint(1000), earnings.TotalAmount)
+		assert.Len(t, earnings.Months, 1)
+		assert.Equal(t, "2025-03", earnings.Months[0].Month)
+	})
+
+	t.Run("Should test that an invalid year gets a 400 error", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ph := NewPeopleHandler(mockDb)
+
+		rr := request(ph.GetPersonEarnings, "hunter", "/people/wallet/earnings?year=last")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the csv export has a line per payment with its fiat value", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ph := NewPeopleHandler(mockDb)
+		mockDb.On("GetPersonEarnings", "hunter").Return(records)
+
+		rr := request(ph.ExportPersonEarnings, "hunter", "/people/wallet/earnings/export?format=csv")
+
+		lines, err := csv.NewReader(rr.Body).ReadAll()
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+		assert.Len(t, lines, 3)
+		assert.Equal(t, "value_usd", lines[0][len(lines[0])-1])
+		assert.Equal(t, []string{"2025-03-03T10:00:00Z", "1", "10", "Fix, the login"}, lines[1][:4])
+		assert.Equal(t, "0.65", lines[1][len(lines[1])-1])
+		assert.Equal(t, "", lines[2][len(lines[2])-1])
+	})
+}
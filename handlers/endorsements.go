@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"gorm.io/gorm"
+)
+
+const maxTestimonialLength = 280
+
+type endorsementHandler struct {
+	db            db.Database
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+type EndorsementRequest struct {
+	Rating      int    `json:"rating"`
+	Testimonial string `json:"testimonial"`
+}
+
+func NewEndorsementHandler(database db.Database) *endorsementHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &endorsementHandler{
+		db:            database,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+// EndorseBounty lets whoever could pay a bounty rate the hunter who completed it, once per paid bounty
+func (eh *endorsementHandler) EndorseBounty(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[endorsements] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid bounty id")
+		return
+	}
+
+	request := EndorsementRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err = json.Unmarshal(body, &request)
+	if err != nil {
+		fmt.Println("[endorsements]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if request.Rating < 1 || request.Rating > 5 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Rating must be between 1 and 5")
+		return
+	}
+	if len(request.Testimonial) > maxTestimonialLength {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Testimonial must be at most %d characters", maxTestimonialLength))
+		return
+	}
+
+	bounty := eh.db.GetBounty(uint(id))
+	if bounty.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Bounty not found")
+		return
+	}
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
+	if !bounty.Paid || bounty.Assignee == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Only paid bounties can be endorsed")
+		return
+	}
+
+	canEndorse := bounty.OwnerID == pubKeyFromAuth
+	if !canEndorse && bounty.WorkspaceUuid != "" {
+		canEndorse = eh.userHasAccess(pubKeyFromAuth, bounty.WorkspaceUuid, db.PayBounty)
+	}
+	if !canEndorse || bounty.Assignee == pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have permission to endorse this bounty")
+		return
+	}
+
+	if existing := eh.db.GetEndorsementByBountyId(bounty.ID); existing.ID != 0 {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("This bounty has already been endorsed")
+		return
+	}
+
+	endorsement, err := eh.db.CreateEndorsement(db.Endorsement{
+		BountyID:       bounty.ID,
+		WorkspaceUuid:  bounty.WorkspaceUuid,
+		EndorserPubKey: pubKeyFromAuth,
+		PersonPubKey:   bounty.Assignee,
+		Rating:         request.Rating,
+		Testimonial:    request.Testimonial,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(endorsement)
+}
+
+func (eh *endorsementHandler) GetPersonEndorsements(w http.ResponseWriter, r *http.Request) {
+	pubkey := chi.URLParam(r, "pubkey")
+
+	endorsements := eh.db.GetEndorsementsByPubkey(pubkey)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(endorsements)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEndorseBounty(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	eHandler := NewEndorsementHandler(mockDb)
+	eHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "workspace_admin" && role == db.PayBounty
+	}
+	paidBounty := db.NewBounty{ID: 1, OwnerID: "bounty_owner", Assignee: "hunter", WorkspaceUuid: "workspace_uuid", Paid: true}
+
+	endorseRequest := func(pubkey string, request EndorsementRequest) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(request)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		req, _ := http.NewRequest("POST", "/gobounties/endorse/1", bytes.NewReader(body))
+		req = req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, pubkey))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(eHandler.EndorseBounty).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a workspace admin can endorse the hunter of a paid bounty", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(paidBounty).Once()
+		mockDb.On("GetEndorsementByBountyId", uint(1)).Return(db.Endorsement{}).Once()
+		mockDb.On("CreateEndorsement", mock.MatchedBy(func(m db.Endorsement) bool {
+			return m.BountyID == 1 && m.PersonPubKey == "hunter" && m.EndorserPubKey == "workspace_admin" && m.Rating == 5
+		})).Return(db.Endorsement{ID: 1, BountyID: 1, PersonPubKey: "hunter", Rating: 5}, nil).Once()
+
+		rr := endorseRequest("workspace_admin", EndorsementRequest{Rating: 5, Testimonial: "Great work"})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a bounty can only be endorsed once", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(paidBounty).Once()
+		mockDb.On("GetEndorsementByBountyId", uint(1)).Return(db.Endorsement{ID: 1}).Once()
+
+		rr := endorseRequest("bounty_owner", EndorsementRequest{Rating: 4})
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Should test that unpaid bounties can't be endorsed", func(t *testing.T) {
+		unpaid := paidBounty
+		unpaid.Paid = false
+		mockDb.On("GetBounty", uint(1)).Return(unpaid).Once()
+
+		rr := endorseRequest("bounty_owner", EndorsementRequest{Rating: 4})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that users without pay access can't endorse", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(paidBounty).Once()
+
+		rr := endorseRequest("random_user", EndorsementRequest{Rating: 1})
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that the rating must be between 1 and 5", func(t *testing.T) {
+		rr := endorseRequest("bounty_owner", EndorsementRequest{Rating: 6})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestGetPersonEndorsements(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	eHandler := NewEndorsementHandler(mockDb)
+
+	t.Run("Should test that the endorsements of a person are returned", func(t *testing.T) {
+		endorsements := []db.Endorsement{{ID: 1, BountyID: 1, PersonPubKey: "hunter", Rating: 5}}
+		mockDb.On("GetEndorsementsByPubkey", "hunter").Return(endorsements).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("pubkey", "hunter")
+		req, _ := http.NewRequest("GET", "/person/hunter/endorsements", nil)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(eHandler.GetPersonEndorsements).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		returned := []db.Endorsement{}
+		json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.Equal(t, endorsements, returned)
+	})
+}
@@ -0,0 +1,405 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/lightning"
+	"github.com/stakwork/sphinx-tribes/notifications"
+)
+
+var errEscrowUnsupported = errors.New("escrow needs a lightning backend with hold invoices")
+
+var errEscrowExists = errors.New("bounty already has an escrow")
+
+// escrowActive is true while an escrow holds or pays out the bounty payment
+func escrowActive(status db.EscrowStatus) bool {
+	return status == db.EscrowAwaitingFunding || status == db.EscrowFunded || status == db.EscrowSettled || status == db.EscrowPaying
+}
+
+func (h *bountyHandler) canPayBounty(pubkey string, bounty db.NewBounty) bool {
+	if bounty.OwnerID == pubkey {
+		return true
+	}
+	return bounty.WorkspaceUuid != "" && h.userHasAccess(pubkey, bounty.WorkspaceUuid, db.PayBounty)
+}
+
+func (h *bountyHandler) escrowBounty(w http.ResponseWriter, r *http.Request) (string, db.NewBounty, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[escrow] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return "", db.NewBounty{}, false
+	}
+
+	bounty, ok := h.getBountyFromPath(w, r)
+	return pubKeyFromAuth, bounty, ok
+}
+
+// refreshEscrow picks up the payment of an escrow that is waiting for it
+func (h *bountyHandler) refreshEscrow(bounty db.NewBounty, escrow db.BountyEscrow) db.BountyEscrow {
+	invoicer, ok := h.lnBackend.(lightning.HoldInvoicer)
+	if !ok || escrow.Status != db.EscrowAwaitingFunding {
+		return escrow
+	}
+
+	state, err := invoicer.LookupHoldInvoice(escrow.PaymentHash)
+	if err != nil {
+		log.Printf("[escrow] could not look up hold invoice of bounty %d: %v", escrow.BountyId, err)
+		return escrow
+	}
+
+	switch state {
+	case lightning.HoldInvoiceAccepted:
+		if funded, err := h.db.UpdateEscrowStatus(escrow, db.EscrowAwaitingFunding, db.EscrowFunded); err == nil {
+			bounty.EscrowStatus = db.EscrowFunded
+			publishBountyEvent(bounty, "escrow_funded")
+			notifications.Notify(escrow.Assignee, db.NotificationPaymentReceived, "The payment of your bounty is in escrow", bounty.Title, bountyLink(bounty.ID))
+			return funded
+		}
+	case lightning.HoldInvoiceCanceled:
+		if refunded, err := h.db.UpdateEscrowStatus(escrow, db.EscrowAwaitingFunding, db.EscrowRefunded); err == nil {
+			return refunded
+		}
+	}
+	return escrow
+}
+
+// CreateBountyEscrow creates the hold invoice the workspace funds an assigned bounty with
+func (h *bountyHandler) CreateBountyEscrow(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := h.escrowBounty(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
+
+	if !h.canPayBounty(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have appropriate permissions to pay bounties")
+		return
+	}
+	if bounty.Paid || bounty.Assignee == "" || bounty.Price == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Only an assigned, priced and unpaid bounty can be escrowed")
+		return
+	}
+
+	escrow, err := h.openEscrow(bounty, pubKeyFromAuth)
+	if errors.Is(err, errEscrowExists) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("Bounty already has an escrow")
+		return
+	}
+	if errors.Is(err, errEscrowUnsupported) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("[escrow] could not open escrow of bounty %d: %v", bounty.ID, err)
+		w.WriteHeader(http.StatusBadGateway)
+		json.NewEncoder(w).Encode("Could not create the escrow")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(escrow)
+}
+
+// openEscrow creates the hold invoice the workspace funds an assigned bounty with
+func (h *bountyHandler) openEscrow(bounty db.NewBounty, createdBy string) (db.BountyEscrow, error) {
+	if escrowActive(h.db.GetBountyEscrow(bounty.ID).Status) {
+		return db.BountyEscrow{}, errEscrowExists
+	}
+
+	invoicer, ok := h.lnBackend.(lightning.HoldInvoicer)
+	if !ok {
+		return db.BountyEscrow{}, errEscrowUnsupported
+	}
+
+	preimage, paymentHash, err := lightning.NewPreimage()
+	if err != nil {
+		return db.BountyEscrow{}, err
+	}
+	invoice, err := invoicer.CreateHoldInvoice(bounty.Price, fmt.Sprintf("Escrow of bounty %d", bounty.ID), paymentHash)
+	if err != nil {
+		return db.BountyEscrow{}, fmt.Errorf("could not create the hold invoice: %w", err)
+	}
+
+	escrow, err := h.db.CreateBountyEscrow(db.BountyEscrow{
+		BountyId:       bounty.ID,
+		WorkspaceUuid:  bounty.WorkspaceUuid,
+		Assignee:       bounty.Assignee,
+		Amount:         bounty.Price,
+		PaymentRequest: invoice.PaymentRequest,
+		PaymentHash:    paymentHash,
+		Preimage:       preimage,
+		CreatedBy:      createdBy,
+	})
+	if err != nil {
+		invoicer.CancelHoldInvoice(paymentHash)
+		return db.BountyEscrow{}, fmt.Errorf("could not store the escrow: %w", err)
+	}
+
+	bounty.EscrowStatus = escrow.Status
+	publishBountyEvent(bounty, "escrow_created")
+	return escrow, nil
+}
+
+// escrowAssignment keeps the escrow in step with the assignee of a bounty when the lightning backend has
+// hold invoices. An escrow held for a previous assignee is refunded, and a bounty that opted into escrow
+// gets a new one for the new assignee. The assignment stands when this fails, the escrow can still be
+// opened or refunded on its own
+func (h *bountyHandler) escrowAssignment(bounty db.NewBounty, createdBy string) db.EscrowStatus {
+	if _, ok := h.lnBackend.(lightning.HoldInvoicer); !ok || bounty.Paid {
+		return bounty.EscrowStatus
+	}
+
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if previous := h.db.GetBountyEscrow(bounty.ID); escrowActive(previous.Status) && previous.Assignee != bounty.Assignee {
+		refunded, err := h.refundEscrow(bounty)
+		if err != nil {
+			log.Printf("[escrow] could not refund escrow of bounty %d for its previous assignee: %v", bounty.ID, err)
+			return previous.Status
+		}
+		bounty.EscrowStatus = refunded.Status
+	}
+	if !bounty.Escrow || bounty.Assignee == "" || bounty.Price == 0 {
+		return bounty.EscrowStatus
+	}
+
+	escrow, err := h.openEscrow(bounty, createdBy)
+	if err != nil {
+		log.Printf("[escrow] could not open escrow of bounty %d: %v", bounty.ID, err)
+		return bounty.EscrowStatus
+	}
+	return escrow.Status
+}
+
+// dropUnfundedEscrow cancels an escrow nobody funded so the bounty can be paid from the budget instead,
+// it reports false when the escrow holds or pays out the payment or could not be cancelled
+func (h *bountyHandler) dropUnfundedEscrow(bounty db.NewBounty) bool {
+	if bounty.EscrowStatus != db.EscrowAwaitingFunding {
+		return false
+	}
+
+	escrow := h.refreshEscrow(bounty, h.db.GetBountyEscrow(bounty.ID))
+	if escrow.Status != db.EscrowAwaitingFunding {
+		return !escrowActive(escrow.Status)
+	}
+	if _, err := h.refundEscrow(bounty); err != nil {
+		log.Printf("[escrow] could not cancel unfunded escrow of bounty %d: %v", bounty.ID, err)
+		return false
+	}
+	return true
+}
+
+// GetBountyEscrow returns the escrow of a bounty, checking with the node whether it was funded
+func (h *bountyHandler) GetBountyEscrow(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := h.escrowBounty(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.canViewBountyPayment(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have permission to view this bounty's payments")
+		return
+	}
+
+	escrow := h.db.GetBountyEscrow(bounty.ID)
+	if escrow.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Bounty has no escrow")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.refreshEscrow(bounty, escrow))
+}
+
+// releaseEscrow settles the funded escrow of an approved bounty and pays it out to the assignee. The escrow
+// is moved to paying before the keysend and keeps the payment once it went out, so releasing it again
+// retries a payout that failed without settling twice and records a payout that was sent without paying twice
+func (h *bountyHandler) releaseEscrow(bounty db.NewBounty, approver string) (db.BountyEscrow, error) {
+	escrow := h.refreshEscrow(bounty, h.db.GetBountyEscrow(bounty.ID))
+
+	if escrow.Status == db.EscrowFunded {
+		invoicer, ok := h.lnBackend.(lightning.HoldInvoicer)
+		if !ok {
+			return escrow, errEscrowUnsupported
+		}
+		if err := invoicer.SettleHoldInvoice(escrow.Preimage); err != nil {
+			return escrow, fmt.Errorf("could not settle the escrow: %w", err)
+		}
+		settled, err := h.db.UpdateEscrowStatus(escrow, db.EscrowFunded, db.EscrowSettled)
+		if err != nil {
+			return escrow, err
+		}
+		escrow = settled
+	}
+
+	if escrow.Status == db.EscrowSettled {
+		paying, err := h.db.UpdateEscrowStatus(escrow, db.EscrowSettled, db.EscrowPaying)
+		if err != nil {
+			return escrow, err
+		}
+		escrow = paying
+
+		assignee := h.db.GetPersonByPubkey(escrow.Assignee)
+		payment, err := h.lnBackend.PayKeysend(escrow.Amount, assignee.OwnerPubKey, assignee.OwnerRouteHint)
+		if err != nil {
+			kind := lightning.ClassifyFailure(err)
+			log.Printf("[escrow] payout of bounty %d failed (%s): %v", bounty.ID, kind, err)
+			// a payout that may have gone through stays paying until it is checked on the node
+			if kind == lightning.FailureUnknown {
+				return escrow, fmt.Errorf("escrow was settled but the payout may have gone through, check it on the node: %w", err)
+			}
+			if settled, err := h.db.UpdateEscrowStatus(escrow, db.EscrowPaying, db.EscrowSettled); err == nil {
+				escrow = settled
+			}
+			return escrow, fmt.Errorf("escrow was settled but the payout failed: %w", err)
+		}
+
+		recorded, err := h.db.RecordEscrowPayout(escrow, payment.PaymentHash, payment.Preimage)
+		if err != nil {
+			log.Printf("[escrow] could not keep payout %s of bounty %d: %v", payment.PaymentHash, bounty.ID, err)
+			recorded = escrow
+			recorded.PayoutHash = payment.PaymentHash
+			recorded.PayoutPreimage = payment.Preimage
+		}
+		escrow = recorded
+	} else if escrow.Status == db.EscrowPaying && escrow.PayoutHash == "" {
+		return escrow, errors.New("the payout of the escrow may have gone through, check it on the node")
+	}
+
+	if escrow.Status != db.EscrowPaying {
+		return escrow, fmt.Errorf("escrow is %s", escrow.Status)
+	}
+
+	now := time.Now()
+	paymentHistory := db.NewPaymentHistory{
+		Amount:         escrow.Amount,
+		SenderPubKey:   approver,
+		ReceiverPubKey: escrow.Assignee,
+		WorkspaceUuid:  bounty.WorkspaceUuid,
+		BountyId:       bounty.ID,
+		Created:        &now,
+		Updated:        &now,
+		Status:         true,
+		PaymentType:    db.Payment,
+		Memo:           "escrow",
+		Fiat:           db.FiatAt(escrow.Amount),
+		PaymentHash:    escrow.PayoutHash,
+		Preimage:       escrow.PayoutPreimage,
+	}
+	bounty.Paid = true
+	bounty.PaidDate = &now
+	bounty.PaidFiat = paymentHistory.Fiat
+	bounty.Completed = true
+	bounty.CompletionDate = &now
+	if err := h.db.ProcessEscrowPayment(paymentHistory, bounty, escrow); err != nil {
+		log.Printf("[escrow] could not record payout of bounty %d: %v", bounty.ID, err)
+		return escrow, err
+	}
+
+	escrow.Status = db.EscrowReleased
+	bounty.EscrowStatus = db.EscrowReleased
+	completeBountyTicket(h.db, bounty)
+	publishBountyEvent(bounty, "escrow_released")
+	emitBountyEvent(events.BountyPaid, bounty)
+	notifications.Notify(escrow.Assignee, db.NotificationPaymentReceived, fmt.Sprintf("You received %d sats", escrow.Amount), bounty.Title, bountyLink(bounty.ID))
+	recordActivity(escrow.Assignee, db.ActivityPaymentReceived, bounty.Title, bountyLink(bounty.ID), escrow.Amount)
+	return escrow, nil
+}
+
+// ReleaseBountyEscrow is the owner's approval of the work, the escrowed payment goes to the assignee
+func (h *bountyHandler) ReleaseBountyEscrow(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := h.escrowBounty(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
+	if !h.canPayBounty(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have appropriate permissions to pay bounties")
+		return
+	}
+
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	escrow, err := h.releaseEscrow(bounty, pubKeyFromAuth)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(escrow)
+}
+
+// RefundBountyEscrow is the owner's rejection of the work, the hold invoice is cancelled and the payer refunded
+func (h *bountyHandler) RefundBountyEscrow(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := h.escrowBounty(w, r)
+	if !ok {
+		return
+	}
+	if !h.canPayBounty(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have appropriate permissions to pay bounties")
+		return
+	}
+
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	escrow, err := h.refundEscrow(bounty)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(escrow)
+}
+
+func (h *bountyHandler) refundEscrow(bounty db.NewBounty) (db.BountyEscrow, error) {
+	escrow := h.db.GetBountyEscrow(bounty.ID)
+	if escrow.Status != db.EscrowAwaitingFunding && escrow.Status != db.EscrowFunded {
+		return escrow, errors.New("bounty has no escrow to refund")
+	}
+
+	invoicer, ok := h.lnBackend.(lightning.HoldInvoicer)
+	if !ok {
+		return escrow, errEscrowUnsupported
+	}
+	if err := invoicer.CancelHoldInvoice(escrow.PaymentHash); err != nil {
+		return escrow, fmt.Errorf("could not cancel the escrow: %w", err)
+	}
+
+	refunded, err := h.db.UpdateEscrowStatus(escrow, escrow.Status, db.EscrowRefunded)
+	if err != nil {
+		return escrow, err
+	}
+
+	bounty.EscrowStatus = db.EscrowRefunded
+	publishBountyEvent(bounty, "escrow_refunded")
+	return refunded, nil
+}
@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/lightning"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type holdTestBackend struct {
+	keysendTestBackend
+	state     lightning.HoldInvoiceState
+	settled   string
+	cancelled string
+}
+
+func (b *holdTestBackend) CreateHoldInvoice(amount uint, memo string, paymentHash string) (lightning.Invoice, error) {
+	return lightning.Invoice{PaymentRequest: "lnbc-hold", PaymentHash: paymentHash, Amount: amount}, nil
+}
+
+func (b *holdTestBackend) LookupHoldInvoice(paymentHash string) (lightning.HoldInvoiceState, error) {
+	return b.state, nil
+}
+
+func (b *holdTestBackend) SettleHoldInvoice(preimage string) error {
+	b.settled = preimage
+	return nil
+}
+
+func (b *holdTestBackend) CancelHoldInvoice(paymentHash string) error {
+	b.cancelled = paymentHash
+	return nil
+}
+
+func TestBountyEscrow(t *testing.T) {
+	bounty := db.NewBounty{ID: 1, Title: "Fix the login", OwnerID: "owner", Assignee: "hunter", WorkspaceUuid: "workspace-uuid", Price: 1000, Created: 1700000000}
+	escrow := db.BountyEscrow{ID: 2, BountyId: 1, Assignee: "hunter", Amount: 1000, PaymentHash: "hash", Preimage: "preimage"}
+
+	serve := func(pattern string, handler http.HandlerFunc, method string, pubkey string, url string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Method(method, pattern, handler)
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, url, nil)
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that the owner creates a hold invoice for the bounty price", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.lnBackend = &holdTestBackend{}
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetBountyEscrow", uint(1)).Return(db.BountyEscrow{})
+		mockDb.On("CreateBountyEscrow", mock.MatchedBy(func(e db.BountyEscrow) bool {
+			return e.Amount == 1000 && e.Assignee == "hunter" && e.PaymentRequest == "lnbc-hold" && len(e.Preimage) == 64
+		})).Return(db.BountyEscrow{Status: db.EscrowAwaitingFunding, PaymentRequest: "lnbc-hold"}, nil)
+
+		rr := serve("/gobounties/{id}/escrow", bHandler.CreateBountyEscrow, http.MethodPost, "owner", "/gobounties/1/escrow")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "lnbc-hold")
+		assert.NotContains(t, rr.Body.String(), "preimage")
+	})
+
+	assign := func(bHandler *bountyHandler, escrow bool) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner")
+		body := fmt.Sprintf(`{"id": 1, "type": "coding", "title": "Fix the login", "description": "It fails", "owner_id": "owner", "assignee": "hunter", "workspace_uuid": "workspace-uuid", "price": 1000, "created": 1700000000, "show": true, "escrow": %t}`, escrow)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/gobounties", strings.NewReader(body))
+		bHandler.CreateOrEditBounty(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that assigning a bounty opted into escrow opens its escrow", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.lnBackend = &holdTestBackend{}
+		unassigned := bounty
+		unassigned.Assignee = ""
+		escrowed := bounty
+		escrowed.Escrow = true
+		mockDb.On("GetBounty", uint(1)).Return(unassigned)
+		mockDb.On("CreateOrEditBounty", mock.MatchedBy(func(b db.NewBounty) bool {
+			return b.ID == 1 && b.Assignee == "hunter" && b.Escrow
+		})).Return(escrowed, nil)
+		mockDb.On("GetBountyEscrow", uint(1)).Return(db.BountyEscrow{})
+		mockDb.On("CreateBountyEscrow", mock.MatchedBy(func(e db.BountyEscrow) bool {
+			return e.BountyId == 1 && e.Assignee == "hunter" && e.Amount == 1000 && e.CreatedBy == "owner"
+		})).Return(db.BountyEscrow{Status: db.EscrowAwaitingFunding, PaymentRequest: "lnbc-hold"}, nil)
+
+		rr := assign(bHandler, true)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"escrow_status":"awaiting_funding"`)
+	})
+
+	t.Run("Should test that assigning a bounty without escrow opens none", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.lnBackend = &holdTestBackend{}
+		unassigned := bounty
+		unassigned.Assignee = ""
+		mockDb.On("GetBounty", uint(1)).Return(unassigned)
+		mockDb.On("CreateOrEditBounty", mock.MatchedBy(func(b db.NewBounty) bool {
+			return b.ID == 1 && b.Assignee == "hunter" && !b.Escrow
+		})).Return(bounty, nil)
+		mockDb.On("GetBountyEscrow", uint(1)).Return(db.BountyEscrow{})
+
+		rr := assign(bHandler, false)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), `"escrow_status"`)
+	})
+
+	t.Run("Should test that an unfunded escrow is cancelled for a budget payment", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		backend := &holdTestBackend{state: lightning.HoldInvoiceOpen}
+		bHandler.lnBackend = backend
+		awaiting := escrow
+		awaiting.Status = db.EscrowAwaitingFunding
+		unfunded := bounty
+		unfunded.EscrowStatus = db.EscrowAwaitingFunding
+		mockDb.On("GetBountyEscrow", uint(1)).Return(awaiting)
+		mockDb.On("UpdateEscrowStatus", awaiting, db.EscrowAwaitingFunding, db.EscrowRefunded).Return(db.BountyEscrow{Status: db.EscrowRefunded}, nil)
+
+		assert.True(t, bHandler.dropUnfundedEscrow(unfunded))
+		assert.Equal(t, "hash", backend.cancelled)
+	})
+
+	t.Run("Should test that a funded escrow keeps the budget from paying", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		backend := &holdTestBackend{state: lightning.HoldInvoiceAccepted}
+		bHandler.lnBackend = backend
+		awaiting := escrow
+		awaiting.Status = db.EscrowAwaitingFunding
+		unfunded := bounty
+		unfunded.EscrowStatus = db.EscrowAwaitingFunding
+		mockDb.On("GetBountyEscrow", uint(1)).Return(awaiting)
+		mockDb.On("UpdateEscrowStatus", awaiting, db.EscrowAwaitingFunding, db.EscrowFunded).Return(db.BountyEscrow{Status: db.EscrowFunded}, nil)
+
+		assert.False(t, bHandler.dropUnfundedEscrow(unfunded))
+		funded := bounty
+		funded.EscrowStatus = db.EscrowFunded
+		assert.False(t, bHandler.dropUnfundedEscrow(funded))
+		assert.Equal(t, "", backend.cancelled)
+	})
+
+	t.Run("Should test that escrow needs a backend with hold invoices", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.lnBackend = &keysendTestBackend{}
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetBountyEscrow", uint(1)).Return(db.BountyEscrow{})
+
+		rr := serve("/gobounties/{id}/escrow", bHandler.CreateBountyEscrow, http.MethodPost, "owner", "/gobounties/1/escrow")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that approval settles the escrow and pays the assignee", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		backend := &holdTestBackend{}
+		bHandler.lnBackend = backend
+
+		funded := escrow
+		funded.Status = db.EscrowFunded
+		settled := escrow
+		settled.Status = db.EscrowSettled
+		paying := escrow
+		paying.Status = db.EscrowPaying
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetBountyEscrow", uint(1)).Return(funded)
+		mockDb.On("UpdateEscrowStatus", funded, db.EscrowFunded, db.EscrowSettled).Return(settled, nil)
+		mockDb.On("UpdateEscrowStatus", settled, db.EscrowSettled, db.EscrowPaying).Return(paying, nil)
+		mockDb.On("GetPersonByPubkey", "hunter").Return(db.Person{OwnerPubKey: "hunter"})
+		mockDb.On("RecordEscrowPayout", paying, "", "").Return(paying, nil)
+		mockDb.On("ProcessEscrowPayment", mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.Amount == 1000 && p.ReceiverPubKey == "hunter" && p.PaymentType == db.Payment
+		}), mock.MatchedBy(func(b db.NewBounty) bool {
+			return b.Paid
+		}), paying).Return(nil)
+
+		rr := serve("/gobounties/{id}/escrow/release", bHandler.ReleaseBountyEscrow, http.MethodPost, "owner", "/gobounties/1/escrow/release")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "preimage", backend.settled)
+		assert.Equal(t, 1, backend.calls)
+	})
+
+	t.Run("Should test that a payout that went out is recorded without paying twice", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		backend := &holdTestBackend{}
+		bHandler.lnBackend = backend
+
+		paid := escrow
+		paid.Status = db.EscrowPaying
+		paid.PayoutHash = "payout-hash"
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetBountyEscrow", uint(1)).Return(paid)
+		mockDb.On("ProcessEscrowPayment", mock.MatchedBy(func(p db.NewPaymentHistory) bool {
+			return p.PaymentHash == "payout-hash" && p.ReceiverPubKey == "hunter"
+		}), mock.Anything, paid).Return(nil)
+
+		rr := serve("/gobounties/{id}/escrow/release", bHandler.ReleaseBountyEscrow, http.MethodPost, "owner", "/gobounties/1/escrow/release")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 0, backend.calls)
+	})
+
+	t.Run("Should test that a payout in an unknown state is not sent again", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		backend := &holdTestBackend{}
+		bHandler.lnBackend = backend
+
+		paying := escrow
+		paying.Status = db.EscrowPaying
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetBountyEscrow", uint(1)).Return(paying)
+
+		rr := serve("/gobounties/{id}/escrow/release", bHandler.ReleaseBountyEscrow, http.MethodPost, "owner", "/gobounties/1/escrow/release")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, 0, backend.calls)
+	})
+
+	t.Run("Should test that a failed payout can be released again", func(t *testing.T) {
+		settled := escrow
+		settled.Status = db.EscrowSettled
+		paying := escrow
+		paying.Status = db.EscrowPaying
+
+		for _, payout := range []struct {
+			err      error
+			reverted bool
+		}{
+			{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+			{errors.New("connection reset"), false},
+		} {
+			mockDb := mocks.NewDatabase(t)
+			bHandler := NewBountyHandler(nil, mockDb)
+			backend := &holdTestBackend{}
+			backend.err = payout.err
+			bHandler.lnBackend = backend
+			mockDb.On("GetBounty", uint(1)).Return(bounty)
+			mockDb.On("GetBountyEscrow", uint(1)).Return(settled)
+			mockDb.On("UpdateEscrowStatus", settled, db.EscrowSettled, db.EscrowPaying).Return(paying, nil)
+			mockDb.On("GetPersonByPubkey", "hunter").Return(db.Person{OwnerPubKey: "hunter"})
+			if payout.reverted {
+				mockDb.On("UpdateEscrowStatus", paying, db.EscrowPaying, db.EscrowSettled).Return(settled, nil)
+			}
+
+			rr := serve("/gobounties/{id}/escrow/release", bHandler.ReleaseBountyEscrow, http.MethodPost, "owner", "/gobounties/1/escrow/release")
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Equal(t, 1, backend.calls)
+		}
+	})
+
+	t.Run("Should test that an unfunded escrow can't be released", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		backend := &holdTestBackend{state: lightning.HoldInvoiceOpen}
+		bHandler.lnBackend = backend
+
+		waiting := escrow
+		waiting.Status = db.EscrowAwaitingFunding
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetBountyEscrow", uint(1)).Return(waiting)
+
+		rr := serve("/gobounties/{id}/escrow/release", bHandler.ReleaseBountyEscrow, http.MethodPost, "owner", "/gobounties/1/escrow/release")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, 0, backend.calls)
+	})
+
+	t.Run("Should test that rejection cancels the hold invoice", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		backend := &holdTestBackend{}
+		bHandler.lnBackend = backend
+
+		funded := escrow
+		funded.Status = db.EscrowFunded
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetBountyEscrow", uint(1)).Return(funded)
+		mockDb.On("UpdateEscrowStatus", funded, db.EscrowFunded, db.EscrowRefunded).Return(db.BountyEscrow{Status: db.EscrowRefunded}, nil)
+
+		rr := serve("/gobounties/{id}/escrow/refund", bHandler.RefundBountyEscrow, http.MethodPost, "owner", "/gobounties/1/escrow/refund")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "hash", backend.cancelled)
+	})
+
+	t.Run("Should test that the assignee can't release the escrow", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+
+		rr := serve("/gobounties/{id}/escrow/release", bHandler.ReleaseBountyEscrow, http.MethodPost, "hunter", "/gobounties/1/escrow/release")
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
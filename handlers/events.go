@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+// StreamEvents streams the websocket topic events as Server-Sent Events.
+// Topics are passed as ?topics=bounty:1,workspace:uuid and clients resume with the Last-Event-ID header
+func StreamEvents(w http.ResponseWriter, r *http.Request) {
+	pool := websocket.WebsocketPool
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Streaming is not supported")
+		return
+	}
+
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		token = r.Header.Get("x-jwt")
+	}
+	pubkey := ""
+	if token != "" {
+		pubkey, _ = auth.PubkeyFromToken(token, auth.ClientFingerprint(r))
+	}
+
+	topics := map[string]bool{}
+	for _, topic := range strings.Split(r.URL.Query().Get("topics"), ",") {
+		topic = strings.TrimSpace(topic)
+		if topic == "" {
+			continue
+		}
+		if pool.Authorize == nil || !pool.Authorize(pubkey, topic) {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode("Not authorized to subscribe to " + topic)
+			return
+		}
+		topics[topic] = true
+	}
+
+	if len(topics) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("At least one topic is required")
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	lastID, _ := strconv.ParseUint(lastEventID, 10, 64)
+
+	events, missed := pool.Events.Subscribe(lastID)
+	defer pool.Events.Unsubscribe(events)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// requests are cut by the router timeout, ask the client to reconnect quickly
+	fmt.Fprint(w, "retry: 2000\n\n")
+	for _, event := range missed {
+		if topics[event.Topic] {
+			writeServerSentEvent(w, event)
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(25 * time.Second)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case event := <-events:
+			if topics[event.Topic] {
+				writeServerSentEvent(w, event)
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+func writeServerSentEvent(w http.ResponseWriter, event websocket.Event) {
+	data, err := json.Marshal(websocket.TopicMessage{Topic: event.Topic, Msg: event.Msg, Body: event.Body})
+	if err != nil {
+		fmt.Println("[events] could not encode event", err)
+		return
+	}
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Msg, data)
+}
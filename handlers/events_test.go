@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamEvents(t *testing.T) {
+	websocket.WebsocketPool.Authorize = func(pubkey string, topic string) bool {
+		return strings.HasPrefix(topic, "bounty:")
+	}
+	defer func() { websocket.WebsocketPool.Authorize = nil }()
+
+	t.Run("Should test that a 400 error is returned when no topic is passed", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/events", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(StreamEvents)
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a 401 error is returned for an unauthorized topic", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/events?topics=workspace:uuid", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(StreamEvents)
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that events after the Last-Event-ID are replayed for the subscribed topics", func(t *testing.T) {
+		first := websocket.WebsocketPool.Events.Publish("bounty:1", "bounty_created", "first")
+		second := websocket.WebsocketPool.Events.Publish("bounty:1", "bounty_updated", "second")
+		other := websocket.WebsocketPool.Events.Publish("bounty:2", "bounty_updated", "other")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, "GET", "/events?topics=bounty:1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Last-Event-ID", fmt.Sprintf("%d", first.ID))
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(StreamEvents)
+
+		handler.ServeHTTP(rr, req)
+
+		body := rr.Body.String()
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+		assert.NotContains(t, body, fmt.Sprintf("id: %d\n", first.ID))
+		assert.Contains(t, body, fmt.Sprintf("id: %d\nevent: bounty_updated\n", second.ID))
+		assert.NotContains(t, body, fmt.Sprintf("id: %d\n", other.ID))
+	})
+}
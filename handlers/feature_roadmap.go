@@ -0,0 +1,355 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+type roadmapNode struct {
+	Type db.RoadmapItemType
+	Uuid string
+}
+
+func (n roadmapNode) String() string {
+	return fmt.Sprintf("%s:%s", n.Type, n.Uuid)
+}
+
+// roadmapGraph walks the dependencies of features and phases, loading them once per request
+type roadmapGraph struct {
+	db       db.Database
+	explicit map[roadmapNode][]roadmapNode
+	phases   map[string][]db.FeaturePhase
+	phase    map[string]db.FeaturePhase
+	ends     map[roadmapNode]int
+	visiting map[roadmapNode]bool
+}
+
+func newRoadmapGraph(database db.Database) *roadmapGraph {
+	return &roadmapGraph{
+		db:       database,
+		explicit: map[roadmapNode][]roadmapNode{},
+		phases:   map[string][]db.FeaturePhase{},
+		phase:    map[string]db.FeaturePhase{},
+		ends:     map[roadmapNode]int{},
+		visiting: map[roadmapNode]bool{},
+	}
+}
+
+func (g *roadmapGraph) explicitDeps(node roadmapNode) []roadmapNode {
+	if deps, ok := g.explicit[node]; ok {
+		return deps
+	}
+	deps := []roadmapNode{}
+	for _, d := range g.db.GetFeatureDependencies(node.Type, node.Uuid) {
+		deps = append(deps, roadmapNode{Type: d.DependsOnType, Uuid: d.DependsOnUuid})
+	}
+	g.explicit[node] = deps
+	return deps
+}
+
+func (g *roadmapGraph) featurePhases(featureUuid string) []db.FeaturePhase {
+	if phases, ok := g.phases[featureUuid]; ok {
+		return phases
+	}
+	phases := g.db.GetPhasesByFeatureUuid(featureUuid)
+	g.phases[featureUuid] = phases
+	for _, p := range phases {
+		g.phase[p.Uuid] = p
+	}
+	return phases
+}
+
+func (g *roadmapGraph) getPhase(uuid string) db.FeaturePhase {
+	if p, ok := g.phase[uuid]; ok {
+		return p
+	}
+	p, _ := g.db.GetPhaseByUuid(uuid)
+	g.phase[uuid] = p
+	return p
+}
+
+// waitsFor is what has to finish before a node starts: a phase waits for its own dependencies
+// and the ones of its feature
+func (g *roadmapGraph) waitsFor(node roadmapNode) []roadmapNode {
+	deps := append([]roadmapNode{}, g.explicitDeps(node)...)
+	if node.Type == db.RoadmapItemPhase {
+		if featureUuid := g.getPhase(node.Uuid).FeatureUuid; featureUuid != "" {
+			deps = append(deps, g.explicitDeps(roadmapNode{Type: db.RoadmapItemFeature, Uuid: featureUuid})...)
+		}
+	}
+	return deps
+}
+
+// next is every node a node's end depends on, a feature ends with its phases
+func (g *roadmapGraph) next(node roadmapNode) []roadmapNode {
+	deps := g.waitsFor(node)
+	if node.Type == db.RoadmapItemFeature {
+		for _, p := range g.featurePhases(node.Uuid) {
+			deps = append(deps, roadmapNode{Type: db.RoadmapItemPhase, Uuid: p.Uuid})
+		}
+	}
+	return deps
+}
+
+// reaches tells if a node depends on another one, directly or not
+func (g *roadmapGraph) reaches(from roadmapNode, to roadmapNode) bool {
+	seen := map[roadmapNode]bool{}
+	stack := g.next(from)
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if node == to {
+			return true
+		}
+		if seen[node] {
+			continue
+		}
+		seen[node] = true
+		stack = append(stack, g.next(node)...)
+	}
+	return false
+}
+
+// start is the earliest day a node can start, once everything it waits for ended
+func (g *roadmapGraph) start(node roadmapNode) int {
+	start := 0
+	for _, dep := range g.waitsFor(node) {
+		if end := g.end(dep); end > start {
+			start = end
+		}
+	}
+	return start
+}
+
+func (g *roadmapGraph) end(node roadmapNode) int {
+	if end, ok := g.ends[node]; ok {
+		return end
+	}
+	if g.visiting[node] {
+		return 0
+	}
+	g.visiting[node] = true
+
+	end := g.start(node)
+	if node.Type == db.RoadmapItemPhase {
+		end += g.getPhase(node.Uuid).EstimatedDays
+	} else {
+		for _, p := range g.featurePhases(node.Uuid) {
+			if e := g.end(roadmapNode{Type: db.RoadmapItemPhase, Uuid: p.Uuid}); e > end {
+				end = e
+			}
+		}
+	}
+
+	delete(g.visiting, node)
+	g.ends[node] = end
+	return end
+}
+
+func (oh *featureHandler) roadmapItemExists(itemType db.RoadmapItemType, uuid string) bool {
+	switch itemType {
+	case db.RoadmapItemFeature:
+		return uuid != "" && oh.db.GetFeatureByUuid(uuid).Uuid == uuid
+	case db.RoadmapItemPhase:
+		phase, err := oh.db.GetPhaseByUuid(uuid)
+		return err == nil && phase.Uuid == uuid
+	}
+	return false
+}
+
+// roadmapItemWorkspace is the workspace of the feature, or of the feature of the phase
+func (oh *featureHandler) roadmapItemWorkspace(itemType db.RoadmapItemType, uuid string) string {
+	if itemType == db.RoadmapItemPhase {
+		return phaseWorkspace(oh.db, uuid)
+	}
+	return featureWorkspace(oh.db, uuid)
+}
+
+// CreateFeatureDependency makes a feature or a phase wait for another one, refusing dependencies
+// that would make them wait for each other
+func (oh *featureHandler) CreateFeatureDependency(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	dependency := db.FeatureDependency{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &dependency); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid dependency")
+		return
+	}
+
+	if !oh.roadmapItemExists(dependency.ItemType, dependency.ItemUuid) || !oh.roadmapItemExists(dependency.DependsOnType, dependency.DependsOnUuid) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Dependencies are between existing features and phases")
+		return
+	}
+
+	if rejectArchivedWorkspaceOf(w, func() string { return oh.roadmapItemWorkspace(dependency.ItemType, dependency.ItemUuid) }) {
+		return
+	}
+
+	item := roadmapNode{Type: dependency.ItemType, Uuid: dependency.ItemUuid}
+	dependsOn := roadmapNode{Type: dependency.DependsOnType, Uuid: dependency.DependsOnUuid}
+	if item == dependsOn {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A feature or a phase can't depend on itself")
+		return
+	}
+
+	graph := newRoadmapGraph(oh.db)
+	for _, existing := range graph.explicitDeps(item) {
+		if existing == dependsOn {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode("The dependency already exists")
+			return
+		}
+	}
+	graph.explicit[item] = append(graph.explicit[item], dependsOn)
+	if graph.reaches(item, item) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The dependency would make them wait for each other")
+		return
+	}
+
+	dependency.ID = 0
+	dependency.CreatedBy = pubKeyFromAuth
+	dependency, err := oh.db.CreateFeatureDependency(dependency)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save dependency")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dependency)
+}
+
+// GetFeatureDependencies returns what a feature and its phases depend on
+func (oh *featureHandler) GetFeatureDependencies(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	dependencies := oh.db.GetFeatureDependencies(db.RoadmapItemFeature, uuid)
+	for _, phase := range oh.db.GetPhasesByFeatureUuid(uuid) {
+		dependencies = append(dependencies, oh.db.GetFeatureDependencies(db.RoadmapItemPhase, phase.Uuid)...)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dependencies)
+}
+
+func (oh *featureHandler) DeleteFeatureDependency(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid dependency id")
+		return
+	}
+
+	dependency := oh.db.GetFeatureDependency(id)
+	if dependency.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Dependency not found")
+		return
+	}
+	if rejectArchivedWorkspaceOf(w, func() string { return oh.roadmapItemWorkspace(dependency.ItemType, dependency.ItemUuid) }) {
+		return
+	}
+
+	if err := oh.db.DeleteFeatureDependency(dependency.ID); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete dependency")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dependency)
+}
+
+// GetFeatureRoadmap plans the phases of a feature from their estimates and dependencies, each
+// phase starting as soon as what it waits for ended, from ?start= (a date, today by default)
+func (oh *featureHandler) GetFeatureRoadmap(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	feature := oh.db.GetFeatureByUuid(uuid)
+	if feature.Uuid == "" || feature.Uuid != uuid {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Feature not found")
+		return
+	}
+
+	startDate := time.Now().UTC().Truncate(24 * time.Hour)
+	if start := r.URL.Query().Get("start"); start != "" {
+		parsed, err := time.Parse("2006-01-02", start)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("start is a date like 2024-01-31")
+			return
+		}
+		startDate = parsed
+	}
+	day := func(n int) time.Time {
+		return startDate.AddDate(0, 0, n)
+	}
+
+	graph := newRoadmapGraph(oh.db)
+	roadmap := db.FeatureRoadmap{FeatureUuid: uuid, StartDate: startDate, Phases: []db.RoadmapPhase{}}
+	for _, phase := range graph.featurePhases(uuid) {
+		node := roadmapNode{Type: db.RoadmapItemPhase, Uuid: phase.Uuid}
+		dependsOn := []string{}
+		for _, dep := range graph.waitsFor(node) {
+			dependsOn = append(dependsOn, dep.String())
+		}
+		start, end := graph.start(node), graph.end(node)
+		roadmap.Phases = append(roadmap.Phases, db.RoadmapPhase{
+			Uuid:          phase.Uuid,
+			FeatureUuid:   phase.FeatureUuid,
+			Name:          phase.Name,
+			Priority:      phase.Priority,
+			EstimatedDays: phase.EstimatedDays,
+			StartDay:      start,
+			EndDay:        end,
+			StartDate:     day(start),
+			EndDate:       day(end),
+			DependsOn:     dependsOn,
+		})
+	}
+
+	sort.SliceStable(roadmap.Phases, func(i, j int) bool {
+		a, b := roadmap.Phases[i], roadmap.Phases[j]
+		if a.StartDay != b.StartDay {
+			return a.StartDay < b.StartDay
+		}
+		if a.EndDay != b.EndDay {
+			return a.EndDay < b.EndDay
+		}
+		return a.Priority < b.Priority
+	})
+	roadmap.EndDate = day(graph.end(roadmapNode{Type: db.RoadmapItemFeature, Uuid: uuid}))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(roadmap)
+}
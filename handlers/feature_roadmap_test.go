@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockRoadmap is feature F with phases P1, P2 after P1 and P3, waiting for feature G and its phase Q
+func mockRoadmap(mockDb *mocks.Database) {
+	mockDb.On("GetFeatureByUuid", "F").Return(db.WorkspaceFeatures{Uuid: "F"}).Maybe()
+	mockDb.On("GetFeatureByUuid", "G").Return(db.WorkspaceFeatures{Uuid: "G"}).Maybe()
+	mockDb.On("GetPhasesByFeatureUuid", "F").Return([]db.FeaturePhase{
+		{Uuid: "P1", FeatureUuid: "F", Name: "Design", EstimatedDays: 3},
+		{Uuid: "P2", FeatureUuid: "F", Name: "Build", EstimatedDays: 2},
+		{Uuid: "P3", FeatureUuid: "F", Name: "Docs", EstimatedDays: 4},
+	}).Maybe()
+	mockDb.On("GetPhasesByFeatureUuid", "G").Return([]db.FeaturePhase{
+		{Uuid: "Q", FeatureUuid: "G", Name: "API", EstimatedDays: 5},
+	}).Maybe()
+	mockDb.On("GetPhaseByUuid", "P2").Return(db.FeaturePhase{Uuid: "P2", FeatureUuid: "F", EstimatedDays: 2}, nil).Maybe()
+	mockDb.On("GetFeatureDependencies", db.RoadmapItemFeature, "F").Return([]db.FeatureDependency{
+		{ItemType: db.RoadmapItemFeature, ItemUuid: "F", DependsOnType: db.RoadmapItemFeature, DependsOnUuid: "G"},
+	}).Maybe()
+	mockDb.On("GetFeatureDependencies", db.RoadmapItemPhase, "P2").Return([]db.FeatureDependency{
+		{ItemType: db.RoadmapItemPhase, ItemUuid: "P2", DependsOnType: db.RoadmapItemPhase, DependsOnUuid: "P1"},
+	}).Maybe()
+	mockDb.On("GetFeatureDependencies", mock.Anything, mock.Anything).Return([]db.FeatureDependency{}).Maybe()
+}
+
+func TestFeatureRoadmap(t *testing.T) {
+	router := func(fHandler *featureHandler) *chi.Mux {
+		router := chi.NewRouter()
+		router.Get("/features/{uuid}/roadmap", fHandler.GetFeatureRoadmap)
+		router.Post("/features/dependencies", fHandler.CreateFeatureDependency)
+		return router
+	}
+	add := func(r *chi.Mux, body interface{}) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/features/dependencies", bytes.NewReader(data))
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "planner"))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that phases start once what they wait for ended", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockRoadmap(mockDb)
+
+		rr := httptest.NewRecorder()
+		router(NewFeatureHandler(mockDb)).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/features/F/roadmap?start=2026-01-05", nil))
+
+		roadmap := db.FeatureRoadmap{}
+		json.Unmarshal(rr.Body.Bytes(), &roadmap)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, roadmap.Phases, 3)
+		assert.Equal(t, []string{"P1", "P3", "P2"}, []string{roadmap.Phases[0].Uuid, roadmap.Phases[1].Uuid, roadmap.Phases[2].Uuid})
+		assert.Equal(t, 5, roadmap.Phases[0].StartDay)
+		assert.Equal(t, 8, roadmap.Phases[0].EndDay)
+		assert.Equal(t, 8, roadmap.Phases[2].StartDay)
+		assert.Equal(t, []string{"phase:P1", "feature:G"}, roadmap.Phases[2].DependsOn)
+		assert.Equal(t, "2026-01-13", roadmap.Phases[2].StartDate.Format("2006-01-02"))
+		assert.Equal(t, "2026-01-15", roadmap.EndDate.Format("2006-01-02"))
+	})
+
+	t.Run("Should test that a roadmap needs a feature and a valid start", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockDb.On("GetFeatureByUuid", "X").Return(db.WorkspaceFeatures{}).Once()
+		mockDb.On("GetFeatureByUuid", "F").Return(db.WorkspaceFeatures{Uuid: "F"}).Once()
+		r := router(NewFeatureHandler(mockDb))
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/features/X/roadmap", nil))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/features/F/roadmap?start=soon", nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a dependency making items wait for each other is refused", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockRoadmap(mockDb)
+
+		rr := add(router(NewFeatureHandler(mockDb)), map[string]interface{}{
+			"item_type": "feature", "item_uuid": "G", "depends_on_type": "phase", "depends_on_uuid": "P2",
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a phase can't wait for its own feature", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockRoadmap(mockDb)
+
+		rr := add(router(NewFeatureHandler(mockDb)), map[string]interface{}{
+			"item_type": "phase", "item_uuid": "P2", "depends_on_type": "feature", "depends_on_uuid": "F",
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a dependency is saved once", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockRoadmap(mockDb)
+		mockDb.On("GetPhaseByUuid", "P3").Return(db.FeaturePhase{Uuid: "P3", FeatureUuid: "F"}, nil).Maybe()
+		mockDb.On("GetPhaseByUuid", "P1").Return(db.FeaturePhase{Uuid: "P1", FeatureUuid: "F"}, nil).Maybe()
+		mockDb.On("CreateFeatureDependency", mock.MatchedBy(func(d db.FeatureDependency) bool {
+			return d.ItemUuid == "P3" && d.DependsOnUuid == "P2" && d.CreatedBy == "planner"
+		})).Return(db.FeatureDependency{ID: 7}, nil).Once()
+		r := router(NewFeatureHandler(mockDb))
+
+		rr := add(r, map[string]interface{}{"item_type": "phase", "item_uuid": "P3", "depends_on_type": "phase", "depends_on_uuid": "P2"})
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		rr = add(r, map[string]interface{}{"item_type": "phase", "item_uuid": "P2", "depends_on_type": "phase", "depends_on_uuid": "P1"})
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Should test that dependencies are between existing items", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockDb.On("GetPhaseByUuid", "nope").Return(db.FeaturePhase{}, errors.New("no phase found")).Once()
+		rr := add(router(NewFeatureHandler(mockDb)), map[string]interface{}{"item_type": "phase", "item_uuid": "nope", "depends_on_type": "phase", "depends_on_uuid": "P1"})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
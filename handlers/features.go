@@ -15,6 +15,7 @@ import (
 type featureHandler struct {
 	db                    db.Database
 	generateBountyHandler func(bounties []db.NewBounty) []db.BountyResponse
+	userHasAccess         func(pubKeyFromAuth string, uuid string, role string) bool
 }
 
 func NewFeatureHandler(database db.Database) *featureHandler {
@@ -22,6 +23,7 @@ func NewFeatureHandler(database db.Database) *featureHandler {
 	return &featureHandler{
 		db:                    database,
 		generateBountyHandler: bHandler.GenerateBountyResponse,
+		userHasAccess:         bHandler.userHasAccess,
 	}
 }
 
@@ -70,6 +72,10 @@ func (oh *featureHandler) CreateOrEditFeatures(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if rejectArchivedWorkspace(w, features.WorkspaceUuid) {
+		return
+	}
+
 	p, err := oh.db.CreateOrEditFeature(features)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -90,6 +96,10 @@ func (oh *featureHandler) DeleteFeature(w http.ResponseWriter, r *http.Request)
 	}
 
 	uuid := chi.URLParam(r, "uuid")
+	if rejectArchivedWorkspaceOf(w, func() string { return featureWorkspace(oh.db, uuid) }) {
+		return
+	}
+
 	err := oh.db.DeleteFeatureByUuid(uuid)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -101,6 +111,20 @@ func (oh *featureHandler) DeleteFeature(w http.ResponseWriter, r *http.Request)
 	fmt.Fprint(w, "Feature deleted successfully")
 }
 
+// featureWorkspace is the workspace of a feature, empty for an unknown feature
+func featureWorkspace(database db.Database, featureUuid string) string {
+	return database.GetFeatureByUuid(featureUuid).WorkspaceUuid
+}
+
+// phaseWorkspace is the workspace of the feature of a phase, empty for an unknown phase
+func phaseWorkspace(database db.Database, phaseUuid string) string {
+	phase, err := database.GetPhaseByUuid(phaseUuid)
+	if err != nil || phase.Uuid == "" {
+		return ""
+	}
+	return featureWorkspace(database, phase.FeatureUuid)
+}
+
 // Old Method for getting features for workspace uuid
 func (oh *featureHandler) GetFeaturesByWorkspaceUuid(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -188,6 +212,10 @@ func (oh *featureHandler) CreateOrEditFeaturePhase(w http.ResponseWriter, r *htt
 		return
 	}
 
+	if rejectArchivedWorkspace(w, feature.WorkspaceUuid) {
+		return
+	}
+
 	phase, err := oh.db.CreateOrEditFeaturePhase(newPhase)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -225,6 +253,10 @@ func (oh *featureHandler) DeleteFeaturePhase(w http.ResponseWriter, r *http.Requ
 	featureUuid := chi.URLParam(r, "feature_uuid")
 	phaseUuid := chi.URLParam(r, "phase_uuid")
 
+	if rejectArchivedWorkspaceOf(w, func() string { return featureWorkspace(oh.db, featureUuid) }) {
+		return
+	}
+
 	err := oh.db.DeleteFeaturePhase(featureUuid, phaseUuid)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -266,6 +298,10 @@ func (oh *featureHandler) CreateOrEditStory(w http.ResponseWriter, r *http.Reque
 
 	newStory.UpdatedBy = pubKeyFromAuth
 
+	if rejectArchivedWorkspaceOf(w, func() string { return featureWorkspace(oh.db, newStory.FeatureUuid) }) {
+		return
+	}
+
 	story, err := oh.db.CreateOrEditFeatureStory(newStory)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -306,6 +342,10 @@ func (oh *featureHandler) DeleteStory(w http.ResponseWriter, r *http.Request) {
 	featureUuid := chi.URLParam(r, "feature_uuid")
 	storyUuid := chi.URLParam(r, "story_uuid")
 
+	if rejectArchivedWorkspaceOf(w, func() string { return featureWorkspace(oh.db, featureUuid) }) {
+		return
+	}
+
 	err := oh.db.DeleteFeatureStoryByUuid(featureUuid, storyUuid)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -13,6 +12,7 @@ import (
 
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/feeds"
+	"github.com/stakwork/sphinx-tribes/stakwork"
 	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 )
@@ -20,7 +20,7 @@ import (
 func GetGenericFeed(w http.ResponseWriter, r *http.Request) {
 	url := r.URL.Query().Get("url")
 
-	feed, err := feeds.ParseFeed(url, false)
+	feed, err := getCachedFeed(url)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		return
@@ -47,6 +47,34 @@ func GetGenericFeed(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(feed)
 }
 
+// getCachedFeed returns the feed warmed by the scheduler, parsing it on a cache miss
+func getCachedFeed(url string) (*feeds.Feed, error) {
+	cached, err := db.Store.GetFeedCache(url)
+	if err == nil {
+		feed := feeds.Feed{}
+		if err := json.Unmarshal([]byte(cached), &feed); err == nil {
+			return &feed, nil
+		}
+	}
+
+	feed, err := feeds.ParseFeed(url, false)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheFeed(url, feed)
+	return feed, nil
+}
+
+func cacheFeed(url string, feed *feeds.Feed) {
+	feedJson, err := json.Marshal(feed)
+	if err != nil {
+		fmt.Println("[feed] could not cache feed", err)
+		return
+	}
+	db.Store.SetFeedCache(url, string(feedJson))
+}
+
 func DownloadYoutubeFeed(w http.ResponseWriter, r *http.Request) {
 	apiKey := os.Getenv("YOUTUBE_KEY")
 	ctx := context.Background()
@@ -86,69 +114,34 @@ func DownloadYoutubeFeed(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	processYoutubeDownload(youtube_download.YoutubeUrls)
+	if err := processYoutubeDownload(youtube_download.YoutubeUrls); err != nil {
+		if stakwork.IsTemporary(err) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode("Could not reach Stakwork, try again later")
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+		json.NewEncoder(w).Encode("Could not process Youtube download")
+		return
+	}
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode("Youtube download processed successfully")
 }
 
-func processYoutubeDownload(data []string) {
-	stakworkKey := fmt.Sprintf("Token token=%s", os.Getenv("STAKWORK_KEY"))
-	if stakworkKey == "" {
-		fmt.Println("[feed] Youtube Download Error: Stakwork key not found")
-	} else {
-		type Vars struct {
-			YoutubeContent []string `json:"youtube_content"`
-		}
-
-		type Attributes struct {
-			Vars Vars `json:"vars"`
-		}
-
-		type SetVar struct {
-			Attributes Attributes `json:"attributes"`
-		}
-
-		type WorkflowParams struct {
-			SetVar SetVar `json:"set_var"`
-		}
-
-		workflows := WorkflowParams{
-			SetVar: SetVar{
-				Attributes: Attributes{
-					Vars: Vars{YoutubeContent: data},
-				},
-			},
-		}
-
-		body := map[string]interface{}{
-			"name":            "Sphinx Youtube Content Storage",
-			"workflow_id":     "11848",
-			"workflow_params": workflows,
-		}
-
-		buf, err := json.Marshal(body)
-		if err != nil {
-			fmt.Println("[feed] Youtube error: Unable to parse message into byte buffer", err)
-			return
-		}
-
-		requestUrl := "https://jobs.stakwork.com/api/v1/projects"
-		request, err := http.NewRequest(http.MethodPost, requestUrl, bytes.NewBuffer(buf))
-		request.Header.Set("Content-Type", "application/json")
-		request.Header.Set("Authorization", stakworkKey)
-
-		client := &http.Client{}
-		response, err := client.Do(request)
-		if err != nil {
-			fmt.Println("[feed] Youtube Download Request Error ===", err)
-		}
-		defer response.Body.Close()
-		res, err := io.ReadAll(response.Body)
-		if err != nil {
-			fmt.Println("[feed] Youtube Download Request Error ==", err)
-		}
-		fmt.Println("[feed] Youtube Download Succces ==", string(res))
+// processYoutubeDownload starts the Stakwork workflow that stores the content of youtube videos
+func processYoutubeDownload(data []string) error {
+	job := db.StakworkJob{Kind: db.StakworkJobYoutubeDownload}
+	_, result, err := startStakworkJob(db.DB, stakwork.NewClient(http.DefaultClient), job, stakwork.Project{
+		Name:       "Sphinx Youtube Content Storage",
+		WorkflowId: "11848",
+		Vars:       map[string]interface{}{"youtube_content": data},
+	})
+	if err != nil {
+		fmt.Println("[feed] Youtube Download Error:", err)
+		return err
 	}
+	fmt.Println("[feed] Youtube Download Succces ==", string(result.Body))
+	return nil
 }
 
 func GetPodcast(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,426 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"gorm.io/gorm"
+)
+
+const (
+	maxGraphqlBytes = 1 << 20
+	maxGraphqlDepth = 10
+	maxGraphqlList  = 100
+	// list items are resolved in parallel, their loads are batched by the loaders anyway
+	graphqlParallelism = 20
+)
+
+const graphqlSchema = `
+schema {
+	query: Query
+}
+
+scalar Time
+
+type Query {
+	bounty(id: ID!): Bounty
+	bounties(ids: [ID!]!): [Bounty]!
+	person(pubkey: String!): Person
+	people(pubkeys: [String!]!): [Person]!
+	tribe(uuid: String!): Tribe
+	tribes(uuids: [String!]!): [Tribe]!
+	workspace(uuid: String!): Workspace
+	feature(uuid: String!): Feature
+	ticket(id: String!): Ticket
+}
+
+type Bounty {
+	id: ID!
+	title: String!
+	description: String!
+	type: String!
+	# in sats
+	price: Float!
+	ticketUrl: String!
+	codingLanguages: [String!]!
+	assigned: Boolean!
+	completed: Boolean!
+	paid: Boolean!
+	created: Time!
+	workspace: Workspace
+	owner: Person
+	assignee: Person
+	phase: Phase
+	# only for the owner, the assignee and the people who can pay the bounty
+	proofs: [Proof!]
+}
+
+type Proof {
+	id: ID!
+	description: String!
+	links: [String!]!
+	files: [String!]!
+	status: String!
+	submittedBy: Person
+	created: Time
+}
+
+type Person {
+	pubkey: String!
+	alias: String!
+	uniqueName: String!
+	img: String!
+	description: String!
+	priceToMeet: Float!
+	tags: [String!]!
+	tickets: [Ticket!]!
+}
+
+type Tribe {
+	uuid: String!
+	name: String!
+	uniqueName: String!
+	description: String!
+	img: String!
+	tags: [String!]!
+	priceToJoin: Float!
+	memberCount: Float!
+	owner: Person
+}
+
+type Workspace {
+	uuid: String!
+	name: String!
+	description: String!
+	img: String!
+	website: String!
+	github: String!
+	mission: String!
+	archived: Boolean!
+	owner: Person
+	# for signed in callers
+	features: [Feature!]
+	bounties(first: Int = 20, offset: Int = 0): [Bounty!]!
+}
+
+type Feature {
+	uuid: String!
+	name: String!
+	brief: String!
+	priority: Int!
+	workspace: Workspace
+	phases: [Phase!]!
+}
+
+type Phase {
+	uuid: String!
+	name: String!
+	priority: Int!
+	feature: Feature
+	tickets: [Ticket!]!
+}
+
+type Ticket {
+	id: String!
+	title: String!
+	description: String!
+	type: String!
+	owner: Person
+	bounty: Bounty
+}
+`
+
+// batchLoader loads the records of one kind by key for the length of a GraphQL request. The
+// resolvers of a list queue the keys its items refer to, and the first load fetches everything
+// queued in one query, so the owners of n bounties are read with one query instead of n
+type batchLoader struct {
+	fetch  func(keys []string) map[string]interface{}
+	mu     sync.Mutex
+	queued map[string]bool
+	loaded map[string]interface{}
+}
+
+func newBatchLoader(fetch func(keys []string) map[string]interface{}) *batchLoader {
+	return &batchLoader{
+		fetch:  fetch,
+		queued: map[string]bool{},
+		loaded: map[string]interface{}{},
+	}
+}
+
+func (l *batchLoader) queue(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		if _, ok := l.loaded[key]; !ok && key != "" {
+			l.queued[key] = true
+		}
+	}
+}
+
+// load returns the record of a key, nil when there is none
+func (l *batchLoader) load(key string) interface{} {
+	if key == "" {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if value, ok := l.loaded[key]; ok {
+		return value
+	}
+
+	l.queued[key] = true
+	keys := []string{}
+	for k := range l.queued {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	l.queued = map[string]bool{}
+	results := l.fetch(keys)
+	for _, k := range keys {
+		l.loaded[k] = results[k]
+	}
+	return l.loaded[key]
+}
+
+func uintKeys(keys []string) []uint {
+	ids := []uint{}
+	for _, key := range keys {
+		if id, err := strconv.ParseUint(key, 10, 64); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
+}
+
+func uintKey(id uint) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// graphqlLoaders are the loaders of a request, records loaded once are shared by every resolver
+type graphqlLoaders struct {
+	people            *batchLoader
+	workspaces        *batchLoader
+	tribes            *batchLoader
+	bounties          *batchLoader
+	proofs            *batchLoader
+	features          *batchLoader
+	workspaceFeatures *batchLoader
+	phases            *batchLoader
+	featurePhases     *batchLoader
+	phaseCards        *batchLoader
+}
+
+func newGraphqlLoaders(database db.Database) *graphqlLoaders {
+	return &graphqlLoaders{
+		people: newBatchLoader(func(keys []string) map[string]interface{} {
+			results := map[string]interface{}{}
+			for _, m := range database.GetPeopleByPubkeys(keys) {
+				results[m.OwnerPubKey] = m
+			}
+			return results
+		}),
+		workspaces: newBatchLoader(func(keys []string) map[string]interface{} {
+			results := map[string]interface{}{}
+			for _, m := range database.GetWorkspacesByUuids(keys) {
+				results[m.Uuid] = m
+			}
+			return results
+		}),
+		tribes: newBatchLoader(func(keys []string) map[string]interface{} {
+			results := map[string]interface{}{}
+			for _, m := range database.GetTribesByUuids(keys) {
+				results[m.UUID] = m
+			}
+			return results
+		}),
+		bounties: newBatchLoader(func(keys []string) map[string]interface{} {
+			results := map[string]interface{}{}
+			for _, m := range database.GetBountiesByIds(uintKeys(keys)) {
+				if m.WorkspaceUuid == "" && m.OrgUuid != "" {
+					m.WorkspaceUuid = m.OrgUuid
+				}
+				results[uintKey(m.ID)] = m
+			}
+			return results
+		}),
+		proofs: newBatchLoader(func(keys []string) map[string]interface{} {
+			grouped := map[string][]db.BountyProof{}
+			for _, m := range database.GetBountyProofsByBountyIds(uintKeys(keys)) {
+				grouped[uintKey(m.BountyId)] = append(grouped[uintKey(m.BountyId)], m)
+			}
+			results := map[string]interface{}{}
+			for k, ms := range grouped {
+				results[k] = ms
+			}
+			return results
+		}),
+		features: newBatchLoader(func(keys []string) map[string]interface{} {
+			results := map[string]interface{}{}
+			for _, m := range database.GetFeaturesByUuids(keys) {
+				results[m.Uuid] = m
+			}
+			return results
+		}),
+		workspaceFeatures: newBatchLoader(func(keys []string) map[string]interface{} {
+			grouped := map[string][]db.WorkspaceFeatures{}
+			for _, m := range database.GetFeaturesByWorkspaceUuids(keys) {
+				grouped[m.WorkspaceUuid] = append(grouped[m.WorkspaceUuid], m)
+			}
+			results := map[string]interface{}{}
+			for k, ms := range grouped {
+				results[k] = ms
+			}
+			return results
+		}),
+		phases: newBatchLoader(func(keys []string) map[string]interface{} {
+			results := map[string]interface{}{}
+			for _, m := range database.GetPhasesByUuids(keys) {
+				results[m.Uuid] = m
+			}
+			return results
+		}),
+		featurePhases: newBatchLoader(func(keys []string) map[string]interface{} {
+			grouped := map[string][]db.FeaturePhase{}
+			for _, m := range database.GetPhasesByFeatureUuids(keys) {
+				grouped[m.FeatureUuid] = append(grouped[m.FeatureUuid], m)
+			}
+			results := map[string]interface{}{}
+			for k, ms := range grouped {
+				results[k] = ms
+			}
+			return results
+		}),
+		phaseCards: newBatchLoader(func(keys []string) map[string]interface{} {
+			grouped := map[string][]db.TicketCard{}
+			for _, m := range database.GetTicketCardsByPhaseUuids(keys) {
+				grouped[m.PhaseUuid] = append(grouped[m.PhaseUuid], m)
+			}
+			results := map[string]interface{}{}
+			for k, ms := range grouped {
+				results[k] = ms
+			}
+			return results
+		}),
+	}
+}
+
+// graphqlRequest is what the resolvers of a request share, it is kept on the context
+type graphqlRequest struct {
+	db            db.Database
+	viewer        string
+	loaders       *graphqlLoaders
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+	matesOnce     sync.Once
+	mates         map[string]bool
+}
+
+type graphqlRequestKey struct{}
+
+func graphqlRequestFrom(ctx context.Context) *graphqlRequest {
+	return ctx.Value(graphqlRequestKey{}).(*graphqlRequest)
+}
+
+// audience is how close the viewer is to a person, for the privacy settings of the profile
+func (gr *graphqlRequest) audience(person db.Person) db.PrivacyAudience {
+	if gr.viewer == "" {
+		return db.AudiencePublic
+	}
+	if gr.viewer == person.OwnerPubKey {
+		return db.AudienceOwner
+	}
+	if !person.HasVisibility(db.PrivacyTribeMembers) {
+		return db.AudiencePublic
+	}
+	gr.matesOnce.Do(func() {
+		gr.mates = gr.db.GetWorkspaceMatePubkeys(gr.viewer)
+	})
+	if gr.mates[person.OwnerPubKey] {
+		return db.AudienceMember
+	}
+	return db.AudiencePublic
+}
+
+type graphqlHandler struct {
+	db            db.Database
+	schema        *graphql.Schema
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewGraphqlHandler(database db.Database) *graphqlHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &graphqlHandler{
+		db: database,
+		schema: graphql.MustParseSchema(graphqlSchema, &graphqlResolver{},
+			graphql.MaxDepth(maxGraphqlDepth),
+			graphql.MaxParallelism(graphqlParallelism),
+		),
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+type graphqlBody struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// ServeGraphql answers GraphQL queries sent as a JSON body, or as the query param of a GET.
+// Tokens are optional, they only widen what the caller can read
+func (gh *graphqlHandler) ServeGraphql(w http.ResponseWriter, r *http.Request) {
+	body := graphqlBody{}
+	if r.Method == http.MethodGet {
+		body.Query = r.URL.Query().Get("query")
+		body.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &body.Variables); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode("Invalid variables")
+				return
+			}
+		}
+	} else {
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxGraphqlBytes))
+		r.Body.Close()
+		if err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			json.NewEncoder(w).Encode(fmt.Sprintf("Queries are limited to %d bytes", maxGraphqlBytes))
+			return
+		}
+		if err := json.Unmarshal(data, &body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("Invalid request body")
+			return
+		}
+	}
+	if body.Query == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A query is required")
+		return
+	}
+
+	viewer, _ := r.Context().Value(auth.ContextKey).(string)
+	ctx := context.WithValue(r.Context(), graphqlRequestKey{}, &graphqlRequest{
+		db:            gh.db,
+		viewer:        viewer,
+		loaders:       newGraphqlLoaders(gh.db),
+		userHasAccess: gh.userHasAccess,
+	})
+	response := gh.schema.Exec(ctx, body.Query, body.OperationName, body.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,434 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+var errGraphqlSignIn = errors.New("sign in to read features")
+
+func graphqlTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
+
+func graphqlListSize(first int32) int {
+	if first <= 0 || first > maxGraphqlList {
+		return maxGraphqlList
+	}
+	return int(first)
+}
+
+type graphqlResolver struct{}
+
+func (r *graphqlResolver) Bounty(ctx context.Context, args struct{ ID graphql.ID }) *bountyResolver {
+	return loadBounty(ctx, string(args.ID))
+}
+
+func (r *graphqlResolver) Bounties(ctx context.Context, args struct{ Ids []graphql.ID }) ([]*bountyResolver, error) {
+	if len(args.Ids) > maxGraphqlList {
+		return nil, errors.New("at most 100 bounties are read at once")
+	}
+	gr := graphqlRequestFrom(ctx)
+	for _, id := range args.Ids {
+		gr.loaders.bounties.queue(string(id))
+	}
+	bounties := []*bountyResolver{}
+	for _, id := range args.Ids {
+		bounties = append(bounties, loadBounty(ctx, string(id)))
+	}
+	return bounties, nil
+}
+
+func (r *graphqlResolver) Person(ctx context.Context, args struct{ Pubkey string }) *personResolver {
+	return loadPerson(ctx, args.Pubkey)
+}
+
+func (r *graphqlResolver) People(ctx context.Context, args struct{ Pubkeys []string }) ([]*personResolver, error) {
+	if len(args.Pubkeys) > maxGraphqlList {
+		return nil, errors.New("at most 100 people are read at once")
+	}
+	graphqlRequestFrom(ctx).loaders.people.queue(args.Pubkeys...)
+	people := []*personResolver{}
+	for _, pubkey := range args.Pubkeys {
+		people = append(people, loadPerson(ctx, pubkey))
+	}
+	return people, nil
+}
+
+func (r *graphqlResolver) Tribe(ctx context.Context, args struct{ Uuid string }) *tribeResolver {
+	return loadTribe(ctx, args.Uuid)
+}
+
+func (r *graphqlResolver) Tribes(ctx context.Context, args struct{ Uuids []string }) ([]*tribeResolver, error) {
+	if len(args.Uuids) > maxGraphqlList {
+		return nil, errors.New("at most 100 tribes are read at once")
+	}
+	graphqlRequestFrom(ctx).loaders.tribes.queue(args.Uuids...)
+	tribes := []*tribeResolver{}
+	for _, uuid := range args.Uuids {
+		tribes = append(tribes, loadTribe(ctx, uuid))
+	}
+	return tribes, nil
+}
+
+func (r *graphqlResolver) Workspace(ctx context.Context, args struct{ Uuid string }) *workspaceResolver {
+	return loadWorkspace(ctx, args.Uuid)
+}
+
+func (r *graphqlResolver) Feature(ctx context.Context, args struct{ Uuid string }) (*featureResolver, error) {
+	if graphqlRequestFrom(ctx).viewer == "" {
+		return nil, errGraphqlSignIn
+	}
+	return loadFeature(ctx, args.Uuid), nil
+}
+
+func (r *graphqlResolver) Ticket(ctx context.Context, args struct{ ID string }) *ticketResolver {
+	pubkey, created, ok := parseTicketKey(args.ID)
+	if !ok {
+		return nil
+	}
+	owner := loadPerson(ctx, pubkey)
+	if owner == nil {
+		return nil
+	}
+	ticket := findTicket(owner.person, created)
+	if ticket == nil {
+		return nil
+	}
+	return &ticketResolver{id: args.ID, pubkey: pubkey, ticket: ticket}
+}
+
+func loadBounty(ctx context.Context, id string) *bountyResolver {
+	bounty, ok := graphqlRequestFrom(ctx).loaders.bounties.load(id).(db.NewBounty)
+	if !ok {
+		return nil
+	}
+	return &bountyResolver{bounty: bounty}
+}
+
+func loadPerson(ctx context.Context, pubkey string) *personResolver {
+	gr := graphqlRequestFrom(ctx)
+	person, ok := gr.loaders.people.load(pubkey).(db.Person)
+	if !ok {
+		return nil
+	}
+	return &personResolver{person: db.ApplyPersonPrivacy(person, gr.audience(person))}
+}
+
+func loadTribe(ctx context.Context, uuid string) *tribeResolver {
+	tribe, ok := graphqlRequestFrom(ctx).loaders.tribes.load(uuid).(db.Tribe)
+	if !ok {
+		return nil
+	}
+	return &tribeResolver{tribe: tribe}
+}
+
+func loadWorkspace(ctx context.Context, uuid string) *workspaceResolver {
+	workspace, ok := graphqlRequestFrom(ctx).loaders.workspaces.load(uuid).(db.Workspace)
+	if !ok {
+		return nil
+	}
+	return &workspaceResolver{workspace: workspace}
+}
+
+func loadFeature(ctx context.Context, uuid string) *featureResolver {
+	feature, ok := graphqlRequestFrom(ctx).loaders.features.load(uuid).(db.WorkspaceFeatures)
+	if !ok {
+		return nil
+	}
+	return &featureResolver{feature: feature}
+}
+
+func loadPhase(ctx context.Context, uuid string) *phaseResolver {
+	phase, ok := graphqlRequestFrom(ctx).loaders.phases.load(uuid).(db.FeaturePhase)
+	if !ok {
+		return nil
+	}
+	return &phaseResolver{phase: phase}
+}
+
+// bountyResolvers resolves a list of bounties, queueing what their fields refer to so each
+// kind is loaded once for the whole list
+func bountyResolvers(ctx context.Context, bounties []db.NewBounty) []*bountyResolver {
+	loaders := graphqlRequestFrom(ctx).loaders
+	resolvers := []*bountyResolver{}
+	for _, bounty := range bounties {
+		loaders.workspaces.queue(bounty.WorkspaceUuid)
+		loaders.people.queue(bounty.OwnerID, bounty.Assignee)
+		loaders.proofs.queue(uintKey(bounty.ID))
+		loaders.phases.queue(bounty.PhaseUuid)
+		resolvers = append(resolvers, &bountyResolver{bounty: bounty})
+	}
+	return resolvers
+}
+
+type bountyResolver struct {
+	bounty db.NewBounty
+}
+
+func (r *bountyResolver) ID() graphql.ID      { return graphql.ID(uintKey(r.bounty.ID)) }
+func (r *bountyResolver) Title() string       { return r.bounty.Title }
+func (r *bountyResolver) Description() string { return r.bounty.Description }
+func (r *bountyResolver) Type() string        { return r.bounty.Type }
+func (r *bountyResolver) Price() float64      { return float64(r.bounty.Price) }
+func (r *bountyResolver) TicketUrl() string   { return r.bounty.TicketUrl }
+func (r *bountyResolver) CodingLanguages() []string {
+	return append([]string{}, r.bounty.CodingLanguages...)
+}
+func (r *bountyResolver) Assigned() bool  { return r.bounty.Assignee != "" }
+func (r *bountyResolver) Completed() bool { return r.bounty.Completed }
+func (r *bountyResolver) Paid() bool      { return r.bounty.Paid }
+func (r *bountyResolver) Created() graphql.Time {
+	return graphql.Time{Time: time.Unix(r.bounty.Created, 0)}
+}
+
+func (r *bountyResolver) Workspace(ctx context.Context) *workspaceResolver {
+	return loadWorkspace(ctx, r.bounty.WorkspaceUuid)
+}
+
+func (r *bountyResolver) Owner(ctx context.Context) *personResolver {
+	return loadPerson(ctx, r.bounty.OwnerID)
+}
+
+func (r *bountyResolver) Assignee(ctx context.Context) *personResolver {
+	return loadPerson(ctx, r.bounty.Assignee)
+}
+
+func (r *bountyResolver) Phase(ctx context.Context) *phaseResolver {
+	return loadPhase(ctx, r.bounty.PhaseUuid)
+}
+
+// Proofs are shown to the people who can see the payment of the bounty
+func (r *bountyResolver) Proofs(ctx context.Context) (*[]*proofResolver, error) {
+	gr := graphqlRequestFrom(ctx)
+	canView := gr.viewer != "" && (r.bounty.OwnerID == gr.viewer || r.bounty.Assignee == gr.viewer ||
+		(r.bounty.WorkspaceUuid != "" && gr.userHasAccess(gr.viewer, r.bounty.WorkspaceUuid, db.PayBounty)))
+	if !canView {
+		return nil, errors.New("the proofs of a bounty are for its owner, assignee and payers")
+	}
+
+	proofs, _ := gr.loaders.proofs.load(uintKey(r.bounty.ID)).([]db.BountyProof)
+	resolvers := []*proofResolver{}
+	for _, proof := range proofs {
+		gr.loaders.people.queue(proof.SubmittedBy)
+		resolvers = append(resolvers, &proofResolver{proof: proof})
+	}
+	return &resolvers, nil
+}
+
+type proofResolver struct {
+	proof db.BountyProof
+}
+
+func (r *proofResolver) ID() graphql.ID         { return graphql.ID(uintKey(r.proof.ID)) }
+func (r *proofResolver) Description() string    { return r.proof.Description }
+func (r *proofResolver) Links() []string        { return append([]string{}, r.proof.Links...) }
+func (r *proofResolver) Files() []string        { return append([]string{}, r.proof.Files...) }
+func (r *proofResolver) Status() string         { return string(r.proof.Status) }
+func (r *proofResolver) Created() *graphql.Time { return graphqlTime(r.proof.Created) }
+
+func (r *proofResolver) SubmittedBy(ctx context.Context) *personResolver {
+	return loadPerson(ctx, r.proof.SubmittedBy)
+}
+
+type personResolver struct {
+	person db.Person
+}
+
+func (r *personResolver) Pubkey() string       { return r.person.OwnerPubKey }
+func (r *personResolver) Alias() string        { return r.person.OwnerAlias }
+func (r *personResolver) UniqueName() string   { return r.person.UniqueName }
+func (r *personResolver) Img() string          { return r.person.Img }
+func (r *personResolver) Description() string  { return r.person.Description }
+func (r *personResolver) PriceToMeet() float64 { return float64(r.person.PriceToMeet) }
+func (r *personResolver) Tags() []string       { return append([]string{}, r.person.Tags...) }
+
+func (r *personResolver) Tickets(ctx context.Context) []*ticketResolver {
+	loaders := graphqlRequestFrom(ctx).loaders
+	tickets := []*ticketResolver{}
+	wanteds, _ := r.person.Extras["wanted"].([]interface{})
+	for _, wanted := range wanteds {
+		ticket, ok := wanted.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		created, ok := ticket["created"].(float64)
+		if !ok {
+			continue
+		}
+		resolver := &ticketResolver{id: ticketKey(r.person.OwnerPubKey, int64(created)), pubkey: r.person.OwnerPubKey, ticket: ticket}
+		loaders.bounties.queue(resolver.bountyKey())
+		tickets = append(tickets, resolver)
+	}
+	return tickets
+}
+
+type ticketResolver struct {
+	id     string
+	pubkey string
+	ticket map[string]interface{}
+}
+
+func (r *ticketResolver) field(name string) string {
+	value, _ := r.ticket[name].(string)
+	return value
+}
+
+func (r *ticketResolver) ID() string          { return r.id }
+func (r *ticketResolver) Title() string       { return r.field("title") }
+func (r *ticketResolver) Description() string { return r.field("description") }
+func (r *ticketResolver) Type() string        { return r.field("type") }
+
+// bountyKey is the id of the bounty created from the ticket, empty if there is none
+func (r *ticketResolver) bountyKey() string {
+	switch id := r.ticket["bounty_id"].(type) {
+	case float64:
+		return strconv.FormatInt(int64(id), 10)
+	case uint:
+		return uintKey(id)
+	}
+	return ""
+}
+
+func (r *ticketResolver) Owner(ctx context.Context) *personResolver {
+	return loadPerson(ctx, r.pubkey)
+}
+
+func (r *ticketResolver) Bounty(ctx context.Context) *bountyResolver {
+	return loadBounty(ctx, r.bountyKey())
+}
+
+type tribeResolver struct {
+	tribe db.Tribe
+}
+
+func (r *tribeResolver) Uuid() string         { return r.tribe.UUID }
+func (r *tribeResolver) Name() string         { return r.tribe.Name }
+func (r *tribeResolver) UniqueName() string   { return r.tribe.UniqueName }
+func (r *tribeResolver) Description() string  { return r.tribe.Description }
+func (r *tribeResolver) Img() string          { return r.tribe.Img }
+func (r *tribeResolver) Tags() []string       { return append([]string{}, r.tribe.Tags...) }
+func (r *tribeResolver) PriceToJoin() float64 { return float64(r.tribe.PriceToJoin) }
+func (r *tribeResolver) MemberCount() float64 { return float64(r.tribe.MemberCount) }
+
+func (r *tribeResolver) Owner(ctx context.Context) *personResolver {
+	return loadPerson(ctx, r.tribe.OwnerPubKey)
+}
+
+type workspaceResolver struct {
+	workspace db.Workspace
+}
+
+func (r *workspaceResolver) Uuid() string        { return r.workspace.Uuid }
+func (r *workspaceResolver) Name() string        { return r.workspace.Name }
+func (r *workspaceResolver) Description() string { return r.workspace.Description }
+func (r *workspaceResolver) Img() string         { return r.workspace.Img }
+func (r *workspaceResolver) Website() string     { return r.workspace.Website }
+func (r *workspaceResolver) Github() string      { return r.workspace.Github }
+func (r *workspaceResolver) Mission() string     { return r.workspace.Mission }
+func (r *workspaceResolver) Archived() bool      { return r.workspace.Archived }
+
+func (r *workspaceResolver) Owner(ctx context.Context) *personResolver {
+	return loadPerson(ctx, r.workspace.OwnerPubKey)
+}
+
+func (r *workspaceResolver) Features(ctx context.Context) (*[]*featureResolver, error) {
+	gr := graphqlRequestFrom(ctx)
+	if gr.viewer == "" {
+		return nil, errGraphqlSignIn
+	}
+	features, _ := gr.loaders.workspaceFeatures.load(r.workspace.Uuid).([]db.WorkspaceFeatures)
+	resolvers := []*featureResolver{}
+	for _, feature := range features {
+		gr.loaders.featurePhases.queue(feature.Uuid)
+		resolvers = append(resolvers, &featureResolver{feature: feature})
+	}
+	return &resolvers, nil
+}
+
+// Bounties are the listed bounties of the workspace, newest first
+func (r *workspaceResolver) Bounties(ctx context.Context, args struct {
+	First  int32
+	Offset int32
+}) []*bountyResolver {
+	offset := int(args.Offset)
+	if offset < 0 {
+		offset = 0
+	}
+	bounties := graphqlRequestFrom(ctx).db.GetListedWorkspaceBounties(r.workspace.Uuid, graphqlListSize(args.First), offset)
+	return bountyResolvers(ctx, bounties)
+}
+
+type featureResolver struct {
+	feature db.WorkspaceFeatures
+}
+
+func (r *featureResolver) Uuid() string    { return r.feature.Uuid }
+func (r *featureResolver) Name() string    { return r.feature.Name }
+func (r *featureResolver) Brief() string   { return r.feature.Brief }
+func (r *featureResolver) Priority() int32 { return int32(r.feature.Priority) }
+
+func (r *featureResolver) Workspace(ctx context.Context) *workspaceResolver {
+	return loadWorkspace(ctx, r.feature.WorkspaceUuid)
+}
+
+func (r *featureResolver) Phases(ctx context.Context) []*phaseResolver {
+	loaders := graphqlRequestFrom(ctx).loaders
+	phases, _ := loaders.featurePhases.load(r.feature.Uuid).([]db.FeaturePhase)
+	resolvers := []*phaseResolver{}
+	for _, phase := range phases {
+		loaders.phaseCards.queue(phase.Uuid)
+		resolvers = append(resolvers, &phaseResolver{phase: phase})
+	}
+	return resolvers
+}
+
+type phaseResolver struct {
+	phase db.FeaturePhase
+}
+
+func (r *phaseResolver) Uuid() string    { return r.phase.Uuid }
+func (r *phaseResolver) Name() string    { return r.phase.Name }
+func (r *phaseResolver) Priority() int32 { return int32(r.phase.Priority) }
+
+func (r *phaseResolver) Feature(ctx context.Context) (*featureResolver, error) {
+	if graphqlRequestFrom(ctx).viewer == "" {
+		return nil, errGraphqlSignIn
+	}
+	return loadFeature(ctx, r.phase.FeatureUuid), nil
+}
+
+// Tickets are the tickets on the board of the phase, in the order of their cards
+func (r *phaseResolver) Tickets(ctx context.Context) []*ticketResolver {
+	loaders := graphqlRequestFrom(ctx).loaders
+	cards, _ := loaders.phaseCards.load(r.phase.Uuid).([]db.TicketCard)
+	for _, card := range cards {
+		if pubkey, _, ok := parseTicketKey(card.TicketId); ok {
+			loaders.people.queue(pubkey)
+		}
+	}
+
+	tickets := []*ticketResolver{}
+	for _, card := range cards {
+		pubkey, created, ok := parseTicketKey(card.TicketId)
+		if !ok {
+			continue
+		}
+		owner, ok := loaders.people.load(pubkey).(db.Person)
+		if !ok {
+			continue
+		}
+		if ticket := findTicket(owner, created); ticket != nil {
+			tickets = append(tickets, &ticketResolver{id: card.TicketId, pubkey: pubkey, ticket: ticket})
+		}
+	}
+	return tickets
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphql(t *testing.T) {
+	query := func(gh *graphqlHandler, viewer string, q string) map[string]interface{} {
+		body, _ := json.Marshal(graphqlBody{Query: q})
+		req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+		if viewer != "" {
+			req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, viewer))
+		}
+		rr := httptest.NewRecorder()
+		gh.ServeGraphql(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		response := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response
+	}
+	newHandler := func(t *testing.T) (*graphqlHandler, *mocks.Database) {
+		mockDb := mocks.NewDatabase(t)
+		gh := NewGraphqlHandler(mockDb)
+		gh.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+		return gh, mockDb
+	}
+
+	t.Run("Should test that a bounty is read with its workspace, assignee and proofs in one request", func(t *testing.T) {
+		gh, mockDb := newHandler(t)
+		mockDb.On("GetBountiesByIds", []uint{12}).Return([]db.NewBounty{{ID: 12, Title: "Login", OwnerID: "owner", Assignee: "ada", WorkspaceUuid: "ws"}}).Once()
+		mockDb.On("GetWorkspacesByUuids", []string{"ws"}).Return([]db.Workspace{{Uuid: "ws", Name: "Sphinx"}}).Once()
+		mockDb.On("GetPeopleByPubkeys", []string{"ada"}).Return([]db.Person{{OwnerPubKey: "ada", OwnerAlias: "Ada"}}).Once()
+		mockDb.On("GetBountyProofsByBountyIds", []uint{12}).Return([]db.BountyProof{{ID: 1, BountyId: 12, SubmittedBy: "ada", Links: []string{"https://github.com/pr/1"}}}).Once()
+
+		response := query(gh, "owner", `{ bounty(id: "12") { title workspace { name } assignee { alias } proofs { links submittedBy { alias } } } }`)
+
+		assert.Nil(t, response["errors"])
+		bounty := response["data"].(map[string]interface{})["bounty"].(map[string]interface{})
+		assert.Equal(t, "Login", bounty["title"])
+		assert.Equal(t, "Sphinx", bounty["workspace"].(map[string]interface{})["name"])
+		assert.Equal(t, "Ada", bounty["assignee"].(map[string]interface{})["alias"])
+		proof := bounty["proofs"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, []interface{}{"https://github.com/pr/1"}, proof["links"])
+		// the submitter was loaded with the assignee
+		assert.Equal(t, "Ada", proof["submittedBy"].(map[string]interface{})["alias"])
+	})
+
+	t.Run("Should test that the people of a list of bounties are loaded in one query", func(t *testing.T) {
+		gh, mockDb := newHandler(t)
+		mockDb.On("GetWorkspacesByUuids", []string{"ws"}).Return([]db.Workspace{{Uuid: "ws", Name: "Sphinx", OwnerPubKey: "owner"}}).Once()
+		mockDb.On("GetListedWorkspaceBounties", "ws", 2, 0).Return([]db.NewBounty{
+			{ID: 1, OwnerID: "owner", Assignee: "ada", WorkspaceUuid: "ws"},
+			{ID: 2, OwnerID: "owner", Assignee: "bob", WorkspaceUuid: "ws"},
+		}).Once()
+		mockDb.On("GetPeopleByPubkeys", []string{"ada", "bob", "owner"}).Return([]db.Person{
+			{OwnerPubKey: "owner", OwnerAlias: "Owner"}, {OwnerPubKey: "ada", OwnerAlias: "Ada"}, {OwnerPubKey: "bob", OwnerAlias: "Bob"},
+		}).Once()
+
+		response := query(gh, "", `{ workspace(uuid: "ws") { bounties(first: 2) { id owner { alias } assignee { alias } } } }`)
+
+		assert.Nil(t, response["errors"])
+		bounties := response["data"].(map[string]interface{})["workspace"].(map[string]interface{})["bounties"].([]interface{})
+		assert.Len(t, bounties, 2)
+		assert.Equal(t, "Bob", bounties[1].(map[string]interface{})["assignee"].(map[string]interface{})["alias"])
+	})
+
+	t.Run("Should test that proofs and features are not shown to anyone", func(t *testing.T) {
+		gh, mockDb := newHandler(t)
+		mockDb.On("GetBountiesByIds", []uint{12}).Return([]db.NewBounty{{ID: 12, Title: "Login", OwnerID: "owner", Assignee: "ada", WorkspaceUuid: "ws"}}).Once()
+
+		response := query(gh, "someone", `{ bounty(id: "12") { title proofs { id } } }`)
+		bounty := response["data"].(map[string]interface{})["bounty"].(map[string]interface{})
+		assert.Equal(t, "Login", bounty["title"])
+		assert.Nil(t, bounty["proofs"])
+		assert.Len(t, response["errors"], 1)
+
+		response = query(gh, "", `{ feature(uuid: "f1") { name } }`)
+		assert.Len(t, response["errors"], 1)
+	})
+
+	t.Run("Should test that queries nested too deep are refused", func(t *testing.T) {
+		gh, _ := newHandler(t)
+
+		response := query(gh, "", `{ bounty(id: "1") { workspace { bounties { workspace { bounties { workspace { bounties { workspace { bounties { workspace { name } } } } } } } } } } }`)
+
+		assert.Nil(t, response["data"])
+		assert.NotEmpty(t, response["errors"])
+	})
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyHandler struct {
+	db db.Database
+}
+
+func NewIdempotencyHandler(database db.Database) *idempotencyHandler {
+	return &idempotencyHandler{
+		db: database,
+	}
+}
+
+// responseRecorder keeps a copy of the response so it can be replayed
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(statusCode int) {
+	if rec.statusCode == 0 {
+		rec.statusCode = statusCode
+	}
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.statusCode == 0 {
+		rec.statusCode = http.StatusOK
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+// Idempotent replays the stored response when a request is retried with the same Idempotency-Key header,
+// keys are scoped to the authenticated pubkey, or to the client ip on routes without auth, and kept for
+// db.IdempotencyKeyTTL
+func (ih *idempotencyHandler) Idempotent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		owner, _ := r.Context().Value(auth.ContextKey).(string)
+		if owner == "" {
+			owner = "ip:" + clientIp(r)
+		}
+
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.Sum256([]byte(r.Method + " " + r.URL.Path + "\n" + string(body)))
+		requestHash := hex.EncodeToString(hash[:])
+
+		existing, err := ih.db.GetIdempotencyKey(key, owner)
+		if err == nil {
+			ih.replay(w, existing, requestHash)
+			return
+		}
+
+		record, err := ih.db.CreateIdempotencyKey(db.IdempotencyKey{
+			Key:         key,
+			OwnerPubKey: owner,
+			Method:      r.Method,
+			Path:        r.URL.Path,
+			RequestHash: requestHash,
+		})
+		if err != nil {
+			// another request with the same key got in first
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode("A request with this Idempotency-Key is already in progress")
+			return
+		}
+
+		// a panicking handler stored no response, the key is released so the request can be retried
+		defer func() {
+			if p := recover(); p != nil {
+				ih.db.DeleteIdempotencyKey(record.ID)
+				panic(p)
+			}
+		}()
+
+		rec := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+
+		statusCode := rec.statusCode
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
+
+		// server errors are not stored so the request can be retried
+		if statusCode >= http.StatusInternalServerError {
+			ih.db.DeleteIdempotencyKey(record.ID)
+			return
+		}
+
+		if err := ih.db.UpdateIdempotencyKeyResponse(record.ID, statusCode, rec.body.String()); err != nil {
+			fmt.Println("[idempotency] could not store response", err)
+		}
+	})
+}
+
+func (ih *idempotencyHandler) replay(w http.ResponseWriter, existing db.IdempotencyKey, requestHash string) {
+	if existing.RequestHash != requestHash {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode("Idempotency-Key was already used with a different request")
+		return
+	}
+
+	if existing.StatusCode == 0 {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("A request with this Idempotency-Key is already in progress")
+		return
+	}
+
+	w.Header().Set("Idempotent-Replayed", "true")
+	if existing.Response != "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(existing.StatusCode)
+	w.Write([]byte(existing.Response))
+}
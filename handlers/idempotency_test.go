@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIdempotent(t *testing.T) {
+	newRequest := func(key string, body string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/gobounties/pay/1", bytes.NewReader([]byte(body)))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		return req
+	}
+
+	t.Run("Should test that requests without the header are passed through", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ih := NewIdempotencyHandler(mockDb)
+		calls := 0
+		handler := ih.Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest("", "{}"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Should test that the first request is executed and its response stored", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ih := NewIdempotencyHandler(mockDb)
+		handler := ih.Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`"paid"`))
+		}))
+
+		mockDb.On("GetIdempotencyKey", "key", "pubkey").Return(db.IdempotencyKey{}, assert.AnError).Once()
+		mockDb.On("CreateIdempotencyKey", mock.MatchedBy(func(k db.IdempotencyKey) bool {
+			return k.Key == "key" && k.OwnerPubKey == "pubkey" && k.RequestHash != ""
+		})).Return(db.IdempotencyKey{ID: 1}, nil).Once()
+		mockDb.On("UpdateIdempotencyKeyResponse", uint(1), http.StatusOK, `"paid"`).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest("key", "{}"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a retried request replays the stored response", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ih := NewIdempotencyHandler(mockDb)
+		calls := 0
+		handler := ih.Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+		}))
+
+		// store the hash of the first request
+		var stored db.IdempotencyKey
+		mockDb.On("GetIdempotencyKey", "key", "pubkey").Return(db.IdempotencyKey{}, assert.AnError).Once()
+		mockDb.On("CreateIdempotencyKey", mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(0).(db.IdempotencyKey)
+		}).Return(db.IdempotencyKey{ID: 1}, nil).Once()
+		mockDb.On("UpdateIdempotencyKeyResponse", uint(1), http.StatusOK, "").Return(nil).Once()
+		handler.ServeHTTP(httptest.NewRecorder(), newRequest("key", "{}"))
+
+		stored.ID = 1
+		stored.StatusCode = http.StatusOK
+		stored.Response = `"paid"`
+		mockDb.On("GetIdempotencyKey", "key", "pubkey").Return(stored, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest("key", "{}"))
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "true", rr.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, `"paid"`, rr.Body.String())
+	})
+
+	t.Run("Should test that a key reused with a different body is rejected", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ih := NewIdempotencyHandler(mockDb)
+		handler := ih.Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler should not be called")
+		}))
+
+		mockDb.On("GetIdempotencyKey", "key", "pubkey").Return(db.IdempotencyKey{ID: 1, RequestHash: "other_hash", StatusCode: http.StatusOK}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest("key", `{"amount": 10}`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("Should test that a server error releases the key", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ih := NewIdempotencyHandler(mockDb)
+		handler := ih.Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+
+		mockDb.On("GetIdempotencyKey", "key", "pubkey").Return(db.IdempotencyKey{}, assert.AnError).Once()
+		mockDb.On("CreateIdempotencyKey", mock.Anything).Return(db.IdempotencyKey{ID: 2}, nil).Once()
+		mockDb.On("DeleteIdempotencyKey", uint(2)).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest("key", "{}"))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("Should test that a panic releases the key", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ih := NewIdempotencyHandler(mockDb)
+		handler := ih.Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("handler failed")
+		}))
+
+		mockDb.On("GetIdempotencyKey", "key", "pubkey").Return(db.IdempotencyKey{}, assert.AnError).Once()
+		mockDb.On("CreateIdempotencyKey", mock.Anything).Return(db.IdempotencyKey{ID: 3}, nil).Once()
+		mockDb.On("DeleteIdempotencyKey", uint(3)).Return(nil).Once()
+
+		assert.PanicsWithValue(t, "handler failed", func() {
+			handler.ServeHTTP(httptest.NewRecorder(), newRequest("key", "{}"))
+		})
+	})
+
+	t.Run("Should test that keys of requests without auth are scoped to the client ip", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ih := NewIdempotencyHandler(mockDb)
+		handler := ih.Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		mockDb.On("GetIdempotencyKey", "key", "ip:10.0.0.1").Return(db.IdempotencyKey{}, assert.AnError).Once()
+		mockDb.On("CreateIdempotencyKey", mock.MatchedBy(func(k db.IdempotencyKey) bool {
+			return k.OwnerPubKey == "ip:10.0.0.1"
+		})).Return(db.IdempotencyKey{ID: 4}, nil).Once()
+		mockDb.On("UpdateIdempotencyKeyResponse", uint(4), http.StatusOK, "").Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/invoices", bytes.NewReader([]byte("{}")))
+		req.RemoteAddr = "10.0.0.1:4000"
+		req.Header.Set(IdempotencyKeyHeader, "key")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
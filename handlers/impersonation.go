@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/notifications"
+)
+
+const (
+	impersonationMinutes    = 15
+	impersonationMaxMinutes = 60
+)
+
+type impersonationHandler struct {
+	db        db.Database
+	encodeJwt func(target string, admin string, impersonationId string, scope string, expires time.Time) (string, error)
+}
+
+func NewImpersonationHandler(database db.Database) *impersonationHandler {
+	return &impersonationHandler{
+		db:        database,
+		encodeJwt: auth.EncodeImpersonationJwt,
+	}
+}
+
+// RecordImpersonatedRequest is used by auth to log each request made with an impersonation token,
+// it refuses the request once the impersonation ended or expired
+func (ih *impersonationHandler) RecordImpersonatedRequest(impersonationId string, method string, path string) bool {
+	impersonation := ih.db.GetImpersonationByUuid(impersonationId)
+	if impersonation.ID == 0 || impersonation.EndedAt != nil || impersonation.ExpiresAt == nil || !impersonation.ExpiresAt.After(time.Now()) {
+		return false
+	}
+
+	err := ih.db.CreateImpersonatedRequest(db.ImpersonatedRequest{
+		ImpersonationUuid: impersonation.Uuid,
+		AdminPubKey:       impersonation.AdminPubKey,
+		TargetPubKey:      impersonation.TargetPubKey,
+		Method:            method,
+		Path:              path,
+	})
+	if err != nil {
+		// a request that can't be audited is not made
+		fmt.Println("[impersonation] could not log request", impersonationId, err)
+		return false
+	}
+	return true
+}
+
+// StartImpersonation issues a short lived token acting as a pubkey for the super admin, read only
+// unless the write scope is asked for
+func (ih *impersonationHandler) StartImpersonation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	request := db.ImpersonationRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	request.PubKey = strings.TrimSpace(request.PubKey)
+	request.Reason = strings.TrimSpace(request.Reason)
+	if request.PubKey == "" || request.Reason == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A pubkey and a reason are required")
+		return
+	}
+	if request.Scope == "" {
+		request.Scope = db.ImpersonationRead
+	}
+	if request.Scope != db.ImpersonationRead && request.Scope != db.ImpersonationWrite {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Scope must be read or write")
+		return
+	}
+	if request.Minutes <= 0 {
+		request.Minutes = impersonationMinutes
+	}
+	if request.Minutes > impersonationMaxMinutes {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("An impersonation lasts at most %d minutes", impersonationMaxMinutes))
+		return
+	}
+	if request.PubKey == pubKeyFromAuth || auth.AdminCheck(request.PubKey) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A super admin can't be impersonated")
+		return
+	}
+	if ih.db.GetPersonByPubkey(request.PubKey).ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Person not found")
+		return
+	}
+
+	expires := time.Now().Add(time.Duration(request.Minutes) * time.Minute)
+	impersonation, err := ih.db.CreateImpersonation(db.Impersonation{
+		AdminPubKey:  pubKeyFromAuth,
+		TargetPubKey: request.PubKey,
+		Reason:       request.Reason,
+		Scope:        request.Scope,
+		ExpiresAt:    &expires,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	token, err := ih.encodeJwt(impersonation.TargetPubKey, impersonation.AdminPubKey, impersonation.Uuid, string(impersonation.Scope), expires)
+	if err != nil {
+		ih.db.EndImpersonation(impersonation.Uuid)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	fmt.Println("[impersonation]", pubKeyFromAuth, "acting as", impersonation.TargetPubKey, "until", expires)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"impersonation": impersonation,
+		"jwt":           token,
+	})
+}
+
+// EndImpersonation ends an impersonation before it expires, its token stops working and the
+// pubkey is notified
+func (ih *impersonationHandler) EndImpersonation(w http.ResponseWriter, r *http.Request) {
+	impersonation, err := ih.db.EndImpersonation(chi.URLParam(r, "uuid"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	ih.notifyImpersonation(impersonation)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(impersonation)
+}
+
+// GetImpersonations lists the latest impersonations, of one pubkey with ?pubkey=
+func (ih *impersonationHandler) GetImpersonations(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ih.db.GetImpersonations(r.URL.Query().Get("pubkey"), 100))
+}
+
+// GetImpersonatedRequests is the audit log of the requests made during an impersonation
+func (ih *impersonationHandler) GetImpersonatedRequests(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+	if ih.db.GetImpersonationByUuid(uuid).ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Impersonation not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ih.db.GetImpersonatedRequests(uuid))
+}
+
+// NotifyEndedImpersonations tells pubkeys that an admin acted as them once the impersonation ends
+func NotifyEndedImpersonations() {
+	NewImpersonationHandler(db.DB).notifyEndedImpersonations()
+}
+
+func (ih *impersonationHandler) notifyEndedImpersonations() {
+	for _, impersonation := range ih.db.GetEndedImpersonations(time.Now()) {
+		ih.notifyImpersonation(impersonation)
+	}
+}
+
+func (ih *impersonationHandler) notifyImpersonation(impersonation db.Impersonation) {
+	if !ih.db.ClaimImpersonationNotification(impersonation.ID) {
+		return
+	}
+
+	requests := len(ih.db.GetImpersonatedRequests(impersonation.Uuid))
+	content := fmt.Sprintf("A platform admin accessed your account with %s access to look into: %s. %d requests were made and logged.", impersonation.Scope, impersonation.Reason, requests)
+	notifications.Notify(impersonation.TargetPubKey, db.NotificationImpersonated, "An admin accessed your account", content, "")
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImpersonation(t *testing.T) {
+	start := func(ih *impersonationHandler, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/admin/impersonations", bytes.NewBufferString(body))
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "admin"))
+		rr := httptest.NewRecorder()
+		ih.StartImpersonation(rr, req)
+		return rr
+	}
+	encodeJwt := func(target string, admin string, impersonationId string, scope string, expires time.Time) (string, error) {
+		return target + ":" + admin + ":" + impersonationId + ":" + scope, nil
+	}
+
+	t.Run("Should test that a read only token is issued for a person", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ih := NewImpersonationHandler(mockDb)
+		ih.encodeJwt = encodeJwt
+		mockDb.On("GetPersonByPubkey", "target").Return(db.Person{ID: 1}).Once()
+		mockDb.On("CreateImpersonation", mock.MatchedBy(func(m db.Impersonation) bool {
+			return m.AdminPubKey == "admin" && m.TargetPubKey == "target" && m.Scope == db.ImpersonationRead &&
+				m.ExpiresAt != nil && time.Until(*m.ExpiresAt) > 14*time.Minute && time.Until(*m.ExpiresAt) <= 15*time.Minute
+		})).Return(db.Impersonation{Uuid: "imp", AdminPubKey: "admin", TargetPubKey: "target", Scope: db.ImpersonationRead}, nil).Once()
+
+		rr := start(ih, `{"pubkey": "target", "reason": "can't see their bounties"}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		response := map[string]interface{}{}
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "target:admin:imp:read", response["jwt"])
+	})
+
+	t.Run("Should test that invalid impersonations are rejected", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ih := NewImpersonationHandler(mockDb)
+		ih.encodeJwt = encodeJwt
+		mockDb.On("GetPersonByPubkey", "unknown").Return(db.Person{}).Once()
+
+		for body, code := range map[string]int{
+			`{"pubkey": "target"}`: http.StatusBadRequest,
+			`{"pubkey": "target", "reason": "debug", "scope": "admin"}`: http.StatusBadRequest,
+			`{"pubkey": "target", "reason": "debug", "minutes": 120}`:   http.StatusBadRequest,
+			`{"pubkey": "admin", "reason": "debug"}`:                    http.StatusBadRequest,
+			`{"pubkey": "unknown", "reason": "debug"}`:                  http.StatusNotFound,
+			`not json`: http.StatusNotAcceptable,
+		} {
+			assert.Equal(t, code, start(ih, body).Code, body)
+		}
+	})
+
+	t.Run("Should test that requests are logged until the impersonation ends", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ih := NewImpersonationHandler(mockDb)
+		expires := time.Now().Add(time.Minute)
+		expired := time.Now().Add(-time.Minute)
+		mockDb.On("GetImpersonationByUuid", "imp").Return(db.Impersonation{ID: 1, Uuid: "imp", AdminPubKey: "admin", TargetPubKey: "target", ExpiresAt: &expires}).Once()
+		mockDb.On("CreateImpersonatedRequest", db.ImpersonatedRequest{ImpersonationUuid: "imp", AdminPubKey: "admin", TargetPubKey: "target", Method: "GET", Path: "/people"}).Return(nil).Once()
+		mockDb.On("GetImpersonationByUuid", "expired").Return(db.Impersonation{ID: 2, Uuid: "expired", ExpiresAt: &expired}).Once()
+		mockDb.On("GetImpersonationByUuid", "failing").Return(db.Impersonation{ID: 3, Uuid: "failing", ExpiresAt: &expires}).Once()
+		mockDb.On("CreateImpersonatedRequest", mock.Anything).Return(errors.New("db down")).Once()
+
+		assert.True(t, ih.RecordImpersonatedRequest("imp", "GET", "/people"))
+		assert.False(t, ih.RecordImpersonatedRequest("expired", "GET", "/people"))
+		assert.False(t, ih.RecordImpersonatedRequest("failing", "GET", "/people"))
+	})
+
+	t.Run("Should test that the person is notified once when it ends", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		ih := NewImpersonationHandler(mockDb)
+		ended := db.Impersonation{ID: 1, Uuid: "imp", TargetPubKey: "target", Scope: db.ImpersonationRead, Reason: "debug"}
+		mockDb.On("EndImpersonation", "imp").Return(ended, nil).Once()
+		mockDb.On("EndImpersonation", "imp").Return(db.Impersonation{}, errors.New("impersonation has already ended")).Once()
+		mockDb.On("ClaimImpersonationNotification", uint(1)).Return(true).Once()
+		mockDb.On("ClaimImpersonationNotification", uint(1)).Return(false).Once()
+		mockDb.On("GetImpersonatedRequests", "imp").Return([]db.ImpersonatedRequest{{Method: "GET"}}).Once()
+		mockDb.On("GetEndedImpersonations", mock.Anything).Return([]db.Impersonation{ended}).Once()
+
+		end := func() int {
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("uuid", "imp")
+			req := httptest.NewRequest(http.MethodPost, "/admin/impersonations/imp/end", nil)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rr := httptest.NewRecorder()
+			ih.EndImpersonation(rr, req)
+			return rr.Code
+		}
+		assert.Equal(t, http.StatusOK, end())
+		assert.Equal(t, http.StatusNotFound, end())
+		ih.notifyEndedImpersonations()
+	})
+	t.Run("Should test that an impersonation can't take over the account", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		apiKeys := NewApiKeyHandler(mockDb)
+		account := NewAccountHandler(mockDb)
+		nostr := NewNostrHandler(mockDb)
+		sessions := NewAuthHandler(mockDb)
+
+		for _, route := range []struct {
+			method  string
+			path    string
+			handler http.HandlerFunc
+		}{
+			{http.MethodPost, "/person/api_keys", apiKeys.CreateApiKey},
+			{http.MethodPost, "/person/nostr", nostr.LinkNostrPubkey},
+			{http.MethodDelete, "/person/nostr/npub", nostr.UnlinkNostrPubkey},
+			{http.MethodGet, "/person/sessions", sessions.GetSessions},
+			{http.MethodPatch, "/person/sessions/session-uuid", sessions.RenameSession},
+			{http.MethodDelete, "/person/sessions/session-uuid", sessions.RevokeSession},
+			{http.MethodGet, "/person/export", account.ExportPersonData},
+			{http.MethodPost, "/person/delete_account", account.DeleteAccount},
+		} {
+			req := httptest.NewRequest(route.method, route.path, bytes.NewBufferString(`{}`))
+			ctx := context.WithValue(req.Context(), auth.ContextKey, "target")
+			ctx = context.WithValue(ctx, auth.ImpersonationKey, auth.Impersonation{Id: "imp_write", Actor: "admin"})
+			rr := httptest.NewRecorder()
+			route.handler(rr, req.WithContext(ctx))
+
+			assert.Equal(t, http.StatusForbidden, rr.Code, route.path)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// InvoiceWebhookSignatureHeader carries the hex HMAC-SHA256 of the body signed with INVOICE_WEBHOOK_SECRET
+const InvoiceWebhookSignatureHeader = "X-Webhook-Signature"
+
+type InvoiceWebhookPayload struct {
+	PaymentRequest string `json:"payment_request"`
+	Settled        bool   `json:"settled"`
+}
+
+// VerifyWebhookSignature checks the HMAC-SHA256 signature of a webhook body, a "sha256=" prefix is accepted
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+	signature = strings.TrimPrefix(signature, "sha256=")
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// InvoiceWebhook receives invoice settled callbacks from the relay, the settlement is
+// confirmed with the relay before the budget or bounty payment is applied
+func (h *bountyHandler) InvoiceWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if !VerifyWebhookSignature(config.InvoiceWebhookSecret, body, r.Header.Get(InvoiceWebhookSignatureHeader)) {
+		fmt.Println("[invoices] invalid webhook signature")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	payload := InvoiceWebhookPayload{}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.PaymentRequest == "" {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if h.db.GetInvoice(payload.PaymentRequest).PaymentRequest == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Invoice not found")
+		return
+	}
+
+	if !payload.Settled {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode("Invoice not settled")
+		return
+	}
+
+	invoiceRes, invoiceErr := h.GetLightningInvoice(payload.PaymentRequest)
+	if invoiceErr.Error != "" || !invoiceRes.Response.Settled {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("Invoice is not settled on the relay")
+		return
+	}
+
+	if err := h.settleInvoice(payload.PaymentRequest); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Invoice settled")
+}
+
+// ReconcileInvoices settles the invoices whose webhook was missed and drops the expired ones
+func ReconcileInvoices() {
+	NewBountyHandler(http.DefaultClient, db.DB).reconcileInvoices()
+}
+
+func (h *bountyHandler) reconcileInvoices() {
+	for _, invoice := range h.db.GetUnsettledInvoices() {
+		invoiceRes, invoiceErr := h.GetLightningInvoice(invoice.PaymentRequest)
+		if invoiceErr.Error != "" {
+			continue
+		}
+
+		if invoiceRes.Response.Settled {
+			if err := h.settleInvoice(invoice.PaymentRequest); err != nil {
+				fmt.Println("[invoices] could not settle invoice", err)
+			}
+		} else if utils.GetInvoiceExpired(invoice.PaymentRequest) {
+			h.db.DeleteInvoice(invoice.PaymentRequest)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	handlerMocks "github.com/stakwork/sphinx-tribes/handlers/mocks"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func signWebhook(secret string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"payment_request":"lnbc1"}`)
+	signature := signWebhook("secret", string(body))
+
+	assert.True(t, VerifyWebhookSignature("secret", body, signature))
+	assert.True(t, VerifyWebhookSignature("secret", body, "sha256="+signature))
+	assert.False(t, VerifyWebhookSignature("other", body, signature))
+	assert.False(t, VerifyWebhookSignature("", body, signature))
+	assert.False(t, VerifyWebhookSignature("secret", []byte(`{}`), signature))
+}
+
+func TestInvoiceWebhook(t *testing.T) {
+	config.InvoiceWebhookSecret = "webhook_secret"
+	defer func() { config.InvoiceWebhookSecret = "" }()
+
+	webhookRequest := func(bHandler *bountyHandler, body string, signature string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks/invoice", bytes.NewBufferString(body))
+		req.Header.Set(InvoiceWebhookSignatureHeader, signature)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.InvoiceWebhook).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a 401 is returned for an invalid signature", func(t *testing.T) {
+		bHandler := NewBountyHandler(handlerMocks.NewHttpClient(t), mocks.NewDatabase(t))
+		rr := webhookRequest(bHandler, `{"payment_request":"lnbc1","settled":true}`, "bad")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a 404 is returned for an unknown invoice", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(handlerMocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetInvoice", "lnbc1").Return(db.NewInvoiceList{}).Once()
+
+		body := `{"payment_request":"lnbc1","settled":true}`
+		rr := webhookRequest(bHandler, body, signWebhook("webhook_secret", body))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Should test that a settled budget invoice tops up the workspace budget", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockHttpClient := handlerMocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+		invoice := db.NewInvoiceList{PaymentRequest: "lnbc1", Type: "BUDGET", WorkspaceUuid: "workspace_uuid", OwnerPubkey: "owner"}
+		mockDb.On("GetInvoice", "lnbc1").Return(invoice)
+		mockDb.On("ProcessUpdateBudget", invoice).Return(nil).Once()
+
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Method == http.MethodGet && req.URL.Query().Get("payment_request") == "lnbc1"
+		})).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"success": true, "response": {"settled": true, "payment_request": "lnbc1"}}`)),
+		}, nil).Once()
+
+		body := `{"payment_request":"lnbc1","settled":true}`
+		rr := webhookRequest(bHandler, body, signWebhook("webhook_secret", body))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	settledOnRelay := func(mockHttpClient *handlerMocks.HttpClient) {
+		mockHttpClient.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"success": true, "response": {"settled": true, "payment_request": "lnbc1"}}`)),
+		}, nil).Once()
+	}
+
+	t.Run("Should test that a budget invoice claimed by another caller is not credited again", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockHttpClient := handlerMocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+		invoice := db.NewInvoiceList{PaymentRequest: "lnbc1", Type: "BUDGET", WorkspaceUuid: "workspace_uuid"}
+		mockDb.On("GetInvoice", "lnbc1").Return(invoice)
+		mockDb.On("ProcessUpdateBudget", invoice).Return(db.ErrInvoiceSettled).Once()
+		settledOnRelay(mockHttpClient)
+
+		body := `{"payment_request":"lnbc1","settled":true}`
+		rr := webhookRequest(bHandler, body, signWebhook("webhook_secret", body))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that the hunter is only paid by the caller that claims the keysend invoice", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockHttpClient := handlerMocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		backend := &keysendTestBackend{Backend: bHandler.lnBackend}
+		bHandler.lnBackend = backend
+
+		mockDb.On("GetInvoice", "lnbc1").Return(db.NewInvoiceList{PaymentRequest: "lnbc1", Type: "KEYSEND"})
+		mockDb.On("ClaimInvoice", "lnbc1").Return(db.ErrInvoiceSettled).Once()
+		settledOnRelay(mockHttpClient)
+
+		body := `{"payment_request":"lnbc1","settled":true}`
+		rr := webhookRequest(bHandler, body, signWebhook("webhook_secret", body))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 0, backend.calls)
+	})
+
+	t.Run("Should test that a keysend invoice is released when the payment fails", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockHttpClient := handlerMocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		backend := &keysendTestBackend{Backend: bHandler.lnBackend, err: errors.New("connection reset")}
+		bHandler.lnBackend = backend
+
+		mockDb.On("GetInvoice", "lnbc1").Return(db.NewInvoiceList{PaymentRequest: "lnbc1", Type: "KEYSEND"})
+		mockDb.On("ClaimInvoice", "lnbc1").Return(nil).Once()
+		mockDb.On("GetUserInvoiceData", "lnbc1").Return(db.UserInvoiceData{Amount: 100, UserPubkey: "hunter"}).Once()
+		mockDb.On("ReleaseInvoice", "lnbc1").Return(nil).Once()
+		settledOnRelay(mockHttpClient)
+
+		body := `{"payment_request":"lnbc1","settled":true}`
+		rr := webhookRequest(bHandler, body, signWebhook("webhook_secret", body))
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Equal(t, 1, backend.calls)
+	})
+
+	t.Run("Should test that a 409 is returned when the relay does not confirm the settlement", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockHttpClient := handlerMocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+		mockDb.On("GetInvoice", "lnbc1").Return(db.NewInvoiceList{PaymentRequest: "lnbc1", Type: "BUDGET"}).Once()
+		mockHttpClient.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"success": true, "response": {"settled": false, "payment_request": "lnbc1"}}`)),
+		}, nil).Once()
+
+		body := `{"payment_request":"lnbc1","settled":true}`
+		rr := webhookRequest(bHandler, body, signWebhook("webhook_secret", body))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
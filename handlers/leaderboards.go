@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const leaderboardSize = 100
+
+var leaderboardWindows = []db.LeaderboardWindow{db.LeaderboardWeekly, db.LeaderboardMonthly, db.LeaderboardAllTime}
+
+// RecomputeLeaderboards aggregates the platform wide leaderboards of every window into the leaderboard table
+func RecomputeLeaderboards() {
+	recomputeLeaderboards(db.DB, time.Now())
+}
+
+func recomputeLeaderboards(database db.Database, now time.Time) {
+	for _, kind := range []db.LeaderboardKind{db.LeaderboardEarners, db.LeaderboardWorkspaces} {
+		for _, window := range leaderboardWindows {
+			entries := database.AggregateLeaderboard(kind, db.LeaderboardSince(window, now), leaderboardSize)
+			if err := database.ReplaceLeaderboard(kind, window, entries); err != nil {
+				fmt.Println("[scheduler] could not store leaderboard", kind, window, err)
+			}
+		}
+	}
+}
+
+// GetLeaderboard returns the top earners or the top paying workspaces of a ?window=, weekly,
+// monthly or all_time which is the default
+func (h *bountyHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	kind := db.LeaderboardKind(chi.URLParam(r, "kind"))
+	if kind != db.LeaderboardEarners && kind != db.LeaderboardWorkspaces {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Leaderboard not found")
+		return
+	}
+
+	window := db.LeaderboardWindow(r.URL.Query().Get("window"))
+	switch window {
+	case "":
+		window = db.LeaderboardAllTime
+	case db.LeaderboardWeekly, db.LeaderboardMonthly, db.LeaderboardAllTime:
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("window must be weekly, monthly or all_time")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.db.GetLeaderboard(kind, window))
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLeaderboards(t *testing.T) {
+	now := time.Date(2025, time.March, 31, 12, 0, 0, 0, time.UTC)
+
+	getLeaderboard := func(bHandler *bountyHandler, url string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Get("/gobounties/leaderboard/{kind}", bHandler.GetLeaderboard)
+
+		rr := httptest.NewRecorder()
+		ro.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, url, nil))
+		return rr
+	}
+
+	t.Run("Should test that every leaderboard window is recomputed", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		weekAgo := now.AddDate(0, 0, -7)
+		earners := []db.LeaderboardEntry{{Key: "hunter", Amount: 5000, Rank: 1}}
+
+		mockDb.On("AggregateLeaderboard", db.LeaderboardEarners, &weekAgo, leaderboardSize).Return(earners)
+		mockDb.On("AggregateLeaderboard", mock.Anything, mock.Anything, leaderboardSize).Return([]db.LeaderboardEntry{})
+		mockDb.On("ReplaceLeaderboard", db.LeaderboardEarners, db.LeaderboardWeekly, earners).Return(nil).Once()
+		mockDb.On("ReplaceLeaderboard", mock.Anything, mock.Anything, []db.LeaderboardEntry{}).Return(nil).Times(5)
+
+		recomputeLeaderboards(mockDb, now)
+	})
+
+	t.Run("Should test that the weekly top earners are read from the leaderboard table", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetLeaderboard", db.LeaderboardEarners, db.LeaderboardWeekly).Return([]db.LeaderboardEntry{{Key: "hunter", Rank: 1}})
+
+		rr := getLeaderboard(bHandler, "/gobounties/leaderboard/earners?window=weekly")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"key":"hunter"`)
+	})
+
+	t.Run("Should test that the workspaces leaderboard defaults to all time", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetLeaderboard", db.LeaderboardWorkspaces, db.LeaderboardAllTime).Return([]db.LeaderboardEntry{})
+
+		rr := getLeaderboard(bHandler, "/gobounties/leaderboard/workspaces")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that an unknown leaderboard or window is refused", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+
+		assert.Equal(t, http.StatusNotFound, getLeaderboard(bHandler, "/gobounties/leaderboard/tribes").Code)
+		assert.Equal(t, http.StatusBadRequest, getLeaderboard(bHandler, "/gobounties/leaderboard/earners?window=daily").Code)
+	})
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fiatjaf/go-lnurl"
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/lightning"
+)
+
+const (
+	lnurlMinSendableMsat = 1000
+	lnurlMaxSendableMsat = 100000000000
+	lnurlCommentAllowed  = 255
+)
+
+type lnurlPayHandler struct {
+	db        db.Database
+	lnBackend lightning.Backend
+}
+
+type LnurlPayRequest struct {
+	Tag            string `json:"tag"`
+	Callback       string `json:"callback"`
+	MinSendable    int64  `json:"minSendable"`
+	MaxSendable    int64  `json:"maxSendable"`
+	Metadata       string `json:"metadata"`
+	CommentAllowed int    `json:"commentAllowed"`
+}
+
+type LnurlPayValues struct {
+	Pr            string              `json:"pr"`
+	Routes        []interface{}       `json:"routes"`
+	SuccessAction *LnurlSuccessAction `json:"successAction,omitempty"`
+}
+
+type LnurlSuccessAction struct {
+	Tag     string `json:"tag"`
+	Message string `json:"message"`
+}
+
+type LnurlError struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+type WorkspaceLnurlPay struct {
+	LightningAddress string `json:"lightning_address"`
+	Lnurl            string `json:"lnurl"`
+}
+
+func NewLnurlPayHandler(httpClient HttpClient, database db.Database) *lnurlPayHandler {
+	return &lnurlPayHandler{
+		db:        database,
+		lnBackend: lightning.NewBackend(httpClient),
+	}
+}
+
+func lnurlBaseUrl(r *http.Request) string {
+	if strings.Contains(r.Host, "localhost") {
+		return "http://" + r.Host
+	}
+	return "https://" + r.Host
+}
+
+func lnurlFail(w http.ResponseWriter, status int, reason string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(LnurlError{Status: "ERROR", Reason: reason})
+}
+
+// lnurlMetadata is the metadata wallets show and the budget invoice description hash commits to
+func lnurlMetadata(workspace db.Workspace, address string) string {
+	metadata, _ := json.Marshal([][]string{
+		{"text/plain", fmt.Sprintf("Fund the %s bounty budget", workspace.Name)},
+		{"text/identifier", address},
+	})
+	return string(metadata)
+}
+
+// descriptionHashInvoicer is the lightning backend when it can create the invoices LNURL-pay wallets
+// accept, the ones committing to the hash of the metadata
+func (lh *lnurlPayHandler) descriptionHashInvoicer() (lightning.DescriptionHashInvoicer, bool) {
+	invoicer, ok := lh.lnBackend.(lightning.DescriptionHashInvoicer)
+	return invoicer, ok
+}
+
+func (lh *lnurlPayHandler) getFundableWorkspace(uuid string) (db.Workspace, bool) {
+	workspace := lh.db.GetWorkspaceByUuid(uuid)
+	return workspace, workspace.ID != 0 && !workspace.Deleted
+}
+
+// GetWorkspaceLnurlPay returns the lightning address and the static LNURL anyone can fund the workspace budget with
+func (lh *lnurlPayHandler) GetWorkspaceLnurlPay(w http.ResponseWriter, r *http.Request) {
+	if _, ok := lh.descriptionHashInvoicer(); !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("The lightning backend can't serve lightning addresses")
+		return
+	}
+	uuid := chi.URLParam(r, "uuid")
+	workspace, ok := lh.getFundableWorkspace(uuid)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+
+	encoded, err := lnurl.Encode(fmt.Sprintf("%s/.well-known/lnurlp/%s", lnurlBaseUrl(r), workspace.Uuid))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Could not encode LNURL")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(WorkspaceLnurlPay{
+		LightningAddress: fmt.Sprintf("%s@%s", workspace.Uuid, r.Host),
+		Lnurl:            strings.ToUpper(encoded),
+	})
+}
+
+// LnurlPayRequest is the first LNURL-pay step, it also serves the workspace lightning address
+func (lh *lnurlPayHandler) LnurlPayRequest(w http.ResponseWriter, r *http.Request) {
+	if _, ok := lh.descriptionHashInvoicer(); !ok {
+		lnurlFail(w, http.StatusNotFound, "The lightning backend can't serve lightning addresses")
+		return
+	}
+	uuid := chi.URLParam(r, "workspace_uuid")
+	workspace, ok := lh.getFundableWorkspace(uuid)
+	if !ok {
+		lnurlFail(w, http.StatusNotFound, "Workspace not found")
+		return
+	}
+	if IsWorkspaceArchived(workspace.Uuid) {
+		lnurlFail(w, http.StatusForbidden, "Workspace is archived")
+		return
+	}
+
+	address := fmt.Sprintf("%s@%s", workspace.Uuid, r.Host)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(LnurlPayRequest{
+		Tag:            "payRequest",
+		Callback:       fmt.Sprintf("%s/lnurlp/%s/callback", lnurlBaseUrl(r), workspace.Uuid),
+		MinSendable:    lnurlMinSendableMsat,
+		MaxSendable:    lnurlMaxSendableMsat,
+		Metadata:       lnurlMetadata(workspace, address),
+		CommentAllowed: lnurlCommentAllowed,
+	})
+}
+
+// LnurlPayCallback creates a budget invoice for the requested amount, it is settled
+// like any other budget invoice and the payer's comment is kept as the deposit memo
+func (lh *lnurlPayHandler) LnurlPayCallback(w http.ResponseWriter, r *http.Request) {
+	invoicer, ok := lh.descriptionHashInvoicer()
+	if !ok {
+		lnurlFail(w, http.StatusNotFound, "The lightning backend can't serve lightning addresses")
+		return
+	}
+	uuid := chi.URLParam(r, "workspace_uuid")
+	workspace, ok := lh.getFundableWorkspace(uuid)
+	if !ok {
+		lnurlFail(w, http.StatusNotFound, "Workspace not found")
+		return
+	}
+	if IsWorkspaceArchived(workspace.Uuid) {
+		lnurlFail(w, http.StatusForbidden, "Workspace is archived")
+		return
+	}
+
+	amountMsat, err := strconv.ParseInt(r.URL.Query().Get("amount"), 10, 64)
+	if err != nil || amountMsat < lnurlMinSendableMsat || amountMsat > lnurlMaxSendableMsat {
+		lnurlFail(w, http.StatusBadRequest, fmt.Sprintf("Amount must be between %d and %d millisatoshis", lnurlMinSendableMsat, lnurlMaxSendableMsat))
+		return
+	}
+	if amountMsat%1000 != 0 {
+		lnurlFail(w, http.StatusBadRequest, "Amount must be a whole number of satoshis")
+		return
+	}
+	amount := uint(amountMsat / 1000)
+
+	comment := r.URL.Query().Get("comment")
+	if len(comment) > lnurlCommentAllowed {
+		lnurlFail(w, http.StatusBadRequest, fmt.Sprintf("Comment must be at most %d characters", lnurlCommentAllowed))
+		return
+	}
+
+	metadata := lnurlMetadata(workspace, fmt.Sprintf("%s@%s", workspace.Uuid, r.Host))
+	invoice, err := invoicer.CreateInvoiceWithDescriptionHash(amount, metadata)
+	if err != nil || invoice.PaymentRequest == "" {
+		log.Printf("[lnurlp] Invoice creation failed: %v", err)
+		lnurlFail(w, http.StatusBadGateway, "Could not create invoice")
+		return
+	}
+
+	now := time.Now()
+	paymentHistory := db.NewPaymentHistory{
+		Amount:        amount,
+		WorkspaceUuid: workspace.Uuid,
+		PaymentType:   db.Deposit,
+		Memo:          comment,
+		Created:       &now,
+		Updated:       &now,
+		Status:        false,
+	}
+	newInvoice := db.NewInvoiceList{
+		PaymentRequest: invoice.PaymentRequest,
+		Type:           db.InvoiceType("BUDGET"),
+		WorkspaceUuid:  workspace.Uuid,
+		Created:        &now,
+		Updated:        &now,
+		Status:         false,
+	}
+	if err := lh.db.ProcessBudgetInvoice(paymentHistory, newInvoice); err != nil {
+		log.Printf("[lnurlp] Could not store budget invoice: %s", err)
+		lnurlFail(w, http.StatusInternalServerError, "Could not create invoice")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(LnurlPayValues{
+		Pr:     invoice.PaymentRequest,
+		Routes: []interface{}{},
+		SuccessAction: &LnurlSuccessAction{
+			Tag:     "message",
+			Message: fmt.Sprintf("Thanks for funding %s", workspace.Name),
+		},
+	})
+}
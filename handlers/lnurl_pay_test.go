@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/lightning"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type lnurlTestBackend struct {
+	lightning.Backend
+	amount      uint
+	description string
+}
+
+func (b *lnurlTestBackend) CreateInvoiceWithDescriptionHash(amount uint, description string) (lightning.Invoice, error) {
+	b.amount = amount
+	b.description = description
+	return lightning.Invoice{PaymentRequest: "lnbc-budget", Amount: amount}, nil
+}
+
+func TestLnurlPay(t *testing.T) {
+	workspace := db.Workspace{ID: 1, Uuid: "workspace-uuid", Name: "Workspace"}
+
+	t.Run("Should test that the pay request points the wallet to the workspace callback", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		lh := NewLnurlPayHandler(nil, mockDb)
+		lh.lnBackend = &lnurlTestBackend{}
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace)
+
+		ro := chi.NewRouter()
+		ro.Get("/.well-known/lnurlp/{workspace_uuid}", lh.LnurlPayRequest)
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/.well-known/lnurlp/workspace-uuid", nil)
+		req.Host = "tribes.sphinx.chat"
+		ro.ServeHTTP(rr, req)
+
+		payRequest := LnurlPayRequest{}
+		json.Unmarshal(rr.Body.Bytes(), &payRequest)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "payRequest", payRequest.Tag)
+		assert.Equal(t, "https://tribes.sphinx.chat/lnurlp/workspace-uuid/callback", payRequest.Callback)
+		assert.Contains(t, payRequest.Metadata, "workspace-uuid@tribes.sphinx.chat")
+	})
+
+	t.Run("Should test that a deleted workspace can't be funded", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		lh := NewLnurlPayHandler(nil, mockDb)
+		lh.lnBackend = &lnurlTestBackend{}
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(db.Workspace{ID: 1, Uuid: workspace.Uuid, Deleted: true})
+
+		ro := chi.NewRouter()
+		ro.Get("/lnurlp/{workspace_uuid}/callback", lh.LnurlPayCallback)
+
+		rr := httptest.NewRecorder()
+		ro.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/lnurlp/workspace-uuid/callback?amount=10000", nil))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"status":"ERROR"`)
+	})
+
+	t.Run("Should test that an amount below the minimum is refused", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		lh := NewLnurlPayHandler(nil, mockDb)
+		lh.lnBackend = &lnurlTestBackend{}
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace)
+
+		ro := chi.NewRouter()
+		ro.Get("/lnurlp/{workspace_uuid}/callback", lh.LnurlPayCallback)
+
+		rr := httptest.NewRecorder()
+		ro.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/lnurlp/workspace-uuid/callback?amount=500", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a backend without description hash invoices serves no lightning address", func(t *testing.T) {
+		lh := NewLnurlPayHandler(nil, mocks.NewDatabase(t))
+		lh.lnBackend = &keysendTestBackend{}
+
+		ro := chi.NewRouter()
+		ro.Get("/.well-known/lnurlp/{workspace_uuid}", lh.LnurlPayRequest)
+		ro.Get("/lnurlp/{workspace_uuid}/callback", lh.LnurlPayCallback)
+		ro.Get("/workspaces/{uuid}/lnurlp", lh.GetWorkspaceLnurlPay)
+
+		for _, path := range []string{"/.well-known/lnurlp/workspace-uuid", "/lnurlp/workspace-uuid/callback?amount=21000", "/workspaces/workspace-uuid/lnurlp"} {
+			rr := httptest.NewRecorder()
+			ro.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusNotFound, rr.Code, path)
+		}
+	})
+
+	t.Run("Should test that the callback stores a budget invoice with the comment as memo", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		lh := NewLnurlPayHandler(nil, mockDb)
+		backend := &lnurlTestBackend{}
+		lh.lnBackend = backend
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace)
+		mockDb.On("ProcessBudgetInvoice", mock.MatchedBy(func(history db.NewPaymentHistory) bool {
+			return history.Amount == 21 && history.Memo == "keep building" && history.WorkspaceUuid == workspace.Uuid && history.PaymentType == db.Deposit
+		}), mock.MatchedBy(func(invoice db.NewInvoiceList) bool {
+			return invoice.PaymentRequest == "lnbc-budget" && invoice.Type == "BUDGET" && invoice.WorkspaceUuid == workspace.Uuid
+		})).Return(nil)
+
+		ro := chi.NewRouter()
+		ro.Get("/lnurlp/{workspace_uuid}/callback", lh.LnurlPayCallback)
+
+		rr := httptest.NewRecorder()
+		ro.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/lnurlp/workspace-uuid/callback?amount=21000&comment=keep+building", nil))
+
+		payValues := LnurlPayValues{}
+		json.Unmarshal(rr.Body.Bytes(), &payValues)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "lnbc-budget", payValues.Pr)
+		assert.Equal(t, uint(21), backend.amount)
+		assert.Contains(t, backend.description, "workspace-uuid@")
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/media"
 )
 
 func MemeImageUpload(w http.ResponseWriter, r *http.Request) {
@@ -36,6 +37,20 @@ func MemeImageUpload(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
+	// the meme server gets images only, small enough to be decoded
+	if header.Size > maxUploadSize {
+		http.Error(w, "File is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if _, err := media.Validate(file); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		http.Error(w, "Unable to read file", http.StatusInternalServerError)
+		return
+	}
+
 	// Check if uploads directory exists or create it
 	CreateUploadsDirectory(dirName)
 
@@ -9,8 +9,11 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -136,6 +139,17 @@ func (mh *metricHandler) BountyMetrics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if computedAt := mh.db.GetMetricsRollupTime(); computedAt != nil {
+		bountyMetrics, err := mh.db.GetRollupBountyMetrics(request, workspace)
+		if err == nil {
+			bountyMetrics.ComputedAt = computedAt
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(bountyMetrics)
+			return
+		}
+		fmt.Println("[metrics] could not read the roll-ups", err)
+	}
+
 	metricsKey := fmt.Sprintf("metrics - %s - %s", request.StartDate, request.EndDate)
 	/**
 	check redis if cache id available for the date range
@@ -189,6 +203,69 @@ func (mh *metricHandler) BountyMetrics(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(bountyMetrics)
 }
 
+// BountyFunnelMetrics shows how many bounties created in a date range reached each stage,
+// from posted to paid, and the median time they spent in it
+func (mh *metricHandler) BountyFunnelMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	workspace := r.URL.Query().Get("workspace")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.PaymentDateRange{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+
+	err = json.Unmarshal(body, &request)
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Request body not accepted")
+		return
+	}
+
+	rows := mh.db.GetBountyFunnelRows(request, workspace)
+	funnel := db.SummarizeBountyFunnel(rows)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(funnel)
+}
+
+// maxMetricsBuckets keeps a time series to about a year of days
+const maxMetricsBuckets = 366
+
+// MetricsTimeSeries returns the bounties created, sats paid and new people per ?interval= day or week
+// between the ?start_date= and ?end_date= unix timestamps, the last 30 days by default
+func (mh *metricHandler) MetricsTimeSeries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	keys := r.URL.Query()
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	interval, from, to, ok := metricsWindow(w, keys)
+	if !ok {
+		return
+	}
+
+	values := mh.db.GetMetricsSeriesValues(from, to, interval, keys.Get("workspace"))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.MetricsTimeSeries{
+		Interval: interval,
+		From:     from.Format(time.RFC3339),
+		To:       to.Format(time.RFC3339),
+		Points:   db.BuildMetricsTimeSeries(from, to, interval, values),
+	})
+}
+
 func (mh *metricHandler) MetricsBounties(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
@@ -210,6 +287,11 @@ func (mh *metricHandler) MetricsBounties(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if strings.Contains(r.Header.Get("Accept"), "text/csv") {
+		mh.streamMetricsCsv(w, r, request)
+		return
+	}
+
 	metricBounties := mh.db.GetBountiesByDateRange(request, r)
 	metricBountiesData := mh.GetMetricsBountiesData(metricBounties)
 
@@ -217,6 +299,100 @@ func (mh *metricHandler) MetricsBounties(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(metricBountiesData)
 }
 
+// ExportMetricsBounties streams every bounty matching the metrics filters as CSV, it is
+// the same as asking /bounties for text/csv
+func (mh *metricHandler) ExportMetricsBounties(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.PaymentDateRange{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+
+	err = json.Unmarshal(body, &request)
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Request body not accepted")
+		return
+	}
+
+	mh.streamMetricsCsv(w, r, request)
+}
+
+// streamMetricsCsv writes the metrics bounties with the columns of the uploaded metrics csv,
+// the pagination params are ignored so the whole date range is exported
+func (mh *metricHandler) streamMetricsCsv(w http.ResponseWriter, r *http.Request, request db.PaymentDateRange) {
+	if _, err := strconv.ParseInt(request.StartDate, 10, 64); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("start_date must be a unix timestamp")
+		return
+	}
+	if _, err := strconv.ParseInt(request.EndDate, 10, 64); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("end_date must be a unix timestamp")
+		return
+	}
+
+	// a provider or hunter usually has many bounties in a range, so the names are looked up once
+	aliases := map[string]string{}
+	alias := func(pubkey string) string {
+		if pubkey == "" {
+			return ""
+		}
+		if _, ok := aliases[pubkey]; !ok {
+			aliases[pubkey] = mh.db.GetPersonByPubkey(pubkey).OwnerAlias
+		}
+		return aliases[pubkey]
+	}
+	workspaces := map[string]string{}
+	workspaceName := func(uuid string) string {
+		if _, ok := workspaces[uuid]; !ok {
+			workspaces[uuid] = mh.db.GetWorkspaceByUuid(uuid).Name
+		}
+		return workspaces[uuid]
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="metrics-%s-%s.csv"`, request.StartDate, request.EndDate))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"DatePosted", "Workspace", "BountyAmount", "Provider", "Hunter", "BountyTitle", "BountyLink", "BountyStatus", "DateAssigned", "DatePaid"})
+
+	err := mh.db.StreamBountiesByDateRange(request, r, func(bounty db.NewBounty) error {
+		status := "Open"
+		if bounty.Paid {
+			status = "Paid"
+		} else if bounty.Assignee != "" {
+			status = "Assigned"
+		}
+
+		posted := time.Unix(bounty.Created, 0)
+		return writer.Write([]string{
+			exportTime(&posted),
+			workspaceName(bounty.WorkspaceUuid),
+			strconv.Itoa(int(bounty.Price)),
+			alias(bounty.OwnerID),
+			alias(bounty.Assignee),
+			bounty.Title,
+			bountyLink(bounty.ID),
+			status,
+			exportTime(bounty.AssignedDate),
+			exportTime(bounty.PaidDate),
+		})
+	})
+	writer.Flush()
+	if err != nil {
+		log.Printf("[metrics] csv export of %s - %s stopped: %v", request.StartDate, request.EndDate, err)
+	}
+}
+
 func (mh *metricHandler) MetricsBountiesCount(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
@@ -264,11 +440,51 @@ func (mh *metricHandler) MetricsBountiesProviders(w http.ResponseWriter, r *http
 		return
 	}
 
+	if computedAt := mh.db.GetMetricsRollupTime(); computedAt != nil {
+		bountiesProviders, err := mh.db.GetRollupBountiesProviders(request, r)
+		if err == nil {
+			w.Header().Set("X-Metrics-Computed-At", computedAt.UTC().Format(time.RFC3339))
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(bountiesProviders)
+			return
+		}
+		fmt.Println("[metrics] could not read the roll-ups", err)
+	}
+
 	bountiesProviders := mh.db.GetBountiesProviders(request, r)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(bountiesProviders)
 }
 
+// RollupMetrics recomputes the metrics roll-ups the bounty stats and providers are served from
+func RollupMetrics() {
+	if err := db.DB.RollupMetrics(time.Now()); err != nil {
+		fmt.Println("[scheduler] could not roll up the metrics", err)
+	}
+}
+
+// RefreshMetricsRollups recomputes the metrics roll-ups on demand instead of waiting for the nightly job
+func (mh *metricHandler) RefreshMetricsRollups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	now := time.Now()
+	if err := mh.db.RollupMetrics(now); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not roll up the metrics")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]time.Time{"computed_at": now})
+}
+
 func MetricsCsv(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
@@ -494,3 +710,55 @@ func getPresignedUrl(path string) (error, string) {
 
 	return nil, presignedUrl.URL
 }
+
+// metricsWindow reads the ?interval= of a time series, day or week, and its window between the
+// ?start_date= and ?end_date= unix timestamps, the last 30 days by default
+func metricsWindow(w http.ResponseWriter, keys url.Values) (db.MetricsInterval, time.Time, time.Time, bool) {
+	interval := db.MetricsInterval(keys.Get("interval"))
+	if interval == "" {
+		interval = db.MetricsDaily
+	}
+	if interval != db.MetricsDaily && interval != db.MetricsWeekly {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("interval must be day or week")
+		return "", time.Time{}, time.Time{}, false
+	}
+
+	to := time.Now().UTC()
+	if end := keys.Get("end_date"); end != "" {
+		unix, err := strconv.ParseInt(end, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("end_date must be a unix timestamp")
+			return "", time.Time{}, time.Time{}, false
+		}
+		to = time.Unix(unix, 0).UTC()
+	}
+
+	from := to.AddDate(0, 0, -30)
+	if start := keys.Get("start_date"); start != "" {
+		unix, err := strconv.ParseInt(start, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("start_date must be a unix timestamp")
+			return "", time.Time{}, time.Time{}, false
+		}
+		from = time.Unix(unix, 0).UTC()
+	}
+
+	if to.Before(from) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("end_date is before start_date")
+		return "", time.Time{}, time.Time{}, false
+	}
+	buckets := int(to.Sub(db.BucketStart(from, interval)).Hours()/24) + 1
+	if interval == db.MetricsWeekly {
+		buckets = buckets/7 + 1
+	}
+	if buckets > maxMetricsBuckets {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("the window can't span more than %d buckets", maxMetricsBuckets))
+		return "", time.Time{}, time.Time{}, false
+	}
+	return interval, from, to, true
+}
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
 	"fmt"
@@ -16,7 +18,9 @@ import (
 
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestBountyMetrics(t *testing.T) {
@@ -653,3 +657,253 @@ func TestMetricsBountiesProviders(t *testing.T) {
 	})
 
 }
+
+func TestBountyFunnelMetrics(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "admin_pubkey")
+	dateRange := db.PaymentDateRange{StartDate: "1700000000", EndDate: "1800000000"}
+
+	t.Run("Should test that a 401 is returned without a pubkey", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewMetricHandler(mockDb)
+		body, _ := json.Marshal(dateRange)
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/metrics/funnel", bytes.NewReader(body))
+		http.HandlerFunc(mh.BountyFunnelMetrics).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a 406 is returned for an invalid body", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewMetricHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/metrics/funnel", bytes.NewReader([]byte("{")))
+		http.HandlerFunc(mh.BountyFunnelMetrics).ServeHTTP(rr, req.WithContext(ctx))
+
+		assert.Equal(t, http.StatusNotAcceptable, rr.Code)
+	})
+
+	t.Run("Should test that the funnel of a workspace is returned with its medians", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewMetricHandler(mockDb)
+		body, _ := json.Marshal(dateRange)
+
+		created := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+		assigned := created.Add(2 * time.Hour)
+		proof := assigned.Add(24 * time.Hour)
+		paid := proof.Add(time.Hour)
+		mockDb.On("GetBountyFunnelRows", dateRange, "workspace_uuid").Return([]db.BountyFunnelRow{
+			{ID: 1, Created: created.Unix(), Assignee: "hunter", AssignedDate: &assigned, ProofSubmitted: &proof, Paid: true, PaidDate: &paid},
+			{ID: 2, Created: created.Unix()},
+		})
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/metrics/funnel?workspace=workspace_uuid", bytes.NewReader(body))
+		http.HandlerFunc(mh.BountyFunnelMetrics).ServeHTTP(rr, req.WithContext(ctx))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var funnel db.BountyFunnel
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &funnel))
+		assert.Equal(t, []db.BountyFunnelStage{
+			{Stage: db.FunnelPosted, Count: 2, Percentage: 100, MedianSeconds: 7200},
+			{Stage: db.FunnelAssigned, Count: 1, Percentage: 50, MedianSeconds: 86400},
+			{Stage: db.FunnelProofSubmitted, Count: 1, Percentage: 50, MedianSeconds: 3600},
+			{Stage: db.FunnelPaid, Count: 1, Percentage: 50},
+		}, funnel.Stages)
+	})
+}
+
+func TestMetricsRollups(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "admin_pubkey")
+	dateRange := db.PaymentDateRange{StartDate: "1700000000", EndDate: "1800000000"}
+	computedAt := time.Date(2025, time.March, 1, 2, 0, 0, 0, time.UTC)
+
+	t.Run("Should test that the bounty stats are served from the roll-ups with their freshness", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewMetricHandler(mockDb)
+		body, _ := json.Marshal(dateRange)
+
+		mockDb.On("GetMetricsRollupTime").Return(&computedAt)
+		mockDb.On("GetRollupBountyMetrics", dateRange, "workspace_uuid").Return(db.BountyMetrics{BountiesPosted: 4, BountiesPaid: 2}, nil)
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/metrics/bounty_stats?workspace=workspace_uuid", bytes.NewReader(body))
+		http.HandlerFunc(mh.BountyMetrics).ServeHTTP(rr, req.WithContext(ctx))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var res db.BountyMetrics
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, int64(4), res.BountiesPosted)
+		assert.Equal(t, int64(2), res.BountiesPaid)
+		assert.Equal(t, computedAt, *res.ComputedAt)
+	})
+
+	t.Run("Should test that the providers are served from the roll-ups", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewMetricHandler(mockDb)
+		body, _ := json.Marshal(dateRange)
+
+		mockDb.On("GetMetricsRollupTime").Return(&computedAt)
+		mockDb.On("GetRollupBountiesProviders", dateRange, mock.Anything).Return([]db.Person{{OwnerPubKey: "provider"}}, nil)
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/metrics/bounties/providers?Paid=true", bytes.NewReader(body))
+		http.HandlerFunc(mh.MetricsBountiesProviders).ServeHTTP(rr, req.WithContext(ctx))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "2025-03-01T02:00:00Z", rr.Header().Get("X-Metrics-Computed-At"))
+		assert.Contains(t, rr.Body.String(), `"owner_pubkey":"provider"`)
+	})
+
+	t.Run("Should test that the roll-ups can be recomputed on demand", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewMetricHandler(mockDb)
+		mockDb.On("RollupMetrics", mock.AnythingOfType("time.Time")).Return(nil)
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/metrics/rollup", nil)
+		http.HandlerFunc(mh.RefreshMetricsRollups).ServeHTTP(rr, req.WithContext(ctx))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"computed_at"`)
+	})
+
+	t.Run("Should test that a failed roll up returns a 500", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewMetricHandler(mockDb)
+		mockDb.On("RollupMetrics", mock.AnythingOfType("time.Time")).Return(errors.New("db down"))
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/metrics/rollup", nil)
+		http.HandlerFunc(mh.RefreshMetricsRollups).ServeHTTP(rr, req.WithContext(ctx))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestExportMetricsBounties(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "admin_pubkey")
+	dateRange := db.PaymentDateRange{StartDate: "1740787200", EndDate: "1743465599"}
+	paidDate := time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC)
+
+	stream := func(args mock.Arguments) {
+		fn := args.Get(2).(func(db.NewBounty) error)
+		fn(db.NewBounty{ID: 1, Title: "Open bounty", OwnerID: "provider", WorkspaceUuid: "workspace_uuid", Price: 1000, Created: 1740787200})
+		fn(db.NewBounty{ID: 2, Title: "Paid bounty", OwnerID: "provider", Assignee: "hunter", WorkspaceUuid: "workspace_uuid", Price: 2000, Created: 1740873600, Paid: true, PaidDate: &paidDate})
+	}
+
+	t.Run("Should test that text/csv on /bounties streams the bounties as csv", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewMetricHandler(mockDb)
+		body, _ := json.Marshal(dateRange)
+
+		mockDb.On("StreamBountiesByDateRange", dateRange, mock.Anything, mock.Anything).Run(stream).Return(nil)
+		mockDb.On("GetPersonByPubkey", "provider").Return(db.Person{OwnerAlias: "Provider"}).Once()
+		mockDb.On("GetPersonByPubkey", "hunter").Return(db.Person{OwnerAlias: "Hunter"}).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Name: "Workspace"}).Once()
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/metrics/bounties?workspace=workspace_uuid", bytes.NewReader(body))
+		req.Header.Set("Accept", "text/csv")
+		http.HandlerFunc(mh.MetricsBounties).ServeHTTP(rr, req.WithContext(ctx))
+
+		lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+		assert.Len(t, lines, 3)
+		assert.Equal(t, "DatePosted,Workspace,BountyAmount,Provider,Hunter,BountyTitle,BountyLink,BountyStatus,DateAssigned,DatePaid", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "2025-03-01T00:00:00Z,Workspace,1000,Provider,,Open bounty,"))
+		assert.True(t, strings.HasSuffix(lines[2], ",Paid,,2025-03-20T00:00:00Z"))
+	})
+
+	t.Run("Should test that the export variant streams csv without an Accept header", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewMetricHandler(mockDb)
+		body, _ := json.Marshal(dateRange)
+
+		mockDb.On("StreamBountiesByDateRange", dateRange, mock.Anything, mock.Anything).Return(nil)
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/metrics/bounties/export", bytes.NewReader(body))
+		http.HandlerFunc(mh.ExportMetricsBounties).ServeHTTP(rr, req.WithContext(ctx))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), "metrics-1740787200-1743465599.csv")
+	})
+
+	t.Run("Should test that a date range that is not unix timestamps is refused", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewMetricHandler(mockDb)
+		body, _ := json.Marshal(db.PaymentDateRange{StartDate: "2025-03-01", EndDate: "2025-03-31"})
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/metrics/bounties/export", bytes.NewReader(body))
+		http.HandlerFunc(mh.ExportMetricsBounties).ServeHTTP(rr, req.WithContext(ctx))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestMetricsTimeSeries(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "admin_pubkey")
+
+	getTimeSeries := func(mh *metricHandler, url string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		http.HandlerFunc(mh.MetricsTimeSeries).ServeHTTP(rr, req.WithContext(ctx))
+		return rr
+	}
+
+	t.Run("Should test that weekly buckets of a workspace are returned", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewMetricHandler(mockDb)
+		from := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC)
+
+		mockDb.On("GetMetricsSeriesValues", from, to, db.MetricsWeekly, "workspace_uuid").Return([]db.MetricsSeriesValue{
+			{Series: "sats_paid", Bucket: time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC), Value: 2500},
+		})
+
+		rr := getTimeSeries(mh, fmt.Sprintf("/metrics/timeseries?interval=week&workspace=workspace_uuid&start_date=%d&end_date=%d", from.Unix(), to.Unix()))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var res db.MetricsTimeSeries
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, db.MetricsWeekly, res.Interval)
+		assert.Equal(t, []db.MetricsTimeSeriesPoint{
+			{Bucket: "2025-03-03"},
+			{Bucket: "2025-03-10", SatsPaid: 2500},
+		}, res.Points)
+	})
+
+	t.Run("Should test that the last 30 days are returned by day by default", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewMetricHandler(mockDb)
+		mockDb.On("GetMetricsSeriesValues", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), db.MetricsDaily, "").Return([]db.MetricsSeriesValue{})
+
+		rr := getTimeSeries(mh, "/metrics/timeseries")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var res db.MetricsTimeSeries
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Len(t, res.Points, 31)
+	})
+
+	t.Run("Should test that an unknown interval is refused", func(t *testing.T) {
+		mh := NewMetricHandler(mocks.NewDatabase(t))
+
+		rr := getTimeSeries(mh, "/metrics/timeseries?interval=month")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a window of more than a year of days is refused", func(t *testing.T) {
+		mh := NewMetricHandler(mocks.NewDatabase(t))
+
+		rr := getTimeSeries(mh, "/metrics/timeseries?start_date=1600000000&end_date=1700000000")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,505 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/notifications"
+)
+
+// reportDetailsMaxLength keeps reports and appeals to something a moderator reads
+const reportDetailsMaxLength = 2000
+
+type moderationHandler struct {
+	db db.Database
+}
+
+func NewModerationHandler(database db.Database) *moderationHandler {
+	return &moderationHandler{
+		db: database,
+	}
+}
+
+func validReportTargetType(targetType db.ReportTargetType) bool {
+	switch targetType {
+	case db.ReportTribe, db.ReportBot, db.ReportPerson, db.ReportBounty:
+		return true
+	}
+	return false
+}
+
+func validReportReason(reason db.ReportReason) bool {
+	switch reason {
+	case db.ReportScam, db.ReportSpam, db.ReportAbuse, db.ReportImpersonation, db.ReportIllegal, db.ReportOther:
+		return true
+	}
+	return false
+}
+
+func parseReportStatus(status string) (db.ReportStatus, bool) {
+	switch db.ReportStatus(status) {
+	case "", db.ReportOpen:
+		return db.ReportOpen, true
+	case db.ReportActioned, db.ReportDismissed:
+		return db.ReportStatus(status), true
+	}
+	return "", false
+}
+
+func parseAppealStatus(status string) (db.AppealStatus, bool) {
+	switch db.AppealStatus(status) {
+	case "", db.AppealOpen:
+		return db.AppealOpen, true
+	case db.AppealUpheld, db.AppealOverturned:
+		return db.AppealStatus(status), true
+	}
+	return "", false
+}
+
+func reportTargetLink(targetType db.ReportTargetType, targetId string) string {
+	return fmt.Sprintf("%s/%s/%s", config.Host, targetType, targetId)
+}
+
+// CreateReport flags a tribe, bot, person or bounty for the moderators
+func (mh *moderationHandler) CreateReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[moderation] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.ContentReportRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	request.TargetId = strings.TrimSpace(request.TargetId)
+	request.Details = strings.TrimSpace(request.Details)
+	if !validReportTargetType(request.TargetType) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("target_type must be tribe, bot, person or bounty")
+		return
+	}
+	if !validReportReason(request.Reason) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("reason must be scam, spam, abuse, impersonation, illegal or other")
+		return
+	}
+	if request.Reason == db.ReportOther && request.Details == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Details are required for other reports")
+		return
+	}
+	if len(request.Details) > reportDetailsMaxLength {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Details can't be longer than %d characters", reportDetailsMaxLength))
+		return
+	}
+
+	owner, ok := mh.db.GetReportTargetOwner(request.TargetType, request.TargetId)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Reported content not found")
+		return
+	}
+	if owner == pubKeyFromAuth {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("You can't report your own content")
+		return
+	}
+	if mh.db.GetOpenContentReport(request.TargetType, request.TargetId, pubKeyFromAuth).ID != 0 {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("You already reported this content")
+		return
+	}
+
+	report, err := mh.db.CreateContentReport(db.ContentReport{
+		TargetType:  request.TargetType,
+		TargetId:    request.TargetId,
+		OwnerPubKey: owner,
+		ReportedBy:  pubKeyFromAuth,
+		Reason:      request.Reason,
+		Details:     request.Details,
+	})
+	if err != nil {
+		log.Printf("[moderation] could not create report: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not create report")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
+
+// GetReportQueue is the moderation queue for the super admins, ?status= defaults to open and
+// ?target_type= narrows it to one type of content
+func (mh *moderationHandler) GetReportQueue(w http.ResponseWriter, r *http.Request) {
+	status, ok := parseReportStatus(r.URL.Query().Get("status"))
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid status")
+		return
+	}
+	targetType := db.ReportTargetType(r.URL.Query().Get("target_type"))
+	if targetType != "" && !validReportTargetType(targetType) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid target_type")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mh.db.GetContentReports(status, targetType))
+}
+
+// ResolveReport takes a moderation action on an open report, the other open reports on the same
+// content are closed with it
+func (mh *moderationHandler) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	report := mh.db.GetContentReportByUuid(chi.URLParam(r, "uuid"))
+	if report.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Report not found")
+		return
+	}
+	if report.Status != db.ReportOpen {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Report is not open")
+		return
+	}
+
+	request := db.ModerationActionRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	switch request.Action {
+	case db.ModerationHide, db.ModerationUnlist, db.ModerationDismiss:
+	case db.ModerationBanOwner:
+		if auth.AdminCheck(report.OwnerPubKey) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("A super admin can't be banned")
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Action must be hide, unlist, ban_owner or dismiss")
+		return
+	}
+
+	report.Action = request.Action
+	report.ModeratorNote = strings.TrimSpace(request.Note)
+	report.ResolvedBy = pubKeyFromAuth
+	report, err := mh.db.ResolveContentReport(report)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if report.Action == db.ModerationBanOwner {
+		auth.RefreshBannedPubkeys()
+	}
+
+	if report.Action != db.ModerationDismiss {
+		title := fmt.Sprintf("Your %s was moderated: %s", report.TargetType, strings.ReplaceAll(string(report.Action), "_", " "))
+		notifications.Notify(report.OwnerPubKey, db.NotificationContentModerated, title, report.ModeratorNote, reportTargetLink(report.TargetType, report.TargetId))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
+
+// CreateAppeal lets the owner of moderated content appeal the action of a report, once
+func (mh *moderationHandler) CreateAppeal(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[moderation] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	report := mh.db.GetContentReportByUuid(chi.URLParam(r, "uuid"))
+	if report.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Report not found")
+		return
+	}
+	if report.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the owner of the content can appeal")
+		return
+	}
+	if report.Status != db.ReportActioned {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Only actioned reports can be appealed")
+		return
+	}
+	if mh.db.GetContentAppealByReport(report.Uuid).ID != 0 {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("This report was already appealed")
+		return
+	}
+
+	request := db.ContentAppealRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+	request.Statement = strings.TrimSpace(request.Statement)
+	if request.Statement == "" || len(request.Statement) > reportDetailsMaxLength {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("A statement of at most %d characters is required", reportDetailsMaxLength))
+		return
+	}
+
+	appeal, err := mh.db.CreateContentAppeal(db.ContentAppeal{
+		ReportUuid:  report.Uuid,
+		OwnerPubKey: pubKeyFromAuth,
+		Statement:   request.Statement,
+	})
+	if err != nil {
+		log.Printf("[moderation] could not create appeal: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not create appeal")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(appeal)
+}
+
+// GetAppealQueue is the queue of appeals for the super admins, ?status= defaults to open
+func (mh *moderationHandler) GetAppealQueue(w http.ResponseWriter, r *http.Request) {
+	status, ok := parseAppealStatus(r.URL.Query().Get("status"))
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid status")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mh.db.GetContentAppeals(status))
+}
+
+// ResolveAppeal upholds the action of a report or overturns it, which undoes the action
+func (mh *moderationHandler) ResolveAppeal(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	appeal := mh.db.GetContentAppealByUuid(chi.URLParam(r, "uuid"))
+	if appeal.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Appeal not found")
+		return
+	}
+	if appeal.Status != db.AppealOpen {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Appeal is not open")
+		return
+	}
+
+	request := db.AppealResolutionRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+	if request.Decision != db.AppealUpheld && request.Decision != db.AppealOverturned {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Decision must be upheld or overturned")
+		return
+	}
+
+	report := mh.db.GetContentReportByUuid(appeal.ReportUuid)
+	appeal.Status = request.Decision
+	appeal.Resolution = strings.TrimSpace(request.Resolution)
+	appeal.ResolvedBy = pubKeyFromAuth
+	appeal, err := mh.db.ResolveContentAppeal(appeal, report)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if appeal.Status == db.AppealOverturned && report.Action == db.ModerationBanOwner {
+		auth.RefreshBannedPubkeys()
+	}
+
+	title := fmt.Sprintf("Your appeal was %s", appeal.Status)
+	notifications.Notify(appeal.OwnerPubKey, db.NotificationAppealResolved, title, appeal.Resolution, reportTargetLink(report.TargetType, report.TargetId))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(appeal)
+}
+
+// GetListingReviewQueue lists the tribes and bots the spam checks held for review, oldest first
+func (mh *moderationHandler) GetListingReviewQueue(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"tribes": mh.db.GetPendingReviewTribes(),
+		"bots":   mh.db.GetPendingReviewBots(),
+	})
+}
+
+// ReviewListing lists a tribe or bot held by the spam checks, or deletes it
+func (mh *moderationHandler) ReviewListing(w http.ResponseWriter, r *http.Request) {
+	targetType := db.ReportTargetType(chi.URLParam(r, "target_type"))
+	uuid := chi.URLParam(r, "uuid")
+	if targetType != db.ReportTribe && targetType != db.ReportBot {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Only tribes and bots are reviewed")
+		return
+	}
+
+	request := db.ListingReviewRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	if err := mh.db.ReviewListing(targetType, uuid, request.Approve); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	if owner, ok := mh.db.GetReportTargetOwner(targetType, uuid); ok {
+		title := fmt.Sprintf("Your %s was reviewed and listed", targetType)
+		if !request.Approve {
+			title = fmt.Sprintf("Your %s was reviewed and taken down", targetType)
+		}
+		notifications.Notify(owner, db.NotificationContentModerated, title, "", reportTargetLink(targetType, uuid))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(request)
+}
+
+// LoadBannedPubkeys reads the active bans for auth.IsBanned
+func (mh *moderationHandler) LoadBannedPubkeys() (map[string]time.Time, error) {
+	bans := map[string]time.Time{}
+	for _, ban := range mh.db.GetActivePubkeyBans() {
+		expires := time.Time{}
+		if ban.ExpiresAt != nil {
+			expires = *ban.ExpiresAt
+		}
+		// a pubkey has one active ban, a permanent one wins if it ever has two
+		if current, ok := bans[ban.PubKey]; ok && current.IsZero() {
+			continue
+		}
+		bans[ban.PubKey] = expires
+	}
+	return bans, nil
+}
+
+// GetPubkeyBans lists the active bans, or the audit of every ban with ?pubkey= for one pubkey or ?all=true
+func (mh *moderationHandler) GetPubkeyBans(w http.ResponseWriter, r *http.Request) {
+	pubkey := r.URL.Query().Get("pubkey")
+
+	w.WriteHeader(http.StatusOK)
+	if pubkey == "" && r.URL.Query().Get("all") != "true" {
+		json.NewEncoder(w).Encode(mh.db.GetActivePubkeyBans())
+		return
+	}
+	json.NewEncoder(w).Encode(mh.db.GetPubkeyBans(pubkey))
+}
+
+// BanPubkey bans a pubkey from the platform, until expires_at when it is set
+func (mh *moderationHandler) BanPubkey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	request := db.PubkeyBanRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	request.PubKey = strings.TrimSpace(request.PubKey)
+	request.Reason = strings.TrimSpace(request.Reason)
+	if request.PubKey == "" || request.Reason == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A pubkey and a reason are required")
+		return
+	}
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(time.Now()) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("expires_at must be in the future")
+		return
+	}
+	if auth.AdminCheck(request.PubKey) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A super admin can't be banned")
+		return
+	}
+
+	ban, err := mh.db.CreatePubkeyBan(db.PubkeyBan{
+		PubKey:    request.PubKey,
+		Reason:    request.Reason,
+		BannedBy:  pubKeyFromAuth,
+		ExpiresAt: request.ExpiresAt,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	auth.RefreshBannedPubkeys()
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ban)
+}
+
+// LiftPubkeyBan lifts the active ban of a pubkey, the ban is kept for the audit
+func (mh *moderationHandler) LiftPubkeyBan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	request := db.PubkeyBanLiftRequest{}
+	if r.ContentLength != 0 {
+		json.NewDecoder(r.Body).Decode(&request)
+	}
+
+	ban, err := mh.db.LiftPubkeyBan(chi.URLParam(r, "pubkey"), pubKeyFromAuth, strings.TrimSpace(request.Reason))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	auth.RefreshBannedPubkeys()
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ban)
+}
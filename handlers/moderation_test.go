@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateReport(t *testing.T) {
+	report := func(mh *moderationHandler, pubkey string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/report", bytes.NewBufferString(body))
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, pubkey))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mh.CreateReport).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that content can be reported with a reason code", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("GetReportTargetOwner", db.ReportTribe, "tribe_uuid").Return("owner", true).Once()
+		mockDb.On("GetOpenContentReport", db.ReportTribe, "tribe_uuid", "reporter").Return(db.ContentReport{}).Once()
+		mockDb.On("CreateContentReport", mock.MatchedBy(func(m db.ContentReport) bool {
+			return m.OwnerPubKey == "owner" && m.ReportedBy == "reporter" && m.Reason == db.ReportScam
+		})).Return(db.ContentReport{Uuid: "report", Status: db.ReportOpen}, nil).Once()
+
+		rr := report(mh, "reporter", `{"target_type": "tribe", "target_id": "tribe_uuid", "reason": "scam"}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that unknown types, reasons and content are rejected", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+
+		assert.Equal(t, http.StatusBadRequest, report(mh, "reporter", `{"target_type": "channel", "target_id": "1", "reason": "scam"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, report(mh, "reporter", `{"target_type": "bot", "target_id": "1", "reason": "rude"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, report(mh, "reporter", `{"target_type": "bot", "target_id": "1", "reason": "other"}`).Code)
+
+		mockDb.On("GetReportTargetOwner", db.ReportBounty, "404").Return("", false).Once()
+		assert.Equal(t, http.StatusNotFound, report(mh, "reporter", `{"target_type": "bounty", "target_id": "404", "reason": "spam"}`).Code)
+	})
+
+	t.Run("Should test that a pubkey can't report its own content or report twice", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("GetReportTargetOwner", db.ReportBot, "bot_uuid").Return("owner", true)
+
+		assert.Equal(t, http.StatusBadRequest, report(mh, "owner", `{"target_type": "bot", "target_id": "bot_uuid", "reason": "spam"}`).Code)
+
+		mockDb.On("GetOpenContentReport", db.ReportBot, "bot_uuid", "reporter").Return(db.ContentReport{ID: 1}).Once()
+		assert.Equal(t, http.StatusConflict, report(mh, "reporter", `{"target_type": "bot", "target_id": "bot_uuid", "reason": "spam"}`).Code)
+	})
+
+	t.Run("Should test that a 401 is returned without a pubkey", func(t *testing.T) {
+		mh := NewModerationHandler(mocks.NewDatabase(t))
+		assert.Equal(t, http.StatusUnauthorized, report(mh, "", `{}`).Code)
+	})
+}
+
+func TestResolveReport(t *testing.T) {
+	resolve := func(mh *moderationHandler, uuid string, body string) *httptest.ResponseRecorder {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", uuid)
+		req := httptest.NewRequest(http.MethodPost, "/admin/moderation/reports/"+uuid, bytes.NewBufferString(body))
+		req = req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, "admin"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mh.ResolveReport).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a moderator can ban the owner of reported content", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		open := db.ContentReport{ID: 1, Uuid: "report", TargetType: db.ReportTribe, TargetId: "tribe_uuid", OwnerPubKey: "owner", Status: db.ReportOpen}
+		mockDb.On("GetContentReportByUuid", "report").Return(open).Once()
+		mockDb.On("ResolveContentReport", mock.MatchedBy(func(m db.ContentReport) bool {
+			return m.Action == db.ModerationBanOwner && m.ResolvedBy == "admin" && m.ModeratorNote == "phishing links"
+		})).Return(db.ContentReport{Uuid: "report", Status: db.ReportActioned, Action: db.ModerationBanOwner}, nil).Once()
+
+		rr := resolve(mh, "report", `{"action": "ban_owner", "note": " phishing links "}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		returned := db.ContentReport{}
+		json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.Equal(t, db.ReportActioned, returned.Status)
+	})
+
+	t.Run("Should test that unknown actions and closed reports are rejected", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("GetContentReportByUuid", "report").Return(db.ContentReport{ID: 1, Uuid: "report", Status: db.ReportOpen}).Once()
+		assert.Equal(t, http.StatusBadRequest, resolve(mh, "report", `{"action": "delete"}`).Code)
+
+		mockDb.On("GetContentReportByUuid", "closed").Return(db.ContentReport{ID: 2, Uuid: "closed", Status: db.ReportDismissed}).Once()
+		assert.Equal(t, http.StatusBadRequest, resolve(mh, "closed", `{"action": "hide"}`).Code)
+
+		mockDb.On("GetContentReportByUuid", "unknown").Return(db.ContentReport{}).Once()
+		assert.Equal(t, http.StatusNotFound, resolve(mh, "unknown", `{"action": "hide"}`).Code)
+	})
+}
+
+func TestContentAppeals(t *testing.T) {
+	withUuid := func(req *http.Request, uuid string, pubkey string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", uuid)
+		return req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, pubkey))
+	}
+	appeal := func(mh *moderationHandler, pubkey string, body string) *httptest.ResponseRecorder {
+		req := withUuid(httptest.NewRequest(http.MethodPost, "/report/report/appeal", bytes.NewBufferString(body)), "report", pubkey)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mh.CreateAppeal).ServeHTTP(rr, req)
+		return rr
+	}
+	actioned := db.ContentReport{ID: 1, Uuid: "report", TargetType: db.ReportBot, TargetId: "bot_uuid", OwnerPubKey: "owner", Status: db.ReportActioned, Action: db.ModerationHide}
+
+	t.Run("Should test that the owner can appeal an actioned report once", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("GetContentReportByUuid", "report").Return(actioned)
+		mockDb.On("GetContentAppealByReport", "report").Return(db.ContentAppeal{}).Once()
+		mockDb.On("CreateContentAppeal", mock.MatchedBy(func(m db.ContentAppeal) bool {
+			return m.ReportUuid == "report" && m.OwnerPubKey == "owner" && m.Statement == "it is not spam"
+		})).Return(db.ContentAppeal{Uuid: "appeal", Status: db.AppealOpen}, nil).Once()
+
+		assert.Equal(t, http.StatusOK, appeal(mh, "owner", `{"statement": "it is not spam"}`).Code)
+
+		mockDb.On("GetContentAppealByReport", "report").Return(db.ContentAppeal{ID: 1}).Once()
+		assert.Equal(t, http.StatusConflict, appeal(mh, "owner", `{"statement": "again"}`).Code)
+	})
+
+	t.Run("Should test that only the owner can appeal and only actioned reports", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("GetContentReportByUuid", "report").Return(actioned).Once()
+		assert.Equal(t, http.StatusUnauthorized, appeal(mh, "reporter", `{"statement": "mine"}`).Code)
+
+		dismissed := actioned
+		dismissed.Status = db.ReportDismissed
+		mockDb.On("GetContentReportByUuid", "report").Return(dismissed).Once()
+		assert.Equal(t, http.StatusBadRequest, appeal(mh, "owner", `{"statement": "mine"}`).Code)
+	})
+
+	t.Run("Should test that an overturned appeal is resolved with its report", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("GetContentAppealByUuid", "appeal").Return(db.ContentAppeal{ID: 1, Uuid: "appeal", ReportUuid: "report", OwnerPubKey: "owner", Status: db.AppealOpen}).Twice()
+		mockDb.On("GetContentReportByUuid", "report").Return(actioned).Once()
+		mockDb.On("ResolveContentAppeal", mock.MatchedBy(func(m db.ContentAppeal) bool {
+			return m.Status == db.AppealOverturned && m.ResolvedBy == "admin"
+		}), actioned).Return(db.ContentAppeal{Uuid: "appeal", Status: db.AppealOverturned}, nil).Once()
+
+		resolve := func(body string) *httptest.ResponseRecorder {
+			req := withUuid(httptest.NewRequest(http.MethodPost, "/admin/moderation/appeals/appeal", bytes.NewBufferString(body)), "appeal", "admin")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(mh.ResolveAppeal).ServeHTTP(rr, req)
+			return rr
+		}
+		assert.Equal(t, http.StatusBadRequest, resolve(`{"decision": "maybe"}`).Code)
+		assert.Equal(t, http.StatusOK, resolve(`{"decision": "overturned", "resolution": "not spam"}`).Code)
+	})
+
+	t.Run("Should test that a failed resolution is reported", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("GetContentAppealByUuid", "appeal").Return(db.ContentAppeal{ID: 1, Uuid: "appeal", ReportUuid: "report", Status: db.AppealOpen}).Once()
+		mockDb.On("GetContentReportByUuid", "report").Return(actioned).Once()
+		mockDb.On("ResolveContentAppeal", mock.Anything, actioned).Return(db.ContentAppeal{}, errors.New("appeal is not open")).Once()
+
+		req := withUuid(httptest.NewRequest(http.MethodPost, "/admin/moderation/appeals/appeal", bytes.NewBufferString(`{"decision": "upheld"}`)), "appeal", "admin")
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mh.ResolveAppeal).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestReviewListing(t *testing.T) {
+	review := func(mh *moderationHandler, targetType string, uuid string, body string) *httptest.ResponseRecorder {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("target_type", targetType)
+		rctx.URLParams.Add("uuid", uuid)
+		req := httptest.NewRequest(http.MethodPost, "/admin/moderation/review/"+targetType+"/"+uuid, bytes.NewBufferString(body))
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(mh.ReviewListing).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a held tribe can be approved", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("ReviewListing", db.ReportTribe, "tribe_uuid", true).Return(nil).Once()
+		mockDb.On("GetReportTargetOwner", db.ReportTribe, "tribe_uuid").Return("owner", true).Once()
+
+		assert.Equal(t, http.StatusOK, review(mh, "tribe", "tribe_uuid", `{"approve": true}`).Code)
+	})
+
+	t.Run("Should test that only held tribes and bots are reviewed", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		assert.Equal(t, http.StatusBadRequest, review(mh, "bounty", "1", `{"approve": true}`).Code)
+
+		mockDb.On("ReviewListing", db.ReportBot, "listed", false).Return(errors.New("nothing to review")).Once()
+		assert.Equal(t, http.StatusNotFound, review(mh, "bot", "listed", `{"approve": false}`).Code)
+	})
+}
+
+func TestPubkeyBans(t *testing.T) {
+	request := func(method string, pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("pubkey", pubkey)
+		req := httptest.NewRequest(method, "/admin/bans", bytes.NewBufferString(body))
+		return req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, "admin"))
+	}
+
+	t.Run("Should test that a pubkey can be banned until a time with a reason", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("CreatePubkeyBan", mock.MatchedBy(func(m db.PubkeyBan) bool {
+			return m.PubKey == "spammer" && m.Reason == "spam" && m.BannedBy == "admin" && m.ExpiresAt != nil
+		})).Return(db.PubkeyBan{ID: 1, PubKey: "spammer"}, nil).Once()
+
+		expires := time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+		rr := httptest.NewRecorder()
+		mh.BanPubkey(rr, request(http.MethodPost, "", `{"pubkey": "spammer", "reason": " spam ", "expires_at": "`+expires+`"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that invalid bans are rejected", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("CreatePubkeyBan", mock.Anything).Return(db.PubkeyBan{}, errors.New("pubkey is already banned")).Once()
+
+		past := time.Now().Add(-time.Hour).Format(time.RFC3339)
+		for body, code := range map[string]int{
+			`{"pubkey": "spammer"}`: http.StatusBadRequest,
+			`{"pubkey": "spammer", "reason": "spam", "expires_at": "` + past + `"}`: http.StatusBadRequest,
+			`{"pubkey": "spammer", "reason": "spam"}`:                               http.StatusConflict,
+			`not json`: http.StatusNotAcceptable,
+		} {
+			rr := httptest.NewRecorder()
+			mh.BanPubkey(rr, request(http.MethodPost, "", body))
+			assert.Equal(t, code, rr.Code, body)
+		}
+	})
+
+	t.Run("Should test that a ban is lifted with who lifted it and why", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		mockDb.On("LiftPubkeyBan", "spammer", "admin", "false positive").Return(db.PubkeyBan{ID: 1, PubKey: "spammer", LiftedBy: "admin"}, nil).Once()
+		mockDb.On("LiftPubkeyBan", "other", "admin", "").Return(db.PubkeyBan{}, errors.New("pubkey is not banned")).Once()
+
+		rr := httptest.NewRecorder()
+		mh.LiftPubkeyBan(rr, request(http.MethodDelete, "spammer", `{"reason": "false positive"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		rr = httptest.NewRecorder()
+		mh.LiftPubkeyBan(rr, request(http.MethodDelete, "other", ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Should test that the loaded bans keep their expiry", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mh := NewModerationHandler(mockDb)
+		expires := time.Now().Add(time.Hour)
+		mockDb.On("GetActivePubkeyBans").Return([]db.PubkeyBan{{PubKey: "permanent"}, {PubKey: "expiring", ExpiresAt: &expires}}).Once()
+
+		bans, err := mh.LoadBannedPubkeys()
+		assert.NoError(t, err)
+		assert.True(t, bans["permanent"].IsZero())
+		assert.Equal(t, expires, bans["expiring"])
+	})
+}
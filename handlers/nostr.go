@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+type nostrHandler struct {
+	db db.Database
+}
+
+func NewNostrHandler(database db.Database) *nostrHandler {
+	return &nostrHandler{
+		db: database,
+	}
+}
+
+// ResolveNostrPubkey is used by auth.PubKeyContext to map a NIP-98 signer to its linked person
+func (nh *nostrHandler) ResolveNostrPubkey(nostrPubkey string) (string, error) {
+	identity, err := nh.db.GetNostrIdentity(nostrPubkey)
+	if err != nil {
+		return "", err
+	}
+	return identity.OwnerPubKey, nil
+}
+
+// LinkNostrPubkey links a nostr key to the authenticated person, the body is a
+// NIP-98 kind event signed by the nostr key whose content is the person's pubkey
+func (nh *nostrHandler) LinkNostrPubkey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[nostr] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if auth.IsImpersonatedRequest(ctx) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("An impersonation can't link nostr keys")
+		return
+	}
+
+	event := auth.NostrEvent{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &event)
+	if err != nil {
+		fmt.Println("[nostr]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if event.Kind != auth.NostrHttpAuthKind || event.Content != pubKeyFromAuth || !auth.NostrEventRecent(event) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Proof must be a recent event whose content is your pubkey")
+		return
+	}
+
+	if err := auth.VerifyNostrEvent(event); err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	if existing, err := nh.db.GetNostrIdentity(event.Pubkey); err == nil {
+		if existing.OwnerPubKey != pubKeyFromAuth {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode("Nostr key is linked to another person")
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(existing)
+		return
+	}
+
+	npub, _ := auth.NostrPubkeyToNpub(event.Pubkey)
+	identity, err := nh.db.CreateNostrIdentity(db.NostrIdentity{
+		NostrPubkey: event.Pubkey,
+		Npub:        npub,
+		OwnerPubKey: pubKeyFromAuth,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(identity)
+}
+
+func (nh *nostrHandler) GetNostrIdentities(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[nostr] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	identities := nh.db.GetNostrIdentitiesByPubkey(pubKeyFromAuth)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(identities)
+}
+
+func (nh *nostrHandler) UnlinkNostrPubkey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[nostr] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if auth.IsImpersonatedRequest(ctx) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("An impersonation can't unlink nostr keys")
+		return
+	}
+
+	nostrPubkey := chi.URLParam(r, "nostr_pubkey")
+	if err := nh.db.DeleteNostrIdentity(pubKeyFromAuth, nostrPubkey); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Nostr key unlinked")
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	btcec "github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func signedNostrProof(t *testing.T, privKey *btcec.PrivateKey, content string) auth.NostrEvent {
+	event := auth.NostrEvent{
+		Pubkey:    hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey())),
+		CreatedAt: time.Now().Unix(),
+		Kind:      auth.NostrHttpAuthKind,
+		Tags:      [][]string{},
+		Content:   content,
+	}
+	serialized, _ := json.Marshal([]interface{}{0, event.Pubkey, event.CreatedAt, event.Kind, event.Tags, event.Content})
+	id := sha256.Sum256(serialized)
+	event.ID = hex.EncodeToString(id[:])
+	sig, err := schnorr.Sign(privKey, id[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.Sig = hex.EncodeToString(sig.Serialize())
+	return event
+}
+
+func TestLinkNostrPubkey(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	nHandler := NewNostrHandler(mockDb)
+	privKey, _ := btcec.NewPrivateKey()
+
+	linkRequest := func(event auth.NostrEvent) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(event)
+		req, _ := http.NewRequest("POST", "/person/nostr", bytes.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "pubkey"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(nHandler.LinkNostrPubkey).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a signed proof links the nostr key", func(t *testing.T) {
+		event := signedNostrProof(t, privKey, "pubkey")
+		mockDb.On("GetNostrIdentity", event.Pubkey).Return(db.NostrIdentity{}, errors.New("no nostr identity found")).Once()
+		mockDb.On("CreateNostrIdentity", mock.MatchedBy(func(m db.NostrIdentity) bool {
+			return m.NostrPubkey == event.Pubkey && m.OwnerPubKey == "pubkey" && m.Npub != ""
+		})).Return(db.NostrIdentity{NostrPubkey: event.Pubkey, OwnerPubKey: "pubkey"}, nil).Once()
+
+		rr := linkRequest(event)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a proof for another pubkey is rejected", func(t *testing.T) {
+		rr := linkRequest(signedNostrProof(t, privKey, "other_pubkey"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a tampered proof is rejected", func(t *testing.T) {
+		event := signedNostrProof(t, privKey, "pubkey")
+		event.CreatedAt = event.CreatedAt - 1
+
+		rr := linkRequest(event)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a key linked to another person returns a conflict", func(t *testing.T) {
+		event := signedNostrProof(t, privKey, "pubkey")
+		mockDb.On("GetNostrIdentity", event.Pubkey).Return(db.NostrIdentity{ID: 1, NostrPubkey: event.Pubkey, OwnerPubKey: "other_pubkey"}, nil).Once()
+
+		rr := linkRequest(event)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
+
+func TestResolveNostrPubkey(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	nHandler := NewNostrHandler(mockDb)
+
+	mockDb.On("GetNostrIdentity", "nostr_pubkey").Return(db.NostrIdentity{ID: 1, NostrPubkey: "nostr_pubkey", OwnerPubKey: "pubkey"}, nil).Once()
+
+	pubkey, err := nHandler.ResolveNostrPubkey("nostr_pubkey")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pubkey", pubkey)
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/notifications"
+)
+
+type notificationHandler struct {
+	db db.Database
+}
+
+func NewNotificationHandler(database db.Database) *notificationHandler {
+	return &notificationHandler{
+		db: database,
+	}
+}
+
+func (nh *notificationHandler) GetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[notifications] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	settings := nh.db.GetNotificationSettings(pubKeyFromAuth)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(settings)
+}
+
+func (nh *notificationHandler) UpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[notifications] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	settings := db.NotificationSettings{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &settings)
+	if err != nil {
+		fmt.Println("[notifications]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	settings.OwnerPubKey = pubKeyFromAuth
+	if settings.DisabledEvents == nil {
+		settings.DisabledEvents = []string{}
+	}
+
+	p, err := nh.db.UpdateNotificationSettings(settings)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(p)
+}
+
+func (nh *notificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[notifications] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	notifications := nh.db.GetNotificationsByPubkey(pubKeyFromAuth, unreadOnly, r)
+	total := nh.db.GetNotificationsCount(pubKeyFromAuth, unreadOnly)
+	unread := nh.db.GetNotificationsCount(pubKeyFromAuth, true)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.NotificationsResponse{
+		Total:         total,
+		Unread:        unread,
+		Notifications: notifications,
+	})
+}
+
+func (nh *notificationHandler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	nh.updateNotificationRead(w, r, true)
+}
+
+func (nh *notificationHandler) MarkNotificationUnread(w http.ResponseWriter, r *http.Request) {
+	nh.updateNotificationRead(w, r, false)
+}
+
+func (nh *notificationHandler) updateNotificationRead(w http.ResponseWriter, r *http.Request, read bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[notifications] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "uuid")
+	err := nh.db.UpdateNotificationRead(pubKeyFromAuth, uuid, read)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(read)
+}
+
+func (nh *notificationHandler) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[notifications] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	err := nh.db.MarkAllNotificationsRead(pubKeyFromAuth)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// UnsubscribeEmail turns off email notifications using the token from the email footer
+func (nh *notificationHandler) UnsubscribeEmail(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	pubkey, err := notifications.VerifyUnsubscribeToken(token)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	settings := nh.db.GetNotificationSettings(pubkey)
+	settings.Email = false
+	if settings.DisabledEvents == nil {
+		settings.DisabledEvents = []string{}
+	}
+
+	_, err = nh.db.UpdateNotificationSettings(settings)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("You have been unsubscribed from email notifications")
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetNotificationSettings(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	nHandler := NewNotificationHandler(mockDb)
+
+	t.Run("Should test that a 401 error is returned if the user is not authorized", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/notifications/settings", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(nHandler.GetNotificationSettings)
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that the settings of the authenticated person are returned", func(t *testing.T) {
+		settings := db.DefaultNotificationSettings("pubkey")
+		mockDb.On("GetNotificationSettings", "pubkey").Return(settings).Once()
+
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "pubkey")
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/notifications/settings", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(nHandler.GetNotificationSettings)
+
+		handler.ServeHTTP(rr, req)
+
+		var returned db.NotificationSettings
+		err = json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, returned.Websocket)
+		assert.False(t, returned.Email)
+	})
+}
+
+func TestUpdateNotificationSettings(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	nHandler := NewNotificationHandler(mockDb)
+
+	t.Run("Should test that the settings are saved for the authenticated pubkey only", func(t *testing.T) {
+		body, _ := json.Marshal(db.NotificationSettings{
+			OwnerPubKey:    "another_pubkey",
+			Email:          true,
+			DisabledEvents: []string{string(db.NotificationPaymentReceived)},
+		})
+
+		mockDb.On("UpdateNotificationSettings", mock.MatchedBy(func(s db.NotificationSettings) bool {
+			return s.OwnerPubKey == "pubkey" && s.Email && len(s.DisabledEvents) == 1
+		})).Return(func(s db.NotificationSettings) (db.NotificationSettings, error) {
+			return s, nil
+		}).Once()
+
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "pubkey")
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, "/notifications/settings", bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(nHandler.UpdateNotificationSettings)
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a 406 error is returned for an invalid body", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "pubkey")
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, "/notifications/settings", bytes.NewReader([]byte("invalid")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(nHandler.UpdateNotificationSettings)
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotAcceptable, rr.Code)
+	})
+}
+
+func TestGetNotifications(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	nHandler := NewNotificationHandler(mockDb)
+
+	t.Run("Should test that the inbox is returned with total and unread counts", func(t *testing.T) {
+		notifications := []db.Notification{{Uuid: "uuid", OwnerPubKey: "pubkey", Event: db.NotificationBountyAssigned}}
+		mockDb.On("GetNotificationsByPubkey", "pubkey", true, mock.Anything).Return(notifications).Once()
+		mockDb.On("GetNotificationsCount", "pubkey", true).Return(int64(1)).Twice()
+
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "pubkey")
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/notifications?unread=true&page=1&limit=10", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(nHandler.GetNotifications)
+
+		handler.ServeHTTP(rr, req)
+
+		var returned db.NotificationsResponse
+		err = json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, int64(1), returned.Total)
+		assert.Equal(t, int64(1), returned.Unread)
+		assert.Len(t, returned.Notifications, 1)
+	})
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	nHandler := NewNotificationHandler(mockDb)
+
+	t.Run("Should test that a 404 error is returned if the notification does not belong to the user", func(t *testing.T) {
+		mockDb.On("UpdateNotificationRead", "pubkey", "uuid", true).Return(assert.AnError).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "pubkey")
+		req, err := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPut, "/notifications/uuid/read", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(nHandler.MarkNotificationRead)
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Should test that a notification can be marked as read", func(t *testing.T) {
+		mockDb.On("UpdateNotificationRead", "pubkey", "uuid", true).Return(nil).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "pubkey")
+		req, err := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPut, "/notifications/uuid/read", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(nHandler.MarkNotificationRead)
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/lightning"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// GetPaymentAttempts lists the keysend attempts of a bounty payment, for whoever can pay the bounty and its assignee
+func (h *bountyHandler) GetPaymentAttempts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid bounty id")
+		return
+	}
+
+	bounty := h.db.GetBounty(id)
+	if bounty.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Bounty not found")
+		return
+	}
+	if bounty.WorkspaceUuid == "" && bounty.OrgUuid != "" {
+		bounty.WorkspaceUuid = bounty.OrgUuid
+	}
+
+	if !h.canViewBountyPayment(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have permission to view this bounty's payments")
+		return
+	}
+
+	attempts := h.db.GetPaymentAttempts(bounty.ID)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(attempts)
+}
+
+// canViewBountyPayment is true for the bounty owner, its assignee and whoever can pay bounties in its workspace
+func (h *bountyHandler) canViewBountyPayment(pubkey string, bounty db.NewBounty) bool {
+	if bounty.OwnerID == pubkey || bounty.Assignee == pubkey {
+		return true
+	}
+	return bounty.WorkspaceUuid != "" && h.userHasAccess(pubkey, bounty.WorkspaceUuid, db.PayBounty)
+}
+
+// recordPaymentAttempt saves an attempt to pay a bounty and publishes it for the payment status streams
+func recordPaymentAttempt(database db.Database, attempt db.PaymentAttempt) (db.PaymentAttempt, error) {
+	recorded, err := database.CreatePaymentAttempt(attempt)
+	if err != nil {
+		return attempt, err
+	}
+	events.Publish(events.PaymentAttempted, recorded.WorkspaceUuid, paymentAttemptPayload(recorded))
+	return recorded, nil
+}
+
+// RetryBountyPayments makes the next attempt of the bounty payments that failed transiently
+func RetryBountyPayments() {
+	NewBountyHandler(http.DefaultClient, db.DB).retryBountyPayments()
+}
+
+func (h *bountyHandler) retryBountyPayments() {
+	for _, due := range h.db.GetDuePaymentRetries(time.Now()) {
+		if !h.db.ClaimPaymentRetry(due.ID) {
+			continue
+		}
+		h.retryBountyPayment(due)
+	}
+}
+
+func (h *bountyHandler) retryBountyPayment(due db.PaymentAttempt) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	now := time.Now()
+	next := db.PaymentAttempt{
+		BountyId:       due.BountyId,
+		WorkspaceUuid:  due.WorkspaceUuid,
+		SenderPubKey:   due.SenderPubKey,
+		ReceiverPubKey: due.ReceiverPubKey,
+		Amount:         due.Amount,
+		Attempt:        due.Attempt + 1,
+		Created:        &now,
+	}
+
+	bounty := h.db.GetBounty(due.BountyId)
+	if bounty.WorkspaceUuid == "" && bounty.OrgUuid != "" {
+		bounty.WorkspaceUuid = bounty.OrgUuid
+	}
+
+	// the bounty was paid, reassigned or repriced since the failed attempt
+	if bounty.ID == 0 || bounty.Paid || bounty.Assignee != due.ReceiverPubKey || bounty.Price != due.Amount {
+		next.Status = db.PaymentAttemptCancelled
+		next.Error = "Bounty changed since the failed attempt"
+		recordPaymentAttempt(h.db, next)
+		return
+	}
+
+	// the bounty is paid from its escrow now, or its new proof of work wasn't accepted yet
+	if escrowActive(bounty.EscrowStatus) && !h.dropUnfundedEscrow(bounty) {
+		next.Status = db.PaymentAttemptCancelled
+		next.Error = "Bounty is paid through its escrow"
+		recordPaymentAttempt(h.db, next)
+		return
+	}
+	if bounty.ProofStatus != "" && bounty.ProofStatus != db.ProofAccepted {
+		next.Status = db.PaymentAttemptCancelled
+		next.Error = "Bounty's proof of work has not been accepted"
+		recordPaymentAttempt(h.db, next)
+		return
+	}
+
+	// an archived workspace is read only, so its queued payments are dropped
+	if IsWorkspaceArchived(bounty.WorkspaceUuid) {
+		next.Status = db.PaymentAttemptCancelled
+		next.Error = "Workspace is archived"
+		recordPaymentAttempt(h.db, next)
+		return
+	}
+
+	if h.db.GetWorkspaceBudget(bounty.WorkspaceUuid).TotalBudget < bounty.Price {
+		next.Status = db.PaymentAttemptFailed
+		next.FailureKind = string(lightning.FailurePermanent)
+		next.Error = "workspace budget is not enough to pay the amount"
+		recordPaymentAttempt(h.db, next)
+		return
+	}
+
+	// the daily caps reset, so a capped payment is tried again later
+	if err := h.db.CheckSpendingCaps(bounty.WorkspaceUuid, bounty.Assignee, bounty.Price); err != nil {
+		next.Status = db.PaymentAttemptFailed
+		next.FailureKind = string(lightning.FailureTransient)
+		next.Error = err.Error()
+		if retry := db.NextPaymentRetry(next.Attempt, now); retry != nil {
+			next.Status = db.PaymentAttemptRetrying
+			next.NextRetry = retry
+		}
+		recordPaymentAttempt(h.db, next)
+		return
+	}
+
+	assignee := h.db.GetPersonByPubkey(bounty.Assignee)
+	if _, err := h.keysendBounty(bounty, assignee, due.SenderPubKey, next.Attempt); err != nil {
+		fmt.Println("[bounty] payment retry failed", bounty.ID, err)
+	}
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/lightning"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type keysendTestBackend struct {
+	lightning.Backend
+	err   error
+	calls int
+}
+
+func (b *keysendTestBackend) PayKeysend(amount uint, pubkey string, routeHint string) (lightning.Payment, error) {
+	b.calls++
+	return lightning.Payment{Amount: amount}, b.err
+}
+
+func TestGetPaymentAttempts(t *testing.T) {
+	bounty := db.NewBounty{ID: 1, OwnerID: "owner", Assignee: "hunter", WorkspaceUuid: "workspace-uuid"}
+
+	getAttempts := func(bHandler *bountyHandler, pubkey string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Get("/gobounties/payment/{id}/attempts", bHandler.GetPaymentAttempts)
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/gobounties/payment/1/attempts", nil)
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that the assignee can see the payment attempts", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetPaymentAttempts", uint(1)).Return([]db.PaymentAttempt{
+			{BountyId: 1, Attempt: 1, Status: db.PaymentAttemptRetrying, FailureKind: "transient", Error: "no route"},
+		})
+
+		rr := getAttempts(bHandler, "hunter")
+
+		attempts := []db.PaymentAttempt{}
+		json.Unmarshal(rr.Body.Bytes(), &attempts)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, attempts, 1)
+		assert.Equal(t, "transient", attempts[0].FailureKind)
+	})
+
+	t.Run("Should test that a user without the pay bounty role gets a 401 error", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+
+		rr := getAttempts(bHandler, "someone-else")
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestRetryBountyPayments(t *testing.T) {
+	bounty := db.NewBounty{ID: 1, Price: 1000, Assignee: "hunter", WorkspaceUuid: "workspace-uuid"}
+	due := db.PaymentAttempt{ID: 7, BountyId: 1, WorkspaceUuid: "workspace-uuid", SenderPubKey: "admin", ReceiverPubKey: "hunter", Amount: 1000, Attempt: 2, Status: db.PaymentAttemptRetrying}
+
+	t.Run("Should test that a transient failure schedules the next attempt", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		backend := &keysendTestBackend{err: &lightning.Error{Message: "unable to find a path to destination"}}
+		bHandler.lnBackend = backend
+
+		mockDb.On("GetDuePaymentRetries", mock.Anything).Return([]db.PaymentAttempt{due})
+		mockDb.On("ClaimPaymentRetry", uint(7)).Return(true)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetWorkspaceBudget", "workspace-uuid").Return(db.NewBountyBudget{TotalBudget: 5000})
+		mockDb.On("CheckSpendingCaps", "workspace-uuid", "hunter", uint(1000)).Return(nil)
+		mockDb.On("GetPersonByPubkey", "hunter").Return(db.Person{OwnerPubKey: "hunter"})
+		mockDb.On("CreatePaymentAttempt", mock.MatchedBy(func(attempt db.PaymentAttempt) bool {
+			return attempt.Attempt == 3 && attempt.Status == db.PaymentAttemptRetrying && attempt.FailureKind == "transient" && attempt.NextRetry != nil
+		})).Return(db.PaymentAttempt{}, nil)
+
+		bHandler.retryBountyPayments()
+
+		assert.Equal(t, 1, backend.calls)
+	})
+
+	t.Run("Should test that a successful retry pays the bounty", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.lnBackend = &keysendTestBackend{}
+
+		mockDb.On("GetDuePaymentRetries", mock.Anything).Return([]db.PaymentAttempt{due})
+		mockDb.On("ClaimPaymentRetry", uint(7)).Return(true)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetWorkspaceBudget", "workspace-uuid").Return(db.NewBountyBudget{TotalBudget: 5000})
+		mockDb.On("CheckSpendingCaps", "workspace-uuid", "hunter", uint(1000)).Return(nil)
+		mockDb.On("GetPersonByPubkey", "hunter").Return(db.Person{OwnerPubKey: "hunter"})
+		mockDb.On("ProcessBountyPayment", mock.MatchedBy(func(history db.NewPaymentHistory) bool {
+			return history.Amount == 1000 && history.SenderPubKey == "admin" && history.ReceiverPubKey == "hunter"
+		}), mock.MatchedBy(func(paid db.NewBounty) bool {
+			return paid.Paid
+		})).Return(nil)
+		mockDb.On("CreatePaymentAttempt", mock.MatchedBy(func(attempt db.PaymentAttempt) bool {
+			return attempt.Attempt == 3 && attempt.Status == db.PaymentAttemptSucceeded
+		})).Return(db.PaymentAttempt{}, nil)
+
+		bHandler.retryBountyPayments()
+	})
+
+	t.Run("Should test that a retry of a paid bounty is cancelled without paying", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		backend := &keysendTestBackend{}
+		bHandler.lnBackend = backend
+
+		paidBounty := bounty
+		paidBounty.Paid = true
+		mockDb.On("GetDuePaymentRetries", mock.Anything).Return([]db.PaymentAttempt{due})
+		mockDb.On("ClaimPaymentRetry", uint(7)).Return(true)
+		mockDb.On("GetBounty", uint(1)).Return(paidBounty)
+		mockDb.On("CreatePaymentAttempt", mock.MatchedBy(func(attempt db.PaymentAttempt) bool {
+			return attempt.Status == db.PaymentAttemptCancelled
+		})).Return(db.PaymentAttempt{}, nil)
+
+		bHandler.retryBountyPayments()
+
+		assert.Equal(t, 0, backend.calls)
+	})
+
+	t.Run("Should test that a retry is cancelled like a payment of an escrowed or unreviewed bounty", func(t *testing.T) {
+		for _, changed := range []db.NewBounty{
+			{ID: 1, Price: 1000, Assignee: "hunter", WorkspaceUuid: "workspace-uuid", EscrowStatus: db.EscrowFunded},
+			{ID: 1, Price: 1000, Assignee: "hunter", WorkspaceUuid: "workspace-uuid", ProofStatus: db.ProofChangesRequested},
+		} {
+			mockDb := mocks.NewDatabase(t)
+			bHandler := NewBountyHandler(nil, mockDb)
+			backend := &keysendTestBackend{}
+			bHandler.lnBackend = backend
+
+			mockDb.On("GetDuePaymentRetries", mock.Anything).Return([]db.PaymentAttempt{due})
+			mockDb.On("ClaimPaymentRetry", uint(7)).Return(true)
+			mockDb.On("GetBounty", uint(1)).Return(changed)
+			mockDb.On("CreatePaymentAttempt", mock.MatchedBy(func(attempt db.PaymentAttempt) bool {
+				return attempt.Status == db.PaymentAttemptCancelled
+			})).Return(db.PaymentAttempt{}, nil)
+
+			bHandler.retryBountyPayments()
+
+			assert.Equal(t, 0, backend.calls)
+		}
+	})
+
+	t.Run("Should test that the retries hold the same lock as the payment routes", func(t *testing.T) {
+		assert.Same(t, NewBountyHandler(nil, nil).m, NewBountyHandler(nil, nil).m)
+	})
+
+	t.Run("Should test that a retry claimed by another worker is skipped", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+
+		mockDb.On("GetDuePaymentRetries", mock.Anything).Return([]db.PaymentAttempt{due})
+		mockDb.On("ClaimPaymentRetry", uint(7)).Return(false)
+
+		bHandler.retryBountyPayments()
+	})
+}
@@ -113,6 +113,9 @@ func (ph *peopleHandler) CreateOrEditPerson(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	recordTicketRevisions(ph.db, existing, person, pubKeyFromAuth)
+	completeTicketBounties(ph.db, existing, person)
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(p)
 }
@@ -175,7 +178,11 @@ func (ph *peopleHandler) UpsertLogin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	responseData := make(map[string]interface{})
-	tokenString, err := auth.EncodeJwt(person.OwnerPubKey)
+	tokenString, err := ph.db.StartAuthSession(db.AuthSession{
+		OwnerPubKey: person.OwnerPubKey,
+		UserAgent:   r.UserAgent(),
+		Fingerprint: auth.HashFingerprint(auth.ClientFingerprint(r)),
+	})
 
 	if err != nil {
 		fmt.Println("Cannot generate jwt token")
@@ -415,8 +422,12 @@ func (ph *peopleHandler) GetPersonByPubkey(w http.ResponseWriter, r *http.Reques
 	pubkey := chi.URLParam(r, "pubkey")
 
 	person := ph.db.GetPersonByPubkey(pubkey)
+	person = ph.applyPrivacy(r, []db.Person{person})[0]
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(person)
+	json.NewEncoder(w).Encode(db.PersonWithEndorsements{
+		Person:       person,
+		Endorsements: ph.db.GetEndorsementSummary(pubkey),
+	})
 }
 
 func (ph *peopleHandler) GetPersonById(w http.ResponseWriter, r *http.Request) {
@@ -424,6 +435,7 @@ func (ph *peopleHandler) GetPersonById(w http.ResponseWriter, r *http.Request) {
 	id, _ := strconv.ParseUint(idParam, 10, 32)
 
 	person := ph.db.GetPerson(uint(id))
+	person = ph.applyPrivacy(r, []db.Person{person})[0]
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(person)
 }
@@ -431,6 +443,7 @@ func (ph *peopleHandler) GetPersonById(w http.ResponseWriter, r *http.Request) {
 func (ph *peopleHandler) GetPersonByUuid(w http.ResponseWriter, r *http.Request) {
 	uuid := chi.URLParam(r, "uuid")
 	person := ph.db.GetPersonByUuid(uuid)
+	person = ph.applyPrivacy(r, []db.Person{person})[0]
 	assetBalanceData, err := GetAssetByPubkey(person.OwnerPubKey)
 
 	personResponse := make(map[string]interface{})
@@ -518,6 +531,7 @@ func (ph *peopleHandler) DeletePerson(w http.ResponseWriter, r *http.Request) {
 	ph.db.UpdatePerson(uint(id), map[string]interface{}{
 		"deleted": true,
 	})
+	ph.db.AddDeletion(db.DeletionKindPerson, strconv.Itoa(int(id)))
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(true)
@@ -676,6 +690,17 @@ func AddOrRemoveBadge(w http.ResponseWriter, r *http.Request) {
 	})
 
 	if updatedTribe {
+		kind := db.TribeActivityBadgeAdded
+		if badgeCreationData.Action == "remove" {
+			kind = db.TribeActivityBadgeRemoved
+		}
+		db.DB.AddTribeActivity(db.TribeActivity{
+			TribeUuid: tribe.UUID,
+			Kind:      kind,
+			Name:      badgeCreationData.Badge,
+			Count:     1,
+		})
+
 		tribe = db.DB.GetTribeByIdAndPubkey(badgeCreationData.TribeUUID, extractedPubkey)
 
 		w.WriteHeader(http.StatusOK)
@@ -693,15 +718,108 @@ func GetPeopleShortList(w http.ResponseWriter, r *http.Request) {
 }
 
 func (ph *peopleHandler) GetPeopleBySearch(w http.ResponseWriter, r *http.Request) {
-	people := ph.db.GetPeopleBySearch(r)
+	people := ph.applyPrivacy(r, ph.db.GetPeopleBySearch(r))
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(people)
 }
 
 func (ph *peopleHandler) GetListedPeople(w http.ResponseWriter, r *http.Request) {
-	people := ph.db.GetListedPeople(r)
+	since, isDelta, err := parseUpdatedSince(r)
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid updated_since")
+		return
+	}
+	shape, ok := responseShapeFromUrl(w, r)
+	if !ok {
+		return
+	}
+	if isDelta {
+		syncedAt := time.Now().Unix()
+		updated, err := shape.shape(ph.applyPrivacy(r, ph.db.GetPeopleUpdatedSince(since, r)), "")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode("Could not write the response")
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(db.DeltaResponse{
+			Updated:   updated,
+			Deletions: ph.db.GetDeletionsSince(db.DeletionKindPerson, since),
+			SyncedAt:  syncedAt,
+		})
+		return
+	}
+
+	people := ph.applyPrivacy(r, ph.db.GetListedPeople(r))
+	writeShaped(w, shape, people, "")
+}
+
+// applyPrivacy hides the profile fields the caller of the request is not allowed to see,
+// tribe-members fields are shown to people sharing a workspace with the person
+func (ph *peopleHandler) applyPrivacy(r *http.Request, people []db.Person) []db.Person {
+	viewer, _ := r.Context().Value(auth.ContextKey).(string)
+
+	var mates map[string]bool
+	for i, person := range people {
+		audience := db.AudiencePublic
+		if viewer != "" && viewer == person.OwnerPubKey {
+			audience = db.AudienceOwner
+		} else if viewer != "" && person.HasVisibility(db.PrivacyTribeMembers) {
+			if mates == nil {
+				mates = ph.db.GetWorkspaceMatePubkeys(viewer)
+			}
+			if mates[person.OwnerPubKey] {
+				audience = db.AudienceMember
+			}
+		}
+		people[i] = db.ApplyPersonPrivacy(person, audience)
+	}
+	return people
+}
+
+// UpdatePrivacy sets the visibility of the email, github, pricing and description of the authenticated person
+func (ph *peopleHandler) UpdatePrivacy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[people] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	privacy := db.PersonPrivacy{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &privacy)
+	if err != nil {
+		fmt.Println("[people]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if err := db.ValidatePrivacy(privacy); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	person, err := ph.db.UpdatePersonPrivacy(pubKeyFromAuth, privacy)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(people)
+	json.NewEncoder(w).Encode(person)
+}
+
+// GetPeopleFacets returns how many people match each coding language and skill for the current filters
+func (ph *peopleHandler) GetPeopleFacets(w http.ResponseWriter, r *http.Request) {
+	facets := ph.db.GetPeopleFacets(r)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(facets)
 }
 
 func GetListedPosts(w http.ResponseWriter, r *http.Request) {
@@ -14,7 +14,9 @@ import (
 	"github.com/lib/pq"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetPersonByPuKey(t *testing.T) {
@@ -513,6 +515,23 @@ func TestGetListedPeople(t *testing.T) {
 		assert.EqualValues(t, expectedPeople, returnedPeople)
 	})
 
+	t.Run("should not return an unlisted user whose unique name matches the search", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(pHandler.GetListedPeople)
+
+		rctx := chi.NewRouteContext()
+		req, err := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/?page=1&limit=10&search="+person.UniqueName, nil)
+		assert.NoError(t, err)
+
+		handler.ServeHTTP(rr, req)
+
+		var returnedPeople []db.Person
+		err = json.Unmarshal(rr.Body.Bytes(), &returnedPeople)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, returnedPeople)
+	})
+
 	t.Run("should return only users that match a skill set when languages are passed to the URL query", func(t *testing.T) {
 		rr := httptest.NewRecorder()
 		handler := http.HandlerFunc(pHandler.GetListedPeople)
@@ -602,3 +621,121 @@ func TestGetPersonByUuid(t *testing.T) {
 		assert.Empty(t, returnedPerson)
 	})
 }
+
+func TestGetPeopleFacets(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	pHandler := NewPeopleHandler(mockDb)
+
+	t.Run("Should test that facet counts are returned for the filters", func(t *testing.T) {
+		facets := db.PeopleFacets{
+			CodingLanguages: []db.FacetCount{{Value: "Go", Count: 12}, {Value: "Rust", Count: 4}},
+			Skills:          []db.FacetCount{{Value: "design", Count: 3}},
+		}
+		mockDb.On("GetPeopleFacets", mock.MatchedBy(func(r *http.Request) bool {
+			return r.URL.Query().Get("available") == "true"
+		})).Return(facets).Once()
+
+		req, _ := http.NewRequest("GET", "/people/facets?available=true", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetPeopleFacets).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		returned := db.PeopleFacets{}
+		json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.Equal(t, facets, returned)
+	})
+}
+
+func TestPersonPrivacy(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	pHandler := NewPeopleHandler(mockDb)
+
+	person := db.Person{
+		ID:          1,
+		OwnerPubKey: "person_pubkey",
+		Description: "description",
+		PriceToMeet: 50,
+		Extras:      db.PropertyMap{"email": "person@example.com", "github": "person"},
+		Privacy: db.PersonPrivacy{
+			db.PrivacyFieldEmail:   db.PrivacyPrivate,
+			db.PrivacyFieldPricing: db.PrivacyTribeMembers,
+		},
+	}
+
+	getPerson := func(viewer string) db.Person {
+		mockDb.On("GetPersonByPubkey", person.OwnerPubKey).Return(person).Once()
+		mockDb.On("GetEndorsementSummary", person.OwnerPubKey).Return(db.EndorsementSummary{}).Once()
+
+		ctx := context.Background()
+		if viewer != "" {
+			ctx = context.WithValue(ctx, auth.ContextKey, viewer)
+		}
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("pubkey", person.OwnerPubKey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodGet, "/person/"+person.OwnerPubKey, nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetPersonByPubkey).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		returned := db.Person{}
+		json.Unmarshal(rr.Body.Bytes(), &returned)
+		return returned
+	}
+
+	t.Run("Should test that anonymous callers only see public fields", func(t *testing.T) {
+		returned := getPerson("")
+		assert.NotContains(t, returned.Extras, "email")
+		assert.Equal(t, "person", returned.Extras["github"])
+		assert.Equal(t, int64(0), returned.PriceToMeet)
+	})
+
+	t.Run("Should test that workspace mates see tribe-members fields", func(t *testing.T) {
+		mockDb.On("GetWorkspaceMatePubkeys", "mate_pubkey").Return(map[string]bool{person.OwnerPubKey: true}).Once()
+		returned := getPerson("mate_pubkey")
+		assert.NotContains(t, returned.Extras, "email")
+		assert.Equal(t, int64(50), returned.PriceToMeet)
+	})
+
+	t.Run("Should test that the owner sees every field", func(t *testing.T) {
+		returned := getPerson(person.OwnerPubKey)
+		assert.Equal(t, "person@example.com", returned.Extras["email"])
+		assert.Equal(t, int64(50), returned.PriceToMeet)
+	})
+}
+
+func TestUpdatePrivacy(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	pHandler := NewPeopleHandler(mockDb)
+
+	updateRequest := func(pubkey string, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPut, "/person/privacy", bytes.NewBufferString(body))
+		if pubkey != "" {
+			req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, pubkey))
+		}
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.UpdatePrivacy).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a 401 is returned without a pubkey", func(t *testing.T) {
+		rr := updateRequest("", `{"email":"private"}`)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that an invalid visibility is rejected", func(t *testing.T) {
+		rr := updateRequest("pubkey", `{"email":"friends"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the privacy settings are saved", func(t *testing.T) {
+		privacy := db.PersonPrivacy{db.PrivacyFieldEmail: db.PrivacyPrivate}
+		mockDb.On("UpdatePersonPrivacy", "pubkey", privacy).Return(db.Person{OwnerPubKey: "pubkey", Privacy: privacy}, nil).Once()
+
+		rr := updateRequest("pubkey", `{"email":"private"}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		returned := db.Person{}
+		json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.Equal(t, privacy, returned.Privacy)
+	})
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// phaseWorkspace returns the workspace of a phase of a feature, writing the error response when
+// the phase is missing or the user doesn't have the role there
+func (oh *featureHandler) phaseWorkspace(w http.ResponseWriter, r *http.Request, role string) (string, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return "", false
+	}
+
+	featureUuid := chi.URLParam(r, "feature_uuid")
+	phaseUuid := chi.URLParam(r, "phase_uuid")
+	if _, err := oh.db.GetFeaturePhaseByUuid(featureUuid, phaseUuid); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Phase not found")
+		return "", false
+	}
+
+	workspaceUuid := oh.db.GetFeatureByUuid(featureUuid).WorkspaceUuid
+	if !oh.userHasAccess(pubKeyFromAuth, workspaceUuid, role) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the phase budget")
+		return "", false
+	}
+	return workspaceUuid, true
+}
+
+// GetPhaseBudget returns the allocation of a phase with what its bounties spent and committed
+func (oh *featureHandler) GetPhaseBudget(w http.ResponseWriter, r *http.Request) {
+	if _, ok := oh.phaseWorkspace(w, r, db.ViewReport); !ok {
+		return
+	}
+
+	phaseUuid := chi.URLParam(r, "phase_uuid")
+	budget := oh.db.GetPhaseBudget(phaseUuid)
+	status := db.NewPhaseBudgetStatus(phaseUuid, budget.Allocated, oh.db.GetPhaseSpent(phaseUuid), oh.db.GetPhaseCommitted(phaseUuid))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(status)
+}
+
+// UpdatePhaseBudget allocates sats to a phase, 0 removes the allocation
+func (oh *featureHandler) UpdatePhaseBudget(w http.ResponseWriter, r *http.Request) {
+	workspaceUuid, ok := oh.phaseWorkspace(w, r, db.EditOrg)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, workspaceUuid) {
+		return
+	}
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+
+	budget := db.PhaseBudget{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &budget); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid budget")
+		return
+	}
+	budget.PhaseUuid = chi.URLParam(r, "phase_uuid")
+	budget.UpdatedBy = pubKeyFromAuth
+
+	budget, err := oh.db.UpdatePhaseBudget(budget)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	status := db.NewPhaseBudgetStatus(budget.PhaseUuid, budget.Allocated, oh.db.GetPhaseSpent(budget.PhaseUuid), oh.db.GetPhaseCommitted(budget.PhaseUuid))
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(status)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPhaseBudget(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	fHandler := NewFeatureHandler(mockDb)
+	fHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return uuid == "workspace" && (pubKeyFromAuth == "admin" || role == db.ViewReport && pubKeyFromAuth == "viewer")
+	}
+
+	router := chi.NewRouter()
+	router.Get("/features/{feature_uuid}/phase/{phase_uuid}/budget", fHandler.GetPhaseBudget)
+	router.Put("/features/{feature_uuid}/phase/{phase_uuid}/budget", fHandler.UpdatePhaseBudget)
+
+	serve := func(method string, body interface{}, pubkey string) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, "/features/feature/phase/phase/budget", bytes.NewReader(data))
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, pubkey))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+	phase := db.FeaturePhase{Uuid: "phase", FeatureUuid: "feature"}
+	feature := db.WorkspaceFeatures{Uuid: "feature", WorkspaceUuid: "workspace"}
+
+	t.Run("Should test that the budget shows what the phase spent and has left", func(t *testing.T) {
+		mockDb.On("GetFeaturePhaseByUuid", "feature", "phase").Return(phase, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature").Return(feature).Once()
+		mockDb.On("GetPhaseBudget", "phase").Return(db.PhaseBudget{PhaseUuid: "phase", Allocated: 10000}).Once()
+		mockDb.On("GetPhaseSpent", "phase").Return(uint(11000)).Once()
+		mockDb.On("GetPhaseCommitted", "phase").Return(uint(0)).Once()
+
+		rr := serve(http.MethodGet, nil, "viewer")
+
+		status := db.PhaseBudgetStatus{}
+		json.Unmarshal(rr.Body.Bytes(), &status)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, uint(11000), status.Spent)
+		assert.Equal(t, int64(-1000), status.Remaining)
+		assert.Len(t, status.Warnings, 1)
+	})
+
+	t.Run("Should test that workspace admins allocate the budget", func(t *testing.T) {
+		mockDb.On("GetFeaturePhaseByUuid", "feature", "phase").Return(phase, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature").Return(feature).Once()
+		rr := serve(http.MethodPut, map[string]interface{}{"allocated": 20000}, "viewer")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		mockDb.On("GetFeaturePhaseByUuid", "feature", "phase").Return(phase, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature").Return(feature).Once()
+		mockDb.On("UpdatePhaseBudget", mock.MatchedBy(func(b db.PhaseBudget) bool {
+			return b.PhaseUuid == "phase" && b.Allocated == 20000 && b.UpdatedBy == "admin"
+		})).Return(db.PhaseBudget{PhaseUuid: "phase", Allocated: 20000}, nil).Once()
+		mockDb.On("GetPhaseSpent", "phase").Return(uint(11000)).Once()
+		mockDb.On("GetPhaseCommitted", "phase").Return(uint(3000)).Once()
+
+		rr = serve(http.MethodPut, map[string]interface{}{"allocated": 20000}, "admin")
+
+		status := db.PhaseBudgetStatus{}
+		json.Unmarshal(rr.Body.Bytes(), &status)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, int64(9000), status.Remaining)
+		assert.Empty(t, status.Warnings)
+	})
+
+	t.Run("Should test that a missing phase is not found", func(t *testing.T) {
+		mockDb.On("GetFeaturePhaseByUuid", "feature", "phase").Return(db.FeaturePhase{}, errors.New("no phase found")).Once()
+		rr := serve(http.MethodGet, nil, "admin")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/lib/pq"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/notifications"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+const maxProofAttachments = 20
+
+// proofUrls keeps the non empty http(s) urls of a submission, files are the urls of uploaded files
+func proofUrls(urls []string) (pq.StringArray, bool) {
+	kept := pq.StringArray{}
+	for _, u := range urls {
+		u = strings.TrimSpace(u)
+		if u == "" {
+			continue
+		}
+		parsed, err := url.Parse(u)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, false
+		}
+		kept = append(kept, u)
+	}
+	return kept, len(kept) <= maxProofAttachments
+}
+
+// SubmitBountyProof lets the assignee deliver the work of a bounty with a description, links and files
+func (h *bountyHandler) SubmitBountyProof(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[proofs] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromPath(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
+
+	if bounty.Assignee == "" || pubKeyFromAuth != bounty.Assignee {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the bounty assignee can submit proof of work")
+		return
+	}
+	if bounty.Paid {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Bounty has already been paid")
+		return
+	}
+	if bounty.ProofStatus == db.ProofSubmitted || bounty.ProofStatus == db.ProofAccepted {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("Bounty's proof of work is " + string(bounty.ProofStatus))
+		return
+	}
+
+	request := db.ProofRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	links, linksOk := proofUrls(request.Links)
+	files, filesOk := proofUrls(request.Files)
+	if !linksOk || !filesOk {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Links and files must be at most %d http(s) urls each", maxProofAttachments))
+		return
+	}
+	request.Description = strings.TrimSpace(request.Description)
+	if request.Description == "" && len(links) == 0 && len(files) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A description, a link or a file is required")
+		return
+	}
+
+	proof, err := h.db.CreateBountyProof(db.BountyProof{
+		BountyId:      bounty.ID,
+		WorkspaceUuid: bounty.WorkspaceUuid,
+		SubmittedBy:   pubKeyFromAuth,
+		Description:   request.Description,
+		Links:         links,
+		Files:         files,
+	})
+	if err != nil {
+		log.Printf("[proofs] could not store proof: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not submit proof of work")
+		return
+	}
+
+	bounty.ProofStatus = proof.Status
+	publishBountyEvent(bounty, "proof_submitted")
+	notifications.Notify(bounty.OwnerID, db.NotificationProofSubmitted, "Work was submitted on your bounty", bounty.Title, bountyLink(bounty.ID))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(proof)
+}
+
+// GetBountyProofs is the proof history of a bounty, for its owner, its assignee and whoever can pay it
+func (h *bountyHandler) GetBountyProofs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[proofs] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	proofs := h.db.GetBountyProofs(bounty.ID)
+	canView := h.canViewBountyPayment(pubKeyFromAuth, bounty)
+	for _, proof := range proofs {
+		canView = canView || proof.SubmittedBy == pubKeyFromAuth
+	}
+	if !canView {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have permission to view this bounty's proofs")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(proofs)
+}
+
+// ReviewBountyProof lets whoever can pay the bounty accept the submitted work or ask for changes.
+// Accepting unlocks the payment and releases an escrow that holds it
+func (h *bountyHandler) ReviewBountyProof(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[proofs] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromPath(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
+
+	proofId, err := utils.ConvertStringToUint(chi.URLParam(r, "proof_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid proof id")
+		return
+	}
+	proof := h.db.GetBountyProof(proofId)
+	if proof.ID == 0 || proof.BountyId != bounty.ID {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Proof not found")
+		return
+	}
+
+	if !h.canPayBounty(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have appropriate permissions to review this bounty")
+		return
+	}
+
+	request := db.ProofReviewRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+	request.Comment = strings.TrimSpace(request.Comment)
+	switch request.Status {
+	case db.ProofAccepted:
+	case db.ProofChangesRequested:
+		if request.Comment == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("A comment is required to request changes")
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Status must be accepted or changes_requested")
+		return
+	}
+
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	proof.Status = request.Status
+	proof.ReviewedBy = pubKeyFromAuth
+	proof.ReviewComment = request.Comment
+	proof, err = h.db.ReviewBountyProof(proof)
+	if err != nil {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	bounty.ProofStatus = proof.Status
+	if proof.Status == db.ProofAccepted {
+		publishBountyEvent(bounty, "proof_accepted")
+		notifications.Notify(proof.SubmittedBy, db.NotificationProofReviewed, "Your work was accepted", bounty.Title, bountyLink(bounty.ID))
+	} else {
+		publishBountyEvent(bounty, "proof_changes_requested")
+		notifications.Notify(proof.SubmittedBy, db.NotificationProofReviewed, "Changes were requested on your work", proof.ReviewComment, bountyLink(bounty.ID))
+	}
+
+	// the acceptance stands when the release fails, the escrow can be released again on its own
+	if proof.Status == db.ProofAccepted && (bounty.EscrowStatus == db.EscrowFunded || bounty.EscrowStatus == db.EscrowSettled || bounty.EscrowStatus == db.EscrowPaying) {
+		if _, err := h.releaseEscrow(bounty, pubKeyFromAuth); err != nil {
+			log.Printf("[proofs] could not release escrow of bounty %d: %v", bounty.ID, err)
+			w.WriteHeader(http.StatusBadGateway)
+			json.NewEncoder(w).Encode("Proof was accepted but the escrow could not be released: " + err.Error())
+			return
+		}
+	}
+	// requesting changes rejects the work, the hold invoice is cancelled so the workspace gets its funds back
+	if proof.Status == db.ProofChangesRequested && (bounty.EscrowStatus == db.EscrowAwaitingFunding || bounty.EscrowStatus == db.EscrowFunded) {
+		if _, err := h.refundEscrow(bounty); err != nil {
+			log.Printf("[proofs] could not refund escrow of bounty %d: %v", bounty.ID, err)
+			w.WriteHeader(http.StatusBadGateway)
+			json.NewEncoder(w).Encode("Changes were requested but the escrow could not be refunded: " + err.Error())
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(proof)
+}
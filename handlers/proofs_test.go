@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBountyProofs(t *testing.T) {
+	bounty := db.NewBounty{ID: 1, Title: "Fix the login", OwnerID: "owner", Assignee: "hunter", WorkspaceUuid: "workspace-uuid", Price: 1000}
+	proof := db.BountyProof{ID: 3, BountyId: 1, SubmittedBy: "hunter", Description: "Done", Status: db.ProofSubmitted}
+
+	serve := func(pattern string, handler http.HandlerFunc, method string, pubkey string, url string, body string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Method(method, pattern, handler)
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, url, bytes.NewBufferString(body))
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that the assignee submits links and files as proof of work", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("CreateBountyProof", mock.MatchedBy(func(p db.BountyProof) bool {
+			return p.SubmittedBy == "hunter" && len(p.Links) == 1 && len(p.Files) == 1 && p.Description == "Done"
+		})).Return(proof, nil)
+
+		body := `{"description":" Done ","links":["https://github.com/stakwork/sphinx-tribes/pull/1",""],"files":["https://memes.sphinx.chat/public/abc"]}`
+		rr := serve("/gobounties/{id}/proofs", bHandler.SubmitBountyProof, http.MethodPost, "hunter", "/gobounties/1/proofs", body)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that only the assignee can submit proof of work", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+
+		rr := serve("/gobounties/{id}/proofs", bHandler.SubmitBountyProof, http.MethodPost, "someone-else", "/gobounties/1/proofs", `{"description":"Done"}`)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a link which is not a url is refused", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+
+		rr := serve("/gobounties/{id}/proofs", bHandler.SubmitBountyProof, http.MethodPost, "hunter", "/gobounties/1/proofs", `{"links":["javascript:alert(1)"]}`)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a proof waiting for review can't be submitted again", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		submitted := bounty
+		submitted.ProofStatus = db.ProofSubmitted
+		mockDb.On("GetBounty", uint(1)).Return(submitted)
+
+		rr := serve("/gobounties/{id}/proofs", bHandler.SubmitBountyProof, http.MethodPost, "hunter", "/gobounties/1/proofs", `{"description":"Done"}`)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Should test that requesting changes needs a comment", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetBountyProof", uint(3)).Return(proof)
+
+		rr := serve("/gobounties/{id}/proofs/{proof_id}/review", bHandler.ReviewBountyProof, http.MethodPost, "owner", "/gobounties/1/proofs/3/review", `{"status":"changes_requested"}`)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the assignee can't review their own proof", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetBountyProof", uint(3)).Return(proof)
+
+		rr := serve("/gobounties/{id}/proofs/{proof_id}/review", bHandler.ReviewBountyProof, http.MethodPost, "hunter", "/gobounties/1/proofs/3/review", `{"status":"accepted"}`)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that accepting the proof releases a funded escrow", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		backend := &holdTestBackend{}
+		bHandler.lnBackend = backend
+
+		escrowed := bounty
+		escrowed.EscrowStatus = db.EscrowFunded
+		funded := db.BountyEscrow{ID: 2, BountyId: 1, Assignee: "hunter", Amount: 1000, PaymentHash: "hash", Preimage: "preimage", Status: db.EscrowFunded}
+		settled := funded
+		settled.Status = db.EscrowSettled
+		paying := funded
+		paying.Status = db.EscrowPaying
+		mockDb.On("GetBounty", uint(1)).Return(escrowed)
+		mockDb.On("GetBountyProof", uint(3)).Return(proof)
+		mockDb.On("ReviewBountyProof", mock.MatchedBy(func(p db.BountyProof) bool {
+			return p.Status == db.ProofAccepted && p.ReviewedBy == "owner"
+		})).Return(db.BountyProof{ID: 3, BountyId: 1, SubmittedBy: "hunter", Status: db.ProofAccepted}, nil)
+		mockDb.On("GetBountyEscrow", uint(1)).Return(funded)
+		mockDb.On("UpdateEscrowStatus", funded, db.EscrowFunded, db.EscrowSettled).Return(settled, nil)
+		mockDb.On("UpdateEscrowStatus", settled, db.EscrowSettled, db.EscrowPaying).Return(paying, nil)
+		mockDb.On("GetPersonByPubkey", "hunter").Return(db.Person{OwnerPubKey: "hunter"})
+		mockDb.On("RecordEscrowPayout", paying, "", "").Return(paying, nil)
+		mockDb.On("ProcessEscrowPayment", mock.Anything, mock.MatchedBy(func(b db.NewBounty) bool {
+			return b.Paid && b.ProofStatus == db.ProofAccepted
+		}), paying).Return(nil)
+
+		rr := serve("/gobounties/{id}/proofs/{proof_id}/review", bHandler.ReviewBountyProof, http.MethodPost, "owner", "/gobounties/1/proofs/3/review", `{"status":"accepted"}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "preimage", backend.settled)
+		assert.Equal(t, 1, backend.calls)
+	})
+
+	t.Run("Should test that requesting changes cancels the hold invoice", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		backend := &holdTestBackend{}
+		bHandler.lnBackend = backend
+
+		escrowed := bounty
+		escrowed.EscrowStatus = db.EscrowFunded
+		funded := db.BountyEscrow{ID: 2, BountyId: 1, Assignee: "hunter", Amount: 1000, PaymentHash: "hash", Preimage: "preimage", Status: db.EscrowFunded}
+		mockDb.On("GetBounty", uint(1)).Return(escrowed)
+		mockDb.On("GetBountyProof", uint(3)).Return(proof)
+		mockDb.On("ReviewBountyProof", mock.MatchedBy(func(p db.BountyProof) bool {
+			return p.Status == db.ProofChangesRequested && p.ReviewComment == "Tests are failing"
+		})).Return(db.BountyProof{ID: 3, Status: db.ProofChangesRequested}, nil)
+		mockDb.On("GetBountyEscrow", uint(1)).Return(funded)
+		mockDb.On("UpdateEscrowStatus", funded, db.EscrowFunded, db.EscrowRefunded).Return(db.BountyEscrow{Status: db.EscrowRefunded}, nil)
+
+		rr := serve("/gobounties/{id}/proofs/{proof_id}/review", bHandler.ReviewBountyProof, http.MethodPost, "owner", "/gobounties/1/proofs/3/review", `{"status":"changes_requested","comment":"Tests are failing"}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "", backend.settled)
+		assert.Equal(t, "hash", backend.cancelled)
+		assert.Equal(t, 0, backend.calls)
+	})
+}
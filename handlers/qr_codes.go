@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/media"
+)
+
+const (
+	qrDefaultScale = 8
+	qrMaxScale     = 32
+)
+
+var qrLevels = map[string]media.QRLevel{
+	"L": media.QRLevelL,
+	"M": media.QRLevelM,
+	"Q": media.QRLevelQ,
+	"H": media.QRLevelH,
+}
+
+// serveQRCode writes the QR code of a text as the {format} of the url, png or svg. The error
+// correction level is ?ecc= (L, M, Q or H, M by default) and ?scale= is the pixels of a module
+// of a png
+func serveQRCode(w http.ResponseWriter, r *http.Request, text string) {
+	format := chi.URLParam(r, "format")
+	if format != "png" && format != "svg" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("The format must be png or svg")
+		return
+	}
+
+	level := media.QRLevelM
+	if ecc := r.URL.Query().Get("ecc"); ecc != "" {
+		l, ok := qrLevels[strings.ToUpper(ecc)]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("ecc must be L, M, Q or H")
+			return
+		}
+		level = l
+	}
+
+	scale := qrDefaultScale
+	if s := r.URL.Query().Get("scale"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > qrMaxScale {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(fmt.Sprintf("scale must be between 1 and %d", qrMaxScale))
+			return
+		}
+		scale = n
+	}
+
+	code, err := media.NewQRCode(text, level)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	if format == "svg" {
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(code.SVG()))
+		return
+	}
+
+	image, err := code.PNG(scale)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.WriteHeader(http.StatusOK)
+	w.Write(image)
+}
+
+// GetConnectionCodeQR is the QR code of the connection code in ?code=, only the codes that
+// exist are drawn
+func (ah *authHandler) GetConnectionCodeQR(w http.ResponseWriter, r *http.Request) {
+	code := r.URL.Query().Get("code")
+	if code == "" || !ah.db.ConnectionCodeExists(code) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Connection code not found")
+		return
+	}
+
+	serveQRCode(w, r, code)
+}
+
+// GetLnurlAuthQR is the QR code of the LNURL-auth challenge of ?k1=, made by GET /lnauth. The
+// LNURL is upper case so it fits the smaller alphanumeric mode
+func GetLnurlAuthQR(w http.ResponseWriter, r *http.Request) {
+	k1 := r.URL.Query().Get("k1")
+	if _, err := db.Store.GetLnCache(k1); k1 == "" || err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("LNURL-auth challenge not found")
+		return
+	}
+
+	encodeData, err := auth.EncodeLNURLForK1(r.Host, k1)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Could not generate LNURL AUTH")
+		return
+	}
+
+	serveQRCode(w, r, strings.ToUpper(encodeData.Encode))
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQRCodes(t *testing.T) {
+	getQR := func(aHandler *authHandler, url string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Get("/connectioncodes/qr.{format}", aHandler.GetConnectionCodeQR)
+		ro.Get("/lnauth/qr.{format}", GetLnurlAuthQR)
+
+		rr := httptest.NewRecorder()
+		ro.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, url, nil))
+		return rr
+	}
+
+	t.Run("Should test that the QR code of a connection code is a png", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("ConnectionCodeExists", "code1").Return(true)
+
+		rr := getQR(aHandler, "/connectioncodes/qr.png?code=code1&scale=4")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/png", rr.Header().Get("Content-Type"))
+		img, err := png.Decode(bytes.NewReader(rr.Body.Bytes()))
+		assert.NoError(t, err)
+		// version 1 is 21 modules and the quiet zone 4 on each side
+		assert.Equal(t, 29*4, img.Bounds().Dx())
+	})
+
+	t.Run("Should test that the QR code can be an svg at a higher level", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("ConnectionCodeExists", "code1").Return(true)
+
+		rr := getQR(aHandler, "/connectioncodes/qr.svg?code=code1&ecc=h")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/svg+xml", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "<svg")
+	})
+
+	t.Run("Should test that unknown codes, formats, levels and scales are rejected", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("ConnectionCodeExists", "nope").Return(false)
+		mockDb.On("ConnectionCodeExists", "code1").Return(true)
+
+		assert.Equal(t, http.StatusNotFound, getQR(aHandler, "/connectioncodes/qr.png?code=nope").Code)
+		assert.Equal(t, http.StatusNotFound, getQR(aHandler, "/connectioncodes/qr.png").Code)
+		assert.Equal(t, http.StatusNotFound, getQR(aHandler, "/connectioncodes/qr.gif?code=code1").Code)
+		assert.Equal(t, http.StatusBadRequest, getQR(aHandler, "/connectioncodes/qr.png?code=code1&ecc=X").Code)
+		assert.Equal(t, http.StatusBadRequest, getQR(aHandler, "/connectioncodes/qr.png?code=code1&scale=100").Code)
+	})
+
+	t.Run("Should test that only pending LNURL-auth challenges are drawn", func(t *testing.T) {
+		db.InitCache()
+		aHandler := NewAuthHandler(mocks.NewDatabase(t))
+		k1 := "0f3e4c3b1a2d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7"
+		db.Store.SetLnCache(k1, db.LnStore{K1: k1})
+
+		assert.Equal(t, http.StatusOK, getQR(aHandler, "/lnauth/qr.svg?k1="+k1).Code)
+		assert.Equal(t, http.StatusNotFound, getQR(aHandler, "/lnauth/qr.svg?k1=unknown").Code)
+	})
+}
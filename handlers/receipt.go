@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// GetPaymentReceipt returns a PDF receipt of a paid bounty for the workspace bookkeeping
+func (h *bountyHandler) GetPaymentReceipt(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid bounty id")
+		return
+	}
+
+	bounty := h.db.GetBounty(id)
+	if bounty.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Bounty not found")
+		return
+	}
+	if bounty.WorkspaceUuid == "" && bounty.OrgUuid != "" {
+		bounty.WorkspaceUuid = bounty.OrgUuid
+	}
+
+	if !h.canViewBountyPayment(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have permission to view this bounty's payments")
+		return
+	}
+
+	payment := h.db.GetBountyPayment(bounty.ID)
+	if payment.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Bounty has no payment")
+		return
+	}
+
+	workspace := h.db.GetWorkspaceByUuid(payment.WorkspaceUuid)
+	payee := h.db.GetPersonByPubkey(payment.ReceiverPubKey)
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="receipt-%d.pdf"`, bounty.ID))
+	w.WriteHeader(http.StatusOK)
+	w.Write(utils.TextPdf("Bounty Payment Receipt", receiptLines(bounty, payment, workspace, payee)))
+}
+
+func receiptTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.UTC().Format("2006-01-02 15:04:05 UTC")
+}
+
+func receiptLines(bounty db.NewBounty, payment db.NewPaymentHistory, workspace db.Workspace, payee db.Person) []string {
+	notRecorded := "not recorded by the lightning backend"
+	preimage, paymentHash := payment.Preimage, payment.PaymentHash
+	if preimage == "" {
+		preimage = notRecorded
+	}
+	if paymentHash == "" {
+		paymentHash = notRecorded
+	}
+
+	amount := strconv.Itoa(int(payment.Amount)) + " sats"
+	currencies := []string{}
+	for currency := range payment.Fiat {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		amount += fmt.Sprintf(" / %.2f %s", payment.Fiat[currency], currency)
+	}
+
+	created := time.Unix(bounty.Created, 0)
+
+	return []string{
+		fmt.Sprintf("Receipt number: %d", payment.ID),
+		fmt.Sprintf("Paid: %s", receiptTime(payment.Created)),
+		"",
+		fmt.Sprintf("Payer workspace: %s", workspace.Name),
+		fmt.Sprintf("Workspace id: %s", payment.WorkspaceUuid),
+		fmt.Sprintf("Paid by: %s", payment.SenderPubKey),
+		"",
+		fmt.Sprintf("Payee: %s", payee.OwnerAlias),
+		fmt.Sprintf("Payee pubkey: %s", payment.ReceiverPubKey),
+		"",
+		fmt.Sprintf("Amount: %s", amount),
+		fmt.Sprintf("Payment hash: %s", paymentHash),
+		fmt.Sprintf("Preimage: %s", preimage),
+		"",
+		fmt.Sprintf("Bounty: %s", bounty.Title),
+		fmt.Sprintf("Bounty link: %s", bountyLink(bounty.ID)),
+		fmt.Sprintf("Created: %s", receiptTime(&created)),
+		fmt.Sprintf("Assigned: %s", receiptTime(bounty.AssignedDate)),
+		fmt.Sprintf("Completed: %s", receiptTime(bounty.CompletionDate)),
+	}
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPaymentReceipt(t *testing.T) {
+	now := time.Now()
+	bounty := db.NewBounty{ID: 1, Title: "Fix the login", OwnerID: "owner", Assignee: "hunter", WorkspaceUuid: "workspace-uuid", Paid: true}
+	payment := db.NewPaymentHistory{ID: 9, BountyId: 1, Amount: 1000, WorkspaceUuid: "workspace-uuid", SenderPubKey: "owner", ReceiverPubKey: "hunter", Preimage: "abc123", Fiat: db.FiatAmounts{"USD": 0.65}, Created: &now}
+
+	getReceipt := func(bHandler *bountyHandler, pubkey string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Get("/gobounties/payment/{id}/receipt.pdf", bHandler.GetPaymentReceipt)
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/gobounties/payment/1/receipt.pdf", nil)
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that the owner gets a pdf receipt of the payment", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetBountyPayment", uint(1)).Return(payment)
+		mockDb.On("GetWorkspaceByUuid", "workspace-uuid").Return(db.Workspace{Name: "Workspace"})
+		mockDb.On("GetPersonByPubkey", "hunter").Return(db.Person{OwnerAlias: "Hunter"})
+
+		rr := getReceipt(bHandler, "owner")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "%PDF-1.4")
+		assert.Contains(t, rr.Body.String(), "(Payer workspace: Workspace) Tj")
+		assert.Contains(t, rr.Body.String(), "(Amount: 1000 sats / 0.65 USD) Tj")
+		assert.Contains(t, rr.Body.String(), "(Preimage: abc123) Tj")
+	})
+
+	t.Run("Should test that an unpaid bounty has no receipt", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetBountyPayment", uint(1)).Return(db.NewPaymentHistory{})
+
+		rr := getReceipt(bHandler, "hunter")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Should test that a user without the pay bounty role gets a 401 error", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+
+		rr := getReceipt(bHandler, "someone-else")
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
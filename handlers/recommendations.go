@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/recommendations"
+)
+
+const (
+	defaultRecommendations = 20
+	maxRecommendations     = 100
+	// the candidates are the newest open bounties sharing a language with the hunter
+	recommendationCandidates = 500
+)
+
+// GetRecommendedBounties ranks the open bounties for the authenticated person by their languages,
+// skills, completed bounties and price to meet
+func (h *bountyHandler) GetRecommendedBounties(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[recommendations] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	limit := defaultRecommendations
+	if param := r.URL.Query().Get("limit"); param != "" {
+		n, err := strconv.Atoi(param)
+		if err != nil || n < 1 || n > maxRecommendations {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(fmt.Sprintf("limit must be between 1 and %d", maxRecommendations))
+			return
+		}
+		limit = n
+	}
+
+	person := h.db.GetPersonByPubkey(pubKeyFromAuth)
+	profile := recommendations.NewProfile(person, h.db.GetCompletedBountyLanguages(pubKeyFromAuth))
+	candidates := h.db.GetRecommendationCandidates(profile.Terms(), pubKeyFromAuth, recommendationCandidates)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(recommendations.Rank(profile, candidates, limit))
+}
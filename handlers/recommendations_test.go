@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/recommendations"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRecommendedBounties(t *testing.T) {
+	recommend := func(bHandler *bountyHandler, url string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "hunter")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		http.HandlerFunc(bHandler.GetRecommendedBounties).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that open bounties are ranked for the person", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetPersonByPubkey", "hunter").Return(db.Person{OwnerPubKey: "hunter", Tags: pq.StringArray{"Go"}})
+		mockDb.On("GetCompletedBountyLanguages", "hunter").Return(map[string]int{"Rust": 1})
+		mockDb.On("GetRecommendationCandidates", []string{"Go", "Rust"}, "hunter", recommendationCandidates).Return([]db.NewBounty{
+			{ID: 1, Created: 1, CodingLanguages: pq.StringArray{"Rust"}},
+			{ID: 2, Created: 2, CodingLanguages: pq.StringArray{"Go"}},
+		})
+
+		rr := recommend(bHandler, "/gobounties/recommended")
+
+		ranked := []recommendations.Recommendation{}
+		json.Unmarshal(rr.Body.Bytes(), &ranked)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, ranked, 2)
+		assert.Equal(t, uint(2), ranked[0].Bounty.ID)
+	})
+
+	t.Run("Should test that an invalid limit is refused", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+
+		rr := recommend(bHandler, "/gobounties/recommended?limit=1000")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/google/go-github/v39/github"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/notifications"
+)
+
+const (
+	repositorySyncBatch   = 20
+	repositorySyncTimeout = 30 * time.Second
+	syncedCommits         = 30
+	syncedPullRequests    = 50
+	repositoryActivityMax = 30
+)
+
+var (
+	// a branch such as bounty-12 or bounty/12 is the work on bounty 12
+	bountyBranchPattern = regexp.MustCompile(`(?i)(?:^|[/_-])bounty[/_-]?(\d+)(?:$|[/_-])`)
+	// "fixes #45" in a pull request closes issue 45 of its repository
+	closingIssuePattern = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\s+#(\d+)\b`)
+)
+
+type repositorySyncer struct {
+	db     db.Database
+	client *github.Client
+}
+
+func NewRepositorySyncer(database db.Database, client *github.Client) *repositorySyncer {
+	return &repositorySyncer{
+		db:     database,
+		client: client,
+	}
+}
+
+// SyncRepositories pulls the recent commits and pull requests of the workspace repositories from GitHub
+func SyncRepositories() {
+	NewRepositorySyncer(db.DB, githubClient()).syncRepositories()
+}
+
+func (rs *repositorySyncer) syncRepositories() {
+	for _, repository := range rs.db.GetRepositoriesToSync(repositorySyncBatch) {
+		rs.syncRepository(repository)
+	}
+}
+
+// parseGithubRepository returns the owner and name of a github.com repository url
+func parseGithubRepository(repositoryUrl string) (string, string, error) {
+	u, err := url.Parse(strings.TrimSpace(repositoryUrl))
+	if err != nil || (u.Host != "github.com" && u.Host != "www.github.com") {
+		return "", "", errors.New("not a GitHub repository")
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.New("not a GitHub repository")
+	}
+	return parts[0], strings.TrimSuffix(parts[1], ".git"), nil
+}
+
+// syncRepository stores what GitHub has on a repository, a failure is kept on the repository
+// for its admins to see and it is tried again on the next run
+func (rs *repositorySyncer) syncRepository(repository db.WorkspaceRepositories) error {
+	openPrCount, err := rs.pullRepository(repository)
+	syncError := ""
+	if err != nil {
+		fmt.Println("[repositories] could not sync", repository.Url, err)
+		syncError = err.Error()
+		openPrCount = repository.OpenPrCount
+	}
+	if err := rs.db.UpdateRepositorySync(repository.Uuid, openPrCount, syncError); err != nil {
+		fmt.Println("[repositories] could not save sync of", repository.Url, err)
+	}
+	return err
+}
+
+func (rs *repositorySyncer) pullRepository(repository db.WorkspaceRepositories) (int, error) {
+	owner, name, err := parseGithubRepository(repository.Url)
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), repositorySyncTimeout)
+	defer cancel()
+
+	commits, _, err := rs.client.Repositories.ListCommits(ctx, owner, name, &github.CommitsListOptions{
+		ListOptions: github.ListOptions{PerPage: syncedCommits},
+	})
+	if err != nil {
+		return 0, err
+	}
+	synced := []db.RepositoryCommit{}
+	for _, commit := range commits {
+		synced = append(synced, repositoryCommit(repository, commit))
+	}
+	if err := rs.db.SaveRepositoryCommits(synced); err != nil {
+		return 0, err
+	}
+
+	pulls, _, err := rs.client.PullRequests.List(ctx, owner, name, &github.PullRequestListOptions{
+		State:       "all",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: syncedPullRequests},
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, pull := range pulls {
+		pullRequest := rs.repositoryPullRequest(repository, owner, name, pull)
+		if err := rs.db.SaveRepositoryPullRequest(pullRequest); err != nil {
+			return 0, err
+		}
+		if pullRequest.State == db.PullRequestMerged && pullRequest.BountyId != 0 {
+			rs.completeBounty(pullRequest)
+		}
+	}
+
+	// one open pull request a page, the number of pages is the number of open pull requests
+	open, response, err := rs.client.PullRequests.List(ctx, owner, name, &github.PullRequestListOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if response != nil && response.LastPage > 0 {
+		return response.LastPage, nil
+	}
+	return len(open), nil
+}
+
+func repositoryCommit(repository db.WorkspaceRepositories, commit *github.RepositoryCommit) db.RepositoryCommit {
+	m := db.RepositoryCommit{
+		RepositoryUuid: repository.Uuid,
+		Sha:            commit.GetSHA(),
+		Url:            commit.GetHTMLURL(),
+		Author:         commit.GetAuthor().GetLogin(),
+	}
+	if details := commit.GetCommit(); details != nil {
+		// the first line is the subject of the commit
+		m.Message = strings.SplitN(details.GetMessage(), "\n", 2)[0]
+		if m.Author == "" {
+			m.Author = details.GetAuthor().GetName()
+		}
+		if date := details.GetAuthor().GetDate(); !date.IsZero() {
+			m.Committed = &date
+		}
+	}
+	return m
+}
+
+func (rs *repositorySyncer) repositoryPullRequest(repository db.WorkspaceRepositories, owner string, name string, pull *github.PullRequest) db.RepositoryPullRequest {
+	m := db.RepositoryPullRequest{
+		RepositoryUuid: repository.Uuid,
+		Number:         pull.GetNumber(),
+		Title:          pull.GetTitle(),
+		State:          db.PullRequestState(pull.GetState()),
+		Author:         pull.GetUser().GetLogin(),
+		Branch:         pull.GetHead().GetRef(),
+		Url:            pull.GetHTMLURL(),
+		Opened:         pull.CreatedAt,
+		Updated:        pull.UpdatedAt,
+		Closed:         pull.ClosedAt,
+	}
+	if pull.MergedAt != nil {
+		m.State = db.PullRequestMerged
+		m.Closed = pull.MergedAt
+	}
+	m.BountyId = rs.linkedBounty(repository, owner, name, pull)
+	return m
+}
+
+// linkedBounty finds the bounty of the workspace a pull request works on, from a branch named
+// after the bounty or from the issue the pull request closes
+func (rs *repositorySyncer) linkedBounty(repository db.WorkspaceRepositories, owner string, name string, pull *github.PullRequest) uint {
+	if match := bountyBranchPattern.FindStringSubmatch(pull.GetHead().GetRef()); match != nil {
+		if id, err := strconv.ParseUint(match[1], 10, 64); err == nil {
+			bounty := rs.db.GetBounty(uint(id))
+			if bounty.ID != 0 && bounty.WorkspaceUuid == repository.WorkspaceUuid {
+				return bounty.ID
+			}
+		}
+	}
+
+	for _, match := range closingIssuePattern.FindAllStringSubmatch(pull.GetTitle()+"\n"+pull.GetBody(), -1) {
+		issueUrl := fmt.Sprintf("https://github.com/%s/%s/issues/%s", owner, name, match[1])
+		if bounty := rs.db.GetWorkspaceBountyByTicketUrl(repository.WorkspaceUuid, issueUrl); bounty.ID != 0 {
+			return bounty.ID
+		}
+	}
+	return 0
+}
+
+// completeBounty completes the bounty of a merged pull request, the pull request is its proof of work
+// and the owner is left to review and pay it
+func (rs *repositorySyncer) completeBounty(pullRequest db.RepositoryPullRequest) {
+	bounty, completed, err := rs.db.CompleteBountyFromPullRequest(pullRequest.BountyId, pullRequest)
+	if err != nil {
+		fmt.Println("[repositories] could not complete bounty", pullRequest.BountyId, err)
+		return
+	}
+	if !completed {
+		return
+	}
+
+	publishBountyEvent(bounty, "bounty_updated")
+	recordActivity(bounty.Assignee, db.ActivityBountyCompleted, bounty.Title, bountyLink(bounty.ID), bounty.Price)
+	notifications.Notify(bounty.OwnerID, db.NotificationPullRequestMerged, "A pull request completed your bounty",
+		fmt.Sprintf("#%d %s was merged. Review the work and pay %s.", pullRequest.Number, pullRequest.Title, bounty.Title), bountyLink(bounty.ID))
+}
+
+// repositoryFromUrl returns the {uuid} repository of the {workspace_uuid} workspace
+func (oh *workspaceHandler) repositoryFromUrl(w http.ResponseWriter, r *http.Request) (db.WorkspaceRepositories, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return db.WorkspaceRepositories{}, false
+	}
+
+	repository, err := oh.db.GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(chi.URLParam(r, "workspace_uuid"), chi.URLParam(r, "uuid"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Repository not found")
+		return db.WorkspaceRepositories{}, false
+	}
+	return repository, true
+}
+
+// GetRepositoryActivity returns the recent commits and pull requests of a repository for the
+// workspace dashboard
+func (oh *workspaceHandler) GetRepositoryActivity(w http.ResponseWriter, r *http.Request) {
+	repository, ok := oh.repositoryFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.RepositoryActivity{
+		Repository:   repository,
+		Commits:      oh.db.GetRepositoryCommits(repository.Uuid, repositoryActivityMax),
+		PullRequests: oh.db.GetRepositoryPullRequests(repository.Uuid, repositoryActivityMax),
+	})
+}
+
+// SyncRepository lets a workspace admin sync a repository without waiting for the schedule
+func (oh *workspaceHandler) SyncRepository(w http.ResponseWriter, r *http.Request) {
+	if _, ok := oh.workspaceAdmin(w, r); !ok {
+		return
+	}
+	repository, ok := oh.repositoryFromUrl(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, repository.WorkspaceUuid) {
+		return
+	}
+
+	if err := NewRepositorySyncer(oh.db, githubClient()).syncRepository(repository); err != nil {
+		w.WriteHeader(http.StatusBadGateway)
+		json.NewEncoder(w).Encode("Could not sync the repository: " + err.Error())
+		return
+	}
+	repository, _ = oh.db.GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(repository.WorkspaceUuid, repository.Uuid)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(repository)
+}
+
+// GetBountyPullRequests lists the pull requests linked to a bounty
+func (h *bountyHandler) GetBountyPullRequests(w http.ResponseWriter, r *http.Request) {
+	bounty, ok := h.getBountyFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.db.GetBountyPullRequests(bounty.ID))
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/google/go-github/v39/github"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRepositorySync(t *testing.T) {
+	repository := db.WorkspaceRepositories{Uuid: "repo", WorkspaceUuid: "ws", Url: "https://github.com/stakwork/sphinx-tribes"}
+
+	githubServer := func(t *testing.T) *github.Client {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/repos/stakwork/sphinx-tribes/commits":
+				w.Write([]byte(`[{"sha":"abc","html_url":"https://github.com/stakwork/sphinx-tribes/commit/abc",
+					"author":{"login":"ada"},"commit":{"message":"Fix login\n\nlonger description","author":{"name":"Ada","date":"2024-01-02T03:04:05Z"}}}]`))
+			case r.URL.Path == "/repos/stakwork/sphinx-tribes/pulls" && r.URL.Query().Get("state") == "open":
+				w.Header().Set("Link", `<https://api.github.com/repos/stakwork/sphinx-tribes/pulls?state=open&per_page=1&page=3>; rel="last"`)
+				w.Write([]byte(`[{"number":7,"state":"open"}]`))
+			case r.URL.Path == "/repos/stakwork/sphinx-tribes/pulls":
+				w.Write([]byte(`[
+					{"number":7,"state":"open","title":"Login","head":{"ref":"feature/bounty-12"},"user":{"login":"ada"}},
+					{"number":8,"state":"closed","title":"Signup","body":"Fixes #45","html_url":"https://github.com/stakwork/sphinx-tribes/pull/8","merged_at":"2024-01-03T00:00:00Z","head":{"ref":"signup"}},
+					{"number":9,"state":"open","title":"Other","head":{"ref":"bounty-99"}}
+				]`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		t.Cleanup(server.Close)
+		client := github.NewClient(nil)
+		client.BaseURL, _ = url.Parse(server.URL + "/")
+		return client
+	}
+
+	t.Run("Should test that only github.com repositories are synced", func(t *testing.T) {
+		owner, name, err := parseGithubRepository("https://github.com/stakwork/sphinx-tribes.git")
+		assert.NoError(t, err)
+		assert.Equal(t, "stakwork", owner)
+		assert.Equal(t, "sphinx-tribes", name)
+
+		_, _, err = parseGithubRepository("https://gitlab.com/stakwork/sphinx-tribes")
+		assert.Error(t, err)
+		_, _, err = parseGithubRepository("https://github.com/stakwork")
+		assert.Error(t, err)
+	})
+
+	t.Run("Should test that commits and pull requests are stored and linked to bounties", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		syncer := NewRepositorySyncer(mockDb, githubServer(t))
+
+		mockDb.On("SaveRepositoryCommits", mock.MatchedBy(func(ms []db.RepositoryCommit) bool {
+			return len(ms) == 1 && ms[0].Sha == "abc" && ms[0].Message == "Fix login" && ms[0].Author == "ada" && ms[0].Committed != nil
+		})).Return(nil).Once()
+		mockDb.On("GetBounty", uint(12)).Return(db.NewBounty{ID: 12, WorkspaceUuid: "ws"}).Once()
+		// a bounty of another workspace is not linked
+		mockDb.On("GetBounty", uint(99)).Return(db.NewBounty{ID: 99, WorkspaceUuid: "other"}).Once()
+		mockDb.On("GetWorkspaceBountyByTicketUrl", "ws", "https://github.com/stakwork/sphinx-tribes/issues/45").Return(db.NewBounty{ID: 45}).Once()
+		mockDb.On("SaveRepositoryPullRequest", mock.MatchedBy(func(m db.RepositoryPullRequest) bool {
+			return m.Number == 7 && m.State == db.PullRequestOpen && m.BountyId == 12 && m.Branch == "feature/bounty-12"
+		})).Return(nil).Once()
+		mockDb.On("SaveRepositoryPullRequest", mock.MatchedBy(func(m db.RepositoryPullRequest) bool {
+			return m.Number == 8 && m.State == db.PullRequestMerged && m.BountyId == 45 && m.Closed != nil
+		})).Return(nil).Once()
+		mockDb.On("SaveRepositoryPullRequest", mock.MatchedBy(func(m db.RepositoryPullRequest) bool {
+			return m.Number == 9 && m.BountyId == 0
+		})).Return(nil).Once()
+		mockDb.On("CompleteBountyFromPullRequest", uint(45), mock.MatchedBy(func(m db.RepositoryPullRequest) bool {
+			return m.Number == 8 && m.Url == "https://github.com/stakwork/sphinx-tribes/pull/8"
+		})).Return(db.NewBounty{ID: 45, OwnerID: "owner", Assignee: "ada", Completed: true}, true, nil).Once()
+		mockDb.On("UpdateRepositorySync", "repo", 3, "").Return(nil).Once()
+
+		assert.NoError(t, syncer.syncRepository(repository))
+	})
+
+	t.Run("Should test that a failed sync is recorded on the repository", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		syncer := NewRepositorySyncer(mockDb, githubServer(t))
+
+		missing := repository
+		missing.Url = "https://github.com/stakwork/missing"
+		missing.OpenPrCount = 4
+		mockDb.On("UpdateRepositorySync", "repo", 4, mock.AnythingOfType("string")).Return(nil).Once()
+
+		assert.Error(t, syncer.syncRepository(missing))
+	})
+
+	t.Run("Should test that the activity of a repository is returned", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceRepoByWorkspaceUuidAndRepoUuid", "ws", "repo").Return(repository, nil).Once()
+		mockDb.On("GetRepositoryCommits", "repo", repositoryActivityMax).Return([]db.RepositoryCommit{{Sha: "abc"}}).Once()
+		mockDb.On("GetRepositoryPullRequests", "repo", repositoryActivityMax).Return([]db.RepositoryPullRequest{{Number: 7}}).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", "ws")
+		rctx.URLParams.Add("uuid", "repo")
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, "member"))
+		rr := httptest.NewRecorder()
+		oh.GetRepositoryActivity(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		activity := db.RepositoryActivity{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &activity))
+		assert.Equal(t, "abc", activity.Commits[0].Sha)
+		assert.Equal(t, 7, activity.PullRequests[0].Number)
+	})
+}
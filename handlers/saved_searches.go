@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/lib/pq"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/notifications"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+const maxSavedSearches = 20
+
+func searchTerms(terms []string) pq.StringArray {
+	kept := pq.StringArray{}
+	for _, term := range terms {
+		if term = strings.TrimSpace(term); term != "" {
+			kept = append(kept, term)
+		}
+	}
+	return kept
+}
+
+// CreateSavedSearch saves bounty filters the person is notified about when a matching bounty is posted
+func (h *bountyHandler) CreateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[saved searches] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.SavedSearchRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	search := db.SavedSearch{
+		OwnerPubKey:   pubKeyFromAuth,
+		Name:          strings.TrimSpace(request.Name),
+		Skills:        searchTerms(request.Skills),
+		Languages:     searchTerms(request.Languages),
+		MinPrice:      request.MinPrice,
+		WorkspaceUuid: strings.TrimSpace(request.WorkspaceUuid),
+	}
+	if search.Name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A name is required")
+		return
+	}
+	if len(h.db.GetSavedSearches(pubKeyFromAuth)) >= maxSavedSearches {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("You can save at most %d searches", maxSavedSearches))
+		return
+	}
+
+	search, err := h.db.CreateSavedSearch(search)
+	if err != nil {
+		log.Printf("[saved searches] could not save search: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save the search")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(search)
+}
+
+// GetSavedSearches lists the saved searches of the authenticated person
+func (h *bountyHandler) GetSavedSearches(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[saved searches] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.db.GetSavedSearches(pubKeyFromAuth))
+}
+
+func (h *bountyHandler) DeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[saved searches] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid saved search id")
+		return
+	}
+
+	if err := h.db.DeleteSavedSearch(pubKeyFromAuth, id); err != nil {
+		log.Printf("[saved searches] could not delete search: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// alertSavedSearches notifies the people with a saved search matching a new bounty, once each
+func (h *bountyHandler) alertSavedSearches(bounty db.NewBounty) {
+	if !bounty.Show {
+		return
+	}
+
+	alerted := map[string]bool{}
+	for _, search := range h.db.GetSavedSearchesForBounty(bounty) {
+		if alerted[search.OwnerPubKey] || search.OwnerPubKey == bounty.OwnerID || !db.MatchesSavedSearch(search, bounty) {
+			continue
+		}
+		alerted[search.OwnerPubKey] = true
+		notifications.Notify(search.OwnerPubKey, db.NotificationSavedSearchMatch, fmt.Sprintf("A new bounty matches \"%s\"", search.Name), bounty.Title, bountyLink(bounty.ID))
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSavedSearches(t *testing.T) {
+	create := func(bHandler *bountyHandler, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "hunter")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/gobounties/saved_searches", bytes.NewBufferString(body))
+		http.HandlerFunc(bHandler.CreateSavedSearch).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a search is saved with its trimmed filters", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetSavedSearches", "hunter").Return([]db.SavedSearch{})
+		mockDb.On("CreateSavedSearch", mock.MatchedBy(func(s db.SavedSearch) bool {
+			return s.OwnerPubKey == "hunter" && s.Name == "Rust work" && len(s.Languages) == 1 && s.MinPrice == 1000
+		})).Return(db.SavedSearch{ID: 1}, nil)
+
+		rr := create(bHandler, `{"name":" Rust work ","languages":["Rust"," "],"min_price":1000}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a search needs a name", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+
+		rr := create(bHandler, `{"languages":["Rust"]}`)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the number of saved searches is limited", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetSavedSearches", "hunter").Return(make([]db.SavedSearch, maxSavedSearches))
+
+		rr := create(bHandler, `{"name":"One more"}`)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a new bounty is matched against the saved searches", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bounty := db.NewBounty{ID: 1, OwnerID: "owner", Title: "Rust client", Show: true, Price: 2000, CodingLanguages: pq.StringArray{"Rust"}}
+		mockDb.On("GetSavedSearchesForBounty", bounty).Return([]db.SavedSearch{
+			{OwnerPubKey: "hunter", Name: "Rust", Languages: pq.StringArray{"Rust"}},
+			{OwnerPubKey: "hunter", Name: "Anything"},
+			{OwnerPubKey: "designer", Name: "Design", Skills: pq.StringArray{"figma"}},
+		})
+
+		bHandler.alertSavedSearches(bounty)
+	})
+
+	t.Run("Should test that a hidden bounty raises no alerts", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+
+		bHandler.alertSavedSearches(db.NewBounty{ID: 1, OwnerID: "owner", Show: false})
+	})
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/go-co-op/gocron"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/feeds"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+type ScheduledJob struct {
+	Name     string     `json:"name"`
+	Schedule string     `json:"schedule"`
+	LastRun  *time.Time `json:"last_run"`
+	NextRun  time.Time  `json:"next_run"`
+}
+
+type RelayChat struct {
+	Uuid       string `json:"uuid"`
+	ContactIds []uint `json:"contact_ids"`
+}
+
+type RelayChatsResponse struct {
+	Success  bool        `json:"success"`
+	Response []RelayChat `json:"response"`
+}
+
+var Scheduler *gocron.Scheduler
+
+// jobSchedules keeps the cron expression each job was registered with, keyed by job name
+var jobSchedules = map[string]string{}
+
+// InitScheduler registers the periodic maintenance tasks, schedules are cron expressions read from the config
+func InitScheduler() {
+	Scheduler = gocron.NewScheduler(time.UTC)
+	Scheduler.TagsUnique()
+
+	tasks := []struct {
+		name     string
+		schedule string
+		task     func()
+	}{
+		{"expire_stale_invoices", config.InvoiceExpirySchedule, ExpireStaleInvoices},
+		{"tribe_member_counts", config.TribeMemberCountSchedule, RecomputeTribeMemberCounts},
+		{"refresh_feeds", config.FeedRefreshSchedule, RefreshCachedFeeds},
+		{"close_expired_bounties", config.BountyDeadlineSchedule, CloseExpiredBounties},
+		{"purge_idempotency_keys", config.IdempotencyPurgeSchedule, PurgeExpiredIdempotencyKeys},
+		{"recompute_reputation", config.ReputationSchedule, RecomputeReputationScores},
+		{"purge_deleted_accounts", config.AccountPurgeSchedule, PurgeDeletedAccounts},
+		{"low_budget_alerts", config.BudgetAlertSchedule, CheckLowBudgets},
+		{"reconcile_invoices", config.InvoiceReconcileSchedule, ReconcileInvoices},
+		{"retry_bounty_payments", config.PaymentRetrySchedule, RetryBountyPayments},
+		{"expire_stale_assignments", config.AssignmentExpirySchedule, ExpireStaleAssignments},
+		{"recompute_leaderboards", config.LeaderboardSchedule, RecomputeLeaderboards},
+		{"rollup_metrics", config.MetricsRollupSchedule, RollupMetrics},
+		{"reindex_search", config.SearchReindexSchedule, ReindexSearch},
+		{"sync_search_index", config.SearchSyncSchedule, SyncSearchIndex},
+		{"process_media", config.MediaJobSchedule, ProcessMediaJobs},
+		{"run_workflows", config.WorkflowJobSchedule, ProcessWorkflowExecutions},
+		{"deliver_webhooks", config.WebhookJobSchedule, ProcessWebhookDeliveries},
+		{"notify_impersonations", config.ImpersonationSchedule, NotifyEndedImpersonations},
+		{"expire_workspace_transfers", config.WorkspaceTransferSchedule, ExpireWorkspaceTransfers},
+		{"sync_repositories", config.RepositorySyncSchedule, SyncRepositories},
+	}
+
+	for _, t := range tasks {
+		_, err := Scheduler.Cron(t.schedule).Tag(t.name).SingletonMode().Do(t.task)
+		if err != nil {
+			fmt.Println("[scheduler] could not schedule", t.name, err)
+			continue
+		}
+		jobSchedules[t.name] = t.schedule
+	}
+
+	Scheduler.StartAsync()
+}
+
+// GetScheduledJobs lists the scheduled maintenance tasks with their next run times
+func GetScheduledJobs(w http.ResponseWriter, r *http.Request) {
+	jobs := []ScheduledJob{}
+
+	if Scheduler != nil {
+		for _, job := range Scheduler.Jobs() {
+			scheduledJob := ScheduledJob{
+				NextRun: job.NextRun(),
+			}
+			if tags := job.Tags(); len(tags) > 0 {
+				scheduledJob.Name = tags[0]
+				scheduledJob.Schedule = jobSchedules[tags[0]]
+			}
+			if lastRun := job.LastRun(); !lastRun.IsZero() {
+				scheduledJob.LastRun = &lastRun
+			}
+			jobs = append(jobs, scheduledJob)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(jobs)
+}
+
+// ExpireStaleInvoices drops expired invoices from the invoice and budget invoice caches
+func ExpireStaleInvoices() {
+	invoiceList, _ := db.Store.GetInvoiceCache()
+	activeInvoices := []db.InvoiceStoreData{}
+	for _, inv := range invoiceList {
+		if !utils.GetInvoiceExpired(inv.Invoice) {
+			activeInvoices = append(activeInvoices, inv)
+		}
+	}
+	if len(activeInvoices) != len(invoiceList) {
+		db.Store.SetInvoiceCache(activeInvoices)
+	}
+
+	budgetInvoiceList, _ := db.Store.GetBudgetInvoiceCache()
+	activeBudgetInvoices := []db.BudgetStoreData{}
+	for _, inv := range budgetInvoiceList {
+		if !utils.GetInvoiceExpired(inv.Invoice) {
+			activeBudgetInvoices = append(activeBudgetInvoices, inv)
+		}
+	}
+	if len(activeBudgetInvoices) != len(budgetInvoiceList) {
+		db.Store.SetBudgetInvoiceCache(activeBudgetInvoices)
+	}
+}
+
+// RecomputeTribeMemberCounts updates the member count of tribes hosted on the relay
+func RecomputeTribeMemberCounts() {
+	url := fmt.Sprintf("%s/chats", config.RelayUrl)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		log.Printf("Request Failed: %s", err)
+		return
+	}
+
+	req.Header.Set("x-user-token", config.RelayAuthKey)
+	req.Header.Set("Content-Type", "application/json")
+	res, err := client.Do(req)
+	if err != nil {
+		log.Printf("Request Failed: %s", err)
+		return
+	}
+
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		log.Printf("Reading body failed: %s", err)
+		return
+	}
+
+	chatsRes := RelayChatsResponse{}
+	if err := json.Unmarshal(body, &chatsRes); err != nil {
+		log.Printf("Reading relay chats failed: %s", err)
+		return
+	}
+
+	now := time.Now()
+	for _, chat := range chatsRes.Response {
+		if chat.Uuid == "" {
+			continue
+		}
+		tribe := db.DB.GetTribe(chat.Uuid)
+		if tribe.UUID == "" || tribe.MemberCount == uint64(len(chat.ContactIds)) {
+			continue
+		}
+		db.DB.UpdateTribe(tribe.UUID, map[string]interface{}{
+			"member_count": uint64(len(chat.ContactIds)),
+			"updated":      &now,
+		})
+	}
+}
+
+// RefreshCachedFeeds re-parses the feeds of all tribes and warms the feed cache
+func RefreshCachedFeeds() {
+	for _, tribe := range db.DB.GetAllTribes() {
+		if tribe.FeedURL == "" {
+			continue
+		}
+		feed, err := feeds.ParseFeed(tribe.FeedURL, false)
+		if err != nil {
+			fmt.Println("[scheduler] could not refresh feed", tribe.FeedURL, err)
+			continue
+		}
+		cacheFeed(tribe.FeedURL, feed)
+	}
+}
+
+// CloseExpiredBounties hides open, unassigned bounties whose expiry date has passed
+func CloseExpiredBounties() {
+	now := time.Now()
+	for _, bounty := range db.DB.GetOpenBountiesWithExpiry() {
+		expires, err := dateparse.ParseAny(bounty.BountyExpires)
+		if err != nil || expires.After(now) {
+			continue
+		}
+		if err := db.DB.CloseBounty(bounty.Created); err != nil {
+			fmt.Println("[scheduler] could not close bounty", bounty.ID, err)
+			continue
+		}
+		db.DB.AddDeletion(db.DeletionKindBounty, strconv.Itoa(int(bounty.ID)))
+	}
+}
+
+// PurgeExpiredIdempotencyKeys removes stored responses that can no longer be replayed
+func PurgeExpiredIdempotencyKeys() {
+	if err := db.DB.DeleteExpiredIdempotencyKeys(); err != nil {
+		fmt.Println("[scheduler] could not purge idempotency keys", err)
+	}
+}
+
+// RecomputeReputationScores refreshes the reputation score of everyone who completed a bounty
+func RecomputeReputationScores() {
+	for _, pubkey := range db.DB.GetBountyHunterPubkeys() {
+		stats := db.DB.GetReputationStats(pubkey)
+		db.DB.UpdateReputationScore(pubkey, db.ReputationScore(stats))
+	}
+}
+
+// PurgeDeletedAccounts removes the remaining data of accounts whose deletion grace period has ended
+func PurgeDeletedAccounts() {
+	for _, purge := range db.DB.GetDueAccountPurges() {
+		if err := db.DB.PurgeAccountData(purge.OwnerPubKey); err != nil {
+			fmt.Println("[scheduler] could not purge account", purge.OwnerPubKey, err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetScheduledJobs(t *testing.T) {
+	t.Run("Should test that scheduled jobs are returned with their next run time", func(t *testing.T) {
+		Scheduler = gocron.NewScheduler(time.UTC)
+		Scheduler.TagsUnique()
+		_, err := Scheduler.Cron("0 0 * * *").Tag("close_expired_bounties").Do(func() {})
+		assert.NoError(t, err)
+		jobSchedules["close_expired_bounties"] = "0 0 * * *"
+		Scheduler.StartAsync()
+		defer Scheduler.Stop()
+
+		req, err := http.NewRequest("GET", "/admin/scheduler", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(GetScheduledJobs)
+
+		handler.ServeHTTP(rr, req)
+
+		var jobs []ScheduledJob
+		err = json.Unmarshal(rr.Body.Bytes(), &jobs)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, jobs, 1)
+		assert.Equal(t, "close_expired_bounties", jobs[0].Name)
+		assert.Equal(t, "0 0 * * *", jobs[0].Schedule)
+		assert.True(t, jobs[0].NextRun.After(time.Now()))
+	})
+}
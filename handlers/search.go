@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/search"
+)
+
+const maxSearchLimit = 50
+
+type searchHandler struct {
+	db     db.Database
+	engine search.Engine
+}
+
+func NewSearchHandler(httpClient search.HttpClient, database db.Database) *searchHandler {
+	return &searchHandler{
+		db:     database,
+		engine: search.NewEngine(httpClient, database),
+	}
+}
+
+// searchReindex is held while the index is rebuilt so the job and the admin endpoint don't overlap
+var searchReindex sync.Mutex
+
+type searchIndexTypeHealth struct {
+	Type     string `json:"type"`
+	Database int64  `json:"database"`
+	Indexed  int64  `json:"indexed"`
+}
+
+type searchIndexHealth struct {
+	Backend   string                  `json:"backend"`
+	Available bool                    `json:"available"`
+	Indexing  bool                    `json:"indexing"`
+	Error     string                  `json:"error,omitempty"`
+	Backlog   *db.SearchIndexBacklog  `json:"backlog,omitempty"`
+	Types     []searchIndexTypeHealth `json:"types,omitempty"`
+}
+
+// Search looks ?q= up across tribes, people, bots, bounties and workspaces, or the ?types= given,
+// and returns the results grouped by type, at most ?limit= per type
+func (sh *searchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	keys := r.URL.Query()
+
+	query := strings.TrimSpace(keys.Get("q"))
+	if query == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("q is required")
+		return
+	}
+
+	types := search.ParseTypes(keys.Get("types"))
+	if types == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("types must be among %s", strings.Join(db.SearchTypes, ", ")))
+		return
+	}
+
+	limit := 10
+	if value := keys.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxSearchLimit {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	results, err := sh.engine.Search(query, types, limit)
+	if err != nil {
+		fmt.Println("[search] search failed", err)
+		w.WriteHeader(http.StatusBadGateway)
+		json.NewEncoder(w).Encode("Search is unavailable")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"query":  query,
+		"groups": search.Grouped(types, results),
+	})
+}
+
+// ReindexSearch rebuilds the search index when the engine keeps its own
+func ReindexSearch() {
+	indexer, ok := search.NewEngine(http.DefaultClient, db.DB).(search.Indexer)
+	if !ok || !searchReindex.TryLock() {
+		return
+	}
+	defer searchReindex.Unlock()
+
+	if err := indexer.Reindex(db.DB); err != nil {
+		fmt.Println("[scheduler] could not reindex the search", err)
+	}
+}
+
+// SyncSearchIndex mirrors the entities written since the last sync into the search index
+func SyncSearchIndex() {
+	indexer, ok := search.NewEngine(http.DefaultClient, db.DB).(search.Indexer)
+	if !ok {
+		return
+	}
+	if _, err := search.SyncChanges(indexer, db.DB); err != nil {
+		fmt.Println("[scheduler] could not sync the search index", err)
+	}
+}
+
+// RebuildSearchIndex starts a rebuild of the search index in the background
+func (sh *searchHandler) RebuildSearchIndex(w http.ResponseWriter, r *http.Request) {
+	indexer, ok := sh.engine.(search.Indexer)
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The search backend has no index to rebuild")
+		return
+	}
+	if !searchReindex.TryLock() {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("The search index is already being rebuilt")
+		return
+	}
+
+	go func() {
+		defer searchReindex.Unlock()
+		if err := indexer.Reindex(sh.db); err != nil {
+			fmt.Println("[search] could not reindex the search", err)
+		}
+	}()
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode("Search index rebuild started")
+}
+
+// GetSearchIndexHealth compares the documents of the search index with the database and reports
+// the changes still waiting to be synced
+func (sh *searchHandler) GetSearchIndexHealth(w http.ResponseWriter, r *http.Request) {
+	indexer, ok := sh.engine.(search.Indexer)
+	if !ok {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(searchIndexHealth{Backend: search.BackendPostgres, Available: true})
+		return
+	}
+
+	backlog := sh.db.GetSearchIndexBacklog()
+	health := searchIndexHealth{Backend: search.BackendMeilisearch, Backlog: &backlog}
+
+	indexHealth, err := indexer.Health()
+	if err != nil {
+		health.Error = err.Error()
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(health)
+		return
+	}
+
+	health.Available = indexHealth.Available
+	health.Indexing = indexHealth.Indexing
+	for _, entityType := range db.SearchTypes {
+		health.Types = append(health.Types, searchIndexTypeHealth{
+			Type:     entityType,
+			Database: sh.db.CountSearchDocuments(entityType),
+			Indexed:  indexHealth.Documents[entityType],
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(health)
+}
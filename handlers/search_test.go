@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearch(t *testing.T) {
+	searchFor := func(sh *searchHandler, url string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sh.Search).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, url, nil))
+		return rr
+	}
+
+	t.Run("Should test that the results are grouped by type with their scores", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		sh := &searchHandler{engine: search.NewPostgresEngine(mockDb)}
+		mockDb.On("SearchEntities", "lightning", db.SearchTypeTribe, 3).Return([]db.SearchResult{{Type: db.SearchTypeTribe, ID: "tribe-uuid", Title: "Lightning", Score: 0.6}})
+		mockDb.On("SearchEntities", "lightning", db.SearchTypeBounty, 3).Return([]db.SearchResult{})
+
+		rr := searchFor(sh, "/search?q=lightning&types=bounty,tribe&limit=3")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"query": "lightning", "groups": [
+			{"type": "tribe", "results": [{"type": "tribe", "id": "tribe-uuid", "title": "Lightning", "description": "", "img": "", "score": 0.6}]},
+			{"type": "bounty", "results": []}
+		]}`, rr.Body.String())
+	})
+
+	t.Run("Should test that every type is searched by default", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		sh := &searchHandler{engine: search.NewPostgresEngine(mockDb)}
+		for _, entityType := range db.SearchTypes {
+			mockDb.On("SearchEntities", "sphinx", entityType, 10).Return([]db.SearchResult{}).Once()
+		}
+
+		rr := searchFor(sh, "/search?q=sphinx")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a missing query, an unknown type or a bad limit is refused", func(t *testing.T) {
+		sh := &searchHandler{engine: search.NewPostgresEngine(mocks.NewDatabase(t))}
+
+		for _, url := range []string{"/search?q=%20", "/search?q=sphinx&types=podcast", "/search?q=sphinx&limit=500"} {
+			rr := searchFor(sh, url)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, url)
+		}
+	})
+}
+
+type testIndexEngine struct {
+	health    search.IndexHealth
+	err       error
+	reindexed chan bool
+}
+
+func (te *testIndexEngine) Search(query string, types []string, limit int) (map[string][]db.SearchResult, error) {
+	return map[string][]db.SearchResult{}, nil
+}
+
+func (te *testIndexEngine) Reindex(database db.Database) error {
+	te.reindexed <- true
+	return nil
+}
+
+func (te *testIndexEngine) Upsert(entityType string, documents []db.SearchResult) error { return nil }
+
+func (te *testIndexEngine) Remove(entityType string, ids []string) error { return nil }
+
+func (te *testIndexEngine) Health() (search.IndexHealth, error) { return te.health, te.err }
+
+func TestSearchIndexAdmin(t *testing.T) {
+	t.Run("Should test that the postgres backend has no index to rebuild", func(t *testing.T) {
+		sh := &searchHandler{engine: search.NewPostgresEngine(nil)}
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sh.RebuildSearchIndex).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/search/reindex", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a rebuild runs in the background and can't overlap", func(t *testing.T) {
+		engine := &testIndexEngine{reindexed: make(chan bool)}
+		sh := &searchHandler{engine: engine}
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sh.RebuildSearchIndex).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/search/reindex", nil))
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+
+		// the first rebuild is blocked until the channel is read
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(sh.RebuildSearchIndex).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/search/reindex", nil))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		<-engine.reindexed
+	})
+
+	t.Run("Should test that the health compares the index with the database", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		engine := &testIndexEngine{health: search.IndexHealth{Available: true, Documents: map[string]int64{db.SearchTypeBounty: 8}}}
+		sh := &searchHandler{db: mockDb, engine: engine}
+
+		mockDb.On("GetSearchIndexBacklog").Return(db.SearchIndexBacklog{Pending: 3})
+		mockDb.On("CountSearchDocuments", db.SearchTypeBounty).Return(int64(10))
+		mockDb.On("CountSearchDocuments", mock.Anything).Return(int64(0))
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sh.GetSearchIndexHealth).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/search/health", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"backlog":{"pending":3}`)
+		assert.Contains(t, rr.Body.String(), `{"type":"bounty","database":10,"indexed":8}`)
+	})
+
+	t.Run("Should test that an unreachable index is reported as unavailable", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		sh := &searchHandler{db: mockDb, engine: &testIndexEngine{err: errors.New("connection refused")}}
+		mockDb.On("GetSearchIndexBacklog").Return(db.SearchIndexBacklog{})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sh.GetSearchIndexHealth).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/search/health", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"error":"connection refused"`)
+	})
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// bountyEmbeds are the objects a bounty response embeds next to the bounty
+var bountyEmbeds = []string{"owner", "assignee", "workspace", "organization", "attachments"}
+
+// responseShape is the part of a response a client asked for, to keep payloads small on mobile.
+// ?fields=id,title keeps those fields of the resource and a dotted field such as assignee.img keeps
+// a field of an embedded object. ?include=assignee,workspace sends only those embedded objects.
+// Without the params the whole response is sent
+type responseShape struct {
+	embeds   []string
+	fields   map[string]map[string]bool
+	included map[string]bool
+}
+
+// parseResponseShape reads the ?fields= and ?include= of a request for a response that can embed
+// the given objects, including anything else is an error
+func parseResponseShape(r *http.Request, embeds ...string) (responseShape, error) {
+	shape := responseShape{embeds: embeds}
+
+	if fields := r.URL.Query().Get("fields"); fields != "" {
+		shape.fields = map[string]map[string]bool{}
+		for _, field := range strings.Split(fields, ",") {
+			field = strings.TrimSpace(field)
+			object := ""
+			if i := strings.Index(field, "."); i >= 0 {
+				object, field = field[:i], field[i+1:]
+			}
+			if field == "" {
+				continue
+			}
+			if shape.fields[object] == nil {
+				shape.fields[object] = map[string]bool{}
+			}
+			shape.fields[object][field] = true
+		}
+	}
+
+	if _, ok := r.URL.Query()["include"]; ok {
+		shape.included = map[string]bool{}
+		for _, embed := range strings.Split(r.URL.Query().Get("include"), ",") {
+			embed = strings.TrimSpace(embed)
+			if embed == "" {
+				continue
+			}
+			if len(embeds) == 0 {
+				return shape, fmt.Errorf("cannot include %s, the response embeds nothing", embed)
+			}
+			if !shape.isEmbed(embed) {
+				return shape, fmt.Errorf("cannot include %s, the response embeds %s", embed, strings.Join(embeds, ", "))
+			}
+			shape.included[embed] = true
+		}
+	}
+	return shape, nil
+}
+
+func (s responseShape) isEmbed(name string) bool {
+	for _, embed := range s.embeds {
+		if embed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// includes reports whether an embedded object is sent, so the handler only loads what is sent
+func (s responseShape) includes(embed string) bool {
+	return s.included == nil || s.included[embed]
+}
+
+// shape trims a response to the shape. The resource key names the field holding the resource when
+// it sits next to its embeds, like the bounty of a bounty response, empty when the embeds are
+// fields of the resource
+func (s responseShape) shape(value interface{}, resourceKey string) (interface{}, error) {
+	if s.fields == nil && s.included == nil {
+		return value, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	// numbers are kept as they were written, large ids don't lose precision
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var shaped interface{}
+	if err := decoder.Decode(&shaped); err != nil {
+		return nil, err
+	}
+
+	if items, ok := shaped.([]interface{}); ok {
+		for _, item := range items {
+			s.shapeObject(item, resourceKey)
+		}
+		return items, nil
+	}
+	s.shapeObject(shaped, resourceKey)
+	return shaped, nil
+}
+
+func (s responseShape) shapeObject(value interface{}, resourceKey string) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, embed := range s.embeds {
+		if !s.includes(embed) {
+			delete(object, embed)
+			continue
+		}
+		if fields := s.fields[embed]; fields != nil {
+			keepFields(object[embed], fields)
+		}
+	}
+
+	fields := s.fields[""]
+	if fields == nil {
+		return
+	}
+	if resourceKey != "" {
+		keepFields(object[resourceKey], fields)
+		return
+	}
+	for key := range object {
+		if !fields[key] && !s.isEmbed(key) {
+			delete(object, key)
+		}
+	}
+}
+
+// keepFields drops the other fields of an object, or of each object of a list
+func keepFields(value interface{}, fields map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key := range v {
+			if !fields[key] {
+				delete(v, key)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			keepFields(item, fields)
+		}
+	}
+}
+
+// writeShaped writes a response trimmed to the shape the request asked for
+func writeShaped(w http.ResponseWriter, shape responseShape, value interface{}, resourceKey string) {
+	shaped, err := shape.shape(value, resourceKey)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not write the response")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(shaped)
+}
+
+// responseShapeFromUrl parses the shape of a request, writing the error response when it is invalid
+func responseShapeFromUrl(w http.ResponseWriter, r *http.Request, embeds ...string) (responseShape, bool) {
+	shape, err := parseResponseShape(r, embeds...)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return shape, false
+	}
+	return shape, true
+}
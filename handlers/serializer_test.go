@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseShape(t *testing.T) {
+	responses := []db.BountyResponse{{
+		Bounty:    db.NewBounty{ID: 12, Title: "Login", Price: 1000, Description: "long"},
+		Assignee:  db.Person{OwnerPubKey: "ada", OwnerAlias: "Ada", Img: "ada.png"},
+		Owner:     db.Person{OwnerPubKey: "owner"},
+		Workspace: db.WorkspaceShort{Uuid: "ws", Name: "Sphinx"},
+	}}
+
+	t.Run("Should test that only the asked fields and embeds are sent", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/?fields=id,title,price,assignee.img&include=assignee,workspace", nil)
+		shape, err := parseResponseShape(req, bountyEmbeds...)
+		assert.NoError(t, err)
+		assert.True(t, shape.includes("assignee"))
+		assert.False(t, shape.includes("owner"))
+
+		shaped, err := shape.shape(responses, "bounty")
+		assert.NoError(t, err)
+		data, _ := json.Marshal(shaped)
+		assert.JSONEq(t, `[{"bounty":{"id":12,"title":"Login","price":1000},"assignee":{"img":"ada.png"},"workspace":{"uuid":"ws","name":"Sphinx","img":""}}]`, string(data))
+	})
+
+	t.Run("Should test that the response is unchanged without the params", func(t *testing.T) {
+		shape, err := parseResponseShape(httptest.NewRequest(http.MethodGet, "/", nil), bountyEmbeds...)
+		assert.NoError(t, err)
+		assert.True(t, shape.includes("owner"))
+
+		shaped, err := shape.shape(responses, "bounty")
+		assert.NoError(t, err)
+		assert.Equal(t, responses, shaped)
+	})
+
+	t.Run("Should test that the fields of a resource without a wrapper are kept next to its embeds", func(t *testing.T) {
+		shape, err := parseResponseShape(httptest.NewRequest(http.MethodGet, "/?fields=owner_alias", nil))
+		assert.NoError(t, err)
+
+		shaped, err := shape.shape([]db.Person{{OwnerPubKey: "ada", OwnerAlias: "Ada"}}, "")
+		assert.NoError(t, err)
+		data, _ := json.Marshal(shaped)
+		assert.JSONEq(t, `[{"owner_alias":"Ada"}]`, string(data))
+	})
+
+	t.Run("Should test that including an unknown object is refused", func(t *testing.T) {
+		_, err := parseResponseShape(httptest.NewRequest(http.MethodGet, "/?include=assignee,payments", nil), bountyEmbeds...)
+		assert.Error(t, err)
+		_, err = parseResponseShape(httptest.NewRequest(http.MethodGet, "/?include=owner", nil))
+		assert.Error(t, err)
+	})
+
+	t.Run("Should test that a bounty only loads the embeds it includes", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBountyById", "12").Return([]db.NewBounty{{ID: 12, Title: "Login", OwnerID: "owner", Assignee: "ada", WorkspaceUuid: "ws"}}, nil).Once()
+		mockDb.On("GetPersonByPubkey", "ada").Return(db.Person{OwnerPubKey: "ada", OwnerAlias: "Ada"}).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("bountyId", "12")
+		req := httptest.NewRequest(http.MethodGet, "/id/12?include=assignee&fields=id,title,assignee.owner_alias", nil)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rr := httptest.NewRecorder()
+		bHandler.GetBountyById(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `[{"bounty":{"id":12,"title":"Login"},"assignee":{"owner_alias":"Ada"}}]`, rr.Body.String())
+	})
+}
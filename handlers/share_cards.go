@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/media"
+	"github.com/stakwork/sphinx-tribes/storage"
+)
+
+const (
+	// shareCardVersion is part of the cache key of the cards, bump it when their layout changes
+	shareCardVersion = "1"
+
+	shareCardAvatarTimeout = 5 * time.Second
+	shareCardAvatarMaxSize = 5 * 1024 * 1024
+)
+
+type shareCardHandler struct {
+	db         db.Database
+	storage    storage.Storage
+	httpClient HttpClient
+}
+
+// NewShareCardHandler caches the cards in the storage selected with STORAGE_BACKEND, they are
+// rendered on every request when it is the meme server
+func NewShareCardHandler(database db.Database) *shareCardHandler {
+	store, err := storage.NewStorage()
+	if err != nil {
+		fmt.Println("could not set up the storage:", err)
+	}
+	return &shareCardHandler{db: database, storage: store, httpClient: http.DefaultClient}
+}
+
+// formatSats writes an amount with thousands separators such as "50,000 sats"
+func formatSats(amount uint) string {
+	digits := strconv.FormatUint(uint64(amount), 10)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return digits + " sats"
+}
+
+// shareCardFooter is the host the cards link back to
+func shareCardFooter() string {
+	host := config.Host
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	return strings.TrimSuffix(host, "/")
+}
+
+// fetchAvatar downloads and decodes an avatar, a card is drawn without one when it can't be
+func (sc *shareCardHandler) fetchAvatar(url string) image.Image {
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shareCardAvatarTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil
+	}
+	response, err := sc.httpClient.Do(request)
+	if err != nil {
+		return nil
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, shareCardAvatarMaxSize))
+	if err != nil {
+		return nil
+	}
+	info, err := media.Validate(bytes.NewReader(data))
+	if err != nil || info.Format == media.FormatWebp {
+		return nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	return img
+}
+
+// serveCard writes the png of a card, taking it from the storage when it was rendered before
+func (sc *shareCardHandler) serveCard(w http.ResponseWriter, fingerprint string, card func() media.Card) {
+	key := storage.ContentKey(storage.PurposeCard, shareCardVersion+":"+fingerprint, ".png")
+
+	var png []byte
+	if sc.storage != nil {
+		if cached, err := sc.storage.Get(key); err == nil {
+			png, _ = io.ReadAll(cached)
+			cached.Close()
+		}
+	}
+	if len(png) == 0 {
+		rendered, err := media.RenderCard(card())
+		if err != nil {
+			fmt.Println("[cards] could not render", key, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		png = rendered
+		if sc.storage != nil {
+			if err := sc.storage.Put(key, "image/png", bytes.NewReader(png)); err != nil {
+				fmt.Println("[cards] could not cache", key, err)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.WriteHeader(http.StatusOK)
+	w.Write(png)
+}
+
+// GetTribeCard renders the share card of a tribe with its name, description, price to join and
+// the avatar of its owner
+func (sc *shareCardHandler) GetTribeCard(w http.ResponseWriter, r *http.Request) {
+	tribe := sc.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" || tribe.Deleted {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	avatar := tribe.Img
+	if owner := sc.db.GetPersonByPubkey(tribe.OwnerPubKey); owner.Img != "" {
+		avatar = owner.Img
+	}
+	highlight := "Free to join"
+	if tribe.PriceToJoin > 0 {
+		highlight = formatSats(uint(tribe.PriceToJoin)) + " to join"
+	}
+
+	fingerprint := strings.Join([]string{"tribe", tribe.UUID, tribe.Name, tribe.Description, highlight, avatar}, "\x00")
+	sc.serveCard(w, fingerprint, func() media.Card {
+		return media.Card{
+			Label:       "TRIBE",
+			Title:       tribe.Name,
+			Description: tribe.Description,
+			Highlight:   highlight,
+			Footer:      shareCardFooter(),
+			Avatar:      sc.fetchAvatar(avatar),
+		}
+	})
+}
+
+// GetBountyCard renders the share card of a bounty with its title, description, price and the
+// avatar of its owner
+func (sc *shareCardHandler) GetBountyCard(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	bounty := sc.db.GetBounty(uint(id))
+	if bounty.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	owner := sc.db.GetPersonByPubkey(bounty.OwnerID)
+	label := "BOUNTY"
+	if bounty.Paid {
+		label = "BOUNTY - PAID"
+	} else if bounty.Assignee != "" {
+		label = "BOUNTY - ASSIGNED"
+	}
+
+	fingerprint := strings.Join([]string{"bounty", strconv.FormatUint(uint64(bounty.ID), 10), label, bounty.Title, bounty.Description, formatSats(bounty.Price), owner.Img}, "\x00")
+	sc.serveCard(w, fingerprint, func() media.Card {
+		return media.Card{
+			Label:       label,
+			Title:       bounty.Title,
+			Description: bounty.Description,
+			Highlight:   formatSats(bounty.Price),
+			Footer:      shareCardFooter(),
+			Avatar:      sc.fetchAvatar(owner.Img),
+		}
+	})
+}
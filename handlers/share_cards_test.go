@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareCards(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	sc := &shareCardHandler{
+		db:         mockDb,
+		storage:    storage.NewLocalStorage(t.TempDir(), "http://localhost", []byte("secret")),
+		httpClient: http.DefaultClient,
+	}
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Get("/tribes/{uuid}/og.png", sc.GetTribeCard)
+		ro.Get("/gobounties/{id}/og.png", sc.GetBountyCard)
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a deleted tribe has no card", func(t *testing.T) {
+		mockDb.On("GetTribe", "deleted").Return(db.Tribe{UUID: "deleted", Deleted: true}).Once()
+		rr := serve("/tribes/deleted/og.png")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Should test that the card of a tribe is a png that is cached", func(t *testing.T) {
+		tribe := db.Tribe{UUID: "tribe", OwnerPubKey: "owner", Name: "Sphinx", Description: "A tribe", PriceToJoin: 1000}
+		mockDb.On("GetTribe", "tribe").Return(tribe).Twice()
+		mockDb.On("GetPersonByPubkey", "owner").Return(db.Person{}).Twice()
+
+		rr := serve("/tribes/tribe/og.png")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/png", rr.Header().Get("Content-Type"))
+		_, err := png.Decode(bytes.NewReader(rr.Body.Bytes()))
+		assert.NoError(t, err)
+
+		key := storage.ContentKey(storage.PurposeCard, shareCardVersion+":tribe\x00tribe\x00Sphinx\x00A tribe\x001,000 sats to join\x00", ".png")
+		cached, err := sc.storage.Get(key)
+		assert.NoError(t, err)
+		cached.Close()
+
+		again := serve("/tribes/tribe/og.png")
+		assert.Equal(t, rr.Body.Bytes(), again.Body.Bytes())
+	})
+
+	t.Run("Should test that the card of a bounty is rendered", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(7)).Return(db.NewBounty{ID: 7, OwnerID: "owner", Title: "Fix the payouts", Price: 50000}).Once()
+		mockDb.On("GetPersonByPubkey", "owner").Return(db.Person{}).Once()
+		rr := serve("/gobounties/7/og.png")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that an unknown bounty has no card", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(8)).Return(db.NewBounty{}).Once()
+		rr := serve("/gobounties/8/og.png")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestFormatSats(t *testing.T) {
+	assert.Equal(t, "0 sats", formatSats(0))
+	assert.Equal(t, "999 sats", formatSats(999))
+	assert.Equal(t, "1,000 sats", formatSats(1000))
+	assert.Equal(t, "12,345,678 sats", formatSats(12345678))
+}
@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/websocket"
 )
 
@@ -10,3 +13,65 @@ func HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	pool := websocket.WebsocketPool
 	websocket.ServeWs(pool, w, r)
 }
+
+// NewSocketTopicAuthorizer checks websocket topic subscriptions,
+// bounty topics are public, person topics are private to the pubkey
+// and workspace and ticket topics need an authenticated workspace member
+func NewSocketTopicAuthorizer(database db.Database) websocket.TopicAuthorizer {
+	isMember := func(pubkey string, workspaceUuid string) bool {
+		if pubkey == "" || workspaceUuid == "" {
+			return false
+		}
+		workspace := database.GetWorkspaceByUuid(workspaceUuid)
+		if workspace.OwnerPubKey == pubkey {
+			return true
+		}
+		return database.GetWorkspaceUser(pubkey, workspaceUuid).OwnerPubKey == pubkey
+	}
+
+	return func(pubkey string, topic string) bool {
+		topicType, id, found := strings.Cut(topic, ":")
+		if !found || id == "" {
+			return false
+		}
+
+		switch topicType {
+		case "bounty":
+			return true
+		case "workspace":
+			return isMember(pubkey, id)
+		case "ticket":
+			// the id is the ticket key, its owner and the members of the workspace whose board
+			// holds it can subscribe
+			if pubkey == "" {
+				return false
+			}
+			if owner, _, ok := parseTicketKey(id); ok && owner == pubkey {
+				return true
+			}
+			return isMember(pubkey, ticketWorkspace(database, id))
+		case "person":
+			return pubkey == id
+		}
+		return false
+	}
+}
+
+// publishBountyEvent notifies subscribers of the bounty and of its workspace
+func publishBountyEvent(bounty db.NewBounty, msg string) {
+	websocket.WebsocketPool.Publish(fmt.Sprintf("bounty:%d", bounty.ID), msg, bounty)
+	publishWorkspaceEvent(bounty.WorkspaceUuid, msg, bounty)
+}
+
+func publishWorkspaceEvent(workspaceUuid string, msg string, body interface{}) {
+	if workspaceUuid != "" {
+		websocket.WebsocketPool.Publish("workspace:"+workspaceUuid, msg, body)
+	}
+}
+
+// publishPersonEvent notifies the private topic of a person
+func publishPersonEvent(pubkey string, msg string, body interface{}) {
+	if pubkey != "" {
+		websocket.WebsocketPool.Publish("person:"+pubkey, msg, body)
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSocketTopicAuthorizer(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	authorize := NewSocketTopicAuthorizer(mockDb)
+
+	t.Run("Should test that anyone can subscribe to a bounty topic", func(t *testing.T) {
+		assert.True(t, authorize("", "bounty:1"))
+	})
+
+	t.Run("Should test that unknown or malformed topics are rejected", func(t *testing.T) {
+		assert.False(t, authorize("pubkey", "tribe:1"))
+		assert.False(t, authorize("pubkey", "workspace:"))
+		assert.False(t, authorize("pubkey", "workspace"))
+	})
+
+	t.Run("Should test that anonymous connections can't subscribe to a workspace topic", func(t *testing.T) {
+		assert.False(t, authorize("", "workspace:workspace_uuid"))
+		assert.False(t, authorize("", "ticket:ticket_uuid"))
+	})
+
+	t.Run("Should test that a ticket topic is for its owner and the members of its workspace", func(t *testing.T) {
+		assert.True(t, authorize("owner", "ticket:owner:1"))
+
+		mockDb.On("GetTicketCard", "owner:1").Return(db.TicketCard{TicketId: "owner:1", PhaseUuid: "phase_uuid"}).Twice()
+		mockDb.On("GetPhaseByUuid", "phase_uuid").Return(db.FeaturePhase{Uuid: "phase_uuid", FeatureUuid: "feature_uuid"}, nil).Twice()
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid"}).Twice()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "workspace_owner"}).Twice()
+		mockDb.On("GetWorkspaceUser", "member", "workspace_uuid").Return(db.WorkspaceUsers{OwnerPubKey: "member", WorkspaceUuid: "workspace_uuid"}).Once()
+		mockDb.On("GetWorkspaceUser", "stranger", "workspace_uuid").Return(db.WorkspaceUsers{}).Once()
+		assert.True(t, authorize("member", "ticket:owner:1"))
+		assert.False(t, authorize("stranger", "ticket:owner:1"))
+
+		mockDb.On("GetTicketCard", "owner:2").Return(db.TicketCard{}).Once()
+		assert.False(t, authorize("member", "ticket:owner:2"))
+	})
+
+	t.Run("Should test that the workspace owner can subscribe to a workspace topic", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", "owned_uuid").Return(db.Workspace{Uuid: "owned_uuid", OwnerPubKey: "owner"}).Once()
+		assert.True(t, authorize("owner", "workspace:owned_uuid"))
+	})
+
+	t.Run("Should test that a workspace user can subscribe to a workspace topic", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner"}).Once()
+		mockDb.On("GetWorkspaceUser", "member", "workspace_uuid").Return(db.WorkspaceUsers{OwnerPubKey: "member", WorkspaceUuid: "workspace_uuid"}).Once()
+		assert.True(t, authorize("member", "workspace:workspace_uuid"))
+	})
+
+	t.Run("Should test that a non member can't subscribe to a workspace topic", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner"}).Once()
+		mockDb.On("GetWorkspaceUser", "stranger", "workspace_uuid").Return(db.WorkspaceUsers{}).Once()
+		assert.False(t, authorize("stranger", "workspace:workspace_uuid"))
+	})
+
+	t.Run("Should test that a person topic is private to its pubkey", func(t *testing.T) {
+		assert.True(t, authorize("pubkey", "person:pubkey"))
+		assert.False(t, authorize("another_pubkey", "person:pubkey"))
+		assert.False(t, authorize("", "person:pubkey"))
+	})
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const (
+	// spamDuplicateWindow and spamDuplicateLimit bound the recent listings a new one is compared with
+	spamDuplicateWindow = 30 * 24 * time.Hour
+	spamDuplicateLimit  = 500
+
+	spamNameSimilarity        = 0.85
+	spamDescriptionSimilarity = 0.8
+	// spamDescriptionMinWords keeps short descriptions like "a tribe" from matching each other
+	spamDescriptionMinWords = 6
+	spamMaxLinks            = 5
+)
+
+var spamLinkPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"')]+`)
+
+// spamShorteners hide where a link goes, listings linking through them are reviewed
+var spamShorteners = map[string]bool{
+	"bit.ly":      true,
+	"tinyurl.com": true,
+	"t.co":        true,
+	"goo.gl":      true,
+	"ow.ly":       true,
+	"is.gd":       true,
+	"buff.ly":     true,
+	"cutt.ly":     true,
+	"rebrand.ly":  true,
+	"shorturl.at": true,
+}
+
+func spamWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func jaccard(a map[string]bool, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for k := range a {
+		if b[k] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+func wordSet(words []string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+// trigramSet is the set of three letter runs of a name with its spacing and punctuation removed,
+// so "Free Bitcoin!!" and "freebitcoin" are the same name
+func trigramSet(name string) map[string]bool {
+	compact := []rune(strings.Join(spamWords(name), ""))
+	set := map[string]bool{}
+	if len(compact) < 3 {
+		if len(compact) > 0 {
+			set[string(compact)] = true
+		}
+		return set
+	}
+	for i := 0; i+3 <= len(compact); i++ {
+		set[string(compact[i:i+3])] = true
+	}
+	return set
+}
+
+// NameSimilarity and DescriptionSimilarity are between 0 for nothing in common and 1 for the same text
+func NameSimilarity(a string, b string) float64 {
+	return jaccard(trigramSet(a), trigramSet(b))
+}
+
+func DescriptionSimilarity(a string, b string) float64 {
+	wordsA, wordsB := spamWords(a), spamWords(b)
+	if len(wordsA) < spamDescriptionMinWords || len(wordsB) < spamDescriptionMinWords {
+		return 0
+	}
+	return jaccard(wordSet(wordsA), wordSet(wordsB))
+}
+
+func spamBlockedDomain(host string) bool {
+	for _, domain := range strings.Split(config.SpamBlockedDomains, ",") {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain != "" && (host == domain || strings.HasSuffix(host, "."+domain)) {
+			return true
+		}
+	}
+	return false
+}
+
+// linkSpamReasons checks the links of a listing against the blocked domains, and flags link
+// shorteners, bare ip addresses and punycode hosts that are used to pass for another site
+func linkSpamReasons(text string, links []string) []string {
+	links = append(spamLinkPattern.FindAllString(text, -1), links...)
+	reasons := []string{}
+	seen := map[string]bool{}
+	count := 0
+	for _, link := range links {
+		if link == "" {
+			continue
+		}
+		count++
+		parsed, err := url.Parse(link)
+		if err != nil || parsed.Hostname() == "" {
+			continue
+		}
+		host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+
+		reason := ""
+		switch {
+		case spamBlockedDomain(host):
+			reason = "blocked_link:" + host
+		case spamShorteners[host]:
+			reason = "shortened_link:" + host
+		case net.ParseIP(host) != nil:
+			reason = "ip_link:" + host
+		case strings.HasPrefix(host, "xn--") || strings.Contains(host, ".xn--"):
+			reason = "punycode_link:" + host
+		}
+		if reason != "" && !seen[reason] {
+			seen[reason] = true
+			reasons = append(reasons, reason)
+		}
+	}
+	if count > spamMaxLinks {
+		reasons = append(reasons, "too_many_links")
+	}
+	return reasons
+}
+
+func spamCreateLimits() (int64, time.Duration) {
+	limit, err := strconv.ParseInt(config.SpamCreateLimit, 10, 64)
+	if err != nil {
+		limit = 3
+	}
+	window, err := time.ParseDuration(config.SpamCreateWindow)
+	if err != nil || window <= 0 {
+		window = time.Hour
+	}
+	return limit, window
+}
+
+// listingSpamReasons runs the spam checks on a new tribe or bot, any reason holds it unlisted
+// until an admin reviews it
+func listingSpamReasons(database db.Database, targetType db.ReportTargetType, pubkey string, name string, description string, links ...string) []string {
+	reasons := []string{}
+
+	limit, window := spamCreateLimits()
+	if limit > 0 && database.CountListingsCreated(targetType, pubkey, time.Now().Add(-window)) >= limit {
+		reasons = append(reasons, "create_velocity")
+	}
+
+	for _, recent := range database.GetRecentListingTexts(targetType, time.Now().Add(-spamDuplicateWindow), spamDuplicateLimit) {
+		if NameSimilarity(name, recent.Name) >= spamNameSimilarity {
+			reasons = append(reasons, fmt.Sprintf("duplicate_name:%s", recent.Uuid))
+			break
+		}
+		if DescriptionSimilarity(description, recent.Description) >= spamDescriptionSimilarity {
+			reasons = append(reasons, fmt.Sprintf("duplicate_description:%s", recent.Uuid))
+			break
+		}
+	}
+
+	return append(reasons, linkSpamReasons(description, links)...)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListingSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, NameSimilarity("Free Bitcoin!!", "freebitcoin"))
+	assert.GreaterOrEqual(t, NameSimilarity("Bitcoin Giveaway", "Bitcoin Giveaways"), spamNameSimilarity)
+	assert.Less(t, NameSimilarity("Bitcoin Giveaway", "Lightning Developers"), spamNameSimilarity)
+
+	description := "Send one bitcoin to this address and get two back within the hour guaranteed"
+	assert.GreaterOrEqual(t, DescriptionSimilarity(description, description+"!"), spamDescriptionSimilarity)
+	assert.Less(t, DescriptionSimilarity(description, "A place to talk about running lightning nodes at home with friends"), spamDescriptionSimilarity)
+	assert.Equal(t, 0.0, DescriptionSimilarity("a tribe", "a tribe"))
+}
+
+func TestLinkSpamReasons(t *testing.T) {
+	config.SpamBlockedDomains = "scam.example"
+	defer func() { config.SpamBlockedDomains = "" }()
+
+	reasons := linkSpamReasons("Join at https://www.bit.ly/abc or http://wallet.scam.example/login, or https://192.0.2.7/x", []string{"https://xn--bcoin-9ra.com"})
+	assert.Equal(t, []string{"shortened_link:bit.ly", "blocked_link:wallet.scam.example", "ip_link:192.0.2.7", "punycode_link:xn--bcoin-9ra.com"}, reasons)
+
+	assert.Empty(t, linkSpamReasons("See https://github.com/stakwork/sphinx-tribes", nil))
+	assert.Equal(t, []string{"too_many_links"}, linkSpamReasons("https://a.com https://b.com https://c.com https://d.com https://e.com https://f.com", nil))
+}
+
+func TestListingSpamReasons(t *testing.T) {
+	config.SpamCreateLimit = "2"
+	config.SpamCreateWindow = "1h"
+	defer func() {
+		config.SpamCreateLimit = ""
+		config.SpamCreateWindow = ""
+	}()
+
+	t.Run("Should test that a fresh listing passes", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockDb.On("CountListingsCreated", db.ReportTribe, "pubkey", mock.AnythingOfType("time.Time")).Return(int64(0)).Once()
+		mockDb.On("GetRecentListingTexts", db.ReportTribe, mock.AnythingOfType("time.Time"), spamDuplicateLimit).Return([]db.ListingText{{Uuid: "other", Name: "Lightning Developers"}}).Once()
+
+		assert.Empty(t, listingSpamReasons(mockDb, db.ReportTribe, "pubkey", "Bitcoin Art", "Art made by bitcoiners"))
+	})
+
+	t.Run("Should test that duplicates and fast creation are held for review", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockDb.On("CountListingsCreated", db.ReportBot, "pubkey", mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since) > 59*time.Minute
+		})).Return(int64(2)).Once()
+		mockDb.On("GetRecentListingTexts", db.ReportBot, mock.AnythingOfType("time.Time"), spamDuplicateLimit).Return([]db.ListingText{{Uuid: "original", Name: "Price Bot"}}).Once()
+
+		reasons := listingSpamReasons(mockDb, db.ReportBot, "pubkey", "price-bot", "", "https://bit.ly/x")
+		assert.Equal(t, []string{"create_velocity", "duplicate_name:original", "shortened_link:bit.ly"}, reasons)
+	})
+}
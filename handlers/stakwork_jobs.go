@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/stakwork"
+	"gorm.io/gorm"
+)
+
+// StakworkCallbackPayload is what Stakwork posts to the webhook url of a project when its status changes
+type StakworkCallbackPayload struct {
+	ProjectId     json.Number            `json:"project_id"`
+	ProjectStatus string                 `json:"project_status"`
+	Status        string                 `json:"status"`
+	Result        map[string]interface{} `json:"result"`
+	Error         string                 `json:"error"`
+}
+
+type stakworkJobHandler struct {
+	db            db.Database
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewStakworkJobHandler(database db.Database) *stakworkJobHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &stakworkJobHandler{
+		db:            database,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+func stakworkCallbackUrl(token string) string {
+	return fmt.Sprintf("%s/webhooks/stakwork/%s", config.Host, token)
+}
+
+// startStakworkJob records a job and starts its project, Stakwork reports the outcome of the
+// project to the callback url of the job. The job is failed when the project can't be started
+func startStakworkJob(database db.Database, client *stakwork.Client, job db.StakworkJob, project stakwork.Project) (db.StakworkJob, stakwork.ProjectResult, error) {
+	job, err := database.CreateStakworkJob(job)
+	if err != nil {
+		return job, stakwork.ProjectResult{}, err
+	}
+	project.WebhookUrl = stakworkCallbackUrl(job.Token)
+
+	result, err := client.CreateProject(context.Background(), project)
+	if err != nil {
+		job.Status = db.StakworkJobFailed
+		job.Error = err.Error()
+	} else {
+		job.ProjectId = result.ProjectId
+	}
+	if updateErr := database.UpdateStakworkJob(job); updateErr != nil {
+		fmt.Println("[stakwork] could not update job", job.Uuid, updateErr)
+	}
+	return job, result, err
+}
+
+// stakworkCallbackStatus maps the status Stakwork reports to the one of the job, anything that
+// isn't finished keeps the job processing
+func stakworkCallbackStatus(payload StakworkCallbackPayload) db.StakworkJobStatus {
+	status := payload.ProjectStatus
+	if status == "" {
+		status = payload.Status
+	}
+	switch strings.ToLower(status) {
+	case "completed", "complete", "finished", "success":
+		return db.StakworkJobCompleted
+	case "error", "failed", "halted", "stopped", "cancelled":
+		return db.StakworkJobFailed
+	}
+	return db.StakworkJobProcessing
+}
+
+// StakworkCallback receives the status of a project from Stakwork, the secret token of the url
+// identifies the job. A finished job is saved once and published to its workspace topic
+func (sh *stakworkJobHandler) StakworkCallback(w http.ResponseWriter, r *http.Request) {
+	job := sh.db.GetStakworkJobByToken(chi.URLParam(r, "token"))
+	if job.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Job not found")
+		return
+	}
+
+	payload := StakworkCallbackPayload{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid callback body")
+		return
+	}
+
+	status := stakworkCallbackStatus(payload)
+	if job.Status != db.StakworkJobProcessing || status == db.StakworkJobProcessing {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(job)
+		return
+	}
+
+	job.Status = status
+	job.Result = payload.Result
+	job.Error = payload.Error
+	if job.ProjectId == "" {
+		job.ProjectId = payload.ProjectId.String()
+	}
+	if err := sh.db.UpdateStakworkJob(job); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not update the job")
+		return
+	}
+	publishWorkspaceEvent(job.WorkspaceUuid, "stakwork_job_updated", job)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(job)
+}
+
+// GetStakworkJob returns the status of a job, the job of a workspace needs access to its reports
+func (sh *stakworkJobHandler) GetStakworkJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[stakwork] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	job := sh.db.GetStakworkJob(chi.URLParam(r, "uuid"))
+	if job.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Job not found")
+		return
+	}
+	if job.WorkspaceUuid != "" && !sh.userHasAccess(pubKeyFromAuth, job.WorkspaceUuid, db.ViewReport) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the job")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(job)
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStakworkCallback(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	sHandler := NewStakworkJobHandler(mockDb)
+
+	callback := func(token string, body string) *httptest.ResponseRecorder {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("token", token)
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/webhooks/stakwork/"+token, bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.StakworkCallback).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a 404 is returned for an unknown token", func(t *testing.T) {
+		mockDb.On("GetStakworkJobByToken", "unknown").Return(db.StakworkJob{}).Once()
+		rr := callback("unknown", `{"project_status": "completed"}`)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Should test that a job still running on Stakwork is left processing", func(t *testing.T) {
+		mockDb.On("GetStakworkJobByToken", "token").Return(db.StakworkJob{ID: 1, Uuid: "job", Status: db.StakworkJobProcessing}).Once()
+		rr := callback("token", `{"project_status": "in_progress"}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a completed project saves the result of the job", func(t *testing.T) {
+		mockDb.On("GetStakworkJobByToken", "token").Return(db.StakworkJob{ID: 1, Uuid: "job", WorkspaceUuid: "workspace", Status: db.StakworkJobProcessing}).Once()
+		mockDb.On("UpdateStakworkJob", mock.MatchedBy(func(job db.StakworkJob) bool {
+			return job.Uuid == "job" && job.Status == db.StakworkJobCompleted && job.ProjectId == "1234" && job.Result["plan"] == "done"
+		})).Return(nil).Once()
+		rr := callback("token", `{"project_id": 1234, "project_status": "completed", "result": {"plan": "done"}}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a finished job is not updated by a repeated callback", func(t *testing.T) {
+		mockDb.On("GetStakworkJobByToken", "token").Return(db.StakworkJob{ID: 1, Uuid: "job", Status: db.StakworkJobCompleted}).Once()
+		rr := callback("token", `{"project_status": "error", "error": "late"}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
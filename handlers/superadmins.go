@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+type superAdminHandler struct {
+	db db.Database
+}
+
+type SuperAdminRequest struct {
+	Pubkey string `json:"pubkey"`
+}
+
+func NewSuperAdminHandler(database db.Database) *superAdminHandler {
+	return &superAdminHandler{
+		db: database,
+	}
+}
+
+// LoadSuperAdmins is used by auth.AdminPubkeys to read the admins from the database
+func (sh *superAdminHandler) LoadSuperAdmins() ([]string, error) {
+	pubkeys := []string{}
+	for _, admin := range sh.db.GetSuperAdmins() {
+		pubkeys = append(pubkeys, admin.OwnerPubKey)
+	}
+	return pubkeys, nil
+}
+
+// BootstrapSuperAdmins seeds an empty superadmins table with the admins of the ADMINS env var
+func (sh *superAdminHandler) BootstrapSuperAdmins() {
+	if len(sh.db.GetSuperAdmins()) > 0 {
+		return
+	}
+	for _, pubkey := range config.SuperAdmins {
+		if pubkey == "" || pubkey == config.AdminDevFreePass {
+			continue
+		}
+		if _, err := sh.db.AddSuperAdmin(db.SuperAdmin{OwnerPubKey: pubkey, AddedBy: "env"}); err != nil {
+			fmt.Println("[superadmins] could not bootstrap", pubkey, err)
+		}
+	}
+}
+
+func (sh *superAdminHandler) GetSuperAdmins(w http.ResponseWriter, r *http.Request) {
+	admins := sh.db.GetSuperAdmins()
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(admins)
+}
+
+func (sh *superAdminHandler) AddSuperAdmin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	request := SuperAdminRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &request)
+	if err != nil {
+		fmt.Println("[superadmins]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if request.Pubkey == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Pubkey is a required field")
+		return
+	}
+
+	admin, err := sh.db.AddSuperAdmin(db.SuperAdmin{OwnerPubKey: request.Pubkey, AddedBy: pubKeyFromAuth})
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	auth.RefreshAdminPubkeys()
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(admin)
+}
+
+func (sh *superAdminHandler) RemoveSuperAdmin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	pubkey := chi.URLParam(r, "pubkey")
+
+	if pubkey == pubKeyFromAuth {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("You can't remove yourself as a super admin")
+		return
+	}
+
+	if err := sh.db.DeleteSuperAdmin(pubkey); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	auth.RefreshAdminPubkeys()
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Super admin removed")
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBootstrapSuperAdmins(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	sHandler := NewSuperAdminHandler(mockDb)
+	superAdmins := config.SuperAdmins
+	defer func() { config.SuperAdmins = superAdmins }()
+	config.SuperAdmins = []string{"admin_one", "admin_two"}
+
+	t.Run("Should test that an empty table is seeded from the env admins", func(t *testing.T) {
+		mockDb.On("GetSuperAdmins").Return([]db.SuperAdmin{}).Once()
+		mockDb.On("AddSuperAdmin", db.SuperAdmin{OwnerPubKey: "admin_one", AddedBy: "env"}).Return(db.SuperAdmin{}, nil).Once()
+		mockDb.On("AddSuperAdmin", db.SuperAdmin{OwnerPubKey: "admin_two", AddedBy: "env"}).Return(db.SuperAdmin{}, nil).Once()
+
+		sHandler.BootstrapSuperAdmins()
+	})
+
+	t.Run("Should test that existing admins are not overwritten", func(t *testing.T) {
+		mockDb.On("GetSuperAdmins").Return([]db.SuperAdmin{{OwnerPubKey: "admin_one"}}).Once()
+
+		sHandler.BootstrapSuperAdmins()
+	})
+}
+
+func TestAddSuperAdmin(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	sHandler := NewSuperAdminHandler(mockDb)
+
+	t.Run("Should test that a super admin can be added", func(t *testing.T) {
+		mockDb.On("AddSuperAdmin", mock.MatchedBy(func(m db.SuperAdmin) bool {
+			return m.OwnerPubKey == "new_admin" && m.AddedBy == "admin_pubkey"
+		})).Return(db.SuperAdmin{OwnerPubKey: "new_admin", AddedBy: "admin_pubkey"}, nil).Once()
+
+		body, _ := json.Marshal(SuperAdminRequest{Pubkey: "new_admin"})
+		req, _ := http.NewRequest("POST", "/admin/superadmins", bytes.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "admin_pubkey"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.AddSuperAdmin).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a pubkey is required", func(t *testing.T) {
+		body, _ := json.Marshal(SuperAdminRequest{})
+		req, _ := http.NewRequest("POST", "/admin/superadmins", bytes.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "admin_pubkey"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.AddSuperAdmin).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestRemoveSuperAdmin(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	sHandler := NewSuperAdminHandler(mockDb)
+
+	removeRequest := func(pubkey string) *httptest.ResponseRecorder {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("pubkey", pubkey)
+		req, _ := http.NewRequest("DELETE", "/admin/superadmins/"+pubkey, nil)
+		req = req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, "admin_pubkey"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.RemoveSuperAdmin).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a super admin can be removed", func(t *testing.T) {
+		mockDb.On("DeleteSuperAdmin", "other_admin").Return(nil).Once()
+
+		assert.Equal(t, http.StatusOK, removeRequest("other_admin").Code)
+	})
+
+	t.Run("Should test that an admin can't remove themselves", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, removeRequest("admin_pubkey").Code)
+	})
+
+	t.Run("Should test that a 404 is returned for an unknown admin", func(t *testing.T) {
+		mockDb.On("DeleteSuperAdmin", "unknown").Return(errors.New("no super admin found")).Once()
+
+		assert.Equal(t, http.StatusNotFound, removeRequest("unknown").Code)
+	})
+}
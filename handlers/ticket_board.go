@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// ticketMove is a drag and drop of a ticket on a phase board, between the cards above and
+// below it, made on the version of the card the planner saw
+type ticketMove struct {
+	TicketPubKey  string        `json:"ticket_pubkey"`
+	TicketCreated int64         `json:"ticket_created"`
+	Lane          db.TicketLane `json:"lane"`
+	Above         string        `json:"above"`
+	Below         string        `json:"below"`
+	Version       int           `json:"version"`
+}
+
+func validTicketLane(lane db.TicketLane) bool {
+	for _, l := range db.TicketLanes {
+		if l == lane {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTicketBoard returns the tickets of a phase by lane, in the order planners left them
+func (oh *featureHandler) GetTicketBoard(w http.ResponseWriter, r *http.Request) {
+	featureUuid := chi.URLParam(r, "feature_uuid")
+	phaseUuid := chi.URLParam(r, "phase_uuid")
+
+	if _, err := oh.db.GetFeaturePhaseByUuid(featureUuid, phaseUuid); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Phase not found")
+		return
+	}
+
+	board := db.TicketBoard{PhaseUuid: phaseUuid, Lanes: []db.TicketBoardLane{}}
+	lanes := map[db.TicketLane]int{}
+	for i, lane := range db.TicketLanes {
+		lanes[lane] = i
+		board.Lanes = append(board.Lanes, db.TicketBoardLane{Lane: lane, Cards: []db.TicketCard{}})
+	}
+
+	people := map[string]db.Person{}
+	for _, card := range oh.db.GetTicketCards(phaseUuid) {
+		owner, created, ok := parseTicketKey(card.TicketId)
+		i, known := lanes[card.Lane]
+		if !ok || !known {
+			continue
+		}
+		if _, ok := people[owner]; !ok {
+			people[owner] = oh.db.GetPersonByPubkey(owner)
+		}
+		ticket := findTicket(people[owner], created)
+		if ticket == nil {
+			continue
+		}
+		card.Title, _ = ticket["title"].(string)
+		board.Lanes[i].Cards = append(board.Lanes[i].Cards, card)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(board)
+}
+
+// ReorderTickets moves a ticket on a phase board, refusing the move with the current card when
+// the board changed since the planner loaded it
+func (oh *featureHandler) ReorderTickets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	featureUuid := chi.URLParam(r, "feature_uuid")
+	phaseUuid := chi.URLParam(r, "phase_uuid")
+	if _, err := oh.db.GetFeaturePhaseByUuid(featureUuid, phaseUuid); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Phase not found")
+		return
+	}
+	if rejectArchivedWorkspaceOf(w, func() string { return featureWorkspace(oh.db, featureUuid) }) {
+		return
+	}
+
+	move := ticketMove{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &move); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid move")
+		return
+	}
+	if !validTicketLane(move.Lane) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A lane is todo, in_progress, review or done")
+		return
+	}
+	if findTicket(oh.db.GetPersonByPubkey(move.TicketPubKey), move.TicketCreated) == nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Ticket not found")
+		return
+	}
+
+	key := ticketKey(move.TicketPubKey, move.TicketCreated)
+	card := oh.db.GetTicketCard(key)
+	if card.Version != move.Version {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(card)
+		return
+	}
+
+	// the neighbours have to still be where the planner saw them
+	neighbour := func(ticketId string) (db.TicketCard, bool) {
+		if ticketId == "" {
+			return db.TicketCard{}, true
+		}
+		n := oh.db.GetTicketCard(ticketId)
+		return n, n.ID != 0 && n.TicketId != key && n.PhaseUuid == phaseUuid && n.Lane == move.Lane
+	}
+	above, aboveOk := neighbour(move.Above)
+	below, belowOk := neighbour(move.Below)
+	if move.Above == "" && move.Below == "" {
+		// dropped on a lane without a position goes to its bottom
+		for _, c := range oh.db.GetTicketCards(phaseUuid) {
+			if c.Lane == move.Lane && c.TicketId != key {
+				above = c
+			}
+		}
+	}
+	if !aboveOk || !belowOk || (above.Rank != "" && below.Rank != "" && above.Rank >= below.Rank) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(card)
+		return
+	}
+
+	card.TicketId = key
+	card.PhaseUuid = phaseUuid
+	card.Lane = move.Lane
+	card.Rank = db.RankBetween(above.Rank, below.Rank)
+	card.UpdatedBy = pubKeyFromAuth
+	moved, err := oh.db.MoveTicketCard(card)
+	if err != nil {
+		fmt.Println("[tickets] could not move card", key, err)
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(oh.db.GetTicketCard(key))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(moved)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTicketBoard(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	fHandler := NewFeatureHandler(mockDb)
+
+	router := chi.NewRouter()
+	router.Get("/features/{feature_uuid}/phase/{phase_uuid}/tickets/board", fHandler.GetTicketBoard)
+	router.Patch("/features/{feature_uuid}/phase/{phase_uuid}/tickets/reorder", fHandler.ReorderTickets)
+
+	move := func(body interface{}) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPatch, "/features/feature/phase/phase/tickets/reorder", bytes.NewReader(data))
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "planner"))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	phase := db.FeaturePhase{Uuid: "phase", FeatureUuid: "feature"}
+	owner := testTicketPerson("owner", 1, 2, 3)
+
+	t.Run("Should test that the board lists the cards of every lane with their titles", func(t *testing.T) {
+		mockDb.On("GetFeaturePhaseByUuid", "feature", "phase").Return(phase, nil).Once()
+		mockDb.On("GetTicketCards", "phase").Return([]db.TicketCard{
+			{TicketId: "owner:2", Lane: db.TicketLaneTodo, Rank: "a"},
+			{TicketId: "owner:1", Lane: db.TicketLaneTodo, Rank: "b"},
+			{TicketId: "owner:3", Lane: db.TicketLaneDone, Rank: "i"},
+			{TicketId: "owner:9", Lane: db.TicketLaneDone, Rank: "j"},
+		}).Once()
+		mockDb.On("GetPersonByPubkey", "owner").Return(owner).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/features/feature/phase/phase/tickets/board", nil))
+
+		board := db.TicketBoard{}
+		json.Unmarshal(rr.Body.Bytes(), &board)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, board.Lanes, 4)
+		assert.Equal(t, db.TicketLaneTodo, board.Lanes[0].Lane)
+		assert.Equal(t, "owner:2", board.Lanes[0].Cards[0].TicketId)
+		assert.Equal(t, "ticket", board.Lanes[0].Cards[0].Title)
+		assert.Empty(t, board.Lanes[1].Cards)
+		assert.Len(t, board.Lanes[3].Cards, 1)
+	})
+
+	t.Run("Should test that a ticket dropped between two cards gets a rank between theirs", func(t *testing.T) {
+		mockDb.On("GetFeaturePhaseByUuid", "feature", "phase").Return(phase, nil).Once()
+		mockDb.On("GetPersonByPubkey", "owner").Return(owner).Once()
+		mockDb.On("GetTicketCard", "owner:3").Return(db.TicketCard{ID: 3, TicketId: "owner:3", PhaseUuid: "phase", Lane: db.TicketLaneDone, Rank: "i", Version: 4}).Once()
+		mockDb.On("GetTicketCard", "owner:2").Return(db.TicketCard{ID: 2, TicketId: "owner:2", PhaseUuid: "phase", Lane: db.TicketLaneTodo, Rank: "a", Version: 1}).Once()
+		mockDb.On("GetTicketCard", "owner:1").Return(db.TicketCard{ID: 1, TicketId: "owner:1", PhaseUuid: "phase", Lane: db.TicketLaneTodo, Rank: "b", Version: 1}).Once()
+		mockDb.On("MoveTicketCard", mock.MatchedBy(func(c db.TicketCard) bool {
+			return c.ID == 3 && c.Lane == db.TicketLaneTodo && c.Rank == "ai" && c.Version == 4 && c.UpdatedBy == "planner"
+		})).Return(func(c db.TicketCard) (db.TicketCard, error) {
+			c.Version++
+			return c, nil
+		}).Once()
+
+		rr := move(map[string]interface{}{"ticket_pubkey": "owner", "ticket_created": 3, "lane": "todo", "above": "owner:2", "below": "owner:1", "version": 4})
+
+		card := db.TicketCard{}
+		json.Unmarshal(rr.Body.Bytes(), &card)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 5, card.Version)
+	})
+
+	t.Run("Should test that a new card dropped on a lane goes to its bottom", func(t *testing.T) {
+		mockDb.On("GetFeaturePhaseByUuid", "feature", "phase").Return(phase, nil).Once()
+		mockDb.On("GetPersonByPubkey", "owner").Return(owner).Once()
+		mockDb.On("GetTicketCard", "owner:3").Return(db.TicketCard{}).Once()
+		mockDb.On("GetTicketCards", "phase").Return([]db.TicketCard{
+			{TicketId: "owner:2", Lane: db.TicketLaneReview, Rank: "a"},
+			{TicketId: "owner:1", Lane: db.TicketLaneReview, Rank: "i"},
+			{TicketId: "owner:4", Lane: db.TicketLaneDone, Rank: "r"},
+		}).Once()
+		mockDb.On("MoveTicketCard", mock.MatchedBy(func(c db.TicketCard) bool {
+			return c.ID == 0 && c.TicketId == "owner:3" && c.PhaseUuid == "phase" && c.Rank == "r"
+		})).Return(db.TicketCard{Version: 1}, nil).Once()
+
+		rr := move(map[string]interface{}{"ticket_pubkey": "owner", "ticket_created": 3, "lane": "review"})
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a move made on a stale board is refused", func(t *testing.T) {
+		current := db.TicketCard{ID: 3, TicketId: "owner:3", PhaseUuid: "phase", Lane: db.TicketLaneDone, Rank: "i", Version: 5}
+		mockDb.On("GetFeaturePhaseByUuid", "feature", "phase").Return(phase, nil).Once()
+		mockDb.On("GetPersonByPubkey", "owner").Return(owner).Once()
+		mockDb.On("GetTicketCard", "owner:3").Return(current).Once()
+
+		rr := move(map[string]interface{}{"ticket_pubkey": "owner", "ticket_created": 3, "lane": "todo", "version": 4})
+
+		card := db.TicketCard{}
+		json.Unmarshal(rr.Body.Bytes(), &card)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Equal(t, 5, card.Version)
+
+		// the card below moved to another lane since
+		mockDb.On("GetFeaturePhaseByUuid", "feature", "phase").Return(phase, nil).Once()
+		mockDb.On("GetPersonByPubkey", "owner").Return(owner).Once()
+		mockDb.On("GetTicketCard", "owner:3").Return(current).Once()
+		mockDb.On("GetTicketCard", "owner:1").Return(db.TicketCard{ID: 1, TicketId: "owner:1", PhaseUuid: "phase", Lane: db.TicketLaneDone, Rank: "b"}).Once()
+		rr = move(map[string]interface{}{"ticket_pubkey": "owner", "ticket_created": 3, "lane": "todo", "below": "owner:1", "version": 5})
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		// another planner moved it first
+		mockDb.On("GetFeaturePhaseByUuid", "feature", "phase").Return(phase, nil).Once()
+		mockDb.On("GetPersonByPubkey", "owner").Return(owner).Once()
+		mockDb.On("GetTicketCard", "owner:3").Return(current).Once()
+		mockDb.On("GetTicketCards", "phase").Return([]db.TicketCard{}).Once()
+		mockDb.On("MoveTicketCard", mock.Anything).Return(db.TicketCard{}, errors.New("card was moved")).Once()
+		mockDb.On("GetTicketCard", "owner:3").Return(db.TicketCard{ID: 3, Version: 6}).Once()
+		rr = move(map[string]interface{}{"ticket_pubkey": "owner", "ticket_created": 3, "lane": "todo", "version": 5})
+		json.Unmarshal(rr.Body.Bytes(), &card)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Equal(t, 6, card.Version)
+	})
+
+	t.Run("Should test that a lane has to be known", func(t *testing.T) {
+		mockDb.On("GetFeaturePhaseByUuid", "feature", "phase").Return(phase, nil).Once()
+		rr := move(map[string]interface{}{"ticket_pubkey": "owner", "ticket_created": 3, "lane": "later"})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
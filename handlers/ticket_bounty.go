@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+)
+
+type ticketBountyRequest struct {
+	WorkspaceUuid string `json:"workspace_uuid"`
+	PhaseUuid     string `json:"phase_uuid"`
+	Type          string `json:"type"`
+	Price         uint   `json:"price"`
+}
+
+func ticketString(ticket map[string]interface{}, field string) string {
+	value, _ := ticket[field].(string)
+	return value
+}
+
+// TicketToBounty creates a bounty from a ticket of the person asking, the ticket keeps the id
+// of the bounty and the bounty the key of the ticket so they complete together
+func (h *bountyHandler) TicketToBounty(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	pubKey := chi.URLParam(r, "pubKey")
+	created, err := strconv.ParseInt(chi.URLParam(r, "created"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid ticket created time")
+		return
+	}
+	if pubKeyFromAuth != pubKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the ticket owner can make it a bounty")
+		return
+	}
+
+	person := h.db.GetPersonByPubkey(pubKey)
+	ticket := findTicket(person, created)
+	if ticket == nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Ticket not found")
+		return
+	}
+	if _, ok := ticket["bounty_id"]; ok {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("The ticket is already a bounty")
+		return
+	}
+
+	request := ticketBountyRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &request); err != nil {
+			w.WriteHeader(http.StatusNotAcceptable)
+			json.NewEncoder(w).Encode("Invalid request")
+			return
+		}
+	}
+
+	now := time.Now()
+	bounty := db.NewBounty{
+		OwnerID:                 pubKey,
+		Show:                    true,
+		Type:                    request.Type,
+		Price:                   request.Price,
+		Title:                   ticketString(ticket, "title"),
+		Tribe:                   "None",
+		TicketUrl:               ticketLink(pubKey, created),
+		WorkspaceUuid:           request.WorkspaceUuid,
+		Description:             ticketString(ticket, "description"),
+		WantedType:              ticketString(ticket, "wanted_type"),
+		Deliverables:            ticketString(ticket, "deliverables"),
+		OneSentenceSummary:      ticketString(ticket, "one_sentence_summary"),
+		EstimatedSessionLength:  ticketString(ticket, "estimated_session_length"),
+		EstimatedCompletionDate: ticketString(ticket, "estimated_completion_date"),
+		Created:                 now.Unix(),
+		Updated:                 &now,
+		PhaseUuid:               request.PhaseUuid,
+		TicketId:                ticketKey(pubKey, created),
+	}
+	if bounty.Type == "" {
+		bounty.Type = ticketString(ticket, "type")
+	}
+	if bounty.Price == 0 {
+		if price, ok := ticket["price"].(float64); ok && price > 0 {
+			bounty.Price = uint(price)
+		}
+	}
+	if bounty.PhaseUuid == "" {
+		bounty.PhaseUuid = ticketString(ticket, "phase_uuid")
+	}
+	if bounty.Description == "" {
+		bounty.Description = bounty.Title
+	}
+	if bounty.Title == "" || bounty.Type == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A bounty needs the ticket to have a title and a type")
+		return
+	}
+
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
+
+	if bounty.WorkspaceUuid != "" && !h.userHasManageBountyRoles(pubKeyFromAuth, bounty.WorkspaceUuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have the right permission to add bounties to the workspace")
+		return
+	}
+	if bounty.PhaseUuid != "" {
+		if phase, err := h.db.GetPhaseByUuid(bounty.PhaseUuid); err != nil || phase.Uuid != bounty.PhaseUuid {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("Not a valid phase")
+			return
+		}
+	}
+	bounty.PriceFiat = db.FiatAt(bounty.Price)
+
+	b, err := h.db.CreateOrEditBounty(bounty)
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	old := map[string]interface{}{}
+	for field, value := range ticket {
+		old[field] = value
+	}
+	ticket["bounty_id"] = b.ID
+	if _, err := h.db.CreateOrEditPerson(person); err != nil {
+		fmt.Println("[bounty] could not link ticket", b.TicketId, err)
+	} else {
+		recordTicketRevision(h.db, b.TicketId, old, ticket, pubKeyFromAuth)
+	}
+
+	publishBountyEvent(b, "bounty_created")
+	emitBountyEvent(events.BountyCreated, b)
+	recordActivity(b.OwnerID, db.ActivityBountyCreated, b.Title, bountyLink(b.ID), b.Price)
+	h.alertSavedSearches(b)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(b)
+}
+
+// completeBountyTicket marks the ticket a bounty was made from as completed, and paid when
+// the bounty is
+func completeBountyTicket(database db.Database, bounty db.NewBounty) {
+	if bounty.TicketId == "" {
+		return
+	}
+	pubKey, created, ok := parseTicketKey(bounty.TicketId)
+	if !ok {
+		return
+	}
+
+	person := database.GetPersonByPubkey(pubKey)
+	ticket := findTicket(person, created)
+	if ticket == nil {
+		return
+	}
+	completed, _ := ticket["completed"].(bool)
+	paid, _ := ticket["paid"].(bool)
+	if completed && (paid || !bounty.Paid) {
+		return
+	}
+
+	old := map[string]interface{}{}
+	for field, value := range ticket {
+		old[field] = value
+	}
+	ticket["completed"] = true
+	if bounty.Paid {
+		ticket["paid"] = true
+	}
+	if _, err := database.CreateOrEditPerson(person); err != nil {
+		fmt.Println("[bounty] could not complete ticket", bounty.TicketId, err)
+		return
+	}
+	recordTicketRevision(database, bounty.TicketId, old, ticket, bounty.OwnerID)
+	if !completed {
+		emitTicketCompleted(bounty.TicketId, ticket, bounty.WorkspaceUuid)
+	}
+}
+
+// completeTicketBounties announces the tickets an edit of a person completes and completes
+// their bounties
+func completeTicketBounties(database db.Database, before db.Person, after db.Person) {
+	wanteds, _ := after.Extras["wanted"].([]interface{})
+	for _, wanted := range wanteds {
+		ticket, ok := wanted.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if completed, _ := ticket["completed"].(bool); !completed {
+			continue
+		}
+		timeF, _ := ticket["created"].(float64)
+		if old := findTicket(before, int64(timeF)); old != nil {
+			if wasCompleted, _ := old["completed"].(bool); wasCompleted {
+				continue
+			}
+		}
+
+		key := ticketKey(after.OwnerPubKey, int64(timeF))
+		bountyId, ok := ticket["bounty_id"].(float64)
+		if !ok {
+			emitTicketCompleted(key, ticket, "")
+			continue
+		}
+		bounty := database.GetBounty(uint(bountyId))
+		emitTicketCompleted(key, ticket, bounty.WorkspaceUuid)
+		if bounty.ID == 0 || bounty.Completed {
+			continue
+		}
+		now := time.Now()
+		bounty.Completed = true
+		bounty.CompletionDate = &now
+		database.UpdateBountyCompleted(bounty)
+		publishBountyEvent(bounty, "bounty_updated")
+		recordActivity(bounty.Assignee, db.ActivityBountyCompleted, bounty.Title, bountyLink(bounty.ID), bounty.Price)
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTicketToBounty(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+	bHandler.userHasManageBountyRoles = func(pubKeyFromAuth string, uuid string) bool { return uuid == "workspace" }
+
+	router := chi.NewRouter()
+	router.Post("/gobounties/ticket/{pubKey}/{created}/to_bounty", bHandler.TicketToBounty)
+
+	serve := func(url string, body interface{}, pubkey string) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, pubkey))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	owner := func(ticket map[string]interface{}) db.Person {
+		return db.Person{OwnerPubKey: "owner", Extras: db.PropertyMap{"wanted": []interface{}{ticket}}}
+	}
+
+	t.Run("Should test that the bounty is filled from the ticket and linked to it", func(t *testing.T) {
+		person := owner(map[string]interface{}{
+			"created": float64(1700000000), "title": "Fix the login", "description": "It fails", "type": "coding_task",
+			"price": float64(5000), "estimated_session_length": "3 hours", "estimated_completion_date": "2026-11-01",
+		})
+		mockDb.On("GetPersonByPubkey", "owner").Return(person).Once()
+		mockDb.On("CreateOrEditBounty", mock.MatchedBy(func(b db.NewBounty) bool {
+			return b.OwnerID == "owner" && b.Title == "Fix the login" && b.Description == "It fails" && b.Type == "coding_task" &&
+				b.Price == 5000 && b.EstimatedSessionLength == "3 hours" && b.EstimatedCompletionDate == "2026-11-01" &&
+				b.WorkspaceUuid == "workspace" && b.TicketId == "owner:1700000000" && b.Show
+		})).Return(func(b db.NewBounty) (db.NewBounty, error) {
+			b.ID = 12
+			return b, nil
+		}).Once()
+		mockDb.On("CreateOrEditPerson", mock.MatchedBy(func(p db.Person) bool {
+			ticket := findTicket(p, 1700000000)
+			return ticket != nil && ticket["bounty_id"] == uint(12)
+		})).Return(person, nil).Once()
+		mockDb.On("CountTicketRevisions", "owner:1700000000").Return(int64(1)).Once()
+		mockDb.On("CreateTicketRevision", mock.Anything).Return(db.TicketRevision{}, nil).Once()
+		mockDb.On("GetSavedSearchesForBounty", mock.Anything).Return([]db.SavedSearch{}).Once()
+
+		rr := serve("/gobounties/ticket/owner/1700000000/to_bounty", map[string]interface{}{"workspace_uuid": "workspace"}, "owner")
+
+		bounty := db.NewBounty{}
+		json.Unmarshal(rr.Body.Bytes(), &bounty)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, uint(12), bounty.ID)
+		assert.Equal(t, "owner:1700000000", bounty.TicketId)
+	})
+
+	t.Run("Should test that a ticket becomes a bounty once", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(owner(map[string]interface{}{"created": float64(1), "title": "t", "bounty_id": float64(3)})).Once()
+		rr := serve("/gobounties/ticket/owner/1/to_bounty", nil, "owner")
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Should test that only the owner converts a ticket, into a workspace they manage", func(t *testing.T) {
+		rr := serve("/gobounties/ticket/owner/1/to_bounty", nil, "someone")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		mockDb.On("GetPersonByPubkey", "owner").Return(owner(map[string]interface{}{"created": float64(1), "title": "t", "type": "coding_task"})).Once()
+		rr = serve("/gobounties/ticket/owner/1/to_bounty", map[string]interface{}{"workspace_uuid": "other"}, "owner")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a ticket needs a type", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(owner(map[string]interface{}{"created": float64(1), "title": "t"})).Once()
+		rr := serve("/gobounties/ticket/owner/1/to_bounty", nil, "owner")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestTicketBountySync(t *testing.T) {
+	t.Run("Should test that a paid bounty completes its ticket", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		person := db.Person{OwnerPubKey: "owner", Extras: db.PropertyMap{"wanted": []interface{}{
+			map[string]interface{}{"created": float64(1), "title": "t", "bounty_id": float64(12)},
+		}}}
+		mockDb.On("GetPersonByPubkey", "owner").Return(person).Once()
+		mockDb.On("CreateOrEditPerson", mock.MatchedBy(func(p db.Person) bool {
+			ticket := findTicket(p, 1)
+			return ticket["completed"] == true && ticket["paid"] == true
+		})).Return(person, nil).Once()
+		mockDb.On("CountTicketRevisions", "owner:1").Return(int64(0)).Once()
+		mockDb.On("CreateTicketRevision", mock.Anything).Return(db.TicketRevision{}, nil).Twice()
+
+		completeBountyTicket(mockDb, db.NewBounty{ID: 12, OwnerID: "owner", Paid: true, Completed: true, TicketId: "owner:1"})
+	})
+
+	t.Run("Should test that a bounty without a ticket changes nothing", func(t *testing.T) {
+		completeBountyTicket(mocks.NewDatabase(t), db.NewBounty{ID: 12, Paid: true})
+	})
+
+	t.Run("Should test that completing a ticket completes its bounty", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		before := db.Person{OwnerPubKey: "owner", Extras: db.PropertyMap{"wanted": []interface{}{
+			map[string]interface{}{"created": float64(1), "bounty_id": float64(12)},
+			map[string]interface{}{"created": float64(2), "bounty_id": float64(13), "completed": true},
+		}}}
+		after := db.Person{OwnerPubKey: "owner", Extras: db.PropertyMap{"wanted": []interface{}{
+			map[string]interface{}{"created": float64(1), "bounty_id": float64(12), "completed": true},
+			map[string]interface{}{"created": float64(2), "bounty_id": float64(13), "completed": true},
+		}}}
+		mockDb.On("GetBounty", uint(12)).Return(db.NewBounty{ID: 12, Created: 5}).Once()
+		mockDb.On("UpdateBountyCompleted", mock.MatchedBy(func(b db.NewBounty) bool {
+			return b.ID == 12 && b.Completed && b.CompletionDate != nil
+		})).Return(db.NewBounty{}, nil).Once()
+
+		completeTicketBounties(mockDb, before, after)
+	})
+}
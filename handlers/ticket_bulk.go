@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const maxBulkTickets = 100
+
+const (
+	ticketBulkStatus = "status"
+	ticketBulkPhase  = "phase"
+	ticketBulkAssign = "assign"
+	ticketBulkDelete = "delete"
+)
+
+type ticketBulkRequest struct {
+	Tickets   []string      `json:"tickets"`
+	Action    string        `json:"action"`
+	Status    db.TicketLane `json:"status"`
+	PhaseUuid string        `json:"phase_uuid"`
+	Assignee  string        `json:"assignee"`
+}
+
+// BulkTickets changes the status, the phase, the assignee of a list of tickets, or deletes them,
+// in one go: when any ticket fails nothing changes and the results tell which ones failed
+func (th *ticketHandler) BulkTickets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tickets] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := ticketBulkRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request")
+		return
+	}
+	if len(request.Tickets) == 0 || len(request.Tickets) > maxBulkTickets {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Send between 1 and %d tickets", maxBulkTickets))
+		return
+	}
+
+	var assignee map[string]interface{}
+	switch request.Action {
+	case ticketBulkStatus:
+		if !validTicketLane(request.Status) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("A status is todo, in_progress, review or done")
+			return
+		}
+	case ticketBulkPhase:
+		if phase, err := th.db.GetPhaseByUuid(request.PhaseUuid); err != nil || phase.Uuid != request.PhaseUuid || phase.Uuid == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("Not a valid phase")
+			return
+		}
+		if rejectArchivedWorkspaceOf(w, func() string { return phaseWorkspace(th.db, request.PhaseUuid) }) {
+			return
+		}
+	case ticketBulkAssign:
+		if request.Assignee != "" {
+			person := th.db.GetPersonByPubkey(request.Assignee)
+			if person.ID == 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode("Assignee not found")
+				return
+			}
+			assignee = map[string]interface{}{
+				"owner_pubkey": person.OwnerPubKey,
+				"owner_alias":  person.OwnerAlias,
+				"img":          person.Img,
+			}
+		}
+	case ticketBulkDelete:
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("An action is status, phase, assign or delete")
+		return
+	}
+
+	result := db.TicketBulkResult{Results: []db.TicketBulkItem{}}
+	failed := false
+	people := map[string]*db.Person{}
+	owners := []string{}
+	changedOwners := map[string]bool{}
+	cards := []db.TicketCard{}
+	removed := []string{}
+	revisions := map[string][2]map[string]interface{}{}
+	seen := map[string]bool{}
+	// the last rank of every lane of a phase, so the tickets moved go to its bottom in order
+	bottoms := map[string]string{}
+	bottom := func(phaseUuid string, lane db.TicketLane) string {
+		key := phaseUuid + "/" + string(lane)
+		if _, ok := bottoms[key]; !ok {
+			bottoms[key] = ""
+			for _, c := range th.db.GetTicketCards(phaseUuid) {
+				if c.Lane == lane {
+					bottoms[key] = c.Rank
+				}
+			}
+		}
+		bottoms[key] = db.RankBetween(bottoms[key], "")
+		return bottoms[key]
+	}
+
+	for _, ticketId := range request.Tickets {
+		item := db.TicketBulkItem{TicketId: ticketId}
+		fail := func(msg string) {
+			item.Error = msg
+			failed = true
+		}
+
+		owner, created, ok := parseTicketKey(ticketId)
+		if !ok {
+			fail("invalid ticket")
+		} else if seen[ticketId] {
+			fail("ticket sent twice")
+		}
+		seen[ticketId] = true
+
+		var person *db.Person
+		var ticket map[string]interface{}
+		if item.Error == "" {
+			if _, ok := people[owner]; !ok {
+				p := th.db.GetPersonByPubkey(owner)
+				people[owner] = &p
+			}
+			person = people[owner]
+			if ticket = findTicket(*person, created); ticket == nil {
+				fail("ticket not found")
+			} else if isArchivedWorkspaceOf(func() string { return ticketWorkspace(th.db, ticketId) }) {
+				fail("workspace is archived")
+			}
+		}
+
+		if item.Error == "" {
+			switch request.Action {
+			case ticketBulkStatus, ticketBulkPhase:
+				card := th.db.GetTicketCard(ticketId)
+				if request.Action == ticketBulkStatus {
+					if card.ID == 0 {
+						fail("ticket is not on a board")
+						break
+					}
+					card.Lane = request.Status
+				} else {
+					card.PhaseUuid = request.PhaseUuid
+					if card.Lane == "" {
+						card.Lane = db.TicketLaneTodo
+					}
+				}
+				card.TicketId = ticketId
+				card.Rank = bottom(card.PhaseUuid, card.Lane)
+				card.UpdatedBy = pubKeyFromAuth
+				cards = append(cards, card)
+			case ticketBulkAssign, ticketBulkDelete:
+				if pubKeyFromAuth != owner {
+					fail("only the ticket owner can change it")
+					break
+				}
+				if !changedOwners[owner] {
+					changedOwners[owner] = true
+					owners = append(owners, owner)
+				}
+				if request.Action == ticketBulkDelete {
+					removeTicket(person, created)
+					removed = append(removed, ticketId)
+					break
+				}
+				old := map[string]interface{}{}
+				for field, value := range ticket {
+					old[field] = value
+				}
+				if assignee == nil {
+					delete(ticket, "assignee")
+				} else {
+					ticket["assignee"] = assignee
+				}
+				revisions[ticketId] = [2]map[string]interface{}{old, ticket}
+			}
+		}
+
+		item.Ok = item.Error == ""
+		result.Results = append(result.Results, item)
+	}
+
+	if failed {
+		for i := range result.Results {
+			result.Results[i].Ok = false
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+
+	changed := []db.Person{}
+	for _, owner := range owners {
+		changed = append(changed, *people[owner])
+	}
+	if err := th.db.ApplyTicketChanges(changed, cards, removed); err != nil {
+		fmt.Println("[tickets] could not apply bulk", request.Action, err)
+		for i := range result.Results {
+			result.Results[i].Ok = false
+			result.Results[i].Error = "could not save the changes"
+		}
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+
+	for _, ticketId := range request.Tickets {
+		if revision, ok := revisions[ticketId]; ok {
+			recordTicketRevision(th.db, ticketId, revision[0], revision[1], pubKeyFromAuth)
+		}
+	}
+
+	result.Applied = true
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// removeTicket takes the ticket created at a time out of the wanted list of a person
+func removeTicket(person *db.Person, created int64) {
+	wanteds, _ := person.Extras["wanted"].([]interface{})
+	kept := []interface{}{}
+	for _, wanted := range wanteds {
+		if ticket, ok := wanted.(map[string]interface{}); ok {
+			if timeF, ok := ticket["created"].(float64); ok && int64(timeF) == created {
+				continue
+			}
+		}
+		kept = append(kept, wanted)
+	}
+	person.Extras["wanted"] = kept
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBulkTickets(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	th := NewTicketHandler(mockDb)
+
+	bulk := func(body interface{}) (*httptest.ResponseRecorder, db.TicketBulkResult) {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/ticket/bulk", bytes.NewReader(data))
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "owner"))
+		rr := httptest.NewRecorder()
+		th.BulkTickets(rr, req)
+		result := db.TicketBulkResult{}
+		json.Unmarshal(rr.Body.Bytes(), &result)
+		return rr, result
+	}
+
+	t.Run("Should test that the tickets are assigned together", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "hunter").Return(db.Person{ID: 4, OwnerPubKey: "hunter", OwnerAlias: "Hunter"}).Once()
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketPerson("owner", 1, 2, 3)).Once()
+		mockDb.On("ApplyTicketChanges", mock.MatchedBy(func(people []db.Person) bool {
+			if len(people) != 1 {
+				return false
+			}
+			assigned := findTicket(people[0], 1)["assignee"].(map[string]interface{})
+			return assigned["owner_pubkey"] == "hunter" && findTicket(people[0], 2)["assignee"] != nil && findTicket(people[0], 3)["assignee"] == nil
+		}), []db.TicketCard{}, []string{}).Return(nil).Once()
+		mockDb.On("CountTicketRevisions", mock.Anything).Return(int64(1)).Twice()
+		mockDb.On("CreateTicketRevision", mock.Anything).Return(db.TicketRevision{}, nil).Twice()
+
+		rr, result := bulk(map[string]interface{}{"tickets": []string{"owner:1", "owner:2"}, "action": "assign", "assignee": "hunter"})
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, result.Applied)
+		assert.Len(t, result.Results, 2)
+		assert.True(t, result.Results[1].Ok)
+	})
+
+	t.Run("Should test that nothing changes when one ticket fails", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketPerson("owner", 1)).Once()
+		mockDb.On("GetPersonByPubkey", "other").Return(testTicketPerson("other", 5)).Once()
+
+		rr, result := bulk(map[string]interface{}{"tickets": []string{"owner:1", "owner:9", "other:5", "owner:1"}, "action": "delete"})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.False(t, result.Applied)
+		assert.Equal(t, "", result.Results[0].Error)
+		assert.False(t, result.Results[0].Ok)
+		assert.Equal(t, "ticket not found", result.Results[1].Error)
+		assert.Equal(t, "only the ticket owner can change it", result.Results[2].Error)
+		assert.Equal(t, "ticket sent twice", result.Results[3].Error)
+	})
+
+	t.Run("Should test that deleted tickets leave the list and their boards", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketPerson("owner", 1, 2)).Once()
+		mockDb.On("ApplyTicketChanges", mock.MatchedBy(func(people []db.Person) bool {
+			return len(people) == 1 && findTicket(people[0], 1) == nil && findTicket(people[0], 2) != nil
+		}), []db.TicketCard{}, []string{"owner:1"}).Return(nil).Once()
+
+		rr, result := bulk(map[string]interface{}{"tickets": []string{"owner:1"}, "action": "delete"})
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, result.Applied)
+	})
+
+	t.Run("Should test that tickets moved to a phase go to the bottom of their lanes in order", func(t *testing.T) {
+		mockDb.On("GetPhaseByUuid", "phase").Return(db.FeaturePhase{Uuid: "phase"}, nil).Once()
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketPerson("owner", 1, 2)).Once()
+		mockDb.On("GetTicketCard", "owner:1").Return(db.TicketCard{}).Once()
+		mockDb.On("GetTicketCard", "owner:2").Return(db.TicketCard{ID: 2, PhaseUuid: "old", Lane: db.TicketLaneTodo, Version: 3}).Once()
+		mockDb.On("GetTicketCards", "phase").Return([]db.TicketCard{{Lane: db.TicketLaneTodo, Rank: "i"}}).Once()
+		mockDb.On("ApplyTicketChanges", []db.Person{}, mock.MatchedBy(func(cards []db.TicketCard) bool {
+			return len(cards) == 2 && cards[0].TicketId == "owner:1" && cards[0].Rank == "r" && cards[0].PhaseUuid == "phase" &&
+				cards[1].ID == 2 && cards[1].Rank == "v" && cards[1].Version == 3 && cards[1].PhaseUuid == "phase"
+		}), []string{}).Return(nil).Once()
+
+		rr, _ := bulk(map[string]interface{}{"tickets": []string{"owner:1", "owner:2"}, "action": "phase", "phase_uuid": "phase"})
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a failed save reports every ticket", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketPerson("owner", 1)).Once()
+		mockDb.On("GetTicketCard", "owner:1").Return(db.TicketCard{ID: 1, PhaseUuid: "phase", Lane: db.TicketLaneTodo, Version: 2}).Once()
+		mockDb.On("GetTicketCards", "phase").Return([]db.TicketCard{}).Once()
+		mockDb.On("ApplyTicketChanges", []db.Person{}, mock.Anything, []string{}).Return(errors.New("card was moved")).Once()
+
+		rr, result := bulk(map[string]interface{}{"tickets": []string{"owner:1"}, "action": "status", "status": "done"})
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.False(t, result.Applied)
+		assert.Equal(t, "could not save the changes", result.Results[0].Error)
+	})
+
+	t.Run("Should test that the action has to be known", func(t *testing.T) {
+		rr, _ := bulk(map[string]interface{}{"tickets": []string{"owner:1"}, "action": "archive"})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr, _ = bulk(map[string]interface{}{"tickets": []string{}, "action": "delete"})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
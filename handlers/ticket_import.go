@@ -0,0 +1,604 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"gorm.io/gorm"
+)
+
+const (
+	maxTicketImportBytes = 10 << 20
+	trackerPageSize      = 100
+	linearApiUrl         = "https://api.linear.app/graphql"
+)
+
+// a Jira project or Linear team key, checked before it goes into a query
+var trackerKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,19}$`)
+
+// defaultImportFields are the columns of the CSV exports of each tracker, the issues read from
+// the apis are given the same columns
+var defaultImportFields = map[db.TicketImportSource]map[string]string{
+	db.TicketImportJira: {
+		"key":         "issue key",
+		"title":       "summary",
+		"description": "description",
+		"status":      "status",
+		"type":        "issue type",
+		"feature":     "parent summary",
+		"phase":       "sprint",
+		"url":         "url",
+	},
+	db.TicketImportLinear: {
+		"key":         "id",
+		"title":       "title",
+		"description": "description",
+		"status":      "status",
+		"type":        "type",
+		"feature":     "project",
+		"phase":       "cycle name",
+		"url":         "url",
+	},
+}
+
+var trackerNames = map[db.TicketImportSource]string{db.TicketImportJira: "Jira", db.TicketImportLinear: "Linear"}
+
+type ticketImportHandler struct {
+	httpClient    HttpClient
+	db            db.Database
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewTicketImportHandler(httpClient HttpClient, database db.Database) *ticketImportHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &ticketImportHandler{
+		httpClient:    httpClient,
+		db:            database,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+// parseTrackerCsv reads the rows of a tracker export by their lowercased column names. Jira repeats
+// a column for each value of a list such as the sprints, the values are joined with commas
+func parseTrackerCsv(file string) ([]map[string]string, error) {
+	reader := csv.NewReader(strings.NewReader(file))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("the export has no header row")
+	}
+
+	columns := []string{}
+	for _, name := range records[0] {
+		columns = append(columns, strings.ToLower(strings.TrimSpace(name)))
+	}
+	issues := []map[string]string{}
+	for _, record := range records[1:] {
+		issue := map[string]string{}
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if i >= len(columns) || value == "" {
+				continue
+			}
+			if issue[columns[i]] != "" {
+				issue[columns[i]] += ", " + value
+			} else {
+				issue[columns[i]] = value
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// jiraSite returns the https address of an Atlassian cloud site, the only hosts an import calls
+func jiraSite(site string) (string, error) {
+	site = strings.TrimSpace(site)
+	if !strings.Contains(site, "://") {
+		site = "https://" + site
+	}
+	u, err := url.Parse(site)
+	if err != nil || u.Scheme != "https" || !strings.HasSuffix(u.Hostname(), ".atlassian.net") || u.Port() != "" {
+		return "", errors.New("the site must be an Atlassian cloud site such as acme.atlassian.net")
+	}
+	return "https://" + u.Hostname(), nil
+}
+
+func (th *ticketImportHandler) trackerRequest(request *http.Request, into interface{}) error {
+	response, err := th.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("the tracker responded %d", response.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(response.Body, maxTicketImportBytes)).Decode(into)
+}
+
+// fetchJiraIssues reads the issues of a Jira project through its search api, oldest first
+func (th *ticketImportHandler) fetchJiraIssues(api db.TicketImportApi) ([]map[string]string, error) {
+	site, err := jiraSite(api.Site)
+	if err != nil {
+		return nil, err
+	}
+	if !trackerKeyPattern.MatchString(api.Project) || api.Email == "" || api.Token == "" {
+		return nil, errors.New("the api needs a project key, an email and a token")
+	}
+
+	type jiraName struct {
+		Name string `json:"name"`
+	}
+	type jiraPage struct {
+		Total  int `json:"total"`
+		Issues []struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Summary     string     `json:"summary"`
+				Description string     `json:"description"`
+				Status      jiraName   `json:"status"`
+				IssueType   jiraName   `json:"issuetype"`
+				Sprints     []jiraName `json:"customfield_10020"`
+				Parent      *struct {
+					Fields struct {
+						Summary string `json:"summary"`
+					} `json:"fields"`
+				} `json:"parent"`
+			} `json:"fields"`
+		} `json:"issues"`
+	}
+
+	issues := []map[string]string{}
+	for {
+		query := url.Values{}
+		query.Set("jql", fmt.Sprintf(`project = "%s" ORDER BY created ASC`, api.Project))
+		query.Set("startAt", fmt.Sprint(len(issues)))
+		query.Set("maxResults", fmt.Sprint(trackerPageSize))
+		query.Set("fields", "summary,description,status,issuetype,parent,customfield_10020")
+		request, err := http.NewRequest(http.MethodGet, site+"/rest/api/2/search?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		request.SetBasicAuth(api.Email, api.Token)
+		request.Header.Set("Accept", "application/json")
+
+		page := jiraPage{}
+		if err := th.trackerRequest(request, &page); err != nil {
+			return nil, err
+		}
+		if page.Total > maxImportRows {
+			return nil, fmt.Errorf("the project has %d issues, imports are limited to %d", page.Total, maxImportRows)
+		}
+		for _, issue := range page.Issues {
+			m := map[string]string{
+				"issue key":   issue.Key,
+				"summary":     issue.Fields.Summary,
+				"description": issue.Fields.Description,
+				"status":      issue.Fields.Status.Name,
+				"issue type":  issue.Fields.IssueType.Name,
+				"url":         site + "/browse/" + issue.Key,
+			}
+			if issue.Fields.Parent != nil {
+				m["parent summary"] = issue.Fields.Parent.Fields.Summary
+			}
+			// an issue carried over keeps its past sprints, the last one is the current
+			if n := len(issue.Fields.Sprints); n > 0 {
+				m["sprint"] = issue.Fields.Sprints[n-1].Name
+			}
+			issues = append(issues, m)
+		}
+		if len(page.Issues) == 0 || len(issues) >= page.Total {
+			return issues, nil
+		}
+	}
+}
+
+const linearIssuesQuery = `query($team: String!, $after: String) {
+  issues(first: 100, after: $after, orderBy: createdAt, filter: {team: {key: {eq: $team}}}) {
+    nodes { identifier title description url state { name } project { name } cycle { name number } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+// fetchLinearIssues reads the issues of a Linear team through its graphql api
+func (th *ticketImportHandler) fetchLinearIssues(api db.TicketImportApi) ([]map[string]string, error) {
+	if !trackerKeyPattern.MatchString(api.Project) || api.Token == "" {
+		return nil, errors.New("the api needs a team key and a token")
+	}
+
+	type linearName struct {
+		Name string `json:"name"`
+	}
+	type linearPage struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+		Data struct {
+			Issues struct {
+				Nodes []struct {
+					Identifier  string      `json:"identifier"`
+					Title       string      `json:"title"`
+					Description string      `json:"description"`
+					Url         string      `json:"url"`
+					State       linearName  `json:"state"`
+					Project     *linearName `json:"project"`
+					Cycle       *struct {
+						Name   string `json:"name"`
+						Number int    `json:"number"`
+					} `json:"cycle"`
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"issues"`
+		} `json:"data"`
+	}
+
+	issues := []map[string]string{}
+	var after *string
+	for {
+		body, _ := json.Marshal(map[string]interface{}{
+			"query":     linearIssuesQuery,
+			"variables": map[string]interface{}{"team": api.Project, "after": after},
+		})
+		request, err := http.NewRequest(http.MethodPost, linearApiUrl, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Authorization", api.Token)
+		request.Header.Set("Content-Type", "application/json")
+
+		page := linearPage{}
+		if err := th.trackerRequest(request, &page); err != nil {
+			return nil, err
+		}
+		if len(page.Errors) > 0 {
+			return nil, errors.New(page.Errors[0].Message)
+		}
+		for _, issue := range page.Data.Issues.Nodes {
+			m := map[string]string{
+				"id":          issue.Identifier,
+				"title":       issue.Title,
+				"description": issue.Description,
+				"status":      issue.State.Name,
+				"url":         issue.Url,
+			}
+			if issue.Project != nil {
+				m["project"] = issue.Project.Name
+			}
+			if issue.Cycle != nil {
+				m["cycle name"] = issue.Cycle.Name
+				if m["cycle name"] == "" {
+					m["cycle name"] = fmt.Sprintf("Cycle %d", issue.Cycle.Number)
+				}
+			}
+			issues = append(issues, m)
+		}
+		if len(issues) > maxImportRows {
+			return nil, fmt.Errorf("the team has more than %d issues, imports are limited to %d", maxImportRows, maxImportRows)
+		}
+		if !page.Data.Issues.PageInfo.HasNextPage {
+			return issues, nil
+		}
+		cursor := page.Data.Issues.PageInfo.EndCursor
+		after = &cursor
+	}
+}
+
+// importLane places an issue on the board by its status, the mapping first and then the usual
+// names of the statuses of Jira and Linear
+func importLane(status string, lanes map[string]db.TicketLane) db.TicketLane {
+	status = strings.ToLower(strings.TrimSpace(status))
+	for name, lane := range lanes {
+		if strings.ToLower(strings.TrimSpace(name)) == status {
+			return lane
+		}
+	}
+	switch {
+	case strings.Contains(status, "done"), strings.Contains(status, "closed"), strings.Contains(status, "resolved"),
+		strings.Contains(status, "complete"), strings.Contains(status, "cancel"), strings.Contains(status, "duplicate"):
+		return db.TicketLaneDone
+	case strings.Contains(status, "review"):
+		return db.TicketLaneReview
+	case strings.Contains(status, "progress"), strings.Contains(status, "started"), strings.Contains(status, "doing"):
+		return db.TicketLaneInProgress
+	}
+	return db.TicketLaneTodo
+}
+
+// planTicketImport works out the features, phases, tickets and cards an import creates in a
+// workspace. Features and phases are matched to the existing ones by name, epics become features,
+// and issues imported before onto the person's tickets are skipped
+func (th *ticketImportHandler) planTicketImport(source db.TicketImportSource, issues []map[string]string, mapping db.TicketImportMapping, workspaceUuid string, person db.Person) (db.TicketImport, db.TicketImportReport, []map[string]interface{}) {
+	fields := map[string]string{}
+	for field, column := range defaultImportFields[source] {
+		fields[field] = column
+	}
+	for field, column := range mapping.Fields {
+		fields[field] = strings.ToLower(strings.TrimSpace(column))
+	}
+	value := func(issue map[string]string, field string) string {
+		return strings.TrimSpace(issue[fields[field]])
+	}
+	defaultFeature := strings.TrimSpace(mapping.DefaultFeature)
+	if defaultFeature == "" {
+		defaultFeature = "Imported from " + trackerNames[source]
+	}
+	defaultPhase := strings.TrimSpace(mapping.DefaultPhase)
+	if defaultPhase == "" {
+		defaultPhase = "Backlog"
+	}
+
+	plan := db.TicketImport{Features: []db.WorkspaceFeatures{}, Phases: []db.FeaturePhase{}, Person: person, Cards: []db.TicketCard{}}
+	report := db.TicketImportReport{
+		Source:   source,
+		Issues:   len(issues),
+		Features: []string{},
+		Phases:   []string{},
+		Tickets:  []db.TicketImportTicket{},
+		Skipped:  []string{},
+	}
+
+	existingFeatures, existingPhases := th.db.GetWorkspaceFeaturesAndPhases(workspaceUuid)
+	features := map[string]string{}
+	for _, feature := range existingFeatures {
+		features[strings.ToLower(feature.Name)] = feature.Uuid
+	}
+	phases := map[string]string{}
+	for _, phase := range existingPhases {
+		phases[phase.FeatureUuid+"/"+strings.ToLower(phase.Name)] = phase.Uuid
+	}
+	feature := func(name string) string {
+		if uuid, ok := features[strings.ToLower(name)]; ok {
+			return uuid
+		}
+		m := db.WorkspaceFeatures{Uuid: xid.New().String(), WorkspaceUuid: workspaceUuid, Name: name, CreatedBy: person.OwnerPubKey}
+		plan.Features = append(plan.Features, m)
+		report.Features = append(report.Features, name)
+		features[strings.ToLower(name)] = m.Uuid
+		return m.Uuid
+	}
+
+	// cards go to the bottom of their lane, after the cards already on the boards
+	ranks := map[string]string{}
+	phase := func(featureName string, name string) string {
+		featureUuid := feature(featureName)
+		key := featureUuid + "/" + strings.ToLower(name)
+		if uuid, ok := phases[key]; ok {
+			return uuid
+		}
+		m := db.FeaturePhase{Uuid: xid.New().String(), FeatureUuid: featureUuid, Name: name, CreatedBy: person.OwnerPubKey}
+		plan.Phases = append(plan.Phases, m)
+		report.Phases = append(report.Phases, featureName+" / "+name)
+		phases[key] = m.Uuid
+		ranks[m.Uuid] = ""
+		return m.Uuid
+	}
+	nextRank := func(phaseUuid string, lane db.TicketLane) string {
+		if _, ok := ranks[phaseUuid]; !ok {
+			ranks[phaseUuid] = ""
+			for _, card := range th.db.GetTicketCards(phaseUuid) {
+				if key := phaseUuid + "/" + string(card.Lane); card.Rank > ranks[key] {
+					ranks[key] = card.Rank
+				}
+			}
+		}
+		key := phaseUuid + "/" + string(lane)
+		ranks[key] = db.RankBetween(ranks[key], "")
+		return ranks[key]
+	}
+
+	imported := map[string]bool{}
+	wanteds, _ := person.Extras["wanted"].([]interface{})
+	for _, wanted := range wanteds {
+		if ticket, ok := wanted.(map[string]interface{}); ok {
+			if key, ok := ticket["import_key"].(string); ok {
+				imported[key] = true
+			}
+		}
+	}
+
+	// epics are features rather than tickets, they are planned first so empty ones are created too
+	tickets := []map[string]string{}
+	for _, issue := range issues {
+		if strings.EqualFold(value(issue, "type"), "epic") && value(issue, "title") != "" {
+			feature(value(issue, "title"))
+			continue
+		}
+		tickets = append(tickets, issue)
+	}
+
+	entries := []map[string]interface{}{}
+	created := time.Now().Unix()
+	for n, issue := range tickets {
+		title := value(issue, "title")
+		key := value(issue, "key")
+		if key == "" {
+			key = fmt.Sprintf("row %d", n+1)
+		}
+		importKey := string(source) + ":" + key
+		if title == "" {
+			report.Skipped = append(report.Skipped, key+" has no title")
+			continue
+		}
+		if imported[importKey] {
+			report.Skipped = append(report.Skipped, key+" was imported before")
+			continue
+		}
+		imported[importKey] = true
+
+		featureName := value(issue, "feature")
+		if featureName == "" {
+			featureName = defaultFeature
+		}
+		phaseName := value(issue, "phase")
+		if phaseName == "" {
+			phaseName = defaultPhase
+		}
+		lane := importLane(value(issue, "status"), mapping.Lanes)
+		phaseUuid := phase(featureName, phaseName)
+
+		for findTicket(person, created) != nil {
+			created++
+		}
+		entry := map[string]interface{}{
+			"title":       title,
+			"description": value(issue, "description"),
+			"type":        "coding_task",
+			"ticket_url":  value(issue, "url"),
+			"import_key":  importKey,
+			"created":     float64(created),
+		}
+		entries = append(entries, entry)
+		plan.Cards = append(plan.Cards, db.TicketCard{
+			TicketId:  ticketKey(person.OwnerPubKey, created),
+			PhaseUuid: phaseUuid,
+			Lane:      lane,
+			Rank:      nextRank(phaseUuid, lane),
+			UpdatedBy: person.OwnerPubKey,
+		})
+		report.Tickets = append(report.Tickets, db.TicketImportTicket{Key: key, Title: title, Feature: featureName, Phase: phaseName, Lane: lane})
+		created++
+	}
+
+	// the profile is copied so a dry run leaves the one it was given as it was
+	extras := db.PropertyMap{}
+	for k, v := range person.Extras {
+		extras[k] = v
+	}
+	kept := append([]interface{}{}, wanteds...)
+	for _, entry := range entries {
+		kept = append(kept, entry)
+	}
+	extras["wanted"] = kept
+	plan.Person.Extras = extras
+	return plan, report, entries
+}
+
+// ImportWorkspaceTickets creates features, phases and tickets in a workspace from a Jira or Linear
+// export file, or from their apis with a token. ?dry_run=true only reports what would be created.
+// The tickets are added to the importer's tickets and placed on the boards of their phases
+func (th *ticketImportHandler) ImportWorkspaceTickets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[ticket import] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "workspace_uuid")
+	source := db.TicketImportSource(chi.URLParam(r, "source"))
+	if source != db.TicketImportJira && source != db.TicketImportLinear {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Tickets are imported from jira or linear")
+		return
+	}
+	workspace := th.db.GetWorkspaceByUuid(uuid)
+	if workspace.ID == 0 || workspace.Deleted {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+	if !th.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the workspace")
+		return
+	}
+	if rejectArchivedWorkspace(w, uuid) {
+		return
+	}
+	person := th.db.GetPersonByPubkey(pubKeyFromAuth)
+	if person.ID == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Tickets are kept on a profile, create one first")
+		return
+	}
+
+	request := db.TicketImportRequest{}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxTicketImportBytes))
+	r.Body.Close()
+	if err != nil {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Imports are limited to %d bytes", maxTicketImportBytes))
+		return
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+	for status, lane := range request.Mapping.Lanes {
+		if !validTicketLane(lane) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(fmt.Sprintf("Status %q is mapped to unknown lane %q", status, lane))
+			return
+		}
+	}
+
+	var issues []map[string]string
+	switch {
+	case request.File != "" && request.Api != nil:
+		err = errors.New("send either an export file or an api, not both")
+	case request.File != "":
+		issues, err = parseTrackerCsv(request.File)
+	case request.Api != nil && source == db.TicketImportJira:
+		issues, err = th.fetchJiraIssues(*request.Api)
+	case request.Api != nil:
+		issues, err = th.fetchLinearIssues(*request.Api)
+	default:
+		err = errors.New("send an export file or an api to read the issues from")
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Could not read the issues: " + err.Error())
+		return
+	}
+	if len(issues) == 0 || len(issues) > maxImportRows {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("An import has between 1 and %d issues", maxImportRows))
+		return
+	}
+
+	plan, report, entries := th.planTicketImport(source, issues, request.Mapping, uuid, person)
+	report.DryRun = r.URL.Query().Get("dry_run") == "true"
+	if report.DryRun || len(plan.Features)+len(plan.Phases)+len(entries) == 0 {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(report)
+		return
+	}
+
+	if err := th.db.ImportWorkspaceTickets(plan); err != nil {
+		log.Printf("[ticket import] could not import tickets into %s: %v", uuid, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not import the tickets, none were created")
+		return
+	}
+	for _, entry := range entries {
+		recordTicketRevision(th.db, ticketKey(pubKeyFromAuth, int64(entry["created"].(float64))), nil, entry, pubKeyFromAuth)
+	}
+	report.Imported = len(entries)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImportWorkspaceTickets(t *testing.T) {
+	workspace := db.Workspace{ID: 1, Uuid: "ws", OwnerPubKey: "owner"}
+	person := db.Person{ID: 1, OwnerPubKey: "owner", Extras: db.PropertyMap{"wanted": []interface{}{
+		map[string]interface{}{"title": "Old", "created": float64(1), "import_key": "jira:APP-3"},
+	}}}
+	jiraCsv := "Issue key,Issue Type,Summary,Status,Parent summary,Sprint,Sprint\n" +
+		"APP-1,Epic,Checkout,To Do,,,\n" +
+		"APP-2,Story,Pay by card,Done,Checkout,Sprint 1,Sprint 2\n" +
+		"APP-3,Bug,Already here,To Do,,,\n" +
+		"APP-4,Task,Write docs,In Review,Onboarding,,\n"
+
+	importTickets := func(th *ticketImportHandler, source string, query string, request db.TicketImportRequest) (*httptest.ResponseRecorder, db.TicketImportReport) {
+		body, _ := json.Marshal(request)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", "ws")
+		rctx.URLParams.Add("source", source)
+		req := httptest.NewRequest(http.MethodPost, "/"+query, bytes.NewReader(body))
+		req = req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, "owner"))
+		rr := httptest.NewRecorder()
+		th.ImportWorkspaceTickets(rr, req)
+
+		report := db.TicketImportReport{}
+		json.Unmarshal(rr.Body.Bytes(), &report)
+		return rr, report
+	}
+	newHandler := func(t *testing.T, httpClient HttpClient) (*ticketImportHandler, *dbMocks.Database) {
+		mockDb := dbMocks.NewDatabase(t)
+		th := NewTicketImportHandler(httpClient, mockDb)
+		th.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+		mockDb.On("GetWorkspaceByUuid", "ws").Return(workspace)
+		mockDb.On("GetPersonByPubkey", "owner").Return(person)
+		return th, mockDb
+	}
+
+	t.Run("Should test that statuses are placed on the usual lanes unless mapped", func(t *testing.T) {
+		assert.Equal(t, db.TicketLaneDone, importLane("Done", nil))
+		assert.Equal(t, db.TicketLaneInProgress, importLane("In Progress", nil))
+		assert.Equal(t, db.TicketLaneReview, importLane("In Review", nil))
+		assert.Equal(t, db.TicketLaneTodo, importLane("Backlog", nil))
+		assert.Equal(t, db.TicketLaneReview, importLane("QA", map[string]db.TicketLane{"qa": db.TicketLaneReview}))
+	})
+
+	t.Run("Should test that only Atlassian cloud sites are called", func(t *testing.T) {
+		site, err := jiraSite("acme.atlassian.net")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://acme.atlassian.net", site)
+		_, err = jiraSite("https://169.254.169.254")
+		assert.Error(t, err)
+		_, err = jiraSite("http://acme.atlassian.net")
+		assert.Error(t, err)
+	})
+
+	t.Run("Should test that a dry run reports what a Jira export creates", func(t *testing.T) {
+		th, mockDb := newHandler(t, nil)
+		mockDb.On("GetWorkspaceFeaturesAndPhases", "ws").Return([]db.WorkspaceFeatures{{Uuid: "f1", Name: "checkout"}}, []db.FeaturePhase{}).Once()
+
+		rr, report := importTickets(th, "jira", "?dry_run=true", db.TicketImportRequest{File: jiraCsv})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 4, report.Issues)
+		// the epic matches the existing feature, the other feature is new
+		assert.Equal(t, []string{"Onboarding"}, report.Features)
+		assert.Equal(t, []string{"Checkout / Sprint 1, Sprint 2", "Onboarding / Backlog"}, report.Phases)
+		assert.Equal(t, []string{"APP-3 was imported before"}, report.Skipped)
+		assert.Len(t, report.Tickets, 2)
+		assert.Equal(t, db.TicketLaneDone, report.Tickets[0].Lane)
+		assert.Equal(t, db.TicketLaneReview, report.Tickets[1].Lane)
+	})
+
+	t.Run("Should test that the tickets are saved with their cards in one go", func(t *testing.T) {
+		th, mockDb := newHandler(t, nil)
+		mockDb.On("GetWorkspaceFeaturesAndPhases", "ws").Return([]db.WorkspaceFeatures{}, []db.FeaturePhase{}).Once()
+		mockDb.On("ImportWorkspaceTickets", mock.MatchedBy(func(m db.TicketImport) bool {
+			wanteds, _ := m.Person.Extras["wanted"].([]interface{})
+			return len(m.Features) == 1 && m.Features[0].Name == "Imported from Jira" &&
+				len(m.Phases) == 1 && m.Phases[0].Name == "Later" && m.Phases[0].FeatureUuid == m.Features[0].Uuid &&
+				len(m.Cards) == 2 && m.Cards[0].PhaseUuid == m.Phases[0].Uuid && m.Cards[0].Rank < m.Cards[1].Rank &&
+				len(wanteds) == 3
+		})).Return(nil).Once()
+		mockDb.On("CreateTicketRevision", mock.AnythingOfType("db.TicketRevision")).Return(db.TicketRevision{}, nil).Twice()
+
+		rr, report := importTickets(th, "jira", "", db.TicketImportRequest{
+			File: "Issue key,Summary,Status\nAPP-5,One,To Do\nAPP-6,Two,To Do\n",
+			Mapping: db.TicketImportMapping{
+				Fields:       map[string]string{"phase": "Fix Version"},
+				DefaultPhase: "Later",
+			},
+		})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 2, report.Imported)
+	})
+
+	t.Run("Should test that the issues of a Linear team are read through its api", func(t *testing.T) {
+		mockHttpClient := mocks.NewHttpClient(t)
+		th, mockDb := newHandler(t, mockHttpClient)
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == linearApiUrl && req.Header.Get("Authorization") == "lin_api_key"
+		})).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(bytes.NewReader([]byte(`{"data":{"issues":{"nodes":[
+				{"identifier":"ENG-1","title":"Sync","url":"https://linear.app/acme/issue/ENG-1","state":{"name":"In Progress"},"project":{"name":"Sync"},"cycle":{"name":null,"number":4}}
+			],"pageInfo":{"hasNextPage":false}}}}`))),
+		}, nil).Once()
+		mockDb.On("GetWorkspaceFeaturesAndPhases", "ws").Return([]db.WorkspaceFeatures{}, []db.FeaturePhase{}).Once()
+
+		rr, report := importTickets(th, "linear", "?dry_run=true", db.TicketImportRequest{
+			Api: &db.TicketImportApi{Token: "lin_api_key", Project: "ENG"},
+		})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, []string{"Sync / Cycle 4"}, report.Phases)
+		assert.Equal(t, db.TicketLaneInProgress, report.Tickets[0].Lane)
+	})
+
+	t.Run("Should test that an unknown lane in the mapping is refused", func(t *testing.T) {
+		th, _ := newHandler(t, nil)
+
+		rr, _ := importTickets(th, "jira", "", db.TicketImportRequest{
+			File:    jiraCsv,
+			Mapping: db.TicketImportMapping{Lanes: map[string]db.TicketLane{"QA": "testing"}},
+		})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
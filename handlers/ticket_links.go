@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+type ticketLinkRequest struct {
+	Type      db.TicketLinkType `json:"type"`
+	ToPubKey  string            `json:"to_pubkey"`
+	ToCreated int64             `json:"to_created"`
+}
+
+// blocksReach tells if a ticket is reached from another following blocks links
+func blocksReach(links []db.TicketLink, from string, to string) bool {
+	next := map[string][]string{}
+	for _, link := range links {
+		next[link.FromTicket] = append(next[link.FromTicket], link.ToTicket)
+	}
+	seen := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		ticket := queue[0]
+		queue = queue[1:]
+		if ticket == to {
+			return true
+		}
+		for _, n := range next[ticket] {
+			if !seen[n] {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	return false
+}
+
+// CreateTicketLink links a ticket to another one, for the owners of either ticket
+func (th *ticketHandler) CreateTicketLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tickets] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	pubKey, created, _, ok := th.ticketFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	request := ticketLinkRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid link")
+		return
+	}
+
+	if findTicket(th.db.GetPersonByPubkey(request.ToPubKey), request.ToCreated) == nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Linked ticket not found")
+		return
+	}
+	if pubKeyFromAuth != pubKey && pubKeyFromAuth != request.ToPubKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the owners of the tickets can link them")
+		return
+	}
+	if rejectArchivedWorkspaceOf(w, func() string { return ticketWorkspace(th.db, ticketKey(pubKey, created)) }) {
+		return
+	}
+	if rejectArchivedWorkspaceOf(w, func() string { return ticketWorkspace(th.db, ticketKey(request.ToPubKey, request.ToCreated)) }) {
+		return
+	}
+
+	link := db.TicketLink{
+		FromTicket: ticketKey(pubKey, created),
+		ToTicket:   ticketKey(request.ToPubKey, request.ToCreated),
+		Type:       request.Type,
+		CreatedBy:  pubKeyFromAuth,
+	}
+	switch link.Type {
+	case db.TicketBlocks, db.TicketRelatesTo, db.TicketDuplicates:
+	case db.TicketBlockedBy:
+		link.FromTicket, link.ToTicket = link.ToTicket, link.FromTicket
+		link.Type = db.TicketBlocks
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A link is blocks, blocked_by, relates_to or duplicates")
+		return
+	}
+	if link.FromTicket == link.ToTicket {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A ticket can't be linked to itself")
+		return
+	}
+
+	for _, existing := range th.db.GetTicketLinks([]string{link.FromTicket}) {
+		same := existing.FromTicket == link.FromTicket && existing.ToTicket == link.ToTicket
+		reversed := existing.FromTicket == link.ToTicket && existing.ToTicket == link.FromTicket
+		if existing.Type == link.Type && (same || (reversed && link.Type == db.TicketRelatesTo)) {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode("The tickets are already linked")
+			return
+		}
+	}
+
+	if link.Type == db.TicketBlocks && blocksReach(th.db.GetTicketLinksByType(db.TicketBlocks), link.ToTicket, link.FromTicket) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The link would make the tickets block each other")
+		return
+	}
+
+	link, err := th.db.CreateTicketLink(link)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save link")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(link)
+}
+
+// GetTicketLinks returns the links from and to a ticket
+func (th *ticketHandler) GetTicketLinks(w http.ResponseWriter, r *http.Request) {
+	pubKey, created, _, ok := th.ticketFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(th.db.GetTicketLinks([]string{ticketKey(pubKey, created)}))
+}
+
+// DeleteTicketLink removes a link, for whoever made it and the owners of either ticket
+func (th *ticketHandler) DeleteTicketLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tickets] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid link id")
+		return
+	}
+
+	link := th.db.GetTicketLink(id)
+	if link.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Link not found")
+		return
+	}
+
+	fromOwner, _, _ := parseTicketKey(link.FromTicket)
+	toOwner, _, _ := parseTicketKey(link.ToTicket)
+	if pubKeyFromAuth != link.CreatedBy && pubKeyFromAuth != fromOwner && pubKeyFromAuth != toOwner {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the owners of the tickets can remove the link")
+		return
+	}
+	if rejectArchivedWorkspaceOf(w, func() string { return ticketWorkspace(th.db, link.FromTicket) }) {
+		return
+	}
+	if rejectArchivedWorkspaceOf(w, func() string { return ticketWorkspace(th.db, link.ToTicket) }) {
+		return
+	}
+
+	if err := th.db.DeleteTicketLink(link.ID); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not remove link")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(link)
+}
+
+// GetTicketGraph returns the dependency graph of someone's tickets, with the tickets of other
+// people they are linked to, and the order to work on them so blocking tickets come first
+func (th *ticketHandler) GetTicketGraph(w http.ResponseWriter, r *http.Request) {
+	pubKey := chi.URLParam(r, "pubKey")
+
+	graph := db.TicketGraph{Nodes: []db.TicketGraphNode{}, Edges: []db.TicketLink{}, Order: []string{}}
+	nodes := map[string]bool{}
+	people := map[string]db.Person{}
+	addNode := func(owner string, created int64, ticket map[string]interface{}) {
+		key := ticketKey(owner, created)
+		if nodes[key] {
+			return
+		}
+		nodes[key] = true
+		title, _ := ticket["title"].(string)
+		graph.Nodes = append(graph.Nodes, db.TicketGraphNode{Key: key, OwnerPubKey: owner, Created: created, Title: title})
+	}
+
+	person := th.db.GetPersonByPubkey(pubKey)
+	people[pubKey] = person
+	wanteds, _ := person.Extras["wanted"].([]interface{})
+	for _, wanted := range wanteds {
+		ticket, ok := wanted.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if timeF, ok := ticket["created"].(float64); ok {
+			addNode(pubKey, int64(timeF), ticket)
+		}
+	}
+
+	keys := []string{}
+	for _, node := range graph.Nodes {
+		keys = append(keys, node.Key)
+	}
+	for _, link := range th.db.GetTicketLinks(keys) {
+		for _, key := range []string{link.FromTicket, link.ToTicket} {
+			if nodes[key] {
+				continue
+			}
+			owner, created, ok := parseTicketKey(key)
+			if !ok {
+				continue
+			}
+			if _, ok := people[owner]; !ok {
+				people[owner] = th.db.GetPersonByPubkey(owner)
+			}
+			if ticket := findTicket(people[owner], created); ticket != nil {
+				addNode(owner, created, ticket)
+			}
+		}
+		if nodes[link.FromTicket] && nodes[link.ToTicket] {
+			graph.Edges = append(graph.Edges, link)
+		}
+	}
+
+	sort.SliceStable(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].Created < graph.Nodes[j].Created
+	})
+
+	// tickets come in created order once nothing left blocks them
+	blockers := map[string]int{}
+	blocked := map[string][]string{}
+	for _, edge := range graph.Edges {
+		if edge.Type == db.TicketBlocks {
+			blockers[edge.ToTicket]++
+			blocked[edge.FromTicket] = append(blocked[edge.FromTicket], edge.ToTicket)
+		}
+	}
+	done := map[string]bool{}
+	for len(graph.Order) < len(graph.Nodes) {
+		next := ""
+		for _, node := range graph.Nodes {
+			if !done[node.Key] && blockers[node.Key] == 0 {
+				next = node.Key
+				break
+			}
+		}
+		if next == "" {
+			// tickets blocking each other are left in created order
+			for _, node := range graph.Nodes {
+				if !done[node.Key] {
+					done[node.Key] = true
+					graph.Order = append(graph.Order, node.Key)
+				}
+			}
+			break
+		}
+		done[next] = true
+		graph.Order = append(graph.Order, next)
+		for _, key := range blocked[next] {
+			blockers[key]--
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(graph)
+}
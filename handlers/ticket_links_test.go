@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testTicketPerson(pubkey string, created ...int64) db.Person {
+	wanteds := []interface{}{}
+	for _, c := range created {
+		wanteds = append(wanteds, map[string]interface{}{"created": float64(c), "title": "ticket"})
+	}
+	return db.Person{OwnerPubKey: pubkey, Extras: db.PropertyMap{"wanted": wanteds}}
+}
+
+func TestTicketLinks(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	th := NewTicketHandler(mockDb)
+
+	router := chi.NewRouter()
+	router.Post("/ticket/{pubKey}/{created}/links", th.CreateTicketLink)
+	router.Delete("/ticket/links/{id}", th.DeleteTicketLink)
+	router.Get("/ticket/{pubKey}/graph", th.GetTicketGraph)
+
+	serve := func(method string, url string, body interface{}, pubkey string) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, url, bytes.NewReader(data))
+		if pubkey != "" {
+			req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, pubkey))
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that blocked_by is kept as a blocks link the other way round", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketPerson("owner", 1, 2)).Twice()
+		mockDb.On("GetTicketLinks", []string{"owner:2"}).Return([]db.TicketLink{}).Once()
+		mockDb.On("GetTicketLinksByType", db.TicketBlocks).Return([]db.TicketLink{}).Once()
+		mockDb.On("CreateTicketLink", mock.MatchedBy(func(l db.TicketLink) bool {
+			return l.FromTicket == "owner:2" && l.ToTicket == "owner:1" && l.Type == db.TicketBlocks && l.CreatedBy == "owner"
+		})).Return(func(l db.TicketLink) (db.TicketLink, error) {
+			l.ID = 1
+			return l, nil
+		}).Once()
+
+		rr := serve(http.MethodPost, "/ticket/owner/1/links", map[string]interface{}{"type": "blocked_by", "to_pubkey": "owner", "to_created": 2}, "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a link making a cycle is refused", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketPerson("owner", 1, 2, 3)).Twice()
+		mockDb.On("GetTicketLinks", []string{"owner:3"}).Return([]db.TicketLink{}).Once()
+		mockDb.On("GetTicketLinksByType", db.TicketBlocks).Return([]db.TicketLink{
+			{FromTicket: "owner:1", ToTicket: "owner:2", Type: db.TicketBlocks},
+			{FromTicket: "owner:2", ToTicket: "owner:3", Type: db.TicketBlocks},
+		}).Once()
+
+		rr := serve(http.MethodPost, "/ticket/owner/3/links", map[string]interface{}{"type": "blocks", "to_pubkey": "owner", "to_created": 1}, "owner")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that tickets are linked once and not to themselves", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketPerson("owner", 1, 2)).Twice()
+		mockDb.On("GetTicketLinks", []string{"owner:1"}).Return([]db.TicketLink{
+			{FromTicket: "owner:2", ToTicket: "owner:1", Type: db.TicketRelatesTo},
+		}).Once()
+		rr := serve(http.MethodPost, "/ticket/owner/1/links", map[string]interface{}{"type": "relates_to", "to_pubkey": "owner", "to_created": 2}, "owner")
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketPerson("owner", 1)).Twice()
+		rr = serve(http.MethodPost, "/ticket/owner/1/links", map[string]interface{}{"type": "duplicates", "to_pubkey": "owner", "to_created": 1}, "owner")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketPerson("owner", 1, 2)).Twice()
+		rr = serve(http.MethodPost, "/ticket/owner/1/links", map[string]interface{}{"type": "parent_of", "to_pubkey": "owner", "to_created": 2}, "owner")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that only the owners of the tickets can link them", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketPerson("owner", 1)).Once()
+		mockDb.On("GetPersonByPubkey", "other").Return(testTicketPerson("other", 5)).Once()
+		rr := serve(http.MethodPost, "/ticket/owner/1/links", map[string]interface{}{"type": "blocks", "to_pubkey": "other", "to_created": 5}, "someone")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that the owner of either ticket removes a link", func(t *testing.T) {
+		link := db.TicketLink{ID: 4, FromTicket: "owner:1", ToTicket: "other:5", Type: db.TicketBlocks, CreatedBy: "owner"}
+		mockDb.On("GetTicketLink", uint(4)).Return(link).Once()
+		rr := serve(http.MethodDelete, "/ticket/links/4", nil, "someone")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		mockDb.On("GetTicketLink", uint(4)).Return(link).Once()
+		mockDb.On("DeleteTicketLink", uint(4)).Return(nil).Once()
+		rr = serve(http.MethodDelete, "/ticket/links/4", nil, "other")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that the graph orders blocking tickets first", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketPerson("owner", 1, 2, 3)).Once()
+		mockDb.On("GetTicketLinks", []string{"owner:1", "owner:2", "owner:3"}).Return([]db.TicketLink{
+			{ID: 1, FromTicket: "owner:3", ToTicket: "owner:1", Type: db.TicketBlocks},
+			{ID: 2, FromTicket: "other:0", ToTicket: "owner:3", Type: db.TicketBlocks},
+			{ID: 3, FromTicket: "owner:2", ToTicket: "owner:1", Type: db.TicketRelatesTo},
+		}).Once()
+		mockDb.On("GetPersonByPubkey", "other").Return(testTicketPerson("other", 0)).Once()
+
+		rr := serve(http.MethodGet, "/ticket/owner/graph", nil, "")
+
+		graph := db.TicketGraph{}
+		json.Unmarshal(rr.Body.Bytes(), &graph)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, graph.Nodes, 4)
+		assert.Equal(t, "other:0", graph.Nodes[0].Key)
+		assert.Len(t, graph.Edges, 3)
+		assert.Equal(t, []string{"other:0", "owner:2", "owner:3", "owner:1"}, graph.Order)
+	})
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// recordTicketRevisions stores a revision of every ticket an edit of a person adds or changes,
+// keeping the content it had before when the ticket had no revision yet
+func recordTicketRevisions(database db.Database, before db.Person, after db.Person, editor string) {
+	previous := map[int64]map[string]interface{}{}
+	wanteds, _ := before.Extras["wanted"].([]interface{})
+	for _, wanted := range wanteds {
+		if ticket, ok := wanted.(map[string]interface{}); ok {
+			if timeF, ok := ticket["created"].(float64); ok {
+				previous[int64(timeF)] = ticket
+			}
+		}
+	}
+
+	wanteds, _ = after.Extras["wanted"].([]interface{})
+	for _, wanted := range wanteds {
+		ticket, ok := wanted.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		timeF, ok := ticket["created"].(float64)
+		if !ok {
+			continue
+		}
+		old := previous[int64(timeF)]
+		if old != nil && reflect.DeepEqual(old, ticket) {
+			continue
+		}
+
+		recordTicketRevision(database, ticketKey(after.OwnerPubKey, int64(timeF)), old, ticket, editor)
+	}
+}
+
+// recordTicketRevision stores the content of a ticket after an edit, and the content before it
+// when that was never stored
+func recordTicketRevision(database db.Database, key string, old map[string]interface{}, ticket map[string]interface{}, editor string) {
+	if old != nil && database.CountTicketRevisions(key) == 0 {
+		owner, _, _ := parseTicketKey(key)
+		if _, err := database.CreateTicketRevision(db.TicketRevision{TicketId: key, Content: old, EditorPubKey: owner}); err != nil {
+			fmt.Println("[tickets] could not save revision", key, err)
+		}
+	}
+	if _, err := database.CreateTicketRevision(db.TicketRevision{TicketId: key, Content: ticket, EditorPubKey: editor}); err != nil {
+		fmt.Println("[tickets] could not save revision", key, err)
+	}
+}
+
+// diffLines compares two texts line by line, through their longest common subsequence
+func diffLines(before []string, after []string) []db.TicketDiffLine {
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	lines := []db.TicketDiffLine{}
+	i, j := 0, 0
+	for i < len(before) && j < len(after) {
+		switch {
+		case before[i] == after[j]:
+			lines = append(lines, db.TicketDiffLine{Op: "=", Text: before[i]})
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			lines = append(lines, db.TicketDiffLine{Op: "-", Text: before[i]})
+			i++
+		default:
+			lines = append(lines, db.TicketDiffLine{Op: "+", Text: after[j]})
+			j++
+		}
+	}
+	for ; i < len(before); i++ {
+		lines = append(lines, db.TicketDiffLine{Op: "-", Text: before[i]})
+	}
+	for ; j < len(after); j++ {
+		lines = append(lines, db.TicketDiffLine{Op: "+", Text: after[j]})
+	}
+	return lines
+}
+
+// DiffTicketContent returns the fields that differ between two contents of a ticket, by name
+func DiffTicketContent(before db.PropertyMap, after db.PropertyMap) []db.TicketFieldChange {
+	fields := []string{}
+	for field := range before {
+		fields = append(fields, field)
+	}
+	for field := range after {
+		if _, ok := before[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := []db.TicketFieldChange{}
+	for _, field := range fields {
+		if reflect.DeepEqual(before[field], after[field]) {
+			continue
+		}
+		change := db.TicketFieldChange{Field: field, Before: before[field], After: after[field]}
+		beforeText, beforeOk := before[field].(string)
+		afterText, afterOk := after[field].(string)
+		if (beforeOk || before[field] == nil) && (afterOk || after[field] == nil) {
+			change.Lines = diffLines(splitLines(beforeText), splitLines(afterText))
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(text, "\n")
+}
+
+// GetTicketHistory returns every revision of a ticket, the oldest first
+func (th *ticketHandler) GetTicketHistory(w http.ResponseWriter, r *http.Request) {
+	pubKey, created, _, ok := th.ticketFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(th.db.GetTicketRevisions(ticketKey(pubKey, created)))
+}
+
+// GetTicketDiff compares the revisions from and to of a ticket, by default the latest one
+// with the one before it
+func (th *ticketHandler) GetTicketDiff(w http.ResponseWriter, r *http.Request) {
+	pubKey, created, _, ok := th.ticketFromUrl(w, r)
+	if !ok {
+		return
+	}
+	key := ticketKey(pubKey, created)
+
+	keys := r.URL.Query()
+	to, err := strconv.Atoi(keys.Get("to"))
+	if err != nil {
+		to = int(th.db.CountTicketRevisions(key))
+	}
+	from, err := strconv.Atoi(keys.Get("from"))
+	if err != nil {
+		from = to - 1
+	}
+
+	before := th.db.GetTicketRevision(key, from)
+	after := th.db.GetTicketRevision(key, to)
+	if before.ID == 0 || after.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Revision not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.TicketDiff{
+		TicketId: key,
+		From:     from,
+		To:       to,
+		Changes:  DiffTicketContent(before.Content, after.Content),
+	})
+}
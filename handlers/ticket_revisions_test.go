@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDiffTicketContent(t *testing.T) {
+	changes := DiffTicketContent(
+		db.PropertyMap{"title": "Fix login", "description": "Steps:\nopen the app\nlog in", "price": float64(100), "tags": []interface{}{"go"}},
+		db.PropertyMap{"title": "Fix login", "description": "Steps:\nopen the app\nsign in\nsee the error", "price": float64(200), "estimate": "2 days"},
+	)
+
+	assert.Len(t, changes, 4)
+	assert.Equal(t, "description", changes[0].Field)
+	assert.Equal(t, []db.TicketDiffLine{
+		{Op: "=", Text: "Steps:"},
+		{Op: "=", Text: "open the app"},
+		{Op: "-", Text: "log in"},
+		{Op: "+", Text: "sign in"},
+		{Op: "+", Text: "see the error"},
+	}, changes[0].Lines)
+	assert.Equal(t, "estimate", changes[1].Field)
+	assert.Equal(t, []db.TicketDiffLine{{Op: "+", Text: "2 days"}}, changes[1].Lines)
+	assert.Equal(t, "price", changes[2].Field)
+	assert.Nil(t, changes[2].Lines)
+	assert.Equal(t, "tags", changes[3].Field)
+	assert.Nil(t, changes[3].After)
+}
+
+func TestTicketRevisions(t *testing.T) {
+	t.Run("Should test that an edit keeps the content a ticket had before", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		before := db.Person{OwnerPubKey: "owner", Extras: db.PropertyMap{"wanted": []interface{}{
+			map[string]interface{}{"created": float64(1), "title": "same"},
+			map[string]interface{}{"created": float64(2), "title": "old"},
+		}}}
+		after := db.Person{OwnerPubKey: "owner", Extras: db.PropertyMap{"wanted": []interface{}{
+			map[string]interface{}{"created": float64(1), "title": "same"},
+			map[string]interface{}{"created": float64(2), "title": "new"},
+			map[string]interface{}{"created": float64(3), "title": "added"},
+		}}}
+
+		mockDb.On("CountTicketRevisions", "owner:2").Return(int64(0)).Once()
+		mockDb.On("CreateTicketRevision", mock.MatchedBy(func(r db.TicketRevision) bool {
+			return r.TicketId == "owner:2" && r.Content["title"] == "old" && r.EditorPubKey == "owner"
+		})).Return(db.TicketRevision{}, nil).Once()
+		mockDb.On("CreateTicketRevision", mock.MatchedBy(func(r db.TicketRevision) bool {
+			return r.TicketId == "owner:2" && r.Content["title"] == "new" && r.EditorPubKey == "editor"
+		})).Return(db.TicketRevision{}, nil).Once()
+		mockDb.On("CreateTicketRevision", mock.MatchedBy(func(r db.TicketRevision) bool {
+			return r.TicketId == "owner:3" && r.Content["title"] == "added"
+		})).Return(db.TicketRevision{}, nil).Once()
+
+		recordTicketRevisions(mockDb, before, after, "editor")
+	})
+
+	mockDb := mocks.NewDatabase(t)
+	th := NewTicketHandler(mockDb)
+	router := chi.NewRouter()
+	router.Get("/ticket/{pubKey}/{created}/history", th.GetTicketHistory)
+	router.Get("/ticket/{pubKey}/{created}/diff", th.GetTicketDiff)
+
+	owner := db.Person{OwnerPubKey: "owner", Extras: db.PropertyMap{"wanted": []interface{}{
+		map[string]interface{}{"created": float64(2), "title": "new"},
+	}}}
+
+	t.Run("Should test that the history lists the revisions", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(owner).Once()
+		mockDb.On("GetTicketRevisions", "owner:2").Return([]db.TicketRevision{{ID: 1, Version: 1}, {ID: 2, Version: 2}}).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/ticket/owner/2/history", nil))
+
+		revisions := []db.TicketRevision{}
+		json.Unmarshal(rr.Body.Bytes(), &revisions)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, revisions, 2)
+	})
+
+	t.Run("Should test that the diff compares the latest revision with the one before", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(owner).Once()
+		mockDb.On("CountTicketRevisions", "owner:2").Return(int64(3)).Once()
+		mockDb.On("GetTicketRevision", "owner:2", 2).Return(db.TicketRevision{ID: 2, Content: db.PropertyMap{"title": "old"}}).Once()
+		mockDb.On("GetTicketRevision", "owner:2", 3).Return(db.TicketRevision{ID: 3, Content: db.PropertyMap{"title": "new"}}).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/ticket/owner/2/diff", nil))
+
+		diff := db.TicketDiff{}
+		json.Unmarshal(rr.Body.Bytes(), &diff)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 2, diff.From)
+		assert.Equal(t, 3, diff.To)
+		assert.Len(t, diff.Changes, 1)
+		assert.Equal(t, "title", diff.Changes[0].Field)
+	})
+
+	t.Run("Should test that a missing revision is not found", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(owner).Once()
+		mockDb.On("GetTicketRevision", "owner:2", 1).Return(db.TicketRevision{ID: 1}).Once()
+		mockDb.On("GetTicketRevision", "owner:2", 9).Return(db.TicketRevision{}).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/ticket/owner/2/diff?from=1&to=9", nil))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
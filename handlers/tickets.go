@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/notifications"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+const maxTicketCommentLength = 5000
+
+// mentionPattern matches the @unique_name mentions of a comment
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([a-zA-Z0-9_.\-]+)`)
+
+type ticketHandler struct {
+	db db.Database
+}
+
+func NewTicketHandler(database db.Database) *ticketHandler {
+	return &ticketHandler{db: database}
+}
+
+// ticketKey addresses a ticket, which is a wanted entry of a person, by its owner and created time
+func ticketKey(pubkey string, created int64) string {
+	return fmt.Sprintf("%s:%d", pubkey, created)
+}
+
+// parseTicketKey splits a ticket key back into the owner and created time of the ticket
+func parseTicketKey(key string) (string, int64, bool) {
+	i := strings.LastIndex(key, ":")
+	if i < 0 {
+		return "", 0, false
+	}
+	created, err := strconv.ParseInt(key[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return key[:i], created, true
+}
+
+func ticketLink(pubkey string, created int64) string {
+	return fmt.Sprintf("%s/ticket/%s/%d", config.Host, pubkey, created)
+}
+
+// findTicket returns the wanted entry of a person created at a time, nil if there is none
+func findTicket(person db.Person, created int64) map[string]interface{} {
+	wanteds, _ := person.Extras["wanted"].([]interface{})
+	for _, wanted := range wanteds {
+		ticket, ok := wanted.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if timeF, ok := ticket["created"].(float64); ok && int64(timeF) == created {
+			return ticket
+		}
+	}
+	return nil
+}
+
+// ticketWorkspace is the workspace whose phase board holds a ticket, empty when it is on no board
+func ticketWorkspace(database db.Database, ticketId string) string {
+	card := database.GetTicketCard(ticketId)
+	if card.PhaseUuid == "" {
+		return ""
+	}
+	return phaseWorkspace(database, card.PhaseUuid)
+}
+
+// ticketFromUrl reads the ticket of the {pubKey} and {created} params of a request, writing the
+// error response when it is invalid or missing
+func (th *ticketHandler) ticketFromUrl(w http.ResponseWriter, r *http.Request) (string, int64, map[string]interface{}, bool) {
+	pubKey := chi.URLParam(r, "pubKey")
+	created, err := strconv.ParseInt(chi.URLParam(r, "created"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid ticket created time")
+		return "", 0, nil, false
+	}
+
+	ticket := findTicket(th.db.GetPersonByPubkey(pubKey), created)
+	if ticket == nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Ticket not found")
+		return "", 0, nil, false
+	}
+	return pubKey, created, ticket, true
+}
+
+// ParseMentions returns the unique names mentioned in a text, once each
+func ParseMentions(text string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		name := strings.TrimRight(match[1], ".-")
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// GetTicketComments returns the threads of a ticket, every comment with its author and replies
+func (th *ticketHandler) GetTicketComments(w http.ResponseWriter, r *http.Request) {
+	pubKey, created, _, ok := th.ticketFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	comments := th.db.GetTicketComments(ticketKey(pubKey, created))
+
+	authors := map[string]db.TicketCommentAuthor{}
+	author := func(pubkey string) db.TicketCommentAuthor {
+		if a, ok := authors[pubkey]; ok {
+			return a
+		}
+		person := th.db.GetPersonByPubkey(pubkey)
+		a := db.TicketCommentAuthor{
+			OwnerPubKey: pubkey,
+			OwnerAlias:  person.OwnerAlias,
+			UniqueName:  person.UniqueName,
+			Img:         person.Img,
+		}
+		authors[pubkey] = a
+		return a
+	}
+
+	threads := []db.TicketThread{}
+	index := map[uint]int{}
+	for _, comment := range comments {
+		thread := db.TicketThread{TicketComment: comment, Author: author(comment.AuthorPubKey)}
+		if comment.ThreadId == nil {
+			index[comment.ID] = len(threads)
+			threads = append(threads, thread)
+			continue
+		}
+		if i, ok := index[*comment.ThreadId]; ok {
+			threads[i].Replies = append(threads[i].Replies, thread)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(threads)
+}
+
+// CreateTicketComment comments on a ticket, or replies to a thread with thread_id, and notifies
+// the people mentioned
+func (th *ticketHandler) CreateTicketComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tickets] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	pubKey, created, ticket, ok := th.ticketFromUrl(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspaceOf(w, func() string { return ticketWorkspace(th.db, ticketKey(pubKey, created)) }) {
+		return
+	}
+
+	comment := db.TicketComment{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &comment); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid comment")
+		return
+	}
+
+	comment.Body = strings.TrimSpace(comment.Body)
+	if comment.Body == "" || len(comment.Body) > maxTicketCommentLength {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("A comment needs a body of at most %d characters", maxTicketCommentLength))
+		return
+	}
+
+	key := ticketKey(pubKey, created)
+	if comment.ThreadId != nil {
+		thread := th.db.GetTicketComment(*comment.ThreadId)
+		if thread.ID == 0 || thread.TicketId != key {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("Thread not found on this ticket")
+			return
+		}
+		// a reply to a reply goes to the thread it is in
+		if thread.ThreadId != nil {
+			comment.ThreadId = thread.ThreadId
+		}
+	}
+
+	mentioned := map[string]bool{}
+	mentions := []string{}
+	for _, name := range ParseMentions(comment.Body) {
+		person := th.db.GetPersonByUniqueName(name)
+		if person.OwnerPubKey == "" || mentioned[person.OwnerPubKey] {
+			continue
+		}
+		mentioned[person.OwnerPubKey] = true
+		mentions = append(mentions, person.OwnerPubKey)
+	}
+
+	comment = db.TicketComment{
+		TicketId:     key,
+		ThreadId:     comment.ThreadId,
+		AuthorPubKey: pubKeyFromAuth,
+		Body:         comment.Body,
+		Mentions:     mentions,
+	}
+	comment, err := th.db.CreateTicketComment(comment)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save comment")
+		return
+	}
+
+	title, _ := ticket["title"].(string)
+	author := th.db.GetPersonByPubkey(pubKeyFromAuth)
+	for _, mention := range mentions {
+		if mention == pubKeyFromAuth {
+			continue
+		}
+		notifications.Notify(mention, db.NotificationTicketMention, fmt.Sprintf("%s mentioned you on \"%s\"", author.OwnerAlias, title), comment.Body, ticketLink(pubKey, created))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.TicketThread{
+		TicketComment: comment,
+		Author: db.TicketCommentAuthor{
+			OwnerPubKey: pubKeyFromAuth,
+			OwnerAlias:  author.OwnerAlias,
+			UniqueName:  author.UniqueName,
+			Img:         author.Img,
+		},
+	})
+}
+
+func (th *ticketHandler) ResolveTicketThread(w http.ResponseWriter, r *http.Request) {
+	th.setThreadResolved(w, r, true)
+}
+
+func (th *ticketHandler) UnresolveTicketThread(w http.ResponseWriter, r *http.Request) {
+	th.setThreadResolved(w, r, false)
+}
+
+// setThreadResolved resolves a thread or opens it again, for the ticket owner and whoever started it
+func (th *ticketHandler) setThreadResolved(w http.ResponseWriter, r *http.Request, resolved bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tickets] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid comment id")
+		return
+	}
+
+	thread := th.db.GetTicketComment(id)
+	if thread.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Comment not found")
+		return
+	}
+	if rejectArchivedWorkspaceOf(w, func() string { return ticketWorkspace(th.db, thread.TicketId) }) {
+		return
+	}
+	if thread.ThreadId != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Only the first comment of a thread can resolve it")
+		return
+	}
+
+	ticketOwner := strings.SplitN(thread.TicketId, ":", 2)[0]
+	if pubKeyFromAuth != ticketOwner && pubKeyFromAuth != thread.AuthorPubKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the ticket owner and the thread author can resolve it")
+		return
+	}
+
+	thread, err = th.db.SetTicketThreadResolved(thread.ID, resolved, pubKeyFromAuth)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not update thread")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(thread)
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testTicketOwner() db.Person {
+	return db.Person{OwnerPubKey: "owner", OwnerAlias: "Owner", Extras: db.PropertyMap{"wanted": []interface{}{
+		map[string]interface{}{"created": float64(1700000000), "title": "Fix the login"},
+	}}}
+}
+
+func TestParseMentions(t *testing.T) {
+	assert.Equal(t, []string{"alice", "bob_2"}, ParseMentions("@alice can you and @bob_2 look? thanks @alice."))
+	assert.Equal(t, []string{}, ParseMentions("mail me at me@example.com"))
+	assert.Equal(t, []string{"carol"}, ParseMentions("(@carol)"))
+}
+
+func TestTicketComments(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	th := NewTicketHandler(mockDb)
+
+	router := chi.NewRouter()
+	router.Get("/ticket/{pubKey}/{created}/comments", th.GetTicketComments)
+	router.Post("/ticket/{pubKey}/{created}/comments", th.CreateTicketComment)
+	router.Post("/ticket/comments/{id}/resolve", th.ResolveTicketThread)
+	router.Post("/ticket/comments/{id}/unresolve", th.UnresolveTicketThread)
+
+	serve := func(method string, url string, body interface{}, pubkey string) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, url, bytes.NewReader(data))
+		if pubkey != "" {
+			req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, pubkey))
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a comment keeps the people it mentions", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketOwner()).Once()
+		mockDb.On("GetPersonByUniqueName", "alice").Return(db.Person{OwnerPubKey: "alice-pubkey"}).Once()
+		mockDb.On("GetPersonByUniqueName", "nobody").Return(db.Person{}).Once()
+		mockDb.On("CreateTicketComment", mock.MatchedBy(func(c db.TicketComment) bool {
+			return c.TicketId == "owner:1700000000" && c.AuthorPubKey == "reviewer" && c.ThreadId == nil &&
+				len(c.Mentions) == 1 && c.Mentions[0] == "alice-pubkey"
+		})).Return(func(c db.TicketComment) (db.TicketComment, error) {
+			c.ID = 1
+			return c, nil
+		}).Once()
+		mockDb.On("GetPersonByPubkey", "reviewer").Return(db.Person{OwnerPubKey: "reviewer", OwnerAlias: "Reviewer"}).Once()
+
+		rr := serve(http.MethodPost, "/ticket/owner/1700000000/comments", map[string]interface{}{"body": " @alice @nobody does this cover the signup? "}, "reviewer")
+
+		thread := db.TicketThread{}
+		json.Unmarshal(rr.Body.Bytes(), &thread)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "@alice @nobody does this cover the signup?", thread.Body)
+		assert.Equal(t, "Reviewer", thread.Author.OwnerAlias)
+	})
+
+	t.Run("Should test that a reply to a reply joins its thread", func(t *testing.T) {
+		root := uint(1)
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketOwner()).Once()
+		mockDb.On("GetTicketComment", uint(2)).Return(db.TicketComment{ID: 2, TicketId: "owner:1700000000", ThreadId: &root}).Once()
+		mockDb.On("CreateTicketComment", mock.MatchedBy(func(c db.TicketComment) bool {
+			return c.ThreadId != nil && *c.ThreadId == root
+		})).Return(func(c db.TicketComment) (db.TicketComment, error) {
+			return c, nil
+		}).Once()
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketOwner()).Once()
+
+		rr := serve(http.MethodPost, "/ticket/owner/1700000000/comments", map[string]interface{}{"body": "yes", "thread_id": 2}, "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a thread of another ticket can't be replied to", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketOwner()).Once()
+		mockDb.On("GetTicketComment", uint(7)).Return(db.TicketComment{ID: 7, TicketId: "other:1"}).Once()
+
+		rr := serve(http.MethodPost, "/ticket/owner/1700000000/comments", map[string]interface{}{"body": "yes", "thread_id": 7}, "owner")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that an empty comment or a missing ticket is refused", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketOwner()).Once()
+		rr := serve(http.MethodPost, "/ticket/owner/1700000000/comments", map[string]interface{}{"body": "  "}, "owner")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketOwner()).Once()
+		rr = serve(http.MethodPost, "/ticket/owner/1600000000/comments", map[string]interface{}{"body": "hi"}, "owner")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = serve(http.MethodPost, "/ticket/owner/1700000000/comments", map[string]interface{}{"body": "hi"}, "")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that the comments are grouped in threads with their authors", func(t *testing.T) {
+		root := uint(1)
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketOwner()).Once()
+		mockDb.On("GetTicketComments", "owner:1700000000").Return([]db.TicketComment{
+			{ID: 1, AuthorPubKey: "reviewer", Body: "question"},
+			{ID: 2, AuthorPubKey: "owner", Body: "answer", ThreadId: &root},
+			{ID: 3, AuthorPubKey: "reviewer", Body: "another"},
+			{ID: 4, AuthorPubKey: "reviewer", Body: "thanks", ThreadId: &root},
+		}).Once()
+		mockDb.On("GetPersonByPubkey", "reviewer").Return(db.Person{OwnerAlias: "Reviewer", UniqueName: "reviewer"}).Once()
+		mockDb.On("GetPersonByPubkey", "owner").Return(testTicketOwner()).Once()
+
+		rr := serve(http.MethodGet, "/ticket/owner/1700000000/comments", nil, "")
+
+		threads := []db.TicketThread{}
+		json.Unmarshal(rr.Body.Bytes(), &threads)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, threads, 2)
+		assert.Equal(t, "reviewer", threads[0].Author.UniqueName)
+		assert.Len(t, threads[0].Replies, 2)
+		assert.Equal(t, "Owner", threads[0].Replies[0].Author.OwnerAlias)
+		assert.Equal(t, "thanks", threads[0].Replies[1].Body)
+		assert.Empty(t, threads[1].Replies)
+	})
+
+	t.Run("Should test that the ticket owner resolves a thread and opens it again", func(t *testing.T) {
+		mockDb.On("GetTicketComment", uint(1)).Return(db.TicketComment{ID: 1, TicketId: "owner:1700000000", AuthorPubKey: "reviewer"}).Once()
+		mockDb.On("SetTicketThreadResolved", uint(1), true, "owner").Return(db.TicketComment{ID: 1, Resolved: true, ResolvedBy: "owner"}, nil).Once()
+		rr := serve(http.MethodPost, "/ticket/comments/1/resolve", nil, "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		mockDb.On("GetTicketComment", uint(1)).Return(db.TicketComment{ID: 1, TicketId: "owner:1700000000", AuthorPubKey: "reviewer", Resolved: true}).Once()
+		mockDb.On("SetTicketThreadResolved", uint(1), false, "reviewer").Return(db.TicketComment{ID: 1}, nil).Once()
+		rr = serve(http.MethodPost, "/ticket/comments/1/unresolve", nil, "reviewer")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that others can't resolve a thread and replies can't be resolved", func(t *testing.T) {
+		mockDb.On("GetTicketComment", uint(1)).Return(db.TicketComment{ID: 1, TicketId: "owner:1700000000", AuthorPubKey: "reviewer"}).Once()
+		rr := serve(http.MethodPost, "/ticket/comments/1/resolve", nil, "someone")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		root := uint(1)
+		mockDb.On("GetTicketComment", uint(2)).Return(db.TicketComment{ID: 2, TicketId: "owner:1700000000", ThreadId: &root}).Once()
+		rr = serve(http.MethodPost, "/ticket/comments/2/resolve", nil, "owner")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const maxTimeReportWeeks = 52
+
+// timerBounty returns the caller and the bounty of a timer request
+func (h *bountyHandler) timerBounty(w http.ResponseWriter, r *http.Request) (string, db.NewBounty, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[timer] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return "", db.NewBounty{}, false
+	}
+
+	bounty, ok := h.getBountyFromPath(w, r)
+	return pubKeyFromAuth, bounty, ok
+}
+
+// updateWorkSession moves the caller's active session on the bounty to the next status and publishes it
+func (h *bountyHandler) updateWorkSession(w http.ResponseWriter, bounty db.NewBounty, session db.BountyWorkSession, from db.WorkSessionStatus, added uint, msg string) {
+	session, err := h.db.UpdateWorkSession(session, from, added)
+	if err != nil {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	bounty.TimeSpent += added
+	publishBountyEvent(bounty, msg)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(session)
+}
+
+// StartBountyTimer starts a work session of the assignee on a bounty, or resumes the paused one
+func (h *bountyHandler) StartBountyTimer(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := h.timerBounty(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
+
+	if bounty.Assignee == "" || pubKeyFromAuth != bounty.Assignee {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the bounty assignee can track time on it")
+		return
+	}
+	if bounty.Paid {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Bounty has already been paid")
+		return
+	}
+
+	session := h.db.GetActiveWorkSession(bounty.ID, pubKeyFromAuth)
+	switch session.Status {
+	case db.WorkSessionRunning:
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("Timer is already running")
+		return
+	case db.WorkSessionPaused:
+		now := time.Now()
+		session.Status = db.WorkSessionRunning
+		session.LastStarted = &now
+		h.updateWorkSession(w, bounty, session, db.WorkSessionPaused, 0, "timer_started")
+		return
+	}
+
+	session, err := h.db.CreateWorkSession(db.BountyWorkSession{
+		BountyId:      bounty.ID,
+		WorkspaceUuid: bounty.WorkspaceUuid,
+		Hunter:        pubKeyFromAuth,
+	})
+	if err != nil {
+		log.Printf("[timer] could not start work session: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not start the timer")
+		return
+	}
+
+	publishBountyEvent(bounty, "timer_started")
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(session)
+}
+
+// PauseBountyTimer pauses the running work session of the caller, its time is added to the bounty
+func (h *bountyHandler) PauseBountyTimer(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := h.timerBounty(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
+
+	session := h.db.GetActiveWorkSession(bounty.ID, pubKeyFromAuth)
+	if session.Status != db.WorkSessionRunning {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Timer is not running")
+		return
+	}
+
+	elapsed := session.Elapsed(time.Now())
+	added := elapsed - session.Seconds
+	session.Status = db.WorkSessionPaused
+	session.Seconds = elapsed
+	h.updateWorkSession(w, bounty, session, db.WorkSessionRunning, added, "timer_paused")
+}
+
+// StopBountyTimer ends the running or paused work session of the caller
+func (h *bountyHandler) StopBountyTimer(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := h.timerBounty(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, bounty.WorkspaceUuid) {
+		return
+	}
+
+	session := h.db.GetActiveWorkSession(bounty.ID, pubKeyFromAuth)
+	if session.ID == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Timer is not started")
+		return
+	}
+
+	now := time.Now()
+	from := session.Status
+	elapsed := session.Elapsed(now)
+	added := elapsed - session.Seconds
+	session.Status = db.WorkSessionStopped
+	session.Seconds = elapsed
+	session.Stopped = &now
+	h.updateWorkSession(w, bounty, session, from, added, "timer_stopped")
+}
+
+// GetBountyTimeSpent returns the time spent on a bounty and its work sessions
+func (h *bountyHandler) GetBountyTimeSpent(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, bounty, ok := h.timerBounty(w, r)
+	if !ok {
+		return
+	}
+
+	sessions := h.db.GetBountyWorkSessions(bounty.ID)
+	canView := h.canViewBountyPayment(pubKeyFromAuth, bounty)
+	for _, session := range sessions {
+		canView = canView || session.Hunter == pubKeyFromAuth
+	}
+	if !canView {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have permission to view the time spent on this bounty")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.BountyTimeSpent{TimeSpent: bounty.TimeSpent, Sessions: sessions})
+}
+
+// GetWorkspaceTimeReport sums up the hours each hunter tracked per week on the workspace bounties,
+// over the last ?weeks= weeks including the current one
+func (h *bountyHandler) GetWorkspaceTimeReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[timer] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "workspace_uuid")
+	if !auth.AdminCheck(pubKeyFromAuth) && !h.userHasAccess(pubKeyFromAuth, uuid, db.ViewReport) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have appropriate permissions to view the reports of this workspace")
+		return
+	}
+
+	weeks := 4
+	if param := r.URL.Query().Get("weeks"); param != "" {
+		n, err := strconv.Atoi(param)
+		if err != nil || n < 1 || n > maxTimeReportWeeks {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(fmt.Sprintf("weeks must be between 1 and %d", maxTimeReportWeeks))
+			return
+		}
+		weeks = n
+	}
+
+	now := time.Now()
+	since := db.WeekStart(now).AddDate(0, 0, -7*(weeks-1))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.SummarizeWorkHours(h.db.GetWorkspaceWorkSessions(uuid, since), now))
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBountyTimer(t *testing.T) {
+	bounty := db.NewBounty{ID: 1, OwnerID: "owner", Assignee: "hunter", WorkspaceUuid: "workspace-uuid", TimeSpent: 600}
+
+	serve := func(pattern string, handler http.HandlerFunc, method string, pubkey string, url string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Method(method, pattern, handler)
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, url, nil)
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that the assignee starts a work session", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetActiveWorkSession", uint(1), "hunter").Return(db.BountyWorkSession{})
+		mockDb.On("CreateWorkSession", db.BountyWorkSession{BountyId: 1, WorkspaceUuid: "workspace-uuid", Hunter: "hunter"}).
+			Return(db.BountyWorkSession{ID: 5, Status: db.WorkSessionRunning}, nil)
+
+		rr := serve("/gobounties/{id}/timer/start", bHandler.StartBountyTimer, http.MethodPost, "hunter", "/gobounties/1/timer/start")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that only the assignee can start the timer", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+
+		rr := serve("/gobounties/{id}/timer/start", bHandler.StartBountyTimer, http.MethodPost, "owner", "/gobounties/1/timer/start")
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a running timer can't be started again", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetActiveWorkSession", uint(1), "hunter").Return(db.BountyWorkSession{ID: 5, Status: db.WorkSessionRunning})
+
+		rr := serve("/gobounties/{id}/timer/start", bHandler.StartBountyTimer, http.MethodPost, "hunter", "/gobounties/1/timer/start")
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Should test that pausing adds the run to the time spent", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		started := time.Now().Add(-10 * time.Minute)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetActiveWorkSession", uint(1), "hunter").Return(db.BountyWorkSession{ID: 5, BountyId: 1, Status: db.WorkSessionRunning, Seconds: 60, LastStarted: &started})
+		mockDb.On("UpdateWorkSession", mock.MatchedBy(func(s db.BountyWorkSession) bool {
+			return s.Status == db.WorkSessionPaused && s.Seconds >= 660 && s.Seconds < 670
+		}), db.WorkSessionRunning, mock.MatchedBy(func(added uint) bool {
+			return added >= 600 && added < 610
+		})).Return(db.BountyWorkSession{ID: 5, Status: db.WorkSessionPaused}, nil)
+
+		rr := serve("/gobounties/{id}/timer/pause", bHandler.PauseBountyTimer, http.MethodPost, "hunter", "/gobounties/1/timer/pause")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that stopping a paused session adds no time", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(bounty)
+		mockDb.On("GetActiveWorkSession", uint(1), "hunter").Return(db.BountyWorkSession{ID: 5, BountyId: 1, Status: db.WorkSessionPaused, Seconds: 600})
+		mockDb.On("UpdateWorkSession", mock.MatchedBy(func(s db.BountyWorkSession) bool {
+			return s.Status == db.WorkSessionStopped && s.Seconds == 600 && s.Stopped != nil
+		}), db.WorkSessionPaused, uint(0)).Return(db.BountyWorkSession{ID: 5, Status: db.WorkSessionStopped}, nil)
+
+		rr := serve("/gobounties/{id}/timer/stop", bHandler.StopBountyTimer, http.MethodPost, "hunter", "/gobounties/1/timer/stop")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that the workspace report sums up hours per hunter per week", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return role == db.ViewReport }
+		started := db.WeekStart(time.Now()).Add(time.Hour)
+		mockDb.On("GetWorkspaceWorkSessions", "workspace-uuid", mock.MatchedBy(func(since time.Time) bool {
+			return since.Equal(db.WeekStart(time.Now()).AddDate(0, 0, -7))
+		})).Return([]db.BountyWorkSession{
+			{Hunter: "hunter", Status: db.WorkSessionStopped, Seconds: 7200, Started: &started},
+		})
+
+		rr := serve("/workspaces/{workspace_uuid}/time-report", bHandler.GetWorkspaceTimeReport, http.MethodGet, "admin", "/workspaces/workspace-uuid/time-report?weeks=2")
+
+		weeks := []db.WeeklyWorkHours{}
+		json.Unmarshal(rr.Body.Bytes(), &weeks)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, weeks, 1)
+		assert.Equal(t, 2.0, weeks[0].Hours)
+	})
+
+	t.Run("Should test that the workspace report needs the view report role", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bHandler := NewBountyHandler(nil, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+
+		rr := serve("/workspaces/{workspace_uuid}/time-report", bHandler.GetWorkspaceTimeReport, http.MethodGet, "hunter", "/workspaces/workspace-uuid/time-report")
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+type TribeActivityRequest struct {
+	// the messages sent in the tribe since the last report
+	Messages int64 `json:"messages"`
+}
+
+// GetTribeAnalytics returns the member growth, message activity, bounty volume and badge changes
+// of a tribe per ?interval= day or week between the ?start_date= and ?end_date= unix timestamps
+func (th *tribeHandler) GetTribeAnalytics(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	interval, from, to, ok := metricsWindow(w, r.URL.Query())
+	if !ok {
+		return
+	}
+
+	membersBefore := th.db.GetTribeMembersBefore(tribe.UUID, db.BucketStart(from, interval))
+	values := th.db.GetTribeSeriesValues(tribe.UUID, db.BucketStart(from, interval), to, interval)
+
+	badges := []string(tribe.Badges)
+	if badges == nil {
+		badges = []string{}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.TribeAnalytics{
+		TribeUuid:   tribe.UUID,
+		Interval:    interval,
+		From:        from.Format(time.RFC3339),
+		To:          to.Format(time.RFC3339),
+		MemberCount: tribe.MemberCount,
+		Badges:      badges,
+		Points:      db.BuildTribeAnalytics(from, to, interval, membersBefore, values),
+	})
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTribeAnalytics(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	tHandler := NewTribeHandler(mockDb)
+	tribe := db.Tribe{UUID: "tribe", OwnerPubKey: "owner", MemberCount: 12}
+
+	analytics := func(query string, pubkey string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Get("/tribes/{uuid}/analytics", tHandler.GetTribeAnalytics)
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/tribes/tribe/analytics"+query, nil)
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that only the tribe owner can see its analytics", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		rr := analytics("", "someone")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that an unknown interval is rejected", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		rr := analytics("?interval=month", "owner")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the owner gets one point per day", func(t *testing.T) {
+		start := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("GetTribeMembersBefore", "tribe", start).Return(int64(10)).Once()
+		mockDb.On("GetTribeSeriesValues", "tribe", start, end, db.MetricsDaily).Return([]db.MetricsSeriesValue{
+			{Series: "new_members", Bucket: start, Value: 2},
+		}).Once()
+
+		rr := analytics("?start_date=1709510400&end_date=1710028800", "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		res := db.TribeAnalytics{}
+		json.Unmarshal(rr.Body.Bytes(), &res)
+		assert.Equal(t, uint64(12), res.MemberCount)
+		assert.Len(t, res.Points, 7)
+		assert.Equal(t, int64(12), res.Points[6].Members)
+		mockDb.AssertCalled(t, "GetTribeSeriesValues", "tribe", start, end, mock.Anything)
+	})
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+)
+
+type TribeInviteRequest struct {
+	Label   string `json:"label"`
+	MaxUses uint   `json:"max_uses"`
+	// how long the invite can be redeemed such as "168h", an invite without duration does not expire
+	Duration string `json:"duration"`
+}
+
+type RedeemTribeInviteRequest struct {
+	Alias string `json:"alias"`
+}
+
+type RedeemTribeInviteResponse struct {
+	Tribe      db.Tribe                 `json:"tribe"`
+	Redemption db.TribeInviteRedemption `json:"redemption"`
+}
+
+// tribeInviteFromUrl returns the invite of the {code} param when it belongs to the tribe
+func (th *tribeHandler) tribeInviteFromUrl(w http.ResponseWriter, r *http.Request, tribe db.Tribe) (db.TribeInvite, bool) {
+	invite := th.db.GetTribeInvite(chi.URLParam(r, "code"))
+	if invite.ID == 0 || invite.TribeUuid != tribe.UUID {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Invite not found")
+		return db.TribeInvite{}, false
+	}
+	return invite, true
+}
+
+// CreateTribeInvite generates a shareable invite code for a tribe with an optional limit of uses
+// and expiry
+func (th *tribeHandler) CreateTribeInvite(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	request := TribeInviteRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	invite := db.TribeInvite{
+		TribeUuid: tribe.UUID,
+		CreatedBy: tribe.OwnerPubKey,
+		Label:     request.Label,
+		MaxUses:   request.MaxUses,
+	}
+	if request.Duration != "" {
+		duration, err := time.ParseDuration(request.Duration)
+		if err != nil || duration <= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("The duration must be positive, such as 168h")
+			return
+		}
+		expiresAt := time.Now().Add(duration)
+		invite.ExpiresAt = &expiresAt
+	}
+
+	invite, err := th.db.CreateTribeInvite(invite)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not create the invite")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(invite)
+}
+
+func (th *tribeHandler) GetTribeInvites(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(th.db.GetTribeInvites(tribe.UUID))
+}
+
+// GetTribeInviteAnalytics returns an invite with who redeemed it
+func (th *tribeHandler) GetTribeInviteAnalytics(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+	invite, ok := th.tribeInviteFromUrl(w, r, tribe)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.TribeInviteAnalytics{
+		Invite:      invite,
+		Redemptions: th.db.GetTribeInviteRedemptions(invite.ID),
+	})
+}
+
+// RevokeTribeInvite stops an invite from being redeemed, its analytics are kept
+func (th *tribeHandler) RevokeTribeInvite(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+	invite, ok := th.tribeInviteFromUrl(w, r, tribe)
+	if !ok {
+		return
+	}
+
+	if err := th.db.RevokeTribeInvite(invite.Code); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not revoke the invite")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// RedeemTribeInvite adds the user to the tribe of an invite, banned pubkeys can't redeem one
+func (th *tribeHandler) RedeemTribeInvite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tribes] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := RedeemTribeInviteRequest{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+	}
+
+	invite := th.db.GetTribeInvite(chi.URLParam(r, "code"))
+	if invite.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Invite not found")
+		return
+	}
+	if err := db.TribeInviteError(invite, time.Now()); err != nil {
+		w.WriteHeader(http.StatusGone)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	tribe := th.db.GetTribe(invite.TribeUuid)
+	if tribe.UUID == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Tribe not found")
+		return
+	}
+	if th.db.GetTribeBan(tribe.UUID, pubKeyFromAuth).ID != 0 {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("You are banned from this tribe")
+		return
+	}
+
+	redemption, err := th.db.RedeemTribeInvite(invite, pubKeyFromAuth, request.Alias)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrTribeInviteRedeemed):
+			w.WriteHeader(http.StatusConflict)
+		case errors.Is(err, db.ErrTribeInviteRevoked), errors.Is(err, db.ErrTribeInviteExpired), errors.Is(err, db.ErrTribeInviteUsedUp):
+			w.WriteHeader(http.StatusGone)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode("Could not redeem the invite")
+			return
+		}
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	events.Publish(events.TribeJoined, "", map[string]interface{}{
+		"tribe_uuid":    tribe.UUID,
+		"member_pubkey": pubKeyFromAuth,
+		"alias":         request.Alias,
+		"invite_code":   invite.Code,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(RedeemTribeInviteResponse{
+		Tribe:      tribe,
+		Redemption: redemption,
+	})
+}
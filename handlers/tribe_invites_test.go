@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTribeInvites(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	tHandler := NewTribeHandler(mockDb)
+	tribe := db.Tribe{UUID: "tribe", OwnerPubKey: "owner"}
+
+	serve := func(method string, path string, body string, pubkey string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Post("/tribes/{uuid}/invites", tHandler.CreateTribeInvite)
+		ro.Post("/tribes/invites/{code}/redeem", tHandler.RedeemTribeInvite)
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, path, bytes.NewBufferString(body))
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that only the tribe owner can create invites", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		rr := serve(http.MethodPost, "/tribes/tribe/invites", `{"max_uses": 10}`, "someone")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that the owner can create an invite with limits", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("CreateTribeInvite", mock.MatchedBy(func(invite db.TribeInvite) bool {
+			return invite.TribeUuid == "tribe" && invite.MaxUses == 10 && invite.ExpiresAt != nil
+		})).Return(db.TribeInvite{ID: 1, Code: "code", TribeUuid: "tribe", MaxUses: 10}, nil).Once()
+		rr := serve(http.MethodPost, "/tribes/tribe/invites", `{"max_uses": 10, "duration": "168h"}`, "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a used up invite can't be redeemed", func(t *testing.T) {
+		mockDb.On("GetTribeInvite", "code").Return(db.TribeInvite{ID: 1, Code: "code", TribeUuid: "tribe", MaxUses: 1, Uses: 1}).Once()
+		rr := serve(http.MethodPost, "/tribes/invites/code/redeem", "", "alice")
+		assert.Equal(t, http.StatusGone, rr.Code)
+	})
+
+	t.Run("Should test that a banned pubkey can't redeem an invite", func(t *testing.T) {
+		mockDb.On("GetTribeInvite", "code").Return(db.TribeInvite{ID: 1, Code: "code", TribeUuid: "tribe"}).Once()
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("GetTribeBan", "tribe", "spammer").Return(db.TribeBan{ID: 1}).Once()
+		rr := serve(http.MethodPost, "/tribes/invites/code/redeem", "", "spammer")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("Should test that redeeming an invite twice is a conflict", func(t *testing.T) {
+		invite := db.TribeInvite{ID: 1, Code: "code", TribeUuid: "tribe"}
+		mockDb.On("GetTribeInvite", "code").Return(invite).Once()
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("GetTribeBan", "tribe", "alice").Return(db.TribeBan{}).Once()
+		mockDb.On("RedeemTribeInvite", invite, "alice", "").Return(db.TribeInviteRedemption{}, db.ErrTribeInviteRedeemed).Once()
+		rr := serve(http.MethodPost, "/tribes/invites/code/redeem", "", "alice")
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Should test that a valid invite adds the user to the tribe", func(t *testing.T) {
+		invite := db.TribeInvite{ID: 1, Code: "code", TribeUuid: "tribe"}
+		mockDb.On("GetTribeInvite", "code").Return(invite).Once()
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("GetTribeBan", "tribe", "alice").Return(db.TribeBan{}).Once()
+		mockDb.On("RedeemTribeInvite", invite, "alice", "Alice").Return(db.TribeInviteRedemption{ID: 1, InviteId: 1, PubKey: "alice"}, nil).Once()
+		rr := serve(http.MethodPost, "/tribes/invites/code/redeem", `{"alias": "Alice"}`, "alice")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
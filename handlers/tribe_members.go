@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+)
+
+type TribeMemberSync struct {
+	PubKey   string     `json:"member_pubkey"`
+	Alias    string     `json:"alias"`
+	JoinedAt *time.Time `json:"joined_at"`
+}
+
+type TribeMembersSyncRequest struct {
+	Members []TribeMemberSync `json:"members"`
+}
+
+type TribeBanRequest struct {
+	PubKey string `json:"member_pubkey"`
+	Reason string `json:"reason"`
+	// how long the ban lasts such as "72h", a ban without duration is permanent
+	Duration string `json:"duration"`
+}
+
+// ownedTribeFromUrl returns the tribe of the {uuid} param when the user owns it
+func (th *tribeHandler) ownedTribeFromUrl(w http.ResponseWriter, r *http.Request) (db.Tribe, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tribes] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return db.Tribe{}, false
+	}
+
+	tribe := th.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Tribe not found")
+		return db.Tribe{}, false
+	}
+	if tribe.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the owner of the tribe can moderate it")
+		return db.Tribe{}, false
+	}
+	return tribe, true
+}
+
+// SyncTribeMembers replaces the roster of a tribe with the members its relay reports, banned
+// pubkeys are left out. Members new to the roster are published as tribe.joined events
+func (th *tribeHandler) SyncTribeMembers(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	request := TribeMembersSyncRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	banned := map[string]bool{}
+	for _, ban := range th.db.GetTribeBans(tribe.UUID) {
+		banned[ban.MemberPubKey] = true
+	}
+	members := []db.TribeMember{}
+	seen := map[string]bool{}
+	for _, m := range request.Members {
+		if m.PubKey == "" || banned[m.PubKey] || seen[m.PubKey] {
+			continue
+		}
+		seen[m.PubKey] = true
+		members = append(members, db.TribeMember{MemberPubKey: m.PubKey, Alias: m.Alias, JoinedAt: m.JoinedAt})
+	}
+
+	joined, err := th.db.SyncTribeMembers(tribe.UUID, members)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not sync the members")
+		return
+	}
+	for _, m := range joined {
+		events.Publish(events.TribeJoined, "", map[string]interface{}{
+			"tribe_uuid":    tribe.UUID,
+			"member_pubkey": m.MemberPubKey,
+			"alias":         m.Alias,
+			"member_count":  len(members),
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(th.db.GetTribeMembers(tribe.UUID))
+}
+
+// GetTribeMembers lists the roster of a tribe to its owner and its members
+func (th *tribeHandler) GetTribeMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tribes] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	tribe := th.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Tribe not found")
+		return
+	}
+	if tribe.OwnerPubKey != pubKeyFromAuth && th.db.GetTribeMember(tribe.UUID, pubKeyFromAuth).ID == 0 {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(th.db.GetTribeMembers(tribe.UUID))
+}
+
+// KickTribeMember takes a member off the roster, they can join again unlike a banned one
+func (th *tribeHandler) KickTribeMember(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	pubkey := chi.URLParam(r, "pubkey")
+	if th.db.GetTribeMember(tribe.UUID, pubkey).ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Not a member of the tribe")
+		return
+	}
+	if err := th.db.DeleteTribeMember(tribe.UUID, pubkey); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not kick the member")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// BanTribeMember bans a pubkey from a tribe for a duration or for good, the relay refuses it
+// when it checks the membership
+func (th *tribeHandler) BanTribeMember(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	request := TribeBanRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if request.PubKey == "" || request.PubKey == tribe.OwnerPubKey {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Give the pubkey of a member to ban")
+		return
+	}
+
+	ban := db.TribeBan{
+		TribeUuid:    tribe.UUID,
+		MemberPubKey: request.PubKey,
+		Reason:       request.Reason,
+		BannedBy:     tribe.OwnerPubKey,
+	}
+	if request.Duration != "" {
+		duration, err := time.ParseDuration(request.Duration)
+		if err != nil || duration <= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("The duration must be positive, such as 72h")
+			return
+		}
+		expiresAt := time.Now().Add(duration)
+		ban.ExpiresAt = &expiresAt
+	}
+
+	ban, err := th.db.BanTribeMember(ban)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not ban the member")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ban)
+}
+
+// GetTribeBans lists the bans of a tribe that have not expired
+func (th *tribeHandler) GetTribeBans(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(th.db.GetTribeBans(tribe.UUID))
+}
+
+func (th *tribeHandler) UnbanTribeMember(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	if err := th.db.DeleteTribeBan(tribe.UUID, chi.URLParam(r, "pubkey")); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not remove the ban")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// GetTribeMembership tells the relay whether a pubkey may be in a tribe, a banned one is not
+func (th *tribeHandler) GetTribeMembership(w http.ResponseWriter, r *http.Request) {
+	tribe := th.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Tribe not found")
+		return
+	}
+
+	pubkey := chi.URLParam(r, "pubkey")
+	membership := db.TribeMembership{
+		TribeUuid: tribe.UUID,
+		PubKey:    pubkey,
+		Member:    th.db.GetTribeMember(tribe.UUID, pubkey).ID != 0,
+		Allowed:   true,
+	}
+	if ban := th.db.GetTribeBan(tribe.UUID, pubkey); ban.ID != 0 {
+		membership.Member = false
+		membership.Allowed = false
+		membership.Banned = true
+		membership.Reason = ban.Reason
+		membership.ExpiresAt = ban.ExpiresAt
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(membership)
+}
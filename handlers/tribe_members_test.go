@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTribeModeration(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	tHandler := NewTribeHandler(mockDb)
+	tribe := db.Tribe{UUID: "tribe", OwnerPubKey: "owner"}
+
+	serve := func(method string, path string, body string, pubkey string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Put("/tribes/{uuid}/members", tHandler.SyncTribeMembers)
+		ro.Post("/tribes/{uuid}/members/{pubkey}/kick", tHandler.KickTribeMember)
+		ro.Post("/tribes/{uuid}/bans", tHandler.BanTribeMember)
+		ro.Get("/tribes/{uuid}/membership/{pubkey}", tHandler.GetTribeMembership)
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, path, bytes.NewBufferString(body))
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that only the tribe owner can ban a member", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		rr := serve(http.MethodPost, "/tribes/tribe/bans", `{"member_pubkey": "spammer"}`, "someone")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a ban needs a valid duration", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		rr := serve(http.MethodPost, "/tribes/tribe/bans", `{"member_pubkey": "spammer", "duration": "soon"}`, "owner")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the owner can ban a member for a while", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("BanTribeMember", mock.MatchedBy(func(ban db.TribeBan) bool {
+			return ban.MemberPubKey == "spammer" && ban.Reason == "spam" && ban.ExpiresAt != nil
+		})).Return(db.TribeBan{ID: 1, TribeUuid: "tribe", MemberPubKey: "spammer", Reason: "spam"}, nil).Once()
+		rr := serve(http.MethodPost, "/tribes/tribe/bans", `{"member_pubkey": "spammer", "reason": "spam", "duration": "72h"}`, "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that banned pubkeys are left out of a sync", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("GetTribeBans", "tribe").Return([]db.TribeBan{{MemberPubKey: "spammer"}}).Once()
+		mockDb.On("SyncTribeMembers", "tribe", mock.MatchedBy(func(members []db.TribeMember) bool {
+			return len(members) == 1 && members[0].MemberPubKey == "alice"
+		})).Return([]db.TribeMember{}, nil).Once()
+		mockDb.On("GetTribeMembers", "tribe").Return([]db.TribeMember{{MemberPubKey: "alice"}}).Once()
+		rr := serve(http.MethodPut, "/tribes/tribe/members", `{"members": [{"member_pubkey": "alice"}, {"member_pubkey": "spammer"}, {"member_pubkey": "alice"}]}`, "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that kicking someone not in the tribe returns 404", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("GetTribeMember", "tribe", "bob").Return(db.TribeMember{}).Once()
+		rr := serve(http.MethodPost, "/tribes/tribe/members/bob/kick", "", "owner")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Should test that a banned pubkey is not allowed in the tribe", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("GetTribeMember", "tribe", "spammer").Return(db.TribeMember{}).Once()
+		mockDb.On("GetTribeBan", "tribe", "spammer").Return(db.TribeBan{ID: 1, Reason: "spam"}).Once()
+		rr := serve(http.MethodGet, "/tribes/tribe/membership/spammer", "", "")
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		membership := db.TribeMembership{}
+		json.Unmarshal(rr.Body.Bytes(), &membership)
+		assert.False(t, membership.Allowed)
+		assert.True(t, membership.Banned)
+		assert.Equal(t, "spam", membership.Reason)
+	})
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+type TribeFeaturedRequest struct {
+	Featured bool `json:"featured"`
+	Position int  `json:"position"`
+}
+
+type TribeFeaturedOrderRequest struct {
+	Uuids []string `json:"uuids"`
+}
+
+func (th *tribeHandler) GetFeaturedTribes(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(th.db.GetFeaturedTribes())
+}
+
+// SetTribeFeatured pins a tribe to the home directory at a position or takes it off
+func (th *tribeHandler) SetTribeFeatured(w http.ResponseWriter, r *http.Request) {
+	tribe := th.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Tribe not found")
+		return
+	}
+
+	request := TribeFeaturedRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if request.Position < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The position can't be negative")
+		return
+	}
+
+	if err := th.db.SetTribeFeatured(tribe.UUID, request.Featured, request.Position); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not update the tribe")
+		return
+	}
+	tribe.Featured = request.Featured
+	tribe.FeaturedPosition = 0
+	if request.Featured {
+		tribe.FeaturedPosition = request.Position
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tribe)
+}
+
+// ReorderFeaturedTribes orders the featured tribes as the uuids are given
+func (th *tribeHandler) ReorderFeaturedTribes(w http.ResponseWriter, r *http.Request) {
+	request := TribeFeaturedOrderRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	featured := map[string]bool{}
+	for _, tribe := range th.db.GetFeaturedTribes() {
+		featured[tribe.UUID] = true
+	}
+	seen := map[string]bool{}
+	for _, uuid := range request.Uuids {
+		if !featured[uuid] || seen[uuid] {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("Give each featured tribe once")
+			return
+		}
+		seen[uuid] = true
+	}
+
+	if err := th.db.ReorderFeaturedTribes(request.Uuids); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not reorder the tribes")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(th.db.GetFeaturedTribes())
+}
+
+func (th *tribeHandler) GetTribeRankingSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(th.db.GetTribeRankingSettings())
+}
+
+// UpdateTribeRankingSettings sets the weights of the recency, member count and activity of the
+// tribes in the ranking of the directory
+func (th *tribeHandler) UpdateTribeRankingSettings(w http.ResponseWriter, r *http.Request) {
+	settings := db.TribeRankingSettings{}
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if settings.RecencyWeight < 0 || settings.MemberWeight < 0 || settings.ActivityWeight < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The weights can't be negative")
+		return
+	}
+
+	settings, err := th.db.UpdateTribeRankingSettings(settings)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save the ranking")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(settings)
+}
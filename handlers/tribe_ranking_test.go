@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeaturedTribes(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	tHandler := NewTribeHandler(mockDb)
+
+	serve := func(method string, path string, body string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Put("/admin/tribes/{uuid}/featured", tHandler.SetTribeFeatured)
+		ro.Post("/admin/tribes/featured/reorder", tHandler.ReorderFeaturedTribes)
+		ro.Put("/admin/tribes/ranking", tHandler.UpdateTribeRankingSettings)
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that an unknown tribe can't be featured", func(t *testing.T) {
+		mockDb.On("GetTribe", "missing").Return(db.Tribe{}).Once()
+		rr := serve(http.MethodPut, "/admin/tribes/missing/featured", `{"featured": true}`)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Should test that a tribe is featured at a position", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(db.Tribe{UUID: "tribe"}).Once()
+		mockDb.On("SetTribeFeatured", "tribe", true, 2).Return(nil).Once()
+		rr := serve(http.MethodPut, "/admin/tribes/tribe/featured", `{"featured": true, "position": 2}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that only featured tribes can be reordered", func(t *testing.T) {
+		mockDb.On("GetFeaturedTribes").Return([]db.Tribe{{UUID: "a"}, {UUID: "b"}}).Once()
+		rr := serve(http.MethodPost, "/admin/tribes/featured/reorder", `{"uuids": ["b", "c"]}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the featured tribes are reordered", func(t *testing.T) {
+		mockDb.On("GetFeaturedTribes").Return([]db.Tribe{{UUID: "a"}, {UUID: "b"}}).Once()
+		mockDb.On("ReorderFeaturedTribes", []string{"b", "a"}).Return(nil).Once()
+		mockDb.On("GetFeaturedTribes").Return([]db.Tribe{{UUID: "b"}, {UUID: "a"}}).Once()
+		rr := serve(http.MethodPost, "/admin/tribes/featured/reorder", `{"uuids": ["b", "a"]}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that negative ranking weights are rejected", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/admin/tribes/ranking", `{"recency_weight": -1}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
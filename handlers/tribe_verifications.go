@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// TribeVerificationFile is where a domain can serve the token of a claim instead of a TXT record
+const TribeVerificationFile = "/.well-known/sphinx-tribe-verification.txt"
+
+const (
+	tribeVerificationTimeout = 10 * time.Second
+	tribeVerificationMaxBody = 64 * 1024
+)
+
+var (
+	tribeVerificationDomainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+	tribeVerificationNip05Pattern  = regexp.MustCompile(`^[a-z0-9._-]+$`)
+	tribeVerificationGithubPattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`)
+)
+
+type TribeVerificationRequest struct {
+	Method  db.TribeVerificationMethod `json:"method"`
+	Subject string                     `json:"subject"`
+}
+
+type TribeVerificationReviewRequest struct {
+	Approve bool   `json:"approve"`
+	Note    string `json:"note"`
+}
+
+// splitNip05 splits a NIP-05 identifier in its name and domain, a bare domain is its "_" name
+func splitNip05(subject string) (string, string) {
+	if i := strings.LastIndex(subject, "@"); i >= 0 {
+		return subject[:i], subject[i+1:]
+	}
+	return "_", subject
+}
+
+func validateTribeVerification(request TribeVerificationRequest) error {
+	switch request.Method {
+	case db.TribeVerificationDomain:
+		if !tribeVerificationDomainPattern.MatchString(request.Subject) {
+			return errors.New("give a domain such as example.com")
+		}
+	case db.TribeVerificationNostr:
+		name, domain := splitNip05(request.Subject)
+		if !tribeVerificationNip05Pattern.MatchString(name) || !tribeVerificationDomainPattern.MatchString(domain) {
+			return errors.New("give a NIP-05 identifier such as name@example.com")
+		}
+	case db.TribeVerificationGithub:
+		if !tribeVerificationGithubPattern.MatchString(request.Subject) {
+			return errors.New("give a GitHub username")
+		}
+	default:
+		return errors.New("the method must be domain, nostr or github")
+	}
+	return nil
+}
+
+// tribeVerificationFromUrl returns the claim of the {verification_uuid} param when it belongs to the tribe
+func (th *tribeHandler) tribeVerificationFromUrl(w http.ResponseWriter, r *http.Request, tribe db.Tribe) (db.TribeVerification, bool) {
+	verification := th.db.GetTribeVerification(chi.URLParam(r, "verification_uuid"))
+	if verification.ID == 0 || verification.TribeUuid != tribe.UUID {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Verification not found")
+		return db.TribeVerification{}, false
+	}
+	return verification, true
+}
+
+// fetchVerification gets a small document a claim is proven with
+func (th *tribeHandler) fetchVerification(target string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tribeVerificationTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := th.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s responded %d", target, response.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(response.Body, tribeVerificationMaxBody))
+}
+
+// checkTribeVerification returns why a claim is not proven, or nil when it is
+func (th *tribeHandler) checkTribeVerification(tribe db.Tribe, verification db.TribeVerification) error {
+	switch verification.Method {
+	case db.TribeVerificationDomain:
+		records, _ := th.lookupTXT(verification.Subject)
+		for _, record := range records {
+			if strings.TrimSpace(record) == verification.Token {
+				return nil
+			}
+		}
+		body, err := th.fetchVerification("https://" + verification.Subject + TribeVerificationFile)
+		if err == nil && strings.Contains(string(body), verification.Token) {
+			return nil
+		}
+		return fmt.Errorf("the token was not found in a TXT record of %s nor in %s", verification.Subject, TribeVerificationFile)
+
+	case db.TribeVerificationNostr:
+		name, domain := splitNip05(verification.Subject)
+		body, err := th.fetchVerification("https://" + domain + "/.well-known/nostr.json?name=" + url.QueryEscape(name))
+		if err != nil {
+			return err
+		}
+		nip05 := struct {
+			Names map[string]string `json:"names"`
+		}{}
+		if err := json.Unmarshal(body, &nip05); err != nil {
+			return errors.New("nostr.json is not valid")
+		}
+		nostrPubkey := nip05.Names[name]
+		if nostrPubkey == "" {
+			return fmt.Errorf("%s is not in nostr.json", verification.Subject)
+		}
+		identity, err := th.db.GetNostrIdentity(nostrPubkey)
+		if err != nil || identity.OwnerPubKey != tribe.OwnerPubKey {
+			return errors.New("the nostr key of the identifier is not linked to the owner of the tribe")
+		}
+		return nil
+
+	case db.TribeVerificationGithub:
+		pubkey, err := th.githubPubkey(verification.Subject)
+		if err != nil || pubkey != tribe.OwnerPubKey {
+			return errors.New("no Sphinx Verification gist of the user is signed by the owner of the tribe")
+		}
+		return nil
+	}
+	return errors.New("unknown method")
+}
+
+// CreateTribeVerification starts a claim of a tribe to a domain, a NIP-05 identifier or a GitHub
+// user. A domain is proven with the returned token, published before the claim is checked
+func (th *tribeHandler) CreateTribeVerification(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	request := TribeVerificationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if request.Method != db.TribeVerificationGithub {
+		request.Subject = strings.ToLower(strings.TrimSpace(request.Subject))
+	}
+	if err := validateTribeVerification(request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	verification, err := th.db.CreateTribeVerification(db.TribeVerification{
+		TribeUuid: tribe.UUID,
+		Method:    request.Method,
+		Subject:   request.Subject,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not start the verification")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(verification)
+}
+
+func (th *tribeHandler) GetTribeVerifications(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(th.db.GetTribeVerifications(tribe.UUID))
+}
+
+// CheckTribeVerification checks the proof of a claim, the tribe is verified when it holds
+func (th *tribeHandler) CheckTribeVerification(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+	verification, ok := th.tribeVerificationFromUrl(w, r, tribe)
+	if !ok {
+		return
+	}
+	if verification.Status == db.TribeVerificationVerified || verification.Status == db.TribeVerificationRejected {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The verification is already " + string(verification.Status))
+		return
+	}
+
+	if err := th.checkTribeVerification(tribe, verification); err != nil {
+		verification.Error = err.Error()
+	} else {
+		verification.Status = db.TribeVerificationVerified
+		verification.Error = ""
+	}
+
+	verification, err := th.db.UpdateTribeVerification(verification)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save the verification")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(verification)
+}
+
+// RequestTribeVerificationReview puts a claim that could not be checked in the queue of the admins
+func (th *tribeHandler) RequestTribeVerificationReview(w http.ResponseWriter, r *http.Request) {
+	tribe, ok := th.ownedTribeFromUrl(w, r)
+	if !ok {
+		return
+	}
+	verification, ok := th.tribeVerificationFromUrl(w, r, tribe)
+	if !ok {
+		return
+	}
+	if verification.Status != db.TribeVerificationPending {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Only a pending verification can be reviewed")
+		return
+	}
+
+	verification.Status = db.TribeVerificationNeedsReview
+	verification, err := th.db.UpdateTribeVerification(verification)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save the verification")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(verification)
+}
+
+// GetTribeVerificationQueue lists the claims in a ?status=, those waiting for a review by default
+func (th *tribeHandler) GetTribeVerificationQueue(w http.ResponseWriter, r *http.Request) {
+	status := db.TribeVerificationStatus(r.URL.Query().Get("status"))
+	if status == "" {
+		status = db.TribeVerificationNeedsReview
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(th.db.GetTribeVerificationsByStatus(status))
+}
+
+// ReviewTribeVerification approves or rejects a claim by hand, rejecting a verified claim takes
+// the badge away unless the tribe has another verified claim
+func (th *tribeHandler) ReviewTribeVerification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	verification := th.db.GetTribeVerification(chi.URLParam(r, "verification_uuid"))
+	if verification.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Verification not found")
+		return
+	}
+
+	request := TribeVerificationReviewRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	verification.Status = db.TribeVerificationRejected
+	if request.Approve {
+		verification.Status = db.TribeVerificationVerified
+		verification.Error = ""
+	}
+	verification.ReviewedBy = pubKeyFromAuth
+	verification.Note = request.Note
+
+	verification, err := th.db.UpdateTribeVerification(verification)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save the verification")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(verification)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	httpMocks "github.com/stakwork/sphinx-tribes/handlers/mocks"
+)
+
+func TestTribeVerifications(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	mockHttpClient := httpMocks.NewHttpClient(t)
+	tHandler := NewTribeHandler(mockDb)
+	tHandler.httpClient = mockHttpClient
+	tHandler.lookupTXT = func(name string) ([]string, error) {
+		return nil, errors.New("no records")
+	}
+	tribe := db.Tribe{UUID: "tribe", OwnerPubKey: "owner"}
+
+	serve := func(method string, path string, body string, pubkey string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Post("/tribes/{uuid}/verifications", tHandler.CreateTribeVerification)
+		ro.Post("/tribes/{uuid}/verifications/{verification_uuid}/check", tHandler.CheckTribeVerification)
+		ro.Post("/admin/tribes/verifications/{verification_uuid}", tHandler.ReviewTribeVerification)
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, method, path, bytes.NewBufferString(body))
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+	respond := func(status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewBufferString(body))}
+	}
+
+	t.Run("Should test that an invalid domain is rejected", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		rr := serve(http.MethodPost, "/tribes/tribe/verifications", `{"method": "domain", "subject": "https://example.com"}`, "owner")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that the owner can claim a domain", func(t *testing.T) {
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("CreateTribeVerification", db.TribeVerification{TribeUuid: "tribe", Method: db.TribeVerificationDomain, Subject: "example.com"}).
+			Return(db.TribeVerification{Uuid: "v1", TribeUuid: "tribe", Token: "token", Status: db.TribeVerificationPending}, nil).Once()
+		rr := serve(http.MethodPost, "/tribes/tribe/verifications", `{"method": "domain", "subject": " Example.com "}`, "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a domain serving the token is verified", func(t *testing.T) {
+		verification := db.TribeVerification{ID: 1, Uuid: "v1", TribeUuid: "tribe", Method: db.TribeVerificationDomain, Subject: "example.com", Token: "sphinx-tribe-verification=abc", Status: db.TribeVerificationPending}
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("GetTribeVerification", "v1").Return(verification).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "https://example.com"+TribeVerificationFile
+		})).Return(respond(http.StatusOK, "sphinx-tribe-verification=abc\n"), nil).Once()
+		mockDb.On("UpdateTribeVerification", mock.MatchedBy(func(v db.TribeVerification) bool {
+			return v.Status == db.TribeVerificationVerified
+		})).Return(db.TribeVerification{Uuid: "v1", Status: db.TribeVerificationVerified}, nil).Once()
+		rr := serve(http.MethodPost, "/tribes/tribe/verifications/v1/check", "", "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a NIP-05 key not linked to the owner stays pending", func(t *testing.T) {
+		verification := db.TribeVerification{ID: 2, Uuid: "v2", TribeUuid: "tribe", Method: db.TribeVerificationNostr, Subject: "alice@example.com", Status: db.TribeVerificationPending}
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("GetTribeVerification", "v2").Return(verification).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "https://example.com/.well-known/nostr.json?name=alice"
+		})).Return(respond(http.StatusOK, `{"names": {"alice": "npubhex"}}`), nil).Once()
+		mockDb.On("GetNostrIdentity", "npubhex").Return(db.NostrIdentity{OwnerPubKey: "someone"}, nil).Once()
+		mockDb.On("UpdateTribeVerification", mock.MatchedBy(func(v db.TribeVerification) bool {
+			return v.Status == db.TribeVerificationPending && v.Error != ""
+		})).Return(db.TribeVerification{Uuid: "v2", Status: db.TribeVerificationPending}, nil).Once()
+		rr := serve(http.MethodPost, "/tribes/tribe/verifications/v2/check", "", "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a GitHub gist signed by the owner is verified", func(t *testing.T) {
+		tHandler.githubPubkey = func(username string) (string, error) {
+			return "owner", nil
+		}
+		verification := db.TribeVerification{ID: 3, Uuid: "v3", TribeUuid: "tribe", Method: db.TribeVerificationGithub, Subject: "alice", Status: db.TribeVerificationPending}
+		mockDb.On("GetTribe", "tribe").Return(tribe).Once()
+		mockDb.On("GetTribeVerification", "v3").Return(verification).Once()
+		mockDb.On("UpdateTribeVerification", mock.MatchedBy(func(v db.TribeVerification) bool {
+			return v.Status == db.TribeVerificationVerified
+		})).Return(db.TribeVerification{Uuid: "v3", Status: db.TribeVerificationVerified}, nil).Once()
+		rr := serve(http.MethodPost, "/tribes/tribe/verifications/v3/check", "", "owner")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that an admin can approve a claim by hand", func(t *testing.T) {
+		mockDb.On("GetTribeVerification", "v2").Return(db.TribeVerification{ID: 2, Uuid: "v2", TribeUuid: "tribe", Status: db.TribeVerificationNeedsReview}).Once()
+		mockDb.On("UpdateTribeVerification", mock.MatchedBy(func(v db.TribeVerification) bool {
+			return v.Status == db.TribeVerificationVerified && v.ReviewedBy == "admin"
+		})).Return(db.TribeVerification{Uuid: "v2", Status: db.TribeVerificationVerified}, nil).Once()
+		rr := serve(http.MethodPost, "/admin/tribes/verifications/v2", `{"approve": true}`, "admin")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
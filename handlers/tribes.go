@@ -1,11 +1,11 @@
 package handlers
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -14,6 +14,8 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/lightning"
 	"github.com/stakwork/sphinx-tribes/utils"
 )
 
@@ -21,6 +23,10 @@ type tribeHandler struct {
 	db                      db.Database
 	verifyTribeUUID         func(uuid string, checkTimestamp bool) (string, error)
 	tribeUniqueNameFromName func(name string) (string, error)
+	lnBackend               lightning.Backend
+	httpClient              HttpClient
+	lookupTXT               func(name string) ([]string, error)
+	githubPubkey            func(username string) (string, error)
 }
 
 func NewTribeHandler(db db.Database) *tribeHandler {
@@ -28,6 +34,10 @@ func NewTribeHandler(db db.Database) *tribeHandler {
 		db:                      db,
 		verifyTribeUUID:         auth.VerifyTribeUUID,
 		tribeUniqueNameFromName: TribeUniqueNameFromName,
+		lnBackend:               lightning.NewBackend(http.DefaultClient),
+		httpClient:              http.DefaultClient,
+		lookupTXT:               net.LookupTXT,
+		githubPubkey:            PubkeyForGithubUser,
 	}
 }
 
@@ -44,6 +54,23 @@ func (th *tribeHandler) GetTotalribes(w http.ResponseWriter, r *http.Request) {
 }
 
 func (th *tribeHandler) GetListedTribes(w http.ResponseWriter, r *http.Request) {
+	since, isDelta, err := parseUpdatedSince(r)
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid updated_since")
+		return
+	}
+	if isDelta {
+		syncedAt := time.Now().Unix()
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(db.DeltaResponse{
+			Updated:   th.db.GetTribesUpdatedSince(since, r),
+			Deletions: th.db.GetDeletionsSince(db.DeletionKindTribe, since),
+			SyncedAt:  syncedAt,
+		})
+		return
+	}
+
 	tribes := th.db.GetListedTribes(r)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(tribes)
@@ -114,6 +141,8 @@ func PutTribeStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	previous := db.DB.GetTribe(tribe.UUID)
+
 	now := time.Now()
 	tribe.Updated = &now
 	db.DB.UpdateTribe(tribe.UUID, map[string]interface{}{
@@ -122,6 +151,15 @@ func PutTribeStats(w http.ResponseWriter, r *http.Request) {
 		"bots":         tribe.Bots,
 	})
 
+	// the relay only reports the member count, so new members are seen as it grows
+	if tribe.MemberCount > previous.MemberCount {
+		events.Publish(events.TribeJoined, "", map[string]interface{}{
+			"tribe_uuid":   tribe.UUID,
+			"member_count": tribe.MemberCount,
+			"joined":       tribe.MemberCount - previous.MemberCount,
+		})
+	}
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(true)
 }
@@ -153,6 +191,7 @@ func (th *tribeHandler) DeleteTribe(w http.ResponseWriter, r *http.Request) {
 	th.db.UpdateTribe(uuid, map[string]interface{}{
 		"deleted": true,
 	})
+	th.db.AddDeletion(db.DeletionKindTribe, uuid)
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(true)
@@ -260,6 +299,24 @@ func (th *tribeHandler) CreateOrEditTribe(w http.ResponseWriter, r *http.Request
 	tribe.OwnerPubKey = extractedPubkey
 	tribe.Updated = &now
 	tribe.LastActive = now.Unix()
+	// only admins feature tribes and only verifications verify them
+	tribe.Featured = false
+	tribe.FeaturedPosition = 0
+	tribe.Verified = false
+	tribe.PendingReview = false
+	tribe.ReviewReasons = nil
+
+	// new tribes that look like spam stay unlisted until an admin reviews them
+	if existing.UUID == "" {
+		if tribe.Created == nil {
+			tribe.Created = &now
+		}
+		if reasons := listingSpamReasons(th.db, db.ReportTribe, tribe.OwnerPubKey, tribe.Name, tribe.Description, tribe.AppURL); len(reasons) > 0 {
+			tribe.Unlisted = true
+			tribe.PendingReview = true
+			tribe.ReviewReasons = reasons
+		}
+	}
 
 	_, err = th.db.CreateOrEditTribe(tribe)
 	if err != nil {
@@ -268,6 +325,10 @@ func (th *tribeHandler) CreateOrEditTribe(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if existing.UUID == "" {
+		recordActivity(tribe.OwnerPubKey, db.ActivityTribeCreated, tribe.Name, fmt.Sprintf("%s/t/%s", config.Host, tribe.UniqueName), 0)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(tribe)
 }
@@ -295,10 +356,23 @@ func PutTribeActivity(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the relay may report the messages sent since its last report for the analytics of the tribe
+	activity := TribeActivityRequest{}
+	if r.ContentLength != 0 {
+		json.NewDecoder(r.Body).Decode(&activity)
+	}
+
 	now := time.Now().Unix()
 	db.DB.UpdateTribe(uuid, map[string]interface{}{
 		"last_active": now,
 	})
+	if _, err := db.DB.AddTribeActivity(db.TribeActivity{
+		TribeUuid: uuid,
+		Kind:      db.TribeActivityPing,
+		Count:     activity.Messages,
+	}); err != nil {
+		fmt.Println("[tribes] could not record activity", uuid, err)
+	}
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(true)
@@ -490,41 +564,17 @@ func GenerateInvoice(w http.ResponseWriter, r *http.Request) {
 	routeHint := invoice.Route_hint
 	amount, _ := utils.ConvertStringToUint(invoice.Amount)
 
-	url := fmt.Sprintf("%s/invoices", config.RelayUrl)
-
-	bodyData := fmt.Sprintf(`{"amount": %d, "memo": "%s"}`, amount, memo)
-
-	jsonBody := []byte(bodyData)
-
-	client := &http.Client{}
-	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
-
-	req.Header.Set("x-user-token", config.RelayAuthKey)
-	req.Header.Set("Content-Type", "application/json")
-	res, _ := client.Do(req)
-
+	lnInvoice, err := lightning.NewBackend(http.DefaultClient).CreateInvoice(amount, memo)
 	if err != nil {
-		log.Printf("Request Failed: %s", err)
-		return
-	}
-
-	defer res.Body.Close()
-
-	body, err = io.ReadAll(res.Body)
-
-	if err != nil {
-		log.Printf("Reading body failed: %s", err)
+		log.Printf("Invoice creation failed: %s", err)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
 		return
 	}
 
-	// Unmarshal result
-	invoiceRes := db.InvoiceResponse{}
-
-	err = json.Unmarshal(body, &invoiceRes)
-
-	if err != nil {
-		log.Printf("Unmarshal body failed: %s", err)
-		return
+	invoiceRes := db.InvoiceResponse{
+		Succcess: true,
+		Response: db.Invoice{Invoice: lnInvoice.PaymentRequest},
 	}
 
 	paymentRequest := invoiceRes.Response.Invoice
@@ -579,41 +629,21 @@ func (th *tribeHandler) GenerateBudgetInvoice(w http.ResponseWriter, r *http.Req
 		invoice.WorkspaceUuid = invoice.OrgUuid
 	}
 
-	url := fmt.Sprintf("%s/invoices", config.RelayUrl)
-
-	bodyData := fmt.Sprintf(`{"amount": %d, "memo": "%s"}`, invoice.Amount, "Budget Invoice")
-
-	jsonBody := []byte(bodyData)
-
-	client := &http.Client{}
-	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
-
-	req.Header.Set("x-user-token", config.RelayAuthKey)
-	req.Header.Set("Content-Type", "application/json")
-	res, _ := client.Do(req)
-
-	if err != nil {
-		log.Printf("Request Failed: %s", err)
+	if rejectArchivedWorkspace(w, invoice.WorkspaceUuid) {
 		return
 	}
 
-	defer res.Body.Close()
-
-	body, err = io.ReadAll(res.Body)
-
+	lnInvoice, err := th.lnBackend.CreateInvoice(invoice.Amount, "Budget Invoice")
 	if err != nil {
-		log.Printf("Reading body failed: %s", err)
+		log.Printf("Invoice creation failed: %s", err)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
 		return
 	}
 
-	// Unmarshal result
-	invoiceRes := db.InvoiceResponse{}
-
-	err = json.Unmarshal(body, &invoiceRes)
-
-	if err != nil {
-		log.Printf("Json Unmarshal failed: %s", err)
-		return
+	invoiceRes := db.InvoiceResponse{
+		Succcess: true,
+		Response: db.Invoice{Invoice: lnInvoice.PaymentRequest},
 	}
 
 	now := time.Now()
@@ -0,0 +1,350 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/media"
+	"github.com/stakwork/sphinx-tribes/storage"
+)
+
+const maxUploadSize = 10 << 20
+
+const uploadUrlExpiry = 15 * time.Minute
+
+// mediaJobBatch is how many images the media worker processes per run
+const mediaJobBatch = 20
+
+// uploadPurposes maps the ?purpose= of an upload to the folder its files are kept in
+var uploadPurposes = map[string]string{
+	"proof":  storage.PurposeProof,
+	"chat":   storage.PurposeChat,
+	"avatar": storage.PurposeAvatar,
+}
+
+type uploadHandler struct {
+	db      db.Database
+	storage storage.Storage
+	now     func() time.Time
+}
+
+type UploadResponse struct {
+	Key       string    `json:"key"`
+	Url       string    `json:"url"`
+	SignedUrl string    `json:"signed_url"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// Variants are the urls of the webp copy and thumbnail of an image, served once it is processed
+	Variants map[string]string `json:"variants,omitempty"`
+}
+
+// NewUploadHandler uses the storage selected with STORAGE_BACKEND, uploads are refused when it
+// is the meme server or the storage could not be set up
+func NewUploadHandler(database db.Database) *uploadHandler {
+	store, err := storage.NewStorage()
+	if err != nil {
+		fmt.Println("could not set up the storage:", err)
+	}
+	return &uploadHandler{db: database, storage: store, now: time.Now}
+}
+
+// uploadUrl is the stable url of a file, it redirects to a fresh signed url on every request
+func uploadUrl(key string) string {
+	return strings.TrimSuffix(config.StoragePublicUrl, "/") + "/uploads/" + key
+}
+
+// storedFile is an upload once stored, with the format of an image
+type storedFile struct {
+	Key         string
+	Filename    string
+	ContentType string
+	Size        int64
+	Info        media.Info
+}
+
+// uploadLimit returns the largest size of a file of a type uploaded for a purpose, false when the
+// type can't be uploaded for it
+func uploadLimit(purpose string, contentType string) (int64, bool) {
+	if purpose == storage.PurposeAttachment {
+		limit, ok := attachmentLimits[contentType]
+		return limit, ok
+	}
+	return maxUploadSize, true
+}
+
+// store checks the size and type of the file of a multipart form and stores it under a new key,
+// images are validated and get the extension of their actual format
+func (uh *uploadHandler) store(w http.ResponseWriter, r *http.Request, purpose string) (storedFile, int, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxAttachmentSize+1<<20)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return storedFile{}, http.StatusBadRequest, errors.New("unable to parse file")
+	}
+	defer file.Close()
+
+	// the content type is sniffed rather than trusted from the client
+	sniff := make([]byte, 512)
+	n, _ := io.ReadFull(file, sniff)
+	contentType := http.DetectContentType(sniff[:n])
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return storedFile{}, http.StatusInternalServerError, errors.New("unable to read file")
+	}
+
+	limit, allowed := uploadLimit(purpose, contentType)
+	if !allowed {
+		return storedFile{}, http.StatusUnsupportedMediaType, fmt.Errorf("files of type %s are not allowed", contentType)
+	}
+	if header.Size > limit {
+		return storedFile{}, http.StatusRequestEntityTooLarge, fmt.Errorf("file is larger than %d bytes", limit)
+	}
+
+	filename := header.Filename
+	var info media.Info
+	if storage.Inline(contentType) {
+		info, err = media.Validate(file)
+		if err != nil {
+			return storedFile{}, http.StatusBadRequest, err
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return storedFile{}, http.StatusInternalServerError, errors.New("unable to read file")
+		}
+		filename = strings.TrimSuffix(filename, path.Ext(filename)) + "." + info.Format
+	} else if purpose == storage.PurposeAvatar || purpose == storage.PurposeMeme {
+		return storedFile{}, http.StatusBadRequest, errors.New("file is not an image")
+	}
+
+	key := storage.NewKey(purpose, filename)
+	if err := uh.storage.Put(key, contentType, file); err != nil {
+		fmt.Println("could not store upload:", err)
+		return storedFile{}, http.StatusInternalServerError, errors.New("unable to store file")
+	}
+	return storedFile{
+		Key:         key,
+		Filename:    path.Base(filename),
+		ContentType: contentType,
+		Size:        header.Size,
+		Info:        info,
+	}, http.StatusOK, nil
+}
+
+// queueMedia queues the processing of the memes and the images of the chat, returning the urls
+// their copies will be at once processed
+func (uh *uploadHandler) queueMedia(key string, purpose string, info media.Info) map[string]string {
+	if (purpose != storage.PurposeMeme && purpose != storage.PurposeChat) || info.Format == "" || info.Format == media.FormatWebp {
+		return nil
+	}
+
+	job, err := uh.db.CreateMediaJob(db.MediaJob{
+		Key:          key,
+		WebpKey:      storage.WebPKey(key),
+		ThumbnailKey: storage.ThumbnailKey(key),
+	})
+	if err != nil {
+		fmt.Println("could not queue media job:", err)
+		return nil
+	}
+	return map[string]string{
+		"webp":      uploadUrl(job.WebpKey),
+		"thumbnail": uploadUrl(job.ThumbnailKey),
+	}
+}
+
+// Upload stores a bounty proof, chat artifact or avatar, given with ?purpose=, and returns its
+// stable url along with a signed one
+func (uh *uploadHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if uh.storage == nil {
+		w.WriteHeader(http.StatusNotImplemented)
+		json.NewEncoder(w).Encode("no storage backend is set")
+		return
+	}
+
+	purpose, ok := uploadPurposes[r.URL.Query().Get("purpose")]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("purpose must be proof, chat or avatar")
+		return
+	}
+
+	stored, status, err := uh.store(w, r, purpose)
+	if err != nil {
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	key := stored.Key
+	variants := uh.queueMedia(key, purpose, stored.Info)
+
+	signedUrl, err := uh.storage.SignedURL(key, uploadUrlExpiry)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("unable to sign url")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(UploadResponse{
+		Key:       key,
+		Url:       uploadUrl(key),
+		SignedUrl: signedUrl,
+		ExpiresAt: uh.now().Add(uploadUrlExpiry).UTC(),
+		Variants:  variants,
+	})
+}
+
+// MemeImageUpload keeps the meme images in the storage when one is set, and on the meme server otherwise
+func (uh *uploadHandler) MemeImageUpload(w http.ResponseWriter, r *http.Request) {
+	if uh.storage == nil {
+		MemeImageUpload(w, r)
+		return
+	}
+
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	stored, status, err := uh.store(w, r, storage.PurposeMeme)
+	if err != nil {
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	uh.queueMedia(stored.Key, storage.PurposeMeme, stored.Info)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(uploadUrl(stored.Key))
+}
+
+// Download redirects the stable url of a file to a signed one, the copies of an image that is not
+// processed yet redirect to the image itself
+func (uh *uploadHandler) Download(w http.ResponseWriter, r *http.Request) {
+	if uh.storage == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	key := chi.URLParam(r, "*")
+	if storage.ValidKey(key) && strings.HasSuffix(key, ".webp") {
+		if job := uh.db.GetMediaJobByVariant(key); job.ID != 0 && job.Status != db.MediaJobDone {
+			key = job.Key
+		}
+	}
+	signedUrl, err := uh.storage.SignedURL(key, uploadUrlExpiry)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, max-age=60")
+	http.Redirect(w, r, signedUrl, http.StatusFound)
+}
+
+// ServeStorage sends the files of the local storage to the holders of a signed url
+func (uh *uploadHandler) ServeStorage(w http.ResponseWriter, r *http.Request) {
+	opener, ok := uh.storage.(storage.Opener)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	key := chi.URLParam(r, "*")
+	keys := r.URL.Query()
+	file, err := opener.Open(key, keys.Get("expires"), keys.Get("signature"), uh.now())
+	if errors.Is(err, storage.ErrInvalidSignature) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	contentType := storage.ContentType(key)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if !storage.Inline(contentType) {
+		w.Header().Set("Content-Disposition", "attachment")
+	}
+	http.ServeContent(w, r, "", info.ModTime(), file)
+}
+
+// ProcessMediaJobs runs the queued processing of the uploaded images
+func ProcessMediaJobs() {
+	NewUploadHandler(db.DB).processMediaJobs()
+}
+
+func (uh *uploadHandler) processMediaJobs() {
+	if uh.storage == nil {
+		return
+	}
+	for _, job := range uh.db.GetPendingMediaJobs(mediaJobBatch) {
+		if !uh.db.ClaimMediaJob(job.ID) {
+			continue
+		}
+		err := uh.processMediaJob(job)
+		if err != nil {
+			fmt.Println("could not process media", job.Key, err)
+		}
+		if err := uh.db.FinishMediaJob(job, err); err != nil {
+			fmt.Println("could not finish media job", job.Key, err)
+		}
+	}
+}
+
+// processMediaJob replaces an image with its processed version and stores its copies
+func (uh *uploadHandler) processMediaJob(job db.MediaJob) error {
+	file, err := uh.storage.Get(job.Key)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(io.LimitReader(file, maxUploadSize+1))
+	file.Close()
+	if err != nil {
+		return err
+	}
+
+	result, err := media.Process(data)
+	if err != nil {
+		return err
+	}
+
+	if result.Image != nil {
+		if err := uh.storage.Put(job.Key, result.Info.ContentType(), bytes.NewReader(result.Image)); err != nil {
+			return err
+		}
+	}
+	if err := uh.storage.Put(job.WebpKey, "image/webp", bytes.NewReader(result.WebP)); err != nil {
+		return err
+	}
+	return uh.storage.Put(job.ThumbnailKey, "image/webp", bytes.NewReader(result.Thumbnail))
+}
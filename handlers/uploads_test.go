@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testPng(width int, height int) []byte {
+	buf := &bytes.Buffer{}
+	png.Encode(buf, image.NewNRGBA(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+func uploadRequest(t *testing.T, target string, filename string, content []byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, target, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "pubkey"))
+}
+
+func TestUploads(t *testing.T) {
+	config.StoragePublicUrl = "https://people.sphinx.chat/"
+	defer func() { config.StoragePublicUrl = "" }()
+
+	now := time.Now()
+	mockDb := mocks.NewDatabase(t)
+	uh := &uploadHandler{
+		db:      mockDb,
+		storage: storage.NewLocalStorage(t.TempDir(), "https://people.sphinx.chat", []byte("secret")),
+		now:     func() time.Time { return now },
+	}
+
+	router := chi.NewRouter()
+	router.Get("/uploads/*", uh.Download)
+	router.Get("/storage/*", uh.ServeStorage)
+
+	upload := func(target string, filename string, content []byte) (*httptest.ResponseRecorder, UploadResponse) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(uh.Upload).ServeHTTP(rr, uploadRequest(t, target, filename, content))
+		res := UploadResponse{}
+		json.Unmarshal(rr.Body.Bytes(), &res)
+		return rr, res
+	}
+
+	t.Run("Should test that an uploaded proof is downloaded through its stable url", func(t *testing.T) {
+		rr, res := upload("/uploads?purpose=proof", "report.txt", []byte("the work is done"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, strings.HasPrefix(res.Key, "proofs/"))
+		assert.Equal(t, "https://people.sphinx.chat/uploads/"+res.Key, res.Url)
+		assert.Equal(t, now.Add(uploadUrlExpiry).UTC(), res.ExpiresAt)
+
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/uploads/"+res.Key, nil))
+		assert.Equal(t, http.StatusFound, rr.Code)
+
+		signed, _ := url.Parse(rr.Header().Get("Location"))
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, signed.RequestURI(), nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "the work is done", rr.Body.String())
+		assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "attachment", rr.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("Should test that a signed url can't be used for another file or after it expires", func(t *testing.T) {
+		_, res := upload("/uploads?purpose=avatar", "me.png", testPng(8, 8))
+		signed, _ := url.Parse(res.SignedUrl)
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, signed.RequestURI(), nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("Content-Disposition"))
+
+		_, other := upload("/uploads?purpose=avatar", "me.png", testPng(8, 8))
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/storage/"+other.Key+"?"+signed.RawQuery, nil))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		now = now.Add(time.Hour)
+		defer func() { now = now.Add(-time.Hour) }()
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, signed.RequestURI(), nil))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("Should test that an avatar has to be an image", func(t *testing.T) {
+		rr, _ := upload("/uploads?purpose=avatar", "me.png", []byte("<html><script></script></html>"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that an image declaring huge dimensions is refused", func(t *testing.T) {
+		rr, _ := upload("/uploads?purpose=chat", "big.png", testPng(9000, 1))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that a chat image is queued for processing with the urls of its copies", func(t *testing.T) {
+		var queued db.MediaJob
+		mockDb.On("CreateMediaJob", mock.AnythingOfType("db.MediaJob")).Return(func(job db.MediaJob) (db.MediaJob, error) {
+			queued = job
+			return job, nil
+		}).Once()
+
+		rr, res := upload("/uploads?purpose=chat", "photo.jpeg", testPng(8, 8))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		// the key gets the extension of the actual format
+		assert.True(t, strings.HasSuffix(res.Key, ".png"))
+		assert.Equal(t, res.Key, queued.Key)
+		assert.Equal(t, storage.WebPKey(res.Key), queued.WebpKey)
+		assert.Equal(t, map[string]string{
+			"webp":      "https://people.sphinx.chat/uploads/" + storage.WebPKey(res.Key),
+			"thumbnail": "https://people.sphinx.chat/uploads/" + storage.ThumbnailKey(res.Key),
+		}, res.Variants)
+
+		// the copies redirect to the image until it is processed
+		mockDb.On("GetMediaJobByVariant", queued.ThumbnailKey).Return(db.MediaJob{ID: 1, Key: res.Key, Status: db.MediaJobPending}).Once()
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/uploads/"+queued.ThumbnailKey, nil))
+		assert.Equal(t, http.StatusFound, rr.Code)
+		assert.Contains(t, rr.Header().Get("Location"), "/storage/"+res.Key+"?")
+
+		mockDb.On("GetPendingMediaJobs", mediaJobBatch).Return([]db.MediaJob{{ID: 1, Key: res.Key, WebpKey: queued.WebpKey, ThumbnailKey: queued.ThumbnailKey}}).Once()
+		mockDb.On("ClaimMediaJob", uint(1)).Return(true).Once()
+		mockDb.On("FinishMediaJob", mock.AnythingOfType("db.MediaJob"), nil).Return(nil).Once()
+		uh.processMediaJobs()
+
+		mockDb.On("GetMediaJobByVariant", queued.ThumbnailKey).Return(db.MediaJob{ID: 1, Key: res.Key, Status: db.MediaJobDone}).Once()
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/uploads/"+queued.ThumbnailKey, nil))
+		assert.Contains(t, rr.Header().Get("Location"), "/storage/"+queued.ThumbnailKey+"?")
+
+		signed, _ := url.Parse(rr.Header().Get("Location"))
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, signed.RequestURI(), nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/webp", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "RIFF", rr.Body.String()[:4])
+	})
+
+	t.Run("Should test that a failed job is recorded with its error", func(t *testing.T) {
+		missing := storage.NewKey(storage.PurposeChat, "gone.png")
+		mockDb.On("GetPendingMediaJobs", mediaJobBatch).Return([]db.MediaJob{{ID: 2, Key: missing}}).Once()
+		mockDb.On("ClaimMediaJob", uint(2)).Return(true).Once()
+		mockDb.On("FinishMediaJob", mock.AnythingOfType("db.MediaJob"), storage.ErrNotFound).Return(nil).Once()
+		uh.processMediaJobs()
+	})
+
+	t.Run("Should test that the purpose is required", func(t *testing.T) {
+		rr, _ := upload("/uploads", "report.txt", []byte("text"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that an unknown file is not found", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/uploads/../../etc/passwd", nil))
+		assert.NotEqual(t, http.StatusFound, rr.Code)
+	})
+
+	t.Run("Should test that a meme is stored and its stable url returned", func(t *testing.T) {
+		mockDb.On("CreateMediaJob", mock.AnythingOfType("db.MediaJob")).Return(func(job db.MediaJob) (db.MediaJob, error) {
+			return job, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(uh.MemeImageUpload).ServeHTTP(rr, uploadRequest(t, "/meme_upload", "meme.png", testPng(8, 8)))
+
+		var memeUrl string
+		json.Unmarshal(rr.Body.Bytes(), &memeUrl)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, strings.HasPrefix(memeUrl, "https://people.sphinx.chat/uploads/memes/"))
+	})
+
+	t.Run("Should test that uploads are refused without a storage", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc((&uploadHandler{now: time.Now}).Upload).ServeHTTP(rr, uploadRequest(t, "/uploads?purpose=chat", "a.txt", []byte("a")))
+		assert.Equal(t, http.StatusNotImplemented, rr.Code)
+	})
+
+	t.Run("Should test that the uploader has to be authenticated", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/uploads?purpose=chat", nil)
+		http.HandlerFunc(uh.Upload).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
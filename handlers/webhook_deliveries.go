@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const (
+	// WebhookSignatureHeader carries the "sha256=" hex HMAC-SHA256 of the body signed with the
+	// secret of the webhook, receivers check it with VerifyWebhookSignature
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+
+	webhookDeliveryBatch   = 50
+	webhookDeliveryTimeout = 10 * time.Second
+)
+
+// errWebhookAddress refuses the webhook urls that point into the network of the server
+var errWebhookAddress = errors.New("webhooks can't be sent to private, loopback or link-local addresses")
+
+// carrierNat is the shared address space of carrier-grade NAT, it isn't reachable from the internet either
+var carrierNat = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// publicWebhookAddress reports if a webhook may be sent to an ip
+func publicWebhookAddress(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || carrierNat.Contains(ip))
+}
+
+// webhookDialControl refuses the connections of webhook deliveries to addresses that aren't public. It
+// runs on the address the host resolved to, so a name that is pointed at a private address later is
+// refused too
+func webhookDialControl(network string, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !publicWebhookAddress(ip) {
+		return errWebhookAddress
+	}
+	return nil
+}
+
+// webhookClient sends the webhook deliveries, it never goes through a proxy so every connection is checked
+var webhookClient = &http.Client{
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: webhookDeliveryTimeout,
+			Control: webhookDialControl,
+		}).DialContext,
+		TLSHandshakeTimeout: webhookDeliveryTimeout,
+		MaxIdleConns:        10,
+		IdleConnTimeout:     90 * time.Second,
+	},
+}
+
+// WebhookBody is what is posted to a webhook url
+type WebhookBody struct {
+	Id      string                 `json:"id"`
+	Event   string                 `json:"event"`
+	Created *time.Time             `json:"created"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+type webhookDeliverer struct {
+	httpClient HttpClient
+	db         db.Database
+}
+
+func NewWebhookDeliverer(httpClient HttpClient, database db.Database) *webhookDeliverer {
+	return &webhookDeliverer{
+		httpClient: httpClient,
+		db:         database,
+	}
+}
+
+// SignWebhookBody returns the signature header of a body for a secret
+func SignWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// queueWebhookDelivery queues the post of an event to a url, it is sent by the next run of
+// ProcessWebhookDeliveries
+func queueWebhookDelivery(database db.Database, delivery db.WebhookDelivery) {
+	if _, err := database.CreateWebhookDelivery(delivery); err != nil {
+		fmt.Println("[webhooks] could not queue", delivery.Event, "for", delivery.Url, err)
+	}
+}
+
+// ProcessWebhookDeliveries posts the queued webhook deliveries
+func ProcessWebhookDeliveries() {
+	NewWebhookDeliverer(webhookClient, db.DB).processWebhookDeliveries()
+}
+
+func (wd *webhookDeliverer) processWebhookDeliveries() {
+	for _, delivery := range wd.db.GetPendingWebhookDeliveries(webhookDeliveryBatch) {
+		if !wd.db.ClaimWebhookDelivery(delivery.ID) {
+			continue
+		}
+		delivery = wd.deliverWebhook(delivery)
+		if err := wd.db.UpdateWebhookDelivery(delivery); err != nil {
+			fmt.Println("[webhooks] could not save delivery", delivery.Uuid, err)
+		}
+	}
+}
+
+// deliverWebhook makes one attempt of a delivery, a failed one is put back in the queue with a
+// growing delay until its attempts are used up
+func (wd *webhookDeliverer) deliverWebhook(delivery db.WebhookDelivery) db.WebhookDelivery {
+	delivery.Attempts++
+	status, err := wd.postWebhook(delivery)
+	delivery.ResponseStatus = status
+	if err == nil {
+		delivery.Status = db.WebhookDeliveryDelivered
+		delivery.Error = ""
+		delivery.NextRunAt = nil
+		return delivery
+	}
+
+	// the errors of a webhook that never answered stay in the log, they would tell the owner of the
+	// webhook about the network of the server
+	delivery.Error = err.Error()
+	if status == 0 {
+		log.Printf("[webhooks] delivery %s could not reach the webhook: %v", delivery.Uuid, err)
+		delivery.Error = "Could not reach the webhook"
+	}
+	if delivery.Attempts >= db.MaxWebhookDeliveryAttempts {
+		delivery.Status = db.WebhookDeliveryFailed
+		delivery.NextRunAt = nil
+	} else {
+		next := time.Now().Add(webhookRetryDelay(delivery.Attempts))
+		delivery.Status = db.WebhookDeliveryPending
+		delivery.NextRunAt = &next
+	}
+	return delivery
+}
+
+// webhookRetryDelay is how long a failed delivery waits before its next attempt
+func webhookRetryDelay(attempts int) time.Duration {
+	return time.Duration(attempts*attempts) * time.Minute
+}
+
+func (wd *webhookDeliverer) postWebhook(delivery db.WebhookDelivery) (int, error) {
+	var body interface{} = WebhookBody{
+		Id:      delivery.Uuid,
+		Event:   delivery.Event,
+		Created: delivery.Created,
+		Payload: delivery.Payload,
+	}
+	if delivery.Raw {
+		body = delivery.Payload
+	}
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookDeliveryTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(buf))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookEventHeader, delivery.Event)
+	request.Header.Set(WebhookDeliveryHeader, delivery.Uuid)
+	if delivery.Secret != "" {
+		request.Header.Set(WebhookSignatureHeader, SignWebhookBody(delivery.Secret, buf))
+	}
+
+	response, err := wd.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("webhook responded %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProcessWebhookDeliveries(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	wd := NewWebhookDeliverer(mockHttpClient, mockDb)
+	delivery := db.WebhookDelivery{ID: 1, Uuid: "delivery", Url: "https://bot.example.com/hook", Secret: "secret", Event: events.TribeJoined,
+		Payload: db.PropertyMap{"tribe_uuid": "tribe"}, Status: db.WebhookDeliveryPending}
+
+	t.Run("Should test that a delivery is signed with the secret of the webhook", func(t *testing.T) {
+		mockDb.On("GetPendingWebhookDeliveries", webhookDeliveryBatch).Return([]db.WebhookDelivery{delivery}).Once()
+		mockDb.On("ClaimWebhookDelivery", uint(1)).Return(true).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			reader, _ := req.GetBody()
+			body, _ := io.ReadAll(reader)
+			return req.URL.String() == delivery.Url && req.Header.Get(WebhookEventHeader) == events.TribeJoined &&
+				VerifyWebhookSignature("secret", body, req.Header.Get(WebhookSignatureHeader))
+		})).Return(&http.Response{StatusCode: 204, Body: io.NopCloser(bytes.NewReader(nil))}, nil).Once()
+		mockDb.On("UpdateWebhookDelivery", mock.MatchedBy(func(d db.WebhookDelivery) bool {
+			return d.Status == db.WebhookDeliveryDelivered && d.Attempts == 1 && d.ResponseStatus == 204
+		})).Return(nil).Once()
+
+		wd.processWebhookDeliveries()
+	})
+
+	t.Run("Should test that a raw delivery posts its payload as it is", func(t *testing.T) {
+		raw := db.WebhookDelivery{ID: 2, Uuid: "raw", Url: "https://hooks.slack.com/services/T/B/X", Raw: true, Event: events.BountyPaid,
+			Payload: db.PropertyMap{"text": "Bounty paid"}, Status: db.WebhookDeliveryPending}
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			reader, _ := req.GetBody()
+			body, _ := io.ReadAll(reader)
+			return req.URL.String() == raw.Url && string(body) == `{"text":"Bounty paid"}` && req.Header.Get(WebhookSignatureHeader) == ""
+		})).Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader(nil))}, nil).Once()
+
+		delivered := wd.deliverWebhook(raw)
+		assert.Equal(t, db.WebhookDeliveryDelivered, delivered.Status)
+	})
+
+	t.Run("Should test that a failed delivery is retried later then fails", func(t *testing.T) {
+		mockHttpClient.On("Do", mock.Anything).Return(nil, errors.New("connection refused")).Twice()
+
+		retried := wd.deliverWebhook(delivery)
+		assert.Equal(t, db.WebhookDeliveryPending, retried.Status)
+		assert.Equal(t, 1, retried.Attempts)
+		assert.NotNil(t, retried.NextRunAt)
+
+		delivery.Attempts = db.MaxWebhookDeliveryAttempts - 1
+		failed := wd.deliverWebhook(delivery)
+		assert.Equal(t, db.WebhookDeliveryFailed, failed.Status)
+		assert.Equal(t, "Could not reach the webhook", failed.Error)
+	})
+}
+
+func TestWebhookClient(t *testing.T) {
+	t.Run("Should test that private, loopback and link-local addresses are refused", func(t *testing.T) {
+		for _, address := range []string{"127.0.0.1:80", "[::1]:443", "10.0.0.5:80", "192.168.1.1:8080", "172.16.0.1:80", "169.254.169.254:80", "100.64.0.1:80", "0.0.0.0:80", "[fe80::1]:80"} {
+			assert.ErrorIs(t, webhookDialControl("tcp", address, nil), errWebhookAddress, address)
+		}
+		assert.NoError(t, webhookDialControl("tcp", "93.184.216.34:443", nil))
+	})
+
+	t.Run("Should test that a delivery to a local server is never sent", func(t *testing.T) {
+		reached := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reached = true
+		}))
+		defer server.Close()
+
+		request, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, nil)
+		_, err := webhookClient.Do(request)
+
+		assert.ErrorIs(t, err, errWebhookAddress)
+		var opErr *net.OpError
+		assert.ErrorAs(t, err, &opErr)
+		assert.False(t, reached)
+	})
+
+	t.Run("Should test that webhooks can't be registered for local urls", func(t *testing.T) {
+		for _, url := range []string{"http://localhost:8080/hook", "http://api.localhost/hook", "http://169.254.169.254/latest/meta-data", "http://10.0.0.5/hook", "http://[::1]/hook"} {
+			err := validateBotWebhook(db.BotWebhook{Url: url, Events: []string{events.TribeJoined}})
+			assert.ErrorIs(t, err, errWebhookAddress, url)
+		}
+		assert.NoError(t, validateBotWebhook(db.BotWebhook{Url: "https://bot.example.com/hook", Events: []string{events.TribeJoined}}))
+	})
+}
@@ -0,0 +1,573 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/notifications"
+	"github.com/stakwork/sphinx-tribes/stakwork"
+	"gorm.io/gorm"
+)
+
+const (
+	workflowJobBatch       = 20
+	workflowExecutionLimit = 50
+)
+
+// validWorkflowTrigger reports whether a workflow can start on a trigger, the manual trigger or
+// an event of the internal bus
+func validWorkflowTrigger(trigger string) bool {
+	if trigger == db.WorkflowTriggerManual {
+		return true
+	}
+	for _, name := range events.Names {
+		if trigger == name {
+			return true
+		}
+	}
+	return false
+}
+
+// workflowParamPattern matches the {{name}} placeholders of the string params of a step
+var workflowParamPattern = regexp.MustCompile(`\{\{\s*([\w.]+)\s*\}\}`)
+
+type workflowHandler struct {
+	httpClient    HttpClient
+	stakwork      *stakwork.Client
+	db            db.Database
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewWorkflowHandler(httpClient HttpClient, database db.Database) *workflowHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &workflowHandler{
+		httpClient:    httpClient,
+		stakwork:      stakwork.NewClient(httpClient),
+		db:            database,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+// workflowStore is where triggerWorkflows queues executions, it is nil until InitWorkflows is called
+var workflowStore db.Database
+
+// InitWorkflows subscribes the workflows to the events of the bus
+func InitWorkflows(database db.Database) {
+	workflowStore = database
+	events.Subscribe(events.AllEvents, triggerWorkflows)
+}
+
+// triggerWorkflows queues a run of each enabled workflow of the workspace of an event started by it,
+// the payload of the event is the input of the run
+func triggerWorkflows(event events.Event) {
+	if workflowStore == nil {
+		return
+	}
+	workspaceUuid := event.WorkspaceUuid
+	if phaseUuid, _ := event.Payload["phase_uuid"].(string); workspaceUuid == "" && phaseUuid != "" {
+		if phase, err := workflowStore.GetPhaseByUuid(phaseUuid); err == nil {
+			workspaceUuid = workflowStore.GetFeatureByUuid(phase.FeatureUuid).WorkspaceUuid
+		}
+	}
+	if workspaceUuid == "" {
+		return
+	}
+	for _, workflow := range workflowStore.GetTriggeredWorkflows(workspaceUuid, event.Name) {
+		if _, err := workflowStore.CreateWorkflowExecution(workflow, event.Name, event.Payload); err != nil {
+			fmt.Println("[workflow] could not queue", workflow.Uuid, err)
+		}
+	}
+}
+
+// validateWorkflow checks the trigger and the steps of a workflow
+func validateWorkflow(workflow db.Workflow) error {
+	if workflow.WorkspaceUuid == "" || workflow.Name == "" {
+		return errors.New("A workflow needs a workspace and a name")
+	}
+	if !validWorkflowTrigger(workflow.Trigger) {
+		return fmt.Errorf("Unknown trigger %s", workflow.Trigger)
+	}
+	if len(workflow.Steps) == 0 {
+		return errors.New("A workflow needs at least one step")
+	}
+	for i, step := range workflow.Steps {
+		required := []string{}
+		switch step.Type {
+		case db.WorkflowCallStakwork:
+		case db.WorkflowCreateTicket:
+			required = []string{"owner_pubkey", "title"}
+		case db.WorkflowNotify:
+			required = []string{"pubkey", "title"}
+		case db.WorkflowWebhook:
+			url, _ := step.Params["url"].(string)
+			if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+				return fmt.Errorf("Step %d needs an http url", i+1)
+			}
+		default:
+			return fmt.Errorf("Step %d has an unknown type %s", i+1, step.Type)
+		}
+		for _, param := range required {
+			if _, ok := step.Params[param]; !ok {
+				return fmt.Errorf("Step %d needs the %s param", i+1, param)
+			}
+		}
+	}
+	return nil
+}
+
+func (wh *workflowHandler) CreateOrEditWorkflow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workflow] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	workflow := db.Workflow{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &workflow); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid workflow")
+		return
+	}
+	if workflow.Trigger == "" {
+		workflow.Trigger = db.WorkflowTriggerManual
+	}
+	if err := validateWorkflow(workflow); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	if !wh.userHasAccess(pubKeyFromAuth, workflow.WorkspaceUuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the workspace workflows")
+		return
+	}
+	if rejectArchivedWorkspace(w, workflow.WorkspaceUuid) {
+		return
+	}
+	if workflow.Uuid != "" {
+		existing := wh.db.GetWorkflowByUuid(workflow.Uuid)
+		if existing.Uuid == "" || existing.WorkspaceUuid != workflow.WorkspaceUuid {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode("Workflow not found")
+			return
+		}
+	} else {
+		workflow.CreatedBy = pubKeyFromAuth
+	}
+
+	workflow, err := wh.db.CreateOrEditWorkflow(workflow)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(workflow)
+}
+
+func (wh *workflowHandler) GetWorkspaceWorkflows(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workflow] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	workspaceUuid := chi.URLParam(r, "workspace_uuid")
+	if !wh.userHasAccess(pubKeyFromAuth, workspaceUuid, db.ViewReport) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the workspace workflows")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(wh.db.GetWorkspaceWorkflows(workspaceUuid))
+}
+
+// workflowFromUrl returns the workflow of the {uuid} param, writing the error response when it
+// is missing or the user doesn't have the role in its workspace
+func (wh *workflowHandler) workflowFromUrl(w http.ResponseWriter, r *http.Request, role string) (db.Workflow, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workflow] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return db.Workflow{}, false
+	}
+
+	workflow := wh.db.GetWorkflowByUuid(chi.URLParam(r, "uuid"))
+	if workflow.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workflow not found")
+		return db.Workflow{}, false
+	}
+	if !wh.userHasAccess(pubKeyFromAuth, workflow.WorkspaceUuid, role) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the workspace workflows")
+		return db.Workflow{}, false
+	}
+	return workflow, true
+}
+
+// RunWorkflow queues a run of a workflow, the body is the input its steps can read
+func (wh *workflowHandler) RunWorkflow(w http.ResponseWriter, r *http.Request) {
+	workflow, ok := wh.workflowFromUrl(w, r, db.EditOrg)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, workflow.WorkspaceUuid) {
+		return
+	}
+
+	input := db.PropertyMap{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &input); err != nil {
+			w.WriteHeader(http.StatusNotAcceptable)
+			json.NewEncoder(w).Encode("Invalid workflow input")
+			return
+		}
+	}
+
+	execution, err := wh.db.CreateWorkflowExecution(workflow, db.WorkflowTriggerManual, input)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(execution)
+}
+
+// GetWorkflowExecutions returns the latest runs of a workflow with the state of their steps
+func (wh *workflowHandler) GetWorkflowExecutions(w http.ResponseWriter, r *http.Request) {
+	workflow, ok := wh.workflowFromUrl(w, r, db.ViewReport)
+	if !ok {
+		return
+	}
+
+	limit := workflowExecutionLimit
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l < limit {
+		limit = l
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(wh.db.GetWorkflowExecutions(workflow.Uuid, limit))
+}
+
+// RetryWorkflowExecution queues a failed execution again from the step that failed, with its
+// attempts reset
+func (wh *workflowHandler) RetryWorkflowExecution(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workflow] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	execution := wh.db.GetWorkflowExecution(chi.URLParam(r, "uuid"))
+	if execution.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Execution not found")
+		return
+	}
+	if !wh.userHasAccess(pubKeyFromAuth, execution.WorkspaceUuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the workspace workflows")
+		return
+	}
+	if rejectArchivedWorkspace(w, execution.WorkspaceUuid) {
+		return
+	}
+	if execution.Status != db.WorkflowFailed || execution.CurrentStep >= len(execution.Steps) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("Only a failed execution can be retried")
+		return
+	}
+
+	now := time.Now()
+	step := &execution.Steps[execution.CurrentStep]
+	step.Status = db.WorkflowPending
+	step.Attempts = 0
+	step.Error = ""
+	step.FinishedAt = nil
+	execution.Status = db.WorkflowPending
+	execution.NextRunAt = &now
+	if err := wh.db.UpdateWorkflowExecution(execution); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(execution)
+}
+
+// ProcessWorkflowExecutions runs the queued workflow executions
+func ProcessWorkflowExecutions() {
+	NewWorkflowHandler(http.DefaultClient, db.DB).processWorkflowExecutions()
+}
+
+func (wh *workflowHandler) processWorkflowExecutions() {
+	for _, execution := range wh.db.GetPendingWorkflowExecutions(workflowJobBatch) {
+		if !wh.db.ClaimWorkflowExecution(execution.ID) {
+			continue
+		}
+		execution = wh.runWorkflowExecution(execution)
+		if err := wh.db.UpdateWorkflowExecution(execution); err != nil {
+			fmt.Println("[workflow] could not save execution", execution.Uuid, err)
+		}
+	}
+}
+
+// workflowRetryDelay is how long a failed step waits before its next attempt
+func workflowRetryDelay(attempts int) time.Duration {
+	return time.Duration(attempts*attempts) * time.Minute
+}
+
+// runWorkflowExecution runs the steps of an execution from its current one, a failed step puts
+// the execution back in the queue until its attempts are used up, then fails it
+func (wh *workflowHandler) runWorkflowExecution(execution db.WorkflowExecution) db.WorkflowExecution {
+	vars := map[string]interface{}{}
+	for key, value := range execution.Input {
+		vars[key] = value
+	}
+	for i := 0; i < execution.CurrentStep && i < len(execution.Steps); i++ {
+		for key, value := range execution.Steps[i].Output {
+			vars[key] = value
+		}
+	}
+
+	for execution.CurrentStep < len(execution.Steps) {
+		step := &execution.Steps[execution.CurrentStep]
+		step.Attempts++
+		output, err := wh.runWorkflowStep(execution, step.Type, expandWorkflowParams(step.Params, vars), vars)
+		now := time.Now()
+		if err != nil {
+			fmt.Println("[workflow]", execution.Uuid, step.Type, err)
+			step.Error = err.Error()
+			// a request Stakwork rejected fails the same way when tried again
+			var stakworkErr *stakwork.Error
+			rejected := errors.As(err, &stakworkErr) && !stakworkErr.Temporary()
+			if step.Attempts >= db.MaxWorkflowStepAttempts || rejected {
+				step.Status = db.WorkflowFailed
+				step.FinishedAt = &now
+				execution.Status = db.WorkflowFailed
+			} else {
+				next := now.Add(workflowRetryDelay(step.Attempts))
+				execution.NextRunAt = &next
+				execution.Status = db.WorkflowPending
+			}
+			return execution
+		}
+
+		step.Status = db.WorkflowSucceeded
+		step.Error = ""
+		step.Output = output
+		step.FinishedAt = &now
+		for key, value := range output {
+			vars[key] = value
+		}
+		execution.CurrentStep++
+	}
+	execution.Status = db.WorkflowSucceeded
+	return execution
+}
+
+// expandWorkflowParams replaces the {{name}} placeholders of the string params with the vars,
+// a param that is only a placeholder keeps the type of its var
+func expandWorkflowParams(params map[string]interface{}, vars map[string]interface{}) map[string]interface{} {
+	expanded := map[string]interface{}{}
+	for key, value := range params {
+		expanded[key] = expandWorkflowValue(value, vars)
+	}
+	return expanded
+}
+
+func expandWorkflowValue(value interface{}, vars map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if match := workflowParamPattern.FindStringSubmatch(v); match != nil && match[0] == v {
+			if found, ok := vars[match[1]]; ok {
+				return found
+			}
+			return ""
+		}
+		return workflowParamPattern.ReplaceAllStringFunc(v, func(placeholder string) string {
+			found, ok := vars[workflowParamPattern.FindStringSubmatch(placeholder)[1]]
+			if !ok {
+				return ""
+			}
+			return fmt.Sprint(found)
+		})
+	case map[string]interface{}:
+		return expandWorkflowParams(v, vars)
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = expandWorkflowValue(item, vars)
+		}
+		return list
+	}
+	return value
+}
+
+func workflowParam(params map[string]interface{}, key string) string {
+	value, ok := params[key]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// runWorkflowStep runs one step with its expanded params, returning the output later steps can read
+func (wh *workflowHandler) runWorkflowStep(execution db.WorkflowExecution, stepType db.WorkflowStepType, params map[string]interface{}, vars map[string]interface{}) (map[string]interface{}, error) {
+	switch stepType {
+	case db.WorkflowCallStakwork:
+		return wh.callStakwork(execution, params, vars)
+	case db.WorkflowCreateTicket:
+		return wh.createWorkflowTicket(params)
+	case db.WorkflowNotify:
+		notifications.Notify(workflowParam(params, "pubkey"), db.NotificationWorkflow, workflowParam(params, "title"), workflowParam(params, "content"), workflowParam(params, "link"))
+		return map[string]interface{}{}, nil
+	case db.WorkflowWebhook:
+		return wh.callWebhook(params, vars)
+	}
+	return nil, fmt.Errorf("unknown step type %s", stepType)
+}
+
+// callStakwork starts a project of a Stakwork workflow, its vars are the vars param or else
+// everything the execution knows so far. The workflow defaults to the one of the AI settings of
+// the workspace, and their model settings are added to the vars the step doesn't set. The project
+// is tracked as a job of the execution that Stakwork completes with its callback
+func (wh *workflowHandler) callStakwork(execution db.WorkflowExecution, params map[string]interface{}, vars map[string]interface{}) (map[string]interface{}, error) {
+	settings := db.WorkspaceAiSettings{}
+	if execution.WorkspaceUuid != "" {
+		settings = wh.db.GetWorkspaceAiSettings(execution.WorkspaceUuid)
+	}
+	workflowId := workflowParam(params, "workflow_id")
+	if workflowId == "" {
+		workflowId = settings.WorkflowId
+	}
+	if workflowId == "" {
+		return nil, errors.New("no stakwork workflow id")
+	}
+	name := workflowParam(params, "name")
+	if name == "" {
+		name = "Sphinx Workflow"
+	}
+	source, ok := params["vars"].(map[string]interface{})
+	if !ok {
+		source = vars
+	}
+	stakworkVars := map[string]interface{}{}
+	for key, value := range source {
+		stakworkVars[key] = value
+	}
+	if _, ok := stakworkVars["model"]; !ok && settings.Model != "" {
+		stakworkVars["model"] = settings.Model
+	}
+	if _, ok := stakworkVars["temperature"]; !ok && settings.Temperature != nil {
+		stakworkVars["temperature"] = *settings.Temperature
+	}
+	if _, ok := stakworkVars["system_prompt"]; !ok && settings.SystemPrompt != "" {
+		stakworkVars["system_prompt"] = settings.SystemPrompt
+	}
+
+	job := db.StakworkJob{Kind: db.StakworkJobWorkflow, RefUuid: execution.Uuid, WorkspaceUuid: execution.WorkspaceUuid}
+	job, result, err := startStakworkJob(wh.db, wh.stakwork, job, stakwork.Project{
+		Name:       name,
+		WorkflowId: workflowId,
+		Vars:       stakworkVars,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"project_id": result.ProjectId, "job_uuid": job.Uuid}, nil
+}
+
+// callWebhook posts the body param, or else everything the execution knows so far, to a url
+func (wh *workflowHandler) callWebhook(params map[string]interface{}, vars map[string]interface{}) (map[string]interface{}, error) {
+	body, ok := params["body"]
+	if !ok {
+		body = vars
+	}
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, workflowParam(params, "url"), bytes.NewBuffer(buf))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := wh.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return nil, fmt.Errorf("webhook responded %d", response.StatusCode)
+	}
+	return map[string]interface{}{"webhook_status": response.StatusCode}, nil
+}
+
+// createWorkflowTicket adds a ticket to the wanted list of a person
+func (wh *workflowHandler) createWorkflowTicket(params map[string]interface{}) (map[string]interface{}, error) {
+	pubkey := workflowParam(params, "owner_pubkey")
+	person := wh.db.GetPersonByPubkey(pubkey)
+	if person.ID == 0 {
+		return nil, fmt.Errorf("no person with pubkey %s", pubkey)
+	}
+
+	created := time.Now().Unix()
+	ticket := map[string]interface{}{}
+	for field, value := range params {
+		if field != "owner_pubkey" {
+			ticket[field] = value
+		}
+	}
+	ticket["created"] = float64(created)
+	if ticket["type"] == nil {
+		ticket["type"] = "coding_task"
+	}
+
+	if person.Extras == nil {
+		person.Extras = db.PropertyMap{}
+	}
+	wanteds, _ := person.Extras["wanted"].([]interface{})
+	person.Extras["wanted"] = append(wanteds, ticket)
+	if _, err := wh.db.CreateOrEditPerson(person); err != nil {
+		return nil, err
+	}
+	recordTicketRevision(wh.db, ticketKey(pubkey, created), nil, ticket, pubkey)
+
+	return map[string]interface{}{
+		"ticket_id":   ticketKey(pubkey, created),
+		"ticket_link": ticketLink(pubkey, created),
+	}, nil
+}
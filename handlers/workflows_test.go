@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/stakwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExpandWorkflowParams(t *testing.T) {
+	vars := map[string]interface{}{"bounty_id": float64(7), "title": "Fix the login"}
+	params := map[string]interface{}{
+		"id":    "{{bounty_id}}",
+		"title": "Review {{ title }} ({{missing}})",
+		"vars":  map[string]interface{}{"name": "{{title}}"},
+	}
+
+	expanded := expandWorkflowParams(params, vars)
+	assert.Equal(t, float64(7), expanded["id"])
+	assert.Equal(t, "Review Fix the login ()", expanded["title"])
+	assert.Equal(t, map[string]interface{}{"name": "Fix the login"}, expanded["vars"])
+}
+
+func TestWorkflows(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	wHandler := NewWorkflowHandler(mockHttpClient, mockDb)
+	wHandler.stakwork = stakwork.New(mockHttpClient, stakwork.Options{Key: "key", Breaker: stakwork.NewBreaker(10, time.Minute)})
+	wHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return uuid == "workspace" && (pubKeyFromAuth == "admin" || role == db.ViewReport && pubKeyFromAuth == "viewer")
+	}
+
+	router := chi.NewRouter()
+	router.Post("/workflows", wHandler.CreateOrEditWorkflow)
+	router.Post("/workflows/{uuid}/run", wHandler.RunWorkflow)
+	router.Post("/workflows/executions/{uuid}/retry", wHandler.RetryWorkflowExecution)
+
+	serve := func(url string, body interface{}, pubkey string) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKey, pubkey))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a workflow needs known steps with their params", func(t *testing.T) {
+		rr := serve("/workflows", db.Workflow{WorkspaceUuid: "workspace", Name: "triage", Steps: db.WorkflowSteps{
+			{Type: db.WorkflowNotify, Params: map[string]interface{}{"title": "New bounty"}},
+		}}, "admin")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = serve("/workflows", db.Workflow{WorkspaceUuid: "workspace", Name: "triage", Trigger: "bounty_deleted", Steps: db.WorkflowSteps{
+			{Type: db.WorkflowNotify, Params: map[string]interface{}{"pubkey": "admin", "title": "New bounty"}},
+		}}, "admin")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = serve("/workflows", db.Workflow{WorkspaceUuid: "workspace", Name: "triage", Steps: db.WorkflowSteps{
+			{Type: db.WorkflowWebhook, Params: map[string]interface{}{"url": "ftp://example.com"}},
+		}}, "admin")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should test that workspace admins create workflows", func(t *testing.T) {
+		workflow := db.Workflow{WorkspaceUuid: "workspace", Name: "triage", Trigger: events.BountyPaid, Enabled: true, Steps: db.WorkflowSteps{
+			{Type: db.WorkflowNotify, Params: map[string]interface{}{"pubkey": "admin", "title": "New bounty {{title}}"}},
+		}}
+		rr := serve("/workflows", workflow, "viewer")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		mockDb.On("CreateOrEditWorkflow", mock.MatchedBy(func(w db.Workflow) bool {
+			return w.Name == "triage" && w.CreatedBy == "admin" && len(w.Steps) == 1
+		})).Return(db.Workflow{Uuid: "workflow", WorkspaceUuid: "workspace", Name: "triage"}, nil).Once()
+		rr = serve("/workflows", workflow, "admin")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a manual run queues an execution with the input", func(t *testing.T) {
+		workflow := db.Workflow{Uuid: "workflow", WorkspaceUuid: "workspace"}
+		mockDb.On("GetWorkflowByUuid", "workflow").Return(workflow).Once()
+		mockDb.On("CreateWorkflowExecution", workflow, db.WorkflowTriggerManual, db.PropertyMap{"title": "Fix"}).
+			Return(db.WorkflowExecution{Uuid: "execution", Status: db.WorkflowPending}, nil).Once()
+
+		rr := serve("/workflows/workflow/run", map[string]interface{}{"title": "Fix"}, "admin")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that only a failed execution is retried, from its failed step", func(t *testing.T) {
+		execution := db.WorkflowExecution{Uuid: "execution", WorkspaceUuid: "workspace", Status: db.WorkflowSucceeded, CurrentStep: 1,
+			Steps: db.WorkflowStepStates{{Status: db.WorkflowSucceeded, Attempts: 1}}}
+		mockDb.On("GetWorkflowExecution", "execution").Return(execution).Once()
+		rr := serve("/workflows/executions/execution/retry", nil, "admin")
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		execution = db.WorkflowExecution{Uuid: "execution", WorkspaceUuid: "workspace", Status: db.WorkflowFailed, CurrentStep: 1,
+			Steps: db.WorkflowStepStates{{Status: db.WorkflowSucceeded, Attempts: 1}, {Status: db.WorkflowFailed, Attempts: 3, Error: "down"}}}
+		mockDb.On("GetWorkflowExecution", "execution").Return(execution).Once()
+		mockDb.On("UpdateWorkflowExecution", mock.MatchedBy(func(e db.WorkflowExecution) bool {
+			return e.Status == db.WorkflowPending && e.CurrentStep == 1 && e.Steps[1].Attempts == 0 && e.Steps[1].Error == "" && e.NextRunAt != nil
+		})).Return(nil).Once()
+		rr = serve("/workflows/executions/execution/retry", nil, "admin")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that the steps run in order and pass their output on", func(t *testing.T) {
+		execution := db.WorkflowExecution{ID: 1, Uuid: "execution", Status: db.WorkflowPending, Input: db.PropertyMap{"title": "Fix"}, Steps: db.WorkflowStepStates{
+			{Type: db.WorkflowCreateTicket, Params: map[string]interface{}{"owner_pubkey": "owner", "title": "{{title}}"}},
+			{Type: db.WorkflowCallStakwork, Params: map[string]interface{}{"workflow_id": "42", "vars": map[string]interface{}{"ticket": "{{ticket_link}}"}}},
+		}}
+		mockDb.On("GetPendingWorkflowExecutions", workflowJobBatch).Return([]db.WorkflowExecution{execution}).Once()
+		mockDb.On("ClaimWorkflowExecution", uint(1)).Return(true).Once()
+		mockDb.On("GetPersonByPubkey", "owner").Return(db.Person{ID: 1, OwnerPubKey: "owner"}).Once()
+		mockDb.On("CreateOrEditPerson", mock.MatchedBy(func(p db.Person) bool {
+			wanteds, _ := p.Extras["wanted"].([]interface{})
+			return len(wanteds) == 1 && wanteds[0].(map[string]interface{})["title"] == "Fix"
+		})).Return(db.Person{}, nil).Once()
+		mockDb.On("CreateTicketRevision", mock.AnythingOfType("db.TicketRevision")).Return(db.TicketRevision{}, nil).Once()
+		mockDb.On("CreateStakworkJob", db.StakworkJob{Kind: db.StakworkJobWorkflow, RefUuid: "execution"}).
+			Return(db.StakworkJob{ID: 1, Uuid: "job", Token: "token", Kind: db.StakworkJobWorkflow, RefUuid: "execution", Status: db.StakworkJobProcessing}, nil).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			reader, _ := req.GetBody()
+			body, _ := io.ReadAll(reader)
+			return req.URL.String() == stakwork.ProjectsUrl && req.Header.Get("Authorization") == "Token token=key" &&
+				bytes.Contains(body, []byte(`"workflow_id":"42"`)) && bytes.Contains(body, []byte(`/ticket/owner/`)) &&
+				bytes.Contains(body, []byte(`/webhooks/stakwork/token"`))
+		})).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success":true,"data":{"project_id":1234}}`))),
+		}, nil).Once()
+		mockDb.On("UpdateStakworkJob", mock.MatchedBy(func(job db.StakworkJob) bool {
+			return job.Uuid == "job" && job.ProjectId == "1234" && job.Status == db.StakworkJobProcessing
+		})).Return(nil).Once()
+		mockDb.On("UpdateWorkflowExecution", mock.MatchedBy(func(e db.WorkflowExecution) bool {
+			return e.Status == db.WorkflowSucceeded && e.CurrentStep == 2 && e.Steps[1].Output["project_id"] == "1234" &&
+				e.Steps[1].Output["job_uuid"] == "job"
+		})).Return(nil).Once()
+
+		wHandler.processWorkflowExecutions()
+	})
+
+	t.Run("Should test that a Stakwork step uses the AI settings of the workspace", func(t *testing.T) {
+		temperature := 0.2
+		mockDb.On("GetWorkspaceAiSettings", "workspace").Return(db.WorkspaceAiSettings{WorkflowId: "77", Model: "gpt-4o", Temperature: &temperature}).Once()
+		mockDb.On("CreateStakworkJob", mock.AnythingOfType("db.StakworkJob")).Return(db.StakworkJob{ID: 2, Uuid: "job"}, nil).Once()
+		mockDb.On("UpdateStakworkJob", mock.AnythingOfType("db.StakworkJob")).Return(nil).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			reader, _ := req.GetBody()
+			body, _ := io.ReadAll(reader)
+			return bytes.Contains(body, []byte(`"workflow_id":"77"`)) && bytes.Contains(body, []byte(`"model":"gpt-4o"`)) &&
+				bytes.Contains(body, []byte(`"temperature":0.5`))
+		})).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success":true,"data":{"project_id":1}}`))),
+		}, nil).Once()
+
+		output, err := wHandler.callStakwork(db.WorkflowExecution{Uuid: "execution", WorkspaceUuid: "workspace"}, map[string]interface{}{}, map[string]interface{}{"temperature": 0.5})
+		assert.NoError(t, err)
+		assert.Equal(t, "1", output["project_id"])
+	})
+
+	t.Run("Should test that a failed step is retried later then fails the execution", func(t *testing.T) {
+		steps := func(attempts int) db.WorkflowStepStates {
+			return db.WorkflowStepStates{{Type: db.WorkflowCallStakwork, Params: map[string]interface{}{"workflow_id": "42"}, Attempts: attempts}}
+		}
+		mockHttpClient.On("Do", mock.Anything).Return(nil, errors.New("stakwork is down")).Twice()
+		mockDb.On("CreateStakworkJob", mock.AnythingOfType("db.StakworkJob")).Return(db.StakworkJob{ID: 3, Uuid: "job"}, nil).Twice()
+		mockDb.On("UpdateStakworkJob", mock.MatchedBy(func(job db.StakworkJob) bool {
+			return job.Status == db.StakworkJobFailed && job.Error != ""
+		})).Return(nil).Twice()
+
+		execution := wHandler.runWorkflowExecution(db.WorkflowExecution{Status: db.WorkflowRunning, Steps: steps(0)})
+		assert.Equal(t, db.WorkflowPending, execution.Status)
+		assert.Equal(t, 1, execution.Steps[0].Attempts)
+		assert.NotNil(t, execution.NextRunAt)
+
+		execution = wHandler.runWorkflowExecution(db.WorkflowExecution{Status: db.WorkflowRunning, Steps: steps(db.MaxWorkflowStepAttempts - 1)})
+		assert.Equal(t, db.WorkflowFailed, execution.Status)
+		assert.Equal(t, db.WorkflowFailed, execution.Steps[0].Status)
+		assert.Contains(t, execution.Steps[0].Error, "stakwork is down")
+	})
+
+	t.Run("Should test that a request Stakwork rejects fails the step at once", func(t *testing.T) {
+		mockHttpClient.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: 422,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"error":"unknown workflow"}`))),
+		}, nil).Once()
+		mockDb.On("CreateStakworkJob", mock.AnythingOfType("db.StakworkJob")).Return(db.StakworkJob{ID: 4, Uuid: "job"}, nil).Once()
+		mockDb.On("UpdateStakworkJob", mock.AnythingOfType("db.StakworkJob")).Return(nil).Once()
+
+		execution := wHandler.runWorkflowExecution(db.WorkflowExecution{Status: db.WorkflowRunning, Steps: db.WorkflowStepStates{
+			{Type: db.WorkflowCallStakwork, Params: map[string]interface{}{"workflow_id": "42"}},
+		}})
+		assert.Equal(t, db.WorkflowFailed, execution.Status)
+		assert.Equal(t, 1, execution.Steps[0].Attempts)
+	})
+}
+
+func TestTriggerWorkflows(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	workflowStore = mockDb
+	defer func() { workflowStore = nil }()
+
+	t.Run("Should test that an event queues the workflows of its workspace with its payload", func(t *testing.T) {
+		workflow := db.Workflow{Uuid: "workflow", WorkspaceUuid: "workspace", Trigger: events.BountyPaid}
+		payload := map[string]interface{}{"bounty_id": 1}
+		mockDb.On("GetTriggeredWorkflows", "workspace", events.BountyPaid).Return([]db.Workflow{workflow}).Once()
+		mockDb.On("CreateWorkflowExecution", workflow, events.BountyPaid, db.PropertyMap(payload)).Return(db.WorkflowExecution{}, nil).Once()
+
+		triggerWorkflows(events.Event{Name: events.BountyPaid, WorkspaceUuid: "workspace", Payload: payload})
+	})
+
+	t.Run("Should test that a ticket event finds its workspace from the phase of the ticket", func(t *testing.T) {
+		mockDb.On("GetPhaseByUuid", "phase").Return(db.FeaturePhase{Uuid: "phase", FeatureUuid: "feature"}, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature").Return(db.WorkspaceFeatures{Uuid: "feature", WorkspaceUuid: "workspace"}).Once()
+		mockDb.On("GetTriggeredWorkflows", "workspace", events.TicketCompleted).Return([]db.Workflow{}).Once()
+
+		triggerWorkflows(events.Event{Name: events.TicketCompleted, Payload: map[string]interface{}{"phase_uuid": "phase"}})
+	})
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+)
+
+// ArchivedWorkspacesLoader reads the uuids of the archived workspaces, it is set on startup.
+// Without it no workspace is archived
+var ArchivedWorkspacesLoader func() ([]string, error)
+
+// ArchivedWorkspacesCacheTTL is how long the loaded workspaces are kept before reading them again
+const ArchivedWorkspacesCacheTTL = time.Minute
+
+var archivedWorkspacesCache = struct {
+	sync.Mutex
+	uuids  map[string]bool
+	loaded time.Time
+}{}
+
+func archivedWorkspaces() map[string]bool {
+	if ArchivedWorkspacesLoader == nil {
+		return nil
+	}
+
+	archivedWorkspacesCache.Lock()
+	defer archivedWorkspacesCache.Unlock()
+
+	if archivedWorkspacesCache.uuids == nil || time.Since(archivedWorkspacesCache.loaded) > ArchivedWorkspacesCacheTTL {
+		uuids, err := ArchivedWorkspacesLoader()
+		if err != nil {
+			fmt.Println("[workspaces] could not load archived workspaces", err)
+			return archivedWorkspacesCache.uuids
+		}
+		archivedWorkspacesCache.uuids = map[string]bool{}
+		for _, uuid := range uuids {
+			archivedWorkspacesCache.uuids[uuid] = true
+		}
+		archivedWorkspacesCache.loaded = time.Now()
+	}
+	return archivedWorkspacesCache.uuids
+}
+
+// RefreshArchivedWorkspaces drops the cached workspaces so the next check reads them again
+func RefreshArchivedWorkspaces() {
+	archivedWorkspacesCache.Lock()
+	archivedWorkspacesCache.uuids = nil
+	archivedWorkspacesCache.Unlock()
+}
+
+// IsWorkspaceArchived is true while a workspace is archived and can only be read
+func IsWorkspaceArchived(uuid string) bool {
+	return uuid != "" && archivedWorkspaces()[uuid]
+}
+
+// rejectArchivedWorkspace answers with a 403 and returns true when the workspace is archived
+func rejectArchivedWorkspace(w http.ResponseWriter, uuid string) bool {
+	if !IsWorkspaceArchived(uuid) {
+		return false
+	}
+	fmt.Println("[workspaces] change to archived workspace", uuid)
+	w.WriteHeader(http.StatusForbidden)
+	json.NewEncoder(w).Encode("Workspace is archived")
+	return true
+}
+
+// isArchivedWorkspaceOf is IsWorkspaceArchived for a workspace that has to be looked up, the
+// lookup is skipped while no workspace is archived
+func isArchivedWorkspaceOf(workspaceUuid func() string) bool {
+	return len(archivedWorkspaces()) > 0 && IsWorkspaceArchived(workspaceUuid())
+}
+
+// rejectArchivedWorkspaceOf is rejectArchivedWorkspace for writes that look up their workspace
+func rejectArchivedWorkspaceOf(w http.ResponseWriter, workspaceUuid func() string) bool {
+	if len(archivedWorkspaces()) == 0 {
+		return false
+	}
+	return rejectArchivedWorkspace(w, workspaceUuid())
+}
+
+func (oh *workspaceHandler) LoadArchivedWorkspaces() ([]string, error) {
+	return oh.db.GetArchivedWorkspaceUuids(), nil
+}
+
+// ArchiveWorkspace makes a workspace read only, only its owner can archive it
+func (oh *workspaceHandler) ArchiveWorkspace(w http.ResponseWriter, r *http.Request) {
+	oh.setWorkspaceArchived(w, r, true)
+}
+
+// UnarchiveWorkspace lets a workspace be changed again
+func (oh *workspaceHandler) UnarchiveWorkspace(w http.ResponseWriter, r *http.Request) {
+	oh.setWorkspaceArchived(w, r, false)
+}
+
+func (oh *workspaceHandler) setWorkspaceArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "workspace_uuid")
+	workspace := oh.db.GetWorkspaceByUuid(uuid)
+	if workspace.ID == 0 || workspace.Deleted {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+
+	if pubKeyFromAuth != workspace.OwnerPubKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the workspace owner can archive it")
+		return
+	}
+
+	if workspace.Archived == archived {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(workspace)
+		return
+	}
+
+	workspace, err := oh.db.ArchiveWorkspace(uuid, archived, pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[workspaces] could not archive workspace", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not archive workspace")
+		return
+	}
+	RefreshArchivedWorkspaces()
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(workspace)
+}
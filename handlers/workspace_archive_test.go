@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkspaceArchive(t *testing.T) {
+	archived := []string{}
+	ArchivedWorkspacesLoader = func() ([]string, error) { return archived, nil }
+	RefreshArchivedWorkspaces()
+	defer func() {
+		ArchivedWorkspacesLoader = nil
+		RefreshArchivedWorkspaces()
+	}()
+
+	request := func(method string, pubkey string, params map[string]string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		for key, value := range params {
+			rctx.URLParams.Add(key, value)
+		}
+		req := httptest.NewRequest(method, "/", bytes.NewBufferString(body))
+		return req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, pubkey))
+	}
+
+	t.Run("Should test that only the owner archives a workspace", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceByUuid", "ws").Return(db.Workspace{ID: 1, Uuid: "ws", OwnerPubKey: "owner"})
+
+		rr := httptest.NewRecorder()
+		oh.ArchiveWorkspace(rr, request(http.MethodPost, "member", map[string]string{"workspace_uuid": "ws"}, ""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		mockDb.On("ArchiveWorkspace", "ws", true, "owner").Run(func(args mock.Arguments) {
+			archived = []string{"ws"}
+		}).Return(db.Workspace{ID: 1, Uuid: "ws", OwnerPubKey: "owner", Archived: true}, nil).Once()
+		rr = httptest.NewRecorder()
+		oh.ArchiveWorkspace(rr, request(http.MethodPost, "owner", map[string]string{"workspace_uuid": "ws"}, ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, IsWorkspaceArchived("ws"))
+		assert.False(t, IsWorkspaceArchived("other"))
+	})
+
+	t.Run("Should test that an archived workspace can't get bounties or payments", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		bh := NewBountyHandler(nil, mockDb)
+		mockDb.On("GetBounty", uint(7)).Return(db.NewBounty{ID: 7, WorkspaceUuid: "ws", Price: 100})
+
+		rr := httptest.NewRecorder()
+		bh.CreateOrEditBounty(rr, request(http.MethodPost, "owner", nil, `{"workspace_uuid": "ws", "type": "coding", "title": "Build", "description": "it"}`))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = httptest.NewRecorder()
+		bh.MakeBountyPayment(rr, request(http.MethodPost, "owner", map[string]string{"id": "7"}, ""))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = httptest.NewRecorder()
+		bh.NewBountyBudgetWithdraw(rr, request(http.MethodPost, "owner", nil, `{"workspace_uuid": "ws", "payment_request": "lnbc"}`))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("Should test that an archived workspace can't get features, proofs, tickets or retried payments", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		fh := NewFeatureHandler(mockDb)
+		bh := NewBountyHandler(nil, mockDb)
+		th := NewTicketHandler(mockDb)
+		mockDb.On("GetFeatureByUuid", "feat").Return(db.WorkspaceFeatures{Uuid: "feat", WorkspaceUuid: "ws"})
+		mockDb.On("GetBounty", uint(7)).Return(db.NewBounty{ID: 7, WorkspaceUuid: "ws", Assignee: "hunter", Price: 100})
+
+		rr := httptest.NewRecorder()
+		fh.DeleteFeature(rr, request(http.MethodDelete, "owner", map[string]string{"uuid": "feat"}, ""))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = httptest.NewRecorder()
+		bh.SubmitBountyProof(rr, request(http.MethodPost, "hunter", map[string]string{"id": "7"}, `{"description": "done"}`))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		mockDb.On("GetPersonByPubkey", "owner").Return(db.Person{OwnerPubKey: "owner", Extras: db.PropertyMap{"wanted": []interface{}{map[string]interface{}{"created": float64(1)}}}})
+		mockDb.On("GetTicketCard", "owner:1").Return(db.TicketCard{TicketId: "owner:1", PhaseUuid: "phase"})
+		mockDb.On("GetPhaseByUuid", "phase").Return(db.FeaturePhase{Uuid: "phase", FeatureUuid: "feat"}, nil)
+		rr = httptest.NewRecorder()
+		th.CreateTicketComment(rr, request(http.MethodPost, "owner", map[string]string{"pubKey": "owner", "created": "1"}, `{"body": "hi"}`))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		mockDb.On("CreatePaymentAttempt", mock.MatchedBy(func(attempt db.PaymentAttempt) bool {
+			return attempt.Status == db.PaymentAttemptCancelled && attempt.Attempt == 2
+		})).Return(db.PaymentAttempt{}, nil).Once()
+		bh.retryBountyPayment(db.PaymentAttempt{BountyId: 7, WorkspaceUuid: "ws", ReceiverPubKey: "hunter", Amount: 100, Attempt: 1})
+	})
+
+	t.Run("Should test that an unarchived workspace can be changed again", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceByUuid", "ws").Return(db.Workspace{ID: 1, Uuid: "ws", OwnerPubKey: "owner", Archived: true})
+		mockDb.On("ArchiveWorkspace", "ws", false, "owner").Run(func(args mock.Arguments) {
+			archived = []string{}
+		}).Return(db.Workspace{ID: 1, Uuid: "ws", OwnerPubKey: "owner"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		oh.UnarchiveWorkspace(rr, request(http.MethodPost, "owner", map[string]string{"workspace_uuid": "ws"}, ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.False(t, IsWorkspaceArchived("ws"))
+	})
+}
@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const maxWorkspaceBundleBytes = 20 << 20
+
+// ExportWorkspace returns a versioned backup of a workspace with its features, phases, tickets,
+// bounties, members, roles and budget history, for its owner
+func (oh *workspaceHandler) ExportWorkspace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "workspace_uuid")
+	workspace := oh.db.GetWorkspaceByUuid(uuid)
+	if workspace.ID == 0 || workspace.Deleted {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+	if workspace.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the workspace owner can export it")
+		return
+	}
+
+	bundle := oh.db.GetWorkspaceBundle(uuid)
+	people := map[string]db.Person{}
+	for i, ticket := range bundle.Tickets {
+		owner, created, ok := parseTicketKey(ticket.TicketId)
+		if !ok {
+			continue
+		}
+		if _, ok := people[owner]; !ok {
+			people[owner] = oh.db.GetPersonByPubkey(owner)
+		}
+		bundle.Tickets[i].Ticket = findTicket(people[owner], created)
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="workspace-%s.json"`, uuid))
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bundle)
+}
+
+// remapWorkspaceBundle checks that the parts of a bundle point at each other and gives the
+// workspace, its features, stories and phases new uuids owned by the importer. The budget is not
+// carried over, its sats stay on the deployment the workspace was exported from
+func remapWorkspaceBundle(bundle db.WorkspaceBundle, owner string, name string) (db.WorkspaceBundle, []string) {
+	errs := []string{}
+	if bundle.Version != db.WorkspaceBundleVersion {
+		errs = append(errs, fmt.Sprintf("unsupported bundle version %d, expected %d", bundle.Version, db.WorkspaceBundleVersion))
+		return bundle, errs
+	}
+
+	now := time.Now()
+	workspace := bundle.Workspace
+	workspace.ID = 0
+	workspace.Uuid = xid.New().String()
+	workspace.OwnerPubKey = owner
+	workspace.Deleted = false
+	workspace.Archived = false
+	workspace.ArchivedAt = nil
+	workspace.ArchivedBy = ""
+	workspace.Created = &now
+	workspace.Updated = &now
+	if name != "" {
+		workspace.Name = name
+	}
+	workspace.Name = strings.TrimSpace(workspace.Name)
+	if len(workspace.Name) == 0 || len(workspace.Name) > 20 {
+		errs = append(errs, "workspace name must be present and should not exceed 20 character")
+	}
+	bundle.Workspace = workspace
+
+	for i := range bundle.Repositories {
+		bundle.Repositories[i].ID = 0
+		bundle.Repositories[i].Uuid = xid.New().String()
+		bundle.Repositories[i].WorkspaceUuid = workspace.Uuid
+	}
+
+	features := map[string]string{}
+	for i, feature := range bundle.Features {
+		if _, ok := features[feature.Uuid]; ok || feature.Uuid == "" {
+			errs = append(errs, fmt.Sprintf("feature %q is missing or repeated", feature.Uuid))
+		}
+		features[feature.Uuid] = xid.New().String()
+		bundle.Features[i].ID = 0
+		bundle.Features[i].Uuid = features[feature.Uuid]
+		bundle.Features[i].WorkspaceUuid = workspace.Uuid
+	}
+	for i, story := range bundle.Stories {
+		featureUuid, ok := features[story.FeatureUuid]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("story %q is of unknown feature %q", story.Uuid, story.FeatureUuid))
+		}
+		bundle.Stories[i].ID = 0
+		bundle.Stories[i].Uuid = xid.New().String()
+		bundle.Stories[i].FeatureUuid = featureUuid
+	}
+
+	phases := map[string]string{}
+	for i, phase := range bundle.Phases {
+		featureUuid, ok := features[phase.FeatureUuid]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("phase %q is of unknown feature %q", phase.Uuid, phase.FeatureUuid))
+		}
+		if _, ok := phases[phase.Uuid]; ok || phase.Uuid == "" {
+			errs = append(errs, fmt.Sprintf("phase %q is missing or repeated", phase.Uuid))
+		}
+		phases[phase.Uuid] = xid.New().String()
+		bundle.Phases[i].Uuid = phases[phase.Uuid]
+		bundle.Phases[i].FeatureUuid = featureUuid
+	}
+	for i, ticket := range bundle.Tickets {
+		phaseUuid, ok := phases[ticket.PhaseUuid]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("ticket %q is on unknown phase %q", ticket.TicketId, ticket.PhaseUuid))
+		}
+		bundle.Tickets[i].ID = 0
+		bundle.Tickets[i].PhaseUuid = phaseUuid
+		bundle.Tickets[i].Version = 1
+	}
+
+	members := map[string]bool{owner: true}
+	for i, user := range bundle.Users {
+		members[user.OwnerPubKey] = true
+		bundle.Users[i].ID = 0
+		bundle.Users[i].WorkspaceUuid = workspace.Uuid
+	}
+	for i := range bundle.Roles {
+		bundle.Roles[i].WorkspaceUuid = workspace.Uuid
+	}
+
+	bounties := map[uint]bool{}
+	for i, bounty := range bundle.Bounties {
+		if bounty.ID == 0 || bounties[bounty.ID] {
+			errs = append(errs, fmt.Sprintf("bounty %d is missing or repeated", bounty.ID))
+		}
+		bounties[bounty.ID] = true
+		if bounty.PhaseUuid != "" {
+			phaseUuid, ok := phases[bounty.PhaseUuid]
+			if !ok {
+				errs = append(errs, fmt.Sprintf("bounty %d is on unknown phase %q", bounty.ID, bounty.PhaseUuid))
+			}
+			bundle.Bounties[i].PhaseUuid = phaseUuid
+		}
+		// bounties are only kept on people that come along with the workspace
+		if !members[bounty.OwnerID] {
+			bundle.Bounties[i].OwnerID = owner
+		}
+		// escrowed sats don't move with the workspace either
+		if !bounty.Paid {
+			bundle.Bounties[i].EscrowStatus = ""
+		}
+		bundle.Bounties[i].WorkspaceUuid = workspace.Uuid
+	}
+	for i, payment := range bundle.BudgetHistory {
+		if payment.BountyId != 0 && !bounties[payment.BountyId] {
+			errs = append(errs, fmt.Sprintf("payment %d is for unknown bounty %d", payment.ID, payment.BountyId))
+		}
+		bundle.BudgetHistory[i].ID = 0
+		bundle.BudgetHistory[i].WorkspaceUuid = workspace.Uuid
+	}
+
+	bundle.Budget = db.NewBountyBudget{WorkspaceUuid: workspace.Uuid, Created: &now, Updated: &now}
+	return bundle, errs
+}
+
+// ImportWorkspace creates a workspace owned by the user from an exported bundle, ?name= renames it
+// and ?dry_run=true only checks it. Tickets are placed back on their boards when they exist on
+// this deployment, the user's own missing tickets are restored to their profile
+func (oh *workspaceHandler) ImportWorkspace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWorkspaceBundleBytes))
+	r.Body.Close()
+	if err != nil {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Imports are limited to %d bytes", maxWorkspaceBundleBytes))
+		return
+	}
+	bundle := db.WorkspaceBundle{}
+	if err := json.Unmarshal(body, &bundle); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	bundle, errs := remapWorkspaceBundle(bundle, pubKeyFromAuth, strings.TrimSpace(r.URL.Query().Get("name")))
+	if len(errs) == 0 && oh.db.GetWorkspaceByName(bundle.Workspace.Name).ID != 0 {
+		errs = append(errs, fmt.Sprintf("workspace name already exists - %s, import it with ?name=", bundle.Workspace.Name))
+	}
+
+	result := db.WorkspaceImportResult{
+		Workspace: bundle.Workspace,
+		Errors:    errs,
+		Skipped:   []string{},
+		DryRun:    r.URL.Query().Get("dry_run") == "true",
+	}
+	if len(errs) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+
+	tickets, people, skipped := oh.resolveBundleTickets(bundle.Tickets, pubKeyFromAuth)
+	bundle.Tickets = tickets
+	result.Skipped = skipped
+	result.Counts = map[string]int{
+		"repositories":   len(bundle.Repositories),
+		"features":       len(bundle.Features),
+		"stories":        len(bundle.Stories),
+		"phases":         len(bundle.Phases),
+		"tickets":        len(bundle.Tickets),
+		"bounties":       len(bundle.Bounties),
+		"users":          len(bundle.Users),
+		"roles":          len(bundle.Roles),
+		"budget_history": len(bundle.BudgetHistory),
+	}
+
+	if !result.DryRun {
+		workspace, err := oh.db.ImportWorkspaceBundle(bundle, people)
+		if err != nil {
+			fmt.Println("[workspaces] import failed", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(err.Error())
+			return
+		}
+		result.Workspace = workspace
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// resolveBundleTickets keeps the tickets of a bundle that can be placed on a board here, with the
+// profiles of the user to save when their own tickets have to be restored
+func (oh *workspaceHandler) resolveBundleTickets(tickets []db.WorkspaceBundleTicket, importer string) ([]db.WorkspaceBundleTicket, []db.Person, []string) {
+	kept := []db.WorkspaceBundleTicket{}
+	skipped := []string{}
+	people := map[string]db.Person{}
+	restored := false
+
+	for _, ticket := range tickets {
+		owner, created, ok := parseTicketKey(ticket.TicketId)
+		if !ok {
+			skipped = append(skipped, fmt.Sprintf("ticket %q has an invalid id", ticket.TicketId))
+			continue
+		}
+		if oh.db.GetTicketCard(ticket.TicketId).ID != 0 {
+			skipped = append(skipped, fmt.Sprintf("ticket %q is already on a board", ticket.TicketId))
+			continue
+		}
+		if _, ok := people[owner]; !ok {
+			people[owner] = oh.db.GetPersonByPubkey(owner)
+		}
+		person := people[owner]
+
+		if findTicket(person, created) == nil {
+			if owner != importer || person.ID == 0 || ticket.Ticket == nil {
+				skipped = append(skipped, fmt.Sprintf("ticket %q is not on this deployment", ticket.TicketId))
+				continue
+			}
+			entry := map[string]interface{}{}
+			for k, v := range ticket.Ticket {
+				entry[k] = v
+			}
+			entry["created"] = float64(created)
+			if person.Extras == nil {
+				person.Extras = db.PropertyMap{}
+			}
+			wanteds, _ := person.Extras["wanted"].([]interface{})
+			person.Extras["wanted"] = append(wanteds, entry)
+			people[owner] = person
+			restored = true
+		}
+		ticket.Ticket = nil
+		kept = append(kept, ticket)
+	}
+
+	updated := []db.Person{}
+	if restored {
+		updated = append(updated, people[importer])
+	}
+	return kept, updated, skipped
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testWorkspaceBundle() db.WorkspaceBundle {
+	return db.WorkspaceBundle{
+		Version:   db.WorkspaceBundleVersion,
+		Workspace: db.Workspace{ID: 9, Uuid: "old_ws", Name: "Backup", OwnerPubKey: "old_owner"},
+		Features:  []db.WorkspaceFeatures{{ID: 1, Uuid: "feature", WorkspaceUuid: "old_ws", Name: "Search"}},
+		Stories:   []db.FeatureStory{{ID: 1, Uuid: "story", FeatureUuid: "feature"}},
+		Phases:    []db.FeaturePhase{{Uuid: "phase", FeatureUuid: "feature", Name: "MVP"}},
+		Tickets: []db.WorkspaceBundleTicket{
+			{TicketCard: db.TicketCard{ID: 4, TicketId: "importer:100", PhaseUuid: "phase", Lane: db.TicketLaneTodo, Rank: "i", Version: 3}, Ticket: map[string]interface{}{"title": "Index people", "created": float64(100)}},
+			{TicketCard: db.TicketCard{ID: 5, TicketId: "stranger:200", PhaseUuid: "phase", Lane: db.TicketLaneTodo, Rank: "r", Version: 1}, Ticket: map[string]interface{}{"title": "Elsewhere"}},
+		},
+		Bounties: []db.NewBounty{
+			{ID: 7, OwnerID: "member", PhaseUuid: "phase", WorkspaceUuid: "old_ws", Price: 1000, EscrowStatus: db.EscrowFunded},
+			{ID: 8, OwnerID: "gone", WorkspaceUuid: "old_ws", Price: 500, Paid: true, EscrowStatus: db.EscrowReleased},
+		},
+		Users:         []db.WorkspaceUsers{{ID: 3, OwnerPubKey: "member", WorkspaceUuid: "old_ws"}},
+		Roles:         []db.WorkspaceUserRoles{{Role: "ADD BOUNTY", OwnerPubKey: "member", WorkspaceUuid: "old_ws"}},
+		Budget:        db.NewBountyBudget{ID: 2, WorkspaceUuid: "old_ws", TotalBudget: 5000},
+		BudgetHistory: []db.NewPaymentHistory{{ID: 11, BountyId: 8, Amount: 500, WorkspaceUuid: "old_ws"}, {ID: 12, Amount: 5500, WorkspaceUuid: "old_ws"}},
+	}
+}
+
+func TestRemapWorkspaceBundle(t *testing.T) {
+	t.Run("Should test that a bundle is given new ids that point at each other", func(t *testing.T) {
+		bundle, errs := remapWorkspaceBundle(testWorkspaceBundle(), "importer", "")
+		assert.Empty(t, errs)
+
+		ws := bundle.Workspace
+		assert.NotEqual(t, "old_ws", ws.Uuid)
+		assert.Equal(t, uint(0), ws.ID)
+		assert.Equal(t, "importer", ws.OwnerPubKey)
+		assert.Equal(t, ws.Uuid, bundle.Features[0].WorkspaceUuid)
+		assert.NotEqual(t, "feature", bundle.Features[0].Uuid)
+		assert.Equal(t, bundle.Features[0].Uuid, bundle.Stories[0].FeatureUuid)
+		assert.Equal(t, bundle.Features[0].Uuid, bundle.Phases[0].FeatureUuid)
+		assert.Equal(t, bundle.Phases[0].Uuid, bundle.Tickets[0].PhaseUuid)
+		assert.Equal(t, bundle.Phases[0].Uuid, bundle.Bounties[0].PhaseUuid)
+		assert.Equal(t, 1, bundle.Tickets[0].Version)
+
+		assert.Equal(t, "member", bundle.Bounties[0].OwnerID)
+		assert.Equal(t, "importer", bundle.Bounties[1].OwnerID)
+		assert.Equal(t, db.EscrowStatus(""), bundle.Bounties[0].EscrowStatus)
+		assert.Equal(t, db.EscrowReleased, bundle.Bounties[1].EscrowStatus)
+		// bounty ids are remapped on insert, the history keeps the old ones until then
+		assert.Equal(t, uint(8), bundle.BudgetHistory[0].BountyId)
+		assert.Equal(t, ws.Uuid, bundle.Users[0].WorkspaceUuid)
+		assert.Equal(t, ws.Uuid, bundle.Roles[0].WorkspaceUuid)
+		assert.Equal(t, uint(0), bundle.Budget.TotalBudget)
+	})
+
+	t.Run("Should test that broken references and other versions are refused", func(t *testing.T) {
+		bundle := testWorkspaceBundle()
+		bundle.Phases[0].FeatureUuid = "missing"
+		bundle.Bounties[0].PhaseUuid = "missing"
+		bundle.BudgetHistory[0].BountyId = 99
+		_, errs := remapWorkspaceBundle(bundle, "importer", "")
+		assert.Len(t, errs, 3)
+
+		bundle = testWorkspaceBundle()
+		bundle.Version = 2
+		_, errs = remapWorkspaceBundle(bundle, "importer", "")
+		assert.Len(t, errs, 1)
+
+		_, errs = remapWorkspaceBundle(testWorkspaceBundle(), "importer", "a name that is much too long")
+		assert.Len(t, errs, 1)
+	})
+}
+
+func TestWorkspaceExportImport(t *testing.T) {
+	withPubkey := func(req *http.Request, pubkey string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", "old_ws")
+		return req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, pubkey))
+	}
+
+	t.Run("Should test that only the owner exports a workspace with its tickets", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		bundle := testWorkspaceBundle()
+		bundle.Tickets[0].Ticket = nil
+		mockDb.On("GetWorkspaceByUuid", "old_ws").Return(db.Workspace{ID: 9, Uuid: "old_ws", OwnerPubKey: "owner"})
+		mockDb.On("GetWorkspaceBundle", "old_ws").Return(bundle).Once()
+		mockDb.On("GetPersonByPubkey", "importer").Return(db.Person{ID: 1, Extras: db.PropertyMap{"wanted": []interface{}{map[string]interface{}{"title": "Index people", "created": float64(100)}}}})
+		mockDb.On("GetPersonByPubkey", "stranger").Return(db.Person{})
+
+		rr := httptest.NewRecorder()
+		oh.ExportWorkspace(rr, withPubkey(httptest.NewRequest(http.MethodGet, "/workspaces/old_ws/export", nil), "member"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		rr = httptest.NewRecorder()
+		oh.ExportWorkspace(rr, withPubkey(httptest.NewRequest(http.MethodGet, "/workspaces/old_ws/export", nil), "owner"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		exported := db.WorkspaceBundle{}
+		json.Unmarshal(rr.Body.Bytes(), &exported)
+		assert.Equal(t, db.WorkspaceBundleVersion, exported.Version)
+		assert.Equal(t, "Index people", exported.Tickets[0].Ticket["title"])
+	})
+
+	t.Run("Should test that an import restores the importer's tickets and skips unknown ones", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceByName", "Copy").Return(db.Workspace{})
+		mockDb.On("GetTicketCard", mock.Anything).Return(db.TicketCard{})
+		mockDb.On("GetPersonByPubkey", "importer").Return(db.Person{ID: 1, OwnerPubKey: "importer", Extras: db.PropertyMap{}})
+		mockDb.On("GetPersonByPubkey", "stranger").Return(db.Person{})
+		mockDb.On("ImportWorkspaceBundle", mock.MatchedBy(func(m db.WorkspaceBundle) bool {
+			return m.Workspace.Name == "Copy" && len(m.Tickets) == 1 && m.Tickets[0].TicketId == "importer:100"
+		}), mock.MatchedBy(func(people []db.Person) bool {
+			if len(people) != 1 {
+				return false
+			}
+			wanteds, _ := people[0].Extras["wanted"].([]interface{})
+			return len(wanteds) == 1
+		})).Return(db.Workspace{ID: 10, Name: "Copy"}, nil).Once()
+
+		body, _ := json.Marshal(testWorkspaceBundle())
+		rr := httptest.NewRecorder()
+		oh.ImportWorkspace(rr, withPubkey(httptest.NewRequest(http.MethodPost, "/workspaces/import?name=Copy", bytes.NewReader(body)), "importer"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		result := db.WorkspaceImportResult{}
+		json.Unmarshal(rr.Body.Bytes(), &result)
+		assert.Equal(t, uint(10), result.Workspace.ID)
+		assert.Equal(t, 2, result.Counts["bounties"])
+		assert.Len(t, result.Skipped, 1)
+
+		rr = httptest.NewRecorder()
+		oh.ImportWorkspace(rr, withPubkey(httptest.NewRequest(http.MethodPost, "/workspaces/import?name=Copy&dry_run=true", bytes.NewReader(body)), "importer"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should test that a taken name is refused", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceByName", "Backup").Return(db.Workspace{ID: 9, Name: "Backup"}).Once()
+
+		body, _ := json.Marshal(testWorkspaceBundle())
+		rr := httptest.NewRecorder()
+		oh.ImportWorkspace(rr, withPubkey(httptest.NewRequest(http.MethodPost, "/workspaces/import", bytes.NewReader(body)), "importer"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "?name=")
+	})
+}
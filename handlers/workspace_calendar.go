@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+type WorkspaceCalendarTokenResponse struct {
+	Token string `json:"token"`
+	Url   string `json:"url"`
+}
+
+// icsEscape escapes a text value of an iCalendar property
+func icsEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// icsLine ends a content line with CRLF and folds it so no line is longer than 75 octets
+func icsLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		// don't split a multi byte character
+		for cut > 0 && line[cut]&0xc0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line + "\r\n")
+}
+
+// icsEvent writes an all day event on a date
+func icsEvent(b *strings.Builder, uid string, stamp time.Time, day time.Time, summary string, description string, link string) {
+	day = day.UTC()
+	icsLine(b, "BEGIN:VEVENT")
+	icsLine(b, "UID:"+uid)
+	icsLine(b, "DTSTAMP:"+stamp.UTC().Format("20060102T150405Z"))
+	icsLine(b, "DTSTART;VALUE=DATE:"+day.Format("20060102"))
+	icsLine(b, "DTEND;VALUE=DATE:"+day.AddDate(0, 0, 1).Format("20060102"))
+	icsLine(b, "SUMMARY:"+icsEscape(summary))
+	if description != "" {
+		icsLine(b, "DESCRIPTION:"+icsEscape(description))
+	}
+	if link != "" {
+		icsLine(b, "URL:"+link)
+	}
+	icsLine(b, "END:VEVENT")
+}
+
+// bountyDeadline is the expiry of a bounty or else its estimated completion date
+func bountyDeadline(bounty db.NewBounty) (time.Time, bool) {
+	deadline := bounty.BountyExpires
+	if deadline == "" {
+		deadline = bounty.EstimatedCompletionDate
+	}
+	due, err := dateparse.ParseAny(deadline)
+	return due, err == nil
+}
+
+// BuildWorkspaceCalendar writes the deadlines of the bounties and the end dates of the phases of a
+// workspace as an iCalendar. A phase ends its estimated days after it was created
+func BuildWorkspaceCalendar(workspace db.Workspace, bounties []db.NewBounty, phases []db.WorkspaceCalendarPhase, now time.Time) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(config.Host, "https://"), "http://")
+
+	b := &strings.Builder{}
+	icsLine(b, "BEGIN:VCALENDAR")
+	icsLine(b, "VERSION:2.0")
+	icsLine(b, "PRODID:-//Sphinx Community//Workspace Calendar//EN")
+	icsLine(b, "CALSCALE:GREGORIAN")
+	icsLine(b, "METHOD:PUBLISH")
+	icsLine(b, "X-WR-CALNAME:"+icsEscape(workspace.Name+" deadlines"))
+
+	for _, bounty := range bounties {
+		due, ok := bountyDeadline(bounty)
+		if !ok {
+			continue
+		}
+		stamp := now
+		if bounty.Updated != nil {
+			stamp = *bounty.Updated
+		}
+		icsEvent(b, fmt.Sprintf("bounty-%d@%s", bounty.ID, host), stamp, due,
+			"Bounty due: "+bounty.Title, bounty.OneSentenceSummary, bountyLink(bounty.ID))
+	}
+	for _, phase := range phases {
+		if phase.Created == nil || phase.EstimatedDays <= 0 {
+			continue
+		}
+		stamp := now
+		if phase.Updated != nil {
+			stamp = *phase.Updated
+		}
+		icsEvent(b, "phase-"+phase.Uuid+"@"+host, stamp, phase.Created.AddDate(0, 0, phase.EstimatedDays),
+			"Phase ends: "+phase.FeatureName+" - "+phase.Name, "", "")
+	}
+
+	icsLine(b, "END:VCALENDAR")
+	return b.String()
+}
+
+func workspaceCalendarUrl(workspaceUuid string, token string) string {
+	return fmt.Sprintf("%s/workspaces/%s/calendar.ics?token=%s", config.Host, workspaceUuid, url.QueryEscape(token))
+}
+
+// GetWorkspaceCalendar is the iCalendar feed of a workspace, its ?token= is the calendar token
+// of the workspace
+func (oh *workspaceHandler) GetWorkspaceCalendar(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+	token := r.URL.Query().Get("token")
+
+	calendarToken := oh.db.GetWorkspaceCalendarToken(uuid)
+	if calendarToken.ID == 0 || token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(calendarToken.Token)) != 1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("The calendar token is not valid"))
+		return
+	}
+	workspace := oh.db.GetWorkspaceByUuid(uuid)
+	if workspace.ID == 0 || workspace.Deleted {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("Workspace not found"))
+		return
+	}
+
+	calendar := BuildWorkspaceCalendar(workspace, oh.db.GetWorkspaceCalendarBounties(uuid), oh.db.GetWorkspaceCalendarPhases(uuid), time.Now())
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="calendar.ics"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(calendar))
+}
+
+// GetWorkspaceCalendarToken returns the url to subscribe to the calendar of a workspace
+func (oh *workspaceHandler) GetWorkspaceCalendarToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "workspace_uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if !oh.userHasAccess(pubKeyFromAuth, uuid, db.ViewReport) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the calendar")
+		return
+	}
+
+	calendarToken := oh.db.GetWorkspaceCalendarToken(uuid)
+	if calendarToken.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("The workspace has no calendar token")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(WorkspaceCalendarTokenResponse{
+		Token: calendarToken.Token,
+		Url:   workspaceCalendarUrl(uuid, calendarToken.Token),
+	})
+}
+
+// RotateWorkspaceCalendarToken creates the calendar token of a workspace or replaces it, which
+// stops the subscriptions made with the previous url
+func (oh *workspaceHandler) RotateWorkspaceCalendarToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "workspace_uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if !oh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to change the calendar token")
+		return
+	}
+	if workspace := oh.db.GetWorkspaceByUuid(uuid); workspace.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+	if rejectArchivedWorkspace(w, uuid) {
+		return
+	}
+
+	calendarToken, err := oh.db.RotateWorkspaceCalendarToken(uuid, pubKeyFromAuth)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not create the calendar token")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(WorkspaceCalendarTokenResponse{
+		Token: calendarToken.Token,
+		Url:   workspaceCalendarUrl(uuid, calendarToken.Token),
+	})
+}
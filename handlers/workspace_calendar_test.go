@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildWorkspaceCalendar(t *testing.T) {
+	now := time.Date(2025, time.April, 1, 9, 0, 0, 0, time.UTC)
+	created := time.Date(2025, time.March, 20, 15, 0, 0, 0, time.UTC)
+
+	t.Run("Should test that bounty deadlines and phase end dates are all day events", func(t *testing.T) {
+		bounties := []db.NewBounty{
+			{ID: 3, Title: "Fix login, again", BountyExpires: "2025-04-10T12:00:00Z"},
+			{ID: 4, Title: "Docs", EstimatedCompletionDate: "2025-05-01"},
+			{ID: 5, Title: "No date", BountyExpires: "someday"},
+		}
+		phases := []db.WorkspaceCalendarPhase{{Uuid: "phase", FeatureName: "Payments", Name: "MVP", EstimatedDays: 5, Created: &created}}
+
+		calendar := BuildWorkspaceCalendar(db.Workspace{Name: "Stakwork"}, bounties, phases, now)
+
+		assert.True(t, strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\n"))
+		assert.True(t, strings.HasSuffix(calendar, "END:VCALENDAR\r\n"))
+		assert.Equal(t, 3, strings.Count(calendar, "BEGIN:VEVENT"))
+		assert.Contains(t, calendar, "SUMMARY:Bounty due: Fix login\\, again\r\n")
+		assert.Contains(t, calendar, "DTSTART;VALUE=DATE:20250410\r\nDTEND;VALUE=DATE:20250411\r\n")
+		assert.Contains(t, calendar, "DTSTART;VALUE=DATE:20250501\r\n")
+		assert.Contains(t, calendar, "SUMMARY:Phase ends: Payments - MVP\r\n")
+		assert.Contains(t, calendar, "DTSTART;VALUE=DATE:20250325\r\n")
+		assert.Contains(t, calendar, "DTSTAMP:20250401T090000Z\r\n")
+		assert.NotContains(t, calendar, "No date")
+	})
+
+	t.Run("Should test that long lines are folded", func(t *testing.T) {
+		b := &strings.Builder{}
+		icsLine(b, "SUMMARY:"+strings.Repeat("a", 200))
+		for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(line), 75)
+		}
+		assert.Equal(t, "SUMMARY:"+strings.Repeat("a", 200), strings.ReplaceAll(strings.TrimSuffix(b.String(), "\r\n"), "\r\n ", ""))
+	})
+}
+
+func TestWorkspaceCalendar(t *testing.T) {
+	getCalendar := func(oHandler *workspaceHandler, url string) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Get("/workspaces/{uuid}/calendar.ics", oHandler.GetWorkspaceCalendar)
+
+		rr := httptest.NewRecorder()
+		ro.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, url, nil))
+		return rr
+	}
+
+	t.Run("Should test that the calendar is served with the token of the workspace", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oHandler := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceCalendarToken", "ws").Return(db.WorkspaceCalendarToken{ID: 1, WorkspaceUuid: "ws", Token: "secret"})
+		mockDb.On("GetWorkspaceByUuid", "ws").Return(db.Workspace{ID: 1, Uuid: "ws", Name: "Stakwork"})
+		mockDb.On("GetWorkspaceCalendarBounties", "ws").Return([]db.NewBounty{{ID: 3, Title: "Fix login", BountyExpires: "2025-04-10"}})
+		mockDb.On("GetWorkspaceCalendarPhases", "ws").Return([]db.WorkspaceCalendarPhase{})
+
+		rr := getCalendar(oHandler, "/workspaces/ws/calendar.ics?token=secret")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "SUMMARY:Bounty due: Fix login")
+	})
+
+	t.Run("Should test that a wrong token is unauthorized", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oHandler := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceCalendarToken", "ws").Return(db.WorkspaceCalendarToken{ID: 1, WorkspaceUuid: "ws", Token: "secret"})
+
+		rr := getCalendar(oHandler, "/workspaces/ws/calendar.ics?token=guess")
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that a workspace without a token has no calendar", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oHandler := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceCalendarToken", "ws").Return(db.WorkspaceCalendarToken{})
+
+		rr := getCalendar(oHandler, "/workspaces/ws/calendar.ics?token=")
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should test that rotating the token needs the edit role and returns the new url", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oHandler := NewWorkspaceHandler(mockDb)
+
+		rotate := func() *httptest.ResponseRecorder {
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("workspace_uuid", "ws")
+			ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, auth.ContextKey, "pubkey")
+			req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/workspaces/ws/calendar/token", nil)
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(oHandler.RotateWorkspaceCalendarToken).ServeHTTP(rr, req)
+			return rr
+		}
+
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return role == db.ViewReport
+		}
+		assert.Equal(t, http.StatusUnauthorized, rotate().Code)
+
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return role == db.EditOrg
+		}
+		mockDb.On("GetWorkspaceByUuid", "ws").Return(db.Workspace{ID: 1, Uuid: "ws"})
+		mockDb.On("RotateWorkspaceCalendarToken", "ws", "pubkey").Return(db.WorkspaceCalendarToken{ID: 1, WorkspaceUuid: "ws", Token: "fresh"}, nil)
+
+		rr := rotate()
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		response := WorkspaceCalendarTokenResponse{}
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "fresh", response.Token)
+		assert.True(t, strings.HasSuffix(response.Url, "/workspaces/ws/calendar.ics?token=fresh"))
+	})
+}
@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/notifications"
+)
+
+// WebhookSourceWorkspaceIntegration is the source of the webhook deliveries of Slack and Discord integrations
+const WebhookSourceWorkspaceIntegration = "workspace_integration"
+
+const (
+	workspaceIntegrationDeliveryLimit = 50
+	maxIntegrationNameLength          = 50
+
+	chatColorBounty = 0x618AFF
+	chatColorPaid   = 0x49C998
+	chatColorBudget = 0xED7474
+)
+
+// WorkspaceIntegrationEvents are the events a workspace can post to its chat apps
+var WorkspaceIntegrationEvents = []string{events.BountyCreated, events.BountyAssigned, events.BountyPaid, events.BudgetLow}
+
+// workspaceIntegrationStore is where dispatchWorkspaceIntegrations queues deliveries, it is nil until
+// InitWorkspaceIntegrations is called
+var workspaceIntegrationStore db.Database
+
+// InitWorkspaceIntegrations subscribes the Slack and Discord integrations to the events of the bus
+func InitWorkspaceIntegrations(database db.Database) {
+	workspaceIntegrationStore = database
+	events.Subscribe(events.AllEvents, dispatchWorkspaceIntegrations)
+}
+
+// dispatchWorkspaceIntegrations queues a message about an event to each integration of its workspace
+// subscribed to it
+func dispatchWorkspaceIntegrations(event events.Event) {
+	if workspaceIntegrationStore == nil || event.WorkspaceUuid == "" || !validIntegrationEvent(event.Name) {
+		return
+	}
+	integrations := workspaceIntegrationStore.GetWorkspaceIntegrationsForEvent(event.WorkspaceUuid, event.Name)
+	if len(integrations) == 0 {
+		return
+	}
+
+	message := integrationMessage(workspaceIntegrationStore, event)
+	for _, integration := range integrations {
+		queueIntegrationMessage(workspaceIntegrationStore, integration, event.Name, message)
+	}
+}
+
+func queueIntegrationMessage(database db.Database, integration db.WorkspaceIntegration, event string, message notifications.ChatMessage) {
+	payload := notifications.FormatSlackMessage(message)
+	if integration.Kind == db.WorkspaceIntegrationDiscord {
+		payload = notifications.FormatDiscordMessage(message)
+	}
+	queueWebhookDelivery(database, db.WebhookDelivery{
+		Source:     WebhookSourceWorkspaceIntegration,
+		SourceUuid: integration.Uuid,
+		Url:        integration.Url,
+		Raw:        true,
+		Event:      event,
+		Payload:    payload,
+	})
+}
+
+// integrationMessage describes an event for people reading it in a chat app
+func integrationMessage(database db.Database, event events.Event) notifications.ChatMessage {
+	value := func(key string) string {
+		if v, ok := event.Payload[key]; ok && v != nil {
+			return fmt.Sprint(v)
+		}
+		return ""
+	}
+	person := func(key string) string {
+		pubkey := value(key)
+		if pubkey == "" {
+			return ""
+		}
+		if alias := database.GetPersonByPubkey(pubkey).OwnerAlias; alias != "" {
+			return alias
+		}
+		return pubkey
+	}
+
+	switch event.Name {
+	case events.BountyCreated:
+		return notifications.ChatMessage{
+			Title:  "New bounty: " + value("title"),
+			Link:   value("bounty_link"),
+			Color:  chatColorBounty,
+			Fields: []notifications.ChatField{{Name: "Price", Value: value("price") + " sats"}, {Name: "Posted by", Value: person("owner_pubkey")}},
+		}
+	case events.BountyAssigned:
+		return notifications.ChatMessage{
+			Title:  "Bounty assigned: " + value("title"),
+			Link:   value("bounty_link"),
+			Color:  chatColorBounty,
+			Fields: []notifications.ChatField{{Name: "Assignee", Value: person("assignee")}, {Name: "Price", Value: value("price") + " sats"}},
+		}
+	case events.BountyPaid:
+		return notifications.ChatMessage{
+			Title:  "Bounty paid: " + value("title"),
+			Link:   value("bounty_link"),
+			Color:  chatColorPaid,
+			Fields: []notifications.ChatField{{Name: "Paid to", Value: person("assignee")}, {Name: "Amount", Value: value("price") + " sats"}},
+		}
+	case events.BudgetLow:
+		return notifications.ChatMessage{
+			Title:  fmt.Sprintf("%s budget is low", value("workspace_name")),
+			Text:   "Add budget to keep paying bounties.",
+			Link:   fmt.Sprintf("%s/workspace/%s", config.Host, event.WorkspaceUuid),
+			Color:  chatColorBudget,
+			Fields: []notifications.ChatField{{Name: "Budget", Value: value("budget") + " sats"}, {Name: "Threshold", Value: value("threshold") + " sats"}},
+		}
+	}
+	return notifications.ChatMessage{Title: event.Name}
+}
+
+func validIntegrationEvent(name string) bool {
+	for _, event := range WorkspaceIntegrationEvents {
+		if event == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateWorkspaceIntegration only takes the webhook urls of Slack and Discord so the server never
+// posts to an address of the workspace's choosing
+func validateWorkspaceIntegration(integration db.WorkspaceIntegration) error {
+	if len(integration.Name) > maxIntegrationNameLength {
+		return fmt.Errorf("the name is at most %d characters", maxIntegrationNameLength)
+	}
+	if len(integration.Events) == 0 {
+		return errors.New("subscribe to at least one event")
+	}
+	for _, event := range integration.Events {
+		if !validIntegrationEvent(event) {
+			return fmt.Errorf("unknown event %s", event)
+		}
+	}
+
+	if integration.Url == "" && integration.Uuid != "" {
+		return nil
+	}
+	u, err := url.Parse(integration.Url)
+	if err != nil || u.Scheme != "https" {
+		return errors.New("the url must be an https url")
+	}
+	switch integration.Kind {
+	case db.WorkspaceIntegrationSlack:
+		if u.Host != "hooks.slack.com" || !strings.HasPrefix(u.Path, "/services/") {
+			return errors.New("the url must be a Slack incoming webhook")
+		}
+	case db.WorkspaceIntegrationDiscord:
+		if (u.Host != "discord.com" && u.Host != "discordapp.com") || !strings.HasPrefix(u.Path, "/api/webhooks/") {
+			return errors.New("the url must be a Discord webhook")
+		}
+	default:
+		return errors.New("the kind is slack or discord")
+	}
+	return nil
+}
+
+// workspaceIntegrationFromUrl returns the integration of the {uuid} param when it belongs to the workspace
+func (oh *workspaceHandler) workspaceIntegrationFromUrl(w http.ResponseWriter, r *http.Request, workspaceUuid string) (db.WorkspaceIntegration, bool) {
+	integration := oh.db.GetWorkspaceIntegration(chi.URLParam(r, "uuid"))
+	if integration.ID == 0 || integration.WorkspaceUuid != workspaceUuid {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Integration not found")
+		return db.WorkspaceIntegration{}, false
+	}
+	return integration, true
+}
+
+// CreateOrEditWorkspaceIntegration registers a Slack or Discord webhook for the events of a workspace
+func (oh *workspaceHandler) CreateOrEditWorkspaceIntegration(w http.ResponseWriter, r *http.Request) {
+	uuid, ok := oh.workspaceAdmin(w, r)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, uuid) {
+		return
+	}
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+
+	integration := db.WorkspaceIntegration{}
+	if err := json.NewDecoder(r.Body).Decode(&integration); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+	integration.Name = strings.TrimSpace(integration.Name)
+	integration.Url = strings.TrimSpace(integration.Url)
+
+	if integration.Uuid != "" {
+		existing := oh.db.GetWorkspaceIntegration(integration.Uuid)
+		if existing.ID == 0 || existing.WorkspaceUuid != uuid {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode("Integration not found")
+			return
+		}
+		// the kind can't change under a kept url
+		if integration.Url == "" {
+			integration.Kind = existing.Kind
+		}
+	}
+	if err := validateWorkspaceIntegration(integration); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	integration.WorkspaceUuid = uuid
+	integration.CreatedBy = pubKeyFromAuth
+
+	integration, err := oh.db.CreateOrEditWorkspaceIntegration(integration)
+	if err != nil {
+		fmt.Println("[workspaces] could not save integration", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save the integration")
+		return
+	}
+	integration.Url = ""
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(integration)
+}
+
+// GetWorkspaceIntegrations lists the integrations of a workspace without their urls
+func (oh *workspaceHandler) GetWorkspaceIntegrations(w http.ResponseWriter, r *http.Request) {
+	uuid, ok := oh.workspaceAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	integrations := oh.db.GetWorkspaceIntegrations(uuid)
+	for i := range integrations {
+		integrations[i].Url = ""
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(integrations)
+}
+
+func (oh *workspaceHandler) DeleteWorkspaceIntegration(w http.ResponseWriter, r *http.Request) {
+	uuid, ok := oh.workspaceAdmin(w, r)
+	if !ok {
+		return
+	}
+	integration, ok := oh.workspaceIntegrationFromUrl(w, r, uuid)
+	if !ok {
+		return
+	}
+	if rejectArchivedWorkspace(w, uuid) {
+		return
+	}
+
+	if err := oh.db.DeleteWorkspaceIntegration(integration.Uuid); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete the integration")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// TestWorkspaceIntegration queues a message to check that an integration reaches its channel
+func (oh *workspaceHandler) TestWorkspaceIntegration(w http.ResponseWriter, r *http.Request) {
+	uuid, ok := oh.workspaceAdmin(w, r)
+	if !ok {
+		return
+	}
+	integration, ok := oh.workspaceIntegrationFromUrl(w, r, uuid)
+	if !ok {
+		return
+	}
+
+	workspace := oh.db.GetWorkspaceByUuid(uuid)
+	queueIntegrationMessage(oh.db, integration, "test", notifications.ChatMessage{
+		Title: fmt.Sprintf("%s is connected", workspace.Name),
+		Text:  "Bounty and budget updates of the workspace will be posted here.",
+		Link:  fmt.Sprintf("%s/workspace/%s", config.Host, uuid),
+		Color: chatColorBounty,
+	})
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(true)
+}
+
+// GetWorkspaceIntegrationDeliveries returns the latest messages posted by an integration with their outcome
+func (oh *workspaceHandler) GetWorkspaceIntegrationDeliveries(w http.ResponseWriter, r *http.Request) {
+	uuid, ok := oh.workspaceAdmin(w, r)
+	if !ok {
+		return
+	}
+	integration, ok := oh.workspaceIntegrationFromUrl(w, r, uuid)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(oh.db.GetWebhookDeliveries(WebhookSourceWorkspaceIntegration, integration.Uuid, workspaceIntegrationDeliveryLimit))
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/lib/pq"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkspaceIntegrations(t *testing.T) {
+	request := func(params map[string]string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		for key, value := range params {
+			rctx.URLParams.Add(key, value)
+		}
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+		return req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, "admin"))
+	}
+	slack := db.WorkspaceIntegration{ID: 1, Uuid: "slack", WorkspaceUuid: "ws", Kind: db.WorkspaceIntegrationSlack,
+		Url: "https://hooks.slack.com/services/T/B/X", Events: pq.StringArray{events.BountyPaid}, Enabled: true}
+	discord := db.WorkspaceIntegration{ID: 2, Uuid: "discord", WorkspaceUuid: "ws", Kind: db.WorkspaceIntegrationDiscord,
+		Url: "https://discord.com/api/webhooks/1/x", Events: pq.StringArray{events.BountyPaid}, Enabled: true}
+
+	t.Run("Should test that only Slack and Discord webhooks are taken", func(t *testing.T) {
+		assert.NoError(t, validateWorkspaceIntegration(slack))
+		assert.NoError(t, validateWorkspaceIntegration(discord))
+
+		internal := slack
+		internal.Url = "https://169.254.169.254/services/x"
+		assert.Error(t, validateWorkspaceIntegration(internal))
+		mixed := discord
+		mixed.Url = slack.Url
+		assert.Error(t, validateWorkspaceIntegration(mixed))
+		unknown := slack
+		unknown.Events = pq.StringArray{events.TribeJoined}
+		assert.Error(t, validateWorkspaceIntegration(unknown))
+	})
+
+	t.Run("Should test that an event is posted to each integration in its format", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		workspaceIntegrationStore = mockDb
+		defer func() { workspaceIntegrationStore = nil }()
+
+		mockDb.On("GetWorkspaceIntegrationsForEvent", "ws", events.BountyPaid).Return([]db.WorkspaceIntegration{slack, discord}).Once()
+		mockDb.On("GetPersonByPubkey", "assignee").Return(db.Person{OwnerAlias: "Ada"}).Once()
+		mockDb.On("CreateWebhookDelivery", mock.MatchedBy(func(d db.WebhookDelivery) bool {
+			return d.SourceUuid == "slack" && d.Raw && d.Url == slack.Url && d.Payload["text"] == "Bounty paid: Fix login"
+		})).Return(db.WebhookDelivery{}, nil).Once()
+		mockDb.On("CreateWebhookDelivery", mock.MatchedBy(func(d db.WebhookDelivery) bool {
+			embeds, _ := d.Payload["embeds"].([]interface{})
+			return d.SourceUuid == "discord" && d.Raw && len(embeds) == 1 && d.Source == WebhookSourceWorkspaceIntegration
+		})).Return(db.WebhookDelivery{}, nil).Once()
+
+		dispatchWorkspaceIntegrations(events.Event{Name: events.BountyPaid, WorkspaceUuid: "ws", Payload: map[string]interface{}{
+			"title": "Fix login", "assignee": "assignee", "price": uint(1000), "bounty_link": "https://community.sphinx.chat/bounty/1",
+		}})
+		// events of other kinds are not posted
+		dispatchWorkspaceIntegrations(events.Event{Name: events.TribeJoined, WorkspaceUuid: "ws"})
+	})
+
+	t.Run("Should test that the url of an integration is not returned", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		oh.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+		mockDb.On("CreateOrEditWorkspaceIntegration", mock.MatchedBy(func(m db.WorkspaceIntegration) bool {
+			return m.WorkspaceUuid == "ws" && m.Url == slack.Url && m.CreatedBy == "admin"
+		})).Return(slack, nil).Once()
+
+		rr := httptest.NewRecorder()
+		oh.CreateOrEditWorkspaceIntegration(rr, request(map[string]string{"workspace_uuid": "ws"},
+			`{"kind": "slack", "url": "https://hooks.slack.com/services/T/B/X", "events": ["bounty.paid"], "enabled": true}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		saved := db.WorkspaceIntegration{}
+		json.Unmarshal(rr.Body.Bytes(), &saved)
+		assert.Empty(t, saved.Url)
+
+		mockDb.On("GetWorkspaceIntegration", "other").Return(db.WorkspaceIntegration{ID: 3, Uuid: "other", WorkspaceUuid: "other_ws"}).Once()
+		rr = httptest.NewRecorder()
+		oh.GetWorkspaceIntegrationDeliveries(rr, request(map[string]string{"workspace_uuid": "ws", "uuid": "other"}, ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,442 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/lib/pq"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+func validWorkspaceRole(role string) bool {
+	for _, r := range db.ConfigBountyRoles {
+		if r.Name == role {
+			return true
+		}
+	}
+	return false
+}
+
+// templateStructureErrors checks that the names of a template are set and unique and that its
+// bounties point at phases it has
+func templateStructureErrors(structure db.WorkspaceTemplateStructure) []string {
+	errs := []string{}
+	phases := map[string]map[string]bool{}
+	for _, feature := range structure.Features {
+		name := strings.TrimSpace(feature.Name)
+		if name == "" {
+			errs = append(errs, "a feature has no name")
+			continue
+		}
+		if _, ok := phases[name]; ok {
+			errs = append(errs, fmt.Sprintf("feature %q is repeated", name))
+		}
+		phases[name] = map[string]bool{}
+		for _, phase := range feature.Phases {
+			phaseName := strings.TrimSpace(phase.Name)
+			if phaseName == "" || phases[name][phaseName] {
+				errs = append(errs, fmt.Sprintf("a phase of feature %q has no name or is repeated", name))
+			}
+			phases[name][phaseName] = true
+		}
+	}
+
+	for _, member := range structure.DefaultRoles {
+		if strings.TrimSpace(member.OwnerPubKey) == "" {
+			errs = append(errs, "a default role has no pubkey")
+		}
+		for _, role := range member.Roles {
+			if !validWorkspaceRole(role) {
+				errs = append(errs, fmt.Sprintf("unknown role %q", role))
+			}
+		}
+	}
+
+	for _, bounty := range structure.Bounties {
+		if strings.TrimSpace(bounty.Title) == "" || strings.TrimSpace(bounty.Description) == "" {
+			errs = append(errs, "a bounty has no title or description")
+		}
+		if !validStaleAfter(bounty.StaleAfter) {
+			errs = append(errs, fmt.Sprintf("bounty %q stale_after must be a duration like 72h", bounty.Title))
+		}
+		if bounty.Phase != "" && !phases[bounty.Feature][bounty.Phase] {
+			errs = append(errs, fmt.Sprintf("bounty %q is on unknown phase %q of feature %q", bounty.Title, bounty.Phase, bounty.Feature))
+		}
+		if bounty.Phase == "" && bounty.Feature != "" {
+			errs = append(errs, fmt.Sprintf("bounty %q names a feature without a phase", bounty.Title))
+		}
+	}
+	return errs
+}
+
+// templateStructure takes the features and phases of a workspace, and on request the roles of its
+// members and its open bounties
+func templateStructure(bundle db.WorkspaceBundle, includeRoles bool, includeBounties bool) db.WorkspaceTemplateStructure {
+	structure := db.WorkspaceTemplateStructure{
+		Features:     []db.WorkspaceTemplateFeature{},
+		DefaultRoles: []db.WorkspaceTemplateRole{},
+		Bounties:     []db.WorkspaceTemplateBounty{},
+	}
+
+	featureNames := map[string]string{}
+	featureIndex := map[string]int{}
+	for _, feature := range bundle.Features {
+		featureNames[feature.Uuid] = feature.Name
+		featureIndex[feature.Uuid] = len(structure.Features)
+		structure.Features = append(structure.Features, db.WorkspaceTemplateFeature{
+			Name:         feature.Name,
+			Brief:        feature.Brief,
+			Requirements: feature.Requirements,
+			Architecture: feature.Architecture,
+			Priority:     feature.Priority,
+			Phases:       []db.WorkspaceTemplatePhase{},
+		})
+	}
+	phaseNames := map[string]db.FeaturePhase{}
+	for _, phase := range bundle.Phases {
+		i, ok := featureIndex[phase.FeatureUuid]
+		if !ok {
+			continue
+		}
+		phaseNames[phase.Uuid] = phase
+		structure.Features[i].Phases = append(structure.Features[i].Phases, db.WorkspaceTemplatePhase{
+			Name:          phase.Name,
+			Priority:      phase.Priority,
+			EstimatedDays: phase.EstimatedDays,
+		})
+	}
+
+	if includeRoles {
+		members := map[string]int{}
+		for _, role := range bundle.Roles {
+			i, ok := members[role.OwnerPubKey]
+			if !ok {
+				i = len(structure.DefaultRoles)
+				members[role.OwnerPubKey] = i
+				structure.DefaultRoles = append(structure.DefaultRoles, db.WorkspaceTemplateRole{OwnerPubKey: role.OwnerPubKey, Roles: []string{}})
+			}
+			structure.DefaultRoles[i].Roles = append(structure.DefaultRoles[i].Roles, role.Role)
+		}
+	}
+
+	if includeBounties {
+		for _, bounty := range bundle.Bounties {
+			if bounty.Assignee != "" || bounty.Completed || bounty.Paid {
+				continue
+			}
+			templateBounty := db.WorkspaceTemplateBounty{
+				Title:           bounty.Title,
+				Description:     bounty.Description,
+				Type:            bounty.Type,
+				Price:           bounty.Price,
+				CodingLanguages: bounty.CodingLanguages,
+				StaleAfter:      bounty.StaleAfter,
+			}
+			if phase, ok := phaseNames[bounty.PhaseUuid]; ok {
+				templateBounty.Feature = featureNames[phase.FeatureUuid]
+				templateBounty.Phase = phase.Name
+			}
+			structure.Bounties = append(structure.Bounties, templateBounty)
+		}
+	}
+	return structure
+}
+
+// workspaceBundleFromTemplate lays out a new workspace of a template as a bundle to import
+func workspaceBundleFromTemplate(template db.WorkspaceTemplate, owner string, request db.WorkspaceFromTemplateRequest) db.WorkspaceBundle {
+	now := time.Now()
+	workspace := db.Workspace{
+		Uuid:        xid.New().String(),
+		Name:        request.Name,
+		Description: request.Description,
+		OwnerPubKey: owner,
+		Created:     &now,
+		Updated:     &now,
+	}
+	bundle := db.WorkspaceBundle{
+		Version:   db.WorkspaceBundleVersion,
+		Workspace: workspace,
+		Budget:    db.NewBountyBudget{WorkspaceUuid: workspace.Uuid, Created: &now, Updated: &now},
+	}
+
+	phases := map[string]map[string]string{}
+	for _, feature := range template.Structure.Features {
+		featureUuid := xid.New().String()
+		phases[feature.Name] = map[string]string{}
+		bundle.Features = append(bundle.Features, db.WorkspaceFeatures{
+			Uuid:          featureUuid,
+			WorkspaceUuid: workspace.Uuid,
+			Name:          feature.Name,
+			Brief:         feature.Brief,
+			Requirements:  feature.Requirements,
+			Architecture:  feature.Architecture,
+			Priority:      feature.Priority,
+			Created:       &now,
+			Updated:       &now,
+			CreatedBy:     owner,
+			UpdatedBy:     owner,
+		})
+		for _, phase := range feature.Phases {
+			phaseUuid := xid.New().String()
+			phases[feature.Name][phase.Name] = phaseUuid
+			bundle.Phases = append(bundle.Phases, db.FeaturePhase{
+				Uuid:          phaseUuid,
+				FeatureUuid:   featureUuid,
+				Name:          phase.Name,
+				Priority:      phase.Priority,
+				EstimatedDays: phase.EstimatedDays,
+				Created:       &now,
+				Updated:       &now,
+				CreatedBy:     owner,
+				UpdatedBy:     owner,
+			})
+		}
+	}
+
+	for _, member := range template.Structure.DefaultRoles {
+		if member.OwnerPubKey == owner {
+			continue
+		}
+		bundle.Users = append(bundle.Users, db.WorkspaceUsers{OwnerPubKey: member.OwnerPubKey, WorkspaceUuid: workspace.Uuid, Created: &now, Updated: &now})
+		for _, role := range member.Roles {
+			bundle.Roles = append(bundle.Roles, db.WorkspaceUserRoles{Role: role, OwnerPubKey: member.OwnerPubKey, WorkspaceUuid: workspace.Uuid, Created: &now})
+		}
+	}
+
+	for _, bounty := range template.Structure.Bounties {
+		languages := pq.StringArray{}
+		for _, language := range bounty.CodingLanguages {
+			languages = append(languages, language)
+		}
+		bundle.Bounties = append(bundle.Bounties, db.NewBounty{
+			OwnerID:         owner,
+			Show:            true,
+			Type:            bounty.Type,
+			Price:           bounty.Price,
+			Title:           bounty.Title,
+			Description:     bounty.Description,
+			StaleAfter:      bounty.StaleAfter,
+			CodingLanguages: languages,
+			WorkspaceUuid:   workspace.Uuid,
+			PhaseUuid:       phases[bounty.Feature][bounty.Phase],
+			Created:         now.Unix(),
+			Updated:         &now,
+		})
+	}
+	return bundle
+}
+
+// templateFromUrl reads the {uuid} template of the user, writing the error response when it is
+// missing or someone else's
+func (oh *workspaceHandler) templateFromUrl(w http.ResponseWriter, r *http.Request) (db.WorkspaceTemplate, string, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return db.WorkspaceTemplate{}, "", false
+	}
+
+	template := oh.db.GetWorkspaceTemplate(chi.URLParam(r, "uuid"))
+	if template.ID == 0 || template.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Template not found")
+		return db.WorkspaceTemplate{}, "", false
+	}
+	return template, pubKeyFromAuth, true
+}
+
+// SaveWorkspaceTemplate saves the features and phases of a workspace as a template, with the roles
+// of its members and its open bounties when they are asked for
+func (oh *workspaceHandler) SaveWorkspaceTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "workspace_uuid")
+	workspace := oh.db.GetWorkspaceByUuid(uuid)
+	if workspace.ID == 0 || workspace.Deleted {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+	if workspace.OwnerPubKey != pubKeyFromAuth && !oh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to save a template of this workspace")
+		return
+	}
+
+	request := db.WorkspaceTemplateRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		request.Name = workspace.Name
+	}
+
+	// workspaces can have features or phases with the same name, a template names them apart
+	structure := templateStructure(oh.db.GetWorkspaceBundle(uuid), request.IncludeRoles, request.IncludeBounties)
+	if errs := templateStructureErrors(structure); len(errs) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errs)
+		return
+	}
+
+	template, err := oh.db.CreateOrEditWorkspaceTemplate(db.WorkspaceTemplate{
+		OwnerPubKey:         pubKeyFromAuth,
+		Name:                request.Name,
+		Description:         strings.TrimSpace(request.Description),
+		SourceWorkspaceUuid: uuid,
+		Structure:           structure,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(template)
+}
+
+func (oh *workspaceHandler) GetWorkspaceTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(oh.db.GetWorkspaceTemplates(pubKeyFromAuth))
+}
+
+func (oh *workspaceHandler) GetWorkspaceTemplate(w http.ResponseWriter, r *http.Request) {
+	template, _, ok := oh.templateFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(template)
+}
+
+// UpdateWorkspaceTemplate replaces the name, description and structure of a template
+func (oh *workspaceHandler) UpdateWorkspaceTemplate(w http.ResponseWriter, r *http.Request) {
+	template, _, ok := oh.templateFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	edit := db.WorkspaceTemplate{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &edit); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+	if errs := templateStructureErrors(edit.Structure); len(errs) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errs)
+		return
+	}
+
+	template.Name = strings.TrimSpace(edit.Name)
+	if template.Name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A template needs a name")
+		return
+	}
+	template.Description = strings.TrimSpace(edit.Description)
+	template.Structure = edit.Structure
+
+	template, err := oh.db.CreateOrEditWorkspaceTemplate(template)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(template)
+}
+
+func (oh *workspaceHandler) DeleteWorkspaceTemplate(w http.ResponseWriter, r *http.Request) {
+	template, _, ok := oh.templateFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	if err := oh.db.DeleteWorkspaceTemplate(template.Uuid); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(template)
+}
+
+// CreateWorkspaceFromTemplate creates a workspace owned by the user with the features, phases,
+// members and bounties of a template
+func (oh *workspaceHandler) CreateWorkspaceFromTemplate(w http.ResponseWriter, r *http.Request) {
+	template, pubKeyFromAuth, ok := oh.templateFromUrl(w, r)
+	if !ok {
+		return
+	}
+
+	request := db.WorkspaceFromTemplateRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	request.Name = strings.TrimSpace(request.Name)
+	request.Description = strings.TrimSpace(request.Description)
+	if len(request.Name) == 0 || len(request.Name) > 20 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Error: workspace name must be present and should not exceed 20 character")
+		return
+	}
+	if len(request.Description) > 120 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Error: workspace description should not exceed 120 character")
+		return
+	}
+	if oh.db.GetWorkspaceByName(request.Name).ID != 0 {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("Workspace name already exists - " + request.Name)
+		return
+	}
+	workspace, err := oh.db.ImportWorkspaceBundle(workspaceBundleFromTemplate(template, pubKeyFromAuth, request), nil)
+	if err != nil {
+		fmt.Println("[workspaces] could not create from template", template.Uuid, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(workspace)
+}
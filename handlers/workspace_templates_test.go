@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkspaceTemplates(t *testing.T) {
+	request := func(method string, param string, value string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add(param, value)
+		req := httptest.NewRequest(method, "/workspaces/templates", bytes.NewBufferString(body))
+		return req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, "owner"))
+	}
+
+	t.Run("Should test that a template keeps the structure and open bounties of a workspace", func(t *testing.T) {
+		bundle := testWorkspaceBundle()
+		bundle.Bounties[0].Title, bundle.Bounties[0].Description = "Build search", "An index of people"
+		structure := templateStructure(bundle, true, true)
+
+		assert.Len(t, structure.Features, 1)
+		assert.Equal(t, "MVP", structure.Features[0].Phases[0].Name)
+		assert.Equal(t, []db.WorkspaceTemplateRole{{OwnerPubKey: "member", Roles: []string{"ADD BOUNTY"}}}, structure.DefaultRoles)
+		// the paid bounty is left out
+		assert.Len(t, structure.Bounties, 1)
+		assert.Equal(t, "Search", structure.Bounties[0].Feature)
+		assert.Equal(t, "MVP", structure.Bounties[0].Phase)
+		assert.Empty(t, templateStructureErrors(structure))
+
+		assert.Empty(t, templateStructure(bundle, false, false).Bounties)
+	})
+
+	t.Run("Should test that broken templates are reported", func(t *testing.T) {
+		errs := templateStructureErrors(db.WorkspaceTemplateStructure{
+			Features:     []db.WorkspaceTemplateFeature{{Name: "Search", Phases: []db.WorkspaceTemplatePhase{{Name: "MVP"}, {Name: "MVP"}}}, {Name: "Search"}},
+			DefaultRoles: []db.WorkspaceTemplateRole{{OwnerPubKey: "member", Roles: []string{"SUPERUSER"}}},
+			Bounties:     []db.WorkspaceTemplateBounty{{Title: "Build", Description: "it", Feature: "Search", Phase: "Beta"}},
+		})
+		assert.Len(t, errs, 4)
+	})
+
+	t.Run("Should test that a workspace is created from a template", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		template := db.WorkspaceTemplate{ID: 1, Uuid: "template", OwnerPubKey: "owner", Structure: db.WorkspaceTemplateStructure{
+			Features:     []db.WorkspaceTemplateFeature{{Name: "Search", Phases: []db.WorkspaceTemplatePhase{{Name: "MVP"}}}},
+			DefaultRoles: []db.WorkspaceTemplateRole{{OwnerPubKey: "owner", Roles: []string{db.EditOrg}}, {OwnerPubKey: "member", Roles: []string{db.AddBounty, db.ViewReport}}},
+			Bounties:     []db.WorkspaceTemplateBounty{{Title: "Build", Description: "it", Price: 100, Feature: "Search", Phase: "MVP"}},
+		}}
+		mockDb.On("GetWorkspaceTemplate", "template").Return(template)
+		mockDb.On("GetWorkspaceByName", "Project B").Return(db.Workspace{}).Once()
+		mockDb.On("GetWorkspaceByName", "Taken").Return(db.Workspace{ID: 2}).Once()
+		mockDb.On("ImportWorkspaceBundle", mock.MatchedBy(func(m db.WorkspaceBundle) bool {
+			return m.Workspace.Name == "Project B" && m.Workspace.OwnerPubKey == "owner" &&
+				len(m.Phases) == 1 && m.Bounties[0].PhaseUuid == m.Phases[0].Uuid && m.Bounties[0].OwnerID == "owner" &&
+				len(m.Users) == 1 && m.Users[0].OwnerPubKey == "member" && len(m.Roles) == 2
+		}), []db.Person(nil)).Return(db.Workspace{ID: 3, Name: "Project B"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		oh.CreateWorkspaceFromTemplate(rr, request(http.MethodPost, "uuid", "template", `{"name": "Project B"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		rr = httptest.NewRecorder()
+		oh.CreateWorkspaceFromTemplate(rr, request(http.MethodPost, "uuid", "template", `{"name": "Taken"}`))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Should test that templates of other people are not found", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceTemplate", "theirs").Return(db.WorkspaceTemplate{ID: 1, Uuid: "theirs", OwnerPubKey: "other"}).Twice()
+
+		rr := httptest.NewRecorder()
+		oh.GetWorkspaceTemplate(rr, request(http.MethodGet, "uuid", "theirs", ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		rr = httptest.NewRecorder()
+		oh.DeleteWorkspaceTemplate(rr, request(http.MethodDelete, "uuid", "theirs", ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Should test that only workspace admins save templates", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		oh.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return false }
+		mockDb.On("GetWorkspaceByUuid", "ws").Return(db.Workspace{ID: 1, Uuid: "ws", Name: "Alpha", OwnerPubKey: "other"}).Once()
+
+		rr := httptest.NewRecorder()
+		oh.SaveWorkspaceTemplate(rr, request(http.MethodPost, "workspace_uuid", "ws", `{}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
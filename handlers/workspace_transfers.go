@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/notifications"
+)
+
+const (
+	defaultTransferHours = 72
+	maxTransferHours     = 7 * 24
+)
+
+// StartWorkspaceTransfer lets the owner offer the workspace to one of its users, the transfer
+// only happens once they accept it
+func (oh *workspaceHandler) StartWorkspaceTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "workspace_uuid")
+	workspace := oh.db.GetWorkspaceByUuid(uuid)
+	if workspace.ID == 0 || workspace.Deleted {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+	if pubKeyFromAuth != workspace.OwnerPubKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the workspace owner can transfer it")
+		return
+	}
+	if rejectArchivedWorkspace(w, uuid) {
+		return
+	}
+
+	request := db.WorkspaceTransferRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	request.ToPubKey = strings.TrimSpace(request.ToPubKey)
+	if request.ToPubKey == "" || request.ToPubKey == workspace.OwnerPubKey {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The new owner must be another pubkey")
+		return
+	}
+	if oh.db.GetWorkspaceUser(request.ToPubKey, uuid).OwnerPubKey != request.ToPubKey {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The new owner must be a user of the workspace")
+		return
+	}
+
+	if request.Hours == 0 {
+		request.Hours = defaultTransferHours
+	}
+	if request.Hours < 1 || request.Hours > maxTransferHours {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("A transfer can be accepted for 1 to %d hours", maxTransferHours))
+		return
+	}
+
+	expires := time.Now().Add(time.Duration(request.Hours) * time.Hour)
+	transfer, err := oh.db.CreateWorkspaceTransfer(db.WorkspaceTransfer{
+		WorkspaceUuid: uuid,
+		FromPubKey:    pubKeyFromAuth,
+		ToPubKey:      request.ToPubKey,
+		ExpiresAt:     &expires,
+	})
+	if errors.Is(err, db.ErrTransferPending) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[workspaces] could not start transfer", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not start the transfer")
+		return
+	}
+
+	notifications.Notify(transfer.ToPubKey, db.NotificationWorkspaceTransfer,
+		fmt.Sprintf("You were offered %s", workspace.Name),
+		fmt.Sprintf("The owner wants to transfer %s to you. Accept it before %s", workspace.Name, expires.UTC().Format(time.RFC1123)),
+		"/workspace/"+uuid)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(transfer)
+}
+
+// GetWorkspaceTransfers lists the transfers of a workspace to its admins
+func (oh *workspaceHandler) GetWorkspaceTransfers(w http.ResponseWriter, r *http.Request) {
+	uuid, ok := oh.workspaceAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(oh.db.GetWorkspaceTransfers(uuid))
+}
+
+// GetWorkspaceAuditLog lists who started, accepted, declined or cancelled the transfers of a workspace
+func (oh *workspaceHandler) GetWorkspaceAuditLog(w http.ResponseWriter, r *http.Request) {
+	uuid, ok := oh.workspaceAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(oh.db.GetWorkspaceAuditLog(uuid))
+}
+
+func (oh *workspaceHandler) workspaceAdmin(w http.ResponseWriter, r *http.Request) (string, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return "", false
+	}
+
+	uuid := chi.URLParam(r, "workspace_uuid")
+	if !oh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the workspace")
+		return "", false
+	}
+	return uuid, true
+}
+
+// GetIncomingWorkspaceTransfers lists the transfers the caller can accept
+func (oh *workspaceHandler) GetIncomingWorkspaceTransfers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(oh.db.GetIncomingWorkspaceTransfers(pubKeyFromAuth))
+}
+
+// AcceptWorkspaceTransfer makes the caller the owner of the workspace they were offered
+func (oh *workspaceHandler) AcceptWorkspaceTransfer(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, transfer, ok := oh.transferFromUrl(w, r)
+	if !ok {
+		return
+	}
+	if pubKeyFromAuth != transfer.ToPubKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the new owner can accept the transfer")
+		return
+	}
+	if rejectArchivedWorkspace(w, transfer.WorkspaceUuid) {
+		return
+	}
+	if oh.db.GetWorkspaceUser(pubKeyFromAuth, transfer.WorkspaceUuid).OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("You are no longer a user of the workspace")
+		return
+	}
+
+	transfer, err := oh.db.AcceptWorkspaceTransfer(transfer.Uuid)
+	if errors.Is(err, db.ErrTransferResolved) || errors.Is(err, db.ErrTransferStale) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[workspaces] could not accept transfer", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not accept the transfer")
+		return
+	}
+
+	workspace := oh.db.GetWorkspaceByUuid(transfer.WorkspaceUuid)
+	notifications.Notify(transfer.FromPubKey, db.NotificationWorkspaceTransfer,
+		fmt.Sprintf("%s was transferred", workspace.Name),
+		fmt.Sprintf("The new owner accepted %s. You stay on as a user with every role", workspace.Name),
+		"/workspace/"+workspace.Uuid)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(transfer)
+}
+
+// DeclineWorkspaceTransfer is the new owner turning the transfer down
+func (oh *workspaceHandler) DeclineWorkspaceTransfer(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, transfer, ok := oh.transferFromUrl(w, r)
+	if !ok {
+		return
+	}
+	if pubKeyFromAuth != transfer.ToPubKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the new owner can decline the transfer")
+		return
+	}
+	oh.resolveTransfer(w, transfer, db.WorkspaceTransferDeclined, pubKeyFromAuth, transfer.FromPubKey)
+}
+
+// CancelWorkspaceTransfer is the owner taking the offer back
+func (oh *workspaceHandler) CancelWorkspaceTransfer(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, transfer, ok := oh.transferFromUrl(w, r)
+	if !ok {
+		return
+	}
+	if pubKeyFromAuth != transfer.FromPubKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the owner can cancel the transfer")
+		return
+	}
+	oh.resolveTransfer(w, transfer, db.WorkspaceTransferCancelled, pubKeyFromAuth, transfer.ToPubKey)
+}
+
+func (oh *workspaceHandler) resolveTransfer(w http.ResponseWriter, transfer db.WorkspaceTransfer, status db.WorkspaceTransferStatus, actor string, notify string) {
+	transfer, err := oh.db.ResolveWorkspaceTransfer(transfer.Uuid, status, actor)
+	if errors.Is(err, db.ErrTransferResolved) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[workspaces] could not resolve transfer", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not update the transfer")
+		return
+	}
+
+	workspace := oh.db.GetWorkspaceByUuid(transfer.WorkspaceUuid)
+	notifications.Notify(notify, db.NotificationWorkspaceTransfer,
+		fmt.Sprintf("Transfer of %s %s", workspace.Name, status),
+		fmt.Sprintf("The transfer of %s was %s", workspace.Name, status),
+		"/workspace/"+workspace.Uuid)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(transfer)
+}
+
+// transferFromUrl loads the transfer in the url, it is not found for anyone but its two parties
+func (oh *workspaceHandler) transferFromUrl(w http.ResponseWriter, r *http.Request) (string, db.WorkspaceTransfer, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return "", db.WorkspaceTransfer{}, false
+	}
+
+	transfer := oh.db.GetWorkspaceTransferByUuid(chi.URLParam(r, "uuid"))
+	if transfer.ID == 0 || (pubKeyFromAuth != transfer.FromPubKey && pubKeyFromAuth != transfer.ToPubKey) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Transfer not found")
+		return "", db.WorkspaceTransfer{}, false
+	}
+	return pubKeyFromAuth, transfer, true
+}
+
+// ExpireWorkspaceTransfers closes the transfers that were not accepted in time and tells both parties
+func ExpireWorkspaceTransfers() {
+	NewWorkspaceHandler(db.DB).expireWorkspaceTransfers()
+}
+
+func (oh *workspaceHandler) expireWorkspaceTransfers() {
+	for _, transfer := range oh.db.ExpireWorkspaceTransfers(time.Now()) {
+		workspace := oh.db.GetWorkspaceByUuid(transfer.WorkspaceUuid)
+		title := fmt.Sprintf("Transfer of %s expired", workspace.Name)
+		content := fmt.Sprintf("The transfer of %s was not accepted in time", workspace.Name)
+		for _, pubkey := range []string{transfer.FromPubKey, transfer.ToPubKey} {
+			notifications.Notify(pubkey, db.NotificationWorkspaceTransfer, title, content, "/workspace/"+workspace.Uuid)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkspaceTransfers(t *testing.T) {
+	request := func(pubkey string, param string, value string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add(param, value)
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+		return req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, pubkey))
+	}
+	workspace := db.Workspace{ID: 1, Uuid: "ws", Name: "Alpha", OwnerPubKey: "owner"}
+	expires := time.Now().Add(time.Hour)
+	transfer := db.WorkspaceTransfer{ID: 1, Uuid: "transfer", WorkspaceUuid: "ws", FromPubKey: "owner", ToPubKey: "member", Status: db.WorkspaceTransferPending, ExpiresAt: &expires}
+
+	t.Run("Should test that only the owner offers the workspace to one of its users", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceByUuid", "ws").Return(workspace)
+		mockDb.On("GetWorkspaceUser", "member", "ws").Return(db.WorkspaceUsers{OwnerPubKey: "member", WorkspaceUuid: "ws"})
+		mockDb.On("GetWorkspaceUser", "stranger", "ws").Return(db.WorkspaceUsers{})
+
+		rr := httptest.NewRecorder()
+		oh.StartWorkspaceTransfer(rr, request("member", "workspace_uuid", "ws", `{"to_pubkey": "member"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		rr = httptest.NewRecorder()
+		oh.StartWorkspaceTransfer(rr, request("owner", "workspace_uuid", "ws", `{"to_pubkey": "stranger"}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = httptest.NewRecorder()
+		oh.StartWorkspaceTransfer(rr, request("owner", "workspace_uuid", "ws", `{"to_pubkey": "member", "hours": 1000}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		mockDb.On("CreateWorkspaceTransfer", mock.MatchedBy(func(m db.WorkspaceTransfer) bool {
+			return m.WorkspaceUuid == "ws" && m.FromPubKey == "owner" && m.ToPubKey == "member" &&
+				m.ExpiresAt.Sub(time.Now()) > 71*time.Hour && m.ExpiresAt.Sub(time.Now()) <= 72*time.Hour
+		})).Return(transfer, nil).Once()
+		rr = httptest.NewRecorder()
+		oh.StartWorkspaceTransfer(rr, request("owner", "workspace_uuid", "ws", `{"to_pubkey": "member"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		mockDb.On("CreateWorkspaceTransfer", mock.Anything).Return(db.WorkspaceTransfer{}, db.ErrTransferPending).Once()
+		rr = httptest.NewRecorder()
+		oh.StartWorkspaceTransfer(rr, request("owner", "workspace_uuid", "ws", `{"to_pubkey": "member"}`))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Should test that only the new owner accepts a transfer", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceTransferByUuid", "transfer").Return(transfer)
+
+		rr := httptest.NewRecorder()
+		oh.AcceptWorkspaceTransfer(rr, request("stranger", "uuid", "transfer", ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = httptest.NewRecorder()
+		oh.AcceptWorkspaceTransfer(rr, request("owner", "uuid", "transfer", ""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		mockDb.On("GetWorkspaceUser", "member", "ws").Return(db.WorkspaceUsers{OwnerPubKey: "member", WorkspaceUuid: "ws"})
+		accepted := transfer
+		accepted.Status = db.WorkspaceTransferAccepted
+		mockDb.On("AcceptWorkspaceTransfer", "transfer").Return(accepted, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "ws").Return(db.Workspace{ID: 1, Uuid: "ws", Name: "Alpha", OwnerPubKey: "member"})
+		rr = httptest.NewRecorder()
+		oh.AcceptWorkspaceTransfer(rr, request("member", "uuid", "transfer", ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		mockDb.On("AcceptWorkspaceTransfer", "transfer").Return(db.WorkspaceTransfer{}, db.ErrTransferResolved).Once()
+		rr = httptest.NewRecorder()
+		oh.AcceptWorkspaceTransfer(rr, request("member", "uuid", "transfer", ""))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Should test that the owner cancels and the new owner declines", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		mockDb.On("GetWorkspaceTransferByUuid", "transfer").Return(transfer)
+		mockDb.On("GetWorkspaceByUuid", "ws").Return(workspace)
+
+		rr := httptest.NewRecorder()
+		oh.CancelWorkspaceTransfer(rr, request("member", "uuid", "transfer", ""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		mockDb.On("ResolveWorkspaceTransfer", "transfer", db.WorkspaceTransferCancelled, "owner").Return(transfer, nil).Once()
+		rr = httptest.NewRecorder()
+		oh.CancelWorkspaceTransfer(rr, request("owner", "uuid", "transfer", ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		mockDb.On("ResolveWorkspaceTransfer", "transfer", db.WorkspaceTransferDeclined, "member").Return(db.WorkspaceTransfer{}, db.ErrTransferResolved).Once()
+		rr = httptest.NewRecorder()
+		oh.DeclineWorkspaceTransfer(rr, request("member", "uuid", "transfer", ""))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Should test that both parties hear about an expired transfer", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		oh := NewWorkspaceHandler(mockDb)
+		mockDb.On("ExpireWorkspaceTransfers", mock.Anything).Return([]db.WorkspaceTransfer{transfer}).Once()
+		mockDb.On("GetWorkspaceByUuid", "ws").Return(workspace).Once()
+
+		oh.expireWorkspaceTransfers()
+	})
+}
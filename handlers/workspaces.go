@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -101,6 +103,15 @@ func (oh *workspaceHandler) CreateOrEditWorkspace(w http.ResponseWriter, r *http
 		return
 	}
 
+	if rejectArchivedWorkspace(w, workspace.Uuid) {
+		return
+	}
+
+	// archiving has its own endpoints
+	workspace.Archived = false
+	workspace.ArchivedAt = nil
+	workspace.ArchivedBy = ""
+
 	existing := oh.db.GetWorkspaceByUuid(workspace.Uuid)
 	if existing.ID == 0 { // new!
 		if workspace.ID != 0 { // can't try to "edit" if it does not exist already
@@ -125,6 +136,12 @@ func (oh *workspaceHandler) CreateOrEditWorkspace(w http.ResponseWriter, r *http
 			workspace.Uuid = xid.New().String()
 		}
 	} else {
+		// an owner hands over a workspace with a transfer the new owner accepts
+		if workspace.OwnerPubKey != existing.OwnerPubKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode("The owner of a workspace is changed with a transfer")
+			return
+		}
 		workspace.Updated = &now
 		workspace.Created = existing.Created
 	}
@@ -197,6 +214,10 @@ func CreateWorkspaceUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if rejectArchivedWorkspace(w, workspaceUser.WorkspaceUuid) {
+		return
+	}
+
 	// check if the user is the workspace admin
 	if workspaceUser.OwnerPubKey == workspace.OwnerPubKey {
 		w.WriteHeader(http.StatusUnauthorized)
@@ -310,6 +331,10 @@ func DeleteWorkspaceUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if rejectArchivedWorkspace(w, workspaceUser.WorkspaceUuid) {
+		return
+	}
+
 	workspace := db.DB.GetWorkspaceByUuid(workspaceUser.WorkspaceUuid)
 
 	if workspaceUser.OwnerPubKey == workspace.OwnerPubKey {
@@ -375,6 +400,10 @@ func AddUserRoles(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if rejectArchivedWorkspace(w, uuid) {
+		return
+	}
+
 	// if not the orgnization admin
 	hasRole := db.UserHasAccess(pubKeyFromAuth, uuid, db.AddRoles)
 	isUser := db.CheckUser(roles, pubKeyFromAuth)
@@ -572,6 +601,7 @@ func (oh *workspaceHandler) GetWorkspaceBudget(w http.ResponseWriter, r *http.Re
 
 	// get the workspace budget
 	workspaceBudget := oh.db.GetWorkspaceStatusBudget(uuid)
+	workspaceBudget.CurrentBudgetFiat = db.FiatAt(workspaceBudget.CurrentBudget)
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(workspaceBudget)
@@ -597,6 +627,58 @@ func (oh *workspaceHandler) GetWorkspaceBudgetHistory(w http.ResponseWriter, r *
 	json.NewEncoder(w).Encode(workspaceBudget)
 }
 
+// GetWorkspaceLedger returns the budget ledger entries with running balances, ?format=csv exports them
+func (oh *workspaceHandler) GetWorkspaceLedger(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "workspace_uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	hasRole := oh.userHasAccess(pubKeyFromAuth, uuid, db.ViewReport)
+	if !hasRole {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to view the ledger")
+		return
+	}
+
+	ledger := oh.db.GetWorkspaceLedger(uuid)
+
+	if r.URL.Query().Get("format") != "csv" {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(ledger)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="ledger-%s.csv"`, uuid))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"created", "transaction_uuid", "payment_type", "counterparty", "bounty_id", "debit", "credit", "balance"})
+	for _, line := range ledger.Entries {
+		created := ""
+		if line.Created != nil {
+			created = line.Created.UTC().Format(time.RFC3339)
+		}
+		writer.Write([]string{
+			created,
+			line.TransactionUuid,
+			string(line.PaymentType),
+			line.Counterparty,
+			strconv.Itoa(int(line.BountyId)),
+			strconv.Itoa(int(line.Debit)),
+			strconv.Itoa(int(line.Credit)),
+			strconv.FormatInt(line.Balance, 10),
+		})
+	}
+	writer.Flush()
+}
+
 func GetPaymentHistory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
@@ -776,6 +858,9 @@ func (oh *workspaceHandler) DeleteWorkspace(w http.ResponseWriter, r *http.Reque
 		json.NewEncoder(w).Encode(msg)
 		return
 	}
+	if rejectArchivedWorkspace(w, uuid) {
+		return
+	}
 
 	// Soft delete Workspace and delete user data
 	if err := oh.db.ProcessDeleteWorkspace(uuid); err != nil {
@@ -810,6 +895,12 @@ func (oh *workspaceHandler) UpdateWorkspace(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if oh.db.GetWorkspaceByUuid(workspace.Uuid).OwnerPubKey != workspace.OwnerPubKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("The owner of a workspace is changed with a transfer")
+		return
+	}
+
 	if pubKeyFromAuth != workspace.OwnerPubKey {
 		hasRole := db.UserHasAccess(pubKeyFromAuth, workspace.Uuid, db.EditOrg)
 		if !hasRole {
@@ -831,6 +922,14 @@ func (oh *workspaceHandler) UpdateWorkspace(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if rejectArchivedWorkspace(w, workspace.Uuid) {
+		return
+	}
+
+	workspace.Archived = false
+	workspace.ArchivedAt = nil
+	workspace.ArchivedBy = ""
+
 	p, err := oh.db.CreateOrEditWorkspace(workspace)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -867,6 +966,10 @@ func (oh *workspaceHandler) CreateOrEditWorkspaceRepository(w http.ResponseWrite
 	}
 
 	workspaceRepo.UpdatedBy = pubKeyFromAuth
+	// the sync fields are only written by the repository sync
+	workspaceRepo.OpenPrCount = 0
+	workspaceRepo.LastSyncedAt = nil
+	workspaceRepo.SyncError = ""
 
 	if workspaceRepo.Uuid == "" {
 		workspaceRepo.Uuid = xid.New().String()
@@ -891,6 +994,10 @@ func (oh *workspaceHandler) CreateOrEditWorkspaceRepository(w http.ResponseWrite
 		return
 	}
 
+	if rejectArchivedWorkspace(w, workspaceRepo.WorkspaceUuid) {
+		return
+	}
+
 	p, err := oh.db.CreateOrEditWorkspaceRepository(workspaceRepo)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -954,6 +1061,10 @@ func (oh *workspaceHandler) DeleteWorkspaceRepository(w http.ResponseWriter, r *
 	workspace_uuid := chi.URLParam(r, "workspace_uuid")
 	uuid := chi.URLParam(r, "uuid")
 
+	if rejectArchivedWorkspace(w, workspace_uuid) {
+		return
+	}
+
 	oh.db.DeleteWorkspaceRepository(workspace_uuid, uuid)
 
 	w.WriteHeader(http.StatusOK)
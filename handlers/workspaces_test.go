@@ -16,6 +16,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -687,3 +688,70 @@ func GetFeaturesByWorkspaceUuid(t *testing.T) {
 func TestDeleteWorkspaceRepository(t *testing.T) {
 
 }
+
+func TestGetWorkspaceLedger(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+
+	ledger := db.WorkspaceLedger{
+		WorkspaceUuid: "workspace_uuid",
+		Balance:       700,
+		StoredBudget:  700,
+		Entries: db.LedgerRunningBalances([]db.LedgerEntry{
+			{TransactionUuid: "deposit", Account: db.LedgerBudget, Debit: 1000, PaymentType: db.Deposit},
+			{TransactionUuid: "payment", Account: db.LedgerBudget, Credit: 300, PaymentType: db.Payment, BountyId: 4},
+		}),
+	}
+
+	ledgerRequest := func(pubkey string, url string) *httptest.ResponseRecorder {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", "workspace_uuid")
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		if pubkey != "" {
+			ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		}
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceLedger).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that a 401 is returned without the view report role", func(t *testing.T) {
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return false
+		}
+		rr := ledgerRequest("pubkey", "/workspaces/workspace_uuid/ledger")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return role == db.ViewReport
+	}
+
+	t.Run("Should test that the ledger is returned with running balances", func(t *testing.T) {
+		mockDb.On("GetWorkspaceLedger", "workspace_uuid").Return(ledger).Once()
+
+		rr := ledgerRequest("pubkey", "/workspaces/workspace_uuid/ledger")
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		returned := db.WorkspaceLedger{}
+		err := json.Unmarshal(rr.Body.Bytes(), &returned)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(700), returned.Balance)
+		assert.Len(t, returned.Entries, 2)
+		assert.Equal(t, int64(1000), returned.Entries[0].Balance)
+		assert.Equal(t, int64(700), returned.Entries[1].Balance)
+	})
+
+	t.Run("Should test that the ledger is exported as csv", func(t *testing.T) {
+		mockDb.On("GetWorkspaceLedger", "workspace_uuid").Return(ledger).Once()
+
+		rr := ledgerRequest("pubkey", "/workspaces/workspace_uuid/ledger?format=csv")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+
+		rows := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+		assert.Len(t, rows, 3)
+		assert.Equal(t, ",payment,payment,,4,0,300,700", rows[2])
+	})
+}
@@ -0,0 +1,185 @@
+package lightning
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/rs/xid"
+)
+
+type clnBackend struct {
+	httpClient HttpClient
+	url        string
+	clnRune    string
+}
+
+// NewClnBackend talks to core lightning through the clnrest plugin, authenticated with a rune
+func NewClnBackend(httpClient HttpClient, url string, clnRune string) Backend {
+	return &clnBackend{
+		httpClient: httpClient,
+		url:        strings.TrimSuffix(url, "/"),
+		clnRune:    clnRune,
+	}
+}
+
+type clnPaymentResponse struct {
+	Status          string `json:"status"`
+	PaymentHash     string `json:"payment_hash"`
+	PaymentPreimage string `json:"payment_preimage"`
+	AmountMsat      uint64 `json:"amount_msat"`
+}
+
+// request calls a core lightning rpc method, clnrest exposes every method as POST /v1/{method}
+func (cb *clnBackend) request(method string, params interface{}, out interface{}) error {
+	data, _ := json.Marshal(params)
+
+	status, resBody, err := doRequest(cb.httpClient, http.MethodPost, cb.url+"/v1/"+method, map[string]string{"Rune": cb.clnRune}, data)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusCreated {
+		clnErr := struct {
+			Message string `json:"message"`
+		}{}
+		if json.Unmarshal(resBody, &clnErr) == nil && clnErr.Message != "" {
+			return &Error{Message: clnErr.Message}
+		}
+		return statusError(status, resBody)
+	}
+	return json.Unmarshal(resBody, out)
+}
+
+func (cb *clnBackend) CreateInvoice(amount uint, memo string) (Invoice, error) {
+	invoiceRes := struct {
+		Bolt11      string `json:"bolt11"`
+		PaymentHash string `json:"payment_hash"`
+	}{}
+	params := map[string]interface{}{
+		"amount_msat": uint64(amount) * 1000,
+		"label":       xid.New().String(),
+		"description": memo,
+	}
+	if err := cb.request("invoice", params, &invoiceRes); err != nil {
+		return Invoice{}, err
+	}
+
+	return Invoice{
+		PaymentRequest: invoiceRes.Bolt11,
+		PaymentHash:    invoiceRes.PaymentHash,
+		Amount:         amount,
+	}, nil
+}
+
+func (cb *clnBackend) CreateInvoiceWithDescriptionHash(amount uint, description string) (Invoice, error) {
+	invoiceRes := struct {
+		Bolt11      string `json:"bolt11"`
+		PaymentHash string `json:"payment_hash"`
+	}{}
+	params := map[string]interface{}{
+		"amount_msat":  uint64(amount) * 1000,
+		"label":        xid.New().String(),
+		"description":  description,
+		"deschashonly": true,
+	}
+	if err := cb.request("invoice", params, &invoiceRes); err != nil {
+		return Invoice{}, err
+	}
+
+	return Invoice{
+		PaymentRequest: invoiceRes.Bolt11,
+		PaymentHash:    invoiceRes.PaymentHash,
+		Amount:         amount,
+	}, nil
+}
+
+func (cb *clnBackend) CheckInvoice(paymentRequest string) (Invoice, error) {
+	invoicesRes := struct {
+		Invoices []struct {
+			Status             string `json:"status"`
+			PaymentHash        string `json:"payment_hash"`
+			PaymentPreimage    string `json:"payment_preimage"`
+			AmountMsat         uint64 `json:"amount_msat"`
+			AmountReceivedMsat uint64 `json:"amount_received_msat"`
+		} `json:"invoices"`
+	}{}
+	if err := cb.request("listinvoices", map[string]interface{}{"invstring": paymentRequest}, &invoicesRes); err != nil {
+		return Invoice{}, err
+	}
+	if len(invoicesRes.Invoices) == 0 {
+		return Invoice{}, &Error{Message: "invoice not found"}
+	}
+
+	found := invoicesRes.Invoices[0]
+	invoice := Invoice{
+		PaymentRequest: paymentRequest,
+		PaymentHash:    found.PaymentHash,
+		Amount:         uint(found.AmountMsat / 1000),
+		Settled:        found.Status == "paid",
+	}
+	if invoice.Settled {
+		invoice.Preimage = found.PaymentPreimage
+		invoice.Amount = uint(found.AmountReceivedMsat / 1000)
+	}
+	return invoice, nil
+}
+
+func (cb *clnBackend) PayInvoice(paymentRequest string) (Invoice, error) {
+	paymentRes := clnPaymentResponse{}
+	if err := cb.request("pay", map[string]interface{}{"bolt11": paymentRequest}, &paymentRes); err != nil {
+		return Invoice{}, err
+	}
+	if paymentRes.Status != "complete" {
+		return Invoice{}, &Error{Message: "payment " + paymentRes.Status}
+	}
+
+	return Invoice{
+		PaymentRequest: paymentRequest,
+		PaymentHash:    paymentRes.PaymentHash,
+		Preimage:       paymentRes.PaymentPreimage,
+		Amount:         uint(paymentRes.AmountMsat / 1000),
+		Settled:        true,
+	}, nil
+}
+
+// PayKeysend sends a spontaneous payment, route hints are not passed on so the
+// destination has to be reachable from the node's graph
+func (cb *clnBackend) PayKeysend(amount uint, pubkey string, routeHint string) (Payment, error) {
+	paymentRes := clnPaymentResponse{}
+	params := map[string]interface{}{
+		"destination": pubkey,
+		"amount_msat": uint64(amount) * 1000,
+	}
+	if err := cb.request("keysend", params, &paymentRes); err != nil {
+		return Payment{}, err
+	}
+	if paymentRes.Status != "complete" {
+		return Payment{}, &Error{Message: "keysend " + paymentRes.Status}
+	}
+
+	return Payment{
+		PaymentHash: paymentRes.PaymentHash,
+		Preimage:    paymentRes.PaymentPreimage,
+		Amount:      amount,
+	}, nil
+}
+
+func (cb *clnBackend) GetBalance() (uint, error) {
+	fundsRes := struct {
+		Channels []struct {
+			OurAmountMsat uint64 `json:"our_amount_msat"`
+			State         string `json:"state"`
+		} `json:"channels"`
+	}{}
+	if err := cb.request("listfunds", map[string]interface{}{}, &fundsRes); err != nil {
+		return 0, err
+	}
+
+	var balance uint64
+	for _, channel := range fundsRes.Channels {
+		if channel.State == "CHANNELD_NORMAL" {
+			balance += channel.OurAmountMsat
+		}
+	}
+	return uint(balance / 1000), nil
+}
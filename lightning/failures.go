@@ -0,0 +1,66 @@
+package lightning
+
+import (
+	"errors"
+	"net"
+	"strings"
+)
+
+type FailureKind string
+
+const (
+	// FailureTransient payments can be retried, the route or the peer may be back later
+	FailureTransient FailureKind = "transient"
+	// FailurePermanent payments will fail the same way however often they are retried
+	FailurePermanent FailureKind = "permanent"
+	// FailureUnknown payments may have gone through, they are never retried automatically
+	FailureUnknown FailureKind = "unknown"
+)
+
+var transientFailures = []string{
+	"no route",
+	"route not found",
+	"unable to find a path",
+	"unable to route",
+	"temporary channel failure",
+	"temporary_channel_failure",
+	"peer offline",
+	"peer is offline",
+	"not connected",
+	"timeout",
+	"timed out",
+	"insufficient local balance",
+	"insufficient balance",
+	"failure_reason_no_route",
+	"failure_reason_timeout",
+}
+
+// ClassifyFailure sorts a payment error into the failure taxonomy. Refusals from the node are
+// transient when they describe routing or liquidity, a node that could not be reached is
+// transient too, any other error leaves the payment state unknown
+func ClassifyFailure(err error) FailureKind {
+	if err == nil {
+		return ""
+	}
+	if errors.Is(err, ErrKeysendUnsupported) {
+		return FailurePermanent
+	}
+
+	if message, rejected := Rejected(err); rejected {
+		message = strings.ToLower(message)
+		for _, transient := range transientFailures {
+			if strings.Contains(message, transient) {
+				return FailureTransient
+			}
+		}
+		return FailurePermanent
+	}
+
+	// the request never reached the node, so nothing was paid
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return FailureTransient
+	}
+
+	return FailureUnknown
+}
@@ -0,0 +1,40 @@
+package lightning
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+type HoldInvoiceState string
+
+const (
+	HoldInvoiceOpen HoldInvoiceState = "open"
+	// HoldInvoiceAccepted invoices are paid but the payment is held until it is settled or cancelled
+	HoldInvoiceAccepted HoldInvoiceState = "accepted"
+	HoldInvoiceSettled  HoldInvoiceState = "settled"
+	HoldInvoiceCanceled HoldInvoiceState = "canceled"
+)
+
+// holdInvoiceCltvExpiry is how many blocks, about a week, a paid hold invoice can be held before
+// the payer's node takes the payment back, escrows have to be released or refunded before that
+const holdInvoiceCltvExpiry = 1008
+
+// HoldInvoicer is implemented by backends that can hold an incoming payment until it is settled
+// with the preimage or cancelled, which bounty escrows rely on
+type HoldInvoicer interface {
+	CreateHoldInvoice(amount uint, memo string, paymentHash string) (Invoice, error)
+	LookupHoldInvoice(paymentHash string) (HoldInvoiceState, error)
+	SettleHoldInvoice(preimage string) error
+	CancelHoldInvoice(paymentHash string) error
+}
+
+// NewPreimage returns a random hex preimage and its payment hash
+func NewPreimage() (string, string, error) {
+	preimage := make([]byte, 32)
+	if _, err := rand.Read(preimage); err != nil {
+		return "", "", err
+	}
+	paymentHash := sha256.Sum256(preimage)
+	return hex.EncodeToString(preimage), hex.EncodeToString(paymentHash[:]), nil
+}
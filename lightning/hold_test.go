@@ -0,0 +1,71 @@
+package lightning
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLndHoldInvoices(t *testing.T) {
+	preimage, paymentHash, err := NewPreimage()
+	assert.NoError(t, err)
+
+	decoded, _ := hex.DecodeString(preimage)
+	hash := sha256.Sum256(decoded)
+	assert.Equal(t, hex.EncodeToString(hash[:]), paymentHash)
+
+	t.Run("Should test that a hold invoice is created for the payment hash", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v2/invoices/hodl", r.URL.Path)
+			body := map[string]string{}
+			json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, base64.StdEncoding.EncodeToString(hash[:]), body["hash"])
+			assert.Equal(t, "1000", body["value"])
+			w.Write([]byte(`{"payment_request": "lnbc-hold"}`))
+		}))
+		defer ts.Close()
+
+		invoice, err := NewLndBackend(http.DefaultClient, ts.URL, "macaroon").(HoldInvoicer).CreateHoldInvoice(1000, "escrow", paymentHash)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "lnbc-hold", invoice.PaymentRequest)
+		assert.Equal(t, paymentHash, invoice.PaymentHash)
+	})
+
+	t.Run("Should test that a paid hold invoice is accepted", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/invoice/"+paymentHash, r.URL.Path)
+			w.Write([]byte(`{"state": "ACCEPTED"}`))
+		}))
+		defer ts.Close()
+
+		state, err := NewLndBackend(http.DefaultClient, ts.URL, "macaroon").(HoldInvoicer).LookupHoldInvoice(paymentHash)
+
+		assert.NoError(t, err)
+		assert.Equal(t, HoldInvoiceAccepted, state)
+	})
+
+	t.Run("Should test that settling sends the preimage", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v2/invoices/settle", r.URL.Path)
+			body := map[string]string{}
+			json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, base64.StdEncoding.EncodeToString(decoded), body["preimage"])
+			w.Write([]byte(`{}`))
+		}))
+		defer ts.Close()
+
+		assert.NoError(t, NewLndBackend(http.DefaultClient, ts.URL, "macaroon").(HoldInvoicer).SettleHoldInvoice(preimage))
+	})
+
+	t.Run("Should test that only lnd holds invoices", func(t *testing.T) {
+		_, ok := NewRelayBackend(http.DefaultClient).(HoldInvoicer)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,122 @@
+package lightning
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+const (
+	BackendRelay  = "relay"
+	BackendLnd    = "lnd"
+	BackendCln    = "cln"
+	BackendLndhub = "lndhub"
+)
+
+// keysend record type the preimage is sent in
+const keysendRecordType = 5482373484
+
+var ErrKeysendUnsupported = errors.New("keysend is not supported by this lightning backend")
+
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Backend is the lightning node bounty payments and budget invoices go through
+type Backend interface {
+	CreateInvoice(amount uint, memo string) (Invoice, error)
+	CheckInvoice(paymentRequest string) (Invoice, error)
+	PayInvoice(paymentRequest string) (Invoice, error)
+	PayKeysend(amount uint, pubkey string, routeHint string) (Payment, error)
+	GetBalance() (uint, error)
+}
+
+// DescriptionHashInvoicer is implemented by backends that can create invoices committing to the
+// hash of a description instead of a memo, which LNURL-pay wallets check against the metadata
+type DescriptionHashInvoicer interface {
+	CreateInvoiceWithDescriptionHash(amount uint, description string) (Invoice, error)
+}
+
+type Invoice struct {
+	PaymentRequest string `json:"payment_request"`
+	PaymentHash    string `json:"payment_hash"`
+	Preimage       string `json:"preimage"`
+	Amount         uint   `json:"amount"`
+	Settled        bool   `json:"settled"`
+}
+
+type Payment struct {
+	PaymentHash string `json:"payment_hash"`
+	Preimage    string `json:"preimage"`
+	Amount      uint   `json:"amount"`
+}
+
+// Error is returned when the node answered but refused the request, as opposed
+// to the node not being reachable or answering with something unreadable
+type Error struct {
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Rejected returns the node's message if err is a refusal from the node
+func Rejected(err error) (string, bool) {
+	var lnErr *Error
+	if errors.As(err, &lnErr) {
+		return lnErr.Message, true
+	}
+	return "", false
+}
+
+// NewBackend returns the backend selected with LIGHTNING_BACKEND, the relay is the default
+func NewBackend(httpClient HttpClient) Backend {
+	switch strings.ToLower(config.LightningBackend) {
+	case BackendLnd:
+		return NewLndBackend(httpClient, config.LndUrl, config.LndMacaroon)
+	case BackendCln:
+		return NewClnBackend(httpClient, config.ClnUrl, config.ClnRune)
+	case BackendLndhub:
+		return NewLndhubBackend(httpClient, config.LndhubUrl, config.LndhubLogin, config.LndhubPassword)
+	default:
+		return NewRelayBackend(httpClient)
+	}
+}
+
+func doRequest(httpClient HttpClient, method string, url string, headers map[string]string, body []byte) (int, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewBuffer(body)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return res.StatusCode, nil, err
+	}
+	return res.StatusCode, resBody, nil
+}
+
+func statusError(status int, body []byte) error {
+	return &Error{Message: fmt.Sprintf("lightning node returned %d: %s", status, strings.TrimSpace(string(body)))}
+}
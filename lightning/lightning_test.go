@@ -0,0 +1,213 @@
+package lightning
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const testInvoice = "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs"
+
+func TestNewBackend(t *testing.T) {
+	defer func() { config.LightningBackend = "" }()
+
+	tests := map[string]interface{}{
+		"":       &relayBackend{},
+		"relay":  &relayBackend{},
+		"LND":    &lndBackend{},
+		"cln":    &clnBackend{},
+		"lndhub": &lndhubBackend{},
+	}
+	for name, expected := range tests {
+		config.LightningBackend = name
+		assert.IsType(t, expected, NewBackend(http.DefaultClient), name)
+	}
+}
+
+func TestRelayBackend(t *testing.T) {
+	defer func(url string) { config.RelayUrl = url }(config.RelayUrl)
+
+	t.Run("Should test that an invoice check accepts the amount as a number or a string", func(t *testing.T) {
+		for _, amount := range []string{`1000`, `"1000"`} {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/invoice", r.URL.Path)
+				assert.Equal(t, "req", r.URL.Query().Get("payment_request"))
+				w.Write([]byte(`{"success": true, "response": {"settled": true, "payment_request": "req", "amount": ` + amount + `}}`))
+			}))
+			config.RelayUrl = ts.URL
+
+			invoice, err := NewRelayBackend(http.DefaultClient).CheckInvoice("req")
+			ts.Close()
+
+			assert.NoError(t, err)
+			assert.True(t, invoice.Settled)
+			assert.Equal(t, uint(1000), invoice.Amount)
+		}
+	})
+
+	t.Run("Should test that a relay error is returned as a rejection", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"success": false, "error": "no route"}`))
+		}))
+		defer ts.Close()
+		config.RelayUrl = ts.URL
+
+		_, err := NewRelayBackend(http.DefaultClient).PayInvoice("req")
+
+		message, rejected := Rejected(err)
+		assert.True(t, rejected)
+		assert.Equal(t, "no route", message)
+	})
+
+	t.Run("Should test that an unreachable relay is not a rejection", func(t *testing.T) {
+		config.RelayUrl = "http://127.0.0.1:1"
+
+		_, err := NewRelayBackend(http.DefaultClient).PayKeysend(10, "pubkey", "")
+
+		_, rejected := Rejected(err)
+		assert.Error(t, err)
+		assert.False(t, rejected)
+	})
+}
+
+func TestLndBackend(t *testing.T) {
+	t.Run("Should test that keysend sends the preimage of the payment hash as a custom record", func(t *testing.T) {
+		pubkey := "021111111111111111111111111111111111111111111111111111111111111111"
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/channels/transactions", r.URL.Path)
+			assert.Equal(t, "macaroon", r.Header.Get("Grpc-Metadata-macaroon"))
+
+			body := struct {
+				Amt           string            `json:"amt"`
+				PaymentHash   string            `json:"payment_hash"`
+				CustomRecords map[string]string `json:"dest_custom_records"`
+			}{}
+			json.NewDecoder(r.Body).Decode(&body)
+
+			preimage, _ := base64.StdEncoding.DecodeString(body.CustomRecords["5482373484"])
+			hash := sha256.Sum256(preimage)
+			assert.Equal(t, "500", body.Amt)
+			assert.Equal(t, base64.StdEncoding.EncodeToString(hash[:]), body.PaymentHash)
+
+			json.NewEncoder(w).Encode(map[string]string{"payment_preimage": body.CustomRecords["5482373484"], "payment_hash": body.PaymentHash})
+		}))
+		defer ts.Close()
+
+		payment, err := NewLndBackend(http.DefaultClient, ts.URL, "macaroon").PayKeysend(500, pubkey, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(500), payment.Amount)
+		assert.Len(t, payment.Preimage, 64)
+	})
+
+	t.Run("Should test that a payment error is returned as a rejection", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"payment_error": "insufficient local balance"}`))
+		}))
+		defer ts.Close()
+
+		_, err := NewLndBackend(http.DefaultClient, ts.URL, "macaroon").PayInvoice(testInvoice)
+
+		message, rejected := Rejected(err)
+		assert.True(t, rejected)
+		assert.Equal(t, "insufficient local balance", message)
+	})
+
+	t.Run("Should test that an invoice is looked up by its payment hash", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/invoice/90570c8d3688ad5012aa5ff982606971ae46b3f9df0a100cb15f05f61718f223", r.URL.Path)
+			w.Write([]byte(`{"state": "SETTLED", "value": "1500", "amt_paid_sat": "1500"}`))
+		}))
+		defer ts.Close()
+
+		invoice, err := NewLndBackend(http.DefaultClient, ts.URL, "macaroon").CheckInvoice(testInvoice)
+
+		assert.NoError(t, err)
+		assert.True(t, invoice.Settled)
+		assert.Equal(t, uint(1500), invoice.Amount)
+	})
+}
+
+func TestClnBackend(t *testing.T) {
+	t.Run("Should test that keysend is sent in millisatoshis with the rune", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/keysend", r.URL.Path)
+			assert.Equal(t, "rune", r.Header.Get("Rune"))
+
+			body := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "pubkey", body["destination"])
+			assert.Equal(t, float64(250000), body["amount_msat"])
+
+			w.Write([]byte(`{"status": "complete", "payment_hash": "hash", "payment_preimage": "preimage"}`))
+		}))
+		defer ts.Close()
+
+		payment, err := NewClnBackend(http.DefaultClient, ts.URL, "rune").PayKeysend(250, "pubkey", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "preimage", payment.Preimage)
+	})
+
+	t.Run("Should test that the balance only counts usable channels", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"channels": [{"our_amount_msat": 2000000, "state": "CHANNELD_NORMAL"}, {"our_amount_msat": 5000000, "state": "ONCHAIN"}]}`))
+		}))
+		defer ts.Close()
+
+		balance, err := NewClnBackend(http.DefaultClient, ts.URL, "rune").GetBalance()
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2000), balance)
+	})
+}
+
+func TestLndhubBackend(t *testing.T) {
+	t.Run("Should test that the access token is fetched once and sent with every call", func(t *testing.T) {
+		authCalls := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/auth" {
+				authCalls++
+				w.Write([]byte(`{"access_token": "token", "refresh_token": "refresh"}`))
+				return
+			}
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			w.Write([]byte(`{"BTC": {"AvailableBalance": 4200}}`))
+		}))
+		defer ts.Close()
+
+		backend := NewLndhubBackend(http.DefaultClient, ts.URL, "login", "password")
+		backend.GetBalance()
+		balance, err := backend.GetBalance()
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(4200), balance)
+		assert.Equal(t, 1, authCalls)
+	})
+
+	t.Run("Should test that keysend is not supported", func(t *testing.T) {
+		_, err := NewLndhubBackend(http.DefaultClient, "http://127.0.0.1:1", "login", "password").PayKeysend(10, "pubkey", "")
+
+		assert.Equal(t, ErrKeysendUnsupported, err)
+	})
+}
+
+func TestClassifyFailure(t *testing.T) {
+	_, dialErr := http.Get("http://127.0.0.1:1")
+
+	assert.Equal(t, FailureKind(""), ClassifyFailure(nil))
+	assert.Equal(t, FailureTransient, ClassifyFailure(&Error{Message: "unable to find a path to destination"}))
+	assert.Equal(t, FailureTransient, ClassifyFailure(&Error{Message: "Peer Offline"}))
+	assert.Equal(t, FailurePermanent, ClassifyFailure(&Error{Message: "invoice is already paid"}))
+	assert.Equal(t, FailurePermanent, ClassifyFailure(ErrKeysendUnsupported))
+	assert.Equal(t, FailureTransient, ClassifyFailure(dialErr))
+	assert.Equal(t, FailureUnknown, ClassifyFailure(errors.New("Could not decode keysend response")))
+}
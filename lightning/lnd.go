@@ -0,0 +1,295 @@
+package lightning
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	decodepay "github.com/nbd-wtf/ln-decodepay"
+)
+
+type lndBackend struct {
+	httpClient HttpClient
+	url        string
+	macaroon   string
+}
+
+// NewLndBackend talks to lnd through its REST gateway, macaroon is the hex encoded admin macaroon
+func NewLndBackend(httpClient HttpClient, url string, macaroon string) Backend {
+	return &lndBackend{
+		httpClient: httpClient,
+		url:        strings.TrimSuffix(url, "/"),
+		macaroon:   macaroon,
+	}
+}
+
+// lnd encodes 64 bit integers as strings
+type lndAmount uint
+
+func (a *lndAmount) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseUint(strings.Trim(string(data), `"`), 10, 64)
+	if err == nil {
+		*a = lndAmount(value)
+	}
+	return nil
+}
+
+type lndPaymentResponse struct {
+	PaymentError    string `json:"payment_error"`
+	PaymentPreimage string `json:"payment_preimage"`
+	PaymentHash     string `json:"payment_hash"`
+}
+
+func (lb *lndBackend) request(method string, path string, body interface{}, out interface{}) error {
+	var data []byte
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+
+	status, resBody, err := doRequest(lb.httpClient, method, lb.url+path, map[string]string{"Grpc-Metadata-macaroon": lb.macaroon}, data)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		lndErr := struct {
+			Message string `json:"message"`
+		}{}
+		if json.Unmarshal(resBody, &lndErr) == nil && lndErr.Message != "" {
+			return &Error{Message: lndErr.Message}
+		}
+		return statusError(status, resBody)
+	}
+	return json.Unmarshal(resBody, out)
+}
+
+// base64ToHex turns the bytes fields lnd returns into the hex the rest of the app uses
+func base64ToHex(value string) string {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return value
+	}
+	return hex.EncodeToString(decoded)
+}
+
+func (lb *lndBackend) payment(res lndPaymentResponse, amount uint) (Payment, error) {
+	if res.PaymentError != "" {
+		return Payment{}, &Error{Message: res.PaymentError}
+	}
+	return Payment{
+		PaymentHash: base64ToHex(res.PaymentHash),
+		Preimage:    base64ToHex(res.PaymentPreimage),
+		Amount:      amount,
+	}, nil
+}
+
+func (lb *lndBackend) CreateInvoice(amount uint, memo string) (Invoice, error) {
+	invoiceRes := struct {
+		PaymentRequest string `json:"payment_request"`
+		RHash          string `json:"r_hash"`
+	}{}
+	body := map[string]interface{}{"value": strconv.FormatUint(uint64(amount), 10), "memo": memo}
+	if err := lb.request(http.MethodPost, "/v1/invoices", body, &invoiceRes); err != nil {
+		return Invoice{}, err
+	}
+
+	return Invoice{
+		PaymentRequest: invoiceRes.PaymentRequest,
+		PaymentHash:    base64ToHex(invoiceRes.RHash),
+		Amount:         amount,
+	}, nil
+}
+
+func (lb *lndBackend) CreateInvoiceWithDescriptionHash(amount uint, description string) (Invoice, error) {
+	invoiceRes := struct {
+		PaymentRequest string `json:"payment_request"`
+		RHash          string `json:"r_hash"`
+	}{}
+	descriptionHash := sha256.Sum256([]byte(description))
+	body := map[string]interface{}{
+		"value":            strconv.FormatUint(uint64(amount), 10),
+		"description_hash": base64.StdEncoding.EncodeToString(descriptionHash[:]),
+	}
+	if err := lb.request(http.MethodPost, "/v1/invoices", body, &invoiceRes); err != nil {
+		return Invoice{}, err
+	}
+
+	return Invoice{
+		PaymentRequest: invoiceRes.PaymentRequest,
+		PaymentHash:    base64ToHex(invoiceRes.RHash),
+		Amount:         amount,
+	}, nil
+}
+
+func (lb *lndBackend) CheckInvoice(paymentRequest string) (Invoice, error) {
+	decoded, err := decodepay.Decodepay(paymentRequest)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	invoiceRes := struct {
+		State     string    `json:"state"`
+		RPreimage string    `json:"r_preimage"`
+		AmtPaid   lndAmount `json:"amt_paid_sat"`
+		Value     lndAmount `json:"value"`
+	}{}
+	if err := lb.request(http.MethodGet, "/v1/invoice/"+decoded.PaymentHash, nil, &invoiceRes); err != nil {
+		return Invoice{}, err
+	}
+
+	invoice := Invoice{
+		PaymentRequest: paymentRequest,
+		PaymentHash:    decoded.PaymentHash,
+		Amount:         uint(invoiceRes.Value),
+		Settled:        invoiceRes.State == "SETTLED",
+	}
+	if invoice.Settled {
+		invoice.Preimage = base64ToHex(invoiceRes.RPreimage)
+		invoice.Amount = uint(invoiceRes.AmtPaid)
+	}
+	return invoice, nil
+}
+
+func (lb *lndBackend) PayInvoice(paymentRequest string) (Invoice, error) {
+	paymentRes := lndPaymentResponse{}
+	if err := lb.request(http.MethodPost, "/v1/channels/transactions", map[string]interface{}{"payment_request": paymentRequest}, &paymentRes); err != nil {
+		return Invoice{}, err
+	}
+
+	payment, err := lb.payment(paymentRes, 0)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	amount := uint(0)
+	if decoded, err := decodepay.Decodepay(paymentRequest); err == nil {
+		amount = uint(decoded.MSatoshi / 1000)
+	}
+
+	return Invoice{
+		PaymentRequest: paymentRequest,
+		PaymentHash:    payment.PaymentHash,
+		Preimage:       payment.Preimage,
+		Amount:         amount,
+		Settled:        true,
+	}, nil
+}
+
+// PayKeysend sends a spontaneous payment, lnd's v1 payment call has no route hints so the
+// destination has to be reachable from the node's graph
+func (lb *lndBackend) PayKeysend(amount uint, pubkey string, routeHint string) (Payment, error) {
+	dest, err := hex.DecodeString(pubkey)
+	if err != nil {
+		return Payment{}, fmt.Errorf("invalid destination pubkey: %w", err)
+	}
+
+	preimage := make([]byte, 32)
+	if _, err := rand.Read(preimage); err != nil {
+		return Payment{}, err
+	}
+	paymentHash := sha256.Sum256(preimage)
+
+	body := map[string]interface{}{
+		"dest":         base64.StdEncoding.EncodeToString(dest),
+		"amt":          strconv.FormatUint(uint64(amount), 10),
+		"payment_hash": base64.StdEncoding.EncodeToString(paymentHash[:]),
+		"dest_custom_records": map[string]string{
+			strconv.FormatUint(keysendRecordType, 10): base64.StdEncoding.EncodeToString(preimage),
+		},
+	}
+
+	paymentRes := lndPaymentResponse{}
+	if err := lb.request(http.MethodPost, "/v1/channels/transactions", body, &paymentRes); err != nil {
+		return Payment{}, err
+	}
+
+	return lb.payment(paymentRes, amount)
+}
+
+func (lb *lndBackend) GetBalance() (uint, error) {
+	balanceRes := struct {
+		LocalBalance struct {
+			Sat lndAmount `json:"sat"`
+		} `json:"local_balance"`
+	}{}
+	if err := lb.request(http.MethodGet, "/v1/balance/channels", nil, &balanceRes); err != nil {
+		return 0, err
+	}
+
+	return uint(balanceRes.LocalBalance.Sat), nil
+}
+
+func hexToBase64(value string) (string, error) {
+	decoded, err := hex.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(decoded), nil
+}
+
+func (lb *lndBackend) CreateHoldInvoice(amount uint, memo string, paymentHash string) (Invoice, error) {
+	hash, err := hexToBase64(paymentHash)
+	if err != nil {
+		return Invoice{}, fmt.Errorf("invalid payment hash: %w", err)
+	}
+
+	invoiceRes := struct {
+		PaymentRequest string `json:"payment_request"`
+	}{}
+	body := map[string]interface{}{
+		"value":       strconv.FormatUint(uint64(amount), 10),
+		"memo":        memo,
+		"hash":        hash,
+		"cltv_expiry": strconv.Itoa(holdInvoiceCltvExpiry),
+	}
+	if err := lb.request(http.MethodPost, "/v2/invoices/hodl", body, &invoiceRes); err != nil {
+		return Invoice{}, err
+	}
+
+	return Invoice{
+		PaymentRequest: invoiceRes.PaymentRequest,
+		PaymentHash:    paymentHash,
+		Amount:         amount,
+	}, nil
+}
+
+func (lb *lndBackend) LookupHoldInvoice(paymentHash string) (HoldInvoiceState, error) {
+	invoiceRes := struct {
+		State string `json:"state"`
+	}{}
+	if err := lb.request(http.MethodGet, "/v1/invoice/"+paymentHash, nil, &invoiceRes); err != nil {
+		return "", err
+	}
+
+	switch invoiceRes.State {
+	case "ACCEPTED":
+		return HoldInvoiceAccepted, nil
+	case "SETTLED":
+		return HoldInvoiceSettled, nil
+	case "CANCELED":
+		return HoldInvoiceCanceled, nil
+	default:
+		return HoldInvoiceOpen, nil
+	}
+}
+
+func (lb *lndBackend) SettleHoldInvoice(preimage string) error {
+	value, err := hexToBase64(preimage)
+	if err != nil {
+		return fmt.Errorf("invalid preimage: %w", err)
+	}
+	return lb.request(http.MethodPost, "/v2/invoices/settle", map[string]interface{}{"preimage": value}, &struct{}{})
+}
+
+func (lb *lndBackend) CancelHoldInvoice(paymentHash string) error {
+	hash, err := hexToBase64(paymentHash)
+	if err != nil {
+		return fmt.Errorf("invalid payment hash: %w", err)
+	}
+	return lb.request(http.MethodPost, "/v2/invoices/cancel", map[string]interface{}{"payment_hash": hash}, &struct{}{})
+}
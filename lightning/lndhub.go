@@ -0,0 +1,177 @@
+package lightning
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	decodepay "github.com/nbd-wtf/ln-decodepay"
+)
+
+type lndhubBackend struct {
+	httpClient  HttpClient
+	url         string
+	login       string
+	password    string
+	accessToken string
+	m           sync.Mutex
+}
+
+// NewLndhubBackend uses an LNDHub account, LNDHub has no keysend so only invoice flows work
+func NewLndhubBackend(httpClient HttpClient, url string, login string, password string) Backend {
+	return &lndhubBackend{
+		httpClient: httpClient,
+		url:        strings.TrimSuffix(url, "/"),
+		login:      login,
+		password:   password,
+	}
+}
+
+type lndhubError struct {
+	Error   bool   `json:"error"`
+	Message string `json:"message"`
+}
+
+func (hb *lndhubBackend) authenticate() (string, error) {
+	hb.m.Lock()
+	defer hb.m.Unlock()
+
+	if hb.accessToken != "" {
+		return hb.accessToken, nil
+	}
+
+	data, _ := json.Marshal(map[string]string{"login": hb.login, "password": hb.password})
+	status, resBody, err := doRequest(hb.httpClient, http.MethodPost, hb.url+"/auth?type=auth", nil, data)
+	if err != nil {
+		return "", err
+	}
+
+	authRes := struct {
+		lndhubError
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.Unmarshal(resBody, &authRes); err != nil {
+		return "", statusError(status, resBody)
+	}
+	if authRes.Error || authRes.AccessToken == "" {
+		return "", &Error{Message: "lndhub authentication failed: " + authRes.Message}
+	}
+
+	hb.accessToken = authRes.AccessToken
+	return hb.accessToken, nil
+}
+
+func (hb *lndhubBackend) request(method string, path string, body interface{}, out interface{}) error {
+	token, err := hb.authenticate()
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+
+	status, resBody, err := doRequest(hb.httpClient, method, hb.url+path, map[string]string{"Authorization": "Bearer " + token}, data)
+	if err != nil {
+		return err
+	}
+
+	// LNDHub answers errors with a 200 and an error body
+	hubErr := lndhubError{}
+	if json.Unmarshal(resBody, &hubErr) == nil && hubErr.Error {
+		if strings.Contains(strings.ToLower(hubErr.Message), "bad auth") {
+			hb.m.Lock()
+			hb.accessToken = ""
+			hb.m.Unlock()
+		}
+		return &Error{Message: hubErr.Message}
+	}
+	if status != http.StatusOK {
+		return statusError(status, resBody)
+	}
+	return json.Unmarshal(resBody, out)
+}
+
+func (hb *lndhubBackend) CreateInvoice(amount uint, memo string) (Invoice, error) {
+	invoiceRes := struct {
+		PaymentRequest string `json:"payment_request"`
+	}{}
+	body := map[string]string{"amt": strconv.FormatUint(uint64(amount), 10), "memo": memo}
+	if err := hb.request(http.MethodPost, "/addinvoice", body, &invoiceRes); err != nil {
+		return Invoice{}, err
+	}
+
+	invoice := Invoice{
+		PaymentRequest: invoiceRes.PaymentRequest,
+		Amount:         amount,
+	}
+	if decoded, err := decodepay.Decodepay(invoiceRes.PaymentRequest); err == nil {
+		invoice.PaymentHash = decoded.PaymentHash
+	}
+	return invoice, nil
+}
+
+func (hb *lndhubBackend) CheckInvoice(paymentRequest string) (Invoice, error) {
+	decoded, err := decodepay.Decodepay(paymentRequest)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	checkRes := struct {
+		Paid bool `json:"paid"`
+	}{}
+	if err := hb.request(http.MethodGet, "/checkpayment/"+decoded.PaymentHash, nil, &checkRes); err != nil {
+		return Invoice{}, err
+	}
+
+	return Invoice{
+		PaymentRequest: paymentRequest,
+		PaymentHash:    decoded.PaymentHash,
+		Amount:         uint(decoded.MSatoshi / 1000),
+		Settled:        checkRes.Paid,
+	}, nil
+}
+
+func (hb *lndhubBackend) PayInvoice(paymentRequest string) (Invoice, error) {
+	paymentRes := struct {
+		PaymentError    string `json:"payment_error"`
+		PaymentPreimage string `json:"payment_preimage"`
+	}{}
+	if err := hb.request(http.MethodPost, "/payinvoice", map[string]string{"invoice": paymentRequest}, &paymentRes); err != nil {
+		return Invoice{}, err
+	}
+	if paymentRes.PaymentError != "" {
+		return Invoice{}, &Error{Message: paymentRes.PaymentError}
+	}
+
+	invoice := Invoice{
+		PaymentRequest: paymentRequest,
+		Preimage:       paymentRes.PaymentPreimage,
+		Settled:        true,
+	}
+	if decoded, err := decodepay.Decodepay(paymentRequest); err == nil {
+		invoice.PaymentHash = decoded.PaymentHash
+		invoice.Amount = uint(decoded.MSatoshi / 1000)
+	}
+	return invoice, nil
+}
+
+func (hb *lndhubBackend) PayKeysend(amount uint, pubkey string, routeHint string) (Payment, error) {
+	return Payment{}, ErrKeysendUnsupported
+}
+
+func (hb *lndhubBackend) GetBalance() (uint, error) {
+	balanceRes := struct {
+		BTC struct {
+			AvailableBalance uint `json:"AvailableBalance"`
+		} `json:"BTC"`
+	}{}
+	if err := hb.request(http.MethodGet, "/balance", nil, &balanceRes); err != nil {
+		return 0, err
+	}
+
+	return balanceRes.BTC.AvailableBalance, nil
+}
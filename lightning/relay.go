@@ -0,0 +1,190 @@
+package lightning
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+type relayBackend struct {
+	httpClient HttpClient
+}
+
+// NewRelayBackend talks to the sphinx relay at RELAY_URL, the url is read on every call
+func NewRelayBackend(httpClient HttpClient) Backend {
+	return &relayBackend{
+		httpClient: httpClient,
+	}
+}
+
+// relayAmount accepts the amount as a number or a string, relay versions differ
+type relayAmount uint
+
+func (a *relayAmount) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseUint(strings.Trim(string(data), `"`), 10, 64)
+	if err == nil {
+		*a = relayAmount(value)
+	}
+	return nil
+}
+
+type relayInvoice struct {
+	Settled        bool        `json:"settled"`
+	PaymentRequest string      `json:"payment_request"`
+	PaymentHash    string      `json:"payment_hash"`
+	Preimage       string      `json:"preimage"`
+	Amount         relayAmount `json:"amount"`
+}
+
+func (i relayInvoice) invoice() Invoice {
+	return Invoice{
+		PaymentRequest: i.PaymentRequest,
+		PaymentHash:    i.PaymentHash,
+		Preimage:       i.Preimage,
+		Amount:         uint(i.Amount),
+		Settled:        i.Settled,
+	}
+}
+
+type relayError struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
+func (rb *relayBackend) headers() map[string]string {
+	return map[string]string{"x-user-token": config.RelayAuthKey}
+}
+
+// rejection reads the relay error body, a body that is not a relay error is not a rejection
+func (rb *relayBackend) rejection(body []byte) error {
+	relayErr := relayError{}
+	if err := json.Unmarshal(body, &relayErr); err != nil {
+		return fmt.Errorf("could not read relay error: %w", err)
+	}
+	return &Error{Message: relayErr.Error}
+}
+
+func (rb *relayBackend) CreateInvoice(amount uint, memo string) (Invoice, error) {
+	url := fmt.Sprintf("%s/invoices", config.RelayUrl)
+	bodyData, _ := json.Marshal(map[string]interface{}{"amount": amount, "memo": memo})
+
+	status, body, err := doRequest(rb.httpClient, http.MethodPost, url, rb.headers(), bodyData)
+	if err != nil {
+		return Invoice{}, err
+	}
+	if status != http.StatusOK {
+		return Invoice{}, rb.rejection(body)
+	}
+
+	invoiceRes := struct {
+		Response struct {
+			Invoice string `json:"invoice"`
+		} `json:"response"`
+	}{}
+	if err := json.Unmarshal(body, &invoiceRes); err != nil {
+		return Invoice{}, err
+	}
+
+	return Invoice{
+		PaymentRequest: invoiceRes.Response.Invoice,
+		Amount:         amount,
+	}, nil
+}
+
+func (rb *relayBackend) CheckInvoice(paymentRequest string) (Invoice, error) {
+	url := fmt.Sprintf("%s/invoice?payment_request=%s", config.RelayUrl, paymentRequest)
+
+	status, body, err := doRequest(rb.httpClient, http.MethodGet, url, rb.headers(), nil)
+	if err != nil {
+		return Invoice{}, err
+	}
+	if status != http.StatusOK {
+		return Invoice{}, rb.rejection(body)
+	}
+
+	invoiceRes := struct {
+		Response relayInvoice `json:"response"`
+	}{}
+	// whatever could be read is used, older relays send fields the struct does not expect
+	if err := json.Unmarshal(body, &invoiceRes); err != nil {
+		log.Printf("[lightning] Reading Invoice body failed: %s", err)
+	}
+
+	return invoiceRes.Response.invoice(), nil
+}
+
+func (rb *relayBackend) PayInvoice(paymentRequest string) (Invoice, error) {
+	url := fmt.Sprintf("%s/invoices", config.RelayUrl)
+	bodyData := fmt.Sprintf(`{"payment_request": "%s"}`, paymentRequest)
+
+	status, body, err := doRequest(rb.httpClient, http.MethodPut, url, rb.headers(), []byte(bodyData))
+	if err != nil {
+		return Invoice{}, err
+	}
+	if status != http.StatusOK {
+		return Invoice{}, rb.rejection(body)
+	}
+
+	invoiceRes := struct {
+		Response relayInvoice `json:"response"`
+	}{}
+	if err := json.Unmarshal(body, &invoiceRes); err != nil {
+		return Invoice{}, err
+	}
+
+	return invoiceRes.Response.invoice(), nil
+}
+
+func (rb *relayBackend) PayKeysend(amount uint, pubkey string, routeHint string) (Payment, error) {
+	url := fmt.Sprintf("%s/payment", config.RelayUrl)
+	bodyData := utils.BuildKeysendBodyData(amount, pubkey, routeHint)
+
+	status, body, err := doRequest(rb.httpClient, http.MethodPost, url, rb.headers(), []byte(bodyData))
+	if err != nil {
+		return Payment{}, err
+	}
+	if status != http.StatusOK {
+		relayErr := relayError{}
+		json.Unmarshal(body, &relayErr)
+		return Payment{}, &Error{Message: relayErr.Error}
+	}
+
+	keysendRes := struct {
+		Response map[string]interface{} `json:"response"`
+	}{}
+	if err := json.Unmarshal(body, &keysendRes); err != nil {
+		return Payment{}, errors.New("Could not decode keysend response")
+	}
+
+	return Payment{Amount: amount}, nil
+}
+
+func (rb *relayBackend) GetBalance() (uint, error) {
+	url := fmt.Sprintf("%s/balance", config.RelayUrl)
+
+	status, body, err := doRequest(rb.httpClient, http.MethodGet, url, rb.headers(), nil)
+	if err != nil {
+		return 0, err
+	}
+	if status != http.StatusOK {
+		return 0, rb.rejection(body)
+	}
+
+	balanceRes := struct {
+		Response struct {
+			Balance relayAmount `json:"balance"`
+		} `json:"response"`
+	}{}
+	if err := json.Unmarshal(body, &balanceRes); err != nil {
+		return 0, err
+	}
+
+	return uint(balanceRes.Response.Balance), nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -13,7 +14,11 @@ import (
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/notifications"
+	"github.com/stakwork/sphinx-tribes/rates"
 	"github.com/stakwork/sphinx-tribes/routes"
+	"github.com/stakwork/sphinx-tribes/rpc"
+	"github.com/stakwork/sphinx-tribes/search"
 	"github.com/stakwork/sphinx-tribes/websocket"
 	"gopkg.in/go-playground/validator.v9"
 )
@@ -33,16 +38,48 @@ func main() {
 	// Config has to be inited before JWT, if not it will lead to NO JWT error
 	config.InitConfig()
 	auth.InitJwt()
+	if err := db.DB.SetSearchIndexTriggers(search.IndexingEnabled()); err != nil {
+		fmt.Println("search indexing disabled:", err)
+	}
+	auth.ApiKeyVerifier = handlers.NewApiKeyHandler(db.DB).VerifyApiKey
+	if err := rates.Init(http.DefaultClient); err != nil {
+		fmt.Println("fiat rates disabled:", err)
+	}
+	db.FiatQuote = rates.Quote
+	auth.SessionValidator = handlers.NewAuthHandler(db.DB).ValidateSession
+	auth.NostrPubkeyResolver = handlers.NewNostrHandler(db.DB).ResolveNostrPubkey
+	superAdminHandler := handlers.NewSuperAdminHandler(db.DB)
+	superAdminHandler.BootstrapSuperAdmins()
+	auth.SuperAdminsLoader = superAdminHandler.LoadSuperAdmins
+	auth.BannedPubkeysLoader = handlers.NewModerationHandler(db.DB).LoadBannedPubkeys
+	handlers.ArchivedWorkspacesLoader = handlers.NewWorkspaceHandler(db.DB).LoadArchivedWorkspaces
+	auth.ImpersonationRecorder = handlers.NewImpersonationHandler(db.DB).RecordImpersonatedRequest
 
 	// validate
 	db.Validate = validator.New()
+	notifications.InitDispatcher(db.DB)
+	handlers.InitActivities(db.DB)
+	handlers.InitWorkflows(db.DB)
+	handlers.InitBotWebhooks(db.DB)
+	handlers.InitWorkspaceIntegrations(db.DB)
+
 	// Start websocket pool
+	websocket.WebsocketPool.Authorize = handlers.NewSocketTopicAuthorizer(db.DB)
 	go websocket.WebsocketPool.Start()
 
 	skipLoops := os.Getenv("SKIP_LOOPS")
 	if skipLoops != "true" {
 		go handlers.ProcessTwitterConfirmationsLoop()
 		go handlers.ProcessGithubIssuesLoop()
+		handlers.InitScheduler()
+	}
+
+	if config.GrpcPort != "" {
+		go func() {
+			if err := rpc.Serve(db.DB, config.GrpcPort, config.GrpcToken); err != nil {
+				fmt.Println("gRPC server stopped:", err)
+			}
+		}()
 	}
 
 	run()
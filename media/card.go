@@ -0,0 +1,125 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+)
+
+const (
+	// CardWidth and CardHeight are the size Twitter and Telegram show link previews at
+	CardWidth  = 1200
+	CardHeight = 630
+
+	cardMargin     = 72
+	cardAvatarSize = 180
+)
+
+var (
+	cardBackground = color.RGBA{0x1a, 0x1d, 0x24, 0xff}
+	cardAccent     = color.RGBA{0x61, 0x8a, 0xff, 0xff}
+	cardText       = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	cardMuted      = color.RGBA{0xb0, 0xb5, 0xc1, 0xff}
+)
+
+// Card is what a share card shows, the empty fields are left out
+type Card struct {
+	// Label is the small line above the title such as "TRIBE"
+	Label       string
+	Title       string
+	Description string
+	// Highlight is shown in the accent color at the bottom, the price of a bounty
+	Highlight string
+	Footer    string
+	Avatar    image.Image
+}
+
+func fill(dst *image.RGBA, rect image.Rectangle, src image.Image) {
+	draw.Draw(dst, rect, src, image.Point{}, draw.Src)
+}
+
+// circle masks an image to the circle inscribed in its bounds
+type circle struct {
+	center image.Point
+	radius int
+}
+
+func (c circle) ColorModel() color.Model { return color.AlphaModel }
+
+func (c circle) Bounds() image.Rectangle {
+	return image.Rect(c.center.X-c.radius, c.center.Y-c.radius, c.center.X+c.radius, c.center.Y+c.radius)
+}
+
+func (c circle) At(x int, y int) color.Color {
+	dx, dy := x-c.center.X, y-c.center.Y
+	if dx*dx+dy*dy < c.radius*c.radius {
+		return color.Alpha{A: 0xff}
+	}
+	return color.Alpha{}
+}
+
+// cropSquare returns the centered square of an image
+func cropSquare(img image.Image) image.Image {
+	bounds := img.Bounds()
+	size := bounds.Dx()
+	if bounds.Dy() < size {
+		size = bounds.Dy()
+	}
+	min := image.Pt(bounds.Min.X+(bounds.Dx()-size)/2, bounds.Min.Y+(bounds.Dy()-size)/2)
+	square := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(square, square.Bounds(), img, min, draw.Src)
+	return square
+}
+
+// RenderCard draws a share card as a png
+func RenderCard(card Card) ([]byte, error) {
+	dst := image.NewRGBA(image.Rect(0, 0, CardWidth, CardHeight))
+	fill(dst, dst.Bounds(), image.NewUniform(cardBackground))
+	fill(dst, image.Rect(0, 0, CardWidth, 12), image.NewUniform(cardAccent))
+
+	left := cardMargin
+	if card.Avatar != nil {
+		avatar := Fit(cropSquare(card.Avatar), cardAvatarSize)
+		size := avatar.Bounds().Dx()
+		origin := image.Pt(cardMargin, cardMargin+8)
+		mask := circle{center: image.Pt(size/2, size/2), radius: size / 2}
+		draw.DrawMask(dst, image.Rectangle{Min: origin, Max: origin.Add(image.Pt(size, size))},
+			avatar, avatar.Bounds().Min, mask, image.Point{}, draw.Over)
+		left += cardAvatarSize + 48
+	}
+	width := CardWidth - cardMargin - left
+
+	y := cardMargin + 8
+	if card.Label != "" {
+		DrawText(dst, left, y, 4, cardAccent, card.Label)
+		y += glyphHeight*4 + 28
+	}
+	for _, line := range WrapText(card.Title, width, 8, 2) {
+		DrawText(dst, left, y, 8, cardText, line)
+		y += glyphHeight*8 + 20
+	}
+	y += 16
+
+	// the description takes the lines left above the highlight
+	bottom := CardHeight - cardMargin - glyphHeight*6
+	lineHeight := glyphHeight*4 + 16
+	for _, line := range WrapText(card.Description, width, 4, (bottom-32-y)/lineHeight) {
+		DrawText(dst, left, y, 4, cardMuted, line)
+		y += lineHeight
+	}
+
+	if card.Highlight != "" {
+		DrawText(dst, cardMargin, bottom, 6, cardAccent, card.Highlight)
+	}
+	if card.Footer != "" {
+		DrawText(dst, CardWidth-cardMargin-TextWidth(card.Footer, 4), bottom+glyphHeight*2, 4, cardMuted, card.Footer)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, dst); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,199 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+const (
+	// MaxDimension and MaxPixels keep the decoded images to a sane size, a small file can
+	// declare dimensions that take gigabytes to decode
+	MaxDimension = 8192
+	MaxPixels    = 40000000
+
+	// DisplaySize is the longest side of the processed image, ThumbnailSize of its thumbnail
+	DisplaySize   = 2048
+	ThumbnailSize = 320
+
+	jpegQuality = 85
+)
+
+const (
+	FormatJpeg = "jpeg"
+	FormatPng  = "png"
+	FormatGif  = "gif"
+	FormatWebp = "webp"
+)
+
+var ErrFormat = errors.New("unsupported image format")
+
+var ErrDimensions = errors.New("image dimensions are too large")
+
+type Info struct {
+	Format string
+	Width  int
+	Height int
+}
+
+// ContentType returns the content type of an image format
+func (i Info) ContentType() string {
+	return "image/" + i.Format
+}
+
+// Validate reads the header of an image, it fails when the format is not one of jpeg, png, gif
+// or webp, or the image is too large to be decoded safely
+func Validate(r io.Reader) (Info, error) {
+	header := make([]byte, 30)
+	n, _ := io.ReadFull(r, header)
+	header = header[:n]
+
+	var info Info
+	if isWebp(header) {
+		config, err := webpConfig(header)
+		if err != nil {
+			return Info{}, err
+		}
+		info = Info{Format: FormatWebp, Width: config.Width, Height: config.Height}
+	} else {
+		config, format, err := image.DecodeConfig(io.MultiReader(bytes.NewReader(header), r))
+		if err != nil {
+			return Info{}, ErrFormat
+		}
+		info = Info{Format: format, Width: config.Width, Height: config.Height}
+	}
+
+	if info.Format != FormatJpeg && info.Format != FormatPng && info.Format != FormatGif && info.Format != FormatWebp {
+		return Info{}, ErrFormat
+	}
+	if info.Width < 1 || info.Height < 1 || info.Width > MaxDimension || info.Height > MaxDimension ||
+		info.Width*info.Height > MaxPixels {
+		return Info{}, ErrDimensions
+	}
+	return info, nil
+}
+
+func isWebp(header []byte) bool {
+	return len(header) >= 12 && string(header[0:4]) == "RIFF" && string(header[8:12]) == "WEBP"
+}
+
+// webpConfig reads the dimensions of a webp from its first chunk
+func webpConfig(header []byte) (image.Config, error) {
+	if len(header) < 30 {
+		return image.Config{}, ErrFormat
+	}
+
+	chunk := header[12:16]
+	data := header[20:]
+	switch string(chunk) {
+	case "VP8 ":
+		if data[3] != 0x9d || data[4] != 0x01 || data[5] != 0x2a {
+			return image.Config{}, ErrFormat
+		}
+		return image.Config{
+			Width:  int(binary.LittleEndian.Uint16(data[6:8]) & 0x3fff),
+			Height: int(binary.LittleEndian.Uint16(data[8:10]) & 0x3fff),
+		}, nil
+	case "VP8L":
+		if data[0] != 0x2f {
+			return image.Config{}, ErrFormat
+		}
+		bits := binary.LittleEndian.Uint32(data[1:5])
+		return image.Config{Width: int(bits&0x3fff) + 1, Height: int(bits>>14&0x3fff) + 1}, nil
+	case "VP8X":
+		return image.Config{
+			Width:  int(uint32(data[4])|uint32(data[5])<<8|uint32(data[6])<<16) + 1,
+			Height: int(uint32(data[7])|uint32(data[8])<<8|uint32(data[9])<<16) + 1,
+		}, nil
+	}
+	return image.Config{}, ErrFormat
+}
+
+// Result is an uploaded image once processed
+type Result struct {
+	Info Info
+	// Image is the image re-encoded without its metadata and scaled down to DisplaySize, nil when
+	// the upload is kept as it is
+	Image []byte
+	// WebP is the image as a lossless webp, Thumbnail a webp of it at ThumbnailSize
+	WebP      []byte
+	Thumbnail []byte
+}
+
+// Process strips the metadata of an image, turning it the way its EXIF orientation says, scales
+// it down and converts it to webp along with a thumbnail. Animated gifs are kept as they are and
+// their copies show the first frame, webps are not decoded
+func Process(data []byte) (Result, error) {
+	info, err := Validate(bytes.NewReader(data))
+	if err != nil {
+		return Result{}, err
+	}
+	if info.Format == FormatWebp {
+		return Result{}, ErrFormat
+	}
+
+	result := Result{Info: info}
+
+	var img image.Image
+	switch info.Format {
+	case FormatGif:
+		var animation *gif.GIF
+		animation, err = gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return Result{}, err
+		}
+		img = animation.Image[0]
+		if len(animation.Image) == 1 {
+			img = Fit(img, DisplaySize)
+			result.Image, err = encode(info.Format, img)
+		}
+	default:
+		img, _, err = image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return Result{}, err
+		}
+		if info.Format == FormatJpeg {
+			img = Orient(img, JpegOrientation(data))
+		}
+		img = Fit(img, DisplaySize)
+		result.Image, err = encode(info.Format, img)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := EncodeWebP(buf, img); err != nil {
+		return Result{}, err
+	}
+	result.WebP = buf.Bytes()
+
+	buf = &bytes.Buffer{}
+	if err := EncodeWebP(buf, Fit(img, ThumbnailSize)); err != nil {
+		return Result{}, err
+	}
+	result.Thumbnail = buf.Bytes()
+
+	return result, nil
+}
+
+func encode(format string, img image.Image) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	var err error
+	switch format {
+	case FormatJpeg:
+		err = jpeg.Encode(buf, img, &jpeg.Options{Quality: jpegQuality})
+	case FormatPng:
+		err = png.Encode(buf, img)
+	case FormatGif:
+		err = gif.Encode(buf, img, nil)
+	default:
+		err = ErrFormat
+	}
+	return buf.Bytes(), err
+}
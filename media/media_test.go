@@ -0,0 +1,361 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testImage(width int, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{uint8(x), uint8(y), 100, 255})
+		}
+	}
+	return img
+}
+
+// withOrientation inserts an EXIF segment with an orientation tag after the start of a jpeg
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("II*\x00\x08\x00\x00\x00\x01\x00")
+	entry := make([]byte, 12)
+	binary.LittleEndian.PutUint16(entry[0:], 0x0112)
+	binary.LittleEndian.PutUint16(entry[2:], 3)
+	binary.LittleEndian.PutUint32(entry[4:], 1)
+	binary.LittleEndian.PutUint16(entry[8:], orientation)
+	tiff = append(append(tiff, entry...), 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xff, 0xe1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+
+	out := append([]byte{}, data[:2]...)
+	out = append(append(out, app1...), segment...)
+	return append(out, data[2:]...)
+}
+
+func TestValidate(t *testing.T) {
+	buf := &bytes.Buffer{}
+	png.Encode(buf, testImage(40, 30))
+	info, err := Validate(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, Info{Format: FormatPng, Width: 40, Height: 30}, info)
+	assert.Equal(t, "image/png", info.ContentType())
+
+	_, err = Validate(strings.NewReader("<html><script></script></html>"))
+	assert.Equal(t, ErrFormat, err)
+
+	// a png header declaring a huge image is refused before anything is decoded
+	huge := append([]byte{}, buf.Bytes()[:33]...)
+	binary.BigEndian.PutUint32(huge[16:], 20000)
+	binary.BigEndian.PutUint32(huge[20:], 20000)
+	binary.BigEndian.PutUint32(huge[29:], crc32.ChecksumIEEE(huge[12:29]))
+	_, err = Validate(bytes.NewReader(huge))
+	assert.Equal(t, ErrDimensions, err)
+
+	buf = &bytes.Buffer{}
+	assert.NoError(t, EncodeWebP(buf, testImage(300, 17)))
+	info, err = Validate(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, Info{Format: FormatWebp, Width: 300, Height: 17}, info)
+}
+
+func TestEncodeWebP(t *testing.T) {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, EncodeWebP(buf, testImage(33, 47)))
+
+	data := buf.Bytes()
+	assert.Equal(t, "RIFF", string(data[0:4]))
+	assert.Equal(t, uint32(len(data)-8), binary.LittleEndian.Uint32(data[4:8]))
+	assert.Equal(t, "WEBPVP8L", string(data[8:16]))
+	assert.Equal(t, 0, len(data)%2)
+
+	assert.Equal(t, ErrDimensions, EncodeWebP(buf, image.NewNRGBA(image.Rect(0, 0, 0, 10))))
+}
+
+func TestPrefixEncode(t *testing.T) {
+	decode := func(code int, extra uint32) int {
+		if code < 4 {
+			return code + 1
+		}
+		extraBits := (code - 2) >> 1
+		offset := (2 + code&1) << uint(extraBits)
+		return offset + int(extra) + 1
+	}
+	for _, value := range []int{1, 2, 4, 5, 6, 7, 8, 9, 100, 121, 4096, 1<<18 + 120} {
+		code, extraBits, extra := prefixEncode(value)
+		assert.Equal(t, value, decode(code, extra), value)
+		assert.Equal(t, uint(0), uint(extra)>>extraBits, value)
+	}
+}
+
+func TestCodeLengths(t *testing.T) {
+	// a skewed histogram would make a tree deeper than the limit
+	histogram := make([]int, 40)
+	for i := range histogram {
+		histogram[i] = 1 << uint(i%30)
+	}
+	lengths := codeLengths(histogram, 15)
+
+	kraft := 0.0
+	for _, length := range lengths {
+		assert.True(t, length >= 1 && length <= 15)
+		kraft += 1 / float64(uint(1)<<length)
+	}
+	assert.Equal(t, 1.0, kraft)
+
+	lengths = codeLengths([]int{0, 0, 5, 0}, 7)
+	assert.Equal(t, []uint8{1, 0, 1, 0}, lengths)
+}
+
+func TestFit(t *testing.T) {
+	img := testImage(400, 100)
+	assert.Equal(t, img, Fit(img, 400))
+
+	fitted := Fit(img, 100)
+	assert.Equal(t, image.Rect(0, 0, 100, 25), fitted.Bounds())
+
+	// every target pixel averages a 4x4 block
+	r, g, _, _ := fitted.At(10, 5).RGBA()
+	assert.Equal(t, uint32(42), r>>8)
+	assert.Equal(t, uint32(22), g>>8)
+
+	assert.Equal(t, image.Rect(0, 0, 3, 320), Fit(testImage(10, 1000), 320).Bounds())
+}
+
+func TestOrientation(t *testing.T) {
+	buf := &bytes.Buffer{}
+	jpeg.Encode(buf, testImage(60, 20), nil)
+	assert.Equal(t, 1, JpegOrientation(buf.Bytes()))
+
+	rotated := withOrientation(buf.Bytes(), 6)
+	assert.Equal(t, 6, JpegOrientation(rotated))
+	assert.Equal(t, 1, JpegOrientation(withOrientation(buf.Bytes(), 12)))
+
+	src := testImage(3, 2)
+	turned := Orient(src, 6)
+	assert.Equal(t, image.Rect(0, 0, 2, 3), turned.Bounds())
+	// turned clockwise, the bottom left pixel goes to the top left
+	assert.Equal(t, color.RGBAModel.Convert(src.At(0, 1)), turned.At(0, 0))
+	assert.Equal(t, color.RGBAModel.Convert(src.At(2, 0)), turned.At(1, 2))
+
+	assert.Equal(t, src, Orient(src, 1))
+}
+
+func TestProcess(t *testing.T) {
+	t.Run("Should test that a jpeg is turned upright, scaled down and stripped of its EXIF", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		jpeg.Encode(buf, testImage(3000, 1000), nil)
+
+		result, err := Process(withOrientation(buf.Bytes(), 6))
+		assert.NoError(t, err)
+		assert.Equal(t, FormatJpeg, result.Info.Format)
+		assert.False(t, bytes.Contains(result.Image, []byte("Exif")))
+
+		info, err := Validate(bytes.NewReader(result.Image))
+		assert.NoError(t, err)
+		assert.Equal(t, Info{Format: FormatJpeg, Width: 682, Height: 2048}, info)
+
+		info, _ = Validate(bytes.NewReader(result.WebP))
+		assert.Equal(t, Info{Format: FormatWebp, Width: 682, Height: 2048}, info)
+
+		info, _ = Validate(bytes.NewReader(result.Thumbnail))
+		assert.Equal(t, Info{Format: FormatWebp, Width: 106, Height: 320}, info)
+	})
+
+	t.Run("Should test that a small png keeps its size", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		png.Encode(buf, testImage(50, 40))
+
+		result, err := Process(buf.Bytes())
+		assert.NoError(t, err)
+		info, _ := Validate(bytes.NewReader(result.Image))
+		assert.Equal(t, Info{Format: FormatPng, Width: 50, Height: 40}, info)
+		info, _ = Validate(bytes.NewReader(result.Thumbnail))
+		assert.Equal(t, Info{Format: FormatWebp, Width: 50, Height: 40}, info)
+	})
+
+	t.Run("Should test that webps are not processed", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		EncodeWebP(buf, testImage(5, 5))
+		_, err := Process(buf.Bytes())
+		assert.Equal(t, ErrFormat, err)
+	})
+}
+
+func TestWrapText(t *testing.T) {
+	// 10 characters fit in 59 pixels at scale 1
+	assert.Equal(t, []string{"the quick", "brown fox"}, WrapText("the quick brown fox", 59, 1, 3))
+	assert.Equal(t, []string{"the quick", "brown..."}, WrapText("the quick brown fox jumps", 59, 1, 2))
+	assert.Equal(t, []string{"abcdefghij", "klm"}, WrapText("abcdefghijklm", 59, 1, 3))
+	assert.Equal(t, []string{}, WrapText("text", 59, 1, 0))
+	assert.Equal(t, 59, TextWidth("abcdefghij", 1))
+}
+
+func TestRenderCard(t *testing.T) {
+	data, err := RenderCard(Card{
+		Label:       "TRIBE",
+		Title:       "Sphinx Tribes",
+		Description: "A tribe with a long description that wraps over several lines of the card",
+		Highlight:   "1,000 sats to join",
+		Footer:      "tribes.sphinx.chat",
+		Avatar:      testImage(300, 200),
+	})
+	assert.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, CardWidth, CardHeight), img.Bounds())
+
+	// the corner is the background and the top of the card is the accent bar
+	assert.Equal(t, color.RGBAModel.Convert(cardBackground), color.RGBAModel.Convert(img.At(CardWidth-1, CardHeight-1)))
+	assert.Equal(t, color.RGBAModel.Convert(cardAccent), color.RGBAModel.Convert(img.At(CardWidth/2, 4)))
+}
+
+// readQRCode reads the text back from a code made by NewQRCode, checking the error correction
+// of every block
+func readQRCode(t *testing.T, q *QRCode) string {
+	level, mask := QRLevel(-1), -1
+	for l := QRLevelL; l <= QRLevelH; l++ {
+		for m := 0; m < 8; m++ {
+			bits := qrFormat(l, m)
+			match := true
+			for i := 0; i < 8; i++ {
+				if q.Dark(q.Size-1-i, 8) != ((bits>>uint(i))&1 == 1) {
+					match = false
+				}
+			}
+			if match {
+				level, mask = l, m
+			}
+		}
+	}
+	assert.NotEqual(t, -1, mask, "no format information")
+
+	q.applyMask(mask)
+	defer q.applyMask(mask)
+	raw := make([]byte, qrRawDataModules(q.Version)/8)
+	i := 0
+	for right := q.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vertical := 0; vertical < q.Size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vertical
+				if (right+1)&2 == 0 {
+					y = q.Size - 1 - vertical
+				}
+				if q.function[y][x] || i >= len(raw)*8 {
+					continue
+				}
+				if q.modules[y][x] {
+					raw[i/8] |= 1 << uint(7-i%8)
+				}
+				i++
+			}
+		}
+	}
+
+	blocks := qrBlocks[level][q.Version]
+	eccLength := qrEccPerBlock[level][q.Version]
+	shortBlocks := blocks - len(raw)%blocks
+	shortLength := len(raw)/blocks - eccLength
+	dataBlocks := make([][]byte, blocks)
+	k := 0
+	for i := 0; i <= shortLength; i++ {
+		for b := range dataBlocks {
+			if i < shortLength || b >= shortBlocks {
+				dataBlocks[b] = append(dataBlocks[b], raw[k])
+				k++
+			}
+		}
+	}
+	data := []byte{}
+	for b, block := range dataBlocks {
+		ecc := []byte{}
+		for i := 0; i < eccLength; i++ {
+			ecc = append(ecc, raw[k+i*blocks+b])
+		}
+		assert.Equal(t, qrReedSolomon(block, eccLength), ecc)
+		data = append(data, block...)
+	}
+
+	bit := 0
+	read := func(n int) int {
+		v := 0
+		for ; n > 0; n-- {
+			v = v<<1 | int((data[bit/8]>>uint(7-bit%8))&1)
+			bit++
+		}
+		return v
+	}
+	countBits := (q.Version + 7) / 17
+	text := &strings.Builder{}
+	switch read(4) {
+	case 0x2:
+		count := read([]int{9, 11, 13}[countBits])
+		for ; count >= 2; count -= 2 {
+			v := read(11)
+			text.WriteByte(qrAlphanumeric[v/45])
+			text.WriteByte(qrAlphanumeric[v%45])
+		}
+		if count == 1 {
+			text.WriteByte(qrAlphanumeric[read(6)])
+		}
+	case 0x4:
+		for count := read([]int{8, 16, 16}[countBits]); count > 0; count-- {
+			text.WriteByte(byte(read(8)))
+		}
+	}
+	return text.String()
+}
+
+func TestQRCode(t *testing.T) {
+	// the error correction of "HELLO WORLD" at 1-M
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	assert.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, qrReedSolomon(data, 10))
+	assert.Equal(t, 0x77c4, qrFormat(QRLevelL, 0))
+	assert.Equal(t, []int{6, 22, 38}, qrAlignmentPositions(7))
+	assert.Equal(t, []int{6, 34, 60, 86, 112, 138}, qrAlignmentPositions(32))
+
+	q, err := NewQRCode("HELLO WORLD", QRLevelM)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, q.Version)
+	assert.Equal(t, 21, q.Size)
+	assert.Equal(t, "HELLO WORLD", readQRCode(t, q))
+	// the finder corner and the dark module
+	assert.True(t, q.Dark(0, 0))
+	assert.False(t, q.Dark(7, 7))
+	assert.True(t, q.Dark(8, q.Size-8))
+
+	lnurl := "LNURL1DP68GURN8GHJ7UM9WFMXJCM99E3K7MF0V9CXJ0M385EKVCENXC6R2C35XVUKXEFCV5MKVV34X5EKZD3EV56NYD3HXQURZEPEXEJXXEPNXSCRVWFNV9NXZCN9XQ6XYEFHVGCXXCMYXYMNSERXFQ5FNS"
+	q, err = NewQRCode(lnurl, QRLevelM)
+	assert.NoError(t, err)
+	assert.Equal(t, lnurl, readQRCode(t, q))
+
+	connection := strings.Repeat("sphinx.chat::v2::connection-string-with-lower-case::", 20)
+	q, err = NewQRCode(connection, QRLevelQ)
+	assert.NoError(t, err)
+	assert.True(t, q.Version >= 7)
+	assert.Equal(t, connection, readQRCode(t, q))
+
+	_, err = NewQRCode(strings.Repeat("x", 3000), QRLevelH)
+	assert.Equal(t, ErrQRTooLong, err)
+
+	encoded, err := q.PNG(2)
+	assert.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(encoded))
+	assert.NoError(t, err)
+	assert.Equal(t, (q.Size+8)*2, img.Bounds().Dx())
+	assert.Contains(t, q.SVG(), `viewBox="0 0 `)
+}
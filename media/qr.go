@@ -0,0 +1,542 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// QRLevel is how much of a QR code can be damaged and still be read, from about 7% for L to
+// 30% for H
+type QRLevel int
+
+const (
+	QRLevelL QRLevel = iota
+	QRLevelM
+	QRLevelQ
+	QRLevelH
+)
+
+// qrQuietZone is the light border of modules scanners need around a code
+const qrQuietZone = 4
+
+var ErrQRTooLong = errors.New("the text is too long for a QR code")
+
+// qrFormatBits are the bits a level is written as in the format information
+var qrFormatBits = [4]int{1, 0, 3, 2}
+
+// qrEccPerBlock and qrBlocks are the error correction codewords of a block and the number of
+// blocks of every version at every level, index 0 is unused
+var qrEccPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+var qrBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// qrAlphanumeric are the characters of the alphanumeric mode, which packs two of them in 11 bits
+const qrAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// QRCode is the grid of modules of a QR code, true is dark
+type QRCode struct {
+	Version int
+	Size    int
+	modules [][]bool
+	// function marks the finder, timing, alignment, format and version modules data can't use
+	function [][]bool
+}
+
+// Dark reports whether the module at x, y is dark, the modules outside the code are light
+func (q *QRCode) Dark(x int, y int) bool {
+	return x >= 0 && y >= 0 && x < q.Size && y < q.Size && q.modules[y][x]
+}
+
+type qrBits []bool
+
+func (b *qrBits) append(value int, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>uint(i))&1 == 1)
+	}
+}
+
+// qrSegment is the text encoded in the alphanumeric mode when it can be, else in the byte mode
+func qrSegment(text string) (mode int, count int, data qrBits, countBits [3]int) {
+	alphanumeric := text != ""
+	for _, r := range text {
+		if !strings.ContainsRune(qrAlphanumeric, r) {
+			alphanumeric = false
+			break
+		}
+	}
+
+	if alphanumeric {
+		for i := 0; i+1 < len(text); i += 2 {
+			data.append(strings.IndexByte(qrAlphanumeric, text[i])*45+strings.IndexByte(qrAlphanumeric, text[i+1]), 11)
+		}
+		if len(text)%2 == 1 {
+			data.append(strings.IndexByte(qrAlphanumeric, text[len(text)-1]), 6)
+		}
+		return 0x2, len(text), data, [3]int{9, 11, 13}
+	}
+
+	for i := 0; i < len(text); i++ {
+		data.append(int(text[i]), 8)
+	}
+	return 0x4, len(text), data, [3]int{8, 16, 16}
+}
+
+// qrRawDataModules is how many modules of a version hold data and error correction
+func qrRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		alignments := version/7 + 2
+		result -= (25*alignments-10)*alignments - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+func qrDataCodewords(version int, level QRLevel) int {
+	return qrRawDataModules(version)/8 - qrEccPerBlock[level][version]*qrBlocks[level][version]
+}
+
+func qrMultiply(x byte, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11d)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
+
+// qrReedSolomon returns the error correction codewords of a block of data
+func qrReedSolomon(data []byte, degree int) []byte {
+	divisor := make([]byte, degree)
+	divisor[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := 0; j < degree; j++ {
+			divisor[j] = qrMultiply(divisor[j], root)
+			if j+1 < degree {
+				divisor[j] ^= divisor[j+1]
+			}
+		}
+		root = qrMultiply(root, 0x02)
+	}
+
+	result := make([]byte, degree)
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[degree-1] = 0
+		for i := range result {
+			result[i] ^= qrMultiply(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+// qrCodewords splits the data in blocks, adds their error correction and interleaves them
+func qrCodewords(data []byte, version int, level QRLevel) []byte {
+	blocks := qrBlocks[level][version]
+	eccLength := qrEccPerBlock[level][version]
+	raw := qrRawDataModules(version) / 8
+	shortBlocks := blocks - raw%blocks
+	shortLength := raw/blocks - eccLength
+
+	dataBlocks := [][]byte{}
+	eccBlocks := [][]byte{}
+	for i, k := 0, 0; i < blocks; i++ {
+		length := shortLength
+		if i >= shortBlocks {
+			length++
+		}
+		block := data[k : k+length]
+		k += length
+		dataBlocks = append(dataBlocks, block)
+		eccBlocks = append(eccBlocks, qrReedSolomon(block, eccLength))
+	}
+
+	result := []byte{}
+	for i := 0; i <= shortLength; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < eccLength; i++ {
+		for _, block := range eccBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// NewQRCode encodes text in the smallest QR code that holds it at a level
+func NewQRCode(text string, level QRLevel) (*QRCode, error) {
+	mode, count, segment, countBits := qrSegment(text)
+
+	version := 1
+	for ; version <= 40; version++ {
+		bits := 4 + countBits[(version+7)/17] + len(segment)
+		if bits <= qrDataCodewords(version, level)*8 {
+			break
+		}
+	}
+	if version > 40 {
+		return nil, ErrQRTooLong
+	}
+
+	capacity := qrDataCodewords(version, level) * 8
+	bits := qrBits{}
+	bits.append(mode, 4)
+	bits.append(count, countBits[(version+7)/17])
+	bits = append(bits, segment...)
+	terminator := capacity - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xec; len(bits) < capacity; pad ^= 0xec ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	data := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			data[i/8] |= 1 << uint(7-i%8)
+		}
+	}
+
+	q := &QRCode{Version: version, Size: version*4 + 17}
+	q.modules = make([][]bool, q.Size)
+	q.function = make([][]bool, q.Size)
+	for y := range q.modules {
+		q.modules[y] = make([]bool, q.Size)
+		q.function[y] = make([]bool, q.Size)
+	}
+	q.drawFunctionPatterns(level)
+	q.drawCodewords(qrCodewords(data, version, level))
+
+	// keep the mask that leaves the fewest patterns scanners confuse, masking twice undoes it
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(level, mask)
+		if penalty := q.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		q.applyMask(mask)
+	}
+	q.applyMask(best)
+	q.drawFormatBits(level, best)
+	return q, nil
+}
+
+func (q *QRCode) setFunction(x int, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.function[y][x] = true
+}
+
+// qrAlignmentPositions are the centers of the alignment patterns on each axis
+func qrAlignmentPositions(version int) []int {
+	if version == 1 {
+		return []int{}
+	}
+	count := version/7 + 2
+	step := 26
+	if version != 32 {
+		step = (version*4 + count*2 + 1) / (count*2 - 2) * 2
+	}
+	positions := make([]int, count)
+	positions[0] = 6
+	for i, position := count-1, version*4+10; i >= 1; i, position = i-1, position-step {
+		positions[i] = position
+	}
+	return positions
+}
+
+func (q *QRCode) drawFunctionPatterns(level QRLevel) {
+	for i := 0; i < q.Size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+
+	// the finder patterns with their separators in three corners
+	for _, corner := range [][2]int{{3, 3}, {q.Size - 4, 3}, {3, q.Size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := corner[0]+dx, corner[1]+dy
+				if x < 0 || y < 0 || x >= q.Size || y >= q.Size {
+					continue
+				}
+				distance := abs(dx)
+				if abs(dy) > distance {
+					distance = abs(dy)
+				}
+				q.setFunction(x, y, distance != 2 && distance != 4)
+			}
+		}
+	}
+
+	positions := qrAlignmentPositions(q.Version)
+	last := len(positions) - 1
+	for i, cy := range positions {
+		for j, cx := range positions {
+			// the corners taken by the finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					distance := abs(dx)
+					if abs(dy) > distance {
+						distance = abs(dy)
+					}
+					q.setFunction(cx+dx, cy+dy, distance != 1)
+				}
+			}
+		}
+	}
+
+	// reserve the format modules until the mask is chosen
+	q.drawFormatBits(level, 0)
+
+	if q.Version >= 7 {
+		remainder := q.Version
+		for i := 0; i < 12; i++ {
+			remainder = (remainder << 1) ^ ((remainder >> 11) * 0x1f25)
+		}
+		bits := q.Version<<12 | remainder
+		for i := 0; i < 18; i++ {
+			dark := (bits>>uint(i))&1 == 1
+			a, b := q.Size-11+i%3, i/3
+			q.setFunction(a, b, dark)
+			q.setFunction(b, a, dark)
+		}
+	}
+}
+
+// qrFormat is the format information of a level and a mask with its error correction
+func qrFormat(level QRLevel, mask int) int {
+	data := qrFormatBits[level]<<3 | mask
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+	return (data<<10 | remainder) ^ 0x5412
+}
+
+func (q *QRCode) drawFormatBits(level QRLevel, mask int) {
+	bits := qrFormat(level, mask)
+	bit := func(i int) bool {
+		return (bits>>uint(i))&1 == 1
+	}
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.Size-15+i, bit(i))
+	}
+	q.setFunction(8, q.Size-8, true)
+}
+
+// drawCodewords fills the modules left by the function patterns two columns at a time, going up
+// and down from the bottom right corner
+func (q *QRCode) drawCodewords(codewords []byte) {
+	i := 0
+	for right := q.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vertical := 0; vertical < q.Size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vertical
+				if (right+1)&2 == 0 {
+					y = q.Size - 1 - vertical
+				}
+				if q.function[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				q.modules[y][x] = (codewords[i/8]>>uint(7-i%8))&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+func (q *QRCode) applyMask(mask int) {
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !q.function[y][x] {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores the patterns of the code that are hard to scan: long runs of a color, 2x2
+// blocks, shapes like a finder and an uneven share of dark modules
+func (q *QRCode) penalty() int {
+	result := 0
+	finder := []bool{true, false, true, true, true, false, true}
+
+	line := func(dark func(i int) bool) {
+		run := 1
+		for i := 1; i <= q.Size; i++ {
+			if i < q.Size && dark(i) == dark(i-1) {
+				run++
+				continue
+			}
+			if run >= 5 {
+				result += 3 + run - 5
+			}
+			run = 1
+		}
+
+		// a finder shape with four light modules on a side, the modules outside are light
+		for i := -4; i+7 <= q.Size+4; i++ {
+			match := true
+			for k, want := range finder {
+				if (i+k >= 0 && i+k < q.Size && dark(i+k)) != want {
+					match = false
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+			light := func(from int) bool {
+				for k := from; k < from+4; k++ {
+					if k >= 0 && k < q.Size && dark(k) {
+						return false
+					}
+				}
+				return true
+			}
+			if light(i - 4) {
+				result += 40
+			}
+			if light(i + 7) {
+				result += 40
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < q.Size; y++ {
+		row := y
+		line(func(i int) bool { return q.modules[row][i] })
+		line(func(i int) bool { return q.modules[i][row] })
+		for x := 0; x < q.Size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x+1 < q.Size && y+1 < q.Size {
+				c := q.modules[y][x]
+				if q.modules[y][x+1] == c && q.modules[y+1][x] == c && q.modules[y+1][x+1] == c {
+					result += 3
+				}
+			}
+		}
+	}
+
+	total := q.Size * q.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	result += k * 10
+	return result
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// PNG draws the code with a quiet zone, each module a scale by scale square
+func (q *QRCode) PNG(scale int) ([]byte, error) {
+	size := (q.Size + qrQuietZone*2) * scale
+	img := image.NewGray(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			c := color.Gray{Y: 0xff}
+			if q.Dark(x/scale-qrQuietZone, y/scale-qrQuietZone) {
+				c = color.Gray{}
+			}
+			img.SetGray(x, y, c)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG draws the code with a quiet zone as a single path, a module is a unit of the viewBox so
+// the image can be scaled to any size
+func (q *QRCode) SVG() string {
+	size := q.Size + qrQuietZone*2
+	path := &strings.Builder{}
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if q.modules[y][x] {
+				fmt.Fprintf(path, "M%d,%dh1v1h-1z", x+qrQuietZone, y+qrQuietZone)
+			}
+		}
+	}
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" version="1.1" viewBox="0 0 %d %d" shape-rendering="crispEdges">
+<rect width="100%%" height="100%%" fill="#ffffff"/>
+<path d="%s" fill="#000000"/>
+</svg>
+`, size, size, path.String())
+}
@@ -0,0 +1,94 @@
+package media
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Fit scales an image down so its longest side is at most size, averaging the source pixels each
+// target pixel covers. Smaller images are returned as they are
+func Fit(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	targetWidth, targetHeight := size, height*size/width
+	if height > width {
+		targetWidth, targetHeight = width*size/height, size
+	}
+	if targetWidth < 1 {
+		targetWidth = 1
+	}
+	if targetHeight < 1 {
+		targetHeight = 1
+	}
+
+	// the colors are averaged premultiplied so transparent pixels don't bleed into their neighbours
+	src := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	horizontal := boxWeights(width, targetWidth)
+	vertical := boxWeights(height, targetHeight)
+
+	// the rows are scaled first into a float buffer, then the columns
+	rows := make([]float64, targetWidth*height*4)
+	for y := 0; y < height; y++ {
+		for x, weights := range horizontal {
+			var sum [4]float64
+			for _, w := range weights {
+				p := src.Pix[y*src.Stride+w.index*4:]
+				for c := 0; c < 4; c++ {
+					sum[c] += float64(p[c]) * w.weight
+				}
+			}
+			copy(rows[(y*targetWidth+x)*4:], sum[:])
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+	for y, weights := range vertical {
+		for x := 0; x < targetWidth; x++ {
+			var sum [4]float64
+			for _, w := range weights {
+				p := rows[(w.index*targetWidth+x)*4:]
+				for c := 0; c < 4; c++ {
+					sum[c] += p[c] * w.weight
+				}
+			}
+			for c := 0; c < 4; c++ {
+				dst.Pix[y*dst.Stride+x*4+c] = uint8(sum[c] + 0.5)
+			}
+		}
+	}
+	return dst
+}
+
+type boxWeight struct {
+	index  int
+	weight float64
+}
+
+// boxWeights returns for every target pixel the source pixels it covers, weighted by how much
+// of each it covers
+func boxWeights(from int, to int) [][]boxWeight {
+	scale := float64(from) / float64(to)
+	weights := make([][]boxWeight, to)
+	for i := range weights {
+		start, end := float64(i)*scale, float64(i+1)*scale
+		for j := int(start); j < from && float64(j) < end; j++ {
+			left, right := float64(j), float64(j+1)
+			if left < start {
+				left = start
+			}
+			if right > end {
+				right = end
+			}
+			if right > left {
+				weights[i] = append(weights[i], boxWeight{index: j, weight: (right - left) / scale})
+			}
+		}
+	}
+	return weights
+}
@@ -0,0 +1,602 @@
+package media
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"io"
+)
+
+// EncodeWebP writes an image as a lossless WebP (VP8L). The pixels go through the subtract green
+// and predictor transforms and are compressed with backward references and canonical prefix codes
+func EncodeWebP(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width < 1 || height < 1 || width > 1<<14 || height > 1<<14 {
+		return ErrDimensions
+	}
+
+	nrgba := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(nrgba, nrgba.Bounds(), img, bounds.Min, draw.Src)
+
+	pixels := make([]uint32, width*height)
+	alpha := false
+	for i := range pixels {
+		c := nrgba.Pix[i*4 : i*4+4]
+		pixels[i] = uint32(c[3])<<24 | uint32(c[0])<<16 | uint32(c[1])<<8 | uint32(c[2])
+		alpha = alpha || c[3] != 0xff
+	}
+
+	bw := &bitWriter{}
+	bw.write(0x2f, 8)
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	if alpha {
+		bw.write(1, 1)
+	} else {
+		bw.write(0, 1)
+	}
+	bw.write(0, 3)
+
+	subtractGreen(pixels)
+	bw.write(1, 1)
+	bw.write(transformSubtractGreen, 2)
+
+	modes, residuals := predict(pixels, width, height)
+	bw.write(1, 1)
+	bw.write(transformPredictor, 2)
+	bw.write(predictorBits-2, 3)
+	writeImage(bw, modes, false)
+
+	bw.write(0, 1)
+	writeImage(bw, residuals, true)
+
+	data := bw.bytes()
+	chunk := len(data)
+	if chunk%2 == 1 {
+		data = append(data, 0)
+	}
+
+	header := &bytes.Buffer{}
+	header.WriteString("RIFF")
+	binary.Write(header, binary.LittleEndian, uint32(4+8+len(data)))
+	header.WriteString("WEBPVP8L")
+	binary.Write(header, binary.LittleEndian, uint32(chunk))
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+const (
+	transformPredictor     = 0
+	transformSubtractGreen = 2
+)
+
+const (
+	// predictorBits sets the 16x16 tiles sharing a predictor
+	predictorBits = 4
+
+	greenAlphabet    = 256 + 24
+	distanceAlphabet = 40
+	maxCodeLength    = 15
+
+	minMatch   = 3
+	maxMatch   = 4096
+	maxWindow  = 1 << 18
+	maxChain   = 32
+	hashBits   = 16
+	noPosition = -1
+)
+
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+// write appends the n low bits of v, the least significant bit first
+func (bw *bitWriter) write(v uint32, n uint) {
+	bw.acc |= uint64(v&(1<<n-1)) << bw.nbits
+	bw.nbits += n
+	for bw.nbits >= 8 {
+		bw.buf = append(bw.buf, byte(bw.acc))
+		bw.acc >>= 8
+		bw.nbits -= 8
+	}
+}
+
+func (bw *bitWriter) bytes() []byte {
+	if bw.nbits > 0 {
+		bw.buf = append(bw.buf, byte(bw.acc))
+		bw.acc, bw.nbits = 0, 0
+	}
+	return bw.buf
+}
+
+func subtractGreen(pixels []uint32) {
+	for i, p := range pixels {
+		green := (p >> 8) & 0xff
+		red := ((p >> 16) - green) & 0xff
+		blue := (p - green) & 0xff
+		pixels[i] = p&0xff00ff00 | red<<16 | blue
+	}
+}
+
+func addPixels(a uint32, b uint32) uint32 {
+	return (a&0xff00ff00+b&0xff00ff00)&0xff00ff00 | (a&0x00ff00ff+b&0x00ff00ff)&0x00ff00ff
+}
+
+func subPixels(a uint32, b uint32) uint32 {
+	return (0x01000100+a&0xff00ff00>>8-b&0xff00ff00>>8)&0x00ff00ff<<8 | (0x01000100+a&0x00ff00ff-b&0x00ff00ff)&0x00ff00ff
+}
+
+func average2(a uint32, b uint32) uint32 {
+	return ((a^b)&0xfefefefe)>>1 + a&b
+}
+
+func clampAddSubtractFull(a uint32, b uint32, c uint32) uint32 {
+	var p uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		v := int(a>>shift&0xff) + int(b>>shift&0xff) - int(c>>shift&0xff)
+		if v < 0 {
+			v = 0
+		} else if v > 255 {
+			v = 255
+		}
+		p |= uint32(v) << shift
+	}
+	return p
+}
+
+// predictorModes are the predictors tried on every tile, by their VP8L mode number
+var predictorModes = []uint32{1, 2, 7, 8, 9, 12}
+
+func predictor(mode uint32, pixels []uint32, i int, width int) uint32 {
+	left, top := pixels[i-1], pixels[i-width]
+	switch mode {
+	case 1:
+		return left
+	case 2:
+		return top
+	case 7:
+		return average2(left, top)
+	case 8:
+		return average2(pixels[i-width-1], top)
+	case 9:
+		// the top right pixel of the last column is the first pixel of the current row
+		return average2(top, pixels[i-width+1])
+	default:
+		return clampAddSubtractFull(left, top, pixels[i-width-1])
+	}
+}
+
+// predict picks the predictor of every tile with the smallest residuals and returns the tile
+// modes along with the residual image
+func predict(pixels []uint32, width int, height int) ([]uint32, []uint32) {
+	tileSize := 1 << predictorBits
+	tilesX := (width + tileSize - 1) / tileSize
+	tilesY := (height + tileSize - 1) / tileSize
+
+	modes := make([]uint32, tilesX*tilesY)
+	residuals := make([]uint32, len(pixels))
+
+	for ty := 0; ty < tilesY; ty++ {
+		for tx := 0; tx < tilesX; tx++ {
+			x0, y0 := tx*tileSize, ty*tileSize
+			x1, y1 := x0+tileSize, y0+tileSize
+			if x1 > width {
+				x1 = width
+			}
+			if y1 > height {
+				y1 = height
+			}
+
+			best, bestCost := predictorModes[0], -1
+			for _, mode := range predictorModes {
+				cost := 0
+				for y := y0; y < y1; y++ {
+					for x := x0; x < x1; x++ {
+						if x == 0 || y == 0 {
+							continue
+						}
+						i := y*width + x
+						cost += residualCost(subPixels(pixels[i], predictor(mode, pixels, i, width)))
+					}
+				}
+				if bestCost < 0 || cost < bestCost {
+					best, bestCost = mode, cost
+				}
+			}
+			modes[ty*tilesX+tx] = 0xff000000 | best<<8
+
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					i := y*width + x
+					var prediction uint32
+					switch {
+					case x == 0 && y == 0:
+						prediction = 0xff000000
+					case y == 0:
+						prediction = pixels[i-1]
+					case x == 0:
+						prediction = pixels[i-width]
+					default:
+						prediction = predictor(best, pixels, i, width)
+					}
+					residuals[i] = subPixels(pixels[i], prediction)
+				}
+			}
+		}
+	}
+	return modes, residuals
+}
+
+func residualCost(p uint32) int {
+	cost := 0
+	for shift := uint(0); shift < 32; shift += 8 {
+		v := int(int8(p >> shift))
+		if v < 0 {
+			v = -v
+		}
+		cost += v
+	}
+	return cost
+}
+
+// symbol is a literal pixel, or a backward reference when length is set
+type symbol struct {
+	pixel    uint32
+	length   int
+	distance int
+}
+
+// backwardReferences greedily replaces runs of pixels seen before with copies, found through
+// hash chains of the pairs of pixels
+func backwardReferences(pixels []uint32) []symbol {
+	symbols := make([]symbol, 0, len(pixels))
+	head := make([]int, 1<<hashBits)
+	for i := range head {
+		head[i] = noPosition
+	}
+	chain := make([]int, len(pixels))
+
+	hash := func(i int) uint32 {
+		return (pixels[i]*0x1e35a7bd ^ pixels[i+1]*0x9e3779b1) >> (32 - hashBits)
+	}
+	insert := func(i int) {
+		if i+1 < len(pixels) {
+			h := hash(i)
+			chain[i] = head[h]
+			head[h] = i
+		}
+	}
+
+	for i := 0; i < len(pixels); {
+		bestLength, bestDistance := 0, 0
+		if i+1 < len(pixels) {
+			candidate := head[hash(i)]
+			for tries := 0; candidate != noPosition && i-candidate <= maxWindow && tries < maxChain; tries++ {
+				length := 0
+				for i+length < len(pixels) && length < maxMatch && pixels[candidate+length] == pixels[i+length] {
+					length++
+				}
+				if length > bestLength {
+					bestLength, bestDistance = length, i-candidate
+				}
+				candidate = chain[candidate]
+			}
+		}
+
+		if bestLength >= minMatch {
+			symbols = append(symbols, symbol{length: bestLength, distance: bestDistance})
+			for j := 0; j < bestLength; j++ {
+				insert(i + j)
+			}
+			i += bestLength
+			continue
+		}
+
+		symbols = append(symbols, symbol{pixel: pixels[i]})
+		insert(i)
+		i++
+	}
+	return symbols
+}
+
+// prefixEncode splits a length or distance into its prefix symbol and the extra bits following it
+func prefixEncode(value int) (int, uint, uint32) {
+	if value <= 4 {
+		return value - 1, 0, 0
+	}
+	value--
+	highest := uint(0)
+	for v := value; v > 1; v >>= 1 {
+		highest++
+	}
+	second := (value >> (highest - 1)) & 1
+	extraBits := highest - 1
+	return int(2*highest) + second, extraBits, uint32(value) & (1<<extraBits - 1)
+}
+
+// writeImage writes the prefix codes of an image then its pixels, the main image tells it has
+// no meta prefix codes
+func writeImage(bw *bitWriter, pixels []uint32, main bool) {
+	symbols := backwardReferences(pixels)
+
+	histograms := [5][]int{
+		make([]int, greenAlphabet),
+		make([]int, 256),
+		make([]int, 256),
+		make([]int, 256),
+		make([]int, distanceAlphabet),
+	}
+	for _, s := range symbols {
+		if s.length > 0 {
+			code, _, _ := prefixEncode(s.length)
+			histograms[0][256+code]++
+			code, _, _ = prefixEncode(s.distance + 120)
+			histograms[4][code]++
+			continue
+		}
+		histograms[0][s.pixel>>8&0xff]++
+		histograms[1][s.pixel>>16&0xff]++
+		histograms[2][s.pixel&0xff]++
+		histograms[3][s.pixel>>24]++
+	}
+
+	// no color cache
+	bw.write(0, 1)
+	if main {
+		bw.write(0, 1)
+	}
+
+	var codes [5][]uint32
+	var lengths [5][]uint8
+	for i, histogram := range histograms {
+		lengths[i], codes[i] = writePrefixCode(bw, histogram)
+	}
+
+	writeSymbol := func(tree int, s int) {
+		bw.write(codes[tree][s], uint(lengths[tree][s]))
+	}
+
+	for _, s := range symbols {
+		if s.length > 0 {
+			code, extraBits, extra := prefixEncode(s.length)
+			writeSymbol(0, 256+code)
+			bw.write(extra, extraBits)
+			code, extraBits, extra = prefixEncode(s.distance + 120)
+			writeSymbol(4, code)
+			bw.write(extra, extraBits)
+			continue
+		}
+		writeSymbol(0, int(s.pixel>>8&0xff))
+		writeSymbol(1, int(s.pixel>>16&0xff))
+		writeSymbol(2, int(s.pixel&0xff))
+		writeSymbol(3, int(s.pixel>>24))
+	}
+}
+
+// codeLengthOrder is the order the lengths of the code length code are written in
+var codeLengthOrder = []int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// writePrefixCode writes the prefix code of a histogram and returns its code lengths with the bit
+// reversed codes ready to be written. A code of at most two symbols below 256 is written as a
+// simple code, a single symbol then taking no bits at all
+func writePrefixCode(bw *bitWriter, histogram []int) ([]uint8, []uint32) {
+	used := []int{}
+	for s, count := range histogram {
+		if count > 0 {
+			used = append(used, s)
+		}
+	}
+
+	lengths := make([]uint8, len(histogram))
+	if len(used) == 0 {
+		used = append(used, 0)
+	}
+	if len(used) <= 2 && used[len(used)-1] < 256 {
+		bw.write(1, 1)
+		bw.write(uint32(len(used)-1), 1)
+		if used[0] < 2 {
+			bw.write(0, 1)
+			bw.write(uint32(used[0]), 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(uint32(used[0]), 8)
+		}
+		if len(used) == 2 {
+			bw.write(uint32(used[1]), 8)
+			lengths[used[0]], lengths[used[1]] = 1, 1
+		}
+		return lengths, canonicalCodes(lengths)
+	}
+
+	lengths = codeLengths(histogram, maxCodeLength)
+	bw.write(0, 1)
+
+	// the code lengths are written as they are, with the runs of zeros shortened
+	tokens := [][2]int{}
+	for i := 0; i < len(lengths); {
+		if lengths[i] != 0 {
+			tokens = append(tokens, [2]int{int(lengths[i]), 0})
+			i++
+			continue
+		}
+		run := 0
+		for i+run < len(lengths) && lengths[i+run] == 0 {
+			run++
+		}
+		for run > 0 {
+			switch {
+			case run >= 11:
+				n := run
+				if n > 138 {
+					n = 138
+				}
+				tokens = append(tokens, [2]int{18, n - 11})
+				run -= n
+				i += n
+			case run >= 3:
+				tokens = append(tokens, [2]int{17, run - 3})
+				i += run
+				run = 0
+			default:
+				tokens = append(tokens, [2]int{0, 0})
+				run--
+				i++
+			}
+		}
+	}
+
+	lengthHistogram := make([]int, 19)
+	for _, t := range tokens {
+		lengthHistogram[t[0]]++
+	}
+	lengthLengths := codeLengths(lengthHistogram, 7)
+	lengthCodes := canonicalCodes(lengthLengths)
+
+	count := len(codeLengthOrder)
+	for count > 4 && lengthLengths[codeLengthOrder[count-1]] == 0 {
+		count--
+	}
+	bw.write(uint32(count-4), 4)
+	for _, s := range codeLengthOrder[:count] {
+		bw.write(uint32(lengthLengths[s]), 3)
+	}
+
+	// every symbol of the alphabet is written
+	bw.write(0, 1)
+	for _, t := range tokens {
+		bw.write(lengthCodes[t[0]], uint(lengthLengths[t[0]]))
+		switch t[0] {
+		case 17:
+			bw.write(uint32(t[1]), 3)
+		case 18:
+			bw.write(uint32(t[1]), 7)
+		}
+	}
+	return lengths, canonicalCodes(lengths)
+}
+
+type huffmanNode struct {
+	count       int
+	symbol      int
+	left, right *huffmanNode
+}
+
+type huffmanHeap []*huffmanNode
+
+func (h huffmanHeap) Len() int { return len(h) }
+func (h huffmanHeap) Less(i, j int) bool {
+	if h[i].count == h[j].count {
+		return h[i].symbol < h[j].symbol
+	}
+	return h[i].count < h[j].count
+}
+func (h huffmanHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *huffmanHeap) Push(x interface{}) { *h = append(*h, x.(*huffmanNode)) }
+func (h *huffmanHeap) Pop() interface{} {
+	old := *h
+	node := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return node
+}
+
+// codeLengths builds the huffman code lengths of a histogram, no longer than maxLength. The small
+// counts are raised until the tree is shallow enough. A code always has two symbols at least, so
+// a lone symbol gets a partner
+func codeLengths(histogram []int, maxLength uint8) []uint8 {
+	counts := append([]int{}, histogram...)
+	used := 0
+	for _, count := range counts {
+		if count > 0 {
+			used++
+		}
+	}
+	if used < 2 {
+		for s := range counts {
+			if counts[s] == 0 {
+				counts[s] = 1
+				used++
+				if used == 2 {
+					break
+				}
+			}
+		}
+	}
+
+	for minCount := 1; ; minCount *= 2 {
+		nodes := &huffmanHeap{}
+		for s, count := range counts {
+			if count > 0 {
+				if count < minCount {
+					count = minCount
+				}
+				*nodes = append(*nodes, &huffmanNode{count: count, symbol: s})
+			}
+		}
+		heap.Init(nodes)
+		for nodes.Len() > 1 {
+			a := heap.Pop(nodes).(*huffmanNode)
+			b := heap.Pop(nodes).(*huffmanNode)
+			heap.Push(nodes, &huffmanNode{count: a.count + b.count, symbol: a.symbol, left: a, right: b})
+		}
+
+		lengths := make([]uint8, len(counts))
+		deepest := setDepths(heap.Pop(nodes).(*huffmanNode), 0, lengths)
+		if deepest <= int(maxLength) {
+			return lengths
+		}
+	}
+}
+
+func setDepths(node *huffmanNode, depth int, lengths []uint8) int {
+	if node.left == nil {
+		lengths[node.symbol] = uint8(depth)
+		return depth
+	}
+	left := setDepths(node.left, depth+1, lengths)
+	right := setDepths(node.right, depth+1, lengths)
+	if left > right {
+		return left
+	}
+	return right
+}
+
+// canonicalCodes assigns the canonical codes of the code lengths, bit reversed since the codes
+// are read from their most significant bit
+func canonicalCodes(lengths []uint8) []uint32 {
+	var counts [maxCodeLength + 1]uint32
+	for _, length := range lengths {
+		counts[length]++
+	}
+	counts[0] = 0
+
+	var next [maxCodeLength + 2]uint32
+	code := uint32(0)
+	for length := 1; length <= maxCodeLength; length++ {
+		code = (code + counts[length-1]) << 1
+		next[length] = code
+	}
+
+	codes := make([]uint32, len(lengths))
+	for s, length := range lengths {
+		if length == 0 {
+			continue
+		}
+		c := next[length]
+		next[length]++
+		reversed := uint32(0)
+		for i := uint8(0); i < length; i++ {
+			reversed = reversed<<1 | c>>i&1
+		}
+		codes[s] = reversed
+	}
+	return codes
+}
@@ -25,6 +25,118 @@ func (_m *Database) EXPECT() *Database_Expecter {
 	return &Database_Expecter{mock: &_m.Mock}
 }
 
+// AcceptWorkspaceTransfer provides a mock function with given fields: uuid
+func (_m *Database) AcceptWorkspaceTransfer(uuid string) (db.WorkspaceTransfer, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptWorkspaceTransfer")
+	}
+
+	var r0 db.WorkspaceTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.WorkspaceTransfer, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceTransfer); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_AcceptWorkspaceTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptWorkspaceTransfer'
+type Database_AcceptWorkspaceTransfer_Call struct {
+	*mock.Call
+}
+
+// AcceptWorkspaceTransfer is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) AcceptWorkspaceTransfer(uuid interface{}) *Database_AcceptWorkspaceTransfer_Call {
+	return &Database_AcceptWorkspaceTransfer_Call{Call: _e.mock.On("AcceptWorkspaceTransfer", uuid)}
+}
+
+func (_c *Database_AcceptWorkspaceTransfer_Call) Run(run func(uuid string)) *Database_AcceptWorkspaceTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_AcceptWorkspaceTransfer_Call) Return(_a0 db.WorkspaceTransfer, _a1 error) *Database_AcceptWorkspaceTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_AcceptWorkspaceTransfer_Call) RunAndReturn(run func(string) (db.WorkspaceTransfer, error)) *Database_AcceptWorkspaceTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddActivity provides a mock function with given fields: m
+func (_m *Database) AddActivity(m db.Activity) (db.Activity, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddActivity")
+	}
+
+	var r0 db.Activity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Activity) (db.Activity, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.Activity) db.Activity); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.Activity)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Activity) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_AddActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddActivity'
+type Database_AddActivity_Call struct {
+	*mock.Call
+}
+
+// AddActivity is a helper method to define mock.On call
+//   - m db.Activity
+func (_e *Database_Expecter) AddActivity(m interface{}) *Database_AddActivity_Call {
+	return &Database_AddActivity_Call{Call: _e.mock.On("AddActivity", m)}
+}
+
+func (_c *Database_AddActivity_Call) Run(run func(m db.Activity)) *Database_AddActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Activity))
+	})
+	return _c
+}
+
+func (_c *Database_AddActivity_Call) Return(_a0 db.Activity, _a1 error) *Database_AddActivity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_AddActivity_Call) RunAndReturn(run func(db.Activity) (db.Activity, error)) *Database_AddActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddAndUpdateBudget provides a mock function with given fields: invoice
 func (_m *Database) AddAndUpdateBudget(invoice db.NewInvoiceList) db.NewPaymentHistory {
 	ret := _m.Called(invoice)
@@ -173,6 +285,109 @@ func (_c *Database_AddBudgetHistory_Call) RunAndReturn(run func(db.BudgetHistory
 	return _c
 }
 
+// AddDeletion provides a mock function with given fields: kind, recordId
+func (_m *Database) AddDeletion(kind string, recordId string) error {
+	ret := _m.Called(kind, recordId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDeletion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(kind, recordId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_AddDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddDeletion'
+type Database_AddDeletion_Call struct {
+	*mock.Call
+}
+
+// AddDeletion is a helper method to define mock.On call
+//   - kind string
+//   - recordId string
+func (_e *Database_Expecter) AddDeletion(kind interface{}, recordId interface{}) *Database_AddDeletion_Call {
+	return &Database_AddDeletion_Call{Call: _e.mock.On("AddDeletion", kind, recordId)}
+}
+
+func (_c *Database_AddDeletion_Call) Run(run func(kind string, recordId string)) *Database_AddDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_AddDeletion_Call) Return(_a0 error) *Database_AddDeletion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_AddDeletion_Call) RunAndReturn(run func(string, string) error) *Database_AddDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddDisputeEvidence provides a mock function with given fields: m
+func (_m *Database) AddDisputeEvidence(m db.DisputeEvidence) (db.DisputeEvidence, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDisputeEvidence")
+	}
+
+	var r0 db.DisputeEvidence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.DisputeEvidence) (db.DisputeEvidence, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.DisputeEvidence) db.DisputeEvidence); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.DisputeEvidence)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.DisputeEvidence) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_AddDisputeEvidence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddDisputeEvidence'
+type Database_AddDisputeEvidence_Call struct {
+	*mock.Call
+}
+
+// AddDisputeEvidence is a helper method to define mock.On call
+//   - m db.DisputeEvidence
+func (_e *Database_Expecter) AddDisputeEvidence(m interface{}) *Database_AddDisputeEvidence_Call {
+	return &Database_AddDisputeEvidence_Call{Call: _e.mock.On("AddDisputeEvidence", m)}
+}
+
+func (_c *Database_AddDisputeEvidence_Call) Run(run func(m db.DisputeEvidence)) *Database_AddDisputeEvidence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.DisputeEvidence))
+	})
+	return _c
+}
+
+func (_c *Database_AddDisputeEvidence_Call) Return(_a0 db.DisputeEvidence, _a1 error) *Database_AddDisputeEvidence_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_AddDisputeEvidence_Call) RunAndReturn(run func(db.DisputeEvidence) (db.DisputeEvidence, error)) *Database_AddDisputeEvidence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddInvoice provides a mock function with given fields: invoice
 func (_m *Database) AddInvoice(invoice db.NewInvoiceList) db.NewInvoiceList {
 	ret := _m.Called(invoice)
@@ -219,6 +434,62 @@ func (_c *Database_AddInvoice_Call) RunAndReturn(run func(db.NewInvoiceList) db.
 	return _c
 }
 
+// AddNotification provides a mock function with given fields: m
+func (_m *Database) AddNotification(m db.Notification) (db.Notification, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddNotification")
+	}
+
+	var r0 db.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Notification) (db.Notification, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.Notification) db.Notification); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.Notification)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Notification) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_AddNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddNotification'
+type Database_AddNotification_Call struct {
+	*mock.Call
+}
+
+// AddNotification is a helper method to define mock.On call
+//   - m db.Notification
+func (_e *Database_Expecter) AddNotification(m interface{}) *Database_AddNotification_Call {
+	return &Database_AddNotification_Call{Call: _e.mock.On("AddNotification", m)}
+}
+
+func (_c *Database_AddNotification_Call) Run(run func(m db.Notification)) *Database_AddNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Notification))
+	})
+	return _c
+}
+
+func (_c *Database_AddNotification_Call) Return(_a0 db.Notification, _a1 error) *Database_AddNotification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_AddNotification_Call) RunAndReturn(run func(db.Notification) (db.Notification, error)) *Database_AddNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddPaymentHistory provides a mock function with given fields: payment
 func (_m *Database) AddPaymentHistory(payment db.NewPaymentHistory) db.NewPaymentHistory {
 	ret := _m.Called(payment)
@@ -265,467 +536,481 @@ func (_c *Database_AddPaymentHistory_Call) RunAndReturn(run func(db.NewPaymentHi
 	return _c
 }
 
-// AddUserInvoiceData provides a mock function with given fields: userData
-func (_m *Database) AddUserInvoiceData(userData db.UserInvoiceData) db.UserInvoiceData {
-	ret := _m.Called(userData)
+// AddSuperAdmin provides a mock function with given fields: m
+func (_m *Database) AddSuperAdmin(m db.SuperAdmin) (db.SuperAdmin, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddUserInvoiceData")
+		panic("no return value specified for AddSuperAdmin")
 	}
 
-	var r0 db.UserInvoiceData
-	if rf, ok := ret.Get(0).(func(db.UserInvoiceData) db.UserInvoiceData); ok {
-		r0 = rf(userData)
+	var r0 db.SuperAdmin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.SuperAdmin) (db.SuperAdmin, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.SuperAdmin) db.SuperAdmin); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(db.UserInvoiceData)
+		r0 = ret.Get(0).(db.SuperAdmin)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.SuperAdmin) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_AddUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddUserInvoiceData'
-type Database_AddUserInvoiceData_Call struct {
+// Database_AddSuperAdmin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSuperAdmin'
+type Database_AddSuperAdmin_Call struct {
 	*mock.Call
 }
 
-// AddUserInvoiceData is a helper method to define mock.On call
-//   - userData db.UserInvoiceData
-func (_e *Database_Expecter) AddUserInvoiceData(userData interface{}) *Database_AddUserInvoiceData_Call {
-	return &Database_AddUserInvoiceData_Call{Call: _e.mock.On("AddUserInvoiceData", userData)}
+// AddSuperAdmin is a helper method to define mock.On call
+//   - m db.SuperAdmin
+func (_e *Database_Expecter) AddSuperAdmin(m interface{}) *Database_AddSuperAdmin_Call {
+	return &Database_AddSuperAdmin_Call{Call: _e.mock.On("AddSuperAdmin", m)}
 }
 
-func (_c *Database_AddUserInvoiceData_Call) Run(run func(userData db.UserInvoiceData)) *Database_AddUserInvoiceData_Call {
+func (_c *Database_AddSuperAdmin_Call) Run(run func(m db.SuperAdmin)) *Database_AddSuperAdmin_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.UserInvoiceData))
+		run(args[0].(db.SuperAdmin))
 	})
 	return _c
 }
 
-func (_c *Database_AddUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_AddUserInvoiceData_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_AddSuperAdmin_Call) Return(_a0 db.SuperAdmin, _a1 error) *Database_AddSuperAdmin_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_AddUserInvoiceData_Call) RunAndReturn(run func(db.UserInvoiceData) db.UserInvoiceData) *Database_AddUserInvoiceData_Call {
+func (_c *Database_AddSuperAdmin_Call) RunAndReturn(run func(db.SuperAdmin) (db.SuperAdmin, error)) *Database_AddSuperAdmin_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AverageCompletedTime provides a mock function with given fields: r, workspace
-func (_m *Database) AverageCompletedTime(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// AddTribeActivity provides a mock function with given fields: m
+func (_m *Database) AddTribeActivity(m db.TribeActivity) (db.TribeActivity, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AverageCompletedTime")
+		panic("no return value specified for AddTribeActivity")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 db.TribeActivity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TribeActivity) (db.TribeActivity, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.TribeActivity) db.TribeActivity); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(db.TribeActivity)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.TribeActivity) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_AverageCompletedTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AverageCompletedTime'
-type Database_AverageCompletedTime_Call struct {
+// Database_AddTribeActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTribeActivity'
+type Database_AddTribeActivity_Call struct {
 	*mock.Call
 }
 
-// AverageCompletedTime is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) AverageCompletedTime(r interface{}, workspace interface{}) *Database_AverageCompletedTime_Call {
-	return &Database_AverageCompletedTime_Call{Call: _e.mock.On("AverageCompletedTime", r, workspace)}
+// AddTribeActivity is a helper method to define mock.On call
+//   - m db.TribeActivity
+func (_e *Database_Expecter) AddTribeActivity(m interface{}) *Database_AddTribeActivity_Call {
+	return &Database_AddTribeActivity_Call{Call: _e.mock.On("AddTribeActivity", m)}
 }
 
-func (_c *Database_AverageCompletedTime_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_AverageCompletedTime_Call {
+func (_c *Database_AddTribeActivity_Call) Run(run func(m db.TribeActivity)) *Database_AddTribeActivity_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(db.TribeActivity))
 	})
 	return _c
 }
 
-func (_c *Database_AverageCompletedTime_Call) Return(_a0 uint) *Database_AverageCompletedTime_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_AddTribeActivity_Call) Return(_a0 db.TribeActivity, _a1 error) *Database_AddTribeActivity_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_AverageCompletedTime_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_AverageCompletedTime_Call {
+func (_c *Database_AddTribeActivity_Call) RunAndReturn(run func(db.TribeActivity) (db.TribeActivity, error)) *Database_AddTribeActivity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AveragePaidTime provides a mock function with given fields: r, workspace
-func (_m *Database) AveragePaidTime(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
-
+// AddUserInvoiceData provides a mock function with given fields: userData
+func (_m *Database) AddUserInvoiceData(userData db.UserInvoiceData) db.UserInvoiceData {
+	ret := _m.Called(userData)
+
 	if len(ret) == 0 {
-		panic("no return value specified for AveragePaidTime")
+		panic("no return value specified for AddUserInvoiceData")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 db.UserInvoiceData
+	if rf, ok := ret.Get(0).(func(db.UserInvoiceData) db.UserInvoiceData); ok {
+		r0 = rf(userData)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(db.UserInvoiceData)
 	}
 
 	return r0
 }
 
-// Database_AveragePaidTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AveragePaidTime'
-type Database_AveragePaidTime_Call struct {
+// Database_AddUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddUserInvoiceData'
+type Database_AddUserInvoiceData_Call struct {
 	*mock.Call
 }
 
-// AveragePaidTime is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) AveragePaidTime(r interface{}, workspace interface{}) *Database_AveragePaidTime_Call {
-	return &Database_AveragePaidTime_Call{Call: _e.mock.On("AveragePaidTime", r, workspace)}
+// AddUserInvoiceData is a helper method to define mock.On call
+//   - userData db.UserInvoiceData
+func (_e *Database_Expecter) AddUserInvoiceData(userData interface{}) *Database_AddUserInvoiceData_Call {
+	return &Database_AddUserInvoiceData_Call{Call: _e.mock.On("AddUserInvoiceData", userData)}
 }
 
-func (_c *Database_AveragePaidTime_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_AveragePaidTime_Call {
+func (_c *Database_AddUserInvoiceData_Call) Run(run func(userData db.UserInvoiceData)) *Database_AddUserInvoiceData_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(db.UserInvoiceData))
 	})
 	return _c
 }
 
-func (_c *Database_AveragePaidTime_Call) Return(_a0 uint) *Database_AveragePaidTime_Call {
+func (_c *Database_AddUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_AddUserInvoiceData_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_AveragePaidTime_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_AveragePaidTime_Call {
+func (_c *Database_AddUserInvoiceData_Call) RunAndReturn(run func(db.UserInvoiceData) db.UserInvoiceData) *Database_AddUserInvoiceData_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// BountiesPaidPercentage provides a mock function with given fields: r, workspace
-func (_m *Database) BountiesPaidPercentage(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// AggregateLeaderboard provides a mock function with given fields: kind, since, limit
+func (_m *Database) AggregateLeaderboard(kind db.LeaderboardKind, since *time.Time, limit int) []db.LeaderboardEntry {
+	ret := _m.Called(kind, since, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for BountiesPaidPercentage")
+		panic("no return value specified for AggregateLeaderboard")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 []db.LeaderboardEntry
+	if rf, ok := ret.Get(0).(func(db.LeaderboardKind, *time.Time, int) []db.LeaderboardEntry); ok {
+		r0 = rf(kind, since, limit)
 	} else {
-		r0 = ret.Get(0).(uint)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LeaderboardEntry)
+		}
 	}
 
 	return r0
 }
 
-// Database_BountiesPaidPercentage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BountiesPaidPercentage'
-type Database_BountiesPaidPercentage_Call struct {
+// Database_AggregateLeaderboard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AggregateLeaderboard'
+type Database_AggregateLeaderboard_Call struct {
 	*mock.Call
 }
 
-// BountiesPaidPercentage is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) BountiesPaidPercentage(r interface{}, workspace interface{}) *Database_BountiesPaidPercentage_Call {
-	return &Database_BountiesPaidPercentage_Call{Call: _e.mock.On("BountiesPaidPercentage", r, workspace)}
+// AggregateLeaderboard is a helper method to define mock.On call
+//   - kind db.LeaderboardKind
+//   - since *time.Time
+//   - limit int
+func (_e *Database_Expecter) AggregateLeaderboard(kind interface{}, since interface{}, limit interface{}) *Database_AggregateLeaderboard_Call {
+	return &Database_AggregateLeaderboard_Call{Call: _e.mock.On("AggregateLeaderboard", kind, since, limit)}
 }
 
-func (_c *Database_BountiesPaidPercentage_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_BountiesPaidPercentage_Call {
+func (_c *Database_AggregateLeaderboard_Call) Run(run func(kind db.LeaderboardKind, since *time.Time, limit int)) *Database_AggregateLeaderboard_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(db.LeaderboardKind), args[1].(*time.Time), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Database_BountiesPaidPercentage_Call) Return(_a0 uint) *Database_BountiesPaidPercentage_Call {
+func (_c *Database_AggregateLeaderboard_Call) Return(_a0 []db.LeaderboardEntry) *Database_AggregateLeaderboard_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_BountiesPaidPercentage_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_BountiesPaidPercentage_Call {
+func (_c *Database_AggregateLeaderboard_Call) RunAndReturn(run func(db.LeaderboardKind, *time.Time, int) []db.LeaderboardEntry) *Database_AggregateLeaderboard_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ChangeWorkspaceDeleteStatus provides a mock function with given fields: workspace_uuid, status
-func (_m *Database) ChangeWorkspaceDeleteStatus(workspace_uuid string, status bool) db.Workspace {
-	ret := _m.Called(workspace_uuid, status)
+// AnonymizePerson provides a mock function with given fields: pubkey
+func (_m *Database) AnonymizePerson(pubkey string) error {
+	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ChangeWorkspaceDeleteStatus")
+		panic("no return value specified for AnonymizePerson")
 	}
 
-	var r0 db.Workspace
-	if rf, ok := ret.Get(0).(func(string, bool) db.Workspace); ok {
-		r0 = rf(workspace_uuid, status)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(pubkey)
 	} else {
-		r0 = ret.Get(0).(db.Workspace)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_ChangeWorkspaceDeleteStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeWorkspaceDeleteStatus'
-type Database_ChangeWorkspaceDeleteStatus_Call struct {
+// Database_AnonymizePerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnonymizePerson'
+type Database_AnonymizePerson_Call struct {
 	*mock.Call
 }
 
-// ChangeWorkspaceDeleteStatus is a helper method to define mock.On call
-//   - workspace_uuid string
-//   - status bool
-func (_e *Database_Expecter) ChangeWorkspaceDeleteStatus(workspace_uuid interface{}, status interface{}) *Database_ChangeWorkspaceDeleteStatus_Call {
-	return &Database_ChangeWorkspaceDeleteStatus_Call{Call: _e.mock.On("ChangeWorkspaceDeleteStatus", workspace_uuid, status)}
+// AnonymizePerson is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) AnonymizePerson(pubkey interface{}) *Database_AnonymizePerson_Call {
+	return &Database_AnonymizePerson_Call{Call: _e.mock.On("AnonymizePerson", pubkey)}
 }
 
-func (_c *Database_ChangeWorkspaceDeleteStatus_Call) Run(run func(workspace_uuid string, status bool)) *Database_ChangeWorkspaceDeleteStatus_Call {
+func (_c *Database_AnonymizePerson_Call) Run(run func(pubkey string)) *Database_AnonymizePerson_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(bool))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_ChangeWorkspaceDeleteStatus_Call) Return(_a0 db.Workspace) *Database_ChangeWorkspaceDeleteStatus_Call {
+func (_c *Database_AnonymizePerson_Call) Return(_a0 error) *Database_AnonymizePerson_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_ChangeWorkspaceDeleteStatus_Call) RunAndReturn(run func(string, bool) db.Workspace) *Database_ChangeWorkspaceDeleteStatus_Call {
+func (_c *Database_AnonymizePerson_Call) RunAndReturn(run func(string) error) *Database_AnonymizePerson_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CountBounties provides a mock function with given fields:
-func (_m *Database) CountBounties() uint64 {
-	ret := _m.Called()
+// ApplyTicketChanges provides a mock function with given fields: people, cards, removedTickets
+func (_m *Database) ApplyTicketChanges(people []db.Person, cards []db.TicketCard, removedTickets []string) error {
+	ret := _m.Called(people, cards, removedTickets)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountBounties")
+		panic("no return value specified for ApplyTicketChanges")
 	}
 
-	var r0 uint64
-	if rf, ok := ret.Get(0).(func() uint64); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]db.Person, []db.TicketCard, []string) error); ok {
+		r0 = rf(people, cards, removedTickets)
 	} else {
-		r0 = ret.Get(0).(uint64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_CountBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBounties'
-type Database_CountBounties_Call struct {
+// Database_ApplyTicketChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyTicketChanges'
+type Database_ApplyTicketChanges_Call struct {
 	*mock.Call
 }
 
-// CountBounties is a helper method to define mock.On call
-func (_e *Database_Expecter) CountBounties() *Database_CountBounties_Call {
-	return &Database_CountBounties_Call{Call: _e.mock.On("CountBounties")}
+// ApplyTicketChanges is a helper method to define mock.On call
+//   - people []db.Person
+//   - cards []db.TicketCard
+//   - removedTickets []string
+func (_e *Database_Expecter) ApplyTicketChanges(people interface{}, cards interface{}, removedTickets interface{}) *Database_ApplyTicketChanges_Call {
+	return &Database_ApplyTicketChanges_Call{Call: _e.mock.On("ApplyTicketChanges", people, cards, removedTickets)}
 }
 
-func (_c *Database_CountBounties_Call) Run(run func()) *Database_CountBounties_Call {
+func (_c *Database_ApplyTicketChanges_Call) Run(run func(people []db.Person, cards []db.TicketCard, removedTickets []string)) *Database_ApplyTicketChanges_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].([]db.Person), args[1].([]db.TicketCard), args[2].([]string))
 	})
 	return _c
 }
 
-func (_c *Database_CountBounties_Call) Return(_a0 uint64) *Database_CountBounties_Call {
+func (_c *Database_ApplyTicketChanges_Call) Return(_a0 error) *Database_ApplyTicketChanges_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CountBounties_Call) RunAndReturn(run func() uint64) *Database_CountBounties_Call {
+func (_c *Database_ApplyTicketChanges_Call) RunAndReturn(run func([]db.Person, []db.TicketCard, []string) error) *Database_ApplyTicketChanges_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CountDevelopers provides a mock function with given fields:
-func (_m *Database) CountDevelopers() int64 {
-	ret := _m.Called()
+// ArchiveWorkspace provides a mock function with given fields: uuid, archived, pubkey
+func (_m *Database) ArchiveWorkspace(uuid string, archived bool, pubkey string) (db.Workspace, error) {
+	ret := _m.Called(uuid, archived, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountDevelopers")
+		panic("no return value specified for ArchiveWorkspace")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
-		r0 = rf()
+	var r0 db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, bool, string) (db.Workspace, error)); ok {
+		return rf(uuid, archived, pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string, bool, string) db.Workspace); ok {
+		r0 = rf(uuid, archived, pubkey)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, bool, string) error); ok {
+		r1 = rf(uuid, archived, pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_CountDevelopers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDevelopers'
-type Database_CountDevelopers_Call struct {
+// Database_ArchiveWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveWorkspace'
+type Database_ArchiveWorkspace_Call struct {
 	*mock.Call
 }
 
-// CountDevelopers is a helper method to define mock.On call
-func (_e *Database_Expecter) CountDevelopers() *Database_CountDevelopers_Call {
-	return &Database_CountDevelopers_Call{Call: _e.mock.On("CountDevelopers")}
+// ArchiveWorkspace is a helper method to define mock.On call
+//   - uuid string
+//   - archived bool
+//   - pubkey string
+func (_e *Database_Expecter) ArchiveWorkspace(uuid interface{}, archived interface{}, pubkey interface{}) *Database_ArchiveWorkspace_Call {
+	return &Database_ArchiveWorkspace_Call{Call: _e.mock.On("ArchiveWorkspace", uuid, archived, pubkey)}
 }
 
-func (_c *Database_CountDevelopers_Call) Run(run func()) *Database_CountDevelopers_Call {
+func (_c *Database_ArchiveWorkspace_Call) Run(run func(uuid string, archived bool, pubkey string)) *Database_ArchiveWorkspace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(bool), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CountDevelopers_Call) Return(_a0 int64) *Database_CountDevelopers_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ArchiveWorkspace_Call) Return(_a0 db.Workspace, _a1 error) *Database_ArchiveWorkspace_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CountDevelopers_Call) RunAndReturn(run func() int64) *Database_CountDevelopers_Call {
+func (_c *Database_ArchiveWorkspace_Call) RunAndReturn(run func(string, bool, string) (db.Workspace, error)) *Database_ArchiveWorkspace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateChannel provides a mock function with given fields: c
-func (_m *Database) CreateChannel(c db.Channel) (db.Channel, error) {
-	ret := _m.Called(c)
+// AverageCompletedTime provides a mock function with given fields: r, workspace
+func (_m *Database) AverageCompletedTime(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateChannel")
-	}
-
-	var r0 db.Channel
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Channel) (db.Channel, error)); ok {
-		return rf(c)
-	}
-	if rf, ok := ret.Get(0).(func(db.Channel) db.Channel); ok {
-		r0 = rf(c)
-	} else {
-		r0 = ret.Get(0).(db.Channel)
+		panic("no return value specified for AverageCompletedTime")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Channel) error); ok {
-		r1 = rf(c)
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(uint)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChannel'
-type Database_CreateChannel_Call struct {
+// Database_AverageCompletedTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AverageCompletedTime'
+type Database_AverageCompletedTime_Call struct {
 	*mock.Call
 }
 
-// CreateChannel is a helper method to define mock.On call
-//   - c db.Channel
-func (_e *Database_Expecter) CreateChannel(c interface{}) *Database_CreateChannel_Call {
-	return &Database_CreateChannel_Call{Call: _e.mock.On("CreateChannel", c)}
+// AverageCompletedTime is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) AverageCompletedTime(r interface{}, workspace interface{}) *Database_AverageCompletedTime_Call {
+	return &Database_AverageCompletedTime_Call{Call: _e.mock.On("AverageCompletedTime", r, workspace)}
 }
 
-func (_c *Database_CreateChannel_Call) Run(run func(c db.Channel)) *Database_CreateChannel_Call {
+func (_c *Database_AverageCompletedTime_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_AverageCompletedTime_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Channel))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateChannel_Call) Return(_a0 db.Channel, _a1 error) *Database_CreateChannel_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_AverageCompletedTime_Call) Return(_a0 uint) *Database_AverageCompletedTime_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateChannel_Call) RunAndReturn(run func(db.Channel) (db.Channel, error)) *Database_CreateChannel_Call {
+func (_c *Database_AverageCompletedTime_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_AverageCompletedTime_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateConnectionCode provides a mock function with given fields: c
-func (_m *Database) CreateConnectionCode(c []db.ConnectionCodes) ([]db.ConnectionCodes, error) {
-	ret := _m.Called(c)
+// AveragePaidTime provides a mock function with given fields: r, workspace
+func (_m *Database) AveragePaidTime(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateConnectionCode")
-	}
-
-	var r0 []db.ConnectionCodes
-	var r1 error
-	if rf, ok := ret.Get(0).(func([]db.ConnectionCodes) ([]db.ConnectionCodes, error)); ok {
-		return rf(c)
-	}
-	if rf, ok := ret.Get(0).(func([]db.ConnectionCodes) []db.ConnectionCodes); ok {
-		r0 = rf(c)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.ConnectionCodes)
-		}
+		panic("no return value specified for AveragePaidTime")
 	}
 
-	if rf, ok := ret.Get(1).(func([]db.ConnectionCodes) error); ok {
-		r1 = rf(c)
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(uint)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateConnectionCode'
-type Database_CreateConnectionCode_Call struct {
+// Database_AveragePaidTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AveragePaidTime'
+type Database_AveragePaidTime_Call struct {
 	*mock.Call
 }
 
-// CreateConnectionCode is a helper method to define mock.On call
-//   - c []db.ConnectionCodes
-func (_e *Database_Expecter) CreateConnectionCode(c interface{}) *Database_CreateConnectionCode_Call {
-	return &Database_CreateConnectionCode_Call{Call: _e.mock.On("CreateConnectionCode", c)}
+// AveragePaidTime is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) AveragePaidTime(r interface{}, workspace interface{}) *Database_AveragePaidTime_Call {
+	return &Database_AveragePaidTime_Call{Call: _e.mock.On("AveragePaidTime", r, workspace)}
 }
 
-func (_c *Database_CreateConnectionCode_Call) Run(run func(c []db.ConnectionCodes)) *Database_CreateConnectionCode_Call {
+func (_c *Database_AveragePaidTime_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_AveragePaidTime_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]db.ConnectionCodes))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateConnectionCode_Call) Return(_a0 []db.ConnectionCodes, _a1 error) *Database_CreateConnectionCode_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_AveragePaidTime_Call) Return(_a0 uint) *Database_AveragePaidTime_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateConnectionCode_Call) RunAndReturn(run func([]db.ConnectionCodes) ([]db.ConnectionCodes, error)) *Database_CreateConnectionCode_Call {
+func (_c *Database_AveragePaidTime_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_AveragePaidTime_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateLeaderBoard provides a mock function with given fields: uuid, leaderboards
-func (_m *Database) CreateLeaderBoard(uuid string, leaderboards []db.LeaderBoard) ([]db.LeaderBoard, error) {
-	ret := _m.Called(uuid, leaderboards)
+// BanTribeMember provides a mock function with given fields: ban
+func (_m *Database) BanTribeMember(ban db.TribeBan) (db.TribeBan, error) {
+	ret := _m.Called(ban)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateLeaderBoard")
+		panic("no return value specified for BanTribeMember")
 	}
 
-	var r0 []db.LeaderBoard
+	var r0 db.TribeBan
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, []db.LeaderBoard) ([]db.LeaderBoard, error)); ok {
-		return rf(uuid, leaderboards)
+	if rf, ok := ret.Get(0).(func(db.TribeBan) (db.TribeBan, error)); ok {
+		return rf(ban)
 	}
-	if rf, ok := ret.Get(0).(func(string, []db.LeaderBoard) []db.LeaderBoard); ok {
-		r0 = rf(uuid, leaderboards)
+	if rf, ok := ret.Get(0).(func(db.TribeBan) db.TribeBan); ok {
+		r0 = rf(ban)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.LeaderBoard)
-		}
+		r0 = ret.Get(0).(db.TribeBan)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, []db.LeaderBoard) error); ok {
-		r1 = rf(uuid, leaderboards)
+	if rf, ok := ret.Get(1).(func(db.TribeBan) error); ok {
+		r1 = rf(ban)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -733,559 +1018,518 @@ func (_m *Database) CreateLeaderBoard(uuid string, leaderboards []db.LeaderBoard
 	return r0, r1
 }
 
-// Database_CreateLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLeaderBoard'
-type Database_CreateLeaderBoard_Call struct {
+// Database_BanTribeMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BanTribeMember'
+type Database_BanTribeMember_Call struct {
 	*mock.Call
 }
 
-// CreateLeaderBoard is a helper method to define mock.On call
-//   - uuid string
-//   - leaderboards []db.LeaderBoard
-func (_e *Database_Expecter) CreateLeaderBoard(uuid interface{}, leaderboards interface{}) *Database_CreateLeaderBoard_Call {
-	return &Database_CreateLeaderBoard_Call{Call: _e.mock.On("CreateLeaderBoard", uuid, leaderboards)}
+// BanTribeMember is a helper method to define mock.On call
+//   - ban db.TribeBan
+func (_e *Database_Expecter) BanTribeMember(ban interface{}) *Database_BanTribeMember_Call {
+	return &Database_BanTribeMember_Call{Call: _e.mock.On("BanTribeMember", ban)}
 }
 
-func (_c *Database_CreateLeaderBoard_Call) Run(run func(uuid string, leaderboards []db.LeaderBoard)) *Database_CreateLeaderBoard_Call {
+func (_c *Database_BanTribeMember_Call) Run(run func(ban db.TribeBan)) *Database_BanTribeMember_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].([]db.LeaderBoard))
+		run(args[0].(db.TribeBan))
 	})
 	return _c
 }
 
-func (_c *Database_CreateLeaderBoard_Call) Return(_a0 []db.LeaderBoard, _a1 error) *Database_CreateLeaderBoard_Call {
+func (_c *Database_BanTribeMember_Call) Return(_a0 db.TribeBan, _a1 error) *Database_BanTribeMember_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CreateLeaderBoard_Call) RunAndReturn(run func(string, []db.LeaderBoard) ([]db.LeaderBoard, error)) *Database_CreateLeaderBoard_Call {
+func (_c *Database_BanTribeMember_Call) RunAndReturn(run func(db.TribeBan) (db.TribeBan, error)) *Database_BanTribeMember_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateLnUser provides a mock function with given fields: lnKey
-func (_m *Database) CreateLnUser(lnKey string) (db.Person, error) {
-	ret := _m.Called(lnKey)
+// BountiesPaidPercentage provides a mock function with given fields: r, workspace
+func (_m *Database) BountiesPaidPercentage(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateLnUser")
-	}
-
-	var r0 db.Person
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (db.Person, error)); ok {
-		return rf(lnKey)
-	}
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(lnKey)
-	} else {
-		r0 = ret.Get(0).(db.Person)
+		panic("no return value specified for BountiesPaidPercentage")
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lnKey)
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(uint)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateLnUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLnUser'
-type Database_CreateLnUser_Call struct {
+// Database_BountiesPaidPercentage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BountiesPaidPercentage'
+type Database_BountiesPaidPercentage_Call struct {
 	*mock.Call
 }
 
-// CreateLnUser is a helper method to define mock.On call
-//   - lnKey string
-func (_e *Database_Expecter) CreateLnUser(lnKey interface{}) *Database_CreateLnUser_Call {
-	return &Database_CreateLnUser_Call{Call: _e.mock.On("CreateLnUser", lnKey)}
+// BountiesPaidPercentage is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) BountiesPaidPercentage(r interface{}, workspace interface{}) *Database_BountiesPaidPercentage_Call {
+	return &Database_BountiesPaidPercentage_Call{Call: _e.mock.On("BountiesPaidPercentage", r, workspace)}
 }
 
-func (_c *Database_CreateLnUser_Call) Run(run func(lnKey string)) *Database_CreateLnUser_Call {
+func (_c *Database_BountiesPaidPercentage_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_BountiesPaidPercentage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateLnUser_Call) Return(_a0 db.Person, _a1 error) *Database_CreateLnUser_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_BountiesPaidPercentage_Call) Return(_a0 uint) *Database_BountiesPaidPercentage_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateLnUser_Call) RunAndReturn(run func(string) (db.Person, error)) *Database_CreateLnUser_Call {
+func (_c *Database_BountiesPaidPercentage_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_BountiesPaidPercentage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditBot provides a mock function with given fields: b
-func (_m *Database) CreateOrEditBot(b db.Bot) (db.Bot, error) {
-	ret := _m.Called(b)
+// ChangeWorkspaceDeleteStatus provides a mock function with given fields: workspace_uuid, status
+func (_m *Database) ChangeWorkspaceDeleteStatus(workspace_uuid string, status bool) db.Workspace {
+	ret := _m.Called(workspace_uuid, status)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditBot")
-	}
-
-	var r0 db.Bot
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Bot) (db.Bot, error)); ok {
-		return rf(b)
-	}
-	if rf, ok := ret.Get(0).(func(db.Bot) db.Bot); ok {
-		r0 = rf(b)
-	} else {
-		r0 = ret.Get(0).(db.Bot)
+		panic("no return value specified for ChangeWorkspaceDeleteStatus")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Bot) error); ok {
-		r1 = rf(b)
+	var r0 db.Workspace
+	if rf, ok := ret.Get(0).(func(string, bool) db.Workspace); ok {
+		r0 = rf(workspace_uuid, status)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBot'
-type Database_CreateOrEditBot_Call struct {
+// Database_ChangeWorkspaceDeleteStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeWorkspaceDeleteStatus'
+type Database_ChangeWorkspaceDeleteStatus_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditBot is a helper method to define mock.On call
-//   - b db.Bot
-func (_e *Database_Expecter) CreateOrEditBot(b interface{}) *Database_CreateOrEditBot_Call {
-	return &Database_CreateOrEditBot_Call{Call: _e.mock.On("CreateOrEditBot", b)}
+// ChangeWorkspaceDeleteStatus is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - status bool
+func (_e *Database_Expecter) ChangeWorkspaceDeleteStatus(workspace_uuid interface{}, status interface{}) *Database_ChangeWorkspaceDeleteStatus_Call {
+	return &Database_ChangeWorkspaceDeleteStatus_Call{Call: _e.mock.On("ChangeWorkspaceDeleteStatus", workspace_uuid, status)}
 }
 
-func (_c *Database_CreateOrEditBot_Call) Run(run func(b db.Bot)) *Database_CreateOrEditBot_Call {
+func (_c *Database_ChangeWorkspaceDeleteStatus_Call) Run(run func(workspace_uuid string, status bool)) *Database_ChangeWorkspaceDeleteStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Bot))
+		run(args[0].(string), args[1].(bool))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditBot_Call) Return(_a0 db.Bot, _a1 error) *Database_CreateOrEditBot_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_ChangeWorkspaceDeleteStatus_Call) Return(_a0 db.Workspace) *Database_ChangeWorkspaceDeleteStatus_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditBot_Call) RunAndReturn(run func(db.Bot) (db.Bot, error)) *Database_CreateOrEditBot_Call {
+func (_c *Database_ChangeWorkspaceDeleteStatus_Call) RunAndReturn(run func(string, bool) db.Workspace) *Database_ChangeWorkspaceDeleteStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditBounty provides a mock function with given fields: b
-func (_m *Database) CreateOrEditBounty(b db.NewBounty) (db.NewBounty, error) {
-	ret := _m.Called(b)
+// CheckSpendingCaps provides a mock function with given fields: workspace_uuid, receiver, amount
+func (_m *Database) CheckSpendingCaps(workspace_uuid string, receiver string, amount uint) error {
+	ret := _m.Called(workspace_uuid, receiver, amount)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditBounty")
+		panic("no return value specified for CheckSpendingCaps")
 	}
 
-	var r0 db.NewBounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.NewBounty) (db.NewBounty, error)); ok {
-		return rf(b)
-	}
-	if rf, ok := ret.Get(0).(func(db.NewBounty) db.NewBounty); ok {
-		r0 = rf(b)
-	} else {
-		r0 = ret.Get(0).(db.NewBounty)
-	}
-
-	if rf, ok := ret.Get(1).(func(db.NewBounty) error); ok {
-		r1 = rf(b)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, uint) error); ok {
+		r0 = rf(workspace_uuid, receiver, amount)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBounty'
-type Database_CreateOrEditBounty_Call struct {
+// Database_CheckSpendingCaps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckSpendingCaps'
+type Database_CheckSpendingCaps_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditBounty is a helper method to define mock.On call
-//   - b db.NewBounty
-func (_e *Database_Expecter) CreateOrEditBounty(b interface{}) *Database_CreateOrEditBounty_Call {
-	return &Database_CreateOrEditBounty_Call{Call: _e.mock.On("CreateOrEditBounty", b)}
+// CheckSpendingCaps is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - receiver string
+//   - amount uint
+func (_e *Database_Expecter) CheckSpendingCaps(workspace_uuid interface{}, receiver interface{}, amount interface{}) *Database_CheckSpendingCaps_Call {
+	return &Database_CheckSpendingCaps_Call{Call: _e.mock.On("CheckSpendingCaps", workspace_uuid, receiver, amount)}
 }
 
-func (_c *Database_CreateOrEditBounty_Call) Run(run func(b db.NewBounty)) *Database_CreateOrEditBounty_Call {
+func (_c *Database_CheckSpendingCaps_Call) Run(run func(workspace_uuid string, receiver string, amount uint)) *Database_CheckSpendingCaps_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewBounty))
+		run(args[0].(string), args[1].(string), args[2].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_CreateOrEditBounty_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_CheckSpendingCaps_Call) Return(_a0 error) *Database_CheckSpendingCaps_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditBounty_Call) RunAndReturn(run func(db.NewBounty) (db.NewBounty, error)) *Database_CreateOrEditBounty_Call {
+func (_c *Database_CheckSpendingCaps_Call) RunAndReturn(run func(string, string, uint) error) *Database_CheckSpendingCaps_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditFeature provides a mock function with given fields: m
-func (_m *Database) CreateOrEditFeature(m db.WorkspaceFeatures) (db.WorkspaceFeatures, error) {
-	ret := _m.Called(m)
+// ClaimImpersonationNotification provides a mock function with given fields: id
+func (_m *Database) ClaimImpersonationNotification(id uint) bool {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditFeature")
-	}
-
-	var r0 db.WorkspaceFeatures
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures) (db.WorkspaceFeatures, error)); ok {
-		return rf(m)
-	}
-	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures) db.WorkspaceFeatures); ok {
-		r0 = rf(m)
-	} else {
-		r0 = ret.Get(0).(db.WorkspaceFeatures)
+		panic("no return value specified for ClaimImpersonationNotification")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.WorkspaceFeatures) error); ok {
-		r1 = rf(m)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint) bool); ok {
+		r0 = rf(id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditFeature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeature'
-type Database_CreateOrEditFeature_Call struct {
+// Database_ClaimImpersonationNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimImpersonationNotification'
+type Database_ClaimImpersonationNotification_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditFeature is a helper method to define mock.On call
-//   - m db.WorkspaceFeatures
-func (_e *Database_Expecter) CreateOrEditFeature(m interface{}) *Database_CreateOrEditFeature_Call {
-	return &Database_CreateOrEditFeature_Call{Call: _e.mock.On("CreateOrEditFeature", m)}
+// ClaimImpersonationNotification is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) ClaimImpersonationNotification(id interface{}) *Database_ClaimImpersonationNotification_Call {
+	return &Database_ClaimImpersonationNotification_Call{Call: _e.mock.On("ClaimImpersonationNotification", id)}
 }
 
-func (_c *Database_CreateOrEditFeature_Call) Run(run func(m db.WorkspaceFeatures)) *Database_CreateOrEditFeature_Call {
+func (_c *Database_ClaimImpersonationNotification_Call) Run(run func(id uint)) *Database_ClaimImpersonationNotification_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.WorkspaceFeatures))
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeature_Call) Return(_a0 db.WorkspaceFeatures, _a1 error) *Database_CreateOrEditFeature_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_ClaimImpersonationNotification_Call) Return(_a0 bool) *Database_ClaimImpersonationNotification_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeature_Call) RunAndReturn(run func(db.WorkspaceFeatures) (db.WorkspaceFeatures, error)) *Database_CreateOrEditFeature_Call {
+func (_c *Database_ClaimImpersonationNotification_Call) RunAndReturn(run func(uint) bool) *Database_ClaimImpersonationNotification_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditFeaturePhase provides a mock function with given fields: phase
-func (_m *Database) CreateOrEditFeaturePhase(phase db.FeaturePhase) (db.FeaturePhase, error) {
-	ret := _m.Called(phase)
+// ClaimInvoice provides a mock function with given fields: payment_request
+func (_m *Database) ClaimInvoice(payment_request string) error {
+	ret := _m.Called(payment_request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditFeaturePhase")
-	}
-
-	var r0 db.FeaturePhase
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.FeaturePhase) (db.FeaturePhase, error)); ok {
-		return rf(phase)
-	}
-	if rf, ok := ret.Get(0).(func(db.FeaturePhase) db.FeaturePhase); ok {
-		r0 = rf(phase)
-	} else {
-		r0 = ret.Get(0).(db.FeaturePhase)
+		panic("no return value specified for ClaimInvoice")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.FeaturePhase) error); ok {
-		r1 = rf(phase)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(payment_request)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditFeaturePhase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeaturePhase'
-type Database_CreateOrEditFeaturePhase_Call struct {
+// Database_ClaimInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimInvoice'
+type Database_ClaimInvoice_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditFeaturePhase is a helper method to define mock.On call
-//   - phase db.FeaturePhase
-func (_e *Database_Expecter) CreateOrEditFeaturePhase(phase interface{}) *Database_CreateOrEditFeaturePhase_Call {
-	return &Database_CreateOrEditFeaturePhase_Call{Call: _e.mock.On("CreateOrEditFeaturePhase", phase)}
+// ClaimInvoice is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) ClaimInvoice(payment_request interface{}) *Database_ClaimInvoice_Call {
+	return &Database_ClaimInvoice_Call{Call: _e.mock.On("ClaimInvoice", payment_request)}
 }
 
-func (_c *Database_CreateOrEditFeaturePhase_Call) Run(run func(phase db.FeaturePhase)) *Database_CreateOrEditFeaturePhase_Call {
+func (_c *Database_ClaimInvoice_Call) Run(run func(payment_request string)) *Database_ClaimInvoice_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.FeaturePhase))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeaturePhase_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_CreateOrEditFeaturePhase_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_ClaimInvoice_Call) Return(_a0 error) *Database_ClaimInvoice_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeaturePhase_Call) RunAndReturn(run func(db.FeaturePhase) (db.FeaturePhase, error)) *Database_CreateOrEditFeaturePhase_Call {
+func (_c *Database_ClaimInvoice_Call) RunAndReturn(run func(string) error) *Database_ClaimInvoice_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditFeatureStory provides a mock function with given fields: story
-func (_m *Database) CreateOrEditFeatureStory(story db.FeatureStory) (db.FeatureStory, error) {
-	ret := _m.Called(story)
+// ClaimMediaJob provides a mock function with given fields: id
+func (_m *Database) ClaimMediaJob(id uint) bool {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditFeatureStory")
-	}
-
-	var r0 db.FeatureStory
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.FeatureStory) (db.FeatureStory, error)); ok {
-		return rf(story)
-	}
-	if rf, ok := ret.Get(0).(func(db.FeatureStory) db.FeatureStory); ok {
-		r0 = rf(story)
-	} else {
-		r0 = ret.Get(0).(db.FeatureStory)
+		panic("no return value specified for ClaimMediaJob")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.FeatureStory) error); ok {
-		r1 = rf(story)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint) bool); ok {
+		r0 = rf(id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditFeatureStory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeatureStory'
-type Database_CreateOrEditFeatureStory_Call struct {
+// Database_ClaimMediaJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimMediaJob'
+type Database_ClaimMediaJob_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditFeatureStory is a helper method to define mock.On call
-//   - story db.FeatureStory
-func (_e *Database_Expecter) CreateOrEditFeatureStory(story interface{}) *Database_CreateOrEditFeatureStory_Call {
-	return &Database_CreateOrEditFeatureStory_Call{Call: _e.mock.On("CreateOrEditFeatureStory", story)}
+// ClaimMediaJob is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) ClaimMediaJob(id interface{}) *Database_ClaimMediaJob_Call {
+	return &Database_ClaimMediaJob_Call{Call: _e.mock.On("ClaimMediaJob", id)}
 }
 
-func (_c *Database_CreateOrEditFeatureStory_Call) Run(run func(story db.FeatureStory)) *Database_CreateOrEditFeatureStory_Call {
+func (_c *Database_ClaimMediaJob_Call) Run(run func(id uint)) *Database_ClaimMediaJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.FeatureStory))
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeatureStory_Call) Return(_a0 db.FeatureStory, _a1 error) *Database_CreateOrEditFeatureStory_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_ClaimMediaJob_Call) Return(_a0 bool) *Database_ClaimMediaJob_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeatureStory_Call) RunAndReturn(run func(db.FeatureStory) (db.FeatureStory, error)) *Database_CreateOrEditFeatureStory_Call {
+func (_c *Database_ClaimMediaJob_Call) RunAndReturn(run func(uint) bool) *Database_ClaimMediaJob_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditPerson provides a mock function with given fields: m
-func (_m *Database) CreateOrEditPerson(m db.Person) (db.Person, error) {
-	ret := _m.Called(m)
+// ClaimPaymentRetry provides a mock function with given fields: id
+func (_m *Database) ClaimPaymentRetry(id uint) bool {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditPerson")
-	}
-
-	var r0 db.Person
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Person) (db.Person, error)); ok {
-		return rf(m)
-	}
-	if rf, ok := ret.Get(0).(func(db.Person) db.Person); ok {
-		r0 = rf(m)
-	} else {
-		r0 = ret.Get(0).(db.Person)
+		panic("no return value specified for ClaimPaymentRetry")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Person) error); ok {
-		r1 = rf(m)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint) bool); ok {
+		r0 = rf(id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditPerson'
-type Database_CreateOrEditPerson_Call struct {
+// Database_ClaimPaymentRetry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimPaymentRetry'
+type Database_ClaimPaymentRetry_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditPerson is a helper method to define mock.On call
-//   - m db.Person
-func (_e *Database_Expecter) CreateOrEditPerson(m interface{}) *Database_CreateOrEditPerson_Call {
-	return &Database_CreateOrEditPerson_Call{Call: _e.mock.On("CreateOrEditPerson", m)}
+// ClaimPaymentRetry is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) ClaimPaymentRetry(id interface{}) *Database_ClaimPaymentRetry_Call {
+	return &Database_ClaimPaymentRetry_Call{Call: _e.mock.On("ClaimPaymentRetry", id)}
 }
 
-func (_c *Database_CreateOrEditPerson_Call) Run(run func(m db.Person)) *Database_CreateOrEditPerson_Call {
+func (_c *Database_ClaimPaymentRetry_Call) Run(run func(id uint)) *Database_ClaimPaymentRetry_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Person))
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditPerson_Call) Return(_a0 db.Person, _a1 error) *Database_CreateOrEditPerson_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_ClaimPaymentRetry_Call) Return(_a0 bool) *Database_ClaimPaymentRetry_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditPerson_Call) RunAndReturn(run func(db.Person) (db.Person, error)) *Database_CreateOrEditPerson_Call {
+func (_c *Database_ClaimPaymentRetry_Call) RunAndReturn(run func(uint) bool) *Database_ClaimPaymentRetry_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditTribe provides a mock function with given fields: m
-func (_m *Database) CreateOrEditTribe(m db.Tribe) (db.Tribe, error) {
-	ret := _m.Called(m)
+// ClaimWebhookDelivery provides a mock function with given fields: id
+func (_m *Database) ClaimWebhookDelivery(id uint) bool {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditTribe")
-	}
-
-	var r0 db.Tribe
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Tribe) (db.Tribe, error)); ok {
-		return rf(m)
-	}
-	if rf, ok := ret.Get(0).(func(db.Tribe) db.Tribe); ok {
-		r0 = rf(m)
-	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		panic("no return value specified for ClaimWebhookDelivery")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Tribe) error); ok {
-		r1 = rf(m)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint) bool); ok {
+		r0 = rf(id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditTribe'
-type Database_CreateOrEditTribe_Call struct {
+// Database_ClaimWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimWebhookDelivery'
+type Database_ClaimWebhookDelivery_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditTribe is a helper method to define mock.On call
-//   - m db.Tribe
-func (_e *Database_Expecter) CreateOrEditTribe(m interface{}) *Database_CreateOrEditTribe_Call {
-	return &Database_CreateOrEditTribe_Call{Call: _e.mock.On("CreateOrEditTribe", m)}
+// ClaimWebhookDelivery is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) ClaimWebhookDelivery(id interface{}) *Database_ClaimWebhookDelivery_Call {
+	return &Database_ClaimWebhookDelivery_Call{Call: _e.mock.On("ClaimWebhookDelivery", id)}
 }
 
-func (_c *Database_CreateOrEditTribe_Call) Run(run func(m db.Tribe)) *Database_CreateOrEditTribe_Call {
+func (_c *Database_ClaimWebhookDelivery_Call) Run(run func(id uint)) *Database_ClaimWebhookDelivery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Tribe))
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditTribe_Call) Return(_a0 db.Tribe, _a1 error) *Database_CreateOrEditTribe_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_ClaimWebhookDelivery_Call) Return(_a0 bool) *Database_ClaimWebhookDelivery_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditTribe_Call) RunAndReturn(run func(db.Tribe) (db.Tribe, error)) *Database_CreateOrEditTribe_Call {
+func (_c *Database_ClaimWebhookDelivery_Call) RunAndReturn(run func(uint) bool) *Database_ClaimWebhookDelivery_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditWorkspace provides a mock function with given fields: m
-func (_m *Database) CreateOrEditWorkspace(m db.Workspace) (db.Workspace, error) {
-	ret := _m.Called(m)
+// ClaimWorkflowExecution provides a mock function with given fields: id
+func (_m *Database) ClaimWorkflowExecution(id uint) bool {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditWorkspace")
+		panic("no return value specified for ClaimWorkflowExecution")
 	}
 
-	var r0 db.Workspace
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Workspace) (db.Workspace, error)); ok {
-		return rf(m)
-	}
-	if rf, ok := ret.Get(0).(func(db.Workspace) db.Workspace); ok {
-		r0 = rf(m)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint) bool); ok {
+		r0 = rf(id)
 	} else {
-		r0 = ret.Get(0).(db.Workspace)
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Workspace) error); ok {
-		r1 = rf(m)
+	return r0
+}
+
+// Database_ClaimWorkflowExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimWorkflowExecution'
+type Database_ClaimWorkflowExecution_Call struct {
+	*mock.Call
+}
+
+// ClaimWorkflowExecution is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) ClaimWorkflowExecution(id interface{}) *Database_ClaimWorkflowExecution_Call {
+	return &Database_ClaimWorkflowExecution_Call{Call: _e.mock.On("ClaimWorkflowExecution", id)}
+}
+
+func (_c *Database_ClaimWorkflowExecution_Call) Run(run func(id uint)) *Database_ClaimWorkflowExecution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_ClaimWorkflowExecution_Call) Return(_a0 bool) *Database_ClaimWorkflowExecution_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_ClaimWorkflowExecution_Call) RunAndReturn(run func(uint) bool) *Database_ClaimWorkflowExecution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CloseBounty provides a mock function with given fields: created
+func (_m *Database) CloseBounty(created int64) error {
+	ret := _m.Called(created)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseBounty")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(created)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspace'
-type Database_CreateOrEditWorkspace_Call struct {
+// Database_CloseBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseBounty'
+type Database_CloseBounty_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditWorkspace is a helper method to define mock.On call
-//   - m db.Workspace
-func (_e *Database_Expecter) CreateOrEditWorkspace(m interface{}) *Database_CreateOrEditWorkspace_Call {
-	return &Database_CreateOrEditWorkspace_Call{Call: _e.mock.On("CreateOrEditWorkspace", m)}
+// CloseBounty is a helper method to define mock.On call
+//   - created int64
+func (_e *Database_Expecter) CloseBounty(created interface{}) *Database_CloseBounty_Call {
+	return &Database_CloseBounty_Call{Call: _e.mock.On("CloseBounty", created)}
 }
 
-func (_c *Database_CreateOrEditWorkspace_Call) Run(run func(m db.Workspace)) *Database_CreateOrEditWorkspace_Call {
+func (_c *Database_CloseBounty_Call) Run(run func(created int64)) *Database_CloseBounty_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Workspace))
+		run(args[0].(int64))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditWorkspace_Call) Return(_a0 db.Workspace, _a1 error) *Database_CreateOrEditWorkspace_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_CloseBounty_Call) Return(_a0 error) *Database_CloseBounty_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditWorkspace_Call) RunAndReturn(run func(db.Workspace) (db.Workspace, error)) *Database_CreateOrEditWorkspace_Call {
+func (_c *Database_CloseBounty_Call) RunAndReturn(run func(int64) error) *Database_CloseBounty_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditWorkspaceRepository provides a mock function with given fields: m
-func (_m *Database) CreateOrEditWorkspaceRepository(m db.WorkspaceRepositories) (db.WorkspaceRepositories, error) {
+// CloseDispute provides a mock function with given fields: m
+func (_m *Database) CloseDispute(m db.BountyDispute) (db.BountyDispute, error) {
 	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditWorkspaceRepository")
+		panic("no return value specified for CloseDispute")
 	}
 
-	var r0 db.WorkspaceRepositories
+	var r0 db.BountyDispute
 	var r1 error
-	if rf, ok := ret.Get(0).(func(db.WorkspaceRepositories) (db.WorkspaceRepositories, error)); ok {
+	if rf, ok := ret.Get(0).(func(db.BountyDispute) (db.BountyDispute, error)); ok {
 		return rf(m)
 	}
-	if rf, ok := ret.Get(0).(func(db.WorkspaceRepositories) db.WorkspaceRepositories); ok {
+	if rf, ok := ret.Get(0).(func(db.BountyDispute) db.BountyDispute); ok {
 		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceRepositories)
+		r0 = ret.Get(0).(db.BountyDispute)
 	}
 
-	if rf, ok := ret.Get(1).(func(db.WorkspaceRepositories) error); ok {
+	if rf, ok := ret.Get(1).(func(db.BountyDispute) error); ok {
 		r1 = rf(m)
 	} else {
 		r1 = ret.Error(1)
@@ -1294,771 +1538,838 @@ func (_m *Database) CreateOrEditWorkspaceRepository(m db.WorkspaceRepositories)
 	return r0, r1
 }
 
-// Database_CreateOrEditWorkspaceRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspaceRepository'
-type Database_CreateOrEditWorkspaceRepository_Call struct {
+// Database_CloseDispute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseDispute'
+type Database_CloseDispute_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditWorkspaceRepository is a helper method to define mock.On call
-//   - m db.WorkspaceRepositories
-func (_e *Database_Expecter) CreateOrEditWorkspaceRepository(m interface{}) *Database_CreateOrEditWorkspaceRepository_Call {
-	return &Database_CreateOrEditWorkspaceRepository_Call{Call: _e.mock.On("CreateOrEditWorkspaceRepository", m)}
+// CloseDispute is a helper method to define mock.On call
+//   - m db.BountyDispute
+func (_e *Database_Expecter) CloseDispute(m interface{}) *Database_CloseDispute_Call {
+	return &Database_CloseDispute_Call{Call: _e.mock.On("CloseDispute", m)}
 }
 
-func (_c *Database_CreateOrEditWorkspaceRepository_Call) Run(run func(m db.WorkspaceRepositories)) *Database_CreateOrEditWorkspaceRepository_Call {
+func (_c *Database_CloseDispute_Call) Run(run func(m db.BountyDispute)) *Database_CloseDispute_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.WorkspaceRepositories))
+		run(args[0].(db.BountyDispute))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditWorkspaceRepository_Call) Return(_a0 db.WorkspaceRepositories, _a1 error) *Database_CreateOrEditWorkspaceRepository_Call {
+func (_c *Database_CloseDispute_Call) Return(_a0 db.BountyDispute, _a1 error) *Database_CloseDispute_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CreateOrEditWorkspaceRepository_Call) RunAndReturn(run func(db.WorkspaceRepositories) (db.WorkspaceRepositories, error)) *Database_CreateOrEditWorkspaceRepository_Call {
+func (_c *Database_CloseDispute_Call) RunAndReturn(run func(db.BountyDispute) (db.BountyDispute, error)) *Database_CloseDispute_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateUserRoles provides a mock function with given fields: roles, uuid, pubkey
-func (_m *Database) CreateUserRoles(roles []db.WorkspaceUserRoles, uuid string, pubkey string) []db.WorkspaceUserRoles {
-	ret := _m.Called(roles, uuid, pubkey)
+// CompleteBountyFromPullRequest provides a mock function with given fields: bountyId, pullRequest
+func (_m *Database) CompleteBountyFromPullRequest(bountyId uint, pullRequest db.RepositoryPullRequest) (db.NewBounty, bool, error) {
+	ret := _m.Called(bountyId, pullRequest)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUserRoles")
+		panic("no return value specified for CompleteBountyFromPullRequest")
 	}
 
-	var r0 []db.WorkspaceUserRoles
-	if rf, ok := ret.Get(0).(func([]db.WorkspaceUserRoles, string, string) []db.WorkspaceUserRoles); ok {
-		r0 = rf(roles, uuid, pubkey)
+	var r0 db.NewBounty
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, db.RepositoryPullRequest) (db.NewBounty, bool, error)); ok {
+		return rf(bountyId, pullRequest)
+	}
+	if rf, ok := ret.Get(0).(func(uint, db.RepositoryPullRequest) db.NewBounty); ok {
+		r0 = rf(bountyId, pullRequest)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceUserRoles)
-		}
+		r0 = ret.Get(0).(db.NewBounty)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(uint, db.RepositoryPullRequest) bool); ok {
+		r1 = rf(bountyId, pullRequest)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint, db.RepositoryPullRequest) error); ok {
+		r2 = rf(bountyId, pullRequest)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// Database_CreateUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUserRoles'
-type Database_CreateUserRoles_Call struct {
+// Database_CompleteBountyFromPullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteBountyFromPullRequest'
+type Database_CompleteBountyFromPullRequest_Call struct {
 	*mock.Call
 }
 
-// CreateUserRoles is a helper method to define mock.On call
-//   - roles []db.WorkspaceUserRoles
-//   - uuid string
-//   - pubkey string
-func (_e *Database_Expecter) CreateUserRoles(roles interface{}, uuid interface{}, pubkey interface{}) *Database_CreateUserRoles_Call {
-	return &Database_CreateUserRoles_Call{Call: _e.mock.On("CreateUserRoles", roles, uuid, pubkey)}
+// CompleteBountyFromPullRequest is a helper method to define mock.On call
+//   - bountyId uint
+//   - pullRequest db.RepositoryPullRequest
+func (_e *Database_Expecter) CompleteBountyFromPullRequest(bountyId interface{}, pullRequest interface{}) *Database_CompleteBountyFromPullRequest_Call {
+	return &Database_CompleteBountyFromPullRequest_Call{Call: _e.mock.On("CompleteBountyFromPullRequest", bountyId, pullRequest)}
 }
 
-func (_c *Database_CreateUserRoles_Call) Run(run func(roles []db.WorkspaceUserRoles, uuid string, pubkey string)) *Database_CreateUserRoles_Call {
+func (_c *Database_CompleteBountyFromPullRequest_Call) Run(run func(bountyId uint, pullRequest db.RepositoryPullRequest)) *Database_CompleteBountyFromPullRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]db.WorkspaceUserRoles), args[1].(string), args[2].(string))
+		run(args[0].(uint), args[1].(db.RepositoryPullRequest))
 	})
 	return _c
 }
 
-func (_c *Database_CreateUserRoles_Call) Return(_a0 []db.WorkspaceUserRoles) *Database_CreateUserRoles_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CompleteBountyFromPullRequest_Call) Return(_a0 db.NewBounty, _a1 bool, _a2 error) *Database_CompleteBountyFromPullRequest_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *Database_CreateUserRoles_Call) RunAndReturn(run func([]db.WorkspaceUserRoles, string, string) []db.WorkspaceUserRoles) *Database_CreateUserRoles_Call {
+func (_c *Database_CompleteBountyFromPullRequest_Call) RunAndReturn(run func(uint, db.RepositoryPullRequest) (db.NewBounty, bool, error)) *Database_CompleteBountyFromPullRequest_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateWorkspaceBudget provides a mock function with given fields: budget
-func (_m *Database) CreateWorkspaceBudget(budget db.NewBountyBudget) db.NewBountyBudget {
-	ret := _m.Called(budget)
+// ConnectionCodeExists provides a mock function with given fields: code
+func (_m *Database) ConnectionCodeExists(code string) bool {
+	ret := _m.Called(code)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateWorkspaceBudget")
+		panic("no return value specified for ConnectionCodeExists")
 	}
 
-	var r0 db.NewBountyBudget
-	if rf, ok := ret.Get(0).(func(db.NewBountyBudget) db.NewBountyBudget); ok {
-		r0 = rf(budget)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(code)
 	} else {
-		r0 = ret.Get(0).(db.NewBountyBudget)
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// Database_CreateWorkspaceBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceBudget'
-type Database_CreateWorkspaceBudget_Call struct {
+// Database_ConnectionCodeExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConnectionCodeExists'
+type Database_ConnectionCodeExists_Call struct {
 	*mock.Call
 }
 
-// CreateWorkspaceBudget is a helper method to define mock.On call
-//   - budget db.NewBountyBudget
-func (_e *Database_Expecter) CreateWorkspaceBudget(budget interface{}) *Database_CreateWorkspaceBudget_Call {
-	return &Database_CreateWorkspaceBudget_Call{Call: _e.mock.On("CreateWorkspaceBudget", budget)}
+// ConnectionCodeExists is a helper method to define mock.On call
+//   - code string
+func (_e *Database_Expecter) ConnectionCodeExists(code interface{}) *Database_ConnectionCodeExists_Call {
+	return &Database_ConnectionCodeExists_Call{Call: _e.mock.On("ConnectionCodeExists", code)}
 }
 
-func (_c *Database_CreateWorkspaceBudget_Call) Run(run func(budget db.NewBountyBudget)) *Database_CreateWorkspaceBudget_Call {
+func (_c *Database_ConnectionCodeExists_Call) Run(run func(code string)) *Database_ConnectionCodeExists_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewBountyBudget))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateWorkspaceBudget_Call) Return(_a0 db.NewBountyBudget) *Database_CreateWorkspaceBudget_Call {
+func (_c *Database_ConnectionCodeExists_Call) Return(_a0 bool) *Database_ConnectionCodeExists_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateWorkspaceBudget_Call) RunAndReturn(run func(db.NewBountyBudget) db.NewBountyBudget) *Database_CreateWorkspaceBudget_Call {
+func (_c *Database_ConnectionCodeExists_Call) RunAndReturn(run func(string) bool) *Database_ConnectionCodeExists_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateWorkspaceUser provides a mock function with given fields: orgUser
-func (_m *Database) CreateWorkspaceUser(orgUser db.WorkspaceUsers) db.WorkspaceUsers {
-	ret := _m.Called(orgUser)
+// CountAttachments provides a mock function with given fields: parentType, parentId
+func (_m *Database) CountAttachments(parentType db.AttachmentParent, parentId string) int64 {
+	ret := _m.Called(parentType, parentId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateWorkspaceUser")
+		panic("no return value specified for CountAttachments")
 	}
 
-	var r0 db.WorkspaceUsers
-	if rf, ok := ret.Get(0).(func(db.WorkspaceUsers) db.WorkspaceUsers); ok {
-		r0 = rf(orgUser)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(db.AttachmentParent, string) int64); ok {
+		r0 = rf(parentType, parentId)
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceUsers)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_CreateWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceUser'
-type Database_CreateWorkspaceUser_Call struct {
+// Database_CountAttachments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountAttachments'
+type Database_CountAttachments_Call struct {
 	*mock.Call
 }
 
-// CreateWorkspaceUser is a helper method to define mock.On call
-//   - orgUser db.WorkspaceUsers
-func (_e *Database_Expecter) CreateWorkspaceUser(orgUser interface{}) *Database_CreateWorkspaceUser_Call {
-	return &Database_CreateWorkspaceUser_Call{Call: _e.mock.On("CreateWorkspaceUser", orgUser)}
+// CountAttachments is a helper method to define mock.On call
+//   - parentType db.AttachmentParent
+//   - parentId string
+func (_e *Database_Expecter) CountAttachments(parentType interface{}, parentId interface{}) *Database_CountAttachments_Call {
+	return &Database_CountAttachments_Call{Call: _e.mock.On("CountAttachments", parentType, parentId)}
 }
 
-func (_c *Database_CreateWorkspaceUser_Call) Run(run func(orgUser db.WorkspaceUsers)) *Database_CreateWorkspaceUser_Call {
+func (_c *Database_CountAttachments_Call) Run(run func(parentType db.AttachmentParent, parentId string)) *Database_CountAttachments_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.WorkspaceUsers))
+		run(args[0].(db.AttachmentParent), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateWorkspaceUser_Call) Return(_a0 db.WorkspaceUsers) *Database_CreateWorkspaceUser_Call {
+func (_c *Database_CountAttachments_Call) Return(_a0 int64) *Database_CountAttachments_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateWorkspaceUser_Call) RunAndReturn(run func(db.WorkspaceUsers) db.WorkspaceUsers) *Database_CreateWorkspaceUser_Call {
+func (_c *Database_CountAttachments_Call) RunAndReturn(run func(db.AttachmentParent, string) int64) *Database_CountAttachments_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteAllUsersFromWorkspace provides a mock function with given fields: uuid
-func (_m *Database) DeleteAllUsersFromWorkspace(uuid string) error {
-	ret := _m.Called(uuid)
+// CountBounties provides a mock function with given fields:
+func (_m *Database) CountBounties() uint64 {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAllUsersFromWorkspace")
+		panic("no return value specified for CountBounties")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(uuid)
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(uint64)
 	}
 
 	return r0
 }
 
-// Database_DeleteAllUsersFromWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAllUsersFromWorkspace'
-type Database_DeleteAllUsersFromWorkspace_Call struct {
+// Database_CountBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBounties'
+type Database_CountBounties_Call struct {
 	*mock.Call
 }
 
-// DeleteAllUsersFromWorkspace is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) DeleteAllUsersFromWorkspace(uuid interface{}) *Database_DeleteAllUsersFromWorkspace_Call {
-	return &Database_DeleteAllUsersFromWorkspace_Call{Call: _e.mock.On("DeleteAllUsersFromWorkspace", uuid)}
+// CountBounties is a helper method to define mock.On call
+func (_e *Database_Expecter) CountBounties() *Database_CountBounties_Call {
+	return &Database_CountBounties_Call{Call: _e.mock.On("CountBounties")}
 }
 
-func (_c *Database_DeleteAllUsersFromWorkspace_Call) Run(run func(uuid string)) *Database_DeleteAllUsersFromWorkspace_Call {
+func (_c *Database_CountBounties_Call) Run(run func()) *Database_CountBounties_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_DeleteAllUsersFromWorkspace_Call) Return(_a0 error) *Database_DeleteAllUsersFromWorkspace_Call {
+func (_c *Database_CountBounties_Call) Return(_a0 uint64) *Database_CountBounties_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteAllUsersFromWorkspace_Call) RunAndReturn(run func(string) error) *Database_DeleteAllUsersFromWorkspace_Call {
+func (_c *Database_CountBounties_Call) RunAndReturn(run func() uint64) *Database_CountBounties_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteBounty provides a mock function with given fields: pubkey, created
-func (_m *Database) DeleteBounty(pubkey string, created string) (db.NewBounty, error) {
-	ret := _m.Called(pubkey, created)
+// CountConnectionCodeRedemptions provides a mock function with given fields: ip, device, since
+func (_m *Database) CountConnectionCodeRedemptions(ip string, device string, since time.Time) (int64, int64) {
+	ret := _m.Called(ip, device, since)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteBounty")
+		panic("no return value specified for CountConnectionCodeRedemptions")
 	}
 
-	var r0 db.NewBounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (db.NewBounty, error)); ok {
-		return rf(pubkey, created)
+	var r0 int64
+	var r1 int64
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) (int64, int64)); ok {
+		return rf(ip, device, since)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) db.NewBounty); ok {
-		r0 = rf(pubkey, created)
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) int64); ok {
+		r0 = rf(ip, device, since)
 	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(pubkey, created)
+	if rf, ok := ret.Get(1).(func(string, string, time.Time) int64); ok {
+		r1 = rf(ip, device, since)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
 
 	return r0, r1
 }
 
-// Database_DeleteBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBounty'
-type Database_DeleteBounty_Call struct {
+// Database_CountConnectionCodeRedemptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountConnectionCodeRedemptions'
+type Database_CountConnectionCodeRedemptions_Call struct {
 	*mock.Call
 }
 
-// DeleteBounty is a helper method to define mock.On call
-//   - pubkey string
-//   - created string
-func (_e *Database_Expecter) DeleteBounty(pubkey interface{}, created interface{}) *Database_DeleteBounty_Call {
-	return &Database_DeleteBounty_Call{Call: _e.mock.On("DeleteBounty", pubkey, created)}
+// CountConnectionCodeRedemptions is a helper method to define mock.On call
+//   - ip string
+//   - device string
+//   - since time.Time
+func (_e *Database_Expecter) CountConnectionCodeRedemptions(ip interface{}, device interface{}, since interface{}) *Database_CountConnectionCodeRedemptions_Call {
+	return &Database_CountConnectionCodeRedemptions_Call{Call: _e.mock.On("CountConnectionCodeRedemptions", ip, device, since)}
 }
 
-func (_c *Database_DeleteBounty_Call) Run(run func(pubkey string, created string)) *Database_DeleteBounty_Call {
+func (_c *Database_CountConnectionCodeRedemptions_Call) Run(run func(ip string, device string, since time.Time)) *Database_CountConnectionCodeRedemptions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(string), args[2].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_DeleteBounty_Call {
+func (_c *Database_CountConnectionCodeRedemptions_Call) Return(_a0 int64, _a1 int64) *Database_CountConnectionCodeRedemptions_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_DeleteBounty_Call) RunAndReturn(run func(string, string) (db.NewBounty, error)) *Database_DeleteBounty_Call {
+func (_c *Database_CountConnectionCodeRedemptions_Call) RunAndReturn(run func(string, string, time.Time) (int64, int64)) *Database_CountConnectionCodeRedemptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteFeatureByUuid provides a mock function with given fields: uuid
-func (_m *Database) DeleteFeatureByUuid(uuid string) error {
-	ret := _m.Called(uuid)
+// CountDevelopers provides a mock function with given fields:
+func (_m *Database) CountDevelopers() int64 {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFeatureByUuid")
+		panic("no return value specified for CountDevelopers")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(uuid)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_DeleteFeatureByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeatureByUuid'
-type Database_DeleteFeatureByUuid_Call struct {
+// Database_CountDevelopers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDevelopers'
+type Database_CountDevelopers_Call struct {
 	*mock.Call
 }
 
-// DeleteFeatureByUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) DeleteFeatureByUuid(uuid interface{}) *Database_DeleteFeatureByUuid_Call {
-	return &Database_DeleteFeatureByUuid_Call{Call: _e.mock.On("DeleteFeatureByUuid", uuid)}
+// CountDevelopers is a helper method to define mock.On call
+func (_e *Database_Expecter) CountDevelopers() *Database_CountDevelopers_Call {
+	return &Database_CountDevelopers_Call{Call: _e.mock.On("CountDevelopers")}
 }
 
-func (_c *Database_DeleteFeatureByUuid_Call) Run(run func(uuid string)) *Database_DeleteFeatureByUuid_Call {
+func (_c *Database_CountDevelopers_Call) Run(run func()) *Database_CountDevelopers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_DeleteFeatureByUuid_Call) Return(_a0 error) *Database_DeleteFeatureByUuid_Call {
+func (_c *Database_CountDevelopers_Call) Return(_a0 int64) *Database_CountDevelopers_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteFeatureByUuid_Call) RunAndReturn(run func(string) error) *Database_DeleteFeatureByUuid_Call {
+func (_c *Database_CountDevelopers_Call) RunAndReturn(run func() int64) *Database_CountDevelopers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteFeaturePhase provides a mock function with given fields: featureUuid, phaseUuid
-func (_m *Database) DeleteFeaturePhase(featureUuid string, phaseUuid string) error {
-	ret := _m.Called(featureUuid, phaseUuid)
+// CountListingsCreated provides a mock function with given fields: targetType, pubkey, since
+func (_m *Database) CountListingsCreated(targetType db.ReportTargetType, pubkey string, since time.Time) int64 {
+	ret := _m.Called(targetType, pubkey, since)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFeaturePhase")
+		panic("no return value specified for CountListingsCreated")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(featureUuid, phaseUuid)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(db.ReportTargetType, string, time.Time) int64); ok {
+		r0 = rf(targetType, pubkey, since)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_DeleteFeaturePhase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeaturePhase'
-type Database_DeleteFeaturePhase_Call struct {
+// Database_CountListingsCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountListingsCreated'
+type Database_CountListingsCreated_Call struct {
 	*mock.Call
 }
 
-// DeleteFeaturePhase is a helper method to define mock.On call
-//   - featureUuid string
-//   - phaseUuid string
-func (_e *Database_Expecter) DeleteFeaturePhase(featureUuid interface{}, phaseUuid interface{}) *Database_DeleteFeaturePhase_Call {
-	return &Database_DeleteFeaturePhase_Call{Call: _e.mock.On("DeleteFeaturePhase", featureUuid, phaseUuid)}
+// CountListingsCreated is a helper method to define mock.On call
+//   - targetType db.ReportTargetType
+//   - pubkey string
+//   - since time.Time
+func (_e *Database_Expecter) CountListingsCreated(targetType interface{}, pubkey interface{}, since interface{}) *Database_CountListingsCreated_Call {
+	return &Database_CountListingsCreated_Call{Call: _e.mock.On("CountListingsCreated", targetType, pubkey, since)}
 }
 
-func (_c *Database_DeleteFeaturePhase_Call) Run(run func(featureUuid string, phaseUuid string)) *Database_DeleteFeaturePhase_Call {
+func (_c *Database_CountListingsCreated_Call) Run(run func(targetType db.ReportTargetType, pubkey string, since time.Time)) *Database_CountListingsCreated_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(db.ReportTargetType), args[1].(string), args[2].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteFeaturePhase_Call) Return(_a0 error) *Database_DeleteFeaturePhase_Call {
+func (_c *Database_CountListingsCreated_Call) Return(_a0 int64) *Database_CountListingsCreated_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteFeaturePhase_Call) RunAndReturn(run func(string, string) error) *Database_DeleteFeaturePhase_Call {
+func (_c *Database_CountListingsCreated_Call) RunAndReturn(run func(db.ReportTargetType, string, time.Time) int64) *Database_CountListingsCreated_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteFeatureStoryByUuid provides a mock function with given fields: featureUuid, storyUuid
-func (_m *Database) DeleteFeatureStoryByUuid(featureUuid string, storyUuid string) error {
-	ret := _m.Called(featureUuid, storyUuid)
+// CountSearchDocuments provides a mock function with given fields: entityType
+func (_m *Database) CountSearchDocuments(entityType string) int64 {
+	ret := _m.Called(entityType)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFeatureStoryByUuid")
+		panic("no return value specified for CountSearchDocuments")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(featureUuid, storyUuid)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(entityType)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_DeleteFeatureStoryByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeatureStoryByUuid'
-type Database_DeleteFeatureStoryByUuid_Call struct {
+// Database_CountSearchDocuments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountSearchDocuments'
+type Database_CountSearchDocuments_Call struct {
 	*mock.Call
 }
 
-// DeleteFeatureStoryByUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - storyUuid string
-func (_e *Database_Expecter) DeleteFeatureStoryByUuid(featureUuid interface{}, storyUuid interface{}) *Database_DeleteFeatureStoryByUuid_Call {
-	return &Database_DeleteFeatureStoryByUuid_Call{Call: _e.mock.On("DeleteFeatureStoryByUuid", featureUuid, storyUuid)}
+// CountSearchDocuments is a helper method to define mock.On call
+//   - entityType string
+func (_e *Database_Expecter) CountSearchDocuments(entityType interface{}) *Database_CountSearchDocuments_Call {
+	return &Database_CountSearchDocuments_Call{Call: _e.mock.On("CountSearchDocuments", entityType)}
 }
 
-func (_c *Database_DeleteFeatureStoryByUuid_Call) Run(run func(featureUuid string, storyUuid string)) *Database_DeleteFeatureStoryByUuid_Call {
+func (_c *Database_CountSearchDocuments_Call) Run(run func(entityType string)) *Database_CountSearchDocuments_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteFeatureStoryByUuid_Call) Return(_a0 error) *Database_DeleteFeatureStoryByUuid_Call {
+func (_c *Database_CountSearchDocuments_Call) Return(_a0 int64) *Database_CountSearchDocuments_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteFeatureStoryByUuid_Call) RunAndReturn(run func(string, string) error) *Database_DeleteFeatureStoryByUuid_Call {
+func (_c *Database_CountSearchDocuments_Call) RunAndReturn(run func(string) int64) *Database_CountSearchDocuments_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteInvoice provides a mock function with given fields: payment_request
-func (_m *Database) DeleteInvoice(payment_request string) db.NewInvoiceList {
-	ret := _m.Called(payment_request)
+// CountTicketRevisions provides a mock function with given fields: ticketId
+func (_m *Database) CountTicketRevisions(ticketId string) int64 {
+	ret := _m.Called(ticketId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteInvoice")
+		panic("no return value specified for CountTicketRevisions")
 	}
 
-	var r0 db.NewInvoiceList
-	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
-		r0 = rf(payment_request)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(ticketId)
 	} else {
-		r0 = ret.Get(0).(db.NewInvoiceList)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_DeleteInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInvoice'
-type Database_DeleteInvoice_Call struct {
+// Database_CountTicketRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountTicketRevisions'
+type Database_CountTicketRevisions_Call struct {
 	*mock.Call
 }
 
-// DeleteInvoice is a helper method to define mock.On call
-//   - payment_request string
-func (_e *Database_Expecter) DeleteInvoice(payment_request interface{}) *Database_DeleteInvoice_Call {
-	return &Database_DeleteInvoice_Call{Call: _e.mock.On("DeleteInvoice", payment_request)}
+// CountTicketRevisions is a helper method to define mock.On call
+//   - ticketId string
+func (_e *Database_Expecter) CountTicketRevisions(ticketId interface{}) *Database_CountTicketRevisions_Call {
+	return &Database_CountTicketRevisions_Call{Call: _e.mock.On("CountTicketRevisions", ticketId)}
 }
 
-func (_c *Database_DeleteInvoice_Call) Run(run func(payment_request string)) *Database_DeleteInvoice_Call {
+func (_c *Database_CountTicketRevisions_Call) Run(run func(ticketId string)) *Database_CountTicketRevisions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_DeleteInvoice_Call {
+func (_c *Database_CountTicketRevisions_Call) Return(_a0 int64) *Database_CountTicketRevisions_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_DeleteInvoice_Call {
+func (_c *Database_CountTicketRevisions_Call) RunAndReturn(run func(string) int64) *Database_CountTicketRevisions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteUserInvoiceData provides a mock function with given fields: payment_request
-func (_m *Database) DeleteUserInvoiceData(payment_request string) db.UserInvoiceData {
-	ret := _m.Called(payment_request)
+// CreateApiKey provides a mock function with given fields: m
+func (_m *Database) CreateApiKey(m db.ApiKey) (db.ApiKey, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUserInvoiceData")
+		panic("no return value specified for CreateApiKey")
 	}
 
-	var r0 db.UserInvoiceData
-	if rf, ok := ret.Get(0).(func(string) db.UserInvoiceData); ok {
-		r0 = rf(payment_request)
+	var r0 db.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.ApiKey) (db.ApiKey, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.ApiKey) db.ApiKey); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(db.UserInvoiceData)
+		r0 = ret.Get(0).(db.ApiKey)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.ApiKey) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_DeleteUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserInvoiceData'
-type Database_DeleteUserInvoiceData_Call struct {
+// Database_CreateApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateApiKey'
+type Database_CreateApiKey_Call struct {
 	*mock.Call
 }
 
-// DeleteUserInvoiceData is a helper method to define mock.On call
-//   - payment_request string
-func (_e *Database_Expecter) DeleteUserInvoiceData(payment_request interface{}) *Database_DeleteUserInvoiceData_Call {
-	return &Database_DeleteUserInvoiceData_Call{Call: _e.mock.On("DeleteUserInvoiceData", payment_request)}
+// CreateApiKey is a helper method to define mock.On call
+//   - m db.ApiKey
+func (_e *Database_Expecter) CreateApiKey(m interface{}) *Database_CreateApiKey_Call {
+	return &Database_CreateApiKey_Call{Call: _e.mock.On("CreateApiKey", m)}
 }
 
-func (_c *Database_DeleteUserInvoiceData_Call) Run(run func(payment_request string)) *Database_DeleteUserInvoiceData_Call {
+func (_c *Database_CreateApiKey_Call) Run(run func(m db.ApiKey)) *Database_CreateApiKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.ApiKey))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_DeleteUserInvoiceData_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateApiKey_Call) Return(_a0 db.ApiKey, _a1 error) *Database_CreateApiKey_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_DeleteUserInvoiceData_Call) RunAndReturn(run func(string) db.UserInvoiceData) *Database_DeleteUserInvoiceData_Call {
+func (_c *Database_CreateApiKey_Call) RunAndReturn(run func(db.ApiKey) (db.ApiKey, error)) *Database_CreateApiKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteWorkspaceRepository provides a mock function with given fields: workspace_uuid, uuid
-func (_m *Database) DeleteWorkspaceRepository(workspace_uuid string, uuid string) bool {
-	ret := _m.Called(workspace_uuid, uuid)
+// CreateAttachment provides a mock function with given fields: attachment
+func (_m *Database) CreateAttachment(attachment db.Attachment) (db.Attachment, error) {
+	ret := _m.Called(attachment)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteWorkspaceRepository")
+		panic("no return value specified for CreateAttachment")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
-		r0 = rf(workspace_uuid, uuid)
+	var r0 db.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Attachment) (db.Attachment, error)); ok {
+		return rf(attachment)
+	}
+	if rf, ok := ret.Get(0).(func(db.Attachment) db.Attachment); ok {
+		r0 = rf(attachment)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(db.Attachment)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.Attachment) error); ok {
+		r1 = rf(attachment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_DeleteWorkspaceRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceRepository'
-type Database_DeleteWorkspaceRepository_Call struct {
+// Database_CreateAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAttachment'
+type Database_CreateAttachment_Call struct {
 	*mock.Call
 }
 
-// DeleteWorkspaceRepository is a helper method to define mock.On call
-//   - workspace_uuid string
-//   - uuid string
-func (_e *Database_Expecter) DeleteWorkspaceRepository(workspace_uuid interface{}, uuid interface{}) *Database_DeleteWorkspaceRepository_Call {
-	return &Database_DeleteWorkspaceRepository_Call{Call: _e.mock.On("DeleteWorkspaceRepository", workspace_uuid, uuid)}
+// CreateAttachment is a helper method to define mock.On call
+//   - attachment db.Attachment
+func (_e *Database_Expecter) CreateAttachment(attachment interface{}) *Database_CreateAttachment_Call {
+	return &Database_CreateAttachment_Call{Call: _e.mock.On("CreateAttachment", attachment)}
 }
 
-func (_c *Database_DeleteWorkspaceRepository_Call) Run(run func(workspace_uuid string, uuid string)) *Database_DeleteWorkspaceRepository_Call {
+func (_c *Database_CreateAttachment_Call) Run(run func(attachment db.Attachment)) *Database_CreateAttachment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(db.Attachment))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteWorkspaceRepository_Call) Return(_a0 bool) *Database_DeleteWorkspaceRepository_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateAttachment_Call) Return(_a0 db.Attachment, _a1 error) *Database_CreateAttachment_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_DeleteWorkspaceRepository_Call) RunAndReturn(run func(string, string) bool) *Database_DeleteWorkspaceRepository_Call {
+func (_c *Database_CreateAttachment_Call) RunAndReturn(run func(db.Attachment) (db.Attachment, error)) *Database_CreateAttachment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteWorkspaceUser provides a mock function with given fields: orgUser, org
-func (_m *Database) DeleteWorkspaceUser(orgUser db.WorkspaceUsersData, org string) db.WorkspaceUsersData {
-	ret := _m.Called(orgUser, org)
+// CreateAuthSession provides a mock function with given fields: m
+func (_m *Database) CreateAuthSession(m db.AuthSession) (db.AuthSession, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteWorkspaceUser")
+		panic("no return value specified for CreateAuthSession")
 	}
 
-	var r0 db.WorkspaceUsersData
-	if rf, ok := ret.Get(0).(func(db.WorkspaceUsersData, string) db.WorkspaceUsersData); ok {
-		r0 = rf(orgUser, org)
+	var r0 db.AuthSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.AuthSession) (db.AuthSession, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.AuthSession) db.AuthSession); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceUsersData)
+		r0 = ret.Get(0).(db.AuthSession)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.AuthSession) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_DeleteWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceUser'
-type Database_DeleteWorkspaceUser_Call struct {
+// Database_CreateAuthSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuthSession'
+type Database_CreateAuthSession_Call struct {
 	*mock.Call
 }
 
-// DeleteWorkspaceUser is a helper method to define mock.On call
-//   - orgUser db.WorkspaceUsersData
-//   - org string
-func (_e *Database_Expecter) DeleteWorkspaceUser(orgUser interface{}, org interface{}) *Database_DeleteWorkspaceUser_Call {
-	return &Database_DeleteWorkspaceUser_Call{Call: _e.mock.On("DeleteWorkspaceUser", orgUser, org)}
+// CreateAuthSession is a helper method to define mock.On call
+//   - m db.AuthSession
+func (_e *Database_Expecter) CreateAuthSession(m interface{}) *Database_CreateAuthSession_Call {
+	return &Database_CreateAuthSession_Call{Call: _e.mock.On("CreateAuthSession", m)}
 }
 
-func (_c *Database_DeleteWorkspaceUser_Call) Run(run func(orgUser db.WorkspaceUsersData, org string)) *Database_DeleteWorkspaceUser_Call {
+func (_c *Database_CreateAuthSession_Call) Run(run func(m db.AuthSession)) *Database_CreateAuthSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.WorkspaceUsersData), args[1].(string))
+		run(args[0].(db.AuthSession))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteWorkspaceUser_Call) Return(_a0 db.WorkspaceUsersData) *Database_DeleteWorkspaceUser_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateAuthSession_Call) Return(_a0 db.AuthSession, _a1 error) *Database_CreateAuthSession_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_DeleteWorkspaceUser_Call) RunAndReturn(run func(db.WorkspaceUsersData, string) db.WorkspaceUsersData) *Database_DeleteWorkspaceUser_Call {
+func (_c *Database_CreateAuthSession_Call) RunAndReturn(run func(db.AuthSession) (db.AuthSession, error)) *Database_CreateAuthSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllBounties provides a mock function with given fields: r
-func (_m *Database) GetAllBounties(r *http.Request) []db.NewBounty {
-	ret := _m.Called(r)
+// CreateBotUsage provides a mock function with given fields: usage
+func (_m *Database) CreateBotUsage(usage db.BotUsage) (db.BotUsage, error) {
+	ret := _m.Called(usage)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllBounties")
+		panic("no return value specified for CreateBotUsage")
 	}
 
-	var r0 []db.NewBounty
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
-		r0 = rf(r)
+	var r0 db.BotUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BotUsage) (db.BotUsage, error)); ok {
+		return rf(usage)
+	}
+	if rf, ok := ret.Get(0).(func(db.BotUsage) db.BotUsage); ok {
+		r0 = rf(usage)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Get(0).(db.BotUsage)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.BotUsage) error); ok {
+		r1 = rf(usage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetAllBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBounties'
-type Database_GetAllBounties_Call struct {
+// Database_CreateBotUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBotUsage'
+type Database_CreateBotUsage_Call struct {
 	*mock.Call
 }
 
-// GetAllBounties is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetAllBounties(r interface{}) *Database_GetAllBounties_Call {
-	return &Database_GetAllBounties_Call{Call: _e.mock.On("GetAllBounties", r)}
+// CreateBotUsage is a helper method to define mock.On call
+//   - usage db.BotUsage
+func (_e *Database_Expecter) CreateBotUsage(usage interface{}) *Database_CreateBotUsage_Call {
+	return &Database_CreateBotUsage_Call{Call: _e.mock.On("CreateBotUsage", usage)}
 }
 
-func (_c *Database_GetAllBounties_Call) Run(run func(r *http.Request)) *Database_GetAllBounties_Call {
+func (_c *Database_CreateBotUsage_Call) Run(run func(usage db.BotUsage)) *Database_CreateBotUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.BotUsage))
 	})
 	return _c
 }
 
-func (_c *Database_GetAllBounties_Call) Return(_a0 []db.NewBounty) *Database_GetAllBounties_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateBotUsage_Call) Return(_a0 db.BotUsage, _a1 error) *Database_CreateBotUsage_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetAllBounties_Call) RunAndReturn(run func(*http.Request) []db.NewBounty) *Database_GetAllBounties_Call {
+func (_c *Database_CreateBotUsage_Call) RunAndReturn(run func(db.BotUsage) (db.BotUsage, error)) *Database_CreateBotUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllTribes provides a mock function with given fields:
-func (_m *Database) GetAllTribes() []db.Tribe {
-	ret := _m.Called()
+// CreateBounties provides a mock function with given fields: bounties
+func (_m *Database) CreateBounties(bounties []db.NewBounty) ([]db.NewBounty, error) {
+	ret := _m.Called(bounties)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllTribes")
+		panic("no return value specified for CreateBounties")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func() []db.Tribe); ok {
-		r0 = rf()
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]db.NewBounty) ([]db.NewBounty, error)); ok {
+		return rf(bounties)
+	}
+	if rf, ok := ret.Get(0).(func([]db.NewBounty) []db.NewBounty); ok {
+		r0 = rf(bounties)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
+			r0 = ret.Get(0).([]db.NewBounty)
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func([]db.NewBounty) error); ok {
+		r1 = rf(bounties)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetAllTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTribes'
-type Database_GetAllTribes_Call struct {
+// Database_CreateBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBounties'
+type Database_CreateBounties_Call struct {
 	*mock.Call
 }
 
-// GetAllTribes is a helper method to define mock.On call
-func (_e *Database_Expecter) GetAllTribes() *Database_GetAllTribes_Call {
-	return &Database_GetAllTribes_Call{Call: _e.mock.On("GetAllTribes")}
+// CreateBounties is a helper method to define mock.On call
+//   - bounties []db.NewBounty
+func (_e *Database_Expecter) CreateBounties(bounties interface{}) *Database_CreateBounties_Call {
+	return &Database_CreateBounties_Call{Call: _e.mock.On("CreateBounties", bounties)}
 }
 
-func (_c *Database_GetAllTribes_Call) Run(run func()) *Database_GetAllTribes_Call {
+func (_c *Database_CreateBounties_Call) Run(run func(bounties []db.NewBounty)) *Database_CreateBounties_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].([]db.NewBounty))
 	})
 	return _c
 }
 
-func (_c *Database_GetAllTribes_Call) Return(_a0 []db.Tribe) *Database_GetAllTribes_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateBounties_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_CreateBounties_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetAllTribes_Call) RunAndReturn(run func() []db.Tribe) *Database_GetAllTribes_Call {
+func (_c *Database_CreateBounties_Call) RunAndReturn(run func([]db.NewBounty) ([]db.NewBounty, error)) *Database_CreateBounties_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllTribesByOwner provides a mock function with given fields: pubkey
-func (_m *Database) GetAllTribesByOwner(pubkey string) []db.Tribe {
-	ret := _m.Called(pubkey)
+// CreateBountyEscrow provides a mock function with given fields: m
+func (_m *Database) CreateBountyEscrow(m db.BountyEscrow) (db.BountyEscrow, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllTribesByOwner")
+		panic("no return value specified for CreateBountyEscrow")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
-		r0 = rf(pubkey)
+	var r0 db.BountyEscrow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyEscrow) (db.BountyEscrow, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyEscrow) db.BountyEscrow); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Get(0).(db.BountyEscrow)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.BountyEscrow) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetAllTribesByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTribesByOwner'
-type Database_GetAllTribesByOwner_Call struct {
+// Database_CreateBountyEscrow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBountyEscrow'
+type Database_CreateBountyEscrow_Call struct {
 	*mock.Call
 }
 
-// GetAllTribesByOwner is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetAllTribesByOwner(pubkey interface{}) *Database_GetAllTribesByOwner_Call {
-	return &Database_GetAllTribesByOwner_Call{Call: _e.mock.On("GetAllTribesByOwner", pubkey)}
+// CreateBountyEscrow is a helper method to define mock.On call
+//   - m db.BountyEscrow
+func (_e *Database_Expecter) CreateBountyEscrow(m interface{}) *Database_CreateBountyEscrow_Call {
+	return &Database_CreateBountyEscrow_Call{Call: _e.mock.On("CreateBountyEscrow", m)}
 }
 
-func (_c *Database_GetAllTribesByOwner_Call) Run(run func(pubkey string)) *Database_GetAllTribesByOwner_Call {
+func (_c *Database_CreateBountyEscrow_Call) Run(run func(m db.BountyEscrow)) *Database_CreateBountyEscrow_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.BountyEscrow))
 	})
 	return _c
 }
 
-func (_c *Database_GetAllTribesByOwner_Call) Return(_a0 []db.Tribe) *Database_GetAllTribesByOwner_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateBountyEscrow_Call) Return(_a0 db.BountyEscrow, _a1 error) *Database_CreateBountyEscrow_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetAllTribesByOwner_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetAllTribesByOwner_Call {
+func (_c *Database_CreateBountyEscrow_Call) RunAndReturn(run func(db.BountyEscrow) (db.BountyEscrow, error)) *Database_CreateBountyEscrow_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAssignedBounties provides a mock function with given fields: r
-func (_m *Database) GetAssignedBounties(r *http.Request) ([]db.NewBounty, error) {
-	ret := _m.Called(r)
+// CreateBountyProof provides a mock function with given fields: m
+func (_m *Database) CreateBountyProof(m db.BountyProof) (db.BountyProof, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAssignedBounties")
+		panic("no return value specified for CreateBountyProof")
 	}
 
-	var r0 []db.NewBounty
+	var r0 db.BountyProof
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.NewBounty, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(db.BountyProof) (db.BountyProof, error)); ok {
+		return rf(m)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(db.BountyProof) db.BountyProof); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Get(0).(db.BountyProof)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(db.BountyProof) error); ok {
+		r1 = rf(m)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2066,293 +2377,337 @@ func (_m *Database) GetAssignedBounties(r *http.Request) ([]db.NewBounty, error)
 	return r0, r1
 }
 
-// Database_GetAssignedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssignedBounties'
-type Database_GetAssignedBounties_Call struct {
+// Database_CreateBountyProof_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBountyProof'
+type Database_CreateBountyProof_Call struct {
 	*mock.Call
 }
 
-// GetAssignedBounties is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetAssignedBounties(r interface{}) *Database_GetAssignedBounties_Call {
-	return &Database_GetAssignedBounties_Call{Call: _e.mock.On("GetAssignedBounties", r)}
+// CreateBountyProof is a helper method to define mock.On call
+//   - m db.BountyProof
+func (_e *Database_Expecter) CreateBountyProof(m interface{}) *Database_CreateBountyProof_Call {
+	return &Database_CreateBountyProof_Call{Call: _e.mock.On("CreateBountyProof", m)}
 }
 
-func (_c *Database_GetAssignedBounties_Call) Run(run func(r *http.Request)) *Database_GetAssignedBounties_Call {
+func (_c *Database_CreateBountyProof_Call) Run(run func(m db.BountyProof)) *Database_CreateBountyProof_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.BountyProof))
 	})
 	return _c
 }
 
-func (_c *Database_GetAssignedBounties_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetAssignedBounties_Call {
+func (_c *Database_CreateBountyProof_Call) Return(_a0 db.BountyProof, _a1 error) *Database_CreateBountyProof_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetAssignedBounties_Call) RunAndReturn(run func(*http.Request) ([]db.NewBounty, error)) *Database_GetAssignedBounties_Call {
+func (_c *Database_CreateBountyProof_Call) RunAndReturn(run func(db.BountyProof) (db.BountyProof, error)) *Database_CreateBountyProof_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBot provides a mock function with given fields: uuid
-func (_m *Database) GetBot(uuid string) db.Bot {
-	ret := _m.Called(uuid)
+// CreateChannel provides a mock function with given fields: c
+func (_m *Database) CreateChannel(c db.Channel) (db.Channel, error) {
+	ret := _m.Called(c)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBot")
+		panic("no return value specified for CreateChannel")
 	}
 
-	var r0 db.Bot
-	if rf, ok := ret.Get(0).(func(string) db.Bot); ok {
-		r0 = rf(uuid)
+	var r0 db.Channel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Channel) (db.Channel, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(db.Channel) db.Channel); ok {
+		r0 = rf(c)
 	} else {
-		r0 = ret.Get(0).(db.Bot)
+		r0 = ret.Get(0).(db.Channel)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.Channel) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBot'
-type Database_GetBot_Call struct {
+// Database_CreateChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChannel'
+type Database_CreateChannel_Call struct {
 	*mock.Call
 }
 
-// GetBot is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetBot(uuid interface{}) *Database_GetBot_Call {
-	return &Database_GetBot_Call{Call: _e.mock.On("GetBot", uuid)}
+// CreateChannel is a helper method to define mock.On call
+//   - c db.Channel
+func (_e *Database_Expecter) CreateChannel(c interface{}) *Database_CreateChannel_Call {
+	return &Database_CreateChannel_Call{Call: _e.mock.On("CreateChannel", c)}
 }
 
-func (_c *Database_GetBot_Call) Run(run func(uuid string)) *Database_GetBot_Call {
+func (_c *Database_CreateChannel_Call) Run(run func(c db.Channel)) *Database_CreateChannel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.Channel))
 	})
 	return _c
 }
 
-func (_c *Database_GetBot_Call) Return(_a0 db.Bot) *Database_GetBot_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateChannel_Call) Return(_a0 db.Channel, _a1 error) *Database_CreateChannel_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBot_Call) RunAndReturn(run func(string) db.Bot) *Database_GetBot_Call {
+func (_c *Database_CreateChannel_Call) RunAndReturn(run func(db.Channel) (db.Channel, error)) *Database_CreateChannel_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBotByUniqueName provides a mock function with given fields: un
-func (_m *Database) GetBotByUniqueName(un string) db.Bot {
-	ret := _m.Called(un)
+// CreateConnectionCode provides a mock function with given fields: c
+func (_m *Database) CreateConnectionCode(c []db.ConnectionCodes) ([]db.ConnectionCodes, error) {
+	ret := _m.Called(c)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBotByUniqueName")
+		panic("no return value specified for CreateConnectionCode")
 	}
 
-	var r0 db.Bot
-	if rf, ok := ret.Get(0).(func(string) db.Bot); ok {
-		r0 = rf(un)
+	var r0 []db.ConnectionCodes
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]db.ConnectionCodes) ([]db.ConnectionCodes, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func([]db.ConnectionCodes) []db.ConnectionCodes); ok {
+		r0 = rf(c)
 	} else {
-		r0 = ret.Get(0).(db.Bot)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ConnectionCodes)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func([]db.ConnectionCodes) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBotByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotByUniqueName'
-type Database_GetBotByUniqueName_Call struct {
+// Database_CreateConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateConnectionCode'
+type Database_CreateConnectionCode_Call struct {
 	*mock.Call
 }
 
-// GetBotByUniqueName is a helper method to define mock.On call
-//   - un string
-func (_e *Database_Expecter) GetBotByUniqueName(un interface{}) *Database_GetBotByUniqueName_Call {
-	return &Database_GetBotByUniqueName_Call{Call: _e.mock.On("GetBotByUniqueName", un)}
+// CreateConnectionCode is a helper method to define mock.On call
+//   - c []db.ConnectionCodes
+func (_e *Database_Expecter) CreateConnectionCode(c interface{}) *Database_CreateConnectionCode_Call {
+	return &Database_CreateConnectionCode_Call{Call: _e.mock.On("CreateConnectionCode", c)}
 }
 
-func (_c *Database_GetBotByUniqueName_Call) Run(run func(un string)) *Database_GetBotByUniqueName_Call {
+func (_c *Database_CreateConnectionCode_Call) Run(run func(c []db.ConnectionCodes)) *Database_CreateConnectionCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].([]db.ConnectionCodes))
 	})
 	return _c
 }
 
-func (_c *Database_GetBotByUniqueName_Call) Return(_a0 db.Bot) *Database_GetBotByUniqueName_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateConnectionCode_Call) Return(_a0 []db.ConnectionCodes, _a1 error) *Database_CreateConnectionCode_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBotByUniqueName_Call) RunAndReturn(run func(string) db.Bot) *Database_GetBotByUniqueName_Call {
+func (_c *Database_CreateConnectionCode_Call) RunAndReturn(run func([]db.ConnectionCodes) ([]db.ConnectionCodes, error)) *Database_CreateConnectionCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBotsByOwner provides a mock function with given fields: pubkey
-func (_m *Database) GetBotsByOwner(pubkey string) []db.Bot {
-	ret := _m.Called(pubkey)
+// CreateContentAppeal provides a mock function with given fields: m
+func (_m *Database) CreateContentAppeal(m db.ContentAppeal) (db.ContentAppeal, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBotsByOwner")
+		panic("no return value specified for CreateContentAppeal")
 	}
 
-	var r0 []db.Bot
-	if rf, ok := ret.Get(0).(func(string) []db.Bot); ok {
-		r0 = rf(pubkey)
+	var r0 db.ContentAppeal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.ContentAppeal) (db.ContentAppeal, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.ContentAppeal) db.ContentAppeal); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Bot)
-		}
+		r0 = ret.Get(0).(db.ContentAppeal)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.ContentAppeal) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBotsByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotsByOwner'
-type Database_GetBotsByOwner_Call struct {
+// Database_CreateContentAppeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateContentAppeal'
+type Database_CreateContentAppeal_Call struct {
 	*mock.Call
 }
 
-// GetBotsByOwner is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetBotsByOwner(pubkey interface{}) *Database_GetBotsByOwner_Call {
-	return &Database_GetBotsByOwner_Call{Call: _e.mock.On("GetBotsByOwner", pubkey)}
+// CreateContentAppeal is a helper method to define mock.On call
+//   - m db.ContentAppeal
+func (_e *Database_Expecter) CreateContentAppeal(m interface{}) *Database_CreateContentAppeal_Call {
+	return &Database_CreateContentAppeal_Call{Call: _e.mock.On("CreateContentAppeal", m)}
 }
 
-func (_c *Database_GetBotsByOwner_Call) Run(run func(pubkey string)) *Database_GetBotsByOwner_Call {
+func (_c *Database_CreateContentAppeal_Call) Run(run func(m db.ContentAppeal)) *Database_CreateContentAppeal_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.ContentAppeal))
 	})
 	return _c
 }
 
-func (_c *Database_GetBotsByOwner_Call) Return(_a0 []db.Bot) *Database_GetBotsByOwner_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateContentAppeal_Call) Return(_a0 db.ContentAppeal, _a1 error) *Database_CreateContentAppeal_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBotsByOwner_Call) RunAndReturn(run func(string) []db.Bot) *Database_GetBotsByOwner_Call {
+func (_c *Database_CreateContentAppeal_Call) RunAndReturn(run func(db.ContentAppeal) (db.ContentAppeal, error)) *Database_CreateContentAppeal_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesByDateRange provides a mock function with given fields: r, re
-func (_m *Database) GetBountiesByDateRange(r db.PaymentDateRange, re *http.Request) []db.NewBounty {
-	ret := _m.Called(r, re)
+// CreateContentReport provides a mock function with given fields: m
+func (_m *Database) CreateContentReport(m db.ContentReport) (db.ContentReport, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesByDateRange")
+		panic("no return value specified for CreateContentReport")
 	}
 
-	var r0 []db.NewBounty
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) []db.NewBounty); ok {
-		r0 = rf(r, re)
+	var r0 db.ContentReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.ContentReport) (db.ContentReport, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.ContentReport) db.ContentReport); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Get(0).(db.ContentReport)
 	}
 
-	return r0
-}
-
-// Database_GetBountiesByDateRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByDateRange'
-type Database_GetBountiesByDateRange_Call struct {
+	if rf, ok := ret.Get(1).(func(db.ContentReport) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateContentReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateContentReport'
+type Database_CreateContentReport_Call struct {
 	*mock.Call
 }
 
-// GetBountiesByDateRange is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - re *http.Request
-func (_e *Database_Expecter) GetBountiesByDateRange(r interface{}, re interface{}) *Database_GetBountiesByDateRange_Call {
-	return &Database_GetBountiesByDateRange_Call{Call: _e.mock.On("GetBountiesByDateRange", r, re)}
+// CreateContentReport is a helper method to define mock.On call
+//   - m db.ContentReport
+func (_e *Database_Expecter) CreateContentReport(m interface{}) *Database_CreateContentReport_Call {
+	return &Database_CreateContentReport_Call{Call: _e.mock.On("CreateContentReport", m)}
 }
 
-func (_c *Database_GetBountiesByDateRange_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesByDateRange_Call {
+func (_c *Database_CreateContentReport_Call) Run(run func(m db.ContentReport)) *Database_CreateContentReport_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+		run(args[0].(db.ContentReport))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesByDateRange_Call) Return(_a0 []db.NewBounty) *Database_GetBountiesByDateRange_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateContentReport_Call) Return(_a0 db.ContentReport, _a1 error) *Database_CreateContentReport_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountiesByDateRange_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) []db.NewBounty) *Database_GetBountiesByDateRange_Call {
+func (_c *Database_CreateContentReport_Call) RunAndReturn(run func(db.ContentReport) (db.ContentReport, error)) *Database_CreateContentReport_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesByDateRangeCount provides a mock function with given fields: r, re
-func (_m *Database) GetBountiesByDateRangeCount(r db.PaymentDateRange, re *http.Request) int64 {
-	ret := _m.Called(r, re)
+// CreateDispute provides a mock function with given fields: m
+func (_m *Database) CreateDispute(m db.BountyDispute) (db.BountyDispute, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesByDateRangeCount")
+		panic("no return value specified for CreateDispute")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) int64); ok {
-		r0 = rf(r, re)
+	var r0 db.BountyDispute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyDispute) (db.BountyDispute, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyDispute) db.BountyDispute); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.BountyDispute)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.BountyDispute) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBountiesByDateRangeCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByDateRangeCount'
-type Database_GetBountiesByDateRangeCount_Call struct {
+// Database_CreateDispute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDispute'
+type Database_CreateDispute_Call struct {
 	*mock.Call
 }
 
-// GetBountiesByDateRangeCount is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - re *http.Request
-func (_e *Database_Expecter) GetBountiesByDateRangeCount(r interface{}, re interface{}) *Database_GetBountiesByDateRangeCount_Call {
-	return &Database_GetBountiesByDateRangeCount_Call{Call: _e.mock.On("GetBountiesByDateRangeCount", r, re)}
+// CreateDispute is a helper method to define mock.On call
+//   - m db.BountyDispute
+func (_e *Database_Expecter) CreateDispute(m interface{}) *Database_CreateDispute_Call {
+	return &Database_CreateDispute_Call{Call: _e.mock.On("CreateDispute", m)}
 }
 
-func (_c *Database_GetBountiesByDateRangeCount_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesByDateRangeCount_Call {
+func (_c *Database_CreateDispute_Call) Run(run func(m db.BountyDispute)) *Database_CreateDispute_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+		run(args[0].(db.BountyDispute))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesByDateRangeCount_Call) Return(_a0 int64) *Database_GetBountiesByDateRangeCount_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateDispute_Call) Return(_a0 db.BountyDispute, _a1 error) *Database_CreateDispute_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountiesByDateRangeCount_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) int64) *Database_GetBountiesByDateRangeCount_Call {
+func (_c *Database_CreateDispute_Call) RunAndReturn(run func(db.BountyDispute) (db.BountyDispute, error)) *Database_CreateDispute_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesByFeatureAndPhaseUuid provides a mock function with given fields: featureUuid, phaseUuid, r
-func (_m *Database) GetBountiesByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) ([]db.NewBounty, error) {
-	ret := _m.Called(featureUuid, phaseUuid, r)
+// CreateEndorsement provides a mock function with given fields: m
+func (_m *Database) CreateEndorsement(m db.Endorsement) (db.Endorsement, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesByFeatureAndPhaseUuid")
+		panic("no return value specified for CreateEndorsement")
 	}
 
-	var r0 []db.NewBounty
+	var r0 db.Endorsement
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, *http.Request) ([]db.NewBounty, error)); ok {
-		return rf(featureUuid, phaseUuid, r)
+	if rf, ok := ret.Get(0).(func(db.Endorsement) (db.Endorsement, error)); ok {
+		return rf(m)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, *http.Request) []db.NewBounty); ok {
-		r0 = rf(featureUuid, phaseUuid, r)
+	if rf, ok := ret.Get(0).(func(db.Endorsement) db.Endorsement); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Get(0).(db.Endorsement)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, *http.Request) error); ok {
-		r1 = rf(featureUuid, phaseUuid, r)
+	if rf, ok := ret.Get(1).(func(db.Endorsement) error); ok {
+		r1 = rf(m)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2360,341 +2715,384 @@ func (_m *Database) GetBountiesByFeatureAndPhaseUuid(featureUuid string, phaseUu
 	return r0, r1
 }
 
-// Database_GetBountiesByFeatureAndPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByFeatureAndPhaseUuid'
-type Database_GetBountiesByFeatureAndPhaseUuid_Call struct {
+// Database_CreateEndorsement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEndorsement'
+type Database_CreateEndorsement_Call struct {
 	*mock.Call
 }
 
-// GetBountiesByFeatureAndPhaseUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - phaseUuid string
-//   - r *http.Request
-func (_e *Database_Expecter) GetBountiesByFeatureAndPhaseUuid(featureUuid interface{}, phaseUuid interface{}, r interface{}) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
-	return &Database_GetBountiesByFeatureAndPhaseUuid_Call{Call: _e.mock.On("GetBountiesByFeatureAndPhaseUuid", featureUuid, phaseUuid, r)}
+// CreateEndorsement is a helper method to define mock.On call
+//   - m db.Endorsement
+func (_e *Database_Expecter) CreateEndorsement(m interface{}) *Database_CreateEndorsement_Call {
+	return &Database_CreateEndorsement_Call{Call: _e.mock.On("CreateEndorsement", m)}
 }
 
-func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) Run(run func(featureUuid string, phaseUuid string, r *http.Request)) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+func (_c *Database_CreateEndorsement_Call) Run(run func(m db.Endorsement)) *Database_CreateEndorsement_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(*http.Request))
+		run(args[0].(db.Endorsement))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+func (_c *Database_CreateEndorsement_Call) Return(_a0 db.Endorsement, _a1 error) *Database_CreateEndorsement_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) RunAndReturn(run func(string, string, *http.Request) ([]db.NewBounty, error)) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+func (_c *Database_CreateEndorsement_Call) RunAndReturn(run func(db.Endorsement) (db.Endorsement, error)) *Database_CreateEndorsement_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesByPhaseUuid provides a mock function with given fields: phaseUuid
-func (_m *Database) GetBountiesByPhaseUuid(phaseUuid string) []db.Bounty {
-	ret := _m.Called(phaseUuid)
+// CreateFeatureDependency provides a mock function with given fields: dependency
+func (_m *Database) CreateFeatureDependency(dependency db.FeatureDependency) (db.FeatureDependency, error) {
+	ret := _m.Called(dependency)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesByPhaseUuid")
+		panic("no return value specified for CreateFeatureDependency")
 	}
 
-	var r0 []db.Bounty
-	if rf, ok := ret.Get(0).(func(string) []db.Bounty); ok {
-		r0 = rf(phaseUuid)
+	var r0 db.FeatureDependency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.FeatureDependency) (db.FeatureDependency, error)); ok {
+		return rf(dependency)
+	}
+	if rf, ok := ret.Get(0).(func(db.FeatureDependency) db.FeatureDependency); ok {
+		r0 = rf(dependency)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Bounty)
-		}
+		r0 = ret.Get(0).(db.FeatureDependency)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.FeatureDependency) error); ok {
+		r1 = rf(dependency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBountiesByPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByPhaseUuid'
-type Database_GetBountiesByPhaseUuid_Call struct {
+// Database_CreateFeatureDependency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFeatureDependency'
+type Database_CreateFeatureDependency_Call struct {
 	*mock.Call
 }
 
-// GetBountiesByPhaseUuid is a helper method to define mock.On call
-//   - phaseUuid string
-func (_e *Database_Expecter) GetBountiesByPhaseUuid(phaseUuid interface{}) *Database_GetBountiesByPhaseUuid_Call {
-	return &Database_GetBountiesByPhaseUuid_Call{Call: _e.mock.On("GetBountiesByPhaseUuid", phaseUuid)}
+// CreateFeatureDependency is a helper method to define mock.On call
+//   - dependency db.FeatureDependency
+func (_e *Database_Expecter) CreateFeatureDependency(dependency interface{}) *Database_CreateFeatureDependency_Call {
+	return &Database_CreateFeatureDependency_Call{Call: _e.mock.On("CreateFeatureDependency", dependency)}
 }
 
-func (_c *Database_GetBountiesByPhaseUuid_Call) Run(run func(phaseUuid string)) *Database_GetBountiesByPhaseUuid_Call {
+func (_c *Database_CreateFeatureDependency_Call) Run(run func(dependency db.FeatureDependency)) *Database_CreateFeatureDependency_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.FeatureDependency))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesByPhaseUuid_Call) Return(_a0 []db.Bounty) *Database_GetBountiesByPhaseUuid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateFeatureDependency_Call) Return(_a0 db.FeatureDependency, _a1 error) *Database_CreateFeatureDependency_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountiesByPhaseUuid_Call) RunAndReturn(run func(string) []db.Bounty) *Database_GetBountiesByPhaseUuid_Call {
+func (_c *Database_CreateFeatureDependency_Call) RunAndReturn(run func(db.FeatureDependency) (db.FeatureDependency, error)) *Database_CreateFeatureDependency_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesCount provides a mock function with given fields: r
-func (_m *Database) GetBountiesCount(r *http.Request) int64 {
-	ret := _m.Called(r)
+// CreateIdempotencyKey provides a mock function with given fields: m
+func (_m *Database) CreateIdempotencyKey(m db.IdempotencyKey) (db.IdempotencyKey, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesCount")
+		panic("no return value specified for CreateIdempotencyKey")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
-		r0 = rf(r)
+	var r0 db.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.IdempotencyKey) (db.IdempotencyKey, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.IdempotencyKey) db.IdempotencyKey); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.IdempotencyKey)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.IdempotencyKey) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesCount'
-type Database_GetBountiesCount_Call struct {
+// Database_CreateIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIdempotencyKey'
+type Database_CreateIdempotencyKey_Call struct {
 	*mock.Call
 }
 
-// GetBountiesCount is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetBountiesCount(r interface{}) *Database_GetBountiesCount_Call {
-	return &Database_GetBountiesCount_Call{Call: _e.mock.On("GetBountiesCount", r)}
+// CreateIdempotencyKey is a helper method to define mock.On call
+//   - m db.IdempotencyKey
+func (_e *Database_Expecter) CreateIdempotencyKey(m interface{}) *Database_CreateIdempotencyKey_Call {
+	return &Database_CreateIdempotencyKey_Call{Call: _e.mock.On("CreateIdempotencyKey", m)}
 }
 
-func (_c *Database_GetBountiesCount_Call) Run(run func(r *http.Request)) *Database_GetBountiesCount_Call {
+func (_c *Database_CreateIdempotencyKey_Call) Run(run func(m db.IdempotencyKey)) *Database_CreateIdempotencyKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.IdempotencyKey))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesCount_Call) Return(_a0 int64) *Database_GetBountiesCount_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateIdempotencyKey_Call) Return(_a0 db.IdempotencyKey, _a1 error) *Database_CreateIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountiesCount_Call) RunAndReturn(run func(*http.Request) int64) *Database_GetBountiesCount_Call {
+func (_c *Database_CreateIdempotencyKey_Call) RunAndReturn(run func(db.IdempotencyKey) (db.IdempotencyKey, error)) *Database_CreateIdempotencyKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesCountByFeatureAndPhaseUuid provides a mock function with given fields: featureUuid, phaseUuid, r
-func (_m *Database) GetBountiesCountByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) int64 {
-	ret := _m.Called(featureUuid, phaseUuid, r)
+// CreateImpersonatedRequest provides a mock function with given fields: m
+func (_m *Database) CreateImpersonatedRequest(m db.ImpersonatedRequest) error {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesCountByFeatureAndPhaseUuid")
+		panic("no return value specified for CreateImpersonatedRequest")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string, *http.Request) int64); ok {
-		r0 = rf(featureUuid, phaseUuid, r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.ImpersonatedRequest) error); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetBountiesCountByFeatureAndPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesCountByFeatureAndPhaseUuid'
-type Database_GetBountiesCountByFeatureAndPhaseUuid_Call struct {
+// Database_CreateImpersonatedRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateImpersonatedRequest'
+type Database_CreateImpersonatedRequest_Call struct {
 	*mock.Call
 }
 
-// GetBountiesCountByFeatureAndPhaseUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - phaseUuid string
-//   - r *http.Request
-func (_e *Database_Expecter) GetBountiesCountByFeatureAndPhaseUuid(featureUuid interface{}, phaseUuid interface{}, r interface{}) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
-	return &Database_GetBountiesCountByFeatureAndPhaseUuid_Call{Call: _e.mock.On("GetBountiesCountByFeatureAndPhaseUuid", featureUuid, phaseUuid, r)}
+// CreateImpersonatedRequest is a helper method to define mock.On call
+//   - m db.ImpersonatedRequest
+func (_e *Database_Expecter) CreateImpersonatedRequest(m interface{}) *Database_CreateImpersonatedRequest_Call {
+	return &Database_CreateImpersonatedRequest_Call{Call: _e.mock.On("CreateImpersonatedRequest", m)}
 }
 
-func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) Run(run func(featureUuid string, phaseUuid string, r *http.Request)) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+func (_c *Database_CreateImpersonatedRequest_Call) Run(run func(m db.ImpersonatedRequest)) *Database_CreateImpersonatedRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(*http.Request))
+		run(args[0].(db.ImpersonatedRequest))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) Return(_a0 int64) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+func (_c *Database_CreateImpersonatedRequest_Call) Return(_a0 error) *Database_CreateImpersonatedRequest_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) RunAndReturn(run func(string, string, *http.Request) int64) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+func (_c *Database_CreateImpersonatedRequest_Call) RunAndReturn(run func(db.ImpersonatedRequest) error) *Database_CreateImpersonatedRequest_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesLeaderboard provides a mock function with given fields:
-func (_m *Database) GetBountiesLeaderboard() []db.LeaderData {
-	ret := _m.Called()
+// CreateImpersonation provides a mock function with given fields: m
+func (_m *Database) CreateImpersonation(m db.Impersonation) (db.Impersonation, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesLeaderboard")
+		panic("no return value specified for CreateImpersonation")
 	}
 
-	var r0 []db.LeaderData
-	if rf, ok := ret.Get(0).(func() []db.LeaderData); ok {
-		r0 = rf()
+	var r0 db.Impersonation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Impersonation) (db.Impersonation, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.Impersonation) db.Impersonation); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.LeaderData)
-		}
+		r0 = ret.Get(0).(db.Impersonation)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.Impersonation) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBountiesLeaderboard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesLeaderboard'
-type Database_GetBountiesLeaderboard_Call struct {
+// Database_CreateImpersonation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateImpersonation'
+type Database_CreateImpersonation_Call struct {
 	*mock.Call
 }
 
-// GetBountiesLeaderboard is a helper method to define mock.On call
-func (_e *Database_Expecter) GetBountiesLeaderboard() *Database_GetBountiesLeaderboard_Call {
-	return &Database_GetBountiesLeaderboard_Call{Call: _e.mock.On("GetBountiesLeaderboard")}
+// CreateImpersonation is a helper method to define mock.On call
+//   - m db.Impersonation
+func (_e *Database_Expecter) CreateImpersonation(m interface{}) *Database_CreateImpersonation_Call {
+	return &Database_CreateImpersonation_Call{Call: _e.mock.On("CreateImpersonation", m)}
 }
 
-func (_c *Database_GetBountiesLeaderboard_Call) Run(run func()) *Database_GetBountiesLeaderboard_Call {
+func (_c *Database_CreateImpersonation_Call) Run(run func(m db.Impersonation)) *Database_CreateImpersonation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(db.Impersonation))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesLeaderboard_Call) Return(_a0 []db.LeaderData) *Database_GetBountiesLeaderboard_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateImpersonation_Call) Return(_a0 db.Impersonation, _a1 error) *Database_CreateImpersonation_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountiesLeaderboard_Call) RunAndReturn(run func() []db.LeaderData) *Database_GetBountiesLeaderboard_Call {
+func (_c *Database_CreateImpersonation_Call) RunAndReturn(run func(db.Impersonation) (db.Impersonation, error)) *Database_CreateImpersonation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesProviders provides a mock function with given fields: r, re
-func (_m *Database) GetBountiesProviders(r db.PaymentDateRange, re *http.Request) []db.Person {
-	ret := _m.Called(r, re)
+// CreateLeaderBoard provides a mock function with given fields: uuid, leaderboards
+func (_m *Database) CreateLeaderBoard(uuid string, leaderboards []db.LeaderBoard) ([]db.LeaderBoard, error) {
+	ret := _m.Called(uuid, leaderboards)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesProviders")
+		panic("no return value specified for CreateLeaderBoard")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) []db.Person); ok {
-		r0 = rf(r, re)
+	var r0 []db.LeaderBoard
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []db.LeaderBoard) ([]db.LeaderBoard, error)); ok {
+		return rf(uuid, leaderboards)
+	}
+	if rf, ok := ret.Get(0).(func(string, []db.LeaderBoard) []db.LeaderBoard); ok {
+		r0 = rf(uuid, leaderboards)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
+			r0 = ret.Get(0).([]db.LeaderBoard)
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, []db.LeaderBoard) error); ok {
+		r1 = rf(uuid, leaderboards)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBountiesProviders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesProviders'
-type Database_GetBountiesProviders_Call struct {
+// Database_CreateLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLeaderBoard'
+type Database_CreateLeaderBoard_Call struct {
 	*mock.Call
 }
 
-// GetBountiesProviders is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - re *http.Request
-func (_e *Database_Expecter) GetBountiesProviders(r interface{}, re interface{}) *Database_GetBountiesProviders_Call {
-	return &Database_GetBountiesProviders_Call{Call: _e.mock.On("GetBountiesProviders", r, re)}
+// CreateLeaderBoard is a helper method to define mock.On call
+//   - uuid string
+//   - leaderboards []db.LeaderBoard
+func (_e *Database_Expecter) CreateLeaderBoard(uuid interface{}, leaderboards interface{}) *Database_CreateLeaderBoard_Call {
+	return &Database_CreateLeaderBoard_Call{Call: _e.mock.On("CreateLeaderBoard", uuid, leaderboards)}
 }
 
-func (_c *Database_GetBountiesProviders_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesProviders_Call {
+func (_c *Database_CreateLeaderBoard_Call) Run(run func(uuid string, leaderboards []db.LeaderBoard)) *Database_CreateLeaderBoard_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+		run(args[0].(string), args[1].([]db.LeaderBoard))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesProviders_Call) Return(_a0 []db.Person) *Database_GetBountiesProviders_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateLeaderBoard_Call) Return(_a0 []db.LeaderBoard, _a1 error) *Database_CreateLeaderBoard_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountiesProviders_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) []db.Person) *Database_GetBountiesProviders_Call {
+func (_c *Database_CreateLeaderBoard_Call) RunAndReturn(run func(string, []db.LeaderBoard) ([]db.LeaderBoard, error)) *Database_CreateLeaderBoard_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBounty provides a mock function with given fields: id
-func (_m *Database) GetBounty(id uint) db.NewBounty {
-	ret := _m.Called(id)
+// CreateLnUser provides a mock function with given fields: lnKey
+func (_m *Database) CreateLnUser(lnKey string) (db.Person, error) {
+	ret := _m.Called(lnKey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBounty")
+		panic("no return value specified for CreateLnUser")
 	}
 
-	var r0 db.NewBounty
-	if rf, ok := ret.Get(0).(func(uint) db.NewBounty); ok {
-		r0 = rf(id)
+	var r0 db.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.Person, error)); ok {
+		return rf(lnKey)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(lnKey)
 	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+		r0 = ret.Get(0).(db.Person)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lnKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBounty'
-type Database_GetBounty_Call struct {
+// Database_CreateLnUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLnUser'
+type Database_CreateLnUser_Call struct {
 	*mock.Call
 }
 
-// GetBounty is a helper method to define mock.On call
-//   - id uint
-func (_e *Database_Expecter) GetBounty(id interface{}) *Database_GetBounty_Call {
-	return &Database_GetBounty_Call{Call: _e.mock.On("GetBounty", id)}
+// CreateLnUser is a helper method to define mock.On call
+//   - lnKey string
+func (_e *Database_Expecter) CreateLnUser(lnKey interface{}) *Database_CreateLnUser_Call {
+	return &Database_CreateLnUser_Call{Call: _e.mock.On("CreateLnUser", lnKey)}
 }
 
-func (_c *Database_GetBounty_Call) Run(run func(id uint)) *Database_GetBounty_Call {
+func (_c *Database_CreateLnUser_Call) Run(run func(lnKey string)) *Database_CreateLnUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBounty_Call) Return(_a0 db.NewBounty) *Database_GetBounty_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateLnUser_Call) Return(_a0 db.Person, _a1 error) *Database_CreateLnUser_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBounty_Call) RunAndReturn(run func(uint) db.NewBounty) *Database_GetBounty_Call {
+func (_c *Database_CreateLnUser_Call) RunAndReturn(run func(string) (db.Person, error)) *Database_CreateLnUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyByCreated provides a mock function with given fields: created
-func (_m *Database) GetBountyByCreated(created uint) (db.NewBounty, error) {
-	ret := _m.Called(created)
+// CreateMediaJob provides a mock function with given fields: job
+func (_m *Database) CreateMediaJob(job db.MediaJob) (db.MediaJob, error) {
+	ret := _m.Called(job)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyByCreated")
+		panic("no return value specified for CreateMediaJob")
 	}
 
-	var r0 db.NewBounty
+	var r0 db.MediaJob
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint) (db.NewBounty, error)); ok {
-		return rf(created)
+	if rf, ok := ret.Get(0).(func(db.MediaJob) (db.MediaJob, error)); ok {
+		return rf(job)
 	}
-	if rf, ok := ret.Get(0).(func(uint) db.NewBounty); ok {
-		r0 = rf(created)
+	if rf, ok := ret.Get(0).(func(db.MediaJob) db.MediaJob); ok {
+		r0 = rf(job)
 	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+		r0 = ret.Get(0).(db.MediaJob)
 	}
 
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(created)
+	if rf, ok := ret.Get(1).(func(db.MediaJob) error); ok {
+		r1 = rf(job)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2702,57 +3100,55 @@ func (_m *Database) GetBountyByCreated(created uint) (db.NewBounty, error) {
 	return r0, r1
 }
 
-// Database_GetBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyByCreated'
-type Database_GetBountyByCreated_Call struct {
+// Database_CreateMediaJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMediaJob'
+type Database_CreateMediaJob_Call struct {
 	*mock.Call
 }
 
-// GetBountyByCreated is a helper method to define mock.On call
-//   - created uint
-func (_e *Database_Expecter) GetBountyByCreated(created interface{}) *Database_GetBountyByCreated_Call {
-	return &Database_GetBountyByCreated_Call{Call: _e.mock.On("GetBountyByCreated", created)}
+// CreateMediaJob is a helper method to define mock.On call
+//   - job db.MediaJob
+func (_e *Database_Expecter) CreateMediaJob(job interface{}) *Database_CreateMediaJob_Call {
+	return &Database_CreateMediaJob_Call{Call: _e.mock.On("CreateMediaJob", job)}
 }
 
-func (_c *Database_GetBountyByCreated_Call) Run(run func(created uint)) *Database_GetBountyByCreated_Call {
+func (_c *Database_CreateMediaJob_Call) Run(run func(job db.MediaJob)) *Database_CreateMediaJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(db.MediaJob))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyByCreated_Call) Return(_a0 db.NewBounty, _a1 error) *Database_GetBountyByCreated_Call {
+func (_c *Database_CreateMediaJob_Call) Return(_a0 db.MediaJob, _a1 error) *Database_CreateMediaJob_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountyByCreated_Call) RunAndReturn(run func(uint) (db.NewBounty, error)) *Database_GetBountyByCreated_Call {
+func (_c *Database_CreateMediaJob_Call) RunAndReturn(run func(db.MediaJob) (db.MediaJob, error)) *Database_CreateMediaJob_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyById provides a mock function with given fields: id
-func (_m *Database) GetBountyById(id string) ([]db.NewBounty, error) {
-	ret := _m.Called(id)
+// CreateNostrIdentity provides a mock function with given fields: m
+func (_m *Database) CreateNostrIdentity(m db.NostrIdentity) (db.NostrIdentity, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyById")
+		panic("no return value specified for CreateNostrIdentity")
 	}
 
-	var r0 []db.NewBounty
+	var r0 db.NostrIdentity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]db.NewBounty, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(db.NostrIdentity) (db.NostrIdentity, error)); ok {
+		return rf(m)
 	}
-	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(db.NostrIdentity) db.NostrIdentity); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Get(0).(db.NostrIdentity)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(db.NostrIdentity) error); ok {
+		r1 = rf(m)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2760,57 +3156,55 @@ func (_m *Database) GetBountyById(id string) ([]db.NewBounty, error) {
 	return r0, r1
 }
 
-// Database_GetBountyById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyById'
-type Database_GetBountyById_Call struct {
+// Database_CreateNostrIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNostrIdentity'
+type Database_CreateNostrIdentity_Call struct {
 	*mock.Call
 }
 
-// GetBountyById is a helper method to define mock.On call
-//   - id string
-func (_e *Database_Expecter) GetBountyById(id interface{}) *Database_GetBountyById_Call {
-	return &Database_GetBountyById_Call{Call: _e.mock.On("GetBountyById", id)}
+// CreateNostrIdentity is a helper method to define mock.On call
+//   - m db.NostrIdentity
+func (_e *Database_Expecter) CreateNostrIdentity(m interface{}) *Database_CreateNostrIdentity_Call {
+	return &Database_CreateNostrIdentity_Call{Call: _e.mock.On("CreateNostrIdentity", m)}
 }
 
-func (_c *Database_GetBountyById_Call) Run(run func(id string)) *Database_GetBountyById_Call {
+func (_c *Database_CreateNostrIdentity_Call) Run(run func(m db.NostrIdentity)) *Database_CreateNostrIdentity_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.NostrIdentity))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyById_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountyById_Call {
+func (_c *Database_CreateNostrIdentity_Call) Return(_a0 db.NostrIdentity, _a1 error) *Database_CreateNostrIdentity_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountyById_Call) RunAndReturn(run func(string) ([]db.NewBounty, error)) *Database_GetBountyById_Call {
+func (_c *Database_CreateNostrIdentity_Call) RunAndReturn(run func(db.NostrIdentity) (db.NostrIdentity, error)) *Database_CreateNostrIdentity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyDataByCreated provides a mock function with given fields: created
-func (_m *Database) GetBountyDataByCreated(created string) ([]db.NewBounty, error) {
-	ret := _m.Called(created)
+// CreateOrEditBot provides a mock function with given fields: b
+func (_m *Database) CreateOrEditBot(b db.Bot) (db.Bot, error) {
+	ret := _m.Called(b)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyDataByCreated")
+		panic("no return value specified for CreateOrEditBot")
 	}
 
-	var r0 []db.NewBounty
+	var r0 db.Bot
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]db.NewBounty, error)); ok {
-		return rf(created)
+	if rf, ok := ret.Get(0).(func(db.Bot) (db.Bot, error)); ok {
+		return rf(b)
 	}
-	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
-		r0 = rf(created)
+	if rf, ok := ret.Get(0).(func(db.Bot) db.Bot); ok {
+		r0 = rf(b)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Get(0).(db.Bot)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(created)
+	if rf, ok := ret.Get(1).(func(db.Bot) error); ok {
+		r1 = rf(b)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2818,289 +3212,335 @@ func (_m *Database) GetBountyDataByCreated(created string) ([]db.NewBounty, erro
 	return r0, r1
 }
 
-// Database_GetBountyDataByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyDataByCreated'
-type Database_GetBountyDataByCreated_Call struct {
+// Database_CreateOrEditBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBot'
+type Database_CreateOrEditBot_Call struct {
 	*mock.Call
 }
 
-// GetBountyDataByCreated is a helper method to define mock.On call
-//   - created string
-func (_e *Database_Expecter) GetBountyDataByCreated(created interface{}) *Database_GetBountyDataByCreated_Call {
-	return &Database_GetBountyDataByCreated_Call{Call: _e.mock.On("GetBountyDataByCreated", created)}
+// CreateOrEditBot is a helper method to define mock.On call
+//   - b db.Bot
+func (_e *Database_Expecter) CreateOrEditBot(b interface{}) *Database_CreateOrEditBot_Call {
+	return &Database_CreateOrEditBot_Call{Call: _e.mock.On("CreateOrEditBot", b)}
 }
 
-func (_c *Database_GetBountyDataByCreated_Call) Run(run func(created string)) *Database_GetBountyDataByCreated_Call {
+func (_c *Database_CreateOrEditBot_Call) Run(run func(b db.Bot)) *Database_CreateOrEditBot_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.Bot))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyDataByCreated_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountyDataByCreated_Call {
+func (_c *Database_CreateOrEditBot_Call) Return(_a0 db.Bot, _a1 error) *Database_CreateOrEditBot_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountyDataByCreated_Call) RunAndReturn(run func(string) ([]db.NewBounty, error)) *Database_GetBountyDataByCreated_Call {
+func (_c *Database_CreateOrEditBot_Call) RunAndReturn(run func(db.Bot) (db.Bot, error)) *Database_CreateOrEditBot_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyIndexById provides a mock function with given fields: id
-func (_m *Database) GetBountyIndexById(id string) int64 {
-	ret := _m.Called(id)
+// CreateOrEditBotCategory provides a mock function with given fields: m
+func (_m *Database) CreateOrEditBotCategory(m db.BotCategory) (db.BotCategory, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyIndexById")
+		panic("no return value specified for CreateOrEditBotCategory")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(id)
+	var r0 db.BotCategory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BotCategory) (db.BotCategory, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.BotCategory) db.BotCategory); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.BotCategory)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.BotCategory) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBountyIndexById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyIndexById'
-type Database_GetBountyIndexById_Call struct {
+// Database_CreateOrEditBotCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBotCategory'
+type Database_CreateOrEditBotCategory_Call struct {
 	*mock.Call
 }
 
-// GetBountyIndexById is a helper method to define mock.On call
-//   - id string
-func (_e *Database_Expecter) GetBountyIndexById(id interface{}) *Database_GetBountyIndexById_Call {
-	return &Database_GetBountyIndexById_Call{Call: _e.mock.On("GetBountyIndexById", id)}
+// CreateOrEditBotCategory is a helper method to define mock.On call
+//   - m db.BotCategory
+func (_e *Database_Expecter) CreateOrEditBotCategory(m interface{}) *Database_CreateOrEditBotCategory_Call {
+	return &Database_CreateOrEditBotCategory_Call{Call: _e.mock.On("CreateOrEditBotCategory", m)}
 }
 
-func (_c *Database_GetBountyIndexById_Call) Run(run func(id string)) *Database_GetBountyIndexById_Call {
+func (_c *Database_CreateOrEditBotCategory_Call) Run(run func(m db.BotCategory)) *Database_CreateOrEditBotCategory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.BotCategory))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyIndexById_Call) Return(_a0 int64) *Database_GetBountyIndexById_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateOrEditBotCategory_Call) Return(_a0 db.BotCategory, _a1 error) *Database_CreateOrEditBotCategory_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountyIndexById_Call) RunAndReturn(run func(string) int64) *Database_GetBountyIndexById_Call {
+func (_c *Database_CreateOrEditBotCategory_Call) RunAndReturn(run func(db.BotCategory) (db.BotCategory, error)) *Database_CreateOrEditBotCategory_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyRoles provides a mock function with given fields:
-func (_m *Database) GetBountyRoles() []db.BountyRoles {
-	ret := _m.Called()
+// CreateOrEditBotWebhook provides a mock function with given fields: m
+func (_m *Database) CreateOrEditBotWebhook(m db.BotWebhook) (db.BotWebhook, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyRoles")
+		panic("no return value specified for CreateOrEditBotWebhook")
 	}
 
-	var r0 []db.BountyRoles
-	if rf, ok := ret.Get(0).(func() []db.BountyRoles); ok {
-		r0 = rf()
+	var r0 db.BotWebhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BotWebhook) (db.BotWebhook, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.BotWebhook) db.BotWebhook); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.BountyRoles)
-		}
+		r0 = ret.Get(0).(db.BotWebhook)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.BotWebhook) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBountyRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyRoles'
-type Database_GetBountyRoles_Call struct {
+// Database_CreateOrEditBotWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBotWebhook'
+type Database_CreateOrEditBotWebhook_Call struct {
 	*mock.Call
 }
 
-// GetBountyRoles is a helper method to define mock.On call
-func (_e *Database_Expecter) GetBountyRoles() *Database_GetBountyRoles_Call {
-	return &Database_GetBountyRoles_Call{Call: _e.mock.On("GetBountyRoles")}
+// CreateOrEditBotWebhook is a helper method to define mock.On call
+//   - m db.BotWebhook
+func (_e *Database_Expecter) CreateOrEditBotWebhook(m interface{}) *Database_CreateOrEditBotWebhook_Call {
+	return &Database_CreateOrEditBotWebhook_Call{Call: _e.mock.On("CreateOrEditBotWebhook", m)}
 }
 
-func (_c *Database_GetBountyRoles_Call) Run(run func()) *Database_GetBountyRoles_Call {
+func (_c *Database_CreateOrEditBotWebhook_Call) Run(run func(m db.BotWebhook)) *Database_CreateOrEditBotWebhook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(db.BotWebhook))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyRoles_Call) Return(_a0 []db.BountyRoles) *Database_GetBountyRoles_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateOrEditBotWebhook_Call) Return(_a0 db.BotWebhook, _a1 error) *Database_CreateOrEditBotWebhook_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountyRoles_Call) RunAndReturn(run func() []db.BountyRoles) *Database_GetBountyRoles_Call {
+func (_c *Database_CreateOrEditBotWebhook_Call) RunAndReturn(run func(db.BotWebhook) (db.BotWebhook, error)) *Database_CreateOrEditBotWebhook_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChannel provides a mock function with given fields: id
-func (_m *Database) GetChannel(id uint) db.Channel {
-	ret := _m.Called(id)
+// CreateOrEditBounty provides a mock function with given fields: b
+func (_m *Database) CreateOrEditBounty(b db.NewBounty) (db.NewBounty, error) {
+	ret := _m.Called(b)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChannel")
+		panic("no return value specified for CreateOrEditBounty")
 	}
 
-	var r0 db.Channel
-	if rf, ok := ret.Get(0).(func(uint) db.Channel); ok {
-		r0 = rf(id)
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NewBounty) (db.NewBounty, error)); ok {
+		return rf(b)
+	}
+	if rf, ok := ret.Get(0).(func(db.NewBounty) db.NewBounty); ok {
+		r0 = rf(b)
 	} else {
-		r0 = ret.Get(0).(db.Channel)
+		r0 = ret.Get(0).(db.NewBounty)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.NewBounty) error); ok {
+		r1 = rf(b)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannel'
-type Database_GetChannel_Call struct {
+// Database_CreateOrEditBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBounty'
+type Database_CreateOrEditBounty_Call struct {
 	*mock.Call
 }
 
-// GetChannel is a helper method to define mock.On call
-//   - id uint
-func (_e *Database_Expecter) GetChannel(id interface{}) *Database_GetChannel_Call {
-	return &Database_GetChannel_Call{Call: _e.mock.On("GetChannel", id)}
+// CreateOrEditBounty is a helper method to define mock.On call
+//   - b db.NewBounty
+func (_e *Database_Expecter) CreateOrEditBounty(b interface{}) *Database_CreateOrEditBounty_Call {
+	return &Database_CreateOrEditBounty_Call{Call: _e.mock.On("CreateOrEditBounty", b)}
 }
 
-func (_c *Database_GetChannel_Call) Run(run func(id uint)) *Database_GetChannel_Call {
+func (_c *Database_CreateOrEditBounty_Call) Run(run func(b db.NewBounty)) *Database_CreateOrEditBounty_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(db.NewBounty))
 	})
 	return _c
 }
 
-func (_c *Database_GetChannel_Call) Return(_a0 db.Channel) *Database_GetChannel_Call {
-	_c.Call.Return(_a0)
-	return _c
+func (_c *Database_CreateOrEditBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_CreateOrEditBounty_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
 }
 
-func (_c *Database_GetChannel_Call) RunAndReturn(run func(uint) db.Channel) *Database_GetChannel_Call {
+func (_c *Database_CreateOrEditBounty_Call) RunAndReturn(run func(db.NewBounty) (db.NewBounty, error)) *Database_CreateOrEditBounty_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChannelsByTribe provides a mock function with given fields: tribe_uuid
-func (_m *Database) GetChannelsByTribe(tribe_uuid string) []db.Channel {
-	ret := _m.Called(tribe_uuid)
+// CreateOrEditFeature provides a mock function with given fields: m
+func (_m *Database) CreateOrEditFeature(m db.WorkspaceFeatures) (db.WorkspaceFeatures, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChannelsByTribe")
+		panic("no return value specified for CreateOrEditFeature")
 	}
 
-	var r0 []db.Channel
-	if rf, ok := ret.Get(0).(func(string) []db.Channel); ok {
-		r0 = rf(tribe_uuid)
+	var r0 db.WorkspaceFeatures
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures) (db.WorkspaceFeatures, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures) db.WorkspaceFeatures); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Channel)
-		}
+		r0 = ret.Get(0).(db.WorkspaceFeatures)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.WorkspaceFeatures) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetChannelsByTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannelsByTribe'
-type Database_GetChannelsByTribe_Call struct {
+// Database_CreateOrEditFeature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeature'
+type Database_CreateOrEditFeature_Call struct {
 	*mock.Call
 }
 
-// GetChannelsByTribe is a helper method to define mock.On call
-//   - tribe_uuid string
-func (_e *Database_Expecter) GetChannelsByTribe(tribe_uuid interface{}) *Database_GetChannelsByTribe_Call {
-	return &Database_GetChannelsByTribe_Call{Call: _e.mock.On("GetChannelsByTribe", tribe_uuid)}
+// CreateOrEditFeature is a helper method to define mock.On call
+//   - m db.WorkspaceFeatures
+func (_e *Database_Expecter) CreateOrEditFeature(m interface{}) *Database_CreateOrEditFeature_Call {
+	return &Database_CreateOrEditFeature_Call{Call: _e.mock.On("CreateOrEditFeature", m)}
 }
 
-func (_c *Database_GetChannelsByTribe_Call) Run(run func(tribe_uuid string)) *Database_GetChannelsByTribe_Call {
+func (_c *Database_CreateOrEditFeature_Call) Run(run func(m db.WorkspaceFeatures)) *Database_CreateOrEditFeature_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.WorkspaceFeatures))
 	})
 	return _c
 }
 
-func (_c *Database_GetChannelsByTribe_Call) Return(_a0 []db.Channel) *Database_GetChannelsByTribe_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateOrEditFeature_Call) Return(_a0 db.WorkspaceFeatures, _a1 error) *Database_CreateOrEditFeature_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetChannelsByTribe_Call) RunAndReturn(run func(string) []db.Channel) *Database_GetChannelsByTribe_Call {
+func (_c *Database_CreateOrEditFeature_Call) RunAndReturn(run func(db.WorkspaceFeatures) (db.WorkspaceFeatures, error)) *Database_CreateOrEditFeature_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetConnectionCode provides a mock function with given fields:
-func (_m *Database) GetConnectionCode() db.ConnectionCodesShort {
-	ret := _m.Called()
+// CreateOrEditFeaturePhase provides a mock function with given fields: phase
+func (_m *Database) CreateOrEditFeaturePhase(phase db.FeaturePhase) (db.FeaturePhase, error) {
+	ret := _m.Called(phase)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetConnectionCode")
+		panic("no return value specified for CreateOrEditFeaturePhase")
 	}
 
-	var r0 db.ConnectionCodesShort
-	if rf, ok := ret.Get(0).(func() db.ConnectionCodesShort); ok {
-		r0 = rf()
+	var r0 db.FeaturePhase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.FeaturePhase) (db.FeaturePhase, error)); ok {
+		return rf(phase)
+	}
+	if rf, ok := ret.Get(0).(func(db.FeaturePhase) db.FeaturePhase); ok {
+		r0 = rf(phase)
 	} else {
-		r0 = ret.Get(0).(db.ConnectionCodesShort)
+		r0 = ret.Get(0).(db.FeaturePhase)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.FeaturePhase) error); ok {
+		r1 = rf(phase)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConnectionCode'
-type Database_GetConnectionCode_Call struct {
+// Database_CreateOrEditFeaturePhase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeaturePhase'
+type Database_CreateOrEditFeaturePhase_Call struct {
 	*mock.Call
 }
 
-// GetConnectionCode is a helper method to define mock.On call
-func (_e *Database_Expecter) GetConnectionCode() *Database_GetConnectionCode_Call {
-	return &Database_GetConnectionCode_Call{Call: _e.mock.On("GetConnectionCode")}
+// CreateOrEditFeaturePhase is a helper method to define mock.On call
+//   - phase db.FeaturePhase
+func (_e *Database_Expecter) CreateOrEditFeaturePhase(phase interface{}) *Database_CreateOrEditFeaturePhase_Call {
+	return &Database_CreateOrEditFeaturePhase_Call{Call: _e.mock.On("CreateOrEditFeaturePhase", phase)}
 }
 
-func (_c *Database_GetConnectionCode_Call) Run(run func()) *Database_GetConnectionCode_Call {
+func (_c *Database_CreateOrEditFeaturePhase_Call) Run(run func(phase db.FeaturePhase)) *Database_CreateOrEditFeaturePhase_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(db.FeaturePhase))
 	})
 	return _c
 }
 
-func (_c *Database_GetConnectionCode_Call) Return(_a0 db.ConnectionCodesShort) *Database_GetConnectionCode_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateOrEditFeaturePhase_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_CreateOrEditFeaturePhase_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetConnectionCode_Call) RunAndReturn(run func() db.ConnectionCodesShort) *Database_GetConnectionCode_Call {
+func (_c *Database_CreateOrEditFeaturePhase_Call) RunAndReturn(run func(db.FeaturePhase) (db.FeaturePhase, error)) *Database_CreateOrEditFeaturePhase_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetCreatedBounties provides a mock function with given fields: r
-func (_m *Database) GetCreatedBounties(r *http.Request) ([]db.NewBounty, error) {
-	ret := _m.Called(r)
+// CreateOrEditFeatureStory provides a mock function with given fields: story
+func (_m *Database) CreateOrEditFeatureStory(story db.FeatureStory) (db.FeatureStory, error) {
+	ret := _m.Called(story)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCreatedBounties")
+		panic("no return value specified for CreateOrEditFeatureStory")
 	}
 
-	var r0 []db.NewBounty
+	var r0 db.FeatureStory
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.NewBounty, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(db.FeatureStory) (db.FeatureStory, error)); ok {
+		return rf(story)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(db.FeatureStory) db.FeatureStory); ok {
+		r0 = rf(story)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Get(0).(db.FeatureStory)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(db.FeatureStory) error); ok {
+		r1 = rf(story)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3108,101 +3548,111 @@ func (_m *Database) GetCreatedBounties(r *http.Request) ([]db.NewBounty, error)
 	return r0, r1
 }
 
-// Database_GetCreatedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCreatedBounties'
-type Database_GetCreatedBounties_Call struct {
+// Database_CreateOrEditFeatureStory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeatureStory'
+type Database_CreateOrEditFeatureStory_Call struct {
 	*mock.Call
 }
 
-// GetCreatedBounties is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetCreatedBounties(r interface{}) *Database_GetCreatedBounties_Call {
-	return &Database_GetCreatedBounties_Call{Call: _e.mock.On("GetCreatedBounties", r)}
+// CreateOrEditFeatureStory is a helper method to define mock.On call
+//   - story db.FeatureStory
+func (_e *Database_Expecter) CreateOrEditFeatureStory(story interface{}) *Database_CreateOrEditFeatureStory_Call {
+	return &Database_CreateOrEditFeatureStory_Call{Call: _e.mock.On("CreateOrEditFeatureStory", story)}
 }
 
-func (_c *Database_GetCreatedBounties_Call) Run(run func(r *http.Request)) *Database_GetCreatedBounties_Call {
+func (_c *Database_CreateOrEditFeatureStory_Call) Run(run func(story db.FeatureStory)) *Database_CreateOrEditFeatureStory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.FeatureStory))
 	})
 	return _c
 }
 
-func (_c *Database_GetCreatedBounties_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetCreatedBounties_Call {
+func (_c *Database_CreateOrEditFeatureStory_Call) Return(_a0 db.FeatureStory, _a1 error) *Database_CreateOrEditFeatureStory_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetCreatedBounties_Call) RunAndReturn(run func(*http.Request) ([]db.NewBounty, error)) *Database_GetCreatedBounties_Call {
+func (_c *Database_CreateOrEditFeatureStory_Call) RunAndReturn(run func(db.FeatureStory) (db.FeatureStory, error)) *Database_CreateOrEditFeatureStory_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeatureByUuid provides a mock function with given fields: uuid
-func (_m *Database) GetFeatureByUuid(uuid string) db.WorkspaceFeatures {
-	ret := _m.Called(uuid)
+// CreateOrEditPerson provides a mock function with given fields: m
+func (_m *Database) CreateOrEditPerson(m db.Person) (db.Person, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeatureByUuid")
+		panic("no return value specified for CreateOrEditPerson")
 	}
 
-	var r0 db.WorkspaceFeatures
-	if rf, ok := ret.Get(0).(func(string) db.WorkspaceFeatures); ok {
-		r0 = rf(uuid)
+	var r0 db.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Person) (db.Person, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.Person) db.Person); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceFeatures)
+		r0 = ret.Get(0).(db.Person)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.Person) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetFeatureByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureByUuid'
-type Database_GetFeatureByUuid_Call struct {
+// Database_CreateOrEditPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditPerson'
+type Database_CreateOrEditPerson_Call struct {
 	*mock.Call
 }
 
-// GetFeatureByUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetFeatureByUuid(uuid interface{}) *Database_GetFeatureByUuid_Call {
-	return &Database_GetFeatureByUuid_Call{Call: _e.mock.On("GetFeatureByUuid", uuid)}
+// CreateOrEditPerson is a helper method to define mock.On call
+//   - m db.Person
+func (_e *Database_Expecter) CreateOrEditPerson(m interface{}) *Database_CreateOrEditPerson_Call {
+	return &Database_CreateOrEditPerson_Call{Call: _e.mock.On("CreateOrEditPerson", m)}
 }
 
-func (_c *Database_GetFeatureByUuid_Call) Run(run func(uuid string)) *Database_GetFeatureByUuid_Call {
+func (_c *Database_CreateOrEditPerson_Call) Run(run func(m db.Person)) *Database_CreateOrEditPerson_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.Person))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeatureByUuid_Call) Return(_a0 db.WorkspaceFeatures) *Database_GetFeatureByUuid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateOrEditPerson_Call) Return(_a0 db.Person, _a1 error) *Database_CreateOrEditPerson_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetFeatureByUuid_Call) RunAndReturn(run func(string) db.WorkspaceFeatures) *Database_GetFeatureByUuid_Call {
+func (_c *Database_CreateOrEditPerson_Call) RunAndReturn(run func(db.Person) (db.Person, error)) *Database_CreateOrEditPerson_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeaturePhaseByUuid provides a mock function with given fields: featureUuid, phaseUuid
-func (_m *Database) GetFeaturePhaseByUuid(featureUuid string, phaseUuid string) (db.FeaturePhase, error) {
-	ret := _m.Called(featureUuid, phaseUuid)
+// CreateOrEditTribe provides a mock function with given fields: m
+func (_m *Database) CreateOrEditTribe(m db.Tribe) (db.Tribe, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeaturePhaseByUuid")
+		panic("no return value specified for CreateOrEditTribe")
 	}
 
-	var r0 db.FeaturePhase
+	var r0 db.Tribe
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (db.FeaturePhase, error)); ok {
-		return rf(featureUuid, phaseUuid)
+	if rf, ok := ret.Get(0).(func(db.Tribe) (db.Tribe, error)); ok {
+		return rf(m)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) db.FeaturePhase); ok {
-		r0 = rf(featureUuid, phaseUuid)
+	if rf, ok := ret.Get(0).(func(db.Tribe) db.Tribe); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(db.FeaturePhase)
+		r0 = ret.Get(0).(db.Tribe)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(featureUuid, phaseUuid)
+	if rf, ok := ret.Get(1).(func(db.Tribe) error); ok {
+		r1 = rf(m)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3210,105 +3660,111 @@ func (_m *Database) GetFeaturePhaseByUuid(featureUuid string, phaseUuid string)
 	return r0, r1
 }
 
-// Database_GetFeaturePhaseByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturePhaseByUuid'
-type Database_GetFeaturePhaseByUuid_Call struct {
+// Database_CreateOrEditTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditTribe'
+type Database_CreateOrEditTribe_Call struct {
 	*mock.Call
 }
 
-// GetFeaturePhaseByUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - phaseUuid string
-func (_e *Database_Expecter) GetFeaturePhaseByUuid(featureUuid interface{}, phaseUuid interface{}) *Database_GetFeaturePhaseByUuid_Call {
-	return &Database_GetFeaturePhaseByUuid_Call{Call: _e.mock.On("GetFeaturePhaseByUuid", featureUuid, phaseUuid)}
+// CreateOrEditTribe is a helper method to define mock.On call
+//   - m db.Tribe
+func (_e *Database_Expecter) CreateOrEditTribe(m interface{}) *Database_CreateOrEditTribe_Call {
+	return &Database_CreateOrEditTribe_Call{Call: _e.mock.On("CreateOrEditTribe", m)}
 }
 
-func (_c *Database_GetFeaturePhaseByUuid_Call) Run(run func(featureUuid string, phaseUuid string)) *Database_GetFeaturePhaseByUuid_Call {
+func (_c *Database_CreateOrEditTribe_Call) Run(run func(m db.Tribe)) *Database_CreateOrEditTribe_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(db.Tribe))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeaturePhaseByUuid_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_GetFeaturePhaseByUuid_Call {
+func (_c *Database_CreateOrEditTribe_Call) Return(_a0 db.Tribe, _a1 error) *Database_CreateOrEditTribe_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetFeaturePhaseByUuid_Call) RunAndReturn(run func(string, string) (db.FeaturePhase, error)) *Database_GetFeaturePhaseByUuid_Call {
+func (_c *Database_CreateOrEditTribe_Call) RunAndReturn(run func(db.Tribe) (db.Tribe, error)) *Database_CreateOrEditTribe_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeaturePhasesBountiesCount provides a mock function with given fields: bountyType, phaseUuid
-func (_m *Database) GetFeaturePhasesBountiesCount(bountyType string, phaseUuid string) int64 {
-	ret := _m.Called(bountyType, phaseUuid)
+// CreateOrEditWorkflow provides a mock function with given fields: m
+func (_m *Database) CreateOrEditWorkflow(m db.Workflow) (db.Workflow, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeaturePhasesBountiesCount")
+		panic("no return value specified for CreateOrEditWorkflow")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
-		r0 = rf(bountyType, phaseUuid)
-	} else {
-		r0 = ret.Get(0).(int64)
+	var r0 db.Workflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Workflow) (db.Workflow, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.Workflow) db.Workflow); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.Workflow)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.Workflow) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetFeaturePhasesBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturePhasesBountiesCount'
-type Database_GetFeaturePhasesBountiesCount_Call struct {
+// Database_CreateOrEditWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkflow'
+type Database_CreateOrEditWorkflow_Call struct {
 	*mock.Call
 }
 
-// GetFeaturePhasesBountiesCount is a helper method to define mock.On call
-//   - bountyType string
-//   - phaseUuid string
-func (_e *Database_Expecter) GetFeaturePhasesBountiesCount(bountyType interface{}, phaseUuid interface{}) *Database_GetFeaturePhasesBountiesCount_Call {
-	return &Database_GetFeaturePhasesBountiesCount_Call{Call: _e.mock.On("GetFeaturePhasesBountiesCount", bountyType, phaseUuid)}
+// CreateOrEditWorkflow is a helper method to define mock.On call
+//   - m db.Workflow
+func (_e *Database_Expecter) CreateOrEditWorkflow(m interface{}) *Database_CreateOrEditWorkflow_Call {
+	return &Database_CreateOrEditWorkflow_Call{Call: _e.mock.On("CreateOrEditWorkflow", m)}
 }
 
-func (_c *Database_GetFeaturePhasesBountiesCount_Call) Run(run func(bountyType string, phaseUuid string)) *Database_GetFeaturePhasesBountiesCount_Call {
+func (_c *Database_CreateOrEditWorkflow_Call) Run(run func(m db.Workflow)) *Database_CreateOrEditWorkflow_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(db.Workflow))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeaturePhasesBountiesCount_Call) Return(_a0 int64) *Database_GetFeaturePhasesBountiesCount_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateOrEditWorkflow_Call) Return(_a0 db.Workflow, _a1 error) *Database_CreateOrEditWorkflow_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetFeaturePhasesBountiesCount_Call) RunAndReturn(run func(string, string) int64) *Database_GetFeaturePhasesBountiesCount_Call {
+func (_c *Database_CreateOrEditWorkflow_Call) RunAndReturn(run func(db.Workflow) (db.Workflow, error)) *Database_CreateOrEditWorkflow_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeatureStoriesByFeatureUuid provides a mock function with given fields: featureUuid
-func (_m *Database) GetFeatureStoriesByFeatureUuid(featureUuid string) ([]db.FeatureStory, error) {
-	ret := _m.Called(featureUuid)
+// CreateOrEditWorkspace provides a mock function with given fields: m
+func (_m *Database) CreateOrEditWorkspace(m db.Workspace) (db.Workspace, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeatureStoriesByFeatureUuid")
+		panic("no return value specified for CreateOrEditWorkspace")
 	}
 
-	var r0 []db.FeatureStory
+	var r0 db.Workspace
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]db.FeatureStory, error)); ok {
-		return rf(featureUuid)
+	if rf, ok := ret.Get(0).(func(db.Workspace) (db.Workspace, error)); ok {
+		return rf(m)
 	}
-	if rf, ok := ret.Get(0).(func(string) []db.FeatureStory); ok {
-		r0 = rf(featureUuid)
+	if rf, ok := ret.Get(0).(func(db.Workspace) db.Workspace); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.FeatureStory)
-		}
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(featureUuid)
+	if rf, ok := ret.Get(1).(func(db.Workspace) error); ok {
+		r1 = rf(m)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3316,55 +3772,55 @@ func (_m *Database) GetFeatureStoriesByFeatureUuid(featureUuid string) ([]db.Fea
 	return r0, r1
 }
 
-// Database_GetFeatureStoriesByFeatureUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureStoriesByFeatureUuid'
-type Database_GetFeatureStoriesByFeatureUuid_Call struct {
+// Database_CreateOrEditWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspace'
+type Database_CreateOrEditWorkspace_Call struct {
 	*mock.Call
 }
 
-// GetFeatureStoriesByFeatureUuid is a helper method to define mock.On call
-//   - featureUuid string
-func (_e *Database_Expecter) GetFeatureStoriesByFeatureUuid(featureUuid interface{}) *Database_GetFeatureStoriesByFeatureUuid_Call {
-	return &Database_GetFeatureStoriesByFeatureUuid_Call{Call: _e.mock.On("GetFeatureStoriesByFeatureUuid", featureUuid)}
+// CreateOrEditWorkspace is a helper method to define mock.On call
+//   - m db.Workspace
+func (_e *Database_Expecter) CreateOrEditWorkspace(m interface{}) *Database_CreateOrEditWorkspace_Call {
+	return &Database_CreateOrEditWorkspace_Call{Call: _e.mock.On("CreateOrEditWorkspace", m)}
 }
 
-func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) Run(run func(featureUuid string)) *Database_GetFeatureStoriesByFeatureUuid_Call {
+func (_c *Database_CreateOrEditWorkspace_Call) Run(run func(m db.Workspace)) *Database_CreateOrEditWorkspace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.Workspace))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) Return(_a0 []db.FeatureStory, _a1 error) *Database_GetFeatureStoriesByFeatureUuid_Call {
+func (_c *Database_CreateOrEditWorkspace_Call) Return(_a0 db.Workspace, _a1 error) *Database_CreateOrEditWorkspace_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) RunAndReturn(run func(string) ([]db.FeatureStory, error)) *Database_GetFeatureStoriesByFeatureUuid_Call {
+func (_c *Database_CreateOrEditWorkspace_Call) RunAndReturn(run func(db.Workspace) (db.Workspace, error)) *Database_CreateOrEditWorkspace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeatureStoryByUuid provides a mock function with given fields: featureUuid, storyUuid
-func (_m *Database) GetFeatureStoryByUuid(featureUuid string, storyUuid string) (db.FeatureStory, error) {
-	ret := _m.Called(featureUuid, storyUuid)
+// CreateOrEditWorkspaceIntegration provides a mock function with given fields: m
+func (_m *Database) CreateOrEditWorkspaceIntegration(m db.WorkspaceIntegration) (db.WorkspaceIntegration, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeatureStoryByUuid")
+		panic("no return value specified for CreateOrEditWorkspaceIntegration")
 	}
 
-	var r0 db.FeatureStory
+	var r0 db.WorkspaceIntegration
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (db.FeatureStory, error)); ok {
-		return rf(featureUuid, storyUuid)
+	if rf, ok := ret.Get(0).(func(db.WorkspaceIntegration) (db.WorkspaceIntegration, error)); ok {
+		return rf(m)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) db.FeatureStory); ok {
-		r0 = rf(featureUuid, storyUuid)
+	if rf, ok := ret.Get(0).(func(db.WorkspaceIntegration) db.WorkspaceIntegration); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(db.FeatureStory)
+		r0 = ret.Get(0).(db.WorkspaceIntegration)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(featureUuid, storyUuid)
+	if rf, ok := ret.Get(1).(func(db.WorkspaceIntegration) error); ok {
+		r1 = rf(m)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3372,387 +3828,447 @@ func (_m *Database) GetFeatureStoryByUuid(featureUuid string, storyUuid string)
 	return r0, r1
 }
 
-// Database_GetFeatureStoryByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureStoryByUuid'
-type Database_GetFeatureStoryByUuid_Call struct {
+// Database_CreateOrEditWorkspaceIntegration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspaceIntegration'
+type Database_CreateOrEditWorkspaceIntegration_Call struct {
 	*mock.Call
 }
 
-// GetFeatureStoryByUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - storyUuid string
-func (_e *Database_Expecter) GetFeatureStoryByUuid(featureUuid interface{}, storyUuid interface{}) *Database_GetFeatureStoryByUuid_Call {
-	return &Database_GetFeatureStoryByUuid_Call{Call: _e.mock.On("GetFeatureStoryByUuid", featureUuid, storyUuid)}
+// CreateOrEditWorkspaceIntegration is a helper method to define mock.On call
+//   - m db.WorkspaceIntegration
+func (_e *Database_Expecter) CreateOrEditWorkspaceIntegration(m interface{}) *Database_CreateOrEditWorkspaceIntegration_Call {
+	return &Database_CreateOrEditWorkspaceIntegration_Call{Call: _e.mock.On("CreateOrEditWorkspaceIntegration", m)}
 }
 
-func (_c *Database_GetFeatureStoryByUuid_Call) Run(run func(featureUuid string, storyUuid string)) *Database_GetFeatureStoryByUuid_Call {
+func (_c *Database_CreateOrEditWorkspaceIntegration_Call) Run(run func(m db.WorkspaceIntegration)) *Database_CreateOrEditWorkspaceIntegration_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(db.WorkspaceIntegration))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeatureStoryByUuid_Call) Return(_a0 db.FeatureStory, _a1 error) *Database_GetFeatureStoryByUuid_Call {
+func (_c *Database_CreateOrEditWorkspaceIntegration_Call) Return(_a0 db.WorkspaceIntegration, _a1 error) *Database_CreateOrEditWorkspaceIntegration_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetFeatureStoryByUuid_Call) RunAndReturn(run func(string, string) (db.FeatureStory, error)) *Database_GetFeatureStoryByUuid_Call {
+func (_c *Database_CreateOrEditWorkspaceIntegration_Call) RunAndReturn(run func(db.WorkspaceIntegration) (db.WorkspaceIntegration, error)) *Database_CreateOrEditWorkspaceIntegration_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeaturesByWorkspaceUuid provides a mock function with given fields: uuid, r
-func (_m *Database) GetFeaturesByWorkspaceUuid(uuid string, r *http.Request) []db.WorkspaceFeatures {
-	ret := _m.Called(uuid, r)
+// CreateOrEditWorkspaceRepository provides a mock function with given fields: m
+func (_m *Database) CreateOrEditWorkspaceRepository(m db.WorkspaceRepositories) (db.WorkspaceRepositories, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeaturesByWorkspaceUuid")
+		panic("no return value specified for CreateOrEditWorkspaceRepository")
 	}
 
-	var r0 []db.WorkspaceFeatures
-	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.WorkspaceFeatures); ok {
-		r0 = rf(uuid, r)
+	var r0 db.WorkspaceRepositories
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceRepositories) (db.WorkspaceRepositories, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceRepositories) db.WorkspaceRepositories); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceFeatures)
-		}
+		r0 = ret.Get(0).(db.WorkspaceRepositories)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.WorkspaceRepositories) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetFeaturesByWorkspaceUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturesByWorkspaceUuid'
-type Database_GetFeaturesByWorkspaceUuid_Call struct {
+// Database_CreateOrEditWorkspaceRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspaceRepository'
+type Database_CreateOrEditWorkspaceRepository_Call struct {
 	*mock.Call
 }
 
-// GetFeaturesByWorkspaceUuid is a helper method to define mock.On call
-//   - uuid string
-//   - r *http.Request
-func (_e *Database_Expecter) GetFeaturesByWorkspaceUuid(uuid interface{}, r interface{}) *Database_GetFeaturesByWorkspaceUuid_Call {
-	return &Database_GetFeaturesByWorkspaceUuid_Call{Call: _e.mock.On("GetFeaturesByWorkspaceUuid", uuid, r)}
+// CreateOrEditWorkspaceRepository is a helper method to define mock.On call
+//   - m db.WorkspaceRepositories
+func (_e *Database_Expecter) CreateOrEditWorkspaceRepository(m interface{}) *Database_CreateOrEditWorkspaceRepository_Call {
+	return &Database_CreateOrEditWorkspaceRepository_Call{Call: _e.mock.On("CreateOrEditWorkspaceRepository", m)}
 }
 
-func (_c *Database_GetFeaturesByWorkspaceUuid_Call) Run(run func(uuid string, r *http.Request)) *Database_GetFeaturesByWorkspaceUuid_Call {
+func (_c *Database_CreateOrEditWorkspaceRepository_Call) Run(run func(m db.WorkspaceRepositories)) *Database_CreateOrEditWorkspaceRepository_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(*http.Request))
+		run(args[0].(db.WorkspaceRepositories))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeaturesByWorkspaceUuid_Call) Return(_a0 []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateOrEditWorkspaceRepository_Call) Return(_a0 db.WorkspaceRepositories, _a1 error) *Database_CreateOrEditWorkspaceRepository_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetFeaturesByWorkspaceUuid_Call) RunAndReturn(run func(string, *http.Request) []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuid_Call {
+func (_c *Database_CreateOrEditWorkspaceRepository_Call) RunAndReturn(run func(db.WorkspaceRepositories) (db.WorkspaceRepositories, error)) *Database_CreateOrEditWorkspaceRepository_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFilterStatusCount provides a mock function with given fields:
-func (_m *Database) GetFilterStatusCount() db.FilterStattuCount {
-	ret := _m.Called()
+// CreateOrEditWorkspaceTemplate provides a mock function with given fields: m
+func (_m *Database) CreateOrEditWorkspaceTemplate(m db.WorkspaceTemplate) (db.WorkspaceTemplate, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFilterStatusCount")
+		panic("no return value specified for CreateOrEditWorkspaceTemplate")
 	}
 
-	var r0 db.FilterStattuCount
-	if rf, ok := ret.Get(0).(func() db.FilterStattuCount); ok {
-		r0 = rf()
+	var r0 db.WorkspaceTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceTemplate) (db.WorkspaceTemplate, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceTemplate) db.WorkspaceTemplate); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(db.FilterStattuCount)
+		r0 = ret.Get(0).(db.WorkspaceTemplate)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.WorkspaceTemplate) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetFilterStatusCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFilterStatusCount'
-type Database_GetFilterStatusCount_Call struct {
+// Database_CreateOrEditWorkspaceTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspaceTemplate'
+type Database_CreateOrEditWorkspaceTemplate_Call struct {
 	*mock.Call
 }
 
-// GetFilterStatusCount is a helper method to define mock.On call
-func (_e *Database_Expecter) GetFilterStatusCount() *Database_GetFilterStatusCount_Call {
-	return &Database_GetFilterStatusCount_Call{Call: _e.mock.On("GetFilterStatusCount")}
+// CreateOrEditWorkspaceTemplate is a helper method to define mock.On call
+//   - m db.WorkspaceTemplate
+func (_e *Database_Expecter) CreateOrEditWorkspaceTemplate(m interface{}) *Database_CreateOrEditWorkspaceTemplate_Call {
+	return &Database_CreateOrEditWorkspaceTemplate_Call{Call: _e.mock.On("CreateOrEditWorkspaceTemplate", m)}
 }
 
-func (_c *Database_GetFilterStatusCount_Call) Run(run func()) *Database_GetFilterStatusCount_Call {
+func (_c *Database_CreateOrEditWorkspaceTemplate_Call) Run(run func(m db.WorkspaceTemplate)) *Database_CreateOrEditWorkspaceTemplate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(db.WorkspaceTemplate))
 	})
 	return _c
 }
 
-func (_c *Database_GetFilterStatusCount_Call) Return(_a0 db.FilterStattuCount) *Database_GetFilterStatusCount_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateOrEditWorkspaceTemplate_Call) Return(_a0 db.WorkspaceTemplate, _a1 error) *Database_CreateOrEditWorkspaceTemplate_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetFilterStatusCount_Call) RunAndReturn(run func() db.FilterStattuCount) *Database_GetFilterStatusCount_Call {
+func (_c *Database_CreateOrEditWorkspaceTemplate_Call) RunAndReturn(run func(db.WorkspaceTemplate) (db.WorkspaceTemplate, error)) *Database_CreateOrEditWorkspaceTemplate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFirstTribeByFeedURL provides a mock function with given fields: feedURL
-func (_m *Database) GetFirstTribeByFeedURL(feedURL string) db.Tribe {
-	ret := _m.Called(feedURL)
+// CreatePaymentAttempt provides a mock function with given fields: attempt
+func (_m *Database) CreatePaymentAttempt(attempt db.PaymentAttempt) (db.PaymentAttempt, error) {
+	ret := _m.Called(attempt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFirstTribeByFeedURL")
+		panic("no return value specified for CreatePaymentAttempt")
 	}
 
-	var r0 db.Tribe
-	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
-		r0 = rf(feedURL)
+	var r0 db.PaymentAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.PaymentAttempt) (db.PaymentAttempt, error)); ok {
+		return rf(attempt)
+	}
+	if rf, ok := ret.Get(0).(func(db.PaymentAttempt) db.PaymentAttempt); ok {
+		r0 = rf(attempt)
 	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		r0 = ret.Get(0).(db.PaymentAttempt)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.PaymentAttempt) error); ok {
+		r1 = rf(attempt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetFirstTribeByFeedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirstTribeByFeedURL'
-type Database_GetFirstTribeByFeedURL_Call struct {
+// Database_CreatePaymentAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePaymentAttempt'
+type Database_CreatePaymentAttempt_Call struct {
 	*mock.Call
 }
 
-// GetFirstTribeByFeedURL is a helper method to define mock.On call
-//   - feedURL string
-func (_e *Database_Expecter) GetFirstTribeByFeedURL(feedURL interface{}) *Database_GetFirstTribeByFeedURL_Call {
-	return &Database_GetFirstTribeByFeedURL_Call{Call: _e.mock.On("GetFirstTribeByFeedURL", feedURL)}
+// CreatePaymentAttempt is a helper method to define mock.On call
+//   - attempt db.PaymentAttempt
+func (_e *Database_Expecter) CreatePaymentAttempt(attempt interface{}) *Database_CreatePaymentAttempt_Call {
+	return &Database_CreatePaymentAttempt_Call{Call: _e.mock.On("CreatePaymentAttempt", attempt)}
 }
 
-func (_c *Database_GetFirstTribeByFeedURL_Call) Run(run func(feedURL string)) *Database_GetFirstTribeByFeedURL_Call {
+func (_c *Database_CreatePaymentAttempt_Call) Run(run func(attempt db.PaymentAttempt)) *Database_CreatePaymentAttempt_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.PaymentAttempt))
 	})
 	return _c
 }
 
-func (_c *Database_GetFirstTribeByFeedURL_Call) Return(_a0 db.Tribe) *Database_GetFirstTribeByFeedURL_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreatePaymentAttempt_Call) Return(_a0 db.PaymentAttempt, _a1 error) *Database_CreatePaymentAttempt_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetFirstTribeByFeedURL_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetFirstTribeByFeedURL_Call {
+func (_c *Database_CreatePaymentAttempt_Call) RunAndReturn(run func(db.PaymentAttempt) (db.PaymentAttempt, error)) *Database_CreatePaymentAttempt_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetInvoice provides a mock function with given fields: payment_request
-func (_m *Database) GetInvoice(payment_request string) db.NewInvoiceList {
-	ret := _m.Called(payment_request)
+// CreatePubkeyBan provides a mock function with given fields: m
+func (_m *Database) CreatePubkeyBan(m db.PubkeyBan) (db.PubkeyBan, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetInvoice")
+		panic("no return value specified for CreatePubkeyBan")
 	}
 
-	var r0 db.NewInvoiceList
-	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
-		r0 = rf(payment_request)
+	var r0 db.PubkeyBan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.PubkeyBan) (db.PubkeyBan, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.PubkeyBan) db.PubkeyBan); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(db.NewInvoiceList)
+		r0 = ret.Get(0).(db.PubkeyBan)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.PubkeyBan) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvoice'
-type Database_GetInvoice_Call struct {
+// Database_CreatePubkeyBan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePubkeyBan'
+type Database_CreatePubkeyBan_Call struct {
 	*mock.Call
 }
 
-// GetInvoice is a helper method to define mock.On call
-//   - payment_request string
-func (_e *Database_Expecter) GetInvoice(payment_request interface{}) *Database_GetInvoice_Call {
-	return &Database_GetInvoice_Call{Call: _e.mock.On("GetInvoice", payment_request)}
+// CreatePubkeyBan is a helper method to define mock.On call
+//   - m db.PubkeyBan
+func (_e *Database_Expecter) CreatePubkeyBan(m interface{}) *Database_CreatePubkeyBan_Call {
+	return &Database_CreatePubkeyBan_Call{Call: _e.mock.On("CreatePubkeyBan", m)}
 }
 
-func (_c *Database_GetInvoice_Call) Run(run func(payment_request string)) *Database_GetInvoice_Call {
+func (_c *Database_CreatePubkeyBan_Call) Run(run func(m db.PubkeyBan)) *Database_CreatePubkeyBan_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.PubkeyBan))
 	})
 	return _c
 }
 
-func (_c *Database_GetInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_GetInvoice_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreatePubkeyBan_Call) Return(_a0 db.PubkeyBan, _a1 error) *Database_CreatePubkeyBan_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_GetInvoice_Call {
+func (_c *Database_CreatePubkeyBan_Call) RunAndReturn(run func(db.PubkeyBan) (db.PubkeyBan, error)) *Database_CreatePubkeyBan_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLeaderBoard provides a mock function with given fields: uuid
-func (_m *Database) GetLeaderBoard(uuid string) []db.LeaderBoard {
-	ret := _m.Called(uuid)
+// CreateSavedSearch provides a mock function with given fields: m
+func (_m *Database) CreateSavedSearch(m db.SavedSearch) (db.SavedSearch, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLeaderBoard")
+		panic("no return value specified for CreateSavedSearch")
 	}
 
-	var r0 []db.LeaderBoard
-	if rf, ok := ret.Get(0).(func(string) []db.LeaderBoard); ok {
-		r0 = rf(uuid)
+	var r0 db.SavedSearch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.SavedSearch) (db.SavedSearch, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.SavedSearch) db.SavedSearch); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.LeaderBoard)
-		}
+		r0 = ret.Get(0).(db.SavedSearch)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.SavedSearch) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderBoard'
-type Database_GetLeaderBoard_Call struct {
+// Database_CreateSavedSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSavedSearch'
+type Database_CreateSavedSearch_Call struct {
 	*mock.Call
 }
 
-// GetLeaderBoard is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetLeaderBoard(uuid interface{}) *Database_GetLeaderBoard_Call {
-	return &Database_GetLeaderBoard_Call{Call: _e.mock.On("GetLeaderBoard", uuid)}
+// CreateSavedSearch is a helper method to define mock.On call
+//   - m db.SavedSearch
+func (_e *Database_Expecter) CreateSavedSearch(m interface{}) *Database_CreateSavedSearch_Call {
+	return &Database_CreateSavedSearch_Call{Call: _e.mock.On("CreateSavedSearch", m)}
 }
 
-func (_c *Database_GetLeaderBoard_Call) Run(run func(uuid string)) *Database_GetLeaderBoard_Call {
+func (_c *Database_CreateSavedSearch_Call) Run(run func(m db.SavedSearch)) *Database_CreateSavedSearch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.SavedSearch))
 	})
 	return _c
 }
 
-func (_c *Database_GetLeaderBoard_Call) Return(_a0 []db.LeaderBoard) *Database_GetLeaderBoard_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateSavedSearch_Call) Return(_a0 db.SavedSearch, _a1 error) *Database_CreateSavedSearch_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetLeaderBoard_Call) RunAndReturn(run func(string) []db.LeaderBoard) *Database_GetLeaderBoard_Call {
+func (_c *Database_CreateSavedSearch_Call) RunAndReturn(run func(db.SavedSearch) (db.SavedSearch, error)) *Database_CreateSavedSearch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLeaderBoardByUuidAndAlias provides a mock function with given fields: uuid, alias
-func (_m *Database) GetLeaderBoardByUuidAndAlias(uuid string, alias string) db.LeaderBoard {
-	ret := _m.Called(uuid, alias)
+// CreateStakworkJob provides a mock function with given fields: job
+func (_m *Database) CreateStakworkJob(job db.StakworkJob) (db.StakworkJob, error) {
+	ret := _m.Called(job)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLeaderBoardByUuidAndAlias")
+		panic("no return value specified for CreateStakworkJob")
 	}
 
-	var r0 db.LeaderBoard
-	if rf, ok := ret.Get(0).(func(string, string) db.LeaderBoard); ok {
-		r0 = rf(uuid, alias)
+	var r0 db.StakworkJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.StakworkJob) (db.StakworkJob, error)); ok {
+		return rf(job)
+	}
+	if rf, ok := ret.Get(0).(func(db.StakworkJob) db.StakworkJob); ok {
+		r0 = rf(job)
 	} else {
-		r0 = ret.Get(0).(db.LeaderBoard)
+		r0 = ret.Get(0).(db.StakworkJob)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.StakworkJob) error); ok {
+		r1 = rf(job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetLeaderBoardByUuidAndAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderBoardByUuidAndAlias'
-type Database_GetLeaderBoardByUuidAndAlias_Call struct {
+// Database_CreateStakworkJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStakworkJob'
+type Database_CreateStakworkJob_Call struct {
 	*mock.Call
 }
 
-// GetLeaderBoardByUuidAndAlias is a helper method to define mock.On call
-//   - uuid string
-//   - alias string
-func (_e *Database_Expecter) GetLeaderBoardByUuidAndAlias(uuid interface{}, alias interface{}) *Database_GetLeaderBoardByUuidAndAlias_Call {
-	return &Database_GetLeaderBoardByUuidAndAlias_Call{Call: _e.mock.On("GetLeaderBoardByUuidAndAlias", uuid, alias)}
+// CreateStakworkJob is a helper method to define mock.On call
+//   - job db.StakworkJob
+func (_e *Database_Expecter) CreateStakworkJob(job interface{}) *Database_CreateStakworkJob_Call {
+	return &Database_CreateStakworkJob_Call{Call: _e.mock.On("CreateStakworkJob", job)}
 }
 
-func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) Run(run func(uuid string, alias string)) *Database_GetLeaderBoardByUuidAndAlias_Call {
+func (_c *Database_CreateStakworkJob_Call) Run(run func(job db.StakworkJob)) *Database_CreateStakworkJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(db.StakworkJob))
 	})
 	return _c
 }
 
-func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) Return(_a0 db.LeaderBoard) *Database_GetLeaderBoardByUuidAndAlias_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateStakworkJob_Call) Return(_a0 db.StakworkJob, _a1 error) *Database_CreateStakworkJob_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) RunAndReturn(run func(string, string) db.LeaderBoard) *Database_GetLeaderBoardByUuidAndAlias_Call {
+func (_c *Database_CreateStakworkJob_Call) RunAndReturn(run func(db.StakworkJob) (db.StakworkJob, error)) *Database_CreateStakworkJob_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedBots provides a mock function with given fields: r
-func (_m *Database) GetListedBots(r *http.Request) []db.Bot {
-	ret := _m.Called(r)
+// CreateTicketComment provides a mock function with given fields: comment
+func (_m *Database) CreateTicketComment(comment db.TicketComment) (db.TicketComment, error) {
+	ret := _m.Called(comment)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedBots")
+		panic("no return value specified for CreateTicketComment")
 	}
 
-	var r0 []db.Bot
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Bot); ok {
-		r0 = rf(r)
+	var r0 db.TicketComment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TicketComment) (db.TicketComment, error)); ok {
+		return rf(comment)
+	}
+	if rf, ok := ret.Get(0).(func(db.TicketComment) db.TicketComment); ok {
+		r0 = rf(comment)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Bot)
-		}
+		r0 = ret.Get(0).(db.TicketComment)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.TicketComment) error); ok {
+		r1 = rf(comment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetListedBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedBots'
-type Database_GetListedBots_Call struct {
+// Database_CreateTicketComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTicketComment'
+type Database_CreateTicketComment_Call struct {
 	*mock.Call
 }
 
-// GetListedBots is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedBots(r interface{}) *Database_GetListedBots_Call {
-	return &Database_GetListedBots_Call{Call: _e.mock.On("GetListedBots", r)}
+// CreateTicketComment is a helper method to define mock.On call
+//   - comment db.TicketComment
+func (_e *Database_Expecter) CreateTicketComment(comment interface{}) *Database_CreateTicketComment_Call {
+	return &Database_CreateTicketComment_Call{Call: _e.mock.On("CreateTicketComment", comment)}
 }
 
-func (_c *Database_GetListedBots_Call) Run(run func(r *http.Request)) *Database_GetListedBots_Call {
+func (_c *Database_CreateTicketComment_Call) Run(run func(comment db.TicketComment)) *Database_CreateTicketComment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.TicketComment))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedBots_Call) Return(_a0 []db.Bot) *Database_GetListedBots_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateTicketComment_Call) Return(_a0 db.TicketComment, _a1 error) *Database_CreateTicketComment_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetListedBots_Call) RunAndReturn(run func(*http.Request) []db.Bot) *Database_GetListedBots_Call {
+func (_c *Database_CreateTicketComment_Call) RunAndReturn(run func(db.TicketComment) (db.TicketComment, error)) *Database_CreateTicketComment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedOffers provides a mock function with given fields: r
-func (_m *Database) GetListedOffers(r *http.Request) ([]db.PeopleExtra, error) {
-	ret := _m.Called(r)
+// CreateTicketLink provides a mock function with given fields: link
+func (_m *Database) CreateTicketLink(link db.TicketLink) (db.TicketLink, error) {
+	ret := _m.Called(link)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedOffers")
+		panic("no return value specified for CreateTicketLink")
 	}
 
-	var r0 []db.PeopleExtra
+	var r0 db.TicketLink
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.PeopleExtra, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(db.TicketLink) (db.TicketLink, error)); ok {
+		return rf(link)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.PeopleExtra); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(db.TicketLink) db.TicketLink); ok {
+		r0 = rf(link)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.PeopleExtra)
-		}
+		r0 = ret.Get(0).(db.TicketLink)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(db.TicketLink) error); ok {
+		r1 = rf(link)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3760,105 +4276,111 @@ func (_m *Database) GetListedOffers(r *http.Request) ([]db.PeopleExtra, error) {
 	return r0, r1
 }
 
-// Database_GetListedOffers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedOffers'
-type Database_GetListedOffers_Call struct {
+// Database_CreateTicketLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTicketLink'
+type Database_CreateTicketLink_Call struct {
 	*mock.Call
 }
 
-// GetListedOffers is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedOffers(r interface{}) *Database_GetListedOffers_Call {
-	return &Database_GetListedOffers_Call{Call: _e.mock.On("GetListedOffers", r)}
+// CreateTicketLink is a helper method to define mock.On call
+//   - link db.TicketLink
+func (_e *Database_Expecter) CreateTicketLink(link interface{}) *Database_CreateTicketLink_Call {
+	return &Database_CreateTicketLink_Call{Call: _e.mock.On("CreateTicketLink", link)}
 }
 
-func (_c *Database_GetListedOffers_Call) Run(run func(r *http.Request)) *Database_GetListedOffers_Call {
+func (_c *Database_CreateTicketLink_Call) Run(run func(link db.TicketLink)) *Database_CreateTicketLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.TicketLink))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedOffers_Call) Return(_a0 []db.PeopleExtra, _a1 error) *Database_GetListedOffers_Call {
+func (_c *Database_CreateTicketLink_Call) Return(_a0 db.TicketLink, _a1 error) *Database_CreateTicketLink_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetListedOffers_Call) RunAndReturn(run func(*http.Request) ([]db.PeopleExtra, error)) *Database_GetListedOffers_Call {
+func (_c *Database_CreateTicketLink_Call) RunAndReturn(run func(db.TicketLink) (db.TicketLink, error)) *Database_CreateTicketLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedPeople provides a mock function with given fields: r
-func (_m *Database) GetListedPeople(r *http.Request) []db.Person {
-	ret := _m.Called(r)
+// CreateTicketRevision provides a mock function with given fields: revision
+func (_m *Database) CreateTicketRevision(revision db.TicketRevision) (db.TicketRevision, error) {
+	ret := _m.Called(revision)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedPeople")
+		panic("no return value specified for CreateTicketRevision")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Person); ok {
-		r0 = rf(r)
+	var r0 db.TicketRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TicketRevision) (db.TicketRevision, error)); ok {
+		return rf(revision)
+	}
+	if rf, ok := ret.Get(0).(func(db.TicketRevision) db.TicketRevision); ok {
+		r0 = rf(revision)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Get(0).(db.TicketRevision)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.TicketRevision) error); ok {
+		r1 = rf(revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetListedPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedPeople'
-type Database_GetListedPeople_Call struct {
+// Database_CreateTicketRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTicketRevision'
+type Database_CreateTicketRevision_Call struct {
 	*mock.Call
 }
 
-// GetListedPeople is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedPeople(r interface{}) *Database_GetListedPeople_Call {
-	return &Database_GetListedPeople_Call{Call: _e.mock.On("GetListedPeople", r)}
+// CreateTicketRevision is a helper method to define mock.On call
+//   - revision db.TicketRevision
+func (_e *Database_Expecter) CreateTicketRevision(revision interface{}) *Database_CreateTicketRevision_Call {
+	return &Database_CreateTicketRevision_Call{Call: _e.mock.On("CreateTicketRevision", revision)}
 }
 
-func (_c *Database_GetListedPeople_Call) Run(run func(r *http.Request)) *Database_GetListedPeople_Call {
+func (_c *Database_CreateTicketRevision_Call) Run(run func(revision db.TicketRevision)) *Database_CreateTicketRevision_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.TicketRevision))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedPeople_Call) Return(_a0 []db.Person) *Database_GetListedPeople_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_CreateTicketRevision_Call) Return(_a0 db.TicketRevision, _a1 error) *Database_CreateTicketRevision_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetListedPeople_Call) RunAndReturn(run func(*http.Request) []db.Person) *Database_GetListedPeople_Call {
+func (_c *Database_CreateTicketRevision_Call) RunAndReturn(run func(db.TicketRevision) (db.TicketRevision, error)) *Database_CreateTicketRevision_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedPosts provides a mock function with given fields: r
-func (_m *Database) GetListedPosts(r *http.Request) ([]db.PeopleExtra, error) {
-	ret := _m.Called(r)
+// CreateTribeInvite provides a mock function with given fields: m
+func (_m *Database) CreateTribeInvite(m db.TribeInvite) (db.TribeInvite, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedPosts")
+		panic("no return value specified for CreateTribeInvite")
 	}
 
-	var r0 []db.PeopleExtra
+	var r0 db.TribeInvite
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.PeopleExtra, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(db.TribeInvite) (db.TribeInvite, error)); ok {
+		return rf(m)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.PeopleExtra); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(db.TribeInvite) db.TribeInvite); ok {
+		r0 = rf(m)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.PeopleExtra)
-		}
+		r0 = ret.Get(0).(db.TribeInvite)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(db.TribeInvite) error); ok {
+		r1 = rf(m)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3866,261 +4388,15309 @@ func (_m *Database) GetListedPosts(r *http.Request) ([]db.PeopleExtra, error) {
 	return r0, r1
 }
 
-// Database_GetListedPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedPosts'
-type Database_GetListedPosts_Call struct {
+// Database_CreateTribeInvite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTribeInvite'
+type Database_CreateTribeInvite_Call struct {
 	*mock.Call
 }
 
-// GetListedPosts is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedPosts(r interface{}) *Database_GetListedPosts_Call {
-	return &Database_GetListedPosts_Call{Call: _e.mock.On("GetListedPosts", r)}
+// CreateTribeInvite is a helper method to define mock.On call
+//   - m db.TribeInvite
+func (_e *Database_Expecter) CreateTribeInvite(m interface{}) *Database_CreateTribeInvite_Call {
+	return &Database_CreateTribeInvite_Call{Call: _e.mock.On("CreateTribeInvite", m)}
 }
 
-func (_c *Database_GetListedPosts_Call) Run(run func(r *http.Request)) *Database_GetListedPosts_Call {
+func (_c *Database_CreateTribeInvite_Call) Run(run func(m db.TribeInvite)) *Database_CreateTribeInvite_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.TribeInvite))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedPosts_Call) Return(_a0 []db.PeopleExtra, _a1 error) *Database_GetListedPosts_Call {
+func (_c *Database_CreateTribeInvite_Call) Return(_a0 db.TribeInvite, _a1 error) *Database_CreateTribeInvite_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetListedPosts_Call) RunAndReturn(run func(*http.Request) ([]db.PeopleExtra, error)) *Database_GetListedPosts_Call {
+func (_c *Database_CreateTribeInvite_Call) RunAndReturn(run func(db.TribeInvite) (db.TribeInvite, error)) *Database_CreateTribeInvite_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedTribes provides a mock function with given fields: r
-func (_m *Database) GetListedTribes(r *http.Request) []db.Tribe {
-	ret := _m.Called(r)
+// CreateTribeVerification provides a mock function with given fields: m
+func (_m *Database) CreateTribeVerification(m db.TribeVerification) (db.TribeVerification, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedTribes")
+		panic("no return value specified for CreateTribeVerification")
+	}
+
+	var r0 db.TribeVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TribeVerification) (db.TribeVerification, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.TribeVerification) db.TribeVerification); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.TribeVerification)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.TribeVerification) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateTribeVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTribeVerification'
+type Database_CreateTribeVerification_Call struct {
+	*mock.Call
+}
+
+// CreateTribeVerification is a helper method to define mock.On call
+//   - m db.TribeVerification
+func (_e *Database_Expecter) CreateTribeVerification(m interface{}) *Database_CreateTribeVerification_Call {
+	return &Database_CreateTribeVerification_Call{Call: _e.mock.On("CreateTribeVerification", m)}
+}
+
+func (_c *Database_CreateTribeVerification_Call) Run(run func(m db.TribeVerification)) *Database_CreateTribeVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.TribeVerification))
+	})
+	return _c
+}
+
+func (_c *Database_CreateTribeVerification_Call) Return(_a0 db.TribeVerification, _a1 error) *Database_CreateTribeVerification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateTribeVerification_Call) RunAndReturn(run func(db.TribeVerification) (db.TribeVerification, error)) *Database_CreateTribeVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUserRoles provides a mock function with given fields: roles, uuid, pubkey
+func (_m *Database) CreateUserRoles(roles []db.WorkspaceUserRoles, uuid string, pubkey string) []db.WorkspaceUserRoles {
+	ret := _m.Called(roles, uuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserRoles")
+	}
+
+	var r0 []db.WorkspaceUserRoles
+	if rf, ok := ret.Get(0).(func([]db.WorkspaceUserRoles, string, string) []db.WorkspaceUserRoles); ok {
+		r0 = rf(roles, uuid, pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceUserRoles)
+		}
+	}
+
+	return r0
+}
+
+// Database_CreateUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUserRoles'
+type Database_CreateUserRoles_Call struct {
+	*mock.Call
+}
+
+// CreateUserRoles is a helper method to define mock.On call
+//   - roles []db.WorkspaceUserRoles
+//   - uuid string
+//   - pubkey string
+func (_e *Database_Expecter) CreateUserRoles(roles interface{}, uuid interface{}, pubkey interface{}) *Database_CreateUserRoles_Call {
+	return &Database_CreateUserRoles_Call{Call: _e.mock.On("CreateUserRoles", roles, uuid, pubkey)}
+}
+
+func (_c *Database_CreateUserRoles_Call) Run(run func(roles []db.WorkspaceUserRoles, uuid string, pubkey string)) *Database_CreateUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]db.WorkspaceUserRoles), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Database_CreateUserRoles_Call) Return(_a0 []db.WorkspaceUserRoles) *Database_CreateUserRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateUserRoles_Call) RunAndReturn(run func([]db.WorkspaceUserRoles, string, string) []db.WorkspaceUserRoles) *Database_CreateUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhookDelivery provides a mock function with given fields: m
+func (_m *Database) CreateWebhookDelivery(m db.WebhookDelivery) (db.WebhookDelivery, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhookDelivery")
+	}
+
+	var r0 db.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WebhookDelivery) (db.WebhookDelivery, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.WebhookDelivery) db.WebhookDelivery); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.WebhookDelivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WebhookDelivery) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhookDelivery'
+type Database_CreateWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// CreateWebhookDelivery is a helper method to define mock.On call
+//   - m db.WebhookDelivery
+func (_e *Database_Expecter) CreateWebhookDelivery(m interface{}) *Database_CreateWebhookDelivery_Call {
+	return &Database_CreateWebhookDelivery_Call{Call: _e.mock.On("CreateWebhookDelivery", m)}
+}
+
+func (_c *Database_CreateWebhookDelivery_Call) Run(run func(m db.WebhookDelivery)) *Database_CreateWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWebhookDelivery_Call) Return(_a0 db.WebhookDelivery, _a1 error) *Database_CreateWebhookDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateWebhookDelivery_Call) RunAndReturn(run func(db.WebhookDelivery) (db.WebhookDelivery, error)) *Database_CreateWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkSession provides a mock function with given fields: m
+func (_m *Database) CreateWorkSession(m db.BountyWorkSession) (db.BountyWorkSession, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkSession")
+	}
+
+	var r0 db.BountyWorkSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyWorkSession) (db.BountyWorkSession, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyWorkSession) db.BountyWorkSession); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.BountyWorkSession)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BountyWorkSession) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateWorkSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkSession'
+type Database_CreateWorkSession_Call struct {
+	*mock.Call
+}
+
+// CreateWorkSession is a helper method to define mock.On call
+//   - m db.BountyWorkSession
+func (_e *Database_Expecter) CreateWorkSession(m interface{}) *Database_CreateWorkSession_Call {
+	return &Database_CreateWorkSession_Call{Call: _e.mock.On("CreateWorkSession", m)}
+}
+
+func (_c *Database_CreateWorkSession_Call) Run(run func(m db.BountyWorkSession)) *Database_CreateWorkSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BountyWorkSession))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkSession_Call) Return(_a0 db.BountyWorkSession, _a1 error) *Database_CreateWorkSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateWorkSession_Call) RunAndReturn(run func(db.BountyWorkSession) (db.BountyWorkSession, error)) *Database_CreateWorkSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkflowExecution provides a mock function with given fields: workflow, trigger, input
+func (_m *Database) CreateWorkflowExecution(workflow db.Workflow, trigger string, input db.PropertyMap) (db.WorkflowExecution, error) {
+	ret := _m.Called(workflow, trigger, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkflowExecution")
+	}
+
+	var r0 db.WorkflowExecution
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Workflow, string, db.PropertyMap) (db.WorkflowExecution, error)); ok {
+		return rf(workflow, trigger, input)
+	}
+	if rf, ok := ret.Get(0).(func(db.Workflow, string, db.PropertyMap) db.WorkflowExecution); ok {
+		r0 = rf(workflow, trigger, input)
+	} else {
+		r0 = ret.Get(0).(db.WorkflowExecution)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Workflow, string, db.PropertyMap) error); ok {
+		r1 = rf(workflow, trigger, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateWorkflowExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkflowExecution'
+type Database_CreateWorkflowExecution_Call struct {
+	*mock.Call
+}
+
+// CreateWorkflowExecution is a helper method to define mock.On call
+//   - workflow db.Workflow
+//   - trigger string
+//   - input db.PropertyMap
+func (_e *Database_Expecter) CreateWorkflowExecution(workflow interface{}, trigger interface{}, input interface{}) *Database_CreateWorkflowExecution_Call {
+	return &Database_CreateWorkflowExecution_Call{Call: _e.mock.On("CreateWorkflowExecution", workflow, trigger, input)}
+}
+
+func (_c *Database_CreateWorkflowExecution_Call) Run(run func(workflow db.Workflow, trigger string, input db.PropertyMap)) *Database_CreateWorkflowExecution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Workflow), args[1].(string), args[2].(db.PropertyMap))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkflowExecution_Call) Return(_a0 db.WorkflowExecution, _a1 error) *Database_CreateWorkflowExecution_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateWorkflowExecution_Call) RunAndReturn(run func(db.Workflow, string, db.PropertyMap) (db.WorkflowExecution, error)) *Database_CreateWorkflowExecution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspaceBudget provides a mock function with given fields: budget
+func (_m *Database) CreateWorkspaceBudget(budget db.NewBountyBudget) db.NewBountyBudget {
+	ret := _m.Called(budget)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspaceBudget")
+	}
+
+	var r0 db.NewBountyBudget
+	if rf, ok := ret.Get(0).(func(db.NewBountyBudget) db.NewBountyBudget); ok {
+		r0 = rf(budget)
+	} else {
+		r0 = ret.Get(0).(db.NewBountyBudget)
+	}
+
+	return r0
+}
+
+// Database_CreateWorkspaceBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceBudget'
+type Database_CreateWorkspaceBudget_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceBudget is a helper method to define mock.On call
+//   - budget db.NewBountyBudget
+func (_e *Database_Expecter) CreateWorkspaceBudget(budget interface{}) *Database_CreateWorkspaceBudget_Call {
+	return &Database_CreateWorkspaceBudget_Call{Call: _e.mock.On("CreateWorkspaceBudget", budget)}
+}
+
+func (_c *Database_CreateWorkspaceBudget_Call) Run(run func(budget db.NewBountyBudget)) *Database_CreateWorkspaceBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewBountyBudget))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceBudget_Call) Return(_a0 db.NewBountyBudget) *Database_CreateWorkspaceBudget_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceBudget_Call) RunAndReturn(run func(db.NewBountyBudget) db.NewBountyBudget) *Database_CreateWorkspaceBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspaceTransfer provides a mock function with given fields: m
+func (_m *Database) CreateWorkspaceTransfer(m db.WorkspaceTransfer) (db.WorkspaceTransfer, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspaceTransfer")
+	}
+
+	var r0 db.WorkspaceTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceTransfer) (db.WorkspaceTransfer, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceTransfer) db.WorkspaceTransfer); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceTransfer) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateWorkspaceTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceTransfer'
+type Database_CreateWorkspaceTransfer_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceTransfer is a helper method to define mock.On call
+//   - m db.WorkspaceTransfer
+func (_e *Database_Expecter) CreateWorkspaceTransfer(m interface{}) *Database_CreateWorkspaceTransfer_Call {
+	return &Database_CreateWorkspaceTransfer_Call{Call: _e.mock.On("CreateWorkspaceTransfer", m)}
+}
+
+func (_c *Database_CreateWorkspaceTransfer_Call) Run(run func(m db.WorkspaceTransfer)) *Database_CreateWorkspaceTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceTransfer))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceTransfer_Call) Return(_a0 db.WorkspaceTransfer, _a1 error) *Database_CreateWorkspaceTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceTransfer_Call) RunAndReturn(run func(db.WorkspaceTransfer) (db.WorkspaceTransfer, error)) *Database_CreateWorkspaceTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspaceUser provides a mock function with given fields: orgUser
+func (_m *Database) CreateWorkspaceUser(orgUser db.WorkspaceUsers) db.WorkspaceUsers {
+	ret := _m.Called(orgUser)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspaceUser")
+	}
+
+	var r0 db.WorkspaceUsers
+	if rf, ok := ret.Get(0).(func(db.WorkspaceUsers) db.WorkspaceUsers); ok {
+		r0 = rf(orgUser)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceUsers)
+	}
+
+	return r0
+}
+
+// Database_CreateWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceUser'
+type Database_CreateWorkspaceUser_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceUser is a helper method to define mock.On call
+//   - orgUser db.WorkspaceUsers
+func (_e *Database_Expecter) CreateWorkspaceUser(orgUser interface{}) *Database_CreateWorkspaceUser_Call {
+	return &Database_CreateWorkspaceUser_Call{Call: _e.mock.On("CreateWorkspaceUser", orgUser)}
+}
+
+func (_c *Database_CreateWorkspaceUser_Call) Run(run func(orgUser db.WorkspaceUsers)) *Database_CreateWorkspaceUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceUsers))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceUser_Call) Return(_a0 db.WorkspaceUsers) *Database_CreateWorkspaceUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceUser_Call) RunAndReturn(run func(db.WorkspaceUsers) db.WorkspaceUsers) *Database_CreateWorkspaceUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAllUsersFromWorkspace provides a mock function with given fields: uuid
+func (_m *Database) DeleteAllUsersFromWorkspace(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllUsersFromWorkspace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteAllUsersFromWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAllUsersFromWorkspace'
+type Database_DeleteAllUsersFromWorkspace_Call struct {
+	*mock.Call
+}
+
+// DeleteAllUsersFromWorkspace is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteAllUsersFromWorkspace(uuid interface{}) *Database_DeleteAllUsersFromWorkspace_Call {
+	return &Database_DeleteAllUsersFromWorkspace_Call{Call: _e.mock.On("DeleteAllUsersFromWorkspace", uuid)}
+}
+
+func (_c *Database_DeleteAllUsersFromWorkspace_Call) Run(run func(uuid string)) *Database_DeleteAllUsersFromWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteAllUsersFromWorkspace_Call) Return(_a0 error) *Database_DeleteAllUsersFromWorkspace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteAllUsersFromWorkspace_Call) RunAndReturn(run func(string) error) *Database_DeleteAllUsersFromWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAttachment provides a mock function with given fields: id
+func (_m *Database) DeleteAttachment(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAttachment'
+type Database_DeleteAttachment_Call struct {
+	*mock.Call
+}
+
+// DeleteAttachment is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) DeleteAttachment(id interface{}) *Database_DeleteAttachment_Call {
+	return &Database_DeleteAttachment_Call{Call: _e.mock.On("DeleteAttachment", id)}
+}
+
+func (_c *Database_DeleteAttachment_Call) Run(run func(id uint)) *Database_DeleteAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteAttachment_Call) Return(_a0 error) *Database_DeleteAttachment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteAttachment_Call) RunAndReturn(run func(uint) error) *Database_DeleteAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBotCategory provides a mock function with given fields: slug
+func (_m *Database) DeleteBotCategory(slug string) error {
+	ret := _m.Called(slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBotCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(slug)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteBotCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBotCategory'
+type Database_DeleteBotCategory_Call struct {
+	*mock.Call
+}
+
+// DeleteBotCategory is a helper method to define mock.On call
+//   - slug string
+func (_e *Database_Expecter) DeleteBotCategory(slug interface{}) *Database_DeleteBotCategory_Call {
+	return &Database_DeleteBotCategory_Call{Call: _e.mock.On("DeleteBotCategory", slug)}
+}
+
+func (_c *Database_DeleteBotCategory_Call) Run(run func(slug string)) *Database_DeleteBotCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteBotCategory_Call) Return(_a0 error) *Database_DeleteBotCategory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteBotCategory_Call) RunAndReturn(run func(string) error) *Database_DeleteBotCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBotReview provides a mock function with given fields: botUuid, pubkey
+func (_m *Database) DeleteBotReview(botUuid string, pubkey string) error {
+	ret := _m.Called(botUuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBotReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(botUuid, pubkey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteBotReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBotReview'
+type Database_DeleteBotReview_Call struct {
+	*mock.Call
+}
+
+// DeleteBotReview is a helper method to define mock.On call
+//   - botUuid string
+//   - pubkey string
+func (_e *Database_Expecter) DeleteBotReview(botUuid interface{}, pubkey interface{}) *Database_DeleteBotReview_Call {
+	return &Database_DeleteBotReview_Call{Call: _e.mock.On("DeleteBotReview", botUuid, pubkey)}
+}
+
+func (_c *Database_DeleteBotReview_Call) Run(run func(botUuid string, pubkey string)) *Database_DeleteBotReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteBotReview_Call) Return(_a0 error) *Database_DeleteBotReview_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteBotReview_Call) RunAndReturn(run func(string, string) error) *Database_DeleteBotReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBotWebhook provides a mock function with given fields: uuid
+func (_m *Database) DeleteBotWebhook(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBotWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteBotWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBotWebhook'
+type Database_DeleteBotWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteBotWebhook is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteBotWebhook(uuid interface{}) *Database_DeleteBotWebhook_Call {
+	return &Database_DeleteBotWebhook_Call{Call: _e.mock.On("DeleteBotWebhook", uuid)}
+}
+
+func (_c *Database_DeleteBotWebhook_Call) Run(run func(uuid string)) *Database_DeleteBotWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteBotWebhook_Call) Return(_a0 error) *Database_DeleteBotWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteBotWebhook_Call) RunAndReturn(run func(string) error) *Database_DeleteBotWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBounty provides a mock function with given fields: pubkey, created
+func (_m *Database) DeleteBounty(pubkey string, created string) (db.NewBounty, error) {
+	ret := _m.Called(pubkey, created)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBounty")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.NewBounty, error)); ok {
+		return rf(pubkey, created)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.NewBounty); ok {
+		r0 = rf(pubkey, created)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(pubkey, created)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_DeleteBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBounty'
+type Database_DeleteBounty_Call struct {
+	*mock.Call
+}
+
+// DeleteBounty is a helper method to define mock.On call
+//   - pubkey string
+//   - created string
+func (_e *Database_Expecter) DeleteBounty(pubkey interface{}, created interface{}) *Database_DeleteBounty_Call {
+	return &Database_DeleteBounty_Call{Call: _e.mock.On("DeleteBounty", pubkey, created)}
+}
+
+func (_c *Database_DeleteBounty_Call) Run(run func(pubkey string, created string)) *Database_DeleteBounty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_DeleteBounty_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_DeleteBounty_Call) RunAndReturn(run func(string, string) (db.NewBounty, error)) *Database_DeleteBounty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpiredIdempotencyKeys provides a mock function with given fields:
+func (_m *Database) DeleteExpiredIdempotencyKeys() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredIdempotencyKeys")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteExpiredIdempotencyKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredIdempotencyKeys'
+type Database_DeleteExpiredIdempotencyKeys_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredIdempotencyKeys is a helper method to define mock.On call
+func (_e *Database_Expecter) DeleteExpiredIdempotencyKeys() *Database_DeleteExpiredIdempotencyKeys_Call {
+	return &Database_DeleteExpiredIdempotencyKeys_Call{Call: _e.mock.On("DeleteExpiredIdempotencyKeys")}
+}
+
+func (_c *Database_DeleteExpiredIdempotencyKeys_Call) Run(run func()) *Database_DeleteExpiredIdempotencyKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_DeleteExpiredIdempotencyKeys_Call) Return(_a0 error) *Database_DeleteExpiredIdempotencyKeys_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteExpiredIdempotencyKeys_Call) RunAndReturn(run func() error) *Database_DeleteExpiredIdempotencyKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFeatureByUuid provides a mock function with given fields: uuid
+func (_m *Database) DeleteFeatureByUuid(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFeatureByUuid")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteFeatureByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeatureByUuid'
+type Database_DeleteFeatureByUuid_Call struct {
+	*mock.Call
+}
+
+// DeleteFeatureByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteFeatureByUuid(uuid interface{}) *Database_DeleteFeatureByUuid_Call {
+	return &Database_DeleteFeatureByUuid_Call{Call: _e.mock.On("DeleteFeatureByUuid", uuid)}
+}
+
+func (_c *Database_DeleteFeatureByUuid_Call) Run(run func(uuid string)) *Database_DeleteFeatureByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteFeatureByUuid_Call) Return(_a0 error) *Database_DeleteFeatureByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteFeatureByUuid_Call) RunAndReturn(run func(string) error) *Database_DeleteFeatureByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFeatureDependency provides a mock function with given fields: id
+func (_m *Database) DeleteFeatureDependency(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFeatureDependency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteFeatureDependency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeatureDependency'
+type Database_DeleteFeatureDependency_Call struct {
+	*mock.Call
+}
+
+// DeleteFeatureDependency is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) DeleteFeatureDependency(id interface{}) *Database_DeleteFeatureDependency_Call {
+	return &Database_DeleteFeatureDependency_Call{Call: _e.mock.On("DeleteFeatureDependency", id)}
+}
+
+func (_c *Database_DeleteFeatureDependency_Call) Run(run func(id uint)) *Database_DeleteFeatureDependency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteFeatureDependency_Call) Return(_a0 error) *Database_DeleteFeatureDependency_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteFeatureDependency_Call) RunAndReturn(run func(uint) error) *Database_DeleteFeatureDependency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFeaturePhase provides a mock function with given fields: featureUuid, phaseUuid
+func (_m *Database) DeleteFeaturePhase(featureUuid string, phaseUuid string) error {
+	ret := _m.Called(featureUuid, phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFeaturePhase")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(featureUuid, phaseUuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteFeaturePhase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeaturePhase'
+type Database_DeleteFeaturePhase_Call struct {
+	*mock.Call
+}
+
+// DeleteFeaturePhase is a helper method to define mock.On call
+//   - featureUuid string
+//   - phaseUuid string
+func (_e *Database_Expecter) DeleteFeaturePhase(featureUuid interface{}, phaseUuid interface{}) *Database_DeleteFeaturePhase_Call {
+	return &Database_DeleteFeaturePhase_Call{Call: _e.mock.On("DeleteFeaturePhase", featureUuid, phaseUuid)}
+}
+
+func (_c *Database_DeleteFeaturePhase_Call) Run(run func(featureUuid string, phaseUuid string)) *Database_DeleteFeaturePhase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteFeaturePhase_Call) Return(_a0 error) *Database_DeleteFeaturePhase_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteFeaturePhase_Call) RunAndReturn(run func(string, string) error) *Database_DeleteFeaturePhase_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFeatureStoryByUuid provides a mock function with given fields: featureUuid, storyUuid
+func (_m *Database) DeleteFeatureStoryByUuid(featureUuid string, storyUuid string) error {
+	ret := _m.Called(featureUuid, storyUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFeatureStoryByUuid")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(featureUuid, storyUuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteFeatureStoryByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeatureStoryByUuid'
+type Database_DeleteFeatureStoryByUuid_Call struct {
+	*mock.Call
+}
+
+// DeleteFeatureStoryByUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - storyUuid string
+func (_e *Database_Expecter) DeleteFeatureStoryByUuid(featureUuid interface{}, storyUuid interface{}) *Database_DeleteFeatureStoryByUuid_Call {
+	return &Database_DeleteFeatureStoryByUuid_Call{Call: _e.mock.On("DeleteFeatureStoryByUuid", featureUuid, storyUuid)}
+}
+
+func (_c *Database_DeleteFeatureStoryByUuid_Call) Run(run func(featureUuid string, storyUuid string)) *Database_DeleteFeatureStoryByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteFeatureStoryByUuid_Call) Return(_a0 error) *Database_DeleteFeatureStoryByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteFeatureStoryByUuid_Call) RunAndReturn(run func(string, string) error) *Database_DeleteFeatureStoryByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIdempotencyKey provides a mock function with given fields: id
+func (_m *Database) DeleteIdempotencyKey(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIdempotencyKey'
+type Database_DeleteIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// DeleteIdempotencyKey is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) DeleteIdempotencyKey(id interface{}) *Database_DeleteIdempotencyKey_Call {
+	return &Database_DeleteIdempotencyKey_Call{Call: _e.mock.On("DeleteIdempotencyKey", id)}
+}
+
+func (_c *Database_DeleteIdempotencyKey_Call) Run(run func(id uint)) *Database_DeleteIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteIdempotencyKey_Call) Return(_a0 error) *Database_DeleteIdempotencyKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteIdempotencyKey_Call) RunAndReturn(run func(uint) error) *Database_DeleteIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteInvoice provides a mock function with given fields: payment_request
+func (_m *Database) DeleteInvoice(payment_request string) db.NewInvoiceList {
+	ret := _m.Called(payment_request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInvoice")
+	}
+
+	var r0 db.NewInvoiceList
+	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
+		r0 = rf(payment_request)
+	} else {
+		r0 = ret.Get(0).(db.NewInvoiceList)
+	}
+
+	return r0
+}
+
+// Database_DeleteInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInvoice'
+type Database_DeleteInvoice_Call struct {
+	*mock.Call
+}
+
+// DeleteInvoice is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) DeleteInvoice(payment_request interface{}) *Database_DeleteInvoice_Call {
+	return &Database_DeleteInvoice_Call{Call: _e.mock.On("DeleteInvoice", payment_request)}
+}
+
+func (_c *Database_DeleteInvoice_Call) Run(run func(payment_request string)) *Database_DeleteInvoice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_DeleteInvoice_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_DeleteInvoice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteNostrIdentity provides a mock function with given fields: pubkey, nostrPubkey
+func (_m *Database) DeleteNostrIdentity(pubkey string, nostrPubkey string) error {
+	ret := _m.Called(pubkey, nostrPubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNostrIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(pubkey, nostrPubkey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteNostrIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNostrIdentity'
+type Database_DeleteNostrIdentity_Call struct {
+	*mock.Call
+}
+
+// DeleteNostrIdentity is a helper method to define mock.On call
+//   - pubkey string
+//   - nostrPubkey string
+func (_e *Database_Expecter) DeleteNostrIdentity(pubkey interface{}, nostrPubkey interface{}) *Database_DeleteNostrIdentity_Call {
+	return &Database_DeleteNostrIdentity_Call{Call: _e.mock.On("DeleteNostrIdentity", pubkey, nostrPubkey)}
+}
+
+func (_c *Database_DeleteNostrIdentity_Call) Run(run func(pubkey string, nostrPubkey string)) *Database_DeleteNostrIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteNostrIdentity_Call) Return(_a0 error) *Database_DeleteNostrIdentity_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteNostrIdentity_Call) RunAndReturn(run func(string, string) error) *Database_DeleteNostrIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSavedSearch provides a mock function with given fields: pubkey, id
+func (_m *Database) DeleteSavedSearch(pubkey string, id uint) error {
+	ret := _m.Called(pubkey, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSavedSearch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, uint) error); ok {
+		r0 = rf(pubkey, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteSavedSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSavedSearch'
+type Database_DeleteSavedSearch_Call struct {
+	*mock.Call
+}
+
+// DeleteSavedSearch is a helper method to define mock.On call
+//   - pubkey string
+//   - id uint
+func (_e *Database_Expecter) DeleteSavedSearch(pubkey interface{}, id interface{}) *Database_DeleteSavedSearch_Call {
+	return &Database_DeleteSavedSearch_Call{Call: _e.mock.On("DeleteSavedSearch", pubkey, id)}
+}
+
+func (_c *Database_DeleteSavedSearch_Call) Run(run func(pubkey string, id uint)) *Database_DeleteSavedSearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteSavedSearch_Call) Return(_a0 error) *Database_DeleteSavedSearch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteSavedSearch_Call) RunAndReturn(run func(string, uint) error) *Database_DeleteSavedSearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSearchIndexChanges provides a mock function with given fields: upTo
+func (_m *Database) DeleteSearchIndexChanges(upTo uint) error {
+	ret := _m.Called(upTo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSearchIndexChanges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(upTo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteSearchIndexChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSearchIndexChanges'
+type Database_DeleteSearchIndexChanges_Call struct {
+	*mock.Call
+}
+
+// DeleteSearchIndexChanges is a helper method to define mock.On call
+//   - upTo uint
+func (_e *Database_Expecter) DeleteSearchIndexChanges(upTo interface{}) *Database_DeleteSearchIndexChanges_Call {
+	return &Database_DeleteSearchIndexChanges_Call{Call: _e.mock.On("DeleteSearchIndexChanges", upTo)}
+}
+
+func (_c *Database_DeleteSearchIndexChanges_Call) Run(run func(upTo uint)) *Database_DeleteSearchIndexChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteSearchIndexChanges_Call) Return(_a0 error) *Database_DeleteSearchIndexChanges_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteSearchIndexChanges_Call) RunAndReturn(run func(uint) error) *Database_DeleteSearchIndexChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSuperAdmin provides a mock function with given fields: pubkey
+func (_m *Database) DeleteSuperAdmin(pubkey string) error {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSuperAdmin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteSuperAdmin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSuperAdmin'
+type Database_DeleteSuperAdmin_Call struct {
+	*mock.Call
+}
+
+// DeleteSuperAdmin is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) DeleteSuperAdmin(pubkey interface{}) *Database_DeleteSuperAdmin_Call {
+	return &Database_DeleteSuperAdmin_Call{Call: _e.mock.On("DeleteSuperAdmin", pubkey)}
+}
+
+func (_c *Database_DeleteSuperAdmin_Call) Run(run func(pubkey string)) *Database_DeleteSuperAdmin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteSuperAdmin_Call) Return(_a0 error) *Database_DeleteSuperAdmin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteSuperAdmin_Call) RunAndReturn(run func(string) error) *Database_DeleteSuperAdmin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTicketLink provides a mock function with given fields: id
+func (_m *Database) DeleteTicketLink(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTicketLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteTicketLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTicketLink'
+type Database_DeleteTicketLink_Call struct {
+	*mock.Call
+}
+
+// DeleteTicketLink is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) DeleteTicketLink(id interface{}) *Database_DeleteTicketLink_Call {
+	return &Database_DeleteTicketLink_Call{Call: _e.mock.On("DeleteTicketLink", id)}
+}
+
+func (_c *Database_DeleteTicketLink_Call) Run(run func(id uint)) *Database_DeleteTicketLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteTicketLink_Call) Return(_a0 error) *Database_DeleteTicketLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteTicketLink_Call) RunAndReturn(run func(uint) error) *Database_DeleteTicketLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTribeBan provides a mock function with given fields: tribeUuid, pubkey
+func (_m *Database) DeleteTribeBan(tribeUuid string, pubkey string) error {
+	ret := _m.Called(tribeUuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTribeBan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(tribeUuid, pubkey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteTribeBan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTribeBan'
+type Database_DeleteTribeBan_Call struct {
+	*mock.Call
+}
+
+// DeleteTribeBan is a helper method to define mock.On call
+//   - tribeUuid string
+//   - pubkey string
+func (_e *Database_Expecter) DeleteTribeBan(tribeUuid interface{}, pubkey interface{}) *Database_DeleteTribeBan_Call {
+	return &Database_DeleteTribeBan_Call{Call: _e.mock.On("DeleteTribeBan", tribeUuid, pubkey)}
+}
+
+func (_c *Database_DeleteTribeBan_Call) Run(run func(tribeUuid string, pubkey string)) *Database_DeleteTribeBan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteTribeBan_Call) Return(_a0 error) *Database_DeleteTribeBan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteTribeBan_Call) RunAndReturn(run func(string, string) error) *Database_DeleteTribeBan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTribeMember provides a mock function with given fields: tribeUuid, pubkey
+func (_m *Database) DeleteTribeMember(tribeUuid string, pubkey string) error {
+	ret := _m.Called(tribeUuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTribeMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(tribeUuid, pubkey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteTribeMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTribeMember'
+type Database_DeleteTribeMember_Call struct {
+	*mock.Call
+}
+
+// DeleteTribeMember is a helper method to define mock.On call
+//   - tribeUuid string
+//   - pubkey string
+func (_e *Database_Expecter) DeleteTribeMember(tribeUuid interface{}, pubkey interface{}) *Database_DeleteTribeMember_Call {
+	return &Database_DeleteTribeMember_Call{Call: _e.mock.On("DeleteTribeMember", tribeUuid, pubkey)}
+}
+
+func (_c *Database_DeleteTribeMember_Call) Run(run func(tribeUuid string, pubkey string)) *Database_DeleteTribeMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteTribeMember_Call) Return(_a0 error) *Database_DeleteTribeMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteTribeMember_Call) RunAndReturn(run func(string, string) error) *Database_DeleteTribeMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserInvoiceData provides a mock function with given fields: payment_request
+func (_m *Database) DeleteUserInvoiceData(payment_request string) db.UserInvoiceData {
+	ret := _m.Called(payment_request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserInvoiceData")
+	}
+
+	var r0 db.UserInvoiceData
+	if rf, ok := ret.Get(0).(func(string) db.UserInvoiceData); ok {
+		r0 = rf(payment_request)
+	} else {
+		r0 = ret.Get(0).(db.UserInvoiceData)
+	}
+
+	return r0
+}
+
+// Database_DeleteUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserInvoiceData'
+type Database_DeleteUserInvoiceData_Call struct {
+	*mock.Call
+}
+
+// DeleteUserInvoiceData is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) DeleteUserInvoiceData(payment_request interface{}) *Database_DeleteUserInvoiceData_Call {
+	return &Database_DeleteUserInvoiceData_Call{Call: _e.mock.On("DeleteUserInvoiceData", payment_request)}
+}
+
+func (_c *Database_DeleteUserInvoiceData_Call) Run(run func(payment_request string)) *Database_DeleteUserInvoiceData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_DeleteUserInvoiceData_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteUserInvoiceData_Call) RunAndReturn(run func(string) db.UserInvoiceData) *Database_DeleteUserInvoiceData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkspaceIntegration provides a mock function with given fields: uuid
+func (_m *Database) DeleteWorkspaceIntegration(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspaceIntegration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteWorkspaceIntegration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceIntegration'
+type Database_DeleteWorkspaceIntegration_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkspaceIntegration is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteWorkspaceIntegration(uuid interface{}) *Database_DeleteWorkspaceIntegration_Call {
+	return &Database_DeleteWorkspaceIntegration_Call{Call: _e.mock.On("DeleteWorkspaceIntegration", uuid)}
+}
+
+func (_c *Database_DeleteWorkspaceIntegration_Call) Run(run func(uuid string)) *Database_DeleteWorkspaceIntegration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceIntegration_Call) Return(_a0 error) *Database_DeleteWorkspaceIntegration_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceIntegration_Call) RunAndReturn(run func(string) error) *Database_DeleteWorkspaceIntegration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkspaceRepository provides a mock function with given fields: workspace_uuid, uuid
+func (_m *Database) DeleteWorkspaceRepository(workspace_uuid string, uuid string) bool {
+	ret := _m.Called(workspace_uuid, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspaceRepository")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(workspace_uuid, uuid)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Database_DeleteWorkspaceRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceRepository'
+type Database_DeleteWorkspaceRepository_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkspaceRepository is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - uuid string
+func (_e *Database_Expecter) DeleteWorkspaceRepository(workspace_uuid interface{}, uuid interface{}) *Database_DeleteWorkspaceRepository_Call {
+	return &Database_DeleteWorkspaceRepository_Call{Call: _e.mock.On("DeleteWorkspaceRepository", workspace_uuid, uuid)}
+}
+
+func (_c *Database_DeleteWorkspaceRepository_Call) Run(run func(workspace_uuid string, uuid string)) *Database_DeleteWorkspaceRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceRepository_Call) Return(_a0 bool) *Database_DeleteWorkspaceRepository_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceRepository_Call) RunAndReturn(run func(string, string) bool) *Database_DeleteWorkspaceRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkspaceTemplate provides a mock function with given fields: uuid
+func (_m *Database) DeleteWorkspaceTemplate(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspaceTemplate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteWorkspaceTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceTemplate'
+type Database_DeleteWorkspaceTemplate_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkspaceTemplate is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteWorkspaceTemplate(uuid interface{}) *Database_DeleteWorkspaceTemplate_Call {
+	return &Database_DeleteWorkspaceTemplate_Call{Call: _e.mock.On("DeleteWorkspaceTemplate", uuid)}
+}
+
+func (_c *Database_DeleteWorkspaceTemplate_Call) Run(run func(uuid string)) *Database_DeleteWorkspaceTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceTemplate_Call) Return(_a0 error) *Database_DeleteWorkspaceTemplate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceTemplate_Call) RunAndReturn(run func(string) error) *Database_DeleteWorkspaceTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkspaceUser provides a mock function with given fields: orgUser, org
+func (_m *Database) DeleteWorkspaceUser(orgUser db.WorkspaceUsersData, org string) db.WorkspaceUsersData {
+	ret := _m.Called(orgUser, org)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspaceUser")
+	}
+
+	var r0 db.WorkspaceUsersData
+	if rf, ok := ret.Get(0).(func(db.WorkspaceUsersData, string) db.WorkspaceUsersData); ok {
+		r0 = rf(orgUser, org)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceUsersData)
+	}
+
+	return r0
+}
+
+// Database_DeleteWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceUser'
+type Database_DeleteWorkspaceUser_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkspaceUser is a helper method to define mock.On call
+//   - orgUser db.WorkspaceUsersData
+//   - org string
+func (_e *Database_Expecter) DeleteWorkspaceUser(orgUser interface{}, org interface{}) *Database_DeleteWorkspaceUser_Call {
+	return &Database_DeleteWorkspaceUser_Call{Call: _e.mock.On("DeleteWorkspaceUser", orgUser, org)}
+}
+
+func (_c *Database_DeleteWorkspaceUser_Call) Run(run func(orgUser db.WorkspaceUsersData, org string)) *Database_DeleteWorkspaceUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceUsersData), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceUser_Call) Return(_a0 db.WorkspaceUsersData) *Database_DeleteWorkspaceUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceUser_Call) RunAndReturn(run func(db.WorkspaceUsersData, string) db.WorkspaceUsersData) *Database_DeleteWorkspaceUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EndImpersonation provides a mock function with given fields: uuid
+func (_m *Database) EndImpersonation(uuid string) (db.Impersonation, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EndImpersonation")
+	}
+
+	var r0 db.Impersonation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.Impersonation, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.Impersonation); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Impersonation)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_EndImpersonation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EndImpersonation'
+type Database_EndImpersonation_Call struct {
+	*mock.Call
+}
+
+// EndImpersonation is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) EndImpersonation(uuid interface{}) *Database_EndImpersonation_Call {
+	return &Database_EndImpersonation_Call{Call: _e.mock.On("EndImpersonation", uuid)}
+}
+
+func (_c *Database_EndImpersonation_Call) Run(run func(uuid string)) *Database_EndImpersonation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_EndImpersonation_Call) Return(_a0 db.Impersonation, _a1 error) *Database_EndImpersonation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_EndImpersonation_Call) RunAndReturn(run func(string) (db.Impersonation, error)) *Database_EndImpersonation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExpireWorkspaceTransfers provides a mock function with given fields: now
+func (_m *Database) ExpireWorkspaceTransfers(now time.Time) []db.WorkspaceTransfer {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireWorkspaceTransfers")
+	}
+
+	var r0 []db.WorkspaceTransfer
+	if rf, ok := ret.Get(0).(func(time.Time) []db.WorkspaceTransfer); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceTransfer)
+		}
+	}
+
+	return r0
+}
+
+// Database_ExpireWorkspaceTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireWorkspaceTransfers'
+type Database_ExpireWorkspaceTransfers_Call struct {
+	*mock.Call
+}
+
+// ExpireWorkspaceTransfers is a helper method to define mock.On call
+//   - now time.Time
+func (_e *Database_Expecter) ExpireWorkspaceTransfers(now interface{}) *Database_ExpireWorkspaceTransfers_Call {
+	return &Database_ExpireWorkspaceTransfers_Call{Call: _e.mock.On("ExpireWorkspaceTransfers", now)}
+}
+
+func (_c *Database_ExpireWorkspaceTransfers_Call) Run(run func(now time.Time)) *Database_ExpireWorkspaceTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_ExpireWorkspaceTransfers_Call) Return(_a0 []db.WorkspaceTransfer) *Database_ExpireWorkspaceTransfers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_ExpireWorkspaceTransfers_Call) RunAndReturn(run func(time.Time) []db.WorkspaceTransfer) *Database_ExpireWorkspaceTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExtendBountyAssignment provides a mock function with given fields: bountyId, deadline, staleAfter
+func (_m *Database) ExtendBountyAssignment(bountyId uint, deadline *time.Time, staleAfter string) error {
+	ret := _m.Called(bountyId, deadline, staleAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtendBountyAssignment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, *time.Time, string) error); ok {
+		r0 = rf(bountyId, deadline, staleAfter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_ExtendBountyAssignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtendBountyAssignment'
+type Database_ExtendBountyAssignment_Call struct {
+	*mock.Call
+}
+
+// ExtendBountyAssignment is a helper method to define mock.On call
+//   - bountyId uint
+//   - deadline *time.Time
+//   - staleAfter string
+func (_e *Database_Expecter) ExtendBountyAssignment(bountyId interface{}, deadline interface{}, staleAfter interface{}) *Database_ExtendBountyAssignment_Call {
+	return &Database_ExtendBountyAssignment_Call{Call: _e.mock.On("ExtendBountyAssignment", bountyId, deadline, staleAfter)}
+}
+
+func (_c *Database_ExtendBountyAssignment_Call) Run(run func(bountyId uint, deadline *time.Time, staleAfter string)) *Database_ExtendBountyAssignment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(*time.Time), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Database_ExtendBountyAssignment_Call) Return(_a0 error) *Database_ExtendBountyAssignment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_ExtendBountyAssignment_Call) RunAndReturn(run func(uint, *time.Time, string) error) *Database_ExtendBountyAssignment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinishMediaJob provides a mock function with given fields: job, jobErr
+func (_m *Database) FinishMediaJob(job db.MediaJob, jobErr error) error {
+	ret := _m.Called(job, jobErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishMediaJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.MediaJob, error) error); ok {
+		r0 = rf(job, jobErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_FinishMediaJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishMediaJob'
+type Database_FinishMediaJob_Call struct {
+	*mock.Call
+}
+
+// FinishMediaJob is a helper method to define mock.On call
+//   - job db.MediaJob
+//   - jobErr error
+func (_e *Database_Expecter) FinishMediaJob(job interface{}, jobErr interface{}) *Database_FinishMediaJob_Call {
+	return &Database_FinishMediaJob_Call{Call: _e.mock.On("FinishMediaJob", job, jobErr)}
+}
+
+func (_c *Database_FinishMediaJob_Call) Run(run func(job db.MediaJob, jobErr error)) *Database_FinishMediaJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.MediaJob), args[1].(error))
+	})
+	return _c
+}
+
+func (_c *Database_FinishMediaJob_Call) Return(_a0 error) *Database_FinishMediaJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_FinishMediaJob_Call) RunAndReturn(run func(db.MediaJob, error) error) *Database_FinishMediaJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveAuthSessions provides a mock function with given fields: pubkey
+func (_m *Database) GetActiveAuthSessions(pubkey string) []db.AuthSession {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveAuthSessions")
+	}
+
+	var r0 []db.AuthSession
+	if rf, ok := ret.Get(0).(func(string) []db.AuthSession); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.AuthSession)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetActiveAuthSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveAuthSessions'
+type Database_GetActiveAuthSessions_Call struct {
+	*mock.Call
+}
+
+// GetActiveAuthSessions is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetActiveAuthSessions(pubkey interface{}) *Database_GetActiveAuthSessions_Call {
+	return &Database_GetActiveAuthSessions_Call{Call: _e.mock.On("GetActiveAuthSessions", pubkey)}
+}
+
+func (_c *Database_GetActiveAuthSessions_Call) Run(run func(pubkey string)) *Database_GetActiveAuthSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetActiveAuthSessions_Call) Return(_a0 []db.AuthSession) *Database_GetActiveAuthSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetActiveAuthSessions_Call) RunAndReturn(run func(string) []db.AuthSession) *Database_GetActiveAuthSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActivePubkeyBan provides a mock function with given fields: pubkey
+func (_m *Database) GetActivePubkeyBan(pubkey string) db.PubkeyBan {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActivePubkeyBan")
+	}
+
+	var r0 db.PubkeyBan
+	if rf, ok := ret.Get(0).(func(string) db.PubkeyBan); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Get(0).(db.PubkeyBan)
+	}
+
+	return r0
+}
+
+// Database_GetActivePubkeyBan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActivePubkeyBan'
+type Database_GetActivePubkeyBan_Call struct {
+	*mock.Call
+}
+
+// GetActivePubkeyBan is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetActivePubkeyBan(pubkey interface{}) *Database_GetActivePubkeyBan_Call {
+	return &Database_GetActivePubkeyBan_Call{Call: _e.mock.On("GetActivePubkeyBan", pubkey)}
+}
+
+func (_c *Database_GetActivePubkeyBan_Call) Run(run func(pubkey string)) *Database_GetActivePubkeyBan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetActivePubkeyBan_Call) Return(_a0 db.PubkeyBan) *Database_GetActivePubkeyBan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetActivePubkeyBan_Call) RunAndReturn(run func(string) db.PubkeyBan) *Database_GetActivePubkeyBan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActivePubkeyBans provides a mock function with given fields:
+func (_m *Database) GetActivePubkeyBans() []db.PubkeyBan {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActivePubkeyBans")
+	}
+
+	var r0 []db.PubkeyBan
+	if rf, ok := ret.Get(0).(func() []db.PubkeyBan); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PubkeyBan)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetActivePubkeyBans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActivePubkeyBans'
+type Database_GetActivePubkeyBans_Call struct {
+	*mock.Call
+}
+
+// GetActivePubkeyBans is a helper method to define mock.On call
+func (_e *Database_Expecter) GetActivePubkeyBans() *Database_GetActivePubkeyBans_Call {
+	return &Database_GetActivePubkeyBans_Call{Call: _e.mock.On("GetActivePubkeyBans")}
+}
+
+func (_c *Database_GetActivePubkeyBans_Call) Run(run func()) *Database_GetActivePubkeyBans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetActivePubkeyBans_Call) Return(_a0 []db.PubkeyBan) *Database_GetActivePubkeyBans_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetActivePubkeyBans_Call) RunAndReturn(run func() []db.PubkeyBan) *Database_GetActivePubkeyBans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveWorkSession provides a mock function with given fields: bountyId, hunter
+func (_m *Database) GetActiveWorkSession(bountyId uint, hunter string) db.BountyWorkSession {
+	ret := _m.Called(bountyId, hunter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveWorkSession")
+	}
+
+	var r0 db.BountyWorkSession
+	if rf, ok := ret.Get(0).(func(uint, string) db.BountyWorkSession); ok {
+		r0 = rf(bountyId, hunter)
+	} else {
+		r0 = ret.Get(0).(db.BountyWorkSession)
+	}
+
+	return r0
+}
+
+// Database_GetActiveWorkSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveWorkSession'
+type Database_GetActiveWorkSession_Call struct {
+	*mock.Call
+}
+
+// GetActiveWorkSession is a helper method to define mock.On call
+//   - bountyId uint
+//   - hunter string
+func (_e *Database_Expecter) GetActiveWorkSession(bountyId interface{}, hunter interface{}) *Database_GetActiveWorkSession_Call {
+	return &Database_GetActiveWorkSession_Call{Call: _e.mock.On("GetActiveWorkSession", bountyId, hunter)}
+}
+
+func (_c *Database_GetActiveWorkSession_Call) Run(run func(bountyId uint, hunter string)) *Database_GetActiveWorkSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetActiveWorkSession_Call) Return(_a0 db.BountyWorkSession) *Database_GetActiveWorkSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetActiveWorkSession_Call) RunAndReturn(run func(uint, string) db.BountyWorkSession) *Database_GetActiveWorkSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActivitiesByPubkey provides a mock function with given fields: pubkey, r
+func (_m *Database) GetActivitiesByPubkey(pubkey string, r *http.Request) []db.Activity {
+	ret := _m.Called(pubkey, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActivitiesByPubkey")
+	}
+
+	var r0 []db.Activity
+	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.Activity); ok {
+		r0 = rf(pubkey, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Activity)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetActivitiesByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActivitiesByPubkey'
+type Database_GetActivitiesByPubkey_Call struct {
+	*mock.Call
+}
+
+// GetActivitiesByPubkey is a helper method to define mock.On call
+//   - pubkey string
+//   - r *http.Request
+func (_e *Database_Expecter) GetActivitiesByPubkey(pubkey interface{}, r interface{}) *Database_GetActivitiesByPubkey_Call {
+	return &Database_GetActivitiesByPubkey_Call{Call: _e.mock.On("GetActivitiesByPubkey", pubkey, r)}
+}
+
+func (_c *Database_GetActivitiesByPubkey_Call) Run(run func(pubkey string, r *http.Request)) *Database_GetActivitiesByPubkey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetActivitiesByPubkey_Call) Return(_a0 []db.Activity) *Database_GetActivitiesByPubkey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetActivitiesByPubkey_Call) RunAndReturn(run func(string, *http.Request) []db.Activity) *Database_GetActivitiesByPubkey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActivitiesCount provides a mock function with given fields: pubkey
+func (_m *Database) GetActivitiesCount(pubkey string) int64 {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActivitiesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetActivitiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActivitiesCount'
+type Database_GetActivitiesCount_Call struct {
+	*mock.Call
+}
+
+// GetActivitiesCount is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetActivitiesCount(pubkey interface{}) *Database_GetActivitiesCount_Call {
+	return &Database_GetActivitiesCount_Call{Call: _e.mock.On("GetActivitiesCount", pubkey)}
+}
+
+func (_c *Database_GetActivitiesCount_Call) Run(run func(pubkey string)) *Database_GetActivitiesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetActivitiesCount_Call) Return(_a0 int64) *Database_GetActivitiesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetActivitiesCount_Call) RunAndReturn(run func(string) int64) *Database_GetActivitiesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAdminDashboard provides a mock function with given fields: since
+func (_m *Database) GetAdminDashboard(since time.Time) db.AdminDashboard {
+	ret := _m.Called(since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdminDashboard")
+	}
+
+	var r0 db.AdminDashboard
+	if rf, ok := ret.Get(0).(func(time.Time) db.AdminDashboard); ok {
+		r0 = rf(since)
+	} else {
+		r0 = ret.Get(0).(db.AdminDashboard)
+	}
+
+	return r0
+}
+
+// Database_GetAdminDashboard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAdminDashboard'
+type Database_GetAdminDashboard_Call struct {
+	*mock.Call
+}
+
+// GetAdminDashboard is a helper method to define mock.On call
+//   - since time.Time
+func (_e *Database_Expecter) GetAdminDashboard(since interface{}) *Database_GetAdminDashboard_Call {
+	return &Database_GetAdminDashboard_Call{Call: _e.mock.On("GetAdminDashboard", since)}
+}
+
+func (_c *Database_GetAdminDashboard_Call) Run(run func(since time.Time)) *Database_GetAdminDashboard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetAdminDashboard_Call) Return(_a0 db.AdminDashboard) *Database_GetAdminDashboard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAdminDashboard_Call) RunAndReturn(run func(time.Time) db.AdminDashboard) *Database_GetAdminDashboard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllBounties provides a mock function with given fields: r
+func (_m *Database) GetAllBounties(r *http.Request) []db.NewBounty {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetAllBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBounties'
+type Database_GetAllBounties_Call struct {
+	*mock.Call
+}
+
+// GetAllBounties is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetAllBounties(r interface{}) *Database_GetAllBounties_Call {
+	return &Database_GetAllBounties_Call{Call: _e.mock.On("GetAllBounties", r)}
+}
+
+func (_c *Database_GetAllBounties_Call) Run(run func(r *http.Request)) *Database_GetAllBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetAllBounties_Call) Return(_a0 []db.NewBounty) *Database_GetAllBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAllBounties_Call) RunAndReturn(run func(*http.Request) []db.NewBounty) *Database_GetAllBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllTribes provides a mock function with given fields:
+func (_m *Database) GetAllTribes() []db.Tribe {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllTribes")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func() []db.Tribe); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetAllTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTribes'
+type Database_GetAllTribes_Call struct {
+	*mock.Call
+}
+
+// GetAllTribes is a helper method to define mock.On call
+func (_e *Database_Expecter) GetAllTribes() *Database_GetAllTribes_Call {
+	return &Database_GetAllTribes_Call{Call: _e.mock.On("GetAllTribes")}
+}
+
+func (_c *Database_GetAllTribes_Call) Run(run func()) *Database_GetAllTribes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetAllTribes_Call) Return(_a0 []db.Tribe) *Database_GetAllTribes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAllTribes_Call) RunAndReturn(run func() []db.Tribe) *Database_GetAllTribes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllTribesByOwner provides a mock function with given fields: pubkey
+func (_m *Database) GetAllTribesByOwner(pubkey string) []db.Tribe {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllTribesByOwner")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetAllTribesByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTribesByOwner'
+type Database_GetAllTribesByOwner_Call struct {
+	*mock.Call
+}
+
+// GetAllTribesByOwner is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetAllTribesByOwner(pubkey interface{}) *Database_GetAllTribesByOwner_Call {
+	return &Database_GetAllTribesByOwner_Call{Call: _e.mock.On("GetAllTribesByOwner", pubkey)}
+}
+
+func (_c *Database_GetAllTribesByOwner_Call) Run(run func(pubkey string)) *Database_GetAllTribesByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetAllTribesByOwner_Call) Return(_a0 []db.Tribe) *Database_GetAllTribesByOwner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAllTribesByOwner_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetAllTribesByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetApiKeyByHash provides a mock function with given fields: keyHash
+func (_m *Database) GetApiKeyByHash(keyHash string) (db.ApiKey, error) {
+	ret := _m.Called(keyHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApiKeyByHash")
+	}
+
+	var r0 db.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.ApiKey, error)); ok {
+		return rf(keyHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.ApiKey); ok {
+		r0 = rf(keyHash)
+	} else {
+		r0 = ret.Get(0).(db.ApiKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetApiKeyByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetApiKeyByHash'
+type Database_GetApiKeyByHash_Call struct {
+	*mock.Call
+}
+
+// GetApiKeyByHash is a helper method to define mock.On call
+//   - keyHash string
+func (_e *Database_Expecter) GetApiKeyByHash(keyHash interface{}) *Database_GetApiKeyByHash_Call {
+	return &Database_GetApiKeyByHash_Call{Call: _e.mock.On("GetApiKeyByHash", keyHash)}
+}
+
+func (_c *Database_GetApiKeyByHash_Call) Run(run func(keyHash string)) *Database_GetApiKeyByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetApiKeyByHash_Call) Return(_a0 db.ApiKey, _a1 error) *Database_GetApiKeyByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetApiKeyByHash_Call) RunAndReturn(run func(string) (db.ApiKey, error)) *Database_GetApiKeyByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetApiKeysByPubkey provides a mock function with given fields: pubkey
+func (_m *Database) GetApiKeysByPubkey(pubkey string) []db.ApiKey {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApiKeysByPubkey")
+	}
+
+	var r0 []db.ApiKey
+	if rf, ok := ret.Get(0).(func(string) []db.ApiKey); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ApiKey)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetApiKeysByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetApiKeysByPubkey'
+type Database_GetApiKeysByPubkey_Call struct {
+	*mock.Call
+}
+
+// GetApiKeysByPubkey is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetApiKeysByPubkey(pubkey interface{}) *Database_GetApiKeysByPubkey_Call {
+	return &Database_GetApiKeysByPubkey_Call{Call: _e.mock.On("GetApiKeysByPubkey", pubkey)}
+}
+
+func (_c *Database_GetApiKeysByPubkey_Call) Run(run func(pubkey string)) *Database_GetApiKeysByPubkey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetApiKeysByPubkey_Call) Return(_a0 []db.ApiKey) *Database_GetApiKeysByPubkey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetApiKeysByPubkey_Call) RunAndReturn(run func(string) []db.ApiKey) *Database_GetApiKeysByPubkey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArchivedChannelsByTribe provides a mock function with given fields: tribe_uuid
+func (_m *Database) GetArchivedChannelsByTribe(tribe_uuid string) []db.Channel {
+	ret := _m.Called(tribe_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArchivedChannelsByTribe")
+	}
+
+	var r0 []db.Channel
+	if rf, ok := ret.Get(0).(func(string) []db.Channel); ok {
+		r0 = rf(tribe_uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Channel)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetArchivedChannelsByTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArchivedChannelsByTribe'
+type Database_GetArchivedChannelsByTribe_Call struct {
+	*mock.Call
+}
+
+// GetArchivedChannelsByTribe is a helper method to define mock.On call
+//   - tribe_uuid string
+func (_e *Database_Expecter) GetArchivedChannelsByTribe(tribe_uuid interface{}) *Database_GetArchivedChannelsByTribe_Call {
+	return &Database_GetArchivedChannelsByTribe_Call{Call: _e.mock.On("GetArchivedChannelsByTribe", tribe_uuid)}
+}
+
+func (_c *Database_GetArchivedChannelsByTribe_Call) Run(run func(tribe_uuid string)) *Database_GetArchivedChannelsByTribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetArchivedChannelsByTribe_Call) Return(_a0 []db.Channel) *Database_GetArchivedChannelsByTribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetArchivedChannelsByTribe_Call) RunAndReturn(run func(string) []db.Channel) *Database_GetArchivedChannelsByTribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArchivedWorkspaceUuids provides a mock function with given fields:
+func (_m *Database) GetArchivedWorkspaceUuids() []string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArchivedWorkspaceUuids")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetArchivedWorkspaceUuids_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArchivedWorkspaceUuids'
+type Database_GetArchivedWorkspaceUuids_Call struct {
+	*mock.Call
+}
+
+// GetArchivedWorkspaceUuids is a helper method to define mock.On call
+func (_e *Database_Expecter) GetArchivedWorkspaceUuids() *Database_GetArchivedWorkspaceUuids_Call {
+	return &Database_GetArchivedWorkspaceUuids_Call{Call: _e.mock.On("GetArchivedWorkspaceUuids")}
+}
+
+func (_c *Database_GetArchivedWorkspaceUuids_Call) Run(run func()) *Database_GetArchivedWorkspaceUuids_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetArchivedWorkspaceUuids_Call) Return(_a0 []string) *Database_GetArchivedWorkspaceUuids_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetArchivedWorkspaceUuids_Call) RunAndReturn(run func() []string) *Database_GetArchivedWorkspaceUuids_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssignedBounties provides a mock function with given fields: r
+func (_m *Database) GetAssignedBounties(r *http.Request) ([]db.NewBounty, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssignedBounties")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.NewBounty, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAssignedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssignedBounties'
+type Database_GetAssignedBounties_Call struct {
+	*mock.Call
+}
+
+// GetAssignedBounties is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetAssignedBounties(r interface{}) *Database_GetAssignedBounties_Call {
+	return &Database_GetAssignedBounties_Call{Call: _e.mock.On("GetAssignedBounties", r)}
+}
+
+func (_c *Database_GetAssignedBounties_Call) Run(run func(r *http.Request)) *Database_GetAssignedBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetAssignedBounties_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetAssignedBounties_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAssignedBounties_Call) RunAndReturn(run func(*http.Request) ([]db.NewBounty, error)) *Database_GetAssignedBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssignedBountiesWithExpiry provides a mock function with given fields:
+func (_m *Database) GetAssignedBountiesWithExpiry() []db.NewBounty {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssignedBountiesWithExpiry")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func() []db.NewBounty); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetAssignedBountiesWithExpiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssignedBountiesWithExpiry'
+type Database_GetAssignedBountiesWithExpiry_Call struct {
+	*mock.Call
+}
+
+// GetAssignedBountiesWithExpiry is a helper method to define mock.On call
+func (_e *Database_Expecter) GetAssignedBountiesWithExpiry() *Database_GetAssignedBountiesWithExpiry_Call {
+	return &Database_GetAssignedBountiesWithExpiry_Call{Call: _e.mock.On("GetAssignedBountiesWithExpiry")}
+}
+
+func (_c *Database_GetAssignedBountiesWithExpiry_Call) Run(run func()) *Database_GetAssignedBountiesWithExpiry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetAssignedBountiesWithExpiry_Call) Return(_a0 []db.NewBounty) *Database_GetAssignedBountiesWithExpiry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAssignedBountiesWithExpiry_Call) RunAndReturn(run func() []db.NewBounty) *Database_GetAssignedBountiesWithExpiry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAttachment provides a mock function with given fields: id
+func (_m *Database) GetAttachment(id uint) db.Attachment {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttachment")
+	}
+
+	var r0 db.Attachment
+	if rf, ok := ret.Get(0).(func(uint) db.Attachment); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.Attachment)
+	}
+
+	return r0
+}
+
+// Database_GetAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAttachment'
+type Database_GetAttachment_Call struct {
+	*mock.Call
+}
+
+// GetAttachment is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetAttachment(id interface{}) *Database_GetAttachment_Call {
+	return &Database_GetAttachment_Call{Call: _e.mock.On("GetAttachment", id)}
+}
+
+func (_c *Database_GetAttachment_Call) Run(run func(id uint)) *Database_GetAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetAttachment_Call) Return(_a0 db.Attachment) *Database_GetAttachment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAttachment_Call) RunAndReturn(run func(uint) db.Attachment) *Database_GetAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAttachments provides a mock function with given fields: parentType, parentId
+func (_m *Database) GetAttachments(parentType db.AttachmentParent, parentId string) []db.Attachment {
+	ret := _m.Called(parentType, parentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttachments")
+	}
+
+	var r0 []db.Attachment
+	if rf, ok := ret.Get(0).(func(db.AttachmentParent, string) []db.Attachment); ok {
+		r0 = rf(parentType, parentId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Attachment)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetAttachments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAttachments'
+type Database_GetAttachments_Call struct {
+	*mock.Call
+}
+
+// GetAttachments is a helper method to define mock.On call
+//   - parentType db.AttachmentParent
+//   - parentId string
+func (_e *Database_Expecter) GetAttachments(parentType interface{}, parentId interface{}) *Database_GetAttachments_Call {
+	return &Database_GetAttachments_Call{Call: _e.mock.On("GetAttachments", parentType, parentId)}
+}
+
+func (_c *Database_GetAttachments_Call) Run(run func(parentType db.AttachmentParent, parentId string)) *Database_GetAttachments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.AttachmentParent), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetAttachments_Call) Return(_a0 []db.Attachment) *Database_GetAttachments_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAttachments_Call) RunAndReturn(run func(db.AttachmentParent, string) []db.Attachment) *Database_GetAttachments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthSession provides a mock function with given fields: uuid
+func (_m *Database) GetAuthSession(uuid string) (db.AuthSession, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthSession")
+	}
+
+	var r0 db.AuthSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.AuthSession, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.AuthSession); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.AuthSession)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAuthSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthSession'
+type Database_GetAuthSession_Call struct {
+	*mock.Call
+}
+
+// GetAuthSession is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetAuthSession(uuid interface{}) *Database_GetAuthSession_Call {
+	return &Database_GetAuthSession_Call{Call: _e.mock.On("GetAuthSession", uuid)}
+}
+
+func (_c *Database_GetAuthSession_Call) Run(run func(uuid string)) *Database_GetAuthSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetAuthSession_Call) Return(_a0 db.AuthSession, _a1 error) *Database_GetAuthSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAuthSession_Call) RunAndReturn(run func(string) (db.AuthSession, error)) *Database_GetAuthSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBot provides a mock function with given fields: uuid
+func (_m *Database) GetBot(uuid string) db.Bot {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBot")
+	}
+
+	var r0 db.Bot
+	if rf, ok := ret.Get(0).(func(string) db.Bot); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Bot)
+	}
+
+	return r0
+}
+
+// Database_GetBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBot'
+type Database_GetBot_Call struct {
+	*mock.Call
+}
+
+// GetBot is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetBot(uuid interface{}) *Database_GetBot_Call {
+	return &Database_GetBot_Call{Call: _e.mock.On("GetBot", uuid)}
+}
+
+func (_c *Database_GetBot_Call) Run(run func(uuid string)) *Database_GetBot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBot_Call) Return(_a0 db.Bot) *Database_GetBot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBot_Call) RunAndReturn(run func(string) db.Bot) *Database_GetBot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotByUniqueName provides a mock function with given fields: un
+func (_m *Database) GetBotByUniqueName(un string) db.Bot {
+	ret := _m.Called(un)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotByUniqueName")
+	}
+
+	var r0 db.Bot
+	if rf, ok := ret.Get(0).(func(string) db.Bot); ok {
+		r0 = rf(un)
+	} else {
+		r0 = ret.Get(0).(db.Bot)
+	}
+
+	return r0
+}
+
+// Database_GetBotByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotByUniqueName'
+type Database_GetBotByUniqueName_Call struct {
+	*mock.Call
+}
+
+// GetBotByUniqueName is a helper method to define mock.On call
+//   - un string
+func (_e *Database_Expecter) GetBotByUniqueName(un interface{}) *Database_GetBotByUniqueName_Call {
+	return &Database_GetBotByUniqueName_Call{Call: _e.mock.On("GetBotByUniqueName", un)}
+}
+
+func (_c *Database_GetBotByUniqueName_Call) Run(run func(un string)) *Database_GetBotByUniqueName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotByUniqueName_Call) Return(_a0 db.Bot) *Database_GetBotByUniqueName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotByUniqueName_Call) RunAndReturn(run func(string) db.Bot) *Database_GetBotByUniqueName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotCategories provides a mock function with given fields:
+func (_m *Database) GetBotCategories() []db.BotCategory {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotCategories")
+	}
+
+	var r0 []db.BotCategory
+	if rf, ok := ret.Get(0).(func() []db.BotCategory); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BotCategory)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBotCategories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotCategories'
+type Database_GetBotCategories_Call struct {
+	*mock.Call
+}
+
+// GetBotCategories is a helper method to define mock.On call
+func (_e *Database_Expecter) GetBotCategories() *Database_GetBotCategories_Call {
+	return &Database_GetBotCategories_Call{Call: _e.mock.On("GetBotCategories")}
+}
+
+func (_c *Database_GetBotCategories_Call) Run(run func()) *Database_GetBotCategories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetBotCategories_Call) Return(_a0 []db.BotCategory) *Database_GetBotCategories_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotCategories_Call) RunAndReturn(run func() []db.BotCategory) *Database_GetBotCategories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotCategory provides a mock function with given fields: slug
+func (_m *Database) GetBotCategory(slug string) db.BotCategory {
+	ret := _m.Called(slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotCategory")
+	}
+
+	var r0 db.BotCategory
+	if rf, ok := ret.Get(0).(func(string) db.BotCategory); ok {
+		r0 = rf(slug)
+	} else {
+		r0 = ret.Get(0).(db.BotCategory)
+	}
+
+	return r0
+}
+
+// Database_GetBotCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotCategory'
+type Database_GetBotCategory_Call struct {
+	*mock.Call
+}
+
+// GetBotCategory is a helper method to define mock.On call
+//   - slug string
+func (_e *Database_Expecter) GetBotCategory(slug interface{}) *Database_GetBotCategory_Call {
+	return &Database_GetBotCategory_Call{Call: _e.mock.On("GetBotCategory", slug)}
+}
+
+func (_c *Database_GetBotCategory_Call) Run(run func(slug string)) *Database_GetBotCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotCategory_Call) Return(_a0 db.BotCategory) *Database_GetBotCategory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotCategory_Call) RunAndReturn(run func(string) db.BotCategory) *Database_GetBotCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotMonthlyEarnings provides a mock function with given fields: botUuid
+func (_m *Database) GetBotMonthlyEarnings(botUuid string) []db.BotMonthlyEarnings {
+	ret := _m.Called(botUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotMonthlyEarnings")
+	}
+
+	var r0 []db.BotMonthlyEarnings
+	if rf, ok := ret.Get(0).(func(string) []db.BotMonthlyEarnings); ok {
+		r0 = rf(botUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BotMonthlyEarnings)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBotMonthlyEarnings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotMonthlyEarnings'
+type Database_GetBotMonthlyEarnings_Call struct {
+	*mock.Call
+}
+
+// GetBotMonthlyEarnings is a helper method to define mock.On call
+//   - botUuid string
+func (_e *Database_Expecter) GetBotMonthlyEarnings(botUuid interface{}) *Database_GetBotMonthlyEarnings_Call {
+	return &Database_GetBotMonthlyEarnings_Call{Call: _e.mock.On("GetBotMonthlyEarnings", botUuid)}
+}
+
+func (_c *Database_GetBotMonthlyEarnings_Call) Run(run func(botUuid string)) *Database_GetBotMonthlyEarnings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotMonthlyEarnings_Call) Return(_a0 []db.BotMonthlyEarnings) *Database_GetBotMonthlyEarnings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotMonthlyEarnings_Call) RunAndReturn(run func(string) []db.BotMonthlyEarnings) *Database_GetBotMonthlyEarnings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotPayouts provides a mock function with given fields: botUuid, limit
+func (_m *Database) GetBotPayouts(botUuid string, limit int) []db.BotPayout {
+	ret := _m.Called(botUuid, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotPayouts")
+	}
+
+	var r0 []db.BotPayout
+	if rf, ok := ret.Get(0).(func(string, int) []db.BotPayout); ok {
+		r0 = rf(botUuid, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BotPayout)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBotPayouts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotPayouts'
+type Database_GetBotPayouts_Call struct {
+	*mock.Call
+}
+
+// GetBotPayouts is a helper method to define mock.On call
+//   - botUuid string
+//   - limit int
+func (_e *Database_Expecter) GetBotPayouts(botUuid interface{}, limit interface{}) *Database_GetBotPayouts_Call {
+	return &Database_GetBotPayouts_Call{Call: _e.mock.On("GetBotPayouts", botUuid, limit)}
+}
+
+func (_c *Database_GetBotPayouts_Call) Run(run func(botUuid string, limit int)) *Database_GetBotPayouts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotPayouts_Call) Return(_a0 []db.BotPayout) *Database_GetBotPayouts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotPayouts_Call) RunAndReturn(run func(string, int) []db.BotPayout) *Database_GetBotPayouts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotReview provides a mock function with given fields: botUuid, pubkey
+func (_m *Database) GetBotReview(botUuid string, pubkey string) db.BotReview {
+	ret := _m.Called(botUuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotReview")
+	}
+
+	var r0 db.BotReview
+	if rf, ok := ret.Get(0).(func(string, string) db.BotReview); ok {
+		r0 = rf(botUuid, pubkey)
+	} else {
+		r0 = ret.Get(0).(db.BotReview)
+	}
+
+	return r0
+}
+
+// Database_GetBotReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotReview'
+type Database_GetBotReview_Call struct {
+	*mock.Call
+}
+
+// GetBotReview is a helper method to define mock.On call
+//   - botUuid string
+//   - pubkey string
+func (_e *Database_Expecter) GetBotReview(botUuid interface{}, pubkey interface{}) *Database_GetBotReview_Call {
+	return &Database_GetBotReview_Call{Call: _e.mock.On("GetBotReview", botUuid, pubkey)}
+}
+
+func (_c *Database_GetBotReview_Call) Run(run func(botUuid string, pubkey string)) *Database_GetBotReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotReview_Call) Return(_a0 db.BotReview) *Database_GetBotReview_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotReview_Call) RunAndReturn(run func(string, string) db.BotReview) *Database_GetBotReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotReviews provides a mock function with given fields: botUuid, limit, offset
+func (_m *Database) GetBotReviews(botUuid string, limit int, offset int) []db.BotReview {
+	ret := _m.Called(botUuid, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotReviews")
+	}
+
+	var r0 []db.BotReview
+	if rf, ok := ret.Get(0).(func(string, int, int) []db.BotReview); ok {
+		r0 = rf(botUuid, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BotReview)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBotReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotReviews'
+type Database_GetBotReviews_Call struct {
+	*mock.Call
+}
+
+// GetBotReviews is a helper method to define mock.On call
+//   - botUuid string
+//   - limit int
+//   - offset int
+func (_e *Database_Expecter) GetBotReviews(botUuid interface{}, limit interface{}, offset interface{}) *Database_GetBotReviews_Call {
+	return &Database_GetBotReviews_Call{Call: _e.mock.On("GetBotReviews", botUuid, limit, offset)}
+}
+
+func (_c *Database_GetBotReviews_Call) Run(run func(botUuid string, limit int, offset int)) *Database_GetBotReviews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotReviews_Call) Return(_a0 []db.BotReview) *Database_GetBotReviews_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotReviews_Call) RunAndReturn(run func(string, int, int) []db.BotReview) *Database_GetBotReviews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotUnpaidUsage provides a mock function with given fields: botUuid
+func (_m *Database) GetBotUnpaidUsage(botUuid string) (uint, uint) {
+	ret := _m.Called(botUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotUnpaidUsage")
+	}
+
+	var r0 uint
+	var r1 uint
+	if rf, ok := ret.Get(0).(func(string) (uint, uint)); ok {
+		return rf(botUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) uint); ok {
+		r0 = rf(botUuid)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) uint); ok {
+		r1 = rf(botUuid)
+	} else {
+		r1 = ret.Get(1).(uint)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBotUnpaidUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotUnpaidUsage'
+type Database_GetBotUnpaidUsage_Call struct {
+	*mock.Call
+}
+
+// GetBotUnpaidUsage is a helper method to define mock.On call
+//   - botUuid string
+func (_e *Database_Expecter) GetBotUnpaidUsage(botUuid interface{}) *Database_GetBotUnpaidUsage_Call {
+	return &Database_GetBotUnpaidUsage_Call{Call: _e.mock.On("GetBotUnpaidUsage", botUuid)}
+}
+
+func (_c *Database_GetBotUnpaidUsage_Call) Run(run func(botUuid string)) *Database_GetBotUnpaidUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotUnpaidUsage_Call) Return(_a0 uint, _a1 uint) *Database_GetBotUnpaidUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBotUnpaidUsage_Call) RunAndReturn(run func(string) (uint, uint)) *Database_GetBotUnpaidUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotWebhook provides a mock function with given fields: uuid
+func (_m *Database) GetBotWebhook(uuid string) db.BotWebhook {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotWebhook")
+	}
+
+	var r0 db.BotWebhook
+	if rf, ok := ret.Get(0).(func(string) db.BotWebhook); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.BotWebhook)
+	}
+
+	return r0
+}
+
+// Database_GetBotWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotWebhook'
+type Database_GetBotWebhook_Call struct {
+	*mock.Call
+}
+
+// GetBotWebhook is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetBotWebhook(uuid interface{}) *Database_GetBotWebhook_Call {
+	return &Database_GetBotWebhook_Call{Call: _e.mock.On("GetBotWebhook", uuid)}
+}
+
+func (_c *Database_GetBotWebhook_Call) Run(run func(uuid string)) *Database_GetBotWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotWebhook_Call) Return(_a0 db.BotWebhook) *Database_GetBotWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotWebhook_Call) RunAndReturn(run func(string) db.BotWebhook) *Database_GetBotWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotWebhooks provides a mock function with given fields: botUuid
+func (_m *Database) GetBotWebhooks(botUuid string) []db.BotWebhook {
+	ret := _m.Called(botUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotWebhooks")
+	}
+
+	var r0 []db.BotWebhook
+	if rf, ok := ret.Get(0).(func(string) []db.BotWebhook); ok {
+		r0 = rf(botUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BotWebhook)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBotWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotWebhooks'
+type Database_GetBotWebhooks_Call struct {
+	*mock.Call
+}
+
+// GetBotWebhooks is a helper method to define mock.On call
+//   - botUuid string
+func (_e *Database_Expecter) GetBotWebhooks(botUuid interface{}) *Database_GetBotWebhooks_Call {
+	return &Database_GetBotWebhooks_Call{Call: _e.mock.On("GetBotWebhooks", botUuid)}
+}
+
+func (_c *Database_GetBotWebhooks_Call) Run(run func(botUuid string)) *Database_GetBotWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotWebhooks_Call) Return(_a0 []db.BotWebhook) *Database_GetBotWebhooks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotWebhooks_Call) RunAndReturn(run func(string) []db.BotWebhook) *Database_GetBotWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotWebhooksForEvent provides a mock function with given fields: event, tribeUuid, botUuid
+func (_m *Database) GetBotWebhooksForEvent(event string, tribeUuid string, botUuid string) []db.BotWebhook {
+	ret := _m.Called(event, tribeUuid, botUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotWebhooksForEvent")
+	}
+
+	var r0 []db.BotWebhook
+	if rf, ok := ret.Get(0).(func(string, string, string) []db.BotWebhook); ok {
+		r0 = rf(event, tribeUuid, botUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BotWebhook)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBotWebhooksForEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotWebhooksForEvent'
+type Database_GetBotWebhooksForEvent_Call struct {
+	*mock.Call
+}
+
+// GetBotWebhooksForEvent is a helper method to define mock.On call
+//   - event string
+//   - tribeUuid string
+//   - botUuid string
+func (_e *Database_Expecter) GetBotWebhooksForEvent(event interface{}, tribeUuid interface{}, botUuid interface{}) *Database_GetBotWebhooksForEvent_Call {
+	return &Database_GetBotWebhooksForEvent_Call{Call: _e.mock.On("GetBotWebhooksForEvent", event, tribeUuid, botUuid)}
+}
+
+func (_c *Database_GetBotWebhooksForEvent_Call) Run(run func(event string, tribeUuid string, botUuid string)) *Database_GetBotWebhooksForEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotWebhooksForEvent_Call) Return(_a0 []db.BotWebhook) *Database_GetBotWebhooksForEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotWebhooksForEvent_Call) RunAndReturn(run func(string, string, string) []db.BotWebhook) *Database_GetBotWebhooksForEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotsByCategory provides a mock function with given fields: slug, limit, offset
+func (_m *Database) GetBotsByCategory(slug string, limit int, offset int) []db.Bot {
+	ret := _m.Called(slug, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotsByCategory")
+	}
+
+	var r0 []db.Bot
+	if rf, ok := ret.Get(0).(func(string, int, int) []db.Bot); ok {
+		r0 = rf(slug, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Bot)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBotsByCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotsByCategory'
+type Database_GetBotsByCategory_Call struct {
+	*mock.Call
+}
+
+// GetBotsByCategory is a helper method to define mock.On call
+//   - slug string
+//   - limit int
+//   - offset int
+func (_e *Database_Expecter) GetBotsByCategory(slug interface{}, limit interface{}, offset interface{}) *Database_GetBotsByCategory_Call {
+	return &Database_GetBotsByCategory_Call{Call: _e.mock.On("GetBotsByCategory", slug, limit, offset)}
+}
+
+func (_c *Database_GetBotsByCategory_Call) Run(run func(slug string, limit int, offset int)) *Database_GetBotsByCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotsByCategory_Call) Return(_a0 []db.Bot) *Database_GetBotsByCategory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotsByCategory_Call) RunAndReturn(run func(string, int, int) []db.Bot) *Database_GetBotsByCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotsByOwner provides a mock function with given fields: pubkey
+func (_m *Database) GetBotsByOwner(pubkey string) []db.Bot {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotsByOwner")
+	}
+
+	var r0 []db.Bot
+	if rf, ok := ret.Get(0).(func(string) []db.Bot); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Bot)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBotsByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotsByOwner'
+type Database_GetBotsByOwner_Call struct {
+	*mock.Call
+}
+
+// GetBotsByOwner is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetBotsByOwner(pubkey interface{}) *Database_GetBotsByOwner_Call {
+	return &Database_GetBotsByOwner_Call{Call: _e.mock.On("GetBotsByOwner", pubkey)}
+}
+
+func (_c *Database_GetBotsByOwner_Call) Run(run func(pubkey string)) *Database_GetBotsByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotsByOwner_Call) Return(_a0 []db.Bot) *Database_GetBotsByOwner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotsByOwner_Call) RunAndReturn(run func(string) []db.Bot) *Database_GetBotsByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByDateRange provides a mock function with given fields: r, re
+func (_m *Database) GetBountiesByDateRange(r db.PaymentDateRange, re *http.Request) []db.NewBounty {
+	ret := _m.Called(r, re)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByDateRange")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) []db.NewBounty); ok {
+		r0 = rf(r, re)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesByDateRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByDateRange'
+type Database_GetBountiesByDateRange_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByDateRange is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - re *http.Request
+func (_e *Database_Expecter) GetBountiesByDateRange(r interface{}, re interface{}) *Database_GetBountiesByDateRange_Call {
+	return &Database_GetBountiesByDateRange_Call{Call: _e.mock.On("GetBountiesByDateRange", r, re)}
+}
+
+func (_c *Database_GetBountiesByDateRange_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesByDateRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByDateRange_Call) Return(_a0 []db.NewBounty) *Database_GetBountiesByDateRange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesByDateRange_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) []db.NewBounty) *Database_GetBountiesByDateRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByDateRangeCount provides a mock function with given fields: r, re
+func (_m *Database) GetBountiesByDateRangeCount(r db.PaymentDateRange, re *http.Request) int64 {
+	ret := _m.Called(r, re)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByDateRangeCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) int64); ok {
+		r0 = rf(r, re)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetBountiesByDateRangeCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByDateRangeCount'
+type Database_GetBountiesByDateRangeCount_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByDateRangeCount is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - re *http.Request
+func (_e *Database_Expecter) GetBountiesByDateRangeCount(r interface{}, re interface{}) *Database_GetBountiesByDateRangeCount_Call {
+	return &Database_GetBountiesByDateRangeCount_Call{Call: _e.mock.On("GetBountiesByDateRangeCount", r, re)}
+}
+
+func (_c *Database_GetBountiesByDateRangeCount_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesByDateRangeCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByDateRangeCount_Call) Return(_a0 int64) *Database_GetBountiesByDateRangeCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesByDateRangeCount_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) int64) *Database_GetBountiesByDateRangeCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByFeatureAndPhaseUuid provides a mock function with given fields: featureUuid, phaseUuid, r
+func (_m *Database) GetBountiesByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) ([]db.NewBounty, error) {
+	ret := _m.Called(featureUuid, phaseUuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByFeatureAndPhaseUuid")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, *http.Request) ([]db.NewBounty, error)); ok {
+		return rf(featureUuid, phaseUuid, r)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, *http.Request) []db.NewBounty); ok {
+		r0 = rf(featureUuid, phaseUuid, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, *http.Request) error); ok {
+		r1 = rf(featureUuid, phaseUuid, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountiesByFeatureAndPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByFeatureAndPhaseUuid'
+type Database_GetBountiesByFeatureAndPhaseUuid_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByFeatureAndPhaseUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - phaseUuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetBountiesByFeatureAndPhaseUuid(featureUuid interface{}, phaseUuid interface{}, r interface{}) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+	return &Database_GetBountiesByFeatureAndPhaseUuid_Call{Call: _e.mock.On("GetBountiesByFeatureAndPhaseUuid", featureUuid, phaseUuid, r)}
+}
+
+func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) Run(run func(featureUuid string, phaseUuid string, r *http.Request)) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) RunAndReturn(run func(string, string, *http.Request) ([]db.NewBounty, error)) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByIds provides a mock function with given fields: ids
+func (_m *Database) GetBountiesByIds(ids []uint) []db.NewBounty {
+	ret := _m.Called(ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByIds")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func([]uint) []db.NewBounty); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesByIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByIds'
+type Database_GetBountiesByIds_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByIds is a helper method to define mock.On call
+//   - ids []uint
+func (_e *Database_Expecter) GetBountiesByIds(ids interface{}) *Database_GetBountiesByIds_Call {
+	return &Database_GetBountiesByIds_Call{Call: _e.mock.On("GetBountiesByIds", ids)}
+}
+
+func (_c *Database_GetBountiesByIds_Call) Run(run func(ids []uint)) *Database_GetBountiesByIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByIds_Call) Return(_a0 []db.NewBounty) *Database_GetBountiesByIds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesByIds_Call) RunAndReturn(run func([]uint) []db.NewBounty) *Database_GetBountiesByIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByPhaseUuid provides a mock function with given fields: phaseUuid
+func (_m *Database) GetBountiesByPhaseUuid(phaseUuid string) []db.Bounty {
+	ret := _m.Called(phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByPhaseUuid")
+	}
+
+	var r0 []db.Bounty
+	if rf, ok := ret.Get(0).(func(string) []db.Bounty); ok {
+		r0 = rf(phaseUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Bounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesByPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByPhaseUuid'
+type Database_GetBountiesByPhaseUuid_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByPhaseUuid is a helper method to define mock.On call
+//   - phaseUuid string
+func (_e *Database_Expecter) GetBountiesByPhaseUuid(phaseUuid interface{}) *Database_GetBountiesByPhaseUuid_Call {
+	return &Database_GetBountiesByPhaseUuid_Call{Call: _e.mock.On("GetBountiesByPhaseUuid", phaseUuid)}
+}
+
+func (_c *Database_GetBountiesByPhaseUuid_Call) Run(run func(phaseUuid string)) *Database_GetBountiesByPhaseUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByPhaseUuid_Call) Return(_a0 []db.Bounty) *Database_GetBountiesByPhaseUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesByPhaseUuid_Call) RunAndReturn(run func(string) []db.Bounty) *Database_GetBountiesByPhaseUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesCount provides a mock function with given fields: r
+func (_m *Database) GetBountiesCount(r *http.Request) int64 {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesCount'
+type Database_GetBountiesCount_Call struct {
+	*mock.Call
+}
+
+// GetBountiesCount is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetBountiesCount(r interface{}) *Database_GetBountiesCount_Call {
+	return &Database_GetBountiesCount_Call{Call: _e.mock.On("GetBountiesCount", r)}
+}
+
+func (_c *Database_GetBountiesCount_Call) Run(run func(r *http.Request)) *Database_GetBountiesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesCount_Call) Return(_a0 int64) *Database_GetBountiesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesCount_Call) RunAndReturn(run func(*http.Request) int64) *Database_GetBountiesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesCountByFeatureAndPhaseUuid provides a mock function with given fields: featureUuid, phaseUuid, r
+func (_m *Database) GetBountiesCountByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) int64 {
+	ret := _m.Called(featureUuid, phaseUuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesCountByFeatureAndPhaseUuid")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string, *http.Request) int64); ok {
+		r0 = rf(featureUuid, phaseUuid, r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetBountiesCountByFeatureAndPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesCountByFeatureAndPhaseUuid'
+type Database_GetBountiesCountByFeatureAndPhaseUuid_Call struct {
+	*mock.Call
+}
+
+// GetBountiesCountByFeatureAndPhaseUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - phaseUuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetBountiesCountByFeatureAndPhaseUuid(featureUuid interface{}, phaseUuid interface{}, r interface{}) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+	return &Database_GetBountiesCountByFeatureAndPhaseUuid_Call{Call: _e.mock.On("GetBountiesCountByFeatureAndPhaseUuid", featureUuid, phaseUuid, r)}
+}
+
+func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) Run(run func(featureUuid string, phaseUuid string, r *http.Request)) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) Return(_a0 int64) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) RunAndReturn(run func(string, string, *http.Request) int64) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesLeaderboard provides a mock function with given fields:
+func (_m *Database) GetBountiesLeaderboard() []db.LeaderData {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesLeaderboard")
+	}
+
+	var r0 []db.LeaderData
+	if rf, ok := ret.Get(0).(func() []db.LeaderData); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LeaderData)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesLeaderboard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesLeaderboard'
+type Database_GetBountiesLeaderboard_Call struct {
+	*mock.Call
+}
+
+// GetBountiesLeaderboard is a helper method to define mock.On call
+func (_e *Database_Expecter) GetBountiesLeaderboard() *Database_GetBountiesLeaderboard_Call {
+	return &Database_GetBountiesLeaderboard_Call{Call: _e.mock.On("GetBountiesLeaderboard")}
+}
+
+func (_c *Database_GetBountiesLeaderboard_Call) Run(run func()) *Database_GetBountiesLeaderboard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesLeaderboard_Call) Return(_a0 []db.LeaderData) *Database_GetBountiesLeaderboard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesLeaderboard_Call) RunAndReturn(run func() []db.LeaderData) *Database_GetBountiesLeaderboard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesProviders provides a mock function with given fields: r, re
+func (_m *Database) GetBountiesProviders(r db.PaymentDateRange, re *http.Request) []db.Person {
+	ret := _m.Called(r, re)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesProviders")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) []db.Person); ok {
+		r0 = rf(r, re)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesProviders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesProviders'
+type Database_GetBountiesProviders_Call struct {
+	*mock.Call
+}
+
+// GetBountiesProviders is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - re *http.Request
+func (_e *Database_Expecter) GetBountiesProviders(r interface{}, re interface{}) *Database_GetBountiesProviders_Call {
+	return &Database_GetBountiesProviders_Call{Call: _e.mock.On("GetBountiesProviders", r, re)}
+}
+
+func (_c *Database_GetBountiesProviders_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesProviders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesProviders_Call) Return(_a0 []db.Person) *Database_GetBountiesProviders_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesProviders_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) []db.Person) *Database_GetBountiesProviders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesUpdatedSince provides a mock function with given fields: since, r
+func (_m *Database) GetBountiesUpdatedSince(since time.Time, r *http.Request) []db.NewBounty {
+	ret := _m.Called(since, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesUpdatedSince")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(time.Time, *http.Request) []db.NewBounty); ok {
+		r0 = rf(since, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesUpdatedSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesUpdatedSince'
+type Database_GetBountiesUpdatedSince_Call struct {
+	*mock.Call
+}
+
+// GetBountiesUpdatedSince is a helper method to define mock.On call
+//   - since time.Time
+//   - r *http.Request
+func (_e *Database_Expecter) GetBountiesUpdatedSince(since interface{}, r interface{}) *Database_GetBountiesUpdatedSince_Call {
+	return &Database_GetBountiesUpdatedSince_Call{Call: _e.mock.On("GetBountiesUpdatedSince", since, r)}
+}
+
+func (_c *Database_GetBountiesUpdatedSince_Call) Run(run func(since time.Time, r *http.Request)) *Database_GetBountiesUpdatedSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesUpdatedSince_Call) Return(_a0 []db.NewBounty) *Database_GetBountiesUpdatedSince_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesUpdatedSince_Call) RunAndReturn(run func(time.Time, *http.Request) []db.NewBounty) *Database_GetBountiesUpdatedSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBounty provides a mock function with given fields: id
+func (_m *Database) GetBounty(id uint) db.NewBounty {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBounty")
+	}
+
+	var r0 db.NewBounty
+	if rf, ok := ret.Get(0).(func(uint) db.NewBounty); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	return r0
+}
+
+// Database_GetBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBounty'
+type Database_GetBounty_Call struct {
+	*mock.Call
+}
+
+// GetBounty is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetBounty(id interface{}) *Database_GetBounty_Call {
+	return &Database_GetBounty_Call{Call: _e.mock.On("GetBounty", id)}
+}
+
+func (_c *Database_GetBounty_Call) Run(run func(id uint)) *Database_GetBounty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBounty_Call) Return(_a0 db.NewBounty) *Database_GetBounty_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBounty_Call) RunAndReturn(run func(uint) db.NewBounty) *Database_GetBounty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyByCreated provides a mock function with given fields: created
+func (_m *Database) GetBountyByCreated(created uint) (db.NewBounty, error) {
+	ret := _m.Called(created)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyByCreated")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (db.NewBounty, error)); ok {
+		return rf(created)
+	}
+	if rf, ok := ret.Get(0).(func(uint) db.NewBounty); ok {
+		r0 = rf(created)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(created)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyByCreated'
+type Database_GetBountyByCreated_Call struct {
+	*mock.Call
+}
+
+// GetBountyByCreated is a helper method to define mock.On call
+//   - created uint
+func (_e *Database_Expecter) GetBountyByCreated(created interface{}) *Database_GetBountyByCreated_Call {
+	return &Database_GetBountyByCreated_Call{Call: _e.mock.On("GetBountyByCreated", created)}
+}
+
+func (_c *Database_GetBountyByCreated_Call) Run(run func(created uint)) *Database_GetBountyByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyByCreated_Call) Return(_a0 db.NewBounty, _a1 error) *Database_GetBountyByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyByCreated_Call) RunAndReturn(run func(uint) (db.NewBounty, error)) *Database_GetBountyByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyById provides a mock function with given fields: id
+func (_m *Database) GetBountyById(id string) ([]db.NewBounty, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyById")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.NewBounty, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyById'
+type Database_GetBountyById_Call struct {
+	*mock.Call
+}
+
+// GetBountyById is a helper method to define mock.On call
+//   - id string
+func (_e *Database_Expecter) GetBountyById(id interface{}) *Database_GetBountyById_Call {
+	return &Database_GetBountyById_Call{Call: _e.mock.On("GetBountyById", id)}
+}
+
+func (_c *Database_GetBountyById_Call) Run(run func(id string)) *Database_GetBountyById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyById_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountyById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyById_Call) RunAndReturn(run func(string) ([]db.NewBounty, error)) *Database_GetBountyById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyDataByCreated provides a mock function with given fields: created
+func (_m *Database) GetBountyDataByCreated(created string) ([]db.NewBounty, error) {
+	ret := _m.Called(created)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyDataByCreated")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.NewBounty, error)); ok {
+		return rf(created)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(created)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(created)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyDataByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyDataByCreated'
+type Database_GetBountyDataByCreated_Call struct {
+	*mock.Call
+}
+
+// GetBountyDataByCreated is a helper method to define mock.On call
+//   - created string
+func (_e *Database_Expecter) GetBountyDataByCreated(created interface{}) *Database_GetBountyDataByCreated_Call {
+	return &Database_GetBountyDataByCreated_Call{Call: _e.mock.On("GetBountyDataByCreated", created)}
+}
+
+func (_c *Database_GetBountyDataByCreated_Call) Run(run func(created string)) *Database_GetBountyDataByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyDataByCreated_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountyDataByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyDataByCreated_Call) RunAndReturn(run func(string) ([]db.NewBounty, error)) *Database_GetBountyDataByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyDisputes provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyDisputes(bountyId uint) []db.BountyDispute {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyDisputes")
+	}
+
+	var r0 []db.BountyDispute
+	if rf, ok := ret.Get(0).(func(uint) []db.BountyDispute); ok {
+		r0 = rf(bountyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyDispute)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyDisputes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyDisputes'
+type Database_GetBountyDisputes_Call struct {
+	*mock.Call
+}
+
+// GetBountyDisputes is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyDisputes(bountyId interface{}) *Database_GetBountyDisputes_Call {
+	return &Database_GetBountyDisputes_Call{Call: _e.mock.On("GetBountyDisputes", bountyId)}
+}
+
+func (_c *Database_GetBountyDisputes_Call) Run(run func(bountyId uint)) *Database_GetBountyDisputes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyDisputes_Call) Return(_a0 []db.BountyDispute) *Database_GetBountyDisputes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyDisputes_Call) RunAndReturn(run func(uint) []db.BountyDispute) *Database_GetBountyDisputes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyEscrow provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyEscrow(bountyId uint) db.BountyEscrow {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyEscrow")
+	}
+
+	var r0 db.BountyEscrow
+	if rf, ok := ret.Get(0).(func(uint) db.BountyEscrow); ok {
+		r0 = rf(bountyId)
+	} else {
+		r0 = ret.Get(0).(db.BountyEscrow)
+	}
+
+	return r0
+}
+
+// Database_GetBountyEscrow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyEscrow'
+type Database_GetBountyEscrow_Call struct {
+	*mock.Call
+}
+
+// GetBountyEscrow is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyEscrow(bountyId interface{}) *Database_GetBountyEscrow_Call {
+	return &Database_GetBountyEscrow_Call{Call: _e.mock.On("GetBountyEscrow", bountyId)}
+}
+
+func (_c *Database_GetBountyEscrow_Call) Run(run func(bountyId uint)) *Database_GetBountyEscrow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyEscrow_Call) Return(_a0 db.BountyEscrow) *Database_GetBountyEscrow_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyEscrow_Call) RunAndReturn(run func(uint) db.BountyEscrow) *Database_GetBountyEscrow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyFeed provides a mock function with given fields: filter, limit
+func (_m *Database) GetBountyFeed(filter db.BountyFeedFilter, limit int) []db.NewBounty {
+	ret := _m.Called(filter, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyFeed")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(db.BountyFeedFilter, int) []db.NewBounty); ok {
+		r0 = rf(filter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyFeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyFeed'
+type Database_GetBountyFeed_Call struct {
+	*mock.Call
+}
+
+// GetBountyFeed is a helper method to define mock.On call
+//   - filter db.BountyFeedFilter
+//   - limit int
+func (_e *Database_Expecter) GetBountyFeed(filter interface{}, limit interface{}) *Database_GetBountyFeed_Call {
+	return &Database_GetBountyFeed_Call{Call: _e.mock.On("GetBountyFeed", filter, limit)}
+}
+
+func (_c *Database_GetBountyFeed_Call) Run(run func(filter db.BountyFeedFilter, limit int)) *Database_GetBountyFeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BountyFeedFilter), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyFeed_Call) Return(_a0 []db.NewBounty) *Database_GetBountyFeed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyFeed_Call) RunAndReturn(run func(db.BountyFeedFilter, int) []db.NewBounty) *Database_GetBountyFeed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyFunnelRows provides a mock function with given fields: r, workspace
+func (_m *Database) GetBountyFunnelRows(r db.PaymentDateRange, workspace string) []db.BountyFunnelRow {
+	ret := _m.Called(r, workspace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyFunnelRows")
+	}
+
+	var r0 []db.BountyFunnelRow
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) []db.BountyFunnelRow); ok {
+		r0 = rf(r, workspace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyFunnelRow)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyFunnelRows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyFunnelRows'
+type Database_GetBountyFunnelRows_Call struct {
+	*mock.Call
+}
+
+// GetBountyFunnelRows is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) GetBountyFunnelRows(r interface{}, workspace interface{}) *Database_GetBountyFunnelRows_Call {
+	return &Database_GetBountyFunnelRows_Call{Call: _e.mock.On("GetBountyFunnelRows", r, workspace)}
+}
+
+func (_c *Database_GetBountyFunnelRows_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_GetBountyFunnelRows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyFunnelRows_Call) Return(_a0 []db.BountyFunnelRow) *Database_GetBountyFunnelRows_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyFunnelRows_Call) RunAndReturn(run func(db.PaymentDateRange, string) []db.BountyFunnelRow) *Database_GetBountyFunnelRows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyHunterPubkeys provides a mock function with given fields:
+func (_m *Database) GetBountyHunterPubkeys() []string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyHunterPubkeys")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyHunterPubkeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyHunterPubkeys'
+type Database_GetBountyHunterPubkeys_Call struct {
+	*mock.Call
+}
+
+// GetBountyHunterPubkeys is a helper method to define mock.On call
+func (_e *Database_Expecter) GetBountyHunterPubkeys() *Database_GetBountyHunterPubkeys_Call {
+	return &Database_GetBountyHunterPubkeys_Call{Call: _e.mock.On("GetBountyHunterPubkeys")}
+}
+
+func (_c *Database_GetBountyHunterPubkeys_Call) Run(run func()) *Database_GetBountyHunterPubkeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyHunterPubkeys_Call) Return(_a0 []string) *Database_GetBountyHunterPubkeys_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyHunterPubkeys_Call) RunAndReturn(run func() []string) *Database_GetBountyHunterPubkeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyIndexById provides a mock function with given fields: id
+func (_m *Database) GetBountyIndexById(id string) int64 {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyIndexById")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetBountyIndexById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyIndexById'
+type Database_GetBountyIndexById_Call struct {
+	*mock.Call
+}
+
+// GetBountyIndexById is a helper method to define mock.On call
+//   - id string
+func (_e *Database_Expecter) GetBountyIndexById(id interface{}) *Database_GetBountyIndexById_Call {
+	return &Database_GetBountyIndexById_Call{Call: _e.mock.On("GetBountyIndexById", id)}
+}
+
+func (_c *Database_GetBountyIndexById_Call) Run(run func(id string)) *Database_GetBountyIndexById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyIndexById_Call) Return(_a0 int64) *Database_GetBountyIndexById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyIndexById_Call) RunAndReturn(run func(string) int64) *Database_GetBountyIndexById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyPayment provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyPayment(bountyId uint) db.NewPaymentHistory {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyPayment")
+	}
+
+	var r0 db.NewPaymentHistory
+	if rf, ok := ret.Get(0).(func(uint) db.NewPaymentHistory); ok {
+		r0 = rf(bountyId)
+	} else {
+		r0 = ret.Get(0).(db.NewPaymentHistory)
+	}
+
+	return r0
+}
+
+// Database_GetBountyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyPayment'
+type Database_GetBountyPayment_Call struct {
+	*mock.Call
+}
+
+// GetBountyPayment is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyPayment(bountyId interface{}) *Database_GetBountyPayment_Call {
+	return &Database_GetBountyPayment_Call{Call: _e.mock.On("GetBountyPayment", bountyId)}
+}
+
+func (_c *Database_GetBountyPayment_Call) Run(run func(bountyId uint)) *Database_GetBountyPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyPayment_Call) Return(_a0 db.NewPaymentHistory) *Database_GetBountyPayment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyPayment_Call) RunAndReturn(run func(uint) db.NewPaymentHistory) *Database_GetBountyPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyProof provides a mock function with given fields: id
+func (_m *Database) GetBountyProof(id uint) db.BountyProof {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyProof")
+	}
+
+	var r0 db.BountyProof
+	if rf, ok := ret.Get(0).(func(uint) db.BountyProof); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.BountyProof)
+	}
+
+	return r0
+}
+
+// Database_GetBountyProof_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyProof'
+type Database_GetBountyProof_Call struct {
+	*mock.Call
+}
+
+// GetBountyProof is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetBountyProof(id interface{}) *Database_GetBountyProof_Call {
+	return &Database_GetBountyProof_Call{Call: _e.mock.On("GetBountyProof", id)}
+}
+
+func (_c *Database_GetBountyProof_Call) Run(run func(id uint)) *Database_GetBountyProof_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyProof_Call) Return(_a0 db.BountyProof) *Database_GetBountyProof_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyProof_Call) RunAndReturn(run func(uint) db.BountyProof) *Database_GetBountyProof_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyProofs provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyProofs(bountyId uint) []db.BountyProof {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyProofs")
+	}
+
+	var r0 []db.BountyProof
+	if rf, ok := ret.Get(0).(func(uint) []db.BountyProof); ok {
+		r0 = rf(bountyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyProof)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyProofs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyProofs'
+type Database_GetBountyProofs_Call struct {
+	*mock.Call
+}
+
+// GetBountyProofs is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyProofs(bountyId interface{}) *Database_GetBountyProofs_Call {
+	return &Database_GetBountyProofs_Call{Call: _e.mock.On("GetBountyProofs", bountyId)}
+}
+
+func (_c *Database_GetBountyProofs_Call) Run(run func(bountyId uint)) *Database_GetBountyProofs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyProofs_Call) Return(_a0 []db.BountyProof) *Database_GetBountyProofs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyProofs_Call) RunAndReturn(run func(uint) []db.BountyProof) *Database_GetBountyProofs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyProofsByBountyIds provides a mock function with given fields: ids
+func (_m *Database) GetBountyProofsByBountyIds(ids []uint) []db.BountyProof {
+	ret := _m.Called(ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyProofsByBountyIds")
+	}
+
+	var r0 []db.BountyProof
+	if rf, ok := ret.Get(0).(func([]uint) []db.BountyProof); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyProof)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyProofsByBountyIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyProofsByBountyIds'
+type Database_GetBountyProofsByBountyIds_Call struct {
+	*mock.Call
+}
+
+// GetBountyProofsByBountyIds is a helper method to define mock.On call
+//   - ids []uint
+func (_e *Database_Expecter) GetBountyProofsByBountyIds(ids interface{}) *Database_GetBountyProofsByBountyIds_Call {
+	return &Database_GetBountyProofsByBountyIds_Call{Call: _e.mock.On("GetBountyProofsByBountyIds", ids)}
+}
+
+func (_c *Database_GetBountyProofsByBountyIds_Call) Run(run func(ids []uint)) *Database_GetBountyProofsByBountyIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyProofsByBountyIds_Call) Return(_a0 []db.BountyProof) *Database_GetBountyProofsByBountyIds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyProofsByBountyIds_Call) RunAndReturn(run func([]uint) []db.BountyProof) *Database_GetBountyProofsByBountyIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyPullRequests provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyPullRequests(bountyId uint) []db.RepositoryPullRequest {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyPullRequests")
+	}
+
+	var r0 []db.RepositoryPullRequest
+	if rf, ok := ret.Get(0).(func(uint) []db.RepositoryPullRequest); ok {
+		r0 = rf(bountyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RepositoryPullRequest)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyPullRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyPullRequests'
+type Database_GetBountyPullRequests_Call struct {
+	*mock.Call
+}
+
+// GetBountyPullRequests is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyPullRequests(bountyId interface{}) *Database_GetBountyPullRequests_Call {
+	return &Database_GetBountyPullRequests_Call{Call: _e.mock.On("GetBountyPullRequests", bountyId)}
+}
+
+func (_c *Database_GetBountyPullRequests_Call) Run(run func(bountyId uint)) *Database_GetBountyPullRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyPullRequests_Call) Return(_a0 []db.RepositoryPullRequest) *Database_GetBountyPullRequests_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyPullRequests_Call) RunAndReturn(run func(uint) []db.RepositoryPullRequest) *Database_GetBountyPullRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyRoles provides a mock function with given fields:
+func (_m *Database) GetBountyRoles() []db.BountyRoles {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyRoles")
+	}
+
+	var r0 []db.BountyRoles
+	if rf, ok := ret.Get(0).(func() []db.BountyRoles); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyRoles)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyRoles'
+type Database_GetBountyRoles_Call struct {
+	*mock.Call
+}
+
+// GetBountyRoles is a helper method to define mock.On call
+func (_e *Database_Expecter) GetBountyRoles() *Database_GetBountyRoles_Call {
+	return &Database_GetBountyRoles_Call{Call: _e.mock.On("GetBountyRoles")}
+}
+
+func (_c *Database_GetBountyRoles_Call) Run(run func()) *Database_GetBountyRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyRoles_Call) Return(_a0 []db.BountyRoles) *Database_GetBountyRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyRoles_Call) RunAndReturn(run func() []db.BountyRoles) *Database_GetBountyRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyWorkSessions provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyWorkSessions(bountyId uint) []db.BountyWorkSession {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyWorkSessions")
+	}
+
+	var r0 []db.BountyWorkSession
+	if rf, ok := ret.Get(0).(func(uint) []db.BountyWorkSession); ok {
+		r0 = rf(bountyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyWorkSession)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyWorkSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyWorkSessions'
+type Database_GetBountyWorkSessions_Call struct {
+	*mock.Call
+}
+
+// GetBountyWorkSessions is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyWorkSessions(bountyId interface{}) *Database_GetBountyWorkSessions_Call {
+	return &Database_GetBountyWorkSessions_Call{Call: _e.mock.On("GetBountyWorkSessions", bountyId)}
+}
+
+func (_c *Database_GetBountyWorkSessions_Call) Run(run func(bountyId uint)) *Database_GetBountyWorkSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyWorkSessions_Call) Return(_a0 []db.BountyWorkSession) *Database_GetBountyWorkSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyWorkSessions_Call) RunAndReturn(run func(uint) []db.BountyWorkSession) *Database_GetBountyWorkSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCampaignConnectionCode provides a mock function with given fields: campaign, ip, device
+func (_m *Database) GetCampaignConnectionCode(campaign string, ip string, device string) db.ConnectionCodesShort {
+	ret := _m.Called(campaign, ip, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCampaignConnectionCode")
+	}
+
+	var r0 db.ConnectionCodesShort
+	if rf, ok := ret.Get(0).(func(string, string, string) db.ConnectionCodesShort); ok {
+		r0 = rf(campaign, ip, device)
+	} else {
+		r0 = ret.Get(0).(db.ConnectionCodesShort)
+	}
+
+	return r0
+}
+
+// Database_GetCampaignConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCampaignConnectionCode'
+type Database_GetCampaignConnectionCode_Call struct {
+	*mock.Call
+}
+
+// GetCampaignConnectionCode is a helper method to define mock.On call
+//   - campaign string
+//   - ip string
+//   - device string
+func (_e *Database_Expecter) GetCampaignConnectionCode(campaign interface{}, ip interface{}, device interface{}) *Database_GetCampaignConnectionCode_Call {
+	return &Database_GetCampaignConnectionCode_Call{Call: _e.mock.On("GetCampaignConnectionCode", campaign, ip, device)}
+}
+
+func (_c *Database_GetCampaignConnectionCode_Call) Run(run func(campaign string, ip string, device string)) *Database_GetCampaignConnectionCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetCampaignConnectionCode_Call) Return(_a0 db.ConnectionCodesShort) *Database_GetCampaignConnectionCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetCampaignConnectionCode_Call) RunAndReturn(run func(string, string, string) db.ConnectionCodesShort) *Database_GetCampaignConnectionCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCampaignConnectionCodes provides a mock function with given fields: campaign
+func (_m *Database) GetCampaignConnectionCodes(campaign string) []db.ConnectionCodes {
+	ret := _m.Called(campaign)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCampaignConnectionCodes")
+	}
+
+	var r0 []db.ConnectionCodes
+	if rf, ok := ret.Get(0).(func(string) []db.ConnectionCodes); ok {
+		r0 = rf(campaign)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ConnectionCodes)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetCampaignConnectionCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCampaignConnectionCodes'
+type Database_GetCampaignConnectionCodes_Call struct {
+	*mock.Call
+}
+
+// GetCampaignConnectionCodes is a helper method to define mock.On call
+//   - campaign string
+func (_e *Database_Expecter) GetCampaignConnectionCodes(campaign interface{}) *Database_GetCampaignConnectionCodes_Call {
+	return &Database_GetCampaignConnectionCodes_Call{Call: _e.mock.On("GetCampaignConnectionCodes", campaign)}
+}
+
+func (_c *Database_GetCampaignConnectionCodes_Call) Run(run func(campaign string)) *Database_GetCampaignConnectionCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetCampaignConnectionCodes_Call) Return(_a0 []db.ConnectionCodes) *Database_GetCampaignConnectionCodes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetCampaignConnectionCodes_Call) RunAndReturn(run func(string) []db.ConnectionCodes) *Database_GetCampaignConnectionCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChannel provides a mock function with given fields: id
+func (_m *Database) GetChannel(id uint) db.Channel {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannel")
+	}
+
+	var r0 db.Channel
+	if rf, ok := ret.Get(0).(func(uint) db.Channel); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.Channel)
+	}
+
+	return r0
+}
+
+// Database_GetChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannel'
+type Database_GetChannel_Call struct {
+	*mock.Call
+}
+
+// GetChannel is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetChannel(id interface{}) *Database_GetChannel_Call {
+	return &Database_GetChannel_Call{Call: _e.mock.On("GetChannel", id)}
+}
+
+func (_c *Database_GetChannel_Call) Run(run func(id uint)) *Database_GetChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetChannel_Call) Return(_a0 db.Channel) *Database_GetChannel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetChannel_Call) RunAndReturn(run func(uint) db.Channel) *Database_GetChannel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChannelsByTribe provides a mock function with given fields: tribe_uuid
+func (_m *Database) GetChannelsByTribe(tribe_uuid string) []db.Channel {
+	ret := _m.Called(tribe_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannelsByTribe")
+	}
+
+	var r0 []db.Channel
+	if rf, ok := ret.Get(0).(func(string) []db.Channel); ok {
+		r0 = rf(tribe_uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Channel)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetChannelsByTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannelsByTribe'
+type Database_GetChannelsByTribe_Call struct {
+	*mock.Call
+}
+
+// GetChannelsByTribe is a helper method to define mock.On call
+//   - tribe_uuid string
+func (_e *Database_Expecter) GetChannelsByTribe(tribe_uuid interface{}) *Database_GetChannelsByTribe_Call {
+	return &Database_GetChannelsByTribe_Call{Call: _e.mock.On("GetChannelsByTribe", tribe_uuid)}
+}
+
+func (_c *Database_GetChannelsByTribe_Call) Run(run func(tribe_uuid string)) *Database_GetChannelsByTribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetChannelsByTribe_Call) Return(_a0 []db.Channel) *Database_GetChannelsByTribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetChannelsByTribe_Call) RunAndReturn(run func(string) []db.Channel) *Database_GetChannelsByTribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCompletedBountyLanguages provides a mock function with given fields: pubkey
+func (_m *Database) GetCompletedBountyLanguages(pubkey string) map[string]int {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCompletedBountyLanguages")
+	}
+
+	var r0 map[string]int
+	if rf, ok := ret.Get(0).(func(string) map[string]int); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetCompletedBountyLanguages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCompletedBountyLanguages'
+type Database_GetCompletedBountyLanguages_Call struct {
+	*mock.Call
+}
+
+// GetCompletedBountyLanguages is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetCompletedBountyLanguages(pubkey interface{}) *Database_GetCompletedBountyLanguages_Call {
+	return &Database_GetCompletedBountyLanguages_Call{Call: _e.mock.On("GetCompletedBountyLanguages", pubkey)}
+}
+
+func (_c *Database_GetCompletedBountyLanguages_Call) Run(run func(pubkey string)) *Database_GetCompletedBountyLanguages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetCompletedBountyLanguages_Call) Return(_a0 map[string]int) *Database_GetCompletedBountyLanguages_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetCompletedBountyLanguages_Call) RunAndReturn(run func(string) map[string]int) *Database_GetCompletedBountyLanguages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConnectionCode provides a mock function with given fields:
+func (_m *Database) GetConnectionCode() db.ConnectionCodesShort {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConnectionCode")
+	}
+
+	var r0 db.ConnectionCodesShort
+	if rf, ok := ret.Get(0).(func() db.ConnectionCodesShort); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(db.ConnectionCodesShort)
+	}
+
+	return r0
+}
+
+// Database_GetConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConnectionCode'
+type Database_GetConnectionCode_Call struct {
+	*mock.Call
+}
+
+// GetConnectionCode is a helper method to define mock.On call
+func (_e *Database_Expecter) GetConnectionCode() *Database_GetConnectionCode_Call {
+	return &Database_GetConnectionCode_Call{Call: _e.mock.On("GetConnectionCode")}
+}
+
+func (_c *Database_GetConnectionCode_Call) Run(run func()) *Database_GetConnectionCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetConnectionCode_Call) Return(_a0 db.ConnectionCodesShort) *Database_GetConnectionCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetConnectionCode_Call) RunAndReturn(run func() db.ConnectionCodesShort) *Database_GetConnectionCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConnectionCodeCampaigns provides a mock function with given fields:
+func (_m *Database) GetConnectionCodeCampaigns() []db.ConnectionCodeCampaign {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConnectionCodeCampaigns")
+	}
+
+	var r0 []db.ConnectionCodeCampaign
+	if rf, ok := ret.Get(0).(func() []db.ConnectionCodeCampaign); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ConnectionCodeCampaign)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetConnectionCodeCampaigns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConnectionCodeCampaigns'
+type Database_GetConnectionCodeCampaigns_Call struct {
+	*mock.Call
+}
+
+// GetConnectionCodeCampaigns is a helper method to define mock.On call
+func (_e *Database_Expecter) GetConnectionCodeCampaigns() *Database_GetConnectionCodeCampaigns_Call {
+	return &Database_GetConnectionCodeCampaigns_Call{Call: _e.mock.On("GetConnectionCodeCampaigns")}
+}
+
+func (_c *Database_GetConnectionCodeCampaigns_Call) Run(run func()) *Database_GetConnectionCodeCampaigns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetConnectionCodeCampaigns_Call) Return(_a0 []db.ConnectionCodeCampaign) *Database_GetConnectionCodeCampaigns_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetConnectionCodeCampaigns_Call) RunAndReturn(run func() []db.ConnectionCodeCampaign) *Database_GetConnectionCodeCampaigns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContentAppealByReport provides a mock function with given fields: reportUuid
+func (_m *Database) GetContentAppealByReport(reportUuid string) db.ContentAppeal {
+	ret := _m.Called(reportUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContentAppealByReport")
+	}
+
+	var r0 db.ContentAppeal
+	if rf, ok := ret.Get(0).(func(string) db.ContentAppeal); ok {
+		r0 = rf(reportUuid)
+	} else {
+		r0 = ret.Get(0).(db.ContentAppeal)
+	}
+
+	return r0
+}
+
+// Database_GetContentAppealByReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContentAppealByReport'
+type Database_GetContentAppealByReport_Call struct {
+	*mock.Call
+}
+
+// GetContentAppealByReport is a helper method to define mock.On call
+//   - reportUuid string
+func (_e *Database_Expecter) GetContentAppealByReport(reportUuid interface{}) *Database_GetContentAppealByReport_Call {
+	return &Database_GetContentAppealByReport_Call{Call: _e.mock.On("GetContentAppealByReport", reportUuid)}
+}
+
+func (_c *Database_GetContentAppealByReport_Call) Run(run func(reportUuid string)) *Database_GetContentAppealByReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetContentAppealByReport_Call) Return(_a0 db.ContentAppeal) *Database_GetContentAppealByReport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetContentAppealByReport_Call) RunAndReturn(run func(string) db.ContentAppeal) *Database_GetContentAppealByReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContentAppealByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetContentAppealByUuid(uuid string) db.ContentAppeal {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContentAppealByUuid")
+	}
+
+	var r0 db.ContentAppeal
+	if rf, ok := ret.Get(0).(func(string) db.ContentAppeal); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.ContentAppeal)
+	}
+
+	return r0
+}
+
+// Database_GetContentAppealByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContentAppealByUuid'
+type Database_GetContentAppealByUuid_Call struct {
+	*mock.Call
+}
+
+// GetContentAppealByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetContentAppealByUuid(uuid interface{}) *Database_GetContentAppealByUuid_Call {
+	return &Database_GetContentAppealByUuid_Call{Call: _e.mock.On("GetContentAppealByUuid", uuid)}
+}
+
+func (_c *Database_GetContentAppealByUuid_Call) Run(run func(uuid string)) *Database_GetContentAppealByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetContentAppealByUuid_Call) Return(_a0 db.ContentAppeal) *Database_GetContentAppealByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetContentAppealByUuid_Call) RunAndReturn(run func(string) db.ContentAppeal) *Database_GetContentAppealByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContentAppeals provides a mock function with given fields: status
+func (_m *Database) GetContentAppeals(status db.AppealStatus) []db.ContentAppeal {
+	ret := _m.Called(status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContentAppeals")
+	}
+
+	var r0 []db.ContentAppeal
+	if rf, ok := ret.Get(0).(func(db.AppealStatus) []db.ContentAppeal); ok {
+		r0 = rf(status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ContentAppeal)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetContentAppeals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContentAppeals'
+type Database_GetContentAppeals_Call struct {
+	*mock.Call
+}
+
+// GetContentAppeals is a helper method to define mock.On call
+//   - status db.AppealStatus
+func (_e *Database_Expecter) GetContentAppeals(status interface{}) *Database_GetContentAppeals_Call {
+	return &Database_GetContentAppeals_Call{Call: _e.mock.On("GetContentAppeals", status)}
+}
+
+func (_c *Database_GetContentAppeals_Call) Run(run func(status db.AppealStatus)) *Database_GetContentAppeals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.AppealStatus))
+	})
+	return _c
+}
+
+func (_c *Database_GetContentAppeals_Call) Return(_a0 []db.ContentAppeal) *Database_GetContentAppeals_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetContentAppeals_Call) RunAndReturn(run func(db.AppealStatus) []db.ContentAppeal) *Database_GetContentAppeals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContentReportByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetContentReportByUuid(uuid string) db.ContentReport {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContentReportByUuid")
+	}
+
+	var r0 db.ContentReport
+	if rf, ok := ret.Get(0).(func(string) db.ContentReport); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.ContentReport)
+	}
+
+	return r0
+}
+
+// Database_GetContentReportByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContentReportByUuid'
+type Database_GetContentReportByUuid_Call struct {
+	*mock.Call
+}
+
+// GetContentReportByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetContentReportByUuid(uuid interface{}) *Database_GetContentReportByUuid_Call {
+	return &Database_GetContentReportByUuid_Call{Call: _e.mock.On("GetContentReportByUuid", uuid)}
+}
+
+func (_c *Database_GetContentReportByUuid_Call) Run(run func(uuid string)) *Database_GetContentReportByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetContentReportByUuid_Call) Return(_a0 db.ContentReport) *Database_GetContentReportByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetContentReportByUuid_Call) RunAndReturn(run func(string) db.ContentReport) *Database_GetContentReportByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContentReports provides a mock function with given fields: status, targetType
+func (_m *Database) GetContentReports(status db.ReportStatus, targetType db.ReportTargetType) []db.ContentReport {
+	ret := _m.Called(status, targetType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContentReports")
+	}
+
+	var r0 []db.ContentReport
+	if rf, ok := ret.Get(0).(func(db.ReportStatus, db.ReportTargetType) []db.ContentReport); ok {
+		r0 = rf(status, targetType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ContentReport)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetContentReports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContentReports'
+type Database_GetContentReports_Call struct {
+	*mock.Call
+}
+
+// GetContentReports is a helper method to define mock.On call
+//   - status db.ReportStatus
+//   - targetType db.ReportTargetType
+func (_e *Database_Expecter) GetContentReports(status interface{}, targetType interface{}) *Database_GetContentReports_Call {
+	return &Database_GetContentReports_Call{Call: _e.mock.On("GetContentReports", status, targetType)}
+}
+
+func (_c *Database_GetContentReports_Call) Run(run func(status db.ReportStatus, targetType db.ReportTargetType)) *Database_GetContentReports_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.ReportStatus), args[1].(db.ReportTargetType))
+	})
+	return _c
+}
+
+func (_c *Database_GetContentReports_Call) Return(_a0 []db.ContentReport) *Database_GetContentReports_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetContentReports_Call) RunAndReturn(run func(db.ReportStatus, db.ReportTargetType) []db.ContentReport) *Database_GetContentReports_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCreatedBounties provides a mock function with given fields: r
+func (_m *Database) GetCreatedBounties(r *http.Request) ([]db.NewBounty, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCreatedBounties")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.NewBounty, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetCreatedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCreatedBounties'
+type Database_GetCreatedBounties_Call struct {
+	*mock.Call
+}
+
+// GetCreatedBounties is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetCreatedBounties(r interface{}) *Database_GetCreatedBounties_Call {
+	return &Database_GetCreatedBounties_Call{Call: _e.mock.On("GetCreatedBounties", r)}
+}
+
+func (_c *Database_GetCreatedBounties_Call) Run(run func(r *http.Request)) *Database_GetCreatedBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetCreatedBounties_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetCreatedBounties_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetCreatedBounties_Call) RunAndReturn(run func(*http.Request) ([]db.NewBounty, error)) *Database_GetCreatedBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletionsSince provides a mock function with given fields: kind, since
+func (_m *Database) GetDeletionsSince(kind string, since time.Time) []db.Deletion {
+	ret := _m.Called(kind, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletionsSince")
+	}
+
+	var r0 []db.Deletion
+	if rf, ok := ret.Get(0).(func(string, time.Time) []db.Deletion); ok {
+		r0 = rf(kind, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Deletion)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetDeletionsSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletionsSince'
+type Database_GetDeletionsSince_Call struct {
+	*mock.Call
+}
+
+// GetDeletionsSince is a helper method to define mock.On call
+//   - kind string
+//   - since time.Time
+func (_e *Database_Expecter) GetDeletionsSince(kind interface{}, since interface{}) *Database_GetDeletionsSince_Call {
+	return &Database_GetDeletionsSince_Call{Call: _e.mock.On("GetDeletionsSince", kind, since)}
+}
+
+func (_c *Database_GetDeletionsSince_Call) Run(run func(kind string, since time.Time)) *Database_GetDeletionsSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetDeletionsSince_Call) Return(_a0 []db.Deletion) *Database_GetDeletionsSince_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetDeletionsSince_Call) RunAndReturn(run func(string, time.Time) []db.Deletion) *Database_GetDeletionsSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDisputeByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetDisputeByUuid(uuid string) db.BountyDispute {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDisputeByUuid")
+	}
+
+	var r0 db.BountyDispute
+	if rf, ok := ret.Get(0).(func(string) db.BountyDispute); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.BountyDispute)
+	}
+
+	return r0
+}
+
+// Database_GetDisputeByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDisputeByUuid'
+type Database_GetDisputeByUuid_Call struct {
+	*mock.Call
+}
+
+// GetDisputeByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetDisputeByUuid(uuid interface{}) *Database_GetDisputeByUuid_Call {
+	return &Database_GetDisputeByUuid_Call{Call: _e.mock.On("GetDisputeByUuid", uuid)}
+}
+
+func (_c *Database_GetDisputeByUuid_Call) Run(run func(uuid string)) *Database_GetDisputeByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetDisputeByUuid_Call) Return(_a0 db.BountyDispute) *Database_GetDisputeByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetDisputeByUuid_Call) RunAndReturn(run func(string) db.BountyDispute) *Database_GetDisputeByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDisputeEvidence provides a mock function with given fields: disputeId
+func (_m *Database) GetDisputeEvidence(disputeId uint) []db.DisputeEvidence {
+	ret := _m.Called(disputeId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDisputeEvidence")
+	}
+
+	var r0 []db.DisputeEvidence
+	if rf, ok := ret.Get(0).(func(uint) []db.DisputeEvidence); ok {
+		r0 = rf(disputeId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.DisputeEvidence)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetDisputeEvidence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDisputeEvidence'
+type Database_GetDisputeEvidence_Call struct {
+	*mock.Call
+}
+
+// GetDisputeEvidence is a helper method to define mock.On call
+//   - disputeId uint
+func (_e *Database_Expecter) GetDisputeEvidence(disputeId interface{}) *Database_GetDisputeEvidence_Call {
+	return &Database_GetDisputeEvidence_Call{Call: _e.mock.On("GetDisputeEvidence", disputeId)}
+}
+
+func (_c *Database_GetDisputeEvidence_Call) Run(run func(disputeId uint)) *Database_GetDisputeEvidence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetDisputeEvidence_Call) Return(_a0 []db.DisputeEvidence) *Database_GetDisputeEvidence_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetDisputeEvidence_Call) RunAndReturn(run func(uint) []db.DisputeEvidence) *Database_GetDisputeEvidence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDisputes provides a mock function with given fields: workspaceUuid, status
+func (_m *Database) GetDisputes(workspaceUuid string, status db.DisputeStatus) []db.BountyDispute {
+	ret := _m.Called(workspaceUuid, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDisputes")
+	}
+
+	var r0 []db.BountyDispute
+	if rf, ok := ret.Get(0).(func(string, db.DisputeStatus) []db.BountyDispute); ok {
+		r0 = rf(workspaceUuid, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyDispute)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetDisputes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDisputes'
+type Database_GetDisputes_Call struct {
+	*mock.Call
+}
+
+// GetDisputes is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - status db.DisputeStatus
+func (_e *Database_Expecter) GetDisputes(workspaceUuid interface{}, status interface{}) *Database_GetDisputes_Call {
+	return &Database_GetDisputes_Call{Call: _e.mock.On("GetDisputes", workspaceUuid, status)}
+}
+
+func (_c *Database_GetDisputes_Call) Run(run func(workspaceUuid string, status db.DisputeStatus)) *Database_GetDisputes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(db.DisputeStatus))
+	})
+	return _c
+}
+
+func (_c *Database_GetDisputes_Call) Return(_a0 []db.BountyDispute) *Database_GetDisputes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetDisputes_Call) RunAndReturn(run func(string, db.DisputeStatus) []db.BountyDispute) *Database_GetDisputes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDueAccountPurges provides a mock function with given fields:
+func (_m *Database) GetDueAccountPurges() []db.AccountPurge {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueAccountPurges")
+	}
+
+	var r0 []db.AccountPurge
+	if rf, ok := ret.Get(0).(func() []db.AccountPurge); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.AccountPurge)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetDueAccountPurges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDueAccountPurges'
+type Database_GetDueAccountPurges_Call struct {
+	*mock.Call
+}
+
+// GetDueAccountPurges is a helper method to define mock.On call
+func (_e *Database_Expecter) GetDueAccountPurges() *Database_GetDueAccountPurges_Call {
+	return &Database_GetDueAccountPurges_Call{Call: _e.mock.On("GetDueAccountPurges")}
+}
+
+func (_c *Database_GetDueAccountPurges_Call) Run(run func()) *Database_GetDueAccountPurges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetDueAccountPurges_Call) Return(_a0 []db.AccountPurge) *Database_GetDueAccountPurges_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetDueAccountPurges_Call) RunAndReturn(run func() []db.AccountPurge) *Database_GetDueAccountPurges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDuePaymentRetries provides a mock function with given fields: now
+func (_m *Database) GetDuePaymentRetries(now time.Time) []db.PaymentAttempt {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuePaymentRetries")
+	}
+
+	var r0 []db.PaymentAttempt
+	if rf, ok := ret.Get(0).(func(time.Time) []db.PaymentAttempt); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PaymentAttempt)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetDuePaymentRetries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDuePaymentRetries'
+type Database_GetDuePaymentRetries_Call struct {
+	*mock.Call
+}
+
+// GetDuePaymentRetries is a helper method to define mock.On call
+//   - now time.Time
+func (_e *Database_Expecter) GetDuePaymentRetries(now interface{}) *Database_GetDuePaymentRetries_Call {
+	return &Database_GetDuePaymentRetries_Call{Call: _e.mock.On("GetDuePaymentRetries", now)}
+}
+
+func (_c *Database_GetDuePaymentRetries_Call) Run(run func(now time.Time)) *Database_GetDuePaymentRetries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetDuePaymentRetries_Call) Return(_a0 []db.PaymentAttempt) *Database_GetDuePaymentRetries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetDuePaymentRetries_Call) RunAndReturn(run func(time.Time) []db.PaymentAttempt) *Database_GetDuePaymentRetries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEndedImpersonations provides a mock function with given fields: now
+func (_m *Database) GetEndedImpersonations(now time.Time) []db.Impersonation {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEndedImpersonations")
+	}
+
+	var r0 []db.Impersonation
+	if rf, ok := ret.Get(0).(func(time.Time) []db.Impersonation); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Impersonation)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetEndedImpersonations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEndedImpersonations'
+type Database_GetEndedImpersonations_Call struct {
+	*mock.Call
+}
+
+// GetEndedImpersonations is a helper method to define mock.On call
+//   - now time.Time
+func (_e *Database_Expecter) GetEndedImpersonations(now interface{}) *Database_GetEndedImpersonations_Call {
+	return &Database_GetEndedImpersonations_Call{Call: _e.mock.On("GetEndedImpersonations", now)}
+}
+
+func (_c *Database_GetEndedImpersonations_Call) Run(run func(now time.Time)) *Database_GetEndedImpersonations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetEndedImpersonations_Call) Return(_a0 []db.Impersonation) *Database_GetEndedImpersonations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetEndedImpersonations_Call) RunAndReturn(run func(time.Time) []db.Impersonation) *Database_GetEndedImpersonations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEndorsementByBountyId provides a mock function with given fields: bountyId
+func (_m *Database) GetEndorsementByBountyId(bountyId uint) db.Endorsement {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEndorsementByBountyId")
+	}
+
+	var r0 db.Endorsement
+	if rf, ok := ret.Get(0).(func(uint) db.Endorsement); ok {
+		r0 = rf(bountyId)
+	} else {
+		r0 = ret.Get(0).(db.Endorsement)
+	}
+
+	return r0
+}
+
+// Database_GetEndorsementByBountyId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEndorsementByBountyId'
+type Database_GetEndorsementByBountyId_Call struct {
+	*mock.Call
+}
+
+// GetEndorsementByBountyId is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetEndorsementByBountyId(bountyId interface{}) *Database_GetEndorsementByBountyId_Call {
+	return &Database_GetEndorsementByBountyId_Call{Call: _e.mock.On("GetEndorsementByBountyId", bountyId)}
+}
+
+func (_c *Database_GetEndorsementByBountyId_Call) Run(run func(bountyId uint)) *Database_GetEndorsementByBountyId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetEndorsementByBountyId_Call) Return(_a0 db.Endorsement) *Database_GetEndorsementByBountyId_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetEndorsementByBountyId_Call) RunAndReturn(run func(uint) db.Endorsement) *Database_GetEndorsementByBountyId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEndorsementSummary provides a mock function with given fields: pubkey
+func (_m *Database) GetEndorsementSummary(pubkey string) db.EndorsementSummary {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEndorsementSummary")
+	}
+
+	var r0 db.EndorsementSummary
+	if rf, ok := ret.Get(0).(func(string) db.EndorsementSummary); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Get(0).(db.EndorsementSummary)
+	}
+
+	return r0
+}
+
+// Database_GetEndorsementSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEndorsementSummary'
+type Database_GetEndorsementSummary_Call struct {
+	*mock.Call
+}
+
+// GetEndorsementSummary is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetEndorsementSummary(pubkey interface{}) *Database_GetEndorsementSummary_Call {
+	return &Database_GetEndorsementSummary_Call{Call: _e.mock.On("GetEndorsementSummary", pubkey)}
+}
+
+func (_c *Database_GetEndorsementSummary_Call) Run(run func(pubkey string)) *Database_GetEndorsementSummary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetEndorsementSummary_Call) Return(_a0 db.EndorsementSummary) *Database_GetEndorsementSummary_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetEndorsementSummary_Call) RunAndReturn(run func(string) db.EndorsementSummary) *Database_GetEndorsementSummary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEndorsementsByPubkey provides a mock function with given fields: pubkey
+func (_m *Database) GetEndorsementsByPubkey(pubkey string) []db.Endorsement {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEndorsementsByPubkey")
+	}
+
+	var r0 []db.Endorsement
+	if rf, ok := ret.Get(0).(func(string) []db.Endorsement); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Endorsement)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetEndorsementsByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEndorsementsByPubkey'
+type Database_GetEndorsementsByPubkey_Call struct {
+	*mock.Call
+}
+
+// GetEndorsementsByPubkey is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetEndorsementsByPubkey(pubkey interface{}) *Database_GetEndorsementsByPubkey_Call {
+	return &Database_GetEndorsementsByPubkey_Call{Call: _e.mock.On("GetEndorsementsByPubkey", pubkey)}
+}
+
+func (_c *Database_GetEndorsementsByPubkey_Call) Run(run func(pubkey string)) *Database_GetEndorsementsByPubkey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetEndorsementsByPubkey_Call) Return(_a0 []db.Endorsement) *Database_GetEndorsementsByPubkey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetEndorsementsByPubkey_Call) RunAndReturn(run func(string) []db.Endorsement) *Database_GetEndorsementsByPubkey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetFeatureByUuid(uuid string) db.WorkspaceFeatures {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureByUuid")
+	}
+
+	var r0 db.WorkspaceFeatures
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceFeatures); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceFeatures)
+	}
+
+	return r0
+}
+
+// Database_GetFeatureByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureByUuid'
+type Database_GetFeatureByUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeatureByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetFeatureByUuid(uuid interface{}) *Database_GetFeatureByUuid_Call {
+	return &Database_GetFeatureByUuid_Call{Call: _e.mock.On("GetFeatureByUuid", uuid)}
+}
+
+func (_c *Database_GetFeatureByUuid_Call) Run(run func(uuid string)) *Database_GetFeatureByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureByUuid_Call) Return(_a0 db.WorkspaceFeatures) *Database_GetFeatureByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeatureByUuid_Call) RunAndReturn(run func(string) db.WorkspaceFeatures) *Database_GetFeatureByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureDependencies provides a mock function with given fields: itemType, itemUuid
+func (_m *Database) GetFeatureDependencies(itemType db.RoadmapItemType, itemUuid string) []db.FeatureDependency {
+	ret := _m.Called(itemType, itemUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureDependencies")
+	}
+
+	var r0 []db.FeatureDependency
+	if rf, ok := ret.Get(0).(func(db.RoadmapItemType, string) []db.FeatureDependency); ok {
+		r0 = rf(itemType, itemUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeatureDependency)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeatureDependencies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureDependencies'
+type Database_GetFeatureDependencies_Call struct {
+	*mock.Call
+}
+
+// GetFeatureDependencies is a helper method to define mock.On call
+//   - itemType db.RoadmapItemType
+//   - itemUuid string
+func (_e *Database_Expecter) GetFeatureDependencies(itemType interface{}, itemUuid interface{}) *Database_GetFeatureDependencies_Call {
+	return &Database_GetFeatureDependencies_Call{Call: _e.mock.On("GetFeatureDependencies", itemType, itemUuid)}
+}
+
+func (_c *Database_GetFeatureDependencies_Call) Run(run func(itemType db.RoadmapItemType, itemUuid string)) *Database_GetFeatureDependencies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.RoadmapItemType), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureDependencies_Call) Return(_a0 []db.FeatureDependency) *Database_GetFeatureDependencies_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeatureDependencies_Call) RunAndReturn(run func(db.RoadmapItemType, string) []db.FeatureDependency) *Database_GetFeatureDependencies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureDependency provides a mock function with given fields: id
+func (_m *Database) GetFeatureDependency(id uint) db.FeatureDependency {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureDependency")
+	}
+
+	var r0 db.FeatureDependency
+	if rf, ok := ret.Get(0).(func(uint) db.FeatureDependency); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.FeatureDependency)
+	}
+
+	return r0
+}
+
+// Database_GetFeatureDependency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureDependency'
+type Database_GetFeatureDependency_Call struct {
+	*mock.Call
+}
+
+// GetFeatureDependency is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetFeatureDependency(id interface{}) *Database_GetFeatureDependency_Call {
+	return &Database_GetFeatureDependency_Call{Call: _e.mock.On("GetFeatureDependency", id)}
+}
+
+func (_c *Database_GetFeatureDependency_Call) Run(run func(id uint)) *Database_GetFeatureDependency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureDependency_Call) Return(_a0 db.FeatureDependency) *Database_GetFeatureDependency_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeatureDependency_Call) RunAndReturn(run func(uint) db.FeatureDependency) *Database_GetFeatureDependency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeaturePhaseByUuid provides a mock function with given fields: featureUuid, phaseUuid
+func (_m *Database) GetFeaturePhaseByUuid(featureUuid string, phaseUuid string) (db.FeaturePhase, error) {
+	ret := _m.Called(featureUuid, phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturePhaseByUuid")
+	}
+
+	var r0 db.FeaturePhase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.FeaturePhase, error)); ok {
+		return rf(featureUuid, phaseUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.FeaturePhase); ok {
+		r0 = rf(featureUuid, phaseUuid)
+	} else {
+		r0 = ret.Get(0).(db.FeaturePhase)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(featureUuid, phaseUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFeaturePhaseByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturePhaseByUuid'
+type Database_GetFeaturePhaseByUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeaturePhaseByUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - phaseUuid string
+func (_e *Database_Expecter) GetFeaturePhaseByUuid(featureUuid interface{}, phaseUuid interface{}) *Database_GetFeaturePhaseByUuid_Call {
+	return &Database_GetFeaturePhaseByUuid_Call{Call: _e.mock.On("GetFeaturePhaseByUuid", featureUuid, phaseUuid)}
+}
+
+func (_c *Database_GetFeaturePhaseByUuid_Call) Run(run func(featureUuid string, phaseUuid string)) *Database_GetFeaturePhaseByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeaturePhaseByUuid_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_GetFeaturePhaseByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFeaturePhaseByUuid_Call) RunAndReturn(run func(string, string) (db.FeaturePhase, error)) *Database_GetFeaturePhaseByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeaturePhasesBountiesCount provides a mock function with given fields: bountyType, phaseUuid
+func (_m *Database) GetFeaturePhasesBountiesCount(bountyType string, phaseUuid string) int64 {
+	ret := _m.Called(bountyType, phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturePhasesBountiesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
+		r0 = rf(bountyType, phaseUuid)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetFeaturePhasesBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturePhasesBountiesCount'
+type Database_GetFeaturePhasesBountiesCount_Call struct {
+	*mock.Call
+}
+
+// GetFeaturePhasesBountiesCount is a helper method to define mock.On call
+//   - bountyType string
+//   - phaseUuid string
+func (_e *Database_Expecter) GetFeaturePhasesBountiesCount(bountyType interface{}, phaseUuid interface{}) *Database_GetFeaturePhasesBountiesCount_Call {
+	return &Database_GetFeaturePhasesBountiesCount_Call{Call: _e.mock.On("GetFeaturePhasesBountiesCount", bountyType, phaseUuid)}
+}
+
+func (_c *Database_GetFeaturePhasesBountiesCount_Call) Run(run func(bountyType string, phaseUuid string)) *Database_GetFeaturePhasesBountiesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeaturePhasesBountiesCount_Call) Return(_a0 int64) *Database_GetFeaturePhasesBountiesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeaturePhasesBountiesCount_Call) RunAndReturn(run func(string, string) int64) *Database_GetFeaturePhasesBountiesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureStoriesByFeatureUuid provides a mock function with given fields: featureUuid
+func (_m *Database) GetFeatureStoriesByFeatureUuid(featureUuid string) ([]db.FeatureStory, error) {
+	ret := _m.Called(featureUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureStoriesByFeatureUuid")
+	}
+
+	var r0 []db.FeatureStory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.FeatureStory, error)); ok {
+		return rf(featureUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.FeatureStory); ok {
+		r0 = rf(featureUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeatureStory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(featureUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFeatureStoriesByFeatureUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureStoriesByFeatureUuid'
+type Database_GetFeatureStoriesByFeatureUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeatureStoriesByFeatureUuid is a helper method to define mock.On call
+//   - featureUuid string
+func (_e *Database_Expecter) GetFeatureStoriesByFeatureUuid(featureUuid interface{}) *Database_GetFeatureStoriesByFeatureUuid_Call {
+	return &Database_GetFeatureStoriesByFeatureUuid_Call{Call: _e.mock.On("GetFeatureStoriesByFeatureUuid", featureUuid)}
+}
+
+func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) Run(run func(featureUuid string)) *Database_GetFeatureStoriesByFeatureUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) Return(_a0 []db.FeatureStory, _a1 error) *Database_GetFeatureStoriesByFeatureUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) RunAndReturn(run func(string) ([]db.FeatureStory, error)) *Database_GetFeatureStoriesByFeatureUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureStoryByUuid provides a mock function with given fields: featureUuid, storyUuid
+func (_m *Database) GetFeatureStoryByUuid(featureUuid string, storyUuid string) (db.FeatureStory, error) {
+	ret := _m.Called(featureUuid, storyUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureStoryByUuid")
+	}
+
+	var r0 db.FeatureStory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.FeatureStory, error)); ok {
+		return rf(featureUuid, storyUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.FeatureStory); ok {
+		r0 = rf(featureUuid, storyUuid)
+	} else {
+		r0 = ret.Get(0).(db.FeatureStory)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(featureUuid, storyUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFeatureStoryByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureStoryByUuid'
+type Database_GetFeatureStoryByUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeatureStoryByUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - storyUuid string
+func (_e *Database_Expecter) GetFeatureStoryByUuid(featureUuid interface{}, storyUuid interface{}) *Database_GetFeatureStoryByUuid_Call {
+	return &Database_GetFeatureStoryByUuid_Call{Call: _e.mock.On("GetFeatureStoryByUuid", featureUuid, storyUuid)}
+}
+
+func (_c *Database_GetFeatureStoryByUuid_Call) Run(run func(featureUuid string, storyUuid string)) *Database_GetFeatureStoryByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureStoryByUuid_Call) Return(_a0 db.FeatureStory, _a1 error) *Database_GetFeatureStoryByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFeatureStoryByUuid_Call) RunAndReturn(run func(string, string) (db.FeatureStory, error)) *Database_GetFeatureStoryByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeaturedBots provides a mock function with given fields:
+func (_m *Database) GetFeaturedBots() []db.Bot {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturedBots")
+	}
+
+	var r0 []db.Bot
+	if rf, ok := ret.Get(0).(func() []db.Bot); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Bot)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeaturedBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturedBots'
+type Database_GetFeaturedBots_Call struct {
+	*mock.Call
+}
+
+// GetFeaturedBots is a helper method to define mock.On call
+func (_e *Database_Expecter) GetFeaturedBots() *Database_GetFeaturedBots_Call {
+	return &Database_GetFeaturedBots_Call{Call: _e.mock.On("GetFeaturedBots")}
+}
+
+func (_c *Database_GetFeaturedBots_Call) Run(run func()) *Database_GetFeaturedBots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetFeaturedBots_Call) Return(_a0 []db.Bot) *Database_GetFeaturedBots_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeaturedBots_Call) RunAndReturn(run func() []db.Bot) *Database_GetFeaturedBots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeaturedTribes provides a mock function with given fields:
+func (_m *Database) GetFeaturedTribes() []db.Tribe {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturedTribes")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func() []db.Tribe); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeaturedTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturedTribes'
+type Database_GetFeaturedTribes_Call struct {
+	*mock.Call
+}
+
+// GetFeaturedTribes is a helper method to define mock.On call
+func (_e *Database_Expecter) GetFeaturedTribes() *Database_GetFeaturedTribes_Call {
+	return &Database_GetFeaturedTribes_Call{Call: _e.mock.On("GetFeaturedTribes")}
+}
+
+func (_c *Database_GetFeaturedTribes_Call) Run(run func()) *Database_GetFeaturedTribes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetFeaturedTribes_Call) Return(_a0 []db.Tribe) *Database_GetFeaturedTribes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeaturedTribes_Call) RunAndReturn(run func() []db.Tribe) *Database_GetFeaturedTribes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeaturesByUuids provides a mock function with given fields: uuids
+func (_m *Database) GetFeaturesByUuids(uuids []string) []db.WorkspaceFeatures {
+	ret := _m.Called(uuids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturesByUuids")
+	}
+
+	var r0 []db.WorkspaceFeatures
+	if rf, ok := ret.Get(0).(func([]string) []db.WorkspaceFeatures); ok {
+		r0 = rf(uuids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceFeatures)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeaturesByUuids_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturesByUuids'
+type Database_GetFeaturesByUuids_Call struct {
+	*mock.Call
+}
+
+// GetFeaturesByUuids is a helper method to define mock.On call
+//   - uuids []string
+func (_e *Database_Expecter) GetFeaturesByUuids(uuids interface{}) *Database_GetFeaturesByUuids_Call {
+	return &Database_GetFeaturesByUuids_Call{Call: _e.mock.On("GetFeaturesByUuids", uuids)}
+}
+
+func (_c *Database_GetFeaturesByUuids_Call) Run(run func(uuids []string)) *Database_GetFeaturesByUuids_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeaturesByUuids_Call) Return(_a0 []db.WorkspaceFeatures) *Database_GetFeaturesByUuids_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeaturesByUuids_Call) RunAndReturn(run func([]string) []db.WorkspaceFeatures) *Database_GetFeaturesByUuids_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeaturesByWorkspaceUuid provides a mock function with given fields: uuid, r
+func (_m *Database) GetFeaturesByWorkspaceUuid(uuid string, r *http.Request) []db.WorkspaceFeatures {
+	ret := _m.Called(uuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturesByWorkspaceUuid")
+	}
+
+	var r0 []db.WorkspaceFeatures
+	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.WorkspaceFeatures); ok {
+		r0 = rf(uuid, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceFeatures)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeaturesByWorkspaceUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturesByWorkspaceUuid'
+type Database_GetFeaturesByWorkspaceUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeaturesByWorkspaceUuid is a helper method to define mock.On call
+//   - uuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetFeaturesByWorkspaceUuid(uuid interface{}, r interface{}) *Database_GetFeaturesByWorkspaceUuid_Call {
+	return &Database_GetFeaturesByWorkspaceUuid_Call{Call: _e.mock.On("GetFeaturesByWorkspaceUuid", uuid, r)}
+}
+
+func (_c *Database_GetFeaturesByWorkspaceUuid_Call) Run(run func(uuid string, r *http.Request)) *Database_GetFeaturesByWorkspaceUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeaturesByWorkspaceUuid_Call) Return(_a0 []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeaturesByWorkspaceUuid_Call) RunAndReturn(run func(string, *http.Request) []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeaturesByWorkspaceUuids provides a mock function with given fields: uuids
+func (_m *Database) GetFeaturesByWorkspaceUuids(uuids []string) []db.WorkspaceFeatures {
+	ret := _m.Called(uuids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturesByWorkspaceUuids")
+	}
+
+	var r0 []db.WorkspaceFeatures
+	if rf, ok := ret.Get(0).(func([]string) []db.WorkspaceFeatures); ok {
+		r0 = rf(uuids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceFeatures)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeaturesByWorkspaceUuids_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturesByWorkspaceUuids'
+type Database_GetFeaturesByWorkspaceUuids_Call struct {
+	*mock.Call
+}
+
+// GetFeaturesByWorkspaceUuids is a helper method to define mock.On call
+//   - uuids []string
+func (_e *Database_Expecter) GetFeaturesByWorkspaceUuids(uuids interface{}) *Database_GetFeaturesByWorkspaceUuids_Call {
+	return &Database_GetFeaturesByWorkspaceUuids_Call{Call: _e.mock.On("GetFeaturesByWorkspaceUuids", uuids)}
+}
+
+func (_c *Database_GetFeaturesByWorkspaceUuids_Call) Run(run func(uuids []string)) *Database_GetFeaturesByWorkspaceUuids_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeaturesByWorkspaceUuids_Call) Return(_a0 []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuids_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeaturesByWorkspaceUuids_Call) RunAndReturn(run func([]string) []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuids_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFilterStatusCount provides a mock function with given fields:
+func (_m *Database) GetFilterStatusCount() db.FilterStattuCount {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilterStatusCount")
+	}
+
+	var r0 db.FilterStattuCount
+	if rf, ok := ret.Get(0).(func() db.FilterStattuCount); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(db.FilterStattuCount)
+	}
+
+	return r0
+}
+
+// Database_GetFilterStatusCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFilterStatusCount'
+type Database_GetFilterStatusCount_Call struct {
+	*mock.Call
+}
+
+// GetFilterStatusCount is a helper method to define mock.On call
+func (_e *Database_Expecter) GetFilterStatusCount() *Database_GetFilterStatusCount_Call {
+	return &Database_GetFilterStatusCount_Call{Call: _e.mock.On("GetFilterStatusCount")}
+}
+
+func (_c *Database_GetFilterStatusCount_Call) Run(run func()) *Database_GetFilterStatusCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetFilterStatusCount_Call) Return(_a0 db.FilterStattuCount) *Database_GetFilterStatusCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFilterStatusCount_Call) RunAndReturn(run func() db.FilterStattuCount) *Database_GetFilterStatusCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFirstTribeByFeedURL provides a mock function with given fields: feedURL
+func (_m *Database) GetFirstTribeByFeedURL(feedURL string) db.Tribe {
+	ret := _m.Called(feedURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirstTribeByFeedURL")
+	}
+
+	var r0 db.Tribe
+	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
+		r0 = rf(feedURL)
+	} else {
+		r0 = ret.Get(0).(db.Tribe)
+	}
+
+	return r0
+}
+
+// Database_GetFirstTribeByFeedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirstTribeByFeedURL'
+type Database_GetFirstTribeByFeedURL_Call struct {
+	*mock.Call
+}
+
+// GetFirstTribeByFeedURL is a helper method to define mock.On call
+//   - feedURL string
+func (_e *Database_Expecter) GetFirstTribeByFeedURL(feedURL interface{}) *Database_GetFirstTribeByFeedURL_Call {
+	return &Database_GetFirstTribeByFeedURL_Call{Call: _e.mock.On("GetFirstTribeByFeedURL", feedURL)}
+}
+
+func (_c *Database_GetFirstTribeByFeedURL_Call) Run(run func(feedURL string)) *Database_GetFirstTribeByFeedURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFirstTribeByFeedURL_Call) Return(_a0 db.Tribe) *Database_GetFirstTribeByFeedURL_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFirstTribeByFeedURL_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetFirstTribeByFeedURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIdempotencyKey provides a mock function with given fields: key, pubkey
+func (_m *Database) GetIdempotencyKey(key string, pubkey string) (db.IdempotencyKey, error) {
+	ret := _m.Called(key, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdempotencyKey")
+	}
+
+	var r0 db.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.IdempotencyKey, error)); ok {
+		return rf(key, pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.IdempotencyKey); ok {
+		r0 = rf(key, pubkey)
+	} else {
+		r0 = ret.Get(0).(db.IdempotencyKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(key, pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIdempotencyKey'
+type Database_GetIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// GetIdempotencyKey is a helper method to define mock.On call
+//   - key string
+//   - pubkey string
+func (_e *Database_Expecter) GetIdempotencyKey(key interface{}, pubkey interface{}) *Database_GetIdempotencyKey_Call {
+	return &Database_GetIdempotencyKey_Call{Call: _e.mock.On("GetIdempotencyKey", key, pubkey)}
+}
+
+func (_c *Database_GetIdempotencyKey_Call) Run(run func(key string, pubkey string)) *Database_GetIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetIdempotencyKey_Call) Return(_a0 db.IdempotencyKey, _a1 error) *Database_GetIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetIdempotencyKey_Call) RunAndReturn(run func(string, string) (db.IdempotencyKey, error)) *Database_GetIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImpersonatedRequests provides a mock function with given fields: impersonationUuid
+func (_m *Database) GetImpersonatedRequests(impersonationUuid string) []db.ImpersonatedRequest {
+	ret := _m.Called(impersonationUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImpersonatedRequests")
+	}
+
+	var r0 []db.ImpersonatedRequest
+	if rf, ok := ret.Get(0).(func(string) []db.ImpersonatedRequest); ok {
+		r0 = rf(impersonationUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ImpersonatedRequest)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetImpersonatedRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImpersonatedRequests'
+type Database_GetImpersonatedRequests_Call struct {
+	*mock.Call
+}
+
+// GetImpersonatedRequests is a helper method to define mock.On call
+//   - impersonationUuid string
+func (_e *Database_Expecter) GetImpersonatedRequests(impersonationUuid interface{}) *Database_GetImpersonatedRequests_Call {
+	return &Database_GetImpersonatedRequests_Call{Call: _e.mock.On("GetImpersonatedRequests", impersonationUuid)}
+}
+
+func (_c *Database_GetImpersonatedRequests_Call) Run(run func(impersonationUuid string)) *Database_GetImpersonatedRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetImpersonatedRequests_Call) Return(_a0 []db.ImpersonatedRequest) *Database_GetImpersonatedRequests_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetImpersonatedRequests_Call) RunAndReturn(run func(string) []db.ImpersonatedRequest) *Database_GetImpersonatedRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImpersonationByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetImpersonationByUuid(uuid string) db.Impersonation {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImpersonationByUuid")
+	}
+
+	var r0 db.Impersonation
+	if rf, ok := ret.Get(0).(func(string) db.Impersonation); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Impersonation)
+	}
+
+	return r0
+}
+
+// Database_GetImpersonationByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImpersonationByUuid'
+type Database_GetImpersonationByUuid_Call struct {
+	*mock.Call
+}
+
+// GetImpersonationByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetImpersonationByUuid(uuid interface{}) *Database_GetImpersonationByUuid_Call {
+	return &Database_GetImpersonationByUuid_Call{Call: _e.mock.On("GetImpersonationByUuid", uuid)}
+}
+
+func (_c *Database_GetImpersonationByUuid_Call) Run(run func(uuid string)) *Database_GetImpersonationByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetImpersonationByUuid_Call) Return(_a0 db.Impersonation) *Database_GetImpersonationByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetImpersonationByUuid_Call) RunAndReturn(run func(string) db.Impersonation) *Database_GetImpersonationByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImpersonations provides a mock function with given fields: targetPubkey, limit
+func (_m *Database) GetImpersonations(targetPubkey string, limit int) []db.Impersonation {
+	ret := _m.Called(targetPubkey, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImpersonations")
+	}
+
+	var r0 []db.Impersonation
+	if rf, ok := ret.Get(0).(func(string, int) []db.Impersonation); ok {
+		r0 = rf(targetPubkey, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Impersonation)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetImpersonations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImpersonations'
+type Database_GetImpersonations_Call struct {
+	*mock.Call
+}
+
+// GetImpersonations is a helper method to define mock.On call
+//   - targetPubkey string
+//   - limit int
+func (_e *Database_Expecter) GetImpersonations(targetPubkey interface{}, limit interface{}) *Database_GetImpersonations_Call {
+	return &Database_GetImpersonations_Call{Call: _e.mock.On("GetImpersonations", targetPubkey, limit)}
+}
+
+func (_c *Database_GetImpersonations_Call) Run(run func(targetPubkey string, limit int)) *Database_GetImpersonations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetImpersonations_Call) Return(_a0 []db.Impersonation) *Database_GetImpersonations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetImpersonations_Call) RunAndReturn(run func(string, int) []db.Impersonation) *Database_GetImpersonations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIncomingWorkspaceTransfers provides a mock function with given fields: pubkey
+func (_m *Database) GetIncomingWorkspaceTransfers(pubkey string) []db.WorkspaceTransfer {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIncomingWorkspaceTransfers")
+	}
+
+	var r0 []db.WorkspaceTransfer
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceTransfer); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceTransfer)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetIncomingWorkspaceTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIncomingWorkspaceTransfers'
+type Database_GetIncomingWorkspaceTransfers_Call struct {
+	*mock.Call
+}
+
+// GetIncomingWorkspaceTransfers is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetIncomingWorkspaceTransfers(pubkey interface{}) *Database_GetIncomingWorkspaceTransfers_Call {
+	return &Database_GetIncomingWorkspaceTransfers_Call{Call: _e.mock.On("GetIncomingWorkspaceTransfers", pubkey)}
+}
+
+func (_c *Database_GetIncomingWorkspaceTransfers_Call) Run(run func(pubkey string)) *Database_GetIncomingWorkspaceTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetIncomingWorkspaceTransfers_Call) Return(_a0 []db.WorkspaceTransfer) *Database_GetIncomingWorkspaceTransfers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetIncomingWorkspaceTransfers_Call) RunAndReturn(run func(string) []db.WorkspaceTransfer) *Database_GetIncomingWorkspaceTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInvoice provides a mock function with given fields: payment_request
+func (_m *Database) GetInvoice(payment_request string) db.NewInvoiceList {
+	ret := _m.Called(payment_request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvoice")
+	}
+
+	var r0 db.NewInvoiceList
+	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
+		r0 = rf(payment_request)
+	} else {
+		r0 = ret.Get(0).(db.NewInvoiceList)
+	}
+
+	return r0
+}
+
+// Database_GetInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvoice'
+type Database_GetInvoice_Call struct {
+	*mock.Call
+}
+
+// GetInvoice is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) GetInvoice(payment_request interface{}) *Database_GetInvoice_Call {
+	return &Database_GetInvoice_Call{Call: _e.mock.On("GetInvoice", payment_request)}
+}
+
+func (_c *Database_GetInvoice_Call) Run(run func(payment_request string)) *Database_GetInvoice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_GetInvoice_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_GetInvoice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLeaderBoard provides a mock function with given fields: uuid
+func (_m *Database) GetLeaderBoard(uuid string) []db.LeaderBoard {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLeaderBoard")
+	}
+
+	var r0 []db.LeaderBoard
+	if rf, ok := ret.Get(0).(func(string) []db.LeaderBoard); ok {
+		r0 = rf(uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LeaderBoard)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderBoard'
+type Database_GetLeaderBoard_Call struct {
+	*mock.Call
+}
+
+// GetLeaderBoard is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetLeaderBoard(uuid interface{}) *Database_GetLeaderBoard_Call {
+	return &Database_GetLeaderBoard_Call{Call: _e.mock.On("GetLeaderBoard", uuid)}
+}
+
+func (_c *Database_GetLeaderBoard_Call) Run(run func(uuid string)) *Database_GetLeaderBoard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetLeaderBoard_Call) Return(_a0 []db.LeaderBoard) *Database_GetLeaderBoard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLeaderBoard_Call) RunAndReturn(run func(string) []db.LeaderBoard) *Database_GetLeaderBoard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLeaderBoardByUuidAndAlias provides a mock function with given fields: uuid, alias
+func (_m *Database) GetLeaderBoardByUuidAndAlias(uuid string, alias string) db.LeaderBoard {
+	ret := _m.Called(uuid, alias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLeaderBoardByUuidAndAlias")
+	}
+
+	var r0 db.LeaderBoard
+	if rf, ok := ret.Get(0).(func(string, string) db.LeaderBoard); ok {
+		r0 = rf(uuid, alias)
+	} else {
+		r0 = ret.Get(0).(db.LeaderBoard)
+	}
+
+	return r0
+}
+
+// Database_GetLeaderBoardByUuidAndAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderBoardByUuidAndAlias'
+type Database_GetLeaderBoardByUuidAndAlias_Call struct {
+	*mock.Call
+}
+
+// GetLeaderBoardByUuidAndAlias is a helper method to define mock.On call
+//   - uuid string
+//   - alias string
+func (_e *Database_Expecter) GetLeaderBoardByUuidAndAlias(uuid interface{}, alias interface{}) *Database_GetLeaderBoardByUuidAndAlias_Call {
+	return &Database_GetLeaderBoardByUuidAndAlias_Call{Call: _e.mock.On("GetLeaderBoardByUuidAndAlias", uuid, alias)}
+}
+
+func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) Run(run func(uuid string, alias string)) *Database_GetLeaderBoardByUuidAndAlias_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) Return(_a0 db.LeaderBoard) *Database_GetLeaderBoardByUuidAndAlias_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) RunAndReturn(run func(string, string) db.LeaderBoard) *Database_GetLeaderBoardByUuidAndAlias_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLeaderboard provides a mock function with given fields: kind, window
+func (_m *Database) GetLeaderboard(kind db.LeaderboardKind, window db.LeaderboardWindow) []db.LeaderboardEntry {
+	ret := _m.Called(kind, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLeaderboard")
+	}
+
+	var r0 []db.LeaderboardEntry
+	if rf, ok := ret.Get(0).(func(db.LeaderboardKind, db.LeaderboardWindow) []db.LeaderboardEntry); ok {
+		r0 = rf(kind, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LeaderboardEntry)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetLeaderboard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderboard'
+type Database_GetLeaderboard_Call struct {
+	*mock.Call
+}
+
+// GetLeaderboard is a helper method to define mock.On call
+//   - kind db.LeaderboardKind
+//   - window db.LeaderboardWindow
+func (_e *Database_Expecter) GetLeaderboard(kind interface{}, window interface{}) *Database_GetLeaderboard_Call {
+	return &Database_GetLeaderboard_Call{Call: _e.mock.On("GetLeaderboard", kind, window)}
+}
+
+func (_c *Database_GetLeaderboard_Call) Run(run func(kind db.LeaderboardKind, window db.LeaderboardWindow)) *Database_GetLeaderboard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.LeaderboardKind), args[1].(db.LeaderboardWindow))
+	})
+	return _c
+}
+
+func (_c *Database_GetLeaderboard_Call) Return(_a0 []db.LeaderboardEntry) *Database_GetLeaderboard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLeaderboard_Call) RunAndReturn(run func(db.LeaderboardKind, db.LeaderboardWindow) []db.LeaderboardEntry) *Database_GetLeaderboard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLedgerBalance provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetLedgerBalance(workspace_uuid string) int64 {
+	ret := _m.Called(workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLedgerBalance")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(workspace_uuid)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetLedgerBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLedgerBalance'
+type Database_GetLedgerBalance_Call struct {
+	*mock.Call
+}
+
+// GetLedgerBalance is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetLedgerBalance(workspace_uuid interface{}) *Database_GetLedgerBalance_Call {
+	return &Database_GetLedgerBalance_Call{Call: _e.mock.On("GetLedgerBalance", workspace_uuid)}
+}
+
+func (_c *Database_GetLedgerBalance_Call) Run(run func(workspace_uuid string)) *Database_GetLedgerBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetLedgerBalance_Call) Return(_a0 int64) *Database_GetLedgerBalance_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLedgerBalance_Call) RunAndReturn(run func(string) int64) *Database_GetLedgerBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedBots provides a mock function with given fields: r
+func (_m *Database) GetListedBots(r *http.Request) []db.Bot {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedBots")
+	}
+
+	var r0 []db.Bot
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Bot); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Bot)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetListedBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedBots'
+type Database_GetListedBots_Call struct {
+	*mock.Call
+}
+
+// GetListedBots is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedBots(r interface{}) *Database_GetListedBots_Call {
+	return &Database_GetListedBots_Call{Call: _e.mock.On("GetListedBots", r)}
+}
+
+func (_c *Database_GetListedBots_Call) Run(run func(r *http.Request)) *Database_GetListedBots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedBots_Call) Return(_a0 []db.Bot) *Database_GetListedBots_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetListedBots_Call) RunAndReturn(run func(*http.Request) []db.Bot) *Database_GetListedBots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedOffers provides a mock function with given fields: r
+func (_m *Database) GetListedOffers(r *http.Request) ([]db.PeopleExtra, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedOffers")
+	}
+
+	var r0 []db.PeopleExtra
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.PeopleExtra, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.PeopleExtra); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PeopleExtra)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetListedOffers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedOffers'
+type Database_GetListedOffers_Call struct {
+	*mock.Call
+}
+
+// GetListedOffers is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedOffers(r interface{}) *Database_GetListedOffers_Call {
+	return &Database_GetListedOffers_Call{Call: _e.mock.On("GetListedOffers", r)}
+}
+
+func (_c *Database_GetListedOffers_Call) Run(run func(r *http.Request)) *Database_GetListedOffers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedOffers_Call) Return(_a0 []db.PeopleExtra, _a1 error) *Database_GetListedOffers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetListedOffers_Call) RunAndReturn(run func(*http.Request) ([]db.PeopleExtra, error)) *Database_GetListedOffers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedPeople provides a mock function with given fields: r
+func (_m *Database) GetListedPeople(r *http.Request) []db.Person {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedPeople")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Person); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetListedPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedPeople'
+type Database_GetListedPeople_Call struct {
+	*mock.Call
+}
+
+// GetListedPeople is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedPeople(r interface{}) *Database_GetListedPeople_Call {
+	return &Database_GetListedPeople_Call{Call: _e.mock.On("GetListedPeople", r)}
+}
+
+func (_c *Database_GetListedPeople_Call) Run(run func(r *http.Request)) *Database_GetListedPeople_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedPeople_Call) Return(_a0 []db.Person) *Database_GetListedPeople_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetListedPeople_Call) RunAndReturn(run func(*http.Request) []db.Person) *Database_GetListedPeople_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedPosts provides a mock function with given fields: r
+func (_m *Database) GetListedPosts(r *http.Request) ([]db.PeopleExtra, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedPosts")
+	}
+
+	var r0 []db.PeopleExtra
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.PeopleExtra, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.PeopleExtra); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PeopleExtra)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetListedPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedPosts'
+type Database_GetListedPosts_Call struct {
+	*mock.Call
+}
+
+// GetListedPosts is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedPosts(r interface{}) *Database_GetListedPosts_Call {
+	return &Database_GetListedPosts_Call{Call: _e.mock.On("GetListedPosts", r)}
+}
+
+func (_c *Database_GetListedPosts_Call) Run(run func(r *http.Request)) *Database_GetListedPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedPosts_Call) Return(_a0 []db.PeopleExtra, _a1 error) *Database_GetListedPosts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetListedPosts_Call) RunAndReturn(run func(*http.Request) ([]db.PeopleExtra, error)) *Database_GetListedPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedTribes provides a mock function with given fields: r
+func (_m *Database) GetListedTribes(r *http.Request) []db.Tribe {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedTribes")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Tribe); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetListedTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedTribes'
+type Database_GetListedTribes_Call struct {
+	*mock.Call
+}
+
+// GetListedTribes is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedTribes(r interface{}) *Database_GetListedTribes_Call {
+	return &Database_GetListedTribes_Call{Call: _e.mock.On("GetListedTribes", r)}
+}
+
+func (_c *Database_GetListedTribes_Call) Run(run func(r *http.Request)) *Database_GetListedTribes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedTribes_Call) Return(_a0 []db.Tribe) *Database_GetListedTribes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetListedTribes_Call) RunAndReturn(run func(*http.Request) []db.Tribe) *Database_GetListedTribes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedWorkspaceBounties provides a mock function with given fields: workspaceUuid, limit, offset
+func (_m *Database) GetListedWorkspaceBounties(workspaceUuid string, limit int, offset int) []db.NewBounty {
+	ret := _m.Called(workspaceUuid, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedWorkspaceBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(string, int, int) []db.NewBounty); ok {
+		r0 = rf(workspaceUuid, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetListedWorkspaceBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedWorkspaceBounties'
+type Database_GetListedWorkspaceBounties_Call struct {
+	*mock.Call
+}
+
+// GetListedWorkspaceBounties is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - limit int
+//   - offset int
+func (_e *Database_Expecter) GetListedWorkspaceBounties(workspaceUuid interface{}, limit interface{}, offset interface{}) *Database_GetListedWorkspaceBounties_Call {
+	return &Database_GetListedWorkspaceBounties_Call{Call: _e.mock.On("GetListedWorkspaceBounties", workspaceUuid, limit, offset)}
+}
+
+func (_c *Database_GetListedWorkspaceBounties_Call) Run(run func(workspaceUuid string, limit int, offset int)) *Database_GetListedWorkspaceBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedWorkspaceBounties_Call) Return(_a0 []db.NewBounty) *Database_GetListedWorkspaceBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetListedWorkspaceBounties_Call) RunAndReturn(run func(string, int, int) []db.NewBounty) *Database_GetListedWorkspaceBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLnUser provides a mock function with given fields: lnKey
+func (_m *Database) GetLnUser(lnKey string) int64 {
+	ret := _m.Called(lnKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLnUser")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(lnKey)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetLnUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLnUser'
+type Database_GetLnUser_Call struct {
+	*mock.Call
+}
+
+// GetLnUser is a helper method to define mock.On call
+//   - lnKey string
+func (_e *Database_Expecter) GetLnUser(lnKey interface{}) *Database_GetLnUser_Call {
+	return &Database_GetLnUser_Call{Call: _e.mock.On("GetLnUser", lnKey)}
+}
+
+func (_c *Database_GetLnUser_Call) Run(run func(lnKey string)) *Database_GetLnUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetLnUser_Call) Return(_a0 int64) *Database_GetLnUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLnUser_Call) RunAndReturn(run func(string) int64) *Database_GetLnUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLowBalanceAlertSettings provides a mock function with given fields:
+func (_m *Database) GetLowBalanceAlertSettings() []db.WorkspaceBudgetSettings {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLowBalanceAlertSettings")
+	}
+
+	var r0 []db.WorkspaceBudgetSettings
+	if rf, ok := ret.Get(0).(func() []db.WorkspaceBudgetSettings); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceBudgetSettings)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetLowBalanceAlertSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLowBalanceAlertSettings'
+type Database_GetLowBalanceAlertSettings_Call struct {
+	*mock.Call
+}
+
+// GetLowBalanceAlertSettings is a helper method to define mock.On call
+func (_e *Database_Expecter) GetLowBalanceAlertSettings() *Database_GetLowBalanceAlertSettings_Call {
+	return &Database_GetLowBalanceAlertSettings_Call{Call: _e.mock.On("GetLowBalanceAlertSettings")}
+}
+
+func (_c *Database_GetLowBalanceAlertSettings_Call) Run(run func()) *Database_GetLowBalanceAlertSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetLowBalanceAlertSettings_Call) Return(_a0 []db.WorkspaceBudgetSettings) *Database_GetLowBalanceAlertSettings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLowBalanceAlertSettings_Call) RunAndReturn(run func() []db.WorkspaceBudgetSettings) *Database_GetLowBalanceAlertSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMediaJobByVariant provides a mock function with given fields: key
+func (_m *Database) GetMediaJobByVariant(key string) db.MediaJob {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMediaJobByVariant")
+	}
+
+	var r0 db.MediaJob
+	if rf, ok := ret.Get(0).(func(string) db.MediaJob); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Get(0).(db.MediaJob)
+	}
+
+	return r0
+}
+
+// Database_GetMediaJobByVariant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMediaJobByVariant'
+type Database_GetMediaJobByVariant_Call struct {
+	*mock.Call
+}
+
+// GetMediaJobByVariant is a helper method to define mock.On call
+//   - key string
+func (_e *Database_Expecter) GetMediaJobByVariant(key interface{}) *Database_GetMediaJobByVariant_Call {
+	return &Database_GetMediaJobByVariant_Call{Call: _e.mock.On("GetMediaJobByVariant", key)}
+}
+
+func (_c *Database_GetMediaJobByVariant_Call) Run(run func(key string)) *Database_GetMediaJobByVariant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetMediaJobByVariant_Call) Return(_a0 db.MediaJob) *Database_GetMediaJobByVariant_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetMediaJobByVariant_Call) RunAndReturn(run func(string) db.MediaJob) *Database_GetMediaJobByVariant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMetricsRollupTime provides a mock function with given fields:
+func (_m *Database) GetMetricsRollupTime() *time.Time {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMetricsRollupTime")
+	}
+
+	var r0 *time.Time
+	if rf, ok := ret.Get(0).(func() *time.Time); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*time.Time)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetMetricsRollupTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMetricsRollupTime'
+type Database_GetMetricsRollupTime_Call struct {
+	*mock.Call
+}
+
+// GetMetricsRollupTime is a helper method to define mock.On call
+func (_e *Database_Expecter) GetMetricsRollupTime() *Database_GetMetricsRollupTime_Call {
+	return &Database_GetMetricsRollupTime_Call{Call: _e.mock.On("GetMetricsRollupTime")}
+}
+
+func (_c *Database_GetMetricsRollupTime_Call) Run(run func()) *Database_GetMetricsRollupTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetMetricsRollupTime_Call) Return(_a0 *time.Time) *Database_GetMetricsRollupTime_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetMetricsRollupTime_Call) RunAndReturn(run func() *time.Time) *Database_GetMetricsRollupTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMetricsSeriesValues provides a mock function with given fields: from, to, interval, workspace
+func (_m *Database) GetMetricsSeriesValues(from time.Time, to time.Time, interval db.MetricsInterval, workspace string) []db.MetricsSeriesValue {
+	ret := _m.Called(from, to, interval, workspace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMetricsSeriesValues")
+	}
+
+	var r0 []db.MetricsSeriesValue
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, db.MetricsInterval, string) []db.MetricsSeriesValue); ok {
+		r0 = rf(from, to, interval, workspace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.MetricsSeriesValue)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetMetricsSeriesValues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMetricsSeriesValues'
+type Database_GetMetricsSeriesValues_Call struct {
+	*mock.Call
+}
+
+// GetMetricsSeriesValues is a helper method to define mock.On call
+//   - from time.Time
+//   - to time.Time
+//   - interval db.MetricsInterval
+//   - workspace string
+func (_e *Database_Expecter) GetMetricsSeriesValues(from interface{}, to interface{}, interval interface{}, workspace interface{}) *Database_GetMetricsSeriesValues_Call {
+	return &Database_GetMetricsSeriesValues_Call{Call: _e.mock.On("GetMetricsSeriesValues", from, to, interval, workspace)}
+}
+
+func (_c *Database_GetMetricsSeriesValues_Call) Run(run func(from time.Time, to time.Time, interval db.MetricsInterval, workspace string)) *Database_GetMetricsSeriesValues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(time.Time), args[2].(db.MetricsInterval), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetMetricsSeriesValues_Call) Return(_a0 []db.MetricsSeriesValue) *Database_GetMetricsSeriesValues_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetMetricsSeriesValues_Call) RunAndReturn(run func(time.Time, time.Time, db.MetricsInterval, string) []db.MetricsSeriesValue) *Database_GetMetricsSeriesValues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNextBountyByCreated provides a mock function with given fields: r
+func (_m *Database) GetNextBountyByCreated(r *http.Request) (uint, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNextBountyByCreated")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetNextBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextBountyByCreated'
+type Database_GetNextBountyByCreated_Call struct {
+	*mock.Call
+}
+
+// GetNextBountyByCreated is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetNextBountyByCreated(r interface{}) *Database_GetNextBountyByCreated_Call {
+	return &Database_GetNextBountyByCreated_Call{Call: _e.mock.On("GetNextBountyByCreated", r)}
+}
+
+func (_c *Database_GetNextBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetNextBountyByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetNextBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetNextBountyByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetNextBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetNextBountyByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNextWorkspaceBountyByCreated provides a mock function with given fields: r
+func (_m *Database) GetNextWorkspaceBountyByCreated(r *http.Request) (uint, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNextWorkspaceBountyByCreated")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetNextWorkspaceBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextWorkspaceBountyByCreated'
+type Database_GetNextWorkspaceBountyByCreated_Call struct {
+	*mock.Call
+}
+
+// GetNextWorkspaceBountyByCreated is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetNextWorkspaceBountyByCreated(r interface{}) *Database_GetNextWorkspaceBountyByCreated_Call {
+	return &Database_GetNextWorkspaceBountyByCreated_Call{Call: _e.mock.On("GetNextWorkspaceBountyByCreated", r)}
+}
+
+func (_c *Database_GetNextWorkspaceBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetNextWorkspaceBountyByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetNextWorkspaceBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetNextWorkspaceBountyByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetNextWorkspaceBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetNextWorkspaceBountyByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNostrIdentitiesByPubkey provides a mock function with given fields: pubkey
+func (_m *Database) GetNostrIdentitiesByPubkey(pubkey string) []db.NostrIdentity {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNostrIdentitiesByPubkey")
+	}
+
+	var r0 []db.NostrIdentity
+	if rf, ok := ret.Get(0).(func(string) []db.NostrIdentity); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NostrIdentity)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetNostrIdentitiesByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNostrIdentitiesByPubkey'
+type Database_GetNostrIdentitiesByPubkey_Call struct {
+	*mock.Call
+}
+
+// GetNostrIdentitiesByPubkey is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetNostrIdentitiesByPubkey(pubkey interface{}) *Database_GetNostrIdentitiesByPubkey_Call {
+	return &Database_GetNostrIdentitiesByPubkey_Call{Call: _e.mock.On("GetNostrIdentitiesByPubkey", pubkey)}
+}
+
+func (_c *Database_GetNostrIdentitiesByPubkey_Call) Run(run func(pubkey string)) *Database_GetNostrIdentitiesByPubkey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetNostrIdentitiesByPubkey_Call) Return(_a0 []db.NostrIdentity) *Database_GetNostrIdentitiesByPubkey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetNostrIdentitiesByPubkey_Call) RunAndReturn(run func(string) []db.NostrIdentity) *Database_GetNostrIdentitiesByPubkey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNostrIdentity provides a mock function with given fields: nostrPubkey
+func (_m *Database) GetNostrIdentity(nostrPubkey string) (db.NostrIdentity, error) {
+	ret := _m.Called(nostrPubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNostrIdentity")
+	}
+
+	var r0 db.NostrIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.NostrIdentity, error)); ok {
+		return rf(nostrPubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.NostrIdentity); ok {
+		r0 = rf(nostrPubkey)
+	} else {
+		r0 = ret.Get(0).(db.NostrIdentity)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(nostrPubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetNostrIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNostrIdentity'
+type Database_GetNostrIdentity_Call struct {
+	*mock.Call
+}
+
+// GetNostrIdentity is a helper method to define mock.On call
+//   - nostrPubkey string
+func (_e *Database_Expecter) GetNostrIdentity(nostrPubkey interface{}) *Database_GetNostrIdentity_Call {
+	return &Database_GetNostrIdentity_Call{Call: _e.mock.On("GetNostrIdentity", nostrPubkey)}
+}
+
+func (_c *Database_GetNostrIdentity_Call) Run(run func(nostrPubkey string)) *Database_GetNostrIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetNostrIdentity_Call) Return(_a0 db.NostrIdentity, _a1 error) *Database_GetNostrIdentity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetNostrIdentity_Call) RunAndReturn(run func(string) (db.NostrIdentity, error)) *Database_GetNostrIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotificationSettings provides a mock function with given fields: pubkey
+func (_m *Database) GetNotificationSettings(pubkey string) db.NotificationSettings {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationSettings")
+	}
+
+	var r0 db.NotificationSettings
+	if rf, ok := ret.Get(0).(func(string) db.NotificationSettings); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Get(0).(db.NotificationSettings)
+	}
+
+	return r0
+}
+
+// Database_GetNotificationSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotificationSettings'
+type Database_GetNotificationSettings_Call struct {
+	*mock.Call
+}
+
+// GetNotificationSettings is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetNotificationSettings(pubkey interface{}) *Database_GetNotificationSettings_Call {
+	return &Database_GetNotificationSettings_Call{Call: _e.mock.On("GetNotificationSettings", pubkey)}
+}
+
+func (_c *Database_GetNotificationSettings_Call) Run(run func(pubkey string)) *Database_GetNotificationSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetNotificationSettings_Call) Return(_a0 db.NotificationSettings) *Database_GetNotificationSettings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetNotificationSettings_Call) RunAndReturn(run func(string) db.NotificationSettings) *Database_GetNotificationSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotificationsByPubkey provides a mock function with given fields: pubkey, unreadOnly, r
+func (_m *Database) GetNotificationsByPubkey(pubkey string, unreadOnly bool, r *http.Request) []db.Notification {
+	ret := _m.Called(pubkey, unreadOnly, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationsByPubkey")
+	}
+
+	var r0 []db.Notification
+	if rf, ok := ret.Get(0).(func(string, bool, *http.Request) []db.Notification); ok {
+		r0 = rf(pubkey, unreadOnly, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Notification)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetNotificationsByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotificationsByPubkey'
+type Database_GetNotificationsByPubkey_Call struct {
+	*mock.Call
+}
+
+// GetNotificationsByPubkey is a helper method to define mock.On call
+//   - pubkey string
+//   - unreadOnly bool
+//   - r *http.Request
+func (_e *Database_Expecter) GetNotificationsByPubkey(pubkey interface{}, unreadOnly interface{}, r interface{}) *Database_GetNotificationsByPubkey_Call {
+	return &Database_GetNotificationsByPubkey_Call{Call: _e.mock.On("GetNotificationsByPubkey", pubkey, unreadOnly, r)}
+}
+
+func (_c *Database_GetNotificationsByPubkey_Call) Run(run func(pubkey string, unreadOnly bool, r *http.Request)) *Database_GetNotificationsByPubkey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(bool), args[2].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetNotificationsByPubkey_Call) Return(_a0 []db.Notification) *Database_GetNotificationsByPubkey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetNotificationsByPubkey_Call) RunAndReturn(run func(string, bool, *http.Request) []db.Notification) *Database_GetNotificationsByPubkey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotificationsCount provides a mock function with given fields: pubkey, unreadOnly
+func (_m *Database) GetNotificationsCount(pubkey string, unreadOnly bool) int64 {
+	ret := _m.Called(pubkey, unreadOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationsCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, bool) int64); ok {
+		r0 = rf(pubkey, unreadOnly)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetNotificationsCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotificationsCount'
+type Database_GetNotificationsCount_Call struct {
+	*mock.Call
+}
+
+// GetNotificationsCount is a helper method to define mock.On call
+//   - pubkey string
+//   - unreadOnly bool
+func (_e *Database_Expecter) GetNotificationsCount(pubkey interface{}, unreadOnly interface{}) *Database_GetNotificationsCount_Call {
+	return &Database_GetNotificationsCount_Call{Call: _e.mock.On("GetNotificationsCount", pubkey, unreadOnly)}
+}
+
+func (_c *Database_GetNotificationsCount_Call) Run(run func(pubkey string, unreadOnly bool)) *Database_GetNotificationsCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *Database_GetNotificationsCount_Call) Return(_a0 int64) *Database_GetNotificationsCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetNotificationsCount_Call) RunAndReturn(run func(string, bool) int64) *Database_GetNotificationsCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenBountiesWithExpiry provides a mock function with given fields:
+func (_m *Database) GetOpenBountiesWithExpiry() []db.NewBounty {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenBountiesWithExpiry")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func() []db.NewBounty); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetOpenBountiesWithExpiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenBountiesWithExpiry'
+type Database_GetOpenBountiesWithExpiry_Call struct {
+	*mock.Call
+}
+
+// GetOpenBountiesWithExpiry is a helper method to define mock.On call
+func (_e *Database_Expecter) GetOpenBountiesWithExpiry() *Database_GetOpenBountiesWithExpiry_Call {
+	return &Database_GetOpenBountiesWithExpiry_Call{Call: _e.mock.On("GetOpenBountiesWithExpiry")}
+}
+
+func (_c *Database_GetOpenBountiesWithExpiry_Call) Run(run func()) *Database_GetOpenBountiesWithExpiry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetOpenBountiesWithExpiry_Call) Return(_a0 []db.NewBounty) *Database_GetOpenBountiesWithExpiry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetOpenBountiesWithExpiry_Call) RunAndReturn(run func() []db.NewBounty) *Database_GetOpenBountiesWithExpiry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenContentReport provides a mock function with given fields: targetType, targetId, reportedBy
+func (_m *Database) GetOpenContentReport(targetType db.ReportTargetType, targetId string, reportedBy string) db.ContentReport {
+	ret := _m.Called(targetType, targetId, reportedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenContentReport")
+	}
+
+	var r0 db.ContentReport
+	if rf, ok := ret.Get(0).(func(db.ReportTargetType, string, string) db.ContentReport); ok {
+		r0 = rf(targetType, targetId, reportedBy)
+	} else {
+		r0 = ret.Get(0).(db.ContentReport)
+	}
+
+	return r0
+}
+
+// Database_GetOpenContentReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenContentReport'
+type Database_GetOpenContentReport_Call struct {
+	*mock.Call
+}
+
+// GetOpenContentReport is a helper method to define mock.On call
+//   - targetType db.ReportTargetType
+//   - targetId string
+//   - reportedBy string
+func (_e *Database_Expecter) GetOpenContentReport(targetType interface{}, targetId interface{}, reportedBy interface{}) *Database_GetOpenContentReport_Call {
+	return &Database_GetOpenContentReport_Call{Call: _e.mock.On("GetOpenContentReport", targetType, targetId, reportedBy)}
+}
+
+func (_c *Database_GetOpenContentReport_Call) Run(run func(targetType db.ReportTargetType, targetId string, reportedBy string)) *Database_GetOpenContentReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.ReportTargetType), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetOpenContentReport_Call) Return(_a0 db.ContentReport) *Database_GetOpenContentReport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetOpenContentReport_Call) RunAndReturn(run func(db.ReportTargetType, string, string) db.ContentReport) *Database_GetOpenContentReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenDispute provides a mock function with given fields: bountyId
+func (_m *Database) GetOpenDispute(bountyId uint) db.BountyDispute {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenDispute")
+	}
+
+	var r0 db.BountyDispute
+	if rf, ok := ret.Get(0).(func(uint) db.BountyDispute); ok {
+		r0 = rf(bountyId)
+	} else {
+		r0 = ret.Get(0).(db.BountyDispute)
+	}
+
+	return r0
+}
+
+// Database_GetOpenDispute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenDispute'
+type Database_GetOpenDispute_Call struct {
+	*mock.Call
+}
+
+// GetOpenDispute is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetOpenDispute(bountyId interface{}) *Database_GetOpenDispute_Call {
+	return &Database_GetOpenDispute_Call{Call: _e.mock.On("GetOpenDispute", bountyId)}
+}
+
+func (_c *Database_GetOpenDispute_Call) Run(run func(bountyId uint)) *Database_GetOpenDispute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetOpenDispute_Call) Return(_a0 db.BountyDispute) *Database_GetOpenDispute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetOpenDispute_Call) RunAndReturn(run func(uint) db.BountyDispute) *Database_GetOpenDispute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenGithubIssues provides a mock function with given fields: r
+func (_m *Database) GetOpenGithubIssues(r *http.Request) (int64, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenGithubIssues")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (int64, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetOpenGithubIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenGithubIssues'
+type Database_GetOpenGithubIssues_Call struct {
+	*mock.Call
+}
+
+// GetOpenGithubIssues is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetOpenGithubIssues(r interface{}) *Database_GetOpenGithubIssues_Call {
+	return &Database_GetOpenGithubIssues_Call{Call: _e.mock.On("GetOpenGithubIssues", r)}
+}
+
+func (_c *Database_GetOpenGithubIssues_Call) Run(run func(r *http.Request)) *Database_GetOpenGithubIssues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetOpenGithubIssues_Call) Return(_a0 int64, _a1 error) *Database_GetOpenGithubIssues_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetOpenGithubIssues_Call) RunAndReturn(run func(*http.Request) (int64, error)) *Database_GetOpenGithubIssues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPaymentAttempts provides a mock function with given fields: bountyId
+func (_m *Database) GetPaymentAttempts(bountyId uint) []db.PaymentAttempt {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentAttempts")
+	}
+
+	var r0 []db.PaymentAttempt
+	if rf, ok := ret.Get(0).(func(uint) []db.PaymentAttempt); ok {
+		r0 = rf(bountyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PaymentAttempt)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPaymentAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentAttempts'
+type Database_GetPaymentAttempts_Call struct {
+	*mock.Call
+}
+
+// GetPaymentAttempts is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetPaymentAttempts(bountyId interface{}) *Database_GetPaymentAttempts_Call {
+	return &Database_GetPaymentAttempts_Call{Call: _e.mock.On("GetPaymentAttempts", bountyId)}
+}
+
+func (_c *Database_GetPaymentAttempts_Call) Run(run func(bountyId uint)) *Database_GetPaymentAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetPaymentAttempts_Call) Return(_a0 []db.PaymentAttempt) *Database_GetPaymentAttempts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPaymentAttempts_Call) RunAndReturn(run func(uint) []db.PaymentAttempt) *Database_GetPaymentAttempts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPaymentHistory provides a mock function with given fields: workspace_uuid, r
+func (_m *Database) GetPaymentHistory(workspace_uuid string, r *http.Request) []db.NewPaymentHistory {
+	ret := _m.Called(workspace_uuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentHistory")
+	}
+
+	var r0 []db.NewPaymentHistory
+	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.NewPaymentHistory); ok {
+		r0 = rf(workspace_uuid, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewPaymentHistory)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPaymentHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentHistory'
+type Database_GetPaymentHistory_Call struct {
+	*mock.Call
+}
+
+// GetPaymentHistory is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetPaymentHistory(workspace_uuid interface{}, r interface{}) *Database_GetPaymentHistory_Call {
+	return &Database_GetPaymentHistory_Call{Call: _e.mock.On("GetPaymentHistory", workspace_uuid, r)}
+}
+
+func (_c *Database_GetPaymentHistory_Call) Run(run func(workspace_uuid string, r *http.Request)) *Database_GetPaymentHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetPaymentHistory_Call) Return(_a0 []db.NewPaymentHistory) *Database_GetPaymentHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPaymentHistory_Call) RunAndReturn(run func(string, *http.Request) []db.NewPaymentHistory) *Database_GetPaymentHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPaymentHistoryByCreated provides a mock function with given fields: created, workspace_uuid
+func (_m *Database) GetPaymentHistoryByCreated(created *time.Time, workspace_uuid string) db.NewPaymentHistory {
+	ret := _m.Called(created, workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentHistoryByCreated")
+	}
+
+	var r0 db.NewPaymentHistory
+	if rf, ok := ret.Get(0).(func(*time.Time, string) db.NewPaymentHistory); ok {
+		r0 = rf(created, workspace_uuid)
+	} else {
+		r0 = ret.Get(0).(db.NewPaymentHistory)
+	}
+
+	return r0
+}
+
+// Database_GetPaymentHistoryByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentHistoryByCreated'
+type Database_GetPaymentHistoryByCreated_Call struct {
+	*mock.Call
+}
+
+// GetPaymentHistoryByCreated is a helper method to define mock.On call
+//   - created *time.Time
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetPaymentHistoryByCreated(created interface{}, workspace_uuid interface{}) *Database_GetPaymentHistoryByCreated_Call {
+	return &Database_GetPaymentHistoryByCreated_Call{Call: _e.mock.On("GetPaymentHistoryByCreated", created, workspace_uuid)}
+}
+
+func (_c *Database_GetPaymentHistoryByCreated_Call) Run(run func(created *time.Time, workspace_uuid string)) *Database_GetPaymentHistoryByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*time.Time), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPaymentHistoryByCreated_Call) Return(_a0 db.NewPaymentHistory) *Database_GetPaymentHistoryByCreated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPaymentHistoryByCreated_Call) RunAndReturn(run func(*time.Time, string) db.NewPaymentHistory) *Database_GetPaymentHistoryByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingMediaJobs provides a mock function with given fields: limit
+func (_m *Database) GetPendingMediaJobs(limit int) []db.MediaJob {
+	ret := _m.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingMediaJobs")
+	}
+
+	var r0 []db.MediaJob
+	if rf, ok := ret.Get(0).(func(int) []db.MediaJob); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.MediaJob)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPendingMediaJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingMediaJobs'
+type Database_GetPendingMediaJobs_Call struct {
+	*mock.Call
+}
+
+// GetPendingMediaJobs is a helper method to define mock.On call
+//   - limit int
+func (_e *Database_Expecter) GetPendingMediaJobs(limit interface{}) *Database_GetPendingMediaJobs_Call {
+	return &Database_GetPendingMediaJobs_Call{Call: _e.mock.On("GetPendingMediaJobs", limit)}
+}
+
+func (_c *Database_GetPendingMediaJobs_Call) Run(run func(limit int)) *Database_GetPendingMediaJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetPendingMediaJobs_Call) Return(_a0 []db.MediaJob) *Database_GetPendingMediaJobs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPendingMediaJobs_Call) RunAndReturn(run func(int) []db.MediaJob) *Database_GetPendingMediaJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingReviewBots provides a mock function with given fields:
+func (_m *Database) GetPendingReviewBots() []db.Bot {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingReviewBots")
+	}
+
+	var r0 []db.Bot
+	if rf, ok := ret.Get(0).(func() []db.Bot); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Bot)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPendingReviewBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingReviewBots'
+type Database_GetPendingReviewBots_Call struct {
+	*mock.Call
+}
+
+// GetPendingReviewBots is a helper method to define mock.On call
+func (_e *Database_Expecter) GetPendingReviewBots() *Database_GetPendingReviewBots_Call {
+	return &Database_GetPendingReviewBots_Call{Call: _e.mock.On("GetPendingReviewBots")}
+}
+
+func (_c *Database_GetPendingReviewBots_Call) Run(run func()) *Database_GetPendingReviewBots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetPendingReviewBots_Call) Return(_a0 []db.Bot) *Database_GetPendingReviewBots_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPendingReviewBots_Call) RunAndReturn(run func() []db.Bot) *Database_GetPendingReviewBots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingReviewTribes provides a mock function with given fields:
+func (_m *Database) GetPendingReviewTribes() []db.Tribe {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingReviewTribes")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func() []db.Tribe); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPendingReviewTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingReviewTribes'
+type Database_GetPendingReviewTribes_Call struct {
+	*mock.Call
+}
+
+// GetPendingReviewTribes is a helper method to define mock.On call
+func (_e *Database_Expecter) GetPendingReviewTribes() *Database_GetPendingReviewTribes_Call {
+	return &Database_GetPendingReviewTribes_Call{Call: _e.mock.On("GetPendingReviewTribes")}
+}
+
+func (_c *Database_GetPendingReviewTribes_Call) Run(run func()) *Database_GetPendingReviewTribes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetPendingReviewTribes_Call) Return(_a0 []db.Tribe) *Database_GetPendingReviewTribes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPendingReviewTribes_Call) RunAndReturn(run func() []db.Tribe) *Database_GetPendingReviewTribes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingWebhookDeliveries provides a mock function with given fields: limit
+func (_m *Database) GetPendingWebhookDeliveries(limit int) []db.WebhookDelivery {
+	ret := _m.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingWebhookDeliveries")
+	}
+
+	var r0 []db.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(int) []db.WebhookDelivery); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WebhookDelivery)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPendingWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingWebhookDeliveries'
+type Database_GetPendingWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetPendingWebhookDeliveries is a helper method to define mock.On call
+//   - limit int
+func (_e *Database_Expecter) GetPendingWebhookDeliveries(limit interface{}) *Database_GetPendingWebhookDeliveries_Call {
+	return &Database_GetPendingWebhookDeliveries_Call{Call: _e.mock.On("GetPendingWebhookDeliveries", limit)}
+}
+
+func (_c *Database_GetPendingWebhookDeliveries_Call) Run(run func(limit int)) *Database_GetPendingWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetPendingWebhookDeliveries_Call) Return(_a0 []db.WebhookDelivery) *Database_GetPendingWebhookDeliveries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPendingWebhookDeliveries_Call) RunAndReturn(run func(int) []db.WebhookDelivery) *Database_GetPendingWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingWorkflowExecutions provides a mock function with given fields: limit
+func (_m *Database) GetPendingWorkflowExecutions(limit int) []db.WorkflowExecution {
+	ret := _m.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingWorkflowExecutions")
+	}
+
+	var r0 []db.WorkflowExecution
+	if rf, ok := ret.Get(0).(func(int) []db.WorkflowExecution); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkflowExecution)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPendingWorkflowExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingWorkflowExecutions'
+type Database_GetPendingWorkflowExecutions_Call struct {
+	*mock.Call
+}
+
+// GetPendingWorkflowExecutions is a helper method to define mock.On call
+//   - limit int
+func (_e *Database_Expecter) GetPendingWorkflowExecutions(limit interface{}) *Database_GetPendingWorkflowExecutions_Call {
+	return &Database_GetPendingWorkflowExecutions_Call{Call: _e.mock.On("GetPendingWorkflowExecutions", limit)}
+}
+
+func (_c *Database_GetPendingWorkflowExecutions_Call) Run(run func(limit int)) *Database_GetPendingWorkflowExecutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetPendingWorkflowExecutions_Call) Return(_a0 []db.WorkflowExecution) *Database_GetPendingWorkflowExecutions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPendingWorkflowExecutions_Call) RunAndReturn(run func(int) []db.WorkflowExecution) *Database_GetPendingWorkflowExecutions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPeopleByPubkeys provides a mock function with given fields: pubkeys
+func (_m *Database) GetPeopleByPubkeys(pubkeys []string) []db.Person {
+	ret := _m.Called(pubkeys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPeopleByPubkeys")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func([]string) []db.Person); ok {
+		r0 = rf(pubkeys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPeopleByPubkeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleByPubkeys'
+type Database_GetPeopleByPubkeys_Call struct {
+	*mock.Call
+}
+
+// GetPeopleByPubkeys is a helper method to define mock.On call
+//   - pubkeys []string
+func (_e *Database_Expecter) GetPeopleByPubkeys(pubkeys interface{}) *Database_GetPeopleByPubkeys_Call {
+	return &Database_GetPeopleByPubkeys_Call{Call: _e.mock.On("GetPeopleByPubkeys", pubkeys)}
+}
+
+func (_c *Database_GetPeopleByPubkeys_Call) Run(run func(pubkeys []string)) *Database_GetPeopleByPubkeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPeopleByPubkeys_Call) Return(_a0 []db.Person) *Database_GetPeopleByPubkeys_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPeopleByPubkeys_Call) RunAndReturn(run func([]string) []db.Person) *Database_GetPeopleByPubkeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPeopleBySearch provides a mock function with given fields: r
+func (_m *Database) GetPeopleBySearch(r *http.Request) []db.Person {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPeopleBySearch")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Person); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPeopleBySearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleBySearch'
+type Database_GetPeopleBySearch_Call struct {
+	*mock.Call
+}
+
+// GetPeopleBySearch is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetPeopleBySearch(r interface{}) *Database_GetPeopleBySearch_Call {
+	return &Database_GetPeopleBySearch_Call{Call: _e.mock.On("GetPeopleBySearch", r)}
+}
+
+func (_c *Database_GetPeopleBySearch_Call) Run(run func(r *http.Request)) *Database_GetPeopleBySearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetPeopleBySearch_Call) Return(_a0 []db.Person) *Database_GetPeopleBySearch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPeopleBySearch_Call) RunAndReturn(run func(*http.Request) []db.Person) *Database_GetPeopleBySearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPeopleFacets provides a mock function with given fields: r
+func (_m *Database) GetPeopleFacets(r *http.Request) db.PeopleFacets {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPeopleFacets")
+	}
+
+	var r0 db.PeopleFacets
+	if rf, ok := ret.Get(0).(func(*http.Request) db.PeopleFacets); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(db.PeopleFacets)
+	}
+
+	return r0
+}
+
+// Database_GetPeopleFacets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleFacets'
+type Database_GetPeopleFacets_Call struct {
+	*mock.Call
+}
+
+// GetPeopleFacets is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetPeopleFacets(r interface{}) *Database_GetPeopleFacets_Call {
+	return &Database_GetPeopleFacets_Call{Call: _e.mock.On("GetPeopleFacets", r)}
+}
+
+func (_c *Database_GetPeopleFacets_Call) Run(run func(r *http.Request)) *Database_GetPeopleFacets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetPeopleFacets_Call) Return(_a0 db.PeopleFacets) *Database_GetPeopleFacets_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPeopleFacets_Call) RunAndReturn(run func(*http.Request) db.PeopleFacets) *Database_GetPeopleFacets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPeopleListShort provides a mock function with given fields: count
+func (_m *Database) GetPeopleListShort(count uint32) *[]db.PersonInShort {
+	ret := _m.Called(count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPeopleListShort")
+	}
+
+	var r0 *[]db.PersonInShort
+	if rf, ok := ret.Get(0).(func(uint32) *[]db.PersonInShort); ok {
+		r0 = rf(count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]db.PersonInShort)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPeopleListShort_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleListShort'
+type Database_GetPeopleListShort_Call struct {
+	*mock.Call
+}
+
+// GetPeopleListShort is a helper method to define mock.On call
+//   - count uint32
+func (_e *Database_Expecter) GetPeopleListShort(count interface{}) *Database_GetPeopleListShort_Call {
+	return &Database_GetPeopleListShort_Call{Call: _e.mock.On("GetPeopleListShort", count)}
+}
+
+func (_c *Database_GetPeopleListShort_Call) Run(run func(count uint32)) *Database_GetPeopleListShort_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint32))
+	})
+	return _c
+}
+
+func (_c *Database_GetPeopleListShort_Call) Return(_a0 *[]db.PersonInShort) *Database_GetPeopleListShort_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPeopleListShort_Call) RunAndReturn(run func(uint32) *[]db.PersonInShort) *Database_GetPeopleListShort_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPeopleUpdatedSince provides a mock function with given fields: since, r
+func (_m *Database) GetPeopleUpdatedSince(since time.Time, r *http.Request) []db.Person {
+	ret := _m.Called(since, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPeopleUpdatedSince")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func(time.Time, *http.Request) []db.Person); ok {
+		r0 = rf(since, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPeopleUpdatedSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleUpdatedSince'
+type Database_GetPeopleUpdatedSince_Call struct {
+	*mock.Call
+}
+
+// GetPeopleUpdatedSince is a helper method to define mock.On call
+//   - since time.Time
+//   - r *http.Request
+func (_e *Database_Expecter) GetPeopleUpdatedSince(since interface{}, r interface{}) *Database_GetPeopleUpdatedSince_Call {
+	return &Database_GetPeopleUpdatedSince_Call{Call: _e.mock.On("GetPeopleUpdatedSince", since, r)}
+}
+
+func (_c *Database_GetPeopleUpdatedSince_Call) Run(run func(since time.Time, r *http.Request)) *Database_GetPeopleUpdatedSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetPeopleUpdatedSince_Call) Return(_a0 []db.Person) *Database_GetPeopleUpdatedSince_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPeopleUpdatedSince_Call) RunAndReturn(run func(time.Time, *http.Request) []db.Person) *Database_GetPeopleUpdatedSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPerson provides a mock function with given fields: id
+func (_m *Database) GetPerson(id uint) db.Person {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPerson")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(uint) db.Person); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPerson'
+type Database_GetPerson_Call struct {
+	*mock.Call
+}
+
+// GetPerson is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetPerson(id interface{}) *Database_GetPerson_Call {
+	return &Database_GetPerson_Call{Call: _e.mock.On("GetPerson", id)}
+}
+
+func (_c *Database_GetPerson_Call) Run(run func(id uint)) *Database_GetPerson_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetPerson_Call) Return(_a0 db.Person) *Database_GetPerson_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPerson_Call) RunAndReturn(run func(uint) db.Person) *Database_GetPerson_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonByGithubName provides a mock function with given fields: github_name
+func (_m *Database) GetPersonByGithubName(github_name string) db.Person {
+	ret := _m.Called(github_name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonByGithubName")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(github_name)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPersonByGithubName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByGithubName'
+type Database_GetPersonByGithubName_Call struct {
+	*mock.Call
+}
+
+// GetPersonByGithubName is a helper method to define mock.On call
+//   - github_name string
+func (_e *Database_Expecter) GetPersonByGithubName(github_name interface{}) *Database_GetPersonByGithubName_Call {
+	return &Database_GetPersonByGithubName_Call{Call: _e.mock.On("GetPersonByGithubName", github_name)}
+}
+
+func (_c *Database_GetPersonByGithubName_Call) Run(run func(github_name string)) *Database_GetPersonByGithubName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonByGithubName_Call) Return(_a0 db.Person) *Database_GetPersonByGithubName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonByGithubName_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByGithubName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonByPubkey provides a mock function with given fields: pubkey
+func (_m *Database) GetPersonByPubkey(pubkey string) db.Person {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonByPubkey")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPersonByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByPubkey'
+type Database_GetPersonByPubkey_Call struct {
+	*mock.Call
+}
+
+// GetPersonByPubkey is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetPersonByPubkey(pubkey interface{}) *Database_GetPersonByPubkey_Call {
+	return &Database_GetPersonByPubkey_Call{Call: _e.mock.On("GetPersonByPubkey", pubkey)}
+}
+
+func (_c *Database_GetPersonByPubkey_Call) Run(run func(pubkey string)) *Database_GetPersonByPubkey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonByPubkey_Call) Return(_a0 db.Person) *Database_GetPersonByPubkey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonByPubkey_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByPubkey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonByUniqueName provides a mock function with given fields: un
+func (_m *Database) GetPersonByUniqueName(un string) db.Person {
+	ret := _m.Called(un)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonByUniqueName")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(un)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPersonByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByUniqueName'
+type Database_GetPersonByUniqueName_Call struct {
+	*mock.Call
+}
+
+// GetPersonByUniqueName is a helper method to define mock.On call
+//   - un string
+func (_e *Database_Expecter) GetPersonByUniqueName(un interface{}) *Database_GetPersonByUniqueName_Call {
+	return &Database_GetPersonByUniqueName_Call{Call: _e.mock.On("GetPersonByUniqueName", un)}
+}
+
+func (_c *Database_GetPersonByUniqueName_Call) Run(run func(un string)) *Database_GetPersonByUniqueName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonByUniqueName_Call) Return(_a0 db.Person) *Database_GetPersonByUniqueName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonByUniqueName_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByUniqueName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetPersonByUuid(uuid string) db.Person {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonByUuid")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPersonByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByUuid'
+type Database_GetPersonByUuid_Call struct {
+	*mock.Call
+}
+
+// GetPersonByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetPersonByUuid(uuid interface{}) *Database_GetPersonByUuid_Call {
+	return &Database_GetPersonByUuid_Call{Call: _e.mock.On("GetPersonByUuid", uuid)}
+}
+
+func (_c *Database_GetPersonByUuid_Call) Run(run func(uuid string)) *Database_GetPersonByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonByUuid_Call) Return(_a0 db.Person) *Database_GetPersonByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonByUuid_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonEarnings provides a mock function with given fields: pubkey
+func (_m *Database) GetPersonEarnings(pubkey string) []db.EarningRecord {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonEarnings")
+	}
+
+	var r0 []db.EarningRecord
+	if rf, ok := ret.Get(0).(func(string) []db.EarningRecord); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.EarningRecord)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPersonEarnings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonEarnings'
+type Database_GetPersonEarnings_Call struct {
+	*mock.Call
+}
+
+// GetPersonEarnings is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetPersonEarnings(pubkey interface{}) *Database_GetPersonEarnings_Call {
+	return &Database_GetPersonEarnings_Call{Call: _e.mock.On("GetPersonEarnings", pubkey)}
+}
+
+func (_c *Database_GetPersonEarnings_Call) Run(run func(pubkey string)) *Database_GetPersonEarnings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonEarnings_Call) Return(_a0 []db.EarningRecord) *Database_GetPersonEarnings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonEarnings_Call) RunAndReturn(run func(string) []db.EarningRecord) *Database_GetPersonEarnings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonExport provides a mock function with given fields: pubkey
+func (_m *Database) GetPersonExport(pubkey string) db.PersonExport {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonExport")
+	}
+
+	var r0 db.PersonExport
+	if rf, ok := ret.Get(0).(func(string) db.PersonExport); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Get(0).(db.PersonExport)
+	}
+
+	return r0
+}
+
+// Database_GetPersonExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonExport'
+type Database_GetPersonExport_Call struct {
+	*mock.Call
+}
+
+// GetPersonExport is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetPersonExport(pubkey interface{}) *Database_GetPersonExport_Call {
+	return &Database_GetPersonExport_Call{Call: _e.mock.On("GetPersonExport", pubkey)}
+}
+
+func (_c *Database_GetPersonExport_Call) Run(run func(pubkey string)) *Database_GetPersonExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonExport_Call) Return(_a0 db.PersonExport) *Database_GetPersonExport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonExport_Call) RunAndReturn(run func(string) db.PersonExport) *Database_GetPersonExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhaseBudget provides a mock function with given fields: phaseUuid
+func (_m *Database) GetPhaseBudget(phaseUuid string) db.PhaseBudget {
+	ret := _m.Called(phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhaseBudget")
+	}
+
+	var r0 db.PhaseBudget
+	if rf, ok := ret.Get(0).(func(string) db.PhaseBudget); ok {
+		r0 = rf(phaseUuid)
+	} else {
+		r0 = ret.Get(0).(db.PhaseBudget)
+	}
+
+	return r0
+}
+
+// Database_GetPhaseBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhaseBudget'
+type Database_GetPhaseBudget_Call struct {
+	*mock.Call
+}
+
+// GetPhaseBudget is a helper method to define mock.On call
+//   - phaseUuid string
+func (_e *Database_Expecter) GetPhaseBudget(phaseUuid interface{}) *Database_GetPhaseBudget_Call {
+	return &Database_GetPhaseBudget_Call{Call: _e.mock.On("GetPhaseBudget", phaseUuid)}
+}
+
+func (_c *Database_GetPhaseBudget_Call) Run(run func(phaseUuid string)) *Database_GetPhaseBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhaseBudget_Call) Return(_a0 db.PhaseBudget) *Database_GetPhaseBudget_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPhaseBudget_Call) RunAndReturn(run func(string) db.PhaseBudget) *Database_GetPhaseBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhaseByUuid provides a mock function with given fields: phaseUuid
+func (_m *Database) GetPhaseByUuid(phaseUuid string) (db.FeaturePhase, error) {
+	ret := _m.Called(phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhaseByUuid")
+	}
+
+	var r0 db.FeaturePhase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.FeaturePhase, error)); ok {
+		return rf(phaseUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.FeaturePhase); ok {
+		r0 = rf(phaseUuid)
+	} else {
+		r0 = ret.Get(0).(db.FeaturePhase)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(phaseUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPhaseByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhaseByUuid'
+type Database_GetPhaseByUuid_Call struct {
+	*mock.Call
+}
+
+// GetPhaseByUuid is a helper method to define mock.On call
+//   - phaseUuid string
+func (_e *Database_Expecter) GetPhaseByUuid(phaseUuid interface{}) *Database_GetPhaseByUuid_Call {
+	return &Database_GetPhaseByUuid_Call{Call: _e.mock.On("GetPhaseByUuid", phaseUuid)}
+}
+
+func (_c *Database_GetPhaseByUuid_Call) Run(run func(phaseUuid string)) *Database_GetPhaseByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhaseByUuid_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_GetPhaseByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPhaseByUuid_Call) RunAndReturn(run func(string) (db.FeaturePhase, error)) *Database_GetPhaseByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhaseCommitted provides a mock function with given fields: phaseUuid
+func (_m *Database) GetPhaseCommitted(phaseUuid string) uint {
+	ret := _m.Called(phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhaseCommitted")
+	}
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(string) uint); ok {
+		r0 = rf(phaseUuid)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
+// Database_GetPhaseCommitted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhaseCommitted'
+type Database_GetPhaseCommitted_Call struct {
+	*mock.Call
+}
+
+// GetPhaseCommitted is a helper method to define mock.On call
+//   - phaseUuid string
+func (_e *Database_Expecter) GetPhaseCommitted(phaseUuid interface{}) *Database_GetPhaseCommitted_Call {
+	return &Database_GetPhaseCommitted_Call{Call: _e.mock.On("GetPhaseCommitted", phaseUuid)}
+}
+
+func (_c *Database_GetPhaseCommitted_Call) Run(run func(phaseUuid string)) *Database_GetPhaseCommitted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhaseCommitted_Call) Return(_a0 uint) *Database_GetPhaseCommitted_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPhaseCommitted_Call) RunAndReturn(run func(string) uint) *Database_GetPhaseCommitted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhaseSpent provides a mock function with given fields: phaseUuid
+func (_m *Database) GetPhaseSpent(phaseUuid string) uint {
+	ret := _m.Called(phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhaseSpent")
+	}
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(string) uint); ok {
+		r0 = rf(phaseUuid)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
+// Database_GetPhaseSpent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhaseSpent'
+type Database_GetPhaseSpent_Call struct {
+	*mock.Call
+}
+
+// GetPhaseSpent is a helper method to define mock.On call
+//   - phaseUuid string
+func (_e *Database_Expecter) GetPhaseSpent(phaseUuid interface{}) *Database_GetPhaseSpent_Call {
+	return &Database_GetPhaseSpent_Call{Call: _e.mock.On("GetPhaseSpent", phaseUuid)}
+}
+
+func (_c *Database_GetPhaseSpent_Call) Run(run func(phaseUuid string)) *Database_GetPhaseSpent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhaseSpent_Call) Return(_a0 uint) *Database_GetPhaseSpent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPhaseSpent_Call) RunAndReturn(run func(string) uint) *Database_GetPhaseSpent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhasesByFeatureUuid provides a mock function with given fields: featureUuid
+func (_m *Database) GetPhasesByFeatureUuid(featureUuid string) []db.FeaturePhase {
+	ret := _m.Called(featureUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhasesByFeatureUuid")
+	}
+
+	var r0 []db.FeaturePhase
+	if rf, ok := ret.Get(0).(func(string) []db.FeaturePhase); ok {
+		r0 = rf(featureUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeaturePhase)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPhasesByFeatureUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhasesByFeatureUuid'
+type Database_GetPhasesByFeatureUuid_Call struct {
+	*mock.Call
+}
+
+// GetPhasesByFeatureUuid is a helper method to define mock.On call
+//   - featureUuid string
+func (_e *Database_Expecter) GetPhasesByFeatureUuid(featureUuid interface{}) *Database_GetPhasesByFeatureUuid_Call {
+	return &Database_GetPhasesByFeatureUuid_Call{Call: _e.mock.On("GetPhasesByFeatureUuid", featureUuid)}
+}
+
+func (_c *Database_GetPhasesByFeatureUuid_Call) Run(run func(featureUuid string)) *Database_GetPhasesByFeatureUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhasesByFeatureUuid_Call) Return(_a0 []db.FeaturePhase) *Database_GetPhasesByFeatureUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPhasesByFeatureUuid_Call) RunAndReturn(run func(string) []db.FeaturePhase) *Database_GetPhasesByFeatureUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhasesByFeatureUuids provides a mock function with given fields: uuids
+func (_m *Database) GetPhasesByFeatureUuids(uuids []string) []db.FeaturePhase {
+	ret := _m.Called(uuids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhasesByFeatureUuids")
+	}
+
+	var r0 []db.FeaturePhase
+	if rf, ok := ret.Get(0).(func([]string) []db.FeaturePhase); ok {
+		r0 = rf(uuids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeaturePhase)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPhasesByFeatureUuids_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhasesByFeatureUuids'
+type Database_GetPhasesByFeatureUuids_Call struct {
+	*mock.Call
+}
+
+// GetPhasesByFeatureUuids is a helper method to define mock.On call
+//   - uuids []string
+func (_e *Database_Expecter) GetPhasesByFeatureUuids(uuids interface{}) *Database_GetPhasesByFeatureUuids_Call {
+	return &Database_GetPhasesByFeatureUuids_Call{Call: _e.mock.On("GetPhasesByFeatureUuids", uuids)}
+}
+
+func (_c *Database_GetPhasesByFeatureUuids_Call) Run(run func(uuids []string)) *Database_GetPhasesByFeatureUuids_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhasesByFeatureUuids_Call) Return(_a0 []db.FeaturePhase) *Database_GetPhasesByFeatureUuids_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPhasesByFeatureUuids_Call) RunAndReturn(run func([]string) []db.FeaturePhase) *Database_GetPhasesByFeatureUuids_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhasesByUuids provides a mock function with given fields: uuids
+func (_m *Database) GetPhasesByUuids(uuids []string) []db.FeaturePhase {
+	ret := _m.Called(uuids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhasesByUuids")
+	}
+
+	var r0 []db.FeaturePhase
+	if rf, ok := ret.Get(0).(func([]string) []db.FeaturePhase); ok {
+		r0 = rf(uuids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeaturePhase)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPhasesByUuids_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhasesByUuids'
+type Database_GetPhasesByUuids_Call struct {
+	*mock.Call
+}
+
+// GetPhasesByUuids is a helper method to define mock.On call
+//   - uuids []string
+func (_e *Database_Expecter) GetPhasesByUuids(uuids interface{}) *Database_GetPhasesByUuids_Call {
+	return &Database_GetPhasesByUuids_Call{Call: _e.mock.On("GetPhasesByUuids", uuids)}
+}
+
+func (_c *Database_GetPhasesByUuids_Call) Run(run func(uuids []string)) *Database_GetPhasesByUuids_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhasesByUuids_Call) Return(_a0 []db.FeaturePhase) *Database_GetPhasesByUuids_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPhasesByUuids_Call) RunAndReturn(run func([]string) []db.FeaturePhase) *Database_GetPhasesByUuids_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPreviousBountyByCreated provides a mock function with given fields: r
+func (_m *Database) GetPreviousBountyByCreated(r *http.Request) (uint, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreviousBountyByCreated")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPreviousBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreviousBountyByCreated'
+type Database_GetPreviousBountyByCreated_Call struct {
+	*mock.Call
+}
+
+// GetPreviousBountyByCreated is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetPreviousBountyByCreated(r interface{}) *Database_GetPreviousBountyByCreated_Call {
+	return &Database_GetPreviousBountyByCreated_Call{Call: _e.mock.On("GetPreviousBountyByCreated", r)}
+}
+
+func (_c *Database_GetPreviousBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetPreviousBountyByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetPreviousBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetPreviousBountyByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPreviousBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetPreviousBountyByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPreviousWorkspaceBountyByCreated provides a mock function with given fields: r
+func (_m *Database) GetPreviousWorkspaceBountyByCreated(r *http.Request) (uint, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreviousWorkspaceBountyByCreated")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPreviousWorkspaceBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreviousWorkspaceBountyByCreated'
+type Database_GetPreviousWorkspaceBountyByCreated_Call struct {
+	*mock.Call
+}
+
+// GetPreviousWorkspaceBountyByCreated is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetPreviousWorkspaceBountyByCreated(r interface{}) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+	return &Database_GetPreviousWorkspaceBountyByCreated_Call{Call: _e.mock.On("GetPreviousWorkspaceBountyByCreated", r)}
+}
+
+func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPubkeyBans provides a mock function with given fields: pubkey
+func (_m *Database) GetPubkeyBans(pubkey string) []db.PubkeyBan {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPubkeyBans")
+	}
+
+	var r0 []db.PubkeyBan
+	if rf, ok := ret.Get(0).(func(string) []db.PubkeyBan); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PubkeyBan)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPubkeyBans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPubkeyBans'
+type Database_GetPubkeyBans_Call struct {
+	*mock.Call
+}
+
+// GetPubkeyBans is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetPubkeyBans(pubkey interface{}) *Database_GetPubkeyBans_Call {
+	return &Database_GetPubkeyBans_Call{Call: _e.mock.On("GetPubkeyBans", pubkey)}
+}
+
+func (_c *Database_GetPubkeyBans_Call) Run(run func(pubkey string)) *Database_GetPubkeyBans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPubkeyBans_Call) Return(_a0 []db.PubkeyBan) *Database_GetPubkeyBans_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPubkeyBans_Call) RunAndReturn(run func(string) []db.PubkeyBan) *Database_GetPubkeyBans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecentErrors provides a mock function with given fields: since, limit
+func (_m *Database) GetRecentErrors(since time.Time, limit int) []db.AdminError {
+	ret := _m.Called(since, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecentErrors")
+	}
+
+	var r0 []db.AdminError
+	if rf, ok := ret.Get(0).(func(time.Time, int) []db.AdminError); ok {
+		r0 = rf(since, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.AdminError)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetRecentErrors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecentErrors'
+type Database_GetRecentErrors_Call struct {
+	*mock.Call
+}
+
+// GetRecentErrors is a helper method to define mock.On call
+//   - since time.Time
+//   - limit int
+func (_e *Database_Expecter) GetRecentErrors(since interface{}, limit interface{}) *Database_GetRecentErrors_Call {
+	return &Database_GetRecentErrors_Call{Call: _e.mock.On("GetRecentErrors", since, limit)}
+}
+
+func (_c *Database_GetRecentErrors_Call) Run(run func(since time.Time, limit int)) *Database_GetRecentErrors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetRecentErrors_Call) Return(_a0 []db.AdminError) *Database_GetRecentErrors_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetRecentErrors_Call) RunAndReturn(run func(time.Time, int) []db.AdminError) *Database_GetRecentErrors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecentListingTexts provides a mock function with given fields: targetType, since, limit
+func (_m *Database) GetRecentListingTexts(targetType db.ReportTargetType, since time.Time, limit int) []db.ListingText {
+	ret := _m.Called(targetType, since, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecentListingTexts")
+	}
+
+	var r0 []db.ListingText
+	if rf, ok := ret.Get(0).(func(db.ReportTargetType, time.Time, int) []db.ListingText); ok {
+		r0 = rf(targetType, since, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListingText)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetRecentListingTexts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecentListingTexts'
+type Database_GetRecentListingTexts_Call struct {
+	*mock.Call
+}
+
+// GetRecentListingTexts is a helper method to define mock.On call
+//   - targetType db.ReportTargetType
+//   - since time.Time
+//   - limit int
+func (_e *Database_Expecter) GetRecentListingTexts(targetType interface{}, since interface{}, limit interface{}) *Database_GetRecentListingTexts_Call {
+	return &Database_GetRecentListingTexts_Call{Call: _e.mock.On("GetRecentListingTexts", targetType, since, limit)}
+}
+
+func (_c *Database_GetRecentListingTexts_Call) Run(run func(targetType db.ReportTargetType, since time.Time, limit int)) *Database_GetRecentListingTexts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.ReportTargetType), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetRecentListingTexts_Call) Return(_a0 []db.ListingText) *Database_GetRecentListingTexts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetRecentListingTexts_Call) RunAndReturn(run func(db.ReportTargetType, time.Time, int) []db.ListingText) *Database_GetRecentListingTexts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecommendationCandidates provides a mock function with given fields: terms, pubkey, limit
+func (_m *Database) GetRecommendationCandidates(terms []string, pubkey string, limit int) []db.NewBounty {
+	ret := _m.Called(terms, pubkey, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecommendationCandidates")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func([]string, string, int) []db.NewBounty); ok {
+		r0 = rf(terms, pubkey, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetRecommendationCandidates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecommendationCandidates'
+type Database_GetRecommendationCandidates_Call struct {
+	*mock.Call
+}
+
+// GetRecommendationCandidates is a helper method to define mock.On call
+//   - terms []string
+//   - pubkey string
+//   - limit int
+func (_e *Database_Expecter) GetRecommendationCandidates(terms interface{}, pubkey interface{}, limit interface{}) *Database_GetRecommendationCandidates_Call {
+	return &Database_GetRecommendationCandidates_Call{Call: _e.mock.On("GetRecommendationCandidates", terms, pubkey, limit)}
+}
+
+func (_c *Database_GetRecommendationCandidates_Call) Run(run func(terms []string, pubkey string, limit int)) *Database_GetRecommendationCandidates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetRecommendationCandidates_Call) Return(_a0 []db.NewBounty) *Database_GetRecommendationCandidates_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetRecommendationCandidates_Call) RunAndReturn(run func([]string, string, int) []db.NewBounty) *Database_GetRecommendationCandidates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReportTargetOwner provides a mock function with given fields: targetType, targetId
+func (_m *Database) GetReportTargetOwner(targetType db.ReportTargetType, targetId string) (string, bool) {
+	ret := _m.Called(targetType, targetId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReportTargetOwner")
+	}
+
+	var r0 string
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(db.ReportTargetType, string) (string, bool)); ok {
+		return rf(targetType, targetId)
+	}
+	if rf, ok := ret.Get(0).(func(db.ReportTargetType, string) string); ok {
+		r0 = rf(targetType, targetId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.ReportTargetType, string) bool); ok {
+		r1 = rf(targetType, targetId)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// Database_GetReportTargetOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReportTargetOwner'
+type Database_GetReportTargetOwner_Call struct {
+	*mock.Call
+}
+
+// GetReportTargetOwner is a helper method to define mock.On call
+//   - targetType db.ReportTargetType
+//   - targetId string
+func (_e *Database_Expecter) GetReportTargetOwner(targetType interface{}, targetId interface{}) *Database_GetReportTargetOwner_Call {
+	return &Database_GetReportTargetOwner_Call{Call: _e.mock.On("GetReportTargetOwner", targetType, targetId)}
+}
+
+func (_c *Database_GetReportTargetOwner_Call) Run(run func(targetType db.ReportTargetType, targetId string)) *Database_GetReportTargetOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.ReportTargetType), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetReportTargetOwner_Call) Return(_a0 string, _a1 bool) *Database_GetReportTargetOwner_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetReportTargetOwner_Call) RunAndReturn(run func(db.ReportTargetType, string) (string, bool)) *Database_GetReportTargetOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRepositoriesToSync provides a mock function with given fields: limit
+func (_m *Database) GetRepositoriesToSync(limit int) []db.WorkspaceRepositories {
+	ret := _m.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepositoriesToSync")
+	}
+
+	var r0 []db.WorkspaceRepositories
+	if rf, ok := ret.Get(0).(func(int) []db.WorkspaceRepositories); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceRepositories)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetRepositoriesToSync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepositoriesToSync'
+type Database_GetRepositoriesToSync_Call struct {
+	*mock.Call
+}
+
+// GetRepositoriesToSync is a helper method to define mock.On call
+//   - limit int
+func (_e *Database_Expecter) GetRepositoriesToSync(limit interface{}) *Database_GetRepositoriesToSync_Call {
+	return &Database_GetRepositoriesToSync_Call{Call: _e.mock.On("GetRepositoriesToSync", limit)}
+}
+
+func (_c *Database_GetRepositoriesToSync_Call) Run(run func(limit int)) *Database_GetRepositoriesToSync_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetRepositoriesToSync_Call) Return(_a0 []db.WorkspaceRepositories) *Database_GetRepositoriesToSync_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetRepositoriesToSync_Call) RunAndReturn(run func(int) []db.WorkspaceRepositories) *Database_GetRepositoriesToSync_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRepositoryCommits provides a mock function with given fields: repositoryUuid, limit
+func (_m *Database) GetRepositoryCommits(repositoryUuid string, limit int) []db.RepositoryCommit {
+	ret := _m.Called(repositoryUuid, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepositoryCommits")
+	}
+
+	var r0 []db.RepositoryCommit
+	if rf, ok := ret.Get(0).(func(string, int) []db.RepositoryCommit); ok {
+		r0 = rf(repositoryUuid, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RepositoryCommit)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetRepositoryCommits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepositoryCommits'
+type Database_GetRepositoryCommits_Call struct {
+	*mock.Call
+}
+
+// GetRepositoryCommits is a helper method to define mock.On call
+//   - repositoryUuid string
+//   - limit int
+func (_e *Database_Expecter) GetRepositoryCommits(repositoryUuid interface{}, limit interface{}) *Database_GetRepositoryCommits_Call {
+	return &Database_GetRepositoryCommits_Call{Call: _e.mock.On("GetRepositoryCommits", repositoryUuid, limit)}
+}
+
+func (_c *Database_GetRepositoryCommits_Call) Run(run func(repositoryUuid string, limit int)) *Database_GetRepositoryCommits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetRepositoryCommits_Call) Return(_a0 []db.RepositoryCommit) *Database_GetRepositoryCommits_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetRepositoryCommits_Call) RunAndReturn(run func(string, int) []db.RepositoryCommit) *Database_GetRepositoryCommits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRepositoryPullRequests provides a mock function with given fields: repositoryUuid, limit
+func (_m *Database) GetRepositoryPullRequests(repositoryUuid string, limit int) []db.RepositoryPullRequest {
+	ret := _m.Called(repositoryUuid, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepositoryPullRequests")
+	}
+
+	var r0 []db.RepositoryPullRequest
+	if rf, ok := ret.Get(0).(func(string, int) []db.RepositoryPullRequest); ok {
+		r0 = rf(repositoryUuid, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RepositoryPullRequest)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetRepositoryPullRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepositoryPullRequests'
+type Database_GetRepositoryPullRequests_Call struct {
+	*mock.Call
+}
+
+// GetRepositoryPullRequests is a helper method to define mock.On call
+//   - repositoryUuid string
+//   - limit int
+func (_e *Database_Expecter) GetRepositoryPullRequests(repositoryUuid interface{}, limit interface{}) *Database_GetRepositoryPullRequests_Call {
+	return &Database_GetRepositoryPullRequests_Call{Call: _e.mock.On("GetRepositoryPullRequests", repositoryUuid, limit)}
+}
+
+func (_c *Database_GetRepositoryPullRequests_Call) Run(run func(repositoryUuid string, limit int)) *Database_GetRepositoryPullRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetRepositoryPullRequests_Call) Return(_a0 []db.RepositoryPullRequest) *Database_GetRepositoryPullRequests_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetRepositoryPullRequests_Call) RunAndReturn(run func(string, int) []db.RepositoryPullRequest) *Database_GetRepositoryPullRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReputationStats provides a mock function with given fields: pubkey
+func (_m *Database) GetReputationStats(pubkey string) db.ReputationStats {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReputationStats")
+	}
+
+	var r0 db.ReputationStats
+	if rf, ok := ret.Get(0).(func(string) db.ReputationStats); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Get(0).(db.ReputationStats)
+	}
+
+	return r0
+}
+
+// Database_GetReputationStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReputationStats'
+type Database_GetReputationStats_Call struct {
+	*mock.Call
+}
+
+// GetReputationStats is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetReputationStats(pubkey interface{}) *Database_GetReputationStats_Call {
+	return &Database_GetReputationStats_Call{Call: _e.mock.On("GetReputationStats", pubkey)}
+}
+
+func (_c *Database_GetReputationStats_Call) Run(run func(pubkey string)) *Database_GetReputationStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetReputationStats_Call) Return(_a0 db.ReputationStats) *Database_GetReputationStats_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetReputationStats_Call) RunAndReturn(run func(string) db.ReputationStats) *Database_GetReputationStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRollupBountiesProviders provides a mock function with given fields: r, re
+func (_m *Database) GetRollupBountiesProviders(r db.PaymentDateRange, re *http.Request) ([]db.Person, error) {
+	ret := _m.Called(r, re)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRollupBountiesProviders")
+	}
+
+	var r0 []db.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) ([]db.Person, error)); ok {
+		return rf(r, re)
+	}
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) []db.Person); ok {
+		r0 = rf(r, re)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(db.PaymentDateRange, *http.Request) error); ok {
+		r1 = rf(r, re)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetRollupBountiesProviders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRollupBountiesProviders'
+type Database_GetRollupBountiesProviders_Call struct {
+	*mock.Call
+}
+
+// GetRollupBountiesProviders is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - re *http.Request
+func (_e *Database_Expecter) GetRollupBountiesProviders(r interface{}, re interface{}) *Database_GetRollupBountiesProviders_Call {
+	return &Database_GetRollupBountiesProviders_Call{Call: _e.mock.On("GetRollupBountiesProviders", r, re)}
+}
+
+func (_c *Database_GetRollupBountiesProviders_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetRollupBountiesProviders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetRollupBountiesProviders_Call) Return(_a0 []db.Person, _a1 error) *Database_GetRollupBountiesProviders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetRollupBountiesProviders_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) ([]db.Person, error)) *Database_GetRollupBountiesProviders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRollupBountyMetrics provides a mock function with given fields: r, workspace
+func (_m *Database) GetRollupBountyMetrics(r db.PaymentDateRange, workspace string) (db.BountyMetrics, error) {
+	ret := _m.Called(r, workspace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRollupBountyMetrics")
+	}
+
+	var r0 db.BountyMetrics
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) (db.BountyMetrics, error)); ok {
+		return rf(r, workspace)
+	}
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) db.BountyMetrics); ok {
+		r0 = rf(r, workspace)
+	} else {
+		r0 = ret.Get(0).(db.BountyMetrics)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.PaymentDateRange, string) error); ok {
+		r1 = rf(r, workspace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetRollupBountyMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRollupBountyMetrics'
+type Database_GetRollupBountyMetrics_Call struct {
+	*mock.Call
+}
+
+// GetRollupBountyMetrics is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) GetRollupBountyMetrics(r interface{}, workspace interface{}) *Database_GetRollupBountyMetrics_Call {
+	return &Database_GetRollupBountyMetrics_Call{Call: _e.mock.On("GetRollupBountyMetrics", r, workspace)}
+}
+
+func (_c *Database_GetRollupBountyMetrics_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_GetRollupBountyMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetRollupBountyMetrics_Call) Return(_a0 db.BountyMetrics, _a1 error) *Database_GetRollupBountyMetrics_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetRollupBountyMetrics_Call) RunAndReturn(run func(db.PaymentDateRange, string) (db.BountyMetrics, error)) *Database_GetRollupBountyMetrics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSavedSearches provides a mock function with given fields: pubkey
+func (_m *Database) GetSavedSearches(pubkey string) []db.SavedSearch {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSavedSearches")
+	}
+
+	var r0 []db.SavedSearch
+	if rf, ok := ret.Get(0).(func(string) []db.SavedSearch); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SavedSearch)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetSavedSearches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSavedSearches'
+type Database_GetSavedSearches_Call struct {
+	*mock.Call
+}
+
+// GetSavedSearches is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetSavedSearches(pubkey interface{}) *Database_GetSavedSearches_Call {
+	return &Database_GetSavedSearches_Call{Call: _e.mock.On("GetSavedSearches", pubkey)}
+}
+
+func (_c *Database_GetSavedSearches_Call) Run(run func(pubkey string)) *Database_GetSavedSearches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetSavedSearches_Call) Return(_a0 []db.SavedSearch) *Database_GetSavedSearches_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetSavedSearches_Call) RunAndReturn(run func(string) []db.SavedSearch) *Database_GetSavedSearches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSavedSearchesForBounty provides a mock function with given fields: bounty
+func (_m *Database) GetSavedSearchesForBounty(bounty db.NewBounty) []db.SavedSearch {
+	ret := _m.Called(bounty)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSavedSearchesForBounty")
+	}
+
+	var r0 []db.SavedSearch
+	if rf, ok := ret.Get(0).(func(db.NewBounty) []db.SavedSearch); ok {
+		r0 = rf(bounty)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SavedSearch)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetSavedSearchesForBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSavedSearchesForBounty'
+type Database_GetSavedSearchesForBounty_Call struct {
+	*mock.Call
+}
+
+// GetSavedSearchesForBounty is a helper method to define mock.On call
+//   - bounty db.NewBounty
+func (_e *Database_Expecter) GetSavedSearchesForBounty(bounty interface{}) *Database_GetSavedSearchesForBounty_Call {
+	return &Database_GetSavedSearchesForBounty_Call{Call: _e.mock.On("GetSavedSearchesForBounty", bounty)}
+}
+
+func (_c *Database_GetSavedSearchesForBounty_Call) Run(run func(bounty db.NewBounty)) *Database_GetSavedSearchesForBounty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewBounty))
+	})
+	return _c
+}
+
+func (_c *Database_GetSavedSearchesForBounty_Call) Return(_a0 []db.SavedSearch) *Database_GetSavedSearchesForBounty_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetSavedSearchesForBounty_Call) RunAndReturn(run func(db.NewBounty) []db.SavedSearch) *Database_GetSavedSearchesForBounty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSearchDocuments provides a mock function with given fields: entityType, offset, limit
+func (_m *Database) GetSearchDocuments(entityType string, offset int, limit int) []db.SearchResult {
+	ret := _m.Called(entityType, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSearchDocuments")
+	}
+
+	var r0 []db.SearchResult
+	if rf, ok := ret.Get(0).(func(string, int, int) []db.SearchResult); ok {
+		r0 = rf(entityType, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SearchResult)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetSearchDocuments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSearchDocuments'
+type Database_GetSearchDocuments_Call struct {
+	*mock.Call
+}
+
+// GetSearchDocuments is a helper method to define mock.On call
+//   - entityType string
+//   - offset int
+//   - limit int
+func (_e *Database_Expecter) GetSearchDocuments(entityType interface{}, offset interface{}, limit interface{}) *Database_GetSearchDocuments_Call {
+	return &Database_GetSearchDocuments_Call{Call: _e.mock.On("GetSearchDocuments", entityType, offset, limit)}
+}
+
+func (_c *Database_GetSearchDocuments_Call) Run(run func(entityType string, offset int, limit int)) *Database_GetSearchDocuments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetSearchDocuments_Call) Return(_a0 []db.SearchResult) *Database_GetSearchDocuments_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetSearchDocuments_Call) RunAndReturn(run func(string, int, int) []db.SearchResult) *Database_GetSearchDocuments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSearchDocumentsByID provides a mock function with given fields: entityType, ids
+func (_m *Database) GetSearchDocumentsByID(entityType string, ids []string) []db.SearchResult {
+	ret := _m.Called(entityType, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSearchDocumentsByID")
+	}
+
+	var r0 []db.SearchResult
+	if rf, ok := ret.Get(0).(func(string, []string) []db.SearchResult); ok {
+		r0 = rf(entityType, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SearchResult)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetSearchDocumentsByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSearchDocumentsByID'
+type Database_GetSearchDocumentsByID_Call struct {
+	*mock.Call
+}
+
+// GetSearchDocumentsByID is a helper method to define mock.On call
+//   - entityType string
+//   - ids []string
+func (_e *Database_Expecter) GetSearchDocumentsByID(entityType interface{}, ids interface{}) *Database_GetSearchDocumentsByID_Call {
+	return &Database_GetSearchDocumentsByID_Call{Call: _e.mock.On("GetSearchDocumentsByID", entityType, ids)}
+}
+
+func (_c *Database_GetSearchDocumentsByID_Call) Run(run func(entityType string, ids []string)) *Database_GetSearchDocumentsByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetSearchDocumentsByID_Call) Return(_a0 []db.SearchResult) *Database_GetSearchDocumentsByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetSearchDocumentsByID_Call) RunAndReturn(run func(string, []string) []db.SearchResult) *Database_GetSearchDocumentsByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSearchIndexBacklog provides a mock function with given fields:
+func (_m *Database) GetSearchIndexBacklog() db.SearchIndexBacklog {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSearchIndexBacklog")
+	}
+
+	var r0 db.SearchIndexBacklog
+	if rf, ok := ret.Get(0).(func() db.SearchIndexBacklog); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(db.SearchIndexBacklog)
+	}
+
+	return r0
+}
+
+// Database_GetSearchIndexBacklog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSearchIndexBacklog'
+type Database_GetSearchIndexBacklog_Call struct {
+	*mock.Call
+}
+
+// GetSearchIndexBacklog is a helper method to define mock.On call
+func (_e *Database_Expecter) GetSearchIndexBacklog() *Database_GetSearchIndexBacklog_Call {
+	return &Database_GetSearchIndexBacklog_Call{Call: _e.mock.On("GetSearchIndexBacklog")}
+}
+
+func (_c *Database_GetSearchIndexBacklog_Call) Run(run func()) *Database_GetSearchIndexBacklog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetSearchIndexBacklog_Call) Return(_a0 db.SearchIndexBacklog) *Database_GetSearchIndexBacklog_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetSearchIndexBacklog_Call) RunAndReturn(run func() db.SearchIndexBacklog) *Database_GetSearchIndexBacklog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSearchIndexChanges provides a mock function with given fields: limit
+func (_m *Database) GetSearchIndexChanges(limit int) []db.SearchIndexChange {
+	ret := _m.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSearchIndexChanges")
+	}
+
+	var r0 []db.SearchIndexChange
+	if rf, ok := ret.Get(0).(func(int) []db.SearchIndexChange); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SearchIndexChange)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetSearchIndexChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSearchIndexChanges'
+type Database_GetSearchIndexChanges_Call struct {
+	*mock.Call
+}
+
+// GetSearchIndexChanges is a helper method to define mock.On call
+//   - limit int
+func (_e *Database_Expecter) GetSearchIndexChanges(limit interface{}) *Database_GetSearchIndexChanges_Call {
+	return &Database_GetSearchIndexChanges_Call{Call: _e.mock.On("GetSearchIndexChanges", limit)}
+}
+
+func (_c *Database_GetSearchIndexChanges_Call) Run(run func(limit int)) *Database_GetSearchIndexChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetSearchIndexChanges_Call) Return(_a0 []db.SearchIndexChange) *Database_GetSearchIndexChanges_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetSearchIndexChanges_Call) RunAndReturn(run func(int) []db.SearchIndexChange) *Database_GetSearchIndexChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStakworkJob provides a mock function with given fields: uuid
+func (_m *Database) GetStakworkJob(uuid string) db.StakworkJob {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStakworkJob")
+	}
+
+	var r0 db.StakworkJob
+	if rf, ok := ret.Get(0).(func(string) db.StakworkJob); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.StakworkJob)
+	}
+
+	return r0
+}
+
+// Database_GetStakworkJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStakworkJob'
+type Database_GetStakworkJob_Call struct {
+	*mock.Call
+}
+
+// GetStakworkJob is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetStakworkJob(uuid interface{}) *Database_GetStakworkJob_Call {
+	return &Database_GetStakworkJob_Call{Call: _e.mock.On("GetStakworkJob", uuid)}
+}
+
+func (_c *Database_GetStakworkJob_Call) Run(run func(uuid string)) *Database_GetStakworkJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetStakworkJob_Call) Return(_a0 db.StakworkJob) *Database_GetStakworkJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetStakworkJob_Call) RunAndReturn(run func(string) db.StakworkJob) *Database_GetStakworkJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStakworkJobByToken provides a mock function with given fields: token
+func (_m *Database) GetStakworkJobByToken(token string) db.StakworkJob {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStakworkJobByToken")
+	}
+
+	var r0 db.StakworkJob
+	if rf, ok := ret.Get(0).(func(string) db.StakworkJob); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(db.StakworkJob)
+	}
+
+	return r0
+}
+
+// Database_GetStakworkJobByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStakworkJobByToken'
+type Database_GetStakworkJobByToken_Call struct {
+	*mock.Call
+}
+
+// GetStakworkJobByToken is a helper method to define mock.On call
+//   - token string
+func (_e *Database_Expecter) GetStakworkJobByToken(token interface{}) *Database_GetStakworkJobByToken_Call {
+	return &Database_GetStakworkJobByToken_Call{Call: _e.mock.On("GetStakworkJobByToken", token)}
+}
+
+func (_c *Database_GetStakworkJobByToken_Call) Run(run func(token string)) *Database_GetStakworkJobByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetStakworkJobByToken_Call) Return(_a0 db.StakworkJob) *Database_GetStakworkJobByToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetStakworkJobByToken_Call) RunAndReturn(run func(string) db.StakworkJob) *Database_GetStakworkJobByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSuperAdmins provides a mock function with given fields:
+func (_m *Database) GetSuperAdmins() []db.SuperAdmin {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSuperAdmins")
+	}
+
+	var r0 []db.SuperAdmin
+	if rf, ok := ret.Get(0).(func() []db.SuperAdmin); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SuperAdmin)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetSuperAdmins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSuperAdmins'
+type Database_GetSuperAdmins_Call struct {
+	*mock.Call
+}
+
+// GetSuperAdmins is a helper method to define mock.On call
+func (_e *Database_Expecter) GetSuperAdmins() *Database_GetSuperAdmins_Call {
+	return &Database_GetSuperAdmins_Call{Call: _e.mock.On("GetSuperAdmins")}
+}
+
+func (_c *Database_GetSuperAdmins_Call) Run(run func()) *Database_GetSuperAdmins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetSuperAdmins_Call) Return(_a0 []db.SuperAdmin) *Database_GetSuperAdmins_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetSuperAdmins_Call) RunAndReturn(run func() []db.SuperAdmin) *Database_GetSuperAdmins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTicketCard provides a mock function with given fields: ticketId
+func (_m *Database) GetTicketCard(ticketId string) db.TicketCard {
+	ret := _m.Called(ticketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTicketCard")
+	}
+
+	var r0 db.TicketCard
+	if rf, ok := ret.Get(0).(func(string) db.TicketCard); ok {
+		r0 = rf(ticketId)
+	} else {
+		r0 = ret.Get(0).(db.TicketCard)
+	}
+
+	return r0
+}
+
+// Database_GetTicketCard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTicketCard'
+type Database_GetTicketCard_Call struct {
+	*mock.Call
+}
+
+// GetTicketCard is a helper method to define mock.On call
+//   - ticketId string
+func (_e *Database_Expecter) GetTicketCard(ticketId interface{}) *Database_GetTicketCard_Call {
+	return &Database_GetTicketCard_Call{Call: _e.mock.On("GetTicketCard", ticketId)}
+}
+
+func (_c *Database_GetTicketCard_Call) Run(run func(ticketId string)) *Database_GetTicketCard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTicketCard_Call) Return(_a0 db.TicketCard) *Database_GetTicketCard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTicketCard_Call) RunAndReturn(run func(string) db.TicketCard) *Database_GetTicketCard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTicketCards provides a mock function with given fields: phaseUuid
+func (_m *Database) GetTicketCards(phaseUuid string) []db.TicketCard {
+	ret := _m.Called(phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTicketCards")
+	}
+
+	var r0 []db.TicketCard
+	if rf, ok := ret.Get(0).(func(string) []db.TicketCard); ok {
+		r0 = rf(phaseUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TicketCard)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTicketCards_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTicketCards'
+type Database_GetTicketCards_Call struct {
+	*mock.Call
+}
+
+// GetTicketCards is a helper method to define mock.On call
+//   - phaseUuid string
+func (_e *Database_Expecter) GetTicketCards(phaseUuid interface{}) *Database_GetTicketCards_Call {
+	return &Database_GetTicketCards_Call{Call: _e.mock.On("GetTicketCards", phaseUuid)}
+}
+
+func (_c *Database_GetTicketCards_Call) Run(run func(phaseUuid string)) *Database_GetTicketCards_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTicketCards_Call) Return(_a0 []db.TicketCard) *Database_GetTicketCards_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTicketCards_Call) RunAndReturn(run func(string) []db.TicketCard) *Database_GetTicketCards_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTicketCardsByPhaseUuids provides a mock function with given fields: uuids
+func (_m *Database) GetTicketCardsByPhaseUuids(uuids []string) []db.TicketCard {
+	ret := _m.Called(uuids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTicketCardsByPhaseUuids")
+	}
+
+	var r0 []db.TicketCard
+	if rf, ok := ret.Get(0).(func([]string) []db.TicketCard); ok {
+		r0 = rf(uuids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TicketCard)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTicketCardsByPhaseUuids_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTicketCardsByPhaseUuids'
+type Database_GetTicketCardsByPhaseUuids_Call struct {
+	*mock.Call
+}
+
+// GetTicketCardsByPhaseUuids is a helper method to define mock.On call
+//   - uuids []string
+func (_e *Database_Expecter) GetTicketCardsByPhaseUuids(uuids interface{}) *Database_GetTicketCardsByPhaseUuids_Call {
+	return &Database_GetTicketCardsByPhaseUuids_Call{Call: _e.mock.On("GetTicketCardsByPhaseUuids", uuids)}
+}
+
+func (_c *Database_GetTicketCardsByPhaseUuids_Call) Run(run func(uuids []string)) *Database_GetTicketCardsByPhaseUuids_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTicketCardsByPhaseUuids_Call) Return(_a0 []db.TicketCard) *Database_GetTicketCardsByPhaseUuids_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTicketCardsByPhaseUuids_Call) RunAndReturn(run func([]string) []db.TicketCard) *Database_GetTicketCardsByPhaseUuids_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTicketComment provides a mock function with given fields: id
+func (_m *Database) GetTicketComment(id uint) db.TicketComment {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTicketComment")
+	}
+
+	var r0 db.TicketComment
+	if rf, ok := ret.Get(0).(func(uint) db.TicketComment); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.TicketComment)
+	}
+
+	return r0
+}
+
+// Database_GetTicketComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTicketComment'
+type Database_GetTicketComment_Call struct {
+	*mock.Call
+}
+
+// GetTicketComment is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetTicketComment(id interface{}) *Database_GetTicketComment_Call {
+	return &Database_GetTicketComment_Call{Call: _e.mock.On("GetTicketComment", id)}
+}
+
+func (_c *Database_GetTicketComment_Call) Run(run func(id uint)) *Database_GetTicketComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetTicketComment_Call) Return(_a0 db.TicketComment) *Database_GetTicketComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTicketComment_Call) RunAndReturn(run func(uint) db.TicketComment) *Database_GetTicketComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTicketComments provides a mock function with given fields: ticketId
+func (_m *Database) GetTicketComments(ticketId string) []db.TicketComment {
+	ret := _m.Called(ticketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTicketComments")
+	}
+
+	var r0 []db.TicketComment
+	if rf, ok := ret.Get(0).(func(string) []db.TicketComment); ok {
+		r0 = rf(ticketId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TicketComment)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTicketComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTicketComments'
+type Database_GetTicketComments_Call struct {
+	*mock.Call
+}
+
+// GetTicketComments is a helper method to define mock.On call
+//   - ticketId string
+func (_e *Database_Expecter) GetTicketComments(ticketId interface{}) *Database_GetTicketComments_Call {
+	return &Database_GetTicketComments_Call{Call: _e.mock.On("GetTicketComments", ticketId)}
+}
+
+func (_c *Database_GetTicketComments_Call) Run(run func(ticketId string)) *Database_GetTicketComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTicketComments_Call) Return(_a0 []db.TicketComment) *Database_GetTicketComments_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTicketComments_Call) RunAndReturn(run func(string) []db.TicketComment) *Database_GetTicketComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTicketLink provides a mock function with given fields: id
+func (_m *Database) GetTicketLink(id uint) db.TicketLink {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTicketLink")
+	}
+
+	var r0 db.TicketLink
+	if rf, ok := ret.Get(0).(func(uint) db.TicketLink); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.TicketLink)
+	}
+
+	return r0
+}
+
+// Database_GetTicketLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTicketLink'
+type Database_GetTicketLink_Call struct {
+	*mock.Call
+}
+
+// GetTicketLink is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetTicketLink(id interface{}) *Database_GetTicketLink_Call {
+	return &Database_GetTicketLink_Call{Call: _e.mock.On("GetTicketLink", id)}
+}
+
+func (_c *Database_GetTicketLink_Call) Run(run func(id uint)) *Database_GetTicketLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetTicketLink_Call) Return(_a0 db.TicketLink) *Database_GetTicketLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTicketLink_Call) RunAndReturn(run func(uint) db.TicketLink) *Database_GetTicketLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTicketLinks provides a mock function with given fields: ticketIds
+func (_m *Database) GetTicketLinks(ticketIds []string) []db.TicketLink {
+	ret := _m.Called(ticketIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTicketLinks")
+	}
+
+	var r0 []db.TicketLink
+	if rf, ok := ret.Get(0).(func([]string) []db.TicketLink); ok {
+		r0 = rf(ticketIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TicketLink)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTicketLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTicketLinks'
+type Database_GetTicketLinks_Call struct {
+	*mock.Call
+}
+
+// GetTicketLinks is a helper method to define mock.On call
+//   - ticketIds []string
+func (_e *Database_Expecter) GetTicketLinks(ticketIds interface{}) *Database_GetTicketLinks_Call {
+	return &Database_GetTicketLinks_Call{Call: _e.mock.On("GetTicketLinks", ticketIds)}
+}
+
+func (_c *Database_GetTicketLinks_Call) Run(run func(ticketIds []string)) *Database_GetTicketLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTicketLinks_Call) Return(_a0 []db.TicketLink) *Database_GetTicketLinks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTicketLinks_Call) RunAndReturn(run func([]string) []db.TicketLink) *Database_GetTicketLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTicketLinksByType provides a mock function with given fields: linkType
+func (_m *Database) GetTicketLinksByType(linkType db.TicketLinkType) []db.TicketLink {
+	ret := _m.Called(linkType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTicketLinksByType")
+	}
+
+	var r0 []db.TicketLink
+	if rf, ok := ret.Get(0).(func(db.TicketLinkType) []db.TicketLink); ok {
+		r0 = rf(linkType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TicketLink)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTicketLinksByType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTicketLinksByType'
+type Database_GetTicketLinksByType_Call struct {
+	*mock.Call
+}
+
+// GetTicketLinksByType is a helper method to define mock.On call
+//   - linkType db.TicketLinkType
+func (_e *Database_Expecter) GetTicketLinksByType(linkType interface{}) *Database_GetTicketLinksByType_Call {
+	return &Database_GetTicketLinksByType_Call{Call: _e.mock.On("GetTicketLinksByType", linkType)}
+}
+
+func (_c *Database_GetTicketLinksByType_Call) Run(run func(linkType db.TicketLinkType)) *Database_GetTicketLinksByType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.TicketLinkType))
+	})
+	return _c
+}
+
+func (_c *Database_GetTicketLinksByType_Call) Return(_a0 []db.TicketLink) *Database_GetTicketLinksByType_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTicketLinksByType_Call) RunAndReturn(run func(db.TicketLinkType) []db.TicketLink) *Database_GetTicketLinksByType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTicketRevision provides a mock function with given fields: ticketId, version
+func (_m *Database) GetTicketRevision(ticketId string, version int) db.TicketRevision {
+	ret := _m.Called(ticketId, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTicketRevision")
+	}
+
+	var r0 db.TicketRevision
+	if rf, ok := ret.Get(0).(func(string, int) db.TicketRevision); ok {
+		r0 = rf(ticketId, version)
+	} else {
+		r0 = ret.Get(0).(db.TicketRevision)
+	}
+
+	return r0
+}
+
+// Database_GetTicketRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTicketRevision'
+type Database_GetTicketRevision_Call struct {
+	*mock.Call
+}
+
+// GetTicketRevision is a helper method to define mock.On call
+//   - ticketId string
+//   - version int
+func (_e *Database_Expecter) GetTicketRevision(ticketId interface{}, version interface{}) *Database_GetTicketRevision_Call {
+	return &Database_GetTicketRevision_Call{Call: _e.mock.On("GetTicketRevision", ticketId, version)}
+}
+
+func (_c *Database_GetTicketRevision_Call) Run(run func(ticketId string, version int)) *Database_GetTicketRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetTicketRevision_Call) Return(_a0 db.TicketRevision) *Database_GetTicketRevision_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTicketRevision_Call) RunAndReturn(run func(string, int) db.TicketRevision) *Database_GetTicketRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTicketRevisions provides a mock function with given fields: ticketId
+func (_m *Database) GetTicketRevisions(ticketId string) []db.TicketRevision {
+	ret := _m.Called(ticketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTicketRevisions")
+	}
+
+	var r0 []db.TicketRevision
+	if rf, ok := ret.Get(0).(func(string) []db.TicketRevision); ok {
+		r0 = rf(ticketId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TicketRevision)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTicketRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTicketRevisions'
+type Database_GetTicketRevisions_Call struct {
+	*mock.Call
+}
+
+// GetTicketRevisions is a helper method to define mock.On call
+//   - ticketId string
+func (_e *Database_Expecter) GetTicketRevisions(ticketId interface{}) *Database_GetTicketRevisions_Call {
+	return &Database_GetTicketRevisions_Call{Call: _e.mock.On("GetTicketRevisions", ticketId)}
+}
+
+func (_c *Database_GetTicketRevisions_Call) Run(run func(ticketId string)) *Database_GetTicketRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTicketRevisions_Call) Return(_a0 []db.TicketRevision) *Database_GetTicketRevisions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTicketRevisions_Call) RunAndReturn(run func(string) []db.TicketRevision) *Database_GetTicketRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribe provides a mock function with given fields: uuid
+func (_m *Database) GetTribe(uuid string) db.Tribe {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribe")
+	}
+
+	var r0 db.Tribe
+	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Tribe)
+	}
+
+	return r0
+}
+
+// Database_GetTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribe'
+type Database_GetTribe_Call struct {
+	*mock.Call
+}
+
+// GetTribe is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetTribe(uuid interface{}) *Database_GetTribe_Call {
+	return &Database_GetTribe_Call{Call: _e.mock.On("GetTribe", uuid)}
+}
+
+func (_c *Database_GetTribe_Call) Run(run func(uuid string)) *Database_GetTribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribe_Call) Return(_a0 db.Tribe) *Database_GetTribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribe_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetTribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeBan provides a mock function with given fields: tribeUuid, pubkey
+func (_m *Database) GetTribeBan(tribeUuid string, pubkey string) db.TribeBan {
+	ret := _m.Called(tribeUuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeBan")
+	}
+
+	var r0 db.TribeBan
+	if rf, ok := ret.Get(0).(func(string, string) db.TribeBan); ok {
+		r0 = rf(tribeUuid, pubkey)
+	} else {
+		r0 = ret.Get(0).(db.TribeBan)
+	}
+
+	return r0
+}
+
+// Database_GetTribeBan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeBan'
+type Database_GetTribeBan_Call struct {
+	*mock.Call
+}
+
+// GetTribeBan is a helper method to define mock.On call
+//   - tribeUuid string
+//   - pubkey string
+func (_e *Database_Expecter) GetTribeBan(tribeUuid interface{}, pubkey interface{}) *Database_GetTribeBan_Call {
+	return &Database_GetTribeBan_Call{Call: _e.mock.On("GetTribeBan", tribeUuid, pubkey)}
+}
+
+func (_c *Database_GetTribeBan_Call) Run(run func(tribeUuid string, pubkey string)) *Database_GetTribeBan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeBan_Call) Return(_a0 db.TribeBan) *Database_GetTribeBan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeBan_Call) RunAndReturn(run func(string, string) db.TribeBan) *Database_GetTribeBan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeBans provides a mock function with given fields: tribeUuid
+func (_m *Database) GetTribeBans(tribeUuid string) []db.TribeBan {
+	ret := _m.Called(tribeUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeBans")
+	}
+
+	var r0 []db.TribeBan
+	if rf, ok := ret.Get(0).(func(string) []db.TribeBan); ok {
+		r0 = rf(tribeUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeBan)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribeBans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeBans'
+type Database_GetTribeBans_Call struct {
+	*mock.Call
+}
+
+// GetTribeBans is a helper method to define mock.On call
+//   - tribeUuid string
+func (_e *Database_Expecter) GetTribeBans(tribeUuid interface{}) *Database_GetTribeBans_Call {
+	return &Database_GetTribeBans_Call{Call: _e.mock.On("GetTribeBans", tribeUuid)}
+}
+
+func (_c *Database_GetTribeBans_Call) Run(run func(tribeUuid string)) *Database_GetTribeBans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeBans_Call) Return(_a0 []db.TribeBan) *Database_GetTribeBans_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeBans_Call) RunAndReturn(run func(string) []db.TribeBan) *Database_GetTribeBans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeByIdAndPubkey provides a mock function with given fields: uuid, pubkey
+func (_m *Database) GetTribeByIdAndPubkey(uuid string, pubkey string) db.Tribe {
+	ret := _m.Called(uuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeByIdAndPubkey")
+	}
+
+	var r0 db.Tribe
+	if rf, ok := ret.Get(0).(func(string, string) db.Tribe); ok {
+		r0 = rf(uuid, pubkey)
+	} else {
+		r0 = ret.Get(0).(db.Tribe)
+	}
+
+	return r0
+}
+
+// Database_GetTribeByIdAndPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeByIdAndPubkey'
+type Database_GetTribeByIdAndPubkey_Call struct {
+	*mock.Call
+}
+
+// GetTribeByIdAndPubkey is a helper method to define mock.On call
+//   - uuid string
+//   - pubkey string
+func (_e *Database_Expecter) GetTribeByIdAndPubkey(uuid interface{}, pubkey interface{}) *Database_GetTribeByIdAndPubkey_Call {
+	return &Database_GetTribeByIdAndPubkey_Call{Call: _e.mock.On("GetTribeByIdAndPubkey", uuid, pubkey)}
+}
+
+func (_c *Database_GetTribeByIdAndPubkey_Call) Run(run func(uuid string, pubkey string)) *Database_GetTribeByIdAndPubkey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeByIdAndPubkey_Call) Return(_a0 db.Tribe) *Database_GetTribeByIdAndPubkey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeByIdAndPubkey_Call) RunAndReturn(run func(string, string) db.Tribe) *Database_GetTribeByIdAndPubkey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeByUniqueName provides a mock function with given fields: un
+func (_m *Database) GetTribeByUniqueName(un string) db.Tribe {
+	ret := _m.Called(un)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeByUniqueName")
+	}
+
+	var r0 db.Tribe
+	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
+		r0 = rf(un)
+	} else {
+		r0 = ret.Get(0).(db.Tribe)
+	}
+
+	return r0
+}
+
+// Database_GetTribeByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeByUniqueName'
+type Database_GetTribeByUniqueName_Call struct {
+	*mock.Call
+}
+
+// GetTribeByUniqueName is a helper method to define mock.On call
+//   - un string
+func (_e *Database_Expecter) GetTribeByUniqueName(un interface{}) *Database_GetTribeByUniqueName_Call {
+	return &Database_GetTribeByUniqueName_Call{Call: _e.mock.On("GetTribeByUniqueName", un)}
+}
+
+func (_c *Database_GetTribeByUniqueName_Call) Run(run func(un string)) *Database_GetTribeByUniqueName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeByUniqueName_Call) Return(_a0 db.Tribe) *Database_GetTribeByUniqueName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeByUniqueName_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetTribeByUniqueName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeInvite provides a mock function with given fields: code
+func (_m *Database) GetTribeInvite(code string) db.TribeInvite {
+	ret := _m.Called(code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeInvite")
+	}
+
+	var r0 db.TribeInvite
+	if rf, ok := ret.Get(0).(func(string) db.TribeInvite); ok {
+		r0 = rf(code)
+	} else {
+		r0 = ret.Get(0).(db.TribeInvite)
+	}
+
+	return r0
+}
+
+// Database_GetTribeInvite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeInvite'
+type Database_GetTribeInvite_Call struct {
+	*mock.Call
+}
+
+// GetTribeInvite is a helper method to define mock.On call
+//   - code string
+func (_e *Database_Expecter) GetTribeInvite(code interface{}) *Database_GetTribeInvite_Call {
+	return &Database_GetTribeInvite_Call{Call: _e.mock.On("GetTribeInvite", code)}
+}
+
+func (_c *Database_GetTribeInvite_Call) Run(run func(code string)) *Database_GetTribeInvite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeInvite_Call) Return(_a0 db.TribeInvite) *Database_GetTribeInvite_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeInvite_Call) RunAndReturn(run func(string) db.TribeInvite) *Database_GetTribeInvite_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeInviteRedemptions provides a mock function with given fields: inviteId
+func (_m *Database) GetTribeInviteRedemptions(inviteId uint) []db.TribeInviteRedemption {
+	ret := _m.Called(inviteId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeInviteRedemptions")
+	}
+
+	var r0 []db.TribeInviteRedemption
+	if rf, ok := ret.Get(0).(func(uint) []db.TribeInviteRedemption); ok {
+		r0 = rf(inviteId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeInviteRedemption)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribeInviteRedemptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeInviteRedemptions'
+type Database_GetTribeInviteRedemptions_Call struct {
+	*mock.Call
+}
+
+// GetTribeInviteRedemptions is a helper method to define mock.On call
+//   - inviteId uint
+func (_e *Database_Expecter) GetTribeInviteRedemptions(inviteId interface{}) *Database_GetTribeInviteRedemptions_Call {
+	return &Database_GetTribeInviteRedemptions_Call{Call: _e.mock.On("GetTribeInviteRedemptions", inviteId)}
+}
+
+func (_c *Database_GetTribeInviteRedemptions_Call) Run(run func(inviteId uint)) *Database_GetTribeInviteRedemptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeInviteRedemptions_Call) Return(_a0 []db.TribeInviteRedemption) *Database_GetTribeInviteRedemptions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeInviteRedemptions_Call) RunAndReturn(run func(uint) []db.TribeInviteRedemption) *Database_GetTribeInviteRedemptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeInvites provides a mock function with given fields: tribeUuid
+func (_m *Database) GetTribeInvites(tribeUuid string) []db.TribeInvite {
+	ret := _m.Called(tribeUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeInvites")
+	}
+
+	var r0 []db.TribeInvite
+	if rf, ok := ret.Get(0).(func(string) []db.TribeInvite); ok {
+		r0 = rf(tribeUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeInvite)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribeInvites_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeInvites'
+type Database_GetTribeInvites_Call struct {
+	*mock.Call
+}
+
+// GetTribeInvites is a helper method to define mock.On call
+//   - tribeUuid string
+func (_e *Database_Expecter) GetTribeInvites(tribeUuid interface{}) *Database_GetTribeInvites_Call {
+	return &Database_GetTribeInvites_Call{Call: _e.mock.On("GetTribeInvites", tribeUuid)}
+}
+
+func (_c *Database_GetTribeInvites_Call) Run(run func(tribeUuid string)) *Database_GetTribeInvites_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeInvites_Call) Return(_a0 []db.TribeInvite) *Database_GetTribeInvites_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeInvites_Call) RunAndReturn(run func(string) []db.TribeInvite) *Database_GetTribeInvites_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeMember provides a mock function with given fields: tribeUuid, pubkey
+func (_m *Database) GetTribeMember(tribeUuid string, pubkey string) db.TribeMember {
+	ret := _m.Called(tribeUuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeMember")
+	}
+
+	var r0 db.TribeMember
+	if rf, ok := ret.Get(0).(func(string, string) db.TribeMember); ok {
+		r0 = rf(tribeUuid, pubkey)
+	} else {
+		r0 = ret.Get(0).(db.TribeMember)
+	}
+
+	return r0
+}
+
+// Database_GetTribeMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeMember'
+type Database_GetTribeMember_Call struct {
+	*mock.Call
+}
+
+// GetTribeMember is a helper method to define mock.On call
+//   - tribeUuid string
+//   - pubkey string
+func (_e *Database_Expecter) GetTribeMember(tribeUuid interface{}, pubkey interface{}) *Database_GetTribeMember_Call {
+	return &Database_GetTribeMember_Call{Call: _e.mock.On("GetTribeMember", tribeUuid, pubkey)}
+}
+
+func (_c *Database_GetTribeMember_Call) Run(run func(tribeUuid string, pubkey string)) *Database_GetTribeMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeMember_Call) Return(_a0 db.TribeMember) *Database_GetTribeMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeMember_Call) RunAndReturn(run func(string, string) db.TribeMember) *Database_GetTribeMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeMembers provides a mock function with given fields: tribeUuid
+func (_m *Database) GetTribeMembers(tribeUuid string) []db.TribeMember {
+	ret := _m.Called(tribeUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeMembers")
+	}
+
+	var r0 []db.TribeMember
+	if rf, ok := ret.Get(0).(func(string) []db.TribeMember); ok {
+		r0 = rf(tribeUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeMember)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribeMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeMembers'
+type Database_GetTribeMembers_Call struct {
+	*mock.Call
+}
+
+// GetTribeMembers is a helper method to define mock.On call
+//   - tribeUuid string
+func (_e *Database_Expecter) GetTribeMembers(tribeUuid interface{}) *Database_GetTribeMembers_Call {
+	return &Database_GetTribeMembers_Call{Call: _e.mock.On("GetTribeMembers", tribeUuid)}
+}
+
+func (_c *Database_GetTribeMembers_Call) Run(run func(tribeUuid string)) *Database_GetTribeMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeMembers_Call) Return(_a0 []db.TribeMember) *Database_GetTribeMembers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeMembers_Call) RunAndReturn(run func(string) []db.TribeMember) *Database_GetTribeMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeMembersBefore provides a mock function with given fields: tribeUuid, before
+func (_m *Database) GetTribeMembersBefore(tribeUuid string, before time.Time) int64 {
+	ret := _m.Called(tribeUuid, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeMembersBefore")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, time.Time) int64); ok {
+		r0 = rf(tribeUuid, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetTribeMembersBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeMembersBefore'
+type Database_GetTribeMembersBefore_Call struct {
+	*mock.Call
+}
+
+// GetTribeMembersBefore is a helper method to define mock.On call
+//   - tribeUuid string
+//   - before time.Time
+func (_e *Database_Expecter) GetTribeMembersBefore(tribeUuid interface{}, before interface{}) *Database_GetTribeMembersBefore_Call {
+	return &Database_GetTribeMembersBefore_Call{Call: _e.mock.On("GetTribeMembersBefore", tribeUuid, before)}
+}
+
+func (_c *Database_GetTribeMembersBefore_Call) Run(run func(tribeUuid string, before time.Time)) *Database_GetTribeMembersBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeMembersBefore_Call) Return(_a0 int64) *Database_GetTribeMembersBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeMembersBefore_Call) RunAndReturn(run func(string, time.Time) int64) *Database_GetTribeMembersBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeRankingSettings provides a mock function with given fields:
+func (_m *Database) GetTribeRankingSettings() db.TribeRankingSettings {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeRankingSettings")
+	}
+
+	var r0 db.TribeRankingSettings
+	if rf, ok := ret.Get(0).(func() db.TribeRankingSettings); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(db.TribeRankingSettings)
+	}
+
+	return r0
+}
+
+// Database_GetTribeRankingSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeRankingSettings'
+type Database_GetTribeRankingSettings_Call struct {
+	*mock.Call
+}
+
+// GetTribeRankingSettings is a helper method to define mock.On call
+func (_e *Database_Expecter) GetTribeRankingSettings() *Database_GetTribeRankingSettings_Call {
+	return &Database_GetTribeRankingSettings_Call{Call: _e.mock.On("GetTribeRankingSettings")}
+}
+
+func (_c *Database_GetTribeRankingSettings_Call) Run(run func()) *Database_GetTribeRankingSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeRankingSettings_Call) Return(_a0 db.TribeRankingSettings) *Database_GetTribeRankingSettings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeRankingSettings_Call) RunAndReturn(run func() db.TribeRankingSettings) *Database_GetTribeRankingSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeSeriesValues provides a mock function with given fields: tribeUuid, from, to, interval
+func (_m *Database) GetTribeSeriesValues(tribeUuid string, from time.Time, to time.Time, interval db.MetricsInterval) []db.MetricsSeriesValue {
+	ret := _m.Called(tribeUuid, from, to, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeSeriesValues")
+	}
+
+	var r0 []db.MetricsSeriesValue
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time, db.MetricsInterval) []db.MetricsSeriesValue); ok {
+		r0 = rf(tribeUuid, from, to, interval)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.MetricsSeriesValue)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribeSeriesValues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeSeriesValues'
+type Database_GetTribeSeriesValues_Call struct {
+	*mock.Call
+}
+
+// GetTribeSeriesValues is a helper method to define mock.On call
+//   - tribeUuid string
+//   - from time.Time
+//   - to time.Time
+//   - interval db.MetricsInterval
+func (_e *Database_Expecter) GetTribeSeriesValues(tribeUuid interface{}, from interface{}, to interface{}, interval interface{}) *Database_GetTribeSeriesValues_Call {
+	return &Database_GetTribeSeriesValues_Call{Call: _e.mock.On("GetTribeSeriesValues", tribeUuid, from, to, interval)}
+}
+
+func (_c *Database_GetTribeSeriesValues_Call) Run(run func(tribeUuid string, from time.Time, to time.Time, interval db.MetricsInterval)) *Database_GetTribeSeriesValues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time), args[2].(time.Time), args[3].(db.MetricsInterval))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeSeriesValues_Call) Return(_a0 []db.MetricsSeriesValue) *Database_GetTribeSeriesValues_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeSeriesValues_Call) RunAndReturn(run func(string, time.Time, time.Time, db.MetricsInterval) []db.MetricsSeriesValue) *Database_GetTribeSeriesValues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeVerification provides a mock function with given fields: uuid
+func (_m *Database) GetTribeVerification(uuid string) db.TribeVerification {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeVerification")
+	}
+
+	var r0 db.TribeVerification
+	if rf, ok := ret.Get(0).(func(string) db.TribeVerification); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.TribeVerification)
+	}
+
+	return r0
+}
+
+// Database_GetTribeVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeVerification'
+type Database_GetTribeVerification_Call struct {
+	*mock.Call
+}
+
+// GetTribeVerification is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetTribeVerification(uuid interface{}) *Database_GetTribeVerification_Call {
+	return &Database_GetTribeVerification_Call{Call: _e.mock.On("GetTribeVerification", uuid)}
+}
+
+func (_c *Database_GetTribeVerification_Call) Run(run func(uuid string)) *Database_GetTribeVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeVerification_Call) Return(_a0 db.TribeVerification) *Database_GetTribeVerification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeVerification_Call) RunAndReturn(run func(string) db.TribeVerification) *Database_GetTribeVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeVerifications provides a mock function with given fields: tribeUuid
+func (_m *Database) GetTribeVerifications(tribeUuid string) []db.TribeVerification {
+	ret := _m.Called(tribeUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeVerifications")
+	}
+
+	var r0 []db.TribeVerification
+	if rf, ok := ret.Get(0).(func(string) []db.TribeVerification); ok {
+		r0 = rf(tribeUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeVerification)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribeVerifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeVerifications'
+type Database_GetTribeVerifications_Call struct {
+	*mock.Call
+}
+
+// GetTribeVerifications is a helper method to define mock.On call
+//   - tribeUuid string
+func (_e *Database_Expecter) GetTribeVerifications(tribeUuid interface{}) *Database_GetTribeVerifications_Call {
+	return &Database_GetTribeVerifications_Call{Call: _e.mock.On("GetTribeVerifications", tribeUuid)}
+}
+
+func (_c *Database_GetTribeVerifications_Call) Run(run func(tribeUuid string)) *Database_GetTribeVerifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeVerifications_Call) Return(_a0 []db.TribeVerification) *Database_GetTribeVerifications_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeVerifications_Call) RunAndReturn(run func(string) []db.TribeVerification) *Database_GetTribeVerifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribeVerificationsByStatus provides a mock function with given fields: status
+func (_m *Database) GetTribeVerificationsByStatus(status db.TribeVerificationStatus) []db.TribeVerification {
+	ret := _m.Called(status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribeVerificationsByStatus")
+	}
+
+	var r0 []db.TribeVerification
+	if rf, ok := ret.Get(0).(func(db.TribeVerificationStatus) []db.TribeVerification); ok {
+		r0 = rf(status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeVerification)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribeVerificationsByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeVerificationsByStatus'
+type Database_GetTribeVerificationsByStatus_Call struct {
+	*mock.Call
+}
+
+// GetTribeVerificationsByStatus is a helper method to define mock.On call
+//   - status db.TribeVerificationStatus
+func (_e *Database_Expecter) GetTribeVerificationsByStatus(status interface{}) *Database_GetTribeVerificationsByStatus_Call {
+	return &Database_GetTribeVerificationsByStatus_Call{Call: _e.mock.On("GetTribeVerificationsByStatus", status)}
+}
+
+func (_c *Database_GetTribeVerificationsByStatus_Call) Run(run func(status db.TribeVerificationStatus)) *Database_GetTribeVerificationsByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.TribeVerificationStatus))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribeVerificationsByStatus_Call) Return(_a0 []db.TribeVerification) *Database_GetTribeVerificationsByStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribeVerificationsByStatus_Call) RunAndReturn(run func(db.TribeVerificationStatus) []db.TribeVerification) *Database_GetTribeVerificationsByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribesByAppUrl provides a mock function with given fields: aurl
+func (_m *Database) GetTribesByAppUrl(aurl string) []db.Tribe {
+	ret := _m.Called(aurl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribesByAppUrl")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
+		r0 = rf(aurl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribesByAppUrl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByAppUrl'
+type Database_GetTribesByAppUrl_Call struct {
+	*mock.Call
+}
+
+// GetTribesByAppUrl is a helper method to define mock.On call
+//   - aurl string
+func (_e *Database_Expecter) GetTribesByAppUrl(aurl interface{}) *Database_GetTribesByAppUrl_Call {
+	return &Database_GetTribesByAppUrl_Call{Call: _e.mock.On("GetTribesByAppUrl", aurl)}
+}
+
+func (_c *Database_GetTribesByAppUrl_Call) Run(run func(aurl string)) *Database_GetTribesByAppUrl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribesByAppUrl_Call) Return(_a0 []db.Tribe) *Database_GetTribesByAppUrl_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribesByAppUrl_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetTribesByAppUrl_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribesByOwner provides a mock function with given fields: pubkey
+func (_m *Database) GetTribesByOwner(pubkey string) []db.Tribe {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribesByOwner")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribesByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByOwner'
+type Database_GetTribesByOwner_Call struct {
+	*mock.Call
+}
+
+// GetTribesByOwner is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetTribesByOwner(pubkey interface{}) *Database_GetTribesByOwner_Call {
+	return &Database_GetTribesByOwner_Call{Call: _e.mock.On("GetTribesByOwner", pubkey)}
+}
+
+func (_c *Database_GetTribesByOwner_Call) Run(run func(pubkey string)) *Database_GetTribesByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribesByOwner_Call) Return(_a0 []db.Tribe) *Database_GetTribesByOwner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribesByOwner_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetTribesByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribesByUuids provides a mock function with given fields: uuids
+func (_m *Database) GetTribesByUuids(uuids []string) []db.Tribe {
+	ret := _m.Called(uuids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribesByUuids")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func([]string) []db.Tribe); ok {
+		r0 = rf(uuids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribesByUuids_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByUuids'
+type Database_GetTribesByUuids_Call struct {
+	*mock.Call
+}
+
+// GetTribesByUuids is a helper method to define mock.On call
+//   - uuids []string
+func (_e *Database_Expecter) GetTribesByUuids(uuids interface{}) *Database_GetTribesByUuids_Call {
+	return &Database_GetTribesByUuids_Call{Call: _e.mock.On("GetTribesByUuids", uuids)}
+}
+
+func (_c *Database_GetTribesByUuids_Call) Run(run func(uuids []string)) *Database_GetTribesByUuids_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribesByUuids_Call) Return(_a0 []db.Tribe) *Database_GetTribesByUuids_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribesByUuids_Call) RunAndReturn(run func([]string) []db.Tribe) *Database_GetTribesByUuids_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribesTotal provides a mock function with given fields:
+func (_m *Database) GetTribesTotal() int64 {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribesTotal")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetTribesTotal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesTotal'
+type Database_GetTribesTotal_Call struct {
+	*mock.Call
+}
+
+// GetTribesTotal is a helper method to define mock.On call
+func (_e *Database_Expecter) GetTribesTotal() *Database_GetTribesTotal_Call {
+	return &Database_GetTribesTotal_Call{Call: _e.mock.On("GetTribesTotal")}
+}
+
+func (_c *Database_GetTribesTotal_Call) Run(run func()) *Database_GetTribesTotal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetTribesTotal_Call) Return(_a0 int64) *Database_GetTribesTotal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribesTotal_Call) RunAndReturn(run func() int64) *Database_GetTribesTotal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTribesUpdatedSince provides a mock function with given fields: since, r
+func (_m *Database) GetTribesUpdatedSince(since time.Time, r *http.Request) []db.Tribe {
+	ret := _m.Called(since, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTribesUpdatedSince")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(time.Time, *http.Request) []db.Tribe); ok {
+		r0 = rf(since, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTribesUpdatedSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesUpdatedSince'
+type Database_GetTribesUpdatedSince_Call struct {
+	*mock.Call
+}
+
+// GetTribesUpdatedSince is a helper method to define mock.On call
+//   - since time.Time
+//   - r *http.Request
+func (_e *Database_Expecter) GetTribesUpdatedSince(since interface{}, r interface{}) *Database_GetTribesUpdatedSince_Call {
+	return &Database_GetTribesUpdatedSince_Call{Call: _e.mock.On("GetTribesUpdatedSince", since, r)}
+}
+
+func (_c *Database_GetTribesUpdatedSince_Call) Run(run func(since time.Time, r *http.Request)) *Database_GetTribesUpdatedSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetTribesUpdatedSince_Call) Return(_a0 []db.Tribe) *Database_GetTribesUpdatedSince_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTribesUpdatedSince_Call) RunAndReturn(run func(time.Time, *http.Request) []db.Tribe) *Database_GetTribesUpdatedSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTriggeredWorkflows provides a mock function with given fields: workspaceUuid, trigger
+func (_m *Database) GetTriggeredWorkflows(workspaceUuid string, trigger string) []db.Workflow {
+	ret := _m.Called(workspaceUuid, trigger)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTriggeredWorkflows")
+	}
+
+	var r0 []db.Workflow
+	if rf, ok := ret.Get(0).(func(string, string) []db.Workflow); ok {
+		r0 = rf(workspaceUuid, trigger)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Workflow)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetTriggeredWorkflows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTriggeredWorkflows'
+type Database_GetTriggeredWorkflows_Call struct {
+	*mock.Call
+}
+
+// GetTriggeredWorkflows is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - trigger string
+func (_e *Database_Expecter) GetTriggeredWorkflows(workspaceUuid interface{}, trigger interface{}) *Database_GetTriggeredWorkflows_Call {
+	return &Database_GetTriggeredWorkflows_Call{Call: _e.mock.On("GetTriggeredWorkflows", workspaceUuid, trigger)}
+}
+
+func (_c *Database_GetTriggeredWorkflows_Call) Run(run func(workspaceUuid string, trigger string)) *Database_GetTriggeredWorkflows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTriggeredWorkflows_Call) Return(_a0 []db.Workflow) *Database_GetTriggeredWorkflows_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetTriggeredWorkflows_Call) RunAndReturn(run func(string, string) []db.Workflow) *Database_GetTriggeredWorkflows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnconfirmedGithub provides a mock function with given fields:
+func (_m *Database) GetUnconfirmedGithub() []db.Person {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnconfirmedGithub")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func() []db.Person); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetUnconfirmedGithub_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedGithub'
+type Database_GetUnconfirmedGithub_Call struct {
+	*mock.Call
+}
+
+// GetUnconfirmedGithub is a helper method to define mock.On call
+func (_e *Database_Expecter) GetUnconfirmedGithub() *Database_GetUnconfirmedGithub_Call {
+	return &Database_GetUnconfirmedGithub_Call{Call: _e.mock.On("GetUnconfirmedGithub")}
+}
+
+func (_c *Database_GetUnconfirmedGithub_Call) Run(run func()) *Database_GetUnconfirmedGithub_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetUnconfirmedGithub_Call) Return(_a0 []db.Person) *Database_GetUnconfirmedGithub_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetUnconfirmedGithub_Call) RunAndReturn(run func() []db.Person) *Database_GetUnconfirmedGithub_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnconfirmedTwitter provides a mock function with given fields:
+func (_m *Database) GetUnconfirmedTwitter() []db.Person {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnconfirmedTwitter")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func() []db.Person); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetUnconfirmedTwitter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedTwitter'
+type Database_GetUnconfirmedTwitter_Call struct {
+	*mock.Call
+}
+
+// GetUnconfirmedTwitter is a helper method to define mock.On call
+func (_e *Database_Expecter) GetUnconfirmedTwitter() *Database_GetUnconfirmedTwitter_Call {
+	return &Database_GetUnconfirmedTwitter_Call{Call: _e.mock.On("GetUnconfirmedTwitter")}
+}
+
+func (_c *Database_GetUnconfirmedTwitter_Call) Run(run func()) *Database_GetUnconfirmedTwitter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetUnconfirmedTwitter_Call) Return(_a0 []db.Person) *Database_GetUnconfirmedTwitter_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetUnconfirmedTwitter_Call) RunAndReturn(run func() []db.Person) *Database_GetUnconfirmedTwitter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnsettledInvoices provides a mock function with given fields:
+func (_m *Database) GetUnsettledInvoices() []db.NewInvoiceList {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnsettledInvoices")
+	}
+
+	var r0 []db.NewInvoiceList
+	if rf, ok := ret.Get(0).(func() []db.NewInvoiceList); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewInvoiceList)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetUnsettledInvoices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnsettledInvoices'
+type Database_GetUnsettledInvoices_Call struct {
+	*mock.Call
+}
+
+// GetUnsettledInvoices is a helper method to define mock.On call
+func (_e *Database_Expecter) GetUnsettledInvoices() *Database_GetUnsettledInvoices_Call {
+	return &Database_GetUnsettledInvoices_Call{Call: _e.mock.On("GetUnsettledInvoices")}
+}
+
+func (_c *Database_GetUnsettledInvoices_Call) Run(run func()) *Database_GetUnsettledInvoices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetUnsettledInvoices_Call) Return(_a0 []db.NewInvoiceList) *Database_GetUnsettledInvoices_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetUnsettledInvoices_Call) RunAndReturn(run func() []db.NewInvoiceList) *Database_GetUnsettledInvoices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserAssignedWorkspaces provides a mock function with given fields: pubkey
+func (_m *Database) GetUserAssignedWorkspaces(pubkey string) []db.WorkspaceUsers {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserAssignedWorkspaces")
+	}
+
+	var r0 []db.WorkspaceUsers
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceUsers); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceUsers)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetUserAssignedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAssignedWorkspaces'
+type Database_GetUserAssignedWorkspaces_Call struct {
+	*mock.Call
+}
+
+// GetUserAssignedWorkspaces is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetUserAssignedWorkspaces(pubkey interface{}) *Database_GetUserAssignedWorkspaces_Call {
+	return &Database_GetUserAssignedWorkspaces_Call{Call: _e.mock.On("GetUserAssignedWorkspaces", pubkey)}
+}
+
+func (_c *Database_GetUserAssignedWorkspaces_Call) Run(run func(pubkey string)) *Database_GetUserAssignedWorkspaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetUserAssignedWorkspaces_Call) Return(_a0 []db.WorkspaceUsers) *Database_GetUserAssignedWorkspaces_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetUserAssignedWorkspaces_Call) RunAndReturn(run func(string) []db.WorkspaceUsers) *Database_GetUserAssignedWorkspaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserBountiesCount provides a mock function with given fields: personKey, tabType
+func (_m *Database) GetUserBountiesCount(personKey string, tabType string) int64 {
+	ret := _m.Called(personKey, tabType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserBountiesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
+		r0 = rf(personKey, tabType)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetUserBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserBountiesCount'
+type Database_GetUserBountiesCount_Call struct {
+	*mock.Call
+}
+
+// GetUserBountiesCount is a helper method to define mock.On call
+//   - personKey string
+//   - tabType string
+func (_e *Database_Expecter) GetUserBountiesCount(personKey interface{}, tabType interface{}) *Database_GetUserBountiesCount_Call {
+	return &Database_GetUserBountiesCount_Call{Call: _e.mock.On("GetUserBountiesCount", personKey, tabType)}
+}
+
+func (_c *Database_GetUserBountiesCount_Call) Run(run func(personKey string, tabType string)) *Database_GetUserBountiesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetUserBountiesCount_Call) Return(_a0 int64) *Database_GetUserBountiesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetUserBountiesCount_Call) RunAndReturn(run func(string, string) int64) *Database_GetUserBountiesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserCreatedWorkspaces provides a mock function with given fields: pubkey
+func (_m *Database) GetUserCreatedWorkspaces(pubkey string) []db.Workspace {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserCreatedWorkspaces")
+	}
+
+	var r0 []db.Workspace
+	if rf, ok := ret.Get(0).(func(string) []db.Workspace); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Workspace)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetUserCreatedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserCreatedWorkspaces'
+type Database_GetUserCreatedWorkspaces_Call struct {
+	*mock.Call
+}
+
+// GetUserCreatedWorkspaces is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetUserCreatedWorkspaces(pubkey interface{}) *Database_GetUserCreatedWorkspaces_Call {
+	return &Database_GetUserCreatedWorkspaces_Call{Call: _e.mock.On("GetUserCreatedWorkspaces", pubkey)}
+}
+
+func (_c *Database_GetUserCreatedWorkspaces_Call) Run(run func(pubkey string)) *Database_GetUserCreatedWorkspaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetUserCreatedWorkspaces_Call) Return(_a0 []db.Workspace) *Database_GetUserCreatedWorkspaces_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetUserCreatedWorkspaces_Call) RunAndReturn(run func(string) []db.Workspace) *Database_GetUserCreatedWorkspaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserInvoiceData provides a mock function with given fields: payment_request
+func (_m *Database) GetUserInvoiceData(payment_request string) db.UserInvoiceData {
+	ret := _m.Called(payment_request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserInvoiceData")
+	}
+
+	var r0 db.UserInvoiceData
+	if rf, ok := ret.Get(0).(func(string) db.UserInvoiceData); ok {
+		r0 = rf(payment_request)
+	} else {
+		r0 = ret.Get(0).(db.UserInvoiceData)
+	}
+
+	return r0
+}
+
+// Database_GetUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserInvoiceData'
+type Database_GetUserInvoiceData_Call struct {
+	*mock.Call
+}
+
+// GetUserInvoiceData is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) GetUserInvoiceData(payment_request interface{}) *Database_GetUserInvoiceData_Call {
+	return &Database_GetUserInvoiceData_Call{Call: _e.mock.On("GetUserInvoiceData", payment_request)}
+}
+
+func (_c *Database_GetUserInvoiceData_Call) Run(run func(payment_request string)) *Database_GetUserInvoiceData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_GetUserInvoiceData_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetUserInvoiceData_Call) RunAndReturn(run func(string) db.UserInvoiceData) *Database_GetUserInvoiceData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserRoles provides a mock function with given fields: uuid, pubkey
+func (_m *Database) GetUserRoles(uuid string, pubkey string) []db.WorkspaceUserRoles {
+	ret := _m.Called(uuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserRoles")
+	}
+
+	var r0 []db.WorkspaceUserRoles
+	if rf, ok := ret.Get(0).(func(string, string) []db.WorkspaceUserRoles); ok {
+		r0 = rf(uuid, pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceUserRoles)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserRoles'
+type Database_GetUserRoles_Call struct {
+	*mock.Call
+}
+
+// GetUserRoles is a helper method to define mock.On call
+//   - uuid string
+//   - pubkey string
+func (_e *Database_Expecter) GetUserRoles(uuid interface{}, pubkey interface{}) *Database_GetUserRoles_Call {
+	return &Database_GetUserRoles_Call{Call: _e.mock.On("GetUserRoles", uuid, pubkey)}
+}
+
+func (_c *Database_GetUserRoles_Call) Run(run func(uuid string, pubkey string)) *Database_GetUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetUserRoles_Call) Return(_a0 []db.WorkspaceUserRoles) *Database_GetUserRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetUserRoles_Call) RunAndReturn(run func(string, string) []db.WorkspaceUserRoles) *Database_GetUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookDeliveries provides a mock function with given fields: source, sourceUuid, limit
+func (_m *Database) GetWebhookDeliveries(source string, sourceUuid string, limit int) []db.WebhookDelivery {
+	ret := _m.Called(source, sourceUuid, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDeliveries")
+	}
+
+	var r0 []db.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(string, string, int) []db.WebhookDelivery); ok {
+		r0 = rf(source, sourceUuid, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WebhookDelivery)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDeliveries'
+type Database_GetWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetWebhookDeliveries is a helper method to define mock.On call
+//   - source string
+//   - sourceUuid string
+//   - limit int
+func (_e *Database_Expecter) GetWebhookDeliveries(source interface{}, sourceUuid interface{}, limit interface{}) *Database_GetWebhookDeliveries_Call {
+	return &Database_GetWebhookDeliveries_Call{Call: _e.mock.On("GetWebhookDeliveries", source, sourceUuid, limit)}
+}
+
+func (_c *Database_GetWebhookDeliveries_Call) Run(run func(source string, sourceUuid string, limit int)) *Database_GetWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetWebhookDeliveries_Call) Return(_a0 []db.WebhookDelivery) *Database_GetWebhookDeliveries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWebhookDeliveries_Call) RunAndReturn(run func(string, string, int) []db.WebhookDelivery) *Database_GetWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetWorkflowByUuid(uuid string) db.Workflow {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowByUuid")
+	}
+
+	var r0 db.Workflow
+	if rf, ok := ret.Get(0).(func(string) db.Workflow); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Workflow)
+	}
+
+	return r0
+}
+
+// Database_GetWorkflowByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowByUuid'
+type Database_GetWorkflowByUuid_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkflowByUuid(uuid interface{}) *Database_GetWorkflowByUuid_Call {
+	return &Database_GetWorkflowByUuid_Call{Call: _e.mock.On("GetWorkflowByUuid", uuid)}
+}
+
+func (_c *Database_GetWorkflowByUuid_Call) Run(run func(uuid string)) *Database_GetWorkflowByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkflowByUuid_Call) Return(_a0 db.Workflow) *Database_GetWorkflowByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkflowByUuid_Call) RunAndReturn(run func(string) db.Workflow) *Database_GetWorkflowByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowExecution provides a mock function with given fields: uuid
+func (_m *Database) GetWorkflowExecution(uuid string) db.WorkflowExecution {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowExecution")
+	}
+
+	var r0 db.WorkflowExecution
+	if rf, ok := ret.Get(0).(func(string) db.WorkflowExecution); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkflowExecution)
+	}
+
+	return r0
+}
+
+// Database_GetWorkflowExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowExecution'
+type Database_GetWorkflowExecution_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowExecution is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkflowExecution(uuid interface{}) *Database_GetWorkflowExecution_Call {
+	return &Database_GetWorkflowExecution_Call{Call: _e.mock.On("GetWorkflowExecution", uuid)}
+}
+
+func (_c *Database_GetWorkflowExecution_Call) Run(run func(uuid string)) *Database_GetWorkflowExecution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkflowExecution_Call) Return(_a0 db.WorkflowExecution) *Database_GetWorkflowExecution_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkflowExecution_Call) RunAndReturn(run func(string) db.WorkflowExecution) *Database_GetWorkflowExecution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowExecutions provides a mock function with given fields: workflowUuid, limit
+func (_m *Database) GetWorkflowExecutions(workflowUuid string, limit int) []db.WorkflowExecution {
+	ret := _m.Called(workflowUuid, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowExecutions")
+	}
+
+	var r0 []db.WorkflowExecution
+	if rf, ok := ret.Get(0).(func(string, int) []db.WorkflowExecution); ok {
+		r0 = rf(workflowUuid, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkflowExecution)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkflowExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowExecutions'
+type Database_GetWorkflowExecutions_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowExecutions is a helper method to define mock.On call
+//   - workflowUuid string
+//   - limit int
+func (_e *Database_Expecter) GetWorkflowExecutions(workflowUuid interface{}, limit interface{}) *Database_GetWorkflowExecutions_Call {
+	return &Database_GetWorkflowExecutions_Call{Call: _e.mock.On("GetWorkflowExecutions", workflowUuid, limit)}
+}
+
+func (_c *Database_GetWorkflowExecutions_Call) Run(run func(workflowUuid string, limit int)) *Database_GetWorkflowExecutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkflowExecutions_Call) Return(_a0 []db.WorkflowExecution) *Database_GetWorkflowExecutions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkflowExecutions_Call) RunAndReturn(run func(string, int) []db.WorkflowExecution) *Database_GetWorkflowExecutions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceAiSettings provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceAiSettings(workspace_uuid string) db.WorkspaceAiSettings {
+	ret := _m.Called(workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceAiSettings")
+	}
+
+	var r0 db.WorkspaceAiSettings
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceAiSettings); ok {
+		r0 = rf(workspace_uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceAiSettings)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceAiSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceAiSettings'
+type Database_GetWorkspaceAiSettings_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceAiSettings is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceAiSettings(workspace_uuid interface{}) *Database_GetWorkspaceAiSettings_Call {
+	return &Database_GetWorkspaceAiSettings_Call{Call: _e.mock.On("GetWorkspaceAiSettings", workspace_uuid)}
+}
+
+func (_c *Database_GetWorkspaceAiSettings_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceAiSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceAiSettings_Call) Return(_a0 db.WorkspaceAiSettings) *Database_GetWorkspaceAiSettings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceAiSettings_Call) RunAndReturn(run func(string) db.WorkspaceAiSettings) *Database_GetWorkspaceAiSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceAuditLog provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceAuditLog(workspaceUuid string) []db.WorkspaceAuditLog {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceAuditLog")
+	}
+
+	var r0 []db.WorkspaceAuditLog
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceAuditLog); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceAuditLog)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceAuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceAuditLog'
+type Database_GetWorkspaceAuditLog_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceAuditLog is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceAuditLog(workspaceUuid interface{}) *Database_GetWorkspaceAuditLog_Call {
+	return &Database_GetWorkspaceAuditLog_Call{Call: _e.mock.On("GetWorkspaceAuditLog", workspaceUuid)}
+}
+
+func (_c *Database_GetWorkspaceAuditLog_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceAuditLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceAuditLog_Call) Return(_a0 []db.WorkspaceAuditLog) *Database_GetWorkspaceAuditLog_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceAuditLog_Call) RunAndReturn(run func(string) []db.WorkspaceAuditLog) *Database_GetWorkspaceAuditLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceBounties provides a mock function with given fields: r, workspace_uuid
+func (_m *Database) GetWorkspaceBounties(r *http.Request, workspace_uuid string) []db.NewBounty {
+	ret := _m.Called(r, workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(*http.Request, string) []db.NewBounty); ok {
+		r0 = rf(r, workspace_uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBounties'
+type Database_GetWorkspaceBounties_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceBounties is a helper method to define mock.On call
+//   - r *http.Request
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceBounties(r interface{}, workspace_uuid interface{}) *Database_GetWorkspaceBounties_Call {
+	return &Database_GetWorkspaceBounties_Call{Call: _e.mock.On("GetWorkspaceBounties", r, workspace_uuid)}
+}
+
+func (_c *Database_GetWorkspaceBounties_Call) Run(run func(r *http.Request, workspace_uuid string)) *Database_GetWorkspaceBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBounties_Call) Return(_a0 []db.NewBounty) *Database_GetWorkspaceBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBounties_Call) RunAndReturn(run func(*http.Request, string) []db.NewBounty) *Database_GetWorkspaceBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceBountiesCount provides a mock function with given fields: r, workspace_uuid
+func (_m *Database) GetWorkspaceBountiesCount(r *http.Request, workspace_uuid string) int64 {
+	ret := _m.Called(r, workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceBountiesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*http.Request, string) int64); ok {
+		r0 = rf(r, workspace_uuid)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountiesCount'
+type Database_GetWorkspaceBountiesCount_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceBountiesCount is a helper method to define mock.On call
+//   - r *http.Request
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceBountiesCount(r interface{}, workspace_uuid interface{}) *Database_GetWorkspaceBountiesCount_Call {
+	return &Database_GetWorkspaceBountiesCount_Call{Call: _e.mock.On("GetWorkspaceBountiesCount", r, workspace_uuid)}
+}
+
+func (_c *Database_GetWorkspaceBountiesCount_Call) Run(run func(r *http.Request, workspace_uuid string)) *Database_GetWorkspaceBountiesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBountiesCount_Call) Return(_a0 int64) *Database_GetWorkspaceBountiesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBountiesCount_Call) RunAndReturn(run func(*http.Request, string) int64) *Database_GetWorkspaceBountiesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceBountyByTicketUrl provides a mock function with given fields: workspaceUuid, ticketUrl
+func (_m *Database) GetWorkspaceBountyByTicketUrl(workspaceUuid string, ticketUrl string) db.NewBounty {
+	ret := _m.Called(workspaceUuid, ticketUrl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceBountyByTicketUrl")
+	}
+
+	var r0 db.NewBounty
+	if rf, ok := ret.Get(0).(func(string, string) db.NewBounty); ok {
+		r0 = rf(workspaceUuid, ticketUrl)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceBountyByTicketUrl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountyByTicketUrl'
+type Database_GetWorkspaceBountyByTicketUrl_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceBountyByTicketUrl is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - ticketUrl string
+func (_e *Database_Expecter) GetWorkspaceBountyByTicketUrl(workspaceUuid interface{}, ticketUrl interface{}) *Database_GetWorkspaceBountyByTicketUrl_Call {
+	return &Database_GetWorkspaceBountyByTicketUrl_Call{Call: _e.mock.On("GetWorkspaceBountyByTicketUrl", workspaceUuid, ticketUrl)}
+}
+
+func (_c *Database_GetWorkspaceBountyByTicketUrl_Call) Run(run func(workspaceUuid string, ticketUrl string)) *Database_GetWorkspaceBountyByTicketUrl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBountyByTicketUrl_Call) Return(_a0 db.NewBounty) *Database_GetWorkspaceBountyByTicketUrl_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBountyByTicketUrl_Call) RunAndReturn(run func(string, string) db.NewBounty) *Database_GetWorkspaceBountyByTicketUrl_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceBountyCount provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceBountyCount(uuid string) int64 {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceBountyCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceBountyCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountyCount'
+type Database_GetWorkspaceBountyCount_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceBountyCount is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceBountyCount(uuid interface{}) *Database_GetWorkspaceBountyCount_Call {
+	return &Database_GetWorkspaceBountyCount_Call{Call: _e.mock.On("GetWorkspaceBountyCount", uuid)}
+}
+
+func (_c *Database_GetWorkspaceBountyCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceBountyCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBountyCount_Call) Return(_a0 int64) *Database_GetWorkspaceBountyCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBountyCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceBountyCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceBudget provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceBudget(workspace_uuid string) db.NewBountyBudget {
+	ret := _m.Called(workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceBudget")
+	}
+
+	var r0 db.NewBountyBudget
+	if rf, ok := ret.Get(0).(func(string) db.NewBountyBudget); ok {
+		r0 = rf(workspace_uuid)
+	} else {
+		r0 = ret.Get(0).(db.NewBountyBudget)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudget'
+type Database_GetWorkspaceBudget_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceBudget is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceBudget(workspace_uuid interface{}) *Database_GetWorkspaceBudget_Call {
+	return &Database_GetWorkspaceBudget_Call{Call: _e.mock.On("GetWorkspaceBudget", workspace_uuid)}
+}
+
+func (_c *Database_GetWorkspaceBudget_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBudget_Call) Return(_a0 db.NewBountyBudget) *Database_GetWorkspaceBudget_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBudget_Call) RunAndReturn(run func(string) db.NewBountyBudget) *Database_GetWorkspaceBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceBudgetHistory provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceBudgetHistory(workspace_uuid string) []db.BudgetHistoryData {
+	ret := _m.Called(workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceBudgetHistory")
+	}
+
+	var r0 []db.BudgetHistoryData
+	if rf, ok := ret.Get(0).(func(string) []db.BudgetHistoryData); ok {
+		r0 = rf(workspace_uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BudgetHistoryData)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceBudgetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudgetHistory'
+type Database_GetWorkspaceBudgetHistory_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceBudgetHistory is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceBudgetHistory(workspace_uuid interface{}) *Database_GetWorkspaceBudgetHistory_Call {
+	return &Database_GetWorkspaceBudgetHistory_Call{Call: _e.mock.On("GetWorkspaceBudgetHistory", workspace_uuid)}
+}
+
+func (_c *Database_GetWorkspaceBudgetHistory_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceBudgetHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBudgetHistory_Call) Return(_a0 []db.BudgetHistoryData) *Database_GetWorkspaceBudgetHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBudgetHistory_Call) RunAndReturn(run func(string) []db.BudgetHistoryData) *Database_GetWorkspaceBudgetHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceBudgetSettings provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceBudgetSettings(workspace_uuid string) db.WorkspaceBudgetSettings {
+	ret := _m.Called(workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceBudgetSettings")
+	}
+
+	var r0 db.WorkspaceBudgetSettings
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceBudgetSettings); ok {
+		r0 = rf(workspace_uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceBudgetSettings)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceBudgetSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudgetSettings'
+type Database_GetWorkspaceBudgetSettings_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceBudgetSettings is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceBudgetSettings(workspace_uuid interface{}) *Database_GetWorkspaceBudgetSettings_Call {
+	return &Database_GetWorkspaceBudgetSettings_Call{Call: _e.mock.On("GetWorkspaceBudgetSettings", workspace_uuid)}
+}
+
+func (_c *Database_GetWorkspaceBudgetSettings_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceBudgetSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBudgetSettings_Call) Return(_a0 db.WorkspaceBudgetSettings) *Database_GetWorkspaceBudgetSettings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBudgetSettings_Call) RunAndReturn(run func(string) db.WorkspaceBudgetSettings) *Database_GetWorkspaceBudgetSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceBundle provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceBundle(uuid string) db.WorkspaceBundle {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceBundle")
+	}
+
+	var r0 db.WorkspaceBundle
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceBundle); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceBundle)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceBundle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBundle'
+type Database_GetWorkspaceBundle_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceBundle is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceBundle(uuid interface{}) *Database_GetWorkspaceBundle_Call {
+	return &Database_GetWorkspaceBundle_Call{Call: _e.mock.On("GetWorkspaceBundle", uuid)}
+}
+
+func (_c *Database_GetWorkspaceBundle_Call) Run(run func(uuid string)) *Database_GetWorkspaceBundle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBundle_Call) Return(_a0 db.WorkspaceBundle) *Database_GetWorkspaceBundle_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceBundle_Call) RunAndReturn(run func(string) db.WorkspaceBundle) *Database_GetWorkspaceBundle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceByName provides a mock function with given fields: name
+func (_m *Database) GetWorkspaceByName(name string) db.Workspace {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceByName")
+	}
+
+	var r0 db.Workspace
+	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(db.Workspace)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceByName'
+type Database_GetWorkspaceByName_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceByName is a helper method to define mock.On call
+//   - name string
+func (_e *Database_Expecter) GetWorkspaceByName(name interface{}) *Database_GetWorkspaceByName_Call {
+	return &Database_GetWorkspaceByName_Call{Call: _e.mock.On("GetWorkspaceByName", name)}
+}
+
+func (_c *Database_GetWorkspaceByName_Call) Run(run func(name string)) *Database_GetWorkspaceByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceByName_Call) Return(_a0 db.Workspace) *Database_GetWorkspaceByName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceByName_Call) RunAndReturn(run func(string) db.Workspace) *Database_GetWorkspaceByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceByUuid(uuid string) db.Workspace {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceByUuid")
+	}
+
+	var r0 db.Workspace
+	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Workspace)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceByUuid'
+type Database_GetWorkspaceByUuid_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceByUuid(uuid interface{}) *Database_GetWorkspaceByUuid_Call {
+	return &Database_GetWorkspaceByUuid_Call{Call: _e.mock.On("GetWorkspaceByUuid", uuid)}
+}
+
+func (_c *Database_GetWorkspaceByUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceByUuid_Call) Return(_a0 db.Workspace) *Database_GetWorkspaceByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceByUuid_Call) RunAndReturn(run func(string) db.Workspace) *Database_GetWorkspaceByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceCalendarBounties provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceCalendarBounties(workspaceUuid string) []db.NewBounty {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceCalendarBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceCalendarBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceCalendarBounties'
+type Database_GetWorkspaceCalendarBounties_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceCalendarBounties is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceCalendarBounties(workspaceUuid interface{}) *Database_GetWorkspaceCalendarBounties_Call {
+	return &Database_GetWorkspaceCalendarBounties_Call{Call: _e.mock.On("GetWorkspaceCalendarBounties", workspaceUuid)}
+}
+
+func (_c *Database_GetWorkspaceCalendarBounties_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceCalendarBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceCalendarBounties_Call) Return(_a0 []db.NewBounty) *Database_GetWorkspaceCalendarBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceCalendarBounties_Call) RunAndReturn(run func(string) []db.NewBounty) *Database_GetWorkspaceCalendarBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceCalendarPhases provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceCalendarPhases(workspaceUuid string) []db.WorkspaceCalendarPhase {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceCalendarPhases")
+	}
+
+	var r0 []db.WorkspaceCalendarPhase
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceCalendarPhase); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceCalendarPhase)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceCalendarPhases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceCalendarPhases'
+type Database_GetWorkspaceCalendarPhases_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceCalendarPhases is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceCalendarPhases(workspaceUuid interface{}) *Database_GetWorkspaceCalendarPhases_Call {
+	return &Database_GetWorkspaceCalendarPhases_Call{Call: _e.mock.On("GetWorkspaceCalendarPhases", workspaceUuid)}
+}
+
+func (_c *Database_GetWorkspaceCalendarPhases_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceCalendarPhases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceCalendarPhases_Call) Return(_a0 []db.WorkspaceCalendarPhase) *Database_GetWorkspaceCalendarPhases_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceCalendarPhases_Call) RunAndReturn(run func(string) []db.WorkspaceCalendarPhase) *Database_GetWorkspaceCalendarPhases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceCalendarToken provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceCalendarToken(workspaceUuid string) db.WorkspaceCalendarToken {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceCalendarToken")
+	}
+
+	var r0 db.WorkspaceCalendarToken
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceCalendarToken); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceCalendarToken)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceCalendarToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceCalendarToken'
+type Database_GetWorkspaceCalendarToken_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceCalendarToken is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceCalendarToken(workspaceUuid interface{}) *Database_GetWorkspaceCalendarToken_Call {
+	return &Database_GetWorkspaceCalendarToken_Call{Call: _e.mock.On("GetWorkspaceCalendarToken", workspaceUuid)}
+}
+
+func (_c *Database_GetWorkspaceCalendarToken_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceCalendarToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceCalendarToken_Call) Return(_a0 db.WorkspaceCalendarToken) *Database_GetWorkspaceCalendarToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceCalendarToken_Call) RunAndReturn(run func(string) db.WorkspaceCalendarToken) *Database_GetWorkspaceCalendarToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceFeaturesAndPhases provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceFeaturesAndPhases(workspaceUuid string) ([]db.WorkspaceFeatures, []db.FeaturePhase) {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceFeaturesAndPhases")
+	}
+
+	var r0 []db.WorkspaceFeatures
+	var r1 []db.FeaturePhase
+	if rf, ok := ret.Get(0).(func(string) ([]db.WorkspaceFeatures, []db.FeaturePhase)); ok {
+		return rf(workspaceUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceFeatures); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceFeatures)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) []db.FeaturePhase); ok {
+		r1 = rf(workspaceUuid)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]db.FeaturePhase)
+		}
+	}
+
+	return r0, r1
+}
+
+// Database_GetWorkspaceFeaturesAndPhases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceFeaturesAndPhases'
+type Database_GetWorkspaceFeaturesAndPhases_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceFeaturesAndPhases is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceFeaturesAndPhases(workspaceUuid interface{}) *Database_GetWorkspaceFeaturesAndPhases_Call {
+	return &Database_GetWorkspaceFeaturesAndPhases_Call{Call: _e.mock.On("GetWorkspaceFeaturesAndPhases", workspaceUuid)}
+}
+
+func (_c *Database_GetWorkspaceFeaturesAndPhases_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceFeaturesAndPhases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceFeaturesAndPhases_Call) Return(_a0 []db.WorkspaceFeatures, _a1 []db.FeaturePhase) *Database_GetWorkspaceFeaturesAndPhases_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceFeaturesAndPhases_Call) RunAndReturn(run func(string) ([]db.WorkspaceFeatures, []db.FeaturePhase)) *Database_GetWorkspaceFeaturesAndPhases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceFeaturesCount provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceFeaturesCount(uuid string) int64 {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceFeaturesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceFeaturesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceFeaturesCount'
+type Database_GetWorkspaceFeaturesCount_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceFeaturesCount is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceFeaturesCount(uuid interface{}) *Database_GetWorkspaceFeaturesCount_Call {
+	return &Database_GetWorkspaceFeaturesCount_Call{Call: _e.mock.On("GetWorkspaceFeaturesCount", uuid)}
+}
+
+func (_c *Database_GetWorkspaceFeaturesCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceFeaturesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceFeaturesCount_Call) Return(_a0 int64) *Database_GetWorkspaceFeaturesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceFeaturesCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceFeaturesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceIntegration provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceIntegration(uuid string) db.WorkspaceIntegration {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceIntegration")
+	}
+
+	var r0 db.WorkspaceIntegration
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceIntegration); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceIntegration)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceIntegration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceIntegration'
+type Database_GetWorkspaceIntegration_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceIntegration is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceIntegration(uuid interface{}) *Database_GetWorkspaceIntegration_Call {
+	return &Database_GetWorkspaceIntegration_Call{Call: _e.mock.On("GetWorkspaceIntegration", uuid)}
+}
+
+func (_c *Database_GetWorkspaceIntegration_Call) Run(run func(uuid string)) *Database_GetWorkspaceIntegration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceIntegration_Call) Return(_a0 db.WorkspaceIntegration) *Database_GetWorkspaceIntegration_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceIntegration_Call) RunAndReturn(run func(string) db.WorkspaceIntegration) *Database_GetWorkspaceIntegration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceIntegrations provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceIntegrations(workspaceUuid string) []db.WorkspaceIntegration {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceIntegrations")
+	}
+
+	var r0 []db.WorkspaceIntegration
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceIntegration); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceIntegration)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceIntegrations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceIntegrations'
+type Database_GetWorkspaceIntegrations_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceIntegrations is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceIntegrations(workspaceUuid interface{}) *Database_GetWorkspaceIntegrations_Call {
+	return &Database_GetWorkspaceIntegrations_Call{Call: _e.mock.On("GetWorkspaceIntegrations", workspaceUuid)}
+}
+
+func (_c *Database_GetWorkspaceIntegrations_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceIntegrations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceIntegrations_Call) Return(_a0 []db.WorkspaceIntegration) *Database_GetWorkspaceIntegrations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceIntegrations_Call) RunAndReturn(run func(string) []db.WorkspaceIntegration) *Database_GetWorkspaceIntegrations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceIntegrationsForEvent provides a mock function with given fields: workspaceUuid, event
+func (_m *Database) GetWorkspaceIntegrationsForEvent(workspaceUuid string, event string) []db.WorkspaceIntegration {
+	ret := _m.Called(workspaceUuid, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceIntegrationsForEvent")
+	}
+
+	var r0 []db.WorkspaceIntegration
+	if rf, ok := ret.Get(0).(func(string, string) []db.WorkspaceIntegration); ok {
+		r0 = rf(workspaceUuid, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceIntegration)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceIntegrationsForEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceIntegrationsForEvent'
+type Database_GetWorkspaceIntegrationsForEvent_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceIntegrationsForEvent is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - event string
+func (_e *Database_Expecter) GetWorkspaceIntegrationsForEvent(workspaceUuid interface{}, event interface{}) *Database_GetWorkspaceIntegrationsForEvent_Call {
+	return &Database_GetWorkspaceIntegrationsForEvent_Call{Call: _e.mock.On("GetWorkspaceIntegrationsForEvent", workspaceUuid, event)}
+}
+
+func (_c *Database_GetWorkspaceIntegrationsForEvent_Call) Run(run func(workspaceUuid string, event string)) *Database_GetWorkspaceIntegrationsForEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceIntegrationsForEvent_Call) Return(_a0 []db.WorkspaceIntegration) *Database_GetWorkspaceIntegrationsForEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceIntegrationsForEvent_Call) RunAndReturn(run func(string, string) []db.WorkspaceIntegration) *Database_GetWorkspaceIntegrationsForEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceInvoices provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceInvoices(workspace_uuid string) []db.NewInvoiceList {
+	ret := _m.Called(workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceInvoices")
+	}
+
+	var r0 []db.NewInvoiceList
+	if rf, ok := ret.Get(0).(func(string) []db.NewInvoiceList); ok {
+		r0 = rf(workspace_uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewInvoiceList)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceInvoices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceInvoices'
+type Database_GetWorkspaceInvoices_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceInvoices is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceInvoices(workspace_uuid interface{}) *Database_GetWorkspaceInvoices_Call {
+	return &Database_GetWorkspaceInvoices_Call{Call: _e.mock.On("GetWorkspaceInvoices", workspace_uuid)}
+}
+
+func (_c *Database_GetWorkspaceInvoices_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceInvoices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceInvoices_Call) Return(_a0 []db.NewInvoiceList) *Database_GetWorkspaceInvoices_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceInvoices_Call) RunAndReturn(run func(string) []db.NewInvoiceList) *Database_GetWorkspaceInvoices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceInvoicesCount provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceInvoicesCount(workspace_uuid string) int64 {
+	ret := _m.Called(workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceInvoicesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(workspace_uuid)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceInvoicesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceInvoicesCount'
+type Database_GetWorkspaceInvoicesCount_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceInvoicesCount is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceInvoicesCount(workspace_uuid interface{}) *Database_GetWorkspaceInvoicesCount_Call {
+	return &Database_GetWorkspaceInvoicesCount_Call{Call: _e.mock.On("GetWorkspaceInvoicesCount", workspace_uuid)}
+}
+
+func (_c *Database_GetWorkspaceInvoicesCount_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceInvoicesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceInvoicesCount_Call) Return(_a0 int64) *Database_GetWorkspaceInvoicesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceInvoicesCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceInvoicesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceLedger provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceLedger(workspace_uuid string) db.WorkspaceLedger {
+	ret := _m.Called(workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceLedger")
+	}
+
+	var r0 db.WorkspaceLedger
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceLedger); ok {
+		r0 = rf(workspace_uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceLedger)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceLedger_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceLedger'
+type Database_GetWorkspaceLedger_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceLedger is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceLedger(workspace_uuid interface{}) *Database_GetWorkspaceLedger_Call {
+	return &Database_GetWorkspaceLedger_Call{Call: _e.mock.On("GetWorkspaceLedger", workspace_uuid)}
+}
+
+func (_c *Database_GetWorkspaceLedger_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceLedger_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceLedger_Call) Return(_a0 db.WorkspaceLedger) *Database_GetWorkspaceLedger_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceLedger_Call) RunAndReturn(run func(string) db.WorkspaceLedger) *Database_GetWorkspaceLedger_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceMatePubkeys provides a mock function with given fields: pubkey
+func (_m *Database) GetWorkspaceMatePubkeys(pubkey string) map[string]bool {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceMatePubkeys")
+	}
+
+	var r0 map[string]bool
+	if rf, ok := ret.Get(0).(func(string) map[string]bool); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceMatePubkeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceMatePubkeys'
+type Database_GetWorkspaceMatePubkeys_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceMatePubkeys is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetWorkspaceMatePubkeys(pubkey interface{}) *Database_GetWorkspaceMatePubkeys_Call {
+	return &Database_GetWorkspaceMatePubkeys_Call{Call: _e.mock.On("GetWorkspaceMatePubkeys", pubkey)}
+}
+
+func (_c *Database_GetWorkspaceMatePubkeys_Call) Run(run func(pubkey string)) *Database_GetWorkspaceMatePubkeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceMatePubkeys_Call) Return(_a0 map[string]bool) *Database_GetWorkspaceMatePubkeys_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceMatePubkeys_Call) RunAndReturn(run func(string) map[string]bool) *Database_GetWorkspaceMatePubkeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceRepoByWorkspaceUuidAndRepoUuid provides a mock function with given fields: workspace_uuid, uuid
+func (_m *Database) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid string, uuid string) (db.WorkspaceRepositories, error) {
+	ret := _m.Called(workspace_uuid, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceRepoByWorkspaceUuidAndRepoUuid")
+	}
+
+	var r0 db.WorkspaceRepositories
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.WorkspaceRepositories, error)); ok {
+		return rf(workspace_uuid, uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceRepositories); ok {
+		r0 = rf(workspace_uuid, uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceRepositories)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(workspace_uuid, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceRepoByWorkspaceUuidAndRepoUuid'
+type Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceRepoByWorkspaceUuidAndRepoUuid is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid interface{}, uuid interface{}) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+	return &Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call{Call: _e.mock.On("GetWorkspaceRepoByWorkspaceUuidAndRepoUuid", workspace_uuid, uuid)}
+}
+
+func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) Run(run func(workspace_uuid string, uuid string)) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) Return(_a0 db.WorkspaceRepositories, _a1 error) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) RunAndReturn(run func(string, string) (db.WorkspaceRepositories, error)) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceRepositorByWorkspaceUuid provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceRepositorByWorkspaceUuid(uuid string) []db.WorkspaceRepositories {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceRepositorByWorkspaceUuid")
+	}
+
+	var r0 []db.WorkspaceRepositories
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceRepositories); ok {
+		r0 = rf(uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceRepositories)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceRepositorByWorkspaceUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceRepositorByWorkspaceUuid'
+type Database_GetWorkspaceRepositorByWorkspaceUuid_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceRepositorByWorkspaceUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceRepositorByWorkspaceUuid(uuid interface{}) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+	return &Database_GetWorkspaceRepositorByWorkspaceUuid_Call{Call: _e.mock.On("GetWorkspaceRepositorByWorkspaceUuid", uuid)}
+}
+
+func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) Return(_a0 []db.WorkspaceRepositories) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) RunAndReturn(run func(string) []db.WorkspaceRepositories) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceSpentSince provides a mock function with given fields: workspace_uuid, receiver, since
+func (_m *Database) GetWorkspaceSpentSince(workspace_uuid string, receiver string, since time.Time) uint {
+	ret := _m.Called(workspace_uuid, receiver, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceSpentSince")
+	}
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) uint); ok {
+		r0 = rf(workspace_uuid, receiver, since)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceSpentSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceSpentSince'
+type Database_GetWorkspaceSpentSince_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceSpentSince is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - receiver string
+//   - since time.Time
+func (_e *Database_Expecter) GetWorkspaceSpentSince(workspace_uuid interface{}, receiver interface{}, since interface{}) *Database_GetWorkspaceSpentSince_Call {
+	return &Database_GetWorkspaceSpentSince_Call{Call: _e.mock.On("GetWorkspaceSpentSince", workspace_uuid, receiver, since)}
+}
+
+func (_c *Database_GetWorkspaceSpentSince_Call) Run(run func(workspace_uuid string, receiver string, since time.Time)) *Database_GetWorkspaceSpentSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceSpentSince_Call) Return(_a0 uint) *Database_GetWorkspaceSpentSince_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceSpentSince_Call) RunAndReturn(run func(string, string, time.Time) uint) *Database_GetWorkspaceSpentSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceStatusBudget provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceStatusBudget(workspace_uuid string) db.StatusBudget {
+	ret := _m.Called(workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceStatusBudget")
+	}
+
+	var r0 db.StatusBudget
+	if rf, ok := ret.Get(0).(func(string) db.StatusBudget); ok {
+		r0 = rf(workspace_uuid)
+	} else {
+		r0 = ret.Get(0).(db.StatusBudget)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceStatusBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceStatusBudget'
+type Database_GetWorkspaceStatusBudget_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceStatusBudget is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceStatusBudget(workspace_uuid interface{}) *Database_GetWorkspaceStatusBudget_Call {
+	return &Database_GetWorkspaceStatusBudget_Call{Call: _e.mock.On("GetWorkspaceStatusBudget", workspace_uuid)}
+}
+
+func (_c *Database_GetWorkspaceStatusBudget_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceStatusBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceStatusBudget_Call) Return(_a0 db.StatusBudget) *Database_GetWorkspaceStatusBudget_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceStatusBudget_Call) RunAndReturn(run func(string) db.StatusBudget) *Database_GetWorkspaceStatusBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceTemplate provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceTemplate(uuid string) db.WorkspaceTemplate {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceTemplate")
+	}
+
+	var r0 db.WorkspaceTemplate
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceTemplate); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceTemplate)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceTemplate'
+type Database_GetWorkspaceTemplate_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceTemplate is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceTemplate(uuid interface{}) *Database_GetWorkspaceTemplate_Call {
+	return &Database_GetWorkspaceTemplate_Call{Call: _e.mock.On("GetWorkspaceTemplate", uuid)}
+}
+
+func (_c *Database_GetWorkspaceTemplate_Call) Run(run func(uuid string)) *Database_GetWorkspaceTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceTemplate_Call) Return(_a0 db.WorkspaceTemplate) *Database_GetWorkspaceTemplate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceTemplate_Call) RunAndReturn(run func(string) db.WorkspaceTemplate) *Database_GetWorkspaceTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceTemplates provides a mock function with given fields: pubkey
+func (_m *Database) GetWorkspaceTemplates(pubkey string) []db.WorkspaceTemplate {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceTemplates")
+	}
+
+	var r0 []db.WorkspaceTemplate
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceTemplate); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceTemplate)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceTemplates'
+type Database_GetWorkspaceTemplates_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceTemplates is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetWorkspaceTemplates(pubkey interface{}) *Database_GetWorkspaceTemplates_Call {
+	return &Database_GetWorkspaceTemplates_Call{Call: _e.mock.On("GetWorkspaceTemplates", pubkey)}
+}
+
+func (_c *Database_GetWorkspaceTemplates_Call) Run(run func(pubkey string)) *Database_GetWorkspaceTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceTemplates_Call) Return(_a0 []db.WorkspaceTemplate) *Database_GetWorkspaceTemplates_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceTemplates_Call) RunAndReturn(run func(string) []db.WorkspaceTemplate) *Database_GetWorkspaceTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceTransferByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceTransferByUuid(uuid string) db.WorkspaceTransfer {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceTransferByUuid")
+	}
+
+	var r0 db.WorkspaceTransfer
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceTransfer); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceTransfer)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceTransferByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceTransferByUuid'
+type Database_GetWorkspaceTransferByUuid_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceTransferByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceTransferByUuid(uuid interface{}) *Database_GetWorkspaceTransferByUuid_Call {
+	return &Database_GetWorkspaceTransferByUuid_Call{Call: _e.mock.On("GetWorkspaceTransferByUuid", uuid)}
+}
+
+func (_c *Database_GetWorkspaceTransferByUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceTransferByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceTransferByUuid_Call) Return(_a0 db.WorkspaceTransfer) *Database_GetWorkspaceTransferByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceTransferByUuid_Call) RunAndReturn(run func(string) db.WorkspaceTransfer) *Database_GetWorkspaceTransferByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceTransfers provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceTransfers(workspaceUuid string) []db.WorkspaceTransfer {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceTransfers")
+	}
+
+	var r0 []db.WorkspaceTransfer
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceTransfer); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceTransfer)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceTransfers'
+type Database_GetWorkspaceTransfers_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceTransfers is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceTransfers(workspaceUuid interface{}) *Database_GetWorkspaceTransfers_Call {
+	return &Database_GetWorkspaceTransfers_Call{Call: _e.mock.On("GetWorkspaceTransfers", workspaceUuid)}
+}
+
+func (_c *Database_GetWorkspaceTransfers_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceTransfers_Call) Return(_a0 []db.WorkspaceTransfer) *Database_GetWorkspaceTransfers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceTransfers_Call) RunAndReturn(run func(string) []db.WorkspaceTransfer) *Database_GetWorkspaceTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceUser provides a mock function with given fields: pubkey, workspace_uuid
+func (_m *Database) GetWorkspaceUser(pubkey string, workspace_uuid string) db.WorkspaceUsers {
+	ret := _m.Called(pubkey, workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceUser")
+	}
+
+	var r0 db.WorkspaceUsers
+	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceUsers); ok {
+		r0 = rf(pubkey, workspace_uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceUsers)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUser'
+type Database_GetWorkspaceUser_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceUser is a helper method to define mock.On call
+//   - pubkey string
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceUser(pubkey interface{}, workspace_uuid interface{}) *Database_GetWorkspaceUser_Call {
+	return &Database_GetWorkspaceUser_Call{Call: _e.mock.On("GetWorkspaceUser", pubkey, workspace_uuid)}
+}
+
+func (_c *Database_GetWorkspaceUser_Call) Run(run func(pubkey string, workspace_uuid string)) *Database_GetWorkspaceUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceUser_Call) Return(_a0 db.WorkspaceUsers) *Database_GetWorkspaceUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceUser_Call) RunAndReturn(run func(string, string) db.WorkspaceUsers) *Database_GetWorkspaceUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceUsers provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceUsers(uuid string) ([]db.WorkspaceUsersData, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceUsers")
+	}
+
+	var r0 []db.WorkspaceUsersData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.WorkspaceUsersData, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceUsersData); ok {
+		r0 = rf(uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceUsersData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetWorkspaceUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsers'
+type Database_GetWorkspaceUsers_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceUsers is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceUsers(uuid interface{}) *Database_GetWorkspaceUsers_Call {
+	return &Database_GetWorkspaceUsers_Call{Call: _e.mock.On("GetWorkspaceUsers", uuid)}
+}
+
+func (_c *Database_GetWorkspaceUsers_Call) Run(run func(uuid string)) *Database_GetWorkspaceUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceUsers_Call) Return(_a0 []db.WorkspaceUsersData, _a1 error) *Database_GetWorkspaceUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceUsers_Call) RunAndReturn(run func(string) ([]db.WorkspaceUsersData, error)) *Database_GetWorkspaceUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceUsersCount provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceUsersCount(uuid string) int64 {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceUsersCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceUsersCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsersCount'
+type Database_GetWorkspaceUsersCount_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceUsersCount is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceUsersCount(uuid interface{}) *Database_GetWorkspaceUsersCount_Call {
+	return &Database_GetWorkspaceUsersCount_Call{Call: _e.mock.On("GetWorkspaceUsersCount", uuid)}
+}
+
+func (_c *Database_GetWorkspaceUsersCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceUsersCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceUsersCount_Call) Return(_a0 int64) *Database_GetWorkspaceUsersCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceUsersCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceUsersCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceWorkSessions provides a mock function with given fields: workspaceUuid, since
+func (_m *Database) GetWorkspaceWorkSessions(workspaceUuid string, since time.Time) []db.BountyWorkSession {
+	ret := _m.Called(workspaceUuid, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceWorkSessions")
+	}
+
+	var r0 []db.BountyWorkSession
+	if rf, ok := ret.Get(0).(func(string, time.Time) []db.BountyWorkSession); ok {
+		r0 = rf(workspaceUuid, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyWorkSession)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceWorkSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceWorkSessions'
+type Database_GetWorkspaceWorkSessions_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceWorkSessions is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - since time.Time
+func (_e *Database_Expecter) GetWorkspaceWorkSessions(workspaceUuid interface{}, since interface{}) *Database_GetWorkspaceWorkSessions_Call {
+	return &Database_GetWorkspaceWorkSessions_Call{Call: _e.mock.On("GetWorkspaceWorkSessions", workspaceUuid, since)}
+}
+
+func (_c *Database_GetWorkspaceWorkSessions_Call) Run(run func(workspaceUuid string, since time.Time)) *Database_GetWorkspaceWorkSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceWorkSessions_Call) Return(_a0 []db.BountyWorkSession) *Database_GetWorkspaceWorkSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceWorkSessions_Call) RunAndReturn(run func(string, time.Time) []db.BountyWorkSession) *Database_GetWorkspaceWorkSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceWorkflows provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceWorkflows(workspaceUuid string) []db.Workflow {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceWorkflows")
+	}
+
+	var r0 []db.Workflow
+	if rf, ok := ret.Get(0).(func(string) []db.Workflow); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Workflow)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaceWorkflows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceWorkflows'
+type Database_GetWorkspaceWorkflows_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceWorkflows is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceWorkflows(workspaceUuid interface{}) *Database_GetWorkspaceWorkflows_Call {
+	return &Database_GetWorkspaceWorkflows_Call{Call: _e.mock.On("GetWorkspaceWorkflows", workspaceUuid)}
+}
+
+func (_c *Database_GetWorkspaceWorkflows_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceWorkflows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceWorkflows_Call) Return(_a0 []db.Workflow) *Database_GetWorkspaceWorkflows_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceWorkflows_Call) RunAndReturn(run func(string) []db.Workflow) *Database_GetWorkspaceWorkflows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaces provides a mock function with given fields: r
+func (_m *Database) GetWorkspaces(r *http.Request) []db.Workspace {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaces")
+	}
+
+	var r0 []db.Workspace
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Workspace); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Workspace)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaces'
+type Database_GetWorkspaces_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaces is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetWorkspaces(r interface{}) *Database_GetWorkspaces_Call {
+	return &Database_GetWorkspaces_Call{Call: _e.mock.On("GetWorkspaces", r)}
+}
+
+func (_c *Database_GetWorkspaces_Call) Run(run func(r *http.Request)) *Database_GetWorkspaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaces_Call) Return(_a0 []db.Workspace) *Database_GetWorkspaces_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspaces_Call) RunAndReturn(run func(*http.Request) []db.Workspace) *Database_GetWorkspaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspacesByUuids provides a mock function with given fields: uuids
+func (_m *Database) GetWorkspacesByUuids(uuids []string) []db.Workspace {
+	ret := _m.Called(uuids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspacesByUuids")
+	}
+
+	var r0 []db.Workspace
+	if rf, ok := ret.Get(0).(func([]string) []db.Workspace); ok {
+		r0 = rf(uuids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Workspace)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetWorkspacesByUuids_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspacesByUuids'
+type Database_GetWorkspacesByUuids_Call struct {
+	*mock.Call
+}
+
+// GetWorkspacesByUuids is a helper method to define mock.On call
+//   - uuids []string
+func (_e *Database_Expecter) GetWorkspacesByUuids(uuids interface{}) *Database_GetWorkspacesByUuids_Call {
+	return &Database_GetWorkspacesByUuids_Call{Call: _e.mock.On("GetWorkspacesByUuids", uuids)}
+}
+
+func (_c *Database_GetWorkspacesByUuids_Call) Run(run func(uuids []string)) *Database_GetWorkspacesByUuids_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspacesByUuids_Call) Return(_a0 []db.Workspace) *Database_GetWorkspacesByUuids_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspacesByUuids_Call) RunAndReturn(run func([]string) []db.Workspace) *Database_GetWorkspacesByUuids_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspacesCount provides a mock function with given fields:
+func (_m *Database) GetWorkspacesCount() int64 {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspacesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetWorkspacesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspacesCount'
+type Database_GetWorkspacesCount_Call struct {
+	*mock.Call
+}
+
+// GetWorkspacesCount is a helper method to define mock.On call
+func (_e *Database_Expecter) GetWorkspacesCount() *Database_GetWorkspacesCount_Call {
+	return &Database_GetWorkspacesCount_Call{Call: _e.mock.On("GetWorkspacesCount")}
+}
+
+func (_c *Database_GetWorkspacesCount_Call) Run(run func()) *Database_GetWorkspacesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspacesCount_Call) Return(_a0 int64) *Database_GetWorkspacesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetWorkspacesCount_Call) RunAndReturn(run func() int64) *Database_GetWorkspacesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportWorkspaceBundle provides a mock function with given fields: m, people
+func (_m *Database) ImportWorkspaceBundle(m db.WorkspaceBundle, people []db.Person) (db.Workspace, error) {
+	ret := _m.Called(m, people)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportWorkspaceBundle")
+	}
+
+	var r0 db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBundle, []db.Person) (db.Workspace, error)); ok {
+		return rf(m, people)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBundle, []db.Person) db.Workspace); ok {
+		r0 = rf(m, people)
+	} else {
+		r0 = ret.Get(0).(db.Workspace)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceBundle, []db.Person) error); ok {
+		r1 = rf(m, people)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_ImportWorkspaceBundle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportWorkspaceBundle'
+type Database_ImportWorkspaceBundle_Call struct {
+	*mock.Call
+}
+
+// ImportWorkspaceBundle is a helper method to define mock.On call
+//   - m db.WorkspaceBundle
+//   - people []db.Person
+func (_e *Database_Expecter) ImportWorkspaceBundle(m interface{}, people interface{}) *Database_ImportWorkspaceBundle_Call {
+	return &Database_ImportWorkspaceBundle_Call{Call: _e.mock.On("ImportWorkspaceBundle", m, people)}
+}
+
+func (_c *Database_ImportWorkspaceBundle_Call) Run(run func(m db.WorkspaceBundle, people []db.Person)) *Database_ImportWorkspaceBundle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceBundle), args[1].([]db.Person))
+	})
+	return _c
+}
+
+func (_c *Database_ImportWorkspaceBundle_Call) Return(_a0 db.Workspace, _a1 error) *Database_ImportWorkspaceBundle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_ImportWorkspaceBundle_Call) RunAndReturn(run func(db.WorkspaceBundle, []db.Person) (db.Workspace, error)) *Database_ImportWorkspaceBundle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportWorkspaceTickets provides a mock function with given fields: m
+func (_m *Database) ImportWorkspaceTickets(m db.TicketImport) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportWorkspaceTickets")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.TicketImport) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_ImportWorkspaceTickets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportWorkspaceTickets'
+type Database_ImportWorkspaceTickets_Call struct {
+	*mock.Call
+}
+
+// ImportWorkspaceTickets is a helper method to define mock.On call
+//   - m db.TicketImport
+func (_e *Database_Expecter) ImportWorkspaceTickets(m interface{}) *Database_ImportWorkspaceTickets_Call {
+	return &Database_ImportWorkspaceTickets_Call{Call: _e.mock.On("ImportWorkspaceTickets", m)}
+}
+
+func (_c *Database_ImportWorkspaceTickets_Call) Run(run func(m db.TicketImport)) *Database_ImportWorkspaceTickets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.TicketImport))
+	})
+	return _c
+}
+
+func (_c *Database_ImportWorkspaceTickets_Call) Return(_a0 error) *Database_ImportWorkspaceTickets_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_ImportWorkspaceTickets_Call) RunAndReturn(run func(db.TicketImport) error) *Database_ImportWorkspaceTickets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LiftPubkeyBan provides a mock function with given fields: pubkey, liftedBy, reason
+func (_m *Database) LiftPubkeyBan(pubkey string, liftedBy string, reason string) (db.PubkeyBan, error) {
+	ret := _m.Called(pubkey, liftedBy, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LiftPubkeyBan")
+	}
+
+	var r0 db.PubkeyBan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (db.PubkeyBan, error)); ok {
+		return rf(pubkey, liftedBy, reason)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) db.PubkeyBan); ok {
+		r0 = rf(pubkey, liftedBy, reason)
+	} else {
+		r0 = ret.Get(0).(db.PubkeyBan)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(pubkey, liftedBy, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_LiftPubkeyBan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LiftPubkeyBan'
+type Database_LiftPubkeyBan_Call struct {
+	*mock.Call
+}
+
+// LiftPubkeyBan is a helper method to define mock.On call
+//   - pubkey string
+//   - liftedBy string
+//   - reason string
+func (_e *Database_Expecter) LiftPubkeyBan(pubkey interface{}, liftedBy interface{}, reason interface{}) *Database_LiftPubkeyBan_Call {
+	return &Database_LiftPubkeyBan_Call{Call: _e.mock.On("LiftPubkeyBan", pubkey, liftedBy, reason)}
+}
+
+func (_c *Database_LiftPubkeyBan_Call) Run(run func(pubkey string, liftedBy string, reason string)) *Database_LiftPubkeyBan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Database_LiftPubkeyBan_Call) Return(_a0 db.PubkeyBan, _a1 error) *Database_LiftPubkeyBan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_LiftPubkeyBan_Call) RunAndReturn(run func(string, string, string) (db.PubkeyBan, error)) *Database_LiftPubkeyBan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkAllNotificationsRead provides a mock function with given fields: pubkey
+func (_m *Database) MarkAllNotificationsRead(pubkey string) error {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAllNotificationsRead")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_MarkAllNotificationsRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAllNotificationsRead'
+type Database_MarkAllNotificationsRead_Call struct {
+	*mock.Call
+}
+
+// MarkAllNotificationsRead is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) MarkAllNotificationsRead(pubkey interface{}) *Database_MarkAllNotificationsRead_Call {
+	return &Database_MarkAllNotificationsRead_Call{Call: _e.mock.On("MarkAllNotificationsRead", pubkey)}
+}
+
+func (_c *Database_MarkAllNotificationsRead_Call) Run(run func(pubkey string)) *Database_MarkAllNotificationsRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_MarkAllNotificationsRead_Call) Return(_a0 error) *Database_MarkAllNotificationsRead_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_MarkAllNotificationsRead_Call) RunAndReturn(run func(string) error) *Database_MarkAllNotificationsRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkExpiryWarned provides a mock function with given fields: bountyId
+func (_m *Database) MarkExpiryWarned(bountyId uint) error {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkExpiryWarned")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(bountyId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_MarkExpiryWarned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkExpiryWarned'
+type Database_MarkExpiryWarned_Call struct {
+	*mock.Call
+}
+
+// MarkExpiryWarned is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) MarkExpiryWarned(bountyId interface{}) *Database_MarkExpiryWarned_Call {
+	return &Database_MarkExpiryWarned_Call{Call: _e.mock.On("MarkExpiryWarned", bountyId)}
+}
+
+func (_c *Database_MarkExpiryWarned_Call) Run(run func(bountyId uint)) *Database_MarkExpiryWarned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_MarkExpiryWarned_Call) Return(_a0 error) *Database_MarkExpiryWarned_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_MarkExpiryWarned_Call) RunAndReturn(run func(uint) error) *Database_MarkExpiryWarned_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveTicketCard provides a mock function with given fields: card
+func (_m *Database) MoveTicketCard(card db.TicketCard) (db.TicketCard, error) {
+	ret := _m.Called(card)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveTicketCard")
+	}
+
+	var r0 db.TicketCard
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TicketCard) (db.TicketCard, error)); ok {
+		return rf(card)
+	}
+	if rf, ok := ret.Get(0).(func(db.TicketCard) db.TicketCard); ok {
+		r0 = rf(card)
+	} else {
+		r0 = ret.Get(0).(db.TicketCard)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.TicketCard) error); ok {
+		r1 = rf(card)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_MoveTicketCard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveTicketCard'
+type Database_MoveTicketCard_Call struct {
+	*mock.Call
+}
+
+// MoveTicketCard is a helper method to define mock.On call
+//   - card db.TicketCard
+func (_e *Database_Expecter) MoveTicketCard(card interface{}) *Database_MoveTicketCard_Call {
+	return &Database_MoveTicketCard_Call{Call: _e.mock.On("MoveTicketCard", card)}
+}
+
+func (_c *Database_MoveTicketCard_Call) Run(run func(card db.TicketCard)) *Database_MoveTicketCard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.TicketCard))
+	})
+	return _c
+}
+
+func (_c *Database_MoveTicketCard_Call) Return(_a0 db.TicketCard, _a1 error) *Database_MoveTicketCard_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_MoveTicketCard_Call) RunAndReturn(run func(db.TicketCard) (db.TicketCard, error)) *Database_MoveTicketCard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHuntersPaid provides a mock function with given fields: r, workspace
+func (_m *Database) NewHuntersPaid(r db.PaymentDateRange, workspace string) int64 {
+	ret := _m.Called(r, workspace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewHuntersPaid")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
+		r0 = rf(r, workspace)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_NewHuntersPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewHuntersPaid'
+type Database_NewHuntersPaid_Call struct {
+	*mock.Call
+}
+
+// NewHuntersPaid is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) NewHuntersPaid(r interface{}, workspace interface{}) *Database_NewHuntersPaid_Call {
+	return &Database_NewHuntersPaid_Call{Call: _e.mock.On("NewHuntersPaid", r, workspace)}
+}
+
+func (_c *Database_NewHuntersPaid_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_NewHuntersPaid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_NewHuntersPaid_Call) Return(_a0 int64) *Database_NewHuntersPaid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_NewHuntersPaid_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_NewHuntersPaid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PersonUniqueNameFromName provides a mock function with given fields: name
+func (_m *Database) PersonUniqueNameFromName(name string) (string, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PersonUniqueNameFromName")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_PersonUniqueNameFromName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PersonUniqueNameFromName'
+type Database_PersonUniqueNameFromName_Call struct {
+	*mock.Call
+}
+
+// PersonUniqueNameFromName is a helper method to define mock.On call
+//   - name string
+func (_e *Database_Expecter) PersonUniqueNameFromName(name interface{}) *Database_PersonUniqueNameFromName_Call {
+	return &Database_PersonUniqueNameFromName_Call{Call: _e.mock.On("PersonUniqueNameFromName", name)}
+}
+
+func (_c *Database_PersonUniqueNameFromName_Call) Run(run func(name string)) *Database_PersonUniqueNameFromName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_PersonUniqueNameFromName_Call) Return(_a0 string, _a1 error) *Database_PersonUniqueNameFromName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_PersonUniqueNameFromName_Call) RunAndReturn(run func(string) (string, error)) *Database_PersonUniqueNameFromName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessAddInvoice provides a mock function with given fields: invoice, userData
+func (_m *Database) ProcessAddInvoice(invoice db.NewInvoiceList, userData db.UserInvoiceData) error {
+	ret := _m.Called(invoice, userData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessAddInvoice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewInvoiceList, db.UserInvoiceData) error); ok {
+		r0 = rf(invoice, userData)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_ProcessAddInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessAddInvoice'
+type Database_ProcessAddInvoice_Call struct {
+	*mock.Call
+}
+
+// ProcessAddInvoice is a helper method to define mock.On call
+//   - invoice db.NewInvoiceList
+//   - userData db.UserInvoiceData
+func (_e *Database_Expecter) ProcessAddInvoice(invoice interface{}, userData interface{}) *Database_ProcessAddInvoice_Call {
+	return &Database_ProcessAddInvoice_Call{Call: _e.mock.On("ProcessAddInvoice", invoice, userData)}
+}
+
+func (_c *Database_ProcessAddInvoice_Call) Run(run func(invoice db.NewInvoiceList, userData db.UserInvoiceData)) *Database_ProcessAddInvoice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewInvoiceList), args[1].(db.UserInvoiceData))
+	})
+	return _c
+}
+
+func (_c *Database_ProcessAddInvoice_Call) Return(_a0 error) *Database_ProcessAddInvoice_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_ProcessAddInvoice_Call) RunAndReturn(run func(db.NewInvoiceList, db.UserInvoiceData) error) *Database_ProcessAddInvoice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessAlerts provides a mock function with given fields: p
+func (_m *Database) ProcessAlerts(p db.Person) {
+	_m.Called(p)
+}
+
+// Database_ProcessAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessAlerts'
+type Database_ProcessAlerts_Call struct {
+	*mock.Call
+}
+
+// ProcessAlerts is a helper method to define mock.On call
+//   - p db.Person
+func (_e *Database_Expecter) ProcessAlerts(p interface{}) *Database_ProcessAlerts_Call {
+	return &Database_ProcessAlerts_Call{Call: _e.mock.On("ProcessAlerts", p)}
+}
+
+func (_c *Database_ProcessAlerts_Call) Run(run func(p db.Person)) *Database_ProcessAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Person))
+	})
+	return _c
+}
+
+func (_c *Database_ProcessAlerts_Call) Return() *Database_ProcessAlerts_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Database_ProcessAlerts_Call) RunAndReturn(run func(db.Person)) *Database_ProcessAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessBountyPayment provides a mock function with given fields: payment, bounty
+func (_m *Database) ProcessBountyPayment(payment db.NewPaymentHistory, bounty db.NewBounty) error {
+	ret := _m.Called(payment, bounty)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessBountyPayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory, db.NewBounty) error); ok {
+		r0 = rf(payment, bounty)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_ProcessBountyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessBountyPayment'
+type Database_ProcessBountyPayment_Call struct {
+	*mock.Call
+}
+
+// ProcessBountyPayment is a helper method to define mock.On call
+//   - payment db.NewPaymentHistory
+//   - bounty db.NewBounty
+func (_e *Database_Expecter) ProcessBountyPayment(payment interface{}, bounty interface{}) *Database_ProcessBountyPayment_Call {
+	return &Database_ProcessBountyPayment_Call{Call: _e.mock.On("ProcessBountyPayment", payment, bounty)}
+}
+
+func (_c *Database_ProcessBountyPayment_Call) Run(run func(payment db.NewPaymentHistory, bounty db.NewBounty)) *Database_ProcessBountyPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewPaymentHistory), args[1].(db.NewBounty))
+	})
+	return _c
+}
+
+func (_c *Database_ProcessBountyPayment_Call) Return(_a0 error) *Database_ProcessBountyPayment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_ProcessBountyPayment_Call) RunAndReturn(run func(db.NewPaymentHistory, db.NewBounty) error) *Database_ProcessBountyPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessBudgetInvoice provides a mock function with given fields: paymentHistory, newInvoice
+func (_m *Database) ProcessBudgetInvoice(paymentHistory db.NewPaymentHistory, newInvoice db.NewInvoiceList) error {
+	ret := _m.Called(paymentHistory, newInvoice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessBudgetInvoice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory, db.NewInvoiceList) error); ok {
+		r0 = rf(paymentHistory, newInvoice)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_ProcessBudgetInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessBudgetInvoice'
+type Database_ProcessBudgetInvoice_Call struct {
+	*mock.Call
+}
+
+// ProcessBudgetInvoice is a helper method to define mock.On call
+//   - paymentHistory db.NewPaymentHistory
+//   - newInvoice db.NewInvoiceList
+func (_e *Database_Expecter) ProcessBudgetInvoice(paymentHistory interface{}, newInvoice interface{}) *Database_ProcessBudgetInvoice_Call {
+	return &Database_ProcessBudgetInvoice_Call{Call: _e.mock.On("ProcessBudgetInvoice", paymentHistory, newInvoice)}
+}
+
+func (_c *Database_ProcessBudgetInvoice_Call) Run(run func(paymentHistory db.NewPaymentHistory, newInvoice db.NewInvoiceList)) *Database_ProcessBudgetInvoice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewPaymentHistory), args[1].(db.NewInvoiceList))
+	})
+	return _c
+}
+
+func (_c *Database_ProcessBudgetInvoice_Call) Return(_a0 error) *Database_ProcessBudgetInvoice_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_ProcessBudgetInvoice_Call) RunAndReturn(run func(db.NewPaymentHistory, db.NewInvoiceList) error) *Database_ProcessBudgetInvoice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessDeleteWorkspace provides a mock function with given fields: workspace_uuid
+func (_m *Database) ProcessDeleteWorkspace(workspace_uuid string) error {
+	ret := _m.Called(workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessDeleteWorkspace")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Tribe); ok {
-		r0 = rf(r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(workspace_uuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetListedTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedTribes'
-type Database_GetListedTribes_Call struct {
+// Database_ProcessDeleteWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessDeleteWorkspace'
+type Database_ProcessDeleteWorkspace_Call struct {
 	*mock.Call
 }
 
-// GetListedTribes is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedTribes(r interface{}) *Database_GetListedTribes_Call {
-	return &Database_GetListedTribes_Call{Call: _e.mock.On("GetListedTribes", r)}
+// ProcessDeleteWorkspace is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) ProcessDeleteWorkspace(workspace_uuid interface{}) *Database_ProcessDeleteWorkspace_Call {
+	return &Database_ProcessDeleteWorkspace_Call{Call: _e.mock.On("ProcessDeleteWorkspace", workspace_uuid)}
 }
 
-func (_c *Database_GetListedTribes_Call) Run(run func(r *http.Request)) *Database_GetListedTribes_Call {
+func (_c *Database_ProcessDeleteWorkspace_Call) Run(run func(workspace_uuid string)) *Database_ProcessDeleteWorkspace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedTribes_Call) Return(_a0 []db.Tribe) *Database_GetListedTribes_Call {
+func (_c *Database_ProcessDeleteWorkspace_Call) Return(_a0 error) *Database_ProcessDeleteWorkspace_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetListedTribes_Call) RunAndReturn(run func(*http.Request) []db.Tribe) *Database_GetListedTribes_Call {
+func (_c *Database_ProcessDeleteWorkspace_Call) RunAndReturn(run func(string) error) *Database_ProcessDeleteWorkspace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLnUser provides a mock function with given fields: lnKey
-func (_m *Database) GetLnUser(lnKey string) int64 {
-	ret := _m.Called(lnKey)
+// ProcessEscrowPayment provides a mock function with given fields: payment, bounty, escrow
+func (_m *Database) ProcessEscrowPayment(payment db.NewPaymentHistory, bounty db.NewBounty, escrow db.BountyEscrow) error {
+	ret := _m.Called(payment, bounty, escrow)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLnUser")
+		panic("no return value specified for ProcessEscrowPayment")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(lnKey)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory, db.NewBounty, db.BountyEscrow) error); ok {
+		r0 = rf(payment, bounty, escrow)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetLnUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLnUser'
-type Database_GetLnUser_Call struct {
+// Database_ProcessEscrowPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessEscrowPayment'
+type Database_ProcessEscrowPayment_Call struct {
 	*mock.Call
 }
 
-// GetLnUser is a helper method to define mock.On call
-//   - lnKey string
-func (_e *Database_Expecter) GetLnUser(lnKey interface{}) *Database_GetLnUser_Call {
-	return &Database_GetLnUser_Call{Call: _e.mock.On("GetLnUser", lnKey)}
+// ProcessEscrowPayment is a helper method to define mock.On call
+//   - payment db.NewPaymentHistory
+//   - bounty db.NewBounty
+//   - escrow db.BountyEscrow
+func (_e *Database_Expecter) ProcessEscrowPayment(payment interface{}, bounty interface{}, escrow interface{}) *Database_ProcessEscrowPayment_Call {
+	return &Database_ProcessEscrowPayment_Call{Call: _e.mock.On("ProcessEscrowPayment", payment, bounty, escrow)}
 }
 
-func (_c *Database_GetLnUser_Call) Run(run func(lnKey string)) *Database_GetLnUser_Call {
+func (_c *Database_ProcessEscrowPayment_Call) Run(run func(payment db.NewPaymentHistory, bounty db.NewBounty, escrow db.BountyEscrow)) *Database_ProcessEscrowPayment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.NewPaymentHistory), args[1].(db.NewBounty), args[2].(db.BountyEscrow))
 	})
 	return _c
 }
 
-func (_c *Database_GetLnUser_Call) Return(_a0 int64) *Database_GetLnUser_Call {
+func (_c *Database_ProcessEscrowPayment_Call) Return(_a0 error) *Database_ProcessEscrowPayment_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetLnUser_Call) RunAndReturn(run func(string) int64) *Database_GetLnUser_Call {
+func (_c *Database_ProcessEscrowPayment_Call) RunAndReturn(run func(db.NewPaymentHistory, db.NewBounty, db.BountyEscrow) error) *Database_ProcessEscrowPayment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNextBountyByCreated provides a mock function with given fields: r
-func (_m *Database) GetNextBountyByCreated(r *http.Request) (uint, error) {
-	ret := _m.Called(r)
+// ProcessUpdateBudget provides a mock function with given fields: invoice
+func (_m *Database) ProcessUpdateBudget(invoice db.NewInvoiceList) error {
+	ret := _m.Called(invoice)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNextBountyByCreated")
-	}
-
-	var r0 uint
-	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
-		return rf(r)
-	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
-		r0 = rf(r)
-	} else {
-		r0 = ret.Get(0).(uint)
+		panic("no return value specified for ProcessUpdateBudget")
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewInvoiceList) error); ok {
+		r0 = rf(invoice)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetNextBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextBountyByCreated'
-type Database_GetNextBountyByCreated_Call struct {
+// Database_ProcessUpdateBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessUpdateBudget'
+type Database_ProcessUpdateBudget_Call struct {
 	*mock.Call
 }
 
-// GetNextBountyByCreated is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetNextBountyByCreated(r interface{}) *Database_GetNextBountyByCreated_Call {
-	return &Database_GetNextBountyByCreated_Call{Call: _e.mock.On("GetNextBountyByCreated", r)}
+// ProcessUpdateBudget is a helper method to define mock.On call
+//   - invoice db.NewInvoiceList
+func (_e *Database_Expecter) ProcessUpdateBudget(invoice interface{}) *Database_ProcessUpdateBudget_Call {
+	return &Database_ProcessUpdateBudget_Call{Call: _e.mock.On("ProcessUpdateBudget", invoice)}
 }
 
-func (_c *Database_GetNextBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetNextBountyByCreated_Call {
+func (_c *Database_ProcessUpdateBudget_Call) Run(run func(invoice db.NewInvoiceList)) *Database_ProcessUpdateBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.NewInvoiceList))
 	})
 	return _c
 }
 
-func (_c *Database_GetNextBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetNextBountyByCreated_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_ProcessUpdateBudget_Call) Return(_a0 error) *Database_ProcessUpdateBudget_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetNextBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetNextBountyByCreated_Call {
+func (_c *Database_ProcessUpdateBudget_Call) RunAndReturn(run func(db.NewInvoiceList) error) *Database_ProcessUpdateBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNextWorkspaceBountyByCreated provides a mock function with given fields: r
-func (_m *Database) GetNextWorkspaceBountyByCreated(r *http.Request) (uint, error) {
-	ret := _m.Called(r)
+// PurgeAccountData provides a mock function with given fields: pubkey
+func (_m *Database) PurgeAccountData(pubkey string) error {
+	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNextWorkspaceBountyByCreated")
-	}
-
-	var r0 uint
-	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
-		return rf(r)
-	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
-		r0 = rf(r)
-	} else {
-		r0 = ret.Get(0).(uint)
+		panic("no return value specified for PurgeAccountData")
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(pubkey)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetNextWorkspaceBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextWorkspaceBountyByCreated'
-type Database_GetNextWorkspaceBountyByCreated_Call struct {
+// Database_PurgeAccountData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeAccountData'
+type Database_PurgeAccountData_Call struct {
 	*mock.Call
 }
 
-// GetNextWorkspaceBountyByCreated is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetNextWorkspaceBountyByCreated(r interface{}) *Database_GetNextWorkspaceBountyByCreated_Call {
-	return &Database_GetNextWorkspaceBountyByCreated_Call{Call: _e.mock.On("GetNextWorkspaceBountyByCreated", r)}
+// PurgeAccountData is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) PurgeAccountData(pubkey interface{}) *Database_PurgeAccountData_Call {
+	return &Database_PurgeAccountData_Call{Call: _e.mock.On("PurgeAccountData", pubkey)}
 }
 
-func (_c *Database_GetNextWorkspaceBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetNextWorkspaceBountyByCreated_Call {
+func (_c *Database_PurgeAccountData_Call) Run(run func(pubkey string)) *Database_PurgeAccountData_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetNextWorkspaceBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetNextWorkspaceBountyByCreated_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_PurgeAccountData_Call) Return(_a0 error) *Database_PurgeAccountData_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetNextWorkspaceBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetNextWorkspaceBountyByCreated_Call {
+func (_c *Database_PurgeAccountData_Call) RunAndReturn(run func(string) error) *Database_PurgeAccountData_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOpenGithubIssues provides a mock function with given fields: r
-func (_m *Database) GetOpenGithubIssues(r *http.Request) (int64, error) {
-	ret := _m.Called(r)
+// RecordEscrowPayout provides a mock function with given fields: m, paymentHash, preimage
+func (_m *Database) RecordEscrowPayout(m db.BountyEscrow, paymentHash string, preimage string) (db.BountyEscrow, error) {
+	ret := _m.Called(m, paymentHash, preimage)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOpenGithubIssues")
+		panic("no return value specified for RecordEscrowPayout")
 	}
 
-	var r0 int64
+	var r0 db.BountyEscrow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (int64, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(db.BountyEscrow, string, string) (db.BountyEscrow, error)); ok {
+		return rf(m, paymentHash, preimage)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(db.BountyEscrow, string, string) db.BountyEscrow); ok {
+		r0 = rf(m, paymentHash, preimage)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.BountyEscrow)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(db.BountyEscrow, string, string) error); ok {
+		r1 = rf(m, paymentHash, preimage)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4128,477 +19698,521 @@ func (_m *Database) GetOpenGithubIssues(r *http.Request) (int64, error) {
 	return r0, r1
 }
 
-// Database_GetOpenGithubIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenGithubIssues'
-type Database_GetOpenGithubIssues_Call struct {
+// Database_RecordEscrowPayout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordEscrowPayout'
+type Database_RecordEscrowPayout_Call struct {
 	*mock.Call
 }
 
-// GetOpenGithubIssues is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetOpenGithubIssues(r interface{}) *Database_GetOpenGithubIssues_Call {
-	return &Database_GetOpenGithubIssues_Call{Call: _e.mock.On("GetOpenGithubIssues", r)}
+// RecordEscrowPayout is a helper method to define mock.On call
+//   - m db.BountyEscrow
+//   - paymentHash string
+//   - preimage string
+func (_e *Database_Expecter) RecordEscrowPayout(m interface{}, paymentHash interface{}, preimage interface{}) *Database_RecordEscrowPayout_Call {
+	return &Database_RecordEscrowPayout_Call{Call: _e.mock.On("RecordEscrowPayout", m, paymentHash, preimage)}
 }
 
-func (_c *Database_GetOpenGithubIssues_Call) Run(run func(r *http.Request)) *Database_GetOpenGithubIssues_Call {
+func (_c *Database_RecordEscrowPayout_Call) Run(run func(m db.BountyEscrow, paymentHash string, preimage string)) *Database_RecordEscrowPayout_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.BountyEscrow), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetOpenGithubIssues_Call) Return(_a0 int64, _a1 error) *Database_GetOpenGithubIssues_Call {
+func (_c *Database_RecordEscrowPayout_Call) Return(_a0 db.BountyEscrow, _a1 error) *Database_RecordEscrowPayout_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetOpenGithubIssues_Call) RunAndReturn(run func(*http.Request) (int64, error)) *Database_GetOpenGithubIssues_Call {
+func (_c *Database_RecordEscrowPayout_Call) RunAndReturn(run func(db.BountyEscrow, string, string) (db.BountyEscrow, error)) *Database_RecordEscrowPayout_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPaymentHistory provides a mock function with given fields: workspace_uuid, r
-func (_m *Database) GetPaymentHistory(workspace_uuid string, r *http.Request) []db.NewPaymentHistory {
-	ret := _m.Called(workspace_uuid, r)
+// RedeemTribeInvite provides a mock function with given fields: invite, pubkey, alias
+func (_m *Database) RedeemTribeInvite(invite db.TribeInvite, pubkey string, alias string) (db.TribeInviteRedemption, error) {
+	ret := _m.Called(invite, pubkey, alias)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPaymentHistory")
+		panic("no return value specified for RedeemTribeInvite")
 	}
 
-	var r0 []db.NewPaymentHistory
-	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.NewPaymentHistory); ok {
-		r0 = rf(workspace_uuid, r)
+	var r0 db.TribeInviteRedemption
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TribeInvite, string, string) (db.TribeInviteRedemption, error)); ok {
+		return rf(invite, pubkey, alias)
+	}
+	if rf, ok := ret.Get(0).(func(db.TribeInvite, string, string) db.TribeInviteRedemption); ok {
+		r0 = rf(invite, pubkey, alias)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewPaymentHistory)
-		}
+		r0 = ret.Get(0).(db.TribeInviteRedemption)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.TribeInvite, string, string) error); ok {
+		r1 = rf(invite, pubkey, alias)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetPaymentHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentHistory'
-type Database_GetPaymentHistory_Call struct {
+// Database_RedeemTribeInvite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RedeemTribeInvite'
+type Database_RedeemTribeInvite_Call struct {
 	*mock.Call
 }
 
-// GetPaymentHistory is a helper method to define mock.On call
-//   - workspace_uuid string
-//   - r *http.Request
-func (_e *Database_Expecter) GetPaymentHistory(workspace_uuid interface{}, r interface{}) *Database_GetPaymentHistory_Call {
-	return &Database_GetPaymentHistory_Call{Call: _e.mock.On("GetPaymentHistory", workspace_uuid, r)}
+// RedeemTribeInvite is a helper method to define mock.On call
+//   - invite db.TribeInvite
+//   - pubkey string
+//   - alias string
+func (_e *Database_Expecter) RedeemTribeInvite(invite interface{}, pubkey interface{}, alias interface{}) *Database_RedeemTribeInvite_Call {
+	return &Database_RedeemTribeInvite_Call{Call: _e.mock.On("RedeemTribeInvite", invite, pubkey, alias)}
 }
 
-func (_c *Database_GetPaymentHistory_Call) Run(run func(workspace_uuid string, r *http.Request)) *Database_GetPaymentHistory_Call {
+func (_c *Database_RedeemTribeInvite_Call) Run(run func(invite db.TribeInvite, pubkey string, alias string)) *Database_RedeemTribeInvite_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(*http.Request))
+		run(args[0].(db.TribeInvite), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPaymentHistory_Call) Return(_a0 []db.NewPaymentHistory) *Database_GetPaymentHistory_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_RedeemTribeInvite_Call) Return(_a0 db.TribeInviteRedemption, _a1 error) *Database_RedeemTribeInvite_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPaymentHistory_Call) RunAndReturn(run func(string, *http.Request) []db.NewPaymentHistory) *Database_GetPaymentHistory_Call {
+func (_c *Database_RedeemTribeInvite_Call) RunAndReturn(run func(db.TribeInvite, string, string) (db.TribeInviteRedemption, error)) *Database_RedeemTribeInvite_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPaymentHistoryByCreated provides a mock function with given fields: created, workspace_uuid
-func (_m *Database) GetPaymentHistoryByCreated(created *time.Time, workspace_uuid string) db.NewPaymentHistory {
-	ret := _m.Called(created, workspace_uuid)
+// ReleaseInvoice provides a mock function with given fields: payment_request
+func (_m *Database) ReleaseInvoice(payment_request string) error {
+	ret := _m.Called(payment_request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPaymentHistoryByCreated")
+		panic("no return value specified for ReleaseInvoice")
 	}
 
-	var r0 db.NewPaymentHistory
-	if rf, ok := ret.Get(0).(func(*time.Time, string) db.NewPaymentHistory); ok {
-		r0 = rf(created, workspace_uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(payment_request)
 	} else {
-		r0 = ret.Get(0).(db.NewPaymentHistory)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPaymentHistoryByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentHistoryByCreated'
-type Database_GetPaymentHistoryByCreated_Call struct {
+// Database_ReleaseInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseInvoice'
+type Database_ReleaseInvoice_Call struct {
 	*mock.Call
 }
 
-// GetPaymentHistoryByCreated is a helper method to define mock.On call
-//   - created *time.Time
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetPaymentHistoryByCreated(created interface{}, workspace_uuid interface{}) *Database_GetPaymentHistoryByCreated_Call {
-	return &Database_GetPaymentHistoryByCreated_Call{Call: _e.mock.On("GetPaymentHistoryByCreated", created, workspace_uuid)}
+// ReleaseInvoice is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) ReleaseInvoice(payment_request interface{}) *Database_ReleaseInvoice_Call {
+	return &Database_ReleaseInvoice_Call{Call: _e.mock.On("ReleaseInvoice", payment_request)}
 }
 
-func (_c *Database_GetPaymentHistoryByCreated_Call) Run(run func(created *time.Time, workspace_uuid string)) *Database_GetPaymentHistoryByCreated_Call {
+func (_c *Database_ReleaseInvoice_Call) Run(run func(payment_request string)) *Database_ReleaseInvoice_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*time.Time), args[1].(string))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPaymentHistoryByCreated_Call) Return(_a0 db.NewPaymentHistory) *Database_GetPaymentHistoryByCreated_Call {
+func (_c *Database_ReleaseInvoice_Call) Return(_a0 error) *Database_ReleaseInvoice_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPaymentHistoryByCreated_Call) RunAndReturn(run func(*time.Time, string) db.NewPaymentHistory) *Database_GetPaymentHistoryByCreated_Call {
+func (_c *Database_ReleaseInvoice_Call) RunAndReturn(run func(string) error) *Database_ReleaseInvoice_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPeopleBySearch provides a mock function with given fields: r
-func (_m *Database) GetPeopleBySearch(r *http.Request) []db.Person {
-	ret := _m.Called(r)
+// RenameAuthSession provides a mock function with given fields: pubkey, uuid, deviceName
+func (_m *Database) RenameAuthSession(pubkey string, uuid string, deviceName string) error {
+	ret := _m.Called(pubkey, uuid, deviceName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPeopleBySearch")
+		panic("no return value specified for RenameAuthSession")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Person); ok {
-		r0 = rf(r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(pubkey, uuid, deviceName)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPeopleBySearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleBySearch'
-type Database_GetPeopleBySearch_Call struct {
+// Database_RenameAuthSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameAuthSession'
+type Database_RenameAuthSession_Call struct {
 	*mock.Call
 }
 
-// GetPeopleBySearch is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetPeopleBySearch(r interface{}) *Database_GetPeopleBySearch_Call {
-	return &Database_GetPeopleBySearch_Call{Call: _e.mock.On("GetPeopleBySearch", r)}
+// RenameAuthSession is a helper method to define mock.On call
+//   - pubkey string
+//   - uuid string
+//   - deviceName string
+func (_e *Database_Expecter) RenameAuthSession(pubkey interface{}, uuid interface{}, deviceName interface{}) *Database_RenameAuthSession_Call {
+	return &Database_RenameAuthSession_Call{Call: _e.mock.On("RenameAuthSession", pubkey, uuid, deviceName)}
 }
 
-func (_c *Database_GetPeopleBySearch_Call) Run(run func(r *http.Request)) *Database_GetPeopleBySearch_Call {
+func (_c *Database_RenameAuthSession_Call) Run(run func(pubkey string, uuid string, deviceName string)) *Database_RenameAuthSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPeopleBySearch_Call) Return(_a0 []db.Person) *Database_GetPeopleBySearch_Call {
+func (_c *Database_RenameAuthSession_Call) Return(_a0 error) *Database_RenameAuthSession_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPeopleBySearch_Call) RunAndReturn(run func(*http.Request) []db.Person) *Database_GetPeopleBySearch_Call {
+func (_c *Database_RenameAuthSession_Call) RunAndReturn(run func(string, string, string) error) *Database_RenameAuthSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPeopleListShort provides a mock function with given fields: count
-func (_m *Database) GetPeopleListShort(count uint32) *[]db.PersonInShort {
-	ret := _m.Called(count)
+// ReorderChannels provides a mock function with given fields: tribe_uuid, ids
+func (_m *Database) ReorderChannels(tribe_uuid string, ids []uint) error {
+	ret := _m.Called(tribe_uuid, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPeopleListShort")
-	}
-
-	var r0 *[]db.PersonInShort
-	if rf, ok := ret.Get(0).(func(uint32) *[]db.PersonInShort); ok {
-		r0 = rf(count)
+		panic("no return value specified for ReorderChannels")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []uint) error); ok {
+		r0 = rf(tribe_uuid, ids)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*[]db.PersonInShort)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPeopleListShort_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleListShort'
-type Database_GetPeopleListShort_Call struct {
+// Database_ReorderChannels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReorderChannels'
+type Database_ReorderChannels_Call struct {
 	*mock.Call
 }
 
-// GetPeopleListShort is a helper method to define mock.On call
-//   - count uint32
-func (_e *Database_Expecter) GetPeopleListShort(count interface{}) *Database_GetPeopleListShort_Call {
-	return &Database_GetPeopleListShort_Call{Call: _e.mock.On("GetPeopleListShort", count)}
+// ReorderChannels is a helper method to define mock.On call
+//   - tribe_uuid string
+//   - ids []uint
+func (_e *Database_Expecter) ReorderChannels(tribe_uuid interface{}, ids interface{}) *Database_ReorderChannels_Call {
+	return &Database_ReorderChannels_Call{Call: _e.mock.On("ReorderChannels", tribe_uuid, ids)}
 }
 
-func (_c *Database_GetPeopleListShort_Call) Run(run func(count uint32)) *Database_GetPeopleListShort_Call {
+func (_c *Database_ReorderChannels_Call) Run(run func(tribe_uuid string, ids []uint)) *Database_ReorderChannels_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint32))
+		run(args[0].(string), args[1].([]uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetPeopleListShort_Call) Return(_a0 *[]db.PersonInShort) *Database_GetPeopleListShort_Call {
+func (_c *Database_ReorderChannels_Call) Return(_a0 error) *Database_ReorderChannels_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPeopleListShort_Call) RunAndReturn(run func(uint32) *[]db.PersonInShort) *Database_GetPeopleListShort_Call {
+func (_c *Database_ReorderChannels_Call) RunAndReturn(run func(string, []uint) error) *Database_ReorderChannels_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPerson provides a mock function with given fields: id
-func (_m *Database) GetPerson(id uint) db.Person {
-	ret := _m.Called(id)
+// ReorderFeaturedTribes provides a mock function with given fields: uuids
+func (_m *Database) ReorderFeaturedTribes(uuids []string) error {
+	ret := _m.Called(uuids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPerson")
+		panic("no return value specified for ReorderFeaturedTribes")
 	}
 
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(uint) db.Person); ok {
-		r0 = rf(id)
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string) error); ok {
+		r0 = rf(uuids)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPerson'
-type Database_GetPerson_Call struct {
+// Database_ReorderFeaturedTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReorderFeaturedTribes'
+type Database_ReorderFeaturedTribes_Call struct {
 	*mock.Call
 }
 
-// GetPerson is a helper method to define mock.On call
-//   - id uint
-func (_e *Database_Expecter) GetPerson(id interface{}) *Database_GetPerson_Call {
-	return &Database_GetPerson_Call{Call: _e.mock.On("GetPerson", id)}
+// ReorderFeaturedTribes is a helper method to define mock.On call
+//   - uuids []string
+func (_e *Database_Expecter) ReorderFeaturedTribes(uuids interface{}) *Database_ReorderFeaturedTribes_Call {
+	return &Database_ReorderFeaturedTribes_Call{Call: _e.mock.On("ReorderFeaturedTribes", uuids)}
 }
 
-func (_c *Database_GetPerson_Call) Run(run func(id uint)) *Database_GetPerson_Call {
+func (_c *Database_ReorderFeaturedTribes_Call) Run(run func(uuids []string)) *Database_ReorderFeaturedTribes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].([]string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPerson_Call) Return(_a0 db.Person) *Database_GetPerson_Call {
+func (_c *Database_ReorderFeaturedTribes_Call) Return(_a0 error) *Database_ReorderFeaturedTribes_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPerson_Call) RunAndReturn(run func(uint) db.Person) *Database_GetPerson_Call {
+func (_c *Database_ReorderFeaturedTribes_Call) RunAndReturn(run func([]string) error) *Database_ReorderFeaturedTribes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPersonByGithubName provides a mock function with given fields: github_name
-func (_m *Database) GetPersonByGithubName(github_name string) db.Person {
-	ret := _m.Called(github_name)
+// ReplaceLeaderboard provides a mock function with given fields: kind, window, entries
+func (_m *Database) ReplaceLeaderboard(kind db.LeaderboardKind, window db.LeaderboardWindow, entries []db.LeaderboardEntry) error {
+	ret := _m.Called(kind, window, entries)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPersonByGithubName")
+		panic("no return value specified for ReplaceLeaderboard")
 	}
 
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(github_name)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.LeaderboardKind, db.LeaderboardWindow, []db.LeaderboardEntry) error); ok {
+		r0 = rf(kind, window, entries)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPersonByGithubName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByGithubName'
-type Database_GetPersonByGithubName_Call struct {
+// Database_ReplaceLeaderboard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceLeaderboard'
+type Database_ReplaceLeaderboard_Call struct {
 	*mock.Call
 }
 
-// GetPersonByGithubName is a helper method to define mock.On call
-//   - github_name string
-func (_e *Database_Expecter) GetPersonByGithubName(github_name interface{}) *Database_GetPersonByGithubName_Call {
-	return &Database_GetPersonByGithubName_Call{Call: _e.mock.On("GetPersonByGithubName", github_name)}
+// ReplaceLeaderboard is a helper method to define mock.On call
+//   - kind db.LeaderboardKind
+//   - window db.LeaderboardWindow
+//   - entries []db.LeaderboardEntry
+func (_e *Database_Expecter) ReplaceLeaderboard(kind interface{}, window interface{}, entries interface{}) *Database_ReplaceLeaderboard_Call {
+	return &Database_ReplaceLeaderboard_Call{Call: _e.mock.On("ReplaceLeaderboard", kind, window, entries)}
 }
 
-func (_c *Database_GetPersonByGithubName_Call) Run(run func(github_name string)) *Database_GetPersonByGithubName_Call {
+func (_c *Database_ReplaceLeaderboard_Call) Run(run func(kind db.LeaderboardKind, window db.LeaderboardWindow, entries []db.LeaderboardEntry)) *Database_ReplaceLeaderboard_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.LeaderboardKind), args[1].(db.LeaderboardWindow), args[2].([]db.LeaderboardEntry))
 	})
 	return _c
 }
 
-func (_c *Database_GetPersonByGithubName_Call) Return(_a0 db.Person) *Database_GetPersonByGithubName_Call {
+func (_c *Database_ReplaceLeaderboard_Call) Return(_a0 error) *Database_ReplaceLeaderboard_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPersonByGithubName_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByGithubName_Call {
+func (_c *Database_ReplaceLeaderboard_Call) RunAndReturn(run func(db.LeaderboardKind, db.LeaderboardWindow, []db.LeaderboardEntry) error) *Database_ReplaceLeaderboard_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPersonByPubkey provides a mock function with given fields: pubkey
-func (_m *Database) GetPersonByPubkey(pubkey string) db.Person {
-	ret := _m.Called(pubkey)
+// ResolveContentAppeal provides a mock function with given fields: m, report
+func (_m *Database) ResolveContentAppeal(m db.ContentAppeal, report db.ContentReport) (db.ContentAppeal, error) {
+	ret := _m.Called(m, report)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPersonByPubkey")
+		panic("no return value specified for ResolveContentAppeal")
 	}
 
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(pubkey)
+	var r0 db.ContentAppeal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.ContentAppeal, db.ContentReport) (db.ContentAppeal, error)); ok {
+		return rf(m, report)
+	}
+	if rf, ok := ret.Get(0).(func(db.ContentAppeal, db.ContentReport) db.ContentAppeal); ok {
+		r0 = rf(m, report)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Get(0).(db.ContentAppeal)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.ContentAppeal, db.ContentReport) error); ok {
+		r1 = rf(m, report)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetPersonByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByPubkey'
-type Database_GetPersonByPubkey_Call struct {
+// Database_ResolveContentAppeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveContentAppeal'
+type Database_ResolveContentAppeal_Call struct {
 	*mock.Call
 }
 
-// GetPersonByPubkey is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetPersonByPubkey(pubkey interface{}) *Database_GetPersonByPubkey_Call {
-	return &Database_GetPersonByPubkey_Call{Call: _e.mock.On("GetPersonByPubkey", pubkey)}
+// ResolveContentAppeal is a helper method to define mock.On call
+//   - m db.ContentAppeal
+//   - report db.ContentReport
+func (_e *Database_Expecter) ResolveContentAppeal(m interface{}, report interface{}) *Database_ResolveContentAppeal_Call {
+	return &Database_ResolveContentAppeal_Call{Call: _e.mock.On("ResolveContentAppeal", m, report)}
 }
 
-func (_c *Database_GetPersonByPubkey_Call) Run(run func(pubkey string)) *Database_GetPersonByPubkey_Call {
+func (_c *Database_ResolveContentAppeal_Call) Run(run func(m db.ContentAppeal, report db.ContentReport)) *Database_ResolveContentAppeal_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.ContentAppeal), args[1].(db.ContentReport))
 	})
 	return _c
 }
 
-func (_c *Database_GetPersonByPubkey_Call) Return(_a0 db.Person) *Database_GetPersonByPubkey_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ResolveContentAppeal_Call) Return(_a0 db.ContentAppeal, _a1 error) *Database_ResolveContentAppeal_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPersonByPubkey_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByPubkey_Call {
+func (_c *Database_ResolveContentAppeal_Call) RunAndReturn(run func(db.ContentAppeal, db.ContentReport) (db.ContentAppeal, error)) *Database_ResolveContentAppeal_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPersonByUniqueName provides a mock function with given fields: un
-func (_m *Database) GetPersonByUniqueName(un string) db.Person {
-	ret := _m.Called(un)
+// ResolveContentReport provides a mock function with given fields: m
+func (_m *Database) ResolveContentReport(m db.ContentReport) (db.ContentReport, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPersonByUniqueName")
+		panic("no return value specified for ResolveContentReport")
 	}
 
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(un)
+	var r0 db.ContentReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.ContentReport) (db.ContentReport, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.ContentReport) db.ContentReport); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Get(0).(db.ContentReport)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.ContentReport) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetPersonByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByUniqueName'
-type Database_GetPersonByUniqueName_Call struct {
+// Database_ResolveContentReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveContentReport'
+type Database_ResolveContentReport_Call struct {
 	*mock.Call
 }
 
-// GetPersonByUniqueName is a helper method to define mock.On call
-//   - un string
-func (_e *Database_Expecter) GetPersonByUniqueName(un interface{}) *Database_GetPersonByUniqueName_Call {
-	return &Database_GetPersonByUniqueName_Call{Call: _e.mock.On("GetPersonByUniqueName", un)}
+// ResolveContentReport is a helper method to define mock.On call
+//   - m db.ContentReport
+func (_e *Database_Expecter) ResolveContentReport(m interface{}) *Database_ResolveContentReport_Call {
+	return &Database_ResolveContentReport_Call{Call: _e.mock.On("ResolveContentReport", m)}
 }
 
-func (_c *Database_GetPersonByUniqueName_Call) Run(run func(un string)) *Database_GetPersonByUniqueName_Call {
+func (_c *Database_ResolveContentReport_Call) Run(run func(m db.ContentReport)) *Database_ResolveContentReport_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.ContentReport))
 	})
 	return _c
 }
 
-func (_c *Database_GetPersonByUniqueName_Call) Return(_a0 db.Person) *Database_GetPersonByUniqueName_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ResolveContentReport_Call) Return(_a0 db.ContentReport, _a1 error) *Database_ResolveContentReport_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPersonByUniqueName_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByUniqueName_Call {
+func (_c *Database_ResolveContentReport_Call) RunAndReturn(run func(db.ContentReport) (db.ContentReport, error)) *Database_ResolveContentReport_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPersonByUuid provides a mock function with given fields: uuid
-func (_m *Database) GetPersonByUuid(uuid string) db.Person {
-	ret := _m.Called(uuid)
+// ResolveWorkspaceTransfer provides a mock function with given fields: uuid, status, actor
+func (_m *Database) ResolveWorkspaceTransfer(uuid string, status db.WorkspaceTransferStatus, actor string) (db.WorkspaceTransfer, error) {
+	ret := _m.Called(uuid, status, actor)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPersonByUuid")
+		panic("no return value specified for ResolveWorkspaceTransfer")
 	}
 
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(uuid)
+	var r0 db.WorkspaceTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, db.WorkspaceTransferStatus, string) (db.WorkspaceTransfer, error)); ok {
+		return rf(uuid, status, actor)
+	}
+	if rf, ok := ret.Get(0).(func(string, db.WorkspaceTransferStatus, string) db.WorkspaceTransfer); ok {
+		r0 = rf(uuid, status, actor)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Get(0).(db.WorkspaceTransfer)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, db.WorkspaceTransferStatus, string) error); ok {
+		r1 = rf(uuid, status, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetPersonByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByUuid'
-type Database_GetPersonByUuid_Call struct {
+// Database_ResolveWorkspaceTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveWorkspaceTransfer'
+type Database_ResolveWorkspaceTransfer_Call struct {
 	*mock.Call
 }
 
-// GetPersonByUuid is a helper method to define mock.On call
+// ResolveWorkspaceTransfer is a helper method to define mock.On call
 //   - uuid string
-func (_e *Database_Expecter) GetPersonByUuid(uuid interface{}) *Database_GetPersonByUuid_Call {
-	return &Database_GetPersonByUuid_Call{Call: _e.mock.On("GetPersonByUuid", uuid)}
+//   - status db.WorkspaceTransferStatus
+//   - actor string
+func (_e *Database_Expecter) ResolveWorkspaceTransfer(uuid interface{}, status interface{}, actor interface{}) *Database_ResolveWorkspaceTransfer_Call {
+	return &Database_ResolveWorkspaceTransfer_Call{Call: _e.mock.On("ResolveWorkspaceTransfer", uuid, status, actor)}
 }
 
-func (_c *Database_GetPersonByUuid_Call) Run(run func(uuid string)) *Database_GetPersonByUuid_Call {
+func (_c *Database_ResolveWorkspaceTransfer_Call) Run(run func(uuid string, status db.WorkspaceTransferStatus, actor string)) *Database_ResolveWorkspaceTransfer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(db.WorkspaceTransferStatus), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPersonByUuid_Call) Return(_a0 db.Person) *Database_GetPersonByUuid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ResolveWorkspaceTransfer_Call) Return(_a0 db.WorkspaceTransfer, _a1 error) *Database_ResolveWorkspaceTransfer_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPersonByUuid_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByUuid_Call {
+func (_c *Database_ResolveWorkspaceTransfer_Call) RunAndReturn(run func(string, db.WorkspaceTransferStatus, string) (db.WorkspaceTransfer, error)) *Database_ResolveWorkspaceTransfer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPhaseByUuid provides a mock function with given fields: phaseUuid
-func (_m *Database) GetPhaseByUuid(phaseUuid string) (db.FeaturePhase, error) {
-	ret := _m.Called(phaseUuid)
+// ReviewBountyProof provides a mock function with given fields: m
+func (_m *Database) ReviewBountyProof(m db.BountyProof) (db.BountyProof, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPhaseByUuid")
+		panic("no return value specified for ReviewBountyProof")
 	}
 
-	var r0 db.FeaturePhase
+	var r0 db.BountyProof
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (db.FeaturePhase, error)); ok {
-		return rf(phaseUuid)
+	if rf, ok := ret.Get(0).(func(db.BountyProof) (db.BountyProof, error)); ok {
+		return rf(m)
 	}
-	if rf, ok := ret.Get(0).(func(string) db.FeaturePhase); ok {
-		r0 = rf(phaseUuid)
+	if rf, ok := ret.Get(0).(func(db.BountyProof) db.BountyProof); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(db.FeaturePhase)
+		r0 = ret.Get(0).(db.BountyProof)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(phaseUuid)
+	if rf, ok := ret.Get(1).(func(db.BountyProof) error); ok {
+		r1 = rf(m)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4606,1246 +20220,1301 @@ func (_m *Database) GetPhaseByUuid(phaseUuid string) (db.FeaturePhase, error) {
 	return r0, r1
 }
 
-// Database_GetPhaseByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhaseByUuid'
-type Database_GetPhaseByUuid_Call struct {
+// Database_ReviewBountyProof_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReviewBountyProof'
+type Database_ReviewBountyProof_Call struct {
 	*mock.Call
 }
 
-// GetPhaseByUuid is a helper method to define mock.On call
-//   - phaseUuid string
-func (_e *Database_Expecter) GetPhaseByUuid(phaseUuid interface{}) *Database_GetPhaseByUuid_Call {
-	return &Database_GetPhaseByUuid_Call{Call: _e.mock.On("GetPhaseByUuid", phaseUuid)}
+// ReviewBountyProof is a helper method to define mock.On call
+//   - m db.BountyProof
+func (_e *Database_Expecter) ReviewBountyProof(m interface{}) *Database_ReviewBountyProof_Call {
+	return &Database_ReviewBountyProof_Call{Call: _e.mock.On("ReviewBountyProof", m)}
 }
 
-func (_c *Database_GetPhaseByUuid_Call) Run(run func(phaseUuid string)) *Database_GetPhaseByUuid_Call {
+func (_c *Database_ReviewBountyProof_Call) Run(run func(m db.BountyProof)) *Database_ReviewBountyProof_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.BountyProof))
 	})
 	return _c
 }
 
-func (_c *Database_GetPhaseByUuid_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_GetPhaseByUuid_Call {
+func (_c *Database_ReviewBountyProof_Call) Return(_a0 db.BountyProof, _a1 error) *Database_ReviewBountyProof_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPhaseByUuid_Call) RunAndReturn(run func(string) (db.FeaturePhase, error)) *Database_GetPhaseByUuid_Call {
+func (_c *Database_ReviewBountyProof_Call) RunAndReturn(run func(db.BountyProof) (db.BountyProof, error)) *Database_ReviewBountyProof_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPhasesByFeatureUuid provides a mock function with given fields: featureUuid
-func (_m *Database) GetPhasesByFeatureUuid(featureUuid string) []db.FeaturePhase {
-	ret := _m.Called(featureUuid)
+// ReviewListing provides a mock function with given fields: targetType, uuid, approve
+func (_m *Database) ReviewListing(targetType db.ReportTargetType, uuid string, approve bool) error {
+	ret := _m.Called(targetType, uuid, approve)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPhasesByFeatureUuid")
+		panic("no return value specified for ReviewListing")
 	}
 
-	var r0 []db.FeaturePhase
-	if rf, ok := ret.Get(0).(func(string) []db.FeaturePhase); ok {
-		r0 = rf(featureUuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.ReportTargetType, string, bool) error); ok {
+		r0 = rf(targetType, uuid, approve)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.FeaturePhase)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPhasesByFeatureUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhasesByFeatureUuid'
-type Database_GetPhasesByFeatureUuid_Call struct {
+// Database_ReviewListing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReviewListing'
+type Database_ReviewListing_Call struct {
 	*mock.Call
 }
 
-// GetPhasesByFeatureUuid is a helper method to define mock.On call
-//   - featureUuid string
-func (_e *Database_Expecter) GetPhasesByFeatureUuid(featureUuid interface{}) *Database_GetPhasesByFeatureUuid_Call {
-	return &Database_GetPhasesByFeatureUuid_Call{Call: _e.mock.On("GetPhasesByFeatureUuid", featureUuid)}
+// ReviewListing is a helper method to define mock.On call
+//   - targetType db.ReportTargetType
+//   - uuid string
+//   - approve bool
+func (_e *Database_Expecter) ReviewListing(targetType interface{}, uuid interface{}, approve interface{}) *Database_ReviewListing_Call {
+	return &Database_ReviewListing_Call{Call: _e.mock.On("ReviewListing", targetType, uuid, approve)}
 }
 
-func (_c *Database_GetPhasesByFeatureUuid_Call) Run(run func(featureUuid string)) *Database_GetPhasesByFeatureUuid_Call {
+func (_c *Database_ReviewListing_Call) Run(run func(targetType db.ReportTargetType, uuid string, approve bool)) *Database_ReviewListing_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.ReportTargetType), args[1].(string), args[2].(bool))
 	})
 	return _c
 }
 
-func (_c *Database_GetPhasesByFeatureUuid_Call) Return(_a0 []db.FeaturePhase) *Database_GetPhasesByFeatureUuid_Call {
+func (_c *Database_ReviewListing_Call) Return(_a0 error) *Database_ReviewListing_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPhasesByFeatureUuid_Call) RunAndReturn(run func(string) []db.FeaturePhase) *Database_GetPhasesByFeatureUuid_Call {
+func (_c *Database_ReviewListing_Call) RunAndReturn(run func(db.ReportTargetType, string, bool) error) *Database_ReviewListing_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPreviousBountyByCreated provides a mock function with given fields: r
-func (_m *Database) GetPreviousBountyByCreated(r *http.Request) (uint, error) {
-	ret := _m.Called(r)
+// RevokeAllApiKeys provides a mock function with given fields: pubkey
+func (_m *Database) RevokeAllApiKeys(pubkey string) error {
+	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPreviousBountyByCreated")
-	}
-
-	var r0 uint
-	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
-		return rf(r)
-	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
-		r0 = rf(r)
-	} else {
-		r0 = ret.Get(0).(uint)
+		panic("no return value specified for RevokeAllApiKeys")
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(pubkey)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetPreviousBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreviousBountyByCreated'
-type Database_GetPreviousBountyByCreated_Call struct {
+// Database_RevokeAllApiKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAllApiKeys'
+type Database_RevokeAllApiKeys_Call struct {
 	*mock.Call
 }
 
-// GetPreviousBountyByCreated is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetPreviousBountyByCreated(r interface{}) *Database_GetPreviousBountyByCreated_Call {
-	return &Database_GetPreviousBountyByCreated_Call{Call: _e.mock.On("GetPreviousBountyByCreated", r)}
+// RevokeAllApiKeys is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) RevokeAllApiKeys(pubkey interface{}) *Database_RevokeAllApiKeys_Call {
+	return &Database_RevokeAllApiKeys_Call{Call: _e.mock.On("RevokeAllApiKeys", pubkey)}
 }
 
-func (_c *Database_GetPreviousBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetPreviousBountyByCreated_Call {
+func (_c *Database_RevokeAllApiKeys_Call) Run(run func(pubkey string)) *Database_RevokeAllApiKeys_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPreviousBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetPreviousBountyByCreated_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_RevokeAllApiKeys_Call) Return(_a0 error) *Database_RevokeAllApiKeys_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPreviousBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetPreviousBountyByCreated_Call {
+func (_c *Database_RevokeAllApiKeys_Call) RunAndReturn(run func(string) error) *Database_RevokeAllApiKeys_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPreviousWorkspaceBountyByCreated provides a mock function with given fields: r
-func (_m *Database) GetPreviousWorkspaceBountyByCreated(r *http.Request) (uint, error) {
-	ret := _m.Called(r)
+// RevokeAllAuthSessions provides a mock function with given fields: pubkey
+func (_m *Database) RevokeAllAuthSessions(pubkey string) error {
+	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPreviousWorkspaceBountyByCreated")
-	}
-
-	var r0 uint
-	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
-		return rf(r)
-	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
-		r0 = rf(r)
-	} else {
-		r0 = ret.Get(0).(uint)
+		panic("no return value specified for RevokeAllAuthSessions")
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(pubkey)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetPreviousWorkspaceBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreviousWorkspaceBountyByCreated'
-type Database_GetPreviousWorkspaceBountyByCreated_Call struct {
+// Database_RevokeAllAuthSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAllAuthSessions'
+type Database_RevokeAllAuthSessions_Call struct {
 	*mock.Call
 }
 
-// GetPreviousWorkspaceBountyByCreated is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetPreviousWorkspaceBountyByCreated(r interface{}) *Database_GetPreviousWorkspaceBountyByCreated_Call {
-	return &Database_GetPreviousWorkspaceBountyByCreated_Call{Call: _e.mock.On("GetPreviousWorkspaceBountyByCreated", r)}
+// RevokeAllAuthSessions is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) RevokeAllAuthSessions(pubkey interface{}) *Database_RevokeAllAuthSessions_Call {
+	return &Database_RevokeAllAuthSessions_Call{Call: _e.mock.On("RevokeAllAuthSessions", pubkey)}
 }
 
-func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+func (_c *Database_RevokeAllAuthSessions_Call) Run(run func(pubkey string)) *Database_RevokeAllAuthSessions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetPreviousWorkspaceBountyByCreated_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_RevokeAllAuthSessions_Call) Return(_a0 error) *Database_RevokeAllAuthSessions_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+func (_c *Database_RevokeAllAuthSessions_Call) RunAndReturn(run func(string) error) *Database_RevokeAllAuthSessions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribe provides a mock function with given fields: uuid
-func (_m *Database) GetTribe(uuid string) db.Tribe {
-	ret := _m.Called(uuid)
+// RevokeApiKey provides a mock function with given fields: pubkey, uuid
+func (_m *Database) RevokeApiKey(pubkey string, uuid string) error {
+	ret := _m.Called(pubkey, uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribe")
+		panic("no return value specified for RevokeApiKey")
 	}
 
-	var r0 db.Tribe
-	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
-		r0 = rf(uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(pubkey, uuid)
 	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribe'
-type Database_GetTribe_Call struct {
+// Database_RevokeApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeApiKey'
+type Database_RevokeApiKey_Call struct {
 	*mock.Call
 }
 
-// GetTribe is a helper method to define mock.On call
+// RevokeApiKey is a helper method to define mock.On call
+//   - pubkey string
 //   - uuid string
-func (_e *Database_Expecter) GetTribe(uuid interface{}) *Database_GetTribe_Call {
-	return &Database_GetTribe_Call{Call: _e.mock.On("GetTribe", uuid)}
+func (_e *Database_Expecter) RevokeApiKey(pubkey interface{}, uuid interface{}) *Database_RevokeApiKey_Call {
+	return &Database_RevokeApiKey_Call{Call: _e.mock.On("RevokeApiKey", pubkey, uuid)}
 }
 
-func (_c *Database_GetTribe_Call) Run(run func(uuid string)) *Database_GetTribe_Call {
+func (_c *Database_RevokeApiKey_Call) Run(run func(pubkey string, uuid string)) *Database_RevokeApiKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribe_Call) Return(_a0 db.Tribe) *Database_GetTribe_Call {
+func (_c *Database_RevokeApiKey_Call) Return(_a0 error) *Database_RevokeApiKey_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTribe_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetTribe_Call {
+func (_c *Database_RevokeApiKey_Call) RunAndReturn(run func(string, string) error) *Database_RevokeApiKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribeByIdAndPubkey provides a mock function with given fields: uuid, pubkey
-func (_m *Database) GetTribeByIdAndPubkey(uuid string, pubkey string) db.Tribe {
-	ret := _m.Called(uuid, pubkey)
+// RevokeAuthSession provides a mock function with given fields: pubkey, uuid
+func (_m *Database) RevokeAuthSession(pubkey string, uuid string) error {
+	ret := _m.Called(pubkey, uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribeByIdAndPubkey")
+		panic("no return value specified for RevokeAuthSession")
 	}
 
-	var r0 db.Tribe
-	if rf, ok := ret.Get(0).(func(string, string) db.Tribe); ok {
-		r0 = rf(uuid, pubkey)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(pubkey, uuid)
 	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetTribeByIdAndPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeByIdAndPubkey'
-type Database_GetTribeByIdAndPubkey_Call struct {
+// Database_RevokeAuthSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAuthSession'
+type Database_RevokeAuthSession_Call struct {
 	*mock.Call
 }
 
-// GetTribeByIdAndPubkey is a helper method to define mock.On call
-//   - uuid string
+// RevokeAuthSession is a helper method to define mock.On call
 //   - pubkey string
-func (_e *Database_Expecter) GetTribeByIdAndPubkey(uuid interface{}, pubkey interface{}) *Database_GetTribeByIdAndPubkey_Call {
-	return &Database_GetTribeByIdAndPubkey_Call{Call: _e.mock.On("GetTribeByIdAndPubkey", uuid, pubkey)}
+//   - uuid string
+func (_e *Database_Expecter) RevokeAuthSession(pubkey interface{}, uuid interface{}) *Database_RevokeAuthSession_Call {
+	return &Database_RevokeAuthSession_Call{Call: _e.mock.On("RevokeAuthSession", pubkey, uuid)}
 }
 
-func (_c *Database_GetTribeByIdAndPubkey_Call) Run(run func(uuid string, pubkey string)) *Database_GetTribeByIdAndPubkey_Call {
+func (_c *Database_RevokeAuthSession_Call) Run(run func(pubkey string, uuid string)) *Database_RevokeAuthSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribeByIdAndPubkey_Call) Return(_a0 db.Tribe) *Database_GetTribeByIdAndPubkey_Call {
+func (_c *Database_RevokeAuthSession_Call) Return(_a0 error) *Database_RevokeAuthSession_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTribeByIdAndPubkey_Call) RunAndReturn(run func(string, string) db.Tribe) *Database_GetTribeByIdAndPubkey_Call {
+func (_c *Database_RevokeAuthSession_Call) RunAndReturn(run func(string, string) error) *Database_RevokeAuthSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribeByUniqueName provides a mock function with given fields: un
-func (_m *Database) GetTribeByUniqueName(un string) db.Tribe {
-	ret := _m.Called(un)
+// RevokeCampaignConnectionCodes provides a mock function with given fields: campaign
+func (_m *Database) RevokeCampaignConnectionCodes(campaign string) (int64, error) {
+	ret := _m.Called(campaign)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribeByUniqueName")
+		panic("no return value specified for RevokeCampaignConnectionCodes")
 	}
 
-	var r0 db.Tribe
-	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
-		r0 = rf(un)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(campaign)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(campaign)
 	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(campaign)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetTribeByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeByUniqueName'
-type Database_GetTribeByUniqueName_Call struct {
+// Database_RevokeCampaignConnectionCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeCampaignConnectionCodes'
+type Database_RevokeCampaignConnectionCodes_Call struct {
 	*mock.Call
 }
 
-// GetTribeByUniqueName is a helper method to define mock.On call
-//   - un string
-func (_e *Database_Expecter) GetTribeByUniqueName(un interface{}) *Database_GetTribeByUniqueName_Call {
-	return &Database_GetTribeByUniqueName_Call{Call: _e.mock.On("GetTribeByUniqueName", un)}
+// RevokeCampaignConnectionCodes is a helper method to define mock.On call
+//   - campaign string
+func (_e *Database_Expecter) RevokeCampaignConnectionCodes(campaign interface{}) *Database_RevokeCampaignConnectionCodes_Call {
+	return &Database_RevokeCampaignConnectionCodes_Call{Call: _e.mock.On("RevokeCampaignConnectionCodes", campaign)}
 }
 
-func (_c *Database_GetTribeByUniqueName_Call) Run(run func(un string)) *Database_GetTribeByUniqueName_Call {
+func (_c *Database_RevokeCampaignConnectionCodes_Call) Run(run func(campaign string)) *Database_RevokeCampaignConnectionCodes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribeByUniqueName_Call) Return(_a0 db.Tribe) *Database_GetTribeByUniqueName_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_RevokeCampaignConnectionCodes_Call) Return(_a0 int64, _a1 error) *Database_RevokeCampaignConnectionCodes_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetTribeByUniqueName_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetTribeByUniqueName_Call {
+func (_c *Database_RevokeCampaignConnectionCodes_Call) RunAndReturn(run func(string) (int64, error)) *Database_RevokeCampaignConnectionCodes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribesByAppUrl provides a mock function with given fields: aurl
-func (_m *Database) GetTribesByAppUrl(aurl string) []db.Tribe {
-	ret := _m.Called(aurl)
+// RevokeTribeInvite provides a mock function with given fields: code
+func (_m *Database) RevokeTribeInvite(code string) error {
+	ret := _m.Called(code)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribesByAppUrl")
+		panic("no return value specified for RevokeTribeInvite")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
-		r0 = rf(aurl)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(code)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetTribesByAppUrl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByAppUrl'
-type Database_GetTribesByAppUrl_Call struct {
+// Database_RevokeTribeInvite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeTribeInvite'
+type Database_RevokeTribeInvite_Call struct {
 	*mock.Call
 }
 
-// GetTribesByAppUrl is a helper method to define mock.On call
-//   - aurl string
-func (_e *Database_Expecter) GetTribesByAppUrl(aurl interface{}) *Database_GetTribesByAppUrl_Call {
-	return &Database_GetTribesByAppUrl_Call{Call: _e.mock.On("GetTribesByAppUrl", aurl)}
+// RevokeTribeInvite is a helper method to define mock.On call
+//   - code string
+func (_e *Database_Expecter) RevokeTribeInvite(code interface{}) *Database_RevokeTribeInvite_Call {
+	return &Database_RevokeTribeInvite_Call{Call: _e.mock.On("RevokeTribeInvite", code)}
 }
 
-func (_c *Database_GetTribesByAppUrl_Call) Run(run func(aurl string)) *Database_GetTribesByAppUrl_Call {
+func (_c *Database_RevokeTribeInvite_Call) Run(run func(code string)) *Database_RevokeTribeInvite_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribesByAppUrl_Call) Return(_a0 []db.Tribe) *Database_GetTribesByAppUrl_Call {
+func (_c *Database_RevokeTribeInvite_Call) Return(_a0 error) *Database_RevokeTribeInvite_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTribesByAppUrl_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetTribesByAppUrl_Call {
+func (_c *Database_RevokeTribeInvite_Call) RunAndReturn(run func(string) error) *Database_RevokeTribeInvite_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribesByOwner provides a mock function with given fields: pubkey
-func (_m *Database) GetTribesByOwner(pubkey string) []db.Tribe {
-	ret := _m.Called(pubkey)
+// RollupMetrics provides a mock function with given fields: now
+func (_m *Database) RollupMetrics(now time.Time) error {
+	ret := _m.Called(now)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribesByOwner")
+		panic("no return value specified for RollupMetrics")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
-		r0 = rf(pubkey)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(now)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetTribesByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByOwner'
-type Database_GetTribesByOwner_Call struct {
+// Database_RollupMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollupMetrics'
+type Database_RollupMetrics_Call struct {
 	*mock.Call
 }
 
-// GetTribesByOwner is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetTribesByOwner(pubkey interface{}) *Database_GetTribesByOwner_Call {
-	return &Database_GetTribesByOwner_Call{Call: _e.mock.On("GetTribesByOwner", pubkey)}
+// RollupMetrics is a helper method to define mock.On call
+//   - now time.Time
+func (_e *Database_Expecter) RollupMetrics(now interface{}) *Database_RollupMetrics_Call {
+	return &Database_RollupMetrics_Call{Call: _e.mock.On("RollupMetrics", now)}
 }
 
-func (_c *Database_GetTribesByOwner_Call) Run(run func(pubkey string)) *Database_GetTribesByOwner_Call {
+func (_c *Database_RollupMetrics_Call) Run(run func(now time.Time)) *Database_RollupMetrics_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribesByOwner_Call) Return(_a0 []db.Tribe) *Database_GetTribesByOwner_Call {
+func (_c *Database_RollupMetrics_Call) Return(_a0 error) *Database_RollupMetrics_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTribesByOwner_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetTribesByOwner_Call {
+func (_c *Database_RollupMetrics_Call) RunAndReturn(run func(time.Time) error) *Database_RollupMetrics_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribesTotal provides a mock function with given fields:
-func (_m *Database) GetTribesTotal() int64 {
-	ret := _m.Called()
+// RotateAuthSession provides a mock function with given fields: uuid, currentJti, jti
+func (_m *Database) RotateAuthSession(uuid string, currentJti string, jti string) error {
+	ret := _m.Called(uuid, currentJti, jti)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribesTotal")
+		panic("no return value specified for RotateAuthSession")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(uuid, currentJti, jti)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetTribesTotal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesTotal'
-type Database_GetTribesTotal_Call struct {
+// Database_RotateAuthSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateAuthSession'
+type Database_RotateAuthSession_Call struct {
 	*mock.Call
 }
 
-// GetTribesTotal is a helper method to define mock.On call
-func (_e *Database_Expecter) GetTribesTotal() *Database_GetTribesTotal_Call {
-	return &Database_GetTribesTotal_Call{Call: _e.mock.On("GetTribesTotal")}
+// RotateAuthSession is a helper method to define mock.On call
+//   - uuid string
+//   - currentJti string
+//   - jti string
+func (_e *Database_Expecter) RotateAuthSession(uuid interface{}, currentJti interface{}, jti interface{}) *Database_RotateAuthSession_Call {
+	return &Database_RotateAuthSession_Call{Call: _e.mock.On("RotateAuthSession", uuid, currentJti, jti)}
 }
 
-func (_c *Database_GetTribesTotal_Call) Run(run func()) *Database_GetTribesTotal_Call {
+func (_c *Database_RotateAuthSession_Call) Run(run func(uuid string, currentJti string, jti string)) *Database_RotateAuthSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribesTotal_Call) Return(_a0 int64) *Database_GetTribesTotal_Call {
+func (_c *Database_RotateAuthSession_Call) Return(_a0 error) *Database_RotateAuthSession_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTribesTotal_Call) RunAndReturn(run func() int64) *Database_GetTribesTotal_Call {
+func (_c *Database_RotateAuthSession_Call) RunAndReturn(run func(string, string, string) error) *Database_RotateAuthSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUnconfirmedGithub provides a mock function with given fields:
-func (_m *Database) GetUnconfirmedGithub() []db.Person {
-	ret := _m.Called()
+// RotateWorkspaceCalendarToken provides a mock function with given fields: workspaceUuid, createdBy
+func (_m *Database) RotateWorkspaceCalendarToken(workspaceUuid string, createdBy string) (db.WorkspaceCalendarToken, error) {
+	ret := _m.Called(workspaceUuid, createdBy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUnconfirmedGithub")
+		panic("no return value specified for RotateWorkspaceCalendarToken")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func() []db.Person); ok {
-		r0 = rf()
+	var r0 db.WorkspaceCalendarToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.WorkspaceCalendarToken, error)); ok {
+		return rf(workspaceUuid, createdBy)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceCalendarToken); ok {
+		r0 = rf(workspaceUuid, createdBy)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Get(0).(db.WorkspaceCalendarToken)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(workspaceUuid, createdBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetUnconfirmedGithub_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedGithub'
-type Database_GetUnconfirmedGithub_Call struct {
+// Database_RotateWorkspaceCalendarToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateWorkspaceCalendarToken'
+type Database_RotateWorkspaceCalendarToken_Call struct {
 	*mock.Call
 }
 
-// GetUnconfirmedGithub is a helper method to define mock.On call
-func (_e *Database_Expecter) GetUnconfirmedGithub() *Database_GetUnconfirmedGithub_Call {
-	return &Database_GetUnconfirmedGithub_Call{Call: _e.mock.On("GetUnconfirmedGithub")}
+// RotateWorkspaceCalendarToken is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - createdBy string
+func (_e *Database_Expecter) RotateWorkspaceCalendarToken(workspaceUuid interface{}, createdBy interface{}) *Database_RotateWorkspaceCalendarToken_Call {
+	return &Database_RotateWorkspaceCalendarToken_Call{Call: _e.mock.On("RotateWorkspaceCalendarToken", workspaceUuid, createdBy)}
 }
 
-func (_c *Database_GetUnconfirmedGithub_Call) Run(run func()) *Database_GetUnconfirmedGithub_Call {
+func (_c *Database_RotateWorkspaceCalendarToken_Call) Run(run func(workspaceUuid string, createdBy string)) *Database_RotateWorkspaceCalendarToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetUnconfirmedGithub_Call) Return(_a0 []db.Person) *Database_GetUnconfirmedGithub_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_RotateWorkspaceCalendarToken_Call) Return(_a0 db.WorkspaceCalendarToken, _a1 error) *Database_RotateWorkspaceCalendarToken_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetUnconfirmedGithub_Call) RunAndReturn(run func() []db.Person) *Database_GetUnconfirmedGithub_Call {
+func (_c *Database_RotateWorkspaceCalendarToken_Call) RunAndReturn(run func(string, string) (db.WorkspaceCalendarToken, error)) *Database_RotateWorkspaceCalendarToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUnconfirmedTwitter provides a mock function with given fields:
-func (_m *Database) GetUnconfirmedTwitter() []db.Person {
-	ret := _m.Called()
+// SatsPaidPercentage provides a mock function with given fields: r, workspace
+func (_m *Database) SatsPaidPercentage(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUnconfirmedTwitter")
+		panic("no return value specified for SatsPaidPercentage")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func() []db.Person); ok {
-		r0 = rf()
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Get(0).(uint)
 	}
 
 	return r0
 }
 
-// Database_GetUnconfirmedTwitter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedTwitter'
-type Database_GetUnconfirmedTwitter_Call struct {
+// Database_SatsPaidPercentage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SatsPaidPercentage'
+type Database_SatsPaidPercentage_Call struct {
 	*mock.Call
 }
 
-// GetUnconfirmedTwitter is a helper method to define mock.On call
-func (_e *Database_Expecter) GetUnconfirmedTwitter() *Database_GetUnconfirmedTwitter_Call {
-	return &Database_GetUnconfirmedTwitter_Call{Call: _e.mock.On("GetUnconfirmedTwitter")}
+// SatsPaidPercentage is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) SatsPaidPercentage(r interface{}, workspace interface{}) *Database_SatsPaidPercentage_Call {
+	return &Database_SatsPaidPercentage_Call{Call: _e.mock.On("SatsPaidPercentage", r, workspace)}
 }
 
-func (_c *Database_GetUnconfirmedTwitter_Call) Run(run func()) *Database_GetUnconfirmedTwitter_Call {
+func (_c *Database_SatsPaidPercentage_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_SatsPaidPercentage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetUnconfirmedTwitter_Call) Return(_a0 []db.Person) *Database_GetUnconfirmedTwitter_Call {
+func (_c *Database_SatsPaidPercentage_Call) Return(_a0 uint) *Database_SatsPaidPercentage_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetUnconfirmedTwitter_Call) RunAndReturn(run func() []db.Person) *Database_GetUnconfirmedTwitter_Call {
+func (_c *Database_SatsPaidPercentage_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_SatsPaidPercentage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserAssignedWorkspaces provides a mock function with given fields: pubkey
-func (_m *Database) GetUserAssignedWorkspaces(pubkey string) []db.WorkspaceUsers {
-	ret := _m.Called(pubkey)
+// SaveRepositoryCommits provides a mock function with given fields: ms
+func (_m *Database) SaveRepositoryCommits(ms []db.RepositoryCommit) error {
+	ret := _m.Called(ms)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserAssignedWorkspaces")
+		panic("no return value specified for SaveRepositoryCommits")
 	}
 
-	var r0 []db.WorkspaceUsers
-	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceUsers); ok {
-		r0 = rf(pubkey)
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]db.RepositoryCommit) error); ok {
+		r0 = rf(ms)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceUsers)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetUserAssignedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAssignedWorkspaces'
-type Database_GetUserAssignedWorkspaces_Call struct {
+// Database_SaveRepositoryCommits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveRepositoryCommits'
+type Database_SaveRepositoryCommits_Call struct {
 	*mock.Call
 }
 
-// GetUserAssignedWorkspaces is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetUserAssignedWorkspaces(pubkey interface{}) *Database_GetUserAssignedWorkspaces_Call {
-	return &Database_GetUserAssignedWorkspaces_Call{Call: _e.mock.On("GetUserAssignedWorkspaces", pubkey)}
+// SaveRepositoryCommits is a helper method to define mock.On call
+//   - ms []db.RepositoryCommit
+func (_e *Database_Expecter) SaveRepositoryCommits(ms interface{}) *Database_SaveRepositoryCommits_Call {
+	return &Database_SaveRepositoryCommits_Call{Call: _e.mock.On("SaveRepositoryCommits", ms)}
 }
 
-func (_c *Database_GetUserAssignedWorkspaces_Call) Run(run func(pubkey string)) *Database_GetUserAssignedWorkspaces_Call {
+func (_c *Database_SaveRepositoryCommits_Call) Run(run func(ms []db.RepositoryCommit)) *Database_SaveRepositoryCommits_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].([]db.RepositoryCommit))
 	})
 	return _c
 }
 
-func (_c *Database_GetUserAssignedWorkspaces_Call) Return(_a0 []db.WorkspaceUsers) *Database_GetUserAssignedWorkspaces_Call {
+func (_c *Database_SaveRepositoryCommits_Call) Return(_a0 error) *Database_SaveRepositoryCommits_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetUserAssignedWorkspaces_Call) RunAndReturn(run func(string) []db.WorkspaceUsers) *Database_GetUserAssignedWorkspaces_Call {
+func (_c *Database_SaveRepositoryCommits_Call) RunAndReturn(run func([]db.RepositoryCommit) error) *Database_SaveRepositoryCommits_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserBountiesCount provides a mock function with given fields: personKey, tabType
-func (_m *Database) GetUserBountiesCount(personKey string, tabType string) int64 {
-	ret := _m.Called(personKey, tabType)
+// SaveRepositoryPullRequest provides a mock function with given fields: m
+func (_m *Database) SaveRepositoryPullRequest(m db.RepositoryPullRequest) error {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserBountiesCount")
-	}
-
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
-		r0 = rf(personKey, tabType)
+		panic("no return value specified for SaveRepositoryPullRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.RepositoryPullRequest) error); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetUserBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserBountiesCount'
-type Database_GetUserBountiesCount_Call struct {
+// Database_SaveRepositoryPullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveRepositoryPullRequest'
+type Database_SaveRepositoryPullRequest_Call struct {
 	*mock.Call
 }
 
-// GetUserBountiesCount is a helper method to define mock.On call
-//   - personKey string
-//   - tabType string
-func (_e *Database_Expecter) GetUserBountiesCount(personKey interface{}, tabType interface{}) *Database_GetUserBountiesCount_Call {
-	return &Database_GetUserBountiesCount_Call{Call: _e.mock.On("GetUserBountiesCount", personKey, tabType)}
+// SaveRepositoryPullRequest is a helper method to define mock.On call
+//   - m db.RepositoryPullRequest
+func (_e *Database_Expecter) SaveRepositoryPullRequest(m interface{}) *Database_SaveRepositoryPullRequest_Call {
+	return &Database_SaveRepositoryPullRequest_Call{Call: _e.mock.On("SaveRepositoryPullRequest", m)}
 }
 
-func (_c *Database_GetUserBountiesCount_Call) Run(run func(personKey string, tabType string)) *Database_GetUserBountiesCount_Call {
+func (_c *Database_SaveRepositoryPullRequest_Call) Run(run func(m db.RepositoryPullRequest)) *Database_SaveRepositoryPullRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(db.RepositoryPullRequest))
 	})
 	return _c
 }
 
-func (_c *Database_GetUserBountiesCount_Call) Return(_a0 int64) *Database_GetUserBountiesCount_Call {
+func (_c *Database_SaveRepositoryPullRequest_Call) Return(_a0 error) *Database_SaveRepositoryPullRequest_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetUserBountiesCount_Call) RunAndReturn(run func(string, string) int64) *Database_GetUserBountiesCount_Call {
+func (_c *Database_SaveRepositoryPullRequest_Call) RunAndReturn(run func(db.RepositoryPullRequest) error) *Database_SaveRepositoryPullRequest_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserCreatedWorkspaces provides a mock function with given fields: pubkey
-func (_m *Database) GetUserCreatedWorkspaces(pubkey string) []db.Workspace {
+// ScheduleAccountPurge provides a mock function with given fields: pubkey
+func (_m *Database) ScheduleAccountPurge(pubkey string) (db.AccountPurge, error) {
 	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserCreatedWorkspaces")
+		panic("no return value specified for ScheduleAccountPurge")
 	}
 
-	var r0 []db.Workspace
-	if rf, ok := ret.Get(0).(func(string) []db.Workspace); ok {
+	var r0 db.AccountPurge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.AccountPurge, error)); ok {
+		return rf(pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.AccountPurge); ok {
 		r0 = rf(pubkey)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Workspace)
-		}
+		r0 = ret.Get(0).(db.AccountPurge)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetUserCreatedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserCreatedWorkspaces'
-type Database_GetUserCreatedWorkspaces_Call struct {
+// Database_ScheduleAccountPurge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleAccountPurge'
+type Database_ScheduleAccountPurge_Call struct {
 	*mock.Call
 }
 
-// GetUserCreatedWorkspaces is a helper method to define mock.On call
+// ScheduleAccountPurge is a helper method to define mock.On call
 //   - pubkey string
-func (_e *Database_Expecter) GetUserCreatedWorkspaces(pubkey interface{}) *Database_GetUserCreatedWorkspaces_Call {
-	return &Database_GetUserCreatedWorkspaces_Call{Call: _e.mock.On("GetUserCreatedWorkspaces", pubkey)}
+func (_e *Database_Expecter) ScheduleAccountPurge(pubkey interface{}) *Database_ScheduleAccountPurge_Call {
+	return &Database_ScheduleAccountPurge_Call{Call: _e.mock.On("ScheduleAccountPurge", pubkey)}
 }
 
-func (_c *Database_GetUserCreatedWorkspaces_Call) Run(run func(pubkey string)) *Database_GetUserCreatedWorkspaces_Call {
+func (_c *Database_ScheduleAccountPurge_Call) Run(run func(pubkey string)) *Database_ScheduleAccountPurge_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetUserCreatedWorkspaces_Call) Return(_a0 []db.Workspace) *Database_GetUserCreatedWorkspaces_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ScheduleAccountPurge_Call) Return(_a0 db.AccountPurge, _a1 error) *Database_ScheduleAccountPurge_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetUserCreatedWorkspaces_Call) RunAndReturn(run func(string) []db.Workspace) *Database_GetUserCreatedWorkspaces_Call {
+func (_c *Database_ScheduleAccountPurge_Call) RunAndReturn(run func(string) (db.AccountPurge, error)) *Database_ScheduleAccountPurge_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserInvoiceData provides a mock function with given fields: payment_request
-func (_m *Database) GetUserInvoiceData(payment_request string) db.UserInvoiceData {
-	ret := _m.Called(payment_request)
+// SearchBots provides a mock function with given fields: s, limit, offset, sortBy, tags
+func (_m *Database) SearchBots(s string, limit int, offset int, sortBy string, tags []string) []db.BotRes {
+	ret := _m.Called(s, limit, offset, sortBy, tags)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserInvoiceData")
+		panic("no return value specified for SearchBots")
 	}
 
-	var r0 db.UserInvoiceData
-	if rf, ok := ret.Get(0).(func(string) db.UserInvoiceData); ok {
-		r0 = rf(payment_request)
+	var r0 []db.BotRes
+	if rf, ok := ret.Get(0).(func(string, int, int, string, []string) []db.BotRes); ok {
+		r0 = rf(s, limit, offset, sortBy, tags)
 	} else {
-		r0 = ret.Get(0).(db.UserInvoiceData)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BotRes)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserInvoiceData'
-type Database_GetUserInvoiceData_Call struct {
+// Database_SearchBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchBots'
+type Database_SearchBots_Call struct {
 	*mock.Call
 }
 
-// GetUserInvoiceData is a helper method to define mock.On call
-//   - payment_request string
-func (_e *Database_Expecter) GetUserInvoiceData(payment_request interface{}) *Database_GetUserInvoiceData_Call {
-	return &Database_GetUserInvoiceData_Call{Call: _e.mock.On("GetUserInvoiceData", payment_request)}
+// SearchBots is a helper method to define mock.On call
+//   - s string
+//   - limit int
+//   - offset int
+//   - sortBy string
+//   - tags []string
+func (_e *Database_Expecter) SearchBots(s interface{}, limit interface{}, offset interface{}, sortBy interface{}, tags interface{}) *Database_SearchBots_Call {
+	return &Database_SearchBots_Call{Call: _e.mock.On("SearchBots", s, limit, offset, sortBy, tags)}
 }
 
-func (_c *Database_GetUserInvoiceData_Call) Run(run func(payment_request string)) *Database_GetUserInvoiceData_Call {
+func (_c *Database_SearchBots_Call) Run(run func(s string, limit int, offset int, sortBy string, tags []string)) *Database_SearchBots_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(int), args[2].(int), args[3].(string), args[4].([]string))
 	})
 	return _c
 }
 
-func (_c *Database_GetUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_GetUserInvoiceData_Call {
+func (_c *Database_SearchBots_Call) Return(_a0 []db.BotRes) *Database_SearchBots_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetUserInvoiceData_Call) RunAndReturn(run func(string) db.UserInvoiceData) *Database_GetUserInvoiceData_Call {
+func (_c *Database_SearchBots_Call) RunAndReturn(run func(string, int, int, string, []string) []db.BotRes) *Database_SearchBots_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBounties provides a mock function with given fields: r, workspace_uuid
-func (_m *Database) GetWorkspaceBounties(r *http.Request, workspace_uuid string) []db.NewBounty {
-	ret := _m.Called(r, workspace_uuid)
+// SearchEntities provides a mock function with given fields: query, entityType, limit
+func (_m *Database) SearchEntities(query string, entityType string, limit int) []db.SearchResult {
+	ret := _m.Called(query, entityType, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBounties")
+		panic("no return value specified for SearchEntities")
 	}
 
-	var r0 []db.NewBounty
-	if rf, ok := ret.Get(0).(func(*http.Request, string) []db.NewBounty); ok {
-		r0 = rf(r, workspace_uuid)
+	var r0 []db.SearchResult
+	if rf, ok := ret.Get(0).(func(string, string, int) []db.SearchResult); ok {
+		r0 = rf(query, entityType, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
+			r0 = ret.Get(0).([]db.SearchResult)
 		}
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBounties'
-type Database_GetWorkspaceBounties_Call struct {
+// Database_SearchEntities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchEntities'
+type Database_SearchEntities_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBounties is a helper method to define mock.On call
-//   - r *http.Request
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceBounties(r interface{}, workspace_uuid interface{}) *Database_GetWorkspaceBounties_Call {
-	return &Database_GetWorkspaceBounties_Call{Call: _e.mock.On("GetWorkspaceBounties", r, workspace_uuid)}
+// SearchEntities is a helper method to define mock.On call
+//   - query string
+//   - entityType string
+//   - limit int
+func (_e *Database_Expecter) SearchEntities(query interface{}, entityType interface{}, limit interface{}) *Database_SearchEntities_Call {
+	return &Database_SearchEntities_Call{Call: _e.mock.On("SearchEntities", query, entityType, limit)}
 }
 
-func (_c *Database_GetWorkspaceBounties_Call) Run(run func(r *http.Request, workspace_uuid string)) *Database_GetWorkspaceBounties_Call {
+func (_c *Database_SearchEntities_Call) Run(run func(query string, entityType string, limit int)) *Database_SearchEntities_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request), args[1].(string))
+		run(args[0].(string), args[1].(string), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBounties_Call) Return(_a0 []db.NewBounty) *Database_GetWorkspaceBounties_Call {
+func (_c *Database_SearchEntities_Call) Return(_a0 []db.SearchResult) *Database_SearchEntities_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBounties_Call) RunAndReturn(run func(*http.Request, string) []db.NewBounty) *Database_GetWorkspaceBounties_Call {
+func (_c *Database_SearchEntities_Call) RunAndReturn(run func(string, string, int) []db.SearchResult) *Database_SearchEntities_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBountiesCount provides a mock function with given fields: r, workspace_uuid
-func (_m *Database) GetWorkspaceBountiesCount(r *http.Request, workspace_uuid string) int64 {
-	ret := _m.Called(r, workspace_uuid)
+// SearchPeople provides a mock function with given fields: s, limit, offset
+func (_m *Database) SearchPeople(s string, limit int, offset int) []db.Person {
+	ret := _m.Called(s, limit, offset)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBountiesCount")
+		panic("no return value specified for SearchPeople")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(*http.Request, string) int64); ok {
-		r0 = rf(r, workspace_uuid)
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func(string, int, int) []db.Person); ok {
+		r0 = rf(s, limit, offset)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountiesCount'
-type Database_GetWorkspaceBountiesCount_Call struct {
+// Database_SearchPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchPeople'
+type Database_SearchPeople_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBountiesCount is a helper method to define mock.On call
-//   - r *http.Request
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceBountiesCount(r interface{}, workspace_uuid interface{}) *Database_GetWorkspaceBountiesCount_Call {
-	return &Database_GetWorkspaceBountiesCount_Call{Call: _e.mock.On("GetWorkspaceBountiesCount", r, workspace_uuid)}
+// SearchPeople is a helper method to define mock.On call
+//   - s string
+//   - limit int
+//   - offset int
+func (_e *Database_Expecter) SearchPeople(s interface{}, limit interface{}, offset interface{}) *Database_SearchPeople_Call {
+	return &Database_SearchPeople_Call{Call: _e.mock.On("SearchPeople", s, limit, offset)}
 }
 
-func (_c *Database_GetWorkspaceBountiesCount_Call) Run(run func(r *http.Request, workspace_uuid string)) *Database_GetWorkspaceBountiesCount_Call {
+func (_c *Database_SearchPeople_Call) Run(run func(s string, limit int, offset int)) *Database_SearchPeople_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request), args[1].(string))
+		run(args[0].(string), args[1].(int), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBountiesCount_Call) Return(_a0 int64) *Database_GetWorkspaceBountiesCount_Call {
+func (_c *Database_SearchPeople_Call) Return(_a0 []db.Person) *Database_SearchPeople_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBountiesCount_Call) RunAndReturn(run func(*http.Request, string) int64) *Database_GetWorkspaceBountiesCount_Call {
+func (_c *Database_SearchPeople_Call) RunAndReturn(run func(string, int, int) []db.Person) *Database_SearchPeople_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBountyCount provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceBountyCount(uuid string) int64 {
-	ret := _m.Called(uuid)
+// SearchTribes provides a mock function with given fields: s
+func (_m *Database) SearchTribes(s string) []db.Tribe {
+	ret := _m.Called(s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBountyCount")
+		panic("no return value specified for SearchTribes")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(uuid)
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
+		r0 = rf(s)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceBountyCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountyCount'
-type Database_GetWorkspaceBountyCount_Call struct {
+// Database_SearchTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchTribes'
+type Database_SearchTribes_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBountyCount is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceBountyCount(uuid interface{}) *Database_GetWorkspaceBountyCount_Call {
-	return &Database_GetWorkspaceBountyCount_Call{Call: _e.mock.On("GetWorkspaceBountyCount", uuid)}
+// SearchTribes is a helper method to define mock.On call
+//   - s string
+func (_e *Database_Expecter) SearchTribes(s interface{}) *Database_SearchTribes_Call {
+	return &Database_SearchTribes_Call{Call: _e.mock.On("SearchTribes", s)}
 }
 
-func (_c *Database_GetWorkspaceBountyCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceBountyCount_Call {
+func (_c *Database_SearchTribes_Call) Run(run func(s string)) *Database_SearchTribes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBountyCount_Call) Return(_a0 int64) *Database_GetWorkspaceBountyCount_Call {
+func (_c *Database_SearchTribes_Call) Return(_a0 []db.Tribe) *Database_SearchTribes_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBountyCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceBountyCount_Call {
+func (_c *Database_SearchTribes_Call) RunAndReturn(run func(string) []db.Tribe) *Database_SearchTribes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBudget provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceBudget(workspace_uuid string) db.NewBountyBudget {
-	ret := _m.Called(workspace_uuid)
+// SetLowBalanceAlerted provides a mock function with given fields: workspace_uuid, alerted
+func (_m *Database) SetLowBalanceAlerted(workspace_uuid string, alerted bool) error {
+	ret := _m.Called(workspace_uuid, alerted)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBudget")
+		panic("no return value specified for SetLowBalanceAlerted")
 	}
 
-	var r0 db.NewBountyBudget
-	if rf, ok := ret.Get(0).(func(string) db.NewBountyBudget); ok {
-		r0 = rf(workspace_uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(workspace_uuid, alerted)
 	} else {
-		r0 = ret.Get(0).(db.NewBountyBudget)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudget'
-type Database_GetWorkspaceBudget_Call struct {
+// Database_SetLowBalanceAlerted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLowBalanceAlerted'
+type Database_SetLowBalanceAlerted_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBudget is a helper method to define mock.On call
+// SetLowBalanceAlerted is a helper method to define mock.On call
 //   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceBudget(workspace_uuid interface{}) *Database_GetWorkspaceBudget_Call {
-	return &Database_GetWorkspaceBudget_Call{Call: _e.mock.On("GetWorkspaceBudget", workspace_uuid)}
+//   - alerted bool
+func (_e *Database_Expecter) SetLowBalanceAlerted(workspace_uuid interface{}, alerted interface{}) *Database_SetLowBalanceAlerted_Call {
+	return &Database_SetLowBalanceAlerted_Call{Call: _e.mock.On("SetLowBalanceAlerted", workspace_uuid, alerted)}
 }
 
-func (_c *Database_GetWorkspaceBudget_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceBudget_Call {
+func (_c *Database_SetLowBalanceAlerted_Call) Run(run func(workspace_uuid string, alerted bool)) *Database_SetLowBalanceAlerted_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(bool))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBudget_Call) Return(_a0 db.NewBountyBudget) *Database_GetWorkspaceBudget_Call {
+func (_c *Database_SetLowBalanceAlerted_Call) Return(_a0 error) *Database_SetLowBalanceAlerted_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBudget_Call) RunAndReturn(run func(string) db.NewBountyBudget) *Database_GetWorkspaceBudget_Call {
+func (_c *Database_SetLowBalanceAlerted_Call) RunAndReturn(run func(string, bool) error) *Database_SetLowBalanceAlerted_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBudgetHistory provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceBudgetHistory(workspace_uuid string) []db.BudgetHistoryData {
-	ret := _m.Called(workspace_uuid)
+// SetTicketThreadResolved provides a mock function with given fields: id, resolved, pubkey
+func (_m *Database) SetTicketThreadResolved(id uint, resolved bool, pubkey string) (db.TicketComment, error) {
+	ret := _m.Called(id, resolved, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBudgetHistory")
+		panic("no return value specified for SetTicketThreadResolved")
 	}
 
-	var r0 []db.BudgetHistoryData
-	if rf, ok := ret.Get(0).(func(string) []db.BudgetHistoryData); ok {
-		r0 = rf(workspace_uuid)
+	var r0 db.TicketComment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, bool, string) (db.TicketComment, error)); ok {
+		return rf(id, resolved, pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(uint, bool, string) db.TicketComment); ok {
+		r0 = rf(id, resolved, pubkey)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.BudgetHistoryData)
-		}
+		r0 = ret.Get(0).(db.TicketComment)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(uint, bool, string) error); ok {
+		r1 = rf(id, resolved, pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceBudgetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudgetHistory'
-type Database_GetWorkspaceBudgetHistory_Call struct {
+// Database_SetTicketThreadResolved_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTicketThreadResolved'
+type Database_SetTicketThreadResolved_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBudgetHistory is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceBudgetHistory(workspace_uuid interface{}) *Database_GetWorkspaceBudgetHistory_Call {
-	return &Database_GetWorkspaceBudgetHistory_Call{Call: _e.mock.On("GetWorkspaceBudgetHistory", workspace_uuid)}
+// SetTicketThreadResolved is a helper method to define mock.On call
+//   - id uint
+//   - resolved bool
+//   - pubkey string
+func (_e *Database_Expecter) SetTicketThreadResolved(id interface{}, resolved interface{}, pubkey interface{}) *Database_SetTicketThreadResolved_Call {
+	return &Database_SetTicketThreadResolved_Call{Call: _e.mock.On("SetTicketThreadResolved", id, resolved, pubkey)}
 }
 
-func (_c *Database_GetWorkspaceBudgetHistory_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceBudgetHistory_Call {
+func (_c *Database_SetTicketThreadResolved_Call) Run(run func(id uint, resolved bool, pubkey string)) *Database_SetTicketThreadResolved_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(uint), args[1].(bool), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBudgetHistory_Call) Return(_a0 []db.BudgetHistoryData) *Database_GetWorkspaceBudgetHistory_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetTicketThreadResolved_Call) Return(_a0 db.TicketComment, _a1 error) *Database_SetTicketThreadResolved_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBudgetHistory_Call) RunAndReturn(run func(string) []db.BudgetHistoryData) *Database_GetWorkspaceBudgetHistory_Call {
+func (_c *Database_SetTicketThreadResolved_Call) RunAndReturn(run func(uint, bool, string) (db.TicketComment, error)) *Database_SetTicketThreadResolved_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceByName provides a mock function with given fields: name
-func (_m *Database) GetWorkspaceByName(name string) db.Workspace {
-	ret := _m.Called(name)
+// SetTribeFeatured provides a mock function with given fields: uuid, featured, position
+func (_m *Database) SetTribeFeatured(uuid string, featured bool, position int) error {
+	ret := _m.Called(uuid, featured, position)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceByName")
+		panic("no return value specified for SetTribeFeatured")
 	}
 
-	var r0 db.Workspace
-	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
-		r0 = rf(name)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bool, int) error); ok {
+		r0 = rf(uuid, featured, position)
 	} else {
-		r0 = ret.Get(0).(db.Workspace)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceByName'
-type Database_GetWorkspaceByName_Call struct {
+// Database_SetTribeFeatured_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTribeFeatured'
+type Database_SetTribeFeatured_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceByName is a helper method to define mock.On call
-//   - name string
-func (_e *Database_Expecter) GetWorkspaceByName(name interface{}) *Database_GetWorkspaceByName_Call {
-	return &Database_GetWorkspaceByName_Call{Call: _e.mock.On("GetWorkspaceByName", name)}
+// SetTribeFeatured is a helper method to define mock.On call
+//   - uuid string
+//   - featured bool
+//   - position int
+func (_e *Database_Expecter) SetTribeFeatured(uuid interface{}, featured interface{}, position interface{}) *Database_SetTribeFeatured_Call {
+	return &Database_SetTribeFeatured_Call{Call: _e.mock.On("SetTribeFeatured", uuid, featured, position)}
 }
 
-func (_c *Database_GetWorkspaceByName_Call) Run(run func(name string)) *Database_GetWorkspaceByName_Call {
+func (_c *Database_SetTribeFeatured_Call) Run(run func(uuid string, featured bool, position int)) *Database_SetTribeFeatured_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(bool), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceByName_Call) Return(_a0 db.Workspace) *Database_GetWorkspaceByName_Call {
+func (_c *Database_SetTribeFeatured_Call) Return(_a0 error) *Database_SetTribeFeatured_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceByName_Call) RunAndReturn(run func(string) db.Workspace) *Database_GetWorkspaceByName_Call {
+func (_c *Database_SetTribeFeatured_Call) RunAndReturn(run func(string, bool, int) error) *Database_SetTribeFeatured_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceByUuid provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceByUuid(uuid string) db.Workspace {
-	ret := _m.Called(uuid)
+// SettleBotUsage provides a mock function with given fields: payout, lastUsageId
+func (_m *Database) SettleBotUsage(payout db.BotPayout, lastUsageId uint) (db.BotPayout, error) {
+	ret := _m.Called(payout, lastUsageId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceByUuid")
+		panic("no return value specified for SettleBotUsage")
 	}
 
-	var r0 db.Workspace
-	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
-		r0 = rf(uuid)
+	var r0 db.BotPayout
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BotPayout, uint) (db.BotPayout, error)); ok {
+		return rf(payout, lastUsageId)
+	}
+	if rf, ok := ret.Get(0).(func(db.BotPayout, uint) db.BotPayout); ok {
+		r0 = rf(payout, lastUsageId)
+	} else {
+		r0 = ret.Get(0).(db.BotPayout)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BotPayout, uint) error); ok {
+		r1 = rf(payout, lastUsageId)
 	} else {
-		r0 = ret.Get(0).(db.Workspace)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// Database_GetWorkspaceByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceByUuid'
-type Database_GetWorkspaceByUuid_Call struct {
+// Database_SettleBotUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SettleBotUsage'
+type Database_SettleBotUsage_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceByUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceByUuid(uuid interface{}) *Database_GetWorkspaceByUuid_Call {
-	return &Database_GetWorkspaceByUuid_Call{Call: _e.mock.On("GetWorkspaceByUuid", uuid)}
+// SettleBotUsage is a helper method to define mock.On call
+//   - payout db.BotPayout
+//   - lastUsageId uint
+func (_e *Database_Expecter) SettleBotUsage(payout interface{}, lastUsageId interface{}) *Database_SettleBotUsage_Call {
+	return &Database_SettleBotUsage_Call{Call: _e.mock.On("SettleBotUsage", payout, lastUsageId)}
 }
 
-func (_c *Database_GetWorkspaceByUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceByUuid_Call {
+func (_c *Database_SettleBotUsage_Call) Run(run func(payout db.BotPayout, lastUsageId uint)) *Database_SettleBotUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.BotPayout), args[1].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceByUuid_Call) Return(_a0 db.Workspace) *Database_GetWorkspaceByUuid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SettleBotUsage_Call) Return(_a0 db.BotPayout, _a1 error) *Database_SettleBotUsage_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceByUuid_Call) RunAndReturn(run func(string) db.Workspace) *Database_GetWorkspaceByUuid_Call {
+func (_c *Database_SettleBotUsage_Call) RunAndReturn(run func(db.BotPayout, uint) (db.BotPayout, error)) *Database_SettleBotUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceFeaturesCount provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceFeaturesCount(uuid string) int64 {
-	ret := _m.Called(uuid)
+// StartAuthSession provides a mock function with given fields: m
+func (_m *Database) StartAuthSession(m db.AuthSession) (string, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceFeaturesCount")
+		panic("no return value specified for StartAuthSession")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(uuid)
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.AuthSession) (string, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.AuthSession) string); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(string)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.AuthSession) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceFeaturesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceFeaturesCount'
-type Database_GetWorkspaceFeaturesCount_Call struct {
+// Database_StartAuthSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartAuthSession'
+type Database_StartAuthSession_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceFeaturesCount is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceFeaturesCount(uuid interface{}) *Database_GetWorkspaceFeaturesCount_Call {
-	return &Database_GetWorkspaceFeaturesCount_Call{Call: _e.mock.On("GetWorkspaceFeaturesCount", uuid)}
+// StartAuthSession is a helper method to define mock.On call
+//   - m db.AuthSession
+func (_e *Database_Expecter) StartAuthSession(m interface{}) *Database_StartAuthSession_Call {
+	return &Database_StartAuthSession_Call{Call: _e.mock.On("StartAuthSession", m)}
 }
 
-func (_c *Database_GetWorkspaceFeaturesCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceFeaturesCount_Call {
+func (_c *Database_StartAuthSession_Call) Run(run func(m db.AuthSession)) *Database_StartAuthSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.AuthSession))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceFeaturesCount_Call) Return(_a0 int64) *Database_GetWorkspaceFeaturesCount_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_StartAuthSession_Call) Return(_a0 string, _a1 error) *Database_StartAuthSession_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceFeaturesCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceFeaturesCount_Call {
+func (_c *Database_StartAuthSession_Call) RunAndReturn(run func(db.AuthSession) (string, error)) *Database_StartAuthSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceInvoices provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceInvoices(workspace_uuid string) []db.NewInvoiceList {
-	ret := _m.Called(workspace_uuid)
+// StreamBountiesByDateRange provides a mock function with given fields: r, re, fn
+func (_m *Database) StreamBountiesByDateRange(r db.PaymentDateRange, re *http.Request, fn func(db.NewBounty) error) error {
+	ret := _m.Called(r, re, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceInvoices")
+		panic("no return value specified for StreamBountiesByDateRange")
 	}
 
-	var r0 []db.NewInvoiceList
-	if rf, ok := ret.Get(0).(func(string) []db.NewInvoiceList); ok {
-		r0 = rf(workspace_uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request, func(db.NewBounty) error) error); ok {
+		r0 = rf(r, re, fn)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewInvoiceList)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceInvoices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceInvoices'
-type Database_GetWorkspaceInvoices_Call struct {
+// Database_StreamBountiesByDateRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamBountiesByDateRange'
+type Database_StreamBountiesByDateRange_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceInvoices is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceInvoices(workspace_uuid interface{}) *Database_GetWorkspaceInvoices_Call {
-	return &Database_GetWorkspaceInvoices_Call{Call: _e.mock.On("GetWorkspaceInvoices", workspace_uuid)}
+// StreamBountiesByDateRange is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - re *http.Request
+//   - fn func(db.NewBounty) error
+func (_e *Database_Expecter) StreamBountiesByDateRange(r interface{}, re interface{}, fn interface{}) *Database_StreamBountiesByDateRange_Call {
+	return &Database_StreamBountiesByDateRange_Call{Call: _e.mock.On("StreamBountiesByDateRange", r, re, fn)}
 }
 
-func (_c *Database_GetWorkspaceInvoices_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceInvoices_Call {
+func (_c *Database_StreamBountiesByDateRange_Call) Run(run func(r db.PaymentDateRange, re *http.Request, fn func(db.NewBounty) error)) *Database_StreamBountiesByDateRange_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.PaymentDateRange), args[1].(*http.Request), args[2].(func(db.NewBounty) error))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceInvoices_Call) Return(_a0 []db.NewInvoiceList) *Database_GetWorkspaceInvoices_Call {
+func (_c *Database_StreamBountiesByDateRange_Call) Return(_a0 error) *Database_StreamBountiesByDateRange_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceInvoices_Call) RunAndReturn(run func(string) []db.NewInvoiceList) *Database_GetWorkspaceInvoices_Call {
+func (_c *Database_StreamBountiesByDateRange_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request, func(db.NewBounty) error) error) *Database_StreamBountiesByDateRange_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceInvoicesCount provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceInvoicesCount(workspace_uuid string) int64 {
-	ret := _m.Called(workspace_uuid)
+// StreamWorkspaceBounties provides a mock function with given fields: workspaceUuid, filter, fn
+func (_m *Database) StreamWorkspaceBounties(workspaceUuid string, filter db.BountyExportFilter, fn func(db.NewBounty) error) error {
+	ret := _m.Called(workspaceUuid, filter, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceInvoicesCount")
+		panic("no return value specified for StreamWorkspaceBounties")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(workspace_uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, db.BountyExportFilter, func(db.NewBounty) error) error); ok {
+		r0 = rf(workspaceUuid, filter, fn)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceInvoicesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceInvoicesCount'
-type Database_GetWorkspaceInvoicesCount_Call struct {
+// Database_StreamWorkspaceBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamWorkspaceBounties'
+type Database_StreamWorkspaceBounties_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceInvoicesCount is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceInvoicesCount(workspace_uuid interface{}) *Database_GetWorkspaceInvoicesCount_Call {
-	return &Database_GetWorkspaceInvoicesCount_Call{Call: _e.mock.On("GetWorkspaceInvoicesCount", workspace_uuid)}
+// StreamWorkspaceBounties is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - filter db.BountyExportFilter
+//   - fn func(db.NewBounty) error
+func (_e *Database_Expecter) StreamWorkspaceBounties(workspaceUuid interface{}, filter interface{}, fn interface{}) *Database_StreamWorkspaceBounties_Call {
+	return &Database_StreamWorkspaceBounties_Call{Call: _e.mock.On("StreamWorkspaceBounties", workspaceUuid, filter, fn)}
 }
 
-func (_c *Database_GetWorkspaceInvoicesCount_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceInvoicesCount_Call {
+func (_c *Database_StreamWorkspaceBounties_Call) Run(run func(workspaceUuid string, filter db.BountyExportFilter, fn func(db.NewBounty) error)) *Database_StreamWorkspaceBounties_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(db.BountyExportFilter), args[2].(func(db.NewBounty) error))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceInvoicesCount_Call) Return(_a0 int64) *Database_GetWorkspaceInvoicesCount_Call {
+func (_c *Database_StreamWorkspaceBounties_Call) Return(_a0 error) *Database_StreamWorkspaceBounties_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceInvoicesCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceInvoicesCount_Call {
+func (_c *Database_StreamWorkspaceBounties_Call) RunAndReturn(run func(string, db.BountyExportFilter, func(db.NewBounty) error) error) *Database_StreamWorkspaceBounties_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceRepoByWorkspaceUuidAndRepoUuid provides a mock function with given fields: workspace_uuid, uuid
-func (_m *Database) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid string, uuid string) (db.WorkspaceRepositories, error) {
-	ret := _m.Called(workspace_uuid, uuid)
+// SyncTribeMembers provides a mock function with given fields: tribeUuid, members
+func (_m *Database) SyncTribeMembers(tribeUuid string, members []db.TribeMember) ([]db.TribeMember, error) {
+	ret := _m.Called(tribeUuid, members)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceRepoByWorkspaceUuidAndRepoUuid")
+		panic("no return value specified for SyncTribeMembers")
 	}
 
-	var r0 db.WorkspaceRepositories
+	var r0 []db.TribeMember
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (db.WorkspaceRepositories, error)); ok {
-		return rf(workspace_uuid, uuid)
+	if rf, ok := ret.Get(0).(func(string, []db.TribeMember) ([]db.TribeMember, error)); ok {
+		return rf(tribeUuid, members)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceRepositories); ok {
-		r0 = rf(workspace_uuid, uuid)
+	if rf, ok := ret.Get(0).(func(string, []db.TribeMember) []db.TribeMember); ok {
+		r0 = rf(tribeUuid, members)
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceRepositories)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeMember)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(workspace_uuid, uuid)
+	if rf, ok := ret.Get(1).(func(string, []db.TribeMember) error); ok {
+		r1 = rf(tribeUuid, members)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -5853,1287 +21522,1295 @@ func (_m *Database) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid st
 	return r0, r1
 }
 
-// Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceRepoByWorkspaceUuidAndRepoUuid'
-type Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call struct {
+// Database_SyncTribeMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncTribeMembers'
+type Database_SyncTribeMembers_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceRepoByWorkspaceUuidAndRepoUuid is a helper method to define mock.On call
-//   - workspace_uuid string
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid interface{}, uuid interface{}) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
-	return &Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call{Call: _e.mock.On("GetWorkspaceRepoByWorkspaceUuidAndRepoUuid", workspace_uuid, uuid)}
+// SyncTribeMembers is a helper method to define mock.On call
+//   - tribeUuid string
+//   - members []db.TribeMember
+func (_e *Database_Expecter) SyncTribeMembers(tribeUuid interface{}, members interface{}) *Database_SyncTribeMembers_Call {
+	return &Database_SyncTribeMembers_Call{Call: _e.mock.On("SyncTribeMembers", tribeUuid, members)}
 }
 
-func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) Run(run func(workspace_uuid string, uuid string)) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+func (_c *Database_SyncTribeMembers_Call) Run(run func(tribeUuid string, members []db.TribeMember)) *Database_SyncTribeMembers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].([]db.TribeMember))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) Return(_a0 db.WorkspaceRepositories, _a1 error) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+func (_c *Database_SyncTribeMembers_Call) Return(_a0 []db.TribeMember, _a1 error) *Database_SyncTribeMembers_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) RunAndReturn(run func(string, string) (db.WorkspaceRepositories, error)) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+func (_c *Database_SyncTribeMembers_Call) RunAndReturn(run func(string, []db.TribeMember) ([]db.TribeMember, error)) *Database_SyncTribeMembers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceRepositorByWorkspaceUuid provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceRepositorByWorkspaceUuid(uuid string) []db.WorkspaceRepositories {
-	ret := _m.Called(uuid)
+// TotalAssignedBounties provides a mock function with given fields: r, workspace
+func (_m *Database) TotalAssignedBounties(r db.PaymentDateRange, workspace string) int64 {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceRepositorByWorkspaceUuid")
+		panic("no return value specified for TotalAssignedBounties")
 	}
 
-	var r0 []db.WorkspaceRepositories
-	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceRepositories); ok {
-		r0 = rf(uuid)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
+		r0 = rf(r, workspace)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceRepositories)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceRepositorByWorkspaceUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceRepositorByWorkspaceUuid'
-type Database_GetWorkspaceRepositorByWorkspaceUuid_Call struct {
+// Database_TotalAssignedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalAssignedBounties'
+type Database_TotalAssignedBounties_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceRepositorByWorkspaceUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceRepositorByWorkspaceUuid(uuid interface{}) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
-	return &Database_GetWorkspaceRepositorByWorkspaceUuid_Call{Call: _e.mock.On("GetWorkspaceRepositorByWorkspaceUuid", uuid)}
+// TotalAssignedBounties is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) TotalAssignedBounties(r interface{}, workspace interface{}) *Database_TotalAssignedBounties_Call {
+	return &Database_TotalAssignedBounties_Call{Call: _e.mock.On("TotalAssignedBounties", r, workspace)}
 }
 
-func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+func (_c *Database_TotalAssignedBounties_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalAssignedBounties_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) Return(_a0 []db.WorkspaceRepositories) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+func (_c *Database_TotalAssignedBounties_Call) Return(_a0 int64) *Database_TotalAssignedBounties_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) RunAndReturn(run func(string) []db.WorkspaceRepositories) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+func (_c *Database_TotalAssignedBounties_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_TotalAssignedBounties_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceStatusBudget provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceStatusBudget(workspace_uuid string) db.StatusBudget {
-	ret := _m.Called(workspace_uuid)
+// TotalBountiesPosted provides a mock function with given fields: r, workspace
+func (_m *Database) TotalBountiesPosted(r db.PaymentDateRange, workspace string) int64 {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceStatusBudget")
+		panic("no return value specified for TotalBountiesPosted")
 	}
 
-	var r0 db.StatusBudget
-	if rf, ok := ret.Get(0).(func(string) db.StatusBudget); ok {
-		r0 = rf(workspace_uuid)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r0 = ret.Get(0).(db.StatusBudget)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceStatusBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceStatusBudget'
-type Database_GetWorkspaceStatusBudget_Call struct {
+// Database_TotalBountiesPosted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalBountiesPosted'
+type Database_TotalBountiesPosted_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceStatusBudget is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceStatusBudget(workspace_uuid interface{}) *Database_GetWorkspaceStatusBudget_Call {
-	return &Database_GetWorkspaceStatusBudget_Call{Call: _e.mock.On("GetWorkspaceStatusBudget", workspace_uuid)}
+// TotalBountiesPosted is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) TotalBountiesPosted(r interface{}, workspace interface{}) *Database_TotalBountiesPosted_Call {
+	return &Database_TotalBountiesPosted_Call{Call: _e.mock.On("TotalBountiesPosted", r, workspace)}
 }
 
-func (_c *Database_GetWorkspaceStatusBudget_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceStatusBudget_Call {
+func (_c *Database_TotalBountiesPosted_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalBountiesPosted_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceStatusBudget_Call) Return(_a0 db.StatusBudget) *Database_GetWorkspaceStatusBudget_Call {
+func (_c *Database_TotalBountiesPosted_Call) Return(_a0 int64) *Database_TotalBountiesPosted_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceStatusBudget_Call) RunAndReturn(run func(string) db.StatusBudget) *Database_GetWorkspaceStatusBudget_Call {
+func (_c *Database_TotalBountiesPosted_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_TotalBountiesPosted_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceUser provides a mock function with given fields: pubkey, workspace_uuid
-func (_m *Database) GetWorkspaceUser(pubkey string, workspace_uuid string) db.WorkspaceUsers {
-	ret := _m.Called(pubkey, workspace_uuid)
+// TotalHuntersPaid provides a mock function with given fields: r, workspace
+func (_m *Database) TotalHuntersPaid(r db.PaymentDateRange, workspace string) int64 {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceUser")
+		panic("no return value specified for TotalHuntersPaid")
 	}
 
-	var r0 db.WorkspaceUsers
-	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceUsers); ok {
-		r0 = rf(pubkey, workspace_uuid)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceUsers)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUser'
-type Database_GetWorkspaceUser_Call struct {
+// Database_TotalHuntersPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalHuntersPaid'
+type Database_TotalHuntersPaid_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceUser is a helper method to define mock.On call
-//   - pubkey string
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceUser(pubkey interface{}, workspace_uuid interface{}) *Database_GetWorkspaceUser_Call {
-	return &Database_GetWorkspaceUser_Call{Call: _e.mock.On("GetWorkspaceUser", pubkey, workspace_uuid)}
+// TotalHuntersPaid is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) TotalHuntersPaid(r interface{}, workspace interface{}) *Database_TotalHuntersPaid_Call {
+	return &Database_TotalHuntersPaid_Call{Call: _e.mock.On("TotalHuntersPaid", r, workspace)}
 }
 
-func (_c *Database_GetWorkspaceUser_Call) Run(run func(pubkey string, workspace_uuid string)) *Database_GetWorkspaceUser_Call {
+func (_c *Database_TotalHuntersPaid_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalHuntersPaid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUser_Call) Return(_a0 db.WorkspaceUsers) *Database_GetWorkspaceUser_Call {
+func (_c *Database_TotalHuntersPaid_Call) Return(_a0 int64) *Database_TotalHuntersPaid_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUser_Call) RunAndReturn(run func(string, string) db.WorkspaceUsers) *Database_GetWorkspaceUser_Call {
+func (_c *Database_TotalHuntersPaid_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_TotalHuntersPaid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceUsers provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceUsers(uuid string) ([]db.WorkspaceUsersData, error) {
-	ret := _m.Called(uuid)
+// TotalPaidBounties provides a mock function with given fields: r, workspace
+func (_m *Database) TotalPaidBounties(r db.PaymentDateRange, workspace string) int64 {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceUsers")
-	}
-
-	var r0 []db.WorkspaceUsersData
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]db.WorkspaceUsersData, error)); ok {
-		return rf(uuid)
-	}
-	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceUsersData); ok {
-		r0 = rf(uuid)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceUsersData)
-		}
+		panic("no return value specified for TotalPaidBounties")
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(uuid)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetWorkspaceUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsers'
-type Database_GetWorkspaceUsers_Call struct {
+// Database_TotalPaidBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalPaidBounties'
+type Database_TotalPaidBounties_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceUsers is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceUsers(uuid interface{}) *Database_GetWorkspaceUsers_Call {
-	return &Database_GetWorkspaceUsers_Call{Call: _e.mock.On("GetWorkspaceUsers", uuid)}
+// TotalPaidBounties is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) TotalPaidBounties(r interface{}, workspace interface{}) *Database_TotalPaidBounties_Call {
+	return &Database_TotalPaidBounties_Call{Call: _e.mock.On("TotalPaidBounties", r, workspace)}
 }
 
-func (_c *Database_GetWorkspaceUsers_Call) Run(run func(uuid string)) *Database_GetWorkspaceUsers_Call {
+func (_c *Database_TotalPaidBounties_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalPaidBounties_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUsers_Call) Return(_a0 []db.WorkspaceUsersData, _a1 error) *Database_GetWorkspaceUsers_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_TotalPaidBounties_Call) Return(_a0 int64) *Database_TotalPaidBounties_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUsers_Call) RunAndReturn(run func(string) ([]db.WorkspaceUsersData, error)) *Database_GetWorkspaceUsers_Call {
+func (_c *Database_TotalPaidBounties_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_TotalPaidBounties_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceUsersCount provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceUsersCount(uuid string) int64 {
-	ret := _m.Called(uuid)
+// TotalSatsPaid provides a mock function with given fields: r, workspace
+func (_m *Database) TotalSatsPaid(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceUsersCount")
+		panic("no return value specified for TotalSatsPaid")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(uuid)
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(uint)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceUsersCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsersCount'
-type Database_GetWorkspaceUsersCount_Call struct {
+// Database_TotalSatsPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalSatsPaid'
+type Database_TotalSatsPaid_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceUsersCount is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceUsersCount(uuid interface{}) *Database_GetWorkspaceUsersCount_Call {
-	return &Database_GetWorkspaceUsersCount_Call{Call: _e.mock.On("GetWorkspaceUsersCount", uuid)}
+// TotalSatsPaid is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) TotalSatsPaid(r interface{}, workspace interface{}) *Database_TotalSatsPaid_Call {
+	return &Database_TotalSatsPaid_Call{Call: _e.mock.On("TotalSatsPaid", r, workspace)}
 }
 
-func (_c *Database_GetWorkspaceUsersCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceUsersCount_Call {
+func (_c *Database_TotalSatsPaid_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalSatsPaid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUsersCount_Call) Return(_a0 int64) *Database_GetWorkspaceUsersCount_Call {
+func (_c *Database_TotalSatsPaid_Call) Return(_a0 uint) *Database_TotalSatsPaid_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUsersCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceUsersCount_Call {
+func (_c *Database_TotalSatsPaid_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_TotalSatsPaid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaces provides a mock function with given fields: r
-func (_m *Database) GetWorkspaces(r *http.Request) []db.Workspace {
-	ret := _m.Called(r)
+// TotalSatsPosted provides a mock function with given fields: r, workspace
+func (_m *Database) TotalSatsPosted(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaces")
+		panic("no return value specified for TotalSatsPosted")
 	}
 
-	var r0 []db.Workspace
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Workspace); ok {
-		r0 = rf(r)
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Workspace)
-		}
+		r0 = ret.Get(0).(uint)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaces'
-type Database_GetWorkspaces_Call struct {
+// Database_TotalSatsPosted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalSatsPosted'
+type Database_TotalSatsPosted_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaces is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetWorkspaces(r interface{}) *Database_GetWorkspaces_Call {
-	return &Database_GetWorkspaces_Call{Call: _e.mock.On("GetWorkspaces", r)}
+// TotalSatsPosted is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) TotalSatsPosted(r interface{}, workspace interface{}) *Database_TotalSatsPosted_Call {
+	return &Database_TotalSatsPosted_Call{Call: _e.mock.On("TotalSatsPosted", r, workspace)}
 }
 
-func (_c *Database_GetWorkspaces_Call) Run(run func(r *http.Request)) *Database_GetWorkspaces_Call {
+func (_c *Database_TotalSatsPosted_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalSatsPosted_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaces_Call) Return(_a0 []db.Workspace) *Database_GetWorkspaces_Call {
+func (_c *Database_TotalSatsPosted_Call) Return(_a0 uint) *Database_TotalSatsPosted_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaces_Call) RunAndReturn(run func(*http.Request) []db.Workspace) *Database_GetWorkspaces_Call {
+func (_c *Database_TotalSatsPosted_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_TotalSatsPosted_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspacesCount provides a mock function with given fields:
-func (_m *Database) GetWorkspacesCount() int64 {
-	ret := _m.Called()
+// TouchAuthSession provides a mock function with given fields: uuid
+func (_m *Database) TouchAuthSession(uuid string) error {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspacesCount")
+		panic("no return value specified for TouchAuthSession")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspacesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspacesCount'
-type Database_GetWorkspacesCount_Call struct {
+// Database_TouchAuthSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchAuthSession'
+type Database_TouchAuthSession_Call struct {
 	*mock.Call
 }
 
-// GetWorkspacesCount is a helper method to define mock.On call
-func (_e *Database_Expecter) GetWorkspacesCount() *Database_GetWorkspacesCount_Call {
-	return &Database_GetWorkspacesCount_Call{Call: _e.mock.On("GetWorkspacesCount")}
+// TouchAuthSession is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) TouchAuthSession(uuid interface{}) *Database_TouchAuthSession_Call {
+	return &Database_TouchAuthSession_Call{Call: _e.mock.On("TouchAuthSession", uuid)}
 }
 
-func (_c *Database_GetWorkspacesCount_Call) Run(run func()) *Database_GetWorkspacesCount_Call {
+func (_c *Database_TouchAuthSession_Call) Run(run func(uuid string)) *Database_TouchAuthSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspacesCount_Call) Return(_a0 int64) *Database_GetWorkspacesCount_Call {
+func (_c *Database_TouchAuthSession_Call) Return(_a0 error) *Database_TouchAuthSession_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspacesCount_Call) RunAndReturn(run func() int64) *Database_GetWorkspacesCount_Call {
+func (_c *Database_TouchAuthSession_Call) RunAndReturn(run func(string) error) *Database_TouchAuthSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewHuntersPaid provides a mock function with given fields: r, workspace
-func (_m *Database) NewHuntersPaid(r db.PaymentDateRange, workspace string) int64 {
-	ret := _m.Called(r, workspace)
+// UnassignExpiredBounty provides a mock function with given fields: b
+func (_m *Database) UnassignExpiredBounty(b db.NewBounty) error {
+	ret := _m.Called(b)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NewHuntersPaid")
+		panic("no return value specified for UnassignExpiredBounty")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
-		r0 = rf(r, workspace)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewBounty) error); ok {
+		r0 = rf(b)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_NewHuntersPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewHuntersPaid'
-type Database_NewHuntersPaid_Call struct {
+// Database_UnassignExpiredBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnassignExpiredBounty'
+type Database_UnassignExpiredBounty_Call struct {
 	*mock.Call
 }
 
-// NewHuntersPaid is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) NewHuntersPaid(r interface{}, workspace interface{}) *Database_NewHuntersPaid_Call {
-	return &Database_NewHuntersPaid_Call{Call: _e.mock.On("NewHuntersPaid", r, workspace)}
+// UnassignExpiredBounty is a helper method to define mock.On call
+//   - b db.NewBounty
+func (_e *Database_Expecter) UnassignExpiredBounty(b interface{}) *Database_UnassignExpiredBounty_Call {
+	return &Database_UnassignExpiredBounty_Call{Call: _e.mock.On("UnassignExpiredBounty", b)}
 }
 
-func (_c *Database_NewHuntersPaid_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_NewHuntersPaid_Call {
+func (_c *Database_UnassignExpiredBounty_Call) Run(run func(b db.NewBounty)) *Database_UnassignExpiredBounty_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(db.NewBounty))
 	})
 	return _c
 }
 
-func (_c *Database_NewHuntersPaid_Call) Return(_a0 int64) *Database_NewHuntersPaid_Call {
+func (_c *Database_UnassignExpiredBounty_Call) Return(_a0 error) *Database_UnassignExpiredBounty_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_NewHuntersPaid_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_NewHuntersPaid_Call {
+func (_c *Database_UnassignExpiredBounty_Call) RunAndReturn(run func(db.NewBounty) error) *Database_UnassignExpiredBounty_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PersonUniqueNameFromName provides a mock function with given fields: name
-func (_m *Database) PersonUniqueNameFromName(name string) (string, error) {
-	ret := _m.Called(name)
-
-	if len(ret) == 0 {
-		panic("no return value specified for PersonUniqueNameFromName")
-	}
-
-	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(name)
-	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(name)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
+// UpdateApiKeyLastUsed provides a mock function with given fields: id
+func (_m *Database) UpdateApiKeyLastUsed(id uint) {
+	_m.Called(id)
 }
 
-// Database_PersonUniqueNameFromName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PersonUniqueNameFromName'
-type Database_PersonUniqueNameFromName_Call struct {
+// Database_UpdateApiKeyLastUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateApiKeyLastUsed'
+type Database_UpdateApiKeyLastUsed_Call struct {
 	*mock.Call
 }
 
-// PersonUniqueNameFromName is a helper method to define mock.On call
-//   - name string
-func (_e *Database_Expecter) PersonUniqueNameFromName(name interface{}) *Database_PersonUniqueNameFromName_Call {
-	return &Database_PersonUniqueNameFromName_Call{Call: _e.mock.On("PersonUniqueNameFromName", name)}
+// UpdateApiKeyLastUsed is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) UpdateApiKeyLastUsed(id interface{}) *Database_UpdateApiKeyLastUsed_Call {
+	return &Database_UpdateApiKeyLastUsed_Call{Call: _e.mock.On("UpdateApiKeyLastUsed", id)}
 }
 
-func (_c *Database_PersonUniqueNameFromName_Call) Run(run func(name string)) *Database_PersonUniqueNameFromName_Call {
+func (_c *Database_UpdateApiKeyLastUsed_Call) Run(run func(id uint)) *Database_UpdateApiKeyLastUsed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_PersonUniqueNameFromName_Call) Return(_a0 string, _a1 error) *Database_PersonUniqueNameFromName_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_UpdateApiKeyLastUsed_Call) Return() *Database_UpdateApiKeyLastUsed_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *Database_PersonUniqueNameFromName_Call) RunAndReturn(run func(string) (string, error)) *Database_PersonUniqueNameFromName_Call {
+func (_c *Database_UpdateApiKeyLastUsed_Call) RunAndReturn(run func(uint)) *Database_UpdateApiKeyLastUsed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessAddInvoice provides a mock function with given fields: invoice, userData
-func (_m *Database) ProcessAddInvoice(invoice db.NewInvoiceList, userData db.UserInvoiceData) error {
-	ret := _m.Called(invoice, userData)
+// UpdateBot provides a mock function with given fields: uuid, u
+func (_m *Database) UpdateBot(uuid string, u map[string]interface{}) bool {
+	ret := _m.Called(uuid, u)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessAddInvoice")
+		panic("no return value specified for UpdateBot")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(db.NewInvoiceList, db.UserInvoiceData) error); ok {
-		r0 = rf(invoice, userData)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, map[string]interface{}) bool); ok {
+		r0 = rf(uuid, u)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// Database_ProcessAddInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessAddInvoice'
-type Database_ProcessAddInvoice_Call struct {
+// Database_UpdateBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBot'
+type Database_UpdateBot_Call struct {
 	*mock.Call
 }
 
-// ProcessAddInvoice is a helper method to define mock.On call
-//   - invoice db.NewInvoiceList
-//   - userData db.UserInvoiceData
-func (_e *Database_Expecter) ProcessAddInvoice(invoice interface{}, userData interface{}) *Database_ProcessAddInvoice_Call {
-	return &Database_ProcessAddInvoice_Call{Call: _e.mock.On("ProcessAddInvoice", invoice, userData)}
+// UpdateBot is a helper method to define mock.On call
+//   - uuid string
+//   - u map[string]interface{}
+func (_e *Database_Expecter) UpdateBot(uuid interface{}, u interface{}) *Database_UpdateBot_Call {
+	return &Database_UpdateBot_Call{Call: _e.mock.On("UpdateBot", uuid, u)}
 }
 
-func (_c *Database_ProcessAddInvoice_Call) Run(run func(invoice db.NewInvoiceList, userData db.UserInvoiceData)) *Database_ProcessAddInvoice_Call {
+func (_c *Database_UpdateBot_Call) Run(run func(uuid string, u map[string]interface{})) *Database_UpdateBot_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewInvoiceList), args[1].(db.UserInvoiceData))
+		run(args[0].(string), args[1].(map[string]interface{}))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessAddInvoice_Call) Return(_a0 error) *Database_ProcessAddInvoice_Call {
+func (_c *Database_UpdateBot_Call) Return(_a0 bool) *Database_UpdateBot_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_ProcessAddInvoice_Call) RunAndReturn(run func(db.NewInvoiceList, db.UserInvoiceData) error) *Database_ProcessAddInvoice_Call {
+func (_c *Database_UpdateBot_Call) RunAndReturn(run func(string, map[string]interface{}) bool) *Database_UpdateBot_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessAlerts provides a mock function with given fields: p
-func (_m *Database) ProcessAlerts(p db.Person) {
-	_m.Called(p)
+// UpdateBounty provides a mock function with given fields: b
+func (_m *Database) UpdateBounty(b db.NewBounty) (db.NewBounty, error) {
+	ret := _m.Called(b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBounty")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NewBounty) (db.NewBounty, error)); ok {
+		return rf(b)
+	}
+	if rf, ok := ret.Get(0).(func(db.NewBounty) db.NewBounty); ok {
+		r0 = rf(b)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.NewBounty) error); ok {
+		r1 = rf(b)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ProcessAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessAlerts'
-type Database_ProcessAlerts_Call struct {
+// Database_UpdateBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBounty'
+type Database_UpdateBounty_Call struct {
 	*mock.Call
 }
 
-// ProcessAlerts is a helper method to define mock.On call
-//   - p db.Person
-func (_e *Database_Expecter) ProcessAlerts(p interface{}) *Database_ProcessAlerts_Call {
-	return &Database_ProcessAlerts_Call{Call: _e.mock.On("ProcessAlerts", p)}
+// UpdateBounty is a helper method to define mock.On call
+//   - b db.NewBounty
+func (_e *Database_Expecter) UpdateBounty(b interface{}) *Database_UpdateBounty_Call {
+	return &Database_UpdateBounty_Call{Call: _e.mock.On("UpdateBounty", b)}
 }
 
-func (_c *Database_ProcessAlerts_Call) Run(run func(p db.Person)) *Database_ProcessAlerts_Call {
+func (_c *Database_UpdateBounty_Call) Run(run func(b db.NewBounty)) *Database_UpdateBounty_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Person))
+		run(args[0].(db.NewBounty))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessAlerts_Call) Return() *Database_ProcessAlerts_Call {
-	_c.Call.Return()
+func (_c *Database_UpdateBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_UpdateBounty_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessAlerts_Call) RunAndReturn(run func(db.Person)) *Database_ProcessAlerts_Call {
+func (_c *Database_UpdateBounty_Call) RunAndReturn(run func(db.NewBounty) (db.NewBounty, error)) *Database_UpdateBounty_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessBountyPayment provides a mock function with given fields: payment, bounty
-func (_m *Database) ProcessBountyPayment(payment db.NewPaymentHistory, bounty db.NewBounty) error {
-	ret := _m.Called(payment, bounty)
+// UpdateBountyBoolColumn provides a mock function with given fields: b, column
+func (_m *Database) UpdateBountyBoolColumn(b db.NewBounty, column string) db.NewBounty {
+	ret := _m.Called(b, column)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessBountyPayment")
+		panic("no return value specified for UpdateBountyBoolColumn")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory, db.NewBounty) error); ok {
-		r0 = rf(payment, bounty)
+	var r0 db.NewBounty
+	if rf, ok := ret.Get(0).(func(db.NewBounty, string) db.NewBounty); ok {
+		r0 = rf(b, column)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.NewBounty)
 	}
 
 	return r0
 }
 
-// Database_ProcessBountyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessBountyPayment'
-type Database_ProcessBountyPayment_Call struct {
+// Database_UpdateBountyBoolColumn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBountyBoolColumn'
+type Database_UpdateBountyBoolColumn_Call struct {
 	*mock.Call
 }
 
-// ProcessBountyPayment is a helper method to define mock.On call
-//   - payment db.NewPaymentHistory
-//   - bounty db.NewBounty
-func (_e *Database_Expecter) ProcessBountyPayment(payment interface{}, bounty interface{}) *Database_ProcessBountyPayment_Call {
-	return &Database_ProcessBountyPayment_Call{Call: _e.mock.On("ProcessBountyPayment", payment, bounty)}
+// UpdateBountyBoolColumn is a helper method to define mock.On call
+//   - b db.NewBounty
+//   - column string
+func (_e *Database_Expecter) UpdateBountyBoolColumn(b interface{}, column interface{}) *Database_UpdateBountyBoolColumn_Call {
+	return &Database_UpdateBountyBoolColumn_Call{Call: _e.mock.On("UpdateBountyBoolColumn", b, column)}
 }
 
-func (_c *Database_ProcessBountyPayment_Call) Run(run func(payment db.NewPaymentHistory, bounty db.NewBounty)) *Database_ProcessBountyPayment_Call {
+func (_c *Database_UpdateBountyBoolColumn_Call) Run(run func(b db.NewBounty, column string)) *Database_UpdateBountyBoolColumn_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewPaymentHistory), args[1].(db.NewBounty))
+		run(args[0].(db.NewBounty), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessBountyPayment_Call) Return(_a0 error) *Database_ProcessBountyPayment_Call {
+func (_c *Database_UpdateBountyBoolColumn_Call) Return(_a0 db.NewBounty) *Database_UpdateBountyBoolColumn_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_ProcessBountyPayment_Call) RunAndReturn(run func(db.NewPaymentHistory, db.NewBounty) error) *Database_ProcessBountyPayment_Call {
+func (_c *Database_UpdateBountyBoolColumn_Call) RunAndReturn(run func(db.NewBounty, string) db.NewBounty) *Database_UpdateBountyBoolColumn_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessBudgetInvoice provides a mock function with given fields: paymentHistory, newInvoice
-func (_m *Database) ProcessBudgetInvoice(paymentHistory db.NewPaymentHistory, newInvoice db.NewInvoiceList) error {
-	ret := _m.Called(paymentHistory, newInvoice)
+// UpdateBountyCompleted provides a mock function with given fields: b
+func (_m *Database) UpdateBountyCompleted(b db.NewBounty) (db.NewBounty, error) {
+	ret := _m.Called(b)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessBudgetInvoice")
+		panic("no return value specified for UpdateBountyCompleted")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory, db.NewInvoiceList) error); ok {
-		r0 = rf(paymentHistory, newInvoice)
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NewBounty) (db.NewBounty, error)); ok {
+		return rf(b)
+	}
+	if rf, ok := ret.Get(0).(func(db.NewBounty) db.NewBounty); ok {
+		r0 = rf(b)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.NewBounty)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.NewBounty) error); ok {
+		r1 = rf(b)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ProcessBudgetInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessBudgetInvoice'
-type Database_ProcessBudgetInvoice_Call struct {
+// Database_UpdateBountyCompleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBountyCompleted'
+type Database_UpdateBountyCompleted_Call struct {
 	*mock.Call
 }
 
-// ProcessBudgetInvoice is a helper method to define mock.On call
-//   - paymentHistory db.NewPaymentHistory
-//   - newInvoice db.NewInvoiceList
-func (_e *Database_Expecter) ProcessBudgetInvoice(paymentHistory interface{}, newInvoice interface{}) *Database_ProcessBudgetInvoice_Call {
-	return &Database_ProcessBudgetInvoice_Call{Call: _e.mock.On("ProcessBudgetInvoice", paymentHistory, newInvoice)}
+// UpdateBountyCompleted is a helper method to define mock.On call
+//   - b db.NewBounty
+func (_e *Database_Expecter) UpdateBountyCompleted(b interface{}) *Database_UpdateBountyCompleted_Call {
+	return &Database_UpdateBountyCompleted_Call{Call: _e.mock.On("UpdateBountyCompleted", b)}
 }
 
-func (_c *Database_ProcessBudgetInvoice_Call) Run(run func(paymentHistory db.NewPaymentHistory, newInvoice db.NewInvoiceList)) *Database_ProcessBudgetInvoice_Call {
+func (_c *Database_UpdateBountyCompleted_Call) Run(run func(b db.NewBounty)) *Database_UpdateBountyCompleted_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewPaymentHistory), args[1].(db.NewInvoiceList))
+		run(args[0].(db.NewBounty))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessBudgetInvoice_Call) Return(_a0 error) *Database_ProcessBudgetInvoice_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateBountyCompleted_Call) Return(_a0 db.NewBounty, _a1 error) *Database_UpdateBountyCompleted_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessBudgetInvoice_Call) RunAndReturn(run func(db.NewPaymentHistory, db.NewInvoiceList) error) *Database_ProcessBudgetInvoice_Call {
+func (_c *Database_UpdateBountyCompleted_Call) RunAndReturn(run func(db.NewBounty) (db.NewBounty, error)) *Database_UpdateBountyCompleted_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessDeleteWorkspace provides a mock function with given fields: workspace_uuid
-func (_m *Database) ProcessDeleteWorkspace(workspace_uuid string) error {
-	ret := _m.Called(workspace_uuid)
+// UpdateBountyNullColumn provides a mock function with given fields: b, column
+func (_m *Database) UpdateBountyNullColumn(b db.NewBounty, column string) db.NewBounty {
+	ret := _m.Called(b, column)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessDeleteWorkspace")
+		panic("no return value specified for UpdateBountyNullColumn")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(workspace_uuid)
+	var r0 db.NewBounty
+	if rf, ok := ret.Get(0).(func(db.NewBounty, string) db.NewBounty); ok {
+		r0 = rf(b, column)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.NewBounty)
 	}
 
 	return r0
 }
 
-// Database_ProcessDeleteWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessDeleteWorkspace'
-type Database_ProcessDeleteWorkspace_Call struct {
+// Database_UpdateBountyNullColumn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBountyNullColumn'
+type Database_UpdateBountyNullColumn_Call struct {
 	*mock.Call
 }
 
-// ProcessDeleteWorkspace is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) ProcessDeleteWorkspace(workspace_uuid interface{}) *Database_ProcessDeleteWorkspace_Call {
-	return &Database_ProcessDeleteWorkspace_Call{Call: _e.mock.On("ProcessDeleteWorkspace", workspace_uuid)}
+// UpdateBountyNullColumn is a helper method to define mock.On call
+//   - b db.NewBounty
+//   - column string
+func (_e *Database_Expecter) UpdateBountyNullColumn(b interface{}, column interface{}) *Database_UpdateBountyNullColumn_Call {
+	return &Database_UpdateBountyNullColumn_Call{Call: _e.mock.On("UpdateBountyNullColumn", b, column)}
 }
 
-func (_c *Database_ProcessDeleteWorkspace_Call) Run(run func(workspace_uuid string)) *Database_ProcessDeleteWorkspace_Call {
+func (_c *Database_UpdateBountyNullColumn_Call) Run(run func(b db.NewBounty, column string)) *Database_UpdateBountyNullColumn_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.NewBounty), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessDeleteWorkspace_Call) Return(_a0 error) *Database_ProcessDeleteWorkspace_Call {
+func (_c *Database_UpdateBountyNullColumn_Call) Return(_a0 db.NewBounty) *Database_UpdateBountyNullColumn_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_ProcessDeleteWorkspace_Call) RunAndReturn(run func(string) error) *Database_ProcessDeleteWorkspace_Call {
+func (_c *Database_UpdateBountyNullColumn_Call) RunAndReturn(run func(db.NewBounty, string) db.NewBounty) *Database_UpdateBountyNullColumn_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessUpdateBudget provides a mock function with given fields: invoice
-func (_m *Database) ProcessUpdateBudget(invoice db.NewInvoiceList) error {
-	ret := _m.Called(invoice)
+// UpdateBountyPayment provides a mock function with given fields: b
+func (_m *Database) UpdateBountyPayment(b db.NewBounty) (db.NewBounty, error) {
+	ret := _m.Called(b)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessUpdateBudget")
+		panic("no return value specified for UpdateBountyPayment")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(db.NewInvoiceList) error); ok {
-		r0 = rf(invoice)
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NewBounty) (db.NewBounty, error)); ok {
+		return rf(b)
+	}
+	if rf, ok := ret.Get(0).(func(db.NewBounty) db.NewBounty); ok {
+		r0 = rf(b)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.NewBounty)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.NewBounty) error); ok {
+		r1 = rf(b)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ProcessUpdateBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessUpdateBudget'
-type Database_ProcessUpdateBudget_Call struct {
+// Database_UpdateBountyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBountyPayment'
+type Database_UpdateBountyPayment_Call struct {
 	*mock.Call
 }
 
-// ProcessUpdateBudget is a helper method to define mock.On call
-//   - invoice db.NewInvoiceList
-func (_e *Database_Expecter) ProcessUpdateBudget(invoice interface{}) *Database_ProcessUpdateBudget_Call {
-	return &Database_ProcessUpdateBudget_Call{Call: _e.mock.On("ProcessUpdateBudget", invoice)}
+// UpdateBountyPayment is a helper method to define mock.On call
+//   - b db.NewBounty
+func (_e *Database_Expecter) UpdateBountyPayment(b interface{}) *Database_UpdateBountyPayment_Call {
+	return &Database_UpdateBountyPayment_Call{Call: _e.mock.On("UpdateBountyPayment", b)}
 }
 
-func (_c *Database_ProcessUpdateBudget_Call) Run(run func(invoice db.NewInvoiceList)) *Database_ProcessUpdateBudget_Call {
+func (_c *Database_UpdateBountyPayment_Call) Run(run func(b db.NewBounty)) *Database_UpdateBountyPayment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewInvoiceList))
+		run(args[0].(db.NewBounty))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessUpdateBudget_Call) Return(_a0 error) *Database_ProcessUpdateBudget_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateBountyPayment_Call) Return(_a0 db.NewBounty, _a1 error) *Database_UpdateBountyPayment_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessUpdateBudget_Call) RunAndReturn(run func(db.NewInvoiceList) error) *Database_ProcessUpdateBudget_Call {
+func (_c *Database_UpdateBountyPayment_Call) RunAndReturn(run func(db.NewBounty) (db.NewBounty, error)) *Database_UpdateBountyPayment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SatsPaidPercentage provides a mock function with given fields: r, workspace
-func (_m *Database) SatsPaidPercentage(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// UpdateChannel provides a mock function with given fields: id, u
+func (_m *Database) UpdateChannel(id uint, u map[string]interface{}) bool {
+	ret := _m.Called(id, u)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SatsPaidPercentage")
+		panic("no return value specified for UpdateChannel")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint, map[string]interface{}) bool); ok {
+		r0 = rf(id, u)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// Database_SatsPaidPercentage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SatsPaidPercentage'
-type Database_SatsPaidPercentage_Call struct {
+// Database_UpdateChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChannel'
+type Database_UpdateChannel_Call struct {
 	*mock.Call
 }
 
-// SatsPaidPercentage is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) SatsPaidPercentage(r interface{}, workspace interface{}) *Database_SatsPaidPercentage_Call {
-	return &Database_SatsPaidPercentage_Call{Call: _e.mock.On("SatsPaidPercentage", r, workspace)}
+// UpdateChannel is a helper method to define mock.On call
+//   - id uint
+//   - u map[string]interface{}
+func (_e *Database_Expecter) UpdateChannel(id interface{}, u interface{}) *Database_UpdateChannel_Call {
+	return &Database_UpdateChannel_Call{Call: _e.mock.On("UpdateChannel", id, u)}
 }
 
-func (_c *Database_SatsPaidPercentage_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_SatsPaidPercentage_Call {
+func (_c *Database_UpdateChannel_Call) Run(run func(id uint, u map[string]interface{})) *Database_UpdateChannel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(uint), args[1].(map[string]interface{}))
 	})
 	return _c
 }
 
-func (_c *Database_SatsPaidPercentage_Call) Return(_a0 uint) *Database_SatsPaidPercentage_Call {
+func (_c *Database_UpdateChannel_Call) Return(_a0 bool) *Database_UpdateChannel_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_SatsPaidPercentage_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_SatsPaidPercentage_Call {
+func (_c *Database_UpdateChannel_Call) RunAndReturn(run func(uint, map[string]interface{}) bool) *Database_UpdateChannel_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchBots provides a mock function with given fields: s, limit, offset
-func (_m *Database) SearchBots(s string, limit int, offset int) []db.BotRes {
-	ret := _m.Called(s, limit, offset)
+// UpdateEscrowStatus provides a mock function with given fields: m, from, to
+func (_m *Database) UpdateEscrowStatus(m db.BountyEscrow, from db.EscrowStatus, to db.EscrowStatus) (db.BountyEscrow, error) {
+	ret := _m.Called(m, from, to)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SearchBots")
+		panic("no return value specified for UpdateEscrowStatus")
 	}
 
-	var r0 []db.BotRes
-	if rf, ok := ret.Get(0).(func(string, int, int) []db.BotRes); ok {
-		r0 = rf(s, limit, offset)
+	var r0 db.BountyEscrow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyEscrow, db.EscrowStatus, db.EscrowStatus) (db.BountyEscrow, error)); ok {
+		return rf(m, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyEscrow, db.EscrowStatus, db.EscrowStatus) db.BountyEscrow); ok {
+		r0 = rf(m, from, to)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.BotRes)
-		}
+		r0 = ret.Get(0).(db.BountyEscrow)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.BountyEscrow, db.EscrowStatus, db.EscrowStatus) error); ok {
+		r1 = rf(m, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_SearchBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchBots'
-type Database_SearchBots_Call struct {
+// Database_UpdateEscrowStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEscrowStatus'
+type Database_UpdateEscrowStatus_Call struct {
 	*mock.Call
 }
 
-// SearchBots is a helper method to define mock.On call
-//   - s string
-//   - limit int
-//   - offset int
-func (_e *Database_Expecter) SearchBots(s interface{}, limit interface{}, offset interface{}) *Database_SearchBots_Call {
-	return &Database_SearchBots_Call{Call: _e.mock.On("SearchBots", s, limit, offset)}
+// UpdateEscrowStatus is a helper method to define mock.On call
+//   - m db.BountyEscrow
+//   - from db.EscrowStatus
+//   - to db.EscrowStatus
+func (_e *Database_Expecter) UpdateEscrowStatus(m interface{}, from interface{}, to interface{}) *Database_UpdateEscrowStatus_Call {
+	return &Database_UpdateEscrowStatus_Call{Call: _e.mock.On("UpdateEscrowStatus", m, from, to)}
 }
 
-func (_c *Database_SearchBots_Call) Run(run func(s string, limit int, offset int)) *Database_SearchBots_Call {
+func (_c *Database_UpdateEscrowStatus_Call) Run(run func(m db.BountyEscrow, from db.EscrowStatus, to db.EscrowStatus)) *Database_UpdateEscrowStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(int), args[2].(int))
+		run(args[0].(db.BountyEscrow), args[1].(db.EscrowStatus), args[2].(db.EscrowStatus))
 	})
 	return _c
 }
 
-func (_c *Database_SearchBots_Call) Return(_a0 []db.BotRes) *Database_SearchBots_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateEscrowStatus_Call) Return(_a0 db.BountyEscrow, _a1 error) *Database_UpdateEscrowStatus_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_SearchBots_Call) RunAndReturn(run func(string, int, int) []db.BotRes) *Database_SearchBots_Call {
+func (_c *Database_UpdateEscrowStatus_Call) RunAndReturn(run func(db.BountyEscrow, db.EscrowStatus, db.EscrowStatus) (db.BountyEscrow, error)) *Database_UpdateEscrowStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchPeople provides a mock function with given fields: s, limit, offset
-func (_m *Database) SearchPeople(s string, limit int, offset int) []db.Person {
-	ret := _m.Called(s, limit, offset)
-
-	if len(ret) == 0 {
-		panic("no return value specified for SearchPeople")
-	}
-
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func(string, int, int) []db.Person); ok {
-		r0 = rf(s, limit, offset)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
-	}
-
-	return r0
+// UpdateGithubConfirmed provides a mock function with given fields: id, confirmed
+func (_m *Database) UpdateGithubConfirmed(id uint, confirmed bool) {
+	_m.Called(id, confirmed)
 }
 
-// Database_SearchPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchPeople'
-type Database_SearchPeople_Call struct {
+// Database_UpdateGithubConfirmed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateGithubConfirmed'
+type Database_UpdateGithubConfirmed_Call struct {
 	*mock.Call
 }
 
-// SearchPeople is a helper method to define mock.On call
-//   - s string
-//   - limit int
-//   - offset int
-func (_e *Database_Expecter) SearchPeople(s interface{}, limit interface{}, offset interface{}) *Database_SearchPeople_Call {
-	return &Database_SearchPeople_Call{Call: _e.mock.On("SearchPeople", s, limit, offset)}
+// UpdateGithubConfirmed is a helper method to define mock.On call
+//   - id uint
+//   - confirmed bool
+func (_e *Database_Expecter) UpdateGithubConfirmed(id interface{}, confirmed interface{}) *Database_UpdateGithubConfirmed_Call {
+	return &Database_UpdateGithubConfirmed_Call{Call: _e.mock.On("UpdateGithubConfirmed", id, confirmed)}
 }
 
-func (_c *Database_SearchPeople_Call) Run(run func(s string, limit int, offset int)) *Database_SearchPeople_Call {
+func (_c *Database_UpdateGithubConfirmed_Call) Run(run func(id uint, confirmed bool)) *Database_UpdateGithubConfirmed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(int), args[2].(int))
+		run(args[0].(uint), args[1].(bool))
 	})
 	return _c
 }
 
-func (_c *Database_SearchPeople_Call) Return(_a0 []db.Person) *Database_SearchPeople_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateGithubConfirmed_Call) Return() *Database_UpdateGithubConfirmed_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *Database_SearchPeople_Call) RunAndReturn(run func(string, int, int) []db.Person) *Database_SearchPeople_Call {
+func (_c *Database_UpdateGithubConfirmed_Call) RunAndReturn(run func(uint, bool)) *Database_UpdateGithubConfirmed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchTribes provides a mock function with given fields: s
-func (_m *Database) SearchTribes(s string) []db.Tribe {
-	ret := _m.Called(s)
-
-	if len(ret) == 0 {
-		panic("no return value specified for SearchTribes")
-	}
-
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
-		r0 = rf(s)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
-	}
-
-	return r0
+// UpdateGithubIssues provides a mock function with given fields: id, issues
+func (_m *Database) UpdateGithubIssues(id uint, issues map[string]interface{}) {
+	_m.Called(id, issues)
 }
 
-// Database_SearchTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchTribes'
-type Database_SearchTribes_Call struct {
+// Database_UpdateGithubIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateGithubIssues'
+type Database_UpdateGithubIssues_Call struct {
 	*mock.Call
 }
 
-// SearchTribes is a helper method to define mock.On call
-//   - s string
-func (_e *Database_Expecter) SearchTribes(s interface{}) *Database_SearchTribes_Call {
-	return &Database_SearchTribes_Call{Call: _e.mock.On("SearchTribes", s)}
+// UpdateGithubIssues is a helper method to define mock.On call
+//   - id uint
+//   - issues map[string]interface{}
+func (_e *Database_Expecter) UpdateGithubIssues(id interface{}, issues interface{}) *Database_UpdateGithubIssues_Call {
+	return &Database_UpdateGithubIssues_Call{Call: _e.mock.On("UpdateGithubIssues", id, issues)}
 }
 
-func (_c *Database_SearchTribes_Call) Run(run func(s string)) *Database_SearchTribes_Call {
+func (_c *Database_UpdateGithubIssues_Call) Run(run func(id uint, issues map[string]interface{})) *Database_UpdateGithubIssues_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(uint), args[1].(map[string]interface{}))
 	})
 	return _c
 }
 
-func (_c *Database_SearchTribes_Call) Return(_a0 []db.Tribe) *Database_SearchTribes_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateGithubIssues_Call) Return() *Database_UpdateGithubIssues_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *Database_SearchTribes_Call) RunAndReturn(run func(string) []db.Tribe) *Database_SearchTribes_Call {
+func (_c *Database_UpdateGithubIssues_Call) RunAndReturn(run func(uint, map[string]interface{})) *Database_UpdateGithubIssues_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TotalAssignedBounties provides a mock function with given fields: r, workspace
-func (_m *Database) TotalAssignedBounties(r db.PaymentDateRange, workspace string) int64 {
-	ret := _m.Called(r, workspace)
+// UpdateIdempotencyKeyResponse provides a mock function with given fields: id, statusCode, response
+func (_m *Database) UpdateIdempotencyKeyResponse(id uint, statusCode int, response string) error {
+	ret := _m.Called(id, statusCode, response)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TotalAssignedBounties")
+		panic("no return value specified for UpdateIdempotencyKeyResponse")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
-		r0 = rf(r, workspace)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, int, string) error); ok {
+		r0 = rf(id, statusCode, response)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_TotalAssignedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalAssignedBounties'
-type Database_TotalAssignedBounties_Call struct {
+// Database_UpdateIdempotencyKeyResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateIdempotencyKeyResponse'
+type Database_UpdateIdempotencyKeyResponse_Call struct {
 	*mock.Call
 }
 
-// TotalAssignedBounties is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) TotalAssignedBounties(r interface{}, workspace interface{}) *Database_TotalAssignedBounties_Call {
-	return &Database_TotalAssignedBounties_Call{Call: _e.mock.On("TotalAssignedBounties", r, workspace)}
+// UpdateIdempotencyKeyResponse is a helper method to define mock.On call
+//   - id uint
+//   - statusCode int
+//   - response string
+func (_e *Database_Expecter) UpdateIdempotencyKeyResponse(id interface{}, statusCode interface{}, response interface{}) *Database_UpdateIdempotencyKeyResponse_Call {
+	return &Database_UpdateIdempotencyKeyResponse_Call{Call: _e.mock.On("UpdateIdempotencyKeyResponse", id, statusCode, response)}
 }
 
-func (_c *Database_TotalAssignedBounties_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalAssignedBounties_Call {
+func (_c *Database_UpdateIdempotencyKeyResponse_Call) Run(run func(id uint, statusCode int, response string)) *Database_UpdateIdempotencyKeyResponse_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(uint), args[1].(int), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_TotalAssignedBounties_Call) Return(_a0 int64) *Database_TotalAssignedBounties_Call {
+func (_c *Database_UpdateIdempotencyKeyResponse_Call) Return(_a0 error) *Database_UpdateIdempotencyKeyResponse_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_TotalAssignedBounties_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_TotalAssignedBounties_Call {
+func (_c *Database_UpdateIdempotencyKeyResponse_Call) RunAndReturn(run func(uint, int, string) error) *Database_UpdateIdempotencyKeyResponse_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TotalBountiesPosted provides a mock function with given fields: r, workspace
-func (_m *Database) TotalBountiesPosted(r db.PaymentDateRange, workspace string) int64 {
-	ret := _m.Called(r, workspace)
+// UpdateInvoice provides a mock function with given fields: payment_request
+func (_m *Database) UpdateInvoice(payment_request string) db.NewInvoiceList {
+	ret := _m.Called(payment_request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TotalBountiesPosted")
+		panic("no return value specified for UpdateInvoice")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
-		r0 = rf(r, workspace)
+	var r0 db.NewInvoiceList
+	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
+		r0 = rf(payment_request)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.NewInvoiceList)
 	}
 
 	return r0
 }
 
-// Database_TotalBountiesPosted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalBountiesPosted'
-type Database_TotalBountiesPosted_Call struct {
+// Database_UpdateInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateInvoice'
+type Database_UpdateInvoice_Call struct {
 	*mock.Call
 }
 
-// TotalBountiesPosted is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) TotalBountiesPosted(r interface{}, workspace interface{}) *Database_TotalBountiesPosted_Call {
-	return &Database_TotalBountiesPosted_Call{Call: _e.mock.On("TotalBountiesPosted", r, workspace)}
+// UpdateInvoice is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) UpdateInvoice(payment_request interface{}) *Database_UpdateInvoice_Call {
+	return &Database_UpdateInvoice_Call{Call: _e.mock.On("UpdateInvoice", payment_request)}
 }
 
-func (_c *Database_TotalBountiesPosted_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalBountiesPosted_Call {
+func (_c *Database_UpdateInvoice_Call) Run(run func(payment_request string)) *Database_UpdateInvoice_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_TotalBountiesPosted_Call) Return(_a0 int64) *Database_TotalBountiesPosted_Call {
+func (_c *Database_UpdateInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_UpdateInvoice_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_TotalBountiesPosted_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_TotalBountiesPosted_Call {
+func (_c *Database_UpdateInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_UpdateInvoice_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TotalHuntersPaid provides a mock function with given fields: r, workspace
-func (_m *Database) TotalHuntersPaid(r db.PaymentDateRange, workspace string) int64 {
-	ret := _m.Called(r, workspace)
+// UpdateLeaderBoard provides a mock function with given fields: uuid, alias, u
+func (_m *Database) UpdateLeaderBoard(uuid string, alias string, u map[string]interface{}) bool {
+	ret := _m.Called(uuid, alias, u)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TotalHuntersPaid")
+		panic("no return value specified for UpdateLeaderBoard")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
-		r0 = rf(r, workspace)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, map[string]interface{}) bool); ok {
+		r0 = rf(uuid, alias, u)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// Database_TotalHuntersPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalHuntersPaid'
-type Database_TotalHuntersPaid_Call struct {
+// Database_UpdateLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLeaderBoard'
+type Database_UpdateLeaderBoard_Call struct {
 	*mock.Call
 }
 
-// TotalHuntersPaid is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) TotalHuntersPaid(r interface{}, workspace interface{}) *Database_TotalHuntersPaid_Call {
-	return &Database_TotalHuntersPaid_Call{Call: _e.mock.On("TotalHuntersPaid", r, workspace)}
+// UpdateLeaderBoard is a helper method to define mock.On call
+//   - uuid string
+//   - alias string
+//   - u map[string]interface{}
+func (_e *Database_Expecter) UpdateLeaderBoard(uuid interface{}, alias interface{}, u interface{}) *Database_UpdateLeaderBoard_Call {
+	return &Database_UpdateLeaderBoard_Call{Call: _e.mock.On("UpdateLeaderBoard", uuid, alias, u)}
 }
 
-func (_c *Database_TotalHuntersPaid_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalHuntersPaid_Call {
+func (_c *Database_UpdateLeaderBoard_Call) Run(run func(uuid string, alias string, u map[string]interface{})) *Database_UpdateLeaderBoard_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(string), args[1].(string), args[2].(map[string]interface{}))
 	})
 	return _c
 }
 
-func (_c *Database_TotalHuntersPaid_Call) Return(_a0 int64) *Database_TotalHuntersPaid_Call {
+func (_c *Database_UpdateLeaderBoard_Call) Return(_a0 bool) *Database_UpdateLeaderBoard_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_TotalHuntersPaid_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_TotalHuntersPaid_Call {
+func (_c *Database_UpdateLeaderBoard_Call) RunAndReturn(run func(string, string, map[string]interface{}) bool) *Database_UpdateLeaderBoard_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TotalPaidBounties provides a mock function with given fields: r, workspace
-func (_m *Database) TotalPaidBounties(r db.PaymentDateRange, workspace string) int64 {
-	ret := _m.Called(r, workspace)
+// UpdateNotificationRead provides a mock function with given fields: pubkey, uuid, read
+func (_m *Database) UpdateNotificationRead(pubkey string, uuid string, read bool) error {
+	ret := _m.Called(pubkey, uuid, read)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TotalPaidBounties")
+		panic("no return value specified for UpdateNotificationRead")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
-		r0 = rf(r, workspace)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, bool) error); ok {
+		r0 = rf(pubkey, uuid, read)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_TotalPaidBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalPaidBounties'
-type Database_TotalPaidBounties_Call struct {
+// Database_UpdateNotificationRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotificationRead'
+type Database_UpdateNotificationRead_Call struct {
 	*mock.Call
 }
 
-// TotalPaidBounties is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) TotalPaidBounties(r interface{}, workspace interface{}) *Database_TotalPaidBounties_Call {
-	return &Database_TotalPaidBounties_Call{Call: _e.mock.On("TotalPaidBounties", r, workspace)}
+// UpdateNotificationRead is a helper method to define mock.On call
+//   - pubkey string
+//   - uuid string
+//   - read bool
+func (_e *Database_Expecter) UpdateNotificationRead(pubkey interface{}, uuid interface{}, read interface{}) *Database_UpdateNotificationRead_Call {
+	return &Database_UpdateNotificationRead_Call{Call: _e.mock.On("UpdateNotificationRead", pubkey, uuid, read)}
 }
 
-func (_c *Database_TotalPaidBounties_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalPaidBounties_Call {
+func (_c *Database_UpdateNotificationRead_Call) Run(run func(pubkey string, uuid string, read bool)) *Database_UpdateNotificationRead_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(string), args[1].(string), args[2].(bool))
 	})
 	return _c
 }
 
-func (_c *Database_TotalPaidBounties_Call) Return(_a0 int64) *Database_TotalPaidBounties_Call {
+func (_c *Database_UpdateNotificationRead_Call) Return(_a0 error) *Database_UpdateNotificationRead_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_TotalPaidBounties_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_TotalPaidBounties_Call {
+func (_c *Database_UpdateNotificationRead_Call) RunAndReturn(run func(string, string, bool) error) *Database_UpdateNotificationRead_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TotalSatsPaid provides a mock function with given fields: r, workspace
-func (_m *Database) TotalSatsPaid(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// UpdateNotificationSettings provides a mock function with given fields: m
+func (_m *Database) UpdateNotificationSettings(m db.NotificationSettings) (db.NotificationSettings, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TotalSatsPaid")
+		panic("no return value specified for UpdateNotificationSettings")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 db.NotificationSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NotificationSettings) (db.NotificationSettings, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.NotificationSettings) db.NotificationSettings); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(db.NotificationSettings)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.NotificationSettings) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_TotalSatsPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalSatsPaid'
-type Database_TotalSatsPaid_Call struct {
+// Database_UpdateNotificationSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotificationSettings'
+type Database_UpdateNotificationSettings_Call struct {
 	*mock.Call
 }
 
-// TotalSatsPaid is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) TotalSatsPaid(r interface{}, workspace interface{}) *Database_TotalSatsPaid_Call {
-	return &Database_TotalSatsPaid_Call{Call: _e.mock.On("TotalSatsPaid", r, workspace)}
+// UpdateNotificationSettings is a helper method to define mock.On call
+//   - m db.NotificationSettings
+func (_e *Database_Expecter) UpdateNotificationSettings(m interface{}) *Database_UpdateNotificationSettings_Call {
+	return &Database_UpdateNotificationSettings_Call{Call: _e.mock.On("UpdateNotificationSettings", m)}
 }
 
-func (_c *Database_TotalSatsPaid_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalSatsPaid_Call {
+func (_c *Database_UpdateNotificationSettings_Call) Run(run func(m db.NotificationSettings)) *Database_UpdateNotificationSettings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(db.NotificationSettings))
 	})
 	return _c
 }
 
-func (_c *Database_TotalSatsPaid_Call) Return(_a0 uint) *Database_TotalSatsPaid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateNotificationSettings_Call) Return(_a0 db.NotificationSettings, _a1 error) *Database_UpdateNotificationSettings_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_TotalSatsPaid_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_TotalSatsPaid_Call {
+func (_c *Database_UpdateNotificationSettings_Call) RunAndReturn(run func(db.NotificationSettings) (db.NotificationSettings, error)) *Database_UpdateNotificationSettings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TotalSatsPosted provides a mock function with given fields: r, workspace
-func (_m *Database) TotalSatsPosted(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// UpdatePerson provides a mock function with given fields: id, u
+func (_m *Database) UpdatePerson(id uint, u map[string]interface{}) bool {
+	ret := _m.Called(id, u)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TotalSatsPosted")
+		panic("no return value specified for UpdatePerson")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint, map[string]interface{}) bool); ok {
+		r0 = rf(id, u)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// Database_TotalSatsPosted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalSatsPosted'
-type Database_TotalSatsPosted_Call struct {
+// Database_UpdatePerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePerson'
+type Database_UpdatePerson_Call struct {
 	*mock.Call
 }
 
-// TotalSatsPosted is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) TotalSatsPosted(r interface{}, workspace interface{}) *Database_TotalSatsPosted_Call {
-	return &Database_TotalSatsPosted_Call{Call: _e.mock.On("TotalSatsPosted", r, workspace)}
+// UpdatePerson is a helper method to define mock.On call
+//   - id uint
+//   - u map[string]interface{}
+func (_e *Database_Expecter) UpdatePerson(id interface{}, u interface{}) *Database_UpdatePerson_Call {
+	return &Database_UpdatePerson_Call{Call: _e.mock.On("UpdatePerson", id, u)}
 }
 
-func (_c *Database_TotalSatsPosted_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalSatsPosted_Call {
+func (_c *Database_UpdatePerson_Call) Run(run func(id uint, u map[string]interface{})) *Database_UpdatePerson_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(uint), args[1].(map[string]interface{}))
 	})
 	return _c
 }
 
-func (_c *Database_TotalSatsPosted_Call) Return(_a0 uint) *Database_TotalSatsPosted_Call {
+func (_c *Database_UpdatePerson_Call) Return(_a0 bool) *Database_UpdatePerson_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_TotalSatsPosted_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_TotalSatsPosted_Call {
+func (_c *Database_UpdatePerson_Call) RunAndReturn(run func(uint, map[string]interface{}) bool) *Database_UpdatePerson_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateBot provides a mock function with given fields: uuid, u
-func (_m *Database) UpdateBot(uuid string, u map[string]interface{}) bool {
-	ret := _m.Called(uuid, u)
+// UpdatePersonPrivacy provides a mock function with given fields: pubkey, privacy
+func (_m *Database) UpdatePersonPrivacy(pubkey string, privacy db.PersonPrivacy) (db.Person, error) {
+	ret := _m.Called(pubkey, privacy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateBot")
+		panic("no return value specified for UpdatePersonPrivacy")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(string, map[string]interface{}) bool); ok {
-		r0 = rf(uuid, u)
+	var r0 db.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, db.PersonPrivacy) (db.Person, error)); ok {
+		return rf(pubkey, privacy)
+	}
+	if rf, ok := ret.Get(0).(func(string, db.PersonPrivacy) db.Person); ok {
+		r0 = rf(pubkey, privacy)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(db.Person)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, db.PersonPrivacy) error); ok {
+		r1 = rf(pubkey, privacy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_UpdateBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBot'
-type Database_UpdateBot_Call struct {
+// Database_UpdatePersonPrivacy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePersonPrivacy'
+type Database_UpdatePersonPrivacy_Call struct {
 	*mock.Call
 }
 
-// UpdateBot is a helper method to define mock.On call
-//   - uuid string
-//   - u map[string]interface{}
-func (_e *Database_Expecter) UpdateBot(uuid interface{}, u interface{}) *Database_UpdateBot_Call {
-	return &Database_UpdateBot_Call{Call: _e.mock.On("UpdateBot", uuid, u)}
+// UpdatePersonPrivacy is a helper method to define mock.On call
+//   - pubkey string
+//   - privacy db.PersonPrivacy
+func (_e *Database_Expecter) UpdatePersonPrivacy(pubkey interface{}, privacy interface{}) *Database_UpdatePersonPrivacy_Call {
+	return &Database_UpdatePersonPrivacy_Call{Call: _e.mock.On("UpdatePersonPrivacy", pubkey, privacy)}
 }
 
-func (_c *Database_UpdateBot_Call) Run(run func(uuid string, u map[string]interface{})) *Database_UpdateBot_Call {
+func (_c *Database_UpdatePersonPrivacy_Call) Run(run func(pubkey string, privacy db.PersonPrivacy)) *Database_UpdatePersonPrivacy_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(map[string]interface{}))
+		run(args[0].(string), args[1].(db.PersonPrivacy))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateBot_Call) Return(_a0 bool) *Database_UpdateBot_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdatePersonPrivacy_Call) Return(_a0 db.Person, _a1 error) *Database_UpdatePersonPrivacy_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_UpdateBot_Call) RunAndReturn(run func(string, map[string]interface{}) bool) *Database_UpdateBot_Call {
+func (_c *Database_UpdatePersonPrivacy_Call) RunAndReturn(run func(string, db.PersonPrivacy) (db.Person, error)) *Database_UpdatePersonPrivacy_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateBounty provides a mock function with given fields: b
-func (_m *Database) UpdateBounty(b db.NewBounty) (db.NewBounty, error) {
-	ret := _m.Called(b)
+// UpdatePhaseBudget provides a mock function with given fields: budget
+func (_m *Database) UpdatePhaseBudget(budget db.PhaseBudget) (db.PhaseBudget, error) {
+	ret := _m.Called(budget)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateBounty")
+		panic("no return value specified for UpdatePhaseBudget")
 	}
 
-	var r0 db.NewBounty
+	var r0 db.PhaseBudget
 	var r1 error
-	if rf, ok := ret.Get(0).(func(db.NewBounty) (db.NewBounty, error)); ok {
-		return rf(b)
+	if rf, ok := ret.Get(0).(func(db.PhaseBudget) (db.PhaseBudget, error)); ok {
+		return rf(budget)
 	}
-	if rf, ok := ret.Get(0).(func(db.NewBounty) db.NewBounty); ok {
-		r0 = rf(b)
+	if rf, ok := ret.Get(0).(func(db.PhaseBudget) db.PhaseBudget); ok {
+		r0 = rf(budget)
 	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+		r0 = ret.Get(0).(db.PhaseBudget)
 	}
 
-	if rf, ok := ret.Get(1).(func(db.NewBounty) error); ok {
-		r1 = rf(b)
+	if rf, ok := ret.Get(1).(func(db.PhaseBudget) error); ok {
+		r1 = rf(budget)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -7141,195 +22818,173 @@ func (_m *Database) UpdateBounty(b db.NewBounty) (db.NewBounty, error) {
 	return r0, r1
 }
 
-// Database_UpdateBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBounty'
-type Database_UpdateBounty_Call struct {
+// Database_UpdatePhaseBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePhaseBudget'
+type Database_UpdatePhaseBudget_Call struct {
 	*mock.Call
 }
 
-// UpdateBounty is a helper method to define mock.On call
-//   - b db.NewBounty
-func (_e *Database_Expecter) UpdateBounty(b interface{}) *Database_UpdateBounty_Call {
-	return &Database_UpdateBounty_Call{Call: _e.mock.On("UpdateBounty", b)}
+// UpdatePhaseBudget is a helper method to define mock.On call
+//   - budget db.PhaseBudget
+func (_e *Database_Expecter) UpdatePhaseBudget(budget interface{}) *Database_UpdatePhaseBudget_Call {
+	return &Database_UpdatePhaseBudget_Call{Call: _e.mock.On("UpdatePhaseBudget", budget)}
 }
 
-func (_c *Database_UpdateBounty_Call) Run(run func(b db.NewBounty)) *Database_UpdateBounty_Call {
+func (_c *Database_UpdatePhaseBudget_Call) Run(run func(budget db.PhaseBudget)) *Database_UpdatePhaseBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewBounty))
+		run(args[0].(db.PhaseBudget))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_UpdateBounty_Call {
+func (_c *Database_UpdatePhaseBudget_Call) Return(_a0 db.PhaseBudget, _a1 error) *Database_UpdatePhaseBudget_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_UpdateBounty_Call) RunAndReturn(run func(db.NewBounty) (db.NewBounty, error)) *Database_UpdateBounty_Call {
+func (_c *Database_UpdatePhaseBudget_Call) RunAndReturn(run func(db.PhaseBudget) (db.PhaseBudget, error)) *Database_UpdatePhaseBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateBountyBoolColumn provides a mock function with given fields: b, column
-func (_m *Database) UpdateBountyBoolColumn(b db.NewBounty, column string) db.NewBounty {
-	ret := _m.Called(b, column)
+// UpdateRepositorySync provides a mock function with given fields: uuid, openPrCount, syncError
+func (_m *Database) UpdateRepositorySync(uuid string, openPrCount int, syncError string) error {
+	ret := _m.Called(uuid, openPrCount, syncError)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateBountyBoolColumn")
+		panic("no return value specified for UpdateRepositorySync")
 	}
 
-	var r0 db.NewBounty
-	if rf, ok := ret.Get(0).(func(db.NewBounty, string) db.NewBounty); ok {
-		r0 = rf(b, column)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int, string) error); ok {
+		r0 = rf(uuid, openPrCount, syncError)
 	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_UpdateBountyBoolColumn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBountyBoolColumn'
-type Database_UpdateBountyBoolColumn_Call struct {
+// Database_UpdateRepositorySync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRepositorySync'
+type Database_UpdateRepositorySync_Call struct {
 	*mock.Call
 }
 
-// UpdateBountyBoolColumn is a helper method to define mock.On call
-//   - b db.NewBounty
-//   - column string
-func (_e *Database_Expecter) UpdateBountyBoolColumn(b interface{}, column interface{}) *Database_UpdateBountyBoolColumn_Call {
-	return &Database_UpdateBountyBoolColumn_Call{Call: _e.mock.On("UpdateBountyBoolColumn", b, column)}
+// UpdateRepositorySync is a helper method to define mock.On call
+//   - uuid string
+//   - openPrCount int
+//   - syncError string
+func (_e *Database_Expecter) UpdateRepositorySync(uuid interface{}, openPrCount interface{}, syncError interface{}) *Database_UpdateRepositorySync_Call {
+	return &Database_UpdateRepositorySync_Call{Call: _e.mock.On("UpdateRepositorySync", uuid, openPrCount, syncError)}
 }
 
-func (_c *Database_UpdateBountyBoolColumn_Call) Run(run func(b db.NewBounty, column string)) *Database_UpdateBountyBoolColumn_Call {
+func (_c *Database_UpdateRepositorySync_Call) Run(run func(uuid string, openPrCount int, syncError string)) *Database_UpdateRepositorySync_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewBounty), args[1].(string))
+		run(args[0].(string), args[1].(int), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateBountyBoolColumn_Call) Return(_a0 db.NewBounty) *Database_UpdateBountyBoolColumn_Call {
+func (_c *Database_UpdateRepositorySync_Call) Return(_a0 error) *Database_UpdateRepositorySync_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_UpdateBountyBoolColumn_Call) RunAndReturn(run func(db.NewBounty, string) db.NewBounty) *Database_UpdateBountyBoolColumn_Call {
+func (_c *Database_UpdateRepositorySync_Call) RunAndReturn(run func(string, int, string) error) *Database_UpdateRepositorySync_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateBountyNullColumn provides a mock function with given fields: b, column
-func (_m *Database) UpdateBountyNullColumn(b db.NewBounty, column string) db.NewBounty {
-	ret := _m.Called(b, column)
-
-	if len(ret) == 0 {
-		panic("no return value specified for UpdateBountyNullColumn")
-	}
-
-	var r0 db.NewBounty
-	if rf, ok := ret.Get(0).(func(db.NewBounty, string) db.NewBounty); ok {
-		r0 = rf(b, column)
-	} else {
-		r0 = ret.Get(0).(db.NewBounty)
-	}
-
-	return r0
+// UpdateReputationScore provides a mock function with given fields: pubkey, score
+func (_m *Database) UpdateReputationScore(pubkey string, score float64) {
+	_m.Called(pubkey, score)
 }
 
-// Database_UpdateBountyNullColumn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBountyNullColumn'
-type Database_UpdateBountyNullColumn_Call struct {
+// Database_UpdateReputationScore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateReputationScore'
+type Database_UpdateReputationScore_Call struct {
 	*mock.Call
 }
 
-// UpdateBountyNullColumn is a helper method to define mock.On call
-//   - b db.NewBounty
-//   - column string
-func (_e *Database_Expecter) UpdateBountyNullColumn(b interface{}, column interface{}) *Database_UpdateBountyNullColumn_Call {
-	return &Database_UpdateBountyNullColumn_Call{Call: _e.mock.On("UpdateBountyNullColumn", b, column)}
+// UpdateReputationScore is a helper method to define mock.On call
+//   - pubkey string
+//   - score float64
+func (_e *Database_Expecter) UpdateReputationScore(pubkey interface{}, score interface{}) *Database_UpdateReputationScore_Call {
+	return &Database_UpdateReputationScore_Call{Call: _e.mock.On("UpdateReputationScore", pubkey, score)}
 }
 
-func (_c *Database_UpdateBountyNullColumn_Call) Run(run func(b db.NewBounty, column string)) *Database_UpdateBountyNullColumn_Call {
+func (_c *Database_UpdateReputationScore_Call) Run(run func(pubkey string, score float64)) *Database_UpdateReputationScore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewBounty), args[1].(string))
+		run(args[0].(string), args[1].(float64))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateBountyNullColumn_Call) Return(_a0 db.NewBounty) *Database_UpdateBountyNullColumn_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateReputationScore_Call) Return() *Database_UpdateReputationScore_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *Database_UpdateBountyNullColumn_Call) RunAndReturn(run func(db.NewBounty, string) db.NewBounty) *Database_UpdateBountyNullColumn_Call {
+func (_c *Database_UpdateReputationScore_Call) RunAndReturn(run func(string, float64)) *Database_UpdateReputationScore_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateBountyPayment provides a mock function with given fields: b
-func (_m *Database) UpdateBountyPayment(b db.NewBounty) (db.NewBounty, error) {
-	ret := _m.Called(b)
+// UpdateStakworkJob provides a mock function with given fields: job
+func (_m *Database) UpdateStakworkJob(job db.StakworkJob) error {
+	ret := _m.Called(job)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateBountyPayment")
-	}
-
-	var r0 db.NewBounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.NewBounty) (db.NewBounty, error)); ok {
-		return rf(b)
-	}
-	if rf, ok := ret.Get(0).(func(db.NewBounty) db.NewBounty); ok {
-		r0 = rf(b)
-	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+		panic("no return value specified for UpdateStakworkJob")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.NewBounty) error); ok {
-		r1 = rf(b)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.StakworkJob) error); ok {
+		r0 = rf(job)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_UpdateBountyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBountyPayment'
-type Database_UpdateBountyPayment_Call struct {
+// Database_UpdateStakworkJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStakworkJob'
+type Database_UpdateStakworkJob_Call struct {
 	*mock.Call
 }
 
-// UpdateBountyPayment is a helper method to define mock.On call
-//   - b db.NewBounty
-func (_e *Database_Expecter) UpdateBountyPayment(b interface{}) *Database_UpdateBountyPayment_Call {
-	return &Database_UpdateBountyPayment_Call{Call: _e.mock.On("UpdateBountyPayment", b)}
+// UpdateStakworkJob is a helper method to define mock.On call
+//   - job db.StakworkJob
+func (_e *Database_Expecter) UpdateStakworkJob(job interface{}) *Database_UpdateStakworkJob_Call {
+	return &Database_UpdateStakworkJob_Call{Call: _e.mock.On("UpdateStakworkJob", job)}
 }
 
-func (_c *Database_UpdateBountyPayment_Call) Run(run func(b db.NewBounty)) *Database_UpdateBountyPayment_Call {
+func (_c *Database_UpdateStakworkJob_Call) Run(run func(job db.StakworkJob)) *Database_UpdateStakworkJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewBounty))
+		run(args[0].(db.StakworkJob))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateBountyPayment_Call) Return(_a0 db.NewBounty, _a1 error) *Database_UpdateBountyPayment_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_UpdateStakworkJob_Call) Return(_a0 error) *Database_UpdateStakworkJob_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_UpdateBountyPayment_Call) RunAndReturn(run func(db.NewBounty) (db.NewBounty, error)) *Database_UpdateBountyPayment_Call {
+func (_c *Database_UpdateStakworkJob_Call) RunAndReturn(run func(db.StakworkJob) error) *Database_UpdateStakworkJob_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateChannel provides a mock function with given fields: id, u
-func (_m *Database) UpdateChannel(id uint, u map[string]interface{}) bool {
-	ret := _m.Called(id, u)
+// UpdateTribe provides a mock function with given fields: uuid, u
+func (_m *Database) UpdateTribe(uuid string, u map[string]interface{}) bool {
+	ret := _m.Called(uuid, u)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateChannel")
+		panic("no return value specified for UpdateTribe")
 	}
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(uint, map[string]interface{}) bool); ok {
-		r0 = rf(id, u)
+	if rf, ok := ret.Get(0).(func(string, map[string]interface{}) bool); ok {
+		r0 = rf(uuid, u)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
@@ -7337,355 +22992,417 @@ func (_m *Database) UpdateChannel(id uint, u map[string]interface{}) bool {
 	return r0
 }
 
-// Database_UpdateChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChannel'
-type Database_UpdateChannel_Call struct {
+// Database_UpdateTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTribe'
+type Database_UpdateTribe_Call struct {
 	*mock.Call
 }
 
-// UpdateChannel is a helper method to define mock.On call
-//   - id uint
+// UpdateTribe is a helper method to define mock.On call
+//   - uuid string
 //   - u map[string]interface{}
-func (_e *Database_Expecter) UpdateChannel(id interface{}, u interface{}) *Database_UpdateChannel_Call {
-	return &Database_UpdateChannel_Call{Call: _e.mock.On("UpdateChannel", id, u)}
+func (_e *Database_Expecter) UpdateTribe(uuid interface{}, u interface{}) *Database_UpdateTribe_Call {
+	return &Database_UpdateTribe_Call{Call: _e.mock.On("UpdateTribe", uuid, u)}
 }
 
-func (_c *Database_UpdateChannel_Call) Run(run func(id uint, u map[string]interface{})) *Database_UpdateChannel_Call {
+func (_c *Database_UpdateTribe_Call) Run(run func(uuid string, u map[string]interface{})) *Database_UpdateTribe_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].(map[string]interface{}))
+		run(args[0].(string), args[1].(map[string]interface{}))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateChannel_Call) Return(_a0 bool) *Database_UpdateChannel_Call {
+func (_c *Database_UpdateTribe_Call) Return(_a0 bool) *Database_UpdateTribe_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_UpdateChannel_Call) RunAndReturn(run func(uint, map[string]interface{}) bool) *Database_UpdateChannel_Call {
+func (_c *Database_UpdateTribe_Call) RunAndReturn(run func(string, map[string]interface{}) bool) *Database_UpdateTribe_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateGithubConfirmed provides a mock function with given fields: id, confirmed
-func (_m *Database) UpdateGithubConfirmed(id uint, confirmed bool) {
-	_m.Called(id, confirmed)
+// UpdateTribeRankingSettings provides a mock function with given fields: settings
+func (_m *Database) UpdateTribeRankingSettings(settings db.TribeRankingSettings) (db.TribeRankingSettings, error) {
+	ret := _m.Called(settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTribeRankingSettings")
+	}
+
+	var r0 db.TribeRankingSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TribeRankingSettings) (db.TribeRankingSettings, error)); ok {
+		return rf(settings)
+	}
+	if rf, ok := ret.Get(0).(func(db.TribeRankingSettings) db.TribeRankingSettings); ok {
+		r0 = rf(settings)
+	} else {
+		r0 = ret.Get(0).(db.TribeRankingSettings)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.TribeRankingSettings) error); ok {
+		r1 = rf(settings)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_UpdateGithubConfirmed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateGithubConfirmed'
-type Database_UpdateGithubConfirmed_Call struct {
+// Database_UpdateTribeRankingSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTribeRankingSettings'
+type Database_UpdateTribeRankingSettings_Call struct {
 	*mock.Call
 }
 
-// UpdateGithubConfirmed is a helper method to define mock.On call
-//   - id uint
-//   - confirmed bool
-func (_e *Database_Expecter) UpdateGithubConfirmed(id interface{}, confirmed interface{}) *Database_UpdateGithubConfirmed_Call {
-	return &Database_UpdateGithubConfirmed_Call{Call: _e.mock.On("UpdateGithubConfirmed", id, confirmed)}
+// UpdateTribeRankingSettings is a helper method to define mock.On call
+//   - settings db.TribeRankingSettings
+func (_e *Database_Expecter) UpdateTribeRankingSettings(settings interface{}) *Database_UpdateTribeRankingSettings_Call {
+	return &Database_UpdateTribeRankingSettings_Call{Call: _e.mock.On("UpdateTribeRankingSettings", settings)}
 }
 
-func (_c *Database_UpdateGithubConfirmed_Call) Run(run func(id uint, confirmed bool)) *Database_UpdateGithubConfirmed_Call {
+func (_c *Database_UpdateTribeRankingSettings_Call) Run(run func(settings db.TribeRankingSettings)) *Database_UpdateTribeRankingSettings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].(bool))
+		run(args[0].(db.TribeRankingSettings))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateGithubConfirmed_Call) Return() *Database_UpdateGithubConfirmed_Call {
-	_c.Call.Return()
+func (_c *Database_UpdateTribeRankingSettings_Call) Return(_a0 db.TribeRankingSettings, _a1 error) *Database_UpdateTribeRankingSettings_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_UpdateGithubConfirmed_Call) RunAndReturn(run func(uint, bool)) *Database_UpdateGithubConfirmed_Call {
+func (_c *Database_UpdateTribeRankingSettings_Call) RunAndReturn(run func(db.TribeRankingSettings) (db.TribeRankingSettings, error)) *Database_UpdateTribeRankingSettings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateGithubIssues provides a mock function with given fields: id, issues
-func (_m *Database) UpdateGithubIssues(id uint, issues map[string]interface{}) {
-	_m.Called(id, issues)
+// UpdateTribeUniqueName provides a mock function with given fields: uuid, u
+func (_m *Database) UpdateTribeUniqueName(uuid string, u string) {
+	_m.Called(uuid, u)
 }
 
-// Database_UpdateGithubIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateGithubIssues'
-type Database_UpdateGithubIssues_Call struct {
+// Database_UpdateTribeUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTribeUniqueName'
+type Database_UpdateTribeUniqueName_Call struct {
 	*mock.Call
 }
 
-// UpdateGithubIssues is a helper method to define mock.On call
-//   - id uint
-//   - issues map[string]interface{}
-func (_e *Database_Expecter) UpdateGithubIssues(id interface{}, issues interface{}) *Database_UpdateGithubIssues_Call {
-	return &Database_UpdateGithubIssues_Call{Call: _e.mock.On("UpdateGithubIssues", id, issues)}
+// UpdateTribeUniqueName is a helper method to define mock.On call
+//   - uuid string
+//   - u string
+func (_e *Database_Expecter) UpdateTribeUniqueName(uuid interface{}, u interface{}) *Database_UpdateTribeUniqueName_Call {
+	return &Database_UpdateTribeUniqueName_Call{Call: _e.mock.On("UpdateTribeUniqueName", uuid, u)}
 }
 
-func (_c *Database_UpdateGithubIssues_Call) Run(run func(id uint, issues map[string]interface{})) *Database_UpdateGithubIssues_Call {
+func (_c *Database_UpdateTribeUniqueName_Call) Run(run func(uuid string, u string)) *Database_UpdateTribeUniqueName_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].(map[string]interface{}))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateGithubIssues_Call) Return() *Database_UpdateGithubIssues_Call {
+func (_c *Database_UpdateTribeUniqueName_Call) Return() *Database_UpdateTribeUniqueName_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *Database_UpdateGithubIssues_Call) RunAndReturn(run func(uint, map[string]interface{})) *Database_UpdateGithubIssues_Call {
+func (_c *Database_UpdateTribeUniqueName_Call) RunAndReturn(run func(string, string)) *Database_UpdateTribeUniqueName_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateInvoice provides a mock function with given fields: payment_request
-func (_m *Database) UpdateInvoice(payment_request string) db.NewInvoiceList {
-	ret := _m.Called(payment_request)
+// UpdateTribeVerification provides a mock function with given fields: m
+func (_m *Database) UpdateTribeVerification(m db.TribeVerification) (db.TribeVerification, error) {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateInvoice")
+		panic("no return value specified for UpdateTribeVerification")
 	}
 
-	var r0 db.NewInvoiceList
-	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
-		r0 = rf(payment_request)
+	var r0 db.TribeVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TribeVerification) (db.TribeVerification, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.TribeVerification) db.TribeVerification); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(db.NewInvoiceList)
+		r0 = ret.Get(0).(db.TribeVerification)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.TribeVerification) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_UpdateInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateInvoice'
-type Database_UpdateInvoice_Call struct {
+// Database_UpdateTribeVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTribeVerification'
+type Database_UpdateTribeVerification_Call struct {
 	*mock.Call
 }
 
-// UpdateInvoice is a helper method to define mock.On call
-//   - payment_request string
-func (_e *Database_Expecter) UpdateInvoice(payment_request interface{}) *Database_UpdateInvoice_Call {
-	return &Database_UpdateInvoice_Call{Call: _e.mock.On("UpdateInvoice", payment_request)}
+// UpdateTribeVerification is a helper method to define mock.On call
+//   - m db.TribeVerification
+func (_e *Database_Expecter) UpdateTribeVerification(m interface{}) *Database_UpdateTribeVerification_Call {
+	return &Database_UpdateTribeVerification_Call{Call: _e.mock.On("UpdateTribeVerification", m)}
 }
 
-func (_c *Database_UpdateInvoice_Call) Run(run func(payment_request string)) *Database_UpdateInvoice_Call {
+func (_c *Database_UpdateTribeVerification_Call) Run(run func(m db.TribeVerification)) *Database_UpdateTribeVerification_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.TribeVerification))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_UpdateInvoice_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateTribeVerification_Call) Return(_a0 db.TribeVerification, _a1 error) *Database_UpdateTribeVerification_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_UpdateInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_UpdateInvoice_Call {
+func (_c *Database_UpdateTribeVerification_Call) RunAndReturn(run func(db.TribeVerification) (db.TribeVerification, error)) *Database_UpdateTribeVerification_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateLeaderBoard provides a mock function with given fields: uuid, alias, u
-func (_m *Database) UpdateLeaderBoard(uuid string, alias string, u map[string]interface{}) bool {
-	ret := _m.Called(uuid, alias, u)
-
-	if len(ret) == 0 {
-		panic("no return value specified for UpdateLeaderBoard")
-	}
-
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(string, string, map[string]interface{}) bool); ok {
-		r0 = rf(uuid, alias, u)
-	} else {
-		r0 = ret.Get(0).(bool)
-	}
-
-	return r0
+// UpdateTwitterConfirmed provides a mock function with given fields: id, confirmed
+func (_m *Database) UpdateTwitterConfirmed(id uint, confirmed bool) {
+	_m.Called(id, confirmed)
 }
 
-// Database_UpdateLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLeaderBoard'
-type Database_UpdateLeaderBoard_Call struct {
+// Database_UpdateTwitterConfirmed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTwitterConfirmed'
+type Database_UpdateTwitterConfirmed_Call struct {
 	*mock.Call
 }
 
-// UpdateLeaderBoard is a helper method to define mock.On call
-//   - uuid string
-//   - alias string
-//   - u map[string]interface{}
-func (_e *Database_Expecter) UpdateLeaderBoard(uuid interface{}, alias interface{}, u interface{}) *Database_UpdateLeaderBoard_Call {
-	return &Database_UpdateLeaderBoard_Call{Call: _e.mock.On("UpdateLeaderBoard", uuid, alias, u)}
+// UpdateTwitterConfirmed is a helper method to define mock.On call
+//   - id uint
+//   - confirmed bool
+func (_e *Database_Expecter) UpdateTwitterConfirmed(id interface{}, confirmed interface{}) *Database_UpdateTwitterConfirmed_Call {
+	return &Database_UpdateTwitterConfirmed_Call{Call: _e.mock.On("UpdateTwitterConfirmed", id, confirmed)}
 }
 
-func (_c *Database_UpdateLeaderBoard_Call) Run(run func(uuid string, alias string, u map[string]interface{})) *Database_UpdateLeaderBoard_Call {
+func (_c *Database_UpdateTwitterConfirmed_Call) Run(run func(id uint, confirmed bool)) *Database_UpdateTwitterConfirmed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(map[string]interface{}))
+		run(args[0].(uint), args[1].(bool))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateLeaderBoard_Call) Return(_a0 bool) *Database_UpdateLeaderBoard_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateTwitterConfirmed_Call) Return() *Database_UpdateTwitterConfirmed_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *Database_UpdateLeaderBoard_Call) RunAndReturn(run func(string, string, map[string]interface{}) bool) *Database_UpdateLeaderBoard_Call {
+func (_c *Database_UpdateTwitterConfirmed_Call) RunAndReturn(run func(uint, bool)) *Database_UpdateTwitterConfirmed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdatePerson provides a mock function with given fields: id, u
-func (_m *Database) UpdatePerson(id uint, u map[string]interface{}) bool {
-	ret := _m.Called(id, u)
+// UpdateWebhookDelivery provides a mock function with given fields: m
+func (_m *Database) UpdateWebhookDelivery(m db.WebhookDelivery) error {
+	ret := _m.Called(m)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdatePerson")
+		panic("no return value specified for UpdateWebhookDelivery")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(uint, map[string]interface{}) bool); ok {
-		r0 = rf(id, u)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.WebhookDelivery) error); ok {
+		r0 = rf(m)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_UpdatePerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePerson'
-type Database_UpdatePerson_Call struct {
+// Database_UpdateWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhookDelivery'
+type Database_UpdateWebhookDelivery_Call struct {
 	*mock.Call
 }
 
-// UpdatePerson is a helper method to define mock.On call
-//   - id uint
-//   - u map[string]interface{}
-func (_e *Database_Expecter) UpdatePerson(id interface{}, u interface{}) *Database_UpdatePerson_Call {
-	return &Database_UpdatePerson_Call{Call: _e.mock.On("UpdatePerson", id, u)}
+// UpdateWebhookDelivery is a helper method to define mock.On call
+//   - m db.WebhookDelivery
+func (_e *Database_Expecter) UpdateWebhookDelivery(m interface{}) *Database_UpdateWebhookDelivery_Call {
+	return &Database_UpdateWebhookDelivery_Call{Call: _e.mock.On("UpdateWebhookDelivery", m)}
 }
 
-func (_c *Database_UpdatePerson_Call) Run(run func(id uint, u map[string]interface{})) *Database_UpdatePerson_Call {
+func (_c *Database_UpdateWebhookDelivery_Call) Run(run func(m db.WebhookDelivery)) *Database_UpdateWebhookDelivery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].(map[string]interface{}))
+		run(args[0].(db.WebhookDelivery))
 	})
 	return _c
 }
 
-func (_c *Database_UpdatePerson_Call) Return(_a0 bool) *Database_UpdatePerson_Call {
+func (_c *Database_UpdateWebhookDelivery_Call) Return(_a0 error) *Database_UpdateWebhookDelivery_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_UpdatePerson_Call) RunAndReturn(run func(uint, map[string]interface{}) bool) *Database_UpdatePerson_Call {
+func (_c *Database_UpdateWebhookDelivery_Call) RunAndReturn(run func(db.WebhookDelivery) error) *Database_UpdateWebhookDelivery_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateTribe provides a mock function with given fields: uuid, u
-func (_m *Database) UpdateTribe(uuid string, u map[string]interface{}) bool {
-	ret := _m.Called(uuid, u)
+// UpdateWorkSession provides a mock function with given fields: m, from, added
+func (_m *Database) UpdateWorkSession(m db.BountyWorkSession, from db.WorkSessionStatus, added uint) (db.BountyWorkSession, error) {
+	ret := _m.Called(m, from, added)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateTribe")
+		panic("no return value specified for UpdateWorkSession")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(string, map[string]interface{}) bool); ok {
-		r0 = rf(uuid, u)
+	var r0 db.BountyWorkSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyWorkSession, db.WorkSessionStatus, uint) (db.BountyWorkSession, error)); ok {
+		return rf(m, from, added)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyWorkSession, db.WorkSessionStatus, uint) db.BountyWorkSession); ok {
+		r0 = rf(m, from, added)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(db.BountyWorkSession)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.BountyWorkSession, db.WorkSessionStatus, uint) error); ok {
+		r1 = rf(m, from, added)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_UpdateTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTribe'
-type Database_UpdateTribe_Call struct {
+// Database_UpdateWorkSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWorkSession'
+type Database_UpdateWorkSession_Call struct {
 	*mock.Call
 }
 
-// UpdateTribe is a helper method to define mock.On call
-//   - uuid string
-//   - u map[string]interface{}
-func (_e *Database_Expecter) UpdateTribe(uuid interface{}, u interface{}) *Database_UpdateTribe_Call {
-	return &Database_UpdateTribe_Call{Call: _e.mock.On("UpdateTribe", uuid, u)}
+// UpdateWorkSession is a helper method to define mock.On call
+//   - m db.BountyWorkSession
+//   - from db.WorkSessionStatus
+//   - added uint
+func (_e *Database_Expecter) UpdateWorkSession(m interface{}, from interface{}, added interface{}) *Database_UpdateWorkSession_Call {
+	return &Database_UpdateWorkSession_Call{Call: _e.mock.On("UpdateWorkSession", m, from, added)}
 }
 
-func (_c *Database_UpdateTribe_Call) Run(run func(uuid string, u map[string]interface{})) *Database_UpdateTribe_Call {
+func (_c *Database_UpdateWorkSession_Call) Run(run func(m db.BountyWorkSession, from db.WorkSessionStatus, added uint)) *Database_UpdateWorkSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(map[string]interface{}))
+		run(args[0].(db.BountyWorkSession), args[1].(db.WorkSessionStatus), args[2].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateTribe_Call) Return(_a0 bool) *Database_UpdateTribe_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateWorkSession_Call) Return(_a0 db.BountyWorkSession, _a1 error) *Database_UpdateWorkSession_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_UpdateTribe_Call) RunAndReturn(run func(string, map[string]interface{}) bool) *Database_UpdateTribe_Call {
+func (_c *Database_UpdateWorkSession_Call) RunAndReturn(run func(db.BountyWorkSession, db.WorkSessionStatus, uint) (db.BountyWorkSession, error)) *Database_UpdateWorkSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateTribeUniqueName provides a mock function with given fields: uuid, u
-func (_m *Database) UpdateTribeUniqueName(uuid string, u string) {
-	_m.Called(uuid, u)
+// UpdateWorkflowExecution provides a mock function with given fields: m
+func (_m *Database) UpdateWorkflowExecution(m db.WorkflowExecution) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWorkflowExecution")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.WorkflowExecution) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
-// Database_UpdateTribeUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTribeUniqueName'
-type Database_UpdateTribeUniqueName_Call struct {
+// Database_UpdateWorkflowExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWorkflowExecution'
+type Database_UpdateWorkflowExecution_Call struct {
 	*mock.Call
 }
 
-// UpdateTribeUniqueName is a helper method to define mock.On call
-//   - uuid string
-//   - u string
-func (_e *Database_Expecter) UpdateTribeUniqueName(uuid interface{}, u interface{}) *Database_UpdateTribeUniqueName_Call {
-	return &Database_UpdateTribeUniqueName_Call{Call: _e.mock.On("UpdateTribeUniqueName", uuid, u)}
+// UpdateWorkflowExecution is a helper method to define mock.On call
+//   - m db.WorkflowExecution
+func (_e *Database_Expecter) UpdateWorkflowExecution(m interface{}) *Database_UpdateWorkflowExecution_Call {
+	return &Database_UpdateWorkflowExecution_Call{Call: _e.mock.On("UpdateWorkflowExecution", m)}
 }
 
-func (_c *Database_UpdateTribeUniqueName_Call) Run(run func(uuid string, u string)) *Database_UpdateTribeUniqueName_Call {
+func (_c *Database_UpdateWorkflowExecution_Call) Run(run func(m db.WorkflowExecution)) *Database_UpdateWorkflowExecution_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(db.WorkflowExecution))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateTribeUniqueName_Call) Return() *Database_UpdateTribeUniqueName_Call {
-	_c.Call.Return()
+func (_c *Database_UpdateWorkflowExecution_Call) Return(_a0 error) *Database_UpdateWorkflowExecution_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_UpdateTribeUniqueName_Call) RunAndReturn(run func(string, string)) *Database_UpdateTribeUniqueName_Call {
+func (_c *Database_UpdateWorkflowExecution_Call) RunAndReturn(run func(db.WorkflowExecution) error) *Database_UpdateWorkflowExecution_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateTwitterConfirmed provides a mock function with given fields: id, confirmed
-func (_m *Database) UpdateTwitterConfirmed(id uint, confirmed bool) {
-	_m.Called(id, confirmed)
+// UpdateWorkspaceAiSettings provides a mock function with given fields: settings
+func (_m *Database) UpdateWorkspaceAiSettings(settings db.WorkspaceAiSettings) (db.WorkspaceAiSettings, error) {
+	ret := _m.Called(settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWorkspaceAiSettings")
+	}
+
+	var r0 db.WorkspaceAiSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceAiSettings) (db.WorkspaceAiSettings, error)); ok {
+		return rf(settings)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceAiSettings) db.WorkspaceAiSettings); ok {
+		r0 = rf(settings)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceAiSettings)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceAiSettings) error); ok {
+		r1 = rf(settings)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_UpdateTwitterConfirmed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTwitterConfirmed'
-type Database_UpdateTwitterConfirmed_Call struct {
+// Database_UpdateWorkspaceAiSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWorkspaceAiSettings'
+type Database_UpdateWorkspaceAiSettings_Call struct {
 	*mock.Call
 }
 
-// UpdateTwitterConfirmed is a helper method to define mock.On call
-//   - id uint
-//   - confirmed bool
-func (_e *Database_Expecter) UpdateTwitterConfirmed(id interface{}, confirmed interface{}) *Database_UpdateTwitterConfirmed_Call {
-	return &Database_UpdateTwitterConfirmed_Call{Call: _e.mock.On("UpdateTwitterConfirmed", id, confirmed)}
+// UpdateWorkspaceAiSettings is a helper method to define mock.On call
+//   - settings db.WorkspaceAiSettings
+func (_e *Database_Expecter) UpdateWorkspaceAiSettings(settings interface{}) *Database_UpdateWorkspaceAiSettings_Call {
+	return &Database_UpdateWorkspaceAiSettings_Call{Call: _e.mock.On("UpdateWorkspaceAiSettings", settings)}
 }
 
-func (_c *Database_UpdateTwitterConfirmed_Call) Run(run func(id uint, confirmed bool)) *Database_UpdateTwitterConfirmed_Call {
+func (_c *Database_UpdateWorkspaceAiSettings_Call) Run(run func(settings db.WorkspaceAiSettings)) *Database_UpdateWorkspaceAiSettings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].(bool))
+		run(args[0].(db.WorkspaceAiSettings))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateTwitterConfirmed_Call) Return() *Database_UpdateTwitterConfirmed_Call {
-	_c.Call.Return()
+func (_c *Database_UpdateWorkspaceAiSettings_Call) Return(_a0 db.WorkspaceAiSettings, _a1 error) *Database_UpdateWorkspaceAiSettings_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_UpdateTwitterConfirmed_Call) RunAndReturn(run func(uint, bool)) *Database_UpdateTwitterConfirmed_Call {
+func (_c *Database_UpdateWorkspaceAiSettings_Call) RunAndReturn(run func(db.WorkspaceAiSettings) (db.WorkspaceAiSettings, error)) *Database_UpdateWorkspaceAiSettings_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7736,6 +23453,62 @@ func (_c *Database_UpdateWorkspaceBudget_Call) RunAndReturn(run func(db.NewBount
 	return _c
 }
 
+// UpdateWorkspaceBudgetSettings provides a mock function with given fields: settings
+func (_m *Database) UpdateWorkspaceBudgetSettings(settings db.WorkspaceBudgetSettings) (db.WorkspaceBudgetSettings, error) {
+	ret := _m.Called(settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWorkspaceBudgetSettings")
+	}
+
+	var r0 db.WorkspaceBudgetSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBudgetSettings) (db.WorkspaceBudgetSettings, error)); ok {
+		return rf(settings)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBudgetSettings) db.WorkspaceBudgetSettings); ok {
+		r0 = rf(settings)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceBudgetSettings)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceBudgetSettings) error); ok {
+		r1 = rf(settings)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_UpdateWorkspaceBudgetSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWorkspaceBudgetSettings'
+type Database_UpdateWorkspaceBudgetSettings_Call struct {
+	*mock.Call
+}
+
+// UpdateWorkspaceBudgetSettings is a helper method to define mock.On call
+//   - settings db.WorkspaceBudgetSettings
+func (_e *Database_Expecter) UpdateWorkspaceBudgetSettings(settings interface{}) *Database_UpdateWorkspaceBudgetSettings_Call {
+	return &Database_UpdateWorkspaceBudgetSettings_Call{Call: _e.mock.On("UpdateWorkspaceBudgetSettings", settings)}
+}
+
+func (_c *Database_UpdateWorkspaceBudgetSettings_Call) Run(run func(settings db.WorkspaceBudgetSettings)) *Database_UpdateWorkspaceBudgetSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceBudgetSettings))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateWorkspaceBudgetSettings_Call) Return(_a0 db.WorkspaceBudgetSettings, _a1 error) *Database_UpdateWorkspaceBudgetSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_UpdateWorkspaceBudgetSettings_Call) RunAndReturn(run func(db.WorkspaceBudgetSettings) (db.WorkspaceBudgetSettings, error)) *Database_UpdateWorkspaceBudgetSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateWorkspaceForDeletion provides a mock function with given fields: uuid
 func (_m *Database) UpdateWorkspaceForDeletion(uuid string) error {
 	ret := _m.Called(uuid)
@@ -7782,6 +23555,62 @@ func (_c *Database_UpdateWorkspaceForDeletion_Call) RunAndReturn(run func(string
 	return _c
 }
 
+// UpsertBotReview provides a mock function with given fields: review
+func (_m *Database) UpsertBotReview(review db.BotReview) (db.BotReview, error) {
+	ret := _m.Called(review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertBotReview")
+	}
+
+	var r0 db.BotReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BotReview) (db.BotReview, error)); ok {
+		return rf(review)
+	}
+	if rf, ok := ret.Get(0).(func(db.BotReview) db.BotReview); ok {
+		r0 = rf(review)
+	} else {
+		r0 = ret.Get(0).(db.BotReview)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BotReview) error); ok {
+		r1 = rf(review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_UpsertBotReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertBotReview'
+type Database_UpsertBotReview_Call struct {
+	*mock.Call
+}
+
+// UpsertBotReview is a helper method to define mock.On call
+//   - review db.BotReview
+func (_e *Database_Expecter) UpsertBotReview(review interface{}) *Database_UpsertBotReview_Call {
+	return &Database_UpsertBotReview_Call{Call: _e.mock.On("UpsertBotReview", review)}
+}
+
+func (_c *Database_UpsertBotReview_Call) Run(run func(review db.BotReview)) *Database_UpsertBotReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BotReview))
+	})
+	return _c
+}
+
+func (_c *Database_UpsertBotReview_Call) Return(_a0 db.BotReview, _a1 error) *Database_UpsertBotReview_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_UpsertBotReview_Call) RunAndReturn(run func(db.BotReview) (db.BotReview, error)) *Database_UpsertBotReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UserHasAccess provides a mock function with given fields: pubKeyFromAuth, uuid, role
 func (_m *Database) UserHasAccess(pubKeyFromAuth string, uuid string, role string) bool {
 	ret := _m.Called(pubKeyFromAuth, uuid, role)
@@ -0,0 +1,123 @@
+package notifications
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ChatMessage is a notification for a workspace's chat app, formatted for Slack or Discord
+type ChatMessage struct {
+	Title  string
+	Text   string
+	Link   string
+	Color  int
+	Fields []ChatField
+}
+
+type ChatField struct {
+	Name  string
+	Value string
+}
+
+const (
+	slackHeaderLimit   = 150
+	discordTitleLimit  = 256
+	discordTextLimit   = 4096
+	discordFieldLimit  = 1024
+	discordFieldsLimit = 25
+)
+
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+// escapeSlack keeps user text such as bounty titles from being read as links or mentions
+func escapeSlack(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// FormatSlackMessage builds the body of a Slack incoming webhook, with a plain text fallback
+// for the notification and blocks for the message itself
+func FormatSlackMessage(message ChatMessage) map[string]interface{} {
+	blocks := []interface{}{
+		map[string]interface{}{
+			"type": "header",
+			"text": map[string]interface{}{"type": "plain_text", "text": truncate(message.Title, slackHeaderLimit)},
+		},
+	}
+	if message.Text != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": escapeSlack(message.Text)},
+		})
+	}
+	if len(message.Fields) > 0 {
+		fields := []interface{}{}
+		for _, field := range message.Fields {
+			fields = append(fields, map[string]interface{}{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("*%s*\n%s", escapeSlack(field.Name), escapeSlack(field.Value)),
+			})
+		}
+		blocks = append(blocks, map[string]interface{}{"type": "section", "fields": fields})
+	}
+	if message.Link != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "actions",
+			"elements": []interface{}{
+				map[string]interface{}{
+					"type": "button",
+					"text": map[string]interface{}{"type": "plain_text", "text": "Open"},
+					"url":  message.Link,
+				},
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"text":   escapeSlack(message.Title),
+		"blocks": blocks,
+	}
+}
+
+// FormatDiscordMessage builds the body of a Discord webhook as one embed. Mentions are turned
+// off so a bounty title can't ping a whole server
+func FormatDiscordMessage(message ChatMessage) map[string]interface{} {
+	embed := map[string]interface{}{
+		"title":     truncate(message.Title, discordTitleLimit),
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+	if message.Text != "" {
+		embed["description"] = truncate(message.Text, discordTextLimit)
+	}
+	if message.Link != "" {
+		embed["url"] = message.Link
+	}
+	if message.Color != 0 {
+		embed["color"] = message.Color
+	}
+	if len(message.Fields) > 0 {
+		fields := []interface{}{}
+		for i, field := range message.Fields {
+			if i == discordFieldsLimit {
+				break
+			}
+			fields = append(fields, map[string]interface{}{
+				"name":   truncate(field.Name, discordTitleLimit),
+				"value":  truncate(field.Value, discordFieldLimit),
+				"inline": true,
+			})
+		}
+		embed["fields"] = fields
+	}
+
+	return map[string]interface{}{
+		"embeds":           []interface{}{embed},
+		"allowed_mentions": map[string]interface{}{"parse": []interface{}{}},
+	}
+}
@@ -0,0 +1,42 @@
+package notifications
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatChatMessages(t *testing.T) {
+	message := ChatMessage{
+		Title:  "Bounty paid: <!channel> fix the <login> page",
+		Link:   "https://community.sphinx.chat/bounty/1",
+		Color:  0x49C998,
+		Fields: []ChatField{{Name: "Amount", Value: "1000 sats"}},
+	}
+
+	t.Run("Should test that a Slack message escapes user text into blocks", func(t *testing.T) {
+		body := FormatSlackMessage(message)
+
+		assert.Equal(t, "Bounty paid: &lt;!channel&gt; fix the &lt;login&gt; page", body["text"])
+		blocks := body["blocks"].([]interface{})
+		assert.Len(t, blocks, 3)
+		fields := blocks[1].(map[string]interface{})["fields"].([]interface{})
+		assert.Equal(t, "*Amount*\n1000 sats", fields[0].(map[string]interface{})["text"])
+		button := blocks[2].(map[string]interface{})["elements"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, message.Link, button["url"])
+	})
+
+	t.Run("Should test that a Discord message is an embed that can't mention anyone", func(t *testing.T) {
+		long := message
+		long.Title = strings.Repeat("a", 300)
+		body := FormatDiscordMessage(long)
+
+		embed := body["embeds"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, discordTitleLimit, len([]rune(embed["title"].(string))))
+		assert.Equal(t, message.Link, embed["url"])
+		assert.Equal(t, 0x49C998, embed["color"])
+		assert.Len(t, embed["fields"], 1)
+		assert.Empty(t, body["allowed_mentions"].(map[string]interface{})["parse"])
+	})
+}
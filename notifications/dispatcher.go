@@ -0,0 +1,132 @@
+package notifications
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+// Channel delivers a stored notification through one medium
+type Channel interface {
+	Name() string
+	Enabled(settings db.NotificationSettings) bool
+	Send(notification db.Notification) error
+}
+
+type Dispatcher struct {
+	db       db.Database
+	channels []Channel
+}
+
+var dispatcher *Dispatcher
+
+func NewDispatcher(database db.Database, channels ...Channel) *Dispatcher {
+	return &Dispatcher{
+		db:       database,
+		channels: channels,
+	}
+}
+
+// InitDispatcher sets up the dispatcher used by Notify with the default channels
+func InitDispatcher(database db.Database) {
+	dispatcher = NewDispatcher(database,
+		WebsocketChannel{},
+		SphinxPushChannel{
+			RelayUrl:  os.Getenv("ALERT_URL"),
+			Secret:    os.Getenv("ALERT_SECRET"),
+			TribeUuid: os.Getenv("ALERT_TRIBE_UUID"),
+			BotId:     os.Getenv("ALERT_BOT_ID"),
+		},
+		NewEmailChannel(database),
+	)
+}
+
+// Notify stores a notification in the person's inbox and delivers it through their enabled channels
+func Notify(pubkey string, event db.NotificationEvent, title string, content string, link string) {
+	if dispatcher == nil || pubkey == "" {
+		return
+	}
+	dispatcher.Dispatch(db.Notification{
+		OwnerPubKey: pubkey,
+		Event:       event,
+		Title:       title,
+		Content:     content,
+		Link:        link,
+	})
+}
+
+func (d *Dispatcher) Dispatch(notification db.Notification) {
+	settings := d.db.GetNotificationSettings(notification.OwnerPubKey)
+	for _, disabled := range settings.DisabledEvents {
+		if disabled == string(notification.Event) {
+			return
+		}
+	}
+
+	notification, err := d.db.AddNotification(notification)
+	if err != nil {
+		fmt.Println("[notifications] could not save notification", err)
+		return
+	}
+
+	for _, channel := range d.channels {
+		if !channel.Enabled(settings) {
+			continue
+		}
+		if err := channel.Send(notification); err != nil {
+			fmt.Println("[notifications]", channel.Name(), "delivery failed:", err)
+		}
+	}
+}
+
+// WebsocketChannel publishes notifications to the person's websocket topic
+type WebsocketChannel struct{}
+
+func (WebsocketChannel) Name() string {
+	return "websocket"
+}
+
+func (WebsocketChannel) Enabled(settings db.NotificationSettings) bool {
+	return settings.Websocket
+}
+
+func (WebsocketChannel) Send(notification db.Notification) error {
+	websocket.WebsocketPool.Publish("person:"+notification.OwnerPubKey, "notification", notification)
+	return nil
+}
+
+// SphinxPushChannel sends notifications as a Sphinx DM through the alert bot
+type SphinxPushChannel struct {
+	RelayUrl  string
+	Secret    string
+	TribeUuid string
+	BotId     string
+}
+
+func (SphinxPushChannel) Name() string {
+	return "sphinx_push"
+}
+
+func (c SphinxPushChannel) Enabled(settings db.NotificationSettings) bool {
+	return settings.SphinxPush && c.RelayUrl != "" && c.Secret != "" && c.BotId != ""
+}
+
+func (c SphinxPushChannel) Send(notification db.Notification) error {
+	content := notification.Title
+	if notification.Content != "" {
+		content += " - " + notification.Content
+	}
+	if notification.Link != "" {
+		content += " " + notification.Link
+	}
+
+	return db.SendBotAction(c.RelayUrl, c.Secret, db.Action{
+		Action:   "dm",
+		ChatUuid: c.TribeUuid,
+		Pubkey:   notification.OwnerPubKey,
+		Content:  content,
+		BotId:    c.BotId,
+	})
+}
@@ -0,0 +1,142 @@
+package notifications
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/smtp"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+var emailTemplate = template.Must(template.New("email").Parse(`<!DOCTYPE html>
+<html>
+  <body style="font-family: Arial, sans-serif; color: #3c3f41;">
+    <h2>{{.Title}}</h2>
+    {{if .Content}}<p>{{.Content}}</p>{{end}}
+    {{if .Link}}<p><a href="{{.Link}}">View on Sphinx Community</a></p>{{end}}
+    <hr />
+    <p style="font-size: 12px; color: #8e969c;">
+      You are receiving this email because email notifications are enabled on your profile.
+      <a href="{{.UnsubscribeLink}}">Unsubscribe</a>
+    </p>
+  </body>
+</html>`))
+
+type emailData struct {
+	Title           string
+	Content         string
+	Link            string
+	UnsubscribeLink string
+}
+
+// EmailChannel sends notifications to the email address on the person's profile
+type EmailChannel struct {
+	db       db.Database
+	sendMail func(to string, subject string, html string) error
+}
+
+func NewEmailChannel(database db.Database) EmailChannel {
+	return EmailChannel{
+		db:       database,
+		sendMail: sendSmtpMail,
+	}
+}
+
+func (EmailChannel) Name() string {
+	return "email"
+}
+
+func (EmailChannel) Enabled(settings db.NotificationSettings) bool {
+	return settings.Email && config.SmtpHost != ""
+}
+
+func (c EmailChannel) Send(notification db.Notification) error {
+	person := c.db.GetPersonByPubkey(notification.OwnerPubKey)
+	email := PersonEmail(person)
+	if email == "" {
+		return errors.New("no email address on profile")
+	}
+
+	var body bytes.Buffer
+	err := emailTemplate.Execute(&body, emailData{
+		Title:           notification.Title,
+		Content:         notification.Content,
+		Link:            notification.Link,
+		UnsubscribeLink: fmt.Sprintf("%s/person/notifications/unsubscribe?token=%s", config.Host, UnsubscribeToken(notification.OwnerPubKey)),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.sendMail(email, notification.Title, body.String())
+}
+
+// PersonEmail reads the email address stored in the profile extras
+func PersonEmail(person db.Person) string {
+	emails, ok := person.Extras["email"].([]interface{})
+	if !ok || len(emails) == 0 {
+		return ""
+	}
+	email, ok := emails[0].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, _ := email["value"].(string)
+	return strings.TrimSpace(value)
+}
+
+func sendSmtpMail(to string, subject string, html string) error {
+	headers := []string{
+		"From: " + config.SmtpFrom,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/html; charset=\"UTF-8\"",
+	}
+	msg := strings.Join(headers, "\r\n") + "\r\n\r\n" + html
+
+	var smtpAuth smtp.Auth
+	if config.SmtpUser != "" {
+		smtpAuth = smtp.PlainAuth("", config.SmtpUser, config.SmtpPass, config.SmtpHost)
+	}
+
+	return smtp.SendMail(config.SmtpHost+":"+config.SmtpPort, smtpAuth, config.SmtpFrom, []string{to}, []byte(msg))
+}
+
+// UnsubscribeToken is a stateless token made of the pubkey and its signature with the JWT key
+func UnsubscribeToken(pubkey string) string {
+	encodedPubkey := base64.RawURLEncoding.EncodeToString([]byte(pubkey))
+	return encodedPubkey + "." + unsubscribeSignature(pubkey)
+}
+
+// VerifyUnsubscribeToken returns the pubkey of a valid unsubscribe token
+func VerifyUnsubscribeToken(token string) (string, error) {
+	encodedPubkey, signature, found := strings.Cut(token, ".")
+	if !found {
+		return "", errors.New("invalid unsubscribe token")
+	}
+
+	pubkey, err := base64.RawURLEncoding.DecodeString(encodedPubkey)
+	if err != nil {
+		return "", errors.New("invalid unsubscribe token")
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(unsubscribeSignature(string(pubkey)))) {
+		return "", errors.New("invalid unsubscribe token")
+	}
+	return string(pubkey), nil
+}
+
+func unsubscribeSignature(pubkey string) string {
+	mac := hmac.New(sha256.New, []byte(config.JwtKey))
+	mac.Write([]byte("unsubscribe:" + pubkey))
+	return hex.EncodeToString(mac.Sum(nil))
+}
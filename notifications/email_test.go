@@ -0,0 +1,83 @@
+package notifications
+
+import (
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsubscribeToken(t *testing.T) {
+	config.JwtKey = "test_jwt_key"
+
+	t.Run("Should test that a generated token is verified back to its pubkey", func(t *testing.T) {
+		token := UnsubscribeToken("pubkey")
+
+		pubkey, err := VerifyUnsubscribeToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, "pubkey", pubkey)
+	})
+
+	t.Run("Should test that a tampered token is rejected", func(t *testing.T) {
+		token := UnsubscribeToken("pubkey")
+		otherToken := UnsubscribeToken("other_pubkey")
+
+		_, err := VerifyUnsubscribeToken(otherToken[:len(otherToken)-2] + token[len(token)-2:])
+		assert.Error(t, err)
+
+		_, err = VerifyUnsubscribeToken("invalid")
+		assert.Error(t, err)
+	})
+}
+
+func TestPersonEmail(t *testing.T) {
+	t.Run("Should test that the email is read from the profile extras", func(t *testing.T) {
+		person := db.Person{Extras: db.PropertyMap{"email": []interface{}{map[string]interface{}{"value": " test@example.com "}}}}
+		assert.Equal(t, "test@example.com", PersonEmail(person))
+	})
+
+	t.Run("Should test that an empty string is returned when there is no email", func(t *testing.T) {
+		assert.Equal(t, "", PersonEmail(db.Person{}))
+	})
+}
+
+func TestEmailChannelSend(t *testing.T) {
+	config.Host = "https://people.sphinx.chat"
+
+	t.Run("Should test that the notification is rendered and sent to the profile email", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockDb.On("GetPersonByPubkey", "pubkey").Return(db.Person{
+			OwnerPubKey: "pubkey",
+			Extras:      db.PropertyMap{"email": []interface{}{map[string]interface{}{"value": "test@example.com"}}},
+		}).Once()
+
+		var sentTo, sentSubject, sentBody string
+		channel := EmailChannel{
+			db: mockDb,
+			sendMail: func(to string, subject string, html string) error {
+				sentTo, sentSubject, sentBody = to, subject, html
+				return nil
+			},
+		}
+
+		err := channel.Send(db.Notification{OwnerPubKey: "pubkey", Title: "A bounty was assigned to you", Content: "<b>Fix bug</b>"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test@example.com", sentTo)
+		assert.Equal(t, "A bounty was assigned to you", sentSubject)
+		assert.Contains(t, sentBody, "&lt;b&gt;Fix bug&lt;/b&gt;")
+		assert.Contains(t, sentBody, "/person/notifications/unsubscribe?token="+UnsubscribeToken("pubkey"))
+	})
+
+	t.Run("Should test that an error is returned when the person has no email", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		mockDb.On("GetPersonByPubkey", "pubkey").Return(db.Person{OwnerPubKey: "pubkey"}).Once()
+
+		channel := EmailChannel{db: mockDb, sendMail: func(string, string, string) error { return nil }}
+
+		err := channel.Send(db.Notification{OwnerPubKey: "pubkey", Title: "title"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,147 @@
+package rates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	ProviderCoingecko = "coingecko"
+	ProviderKraken    = "kraken"
+	ProviderStatic    = "static"
+)
+
+type coingecko struct {
+	httpClient HttpClient
+	url        string
+}
+
+func NewCoingecko(httpClient HttpClient) Provider {
+	return &coingecko{httpClient: httpClient, url: "https://api.coingecko.com/api/v3"}
+}
+
+func (c *coingecko) Rates(currencies []string) (map[string]float64, error) {
+	query := url.Values{}
+	query.Set("ids", "bitcoin")
+	query.Set("vs_currencies", strings.ToLower(strings.Join(currencies, ",")))
+
+	prices := map[string]map[string]float64{}
+	if err := getJson(c.httpClient, c.url+"/simple/price?"+query.Encode(), &prices); err != nil {
+		return nil, err
+	}
+
+	rates := map[string]float64{}
+	for _, currency := range currencies {
+		price, ok := prices["bitcoin"][strings.ToLower(currency)]
+		if !ok {
+			return nil, fmt.Errorf("coingecko has no %s rate", currency)
+		}
+		rates[currency] = price
+	}
+	return rates, nil
+}
+
+type kraken struct {
+	httpClient HttpClient
+	url        string
+}
+
+func NewKraken(httpClient HttpClient) Provider {
+	return &kraken{httpClient: httpClient, url: "https://api.kraken.com/0/public"}
+}
+
+type krakenTicker struct {
+	Error  []string `json:"error"`
+	Result map[string]struct {
+		// last trade closed, price and lot volume
+		Close []string `json:"c"`
+	} `json:"result"`
+}
+
+func (k *kraken) Rates(currencies []string) (map[string]float64, error) {
+	rates := map[string]float64{}
+	for _, currency := range currencies {
+		ticker := krakenTicker{}
+		if err := getJson(k.httpClient, k.url+"/Ticker?pair=XBT"+currency, &ticker); err != nil {
+			return nil, err
+		}
+		if len(ticker.Error) > 0 {
+			return nil, fmt.Errorf("kraken: %s", strings.Join(ticker.Error, ", "))
+		}
+
+		// kraken names the pair itself, e.g. XXBTZUSD, so the only result is taken
+		for _, pair := range ticker.Result {
+			if len(pair.Close) == 0 {
+				break
+			}
+			price, err := strconv.ParseFloat(pair.Close[0], 64)
+			if err != nil {
+				return nil, err
+			}
+			rates[currency] = price
+		}
+		if _, ok := rates[currency]; !ok {
+			return nil, fmt.Errorf("kraken has no %s rate", currency)
+		}
+	}
+	return rates, nil
+}
+
+type static map[string]float64
+
+// ParseStatic reads fixed rates like "USD=65000,EUR=60000", for development and tests
+func ParseStatic(rates string) (Provider, error) {
+	parsed := static{}
+	for _, rate := range strings.Split(rates, ",") {
+		if strings.TrimSpace(rate) == "" {
+			continue
+		}
+		currency, price, ok := strings.Cut(rate, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid static rate %q", rate)
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(price), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid static rate %q", rate)
+		}
+		parsed[strings.ToUpper(strings.TrimSpace(currency))] = value
+	}
+	if len(parsed) == 0 {
+		return nil, errors.New("no static rates set")
+	}
+	return parsed, nil
+}
+
+func (s static) Rates(currencies []string) (map[string]float64, error) {
+	rates := map[string]float64{}
+	for _, currency := range currencies {
+		if price, ok := s[currency]; ok {
+			rates[currency] = price
+		}
+	}
+	return rates, nil
+}
+
+func getJson(httpClient HttpClient, url string, target interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("rates request failed with status %d", res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(target)
+}
@@ -0,0 +1,130 @@
+package rates
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+const satsPerBitcoin = 100000000
+
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Provider returns the price of one bitcoin in each of the requested currencies
+type Provider interface {
+	Rates(currencies []string) (map[string]float64, error)
+}
+
+// Service caches the rates of a provider, when the provider fails the last rates are kept
+type Service struct {
+	provider   Provider
+	currencies []string
+	ttl        time.Duration
+
+	m       sync.Mutex
+	rates   map[string]float64
+	fetched time.Time
+	now     func() time.Time
+}
+
+func NewService(provider Provider, currencies []string, ttl time.Duration) *Service {
+	return &Service{
+		provider:   provider,
+		currencies: currencies,
+		ttl:        ttl,
+		now:        time.Now,
+	}
+}
+
+// Rates returns the cached bitcoin price in every configured currency
+func (s *Service) Rates() map[string]float64 {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.rates != nil && s.now().Sub(s.fetched) < s.ttl {
+		return s.rates
+	}
+
+	rates, err := s.provider.Rates(s.currencies)
+	if err != nil {
+		log.Printf("[rates] could not fetch rates: %v", err)
+		return s.rates
+	}
+	s.rates = rates
+	s.fetched = s.now()
+	return s.rates
+}
+
+// Quote converts an amount of sats to every configured currency, rounded to cents
+func (s *Service) Quote(sats uint) map[string]float64 {
+	rates := s.Rates()
+	if len(rates) == 0 {
+		return nil
+	}
+
+	quote := make(map[string]float64, len(rates))
+	for currency, price := range rates {
+		quote[currency] = math.Round(float64(sats)*price/satsPerBitcoin*100) / 100
+	}
+	return quote
+}
+
+// ParseCurrencies reads a comma separated list of currency codes
+func ParseCurrencies(currencies string) []string {
+	parsed := []string{}
+	for _, currency := range strings.Split(currencies, ",") {
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if currency != "" {
+			parsed = append(parsed, currency)
+		}
+	}
+	return parsed
+}
+
+// NewProvider picks the rates provider from config.RatesProvider
+func NewProvider(httpClient HttpClient) (Provider, error) {
+	switch config.RatesProvider {
+	case "", ProviderCoingecko:
+		return NewCoingecko(httpClient), nil
+	case ProviderKraken:
+		return NewKraken(httpClient), nil
+	case ProviderStatic:
+		return ParseStatic(config.RatesStatic)
+	default:
+		return nil, fmt.Errorf("unknown rates provider %q", config.RatesProvider)
+	}
+}
+
+var service *Service
+
+// Init sets up the rates service from the config, Quote returns nothing until it is called
+func Init(httpClient HttpClient) error {
+	provider, err := NewProvider(httpClient)
+	if err != nil {
+		return err
+	}
+
+	ttl, err := time.ParseDuration(config.RatesCacheTTL)
+	if err != nil {
+		return fmt.Errorf("invalid RATES_CACHE_TTL: %w", err)
+	}
+
+	service = NewService(provider, ParseCurrencies(config.FiatCurrencies), ttl)
+	return nil
+}
+
+// Quote converts sats at the current rates of the configured service
+func Quote(sats uint) map[string]float64 {
+	if service == nil {
+		return nil
+	}
+	return service.Quote(sats)
+}
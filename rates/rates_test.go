@@ -0,0 +1,122 @@
+package rates
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingProvider struct {
+	rates map[string]float64
+	err   error
+	calls int
+}
+
+func (p *countingProvider) Rates(currencies []string) (map[string]float64, error) {
+	p.calls++
+	return p.rates, p.err
+}
+
+func TestService(t *testing.T) {
+	t.Run("Should test that sats are converted and rounded to cents", func(t *testing.T) {
+		provider := &countingProvider{rates: map[string]float64{"USD": 65000, "EUR": 60000}}
+		service := NewService(provider, []string{"USD", "EUR"}, time.Minute)
+
+		assert.Equal(t, map[string]float64{"USD": 650, "EUR": 600}, service.Quote(1000000))
+		assert.Equal(t, map[string]float64{"USD": 0.65, "EUR": 0.6}, service.Quote(1000))
+	})
+
+	t.Run("Should test that rates are cached until they expire", func(t *testing.T) {
+		provider := &countingProvider{rates: map[string]float64{"USD": 65000}}
+		service := NewService(provider, []string{"USD"}, time.Minute)
+		now := time.Now()
+		service.now = func() time.Time { return now }
+
+		service.Quote(1000)
+		service.Quote(2000)
+		assert.Equal(t, 1, provider.calls)
+
+		now = now.Add(2 * time.Minute)
+		service.Quote(1000)
+		assert.Equal(t, 2, provider.calls)
+	})
+
+	t.Run("Should test that the last rates are kept when the provider fails", func(t *testing.T) {
+		provider := &countingProvider{rates: map[string]float64{"USD": 65000}}
+		service := NewService(provider, []string{"USD"}, 0)
+		service.Quote(1000)
+
+		provider.err = errors.New("rate limited")
+		provider.rates = nil
+
+		assert.Equal(t, map[string]float64{"USD": 0.65}, service.Quote(1000))
+	})
+
+	t.Run("Should test that no quote is made without rates", func(t *testing.T) {
+		service := NewService(&countingProvider{err: errors.New("offline")}, []string{"USD"}, time.Minute)
+
+		assert.Nil(t, service.Quote(1000))
+	})
+}
+
+func TestProviders(t *testing.T) {
+	t.Run("Should test that coingecko prices are read for every currency", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/simple/price", r.URL.Path)
+			assert.Equal(t, "usd,eur", r.URL.Query().Get("vs_currencies"))
+			w.Write([]byte(`{"bitcoin": {"usd": 65000.5, "eur": 60000}}`))
+		}))
+		defer ts.Close()
+
+		provider := &coingecko{httpClient: http.DefaultClient, url: ts.URL}
+		rates, err := provider.Rates([]string{"USD", "EUR"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]float64{"USD": 65000.5, "EUR": 60000}, rates)
+	})
+
+	t.Run("Should test that kraken reads the last trade of each pair", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "XBTUSD", r.URL.Query().Get("pair"))
+			w.Write([]byte(`{"error": [], "result": {"XXBTZUSD": {"c": ["65000.10", "0.01"]}}}`))
+		}))
+		defer ts.Close()
+
+		provider := &kraken{httpClient: http.DefaultClient, url: ts.URL}
+		rates, err := provider.Rates([]string{"USD"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]float64{"USD": 65000.10}, rates)
+	})
+
+	t.Run("Should test that a failed provider request is an error", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer ts.Close()
+
+		provider := &coingecko{httpClient: http.DefaultClient, url: ts.URL}
+		_, err := provider.Rates([]string{"USD"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Should test that static rates are parsed", func(t *testing.T) {
+		provider, err := ParseStatic("usd=65000, EUR=60000")
+		assert.NoError(t, err)
+
+		rates, _ := provider.Rates([]string{"USD", "EUR", "GBP"})
+		assert.Equal(t, map[string]float64{"USD": 65000, "EUR": 60000}, rates)
+
+		_, err = ParseStatic("USD:65000")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseCurrencies(t *testing.T) {
+	assert.Equal(t, []string{"USD", "EUR"}, ParseCurrencies(" usd, EUR ,"))
+}
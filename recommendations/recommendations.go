@@ -0,0 +1,161 @@
+package recommendations
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const (
+	languageWeight = 3.0
+	skillWeight    = 2.0
+	historyWeight  = 1.0
+	priceWeight    = 2.0
+	// a language completed more often than this adds no more to the score
+	maxHistoryCount = 3
+)
+
+// Profile is what the recommendations know about a hunter
+type Profile struct {
+	// Languages are the coding languages declared on the profile
+	Languages []string
+	// Skills are the profile tags
+	Skills []string
+	// History counts the coding languages of the bounties the hunter completed
+	History map[string]int
+	// PriceToMeet is the least the hunter wants to be paid
+	PriceToMeet uint
+}
+
+type Recommendation struct {
+	Bounty  db.NewBounty `json:"bounty"`
+	Score   float64      `json:"score"`
+	Reasons []string     `json:"reasons"`
+}
+
+// NewProfile builds the profile of a person from their declared languages and skills and the
+// languages of the bounties they completed
+func NewProfile(person db.Person, history map[string]int) Profile {
+	profile := Profile{
+		Skills:  []string(person.Tags),
+		History: history,
+	}
+	if person.PriceToMeet > 0 {
+		profile.PriceToMeet = uint(person.PriceToMeet)
+	}
+
+	languages, _ := person.Extras["coding_languages"].([]interface{})
+	for _, language := range languages {
+		if entry, ok := language.(map[string]interface{}); ok {
+			if label, ok := entry["label"].(string); ok && label != "" {
+				profile.Languages = append(profile.Languages, label)
+			}
+		}
+	}
+	return profile
+}
+
+// Terms are the coding languages a bounty has to share with the profile to be a candidate
+func (p Profile) Terms() []string {
+	seen := map[string]bool{}
+	terms := []string{}
+	add := func(term string) {
+		if term != "" && !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	for _, language := range p.Languages {
+		add(language)
+	}
+	for _, skill := range p.Skills {
+		add(skill)
+	}
+	for language := range p.History {
+		add(language)
+	}
+	sort.Strings(terms)
+	return terms
+}
+
+// Score rates how well a bounty suits a profile with the reasons behind it. Declared languages
+// weigh the most, then skills found in the languages or the text of the bounty, then the
+// languages the hunter already completed bounties in, and a price that meets their own.
+// A bounty that matches none of the languages or skills scores 0
+func Score(profile Profile, bounty db.NewBounty) (float64, []string) {
+	score := 0.0
+	reasons := []string{}
+
+	languages := map[string]string{}
+	for _, language := range bounty.CodingLanguages {
+		languages[strings.ToLower(language)] = language
+	}
+
+	for _, language := range profile.Languages {
+		if matched, ok := languages[strings.ToLower(language)]; ok {
+			score += languageWeight
+			reasons = append(reasons, "language:"+matched)
+		}
+	}
+
+	text := strings.ToLower(bounty.Title + " " + bounty.Description)
+	for _, skill := range profile.Skills {
+		lower := strings.ToLower(skill)
+		if _, ok := languages[lower]; ok || (lower != "" && strings.Contains(text, lower)) {
+			score += skillWeight
+			reasons = append(reasons, "skill:"+skill)
+		}
+	}
+
+	for language, count := range profile.History {
+		if matched, ok := languages[strings.ToLower(language)]; ok && count > 0 {
+			if count > maxHistoryCount {
+				count = maxHistoryCount
+			}
+			score += historyWeight * float64(count)
+			reasons = append(reasons, "completed:"+matched)
+		}
+	}
+
+	// the price only orders bounties that match the hunter otherwise
+	if score == 0 {
+		return 0, reasons
+	}
+	if profile.PriceToMeet > 0 && bounty.Price > 0 {
+		ratio := math.Min(1, float64(bounty.Price)/float64(profile.PriceToMeet))
+		score += priceWeight * ratio
+		if ratio == 1 {
+			reasons = append(reasons, "price")
+		}
+	}
+
+	sort.Strings(reasons)
+	return math.Round(score*100) / 100, reasons
+}
+
+// Rank orders the bounties by score, newest first on a tie, leaving out the ones that don't match
+// the profile at all. A limit of 0 keeps them all
+func Rank(profile Profile, bounties []db.NewBounty, limit int) []Recommendation {
+	ranked := []Recommendation{}
+	for _, bounty := range bounties {
+		score, reasons := Score(profile, bounty)
+		if score <= 0 {
+			continue
+		}
+		ranked = append(ranked, Recommendation{Bounty: bounty, Score: score, Reasons: reasons})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Bounty.Created > ranked[j].Bounty.Created
+	})
+
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
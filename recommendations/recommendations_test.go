@@ -0,0 +1,59 @@
+package recommendations
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProfile(t *testing.T) {
+	person := db.Person{
+		Tags:        pq.StringArray{"design"},
+		PriceToMeet: 5000,
+		Extras: db.PropertyMap{"coding_languages": []interface{}{
+			map[string]interface{}{"label": "Go", "value": "Go"},
+			map[string]interface{}{"label": "Rust", "value": "Rust"},
+		}},
+	}
+
+	profile := NewProfile(person, map[string]int{"Typescript": 2, "Go": 1})
+
+	assert.Equal(t, []string{"Go", "Rust"}, profile.Languages)
+	assert.Equal(t, uint(5000), profile.PriceToMeet)
+	assert.Equal(t, []string{"Go", "Rust", "Typescript", "design"}, profile.Terms())
+}
+
+func TestScore(t *testing.T) {
+	profile := Profile{
+		Languages:   []string{"Go"},
+		Skills:      []string{"lightning"},
+		History:     map[string]int{"go": 5},
+		PriceToMeet: 10000,
+	}
+
+	score, reasons := Score(profile, db.NewBounty{Title: "Lightning payments", CodingLanguages: pq.StringArray{"Go"}, Price: 5000})
+	assert.Equal(t, 9.0, score)
+	assert.Equal(t, []string{"completed:Go", "language:Go", "skill:lightning"}, reasons)
+
+	score, reasons = Score(profile, db.NewBounty{Title: "Landing page", CodingLanguages: pq.StringArray{"CSS"}, Price: 50000})
+	assert.Equal(t, 0.0, score)
+	assert.Empty(t, reasons)
+}
+
+func TestRank(t *testing.T) {
+	profile := Profile{Languages: []string{"Go", "Rust"}}
+	bounties := []db.NewBounty{
+		{ID: 1, Created: 1, CodingLanguages: pq.StringArray{"Go"}},
+		{ID: 2, Created: 2, CodingLanguages: pq.StringArray{"Python"}},
+		{ID: 3, Created: 3, CodingLanguages: pq.StringArray{"Go", "Rust"}},
+		{ID: 4, Created: 4, CodingLanguages: pq.StringArray{"Rust"}},
+	}
+
+	ranked := Rank(profile, bounties, 2)
+
+	assert.Len(t, ranked, 2)
+	assert.Equal(t, uint(3), ranked[0].Bounty.ID)
+	assert.Equal(t, uint(4), ranked[1].Bounty.ID)
+}
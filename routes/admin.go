@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+)
+
+func AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+	superAdminHandler := handlers.NewSuperAdminHandler(db.DB)
+	bountyHandler := handlers.NewBountyHandler(http.DefaultClient, db.DB)
+	searchHandler := handlers.NewSearchHandler(http.DefaultClient, db.DB)
+	botHandler := handlers.NewBotHandler(db.DB)
+	tribeHandler := handlers.NewTribeHandler(db.DB)
+	moderationHandler := handlers.NewModerationHandler(db.DB)
+	impersonationHandler := handlers.NewImpersonationHandler(db.DB)
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContextSuperAdmin)
+
+		r.Get("/scheduler", handlers.GetScheduledJobs)
+		r.Get("/dashboard", superAdminHandler.GetAdminDashboard)
+		r.Get("/dashboard/errors", superAdminHandler.GetRecentErrors)
+		r.Get("/superadmins", superAdminHandler.GetSuperAdmins)
+		r.Post("/superadmins", superAdminHandler.AddSuperAdmin)
+		r.Delete("/superadmins/{pubkey}", superAdminHandler.RemoveSuperAdmin)
+		r.Get("/disputes", bountyHandler.GetDisputeQueue)
+		r.Post("/search/reindex", searchHandler.RebuildSearchIndex)
+		r.Get("/search/health", searchHandler.GetSearchIndexHealth)
+		r.Post("/bots/categories", botHandler.CreateOrEditBotCategory)
+		r.Delete("/bots/categories/{slug}", botHandler.DeleteBotCategory)
+		r.Put("/bots/{uuid}/featured", botHandler.SetBotFeatured)
+		r.Put("/tribes/{uuid}/featured", tribeHandler.SetTribeFeatured)
+		r.Post("/tribes/featured/reorder", tribeHandler.ReorderFeaturedTribes)
+		r.Get("/tribes/ranking", tribeHandler.GetTribeRankingSettings)
+		r.Put("/tribes/ranking", tribeHandler.UpdateTribeRankingSettings)
+		r.Get("/tribes/verifications", tribeHandler.GetTribeVerificationQueue)
+		r.Post("/tribes/verifications/{verification_uuid}", tribeHandler.ReviewTribeVerification)
+		r.Get("/moderation/reports", moderationHandler.GetReportQueue)
+		r.Post("/moderation/reports/{uuid}", moderationHandler.ResolveReport)
+		r.Get("/moderation/appeals", moderationHandler.GetAppealQueue)
+		r.Post("/moderation/appeals/{uuid}", moderationHandler.ResolveAppeal)
+		r.Get("/moderation/review", moderationHandler.GetListingReviewQueue)
+		r.Post("/moderation/review/{target_type}/{uuid}", moderationHandler.ReviewListing)
+		r.Get("/bans", moderationHandler.GetPubkeyBans)
+		r.Post("/bans", moderationHandler.BanPubkey)
+		r.Delete("/bans/{pubkey}", moderationHandler.LiftPubkeyBan)
+		r.Get("/impersonations", impersonationHandler.GetImpersonations)
+		r.Post("/impersonations", impersonationHandler.StartImpersonation)
+		r.Post("/impersonations/{uuid}/end", impersonationHandler.EndImpersonation)
+		r.Get("/impersonations/{uuid}/requests", impersonationHandler.GetImpersonatedRequests)
+	})
+	return r
+}
@@ -18,6 +18,15 @@ func BotRoutes() chi.Router {
 
 		r.Put("/", botHandler.CreateOrEditBot)
 		r.Delete("/{uuid}", botHandler.DeleteBot)
+		r.Post("/{uuid}/usage", botHandler.RecordBotUsage)
+		r.Get("/{uuid}/earnings", botHandler.GetBotEarnings)
+		r.Post("/{uuid}/payout", botHandler.PayoutBot)
+		r.Post("/{uuid}/reviews", botHandler.ReviewBot)
+		r.Delete("/{uuid}/reviews", botHandler.DeleteBotReview)
+		r.Post("/{uuid}/webhooks", botHandler.CreateOrEditBotWebhook)
+		r.Get("/{uuid}/webhooks", botHandler.GetBotWebhooks)
+		r.Delete("/{uuid}/webhooks/{webhook_uuid}", botHandler.DeleteBotWebhook)
+		r.Get("/{uuid}/webhooks/{webhook_uuid}/deliveries", botHandler.GetBotWebhookDeliveries)
 	})
 	return r
 }
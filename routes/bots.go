@@ -14,7 +14,11 @@ func BotsRoutes() chi.Router {
 		r.Post("/", botHandler.CreateOrEditBot)
 		r.Get("/", botHandler.GetListedBots)
 		r.Get("/owner/{pubkey}", botHandler.GetBotsByOwner)
+		r.Get("/categories", botHandler.GetBotCategories)
+		r.Get("/category/{slug}", botHandler.GetBotsByCategory)
+		r.Get("/featured", botHandler.GetFeaturedBots)
 		r.Get("/{uuid}", botHandler.GetBot)
+		r.Get("/{uuid}/reviews", botHandler.GetBotReviews)
 	})
 	return r
 }
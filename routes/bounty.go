@@ -12,6 +12,10 @@ import (
 func BountyRoutes() chi.Router {
 	r := chi.NewRouter()
 	bountyHandler := handlers.NewBountyHandler(http.DefaultClient, db.DB)
+	idempotencyHandler := handlers.NewIdempotencyHandler(db.DB)
+	endorsementHandler := handlers.NewEndorsementHandler(db.DB)
+	uploadHandler := handlers.NewUploadHandler(db.DB)
+	shareCardHandler := handlers.NewShareCardHandler(db.DB)
 	r.Group(func(r chi.Router) {
 		r.Get("/all", bountyHandler.GetAllBounties)
 
@@ -29,13 +33,43 @@ func BountyRoutes() chi.Router {
 		r.Get("/count", handlers.GetBountyCount)
 		r.Get("/invoice/{paymentRequest}", bountyHandler.GetInvoiceData)
 		r.Get("/filter/count", handlers.GetFilterCount)
+		r.Get("/leaderboard/{kind}", bountyHandler.GetLeaderboard)
+		r.Get("/{id}/og.png", shareCardHandler.GetBountyCard)
+		r.Get("/feed.rss", bountyHandler.GetBountyFeed)
 
 	})
 	r.Group(func(r chi.Router) {
 		r.Use(auth.PubKeyContext)
-		r.Post("/pay/{id}", bountyHandler.MakeBountyPayment)
-		r.Post("/budget/withdraw", bountyHandler.BountyBudgetWithdraw)
-		r.Post("/budget_workspace/withdraw", bountyHandler.NewBountyBudgetWithdraw)
+		r.With(idempotencyHandler.Idempotent).Post("/pay/{id}", bountyHandler.MakeBountyPayment)
+		r.Get("/payment/{id}/attempts", bountyHandler.GetPaymentAttempts)
+		r.Get("/payment/{id}/receipt.pdf", bountyHandler.GetPaymentReceipt)
+		r.Post("/{id}/disputes", bountyHandler.OpenDispute)
+		r.Get("/{id}/disputes", bountyHandler.GetBountyDisputes)
+		r.Post("/disputes/{uuid}/evidence", bountyHandler.AddDisputeEvidence)
+		r.Post("/disputes/{uuid}/withdraw", bountyHandler.WithdrawDispute)
+		r.Post("/disputes/{uuid}/resolve", bountyHandler.ResolveDispute)
+		r.Post("/{id}/escrow", bountyHandler.CreateBountyEscrow)
+		r.Get("/{id}/escrow", bountyHandler.GetBountyEscrow)
+		r.Post("/{id}/escrow/release", bountyHandler.ReleaseBountyEscrow)
+		r.Post("/{id}/escrow/refund", bountyHandler.RefundBountyEscrow)
+		r.Post("/{id}/proofs", bountyHandler.SubmitBountyProof)
+		r.Get("/{id}/proofs", bountyHandler.GetBountyProofs)
+		r.Get("/{id}/pull_requests", bountyHandler.GetBountyPullRequests)
+		r.Post("/{id}/attachments", uploadHandler.AttachToBounty)
+		r.Post("/ticket/{pubKey}/{created}/to_bounty", bountyHandler.TicketToBounty)
+		r.Post("/{id}/proofs/{proof_id}/review", bountyHandler.ReviewBountyProof)
+		r.Post("/{id}/timer/start", bountyHandler.StartBountyTimer)
+		r.Post("/{id}/timer/pause", bountyHandler.PauseBountyTimer)
+		r.Post("/{id}/timer/stop", bountyHandler.StopBountyTimer)
+		r.Get("/{id}/timer", bountyHandler.GetBountyTimeSpent)
+		r.Post("/{id}/extend", bountyHandler.ExtendBountyAssignment)
+		r.Post("/saved_searches", bountyHandler.CreateSavedSearch)
+		r.Get("/saved_searches", bountyHandler.GetSavedSearches)
+		r.Delete("/saved_searches/{id}", bountyHandler.DeleteSavedSearch)
+		r.Get("/recommended", bountyHandler.GetRecommendedBounties)
+		r.Post("/endorse/{id}", endorsementHandler.EndorseBounty)
+		r.With(idempotencyHandler.Idempotent).Post("/budget/withdraw", bountyHandler.BountyBudgetWithdraw)
+		r.With(idempotencyHandler.Idempotent).Post("/budget_workspace/withdraw", bountyHandler.NewBountyBudgetWithdraw)
 
 		r.Post("/", bountyHandler.CreateOrEditBounty)
 		r.Delete("/assignee", handlers.DeleteBountyAssignee)
@@ -0,0 +1,256 @@
+package routes
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// compressor compresses the responses of the allowed content types that are big enough, in the
+// encoding the client prefers among the ones that are turned on
+type compressor struct {
+	encodings []string
+	minSize   int
+	types     map[string]bool
+	pools     map[string]*sync.Pool
+}
+
+func newCompressor(encodings []string, minSize int, level int, types []string) *compressor {
+	c := &compressor{
+		minSize: minSize,
+		types:   map[string]bool{},
+		pools:   map[string]*sync.Pool{},
+	}
+	for _, contentType := range types {
+		c.types[strings.ToLower(contentType)] = true
+	}
+	for _, encoding := range encodings {
+		switch encoding {
+		case "br":
+			c.pools[encoding] = &sync.Pool{New: func() interface{} { return brotli.NewWriterLevel(io.Discard, level) }}
+		case "gzip":
+			gzipLevel := level
+			if gzipLevel < gzip.BestSpeed || gzipLevel > gzip.BestCompression {
+				gzipLevel = gzip.DefaultCompression
+			}
+			c.pools[encoding] = &sync.Pool{New: func() interface{} {
+				w, _ := gzip.NewWriterLevel(io.Discard, gzipLevel)
+				return w
+			}}
+		default:
+			continue
+		}
+		c.encodings = append(c.encodings, encoding)
+	}
+	return c
+}
+
+// compressResponses is the compression middleware of the settings in the config
+func compressResponses(next http.Handler) http.Handler {
+	if strings.EqualFold(config.CompressionEncodings, "none") {
+		return next
+	}
+	minSize, err := strconv.Atoi(config.CompressionMinSize)
+	if err != nil || minSize < 0 {
+		minSize = 1024
+	}
+	level, err := strconv.Atoi(config.CompressionLevel)
+	if err != nil {
+		level = 5
+	}
+	c := newCompressor(config.SplitList(config.CompressionEncodings, ","), minSize, level, config.SplitList(config.CompressionTypes, ","))
+	return c.Handler(next)
+}
+
+// negotiate picks the encoding of a response from the Accept-Encoding of the request, empty when
+// the client accepts none of ours
+func (c *compressor) negotiate(acceptEncoding string) string {
+	accepted := map[string]float64{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if value, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = value
+				}
+			}
+		}
+		accepted[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range c.encodings {
+		q, ok := accepted[encoding]
+		if !ok {
+			q, ok = accepted["*"]
+		}
+		// our encodings are in order of preference, a later one needs a higher weight
+		if ok && q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+func (c *compressor) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// websockets take over the connection and HEAD responses have no body
+		if r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := c.negotiate(r.Header.Get("Accept-Encoding"))
+		if encoding == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, compressor: c, encoding: encoding, status: http.StatusOK}
+		defer cw.Close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+type flushWriter interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// compressWriter holds the start of a response until it knows if it is worth compressing
+type compressWriter struct {
+	http.ResponseWriter
+	compressor  *compressor
+	encoding    string
+	status      int
+	wroteHeader bool
+	buf         []byte
+	decided     bool
+	encoder     flushWriter
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = status
+	// responses without a body are sent right away
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		cw.decide(false)
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.decided {
+		cw.buf = append(cw.buf, p...)
+		if len(cw.buf) >= cw.compressor.minSize {
+			if err := cw.decide(true); err != nil {
+				return 0, err
+			}
+		}
+		return len(p), nil
+	}
+	if cw.encoder != nil {
+		return cw.encoder.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// compressible reports if the content type of the response is allowed and it isn't encoded already
+func (cw *compressWriter) compressible() bool {
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" || cw.status < http.StatusOK || cw.status == http.StatusNoContent || cw.status == http.StatusNotModified {
+		return false
+	}
+	if header.Get("Content-Type") == "" {
+		// net/http would sniff the compressed bytes, so the type is sniffed before
+		header.Set("Content-Type", http.DetectContentType(cw.buf))
+	}
+	contentType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && cw.compressor.types[strings.ToLower(contentType)]
+}
+
+// decide sends the held start of the response, compressed when it is big enough and allowed
+func (cw *compressWriter) decide(bigEnough bool) error {
+	if cw.decided {
+		return nil
+	}
+	cw.decided = true
+
+	if bigEnough && cw.compressible() {
+		header := cw.Header()
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+		cw.encoder = cw.compressor.pools[cw.encoding].Get().(flushWriter)
+		cw.encoder.Reset(cw.ResponseWriter)
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	buf := cw.buf
+	cw.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if cw.encoder != nil {
+		_, err = cw.encoder.Write(buf)
+	} else {
+		_, err = cw.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// Flush sends what the handler wrote so far, a stream flushed before the minimum size isn't compressed
+func (cw *compressWriter) Flush() {
+	cw.decide(len(cw.buf) >= cw.compressor.minSize)
+	if cw.encoder != nil {
+		cw.encoder.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (cw *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := cw.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+	return nil, nil, errors.New("the response writer cannot be hijacked")
+}
+
+func (cw *compressWriter) Close() error {
+	if !cw.decided {
+		if !cw.wroteHeader && len(cw.buf) == 0 {
+			// nothing was written, net/http sends the default response
+			cw.decided = true
+			return nil
+		}
+		cw.decide(false)
+	}
+	if cw.encoder == nil {
+		return nil
+	}
+	err := cw.encoder.Close()
+	cw.encoder.Reset(io.Discard)
+	cw.compressor.pools[cw.encoding].Put(cw.encoder)
+	cw.encoder = nil
+	return err
+}
@@ -0,0 +1,190 @@
+package routes
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompression(t *testing.T) {
+	c := newCompressor([]string{"br", "gzip"}, 100, 5, []string{"application/json", "text/plain"})
+	big := strings.Repeat(`{"title":"Fix the login"}`, 20)
+
+	serve := func(handler http.HandlerFunc, method string, headers map[string]string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, "/gobounties", nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		c.Handler(handler).ServeHTTP(rr, req)
+		return rr
+	}
+	writeJSON := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Length", "9999")
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, body)
+		}
+	}
+
+	t.Run("Should test that the encoding is negotiated from the Accept-Encoding", func(t *testing.T) {
+		assert.Equal(t, "br", c.negotiate("gzip, br"))
+		assert.Equal(t, "gzip", c.negotiate("gzip;q=1.0, br;q=0.5"))
+		assert.Equal(t, "gzip", c.negotiate("GZIP"))
+		assert.Equal(t, "br", c.negotiate("*"))
+		assert.Equal(t, "gzip", c.negotiate("br;q=0, *;q=0.3"))
+		assert.Equal(t, "", c.negotiate("gzip;q=0, br;q=0"))
+		assert.Equal(t, "", c.negotiate("deflate, identity"))
+		assert.Equal(t, "", c.negotiate(""))
+	})
+
+	t.Run("Should test that unknown encodings are not turned on", func(t *testing.T) {
+		gzipOnly := newCompressor([]string{"deflate", "gzip"}, 100, 5, []string{"application/json"})
+		assert.Equal(t, []string{"gzip"}, gzipOnly.encodings)
+		assert.Equal(t, "gzip", gzipOnly.negotiate("br, gzip;q=0.5"))
+	})
+
+	t.Run("Should test that brotli is preferred over gzip", func(t *testing.T) {
+		rr := serve(writeJSON(big), http.MethodGet, map[string]string{"Accept-Encoding": "gzip, br"})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "br", rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rr.Header().Get("Vary"))
+		assert.Equal(t, "", rr.Header().Get("Content-Length"))
+		body, err := io.ReadAll(brotli.NewReader(rr.Body))
+		assert.NoError(t, err)
+		assert.Equal(t, big, string(body))
+	})
+
+	t.Run("Should test that gzip responses decode to the body", func(t *testing.T) {
+		rr := serve(writeJSON(big), http.MethodGet, map[string]string{"Accept-Encoding": "gzip"})
+
+		assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, "", rr.Header().Get("Content-Length"))
+		reader, err := gzip.NewReader(rr.Body)
+		assert.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, big, string(body))
+	})
+
+	t.Run("Should test that bodies under the minimum size are not compressed", func(t *testing.T) {
+		rr := serve(writeJSON(`{"ok":true}`), http.MethodGet, map[string]string{"Accept-Encoding": "gzip, br"})
+
+		assert.Equal(t, "", rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rr.Header().Get("Vary"))
+		assert.Equal(t, "9999", rr.Header().Get("Content-Length"))
+		assert.Equal(t, `{"ok":true}`, rr.Body.String())
+	})
+
+	t.Run("Should test that types that are not allowed are not compressed", func(t *testing.T) {
+		image := strings.Repeat("x", 500)
+		rr := serve(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			io.WriteString(w, image)
+		}, http.MethodGet, map[string]string{"Accept-Encoding": "gzip"})
+
+		assert.Equal(t, "", rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, image, rr.Body.String())
+	})
+
+	t.Run("Should test that responses encoded already are not compressed again", func(t *testing.T) {
+		rr := serve(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Encoding", "identity")
+			io.WriteString(w, big)
+		}, http.MethodGet, map[string]string{"Accept-Encoding": "gzip"})
+
+		assert.Equal(t, "identity", rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, big, rr.Body.String())
+	})
+
+	t.Run("Should test that the content type is sniffed before compressing", func(t *testing.T) {
+		text := strings.Repeat("plain text ", 20)
+		rr := serve(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, text)
+		}, http.MethodGet, map[string]string{"Accept-Encoding": "gzip"})
+
+		assert.Equal(t, "text/plain; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("Should test that a flush sends the streamed response", func(t *testing.T) {
+		var flushedCompressed, flushedSmall bool
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/gobounties", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		c.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, big)
+			w.(http.Flusher).Flush()
+			flushedCompressed = rr.Flushed && rr.Body.Len() > 0
+			io.WriteString(w, big)
+		})).ServeHTTP(rr, req)
+
+		assert.True(t, flushedCompressed)
+		assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+		reader, err := gzip.NewReader(rr.Body)
+		assert.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, big+big, string(body))
+
+		rr = httptest.NewRecorder()
+		c.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			io.WriteString(w, "event: ping\n\n")
+			w.(http.Flusher).Flush()
+			flushedSmall = rr.Flushed && rr.Body.String() == "event: ping\n\n"
+			io.WriteString(w, big)
+		})).ServeHTTP(rr, req)
+
+		assert.True(t, flushedSmall)
+		assert.Equal(t, "", rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, "event: ping\n\n"+big, rr.Body.String())
+	})
+
+	t.Run("Should test that HEAD requests and upgrades are not wrapped", func(t *testing.T) {
+		var wrapped bool
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			_, wrapped = w.(*compressWriter)
+			writeJSON(big)(w, r)
+		}
+
+		rr := serve(handler, http.MethodHead, map[string]string{"Accept-Encoding": "gzip"})
+		assert.False(t, wrapped)
+		assert.Equal(t, "", rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, "", rr.Header().Get("Vary"))
+		assert.Equal(t, "9999", rr.Header().Get("Content-Length"))
+
+		rr = serve(handler, http.MethodGet, map[string]string{"Accept-Encoding": "gzip", "Upgrade": "websocket", "Connection": "Upgrade"})
+		assert.False(t, wrapped)
+		assert.Equal(t, "", rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, big, rr.Body.String())
+	})
+
+	t.Run("Should test that responses without a body keep their status", func(t *testing.T) {
+		rr := serve(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}, http.MethodGet, map[string]string{"Accept-Encoding": "gzip"})
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, "", rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, 0, rr.Body.Len())
+	})
+
+	t.Run("Should test that clients without a shared encoding get the plain body", func(t *testing.T) {
+		rr := serve(writeJSON(big), http.MethodGet, map[string]string{"Accept-Encoding": "deflate"})
+
+		assert.Equal(t, "", rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rr.Header().Get("Vary"))
+		assert.Equal(t, big, rr.Body.String())
+	})
+}
@@ -12,11 +12,17 @@ func ConnectionCodesRoutes() chi.Router {
 	authHandler := handlers.NewAuthHandler(db.DB)
 	r.Group(func(r chi.Router) {
 		r.Get("/", authHandler.GetConnectionCode)
+		r.Get("/qr.{format}", authHandler.GetConnectionCodeQR)
+		r.Get("/challenge", authHandler.GetConnectionCodeChallenge)
 	})
 
 	r.Group(func(r chi.Router) {
 		r.Use(auth.ConnectionCodeContext)
 		r.Post("/", authHandler.CreateConnectionCode)
+		r.Post("/batch", authHandler.CreateConnectionCodeBatch)
+		r.Get("/campaigns", authHandler.GetConnectionCodeCampaigns)
+		r.Get("/campaigns/{campaign}", authHandler.GetCampaignConnectionCodes)
+		r.Post("/campaigns/{campaign}/revoke", authHandler.RevokeCampaignConnectionCodes)
 	})
 	return r
 }
@@ -19,6 +19,10 @@ func FeatureRoutes() chi.Router {
 		r.Get("/forworkspace/{workspace_uuid}", featureHandlers.GetFeaturesByWorkspaceUuid)
 		r.Get("/workspace/count/{uuid}", featureHandlers.GetWorkspaceFeaturesCount)
 		r.Delete("/{uuid}", featureHandlers.DeleteFeature)
+		r.Get("/{uuid}/roadmap", featureHandlers.GetFeatureRoadmap)
+		r.Get("/{uuid}/dependencies", featureHandlers.GetFeatureDependencies)
+		r.Post("/dependencies", featureHandlers.CreateFeatureDependency)
+		r.Delete("/dependencies/{id}", featureHandlers.DeleteFeatureDependency)
 
 		r.Post("/phase", featureHandlers.CreateOrEditFeaturePhase)
 		r.Get("/{feature_uuid}/phase", featureHandlers.GetFeaturePhases)
@@ -31,6 +35,10 @@ func FeatureRoutes() chi.Router {
 		r.Delete("/{feature_uuid}/story/{story_uuid}", featureHandlers.DeleteStory)
 		r.Get("/{feature_uuid}/phase/{phase_uuid}/bounty", featureHandlers.GetBountiesByFeatureAndPhaseUuid)
 		r.Get("/{feature_uuid}/phase/{phase_uuid}/bounty/count", featureHandlers.GetBountiesCountByFeatureAndPhaseUuid)
+		r.Get("/{feature_uuid}/phase/{phase_uuid}/tickets/board", featureHandlers.GetTicketBoard)
+		r.Patch("/{feature_uuid}/phase/{phase_uuid}/tickets/reorder", featureHandlers.ReorderTickets)
+		r.Get("/{feature_uuid}/phase/{phase_uuid}/budget", featureHandlers.GetPhaseBudget)
+		r.Put("/{feature_uuid}/phase/{phase_uuid}/budget", featureHandlers.UpdatePhaseBudget)
 
 	})
 	return r
@@ -10,7 +10,6 @@ import (
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
-	"github.com/rs/cors"
 
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
@@ -25,6 +24,18 @@ func NewRouter() *http.Server {
 	channelHandler := handlers.NewChannelHandler(db.DB)
 	botHandler := handlers.NewBotHandler(db.DB)
 	bHandler := handlers.NewBountyHandler(http.DefaultClient, db.DB)
+	idempotencyHandler := handlers.NewIdempotencyHandler(db.DB)
+	lnurlPayHandler := handlers.NewLnurlPayHandler(http.DefaultClient, db.DB)
+	searchHandler := handlers.NewSearchHandler(http.DefaultClient, db.DB)
+	uploadHandler := handlers.NewUploadHandler(db.DB)
+	ticketHandler := handlers.NewTicketHandler(db.DB)
+	stakworkJobHandler := handlers.NewStakworkJobHandler(db.DB)
+	challengeHandler := handlers.NewChallengeHandler(http.DefaultClient, db.DB)
+	moderationHandler := handlers.NewModerationHandler(db.DB)
+	graphqlHandler := handlers.NewGraphqlHandler(db.DB)
+
+	// the challenge gate only checks writes to the routes in CHALLENGE_ROUTES
+	r.Use(challengeHandler.Challenge)
 
 	r.Mount("/tribes", TribeRoutes())
 	r.Mount("/bots", BotsRoutes())
@@ -37,17 +48,22 @@ func NewRouter() *http.Server {
 	r.Mount("/workspaces", WorkspaceRoutes())
 	r.Mount("/metrics", MetricsRoutes())
 	r.Mount("/features", FeatureRoutes())
+	r.Mount("/admin", AdminRoutes())
+	r.Mount("/workflows", WorkflowRoutes())
 
 	r.Group(func(r chi.Router) {
+		r.Get("/versions", getApiVersions)
 		r.Get("/tribe_by_feed", tribeHandlers.GetFirstTribeByFeed)
 		r.Get("/leaderboard/{tribe_uuid}", handlers.GetLeaderBoard)
 		r.Get("/tribe_by_un/{un}", tribeHandlers.GetTribeByUniqueName)
 		r.Get("/tribes_by_owner/{pubkey}", tribeHandlers.GetTribesByOwner)
 
+		r.Get("/search", searchHandler.Search)
 		r.Get("/search/bots/{query}", botHandler.SearchBots)
 		r.Get("/podcast", handlers.GetPodcast)
 		r.Get("/feed", handlers.GetGenericFeed)
 		r.Post("/feed/download", handlers.DownloadYoutubeFeed)
+		r.Get("/challenge", challengeHandler.GetChallenge)
 		r.Get("/search_podcasts", handlers.SearchPodcasts)
 		r.Get("/search_podcast_episodes", handlers.SearchPodcastEpisodes)
 		r.Get("/search_youtube", handlers.SearchYoutube)
@@ -60,7 +76,24 @@ func NewRouter() *http.Server {
 		r.Post("/save", db.PostSave)
 		r.Get("/save/{key}", db.PollSave)
 		r.Get("/websocket", handlers.HandleWebSocket)
+		r.Get("/events", handlers.StreamEvents)
 		r.Get("/migrate_bounties", handlers.MigrateBounties)
+		r.Post("/webhooks/invoice", bHandler.InvoiceWebhook)
+		r.Post("/webhooks/stakwork/{token}", stakworkJobHandler.StakworkCallback)
+		r.Get("/uploads/*", uploadHandler.Download)
+		r.Get("/storage/*", uploadHandler.ServeStorage)
+		r.Get("/ticket/{pubKey}/{created}", uploadHandler.GetTicket)
+		r.Get("/ticket/{pubKey}/{created}/comments", ticketHandler.GetTicketComments)
+		r.Get("/ticket/{pubKey}/{created}/links", ticketHandler.GetTicketLinks)
+		r.Get("/ticket/{pubKey}/{created}/history", ticketHandler.GetTicketHistory)
+		r.Get("/ticket/{pubKey}/{created}/diff", ticketHandler.GetTicketDiff)
+		r.Get("/ticket/{pubKey}/graph", ticketHandler.GetTicketGraph)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(auth.OptionalPubKeyContext)
+		r.Get("/graphql", graphqlHandler.ServeGraphql)
+		r.Post("/graphql", graphqlHandler.ServeGraphql)
 	})
 
 	r.Group(func(r chi.Router) {
@@ -76,18 +109,37 @@ func NewRouter() *http.Server {
 		r.Post("/verify/{challenge}", db.Verify)
 		r.Post("/badges", handlers.AddOrRemoveBadge)
 		r.Delete("/channel/{id}", channelHandler.DeleteChannel)
+		r.Put("/channel/{id}", channelHandler.UpdateChannel)
+		r.Post("/channel/reorder", channelHandler.ReorderChannels)
+		r.Get("/channel/archived/{tribe_uuid}", channelHandler.GetArchivedChannels)
 		r.Delete("/ticket/{pubKey}/{created}", handlers.DeleteTicketByAdmin)
 		r.Get("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
-		r.Post("/meme_upload", handlers.MemeImageUpload)
+		r.Post("/meme_upload", uploadHandler.MemeImageUpload)
+		r.Post("/uploads", uploadHandler.Upload)
+		r.Post("/ticket/{pubKey}/{created}/attachments", uploadHandler.AttachToTicket)
+		r.Post("/ticket/{pubKey}/{created}/comments", ticketHandler.CreateTicketComment)
+		r.Post("/ticket/comments/{id}/resolve", ticketHandler.ResolveTicketThread)
+		r.Post("/ticket/comments/{id}/unresolve", ticketHandler.UnresolveTicketThread)
+		r.Post("/ticket/{pubKey}/{created}/links", ticketHandler.CreateTicketLink)
+		r.Delete("/ticket/links/{id}", ticketHandler.DeleteTicketLink)
+		r.Post("/ticket/bulk", ticketHandler.BulkTickets)
+		r.Get("/stakwork/jobs/{uuid}", stakworkJobHandler.GetStakworkJob)
+		r.Delete("/attachments/{id}", uploadHandler.DeleteAttachment)
+		r.Post("/report", moderationHandler.CreateReport)
+		r.Post("/report/{uuid}/appeal", moderationHandler.CreateAppeal)
 		r.Get("/admin/auth", authHandler.GetIsAdmin)
+		r.Post("/logout", authHandler.Logout)
 	})
 
 	r.Group(func(r chi.Router) {
 		r.Get("/lnauth_login", handlers.ReceiveLnAuthData)
 		r.Get("/lnauth", handlers.GetLnurlAuth)
+		r.Get("/lnauth/qr.{format}", handlers.GetLnurlAuthQR)
+		r.Get("/.well-known/lnurlp/{workspace_uuid}", lnurlPayHandler.LnurlPayRequest)
+		r.Get("/lnurlp/{workspace_uuid}/callback", lnurlPayHandler.LnurlPayCallback)
 		r.Get("/refresh_jwt", authHandler.RefreshToken)
-		r.Post("/invoices", handlers.GenerateInvoice)
-		r.Post("/budgetinvoices", tribeHandlers.GenerateBudgetInvoice)
+		r.With(idempotencyHandler.Idempotent).Post("/invoices", handlers.GenerateInvoice)
+		r.With(idempotencyHandler.Idempotent).Post("/budgetinvoices", tribeHandlers.GenerateBudgetInvoice)
 	})
 
 	PORT := os.Getenv("PORT")
@@ -143,14 +195,10 @@ func initChi() *chi.Mux {
 	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
-	cors := cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-User", "authorization", "x-jwt", "Referer", "User-Agent"},
-		AllowCredentials: true,
-		MaxAge:           300,
-	})
-	r.Use(cors.Handler)
+	r.Use(apiVersioning)
+	r.Use(compressResponses)
+	r.Use(corsHandler)
+	r.Use(securityHeaders)
 	r.Use(middleware.Timeout(60 * time.Second))
 	return r
 }
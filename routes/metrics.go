@@ -19,10 +19,14 @@ func MetricsRoutes() chi.Router {
 		r.Post("/people", handlers.PeopleMetrics)
 		r.Post("/organization", handlers.WorkspacetMetrics)
 		r.Post("/bounty_stats", mh.BountyMetrics)
+		r.Post("/funnel", mh.BountyFunnelMetrics)
+		r.Get("/timeseries", mh.MetricsTimeSeries)
 		r.Post("/bounties", mh.MetricsBounties)
 		r.Post("/bounties/count", mh.MetricsBountiesCount)
+		r.Post("/bounties/export", mh.ExportMetricsBounties)
 		r.Post("/bounties/providers", mh.MetricsBountiesProviders)
 		r.Post("/csv", handlers.MetricsCsv)
+		r.Post("/rollup", mh.RefreshMetricsRollups)
 	})
 	return r
 }
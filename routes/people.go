@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
 )
@@ -14,8 +15,10 @@ func PeopleRoutes() chi.Router {
 
 	peopleHandler := handlers.NewPeopleHandler(db.DB)
 	r.Group(func(r chi.Router) {
+		r.Use(auth.OptionalPubKeyContext)
 		r.Get("/", peopleHandler.GetListedPeople)
 		r.Get("/search", peopleHandler.GetPeopleBySearch)
+		r.Get("/facets", peopleHandler.GetPeopleFacets)
 		r.Get("/posts", handlers.GetListedPosts)
 		r.Get("/wanteds/assigned/{uuid}", bountyHandler.GetPersonAssignedBounties)
 		r.Get("/wanteds/created/{uuid}", bountyHandler.GetPersonCreatedBounties)
@@ -24,5 +27,11 @@ func PeopleRoutes() chi.Router {
 		r.Get("/offers", handlers.GetListedOffers)
 		r.Get("/bounty/leaderboard", handlers.GetBountiesLeaderboard)
 	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+		r.Get("/wallet/earnings", peopleHandler.GetPersonEarnings)
+		r.Get("/wallet/earnings/export", peopleHandler.ExportPersonEarnings)
+	})
 	return r
 }
@@ -10,12 +10,23 @@ import (
 func PersonRoutes() chi.Router {
 	r := chi.NewRouter()
 	peopleHandler := handlers.NewPeopleHandler(db.DB)
+	notificationHandler := handlers.NewNotificationHandler(db.DB)
+	apiKeyHandler := handlers.NewApiKeyHandler(db.DB)
+	authHandler := handlers.NewAuthHandler(db.DB)
+	nostrHandler := handlers.NewNostrHandler(db.DB)
+	activityHandler := handlers.NewActivityHandler(db.DB)
+	endorsementHandler := handlers.NewEndorsementHandler(db.DB)
+	accountHandler := handlers.NewAccountHandler(db.DB)
 	r.Group(func(r chi.Router) {
+		r.Use(auth.OptionalPubKeyContext)
 		r.Get("/{pubkey}", peopleHandler.GetPersonByPubkey)
+		r.Get("/{pubkey}/activity", activityHandler.GetPersonActivity)
+		r.Get("/{pubkey}/endorsements", endorsementHandler.GetPersonEndorsements)
 		r.Get("/id/{id}", peopleHandler.GetPersonById)
 		r.Get("/uuid/{uuid}", peopleHandler.GetPersonByUuid)
 		r.Get("/uuid/{uuid}/assets", handlers.GetPersonAssetsByUuid)
 		r.Get("/githubname/{github}", handlers.GetPersonByGithubName)
+		r.Get("/notifications/unsubscribe", notificationHandler.UnsubscribeEmail)
 	})
 
 	r.Group(func(r chi.Router) {
@@ -28,6 +39,26 @@ func PersonRoutes() chi.Router {
 
 		r.Post("/", peopleHandler.CreateOrEditPerson)
 		r.Delete("/{id}", peopleHandler.DeletePerson)
+		r.Put("/privacy", peopleHandler.UpdatePrivacy)
+
+		r.Get("/notifications", notificationHandler.GetNotifications)
+		r.Get("/notifications/settings", notificationHandler.GetNotificationSettings)
+		r.Put("/notifications/settings", notificationHandler.UpdateNotificationSettings)
+		r.Put("/notifications/read", notificationHandler.MarkAllNotificationsRead)
+		r.Put("/notifications/{uuid}/read", notificationHandler.MarkNotificationRead)
+		r.Put("/notifications/{uuid}/unread", notificationHandler.MarkNotificationUnread)
+
+		r.Get("/api_keys", apiKeyHandler.GetApiKeys)
+		r.Post("/api_keys", apiKeyHandler.CreateApiKey)
+		r.Delete("/api_keys/{uuid}", apiKeyHandler.RevokeApiKey)
+		r.Get("/export", accountHandler.ExportPersonData)
+		r.Post("/delete_account", accountHandler.DeleteAccount)
+		r.Get("/sessions", authHandler.GetSessions)
+		r.Patch("/sessions/{uuid}", authHandler.RenameSession)
+		r.Delete("/sessions/{uuid}", authHandler.RevokeSession)
+		r.Get("/nostr", nostrHandler.GetNostrIdentities)
+		r.Post("/nostr", nostrHandler.LinkNostrPubkey)
+		r.Delete("/nostr/{nostr_pubkey}", nostrHandler.UnlinkNostrPubkey)
 	})
 	return r
 }
@@ -0,0 +1,66 @@
+package routes
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/rs/cors"
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// newCors builds the cors handler for a set of origins, browsers reject
+// credentials with a wildcard origin so they are only allowed for explicit origins
+func newCors(origins []string) *cors.Cors {
+	allowCredentials := true
+	for _, origin := range origins {
+		if origin == "*" {
+			allowCredentials = false
+		}
+	}
+	return cors.New(cors.Options{
+		AllowedOrigins:   origins,
+		AllowedMethods:   config.CorsAllowedMethods,
+		AllowedHeaders:   config.CorsAllowedHeaders,
+		AllowCredentials: allowCredentials,
+		MaxAge:           300,
+	})
+}
+
+// corsHandler applies the cors settings of the longest matching route prefix, falling back to the default origins
+func corsHandler(next http.Handler) http.Handler {
+	defaultHandler := newCors(config.CorsAllowedOrigins).Handler(next)
+
+	prefixes := []string{}
+	routeHandlers := map[string]http.Handler{}
+	for prefix, origins := range config.CorsRouteOrigins {
+		prefixes = append(prefixes, prefix)
+		routeHandlers[prefix] = newCors(origins).Handler(next)
+	}
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				routeHandlers[prefix].ServeHTTP(w, r)
+				return
+			}
+		}
+		defaultHandler.ServeHTTP(w, r)
+	})
+}
+
+// securityHeaders sets the response headers that harden the API against sniffing, framing and downgrade attacks
+func securityHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers := w.Header()
+		headers.Set("X-Content-Type-Options", "nosniff")
+		headers.Set("X-Frame-Options", "DENY")
+		headers.Set("Referrer-Policy", "strict-origin-when-cross-origin")
+		headers.Set("Content-Security-Policy", config.ContentSecurityPolicy)
+		if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+			headers.Set("Strict-Transport-Security", "max-age="+config.HstsMaxAge+"; includeSubDomains")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
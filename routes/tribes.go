@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
 )
@@ -9,13 +10,40 @@ import (
 func TribeRoutes() chi.Router {
 	r := chi.NewRouter()
 	tribeHandlers := handlers.NewTribeHandler(db.DB)
+	shareCardHandler := handlers.NewShareCardHandler(db.DB)
 	r.Group(func(r chi.Router) {
 		r.Get("/", tribeHandlers.GetListedTribes)
 		r.Get("/app_url/{app_url}", tribeHandlers.GetTribesByAppUrl)
 		r.Get("/app_urls/{app_urls}", handlers.GetTribesByAppUrls)
 		r.Get("/{uuid}", tribeHandlers.GetTribe)
 		r.Get("/total", tribeHandlers.GetTotalribes)
+		r.Get("/featured", tribeHandlers.GetFeaturedTribes)
 		r.Post("/", tribeHandlers.CreateOrEditTribe)
+		r.Get("/{uuid}/membership/{pubkey}", tribeHandlers.GetTribeMembership)
+		r.Get("/{uuid}/og.png", shareCardHandler.GetTribeCard)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+
+		r.Put("/{uuid}/members", tribeHandlers.SyncTribeMembers)
+		r.Get("/{uuid}/members", tribeHandlers.GetTribeMembers)
+		r.Post("/{uuid}/members/{pubkey}/kick", tribeHandlers.KickTribeMember)
+		r.Get("/{uuid}/bans", tribeHandlers.GetTribeBans)
+		r.Post("/{uuid}/bans", tribeHandlers.BanTribeMember)
+		r.Delete("/{uuid}/bans/{pubkey}", tribeHandlers.UnbanTribeMember)
+
+		r.Post("/{uuid}/invites", tribeHandlers.CreateTribeInvite)
+		r.Get("/{uuid}/invites", tribeHandlers.GetTribeInvites)
+		r.Get("/{uuid}/invites/{code}", tribeHandlers.GetTribeInviteAnalytics)
+		r.Delete("/{uuid}/invites/{code}", tribeHandlers.RevokeTribeInvite)
+		r.Post("/invites/{code}/redeem", tribeHandlers.RedeemTribeInvite)
+
+		r.Get("/{uuid}/analytics", tribeHandlers.GetTribeAnalytics)
+
+		r.Post("/{uuid}/verifications", tribeHandlers.CreateTribeVerification)
+		r.Get("/{uuid}/verifications", tribeHandlers.GetTribeVerifications)
+		r.Post("/{uuid}/verifications/{verification_uuid}/check", tribeHandlers.CheckTribeVerification)
+		r.Post("/{uuid}/verifications/{verification_uuid}/review", tribeHandlers.RequestTribeVerificationReview)
 	})
 	return r
 }
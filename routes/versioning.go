@@ -0,0 +1,100 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// apiVersion is the current version of the api, its routes are served under /v1
+const apiVersion = "v1"
+
+const apiVersionPrefix = "/" + apiVersion
+
+// versionPattern matches a path of any version of the api, the ones not served answer not found
+var versionPattern = regexp.MustCompile(`^/v\d+(/|$)`)
+
+// unversionedPrefixes are paths that other services and wallets are set up with, such as LNURL
+// and webhooks, they keep working without a version and are not deprecated
+var unversionedPrefixes = []string{"/.well-known/", "/lnurlp/", "/lnauth", "/webhooks/", "/versions"}
+
+// apiVersionDoc tells clients which versions of the api they can use
+type apiVersionDoc struct {
+	Current  string           `json:"current"`
+	Versions []apiVersionInfo `json:"versions"`
+}
+
+type apiVersionInfo struct {
+	Version string `json:"version"`
+	Prefix  string `json:"prefix"`
+	Status  string `json:"status"`
+	Sunset  string `json:"sunset,omitempty"`
+}
+
+func unversionedPath(path string) bool {
+	for _, prefix := range unversionedPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// legacySunset is the Sunset header of the paths without a version, an HTTP date
+func legacySunset() string {
+	sunset, err := time.Parse("2006-01-02", config.LegacyApiSunset)
+	if err != nil {
+		return ""
+	}
+	return sunset.UTC().Format(http.TimeFormat)
+}
+
+// apiVersioning serves the /v1 paths with the routes of the api, so the routes are declared once.
+// The paths without a version still work but answer with Deprecation and Sunset headers and a
+// link to their /v1 path
+func apiVersioning(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("API-Version", apiVersion)
+
+		path := r.URL.Path
+		if path == apiVersionPrefix || strings.HasPrefix(path, apiVersionPrefix+"/") {
+			r = r.WithContext(context.WithValue(r.Context(), auth.RequestPathKey, path))
+			u := *r.URL
+			r.URL = &u
+			r.URL.Path = "/" + strings.TrimPrefix(strings.TrimPrefix(path, apiVersionPrefix), "/")
+			if r.URL.RawPath != "" {
+				r.URL.RawPath = "/" + strings.TrimPrefix(strings.TrimPrefix(r.URL.RawPath, apiVersionPrefix), "/")
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !unversionedPath(path) && !versionPattern.MatchString(path) {
+			w.Header().Set("Deprecation", "true")
+			if sunset := legacySunset(); sunset != "" {
+				w.Header().Set("Sunset", sunset)
+			}
+			w.Header().Add("Link", "<"+apiVersionPrefix+path+">; rel=\"successor-version\"")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// getApiVersions lists the versions of the api and the date the paths without a version stop working
+func getApiVersions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(apiVersionDoc{
+		Current: apiVersion,
+		Versions: []apiVersionInfo{
+			{Version: apiVersion, Prefix: apiVersionPrefix, Status: "current"},
+			{Version: "legacy", Prefix: "/", Status: "deprecated", Sunset: config.LegacyApiSunset},
+		},
+	})
+}
@@ -0,0 +1,138 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiVersioning(t *testing.T) {
+	previousSunset := config.LegacyApiSunset
+	config.LegacyApiSunset = "2027-06-30"
+	defer func() { config.LegacyApiSunset = previousSunset }()
+
+	var path, rawPath, requestPath string
+	record := func(w http.ResponseWriter, r *http.Request) {
+		path, rawPath, requestPath = r.URL.Path, r.URL.RawPath, auth.RequestPath(r)
+		w.WriteHeader(http.StatusOK)
+	}
+	r := chi.NewRouter()
+	r.Use(apiVersioning)
+	r.Get("/gobounties/{id}", record)
+	r.Get("/tribes/{uuid}/{name}", record)
+	r.Get("/", record)
+	r.Get("/lnurlp/{workspace}", record)
+	r.Get("/.well-known/lnurlp/{workspace}", record)
+	r.Post("/webhooks/github", record)
+	r.Get("/versions", getApiVersions)
+
+	serve := func(method string, target string) *httptest.ResponseRecorder {
+		path, rawPath, requestPath = "", "", ""
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(method, target, nil)
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Should test that /v1 paths are served by the routes of the api", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/v1/gobounties/12")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "/gobounties/12", path)
+		assert.Equal(t, "/v1/gobounties/12", requestPath)
+		assert.Equal(t, "v1", rr.Header().Get("API-Version"))
+		assert.Equal(t, "", rr.Header().Get("Deprecation"))
+		assert.Equal(t, "", rr.Header().Get("Sunset"))
+		assert.Equal(t, "", rr.Header().Get("Link"))
+	})
+
+	t.Run("Should test that /v1 alone is the root of the api", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/v1")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "/", path)
+		assert.Equal(t, "/v1", requestPath)
+	})
+
+	t.Run("Should test that the escaped path is rewritten with the path", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/v1/tribes/uuid/a%2Fb")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "/tribes/uuid/a/b", path)
+		assert.Equal(t, "/tribes/uuid/a%2Fb", rawPath)
+		assert.Equal(t, "/v1/tribes/uuid/a/b", requestPath)
+	})
+
+	t.Run("Should test that paths without a version are deprecated", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/gobounties/12")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "/gobounties/12", path)
+		assert.Equal(t, "/gobounties/12", requestPath)
+		assert.Equal(t, "v1", rr.Header().Get("API-Version"))
+		assert.Equal(t, "true", rr.Header().Get("Deprecation"))
+		assert.Equal(t, "Wed, 30 Jun 2027 00:00:00 GMT", rr.Header().Get("Sunset"))
+		assert.Equal(t, `</v1/gobounties/12>; rel="successor-version"`, rr.Header().Get("Link"))
+	})
+
+	t.Run("Should test that the Sunset header is left out when the date is not valid", func(t *testing.T) {
+		config.LegacyApiSunset = "next year"
+		defer func() { config.LegacyApiSunset = "2027-06-30" }()
+
+		rr := serve(http.MethodGet, "/gobounties/12")
+
+		assert.Equal(t, "true", rr.Header().Get("Deprecation"))
+		assert.Equal(t, "", rr.Header().Get("Sunset"))
+	})
+
+	t.Run("Should test that the unversioned prefixes are not deprecated", func(t *testing.T) {
+		for _, target := range []string{"/lnurlp/workspace-uuid", "/.well-known/lnurlp/workspace-uuid", "/versions"} {
+			rr := serve(http.MethodGet, target)
+
+			assert.Equal(t, http.StatusOK, rr.Code, target)
+			assert.Equal(t, "", rr.Header().Get("Deprecation"), target)
+			assert.Equal(t, "", rr.Header().Get("Link"), target)
+		}
+
+		rr := serve(http.MethodPost, "/webhooks/github")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "", rr.Header().Get("Deprecation"))
+	})
+
+	t.Run("Should test that versions that are not served answer not found", func(t *testing.T) {
+		for _, target := range []string{"/v2/gobounties/12", "/v2", "/v10/gobounties/12"} {
+			rr := serve(http.MethodGet, target)
+
+			assert.Equal(t, http.StatusNotFound, rr.Code, target)
+			assert.Equal(t, "", path, target)
+			assert.Equal(t, "", rr.Header().Get("Deprecation"), target)
+			assert.Equal(t, "", rr.Header().Get("Link"), target)
+		}
+	})
+
+	t.Run("Should test that a path only starting like a version is not rewritten", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/v1gobounties/12")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, "true", rr.Header().Get("Deprecation"))
+	})
+
+	t.Run("Should test that the versions of the api are listed", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/v1/versions")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		doc := apiVersionDoc{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+		assert.Equal(t, "v1", doc.Current)
+		assert.Equal(t, []apiVersionInfo{
+			{Version: "v1", Prefix: "/v1", Status: "current"},
+			{Version: "legacy", Prefix: "/", Status: "deprecated", Sunset: "2027-06-30"},
+		}, doc.Versions)
+	})
+}
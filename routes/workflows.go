@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+)
+
+func WorkflowRoutes() chi.Router {
+	r := chi.NewRouter()
+	workflowHandler := handlers.NewWorkflowHandler(http.DefaultClient, db.DB)
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+
+		r.Post("/", workflowHandler.CreateOrEditWorkflow)
+		r.Get("/workspace/{workspace_uuid}", workflowHandler.GetWorkspaceWorkflows)
+		r.Post("/{uuid}/run", workflowHandler.RunWorkflow)
+		r.Get("/{uuid}/executions", workflowHandler.GetWorkflowExecutions)
+		r.Post("/executions/{uuid}/retry", workflowHandler.RetryWorkflowExecution)
+	})
+	return r
+}
@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"net/http"
+
 	"github.com/go-chi/chi"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
@@ -10,10 +12,15 @@ import (
 func WorkspaceRoutes() chi.Router {
 	r := chi.NewRouter()
 	workspaceHandlers := handlers.NewWorkspaceHandler(db.DB)
+	lnurlPayHandler := handlers.NewLnurlPayHandler(http.DefaultClient, db.DB)
+	bountyHandler := handlers.NewBountyHandler(http.DefaultClient, db.DB)
+	ticketImportHandler := handlers.NewTicketImportHandler(http.DefaultClient, db.DB)
 	r.Group(func(r chi.Router) {
 		r.Get("/", handlers.GetWorkspaces)
 		r.Get("/count", handlers.GetWorkspacesCount)
 		r.Get("/{uuid}", handlers.GetWorkspaceByUuid)
+		r.Get("/{uuid}/lnurlp", lnurlPayHandler.GetWorkspaceLnurlPay)
+		r.Get("/{uuid}/calendar.ics", workspaceHandlers.GetWorkspaceCalendar)
 		r.Get("/users/{uuid}", handlers.GetWorkspaceUsers)
 		r.Get("/users/{uuid}/count", handlers.GetWorkspaceUsersCount)
 		r.Get("/bounties/{uuid}", workspaceHandlers.GetWorkspaceBounties)
@@ -49,8 +56,44 @@ func WorkspaceRoutes() chi.Router {
 		r.Get("/repositories/{uuid}", workspaceHandlers.GetWorkspaceRepositorByWorkspaceUuid)
 		// New route for to getting features for workspace uuid
 		r.Get("/{workspace_uuid}/features", workspaceHandlers.GetFeaturesByWorkspaceUuid)
+		r.Get("/{workspace_uuid}/ledger", workspaceHandlers.GetWorkspaceLedger)
+		r.Get("/{workspace_uuid}/budget/settings", workspaceHandlers.GetWorkspaceBudgetSettings)
+		r.Put("/{workspace_uuid}/budget/settings", workspaceHandlers.UpdateWorkspaceBudgetSettings)
+		r.Get("/{workspace_uuid}/ai_settings", workspaceHandlers.GetWorkspaceAiSettings)
+		r.Put("/{workspace_uuid}/ai_settings", workspaceHandlers.UpdateWorkspaceAiSettings)
+		r.Get("/{workspace_uuid}/calendar/token", workspaceHandlers.GetWorkspaceCalendarToken)
+		r.Post("/{workspace_uuid}/calendar/token", workspaceHandlers.RotateWorkspaceCalendarToken)
+		r.Get("/{workspace_uuid}/disputes", bountyHandler.GetWorkspaceDisputes)
+		r.Get("/{workspace_uuid}/time-report", bountyHandler.GetWorkspaceTimeReport)
+		r.Post("/{workspace_uuid}/bounties/import", bountyHandler.ImportWorkspaceBounties)
+		r.Post("/{workspace_uuid}/tickets/import/{source}", ticketImportHandler.ImportWorkspaceTickets)
+		r.Get("/{workspace_uuid}/bounties/export", bountyHandler.ExportWorkspaceBounties)
+		r.Get("/{workspace_uuid}/export", workspaceHandlers.ExportWorkspace)
+		r.Post("/import", workspaceHandlers.ImportWorkspace)
+		r.Post("/{workspace_uuid}/archive", workspaceHandlers.ArchiveWorkspace)
+		r.Post("/{workspace_uuid}/unarchive", workspaceHandlers.UnarchiveWorkspace)
+		r.Post("/{workspace_uuid}/transfers", workspaceHandlers.StartWorkspaceTransfer)
+		r.Get("/{workspace_uuid}/transfers", workspaceHandlers.GetWorkspaceTransfers)
+		r.Get("/{workspace_uuid}/audit", workspaceHandlers.GetWorkspaceAuditLog)
+		r.Get("/transfers/incoming", workspaceHandlers.GetIncomingWorkspaceTransfers)
+		r.Post("/transfers/{uuid}/accept", workspaceHandlers.AcceptWorkspaceTransfer)
+		r.Post("/transfers/{uuid}/decline", workspaceHandlers.DeclineWorkspaceTransfer)
+		r.Post("/transfers/{uuid}/cancel", workspaceHandlers.CancelWorkspaceTransfer)
+		r.Post("/{workspace_uuid}/integrations", workspaceHandlers.CreateOrEditWorkspaceIntegration)
+		r.Get("/{workspace_uuid}/integrations", workspaceHandlers.GetWorkspaceIntegrations)
+		r.Delete("/{workspace_uuid}/integrations/{uuid}", workspaceHandlers.DeleteWorkspaceIntegration)
+		r.Post("/{workspace_uuid}/integrations/{uuid}/test", workspaceHandlers.TestWorkspaceIntegration)
+		r.Get("/{workspace_uuid}/integrations/{uuid}/deliveries", workspaceHandlers.GetWorkspaceIntegrationDeliveries)
+		r.Post("/{workspace_uuid}/templates", workspaceHandlers.SaveWorkspaceTemplate)
+		r.Get("/templates", workspaceHandlers.GetWorkspaceTemplates)
+		r.Get("/templates/{uuid}", workspaceHandlers.GetWorkspaceTemplate)
+		r.Put("/templates/{uuid}", workspaceHandlers.UpdateWorkspaceTemplate)
+		r.Delete("/templates/{uuid}", workspaceHandlers.DeleteWorkspaceTemplate)
+		r.Post("/templates/{uuid}/workspaces", workspaceHandlers.CreateWorkspaceFromTemplate)
 		r.Get("/{workspace_uuid}/repository/{uuid}", workspaceHandlers.GetWorkspaceRepoByWorkspaceUuidAndRepoUuid)
 		r.Delete("/{workspace_uuid}/repository/{uuid}", workspaceHandlers.DeleteWorkspaceRepository)
+		r.Get("/{workspace_uuid}/repos/{uuid}/activity", workspaceHandlers.GetRepositoryActivity)
+		r.Post("/{workspace_uuid}/repos/{uuid}/sync", workspaceHandlers.SyncRepository)
 	})
 	return r
 }
@@ -0,0 +1,363 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: tribes.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Tribes_GetTribe_FullMethodName            = "/tribes.v1.Tribes/GetTribe"
+	Tribes_ListTribesByOwner_FullMethodName   = "/tribes.v1.Tribes/ListTribesByOwner"
+	Tribes_GetPerson_FullMethodName           = "/tribes.v1.Tribes/GetPerson"
+	Tribes_GetBounty_FullMethodName           = "/tribes.v1.Tribes/GetBounty"
+	Tribes_GetBountyPayment_FullMethodName    = "/tribes.v1.Tribes/GetBountyPayment"
+	Tribes_ListPaymentAttempts_FullMethodName = "/tribes.v1.Tribes/ListPaymentAttempts"
+	Tribes_StreamPaymentStatus_FullMethodName = "/tribes.v1.Tribes/StreamPaymentStatus"
+)
+
+// TribesClient is the client API for Tribes service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TribesClient interface {
+	GetTribe(ctx context.Context, in *GetTribeRequest, opts ...grpc.CallOption) (*Tribe, error)
+	ListTribesByOwner(ctx context.Context, in *ListTribesByOwnerRequest, opts ...grpc.CallOption) (*ListTribesResponse, error)
+	GetPerson(ctx context.Context, in *GetPersonRequest, opts ...grpc.CallOption) (*Person, error)
+	GetBounty(ctx context.Context, in *GetBountyRequest, opts ...grpc.CallOption) (*Bounty, error)
+	// GetBountyPayment returns the payment of a paid bounty
+	GetBountyPayment(ctx context.Context, in *GetBountyPaymentRequest, opts ...grpc.CallOption) (*Payment, error)
+	ListPaymentAttempts(ctx context.Context, in *ListPaymentAttemptsRequest, opts ...grpc.CallOption) (*ListPaymentAttemptsResponse, error)
+	// StreamPaymentStatus sends each bounty payment attempt as it is made, until the caller leaves
+	StreamPaymentStatus(ctx context.Context, in *StreamPaymentStatusRequest, opts ...grpc.CallOption) (Tribes_StreamPaymentStatusClient, error)
+}
+
+type tribesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTribesClient(cc grpc.ClientConnInterface) TribesClient {
+	return &tribesClient{cc}
+}
+
+func (c *tribesClient) GetTribe(ctx context.Context, in *GetTribeRequest, opts ...grpc.CallOption) (*Tribe, error) {
+	out := new(Tribe)
+	err := c.cc.Invoke(ctx, Tribes_GetTribe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tribesClient) ListTribesByOwner(ctx context.Context, in *ListTribesByOwnerRequest, opts ...grpc.CallOption) (*ListTribesResponse, error) {
+	out := new(ListTribesResponse)
+	err := c.cc.Invoke(ctx, Tribes_ListTribesByOwner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tribesClient) GetPerson(ctx context.Context, in *GetPersonRequest, opts ...grpc.CallOption) (*Person, error) {
+	out := new(Person)
+	err := c.cc.Invoke(ctx, Tribes_GetPerson_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tribesClient) GetBounty(ctx context.Context, in *GetBountyRequest, opts ...grpc.CallOption) (*Bounty, error) {
+	out := new(Bounty)
+	err := c.cc.Invoke(ctx, Tribes_GetBounty_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tribesClient) GetBountyPayment(ctx context.Context, in *GetBountyPaymentRequest, opts ...grpc.CallOption) (*Payment, error) {
+	out := new(Payment)
+	err := c.cc.Invoke(ctx, Tribes_GetBountyPayment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tribesClient) ListPaymentAttempts(ctx context.Context, in *ListPaymentAttemptsRequest, opts ...grpc.CallOption) (*ListPaymentAttemptsResponse, error) {
+	out := new(ListPaymentAttemptsResponse)
+	err := c.cc.Invoke(ctx, Tribes_ListPaymentAttempts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tribesClient) StreamPaymentStatus(ctx context.Context, in *StreamPaymentStatusRequest, opts ...grpc.CallOption) (Tribes_StreamPaymentStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Tribes_ServiceDesc.Streams[0], Tribes_StreamPaymentStatus_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tribesStreamPaymentStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Tribes_StreamPaymentStatusClient interface {
+	Recv() (*PaymentStatus, error)
+	grpc.ClientStream
+}
+
+type tribesStreamPaymentStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *tribesStreamPaymentStatusClient) Recv() (*PaymentStatus, error) {
+	m := new(PaymentStatus)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TribesServer is the server API for Tribes service.
+// All implementations must embed UnimplementedTribesServer
+// for forward compatibility
+type TribesServer interface {
+	GetTribe(context.Context, *GetTribeRequest) (*Tribe, error)
+	ListTribesByOwner(context.Context, *ListTribesByOwnerRequest) (*ListTribesResponse, error)
+	GetPerson(context.Context, *GetPersonRequest) (*Person, error)
+	GetBounty(context.Context, *GetBountyRequest) (*Bounty, error)
+	// GetBountyPayment returns the payment of a paid bounty
+	GetBountyPayment(context.Context, *GetBountyPaymentRequest) (*Payment, error)
+	ListPaymentAttempts(context.Context, *ListPaymentAttemptsRequest) (*ListPaymentAttemptsResponse, error)
+	// StreamPaymentStatus sends each bounty payment attempt as it is made, until the caller leaves
+	StreamPaymentStatus(*StreamPaymentStatusRequest, Tribes_StreamPaymentStatusServer) error
+	mustEmbedUnimplementedTribesServer()
+}
+
+// UnimplementedTribesServer must be embedded to have forward compatible implementations.
+type UnimplementedTribesServer struct {
+}
+
+func (UnimplementedTribesServer) GetTribe(context.Context, *GetTribeRequest) (*Tribe, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTribe not implemented")
+}
+func (UnimplementedTribesServer) ListTribesByOwner(context.Context, *ListTribesByOwnerRequest) (*ListTribesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTribesByOwner not implemented")
+}
+func (UnimplementedTribesServer) GetPerson(context.Context, *GetPersonRequest) (*Person, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPerson not implemented")
+}
+func (UnimplementedTribesServer) GetBounty(context.Context, *GetBountyRequest) (*Bounty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBounty not implemented")
+}
+func (UnimplementedTribesServer) GetBountyPayment(context.Context, *GetBountyPaymentRequest) (*Payment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBountyPayment not implemented")
+}
+func (UnimplementedTribesServer) ListPaymentAttempts(context.Context, *ListPaymentAttemptsRequest) (*ListPaymentAttemptsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPaymentAttempts not implemented")
+}
+func (UnimplementedTribesServer) StreamPaymentStatus(*StreamPaymentStatusRequest, Tribes_StreamPaymentStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPaymentStatus not implemented")
+}
+func (UnimplementedTribesServer) mustEmbedUnimplementedTribesServer() {}
+
+// UnsafeTribesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TribesServer will
+// result in compilation errors.
+type UnsafeTribesServer interface {
+	mustEmbedUnimplementedTribesServer()
+}
+
+func RegisterTribesServer(s grpc.ServiceRegistrar, srv TribesServer) {
+	s.RegisterService(&Tribes_ServiceDesc, srv)
+}
+
+func _Tribes_GetTribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TribesServer).GetTribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tribes_GetTribe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TribesServer).GetTribe(ctx, req.(*GetTribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tribes_ListTribesByOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTribesByOwnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TribesServer).ListTribesByOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tribes_ListTribesByOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TribesServer).ListTribesByOwner(ctx, req.(*ListTribesByOwnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tribes_GetPerson_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPersonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TribesServer).GetPerson(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tribes_GetPerson_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TribesServer).GetPerson(ctx, req.(*GetPersonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tribes_GetBounty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBountyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TribesServer).GetBounty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tribes_GetBounty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TribesServer).GetBounty(ctx, req.(*GetBountyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tribes_GetBountyPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBountyPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TribesServer).GetBountyPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tribes_GetBountyPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TribesServer).GetBountyPayment(ctx, req.(*GetBountyPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tribes_ListPaymentAttempts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPaymentAttemptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TribesServer).ListPaymentAttempts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tribes_ListPaymentAttempts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TribesServer).ListPaymentAttempts(ctx, req.(*ListPaymentAttemptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tribes_StreamPaymentStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPaymentStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TribesServer).StreamPaymentStatus(m, &tribesStreamPaymentStatusServer{stream})
+}
+
+type Tribes_StreamPaymentStatusServer interface {
+	Send(*PaymentStatus) error
+	grpc.ServerStream
+}
+
+type tribesStreamPaymentStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *tribesStreamPaymentStatusServer) Send(m *PaymentStatus) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Tribes_ServiceDesc is the grpc.ServiceDesc for Tribes service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Tribes_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tribes.v1.Tribes",
+	HandlerType: (*TribesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTribe",
+			Handler:    _Tribes_GetTribe_Handler,
+		},
+		{
+			MethodName: "ListTribesByOwner",
+			Handler:    _Tribes_ListTribesByOwner_Handler,
+		},
+		{
+			MethodName: "GetPerson",
+			Handler:    _Tribes_GetPerson_Handler,
+		},
+		{
+			MethodName: "GetBounty",
+			Handler:    _Tribes_GetBounty_Handler,
+		},
+		{
+			MethodName: "GetBountyPayment",
+			Handler:    _Tribes_GetBountyPayment_Handler,
+		},
+		{
+			MethodName: "ListPaymentAttempts",
+			Handler:    _Tribes_ListPaymentAttempts_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPaymentStatus",
+			Handler:       _Tribes_StreamPaymentStatus_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tribes.proto",
+}
@@ -0,0 +1,271 @@
+// Package rpc serves the gRPC api internal services such as the relay use to read tribes, people,
+// bounties and payments. It reads through the same database layer as the REST handlers
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type server struct {
+	pb.UnimplementedTribesServer
+	db      db.Database
+	streams *paymentStreams
+}
+
+func NewServer(database db.Database) *server {
+	return &server{
+		db:      database,
+		streams: newPaymentStreams(),
+	}
+}
+
+// Serve answers the gRPC calls on a port until the listener fails, the payment attempts published
+// on the bus are sent to the payment status streams
+func Serve(database db.Database, port string, token string) error {
+	if token == "" {
+		return errors.New("GRPC_TOKEN is required to serve gRPC")
+	}
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+
+	s := NewServer(database)
+	events.Subscribe(events.PaymentAttempted, s.streams.publish)
+	fmt.Println("[grpc] listening on", port)
+	return s.grpcServer(token).Serve(listener)
+}
+
+func (s *server) grpcServer(token string) *grpc.Server {
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := authorize(ctx, token); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := authorize(stream.Context(), token); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	)
+	pb.RegisterTribesServer(grpcServer, s)
+	return grpcServer
+}
+
+// authorize checks the token of a call, sent as the authorization metadata with or without Bearer
+func authorize(ctx context.Context, token string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		value = strings.TrimPrefix(value, "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(value), []byte(token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid token")
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func (s *server) GetTribe(ctx context.Context, req *pb.GetTribeRequest) (*pb.Tribe, error) {
+	tribe := s.db.GetTribe(req.GetUuid())
+	if tribe.UUID == "" || tribe.Deleted {
+		return nil, status.Error(codes.NotFound, "tribe not found")
+	}
+	return tribeMessage(tribe), nil
+}
+
+func (s *server) ListTribesByOwner(ctx context.Context, req *pb.ListTribesByOwnerRequest) (*pb.ListTribesResponse, error) {
+	if req.GetOwnerPubkey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "owner_pubkey is required")
+	}
+	response := &pb.ListTribesResponse{}
+	for _, tribe := range s.db.GetTribesByOwner(req.GetOwnerPubkey()) {
+		response.Tribes = append(response.Tribes, tribeMessage(tribe))
+	}
+	return response, nil
+}
+
+func (s *server) GetPerson(ctx context.Context, req *pb.GetPersonRequest) (*pb.Person, error) {
+	person := s.db.GetPersonByPubkey(req.GetPubkey())
+	if person.ID == 0 || person.Deleted {
+		return nil, status.Error(codes.NotFound, "person not found")
+	}
+	// internal services see the public profile
+	return personMessage(db.ApplyPersonPrivacy(person, db.AudiencePublic)), nil
+}
+
+func (s *server) GetBounty(ctx context.Context, req *pb.GetBountyRequest) (*pb.Bounty, error) {
+	bounty := s.db.GetBounty(uint(req.GetId()))
+	if bounty.ID == 0 {
+		return nil, status.Error(codes.NotFound, "bounty not found")
+	}
+	if bounty.WorkspaceUuid == "" && bounty.OrgUuid != "" {
+		bounty.WorkspaceUuid = bounty.OrgUuid
+	}
+	return bountyMessage(bounty), nil
+}
+
+func (s *server) GetBountyPayment(ctx context.Context, req *pb.GetBountyPaymentRequest) (*pb.Payment, error) {
+	payment := s.db.GetBountyPayment(uint(req.GetBountyId()))
+	if payment.ID == 0 {
+		return nil, status.Error(codes.NotFound, "the bounty has no payment")
+	}
+	return paymentMessage(payment), nil
+}
+
+func (s *server) ListPaymentAttempts(ctx context.Context, req *pb.ListPaymentAttemptsRequest) (*pb.ListPaymentAttemptsResponse, error) {
+	response := &pb.ListPaymentAttemptsResponse{}
+	for _, attempt := range s.db.GetPaymentAttempts(uint(req.GetBountyId())) {
+		response.Attempts = append(response.Attempts, paymentStatusMessage(attempt))
+	}
+	return response, nil
+}
+
+func (s *server) StreamPaymentStatus(req *pb.StreamPaymentStatusRequest, stream pb.Tribes_StreamPaymentStatusServer) error {
+	statuses, unsubscribe := s.streams.subscribe(req)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case paymentStatus, ok := <-statuses:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "the stream fell behind the payments")
+			}
+			if err := stream.Send(paymentStatus); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func tribeMessage(tribe db.Tribe) *pb.Tribe {
+	return &pb.Tribe{
+		Uuid:            tribe.UUID,
+		OwnerPubkey:     tribe.OwnerPubKey,
+		OwnerAlias:      tribe.OwnerAlias,
+		OwnerRouteHint:  tribe.OwnerRouteHint,
+		GroupKey:        tribe.GroupKey,
+		Name:            tribe.Name,
+		UniqueName:      tribe.UniqueName,
+		Description:     tribe.Description,
+		Tags:            tribe.Tags,
+		Img:             tribe.Img,
+		PriceToJoin:     tribe.PriceToJoin,
+		PricePerMessage: tribe.PricePerMessage,
+		EscrowAmount:    tribe.EscrowAmount,
+		EscrowMillis:    tribe.EscrowMillis,
+		MemberCount:     tribe.MemberCount,
+		Unlisted:        tribe.Unlisted,
+		Private:         tribe.Private,
+		AppUrl:          tribe.AppURL,
+		FeedUrl:         tribe.FeedURL,
+		FeedType:        tribe.FeedType,
+		LastActive:      tribe.LastActive,
+		Created:         timestamp(tribe.Created),
+		Updated:         timestamp(tribe.Updated),
+	}
+}
+
+func personMessage(person db.Person) *pb.Person {
+	return &pb.Person{
+		Id:              uint64(person.ID),
+		Uuid:            person.Uuid,
+		OwnerPubkey:     person.OwnerPubKey,
+		OwnerAlias:      person.OwnerAlias,
+		OwnerRouteHint:  person.OwnerRouteHint,
+		OwnerContactKey: person.OwnerContactKey,
+		UniqueName:      person.UniqueName,
+		Description:     person.Description,
+		Tags:            person.Tags,
+		Img:             person.Img,
+		PriceToMeet:     person.PriceToMeet,
+		LastLogin:       person.LastLogin,
+		Created:         timestamp(person.Created),
+		Updated:         timestamp(person.Updated),
+	}
+}
+
+func bountyMessage(bounty db.NewBounty) *pb.Bounty {
+	created := time.Unix(bounty.Created, 0)
+	return &pb.Bounty{
+		Id:              uint64(bounty.ID),
+		OwnerPubkey:     bounty.OwnerID,
+		Assignee:        bounty.Assignee,
+		WorkspaceUuid:   bounty.WorkspaceUuid,
+		Tribe:           bounty.Tribe,
+		Title:           bounty.Title,
+		Description:     bounty.Description,
+		Type:            bounty.Type,
+		TicketUrl:       bounty.TicketUrl,
+		Price:           uint64(bounty.Price),
+		Show:            bounty.Show,
+		Completed:       bounty.Completed,
+		Paid:            bounty.Paid,
+		CodingLanguages: bounty.CodingLanguages,
+		PhaseUuid:       bounty.PhaseUuid,
+		TicketId:        bounty.TicketId,
+		ProofStatus:     string(bounty.ProofStatus),
+		EscrowStatus:    string(bounty.EscrowStatus),
+		Created:         timestamp(&created),
+		CompletionDate:  timestamp(bounty.CompletionDate),
+		PaidDate:        timestamp(bounty.PaidDate),
+	}
+}
+
+func paymentMessage(payment db.NewPaymentHistory) *pb.Payment {
+	return &pb.Payment{
+		Id:             uint64(payment.ID),
+		BountyId:       uint64(payment.BountyId),
+		WorkspaceUuid:  payment.WorkspaceUuid,
+		PaymentType:    string(payment.PaymentType),
+		SenderPubkey:   payment.SenderPubKey,
+		ReceiverPubkey: payment.ReceiverPubKey,
+		Amount:         uint64(payment.Amount),
+		Succeeded:      payment.Status,
+		PaymentHash:    payment.PaymentHash,
+		Created:        timestamp(payment.Created),
+		Updated:        timestamp(payment.Updated),
+	}
+}
+
+func paymentStatusMessage(attempt db.PaymentAttempt) *pb.PaymentStatus {
+	return &pb.PaymentStatus{
+		AttemptId:      uint64(attempt.ID),
+		BountyId:       uint64(attempt.BountyId),
+		WorkspaceUuid:  attempt.WorkspaceUuid,
+		SenderPubkey:   attempt.SenderPubKey,
+		ReceiverPubkey: attempt.ReceiverPubKey,
+		Amount:         uint64(attempt.Amount),
+		Attempt:        int32(attempt.Attempt),
+		Status:         string(attempt.Status),
+		FailureKind:    attempt.FailureKind,
+		Error:          attempt.Error,
+		NextRetry:      timestamp(attempt.NextRetry),
+		Created:        timestamp(attempt.Created),
+	}
+}
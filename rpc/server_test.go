@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestServer(t *testing.T) {
+	start := func(t *testing.T) (*server, *mocks.Database, pb.TribesClient) {
+		mockDb := mocks.NewDatabase(t)
+		s := NewServer(mockDb)
+		listener := bufconn.Listen(1 << 20)
+		grpcServer := s.grpcServer("secret")
+		go grpcServer.Serve(listener)
+		t.Cleanup(grpcServer.Stop)
+
+		conn, err := grpc.DialContext(context.Background(), "bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return s, mockDb, pb.NewTribesClient(conn)
+	}
+	authorized := func() context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer secret")
+	}
+
+	t.Run("Should test that calls without the token are refused", func(t *testing.T) {
+		_, _, client := start(t)
+
+		_, err := client.GetBounty(context.Background(), &pb.GetBountyRequest{Id: 1})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "wrong")
+		_, err = client.GetBounty(ctx, &pb.GetBountyRequest{Id: 1})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Should test that a bounty and the public profile of a person are read", func(t *testing.T) {
+		_, mockDb, client := start(t)
+		mockDb.On("GetBounty", uint(12)).Return(db.NewBounty{ID: 12, Title: "Login", OrgUuid: "ws", Price: 1000, Created: 1700000000}).Once()
+		mockDb.On("GetBounty", uint(13)).Return(db.NewBounty{}).Once()
+		mockDb.On("GetPersonByPubkey", "ada").Return(db.Person{ID: 1, OwnerPubKey: "ada", Description: "hidden", Privacy: db.PersonPrivacy{db.PrivacyFieldDescription: db.PrivacyPrivate}}).Once()
+
+		bounty, err := client.GetBounty(authorized(), &pb.GetBountyRequest{Id: 12})
+		assert.NoError(t, err)
+		assert.Equal(t, "Login", bounty.Title)
+		assert.Equal(t, "ws", bounty.WorkspaceUuid)
+		assert.Equal(t, uint64(1000), bounty.Price)
+		assert.Equal(t, int64(1700000000), bounty.Created.GetSeconds())
+
+		_, err = client.GetBounty(authorized(), &pb.GetBountyRequest{Id: 13})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		person, err := client.GetPerson(authorized(), &pb.GetPersonRequest{Pubkey: "ada"})
+		assert.NoError(t, err)
+		assert.Equal(t, "ada", person.OwnerPubkey)
+		assert.Equal(t, "", person.Description)
+	})
+
+	t.Run("Should test that the payment attempts of a workspace are streamed", func(t *testing.T) {
+		s, _, client := start(t)
+		ctx, cancel := context.WithCancel(authorized())
+		defer cancel()
+
+		stream, err := client.StreamPaymentStatus(ctx, &pb.StreamPaymentStatusRequest{WorkspaceUuid: "ws"})
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool { return s.streams.count() == 1 }, time.Second, 10*time.Millisecond)
+
+		next := time.Now().Add(time.Minute)
+		s.streams.publish(events.Event{Name: events.PaymentAttempted, WorkspaceUuid: "other", Payload: map[string]interface{}{
+			"bounty_id": uint(1), "workspace_uuid": "other", "status": "succeeded",
+		}})
+		s.streams.publish(events.Event{Name: events.PaymentAttempted, WorkspaceUuid: "ws", Payload: map[string]interface{}{
+			"payment_attempt_id": uint(7), "bounty_id": uint(12), "workspace_uuid": "ws", "amount": uint(1000),
+			"attempt": 2, "status": "retrying", "failure_kind": "transient", "next_retry": &next,
+		}})
+
+		paymentStatus, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(12), paymentStatus.BountyId)
+		assert.Equal(t, int32(2), paymentStatus.Attempt)
+		assert.Equal(t, "retrying", paymentStatus.Status)
+		assert.Equal(t, next.Unix(), paymentStatus.NextRetry.GetSeconds())
+
+		cancel()
+		assert.Eventually(t, func() bool { return s.streams.count() == 0 }, time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/events"
+	"github.com/stakwork/sphinx-tribes/rpc/pb"
+)
+
+// a stream that has this many statuses waiting is too slow to keep up, it is closed
+const paymentStreamBuffer = 64
+
+// paymentStreams hands the payment attempts published on the bus to the open payment status streams
+type paymentStreams struct {
+	mu      sync.Mutex
+	streams map[chan *pb.PaymentStatus]*pb.StreamPaymentStatusRequest
+}
+
+func newPaymentStreams() *paymentStreams {
+	return &paymentStreams{streams: map[chan *pb.PaymentStatus]*pb.StreamPaymentStatusRequest{}}
+}
+
+// subscribe opens a stream of the statuses matching a request, until the returned func is called
+func (ps *paymentStreams) subscribe(req *pb.StreamPaymentStatusRequest) (chan *pb.PaymentStatus, func()) {
+	statuses := make(chan *pb.PaymentStatus, paymentStreamBuffer)
+	ps.mu.Lock()
+	ps.streams[statuses] = req
+	ps.mu.Unlock()
+
+	return statuses, func() {
+		ps.mu.Lock()
+		defer ps.mu.Unlock()
+		if _, ok := ps.streams[statuses]; ok {
+			delete(ps.streams, statuses)
+			close(statuses)
+		}
+	}
+}
+
+func (ps *paymentStreams) count() int {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return len(ps.streams)
+}
+
+// publish sends a payment attempt event to the streams it matches, without waiting on slow ones
+func (ps *paymentStreams) publish(event events.Event) {
+	if event.Name != events.PaymentAttempted {
+		return
+	}
+	paymentStatus := paymentStatusMessage(paymentAttemptFromPayload(event.Payload))
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	for statuses, req := range ps.streams {
+		if req.GetWorkspaceUuid() != "" && req.GetWorkspaceUuid() != paymentStatus.WorkspaceUuid {
+			continue
+		}
+		if req.GetBountyId() != 0 && req.GetBountyId() != paymentStatus.BountyId {
+			continue
+		}
+		select {
+		case statuses <- paymentStatus:
+		default:
+			delete(ps.streams, statuses)
+			close(statuses)
+		}
+	}
+}
+
+// paymentAttemptFromPayload reads back the attempt the handlers put in the payload of the event
+func paymentAttemptFromPayload(payload map[string]interface{}) db.PaymentAttempt {
+	str := func(key string) string {
+		value, _ := payload[key].(string)
+		return value
+	}
+	number := func(key string) uint64 {
+		switch value := payload[key].(type) {
+		case uint:
+			return uint64(value)
+		case int:
+			return uint64(value)
+		case uint64:
+			return value
+		case float64:
+			return uint64(value)
+		}
+		return 0
+	}
+	date := func(key string) *time.Time {
+		value, _ := payload[key].(*time.Time)
+		return value
+	}
+
+	return db.PaymentAttempt{
+		ID:             uint(number("payment_attempt_id")),
+		BountyId:       uint(number("bounty_id")),
+		WorkspaceUuid:  str("workspace_uuid"),
+		SenderPubKey:   str("sender_pubkey"),
+		ReceiverPubKey: str("receiver_pubkey"),
+		Amount:         uint(number("amount")),
+		Attempt:        int(number("attempt")),
+		Status:         db.PaymentAttemptStatus(str("status")),
+		FailureKind:    str("failure_kind"),
+		Error:          str("error"),
+		NextRetry:      date("next_retry"),
+		Created:        date("created"),
+	}
+}
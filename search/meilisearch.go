@@ -0,0 +1,182 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const reindexBatchSize = 1000
+
+var errIndexNotFound = errors.New("meilisearch returned 404, the index does not exist")
+
+// meilisearchIndexes are the index uids the entities of each type are kept in
+var meilisearchIndexes = map[string]string{
+	db.SearchTypeTribe:     "tribes",
+	db.SearchTypePerson:    "people",
+	db.SearchTypeBot:       "bots",
+	db.SearchTypeBounty:    "bounties",
+	db.SearchTypeWorkspace: "workspaces",
+}
+
+type meilisearchEngine struct {
+	httpClient HttpClient
+	url        string
+	key        string
+}
+
+// NewMeilisearchEngine searches a meilisearch instance, authenticated with an api key when one is set
+func NewMeilisearchEngine(httpClient HttpClient, url string, key string) Engine {
+	return &meilisearchEngine{
+		httpClient: httpClient,
+		url:        strings.TrimSuffix(url, "/"),
+		key:        key,
+	}
+}
+
+func (me *meilisearchEngine) request(method string, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, me.url+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if me.key != "" {
+		req.Header.Set("Authorization", "Bearer "+me.key)
+	}
+
+	res, err := me.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return errIndexNotFound
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("meilisearch returned %d: %s", res.StatusCode, strings.TrimSpace(string(resBody)))
+	}
+	if out != nil {
+		return json.Unmarshal(resBody, out)
+	}
+	return nil
+}
+
+type meilisearchHit struct {
+	db.SearchResult
+	RankingScore float64 `json:"_rankingScore"`
+}
+
+func (me *meilisearchEngine) Search(query string, types []string, limit int) (map[string][]db.SearchResult, error) {
+	queries := []map[string]interface{}{}
+	for _, t := range types {
+		queries = append(queries, map[string]interface{}{
+			"indexUid":         meilisearchIndexes[t],
+			"q":                query,
+			"limit":            limit,
+			"showRankingScore": true,
+		})
+	}
+
+	res := struct {
+		Results []struct {
+			Hits []meilisearchHit `json:"hits"`
+		} `json:"results"`
+	}{}
+	if err := me.request(http.MethodPost, "/multi-search", map[string]interface{}{"queries": queries}, &res); err != nil {
+		return nil, err
+	}
+
+	// the results come back in the order of the queries
+	results := map[string][]db.SearchResult{}
+	for i, t := range types {
+		results[t] = []db.SearchResult{}
+		if i >= len(res.Results) {
+			continue
+		}
+		for _, hit := range res.Results[i].Hits {
+			result := hit.SearchResult
+			result.Type = t
+			result.Score = hit.RankingScore
+			results[t] = append(results[t], result)
+		}
+	}
+	return results, nil
+}
+
+// Reindex replaces the documents of every index with the listed entities of the database,
+// meilisearch runs the tasks of an index in order so the deletion is done before the new documents land
+func (me *meilisearchEngine) Reindex(database db.Database) error {
+	for _, t := range db.SearchTypes {
+		index := meilisearchIndexes[t]
+		if err := me.request(http.MethodDelete, "/indexes/"+index+"/documents", nil, nil); err != nil {
+			return err
+		}
+
+		for offset := 0; ; offset += reindexBatchSize {
+			documents := database.GetSearchDocuments(t, offset, reindexBatchSize)
+			if len(documents) == 0 {
+				break
+			}
+			if err := me.Upsert(t, documents); err != nil {
+				return err
+			}
+			if len(documents) < reindexBatchSize {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (me *meilisearchEngine) Upsert(entityType string, documents []db.SearchResult) error {
+	return me.request(http.MethodPost, "/indexes/"+meilisearchIndexes[entityType]+"/documents?primaryKey=id", documents, nil)
+}
+
+func (me *meilisearchEngine) Remove(entityType string, ids []string) error {
+	return me.request(http.MethodPost, "/indexes/"+meilisearchIndexes[entityType]+"/documents/delete-batch", ids, nil)
+}
+
+// Health asks meilisearch if it is available, then the document count of every index, an index
+// that was never fed counts no documents
+func (me *meilisearchEngine) Health() (IndexHealth, error) {
+	health := IndexHealth{Documents: map[string]int64{}}
+
+	status := struct {
+		Status string `json:"status"`
+	}{}
+	if err := me.request(http.MethodGet, "/health", nil, &status); err != nil {
+		return health, err
+	}
+	health.Available = status.Status == "available"
+
+	for _, t := range db.SearchTypes {
+		stats := struct {
+			NumberOfDocuments int64 `json:"numberOfDocuments"`
+			IsIndexing        bool  `json:"isIndexing"`
+		}{}
+		err := me.request(http.MethodGet, "/indexes/"+meilisearchIndexes[t]+"/stats", nil, &stats)
+		if err != nil && !errors.Is(err, errIndexNotFound) {
+			return health, err
+		}
+		health.Documents[t] = stats.NumberOfDocuments
+		health.Indexing = health.Indexing || stats.IsIndexing
+	}
+	return health, nil
+}
@@ -0,0 +1,117 @@
+package search
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const (
+	BackendPostgres    = "postgres"
+	BackendMeilisearch = "meilisearch"
+)
+
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Engine finds the entities of the unified search
+type Engine interface {
+	// Search returns the best matches of each type, at most limit per type
+	Search(query string, types []string, limit int) (map[string][]db.SearchResult, error)
+}
+
+// Indexer is implemented by engines keeping their own index, which has to be fed the entities
+type Indexer interface {
+	// Reindex replaces the whole index with the listed entities of the database
+	Reindex(database db.Database) error
+	// Upsert adds or replaces documents of a type
+	Upsert(entityType string, documents []db.SearchResult) error
+	// Remove deletes documents of a type by id
+	Remove(entityType string, ids []string) error
+	// Health reports whether the index is reachable and how many documents each type has
+	Health() (IndexHealth, error)
+}
+
+type IndexHealth struct {
+	Available bool             `json:"available"`
+	Documents map[string]int64 `json:"documents"`
+	Indexing  bool             `json:"indexing"`
+}
+
+// Group is the results of one type
+type Group struct {
+	Type    string            `json:"type"`
+	Results []db.SearchResult `json:"results"`
+}
+
+// NewEngine returns the engine selected with SEARCH_BACKEND, postgres full text search is the default
+func NewEngine(httpClient HttpClient, database db.Database) Engine {
+	switch strings.ToLower(config.SearchBackend) {
+	case BackendMeilisearch:
+		return NewMeilisearchEngine(httpClient, config.MeilisearchUrl, config.MeilisearchKey)
+	default:
+		return NewPostgresEngine(database)
+	}
+}
+
+// IndexingEnabled tells if the selected backend keeps an index the entity changes have to be queued for
+func IndexingEnabled() bool {
+	return strings.EqualFold(config.SearchBackend, BackendMeilisearch)
+}
+
+// ParseTypes reads a comma separated list of types, all of them when it is empty, nil if one is unknown
+func ParseTypes(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return db.SearchTypes
+	}
+
+	requested := map[string]bool{}
+	for _, t := range strings.Split(value, ",") {
+		requested[strings.TrimSpace(t)] = true
+	}
+
+	types := []string{}
+	for _, t := range db.SearchTypes {
+		if requested[t] {
+			types = append(types, t)
+			delete(requested, t)
+		}
+	}
+	if len(requested) > 0 {
+		return nil
+	}
+	return types
+}
+
+// Grouped orders the results of each type like the types, with an empty group for a type without matches
+func Grouped(types []string, results map[string][]db.SearchResult) []Group {
+	groups := []Group{}
+	for _, t := range types {
+		group := Group{Type: t, Results: results[t]}
+		if group.Results == nil {
+			group.Results = []db.SearchResult{}
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+type postgresEngine struct {
+	db db.Database
+}
+
+// NewPostgresEngine searches the tsvectors of the database
+func NewPostgresEngine(database db.Database) Engine {
+	return &postgresEngine{db: database}
+}
+
+func (pe *postgresEngine) Search(query string, types []string, limit int) (map[string][]db.SearchResult, error) {
+	results := map[string][]db.SearchResult{}
+	for _, t := range types {
+		results[t] = pe.db.SearchEntities(query, t, limit)
+	}
+	return results, nil
+}
@@ -0,0 +1,189 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewEngine(t *testing.T) {
+	defer func() { config.SearchBackend = "" }()
+
+	tests := map[string]interface{}{
+		"":            &postgresEngine{},
+		"postgres":    &postgresEngine{},
+		"Meilisearch": &meilisearchEngine{},
+	}
+	for name, expected := range tests {
+		config.SearchBackend = name
+		assert.IsType(t, expected, NewEngine(http.DefaultClient, nil), name)
+		assert.Equal(t, name == "Meilisearch", IndexingEnabled(), name)
+	}
+}
+
+func TestParseTypes(t *testing.T) {
+	assert.Equal(t, db.SearchTypes, ParseTypes(""))
+	assert.Equal(t, []string{db.SearchTypeTribe, db.SearchTypeBounty}, ParseTypes("bounty, tribe"))
+	assert.Nil(t, ParseTypes("bounty,podcast"))
+}
+
+func TestGrouped(t *testing.T) {
+	groups := Grouped([]string{db.SearchTypeBounty, db.SearchTypeTribe}, map[string][]db.SearchResult{
+		db.SearchTypeTribe: {{Type: db.SearchTypeTribe, ID: "tribe-uuid"}},
+	})
+
+	assert.Equal(t, []Group{
+		{Type: db.SearchTypeBounty, Results: []db.SearchResult{}},
+		{Type: db.SearchTypeTribe, Results: []db.SearchResult{{Type: db.SearchTypeTribe, ID: "tribe-uuid"}}},
+	}, groups)
+}
+
+func TestPostgresEngine(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	mockDb.On("SearchEntities", "lightning", db.SearchTypeBounty, 5).Return([]db.SearchResult{{Type: db.SearchTypeBounty, ID: "1", Score: 0.5}})
+	mockDb.On("SearchEntities", "lightning", db.SearchTypePerson, 5).Return([]db.SearchResult{})
+
+	results, err := NewPostgresEngine(mockDb).Search("lightning", []string{db.SearchTypePerson, db.SearchTypeBounty}, 5)
+
+	assert.NoError(t, err)
+	assert.Len(t, results[db.SearchTypeBounty], 1)
+	assert.Empty(t, results[db.SearchTypePerson])
+}
+
+func TestMeilisearchEngine(t *testing.T) {
+	t.Run("Should test that one multi search is made and the hits are scored by their ranking", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/multi-search", r.URL.Path)
+			assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+			body := struct {
+				Queries []map[string]interface{} `json:"queries"`
+			}{}
+			json.NewDecoder(r.Body).Decode(&body)
+			assert.Len(t, body.Queries, 2)
+			assert.Equal(t, "tribes", body.Queries[0]["indexUid"])
+			assert.Equal(t, "bounties", body.Queries[1]["indexUid"])
+
+			w.Write([]byte(`{"results": [
+				{"indexUid": "tribes", "hits": [{"id": "tribe-uuid", "title": "Lightning devs", "_rankingScore": 0.9}]},
+				{"indexUid": "bounties", "hits": []}
+			]}`))
+		}))
+		defer ts.Close()
+
+		results, err := NewMeilisearchEngine(http.DefaultClient, ts.URL+"/", "key").Search("lightning", []string{db.SearchTypeTribe, db.SearchTypeBounty}, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []db.SearchResult{{Type: db.SearchTypeTribe, ID: "tribe-uuid", Title: "Lightning devs", Score: 0.9}}, results[db.SearchTypeTribe])
+		assert.Equal(t, []db.SearchResult{}, results[db.SearchTypeBounty])
+	})
+
+	t.Run("Should test that a meilisearch error is returned", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message": "The provided API key is invalid."}`))
+		}))
+		defer ts.Close()
+
+		_, err := NewMeilisearchEngine(http.DefaultClient, ts.URL, "").Search("lightning", db.SearchTypes, 10)
+
+		assert.ErrorContains(t, err, "meilisearch returned 401")
+	})
+
+	t.Run("Should test that every index is emptied then fed the documents of the database", func(t *testing.T) {
+		requests := []string{}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method+" "+r.URL.RequestURI())
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"taskUid": 1}`))
+		}))
+		defer ts.Close()
+
+		mockDb := mocks.NewDatabase(t)
+		mockDb.On("GetSearchDocuments", db.SearchTypeBounty, 0, reindexBatchSize).Return([]db.SearchResult{{Type: db.SearchTypeBounty, ID: "1"}})
+		mockDb.On("GetSearchDocuments", mock.Anything, 0, reindexBatchSize).Return([]db.SearchResult{})
+
+		err := NewMeilisearchEngine(http.DefaultClient, ts.URL, "").(Indexer).Reindex(mockDb)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"DELETE /indexes/tribes/documents",
+			"DELETE /indexes/people/documents",
+			"DELETE /indexes/bots/documents",
+			"DELETE /indexes/bounties/documents",
+			"POST /indexes/bounties/documents?primaryKey=id",
+			"DELETE /indexes/workspaces/documents",
+		}, requests)
+	})
+
+	t.Run("Should test that documents are upserted and removed by batch", func(t *testing.T) {
+		requests := []string{}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer ts.Close()
+
+		indexer := NewMeilisearchEngine(http.DefaultClient, ts.URL, "").(Indexer)
+
+		assert.NoError(t, indexer.Upsert(db.SearchTypePerson, []db.SearchResult{{Type: db.SearchTypePerson, ID: "pubkey", Title: "alice"}}))
+		assert.NoError(t, indexer.Remove(db.SearchTypeBounty, []string{"1", "2"}))
+		assert.Equal(t, []string{
+			`POST /indexes/people/documents?primaryKey=id [{"type":"person","id":"pubkey","title":"alice","description":"","img":"","score":0}]`,
+			`POST /indexes/bounties/documents/delete-batch ["1","2"]`,
+		}, requests)
+	})
+
+	t.Run("Should test that the health counts the documents of every index", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/health":
+				w.Write([]byte(`{"status": "available"}`))
+			case "/indexes/bounties/stats":
+				w.Write([]byte(`{"numberOfDocuments": 42, "isIndexing": true}`))
+			case "/indexes/workspaces/stats":
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code": "index_not_found"}`))
+			default:
+				w.Write([]byte(`{"numberOfDocuments": 1, "isIndexing": false}`))
+			}
+		}))
+		defer ts.Close()
+
+		health, err := NewMeilisearchEngine(http.DefaultClient, ts.URL, "").(Indexer).Health()
+
+		assert.NoError(t, err)
+		assert.True(t, health.Available)
+		assert.True(t, health.Indexing)
+		assert.Equal(t, int64(42), health.Documents[db.SearchTypeBounty])
+		assert.Equal(t, int64(0), health.Documents[db.SearchTypeWorkspace])
+		assert.Equal(t, int64(1), health.Documents[db.SearchTypeTribe])
+	})
+
+	t.Run("Should test that the reindex stops at the first failure", func(t *testing.T) {
+		client := &failingClient{}
+		err := NewMeilisearchEngine(client, "http://meilisearch", "").(Indexer).Reindex(mocks.NewDatabase(t))
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, client.calls)
+	})
+}
+
+type failingClient struct {
+	calls int
+}
+
+func (fc *failingClient) Do(req *http.Request) (*http.Response, error) {
+	fc.calls++
+	return nil, errors.New("connection refused")
+}
@@ -0,0 +1,71 @@
+package search
+
+import (
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// SyncBatchSize is how many queued changes are synced at a time
+const SyncBatchSize = 500
+
+// SyncChanges mirrors the queued entity changes into the index: the entities still listed are upserted
+// and the others removed. The changes are dropped once the index took them, it returns how many were synced
+func SyncChanges(indexer Indexer, database db.Database) (int, error) {
+	synced := 0
+	for {
+		changes := database.GetSearchIndexChanges(SyncBatchSize)
+		if len(changes) == 0 {
+			return synced, nil
+		}
+
+		// an entity written many times since the last sync is only looked up once
+		changed := map[string][]string{}
+		seen := map[string]bool{}
+		for _, change := range changes {
+			key := change.Type + ":" + change.EntityID
+			if seen[key] || change.EntityID == "" {
+				continue
+			}
+			seen[key] = true
+			changed[change.Type] = append(changed[change.Type], change.EntityID)
+		}
+
+		for _, entityType := range db.SearchTypes {
+			ids := changed[entityType]
+			if len(ids) == 0 {
+				continue
+			}
+
+			documents := database.GetSearchDocumentsByID(entityType, ids)
+			listed := map[string]bool{}
+			for _, document := range documents {
+				listed[document.ID] = true
+			}
+			removed := []string{}
+			for _, id := range ids {
+				if !listed[id] {
+					removed = append(removed, id)
+				}
+			}
+
+			if len(documents) > 0 {
+				if err := indexer.Upsert(entityType, documents); err != nil {
+					return synced, err
+				}
+			}
+			if len(removed) > 0 {
+				if err := indexer.Remove(entityType, removed); err != nil {
+					return synced, err
+				}
+			}
+		}
+
+		if err := database.DeleteSearchIndexChanges(changes[len(changes)-1].ID); err != nil {
+			return synced, err
+		}
+		synced += len(changes)
+
+		if len(changes) < SyncBatchSize {
+			return synced, nil
+		}
+	}
+}
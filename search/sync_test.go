@@ -0,0 +1,77 @@
+package search
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+type testIndexer struct {
+	upserted map[string][]db.SearchResult
+	removed  map[string][]string
+	err      error
+}
+
+func newTestIndexer() *testIndexer {
+	return &testIndexer{upserted: map[string][]db.SearchResult{}, removed: map[string][]string{}}
+}
+
+func (ti *testIndexer) Reindex(database db.Database) error { return nil }
+
+func (ti *testIndexer) Upsert(entityType string, documents []db.SearchResult) error {
+	if ti.err != nil {
+		return ti.err
+	}
+	ti.upserted[entityType] = append(ti.upserted[entityType], documents...)
+	return nil
+}
+
+func (ti *testIndexer) Remove(entityType string, ids []string) error {
+	ti.removed[entityType] = append(ti.removed[entityType], ids...)
+	return nil
+}
+
+func (ti *testIndexer) Health() (IndexHealth, error) { return IndexHealth{}, nil }
+
+func TestSyncChanges(t *testing.T) {
+	t.Run("Should test that listed entities are upserted once and the others removed", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		indexer := newTestIndexer()
+
+		mockDb.On("GetSearchIndexChanges", SyncBatchSize).Return([]db.SearchIndexChange{
+			{ID: 1, Type: db.SearchTypeBounty, EntityID: "10"},
+			{ID: 2, Type: db.SearchTypeBounty, EntityID: "10"},
+			{ID: 3, Type: db.SearchTypeBounty, EntityID: "11"},
+			{ID: 4, Type: db.SearchTypeTribe, EntityID: "tribe-uuid"},
+		}).Once()
+		mockDb.On("GetSearchDocumentsByID", db.SearchTypeTribe, []string{"tribe-uuid"}).Return([]db.SearchResult{{Type: db.SearchTypeTribe, ID: "tribe-uuid"}})
+		mockDb.On("GetSearchDocumentsByID", db.SearchTypeBounty, []string{"10", "11"}).Return([]db.SearchResult{{Type: db.SearchTypeBounty, ID: "10"}})
+		mockDb.On("DeleteSearchIndexChanges", uint(4)).Return(nil)
+
+		synced, err := SyncChanges(indexer, mockDb)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, synced)
+		assert.Equal(t, []db.SearchResult{{Type: db.SearchTypeBounty, ID: "10"}}, indexer.upserted[db.SearchTypeBounty])
+		assert.Equal(t, []string{"11"}, indexer.removed[db.SearchTypeBounty])
+		assert.Len(t, indexer.upserted[db.SearchTypeTribe], 1)
+	})
+
+	t.Run("Should test that the changes are kept when the index fails", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		indexer := newTestIndexer()
+		indexer.err = errors.New("meilisearch is down")
+
+		mockDb.On("GetSearchIndexChanges", SyncBatchSize).Return([]db.SearchIndexChange{{ID: 1, Type: db.SearchTypePerson, EntityID: "pubkey"}})
+		mockDb.On("GetSearchDocumentsByID", db.SearchTypePerson, []string{"pubkey"}).Return([]db.SearchResult{{Type: db.SearchTypePerson, ID: "pubkey"}})
+
+		synced, err := SyncChanges(indexer, mockDb)
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, synced)
+		mockDb.AssertNotCalled(t, "DeleteSearchIndexChanges", uint(1))
+	})
+}
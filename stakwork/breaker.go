@@ -0,0 +1,69 @@
+package stakwork
+
+import (
+	"sync"
+	"time"
+)
+
+// Breaker stops the calls to Stakwork after a run of consecutive failures, once the cooldown is
+// over one call goes through and closes it again if it succeeds
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	m         sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a call may be made now
+func (b *Breaker) Allow() bool {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *Breaker) Success() {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *Breaker) Failure() {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
+
+// Open reports whether calls are being refused
+func (b *Breaker) Open() bool {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.failures >= b.threshold && (b.probing || b.now().Before(b.openUntil))
+}
@@ -0,0 +1,258 @@
+package stakwork
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+const (
+	ProjectsUrl = "https://jobs.stakwork.com/api/v1/projects"
+
+	defaultTimeout   = 10 * time.Second
+	defaultRetries   = 2
+	defaultRetryBase = 500 * time.Millisecond
+)
+
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type ErrorKind string
+
+const (
+	// STAKWORK_KEY is not set
+	ErrNoKey ErrorKind = "no_key"
+	// the circuit breaker is open after repeated failures, no request was made
+	ErrUnavailable ErrorKind = "unavailable"
+	// the request failed or timed out before Stakwork answered
+	ErrNetwork ErrorKind = "network"
+	// Stakwork answered with a 5xx on every attempt
+	ErrServer ErrorKind = "server"
+	// Stakwork refused the request with a 4xx or did not start the project
+	ErrRejected ErrorKind = "rejected"
+)
+
+// Error is what the client returns when a call fails, the kind says whether it is worth retrying later
+type Error struct {
+	Kind       ErrorKind
+	StatusCode int
+	Body       string
+	Err        error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Err != nil:
+		return fmt.Sprintf("stakwork %s: %v", e.Kind, e.Err)
+	case e.StatusCode != 0:
+		return fmt.Sprintf("stakwork %s: responded %d: %s", e.Kind, e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("stakwork %s: %s", e.Kind, e.Body)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Temporary reports whether the call may succeed if it is made again later
+func (e *Error) Temporary() bool {
+	return e.Kind == ErrUnavailable || e.Kind == ErrNetwork || e.Kind == ErrServer
+}
+
+// Project is a run of a Stakwork workflow, the vars are set on its set_var step and Stakwork posts
+// the status of the project to the webhook url when it changes
+type Project struct {
+	Name       string
+	WorkflowId string
+	Vars       interface{}
+	WebhookUrl string
+}
+
+type ProjectResult struct {
+	ProjectId string
+	Body      []byte
+}
+
+// Client calls the Stakwork API, each attempt is bounded by the timeout and a 5xx or a network
+// error is retried with a jittered backoff while the breaker lets requests through
+type Client struct {
+	httpClient HttpClient
+	key        string
+	url        string
+	timeout    time.Duration
+	retries    int
+	retryBase  time.Duration
+	breaker    *Breaker
+	sleep      func(time.Duration)
+}
+
+// breaker is shared by the clients of NewClient so every caller backs off during an outage
+var breaker = NewBreaker(5, 30*time.Second)
+
+type Options struct {
+	Key     string
+	Timeout time.Duration
+	Retries int
+	Breaker *Breaker
+}
+
+func New(httpClient HttpClient, options Options) *Client {
+	if options.Timeout <= 0 {
+		options.Timeout = defaultTimeout
+	}
+	if options.Breaker == nil {
+		options.Breaker = breaker
+	}
+	return &Client{
+		httpClient: httpClient,
+		key:        options.Key,
+		url:        ProjectsUrl,
+		timeout:    options.Timeout,
+		retries:    options.Retries,
+		retryBase:  defaultRetryBase,
+		breaker:    options.Breaker,
+		sleep:      time.Sleep,
+	}
+}
+
+// NewClient returns a client configured from STAKWORK_KEY, STAKWORK_TIMEOUT and STAKWORK_RETRIES
+func NewClient(httpClient HttpClient) *Client {
+	timeout, err := time.ParseDuration(config.StakworkTimeout)
+	if err != nil {
+		timeout = defaultTimeout
+	}
+	retries, err := strconv.Atoi(config.StakworkRetries)
+	if err != nil || retries < 0 {
+		retries = defaultRetries
+	}
+	return New(httpClient, Options{
+		Key:     os.Getenv("STAKWORK_KEY"),
+		Timeout: timeout,
+		Retries: retries,
+	})
+}
+
+// CreateProject starts a project of a Stakwork workflow
+func (c *Client) CreateProject(ctx context.Context, project Project) (ProjectResult, error) {
+	if c.key == "" {
+		return ProjectResult{}, &Error{Kind: ErrNoKey, Body: "STAKWORK_KEY is not set"}
+	}
+
+	request := map[string]interface{}{
+		"name":        project.Name,
+		"workflow_id": project.WorkflowId,
+		"workflow_params": map[string]interface{}{
+			"set_var": map[string]interface{}{
+				"attributes": map[string]interface{}{"vars": project.Vars},
+			},
+		},
+	}
+	if project.WebhookUrl != "" {
+		request["webhook_url"] = project.WebhookUrl
+	}
+	buf, err := json.Marshal(request)
+	if err != nil {
+		return ProjectResult{}, err
+	}
+
+	body, err := c.post(ctx, buf)
+	if err != nil {
+		return ProjectResult{}, err
+	}
+
+	result := struct {
+		Success bool `json:"success"`
+		Data    struct {
+			ProjectId json.Number `json:"project_id"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return ProjectResult{}, &Error{Kind: ErrRejected, Body: string(body), Err: err}
+	}
+	if !result.Success {
+		return ProjectResult{}, &Error{Kind: ErrRejected, Body: string(body)}
+	}
+	return ProjectResult{ProjectId: result.Data.ProjectId.String(), Body: body}, nil
+}
+
+// post sends a request body to Stakwork, retrying the attempts that may succeed later
+func (c *Client) post(ctx context.Context, buf []byte) ([]byte, error) {
+	var lastErr *Error
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		if attempt > 0 {
+			c.sleep(c.retryDelay(attempt))
+		}
+		if !c.breaker.Allow() {
+			return nil, &Error{Kind: ErrUnavailable, Body: "too many recent failures"}
+		}
+
+		body, err := c.attempt(ctx, buf)
+		if err == nil {
+			c.breaker.Success()
+			return body, nil
+		}
+		if err.Kind == ErrRejected {
+			c.breaker.Success()
+			return nil, err
+		}
+		c.breaker.Failure()
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+func (c *Client) attempt(ctx context.Context, buf []byte) ([]byte, *Error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(buf))
+	if err != nil {
+		return nil, &Error{Kind: ErrRejected, Err: err}
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", fmt.Sprintf("Token token=%s", c.key))
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, &Error{Kind: ErrNetwork, Err: err}
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, &Error{Kind: ErrNetwork, Err: err}
+	}
+
+	switch {
+	case response.StatusCode >= 500:
+		return nil, &Error{Kind: ErrServer, StatusCode: response.StatusCode, Body: string(body)}
+	case response.StatusCode >= 300:
+		return nil, &Error{Kind: ErrRejected, StatusCode: response.StatusCode, Body: string(body)}
+	}
+	return body, nil
+}
+
+// retryDelay doubles the wait after each attempt, plus up to one base delay of jitter
+func (c *Client) retryDelay(attempt int) time.Duration {
+	delay := c.retryBase << uint(attempt-1)
+	return delay + time.Duration(rand.Int63n(int64(c.retryBase)+1))
+}
+
+// IsTemporary reports whether an error of the client may go away by calling again later
+func IsTemporary(err error) bool {
+	var stakworkErr *Error
+	return errors.As(err, &stakworkErr) && stakworkErr.Temporary()
+}
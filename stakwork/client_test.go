@@ -0,0 +1,110 @@
+package stakwork
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubClient struct {
+	responses []*http.Response
+	errs      []error
+	calls     int
+}
+
+func (s *stubClient) Do(req *http.Request) (*http.Response, error) {
+	i := s.calls
+	s.calls++
+	if i < len(s.errs) && s.errs[i] != nil {
+		return nil, s.errs[i]
+	}
+	return s.responses[i], nil
+}
+
+func response(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewBufferString(body))}
+}
+
+func testClient(httpClient HttpClient, retries int, b *Breaker) *Client {
+	c := New(httpClient, Options{Key: "key", Retries: retries, Breaker: b})
+	c.sleep = func(time.Duration) {}
+	return c
+}
+
+func TestCreateProject(t *testing.T) {
+	project := Project{Name: "test", WorkflowId: "42", Vars: map[string]interface{}{"a": 1}}
+
+	t.Run("Should test that a 5xx is retried until Stakwork answers", func(t *testing.T) {
+		stub := &stubClient{responses: []*http.Response{
+			response(502, "bad gateway"),
+			response(200, `{"success":true,"data":{"project_id":1234}}`),
+		}}
+		result, err := testClient(stub, 2, NewBreaker(5, time.Minute)).CreateProject(context.Background(), project)
+		assert.NoError(t, err)
+		assert.Equal(t, "1234", result.ProjectId)
+		assert.Equal(t, 2, stub.calls)
+	})
+
+	t.Run("Should test that a 4xx is not retried", func(t *testing.T) {
+		stub := &stubClient{responses: []*http.Response{response(401, "unauthorized")}}
+		_, err := testClient(stub, 2, NewBreaker(5, time.Minute)).CreateProject(context.Background(), project)
+
+		stakworkErr := &Error{}
+		assert.True(t, errors.As(err, &stakworkErr))
+		assert.Equal(t, ErrRejected, stakworkErr.Kind)
+		assert.Equal(t, 401, stakworkErr.StatusCode)
+		assert.False(t, IsTemporary(err))
+		assert.Equal(t, 1, stub.calls)
+	})
+
+	t.Run("Should test that the breaker refuses calls after repeated failures", func(t *testing.T) {
+		down := errors.New("connection refused")
+		stub := &stubClient{errs: []error{down, down, down}}
+		b := NewBreaker(2, time.Minute)
+		c := testClient(stub, 0, b)
+
+		_, err := c.CreateProject(context.Background(), project)
+		assert.True(t, IsTemporary(err))
+		_, err = c.CreateProject(context.Background(), project)
+		assert.True(t, IsTemporary(err))
+		assert.True(t, b.Open())
+
+		_, err = c.CreateProject(context.Background(), project)
+		stakworkErr := &Error{}
+		assert.True(t, errors.As(err, &stakworkErr))
+		assert.Equal(t, ErrUnavailable, stakworkErr.Kind)
+		assert.Equal(t, 2, stub.calls)
+	})
+
+	t.Run("Should test that no call is made without a key", func(t *testing.T) {
+		stub := &stubClient{}
+		_, err := New(stub, Options{}).CreateProject(context.Background(), project)
+		assert.Error(t, err)
+		assert.Equal(t, 0, stub.calls)
+	})
+}
+
+func TestBreaker(t *testing.T) {
+	t.Run("Should test that one call goes through after the cooldown and closes the breaker", func(t *testing.T) {
+		now := time.Now()
+		b := NewBreaker(1, time.Minute)
+		b.now = func() time.Time { return now }
+
+		b.Failure()
+		assert.False(t, b.Allow())
+
+		now = now.Add(2 * time.Minute)
+		assert.True(t, b.Allow())
+		assert.False(t, b.Allow())
+
+		b.Success()
+		assert.False(t, b.Open())
+		assert.True(t, b.Allow())
+	})
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type localStorage struct {
+	dir       string
+	publicUrl string
+	secret    []byte
+}
+
+// NewLocalStorage keeps the files in a directory, they are downloaded through the /storage route
+// of this server with urls signed with a secret
+func NewLocalStorage(dir string, publicUrl string, secret []byte) Storage {
+	return &localStorage{
+		dir:       dir,
+		publicUrl: strings.TrimSuffix(publicUrl, "/"),
+		secret:    secret,
+	}
+}
+
+func (ls *localStorage) path(key string) (string, error) {
+	if !ValidKey(key) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(ls.dir, filepath.FromSlash(key)), nil
+}
+
+func (ls *localStorage) Put(key string, contentType string, body io.ReadSeeker) error {
+	filePath, err := ls.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, body); err != nil {
+		file.Close()
+		os.Remove(filePath)
+		return err
+	}
+	return file.Close()
+}
+
+func (ls *localStorage) Get(key string) (io.ReadCloser, error) {
+	filePath, err := ls.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (ls *localStorage) Delete(key string) error {
+	filePath, err := ls.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (ls *localStorage) signature(key string, expires int64) string {
+	mac := hmac.New(sha256.New, ls.secret)
+	mac.Write([]byte(fmt.Sprintf("%s:%d", key, expires)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (ls *localStorage) SignedURL(key string, expires time.Duration) (string, error) {
+	if !ValidKey(key) {
+		return "", ErrInvalidKey
+	}
+	expiresAt := time.Now().Add(expires).Unix()
+	return fmt.Sprintf("%s/storage/%s?expires=%d&signature=%s", ls.publicUrl, key, expiresAt, ls.signature(key, expiresAt)), nil
+}
+
+// Open checks the signature of a download url and opens its file
+func (ls *localStorage) Open(key string, expires string, signature string, now time.Time) (*os.File, error) {
+	filePath, err := ls.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() > expiresAt || !hmac.Equal([]byte(signature), []byte(ls.signature(key, expiresAt))) {
+		return nil, ErrInvalidSignature
+	}
+
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+// Opener is implemented by the drivers serving their own downloads
+type Opener interface {
+	Open(key string, expires string, signature string, now time.Time) (*os.File, error)
+}
+
+func contentTypeOf(key string) string {
+	return mime.TypeByExtension(path.Ext(key))
+}
+
+// ContentType returns the content type of a file from the extension of its key
+func ContentType(key string) string {
+	if contentType := contentTypeOf(key); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
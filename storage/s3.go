@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+type s3Storage struct {
+	client    *s3.Client
+	presigner *s3.PresignClient
+	bucket    string
+}
+
+// NewS3Storage stores the files in a S3 bucket, or a MinIO one when an endpoint is given in which
+// case the bucket is addressed by path
+func NewS3Storage(endpoint string, region string, bucket string, accessKey string, secretKey string) (Storage, error) {
+	if bucket == "" {
+		return nil, errors.New("no storage bucket set")
+	}
+
+	awsConfig, err := awsconfig.LoadDefaultConfig(context.TODO(),
+		awsconfig.WithRegion(region),
+		awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+			o.UsePathStyle = true
+		}
+	})
+
+	return &s3Storage{
+		client:    client,
+		presigner: s3.NewPresignClient(client),
+		bucket:    bucket,
+	}, nil
+}
+
+func (ss *s3Storage) Put(key string, contentType string, body io.ReadSeeker) error {
+	if !ValidKey(key) {
+		return ErrInvalidKey
+	}
+	_, err := ss.client.PutObject(context.Background(), &s3.PutObjectInput{
+		Bucket:      aws.String(ss.bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
+
+func (ss *s3Storage) Get(key string) (io.ReadCloser, error) {
+	if !ValidKey(key) {
+		return nil, ErrInvalidKey
+	}
+	object, err := ss.client.GetObject(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String(ss.bucket),
+		Key:    aws.String(key),
+	})
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return object.Body, nil
+}
+
+func (ss *s3Storage) Delete(key string) error {
+	if !ValidKey(key) {
+		return ErrInvalidKey
+	}
+	_, err := ss.client.DeleteObject(context.Background(), &s3.DeleteObjectInput{
+		Bucket: aws.String(ss.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+// SignedURL presigns a download, the files that can't be shown inline are sent as attachments
+func (ss *s3Storage) SignedURL(key string, expires time.Duration) (string, error) {
+	if !ValidKey(key) {
+		return "", ErrInvalidKey
+	}
+
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(ss.bucket),
+		Key:    aws.String(key),
+	}
+	if !Inline(contentTypeOf(key)) {
+		input.ResponseContentDisposition = aws.String("attachment")
+	}
+
+	presigned, err := ss.presigner.PresignGetObject(context.Background(), input, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", err
+	}
+	return presigned.URL, nil
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+const (
+	BackendMeme  = "meme"
+	BackendS3    = "s3"
+	BackendLocal = "local"
+)
+
+const (
+	PurposeProof  = "proofs"
+	PurposeChat   = "chat"
+	PurposeAvatar = "avatars"
+	PurposeMeme   = "memes"
+	// the files attached to bounties and tickets
+	PurposeAttachment = "attachments"
+	// the share cards rendered for link previews
+	PurposeCard = "cards"
+)
+
+var ErrNotFound = errors.New("file not found")
+
+var ErrInvalidKey = errors.New("invalid file key")
+
+var ErrInvalidSignature = errors.New("invalid or expired signature")
+
+// Storage keeps uploaded files and signs their download urls
+type Storage interface {
+	Put(key string, contentType string, body io.ReadSeeker) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+	// SignedURL returns a url the file can be downloaded from until it expires
+	SignedURL(key string, expires time.Duration) (string, error)
+}
+
+// NewStorage returns the driver selected with STORAGE_BACKEND, nil for the meme server which
+// is not a general purpose storage and keeps the uploads of /meme_upload only
+func NewStorage() (Storage, error) {
+	switch strings.ToLower(config.StorageBackend) {
+	case BackendS3:
+		return NewS3Storage(config.StorageS3Endpoint, config.StorageS3Region, config.StorageS3Bucket,
+			config.StorageS3AccessKey, config.StorageS3SecretKey)
+	case BackendLocal:
+		return NewLocalStorage(config.StorageLocalDir, config.StoragePublicUrl, []byte(config.JwtKey)), nil
+	default:
+		return nil, nil
+	}
+}
+
+var extensionPattern = regexp.MustCompile(`^\.[a-z0-9]{1,10}$`)
+
+// NewKey returns a random key for a file of a purpose, keeping the extension of its name when it
+// is a plain one. The key can't be guessed so its download url can be shared
+func NewKey(purpose string, filename string) string {
+	random := make([]byte, 16)
+	rand.Read(random)
+
+	key := purpose + "/" + hex.EncodeToString(random)
+	if extension := strings.ToLower(path.Ext(filename)); extensionPattern.MatchString(extension) {
+		key += extension
+	}
+	return key
+}
+
+// ContentKey returns the key of a file made from content, the same content always has the same
+// key so the file can be looked up before it is made again
+func ContentKey(purpose string, content string, extension string) string {
+	sum := sha256.Sum256([]byte(content))
+	return purpose + "/" + hex.EncodeToString(sum[:16]) + extension
+}
+
+var keyPattern = regexp.MustCompile(`^[a-z]+/[0-9a-f]{32}(-thumb)?(\.[a-z0-9]{1,10})?$`)
+
+// ValidKey tells if a key is one made by NewKey or one of its variants, anything else can't be
+// read from the storage
+func ValidKey(key string) bool {
+	return keyPattern.MatchString(key)
+}
+
+// WebPKey is the key of the webp copy of an uploaded image
+func WebPKey(key string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + ".webp"
+}
+
+// ThumbnailKey is the key of the thumbnail of an uploaded image
+func ThumbnailKey(key string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "-thumb.webp"
+}
+
+// Inline tells if a file can be shown in the browser, the others are downloaded so an uploaded
+// page can't run scripts on the origin it is served from
+func Inline(contentType string) bool {
+	switch contentType {
+	case "image/png", "image/jpeg", "image/gif", "image/webp":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStorage(t *testing.T) {
+	defer func() { config.StorageBackend = "" }()
+
+	config.StorageBackend = "meme"
+	store, err := NewStorage()
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+
+	config.StorageBackend = "Local"
+	store, err = NewStorage()
+	assert.NoError(t, err)
+	assert.IsType(t, &localStorage{}, store)
+
+	config.StorageBackend = "s3"
+	config.StorageS3Bucket = ""
+	_, err = NewStorage()
+	assert.Error(t, err)
+}
+
+func TestNewKey(t *testing.T) {
+	key := NewKey(PurposeProof, "Screenshot.PNG")
+	assert.True(t, strings.HasPrefix(key, "proofs/"))
+	assert.True(t, strings.HasSuffix(key, ".png"))
+	assert.True(t, ValidKey(key))
+	assert.NotEqual(t, key, NewKey(PurposeProof, "Screenshot.PNG"))
+
+	assert.True(t, ValidKey(NewKey(PurposeChat, "notes")))
+	assert.False(t, strings.Contains(NewKey(PurposeChat, "page.h<t>ml"), "<"))
+
+	assert.Equal(t, strings.TrimSuffix(key, ".png")+".webp", WebPKey(key))
+	assert.Equal(t, strings.TrimSuffix(key, ".png")+"-thumb.webp", ThumbnailKey(key))
+	assert.True(t, ValidKey(WebPKey(key)))
+	assert.True(t, ValidKey(ThumbnailKey(key)))
+
+	assert.False(t, ValidKey("proofs/../../etc/passwd"))
+	assert.False(t, ValidKey("../0123456789abcdef0123456789abcdef"))
+	assert.False(t, ValidKey("proofs/0123456789abcdef0123456789abcdef/.."))
+}
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	store := NewLocalStorage(dir, "https://people.sphinx.chat/", []byte("secret")).(*localStorage)
+	key := NewKey(PurposeChat, "notes.txt")
+
+	assert.NoError(t, store.Put(key, "text/plain", strings.NewReader("hello")))
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(key)))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	file, err := store.Get(key)
+	assert.NoError(t, err)
+	content, _ = io.ReadAll(file)
+	file.Close()
+	assert.Equal(t, "hello", string(content))
+
+	_, err = store.Get(NewKey(PurposeChat, "notes.txt"))
+	assert.Equal(t, ErrNotFound, err)
+
+	t.Run("Should test that a signed url opens the file until it expires", func(t *testing.T) {
+		signed, err := store.SignedURL(key, time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(signed, "https://people.sphinx.chat/storage/"+key+"?"))
+
+		u, _ := url.Parse(signed)
+		expires, signature := u.Query().Get("expires"), u.Query().Get("signature")
+
+		file, err := store.Open(key, expires, signature, time.Now())
+		assert.NoError(t, err)
+		file.Close()
+
+		_, err = store.Open(key, expires, signature, time.Now().Add(2*time.Minute))
+		assert.Equal(t, ErrInvalidSignature, err)
+
+		_, err = store.Open(key, expires, strings.Repeat("0", len(signature)), time.Now())
+		assert.Equal(t, ErrInvalidSignature, err)
+
+		other := NewKey(PurposeChat, "notes.txt")
+		_, err = store.Open(other, expires, signature, time.Now())
+		assert.Equal(t, ErrInvalidSignature, err)
+	})
+
+	t.Run("Should test that keys outside the storage are refused", func(t *testing.T) {
+		assert.Equal(t, ErrInvalidKey, store.Put("../escape", "text/plain", strings.NewReader("")))
+		_, err := store.SignedURL("../escape", time.Minute)
+		assert.Equal(t, ErrInvalidKey, err)
+	})
+
+	t.Run("Should test that a deleted file is not found", func(t *testing.T) {
+		signed, _ := store.SignedURL(key, time.Minute)
+		u, _ := url.Parse(signed)
+
+		assert.NoError(t, store.Delete(key))
+		assert.NoError(t, store.Delete(key))
+
+		_, err := store.Open(key, u.Query().Get("expires"), u.Query().Get("signature"), time.Now())
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func TestS3StorageSignedURL(t *testing.T) {
+	store, err := NewS3Storage("http://localhost:9000", "us-east-1", "uploads", "minio", "minio-secret")
+	assert.NoError(t, err)
+
+	image := NewKey(PurposeAvatar, "me.png")
+	signed, err := store.SignedURL(image, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(signed, "http://localhost:9000/uploads/"+image+"?"))
+	assert.Contains(t, signed, "X-Amz-Expires=60")
+	assert.NotContains(t, signed, "response-content-disposition")
+
+	document := NewKey(PurposeProof, "report.html")
+	signed, err = store.SignedURL(document, time.Minute)
+	assert.NoError(t, err)
+	assert.Contains(t, signed, "response-content-disposition=attachment")
+
+	_, err = store.SignedURL("../escape", time.Minute)
+	assert.Equal(t, ErrInvalidKey, err)
+}
+
+func TestContentKey(t *testing.T) {
+	key := ContentKey(PurposeCard, "tribe:uuid:name", ".png")
+	assert.True(t, strings.HasPrefix(key, "cards/"))
+	assert.True(t, ValidKey(key))
+	assert.Equal(t, key, ContentKey(PurposeCard, "tribe:uuid:name", ".png"))
+	assert.NotEqual(t, key, ContentKey(PurposeCard, "tribe:uuid:other name", ".png"))
+}
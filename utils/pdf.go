@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 50
+	pdfLineHeight = 16
+	pdfLineLength = 85
+)
+
+// TextPdf renders a title and lines of text on a single A4 page with the standard Helvetica fonts,
+// long lines are wrapped and lines past the bottom of the page are left out. Only ASCII is kept
+func TextPdf(title string, lines []string) []byte {
+	content := bytes.Buffer{}
+	y := pdfPageHeight - pdfMargin - 18
+	fmt.Fprintf(&content, "BT /F2 18 Tf %d %d Td (%s) Tj ET\n", pdfMargin, y, pdfEscape(title))
+	y -= pdfLineHeight * 2
+
+	for _, line := range lines {
+		for _, wrapped := range pdfWrap(line) {
+			if y < pdfMargin {
+				break
+			}
+			if wrapped != "" {
+				fmt.Fprintf(&content, "BT /F1 10 Tf %d %d Td (%s) Tj ET\n", pdfMargin, y, pdfEscape(wrapped))
+			}
+			y -= pdfLineHeight
+		}
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>", pdfPageWidth, pdfPageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	pdf := bytes.Buffer{}
+	pdf.WriteString("%PDF-1.4\n")
+	offsets := []int{}
+	for i, object := range objects {
+		offsets = append(offsets, pdf.Len())
+		fmt.Fprintf(&pdf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := pdf.Len()
+	fmt.Fprintf(&pdf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&pdf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&pdf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return pdf.Bytes()
+}
+
+func pdfEscape(text string) string {
+	escaped := strings.Builder{}
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			escaped.WriteRune('\\')
+			escaped.WriteRune(r)
+		case r < 32 || r > 126:
+			escaped.WriteRune('?')
+		default:
+			escaped.WriteRune(r)
+		}
+	}
+	return escaped.String()
+}
+
+func pdfWrap(line string) []string {
+	wrapped := []string{}
+	for len(line) > pdfLineLength {
+		cut := strings.LastIndex(line[:pdfLineLength], " ")
+		if cut <= 0 {
+			cut = pdfLineLength
+		}
+		wrapped = append(wrapped, line[:cut])
+		line = strings.TrimLeft(line[cut:], " ")
+	}
+	return append(wrapped, line)
+}
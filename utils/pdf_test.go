@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextPdf(t *testing.T) {
+	pdf := string(TextPdf("Receipt (paid)", []string{"Amount: 1000 sats", strings.Repeat("ab ", 40)}))
+
+	assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+	assert.Contains(t, pdf, `(Receipt \(paid\)) Tj`)
+	assert.Contains(t, pdf, "(Amount: 1000 sats) Tj")
+
+	// every xref offset points at its object
+	xref := pdf[strings.Index(pdf, "\nxref\n")+1:]
+	for i, line := range strings.Split(xref, "\n")[3:9] {
+		offset, _ := strconv.Atoi(line[:10])
+		assert.True(t, strings.HasPrefix(pdf[offset:], fmt.Sprintf("%d 0 obj", i+1)))
+	}
+}
@@ -4,15 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sync"
 
 	"github.com/gorilla/websocket"
 	"github.com/stakwork/sphinx-tribes/db"
 )
 
 type Client struct {
-	Host string
-	Conn *websocket.Conn
-	Pool *Pool
+	Host   string
+	Pubkey string
+	Conn   *websocket.Conn
+	Pool   *Pool
+	mu     sync.Mutex
 }
 
 type ClientData struct {
@@ -26,6 +29,26 @@ type Message struct {
 	Body string `json:"body"`
 }
 
+// SubscriptionMessage is sent by clients to join or leave a topic, e.g. {"action": "subscribe", "topic": "bounty:12"}
+type SubscriptionMessage struct {
+	Action string `json:"action"`
+	Topic  string `json:"topic"`
+}
+
+// TopicMessage is an event fanned out to the clients subscribed to a topic
+type TopicMessage struct {
+	Topic string      `json:"topic"`
+	Msg   string      `json:"msg"`
+	Body  interface{} `json:"body"`
+}
+
+// WriteJSON serializes writes to the connection, gorilla connections support a single concurrent writer
+func (c *Client) WriteJSON(v interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Conn.WriteJSON(v)
+}
+
 func (c *Client) Read() {
 	defer func() {
 		c.Pool.Unregister <- c
@@ -41,6 +64,12 @@ func (c *Client) Read() {
 			return
 		}
 
+		subscription := SubscriptionMessage{}
+		if err := json.Unmarshal(p, &subscription); err == nil && subscription.Topic != "" {
+			c.handleSubscription(subscription)
+			continue
+		}
+
 		err = json.Unmarshal(p, &socketMsg)
 		if err != nil {
 			fmt.Println("Message Decode Error", err, string(p))
@@ -51,3 +80,19 @@ func (c *Client) Read() {
 		c.Pool.Broadcast <- message
 	}
 }
+
+func (c *Client) handleSubscription(subscription SubscriptionMessage) {
+	switch subscription.Action {
+	case "subscribe":
+		if err := c.Pool.Subscribe(c, subscription.Topic); err != nil {
+			c.WriteJSON(TopicMessage{Topic: subscription.Topic, Msg: "subscribe_error", Body: err.Error()})
+			return
+		}
+		c.WriteJSON(TopicMessage{Topic: subscription.Topic, Msg: "subscribed"})
+	case "unsubscribe":
+		c.Pool.Unsubscribe(c, subscription.Topic)
+		c.WriteJSON(TopicMessage{Topic: subscription.Topic, Msg: "unsubscribed"})
+	default:
+		c.WriteJSON(TopicMessage{Topic: subscription.Topic, Msg: "subscribe_error", Body: "unknown action"})
+	}
+}
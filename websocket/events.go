@@ -0,0 +1,78 @@
+package websocket
+
+import (
+	"sync"
+)
+
+// Event is a published topic message with a sequential id, used to resume event streams
+type Event struct {
+	ID    uint64      `json:"id"`
+	Topic string      `json:"topic"`
+	Msg   string      `json:"msg"`
+	Body  interface{} `json:"body"`
+}
+
+// EventHub keeps the most recent events in memory and fans them out to stream subscribers
+type EventHub struct {
+	mu          sync.Mutex
+	nextID      uint64
+	size        int
+	history     []Event
+	subscribers map[chan Event]struct{}
+}
+
+func NewEventHub(size int) *EventHub {
+	return &EventHub{
+		nextID:      1,
+		size:        size,
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+func (h *EventHub) Publish(topic string, msg string, body interface{}) Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	event := Event{ID: h.nextID, Topic: topic, Msg: msg, Body: body}
+	h.nextID++
+
+	h.history = append(h.history, event)
+	if len(h.history) > h.size {
+		h.history = h.history[len(h.history)-h.size:]
+	}
+
+	for ch := range h.subscribers {
+		// never block publishers on a slow subscriber
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	return event
+}
+
+// Subscribe registers a new subscriber and returns the buffered events published after lastEventID
+func (h *EventHub) Subscribe(lastEventID uint64) (chan Event, []Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	missed := []Event{}
+	if lastEventID > 0 {
+		for _, event := range h.history {
+			if event.ID > lastEventID {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	ch := make(chan Event, 64)
+	h.subscribers[ch] = struct{}{}
+	return ch, missed
+}
+
+func (h *EventHub) Unsubscribe(ch chan Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subscribers, ch)
+}
@@ -1,16 +1,26 @@
 package websocket
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/stakwork/sphinx-tribes/db"
 )
 
+// TopicAuthorizer decides if a pubkey (empty for anonymous connections) may subscribe to a topic
+type TopicAuthorizer func(pubkey string, topic string) bool
+
 type Pool struct {
 	Register   chan *Client
 	Unregister chan *Client
 	Clients    map[string]*ClientData
 	Broadcast  chan Message
+	Authorize  TopicAuthorizer
+	Events     *EventHub
+
+	topicsMu sync.RWMutex
+	topics   map[string]map[string]*Client
 }
 
 func NewPool() *Pool {
@@ -19,6 +29,8 @@ func NewPool() *Pool {
 		Unregister: make(chan *Client),
 		Clients:    make(map[string]*ClientData),
 		Broadcast:  make(chan Message),
+		Events:     NewEventHub(1000),
+		topics:     make(map[string]map[string]*Client),
 	}
 }
 
@@ -33,24 +45,25 @@ func (pool *Pool) Start() {
 			fmt.Println("Size of Websocket Connection Pool: ", len(pool.Clients))
 			err := db.Store.SetSocketConnections(db.Client{
 				Host: client.Host,
-				Conn: client.Conn,
+				Conn: client,
 			})
 			if err == nil {
-				pool.Clients[client.Host].Client.Conn.WriteJSON(Message{Type: 1, Msg: "user_connect", Body: client.Host})
+				client.WriteJSON(Message{Type: 1, Msg: "user_connect", Body: client.Host})
 				go client.Read()
 			} else {
 				fmt.Println("Websocket pool client save error")
 			}
 			break
 		case client := <-pool.Unregister:
-			pool.Clients[client.Host].Client.Conn.WriteJSON(Message{Type: 1, Body: "User Disconnected..."})
+			client.WriteJSON(Message{Type: 1, Body: "User Disconnected..."})
 			delete(pool.Clients, client.Host)
+			pool.removeClientTopics(client)
 			fmt.Println("Size of Connection Pool: ", len(pool.Clients))
 			break
 		case message := <-pool.Broadcast:
 			fmt.Println("Sending message to all clients in Pool")
 			for client, _ := range pool.Clients {
-				if err := pool.Clients[client].Client.Conn.WriteJSON(message); err != nil {
+				if err := pool.Clients[client].Client.WriteJSON(message); err != nil {
 					fmt.Println(err)
 					return
 				}
@@ -58,3 +71,60 @@ func (pool *Pool) Start() {
 		}
 	}
 }
+
+// Subscribe adds the client to a topic after checking it is allowed to receive its events
+func (pool *Pool) Subscribe(client *Client, topic string) error {
+	if pool.Authorize == nil || !pool.Authorize(client.Pubkey, topic) {
+		return errors.New("not authorized to subscribe to " + topic)
+	}
+
+	pool.topicsMu.Lock()
+	defer pool.topicsMu.Unlock()
+
+	if pool.topics[topic] == nil {
+		pool.topics[topic] = make(map[string]*Client)
+	}
+	pool.topics[topic][client.Host] = client
+	return nil
+}
+
+func (pool *Pool) Unsubscribe(client *Client, topic string) {
+	pool.topicsMu.Lock()
+	defer pool.topicsMu.Unlock()
+
+	delete(pool.topics[topic], client.Host)
+	if len(pool.topics[topic]) == 0 {
+		delete(pool.topics, topic)
+	}
+}
+
+func (pool *Pool) removeClientTopics(client *Client) {
+	pool.topicsMu.Lock()
+	defer pool.topicsMu.Unlock()
+
+	for topic, clients := range pool.topics {
+		delete(clients, client.Host)
+		if len(clients) == 0 {
+			delete(pool.topics, topic)
+		}
+	}
+}
+
+// Publish sends an event to every client subscribed to the topic and to the event streams
+func (pool *Pool) Publish(topic string, msg string, body interface{}) {
+	pool.Events.Publish(topic, msg, body)
+
+	pool.topicsMu.RLock()
+	clients := make([]*Client, 0, len(pool.topics[topic]))
+	for _, client := range pool.topics[topic] {
+		clients = append(clients, client)
+	}
+	pool.topicsMu.RUnlock()
+
+	message := TopicMessage{Topic: topic, Msg: msg, Body: body}
+	for _, client := range clients {
+		if err := client.WriteJSON(message); err != nil {
+			fmt.Println("[websocket] could not publish to", client.Host, err)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/websocket"
+	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/utils"
 )
@@ -38,15 +39,23 @@ func Upgrade(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
 func ServeWs(pool *Pool, w http.ResponseWriter, r *http.Request) {
 	websocketToken := utils.GetRandomToken(40)
 
+	// connections are anonymous unless a token is passed,
+	// authenticated connections can subscribe to private topics
+	pubkey := ""
+	if token := r.URL.Query().Get("token"); token != "" {
+		pubkey, _ = auth.PubkeyFromToken(token, auth.ClientFingerprint(r))
+	}
+
 	conn, err := Upgrade(w, r)
 	if err != nil {
 		fmt.Fprintf(w, "%+v\n", err)
 	}
 
 	client := &Client{
-		Host: websocketToken,
-		Conn: conn,
-		Pool: pool,
+		Host:   websocketToken,
+		Pubkey: pubkey,
+		Conn:   conn,
+		Pool:   pool,
 	}
 	pool.Register <- client
 }